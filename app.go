@@ -9,9 +9,14 @@ import (
 	"runtime"
 	"sync"
 
+	"paddockcontrol-desktop/internal/agentsync"
+	"paddockcontrol-desktop/internal/clock"
 	"paddockcontrol-desktop/internal/config"
+	"paddockcontrol-desktop/internal/crypto"
 	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/keystore"
 	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
 	"paddockcontrol-desktop/internal/services"
 
 	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
@@ -31,17 +36,82 @@ type App struct {
 	updateService      *services.UpdateService
 
 	// Runtime state
-	masterKey               []byte // 32-byte random master key (encrypts all cert private keys)
+	masterKey               *crypto.SecretBuffer // 32-byte random master key (encrypts all cert private keys)
 	waitingForEncryptionKey bool
 	isUnlocked              bool // true when master key is in memory (app is unlocked)
 	isConfigured            bool
-	needsMigration          bool // true if legacy SHA-256 encrypted certs exist without security_keys
-	dataDir                 string
+	needsMigration          bool   // true if legacy SHA-256 encrypted certs exist without security_keys
+	rootDir                 string // Data directory: logs and profiles
+	profile                 string // Name of the open profile
+	dataDir                 string // Directory of the open profile: database and backups
+
+	// Serializes write operations per hostname
+	hostLocks hostnameLocks
+
+	// Result of the startup clock sanity check (nil until it completes)
+	clockCheck *models.ClockCheckResult
+
+	// Operations a previous run left unfinished, resolved when the database
+	// was opened
+	recoveredOperations []models.RecoveredOperation
+
+	// Cancels the background key validation job (nil when none is running)
+	keyValidationCancel context.CancelFunc
+
+	// Cancels the background key envelope migration (nil when none is running)
+	keyEnvelopeCancel context.CancelFunc
+	// Closed once that migration has stopped using the database
+	keyEnvelopeDone chan struct{}
+
+	// RSA keys generated ahead of time for GenerateCSR
+	keyPool services.KeyPool
+	// Cancels the background key pool fill (nil when none is running)
+	keyPoolCancel context.CancelFunc
+
+	// Serves assigned certificates to companion agents (nil while stopped)
+	syncServer *agentsync.Server
+
+	// Operations performed since the last unlock
+	activity sessionActivity
+
+	// Hostnames and SANs for QuickSearch, dropped on every recorded operation
+	searchIndex quickSearchIndex
+
+	// Confirmation token of the last bulk delete preview (nil when none is pending)
+	bulkDelete *bulkDeleteConfirmation
+
+	// Backup mounted for read-only browsing (nil when none is open)
+	backupView *backupView
+
+	// Time source for services (nil means the wall clock)
+	clock clock.Clock
+
+	// Files handed over by the OS, kept until the frontend takes them with
+	// TakeOpenedFiles; afterwards they are emitted as "file:opened" events
+	openedFiles      []*models.OpenedFile
+	openedFilesReady bool
+
+	// System tray icon and menu
+	tray trayState
+
+	// Certificates already announced by desktop notifications
+	expiryNotifier expiryNotifier
+	// Sends desktop notifications (nil means notify.Send)
+	notify func(title, body string) error
+	// Holds os_native unlock secrets (nil means keystore.Native())
+	keystore keystore.Store
+	// Shows the "still running" notification on the first close in background mode
+	backgroundHint sync.Once
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
-	return &App{}
+	app := &App{}
+	if E2EMode {
+		// Moved by TestAdvanceClock
+		app.clock = clock.NewOffset()
+	}
+	return app
 }
 
 // startup is called when the app starts
@@ -49,16 +119,16 @@ func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
 
 	// Get application data directory
-	dataDir, err := a.getDataDirectory()
+	rootDir, err := a.getDataDirectory()
 	if err != nil {
 		a.showFatalError("Initialization Error",
 			fmt.Sprintf("Failed to get data directory: %v", err))
 		return
 	}
-	a.dataDir = dataDir
+	a.rootDir = rootDir
 
-	// Initialize logger
-	if err := logger.Initialize(dataDir, ProductionMode); err != nil {
+	// Initialize logger (shared by all profiles)
+	if err := logger.Initialize(rootDir, ProductionMode); err != nil {
 		a.showFatalError("Logging Error",
 			fmt.Sprintf("Failed to initialize logger: %v", err))
 		return
@@ -67,12 +137,21 @@ func (a *App) startup(ctx context.Context) {
 	log := logger.WithComponent("app")
 	log.Info("application starting",
 		slog.String("version", Version),
-		slog.String("data_dir", dataDir),
+		slog.String("data_dir", rootDir),
 		slog.Bool("production", ProductionMode),
 	)
 
+	// Resolve the profile to open
+	if err := a.selectStartupProfile(); err != nil {
+		log.Error("profile selection failed", logger.Err(err))
+		a.showFatalError("Profile Error",
+			fmt.Sprintf("Failed to open profile: %v", err))
+		return
+	}
+	log.Info("profile selected", slog.String("profile", a.profile), slog.String("path", a.dataDir))
+
 	// Initialize database
-	a.db, err = db.NewDatabase(dataDir)
+	a.db, err = db.NewDatabase(a.dataDir)
 	if err != nil {
 		log.Error("database initialization failed", logger.Err(err))
 		a.showFatalError("Database Error",
@@ -81,15 +160,36 @@ func (a *App) startup(ctx context.Context) {
 	}
 	log.Info("database initialized successfully")
 
-	// Check if configured
-	tmpConfigService := config.NewService(a.db)
-	a.isConfigured, err = tmpConfigService.IsConfigured(ctx)
-	if err != nil {
+	if err := a.detectDatabaseState(ctx); err != nil {
 		log.Error("configuration check failed", logger.Err(err))
 		a.showFatalError("Configuration Error",
 			fmt.Sprintf("Failed to check configuration: %v", err))
 		return
 	}
+
+	// Initialize services without encryption key (for setup/restore to work)
+	a.initializeServicesWithoutKey()
+	log.Info("services initialized without encryption key")
+
+	// Auto-skip encryption key at startup (limited mode by default)
+	// Users can provide key anytime via Settings
+	a.waitingForEncryptionKey = false
+	a.isUnlocked = false
+	log.Info("starting in limited mode - password can be provided via Settings")
+}
+
+// detectDatabaseState reads the setup state of a freshly opened database:
+// whether it is configured, and whether its keys still use the legacy format
+func (a *App) detectDatabaseState(ctx context.Context) error {
+	log := logger.WithComponent("app")
+
+	// Check if configured
+	tmpConfigService := config.NewService(a.db)
+	configured, err := tmpConfigService.IsConfigured(ctx)
+	if err != nil {
+		return err
+	}
+	a.isConfigured = configured
 	log.Info("configuration status", slog.Bool("configured", a.isConfigured))
 
 	// Detect if migration from legacy SHA-256 format is needed
@@ -97,31 +197,34 @@ func (a *App) startup(ctx context.Context) {
 	if err != nil {
 		log.Error("failed to check security keys", logger.Err(err))
 	}
+	a.needsMigration = false
 	if a.isConfigured && hasSecurityKeys == 0 {
 		// Configured app with no security_keys — may need migration if encrypted certs exist
 		a.needsMigration = true
 		log.Info("legacy encryption detected - migration will run on first unlock")
 	}
-
-	// Initialize services without encryption key (for setup/restore to work)
-	a.initializeServicesWithoutKey()
-	log.Info("services initialized without encryption key")
-
-	// Auto-skip encryption key at startup (limited mode by default)
-	// Users can provide key anytime via Settings
-	a.waitingForEncryptionKey = false
-	a.isUnlocked = false
-	log.Info("starting in limited mode - password can be provided via Settings")
+	return nil
 }
 
 // domReady is called when the frontend DOM is ready
 func (a *App) domReady(ctx context.Context) {
 	log := logger.WithComponent("app")
+
+	// Queued before wails:ready so the frontend finds them with TakeOpenedFiles
+	a.openLaunchFiles()
+
 	log.Info("DOM ready, emitting wails:ready event")
 	wailsruntime.EventsEmit(ctx, "wails:ready")
 
 	// Check for updates in the background (production only)
 	a.startBackgroundUpdateCheck(ctx)
+
+	// Compare the local clock against a reference (skipped when air-gapped)
+	a.startClockCheck(ctx)
+
+	// Tray badge, expiry notifications and scheduled backups, also while the
+	// window is closed in background mode
+	a.startBackgroundScheduler(ctx)
 }
 
 // shutdown is called when the app exits
@@ -129,6 +232,16 @@ func (a *App) shutdown(ctx context.Context) {
 	log := logger.WithComponent("app")
 	log.Info("application shutting down")
 
+	a.stopTray()
+
+	a.mu.Lock()
+	a.stopSyncServer()
+	a.mu.Unlock()
+
+	if err := a.CloseBackupView(); err != nil {
+		log.Error("backup view close error", logger.Err(err))
+	}
+
 	if a.db != nil {
 		if err := a.db.Close(); err != nil {
 			log.Error("database close error", logger.Err(err))
@@ -138,10 +251,8 @@ func (a *App) shutdown(ctx context.Context) {
 	}
 
 	// Clear master key from memory
-	if len(a.masterKey) > 0 {
-		for i := range a.masterKey {
-			a.masterKey[i] = 0
-		}
+	if a.masterKey != nil {
+		a.masterKey.Destroy()
 		a.masterKey = nil
 		log.Info("master key cleared from memory")
 	}
@@ -199,15 +310,40 @@ func (a *App) showFatalError(title, message string) {
 	})
 }
 
+// appClock returns the clock services should use.
+func (a *App) appClock() clock.Clock {
+	if a.clock == nil {
+		return clock.System()
+	}
+	return a.clock
+}
+
+// newCertificateService creates the certificate service for the current
+// database and config service, driven by the app's clock.
+func (a *App) newCertificateService() *services.CertificateService {
+	svc := services.NewCertificateService(a.db, a.configService, Version)
+	svc.SetClock(a.appClock())
+	svc.SetKeyPool(&a.keyPool)
+	return svc
+}
+
 // initializeServicesWithoutKey initializes services for read-only access
 func (a *App) initializeServicesWithoutKey() {
 	a.configService = config.NewService(a.db)
-	a.certificateService = services.NewCertificateService(a.db, a.configService)
+	a.certificateService = a.newCertificateService()
 	a.setupService = services.NewSetupService(a.db, a.configService)
 	if a.dataDir != ":memory:" {
-		a.autoBackupService = services.NewAutoBackupService(a.db.DB(), a.dataDir)
+		a.autoBackupService = services.NewAutoBackupService(a.db.DB(), a.dataDir, Version)
+		a.autoBackupService.SetClock(a.appClock())
 	}
 	a.updateService = services.NewUpdateService(Version, a.db)
+	// The database may have been replaced (restore, reset)
+	a.searchIndex.invalidate()
+	a.applyStoredLogLevels()
+	a.applyStoredLogRotation()
+	a.applyStoredChainCacheTTL()
+	a.recoverOperations()
+	a.purgeExpiredTrash()
 
 	log := logger.WithComponent("app")
 	log.Debug("services initialized without encryption key (limited access)")
@@ -227,7 +363,7 @@ func (a *App) requireUnlocked() error {
 		return fmt.Errorf("app must be unlocked for this operation")
 	}
 
-	if len(a.masterKey) == 0 {
+	if a.masterKey.Len() == 0 {
 		return fmt.Errorf("master key is not available")
 	}
 
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/services"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ============================================================================
+// Audit Log
+// ============================================================================
+
+// recordAudit appends an entry to the audit log of database. Best effort: a
+// failure is logged and never fails the operation being recorded. Callers pass
+// the database they read under a.mu, or a.db when they hold it.
+func (a *App) recordAudit(database *db.Database, eventType, hostname, message string, details map[string]any) {
+	if database == nil {
+		return
+	}
+	if err := services.NewAuditService(database, Version).Record(a.ctx, eventType, hostname, message, details); err != nil {
+		logger.WithComponent("app").Error("failed to record audit entry",
+			slog.String("event_type", eventType), logger.Err(err))
+	}
+}
+
+// readAuditLog reads the audit log of a database a restore is about to
+// replace. Best effort, as recordAudit: nil when it cannot be read.
+func (a *App) readAuditLog(database *db.Database) []sqlc.AuditLog {
+	if database == nil {
+		return nil
+	}
+	entries, err := services.NewAuditService(database, Version).Entries(a.ctx)
+	if err != nil {
+		logger.WithComponent("app").Error("failed to read audit log before restore", logger.Err(err))
+		return nil
+	}
+	return entries
+}
+
+// carryAuditLog copies the audit log of the replaced database into the
+// restored one, so a restore never drops the entries recorded since the
+// backup was taken
+func (a *App) carryAuditLog(database *db.Database, entries []sqlc.AuditLog) {
+	if database == nil || len(entries) == 0 {
+		return
+	}
+	if err := services.NewAuditService(database, Version).Carry(a.ctx, entries); err != nil {
+		logger.WithComponent("app").Error("failed to carry audit log over the restore",
+			slog.Int("entries", len(entries)), logger.Err(err))
+	}
+}
+
+// GetAuditLog returns one page of the audit log (unlocks, failed unlocks, key
+// exports and pulls by sync agents, password changes, restores, resets and
+// sync agent enrollments and revocations), most recent first, filtered
+// by event type, hostname and date range (from inclusive, to exclusive, Unix
+// seconds). limit is capped at 500.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) GetAuditLog(filter models.AuditFilter, limit, offset int) (*models.AuditLogPage, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	log := logger.WithComponent("app")
+	log.Debug("getting audit log", slog.Int("limit", limit), slog.Int("offset", offset))
+
+	a.mu.RLock()
+	database := a.db
+	a.mu.RUnlock()
+
+	if database == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	page, err := services.NewAuditService(database, Version).List(a.ctx, filter, limit, offset)
+	if err != nil {
+		log.Error("get audit log failed", logger.Err(err))
+		return nil, err
+	}
+	return page, nil
+}
+
+// ExportAuditLogCSV prompts the user to save the audit log entries matching
+// the filter as CSV.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) ExportAuditLogCSV(filter models.AuditFilter) error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("exporting audit log as CSV")
+
+	a.mu.RLock()
+	database := a.db
+	a.mu.RUnlock()
+
+	if database == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	data, err := services.NewAuditService(database, Version).BuildCSV(a.ctx, filter)
+	if err != nil {
+		log.Error("build audit log CSV failed", logger.Err(err))
+		return err
+	}
+
+	path, err := wailsruntime.SaveFileDialog(a.ctx, wailsruntime.SaveDialogOptions{
+		DefaultFilename: "audit-log.csv",
+		Title:           "Export Audit Log",
+		Filters: []wailsruntime.FileFilter{
+			{DisplayName: "CSV Files (*.csv)", Pattern: "*.csv"},
+			{DisplayName: "All Files (*.*)", Pattern: "*.*"},
+		},
+	})
+	if err != nil {
+		log.Error("file dialog error", logger.Err(err))
+		return fmt.Errorf("file dialog error: %w", err)
+	}
+	if path == "" {
+		log.Info("user cancelled audit log save dialog")
+		return nil
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		log.Error("failed to write audit log CSV", slog.String("path", path), logger.Err(err))
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	log.Info("audit log CSV saved", slog.String("path", path))
+	return nil
+}
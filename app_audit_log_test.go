@@ -0,0 +1,105 @@
+package main
+
+import (
+	"maps"
+	"path/filepath"
+	"testing"
+
+	"paddockcontrol-desktop/internal/models"
+)
+
+func TestAuditLog_RecordsUnlocksAndPasswordChange(t *testing.T) {
+	app := setupUnlockedApp(t)
+
+	app.ClearEncryptionKey()
+	if _, err := app.ProvideEncryptionKey("wrong-password-16chars"); err == nil {
+		t.Fatal("expected error for wrong password")
+	}
+	if _, err := app.ProvideEncryptionKey(testPassword); err != nil {
+		t.Fatalf("unlock failed: %v", err)
+	}
+	if err := app.ChangeEncryptionKey("another-password-16chars", true); err != nil {
+		t.Fatalf("dry-run password change failed: %v", err)
+	}
+	if err := app.ChangeEncryptionKey("another-password-16chars", false); err != nil {
+		t.Fatalf("password change failed: %v", err)
+	}
+
+	page, err := app.GetAuditLog(models.AuditFilter{}, 0, 0)
+	if err != nil {
+		t.Fatalf("GetAuditLog failed: %v", err)
+	}
+	// Most recent first; the dry run records nothing
+	want := []string{
+		models.AuditPasswordChange,
+		models.AuditUnlock,
+		models.AuditUnlockFailed,
+		models.AuditUnlock,
+	}
+	if page.Total != len(want) || len(page.Entries) != len(want) {
+		t.Fatalf("expected %d entries, got total %d: %+v", len(want), page.Total, page.Entries)
+	}
+	for i, e := range page.Entries {
+		if e.EventType != want[i] {
+			t.Errorf("entry %d: expected %s, got %s", i, want[i], e.EventType)
+		}
+	}
+	if method := page.Entries[2].Details["method"]; method != "password" {
+		t.Errorf("expected failed unlock method password, got %v", method)
+	}
+
+	failed, err := app.GetAuditLog(models.AuditFilter{EventTypes: []string{models.AuditUnlockFailed}}, 0, 0)
+	if err != nil {
+		t.Fatalf("GetAuditLog failed: %v", err)
+	}
+	if failed.Total != 1 {
+		t.Errorf("expected 1 failed unlock, got %d", failed.Total)
+	}
+}
+
+func TestGetAuditLog_RequiresSetup(t *testing.T) {
+	app := setupTestApp(t)
+	if _, err := app.GetAuditLog(models.AuditFilter{}, 0, 0); err == nil {
+		t.Fatal("expected error before setup")
+	}
+}
+
+func TestAuditLog_SurvivesRestore(t *testing.T) {
+	app, _ := setupFileBasedApp(t)
+
+	// The backup holds the unlock of setupFileBasedApp only
+	backupPath, err := app.autoBackupService.CreateBackup("test")
+	if err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+
+	app.ClearEncryptionKey()
+	if _, err := app.ProvideEncryptionKey("wrong-password-16chars"); err == nil {
+		t.Fatal("expected error for wrong password")
+	}
+	if _, err := app.ProvideEncryptionKey(testPassword); err != nil {
+		t.Fatalf("unlock failed: %v", err)
+	}
+
+	if _, err := app.RestoreLocalBackup(filepath.Base(backupPath), false); err != nil {
+		t.Fatalf("RestoreLocalBackup failed: %v", err)
+	}
+
+	page, err := app.GetAuditLog(models.AuditFilter{}, 0, 0)
+	if err != nil {
+		t.Fatalf("GetAuditLog failed: %v", err)
+	}
+	// Entries recorded since the backup are kept, those it holds not duplicated
+	want := map[string]int{
+		models.AuditUnlock:        2,
+		models.AuditUnlockFailed:  1,
+		models.AuditBackupRestore: 1,
+	}
+	got := map[string]int{}
+	for _, e := range page.Entries {
+		got[e.EventType]++
+	}
+	if page.Total != 4 || !maps.Equal(got, want) {
+		t.Errorf("expected entries %v after restore, got %v (total %d)", want, got, page.Total)
+	}
+}
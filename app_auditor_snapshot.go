@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/services"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ============================================================================
+// Auditor Snapshot
+// ============================================================================
+
+// ExportAuditorSnapshot saves a read-only copy of the database for auditors
+// who inspect the inventory and history but must never receive key material:
+// private keys are removed from certificates and the wrapped master keys are
+// deleted. Everything else (hostnames, certificates, history, notes) is kept
+// as is, unlike ExportAnonymizedDatabase.
+// Requires setup complete (not unlock: nothing is decrypted).
+func (a *App) ExportAuditorSnapshot() error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	_, log := logger.WithOperation(a.ctx, "export_auditor_snapshot")
+
+	a.mu.RLock()
+	database := a.db
+	a.mu.RUnlock()
+
+	if database == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	timestamp := time.Now().Format("20060102-150405")
+	tempFile := filepath.Join(os.TempDir(), fmt.Sprintf("paddockcontrol-auditor-%s.db", timestamp))
+	defer os.Remove(tempFile)
+
+	if err := writeAuditorSnapshot(a.ctx, database.DB(), tempFile); err != nil {
+		log.Error("failed to build auditor snapshot", logger.Err(err))
+		return err
+	}
+
+	path, err := wailsruntime.SaveFileDialog(a.ctx, wailsruntime.SaveDialogOptions{
+		DefaultFilename: filepath.Base(tempFile),
+		Title:           "Export Auditor Snapshot",
+		Filters: []wailsruntime.FileFilter{
+			{DisplayName: "Database Files (*.db)", Pattern: "*.db"},
+		},
+	})
+	if err != nil {
+		log.Error("file dialog error", logger.Err(err))
+		return fmt.Errorf("file dialog error: %w", err)
+	}
+
+	if path == "" {
+		log.Info("user cancelled auditor snapshot dialog")
+		return nil
+	}
+
+	// A previous snapshot at this path is read-only; the dialog confirmed the
+	// overwrite
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Error("failed to replace existing file", slog.String("path", path), logger.Err(err))
+		return fmt.Errorf("failed to replace existing file: %w", err)
+	}
+	if err := copyFile(tempFile, path); err != nil {
+		log.Error("failed to save auditor snapshot", slog.String("path", path), logger.Err(err))
+		return fmt.Errorf("failed to save auditor snapshot: %w", err)
+	}
+	if err := os.Chmod(path, 0o444); err != nil {
+		log.Warn("failed to make auditor snapshot read-only", slog.String("path", path), logger.Err(err))
+	}
+
+	log.Info("auditor snapshot exported", slog.String("path", path))
+	return nil
+}
+
+// writeAuditorSnapshot snapshots src into destPath, applies the redactions of
+// auditorSnapshotTables, checks that no key material is left and vacuums the
+// copy so no freed page still holds a key.
+func writeAuditorSnapshot(ctx context.Context, src *sql.DB, destPath string) error {
+	if _, err := src.ExecContext(ctx, "VACUUM INTO ?", destPath); err != nil {
+		return fmt.Errorf("failed to snapshot database: %w", err)
+	}
+
+	dest, err := sql.Open("sqlite", destPath)
+	if err != nil {
+		return fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer dest.Close()
+	dest.SetMaxOpenConns(1)
+
+	tables, err := listTables(ctx, dest)
+	if err != nil {
+		return err
+	}
+	redactions := make(map[string][]string, len(auditorSnapshotTables))
+	for _, t := range auditorSnapshotTables {
+		redactions[t.table] = t.redact
+	}
+	for _, table := range tables {
+		if _, ok := redactions[table]; !ok {
+			return fmt.Errorf("table %s has no auditor snapshot rule", table)
+		}
+	}
+
+	tx, err := dest.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin redaction transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range tables {
+		for _, statement := range redactions[table] {
+			if _, err := tx.ExecContext(ctx, statement); err != nil {
+				return fmt.Errorf("failed to redact %s: %w", table, err)
+			}
+		}
+	}
+
+	var remaining int
+	if err := tx.QueryRowContext(ctx, `
+		SELECT (SELECT COUNT(*) FROM certificates
+		        WHERE encrypted_private_key IS NOT NULL OR pending_encrypted_private_key IS NOT NULL)
+		     + (SELECT COUNT(*) FROM security_keys)
+		     + (SELECT COUNT(*) FROM sync_server)`).Scan(&remaining); err != nil {
+		return fmt.Errorf("failed to verify redaction: %w", err)
+	}
+	if remaining != 0 {
+		return fmt.Errorf("auditor snapshot still holds %d key(s)", remaining)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit redaction: %w", err)
+	}
+
+	if err := services.WriteBackupMetadata(ctx, dest, "export_auditor", Version, time.Now()); err != nil {
+		return err
+	}
+
+	if _, err := dest.ExecContext(ctx, "VACUUM"); err != nil {
+		return fmt.Errorf("failed to compact snapshot: %w", err)
+	}
+	return nil
+}
+
+// auditorSnapshotTable is how one table is redacted: statements run on the
+// copy, none to keep the table as is
+type auditorSnapshotTable struct {
+	table  string
+	redact []string
+}
+
+// auditorSnapshotTables lists every table of the database and what is removed
+// from it. The export refuses a table that is not listed, so a table added
+// later cannot hand key material to an auditor unnoticed;
+// TestAuditorSnapshotTables_CoverSchema enforces this.
+var auditorSnapshotTables = []auditorSnapshotTable{
+	{table: "certificates", redact: []string{
+		"UPDATE certificates SET encrypted_private_key = NULL, pending_encrypted_private_key = NULL",
+	}},
+	{table: "security_keys", redact: []string{"DELETE FROM security_keys"}},
+	// The sync CA key; agents and their assignments are kept
+	{table: "sync_server", redact: []string{"DELETE FROM sync_server"}},
+	// Write-ahead records of operations in progress, meaningless in a copy
+	{table: "operation_intents", redact: []string{"DELETE FROM operation_intents"}},
+	// Draft of a setup wizard that never completed
+	{table: "setup_progress", redact: []string{"DELETE FROM setup_progress"}},
+	// Inventory, history and settings are what the auditor is after
+	{table: "config"},
+	{table: "certificate_history"},
+	{table: "audit_log"},
+	{table: "renewal_checklist"},
+	{table: "chain_overrides"},
+	{table: "certificate_relations"},
+	{table: "certificate_requesters"},
+	{table: "deployment_targets"},
+	{table: "sync_agents"},
+	{table: "sync_agent_hostnames"},
+	{table: "certificate_tags"},
+	{table: "certificate_note_references"},
+	{table: "note_references_pending"},
+	{table: "certificate_search_content"},
+	{table: "certificate_metadata_pending"},
+	{table: "certificate_search"},
+	{table: "certificate_search_data"},
+	{table: "certificate_search_idx"},
+	{table: "certificate_search_docsize"},
+	{table: "certificate_search_config"},
+	{table: "subject_presets"},
+	{table: "update_history"},
+	{table: "schema_migrations"},
+	{table: "sqlite_sequence"},
+	{table: "backup_metadata"},
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+func TestWriteAuditorSnapshot(t *testing.T) {
+	app, _ := setupFileBasedApp(t)
+	insertEncryptedCert(t, app, "web.example.com")
+	wrapped := bytes.Repeat([]byte{0xA5}, 48)
+	if _, err := app.db.Queries().InsertSecurityKey(app.ctx, sqlc.InsertSecurityKeyParams{
+		Method:           models.SecurityKeyMethodPassword,
+		Label:            "Password",
+		WrappedMasterKey: wrapped,
+	}); err != nil {
+		t.Fatalf("failed to insert security key: %v", err)
+	}
+	cert, err := app.db.Queries().GetCertificateByHostname(app.ctx, "web.example.com")
+	if err != nil {
+		t.Fatalf("failed to get certificate: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "auditor.db")
+	if err := writeAuditorSnapshot(app.ctx, app.db.DB(), path); err != nil {
+		t.Fatalf("writeAuditorSnapshot() error = %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read snapshot: %v", err)
+	}
+	if bytes.Contains(raw, cert.EncryptedPrivateKey) {
+		t.Error("snapshot still contains the encrypted private key")
+	}
+	if bytes.Contains(raw, wrapped) {
+		t.Error("snapshot still contains the wrapped master key")
+	}
+
+	snapshot, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("failed to open snapshot: %v", err)
+	}
+	defer snapshot.Close()
+
+	// The inventory is kept as is
+	var hostname string
+	var hasKey bool
+	if err := snapshot.QueryRow("SELECT hostname, encrypted_private_key IS NOT NULL FROM certificates").Scan(&hostname, &hasKey); err != nil {
+		t.Fatalf("failed to read certificates: %v", err)
+	}
+	if hostname != "web.example.com" || hasKey {
+		t.Errorf("got hostname %q with key %v, want web.example.com without key", hostname, hasKey)
+	}
+	var keys int
+	if err := snapshot.QueryRow("SELECT COUNT(*) FROM security_keys").Scan(&keys); err != nil {
+		t.Fatalf("failed to count security keys: %v", err)
+	}
+	if keys != 0 {
+		t.Errorf("expected no security keys, got %d", keys)
+	}
+	var operation string
+	if err := snapshot.QueryRow("SELECT operation FROM backup_metadata").Scan(&operation); err != nil || operation != "export_auditor" {
+		t.Errorf("expected export_auditor metadata, got %q (%v)", operation, err)
+	}
+}
+
+func TestAuditorSnapshotTables_CoverSchema(t *testing.T) {
+	app, _ := setupFileBasedApp(t)
+
+	registered := make(map[string]bool)
+	for _, table := range auditorSnapshotTables {
+		registered[table.table] = true
+	}
+	tables, err := listTables(app.ctx, app.db.DB())
+	if err != nil {
+		t.Fatalf("listTables() error = %v", err)
+	}
+	for _, table := range tables {
+		if !registered[table] {
+			t.Errorf("table %s has no entry in auditorSnapshotTables", table)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"paddockcontrol-desktop/internal/autostart"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// ============================================================================
+// Start on Login
+// ============================================================================
+
+// GetAutostart reports whether the app starts on login. The registration lives
+// in the OS (registry, XDG autostart), so changes made outside the app show.
+// Available before setup and unlock.
+func (a *App) GetAutostart() (*models.AutostartStatus, error) {
+	status := &models.AutostartStatus{Supported: autostart.Supported()}
+	if !status.Supported {
+		return status, nil
+	}
+
+	enabled, err := autostart.Enabled()
+	if err != nil {
+		return nil, err
+	}
+	status.Enabled = enabled
+	return status, nil
+}
+
+// SetAutostart registers or unregisters the app to start on login. Started at
+// login it stays hidden in the tray, locked, until opened from the tray menu.
+// Available before setup and unlock.
+func (a *App) SetAutostart(enabled bool) error {
+	log := logger.WithComponent("app")
+
+	var err error
+	if enabled {
+		var exePath string
+		exePath, err = os.Executable()
+		if err != nil {
+			err = fmt.Errorf("failed to get executable path: %w", err)
+		} else {
+			err = autostart.Enable(exePath)
+		}
+	} else {
+		err = autostart.Disable()
+	}
+
+	a.recordActivity("set_autostart", "", err)
+	if err != nil {
+		log.Error("failed to change start on login", logger.Err(err))
+		return err
+	}
+	log.Info("start on login changed", slog.Bool("enabled", enabled))
+	return nil
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/notify"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ============================================================================
+// Background Mode (scheduler, close to tray)
+// ============================================================================
+
+// backgroundInterval is how often the scheduler runs while the app is open,
+// with or without its window: certificates enter the expiring window as time
+// passes, without any write to refresh the tray badge
+const backgroundInterval = 15 * time.Minute
+
+// scheduledBackupInterval is the minimum age of the newest local backup before
+// the scheduler takes another one, as long as changes were not backed up
+const scheduledBackupInterval = 24 * time.Hour
+
+// notifiedHostnamesMax caps the hostnames listed in one notification
+const notifiedHostnamesMax = 5
+
+// expiryNotifier remembers the certificates already announced, so each one is
+// notified once when it starts expiring and once when it expires. Renewed
+// certificates are forgotten and announced again next time.
+type expiryNotifier struct {
+	mu       sync.Mutex
+	notified map[string]string // hostname → status announced
+}
+
+// due returns the hostnames that entered the expiring or expired status since
+// the last call, sorted, and records them as announced.
+func (n *expiryNotifier) due(entries []models.StatusPreviewEntry) (expiring, expired []string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.notified == nil {
+		n.notified = make(map[string]string)
+	}
+
+	seen := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		if e.CurrentStatus != string(db.StatusExpiring) && e.CurrentStatus != string(db.StatusExpired) {
+			continue
+		}
+		seen[e.Hostname] = true
+		if n.notified[e.Hostname] == e.CurrentStatus {
+			continue
+		}
+		n.notified[e.Hostname] = e.CurrentStatus
+		if e.CurrentStatus == string(db.StatusExpiring) {
+			expiring = append(expiring, e.Hostname)
+		} else {
+			expired = append(expired, e.Hostname)
+		}
+	}
+	for hostname := range n.notified {
+		if !seen[hostname] {
+			delete(n.notified, hostname)
+		}
+	}
+
+	sort.Strings(expiring)
+	sort.Strings(expired)
+	return expiring, expired
+}
+
+// startBackgroundScheduler runs the background checks now and then every
+// backgroundInterval until the app exits. They keep running while the window
+// is closed in background mode.
+func (a *App) startBackgroundScheduler(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(backgroundInterval)
+		defer ticker.Stop()
+		for {
+			a.runBackgroundChecks()
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// runBackgroundChecks refreshes the tray badge, sends expiry notifications
+// and takes the scheduled backup. Nothing runs before setup.
+func (a *App) runBackgroundChecks() {
+	a.mu.RLock()
+	database := a.db
+	certificateService := a.certificateService
+	configured := a.isConfigured
+	a.mu.RUnlock()
+
+	if database == nil || certificateService == nil || !configured {
+		return
+	}
+	log := logger.WithComponent("scheduler")
+
+	a.refreshTray()
+
+	cfg, err := database.Queries().GetConfig(a.ctx)
+	if err != nil {
+		log.Error("failed to read config", logger.Err(err))
+		return
+	}
+
+	if cfg.ExpiryNotifications == 1 {
+		preview, err := certificateService.PreviewStatusAt(a.ctx, a.appClock().Now())
+		if err != nil {
+			log.Error("failed to evaluate certificate statuses", logger.Err(err))
+		} else {
+			a.notifyExpiry(preview.Certificates)
+		}
+	}
+
+	a.runScheduledBackup(database)
+}
+
+// notifyExpiry sends a desktop notification for the certificates that started
+// expiring and another for those that expired since the last check.
+func (a *App) notifyExpiry(entries []models.StatusPreviewEntry) {
+	expiring, expired := a.expiryNotifier.due(entries)
+	if len(expiring) > 0 {
+		a.sendNotification(countLabel(len(expiring), "certificate expiring soon", "certificates expiring soon"), hostnameList(expiring))
+	}
+	if len(expired) > 0 {
+		a.sendNotification(countLabel(len(expired), "certificate expired", "certificates expired"), hostnameList(expired))
+	}
+}
+
+// runScheduledBackup takes a local backup when certificate changes were not
+// backed up and the newest local backup is older than scheduledBackupInterval.
+// Scheduled backups are auto-backups, rotated like the others, and are taken
+// silently (no "backup:created" toast).
+func (a *App) runScheduledBackup(database *db.Database) {
+	log := logger.WithComponent("scheduler")
+
+	a.mu.RLock()
+	autoBackup := a.autoBackupService
+	a.mu.RUnlock()
+	if autoBackup == nil {
+		return
+	}
+
+	freshness, err := backupFreshness(a.ctx, database)
+	if err != nil {
+		log.Error("failed to check backup freshness", logger.Err(err))
+		return
+	}
+	if freshness.WritesSinceBackup == 0 {
+		return
+	}
+
+	backups, err := autoBackup.ListBackups()
+	if err != nil {
+		log.Error("failed to list backups", logger.Err(err))
+		return
+	}
+	now := a.appClock().Now()
+	if len(backups) > 0 && now.Sub(time.Unix(backups[0].Timestamp, 0)) < scheduledBackupInterval {
+		return
+	}
+
+	if _, err := autoBackup.CreateBackup("scheduled"); err != nil {
+		log.Error("scheduled backup failed", logger.Err(err))
+		return
+	}
+	log.Info("scheduled backup created", slog.Int("writes_since_backup", freshness.WritesSinceBackup))
+}
+
+// sendNotification shows a desktop notification, logging failures: a session
+// without a notification service must not break the scheduler.
+func (a *App) sendNotification(title, body string) {
+	send := a.notify
+	if send == nil {
+		send = notify.Send
+	}
+	if err := send(title, body); err != nil {
+		logger.WithComponent("scheduler").Warn("desktop notification failed", logger.Err(err))
+	}
+}
+
+// beforeClose hides the window instead of exiting when background mode is
+// enabled and the tray icon is shown; Quit in the tray menu exits.
+func (a *App) beforeClose(ctx context.Context) (prevent bool) {
+	a.tray.mu.Lock()
+	running := a.tray.running
+	quitting := a.tray.quitting
+	a.tray.mu.Unlock()
+
+	if quitting || !running || !a.runInBackground() {
+		return false
+	}
+
+	wailsruntime.WindowHide(ctx)
+	logger.WithComponent("app").Info("window closed, running in background")
+	a.backgroundHint.Do(func() {
+		a.sendNotification("PaddockControl is still running",
+			"Certificates are monitored in the background. Use Quit in the tray menu to exit.")
+	})
+	return true
+}
+
+// quit exits the app, bypassing background mode (Quit in the tray menu, restart
+// after an update).
+func (a *App) quit() {
+	a.tray.mu.Lock()
+	a.tray.quitting = true
+	a.tray.mu.Unlock()
+
+	if a.ctx != nil {
+		wailsruntime.Quit(a.ctx)
+	}
+}
+
+// runInBackground reports the run_in_background setting (false before setup).
+func (a *App) runInBackground() bool {
+	a.mu.RLock()
+	database := a.db
+	a.mu.RUnlock()
+
+	if database == nil {
+		return false
+	}
+	cfg, err := database.Queries().GetConfig(a.ctx)
+	return err == nil && cfg.RunInBackground == 1
+}
+
+// countLabel formats "1 certificate expired" or "3 certificates expired".
+func countLabel(n int, singular, plural string) string {
+	if n == 1 {
+		return "1 " + singular
+	}
+	return fmt.Sprintf("%d %s", n, plural)
+}
+
+// hostnameList joins hostnames for a notification body, listing at most
+// notifiedHostnamesMax of them.
+func hostnameList(hostnames []string) string {
+	if len(hostnames) <= notifiedHostnamesMax {
+		return strings.Join(hostnames, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(hostnames[:notifiedHostnamesMax], ", "), len(hostnames)-notifiedHostnamesMax)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+func TestExpiryNotifier_Due(t *testing.T) {
+	var n expiryNotifier
+	entry := func(hostname, status string) models.StatusPreviewEntry {
+		return models.StatusPreviewEntry{Hostname: hostname, CurrentStatus: status}
+	}
+
+	expiring, expired := n.due([]models.StatusPreviewEntry{
+		entry("b.example.com", "expiring"),
+		entry("a.example.com", "expiring"),
+		entry("c.example.com", "expired"),
+		entry("d.example.com", "active"),
+	})
+	if !reflect.DeepEqual(expiring, []string{"a.example.com", "b.example.com"}) || !reflect.DeepEqual(expired, []string{"c.example.com"}) {
+		t.Fatalf("first check: expiring=%v expired=%v", expiring, expired)
+	}
+
+	// Unchanged statuses are not announced again; a.example.com now expired
+	// and b.example.com was renewed
+	expiring, expired = n.due([]models.StatusPreviewEntry{
+		entry("a.example.com", "expired"),
+		entry("b.example.com", "active"),
+		entry("c.example.com", "expired"),
+	})
+	if len(expiring) != 0 || !reflect.DeepEqual(expired, []string{"a.example.com"}) {
+		t.Fatalf("second check: expiring=%v expired=%v", expiring, expired)
+	}
+
+	// A renewed certificate is announced again when it next expires
+	expiring, _ = n.due([]models.StatusPreviewEntry{entry("b.example.com", "expiring")})
+	if !reflect.DeepEqual(expiring, []string{"b.example.com"}) {
+		t.Fatalf("third check: expiring=%v", expiring)
+	}
+}
+
+func TestRunBackgroundChecks(t *testing.T) {
+	app, _ := setupFileBasedApp(t)
+
+	var titles, bodies []string
+	app.notify = func(title, body string) error {
+		titles = append(titles, title)
+		bodies = append(bodies, body)
+		return nil
+	}
+
+	if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{
+		Hostname:       "soon.example.com",
+		CertificatePem: sql.NullString{String: "-----BEGIN CERTIFICATE-----", Valid: true},
+		ExpiresAt:      sql.NullInt64{Int64: time.Now().Add(5 * 24 * time.Hour).Unix(), Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	app.runBackgroundChecks()
+
+	if len(titles) != 1 || titles[0] != "1 certificate expiring soon" || bodies[0] != "soon.example.com" {
+		t.Fatalf("unexpected notifications: %v %v", titles, bodies)
+	}
+	backups, err := app.autoBackupService.ListBackups()
+	if err != nil {
+		t.Fatalf("ListBackups() error = %v", err)
+	}
+	if len(backups) != 1 || backups[0].Operation != "scheduled" {
+		t.Fatalf("expected one scheduled backup, got %+v", backups)
+	}
+
+	// Nothing new to announce, and the last backup is recent
+	app.runBackgroundChecks()
+	if len(titles) != 1 {
+		t.Errorf("expected no new notification, got %v", titles)
+	}
+	if backups, _ := app.autoBackupService.ListBackups(); len(backups) != 1 {
+		t.Errorf("expected no new backup, got %d", len(backups))
+	}
+}
+
+func TestRunBackgroundChecks_NotificationsDisabled(t *testing.T) {
+	app, _ := setupFileBasedApp(t)
+	app.notify = func(title, body string) error {
+		t.Errorf("unexpected notification %q", title)
+		return nil
+	}
+
+	if _, err := app.db.DB().Exec("UPDATE config SET expiry_notifications = 0"); err != nil {
+		t.Fatalf("failed to disable notifications: %v", err)
+	}
+	if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{
+		Hostname:  "gone.example.com",
+		ExpiresAt: sql.NullInt64{Int64: time.Now().Add(-time.Hour).Unix(), Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	app.runBackgroundChecks()
+}
+
+func TestHostnameList(t *testing.T) {
+	hostnames := []string{"a", "b", "c", "d", "e", "f", "g"}
+	if got := hostnameList(hostnames[:2]); got != "a, b" {
+		t.Errorf("hostnameList() = %q", got)
+	}
+	if got := hostnameList(hostnames); !strings.HasSuffix(got, "e and 2 more") {
+		t.Errorf("hostnameList() = %q", got)
+	}
+}
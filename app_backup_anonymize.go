@@ -0,0 +1,738 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/services"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ============================================================================
+// Anonymized Database Export
+// ============================================================================
+
+// ExportAnonymizedDatabase saves a copy of the database that is safe to attach
+// to a bug report: hostnames, notes, references and organization details are
+// replaced with deterministic fakes, and private keys, wrapped master keys and
+// certificate bodies are zeroed. Row counts, value sizes, dates and statuses
+// are preserved, so bugs that depend on the shape of the data still reproduce.
+// Requires setup complete (not unlock: nothing is decrypted).
+func (a *App) ExportAnonymizedDatabase() error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	_, log := logger.WithOperation(a.ctx, "export_anonymized_database")
+
+	a.mu.RLock()
+	database := a.db
+	a.mu.RUnlock()
+
+	if database == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	timestamp := time.Now().Format("20060102-150405")
+	tempFile := filepath.Join(os.TempDir(), fmt.Sprintf("paddockcontrol-anonymized-%s.db", timestamp))
+	defer os.Remove(tempFile)
+
+	if err := writeAnonymizedDatabase(a.ctx, database.DB(), tempFile); err != nil {
+		log.Error("failed to build anonymized database", logger.Err(err))
+		return err
+	}
+
+	path, err := wailsruntime.SaveFileDialog(a.ctx, wailsruntime.SaveDialogOptions{
+		DefaultFilename: filepath.Base(tempFile),
+		Title:           "Export Anonymized Database",
+		Filters: []wailsruntime.FileFilter{
+			{DisplayName: "Database Files (*.db)", Pattern: "*.db"},
+		},
+	})
+	if err != nil {
+		log.Error("file dialog error", logger.Err(err))
+		return fmt.Errorf("file dialog error: %w", err)
+	}
+
+	if path == "" {
+		log.Info("user cancelled anonymized export dialog")
+		return nil
+	}
+
+	if err := copyFile(tempFile, path); err != nil {
+		log.Error("failed to save anonymized database", slog.String("path", path), logger.Err(err))
+		return fmt.Errorf("failed to save anonymized database: %w", err)
+	}
+
+	log.Info("anonymized database exported", slog.String("path", path))
+	return nil
+}
+
+// writeAnonymizedDatabase snapshots src into destPath, anonymizes every table
+// of the copy as registered in anonymizedTables, and vacuums it so no freed
+// page still holds original data.
+func writeAnonymizedDatabase(ctx context.Context, src *sql.DB, destPath string) error {
+	if _, err := src.ExecContext(ctx, "VACUUM INTO ?", destPath); err != nil {
+		return fmt.Errorf("failed to snapshot database: %w", err)
+	}
+
+	dest, err := sql.Open("sqlite", destPath)
+	if err != nil {
+		return fmt.Errorf("failed to open anonymized copy: %w", err)
+	}
+	defer dest.Close()
+	// Hostnames are primary keys referenced by other tables; each table is
+	// rewritten on its own, with the same mapping
+	dest.SetMaxOpenConns(1)
+	if _, err := dest.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+		return fmt.Errorf("failed to disable foreign keys: %w", err)
+	}
+
+	tables, err := listTables(ctx, dest)
+	if err != nil {
+		return err
+	}
+	handlers := make(map[string]func(context.Context, *sql.Tx, *anonymizer) error, len(anonymizedTables))
+	for _, t := range anonymizedTables {
+		handlers[t.table] = t.anonymize
+	}
+	for _, table := range tables {
+		if handlers[table] == nil {
+			return fmt.Errorf("table %s has no anonymization rule", table)
+		}
+	}
+
+	tx, err := dest.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin anonymization transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	anon, err := newAnonymizer(ctx, tx)
+	if err != nil {
+		return err
+	}
+	for _, t := range anonymizedTables {
+		if !slices.Contains(tables, t.table) {
+			continue
+		}
+		if err := t.anonymize(ctx, tx, anon); err != nil {
+			return fmt.Errorf("failed to anonymize %s: %w", t.table, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit anonymization: %w", err)
+	}
+
+	if err := services.WriteBackupMetadata(ctx, dest, "export_anonymized", Version, time.Now()); err != nil {
+		return err
+	}
+
+	if _, err := dest.ExecContext(ctx, "VACUUM"); err != nil {
+		return fmt.Errorf("failed to compact anonymized copy: %w", err)
+	}
+	return nil
+}
+
+// anonymizedTable is how one table is anonymized
+type anonymizedTable struct {
+	table     string
+	anonymize func(ctx context.Context, tx *sql.Tx, anon *anonymizer) error
+}
+
+// anonymizedTables lists every table of the database and how its data is
+// anonymized. The export refuses a table that is not listed, so a table added
+// later cannot leak into bug reports unnoticed; TestAnonymizedTables_CoverSchema
+// enforces this.
+var anonymizedTables = []anonymizedTable{
+	{table: "certificates", anonymize: func(ctx context.Context, tx *sql.Tx, anon *anonymizer) error {
+		return rewriteColumns(ctx, tx, "certificates", map[string]func(any) any{
+			"hostname":                      anon.hostname,
+			"encrypted_private_key":         zeroBytes,
+			"pending_encrypted_private_key": zeroBytes,
+			"certificate_pem":               blankPEM,
+			"chain_pem":                     blankPEM,
+			"pending_csr_pem":               blankPEM,
+			"note":                          anon.fake("note"),
+			"pending_note":                  anon.fake("note"),
+			"ca_reference":                  anon.text,
+			"sans":                          func(any) any { return "[]" },
+		})
+	}},
+	{table: "certificate_history", anonymize: func(ctx context.Context, tx *sql.Tx, anon *anonymizer) error {
+		return rewriteColumns(ctx, tx, "certificate_history", map[string]func(any) any{
+			"hostname": anon.hostname,
+			"message":  anon.text,
+			"details":  anon.text,
+			"actor":    anon.actor,
+		})
+	}},
+	{table: "audit_log", anonymize: func(ctx context.Context, tx *sql.Tx, anon *anonymizer) error {
+		return rewriteColumns(ctx, tx, "audit_log", map[string]func(any) any{
+			"hostname": anon.hostname,
+			"actor":    anon.actor,
+			// Unlock method labels, backup file names and agent names are typed
+			// by the user; agent hostnames are real ones
+			"details": func(v any) any {
+				return anon.text(withoutDetailKeys(v, "label", "file", "agent", "hostnames"))
+			},
+		})
+	}},
+	{table: "renewal_checklist", anonymize: func(ctx context.Context, tx *sql.Tx, anon *anonymizer) error {
+		return rewriteColumns(ctx, tx, "renewal_checklist", map[string]func(any) any{
+			"hostname": anon.hostname,
+			"actor":    anon.actor,
+		})
+	}},
+	{table: "chain_overrides", anonymize: func(ctx context.Context, tx *sql.Tx, anon *anonymizer) error {
+		return rewriteColumns(ctx, tx, "chain_overrides", map[string]func(any) any{
+			"hostname":   anon.hostname,
+			"issuer_dn":  anon.fake("ca"),
+			"issuer_url": blankURL,
+			"issuer_pem": blankPEM,
+		})
+	}},
+	{table: "certificate_relations", anonymize: func(ctx context.Context, tx *sql.Tx, anon *anonymizer) error {
+		return rewriteColumns(ctx, tx, "certificate_relations", map[string]func(any) any{
+			"source_hostname": anon.hostname,
+			"target_hostname": anon.hostname,
+			"label":           anon.fake("label"),
+		})
+	}},
+	{table: "certificate_requesters", anonymize: func(ctx context.Context, tx *sql.Tx, anon *anonymizer) error {
+		return rewriteColumns(ctx, tx, "certificate_requesters", map[string]func(any) any{
+			"hostname":        anon.hostname,
+			"requester_name":  anon.fake("requester"),
+			"requester_email": func(any) any { return "requester@example.invalid" },
+			"team":            anon.fake("team"),
+			"justification":   anon.fake("justification"),
+		})
+	}},
+	{table: "deployment_targets", anonymize: func(ctx context.Context, tx *sql.Tx, anon *anonymizer) error {
+		return rewriteColumns(ctx, tx, "deployment_targets", map[string]func(any) any{
+			"hostname": anon.hostname,
+			"name":     anon.fake("target"),
+			"location": anon.fake("location"),
+		})
+	}},
+	{table: "sync_agents", anonymize: func(ctx context.Context, tx *sql.Tx, anon *anonymizer) error {
+		return rewriteColumns(ctx, tx, "sync_agents", map[string]func(any) any{
+			"name": anon.fake("agent"),
+		})
+	}},
+	{table: "sync_agent_hostnames", anonymize: func(ctx context.Context, tx *sql.Tx, anon *anonymizer) error {
+		return rewriteColumns(ctx, tx, "sync_agent_hostnames", map[string]func(any) any{
+			"hostname": anon.hostname,
+		})
+	}},
+	{table: "certificate_tags", anonymize: func(ctx context.Context, tx *sql.Tx, anon *anonymizer) error {
+		// The same tag gets the same fake everywhere so tag filters still group
+		// the same certificates
+		fakeTag := anon.fake("tag")
+		fakes := make(map[string]any)
+		return rewriteColumns(ctx, tx, "certificate_tags", map[string]func(any) any{
+			"hostname": anon.hostname,
+			"tag": func(v any) any {
+				s, ok := v.(string)
+				if !ok {
+					return fakeTag(v)
+				}
+				if _, seen := fakes[s]; !seen {
+					fakes[s] = fakeTag(s)
+				}
+				return fakes[s]
+			},
+		})
+	}},
+	// Extracted from the notes, which are replaced by fakes without URLs or
+	// ticket IDs; rewriting the notes above also queues every certificate
+	{table: "certificate_note_references", anonymize: func(ctx context.Context, tx *sql.Tx, _ *anonymizer) error {
+		_, err := tx.ExecContext(ctx, "DELETE FROM certificate_note_references")
+		return err
+	}},
+	{table: "note_references_pending", anonymize: func(ctx context.Context, tx *sql.Tx, _ *anonymizer) error {
+		_, err := tx.ExecContext(ctx, "DELETE FROM note_references_pending")
+		return err
+	}},
+	// Queued under the original hostnames (foreign keys are off, so nothing
+	// cascades); every certificate is queued again so its SANs are parsed from
+	// the anonymized PEMs
+	{table: "certificate_metadata_pending", anonymize: func(ctx context.Context, tx *sql.Tx, _ *anonymizer) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM certificate_metadata_pending"); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `INSERT INTO certificate_metadata_pending (hostname)
+			SELECT hostname FROM certificates
+			WHERE certificate_pem IS NOT NULL OR pending_csr_pem IS NOT NULL`)
+		return err
+	}},
+	// Hostnames and notes follow the rewrites above through triggers; the SANs
+	// are parsed again from the anonymized PEMs on the next search
+	{table: "certificate_search_content", anonymize: func(ctx context.Context, tx *sql.Tx, _ *anonymizer) error {
+		_, err := tx.ExecContext(ctx, "UPDATE certificate_search_content SET sans = '', sans_stale = 1")
+		return err
+	}},
+	// Deleted terms linger in FTS5 segments until they are merged, so the index
+	// is rebuilt from the anonymized content; that rewrites its shadow tables
+	{table: "certificate_search", anonymize: func(ctx context.Context, tx *sql.Tx, _ *anonymizer) error {
+		_, err := tx.ExecContext(ctx, "INSERT INTO certificate_search (certificate_search) VALUES ('rebuild')")
+		return err
+	}},
+	{table: "certificate_search_data", anonymize: keepTable},
+	{table: "certificate_search_idx", anonymize: keepTable},
+	{table: "certificate_search_docsize", anonymize: keepTable},
+	{table: "certificate_search_config", anonymize: keepTable},
+	{table: "config", anonymize: func(ctx context.Context, tx *sql.Tx, anon *anonymizer) error {
+		return rewriteColumns(ctx, tx, "config", map[string]func(any) any{
+			"owner_email":                 func(any) any { return "owner@example.invalid" },
+			"ca_name":                     anon.fake("ca"),
+			"hostname_suffix":             anon.suffix,
+			"default_organization":        anon.fake("org"),
+			"default_organizational_unit": anon.fake("unit"),
+			"default_city":                anon.fake("city"),
+			"default_state":               anon.fake("state"),
+			"clock_check_url":             blankURL,
+		})
+	}},
+	{table: "security_keys", anonymize: func(ctx context.Context, tx *sql.Tx, anon *anonymizer) error {
+		return rewriteColumns(ctx, tx, "security_keys", map[string]func(any) any{
+			"label":              anon.fake("key"),
+			"wrapped_master_key": zeroBytes,
+			// Salts and credential IDs; the wrapped key is gone anyway
+			"metadata": func(any) any { return nil },
+		})
+	}},
+	{table: "subject_presets", anonymize: func(ctx context.Context, tx *sql.Tx, anon *anonymizer) error {
+		return rewriteColumns(ctx, tx, "subject_presets", map[string]func(any) any{
+			"name":                anon.fake("preset"),
+			"organization":        anon.fake("org"),
+			"organizational_unit": anon.fake("unit"),
+			"city":                anon.fake("city"),
+			"state":               anon.fake("state"),
+		})
+	}},
+	{table: "update_history", anonymize: func(ctx context.Context, tx *sql.Tx, anon *anonymizer) error {
+		// Error messages can hold local paths and user names
+		return rewriteColumns(ctx, tx, "update_history", map[string]func(any) any{
+			"error_message": anon.fake("error"),
+		})
+	}},
+	{table: "operation_intents", anonymize: func(ctx context.Context, tx *sql.Tx, _ *anonymizer) error {
+		_, err := tx.ExecContext(ctx, "DELETE FROM operation_intents")
+		return err
+	}},
+	// Setup wizard draft (owner email, CA name, ...) of an unconfigured app
+	{table: "setup_progress", anonymize: func(ctx context.Context, tx *sql.Tx, _ *anonymizer) error {
+		_, err := tx.ExecContext(ctx, "DELETE FROM setup_progress")
+		return err
+	}},
+	// The sync CA key and the server's listen address
+	{table: "sync_server", anonymize: func(ctx context.Context, tx *sql.Tx, _ *anonymizer) error {
+		_, err := tx.ExecContext(ctx, "DELETE FROM sync_server")
+		return err
+	}},
+	// Nothing identifying: migration state, AUTOINCREMENT counters, and the
+	// metadata of a database restored from a backup
+	{table: "schema_migrations", anonymize: keepTable},
+	{table: "sqlite_sequence", anonymize: keepTable},
+	{table: "backup_metadata", anonymize: keepTable},
+}
+
+func keepTable(context.Context, *sql.Tx, *anonymizer) error { return nil }
+
+// anonymizer maps identifying values to fakes. The mapping is deterministic:
+// the same database always gives the same fakes, and a value that appears in
+// several places (hostname in certificates and history, DNS label shared by
+// several hostnames) gets the same fake everywhere.
+type anonymizer struct {
+	labels   map[string]string // DNS label → fake label
+	ips      map[string]string // IP address → documentation/benchmark address
+	counters map[string]int    // fake kind → values generated
+	actors   map[string]string
+	replacer *strings.Replacer // known hostnames and references in free text
+}
+
+// newAnonymizer collects the hostnames, CA references and actors of the
+// database so that fakes are assigned in a stable (sorted) order and free text
+// can be scrubbed of them.
+func newAnonymizer(ctx context.Context, tx *sql.Tx) (*anonymizer, error) {
+	anon := &anonymizer{
+		labels:   make(map[string]string),
+		ips:      make(map[string]string),
+		counters: make(map[string]int),
+		actors:   make(map[string]string),
+	}
+
+	hostnames, err := queryStrings(ctx, tx, `
+		SELECT hostname FROM certificates
+		UNION SELECT hostname FROM certificate_history
+		UNION SELECT hostname FROM renewal_checklist
+		UNION SELECT hostname FROM audit_log WHERE hostname != ''`)
+	if err != nil {
+		return nil, err
+	}
+	suffixes, err := queryStrings(ctx, tx, "SELECT hostname_suffix FROM config")
+	if err != nil {
+		return nil, err
+	}
+
+	var labels []string
+	for _, name := range append(hostnames, suffixes...) {
+		if _, err := netip.ParseAddr(name); err == nil {
+			continue
+		}
+		for _, label := range strings.Split(strings.TrimPrefix(name, "."), ".") {
+			if label != "" && label != "*" {
+				labels = append(labels, label)
+			}
+		}
+	}
+	sort.Strings(labels)
+	for _, label := range labels {
+		if _, ok := anon.labels[label]; !ok {
+			anon.labels[label] = fakeLabel(len(anon.labels)+1, len(label))
+		}
+	}
+
+	references, err := queryStrings(ctx, tx, "SELECT ca_reference FROM certificates WHERE ca_reference IS NOT NULL")
+	if err != nil {
+		return nil, err
+	}
+	details, err := queryStrings(ctx, tx, "SELECT details FROM certificate_history WHERE details != ''")
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range details {
+		var fields map[string]any
+		if json.Unmarshal([]byte(d), &fields) == nil {
+			if ref, ok := fields["ca_reference"].(string); ok {
+				references = append(references, ref)
+			}
+		}
+	}
+
+	actors, err := queryStrings(ctx, tx, `
+		SELECT actor FROM certificate_history WHERE actor != ''
+		UNION SELECT actor FROM renewal_checklist WHERE actor != ''
+		UNION SELECT actor FROM audit_log WHERE actor != ''`)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(actors)
+	for i, actor := range actors {
+		anon.actors[actor] = fmt.Sprintf("user%d", i+1)
+	}
+
+	// Longest first, so a hostname is replaced before a shorter one it contains
+	var pairs []string
+	known := make(map[string]string)
+	for _, hostname := range hostnames {
+		known[hostname] = anon.hostnameString(hostname)
+	}
+	sort.Strings(references)
+	for _, ref := range references {
+		if ref != "" && known[ref] == "" {
+			known[ref] = padFake("ref", len(known)+1, len(ref))
+		}
+	}
+	originals := make([]string, 0, len(known))
+	for original := range known {
+		originals = append(originals, original)
+	}
+	sort.Slice(originals, func(i, j int) bool {
+		if len(originals[i]) != len(originals[j]) {
+			return len(originals[i]) > len(originals[j])
+		}
+		return originals[i] < originals[j]
+	})
+	for _, original := range originals {
+		pairs = append(pairs, original, known[original])
+	}
+	anon.replacer = strings.NewReplacer(pairs...)
+
+	return anon, nil
+}
+
+// hostname maps a hostname column value.
+func (anon *anonymizer) hostname(v any) any {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	return anon.hostnameString(s)
+}
+
+// hostnameString replaces each DNS label with its fake, keeping wildcards and
+// the number of labels; IP addresses become documentation addresses.
+func (anon *anonymizer) hostnameString(s string) string {
+	if addr, err := netip.ParseAddr(s); err == nil {
+		if fake, ok := anon.ips[s]; ok {
+			return fake
+		}
+		n := len(anon.ips) + 1
+		var fake string
+		if addr.Is4() {
+			// 198.18.0.0/15, reserved for benchmarks
+			fake = net.IPv4(198, byte(18+n>>16&1), byte(n>>8), byte(n)).String()
+		} else {
+			fake = "2001:db8::" + strconv.FormatInt(int64(n), 16)
+		}
+		anon.ips[s] = fake
+		return fake
+	}
+
+	labels := strings.Split(s, ".")
+	for i, label := range labels {
+		if label == "" || label == "*" {
+			continue
+		}
+		fake, ok := anon.labels[label]
+		if !ok {
+			fake = fakeLabel(len(anon.labels)+1, len(label))
+			anon.labels[label] = fake
+		}
+		labels[i] = fake
+	}
+	return strings.Join(labels, ".")
+}
+
+// suffix maps the hostname suffix (".example.com") like the hostnames ending
+// with it.
+func (anon *anonymizer) suffix(v any) any {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	if strings.HasPrefix(s, ".") {
+		return "." + anon.hostnameString(s[1:])
+	}
+	return anon.hostnameString(s)
+}
+
+// text scrubs known hostnames and CA references from free text.
+func (anon *anonymizer) text(v any) any {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	return anon.replacer.Replace(s)
+}
+
+// actor maps the user recorded on history entries and checklist steps.
+func (anon *anonymizer) actor(v any) any {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return v
+	}
+	if fake, ok := anon.actors[s]; ok {
+		return fake
+	}
+	return "user"
+}
+
+// withoutDetailKeys removes keys from a JSON details column value. A value
+// that is not a JSON object is returned unchanged.
+func withoutDetailKeys(v any, keys ...string) any {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return v
+	}
+	var fields map[string]any
+	if json.Unmarshal([]byte(s), &fields) != nil {
+		return v
+	}
+	for _, key := range keys {
+		delete(fields, key)
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return v
+	}
+	return string(data)
+}
+
+// fake returns a rewrite that replaces each non-empty value with "<kind>-<n>",
+// padded to the original length.
+func (anon *anonymizer) fake(kind string) func(any) any {
+	return func(v any) any {
+		s, ok := v.(string)
+		if !ok || s == "" {
+			return v
+		}
+		anon.counters[kind]++
+		return padFake(kind, anon.counters[kind], len(s))
+	}
+}
+
+// fakeLabel is the n-th fake DNS label: "h" and digits, padded with "x" to the
+// original length. Digits then padding keep fakes distinct and valid labels.
+func fakeLabel(n, length int) string {
+	label := "h" + strconv.Itoa(n)
+	if len(label) < length {
+		label += strings.Repeat("x", length-len(label))
+	}
+	return label
+}
+
+// padFake is "<kind>-<n>" padded with "x" to length.
+func padFake(kind string, n, length int) string {
+	s := kind + "-" + strconv.Itoa(n)
+	if len(s) < length {
+		s += strings.Repeat("x", length-len(s))
+	}
+	return s
+}
+
+// zeroBytes replaces a secret with zeros of the same length.
+func zeroBytes(v any) any {
+	switch b := v.(type) {
+	case []byte:
+		return make([]byte, len(b))
+	case string:
+		return make([]byte, len(b))
+	}
+	return v
+}
+
+// blankPEM keeps the PEM blocks of a value (count, types, sizes) but zeroes
+// their contents, so certificates and CSRs no longer parse nor identify
+// anything.
+func blankPEM(v any) any {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return v
+	}
+	var out strings.Builder
+	rest := []byte(s)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		out.Write(pem.EncodeToMemory(&pem.Block{Type: block.Type, Bytes: make([]byte, len(block.Bytes))}))
+	}
+	if out.Len() == 0 {
+		return padFake("pem", 1, len(s))
+	}
+	return out.String()
+}
+
+// blankURL replaces a configured URL, which can name internal hosts.
+func blankURL(v any) any {
+	if s, ok := v.(string); ok && s != "" {
+		return "https://example.invalid/"
+	}
+	return v
+}
+
+// rewriteColumns applies a rewrite to columns of every row of a table, rows in
+// rowid order and columns in name order so the fakes are deterministic. NULL
+// values are left as they are.
+func rewriteColumns(ctx context.Context, tx *sql.Tx, table string, rewrites map[string]func(any) any) error {
+	columns := make([]string, 0, len(rewrites))
+	for column := range rewrites {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
+
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT rowid, %s FROM %s ORDER BY rowid", strings.Join(columns, ", "), table))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", table, err)
+	}
+	type row struct {
+		rowid  int64
+		values []any
+	}
+	var all []row
+	for rows.Next() {
+		r := row{values: make([]any, len(columns))}
+		dest := []any{&r.rowid}
+		for i := range r.values {
+			dest = append(dest, &r.values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan %s: %w", table, err)
+		}
+		all = append(all, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate %s: %w", table, err)
+	}
+
+	assignments := make([]string, len(columns))
+	for i, column := range columns {
+		assignments[i] = column + " = ?"
+	}
+	update := fmt.Sprintf("UPDATE %s SET %s WHERE rowid = ?", table, strings.Join(assignments, ", "))
+
+	for _, r := range all {
+		args := make([]any, 0, len(columns)+1)
+		for i, column := range columns {
+			value := r.values[i]
+			if value != nil {
+				value = rewrites[column](value)
+			}
+			args = append(args, value)
+		}
+		args = append(args, r.rowid)
+		if _, err := tx.ExecContext(ctx, update, args...); err != nil {
+			return fmt.Errorf("failed to update %s (row %d): %w", table, r.rowid, err)
+		}
+	}
+	return nil
+}
+
+// listTables returns the tables of a database, SQLite internals included.
+func listTables(ctx context.Context, database *sql.DB) ([]string, error) {
+	rows, err := database.QueryContext(ctx, "SELECT name FROM sqlite_master WHERE type = 'table' ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+	defer rows.Close()
+	var tables []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("failed to scan table name: %w", err)
+		}
+		tables = append(tables, name)
+	}
+	return tables, rows.Err()
+}
+
+// queryStrings returns the first column of a query's rows.
+func queryStrings(ctx context.Context, tx *sql.Tx, query string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %w", err)
+	}
+	defer rows.Close()
+	var values []string
+	for rows.Next() {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return nil, fmt.Errorf("failed to scan: %w", err)
+		}
+		values = append(values, v)
+	}
+	return values, rows.Err()
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+func TestWriteAnonymizedDatabase(t *testing.T) {
+	app, _ := setupFileBasedApp(t)
+	insertEncryptedCert(t, app, "web.example.com")
+	expiresAt := time.Now().Add(10 * 24 * time.Hour).Unix()
+	if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{
+		Hostname:      "api.web.example.com",
+		PendingCsrPem: sql.NullString{String: newTestCSR(t, "api.web.example.com"), Valid: true},
+		ExpiresAt:     sql.NullInt64{Int64: expiresAt, Valid: true},
+		Note:          sql.NullString{String: "Owned by the payments team", Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	if err := app.certificateService.MarkCSRSubmitted(app.ctx, "api.web.example.com", models.CSRSubmission{CAReference: "TICKET-4242"}); err != nil {
+		t.Fatalf("MarkCSRSubmitted() error = %v", err)
+	}
+
+	app.recordAudit(app.db, models.AuditKeyExport, "web.example.com", "Private key saved to a file",
+		map[string]any{"format": "pem", "label": "Alice laptop", "file": "backup-payments.db"})
+
+	path := filepath.Join(t.TempDir(), "anonymized.db")
+	if err := writeAnonymizedDatabase(app.ctx, app.db.DB(), path); err != nil {
+		t.Fatalf("writeAnonymizedDatabase() error = %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read anonymized copy: %v", err)
+	}
+	for _, secret := range []string{"example.com", "payments", "TICKET-4242", "Test Org", "Test City", "BEGIN RSA PRIVATE KEY", "Alice laptop"} {
+		if bytes.Contains(raw, []byte(secret)) {
+			t.Errorf("anonymized copy still contains %q", secret)
+		}
+	}
+
+	anon, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("failed to open anonymized copy: %v", err)
+	}
+	defer anon.Close()
+
+	var suffix string
+	if err := anon.QueryRow("SELECT hostname_suffix FROM config").Scan(&suffix); err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	hostnames := map[string]bool{}
+	rows, err := anon.Query("SELECT hostname, length(encrypted_private_key), expires_at, note FROM certificates ORDER BY hostname")
+	if err != nil {
+		t.Fatalf("failed to list certificates: %v", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var hostname string
+		var keyLen, expires sql.NullInt64
+		var note sql.NullString
+		if err := rows.Scan(&hostname, &keyLen, &expires, &note); err != nil {
+			t.Fatalf("failed to scan certificate: %v", err)
+		}
+		hostnames[hostname] = true
+		if !strings.HasSuffix(hostname, suffix) {
+			t.Errorf("hostname %q does not keep the suffix %q", hostname, suffix)
+		}
+		if keyLen.Valid && keyLen.Int64 == 0 {
+			t.Errorf("%s: encrypted key size not preserved", hostname)
+		}
+		if expires.Valid && expires.Int64 != expiresAt {
+			t.Errorf("%s: expires_at = %d, want %d", hostname, expires.Int64, expiresAt)
+		}
+		if note.Valid && len(note.String) != len("Owned by the payments team") {
+			t.Errorf("%s: note %q does not keep its length", hostname, note.String)
+		}
+	}
+	if len(hostnames) != 2 {
+		t.Fatalf("expected 2 certificates, got %v", hostnames)
+	}
+
+	// The CSR keeps its PEM framing and size but no longer parses
+	var csrPEM string
+	if err := anon.QueryRow("SELECT pending_csr_pem FROM certificates WHERE pending_csr_pem IS NOT NULL").Scan(&csrPEM); err != nil {
+		t.Fatalf("failed to read CSR: %v", err)
+	}
+	if !strings.HasPrefix(csrPEM, "-----BEGIN CERTIFICATE REQUEST-----") || strings.Contains(csrPEM, "MII") {
+		t.Errorf("CSR not blanked: %q", csrPEM)
+	}
+
+	// History points to the fake hostname and scrubs the CA reference
+	var historyHost, message string
+	if err := anon.QueryRow("SELECT hostname, message FROM certificate_history WHERE event_type = ?", models.EventCSRSubmitted).Scan(&historyHost, &message); err != nil {
+		t.Fatalf("failed to read history: %v", err)
+	}
+	if !hostnames[historyHost] || !strings.Contains(message, "reference ref-") {
+		t.Errorf("history entry not anonymized consistently: %q %q", historyHost, message)
+	}
+
+	// The audit log keeps what happened, not the user's labels and file names
+	var auditHost, details string
+	if err := anon.QueryRow("SELECT hostname, details FROM audit_log WHERE event_type = ?", models.AuditKeyExport).Scan(&auditHost, &details); err != nil {
+		t.Fatalf("failed to read audit log: %v", err)
+	}
+	if !hostnames[auditHost] || details != `{"format":"pem"}` {
+		t.Errorf("audit entry not anonymized: %q %q", auditHost, details)
+	}
+
+	// Same data, same fakes
+	again := filepath.Join(t.TempDir(), "again.db")
+	if err := writeAnonymizedDatabase(app.ctx, app.db.DB(), again); err != nil {
+		t.Fatalf("second writeAnonymizedDatabase() error = %v", err)
+	}
+	againDB, err := sql.Open("sqlite", again)
+	if err != nil {
+		t.Fatalf("failed to open second copy: %v", err)
+	}
+	defer againDB.Close()
+	var first string
+	if err := againDB.QueryRow("SELECT hostname FROM certificates ORDER BY hostname LIMIT 1").Scan(&first); err != nil {
+		t.Fatalf("failed to read second copy: %v", err)
+	}
+	if !hostnames[first] {
+		t.Errorf("fakes are not deterministic: %q not in %v", first, hostnames)
+	}
+}
+
+func TestAnonymizedTables_CoverSchema(t *testing.T) {
+	app, _ := setupFileBasedApp(t)
+
+	registered := make(map[string]bool)
+	for _, table := range anonymizedTables {
+		registered[table.table] = true
+	}
+	tables, err := listTables(app.ctx, app.db.DB())
+	if err != nil {
+		t.Fatalf("listTables() error = %v", err)
+	}
+	for _, table := range tables {
+		if !registered[table] {
+			t.Errorf("table %s has no entry in anonymizedTables", table)
+		}
+	}
+}
+
+func TestAnonymizer_Hostnames(t *testing.T) {
+	anon := &anonymizer{labels: map[string]string{}, ips: map[string]string{}}
+
+	a := anon.hostnameString("*.shop.example.com")
+	b := anon.hostnameString("api.example.com")
+	if !strings.HasPrefix(a, "*.") || strings.Count(a, ".") != 3 {
+		t.Errorf("wildcard hostname mapped to %q", a)
+	}
+	if a[strings.Index(a, ".")+len("shop")+2:] != b[len("api")+1:] {
+		t.Errorf("shared labels mapped differently: %q and %q", a, b)
+	}
+	if got := anon.hostnameString("api.example.com"); got != b {
+		t.Errorf("mapping not stable: %q then %q", b, got)
+	}
+	if got := anon.hostnameString("10.1.2.3"); got != "198.18.0.1" {
+		t.Errorf("IPv4 mapped to %q", got)
+	}
+	if got := anon.hostnameString("fd00::1"); got != "2001:db8::2" {
+		t.Errorf("IPv6 mapped to %q", got)
+	}
+}
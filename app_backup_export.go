@@ -0,0 +1,427 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/services"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ============================================================================
+// Password-Protected Backup Export
+// ============================================================================
+
+// ExportBackupWithPassword saves a copy of the database protected by a one-off
+// password, independent of this installation's unlock methods — suitable for
+// handing to another team. The copy gets its own master key: every private key
+// is re-encrypted for the export and the only unlock method is the export
+// password. The live database and its master key are untouched.
+func (a *App) ExportBackupWithPassword(exportPassword string) error {
+	if err := a.requireUnlocked(); err != nil {
+		return err
+	}
+	if len(exportPassword) < 16 {
+		return fmt.Errorf("password must be at least 16 characters")
+	}
+
+	_, log := logger.WithOperation(a.ctx, "export_backup_with_password")
+
+	a.mu.RLock()
+	database := a.db
+	masterKey := a.masterKey.Clone()
+	a.mu.RUnlock()
+	defer masterKey.Destroy()
+
+	if database == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	now := a.appClock().Now()
+	tempFile := filepath.Join(os.TempDir(), fmt.Sprintf("paddockcontrol-export-%s.pcbackup", now.Format("20060102-150405")))
+	defer os.Remove(tempFile)
+
+	if err := writePasswordProtectedBackup(a.ctx, database.DB(), tempFile, masterKey.Bytes(), exportPassword, a.kdfParams(database), now); err != nil {
+		log.Error("failed to build password-protected backup", logger.Err(err))
+		return err
+	}
+
+	path, err := wailsruntime.SaveFileDialog(a.ctx, wailsruntime.SaveDialogOptions{
+		DefaultFilename: filepath.Base(tempFile),
+		Title:           "Export Password-Protected Backup",
+		Filters: []wailsruntime.FileFilter{
+			{DisplayName: "PaddockControl Backups (*.pcbackup)", Pattern: "*.pcbackup"},
+			{DisplayName: "Database Files (*.db)", Pattern: "*.db"},
+		},
+	})
+	if err != nil {
+		log.Error("file dialog error", logger.Err(err))
+		return fmt.Errorf("file dialog error: %w", err)
+	}
+
+	if path == "" {
+		log.Info("user cancelled backup export dialog")
+		return nil
+	}
+
+	if err := copyFile(tempFile, path); err != nil {
+		log.Error("failed to save backup export", slog.String("path", path), logger.Err(err))
+		return fmt.Errorf("failed to save backup export: %w", err)
+	}
+
+	a.recordBackup(database)
+
+	log.Info("password-protected backup exported", slog.String("path", path))
+	return nil
+}
+
+// writePasswordProtectedBackup snapshots src into destPath and re-keys the copy:
+// a fresh master key re-encrypts every column in masterKeyEncryptedColumns, all
+// unlock methods are replaced by a single password entry wrapping that key with
+// Argon2id(password), and the file is vacuumed so no page of the copy still
+// holds data tied to the source master key. now stamps the backup metadata.
+func writePasswordProtectedBackup(ctx context.Context, src *sql.DB, destPath string, masterKey []byte, password string, params crypto.Argon2idParams, now time.Time) error {
+	if _, err := src.ExecContext(ctx, "VACUUM INTO ?", destPath); err != nil {
+		return fmt.Errorf("failed to snapshot database: %w", err)
+	}
+
+	dest, err := sql.Open("sqlite", destPath)
+	if err != nil {
+		return fmt.Errorf("failed to open backup copy: %w", err)
+	}
+	defer dest.Close()
+
+	exportKey, err := crypto.GenerateMasterKey()
+	if err != nil {
+		return err
+	}
+	defer exportKey.Destroy()
+
+	salt, err := crypto.GenerateSalt(params.SaltLength)
+	if err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+	wrappingKey := crypto.DeriveKeyFromPassword(password, salt, params)
+	defer crypto.Zero(wrappingKey)
+
+	wrappedKey, err := crypto.WrapMasterKey(exportKey.Bytes(), wrappingKey)
+	if err != nil {
+		return fmt.Errorf("failed to wrap export master key: %w", err)
+	}
+	metadataJSON, err := json.Marshal(models.PasswordMetadata{
+		Salt:              salt,
+		Argon2Memory:      params.Memory,
+		Argon2Iterations:  params.Iterations,
+		Argon2Parallelism: params.Parallelism,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	tx, err := dest.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin export transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, set := range masterKeyEncryptedColumns {
+		if err := rekeyEncryptedColumns(ctx, tx, set, masterKey, exportKey.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	// The export is itself a backup: a database restored from it starts fresh
+	if _, err := tx.ExecContext(ctx, "UPDATE config SET writes_since_backup = 0, last_backup_at = unixepoch() WHERE id = 1"); err != nil {
+		return fmt.Errorf("failed to reset backup freshness: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM security_keys"); err != nil {
+		return fmt.Errorf("failed to clear unlock methods: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx,
+		"INSERT INTO security_keys (method, label, wrapped_master_key, metadata) VALUES (?, ?, ?, ?)",
+		models.SecurityKeyMethodPassword, "Password", wrappedKey, string(metadataJSON),
+	); err != nil {
+		return fmt.Errorf("failed to store export password: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit export transaction: %w", err)
+	}
+
+	if err := services.WriteBackupMetadata(ctx, dest, "export_with_password", Version, now); err != nil {
+		return err
+	}
+
+	// Drop freed pages that still hold the source's wrapped keys and ciphertexts
+	if _, err := dest.ExecContext(ctx, "VACUUM"); err != nil {
+		return fmt.Errorf("failed to compact backup copy: %w", err)
+	}
+
+	return nil
+}
+
+// encryptedColumnSet names the columns of a table that hold data encrypted with
+// the master key.
+type encryptedColumnSet struct {
+	table   string
+	columns []string
+}
+
+// masterKeyEncryptedColumns lists every column encrypted with the master key.
+// A password-protected export re-keys all of them, so a table added later that
+// stores secrets (deployment target credentials, CA connector tokens, ...) only
+// round-trips through exports once its columns are registered here. The
+// security_keys table is not listed: exports replace it wholesale.
+var masterKeyEncryptedColumns = []encryptedColumnSet{
+	{table: "certificates", columns: []string{"encrypted_private_key", "pending_encrypted_private_key"}},
+	{table: "sync_server", columns: []string{"ca_encrypted_private_key"}},
+}
+
+// rekeyEncryptedColumns re-encrypts the registered columns of every row of a
+// table from one master key to another. Rows are addressed by rowid so any
+// table shape works. A backup made before the table existed is left as is.
+func rekeyEncryptedColumns(ctx context.Context, tx *sql.Tx, set encryptedColumnSet, fromKey, toKey []byte) error {
+	var exists int
+	if err := tx.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", set.table,
+	).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to look up %s: %w", set.table, err)
+	}
+	if exists == 0 {
+		return nil
+	}
+
+	columns := strings.Join(set.columns, ", ")
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf("SELECT rowid, %s FROM %s", columns, set.table))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", set.table, err)
+	}
+
+	type encryptedRow struct {
+		rowid  int64
+		values [][]byte
+	}
+	var pending []encryptedRow
+	for rows.Next() {
+		row := encryptedRow{values: make([][]byte, len(set.columns))}
+		dest := []any{&row.rowid}
+		for i := range row.values {
+			dest = append(dest, &row.values[i])
+		}
+		if err := rows.Scan(dest...); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan %s: %w", set.table, err)
+		}
+		pending = append(pending, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate %s: %w", set.table, err)
+	}
+
+	assignments := make([]string, len(set.columns))
+	for i, column := range set.columns {
+		assignments[i] = column + " = ?"
+	}
+	update := fmt.Sprintf("UPDATE %s SET %s WHERE rowid = ?", set.table, strings.Join(assignments, ", "))
+
+	for _, row := range pending {
+		args := make([]any, 0, len(row.values)+1)
+		for i, value := range row.values {
+			rekeyed, err := reencryptPrivateKey(value, fromKey, toKey)
+			if err != nil {
+				return fmt.Errorf("failed to re-encrypt %s.%s (row %d): %w", set.table, set.columns[i], row.rowid, err)
+			}
+			args = append(args, rekeyed)
+		}
+		args = append(args, row.rowid)
+		if _, err := tx.ExecContext(ctx, update, args...); err != nil {
+			return fmt.Errorf("failed to update %s (row %d): %w", set.table, row.rowid, err)
+		}
+	}
+
+	return nil
+}
+
+// reencryptPrivateKey decrypts an encrypted private key with one master key and
+// encrypts it with another. Empty input (no key stored) is returned as-is.
+func reencryptPrivateKey(encrypted, fromKey, toKey []byte) ([]byte, error) {
+	if len(encrypted) == 0 {
+		return encrypted, nil
+	}
+	plaintext, err := crypto.DecryptPrivateKey(encrypted, fromKey)
+	if err != nil {
+		return nil, err
+	}
+	defer plaintext.Destroy()
+	return crypto.EncryptPrivateKey(plaintext.Bytes(), toKey)
+}
+
+// RestoreFromBackupFileWithPassword restores a backup protected by its own
+// password, such as one written by ExportBackupWithPassword. The password is
+// validated before anything is replaced: it must unwrap the backup's master key
+// and every stored private key must decrypt with it. On success the app is left
+// unlocked with the restored master key. A dry run stops after the validation.
+func (a *App) RestoreFromBackupFileWithPassword(path, password string, dryRun bool) (*models.RestoreReport, error) {
+	log := logger.WithComponent("app")
+
+	backupMasterKey, certCount, err := unlockBackupFile(path, password)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := a.RestoreFromBackupFile(path, dryRun)
+	if err != nil || dryRun {
+		backupMasterKey.Destroy()
+		return report, err
+	}
+
+	a.finalizeUnlock(backupMasterKey)
+	log.Info("backup restored and unlocked with its password", slog.Int("certificates", certCount))
+	return report, nil
+}
+
+// RestoreFromBackupFileKeepingUnlockMethods restores a backup but keeps this
+// installation's master key and unlock methods, so the user goes on unlocking
+// with their current password (or OS keyring, passkey) instead of the one the
+// backup was made with. The backup password is still needed once: a copy of
+// the backup is re-keyed from its master key to the current one and its unlock
+// methods are replaced by the current ones before it replaces the database.
+// Requires unlocked. The app stays unlocked. A dry run re-keys the copy and
+// stops before replacing the database.
+func (a *App) RestoreFromBackupFileKeepingUnlockMethods(path, backupPassword string, dryRun bool) (*models.RestoreReport, error) {
+	if err := a.requireUnlocked(); err != nil {
+		return nil, err
+	}
+	log := logger.WithComponent("app")
+
+	backupMasterKey, certCount, err := unlockBackupFile(path, backupPassword)
+	if err != nil {
+		return nil, err
+	}
+	defer backupMasterKey.Destroy()
+
+	a.mu.RLock()
+	database := a.db
+	currentKey := a.masterKey.Clone()
+	a.mu.RUnlock()
+
+	unlockMethods, err := database.Queries().ListSecurityKeys(a.ctx)
+	if err != nil {
+		currentKey.Destroy()
+		return nil, fmt.Errorf("failed to read unlock methods: %w", err)
+	}
+
+	rekeyed := filepath.Join(os.TempDir(), fmt.Sprintf("paddockcontrol-restore-%s.db", time.Now().Format("20060102-150405")))
+	defer os.Remove(rekeyed)
+	if err := rekeyBackupCopy(a.ctx, path, rekeyed, backupMasterKey.Bytes(), currentKey.Bytes(), unlockMethods); err != nil {
+		currentKey.Destroy()
+		log.Error("failed to re-key backup copy", logger.Err(err))
+		return nil, err
+	}
+
+	report, err := a.restoreFromBackupFile(rekeyed, path, dryRun)
+	if err != nil || dryRun {
+		currentKey.Destroy()
+		return report, err
+	}
+
+	a.finalizeUnlock(currentKey)
+	log.Info("backup restored with the current unlock methods",
+		slog.Int("certificates", certCount),
+		slog.Int("unlock_methods", len(unlockMethods)),
+	)
+	return report, nil
+}
+
+// unlockBackupFile unwraps a backup's master key with its password and checks
+// that every stored private key decrypts with it, before anything is replaced.
+// It returns the master key and the number of certificates.
+func unlockBackupFile(path, password string) (*crypto.SecretBuffer, int, error) {
+	backupDB, backupMasterKey, err := openBackupForImport(path, password)
+	if err != nil {
+		return nil, 0, err
+	}
+	certs, err := readBackupCertificatesForImport(backupDB)
+	backupDB.Close()
+	if err != nil {
+		backupMasterKey.Destroy()
+		return nil, 0, err
+	}
+
+	for _, c := range certs {
+		for _, encrypted := range [][]byte{c.encryptedKey, c.pendingEncryptedKey} {
+			if len(encrypted) == 0 {
+				continue
+			}
+			if err := checkBackupKeyDecrypts(encrypted, backupMasterKey.Bytes()); err != nil {
+				backupMasterKey.Destroy()
+				logger.WithComponent("app").Error("backup key validation failed", slog.String("hostname", c.hostname), logger.Err(err))
+				return nil, 0, fmt.Errorf("backup password does not decrypt the private key of %s: %w", c.hostname, err)
+			}
+		}
+	}
+	return backupMasterKey, len(certs), nil
+}
+
+// rekeyBackupCopy copies a backup to destPath, re-encrypts every column in
+// masterKeyEncryptedColumns from the backup's master key to the current one
+// and replaces its unlock methods with the given ones (which wrap the current
+// key). The copy is vacuumed so no page still holds the backup's wrapped keys.
+func rekeyBackupCopy(ctx context.Context, srcPath, destPath string, backupKey, currentKey []byte, unlockMethods []sqlc.SecurityKey) error {
+	if err := copyFile(srcPath, destPath); err != nil {
+		return fmt.Errorf("failed to copy backup: %w", err)
+	}
+
+	dest, err := sql.Open("sqlite", destPath)
+	if err != nil {
+		return fmt.Errorf("failed to open backup copy: %w", err)
+	}
+	defer dest.Close()
+
+	tx, err := dest.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin re-key transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, set := range masterKeyEncryptedColumns {
+		if err := rekeyEncryptedColumns(ctx, tx, set, backupKey, currentKey); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM security_keys"); err != nil {
+		return fmt.Errorf("failed to clear backup unlock methods: %w", err)
+	}
+	for _, k := range unlockMethods {
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO security_keys (method, label, wrapped_master_key, metadata, created_at, last_used_at) VALUES (?, ?, ?, ?, ?, ?)",
+			k.Method, k.Label, k.WrappedMasterKey, k.Metadata, k.CreatedAt, k.LastUsedAt,
+		); err != nil {
+			return fmt.Errorf("failed to store unlock method %q: %w", k.Label, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit re-key transaction: %w", err)
+	}
+
+	if _, err := dest.ExecContext(ctx, "VACUUM"); err != nil {
+		return fmt.Errorf("failed to compact backup copy: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/clock"
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+)
+
+const testExportPassword = "one-off-export-password"
+
+// insertEncryptedCert stores a certificate whose private key is encrypted with
+// the app's current master key and returns the plaintext key PEM.
+func insertEncryptedCert(t *testing.T, app *App, hostname string) []byte {
+	t.Helper()
+	key, err := crypto.GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	keyPEM, err := crypto.PrivateKeyToPEM(key)
+	if err != nil {
+		t.Fatalf("failed to encode key: %v", err)
+	}
+	encrypted, err := crypto.EncryptPrivateKey(keyPEM, app.masterKey.Bytes())
+	if err != nil {
+		t.Fatalf("failed to encrypt key: %v", err)
+	}
+	if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{
+		Hostname:            hostname,
+		EncryptedPrivateKey: encrypted,
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	return keyPEM
+}
+
+// exportTestBackup writes a password-protected copy of the app's database.
+func exportTestBackup(t *testing.T, app *App) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "export.db")
+	if err := writePasswordProtectedBackup(app.ctx, app.db.DB(), path, app.masterKey.Bytes(), testExportPassword, fastArgon2Params, app.appClock().Now()); err != nil {
+		t.Fatalf("writePasswordProtectedBackup() error: %v", err)
+	}
+	return path
+}
+
+func TestWritePasswordProtectedBackup_RekeysCopy(t *testing.T) {
+	app, _ := setupFileBasedApp(t)
+	keyPEM := insertEncryptedCert(t, app, "web.example.com")
+	keysBefore := countSecurityKeys(t, app)
+
+	path := exportTestBackup(t, app)
+
+	// The unlock password of the source must not open the export
+	if _, _, err := openBackupForImport(path, testPassword); err == nil {
+		t.Fatal("export should not open with the source unlock password")
+	}
+
+	backupDB, exportKey, err := openBackupForImport(path, testExportPassword)
+	if err != nil {
+		t.Fatalf("export should open with the export password: %v", err)
+	}
+	defer backupDB.Close()
+	defer exportKey.Destroy()
+
+	if bytes.Equal(exportKey.Bytes(), app.masterKey.Bytes()) {
+		t.Fatal("export must use its own master key")
+	}
+
+	var keyCount int
+	if err := backupDB.QueryRow("SELECT COUNT(*) FROM security_keys").Scan(&keyCount); err != nil {
+		t.Fatalf("failed to count security keys: %v", err)
+	}
+	if keyCount != 1 {
+		t.Fatalf("expected exactly the export password as unlock method, got %d", keyCount)
+	}
+
+	certs, err := readBackupCertificatesForImport(backupDB)
+	if err != nil {
+		t.Fatalf("readBackupCertificatesForImport() error: %v", err)
+	}
+	if len(certs) != 1 {
+		t.Fatalf("expected 1 certificate, got %d", len(certs))
+	}
+
+	decrypted, err := crypto.DecryptPrivateKey(certs[0].encryptedKey, exportKey.Bytes())
+	if err != nil {
+		t.Fatalf("exported key should decrypt with the export master key: %v", err)
+	}
+	defer decrypted.Destroy()
+	if !bytes.Equal(decrypted.Bytes(), keyPEM) {
+		t.Fatal("exported key does not match the original")
+	}
+	if _, err := crypto.DecryptPrivateKey(certs[0].encryptedKey, app.masterKey.Bytes()); err == nil {
+		t.Fatal("exported key should no longer decrypt with the source master key")
+	}
+
+	// The live database is untouched
+	if got := countSecurityKeys(t, app); got != keysBefore {
+		t.Fatalf("source unlock methods changed: %d -> %d", keysBefore, got)
+	}
+	cert, err := app.db.Queries().GetCertificateByHostname(app.ctx, "web.example.com")
+	if err != nil {
+		t.Fatalf("failed to get source certificate: %v", err)
+	}
+	if _, err := crypto.DecryptPrivateKey(cert.EncryptedPrivateKey, app.masterKey.Bytes()); err != nil {
+		t.Fatalf("source key should still decrypt with the source master key: %v", err)
+	}
+}
+
+func TestRestoreFromBackupFileWithPassword_Unlocks(t *testing.T) {
+	source, _ := setupFileBasedApp(t)
+	keyPEM := insertEncryptedCert(t, source, "web.example.com")
+	path := exportTestBackup(t, source)
+
+	app, _ := setupFileBasedApp(t)
+	if _, err := app.RestoreFromBackupFileWithPassword(path, testExportPassword, false); err != nil {
+		t.Fatalf("RestoreFromBackupFileWithPassword() error: %v", err)
+	}
+
+	if !app.isUnlocked {
+		t.Fatal("app should be unlocked after a validated restore")
+	}
+
+	cert, err := app.db.Queries().GetCertificateByHostname(app.ctx, "web.example.com")
+	if err != nil {
+		t.Fatalf("restored certificate missing: %v", err)
+	}
+	decrypted, err := crypto.DecryptPrivateKey(cert.EncryptedPrivateKey, app.masterKey.Bytes())
+	if err != nil {
+		t.Fatalf("restored key should decrypt with the unlocked master key: %v", err)
+	}
+	defer decrypted.Destroy()
+	if !bytes.Equal(decrypted.Bytes(), keyPEM) {
+		t.Fatal("restored key does not match the original")
+	}
+}
+
+func TestRestoreFromBackupFileWithPassword_WrongPasswordKeepsDatabase(t *testing.T) {
+	source, _ := setupFileBasedApp(t)
+	insertEncryptedCert(t, source, "web.example.com")
+	path := exportTestBackup(t, source)
+
+	app, _ := setupFileBasedApp(t)
+	if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{
+		Hostname: "original.example.com",
+	}); err != nil {
+		t.Fatalf("failed to create original cert: %v", err)
+	}
+
+	if _, err := app.RestoreFromBackupFileWithPassword(path, "not-the-export-password", false); err == nil {
+		t.Fatal("expected error for wrong backup password")
+	}
+
+	exists, err := app.db.Queries().CertificateExists(app.ctx, "original.example.com")
+	if err != nil {
+		t.Fatalf("CertificateExists() error: %v", err)
+	}
+	if exists != 1 {
+		t.Fatal("original database should be untouched after a rejected restore")
+	}
+	if !app.isUnlocked {
+		t.Fatal("app should stay unlocked after a rejected restore")
+	}
+}
+
+func TestRestoreFromBackupFileKeepingUnlockMethods(t *testing.T) {
+	source, _ := setupFileBasedApp(t)
+	keyPEM := insertEncryptedCert(t, source, "web.example.com")
+	path := exportTestBackup(t, source)
+
+	app, _ := setupFileBasedApp(t)
+	keyBefore := bytes.Clone(app.masterKey.Bytes())
+	keysBefore := countSecurityKeys(t, app)
+
+	if _, err := app.RestoreFromBackupFileKeepingUnlockMethods(path, testExportPassword, false); err != nil {
+		t.Fatalf("RestoreFromBackupFileKeepingUnlockMethods() error: %v", err)
+	}
+
+	if !app.isUnlocked {
+		t.Fatal("app should stay unlocked")
+	}
+	if !bytes.Equal(app.masterKey.Bytes(), keyBefore) {
+		t.Fatal("master key should be the one in use before the restore")
+	}
+	if got := countSecurityKeys(t, app); got != keysBefore {
+		t.Fatalf("unlock methods = %d, want %d", got, keysBefore)
+	}
+
+	cert, err := app.db.Queries().GetCertificateByHostname(app.ctx, "web.example.com")
+	if err != nil {
+		t.Fatalf("restored certificate missing: %v", err)
+	}
+	decrypted, err := crypto.DecryptPrivateKey(cert.EncryptedPrivateKey, app.masterKey.Bytes())
+	if err != nil {
+		t.Fatalf("restored key should decrypt with the current master key: %v", err)
+	}
+	defer decrypted.Destroy()
+	if !bytes.Equal(decrypted.Bytes(), keyPEM) {
+		t.Fatal("restored key does not match the original")
+	}
+
+	// The current password unlocks the restored database
+	if err := app.ClearEncryptionKey(); err != nil {
+		t.Fatalf("ClearEncryptionKey() error: %v", err)
+	}
+	result, err := app.ProvideEncryptionKey(testPassword)
+	if err != nil || !result.Valid {
+		t.Fatalf("current password should unlock the restored database: %v", err)
+	}
+}
+
+func TestRestoreFromBackupFileKeepingUnlockMethods_WrongPasswordKeepsDatabase(t *testing.T) {
+	source, _ := setupFileBasedApp(t)
+	insertEncryptedCert(t, source, "web.example.com")
+	path := exportTestBackup(t, source)
+
+	app, _ := setupFileBasedApp(t)
+	insertEncryptedCert(t, app, "original.example.com")
+
+	if _, err := app.RestoreFromBackupFileKeepingUnlockMethods(path, "not-the-export-password", false); err == nil {
+		t.Fatal("expected error for wrong backup password")
+	}
+
+	exists, err := app.db.Queries().CertificateExists(app.ctx, "original.example.com")
+	if err != nil {
+		t.Fatalf("CertificateExists() error: %v", err)
+	}
+	if exists != 1 {
+		t.Fatal("original database should be untouched after a rejected restore")
+	}
+}
+
+func TestRestoreFromBackupFileKeepingUnlockMethods_DryRun(t *testing.T) {
+	source, _ := setupFileBasedApp(t)
+	insertEncryptedCert(t, source, "web.example.com")
+	path := exportTestBackup(t, source)
+
+	app, _ := setupFileBasedApp(t)
+	insertEncryptedCert(t, app, "original.example.com")
+
+	report, err := app.RestoreFromBackupFileKeepingUnlockMethods(path, testExportPassword, true)
+	if err != nil {
+		t.Fatalf("RestoreFromBackupFileKeepingUnlockMethods() error: %v", err)
+	}
+	if !report.DryRun || report.Certificates != 1 || report.ReplacedCertificates != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if exists, _ := app.db.Queries().CertificateExists(app.ctx, "web.example.com"); exists != 0 {
+		t.Fatal("a dry run must not replace the database")
+	}
+	if !app.isUnlocked || app.masterKey.Len() != 32 {
+		t.Fatal("app should stay unlocked with its master key")
+	}
+}
+
+func TestRestoreFromBackupFileKeepingUnlockMethods_RequiresUnlock(t *testing.T) {
+	app := setupConfiguredApp(t)
+	if _, err := app.RestoreFromBackupFileKeepingUnlockMethods("backup.db", testExportPassword, false); err == nil {
+		t.Fatal("expected error while locked")
+	}
+}
+
+// Every column holding master-key-encrypted data must be registered, or exports
+// would carry it encrypted with a key the recipient never gets.
+func TestMasterKeyEncryptedColumns_CoverSchema(t *testing.T) {
+	app, _ := setupFileBasedApp(t)
+
+	registered := make(map[string]bool)
+	for _, set := range masterKeyEncryptedColumns {
+		for _, column := range set.columns {
+			registered[set.table+"."+column] = true
+		}
+	}
+
+	rows, err := app.db.DB().Query(`
+		SELECT m.name, p.name
+		FROM sqlite_master m, pragma_table_info(m.name) p
+		WHERE m.type = 'table' AND m.name != 'security_keys' AND p.name LIKE '%encrypted%'
+	`)
+	if err != nil {
+		t.Fatalf("failed to list columns: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			t.Fatalf("failed to scan column: %v", err)
+		}
+		if !registered[table+"."+column] {
+			t.Errorf("%s.%s holds encrypted data but is not in masterKeyEncryptedColumns", table, column)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("failed to iterate columns: %v", err)
+	}
+}
+
+func TestWritePasswordProtectedBackup_RecordsMetadata(t *testing.T) {
+	app, _ := setupFileBasedApp(t)
+	// The metadata follows the app clock, not the wall clock
+	app.clock = clock.NewFake(time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC))
+	insertEncryptedCert(t, app, "web.example.com")
+
+	info, err := peekBackupAtPath(exportTestBackup(t, app))
+	if err != nil {
+		t.Fatalf("peekBackupAtPath() error: %v", err)
+	}
+	if info.Metadata == nil {
+		t.Fatal("expected backup metadata")
+	}
+	if info.Metadata.Operation != "export_with_password" || info.Metadata.AppVersion != Version || info.Metadata.CertificateCount != 1 {
+		t.Errorf("unexpected metadata: %+v", info.Metadata)
+	}
+	if want := app.appClock().Now().Unix(); info.Metadata.CreatedAt != want {
+		t.Errorf("metadata created_at = %d, want the app clock %d", info.Metadata.CreatedAt, want)
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+
+	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// ============================================================================
+// Backup Freshness
+// ============================================================================
+
+// GetBackupFreshness reports how many certificate writes happened since the
+// last manual backup or password-protected export, and whether that exceeds
+// the configured threshold.
+// Does NOT require encryption key - read-only operation
+func (a *App) GetBackupFreshness() (*models.BackupFreshness, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	database := a.db
+	a.mu.RUnlock()
+
+	if database == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	return backupFreshness(a.ctx, database)
+}
+
+// backupFreshness evaluates the backup freshness policy. Writes are counted by
+// triggers on the certificates table, so every code path is covered. A
+// database without certificates is never stale.
+func backupFreshness(ctx context.Context, database *db.Database) (*models.BackupFreshness, error) {
+	cfg, err := database.Queries().GetConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	certCount, err := database.Queries().CountCertificates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count certificates: %w", err)
+	}
+
+	freshness := &models.BackupFreshness{
+		WritesSinceBackup: int(cfg.WritesSinceBackup),
+		MaxWrites:         int(cfg.BackupFreshnessMaxWrites),
+	}
+	if cfg.LastBackupAt.Valid {
+		lastBackupAt := cfg.LastBackupAt.Int64
+		freshness.LastBackupAt = &lastBackupAt
+	}
+	freshness.Stale = freshness.MaxWrites > 0 && certCount > 0 && freshness.WritesSinceBackup >= freshness.MaxWrites
+	freshness.Blocking = freshness.Stale && cfg.BackupFreshnessBlock == 1
+
+	return freshness, nil
+}
+
+// requireFreshBackup refuses a risky operation while the backup freshness
+// policy is exceeded and configured to block. Errors reading the policy do
+// not block the operation.
+func (a *App) requireFreshBackup(operation string) error {
+	a.mu.RLock()
+	database := a.db
+	configured := a.isConfigured
+	a.mu.RUnlock()
+
+	if database == nil || !configured {
+		return nil
+	}
+
+	freshness, err := backupFreshness(a.ctx, database)
+	if err != nil {
+		logger.WithComponent("app").Error("failed to check backup freshness",
+			slog.String("operation", operation), logger.Err(err))
+		return nil
+	}
+	if freshness.Blocking {
+		return fmt.Errorf("%d certificate changes since the last backup: create a manual backup or export before this operation", freshness.WritesSinceBackup)
+	}
+	return nil
+}
+
+// recordBackup resets the backup freshness counter after a manual backup or a
+// password-protected export. Failures are logged only: the backup exists.
+func (a *App) recordBackup(database *db.Database) {
+	err := database.Queries().RecordBackup(a.ctx, sql.NullInt64{Int64: a.appClock().Now().Unix(), Valid: true})
+	if err != nil {
+		logger.WithComponent("app").Error("failed to record backup time", logger.Err(err))
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"paddockcontrol-desktop/internal/config"
+)
+
+// setBackupFreshnessPolicy updates the freshness threshold and blocking flag.
+func setBackupFreshnessPolicy(t *testing.T, app *App, maxWrites int, block bool) {
+	t.Helper()
+	cfg, err := app.db.Queries().GetConfig(app.ctx)
+	if err != nil {
+		t.Fatalf("GetConfig() error: %v", err)
+	}
+	cfg.BackupFreshnessMaxWrites = int64(maxWrites)
+	cfg.BackupFreshnessBlock = 0
+	if block {
+		cfg.BackupFreshnessBlock = 1
+	}
+	if err := config.NewService(app.db).SaveConfig(app.ctx, &cfg); err != nil {
+		t.Fatalf("SaveConfig() error: %v", err)
+	}
+}
+
+func TestBackupFreshness_CountsWritesUntilBackup(t *testing.T) {
+	app := setupUnlockedApp(t)
+	setBackupFreshnessPolicy(t, app, 3, false)
+
+	freshness, err := app.GetBackupFreshness()
+	if err != nil {
+		t.Fatalf("GetBackupFreshness() error: %v", err)
+	}
+	if freshness.WritesSinceBackup != 0 || freshness.Stale || freshness.LastBackupAt != nil {
+		t.Fatalf("unexpected initial freshness: %+v", freshness)
+	}
+
+	insertEncryptedCert(t, app, "one.example.com")
+	insertEncryptedCert(t, app, "two.example.com")
+	if err := app.UpdateCertificateNote("one.example.com", "note"); err != nil {
+		t.Fatalf("UpdateCertificateNote() error: %v", err)
+	}
+
+	freshness, err = app.GetBackupFreshness()
+	if err != nil {
+		t.Fatalf("GetBackupFreshness() error: %v", err)
+	}
+	if freshness.WritesSinceBackup != 3 || !freshness.Stale || freshness.Blocking {
+		t.Fatalf("expected 3 writes, stale and not blocking, got %+v", freshness)
+	}
+
+	health := app.GetHealthStatus()
+	if health.BackupFreshness == nil || !health.BackupFreshness.Stale {
+		t.Errorf("health status should report the stale backup: %+v", health.BackupFreshness)
+	}
+	if len(health.Warnings) != 1 || !strings.Contains(health.Warnings[0], "no manual backup") {
+		t.Errorf("unexpected warnings: %v", health.Warnings)
+	}
+
+	app.recordBackup(app.db)
+
+	freshness, err = app.GetBackupFreshness()
+	if err != nil {
+		t.Fatalf("GetBackupFreshness() error: %v", err)
+	}
+	if freshness.WritesSinceBackup != 0 || freshness.Stale || freshness.LastBackupAt == nil {
+		t.Errorf("backup should reset the counter, got %+v", freshness)
+	}
+	if warnings := app.GetHealthStatus().Warnings; len(warnings) != 0 {
+		t.Errorf("expected no warnings after a backup, got %v", warnings)
+	}
+}
+
+func TestBackupFreshness_BlocksRiskyOperations(t *testing.T) {
+	app := setupUnlockedApp(t)
+	setBackupFreshnessPolicy(t, app, 1, true)
+	insertEncryptedCert(t, app, "web.example.com")
+
+	err := app.DeleteCertificate("web.example.com")
+	if err == nil || !strings.Contains(err.Error(), "since the last backup") {
+		t.Fatalf("expected delete to be blocked, got %v", err)
+	}
+	if exists, _ := app.db.Queries().CertificateExists(app.ctx, "web.example.com"); exists != 1 {
+		t.Fatal("blocked delete must not remove the certificate")
+	}
+
+	app.recordBackup(app.db)
+	if err := app.DeleteCertificate("web.example.com"); err != nil {
+		t.Fatalf("delete should be allowed after a backup: %v", err)
+	}
+}
+
+func TestBackupFreshness_DisabledOrEmpty(t *testing.T) {
+	app := setupUnlockedApp(t)
+	setBackupFreshnessPolicy(t, app, 0, true)
+	insertEncryptedCert(t, app, "web.example.com")
+
+	freshness, err := app.GetBackupFreshness()
+	if err != nil {
+		t.Fatalf("GetBackupFreshness() error: %v", err)
+	}
+	if freshness.Stale {
+		t.Errorf("a threshold of 0 disables the policy, got %+v", freshness)
+	}
+
+	// Writes that leave no certificates behind are not worth warning about
+	setBackupFreshnessPolicy(t, app, 1, true)
+	if err := app.db.Queries().DeleteCertificate(app.ctx, "web.example.com"); err != nil {
+		t.Fatalf("DeleteCertificate() error: %v", err)
+	}
+	freshness, err = app.GetBackupFreshness()
+	if err != nil {
+		t.Fatalf("GetBackupFreshness() error: %v", err)
+	}
+	if freshness.Stale {
+		t.Errorf("an empty inventory should not be stale, got %+v", freshness)
+	}
+}
+
+func TestWritePasswordProtectedBackup_StartsFresh(t *testing.T) {
+	app, _ := setupFileBasedApp(t)
+	insertEncryptedCert(t, app, "web.example.com")
+
+	path := filepath.Join(t.TempDir(), "export.db")
+	if err := writePasswordProtectedBackup(app.ctx, app.db.DB(), path, app.masterKey.Bytes(), testExportPassword, fastArgon2Params, app.appClock().Now()); err != nil {
+		t.Fatalf("writePasswordProtectedBackup() error: %v", err)
+	}
+
+	exported, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("failed to open export: %v", err)
+	}
+	defer exported.Close()
+
+	var writes int
+	var lastBackupAt sql.NullInt64
+	if err := exported.QueryRow("SELECT writes_since_backup, last_backup_at FROM config WHERE id = 1").Scan(&writes, &lastBackupAt); err != nil {
+		t.Fatalf("failed to read export config: %v", err)
+	}
+	if writes != 0 || !lastBackupAt.Valid {
+		t.Errorf("export should be recorded as a fresh backup, got writes=%d last_backup_at=%v", writes, lastBackupAt)
+	}
+}
@@ -1,8 +1,11 @@
 package main
 
 import (
+	"context"
+	"crypto/subtle"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -12,8 +15,10 @@ import (
 	"paddockcontrol-desktop/internal/crypto"
 	"paddockcontrol-desktop/internal/db"
 	dbsqlc "paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/hostnames"
 	"paddockcontrol-desktop/internal/logger"
 	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/services"
 
 	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
@@ -61,19 +66,24 @@ func peekBackupAtPath(path string) (*models.BackupPeekInfo, error) {
 	}
 	info.CertificateCount = len(certs)
 
-	// Get CA name from config
-	var caName sql.NullString
-	err = backupDB.QueryRow("SELECT ca_name FROM config WHERE is_configured = 1 LIMIT 1").Scan(&caName)
-	if err == nil && caName.Valid {
+	// Get CA name and hostname suffix from config
+	var caName, suffix sql.NullString
+	err = backupDB.QueryRow("SELECT ca_name, hostname_suffix FROM config WHERE is_configured = 1 LIMIT 1").Scan(&caName, &suffix)
+	if err == nil {
 		info.CAName = caName.String
+		info.HostnameSuffix = suffix.String
 	}
+	info.SuggestedHostnameSuffix = hostnames.SuggestSuffix(info.HostnameSuffix, info.Hostnames)
 
-	// Check if security_keys table has entries
-	var keyCount int
-	err = backupDB.QueryRow("SELECT COUNT(*) FROM security_keys").Scan(&keyCount)
-	if err == nil {
-		info.HasSecurityKeys = keyCount > 0
+	// Unlock methods the restored database will accept
+	methods, err := readBackupUnlockMethods(backupDB)
+	if err != nil {
+		log.Debug("no unlock methods read from backup", logger.Err(err))
 	}
+	info.UnlockMethods = methods
+	info.HasSecurityKeys = len(methods) > 0
+
+	info.Metadata = services.ReadBackupMetadata(backupDB)
 
 	if info.Hostnames == nil {
 		info.Hostnames = []string{}
@@ -81,6 +91,9 @@ func peekBackupAtPath(path string) (*models.BackupPeekInfo, error) {
 	if info.Certificates == nil {
 		info.Certificates = []models.BackupCertificateInfo{}
 	}
+	if info.UnlockMethods == nil {
+		info.UnlockMethods = []models.SecurityKeyInfo{}
+	}
 
 	log.Info("backup peek complete",
 		slog.Int("certificates", info.CertificateCount),
@@ -92,12 +105,77 @@ func peekBackupAtPath(path string) (*models.BackupPeekInfo, error) {
 	return info, nil
 }
 
+// readBackupUnlockMethods lists the unlock methods stored in a backup DB
+// (opened read-only), oldest first. Wrapped keys and metadata are not read.
+func readBackupUnlockMethods(backupDB *sql.DB) ([]models.SecurityKeyInfo, error) {
+	rows, err := backupDB.Query("SELECT id, method, label, created_at, last_used_at FROM security_keys ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var methods []models.SecurityKeyInfo
+	for rows.Next() {
+		var m models.SecurityKeyInfo
+		var lastUsed sql.NullInt64
+		if err := rows.Scan(&m.ID, &m.Method, &m.Label, &m.CreatedAt, &lastUsed); err != nil {
+			return nil, err
+		}
+		if lastUsed.Valid {
+			m.LastUsedAt = &lastUsed.Int64
+		}
+		methods = append(methods, m)
+	}
+	return methods, rows.Err()
+}
+
+// TestBackupPassword checks a password against the password unlock methods of
+// a backup file without restoring it, so the user knows before a restore
+// whether they will be able to unlock afterwards. A wrong password is reported
+// as invalid, not as an error.
+// Available before setup and unlock.
+func (a *App) TestBackupPassword(path, password string) (*models.BackupPasswordCheck, error) {
+	if err := validateBackupPath(path); err != nil {
+		return nil, err
+	}
+	log := logger.WithComponent("app")
+
+	backupDB, err := openBackupDB(path)
+	if err != nil {
+		return nil, err
+	}
+	defer backupDB.Close()
+
+	backupMasterKey, label, err := unwrapBackupMasterKey(backupDB, password)
+	if errors.Is(err, errInvalidBackupPassword) {
+		log.Info("backup password test failed", slog.String("path", path))
+		return &models.BackupPasswordCheck{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer backupMasterKey.Destroy()
+
+	check := &models.BackupPasswordCheck{Valid: true, Label: label}
+	a.mu.RLock()
+	if a.isUnlocked && a.masterKey.Len() > 0 {
+		check.SameMasterKey = subtle.ConstantTimeCompare(a.masterKey.Bytes(), backupMasterKey.Bytes()) == 1
+	}
+	a.mu.RUnlock()
+
+	log.Info("backup password test passed", slog.String("path", path), slog.Bool("same_master_key", check.SameMasterKey))
+	return check, nil
+}
+
 // readBackupCertificates reads all certificates from a backup DB (opened read-only)
 // and returns their display fields: status, SANs, key size, created/expires. Only
 // public data (certificate PEM / pending CSR) is parsed — no master key is needed.
 func readBackupCertificates(backupDB *sql.DB) ([]models.BackupCertificateInfo, error) {
+	// Certificates in the backup's trash (schema v36 on) are not listed
+	deletedAtColumn := backupCertificateColumn(backupDB, "deleted_at")
 	rows, err := backupDB.Query(
-		"SELECT hostname, certificate_pem, pending_csr_pem, created_at, expires_at FROM certificates ORDER BY hostname",
+		"SELECT hostname, certificate_pem, pending_csr_pem, created_at, expires_at FROM certificates WHERE " +
+			deletedAtColumn + " IS NULL ORDER BY hostname",
 	)
 	if err != nil {
 		return nil, err
@@ -124,7 +202,7 @@ func readBackupCertificates(backupDB *sql.DB) ([]models.BackupCertificateInfo, e
 			PendingCsrPem:  pendingCSR,
 			CreatedAt:      createdAt,
 			ExpiresAt:      expiresAt,
-		})
+		}, db.DefaultExpiringThresholdDays)
 
 		info := models.BackupCertificateInfo{
 			Hostname:  hostname,
@@ -160,219 +238,307 @@ func readBackupCertificates(backupDB *sql.DB) ([]models.BackupCertificateInfo, e
 
 // ImportCertificatesFromBackup selectively imports certificates from a backup DB file.
 // Requires the app to be unlocked. Decrypts keys with the backup's master key and
-// re-encrypts them with the current master key.
-func (a *App) ImportCertificatesFromBackup(backupPath string, backupPassword string) (*models.CertImportResult, error) {
+// re-encrypts them with the current master key. By default the import is
+// all-or-nothing; opts.BestEffort imports each certificate independently and
+// reports per-entry failures instead of aborting. An entry whose public key is
+// already held by another hostname is linked to that certificate rather than
+// inserted, unless opts.DuplicateKeyPolicy is KeyDuplicateImport. Entries listed
+// in opts.HostnameMapping are imported under their new hostname, with the
+// original one recorded in the note and in history. A dry run imports inside
+// transactions that are rolled back and takes no backup, returning the result
+// the import would have.
+func (a *App) ImportCertificatesFromBackup(backupPath string, backupPassword string, opts models.CertImportOptions, dryRun bool) (*models.CertImportResult, error) {
 	if err := a.requireUnlocked(); err != nil {
 		return nil, err
 	}
-
-	_, log := logger.WithOperation(a.ctx, "import_certificates")
-	log.Info("importing certificates from backup", slog.String("path", backupPath))
-
-	if err := validateBackupPath(backupPath); err != nil {
+	if err := validateDuplicateKeyPolicy(opts.DuplicateKeyPolicy); err != nil {
 		return nil, err
 	}
 
-	// Open backup DB read-only
-	backupDB, err := openBackupDB(backupPath)
+	_, log := logger.WithOperation(a.ctx, "import_certificates")
+	log.Info("importing certificates from backup",
+		slog.String("path", backupPath),
+		slog.Bool("best_effort", opts.BestEffort),
+		slog.Int("mapped_hostnames", len(opts.HostnameMapping)),
+		slog.Bool("dry_run", dryRun),
+	)
+
+	backupDB, backupMasterKey, err := openBackupForImport(backupPath, backupPassword)
 	if err != nil {
 		return nil, err
 	}
 	defer backupDB.Close()
-
-	// Verify backup schema version supports master key wrapping
-	version, dirty := getBackupSchemaVersion(backupDB)
-	log.Info("backup schema version", slog.Uint64("version", uint64(version)), slog.Bool("dirty", dirty))
-	if dirty {
-		return nil, fmt.Errorf("backup database has a dirty migration state and cannot be imported")
-	}
-	if version < 4 {
-		return nil, fmt.Errorf("backup is from an older version (schema v%d) that doesn't support master key wrapping; full restore is required instead of certificate import", version)
-	}
-
-	// Get backup's master key by unwrapping with the provided password
-	backupMasterKey, err := unwrapBackupMasterKey(backupDB, backupPassword)
-	if err != nil {
-		log.Error("failed to unwrap backup master key", logger.Err(err))
-		return nil, fmt.Errorf("wrong password or invalid backup: %w", err)
-	}
-	defer crypto.Zero(backupMasterKey)
+	defer backupMasterKey.Destroy()
 
 	// Get current master key
 	a.mu.RLock()
-	currentMasterKey := make([]byte, len(a.masterKey))
-	copy(currentMasterKey, a.masterKey)
+	currentMasterKey := a.masterKey.Clone()
 	a.mu.RUnlock()
-	defer crypto.Zero(currentMasterKey)
+	defer currentMasterKey.Destroy()
 
-	if len(currentMasterKey) != 32 {
+	if currentMasterKey.Len() != 32 {
 		return nil, fmt.Errorf("master key is not available")
 	}
 
 	// Read all certificates from backup
-	rows, err := backupDB.Query(`
-		SELECT hostname, encrypted_private_key, pending_csr_pem, certificate_pem,
-		       pending_encrypted_private_key, created_at, expires_at, note, pending_note, read_only
-		FROM certificates
-	`)
+	certs, err := readBackupCertificatesForImport(backupDB)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read backup certificates: %w", err)
+		return nil, err
 	}
-	defer rows.Close()
-
-	type backupCert struct {
-		hostname            string
-		encryptedKey        []byte
-		pendingCSR          sql.NullString
-		certificatePEM      sql.NullString
-		pendingEncryptedKey []byte
-		createdAt           int64
-		expiresAt           sql.NullInt64
-		note                sql.NullString
-		pendingNote         sql.NullString
-		readOnly            int64
+	if err := applyHostnameMapping(certs, opts.HostnameMapping); err != nil {
+		return nil, err
 	}
 
-	var certs []backupCert
-	for rows.Next() {
-		var c backupCert
-		if err := rows.Scan(
-			&c.hostname, &c.encryptedKey, &c.pendingCSR, &c.certificatePEM,
-			&c.pendingEncryptedKey, &c.createdAt, &c.expiresAt, &c.note, &c.pendingNote, &c.readOnly,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan certificate: %w", err)
-		}
-		certs = append(certs, c)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("failed to iterate certificates: %w", err)
+	if !dryRun {
+		a.performAutoBackup("import_certificates")
 	}
 
-	a.performAutoBackup("import_certificates")
-
 	result := &models.CertImportResult{
 		Conflicts: []string{},
+		DryRun:    dryRun,
+		Warnings:  models.NewWarnings(),
 	}
 
 	a.mu.RLock()
 	database := a.db
 	a.mu.RUnlock()
 
-	// Wrap the whole import in one transaction: either every non-conflicting
-	// certificate is inserted, or none are. A failure midway (e.g. a corrupt
-	// key on cert N) rolls back the certs already inserted in this run.
-	if err := database.WithTx(a.ctx, func(q *dbsqlc.Queries) error {
-		for _, cert := range certs {
-			certLog := log.With(slog.String("hostname", cert.hostname))
+	linkKeys := opts.DuplicateKeyPolicy != models.KeyDuplicateImport
+	keys, err := buildPublicKeyIndex(a.ctx, database.Queries())
+	if err != nil {
+		return nil, err
+	}
+	history := services.NewHistoryService(database, Version)
+
+	importOne := func(q *dbsqlc.Queries, cert backupCert) error {
+		certLog := log.With(slog.String("hostname", cert.hostname))
 
-			// Check for hostname conflicts
+		if linkKeys {
 			exists, err := q.CertificateExists(a.ctx, cert.hostname)
 			if err != nil {
 				return fmt.Errorf("failed to check certificate existence for %s: %w", cert.hostname, err)
 			}
-			if exists == 1 {
-				certLog.Debug("skipping duplicate hostname")
-				result.Skipped++
-				result.Conflicts = append(result.Conflicts, cert.hostname)
-				continue
-			}
-
-			// Re-encrypt keys: decrypt with backup master key, encrypt with current master key
-			var newEncryptedKey []byte
-			if len(cert.encryptedKey) > 0 {
-				plaintext, err := crypto.DecryptPrivateKey(cert.encryptedKey, backupMasterKey)
-				if err != nil {
-					certLog.Error("failed to decrypt private key from backup", logger.Err(err))
-					return fmt.Errorf("failed to decrypt private key for %s: %w", cert.hostname, err)
-				}
-				newEncryptedKey, err = crypto.EncryptPrivateKey(plaintext, currentMasterKey)
-				crypto.Zero(plaintext)
-				if err != nil {
-					return fmt.Errorf("failed to re-encrypt private key for %s: %w", cert.hostname, err)
+			holder := keys.match(cert.hostname, cert.certificatePEM, cert.pendingCSR)
+			if exists == 0 && holder != "" {
+				if err := linkBackupCertificate(a.ctx, q, history, cert, holder); err != nil {
+					return err
 				}
+				certLog.Debug("public key already held by another hostname, linked", slog.String("existing_hostname", holder))
+				result.Linked = append(result.Linked, models.CertKeyLink{Hostname: cert.hostname, ExistingHostname: holder})
+				return nil
 			}
+		}
 
-			var newPendingEncryptedKey []byte
-			if len(cert.pendingEncryptedKey) > 0 {
-				plaintext, err := crypto.DecryptPrivateKey(cert.pendingEncryptedKey, backupMasterKey)
-				if err != nil {
-					certLog.Error("failed to decrypt pending private key from backup", logger.Err(err))
-					return fmt.Errorf("failed to decrypt pending private key for %s: %w", cert.hostname, err)
-				}
-				newPendingEncryptedKey, err = crypto.EncryptPrivateKey(plaintext, currentMasterKey)
-				crypto.Zero(plaintext)
-				if err != nil {
-					return fmt.Errorf("failed to re-encrypt pending private key for %s: %w", cert.hostname, err)
-				}
+		if cert.originalHostname != "" {
+			cert.note = renamedImportNote(cert)
+		}
+		imported, err := importBackupCertificate(a.ctx, q, cert, backupMasterKey.Bytes(), currentMasterKey.Bytes())
+		if err != nil {
+			certLog.Error("failed to import certificate from backup", logger.Err(err))
+			return err
+		}
+		if !imported {
+			certLog.Debug("skipping duplicate hostname")
+			result.Skipped++
+			result.Conflicts = append(result.Conflicts, cert.hostname)
+			return nil
+		}
+		if cert.originalHostname != "" {
+			if err := logRenamedImport(a.ctx, q, history, cert); err != nil {
+				return err
 			}
+			certLog.Debug("certificate renamed on import", slog.String("original_hostname", cert.originalHostname))
+			result.Renamed = append(result.Renamed, models.CertRename{
+				OriginalHostname: cert.originalHostname,
+				Hostname:         cert.hostname,
+			})
+		}
 
-			// Insert into current database, preserving the backup's original created_at
-			if err := q.ImportCertificate(a.ctx, dbsqlc.ImportCertificateParams{
-				Hostname:                   cert.hostname,
-				EncryptedPrivateKey:        newEncryptedKey,
-				PendingCsrPem:              cert.pendingCSR,
-				PendingEncryptedPrivateKey: newPendingEncryptedKey,
-				CertificatePem:             cert.certificatePEM,
-				CreatedAt:                  cert.createdAt,
-				ExpiresAt:                  cert.expiresAt,
-				Note:                       cert.note,
-				PendingNote:                cert.pendingNote,
-				ReadOnly:                   cert.readOnly,
+		certLog.Debug("certificate imported")
+		keys.add(cert.hostname, cert.certificatePEM, cert.pendingCSR)
+		result.Imported++
+		return nil
+	}
+
+	if opts.BestEffort {
+		// Best-effort: each certificate gets its own transaction, so a failure
+		// (e.g. a corrupt key) only drops that entry and is reported back.
+		result.Failed = []models.CertImportFailure{}
+		for _, cert := range certs {
+			if err := database.WithDryRunTx(a.ctx, dryRun, func(q *dbsqlc.Queries) error {
+				return importOne(q, cert)
 			}); err != nil {
-				return fmt.Errorf("failed to insert certificate %s: %w", cert.hostname, err)
+				result.Failed = append(result.Failed, models.CertImportFailure{
+					Hostname: cert.hostname,
+					Error:    err.Error(),
+				})
 			}
+		}
+	} else {
+		// Strict (default): wrap the whole import in one transaction, so either every
+		// non-conflicting certificate is inserted, or none are. A failure midway
+		// (e.g. a corrupt key on cert N) rolls back the certs already inserted in this run.
+		if err := database.WithDryRunTx(a.ctx, dryRun, func(q *dbsqlc.Queries) error {
+			for _, cert := range certs {
+				if err := importOne(q, cert); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			if !dryRun {
+				a.recordActivity("import_certificates", "", err)
+			}
+			return nil, err
+		}
+	}
+	if dryRun {
+		log.Info("certificate import dry run completed",
+			slog.Int("imported", result.Imported),
+			slog.Int("skipped", result.Skipped),
+			slog.Int("failed", len(result.Failed)),
+		)
+		return result, nil
+	}
+	a.recordActivity("import_certificates", "", nil)
 
-			certLog.Debug("certificate imported")
-			result.Imported++
+	// Propose a hostname suffix when the imported hostnames do not fit the
+	// configured one, which would otherwise block CSR generation for them
+	a.mu.RLock()
+	configService := a.configService
+	a.mu.RUnlock()
+	if configService != nil && result.Imported > 0 {
+		result.SuggestedHostnameSuffix, err = configService.SuggestHostnameSuffix(a.ctx)
+		if err != nil {
+			log.Warn("failed to infer hostname suffix", logger.Err(err))
 		}
-		return nil
-	}); err != nil {
-		return nil, err
 	}
 
 	log.Info("certificate import completed",
 		slog.Int("imported", result.Imported),
 		slog.Int("skipped", result.Skipped),
 		slog.Int("conflicts", len(result.Conflicts)),
+		slog.Int("failed", len(result.Failed)),
+		slog.Int("linked", len(result.Linked)),
+		slog.Int("renamed", len(result.Renamed)),
 	)
 
 	return result, nil
 }
 
-// RestoreFromBackupFile replaces the current database with a backup file selected by the user.
-// Unlike RestoreLocalBackup, this accepts any valid .db file path (not just local backup files).
-func (a *App) RestoreFromBackupFile(path string) error {
-	log := logger.WithComponent("app")
-	log.Info("restoring from backup file", slog.String("path", path))
+// PreviewCertificateImport validates every certificate of a backup DB file against
+// the current database without writing anything. Each entry is parsed, its keys are
+// decrypted with the backup's master key, its hostname is checked for conflicts, and
+// its public key is matched against the certificates of other hostnames, so the
+// frontend can show what ImportCertificatesFromBackup would do before committing.
+func (a *App) PreviewCertificateImport(backupPath string, backupPassword string) (*models.CertImportPreview, error) {
+	if err := a.requireUnlocked(); err != nil {
+		return nil, err
+	}
 
-	if err := validateBackupPath(path); err != nil {
-		return err
+	_, log := logger.WithOperation(a.ctx, "preview_certificate_import")
+	log.Info("previewing certificate import from backup", slog.String("path", backupPath))
+
+	backupDB, backupMasterKey, err := openBackupForImport(backupPath, backupPassword)
+	if err != nil {
+		log.Error("failed to open backup for import preview", logger.Err(err))
+		return nil, err
 	}
+	defer backupDB.Close()
+	defer backupMasterKey.Destroy()
 
-	// Validate it's a valid SQLite database by trying to open and query it
-	testDB, err := openBackupDB(path)
+	certs, err := readBackupCertificatesForImport(backupDB)
 	if err != nil {
-		return fmt.Errorf("invalid backup file: %w", err)
+		return nil, err
 	}
 
-	version, dirty := getBackupSchemaVersion(testDB)
-	log.Info("backup schema version", slog.Uint64("version", uint64(version)), slog.Bool("dirty", dirty))
-	testDB.Close()
+	a.mu.RLock()
+	database := a.db
+	a.mu.RUnlock()
 
-	if dirty {
-		return fmt.Errorf("backup database has a dirty migration state and cannot be restored")
+	keys, err := buildPublicKeyIndex(a.ctx, database.Queries())
+	if err != nil {
+		return nil, err
 	}
 
-	// Reject databases with an unknown schema version. getBackupSchemaVersion
-	// returns 0 when schema_migrations is missing/unreadable (a corrupt file or
-	// not a PaddockControl database), which must not overwrite the live database.
-	if version == 0 {
-		return fmt.Errorf("unrecognized backup: file is not a valid PaddockControl database")
+	preview := &models.CertImportPreview{
+		Importable:    []models.CertImportPreviewEntry{},
+		Conflicting:   []models.CertImportPreviewEntry{},
+		Invalid:       []models.CertImportPreviewEntry{},
+		KeyDuplicates: []models.CertImportPreviewEntry{},
+	}
+
+	for _, cert := range certs {
+		entry := models.CertImportPreviewEntry{
+			Hostname: cert.hostname,
+			Status:   string(cert.status()),
+		}
+
+		if err := validateBackupCertificate(cert, backupMasterKey.Bytes()); err != nil {
+			entry.Reason = err.Error()
+			preview.Invalid = append(preview.Invalid, entry)
+			continue
+		}
+
+		exists, err := database.Queries().CertificateExists(a.ctx, cert.hostname)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check certificate existence for %s: %w", cert.hostname, err)
+		}
+		if exists == 1 {
+			entry.Reason = "a certificate with this hostname already exists"
+			preview.Conflicting = append(preview.Conflicting, entry)
+			continue
+		}
+
+		if holder := keys.match(cert.hostname, cert.certificatePEM, cert.pendingCSR); holder != "" {
+			entry.Reason = "the same public key is already used by " + holder
+			entry.LinkedTo = holder
+			preview.KeyDuplicates = append(preview.KeyDuplicates, entry)
+			continue
+		}
+
+		keys.add(cert.hostname, cert.certificatePEM, cert.pendingCSR)
+		preview.Importable = append(preview.Importable, entry)
+	}
+
+	log.Info("certificate import preview completed",
+		slog.Int("importable", len(preview.Importable)),
+		slog.Int("conflicting", len(preview.Conflicting)),
+		slog.Int("invalid", len(preview.Invalid)),
+		slog.Int("key_duplicates", len(preview.KeyDuplicates)),
+	)
+
+	return preview, nil
+}
+
+// RestoreFromBackupFile replaces the current database with a backup file selected by the user.
+// Unlike RestoreLocalBackup, this accepts any valid .db file path (not just local backup files).
+// A dry run validates the backup and returns the report without replacing anything.
+func (a *App) RestoreFromBackupFile(path string, dryRun bool) (*models.RestoreReport, error) {
+	return a.restoreFromBackupFile(path, path, dryRun)
+}
+
+// restoreFromBackupFile replaces the current database with the file at path,
+// a copy of the backup selected at origin (a re-keyed copy) or origin itself;
+// origin is what the audit log records.
+func (a *App) restoreFromBackupFile(path, origin string, dryRun bool) (*models.RestoreReport, error) {
+	log := logger.WithComponent("app")
+	log.Info("restoring from backup file", slog.String("path", path), slog.Bool("dry_run", dryRun))
+
+	report, err := inspectBackupForRestore(path)
+	if err != nil {
+		return nil, err
 	}
 
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
+	report.ReplacedCertificates = countCurrentCertificates(a.ctx, a.db)
+	if dryRun {
+		report.DryRun = true
+		return report, nil
+	}
+
 	// Create a safety backup before restore
 	if a.autoBackupService != nil {
 		if _, err := a.autoBackupService.CreateBackup("restore_from_file"); err != nil {
@@ -381,6 +547,14 @@ func (a *App) RestoreFromBackupFile(path string) error {
 		}
 	}
 
+	// The background key migration writes to the database being replaced
+	a.stopKeyEnvelopeMigration()
+	a.stopKeyPool()
+	a.stopSyncServer()
+
+	// The audit log lives in the database being replaced
+	auditEntries := a.readAuditLog(a.db)
+
 	// Close the current database connection
 	if a.db != nil {
 		if err := a.db.Close(); err != nil {
@@ -396,15 +570,16 @@ func (a *App) RestoreFromBackupFile(path string) error {
 
 	if err := copyFile(path, dbPath); err != nil {
 		log.Error("failed to copy backup file", logger.Err(err))
-		return fmt.Errorf("failed to restore backup: %w", err)
+		return nil, fmt.Errorf("failed to restore backup: %w", err)
 	}
 
 	// Re-initialize the database
 	a.db, err = db.NewDatabase(a.dataDir)
 	if err != nil {
 		log.Error("failed to reinitialize database after restore", logger.Err(err))
-		return fmt.Errorf("failed to reinitialize database: %w", err)
+		return nil, fmt.Errorf("failed to reinitialize database: %w", err)
 	}
+	a.carryAuditLog(a.db, auditEntries)
 
 	// Re-check configuration state
 	tmpConfigService := config.NewService(a.db)
@@ -414,29 +589,29 @@ func (a *App) RestoreFromBackupFile(path string) error {
 	}
 
 	// Clear encryption key — restored DB may not match in-memory key
-	if a.masterKey != nil {
-		for i := range a.masterKey {
-			a.masterKey[i] = 0
-		}
-		a.masterKey = nil
-	}
+	a.masterKey.Destroy()
+	a.masterKey = nil
 	a.isUnlocked = false
 	a.waitingForEncryptionKey = false
 
 	// Re-initialize all services
 	a.initializeServicesWithoutKey()
 
+	a.recordAudit(a.db, models.AuditBackupRestore, "", "Database restored from a backup file",
+		map[string]any{"source": "file", "file": filepath.Base(origin)})
+	a.recordActivity("restore_from_file", "", nil)
 	log.Info("backup file restored successfully", slog.String("path", path))
-	return nil
+	return report, nil
 }
 
-// SelectBackupFile opens a file dialog for the user to select a .db backup file.
+// SelectBackupFile opens a file dialog for the user to select a backup file
+// (.pcbackup export or .db backup).
 // Returns the selected file path, or empty string if cancelled.
 func (a *App) SelectBackupFile() (string, error) {
 	path, err := wailsruntime.OpenFileDialog(a.ctx, wailsruntime.OpenDialogOptions{
 		Title: "Select Backup File",
 		Filters: []wailsruntime.FileFilter{
-			{DisplayName: "Database Files (*.db)", Pattern: "*.db"},
+			{DisplayName: "Backups (*.pcbackup, *.db)", Pattern: "*.pcbackup;*.db"},
 			{DisplayName: "All Files (*.*)", Pattern: "*.*"},
 		},
 	})
@@ -450,6 +625,56 @@ func (a *App) SelectBackupFile() (string, error) {
 // Internal helpers
 // ============================================================================
 
+// inspectBackupForRestore checks that path is a PaddockControl database that
+// can replace the current one and reports its schema version and certificates
+func inspectBackupForRestore(path string) (*models.RestoreReport, error) {
+	if err := validateBackupPath(path); err != nil {
+		return nil, err
+	}
+
+	// Validate it's a valid SQLite database by trying to open and query it
+	testDB, err := openBackupDB(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup file: %w", err)
+	}
+	defer testDB.Close()
+
+	version, dirty := getBackupSchemaVersion(testDB)
+	logger.WithComponent("app").Info("backup schema version", slog.Uint64("version", uint64(version)), slog.Bool("dirty", dirty))
+
+	if dirty {
+		return nil, fmt.Errorf("backup database has a dirty migration state and cannot be restored")
+	}
+
+	// Reject databases with an unknown schema version. getBackupSchemaVersion
+	// returns 0 when schema_migrations is missing/unreadable (a corrupt file or
+	// not a PaddockControl database), which must not overwrite the live database.
+	if version == 0 {
+		return nil, fmt.Errorf("unrecognized backup: file is not a valid PaddockControl database")
+	}
+
+	report := &models.RestoreReport{SchemaVersion: version}
+	if err := testDB.QueryRow("SELECT COUNT(*) FROM certificates").Scan(&report.Certificates); err != nil {
+		return nil, fmt.Errorf("failed to count backup certificates: %w", err)
+	}
+	return report, nil
+}
+
+// countCurrentCertificates returns how many certificates a restore would
+// replace. A database that cannot be read counts as empty: restoring is how
+// it gets repaired, so it must not block the restore.
+func countCurrentCertificates(ctx context.Context, database *db.Database) int {
+	if database == nil {
+		return 0
+	}
+	n, err := database.Queries().CountCertificates(ctx)
+	if err != nil {
+		logger.WithComponent("app").Warn("failed to count current certificates", logger.Err(err))
+		return 0
+	}
+	return int(n)
+}
+
 // getBackupSchemaVersion reads the schema version from a backup's schema_migrations table.
 // Returns 0 if the table doesn't exist (pre-migration DB or not a paddockcontrol DB).
 func getBackupSchemaVersion(backupDB *sql.DB) (uint, bool) {
@@ -490,25 +715,31 @@ func openBackupDB(path string) (*sql.DB, error) {
 	return backupDB, nil
 }
 
+// errInvalidBackupPassword is returned when no password method of a backup
+// accepts the password
+var errInvalidBackupPassword = errors.New("invalid password")
+
 // unwrapBackupMasterKey reads the security_keys table from a backup DB and tries to
-// unwrap the master key using the provided password.
-func unwrapBackupMasterKey(backupDB *sql.DB, password string) ([]byte, error) {
+// unwrap the master key using the provided password. It also returns the label
+// of the password method that accepted it.
+func unwrapBackupMasterKey(backupDB *sql.DB, password string) (*crypto.SecretBuffer, string, error) {
 	rows, err := backupDB.Query(
-		"SELECT wrapped_master_key, metadata FROM security_keys WHERE method = ?",
+		"SELECT label, wrapped_master_key, metadata FROM security_keys WHERE method = ?",
 		models.SecurityKeyMethodPassword,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to query security keys: %w", err)
+		return nil, "", fmt.Errorf("failed to query security keys: %w", err)
 	}
 	defer rows.Close()
 
 	found := false
 	for rows.Next() {
 		found = true
+		var label string
 		var wrappedKey []byte
 		var metadataStr sql.NullString
-		if err := rows.Scan(&wrappedKey, &metadataStr); err != nil {
-			return nil, fmt.Errorf("failed to scan security key: %w", err)
+		if err := rows.Scan(&label, &wrappedKey, &metadataStr); err != nil {
+			return nil, "", fmt.Errorf("failed to scan security key: %w", err)
 		}
 
 		if !metadataStr.Valid {
@@ -533,16 +764,602 @@ func unwrapBackupMasterKey(backupDB *sql.DB, password string) ([]byte, error) {
 
 		wrappingKey := crypto.DeriveKeyFromPassword(password, metadata.Salt, params)
 		masterKey, err := crypto.UnwrapMasterKey(wrappedKey, wrappingKey)
+		crypto.Zero(wrappingKey)
 		if err != nil {
 			continue // Wrong password for this entry
 		}
 
-		return masterKey, nil
+		return masterKey, label, nil
 	}
 
 	if !found {
-		return nil, fmt.Errorf("backup has no password unlock methods")
+		return nil, "", fmt.Errorf("backup has no password unlock methods")
 	}
 
-	return nil, fmt.Errorf("invalid password")
+	return nil, "", errInvalidBackupPassword
+}
+
+// backupCert is a certificate row read from a backup DB for import.
+type backupCert struct {
+	hostname            string
+	encryptedKey        []byte
+	pendingCSR          sql.NullString
+	certificatePEM      sql.NullString
+	pendingEncryptedKey []byte
+	createdAt           int64
+	expiresAt           sql.NullInt64
+	lastModified        int64
+	note                sql.NullString
+	pendingNote         sql.NullString
+	readOnly            int64
+	chainPEM            sql.NullString               // empty for backups older than schema v9
+	caReference         sql.NullString               // empty for backups older than schema v14
+	submittedAt         sql.NullInt64                // empty for backups older than schema v14
+	originalHostname    string                       // hostname in the backup, when renamed by a mapping
+	tags                []string                     // empty for backups older than schema v31
+	checklist           []dbsqlc.RenewalChecklist    // empty for backups older than schema v13
+	chainOverride       *dbsqlc.ChainOverride        // nil without a per-certificate override
+	relations           []dbsqlc.CertificateRelation // on either side, hostnames normalized
+	requester           *dbsqlc.CertificateRequester // nil when no request file was recorded
+	deployments         []dbsqlc.DeploymentTarget    // empty for backups older than schema v28
+}
+
+// status computes the certificate status using the shared status rules.
+func (c backupCert) status() db.CertificateStatus {
+	return db.ComputeStatus(&dbsqlc.Certificate{
+		Hostname:       c.hostname,
+		CertificatePem: c.certificatePEM,
+		PendingCsrPem:  c.pendingCSR,
+		CreatedAt:      c.createdAt,
+		ExpiresAt:      c.expiresAt,
+	}, db.DefaultExpiringThresholdDays)
+}
+
+// openBackupForImport opens a backup DB read-only, checks that its schema supports
+// master key wrapping, and unwraps the backup's master key with the given password.
+// The caller must close the DB and zero the returned master key.
+func openBackupForImport(backupPath string, backupPassword string) (*sql.DB, *crypto.SecretBuffer, error) {
+	log := logger.WithComponent("app")
+
+	if err := validateBackupPath(backupPath); err != nil {
+		return nil, nil, err
+	}
+
+	backupDB, err := openBackupDB(backupPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Verify backup schema version supports master key wrapping
+	version, dirty := getBackupSchemaVersion(backupDB)
+	log.Info("backup schema version", slog.Uint64("version", uint64(version)), slog.Bool("dirty", dirty))
+	if dirty {
+		backupDB.Close()
+		return nil, nil, fmt.Errorf("backup database has a dirty migration state and cannot be imported")
+	}
+	if version < 4 {
+		backupDB.Close()
+		return nil, nil, fmt.Errorf("backup is from an older version (schema v%d) that doesn't support master key wrapping; full restore is required instead of certificate import", version)
+	}
+
+	// Get backup's master key by unwrapping with the provided password
+	backupMasterKey, _, err := unwrapBackupMasterKey(backupDB, backupPassword)
+	if err != nil {
+		backupDB.Close()
+		log.Error("failed to unwrap backup master key", logger.Err(err))
+		return nil, nil, fmt.Errorf("wrong password or invalid backup: %w", err)
+	}
+
+	return backupDB, backupMasterKey, nil
+}
+
+// readBackupCertificatesForImport reads every certificate row, including encrypted
+// keys, from a backup DB. Certificates in the backup's trash are left out.
+func readBackupCertificatesForImport(backupDB *sql.DB) ([]backupCert, error) {
+	// chain_pem only exists from schema v9 on, the CSR submission columns from
+	// v14, deleted_at from v36
+	chainColumn := backupCertificateColumn(backupDB, "chain_pem")
+	caReferenceColumn := backupCertificateColumn(backupDB, "ca_reference")
+	submittedAtColumn := backupCertificateColumn(backupDB, "submitted_at")
+	deletedAtColumn := backupCertificateColumn(backupDB, "deleted_at")
+
+	rows, err := backupDB.Query(`
+		SELECT hostname, encrypted_private_key, pending_csr_pem, certificate_pem,
+		       pending_encrypted_private_key, created_at, expires_at, last_modified,
+		       note, pending_note, read_only, ` + chainColumn + `,
+		       ` + caReferenceColumn + `, ` + submittedAtColumn + `
+		FROM certificates
+		WHERE ` + deletedAtColumn + ` IS NULL
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup certificates: %w", err)
+	}
+	defer rows.Close()
+
+	var certs []*backupCert
+	byHostname := make(map[string]*backupCert)
+	for rows.Next() {
+		var c backupCert
+		if err := rows.Scan(
+			&c.hostname, &c.encryptedKey, &c.pendingCSR, &c.certificatePEM,
+			&c.pendingEncryptedKey, &c.createdAt, &c.expiresAt, &c.lastModified,
+			&c.note, &c.pendingNote, &c.readOnly, &c.chainPEM,
+			&c.caReference, &c.submittedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan certificate: %w", err)
+		}
+		certs = append(certs, &c)
+		byHostname[c.hostname] = &c
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate certificates: %w", err)
+	}
+
+	// The rows of the per-certificate tables are keyed by hostname as stored in
+	// the backup, so they are attached before hostnames are normalized
+	for _, t := range backupCertificateTables {
+		if t.read == nil {
+			continue
+		}
+		if err := t.read(backupDB, byHostname); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]backupCert, 0, len(certs))
+	for _, c := range certs {
+		// Older backups may hold non-normalized hostnames; import them in canonical
+		// form. Unparseable names are kept as-is and reported by validation.
+		c.hostname = normalizeBackupHostname(c.hostname)
+		result = append(result, *c)
+	}
+	return result, nil
+}
+
+// normalizeBackupHostname returns the canonical form of a hostname read from a
+// backup, or the hostname unchanged when it does not parse.
+func normalizeBackupHostname(hostname string) string {
+	if normalized, err := hostnames.Normalize(hostname); err == nil {
+		return normalized
+	}
+	return hostname
+}
+
+// readBackupTable calls scan for each row query returns from a backup, doing
+// nothing when the backup predates table.
+func readBackupTable(backupDB *sql.DB, table, query string, scan func(*sql.Rows) error) error {
+	var exists int
+	if err := backupDB.QueryRow(
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", table,
+	).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to inspect backup tables: %w", err)
+	}
+	if exists == 0 {
+		return nil
+	}
+
+	rows, err := backupDB.Query(query)
+	if err != nil {
+		return fmt.Errorf("failed to read backup %s: %w", table, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return fmt.Errorf("failed to scan backup %s: %w", table, err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate backup %s: %w", table, err)
+	}
+	return nil
+}
+
+// readBackupCertificateTags attaches the tags of each certificate in a backup.
+// Backups older than schema v31 have no tags.
+func readBackupCertificateTags(backupDB *sql.DB, certs map[string]*backupCert) error {
+	return readBackupTable(backupDB, "certificate_tags",
+		"SELECT hostname, tag FROM certificate_tags ORDER BY hostname, tag",
+		func(rows *sql.Rows) error {
+			var hostname, tag string
+			if err := rows.Scan(&hostname, &tag); err != nil {
+				return err
+			}
+			if c, ok := certs[hostname]; ok {
+				c.tags = append(c.tags, tag)
+			}
+			return nil
+		})
+}
+
+// readBackupRenewalChecklist attaches the completed renewal steps of each
+// certificate in a backup. Backups older than schema v13 have no checklist.
+func readBackupRenewalChecklist(backupDB *sql.DB, certs map[string]*backupCert) error {
+	return readBackupTable(backupDB, "renewal_checklist",
+		"SELECT hostname, step, completed_at, actor FROM renewal_checklist",
+		func(rows *sql.Rows) error {
+			var step dbsqlc.RenewalChecklist
+			if err := rows.Scan(&step.Hostname, &step.Step, &step.CompletedAt, &step.Actor); err != nil {
+				return err
+			}
+			if c, ok := certs[step.Hostname]; ok {
+				c.checklist = append(c.checklist, step)
+			}
+			return nil
+		})
+}
+
+// readBackupChainOverrides attaches the per-certificate chain overrides of a
+// backup. Overrides of an issuing CA are settings, not certificate data, and are
+// left out. Backups older than schema v21 have no overrides.
+func readBackupChainOverrides(backupDB *sql.DB, certs map[string]*backupCert) error {
+	return readBackupTable(backupDB, "chain_overrides", `
+		SELECT id, hostname, issuer_dn, issuer_url, issuer_pem, created_at
+		FROM chain_overrides
+		WHERE hostname IS NOT NULL`,
+		func(rows *sql.Rows) error {
+			var o dbsqlc.ChainOverride
+			if err := rows.Scan(&o.ID, &o.Hostname, &o.IssuerDn, &o.IssuerUrl, &o.IssuerPem, &o.CreatedAt); err != nil {
+				return err
+			}
+			if c, ok := certs[o.Hostname.String]; ok {
+				c.chainOverride = &o
+			}
+			return nil
+		})
+}
+
+// readBackupCertificateRelations attaches each relation of a backup to both
+// certificates it links, with their hostnames normalized. Backups older than
+// schema v26 have no relations.
+func readBackupCertificateRelations(backupDB *sql.DB, certs map[string]*backupCert) error {
+	return readBackupTable(backupDB, "certificate_relations", `
+		SELECT id, source_hostname, target_hostname, relation_type, label, created_at
+		FROM certificate_relations`,
+		func(rows *sql.Rows) error {
+			var r dbsqlc.CertificateRelation
+			if err := rows.Scan(&r.ID, &r.SourceHostname, &r.TargetHostname, &r.RelationType, &r.Label, &r.CreatedAt); err != nil {
+				return err
+			}
+			source, target := certs[r.SourceHostname], certs[r.TargetHostname]
+			r.SourceHostname = normalizeBackupHostname(r.SourceHostname)
+			r.TargetHostname = normalizeBackupHostname(r.TargetHostname)
+			if source != nil {
+				source.relations = append(source.relations, r)
+			}
+			if target != nil {
+				target.relations = append(target.relations, r)
+			}
+			return nil
+		})
+}
+
+// readBackupCertificateRequesters attaches who requested each certificate of a
+// backup. Backups older than schema v27 have no requesters.
+func readBackupCertificateRequesters(backupDB *sql.DB, certs map[string]*backupCert) error {
+	return readBackupTable(backupDB, "certificate_requesters", `
+		SELECT hostname, requester_name, requester_email, team, justification, recorded_at
+		FROM certificate_requesters`,
+		func(rows *sql.Rows) error {
+			var r dbsqlc.CertificateRequester
+			if err := rows.Scan(&r.Hostname, &r.RequesterName, &r.RequesterEmail, &r.Team, &r.Justification, &r.RecordedAt); err != nil {
+				return err
+			}
+			if c, ok := certs[r.Hostname]; ok {
+				c.requester = &r
+			}
+			return nil
+		})
+}
+
+// readBackupDeploymentTargets attaches where each certificate of a backup is
+// deployed. Backups older than schema v28 have no deployment targets.
+func readBackupDeploymentTargets(backupDB *sql.DB, certs map[string]*backupCert) error {
+	return readBackupTable(backupDB, "deployment_targets", `
+		SELECT id, hostname, name, location, deployed_at
+		FROM deployment_targets
+		ORDER BY name`,
+		func(rows *sql.Rows) error {
+			var d dbsqlc.DeploymentTarget
+			if err := rows.Scan(&d.ID, &d.Hostname, &d.Name, &d.Location, &d.DeployedAt); err != nil {
+				return err
+			}
+			if c, ok := certs[d.Hostname]; ok {
+				c.deployments = append(c.deployments, d)
+			}
+			return nil
+		})
+}
+
+// backupCertificateTable is how certificate import and merge-restore carry a
+// table holding rows of a certificate: read attaches the backup's rows to the
+// certificates being imported, keyed by their hostname in the backup, and write
+// stores them once the certificate row is stored. A table that is not carried
+// has no hooks and gives the reason in skip.
+type backupCertificateTable struct {
+	table string
+	read  func(backupDB *sql.DB, certs map[string]*backupCert) error
+	write func(ctx context.Context, q *dbsqlc.Queries, cert backupCert) error
+	skip  string
+}
+
+// backupCertificateTables lists every table with a foreign key to certificates
+// and how import and merge-restore carry it, so a table added later cannot be
+// dropped from imports unnoticed; TestBackupCertificateTables_CoverSchema
+// enforces the registration.
+var backupCertificateTables = []backupCertificateTable{
+	{table: "certificate_tags", read: readBackupCertificateTags, write: addBackupCertificateTags},
+	{table: "renewal_checklist", read: readBackupRenewalChecklist, write: addBackupRenewalChecklist},
+	{table: "chain_overrides", read: readBackupChainOverrides, write: addBackupChainOverride},
+	{table: "certificate_relations", read: readBackupCertificateRelations, write: addBackupCertificateRelations},
+	{table: "certificate_requesters", read: readBackupCertificateRequesters, write: addBackupCertificateRequester},
+	{table: "deployment_targets", read: readBackupDeploymentTargets, write: addBackupDeploymentTargets},
+	{table: "certificate_history", skip: "the import or merge logs its own event; earlier history stays in the backup"},
+	{table: "sync_agent_hostnames", skip: "sync agents are enrolled per installation and are not imported"},
+	{table: "certificate_note_references", skip: "rebuilt from the imported notes"},
+	{table: "note_references_pending", skip: "filled by triggers when a certificate is stored"},
+	{table: "certificate_metadata_pending", skip: "filled by triggers when a certificate is stored"},
+}
+
+// addBackupCertificateRecords writes the rows a backup held about an imported
+// certificate in every table of backupCertificateTables.
+func addBackupCertificateRecords(ctx context.Context, q *dbsqlc.Queries, cert backupCert) error {
+	for _, t := range backupCertificateTables {
+		if t.write == nil {
+			continue
+		}
+		if err := t.write(ctx, q, cert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addBackupCertificateTags tags an imported certificate with the tags it had in
+// the backup, skipping any that are not valid tags
+func addBackupCertificateTags(ctx context.Context, q *dbsqlc.Queries, cert backupCert) error {
+	for _, tag := range cert.tags {
+		tag, err := services.NormalizeTag(tag)
+		if err != nil {
+			continue
+		}
+		if err := q.AddCertificateTag(ctx, dbsqlc.AddCertificateTagParams{
+			Hostname: cert.hostname,
+			Tag:      tag,
+		}); err != nil {
+			return fmt.Errorf("failed to tag %s: %w", cert.hostname, err)
+		}
+	}
+	return nil
+}
+
+// addBackupRenewalChecklist restores the renewal steps an imported certificate
+// had completed in the backup, with their completion time and actor
+func addBackupRenewalChecklist(ctx context.Context, q *dbsqlc.Queries, cert backupCert) error {
+	for _, step := range cert.checklist {
+		if err := q.RestoreRenewalStep(ctx, dbsqlc.RestoreRenewalStepParams{
+			Hostname:    cert.hostname,
+			Step:        step.Step,
+			CompletedAt: step.CompletedAt,
+			Actor:       step.Actor,
+		}); err != nil {
+			return fmt.Errorf("failed to restore renewal checklist of %s: %w", cert.hostname, err)
+		}
+	}
+	return nil
+}
+
+// addBackupChainOverride restores the chain override an imported certificate
+// had in the backup
+func addBackupChainOverride(ctx context.Context, q *dbsqlc.Queries, cert backupCert) error {
+	if cert.chainOverride == nil {
+		return nil
+	}
+	if err := q.UpsertCertificateChainOverride(ctx, dbsqlc.UpsertCertificateChainOverrideParams{
+		Hostname:  sql.NullString{String: cert.hostname, Valid: true},
+		IssuerUrl: cert.chainOverride.IssuerUrl,
+		IssuerPem: cert.chainOverride.IssuerPem,
+	}); err != nil {
+		return fmt.Errorf("failed to restore chain override of %s: %w", cert.hostname, err)
+	}
+	return nil
+}
+
+// addBackupCertificateRelations restores the relations of an imported
+// certificate whose other certificate is already stored. A relation between two
+// imported certificates is restored with the second of them.
+func addBackupCertificateRelations(ctx context.Context, q *dbsqlc.Queries, cert backupCert) error {
+	backupHostname := cert.hostname
+	if cert.originalHostname != "" {
+		backupHostname = cert.originalHostname
+	}
+	for _, r := range cert.relations {
+		other := r.TargetHostname
+		if r.SourceHostname == backupHostname {
+			r.SourceHostname = cert.hostname
+		} else {
+			r.TargetHostname = cert.hostname
+			other = r.SourceHostname
+		}
+		if other == cert.hostname {
+			continue
+		}
+		exists, err := q.CertificateExists(ctx, other)
+		if err != nil {
+			return fmt.Errorf("failed to check certificate existence for %s: %w", other, err)
+		}
+		if exists == 0 {
+			continue
+		}
+		if err := q.UpsertCertificateRelation(ctx, dbsqlc.UpsertCertificateRelationParams{
+			SourceHostname: r.SourceHostname,
+			TargetHostname: r.TargetHostname,
+			RelationType:   r.RelationType,
+			Label:          r.Label,
+		}); err != nil {
+			return fmt.Errorf("failed to restore relations of %s: %w", cert.hostname, err)
+		}
+	}
+	return nil
+}
+
+// addBackupCertificateRequester restores who requested an imported certificate
+func addBackupCertificateRequester(ctx context.Context, q *dbsqlc.Queries, cert backupCert) error {
+	if cert.requester == nil {
+		return nil
+	}
+	if err := q.UpsertCertificateRequester(ctx, dbsqlc.UpsertCertificateRequesterParams{
+		Hostname:       cert.hostname,
+		RequesterName:  cert.requester.RequesterName,
+		RequesterEmail: cert.requester.RequesterEmail,
+		Team:           cert.requester.Team,
+		Justification:  cert.requester.Justification,
+		RecordedAt:     cert.requester.RecordedAt,
+	}); err != nil {
+		return fmt.Errorf("failed to restore requester of %s: %w", cert.hostname, err)
+	}
+	return nil
+}
+
+// addBackupDeploymentTargets restores where an imported certificate is deployed
+func addBackupDeploymentTargets(ctx context.Context, q *dbsqlc.Queries, cert backupCert) error {
+	for _, d := range cert.deployments {
+		if err := q.UpsertDeploymentTarget(ctx, dbsqlc.UpsertDeploymentTargetParams{
+			Hostname:   cert.hostname,
+			Name:       d.Name,
+			Location:   d.Location,
+			DeployedAt: d.DeployedAt,
+		}); err != nil {
+			return fmt.Errorf("failed to restore deployment targets of %s: %w", cert.hostname, err)
+		}
+	}
+	return nil
+}
+
+// backupCertificateColumn returns column when the backup's certificates table
+// has it, and NULL otherwise so older backups can still be read
+func backupCertificateColumn(backupDB *sql.DB, column string) string {
+	var exists int
+	if err := backupDB.QueryRow(
+		"SELECT COUNT(*) FROM pragma_table_info('certificates') WHERE name = ?", column,
+	).Scan(&exists); err == nil && exists > 0 {
+		return column
+	}
+	return "NULL"
+}
+
+// importBackupCertificate re-encrypts a backup certificate's keys from the backup's
+// master key to the current one and inserts it, preserving its original created_at.
+// Returns false without error when the hostname already exists.
+func importBackupCertificate(ctx context.Context, q *dbsqlc.Queries, cert backupCert, backupMasterKey, currentMasterKey []byte) (bool, error) {
+	// Check for hostname conflicts
+	exists, err := q.CertificateExists(ctx, cert.hostname)
+	if err != nil {
+		return false, fmt.Errorf("failed to check certificate existence for %s: %w", cert.hostname, err)
+	}
+	if exists == 1 {
+		return false, nil
+	}
+
+	// Re-encrypt keys: decrypt with backup master key, encrypt with current master key
+	var newEncryptedKey []byte
+	if len(cert.encryptedKey) > 0 {
+		plaintext, err := crypto.DecryptPrivateKey(cert.encryptedKey, backupMasterKey)
+		if err != nil {
+			return false, fmt.Errorf("failed to decrypt private key for %s: %w", cert.hostname, err)
+		}
+		newEncryptedKey, err = crypto.EncryptPrivateKey(plaintext.Bytes(), currentMasterKey)
+		plaintext.Destroy()
+		if err != nil {
+			return false, fmt.Errorf("failed to re-encrypt private key for %s: %w", cert.hostname, err)
+		}
+	}
+
+	var newPendingEncryptedKey []byte
+	if len(cert.pendingEncryptedKey) > 0 {
+		plaintext, err := crypto.DecryptPrivateKey(cert.pendingEncryptedKey, backupMasterKey)
+		if err != nil {
+			return false, fmt.Errorf("failed to decrypt pending private key for %s: %w", cert.hostname, err)
+		}
+		newPendingEncryptedKey, err = crypto.EncryptPrivateKey(plaintext.Bytes(), currentMasterKey)
+		plaintext.Destroy()
+		if err != nil {
+			return false, fmt.Errorf("failed to re-encrypt pending private key for %s: %w", cert.hostname, err)
+		}
+	}
+
+	if err := q.ImportCertificate(ctx, dbsqlc.ImportCertificateParams{
+		Hostname:                   cert.hostname,
+		EncryptedPrivateKey:        newEncryptedKey,
+		PendingCsrPem:              cert.pendingCSR,
+		PendingEncryptedPrivateKey: newPendingEncryptedKey,
+		CertificatePem:             cert.certificatePEM,
+		CreatedAt:                  cert.createdAt,
+		ExpiresAt:                  cert.expiresAt,
+		Note:                       cert.note,
+		PendingNote:                cert.pendingNote,
+		ReadOnly:                   cert.readOnly,
+		ChainPem:                   cert.chainPEM,
+		CaReference:                cert.caReference,
+		SubmittedAt:                cert.submittedAt,
+	}); err != nil {
+		return false, fmt.Errorf("failed to insert certificate %s: %w", cert.hostname, err)
+	}
+	if err := addBackupCertificateRecords(ctx, q, cert); err != nil {
+		return false, err
+	}
+	if err := services.RefreshKeyStatusTx(ctx, q, cert.hostname, currentMasterKey); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// validateBackupCertificate checks that a backup certificate can be imported: its
+// hostname is valid, its PEM data parses, and its private keys decrypt with the
+// backup's master key.
+func validateBackupCertificate(c backupCert, backupMasterKey []byte) error {
+	if _, err := hostnames.Normalize(c.hostname); err != nil {
+		return err
+	}
+
+	if c.certificatePEM.Valid && c.certificatePEM.String != "" {
+		if _, err := crypto.ParseCertificate([]byte(c.certificatePEM.String)); err != nil {
+			return fmt.Errorf("invalid certificate: %w", err)
+		}
+	}
+
+	if c.pendingCSR.Valid && c.pendingCSR.String != "" {
+		if _, err := crypto.ParseCSR([]byte(c.pendingCSR.String)); err != nil {
+			return fmt.Errorf("invalid pending CSR: %w", err)
+		}
+	}
+
+	if len(c.encryptedKey) > 0 {
+		if err := checkBackupKeyDecrypts(c.encryptedKey, backupMasterKey); err != nil {
+			return fmt.Errorf("private key: %w", err)
+		}
+	}
+
+	if len(c.pendingEncryptedKey) > 0 {
+		if err := checkBackupKeyDecrypts(c.pendingEncryptedKey, backupMasterKey); err != nil {
+			return fmt.Errorf("pending private key: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// checkBackupKeyDecrypts decrypts an encrypted key and verifies the plaintext is a
+// parseable private key. The plaintext is wiped before returning.
+func checkBackupKeyDecrypts(encryptedKey []byte, backupMasterKey []byte) error {
+	plaintext, err := crypto.DecryptPrivateKey(encryptedKey, backupMasterKey)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt: %w", err)
+	}
+	defer plaintext.Destroy()
+
+	if _, err := crypto.ParsePrivateKeyFromPEM(plaintext.Bytes()); err != nil {
+		return fmt.Errorf("failed to parse: %w", err)
+	}
+	return nil
 }
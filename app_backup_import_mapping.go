@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	dbsqlc "paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/hostnames"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/services"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// maxHostnameMappingFileSize caps a mapping file; a few thousand lines of
+// "old,new" fit well below it
+const maxHostnameMappingFileSize = 1 << 20
+
+// ============================================================================
+// Hostname Mapping for Backup Import
+// ============================================================================
+
+// SelectHostnameMappingFile opens a file dialog for the user to select a
+// hostname mapping file. Returns the selected file path, or empty string if
+// cancelled.
+func (a *App) SelectHostnameMappingFile() (string, error) {
+	path, err := wailsruntime.OpenFileDialog(a.ctx, wailsruntime.OpenDialogOptions{
+		Title: "Select Hostname Mapping File",
+		Filters: []wailsruntime.FileFilter{
+			{DisplayName: "Mapping Files (*.csv, *.txt)", Pattern: "*.csv;*.txt"},
+			{DisplayName: "All Files (*.*)", Pattern: "*.*"},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("file dialog error: %w", err)
+	}
+	return path, nil
+}
+
+// ReadHostnameMappingFile reads a mapping file of "old,new" lines (CSV, with an
+// optional header row and # comments) and returns it as old → new hostnames,
+// normalized, for CertImportOptions.HostnameMapping.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) ReadHostnameMappingFile(path string) (map[string]string, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("reading hostname mapping file", slog.String("path", path))
+
+	if path == "" {
+		return nil, fmt.Errorf("mapping file path is empty")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mapping file: %w", err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxHostnameMappingFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mapping file: %w", err)
+	}
+	if len(data) > maxHostnameMappingFileSize {
+		return nil, fmt.Errorf("mapping file is larger than %d KiB", maxHostnameMappingFileSize>>10)
+	}
+
+	mapping, err := parseHostnameMapping(data)
+	if err != nil {
+		log.Warn("hostname mapping file refused", slog.String("path", path), logger.Err(err))
+		return nil, err
+	}
+	return mapping, nil
+}
+
+// parseHostnameMapping parses "old,new" CSV lines. Blank lines and lines
+// starting with # are skipped, as is a header on the first row.
+func parseHostnameMapping(data []byte) (map[string]string, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+
+	mapping := map[string]string{}
+	for first := true; ; first = false {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid mapping file: %w", err)
+		}
+		line, _ := r.FieldPos(0)
+		if len(record) != 2 {
+			return nil, fmt.Errorf("line %d: expected \"old,new\", got %d fields", line, len(record))
+		}
+
+		if first && isHostnameMappingHeader(record) {
+			continue
+		}
+
+		oldHostname, oldErr := hostnames.Normalize(strings.TrimSpace(record[0]))
+		newHostname, newErr := hostnames.Normalize(strings.TrimSpace(record[1]))
+		if oldErr != nil {
+			return nil, fmt.Errorf("line %d: %w", line, oldErr)
+		}
+		if newErr != nil {
+			return nil, fmt.Errorf("line %d: %w", line, newErr)
+		}
+		if _, dup := mapping[oldHostname]; dup {
+			return nil, fmt.Errorf("line %d: %s is mapped more than once", line, oldHostname)
+		}
+		mapping[oldHostname] = newHostname
+	}
+
+	if len(mapping) == 0 {
+		return nil, fmt.Errorf("mapping file has no \"old,new\" lines")
+	}
+	return mapping, nil
+}
+
+// hostnameMappingHeaders are the column names accepted on a header row
+var hostnameMappingHeaders = map[string]bool{
+	"old": true, "new": true,
+	"from": true, "to": true,
+	"source": true, "target": true,
+	"old_hostname": true, "new_hostname": true,
+	"hostname": true,
+}
+
+// isHostnameMappingHeader reports whether a first row names its columns
+// instead of mapping hostnames. Single-label names like "old" are valid
+// hostnames, so a header is recognised by its column names or by fields
+// that are not hostnames at all (e.g. "Old Hostname").
+func isHostnameMappingHeader(record []string) bool {
+	for _, field := range record {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if hostnameMappingHeaders[field] {
+			continue
+		}
+		if _, err := hostnames.Normalize(field); err == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// applyHostnameMapping renames backup entries in place according to mapping
+// (old → new hostname), remembering each original name. It fails when a
+// source is not in the backup, or when two entries would end up under the
+// same hostname, so a typo never silently drops or merges an entry.
+func applyHostnameMapping(certs []backupCert, mapping map[string]string) error {
+	if len(mapping) == 0 {
+		return nil
+	}
+
+	normalized := make(map[string]string, len(mapping))
+	for oldHostname, newHostname := range mapping {
+		from, err := hostnames.Normalize(oldHostname)
+		if err != nil {
+			return fmt.Errorf("invalid hostname mapping source %q: %w", oldHostname, err)
+		}
+		to, err := hostnames.Normalize(newHostname)
+		if err != nil {
+			return fmt.Errorf("invalid hostname mapping target for %s: %w", from, err)
+		}
+		if _, dup := normalized[from]; dup {
+			return fmt.Errorf("%s is mapped more than once", from)
+		}
+		normalized[from] = to
+	}
+
+	found := make(map[string]bool, len(normalized))
+	for i := range certs {
+		if _, ok := normalized[certs[i].hostname]; ok {
+			found[certs[i].hostname] = true
+		}
+	}
+	for from := range normalized {
+		if !found[from] {
+			return fmt.Errorf("hostname mapping source %s is not in the backup", from)
+		}
+	}
+
+	owner := make(map[string]string, len(certs))
+	for i := range certs {
+		c := &certs[i]
+		if to, ok := normalized[c.hostname]; ok && to != c.hostname {
+			c.originalHostname = c.hostname
+			c.hostname = to
+		}
+		source := c.hostname
+		if c.originalHostname != "" {
+			source = c.originalHostname
+		}
+		if other, taken := owner[c.hostname]; taken {
+			return fmt.Errorf("%s and %s would both be imported as %s", other, source, c.hostname)
+		}
+		owner[c.hostname] = source
+	}
+	return nil
+}
+
+// renamedImportNote returns the entry's note with a line recording the
+// hostname it had in the backup
+func renamedImportNote(cert backupCert) sql.NullString {
+	line := "Imported from backup as " + cert.originalHostname
+	if !cert.note.Valid || cert.note.String == "" {
+		return sql.NullString{String: line, Valid: true}
+	}
+	return sql.NullString{String: cert.note.String + "\n\n" + line, Valid: true}
+}
+
+// logRenamedImport records, in the imported certificate's history, the
+// hostname it had in the backup
+func logRenamedImport(ctx context.Context, q *dbsqlc.Queries, history *services.HistoryService, cert backupCert) error {
+	message := fmt.Sprintf("Imported from backup, renamed from %s", cert.originalHostname)
+	details := map[string]any{"original_hostname": cert.originalHostname}
+	if err := history.LogEventDetailsTx(ctx, q, cert.hostname, models.EventCertificateImported, message, details); err != nil {
+		return fmt.Errorf("failed to log history for %s: %w", cert.hostname, err)
+	}
+	return nil
+}
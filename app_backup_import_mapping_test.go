@@ -0,0 +1,113 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"paddockcontrol-desktop/internal/models"
+)
+
+func TestParseHostnameMapping(t *testing.T) {
+	data := []byte("old,new\n# consolidation\nWeb01.Old.LAN, web01.new.lan\n\napi.old.lan,api.new.lan\n")
+
+	mapping, err := parseHostnameMapping(data)
+	if err != nil {
+		t.Fatalf("parseHostnameMapping() error: %v", err)
+	}
+	want := map[string]string{
+		"web01.old.lan": "web01.new.lan",
+		"api.old.lan":   "api.new.lan",
+	}
+	if len(mapping) != len(want) {
+		t.Fatalf("mapping = %v, want %v", mapping, want)
+	}
+	for from, to := range want {
+		if mapping[from] != to {
+			t.Errorf("mapping[%s] = %q, want %q", from, mapping[from], to)
+		}
+	}
+
+	for name, bad := range map[string]string{
+		"three fields": "a.lan,b.lan,c.lan\n",
+		"duplicate":    "a.lan,b.lan\na.lan,c.lan\n",
+		"empty target": "a.lan,b.lan\nc.lan,\n",
+		"empty":        "# nothing\n",
+	} {
+		if _, err := parseHostnameMapping([]byte(bad)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestImportCertificates_HostnameMapping(t *testing.T) {
+	backupPath, _ := createTestBackupDB(t, testBackupDBOpts{
+		hostnames: []string{"web01.old.lan", "web02.old.lan"},
+		password:  testPassword,
+	})
+
+	app := setupUnlockedApp(t)
+
+	result, err := app.ImportCertificatesFromBackup(backupPath, testPassword, models.CertImportOptions{
+		HostnameMapping: map[string]string{"web01.old.lan": "web01.new.lan"},
+	}, false)
+	if err != nil {
+		t.Fatalf("ImportCertificatesFromBackup() error: %v", err)
+	}
+	if result.Imported != 2 {
+		t.Fatalf("expected 2 imported, got %d", result.Imported)
+	}
+	want := models.CertRename{OriginalHostname: "web01.old.lan", Hostname: "web01.new.lan"}
+	if len(result.Renamed) != 1 || result.Renamed[0] != want {
+		t.Fatalf("Renamed = %+v, want [%+v]", result.Renamed, want)
+	}
+
+	for hostname, wantExists := range map[string]int64{
+		"web01.new.lan": 1,
+		"web01.old.lan": 0,
+		"web02.old.lan": 1,
+	} {
+		if exists, _ := app.db.Queries().CertificateExists(app.ctx, hostname); exists != wantExists {
+			t.Errorf("CertificateExists(%s) = %d, want %d", hostname, exists, wantExists)
+		}
+	}
+
+	cert, err := app.db.Queries().GetCertificateByHostname(app.ctx, "web01.new.lan")
+	if err != nil {
+		t.Fatalf("GetCertificateByHostname() error: %v", err)
+	}
+	if !strings.Contains(cert.Note.String, "web01.old.lan") {
+		t.Errorf("expected the note to record the original hostname, got %q", cert.Note.String)
+	}
+
+	history, err := app.GetCertificateHistory("web01.new.lan", 10)
+	if err != nil {
+		t.Fatalf("GetCertificateHistory() error: %v", err)
+	}
+	if len(history) == 0 || history[0].EventType != models.EventCertificateImported {
+		t.Errorf("expected a %s history entry, got %+v", models.EventCertificateImported, history)
+	}
+}
+
+func TestImportCertificates_HostnameMappingRejectsCollisions(t *testing.T) {
+	backupPath, _ := createTestBackupDB(t, testBackupDBOpts{
+		hostnames: []string{"web01.old.lan", "web02.old.lan"},
+		password:  testPassword,
+	})
+
+	app := setupUnlockedApp(t)
+
+	for name, mapping := range map[string]map[string]string{
+		"onto another entry": {"web01.old.lan": "web02.old.lan"},
+		"unknown source":     {"web03.old.lan": "web03.new.lan"},
+	} {
+		if _, err := app.ImportCertificatesFromBackup(backupPath, testPassword, models.CertImportOptions{
+			HostnameMapping: mapping,
+		}, false); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if exists, _ := app.db.Queries().CertificateExists(app.ctx, "web01.old.lan"); exists != 0 {
+		t.Error("a rejected mapping should not import anything")
+	}
+}
@@ -69,6 +69,7 @@ func createTestBackupDB(t *testing.T, opts testBackupDBOpts) (string, []byte) {
 		DefaultCountry:      "FR",
 		DefaultKeySize:      2048,
 		ValidityPeriodDays:  365,
+		KdfProfile:          crypto.KDFProfileStandard,
 	})
 	if err != nil {
 		t.Fatalf("failed to create config: %v", err)
@@ -207,6 +208,7 @@ func setupFileBasedApp(t *testing.T) (*App, string) {
 		DefaultCountry:      "FR",
 		DefaultKeySize:      2048,
 		ValidityPeriodDays:  365,
+		KdfProfile:          crypto.KDFProfileStandard,
 	})
 	if err != nil {
 		t.Fatalf("failed to create config: %v", err)
@@ -224,6 +226,14 @@ func setupFileBasedApp(t *testing.T) (*App, string) {
 	if !result.Valid {
 		t.Fatal("expected valid key validation")
 	}
+	// Stop the migration before the database closes. Referencing app here also
+	// keeps its master key reachable for the whole test: tests pass
+	// app.masterKey.Bytes() around, and a collected SecretBuffer wipes its memory.
+	t.Cleanup(func() {
+		app.mu.Lock()
+		app.stopKeyEnvelopeMigration()
+		app.mu.Unlock()
+	})
 
 	return app, tmpDir
 }
@@ -242,8 +252,8 @@ func TestGetBackupSchemaVersion_V4(t *testing.T) {
 	defer backupDB.Close()
 
 	version, dirty := getBackupSchemaVersion(backupDB)
-	if version != 4 {
-		t.Fatalf("expected schema version 4, got %d", version)
+	if version != currentSchemaVersion {
+		t.Fatalf("expected schema version %d, got %d", currentSchemaVersion, version)
 	}
 	if dirty {
 		t.Fatal("expected dirty=false")
@@ -296,8 +306,11 @@ func TestPeekBackupInfo_V4Backup(t *testing.T) {
 	if !info.HasSecurityKeys {
 		t.Fatal("expected has_security_keys=true")
 	}
-	if info.SchemaVersion != 4 {
-		t.Fatalf("expected schema_version=4, got %d", info.SchemaVersion)
+	if len(info.UnlockMethods) != 1 || info.UnlockMethods[0].Label != "Test Password" || info.UnlockMethods[0].Method != models.SecurityKeyMethodPassword {
+		t.Fatalf("unexpected unlock methods %+v", info.UnlockMethods)
+	}
+	if info.SchemaVersion != currentSchemaVersion {
+		t.Fatalf("expected schema_version=%d, got %d", currentSchemaVersion, info.SchemaVersion)
 	}
 
 	// Hostnames should be sorted
@@ -312,6 +325,25 @@ func TestPeekBackupInfo_V4Backup(t *testing.T) {
 	}
 }
 
+func TestPeekBackupInfo_SuggestsHostnameSuffix(t *testing.T) {
+	backupPath, _ := createTestBackupDB(t, testBackupDBOpts{
+		hostnames: []string{"web.corp.test", "db.corp.test"},
+		password:  testPassword,
+	})
+
+	app := setupTestApp(t)
+	info, err := app.PeekBackupInfo(backupPath)
+	if err != nil {
+		t.Fatalf("PeekBackupInfo() error: %v", err)
+	}
+	if info.HostnameSuffix != ".example.com" {
+		t.Errorf("expected the backup's suffix .example.com, got %q", info.HostnameSuffix)
+	}
+	if info.SuggestedHostnameSuffix != ".corp.test" {
+		t.Errorf("expected .corp.test to be suggested, got %q", info.SuggestedHostnameSuffix)
+	}
+}
+
 func TestPeekBackupInfo_PreV4Backup(t *testing.T) {
 	backupPath, _ := createTestBackupDB(t, testBackupDBOpts{
 		certCount:     2,
@@ -330,6 +362,9 @@ func TestPeekBackupInfo_PreV4Backup(t *testing.T) {
 	if info.HasSecurityKeys {
 		t.Fatal("expected has_security_keys=false for pre-v4 backup")
 	}
+	if info.UnlockMethods == nil || len(info.UnlockMethods) != 0 {
+		t.Fatalf("expected no unlock methods, got %+v", info.UnlockMethods)
+	}
 	if info.CertificateCount != 2 {
 		t.Fatalf("expected 2 certificates, got %d", info.CertificateCount)
 	}
@@ -356,8 +391,8 @@ func TestPeekBackupInfo_EmptyBackup(t *testing.T) {
 	if len(info.Hostnames) != 0 {
 		t.Fatalf("expected empty hostnames, got %d", len(info.Hostnames))
 	}
-	if info.SchemaVersion != 4 {
-		t.Fatalf("expected schema_version=4, got %d", info.SchemaVersion)
+	if info.SchemaVersion != currentSchemaVersion {
+		t.Fatalf("expected schema_version=%d, got %d", currentSchemaVersion, info.SchemaVersion)
 	}
 }
 
@@ -370,6 +405,41 @@ func TestPeekBackupInfo_InvalidPath(t *testing.T) {
 	}
 }
 
+func TestTestBackupPassword(t *testing.T) {
+	app := setupUnlockedApp(t)
+	backupPath, _ := createTestBackupDB(t, testBackupDBOpts{
+		certCount: 1,
+		password:  "historical-backup-password",
+		masterKey: app.masterKey.Bytes(),
+	})
+
+	check, err := app.TestBackupPassword(backupPath, "wrong-password-for-backup")
+	if err != nil {
+		t.Fatalf("TestBackupPassword() error: %v", err)
+	}
+	if check.Valid {
+		t.Fatal("expected a wrong password to be invalid")
+	}
+
+	check, err = app.TestBackupPassword(backupPath, "historical-backup-password")
+	if err != nil {
+		t.Fatalf("TestBackupPassword() error: %v", err)
+	}
+	if !check.Valid || check.Label != "Test Password" || !check.SameMasterKey {
+		t.Fatalf("unexpected check %+v", check)
+	}
+
+	// Another master key, and no password method at all
+	otherPath, _ := createTestBackupDB(t, testBackupDBOpts{certCount: 1, password: testPassword})
+	if check, err := app.TestBackupPassword(otherPath, testPassword); err != nil || !check.Valid || check.SameMasterKey {
+		t.Fatalf("TestBackupPassword() = %+v, %v", check, err)
+	}
+	barePath, _ := createTestBackupDB(t, testBackupDBOpts{certCount: 1})
+	if _, err := app.TestBackupPassword(barePath, testPassword); err == nil {
+		t.Fatal("expected an error for a backup without password methods")
+	}
+}
+
 // ============================================================================
 // ImportCertificatesFromBackup
 // ============================================================================
@@ -382,7 +452,7 @@ func TestImportCertificates_Success(t *testing.T) {
 
 	app := setupUnlockedApp(t)
 
-	result, err := app.ImportCertificatesFromBackup(backupPath, testPassword)
+	result, err := app.ImportCertificatesFromBackup(backupPath, testPassword, models.CertImportOptions{}, false)
 	if err != nil {
 		t.Fatalf("ImportCertificatesFromBackup() error: %v", err)
 	}
@@ -409,6 +479,64 @@ func TestImportCertificates_Success(t *testing.T) {
 	}
 }
 
+func TestImportCertificates_DryRunWritesNothing(t *testing.T) {
+	backupPath, _ := createTestBackupDB(t, testBackupDBOpts{
+		hostnames: []string{"dry1.example.com", "dry2.example.com"},
+		password:  testPassword,
+	})
+
+	app := setupUnlockedApp(t)
+
+	for _, opts := range []models.CertImportOptions{{}, {BestEffort: true}} {
+		result, err := app.ImportCertificatesFromBackup(backupPath, testPassword, opts, true)
+		if err != nil {
+			t.Fatalf("ImportCertificatesFromBackup() error: %v", err)
+		}
+		if !result.DryRun || result.Imported != 2 {
+			t.Fatalf("best_effort=%v: result = %+v, want 2 would-be imports", opts.BestEffort, result)
+		}
+		if n, err := app.db.Queries().CountCertificates(app.ctx); err != nil || n != 0 {
+			t.Fatalf("best_effort=%v: certificates = %d (%v), want none after a dry run", opts.BestEffort, n, err)
+		}
+	}
+
+	// The password is still checked
+	if _, err := app.ImportCertificatesFromBackup(backupPath, "wrong-password-at-least-16", models.CertImportOptions{}, true); err == nil {
+		t.Fatal("expected a dry run with the wrong password to fail")
+	}
+}
+
+func TestImportCertificates_SuggestsHostnameSuffix(t *testing.T) {
+	backupPath, _ := createTestBackupDB(t, testBackupDBOpts{
+		hostnames: []string{"web.corp.test", "db.corp.test"},
+		password:  testPassword,
+	})
+
+	app := setupUnlockedApp(t)
+
+	result, err := app.ImportCertificatesFromBackup(backupPath, testPassword, models.CertImportOptions{}, false)
+	if err != nil {
+		t.Fatalf("ImportCertificatesFromBackup() error: %v", err)
+	}
+	if result.SuggestedHostnameSuffix != ".corp.test" {
+		t.Fatalf("expected .corp.test to be suggested, got %q", result.SuggestedHostnameSuffix)
+	}
+
+	if err := app.SetHostnameSuffix("corp.test"); err == nil {
+		t.Fatal("expected a suffix without a leading dot to be rejected")
+	}
+	if err := app.SetHostnameSuffix(result.SuggestedHostnameSuffix); err != nil {
+		t.Fatalf("SetHostnameSuffix() error: %v", err)
+	}
+	cfg, err := app.GetConfig()
+	if err != nil {
+		t.Fatalf("GetConfig() error: %v", err)
+	}
+	if cfg.HostnameSuffix != ".corp.test" {
+		t.Fatalf("expected hostname suffix .corp.test, got %q", cfg.HostnameSuffix)
+	}
+}
+
 func TestImportCertificates_RollsBackOnFailure(t *testing.T) {
 	// Certs are inserted in slice (rowid) order; corrupt the LAST one so the
 	// first two are inserted within the transaction before the failure.
@@ -430,7 +558,7 @@ func TestImportCertificates_RollsBackOnFailure(t *testing.T) {
 
 	app := setupUnlockedApp(t)
 
-	if _, err := app.ImportCertificatesFromBackup(backupPath, testPassword); err == nil {
+	if _, err := app.ImportCertificatesFromBackup(backupPath, testPassword, models.CertImportOptions{}, false); err == nil {
 		t.Fatal("expected import to fail on the corrupt certificate")
 	}
 
@@ -446,6 +574,54 @@ func TestImportCertificates_RollsBackOnFailure(t *testing.T) {
 	}
 }
 
+func TestImportCertificates_BestEffortReportsFailures(t *testing.T) {
+	backupPath, _ := createTestBackupDB(t, testBackupDBOpts{
+		hostnames: []string{"keep1.example.com", "corrupt.example.com", "keep2.example.com"},
+		password:  testPassword,
+	})
+
+	bdb, err := sql.Open("sqlite", backupPath)
+	if err != nil {
+		t.Fatalf("open backup: %v", err)
+	}
+	if _, err := bdb.Exec("UPDATE certificates SET encrypted_private_key = ? WHERE hostname = ?",
+		[]byte{0x00, 0x01, 0x02}, "corrupt.example.com"); err != nil {
+		t.Fatalf("corrupt key: %v", err)
+	}
+	bdb.Close()
+
+	app := setupUnlockedApp(t)
+
+	result, err := app.ImportCertificatesFromBackup(backupPath, testPassword, models.CertImportOptions{BestEffort: true}, false)
+	if err != nil {
+		t.Fatalf("ImportCertificatesFromBackup() error: %v", err)
+	}
+
+	if result.Imported != 2 {
+		t.Fatalf("expected 2 imported, got %d", result.Imported)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].Hostname != "corrupt.example.com" {
+		t.Fatalf("expected failure for corrupt.example.com, got %+v", result.Failed)
+	}
+	if result.Failed[0].Error == "" {
+		t.Fatal("expected failure to carry an error message")
+	}
+
+	for hostname, want := range map[string]int64{
+		"keep1.example.com":   1,
+		"keep2.example.com":   1,
+		"corrupt.example.com": 0,
+	} {
+		exists, err := app.db.Queries().CertificateExists(app.ctx, hostname)
+		if err != nil {
+			t.Fatalf("CertificateExists(%s): %v", hostname, err)
+		}
+		if exists != want {
+			t.Fatalf("CertificateExists(%s) = %d, want %d", hostname, exists, want)
+		}
+	}
+}
+
 func TestImportCertificates_ReEncryptsKeys(t *testing.T) {
 	backupPath, backupMasterKey := createTestBackupDB(t, testBackupDBOpts{
 		hostnames: []string{"reencrypt.example.com"},
@@ -454,7 +630,7 @@ func TestImportCertificates_ReEncryptsKeys(t *testing.T) {
 
 	app := setupUnlockedApp(t)
 
-	_, err := app.ImportCertificatesFromBackup(backupPath, testPassword)
+	_, err := app.ImportCertificatesFromBackup(backupPath, testPassword, models.CertImportOptions{}, false)
 	if err != nil {
 		t.Fatalf("ImportCertificatesFromBackup() error: %v", err)
 	}
@@ -466,7 +642,7 @@ func TestImportCertificates_ReEncryptsKeys(t *testing.T) {
 	}
 
 	// Should be decryptable with the destination's master key
-	_, err = crypto.DecryptPrivateKey(cert.EncryptedPrivateKey, app.masterKey)
+	_, err = crypto.DecryptPrivateKey(cert.EncryptedPrivateKey, app.masterKey.Bytes())
 	if err != nil {
 		t.Fatalf("failed to decrypt with destination master key: %v", err)
 	}
@@ -478,6 +654,190 @@ func TestImportCertificates_ReEncryptsKeys(t *testing.T) {
 	}
 }
 
+func TestImportCertificates_KeepsStoredChain(t *testing.T) {
+	backupPath, _ := createTestBackupDB(t, testBackupDBOpts{
+		hostnames: []string{"chain.example.com"},
+		password:  testPassword,
+	})
+
+	const chainPEM = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+	backupDB, err := sql.Open("sqlite", backupPath)
+	if err != nil {
+		t.Fatalf("failed to open backup: %v", err)
+	}
+	if _, err := backupDB.Exec("UPDATE certificates SET chain_pem = ? WHERE hostname = ?", chainPEM, "chain.example.com"); err != nil {
+		t.Fatalf("failed to store chain in backup: %v", err)
+	}
+	backupDB.Close()
+
+	app := setupUnlockedApp(t)
+
+	if _, err := app.ImportCertificatesFromBackup(backupPath, testPassword, models.CertImportOptions{}, false); err != nil {
+		t.Fatalf("ImportCertificatesFromBackup() error: %v", err)
+	}
+
+	cert, err := app.db.Queries().GetCertificateByHostname(app.ctx, "chain.example.com")
+	if err != nil {
+		t.Fatalf("GetCertificateByHostname() error: %v", err)
+	}
+	if cert.ChainPem.String != chainPEM {
+		t.Fatalf("expected stored chain to survive the import, got %q", cert.ChainPem.String)
+	}
+}
+
+func TestImportCertificates_KeepsCSRSubmission(t *testing.T) {
+	backupPath, _ := createTestBackupDB(t, testBackupDBOpts{
+		hostnames: []string{"submitted.example.com"},
+		password:  testPassword,
+	})
+
+	backupDB, err := sql.Open("sqlite", backupPath)
+	if err != nil {
+		t.Fatalf("failed to open backup: %v", err)
+	}
+	if _, err := backupDB.Exec(
+		"UPDATE certificates SET ca_reference = ?, submitted_at = ? WHERE hostname = ?",
+		"REQ-4224", 1700000000, "submitted.example.com",
+	); err != nil {
+		t.Fatalf("failed to store submission in backup: %v", err)
+	}
+	backupDB.Close()
+
+	app := setupUnlockedApp(t)
+
+	if _, err := app.ImportCertificatesFromBackup(backupPath, testPassword, models.CertImportOptions{}, false); err != nil {
+		t.Fatalf("ImportCertificatesFromBackup() error: %v", err)
+	}
+
+	cert, err := app.db.Queries().GetCertificateByHostname(app.ctx, "submitted.example.com")
+	if err != nil {
+		t.Fatalf("GetCertificateByHostname() error: %v", err)
+	}
+	if cert.CaReference.String != "REQ-4224" {
+		t.Fatalf("expected CA reference to survive the import, got %q", cert.CaReference.String)
+	}
+	if !cert.SubmittedAt.Valid || cert.SubmittedAt.Int64 != 1700000000 {
+		t.Fatalf("expected submission time to survive the import, got %v", cert.SubmittedAt)
+	}
+}
+
+func TestImportCertificates_KeepsCertificateRecords(t *testing.T) {
+	backupPath, _ := createTestBackupDB(t, testBackupDBOpts{
+		hostnames: []string{"client.example.com", "server.example.com"},
+		password:  testPassword,
+	})
+
+	backupDB, err := sql.Open("sqlite", backupPath)
+	if err != nil {
+		t.Fatalf("failed to open backup: %v", err)
+	}
+	for _, stmt := range []string{
+		`INSERT INTO certificate_requesters (hostname, requester_name, requester_email, team, justification, recorded_at)
+		 VALUES ('client.example.com', 'Alice', 'alice@example.com', 'Payments', 'mTLS to the API', 1700000000)`,
+		`INSERT INTO chain_overrides (hostname, issuer_url) VALUES ('client.example.com', 'http://pki.internal/issuer.crt')`,
+		`INSERT INTO renewal_checklist (hostname, step, completed_at, actor) VALUES ('client.example.com', 'csr_sent', 1700000100, 'bob')`,
+		`INSERT INTO certificate_relations (source_hostname, target_hostname, relation_type, label)
+		 VALUES ('client.example.com', 'server.example.com', 'client_of', 'api')`,
+		`INSERT INTO deployment_targets (hostname, name, location, deployed_at)
+		 VALUES ('server.example.com', 'lb-01', 'dc1', 1700000200)`,
+	} {
+		if _, err := backupDB.Exec(stmt); err != nil {
+			t.Fatalf("failed to fill backup: %v", err)
+		}
+	}
+	backupDB.Close()
+
+	app := setupUnlockedApp(t)
+
+	if _, err := app.ImportCertificatesFromBackup(backupPath, testPassword, models.CertImportOptions{}, false); err != nil {
+		t.Fatalf("ImportCertificatesFromBackup() error: %v", err)
+	}
+
+	q := app.db.Queries()
+	requester, err := q.GetCertificateRequester(app.ctx, "client.example.com")
+	if err != nil {
+		t.Fatalf("expected requester to survive the import: %v", err)
+	}
+	if requester.RequesterName != "Alice" || requester.Team != "Payments" || requester.RecordedAt != 1700000000 {
+		t.Errorf("requester = %+v", requester)
+	}
+
+	overrides, err := q.ListChainOverrides(app.ctx)
+	if err != nil {
+		t.Fatalf("ListChainOverrides() error: %v", err)
+	}
+	if len(overrides) != 1 || overrides[0].Hostname.String != "client.example.com" ||
+		overrides[0].IssuerUrl != "http://pki.internal/issuer.crt" {
+		t.Errorf("expected chain override to survive the import, got %+v", overrides)
+	}
+
+	checklist, err := q.ListRenewalChecklist(app.ctx, "client.example.com")
+	if err != nil {
+		t.Fatalf("ListRenewalChecklist() error: %v", err)
+	}
+	if len(checklist) != 1 || checklist[0].Step != "csr_sent" ||
+		checklist[0].CompletedAt != 1700000100 || checklist[0].Actor != "bob" {
+		t.Errorf("expected renewal checklist to survive the import, got %+v", checklist)
+	}
+
+	relations, err := q.ListCertificateRelations(app.ctx)
+	if err != nil {
+		t.Fatalf("ListCertificateRelations() error: %v", err)
+	}
+	if len(relations) != 1 || relations[0].SourceHostname != "client.example.com" ||
+		relations[0].TargetHostname != "server.example.com" || relations[0].Label != "api" {
+		t.Errorf("expected relation to survive the import, got %+v", relations)
+	}
+
+	targets, err := q.ListDeploymentTargets(app.ctx, "server.example.com")
+	if err != nil {
+		t.Fatalf("ListDeploymentTargets() error: %v", err)
+	}
+	if len(targets) != 1 || targets[0].Name != "lb-01" || targets[0].Location != "dc1" ||
+		targets[0].DeployedAt != 1700000200 {
+		t.Errorf("expected deployment target to survive the import, got %+v", targets)
+	}
+}
+
+func TestBackupCertificateTables_CoverSchema(t *testing.T) {
+	app, _ := setupFileBasedApp(t)
+
+	registered := make(map[string]bool)
+	for _, table := range backupCertificateTables {
+		if (table.read == nil) != (table.write == nil) || (table.read == nil) == (table.skip == "") {
+			t.Errorf("%s needs both read and write hooks, or a reason to skip it", table.table)
+		}
+		registered[table.table] = true
+	}
+
+	rows, err := app.db.DB().Query(`
+		SELECT DISTINCT m.name
+		FROM sqlite_master m, pragma_foreign_key_list(m.name) f
+		WHERE m.type = 'table' AND f."table" = 'certificates'
+	`)
+	if err != nil {
+		t.Fatalf("failed to list tables: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			t.Fatalf("failed to scan table: %v", err)
+		}
+		if !registered[table] {
+			t.Errorf("%s references certificates but is not in backupCertificateTables", table)
+		}
+		delete(registered, table)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("failed to iterate tables: %v", err)
+	}
+	for table := range registered {
+		t.Errorf("%s is in backupCertificateTables but does not reference certificates", table)
+	}
+}
+
 func TestImportCertificates_WrongPassword(t *testing.T) {
 	backupPath, _ := createTestBackupDB(t, testBackupDBOpts{
 		hostnames: []string{"wrongpw.example.com"},
@@ -486,7 +846,7 @@ func TestImportCertificates_WrongPassword(t *testing.T) {
 
 	app := setupUnlockedApp(t)
 
-	_, err := app.ImportCertificatesFromBackup(backupPath, "wrong-password-at-least-16")
+	_, err := app.ImportCertificatesFromBackup(backupPath, "wrong-password-at-least-16", models.CertImportOptions{}, false)
 	if err == nil {
 		t.Fatal("expected error for wrong password")
 	}
@@ -511,7 +871,7 @@ func TestImportCertificates_ConflictSkip(t *testing.T) {
 		t.Fatalf("failed to create conflicting cert: %v", err)
 	}
 
-	result, err := app.ImportCertificatesFromBackup(backupPath, testPassword)
+	result, err := app.ImportCertificatesFromBackup(backupPath, testPassword, models.CertImportOptions{}, false)
 	if err != nil {
 		t.Fatalf("ImportCertificatesFromBackup() error: %v", err)
 	}
@@ -535,7 +895,7 @@ func TestImportCertificates_PreV4Backup(t *testing.T) {
 
 	app := setupUnlockedApp(t)
 
-	_, err := app.ImportCertificatesFromBackup(backupPath, testPassword)
+	_, err := app.ImportCertificatesFromBackup(backupPath, testPassword, models.CertImportOptions{}, false)
 	if err == nil {
 		t.Fatal("expected error for pre-v4 backup")
 	}
@@ -556,7 +916,7 @@ func TestImportCertificates_DirtyMigration(t *testing.T) {
 
 	app := setupUnlockedApp(t)
 
-	_, err := app.ImportCertificatesFromBackup(backupPath, testPassword)
+	_, err := app.ImportCertificatesFromBackup(backupPath, testPassword, models.CertImportOptions{}, false)
 	if err == nil {
 		t.Fatal("expected error for dirty migration")
 	}
@@ -573,12 +933,80 @@ func TestImportCertificates_RequiresUnlock(t *testing.T) {
 
 	app := setupConfiguredApp(t) // configured but locked
 
-	_, err := app.ImportCertificatesFromBackup(backupPath, testPassword)
+	_, err := app.ImportCertificatesFromBackup(backupPath, testPassword, models.CertImportOptions{}, false)
 	if err == nil {
 		t.Fatal("expected error when app is locked")
 	}
 }
 
+// ============================================================================
+// PreviewCertificateImport
+// ============================================================================
+
+func TestPreviewCertificateImport_ClassifiesEntries(t *testing.T) {
+	backupPath, _ := createTestBackupDB(t, testBackupDBOpts{
+		hostnames: []string{"existing.example.com", "fresh.example.com", "corrupt.example.com"},
+		password:  testPassword,
+	})
+
+	bdb, err := sql.Open("sqlite", backupPath)
+	if err != nil {
+		t.Fatalf("open backup: %v", err)
+	}
+	if _, err := bdb.Exec("UPDATE certificates SET encrypted_private_key = ? WHERE hostname = ?",
+		[]byte{0x00, 0x01, 0x02}, "corrupt.example.com"); err != nil {
+		t.Fatalf("corrupt key: %v", err)
+	}
+	bdb.Close()
+
+	app := setupUnlockedApp(t)
+	if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{
+		Hostname: "existing.example.com",
+	}); err != nil {
+		t.Fatalf("failed to create conflicting cert: %v", err)
+	}
+
+	preview, err := app.PreviewCertificateImport(backupPath, testPassword)
+	if err != nil {
+		t.Fatalf("PreviewCertificateImport() error: %v", err)
+	}
+
+	if len(preview.Importable) != 1 || preview.Importable[0].Hostname != "fresh.example.com" {
+		t.Fatalf("expected fresh.example.com to be importable, got %+v", preview.Importable)
+	}
+	if len(preview.Conflicting) != 1 || preview.Conflicting[0].Hostname != "existing.example.com" {
+		t.Fatalf("expected existing.example.com to conflict, got %+v", preview.Conflicting)
+	}
+	if len(preview.Invalid) != 1 || preview.Invalid[0].Hostname != "corrupt.example.com" {
+		t.Fatalf("expected corrupt.example.com to be invalid, got %+v", preview.Invalid)
+	}
+	if preview.Invalid[0].Reason == "" {
+		t.Fatal("expected invalid entry to carry a reason")
+	}
+
+	// Preview must not write anything.
+	exists, err := app.db.Queries().CertificateExists(app.ctx, "fresh.example.com")
+	if err != nil {
+		t.Fatalf("CertificateExists() error: %v", err)
+	}
+	if exists != 0 {
+		t.Fatal("preview should not import certificates")
+	}
+}
+
+func TestPreviewCertificateImport_WrongPassword(t *testing.T) {
+	backupPath, _ := createTestBackupDB(t, testBackupDBOpts{
+		certCount: 1,
+		password:  testPassword,
+	})
+
+	app := setupUnlockedApp(t)
+
+	if _, err := app.PreviewCertificateImport(backupPath, "wrong-password-at-least-16"); err == nil {
+		t.Fatal("expected error for wrong password")
+	}
+}
+
 // ============================================================================
 // RestoreFromBackupFile
 // ============================================================================
@@ -598,10 +1026,13 @@ func TestRestoreFromBackupFile_Success(t *testing.T) {
 		t.Fatal("app should start unlocked")
 	}
 
-	err := app.RestoreFromBackupFile(backupPath)
+	report, err := app.RestoreFromBackupFile(backupPath, false)
 	if err != nil {
 		t.Fatalf("RestoreFromBackupFile() error: %v", err)
 	}
+	if report.DryRun || report.Certificates != 2 || report.SchemaVersion == 0 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
 
 	// App should be locked after restore
 	if app.isUnlocked {
@@ -626,6 +1057,36 @@ func TestRestoreFromBackupFile_Success(t *testing.T) {
 	}
 }
 
+func TestRestoreFromBackupFile_DryRun(t *testing.T) {
+	backupPath, _ := createTestBackupDB(t, testBackupDBOpts{
+		hostnames: []string{"restored1.example.com", "restored2.example.com"},
+		password:  "backup-password-16-chars",
+	})
+
+	app, _ := setupFileBasedApp(t)
+	if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{
+		Hostname: "original.example.com",
+	}); err != nil {
+		t.Fatalf("failed to create original cert: %v", err)
+	}
+
+	report, err := app.RestoreFromBackupFile(backupPath, true)
+	if err != nil {
+		t.Fatalf("RestoreFromBackupFile() error: %v", err)
+	}
+	if !report.DryRun || report.Certificates != 2 || report.ReplacedCertificates != 1 {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+
+	// Nothing was replaced and the app stays unlocked
+	if !app.isUnlocked {
+		t.Fatal("a dry run must not lock the app")
+	}
+	if exists, err := app.db.Queries().CertificateExists(app.ctx, "original.example.com"); err != nil || exists != 1 {
+		t.Fatalf("original cert should still exist after a dry run (%v)", err)
+	}
+}
+
 func TestRestoreFromBackupFile_DirtyMigration(t *testing.T) {
 	backupPath, _ := createTestBackupDB(t, testBackupDBOpts{
 		certCount: 1,
@@ -643,7 +1104,7 @@ func TestRestoreFromBackupFile_DirtyMigration(t *testing.T) {
 		t.Fatalf("failed to create original cert: %v", err)
 	}
 
-	err = app.RestoreFromBackupFile(backupPath)
+	_, err = app.RestoreFromBackupFile(backupPath, false)
 	if err == nil {
 		t.Fatal("expected error for dirty migration")
 	}
@@ -664,7 +1125,7 @@ func TestRestoreFromBackupFile_DirtyMigration(t *testing.T) {
 func TestRestoreFromBackupFile_InvalidPath(t *testing.T) {
 	app, _ := setupFileBasedApp(t)
 
-	err := app.RestoreFromBackupFile("/nonexistent/backup.db")
+	_, err := app.RestoreFromBackupFile("/nonexistent/backup.db", false)
 	if err == nil {
 		t.Fatal("expected error for non-existent file")
 	}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"paddockcontrol-desktop/internal/crypto"
+	dbsqlc "paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/services"
+)
+
+// ============================================================================
+// Public Key Deduplication
+// ============================================================================
+
+// publicKeyIndex maps public key fingerprints to the hostnames holding that key,
+// so an import can spot a backup entry that reuses a key already stored under
+// another hostname (typically the same wildcard key found in several backups).
+type publicKeyIndex map[string][]string
+
+// buildPublicKeyIndex indexes the public keys of every current certificate and
+// pending CSR.
+func buildPublicKeyIndex(ctx context.Context, q *dbsqlc.Queries) (publicKeyIndex, error) {
+	certs, err := q.ListAllCertificates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	index := publicKeyIndex{}
+	for _, c := range certs {
+		index.add(c.Hostname, c.CertificatePem, c.PendingCsrPem)
+	}
+	return index, nil
+}
+
+// add records the public keys of a certificate and its pending CSR under hostname.
+func (idx publicKeyIndex) add(hostname string, certPEM, csrPEM sql.NullString) {
+	for _, fp := range publicKeyFingerprints(certPEM, csrPEM) {
+		idx[fp] = append(idx[fp], hostname)
+	}
+}
+
+// match returns a hostname, other than hostname itself, that already holds the
+// public key of the given certificate or CSR, or "" when the key is new.
+func (idx publicKeyIndex) match(hostname string, certPEM, csrPEM sql.NullString) string {
+	for _, fp := range publicKeyFingerprints(certPEM, csrPEM) {
+		for _, holder := range idx[fp] {
+			if holder != hostname {
+				return holder
+			}
+		}
+	}
+	return ""
+}
+
+// publicKeyFingerprints returns the fingerprints of the public keys in a
+// certificate and a CSR. Missing or unparseable PEM is skipped.
+func publicKeyFingerprints(certPEM, csrPEM sql.NullString) []string {
+	var fps []string
+	if certPEM.Valid && certPEM.String != "" {
+		if cert, err := crypto.ParseCertificate([]byte(certPEM.String)); err == nil {
+			if fp, err := crypto.PublicKeyFingerprint(cert.PublicKey); err == nil {
+				fps = append(fps, fp)
+			}
+		}
+	}
+	if csrPEM.Valid && csrPEM.String != "" {
+		if csr, err := crypto.ParseCSR([]byte(csrPEM.String)); err == nil {
+			if fp, err := crypto.PublicKeyFingerprint(csr.PublicKey); err == nil {
+				fps = append(fps, fp)
+			}
+		}
+	}
+	return fps
+}
+
+// validateDuplicateKeyPolicy rejects unknown duplicate key policies.
+func validateDuplicateKeyPolicy(policy string) error {
+	switch policy {
+	case "", models.KeyDuplicateLink, models.KeyDuplicateImport:
+		return nil
+	}
+	return fmt.Errorf("unknown duplicate key policy: %s", policy)
+}
+
+// linkBackupCertificate records, in the existing certificate's history, that a
+// backup entry with the same public key was linked to it instead of imported.
+func linkBackupCertificate(ctx context.Context, q *dbsqlc.Queries, history *services.HistoryService, cert backupCert, existingHostname string) error {
+	message := fmt.Sprintf("Backup entry %s uses the same public key; linked to this certificate instead of imported", cert.hostname)
+	details := map[string]any{"linked_hostname": cert.hostname}
+	if err := history.LogEventDetailsTx(ctx, q, existingHostname, models.EventKeyLinked, message, details); err != nil {
+		return fmt.Errorf("failed to log history for %s: %w", existingHostname, err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"database/sql"
+	"testing"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// newTestCSR returns a CSR PEM for a freshly generated key.
+func newTestCSR(t *testing.T, commonName string) string {
+	t.Helper()
+	key, err := crypto.GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	csrPEM, err := crypto.CreateCSR(crypto.CSRRequest{CommonName: commonName, Country: "FR"}, key)
+	if err != nil {
+		t.Fatalf("failed to create CSR: %v", err)
+	}
+	return string(csrPEM)
+}
+
+// setBackupPendingCSR stores csrPEM as the pending CSR of a backup entry.
+func setBackupPendingCSR(t *testing.T, backupPath, hostname, csrPEM string) {
+	t.Helper()
+	bdb, err := sql.Open("sqlite", backupPath)
+	if err != nil {
+		t.Fatalf("open backup: %v", err)
+	}
+	defer bdb.Close()
+	if _, err := bdb.Exec("UPDATE certificates SET pending_csr_pem = ? WHERE hostname = ?", csrPEM, hostname); err != nil {
+		t.Fatalf("failed to set pending CSR: %v", err)
+	}
+}
+
+// setupDuplicateKeyScenario returns a backup holding "copy.example.com", which
+// shares its public key with the current "wildcard.example.com", and
+// "fresh.example.com", whose key is new.
+func setupDuplicateKeyScenario(t *testing.T, app *App) string {
+	t.Helper()
+	backupPath, _ := createTestBackupDB(t, testBackupDBOpts{
+		hostnames: []string{"copy.example.com", "fresh.example.com"},
+		password:  testPassword,
+	})
+
+	csrPEM := newTestCSR(t, "*.example.com")
+	setBackupPendingCSR(t, backupPath, "copy.example.com", csrPEM)
+	setBackupPendingCSR(t, backupPath, "fresh.example.com", newTestCSR(t, "fresh.example.com"))
+
+	if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{
+		Hostname:      "wildcard.example.com",
+		PendingCsrPem: sql.NullString{String: csrPEM, Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	return backupPath
+}
+
+func TestPreviewCertificateImport_ReportsKeyDuplicates(t *testing.T) {
+	app := setupUnlockedApp(t)
+	backupPath := setupDuplicateKeyScenario(t, app)
+
+	preview, err := app.PreviewCertificateImport(backupPath, testPassword)
+	if err != nil {
+		t.Fatalf("PreviewCertificateImport() error: %v", err)
+	}
+
+	if len(preview.KeyDuplicates) != 1 {
+		t.Fatalf("expected 1 key duplicate, got %+v", preview.KeyDuplicates)
+	}
+	dup := preview.KeyDuplicates[0]
+	if dup.Hostname != "copy.example.com" || dup.LinkedTo != "wildcard.example.com" {
+		t.Errorf("unexpected key duplicate: %+v", dup)
+	}
+	if len(preview.Importable) != 1 || preview.Importable[0].Hostname != "fresh.example.com" {
+		t.Errorf("expected fresh.example.com to be importable, got %+v", preview.Importable)
+	}
+}
+
+func TestImportCertificates_LinksDuplicateKeys(t *testing.T) {
+	app := setupUnlockedApp(t)
+	backupPath := setupDuplicateKeyScenario(t, app)
+
+	result, err := app.ImportCertificatesFromBackup(backupPath, testPassword, models.CertImportOptions{}, false)
+	if err != nil {
+		t.Fatalf("ImportCertificatesFromBackup() error: %v", err)
+	}
+
+	if result.Imported != 1 {
+		t.Errorf("expected 1 imported, got %d", result.Imported)
+	}
+	want := models.CertKeyLink{Hostname: "copy.example.com", ExistingHostname: "wildcard.example.com"}
+	if len(result.Linked) != 1 || result.Linked[0] != want {
+		t.Fatalf("Linked = %+v, want [%+v]", result.Linked, want)
+	}
+
+	if exists, _ := app.db.Queries().CertificateExists(app.ctx, "copy.example.com"); exists != 0 {
+		t.Error("linked entry should not be inserted")
+	}
+	history, err := app.GetCertificateHistory("wildcard.example.com", 10)
+	if err != nil {
+		t.Fatalf("GetCertificateHistory() error: %v", err)
+	}
+	if len(history) == 0 || history[0].EventType != models.EventKeyLinked {
+		t.Errorf("expected a %s history entry, got %+v", models.EventKeyLinked, history)
+	}
+
+	// Importing anyway inserts the entry under its own hostname
+	result, err = app.ImportCertificatesFromBackup(backupPath, testPassword, models.CertImportOptions{
+		DuplicateKeyPolicy: models.KeyDuplicateImport,
+	}, false)
+	if err != nil {
+		t.Fatalf("ImportCertificatesFromBackup() error: %v", err)
+	}
+	if result.Imported != 1 || len(result.Linked) != 0 {
+		t.Errorf("expected copy.example.com to be imported, got %+v", result)
+	}
+	if exists, _ := app.db.Queries().CertificateExists(app.ctx, "copy.example.com"); exists != 1 {
+		t.Error("entry should be inserted with the import policy")
+	}
+}
+
+func TestImportCertificates_UnknownDuplicateKeyPolicy(t *testing.T) {
+	app := setupUnlockedApp(t)
+	backupPath := setupDuplicateKeyScenario(t, app)
+
+	if _, err := app.ImportCertificatesFromBackup(backupPath, testPassword, models.CertImportOptions{
+		DuplicateKeyPolicy: "merge",
+	}, false); err == nil {
+		t.Fatal("expected an error for an unknown duplicate key policy")
+	}
+}
+
+func TestMergeFromBackupFile_LinksDuplicateKeys(t *testing.T) {
+	app, _ := setupFileBasedApp(t)
+	app.autoBackupService = nil
+	backupPath := setupDuplicateKeyScenario(t, app)
+
+	result, err := app.MergeFromBackupFile(backupPath, testPassword, models.BackupMergeOptions{}, false)
+	if err != nil {
+		t.Fatalf("MergeFromBackupFile() error: %v", err)
+	}
+
+	if len(result.Added) != 1 || result.Added[0] != "fresh.example.com" {
+		t.Errorf("Added = %v, want [fresh.example.com]", result.Added)
+	}
+	want := models.CertKeyLink{Hostname: "copy.example.com", ExistingHostname: "wildcard.example.com"}
+	if len(result.Linked) != 1 || result.Linked[0] != want {
+		t.Errorf("Linked = %+v, want [%+v]", result.Linked, want)
+	}
+	if exists, _ := app.db.Queries().CertificateExists(app.ctx, "copy.example.com"); exists != 0 {
+		t.Error("linked entry should not be inserted")
+	}
+}
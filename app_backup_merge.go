@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sort"
+
+	dbsqlc "paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/services"
+)
+
+// ============================================================================
+// Merge Restore
+// ============================================================================
+
+// MergeFromBackupFile restores a backup into the current database instead of
+// replacing it: certificates only in the backup are added, certificates only in
+// the current database are left alone, and hostnames present in both are
+// resolved with opts. A backup-only entry whose public key is already held by
+// another hostname is linked to that certificate instead of added, unless
+// opts.DuplicateKeyPolicy is KeyDuplicateImport. Keys are re-encrypted from the
+// backup's master key to the current one. Backup entries that fail validation
+// are reported and skipped; every other change is applied in a single
+// transaction. A dry run rolls that transaction back and takes no backup,
+// returning the result the merge would have.
+func (a *App) MergeFromBackupFile(backupPath string, backupPassword string, opts models.BackupMergeOptions, dryRun bool) (*models.BackupMergeResult, error) {
+	if err := a.requireUnlocked(); err != nil {
+		return nil, err
+	}
+	if err := validateMergeOptions(opts); err != nil {
+		return nil, err
+	}
+	if err := a.requireFreshBackup("merge_restore"); err != nil {
+		return nil, err
+	}
+
+	_, log := logger.WithOperation(a.ctx, "merge_restore")
+	log.Info("merging backup into current database",
+		slog.String("path", backupPath),
+		slog.String("conflict_policy", opts.ConflictPolicy),
+		slog.Int("overrides", len(opts.Overrides)),
+		slog.Bool("dry_run", dryRun),
+	)
+
+	backupDB, backupMasterKey, err := openBackupForImport(backupPath, backupPassword)
+	if err != nil {
+		return nil, err
+	}
+	defer backupDB.Close()
+	defer backupMasterKey.Destroy()
+
+	certs, err := readBackupCertificatesForImport(backupDB)
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	database := a.db
+	currentMasterKey := a.masterKey.Clone()
+	a.mu.RUnlock()
+	defer currentMasterKey.Destroy()
+
+	if database == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	if currentMasterKey.Len() != 32 {
+		return nil, fmt.Errorf("master key is not available")
+	}
+
+	withTx := database.WithTx
+	if dryRun {
+		withTx = func(ctx context.Context, fn func(*dbsqlc.Queries) error) error {
+			return database.WithDryRunTx(ctx, true, fn)
+		}
+	} else {
+		a.performAutoBackup("merge_restore")
+	}
+
+	history := services.NewHistoryService(database, Version)
+	result, err := mergeBackupCertificates(a.ctx, withTx, history, certs, opts, backupMasterKey.Bytes(), currentMasterKey.Bytes())
+	if !dryRun {
+		a.recordActivity("merge_restore", "", err)
+	}
+	if err != nil {
+		log.Error("merge restore failed", logger.Err(err))
+		return nil, err
+	}
+	result.DryRun = dryRun
+	if !dryRun {
+		a.recordAudit(database, models.AuditBackupRestore, "", "Backup merged into the database",
+			map[string]any{"source": "merge", "file": filepath.Base(backupPath), "added": len(result.Added), "replaced": len(result.Replaced)})
+	}
+
+	log.Info("merge restore completed",
+		slog.Int("added", len(result.Added)),
+		slog.Int("replaced", len(result.Replaced)),
+		slog.Int("kept", len(result.Kept)),
+		slog.Int("failed", len(result.Failed)),
+		slog.Int("linked", len(result.Linked)),
+	)
+
+	return result, nil
+}
+
+// validateMergeOptions rejects unknown conflict and duplicate key policies.
+func validateMergeOptions(opts models.BackupMergeOptions) error {
+	if err := validateDuplicateKeyPolicy(opts.DuplicateKeyPolicy); err != nil {
+		return err
+	}
+	check := func(policy string) error {
+		switch policy {
+		case "", models.MergeKeepCurrent, models.MergeUseBackup, models.MergeKeepNewer:
+			return nil
+		}
+		return fmt.Errorf("unknown conflict policy: %s", policy)
+	}
+	if err := check(opts.ConflictPolicy); err != nil {
+		return err
+	}
+	for hostname, policy := range opts.Overrides {
+		if err := check(policy); err != nil {
+			return fmt.Errorf("%s: %w", hostname, err)
+		}
+	}
+	return nil
+}
+
+// mergeBackupCertificates applies a merge-restore of certs inside one
+// transaction opened by withTx. Invalid backup entries and conflicts with a
+// read-only current certificate are reported in Failed rather than aborting.
+func mergeBackupCertificates(
+	ctx context.Context,
+	withTx func(context.Context, func(*dbsqlc.Queries) error) error,
+	history *services.HistoryService,
+	certs []backupCert,
+	opts models.BackupMergeOptions,
+	backupMasterKey, currentMasterKey []byte,
+) (*models.BackupMergeResult, error) {
+	result := &models.BackupMergeResult{
+		Added:    []string{},
+		Replaced: []string{},
+		Kept:     []string{},
+		Failed:   []models.CertImportFailure{},
+		Linked:   []models.CertKeyLink{},
+	}
+	linkKeys := opts.DuplicateKeyPolicy != models.KeyDuplicateImport
+
+	err := withTx(ctx, func(q *dbsqlc.Queries) error {
+		keys, err := buildPublicKeyIndex(ctx, q)
+		if err != nil {
+			return err
+		}
+
+		for _, cert := range certs {
+			if err := validateBackupCertificate(cert, backupMasterKey); err != nil {
+				result.Failed = append(result.Failed, models.CertImportFailure{Hostname: cert.hostname, Error: err.Error()})
+				continue
+			}
+
+			exists, err := q.CertificateExists(ctx, cert.hostname)
+			if err != nil {
+				return fmt.Errorf("failed to check certificate existence for %s: %w", cert.hostname, err)
+			}
+
+			message := "Certificate restored from backup (merge)"
+			if exists == 1 {
+				current, err := q.GetCertificateByHostname(ctx, cert.hostname)
+				if err != nil {
+					return fmt.Errorf("failed to get certificate %s: %w", cert.hostname, err)
+				}
+				if !backupWinsConflict(cert, current, opts) {
+					result.Kept = append(result.Kept, cert.hostname)
+					continue
+				}
+				if current.ReadOnly == 1 {
+					result.Failed = append(result.Failed, models.CertImportFailure{
+						Hostname: cert.hostname,
+						Error:    "current certificate is read-only and cannot be replaced",
+					})
+					continue
+				}
+				message = "Certificate replaced by its version from a backup (merge)"
+			} else if linkKeys {
+				if holder := keys.match(cert.hostname, cert.certificatePEM, cert.pendingCSR); holder != "" {
+					if err := linkBackupCertificate(ctx, q, history, cert, holder); err != nil {
+						return err
+					}
+					result.Linked = append(result.Linked, models.CertKeyLink{Hostname: cert.hostname, ExistingHostname: holder})
+					continue
+				}
+			}
+
+			if err := restoreBackupCertificate(ctx, q, cert, backupMasterKey, currentMasterKey); err != nil {
+				return err
+			}
+			if err := history.LogEventTx(ctx, q, cert.hostname, models.EventCertificateRestored, message); err != nil {
+				return fmt.Errorf("failed to log history for %s: %w", cert.hostname, err)
+			}
+			keys.add(cert.hostname, cert.certificatePEM, cert.pendingCSR)
+
+			if exists == 1 {
+				result.Replaced = append(result.Replaced, cert.hostname)
+			} else {
+				result.Added = append(result.Added, cert.hostname)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(result.Added)
+	sort.Strings(result.Replaced)
+	sort.Strings(result.Kept)
+	sort.Slice(result.Failed, func(i, j int) bool {
+		return result.Failed[i].Hostname < result.Failed[j].Hostname
+	})
+	sort.Slice(result.Linked, func(i, j int) bool {
+		return result.Linked[i].Hostname < result.Linked[j].Hostname
+	})
+
+	return result, nil
+}
+
+// backupWinsConflict reports whether the backup's version of a conflicting
+// certificate should replace the current one under opts.
+func backupWinsConflict(cert backupCert, current dbsqlc.Certificate, opts models.BackupMergeOptions) bool {
+	policy := opts.ConflictPolicy
+	if override, ok := opts.Overrides[cert.hostname]; ok {
+		policy = override
+	}
+
+	switch policy {
+	case models.MergeUseBackup:
+		return true
+	case models.MergeKeepNewer:
+		return cert.lastModified > current.LastModified
+	default:
+		return false
+	}
+}
+
+// restoreBackupCertificate re-encrypts a backup certificate's keys to the current
+// master key and upserts the full row, keeping the current created_at when the
+// hostname already exists. The certificate's history is preserved.
+func restoreBackupCertificate(ctx context.Context, q *dbsqlc.Queries, cert backupCert, backupMasterKey, currentMasterKey []byte) error {
+	encryptedKey, err := reencryptPrivateKey(cert.encryptedKey, backupMasterKey, currentMasterKey)
+	if err != nil {
+		return fmt.Errorf("failed to re-encrypt private key for %s: %w", cert.hostname, err)
+	}
+	pendingEncryptedKey, err := reencryptPrivateKey(cert.pendingEncryptedKey, backupMasterKey, currentMasterKey)
+	if err != nil {
+		return fmt.Errorf("failed to re-encrypt pending private key for %s: %w", cert.hostname, err)
+	}
+
+	if err := q.RestoreCertificate(ctx, dbsqlc.RestoreCertificateParams{
+		Hostname:                   cert.hostname,
+		EncryptedPrivateKey:        encryptedKey,
+		PendingEncryptedPrivateKey: pendingEncryptedKey,
+		PendingCsrPem:              cert.pendingCSR,
+		CertificatePem:             cert.certificatePEM,
+		CreatedAt:                  cert.createdAt,
+		ExpiresAt:                  cert.expiresAt,
+		LastModified:               cert.lastModified,
+		Note:                       cert.note,
+		PendingNote:                cert.pendingNote,
+		ReadOnly:                   cert.readOnly,
+		ChainPem:                   cert.chainPEM,
+		CaReference:                cert.caReference,
+		SubmittedAt:                cert.submittedAt,
+	}); err != nil {
+		return fmt.Errorf("failed to restore certificate %s: %w", cert.hostname, err)
+	}
+	if err := addBackupCertificateRecords(ctx, q, cert); err != nil {
+		return err
+	}
+	return services.RefreshKeyStatusTx(ctx, q, cert.hostname, currentMasterKey)
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"testing"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// setupMergeScenario returns an unlocked app and a backup of it taken while it
+// held "deleted.example.com" and "shared.example.com". After the backup,
+// deleted.example.com is removed, shared.example.com gets a new note, and
+// "new.example.com" is created.
+func setupMergeScenario(t *testing.T) (*App, string, []byte) {
+	t.Helper()
+	app, _ := setupFileBasedApp(t)
+	deletedKey := insertEncryptedCert(t, app, "deleted.example.com")
+	insertEncryptedCert(t, app, "shared.example.com")
+
+	path := exportTestBackup(t, app)
+
+	q := app.db.Queries()
+	if err := q.DeleteCertificate(app.ctx, "deleted.example.com"); err != nil {
+		t.Fatalf("failed to delete certificate: %v", err)
+	}
+	if err := q.UpdateCertificateNote(app.ctx, sqlc.UpdateCertificateNoteParams{
+		Note:     sql.NullString{String: "edited after backup", Valid: true},
+		Hostname: "shared.example.com",
+	}); err != nil {
+		t.Fatalf("failed to update note: %v", err)
+	}
+	insertEncryptedCert(t, app, "new.example.com")
+
+	// performAutoBackup emits a Wails event, which needs the runtime context
+	app.autoBackupService = nil
+
+	return app, path, deletedKey
+}
+
+func TestMergeFromBackupFile_DryRun(t *testing.T) {
+	app, path, _ := setupMergeScenario(t)
+
+	result, err := app.MergeFromBackupFile(path, testExportPassword, models.BackupMergeOptions{ConflictPolicy: models.MergeUseBackup}, true)
+	if err != nil {
+		t.Fatalf("MergeFromBackupFile() error: %v", err)
+	}
+	if !result.DryRun || len(result.Added) != 1 || len(result.Replaced) != 1 {
+		t.Fatalf("result = %+v, want one addition and one replacement reported", result)
+	}
+
+	q := app.db.Queries()
+	if exists, _ := q.CertificateExists(app.ctx, "deleted.example.com"); exists != 0 {
+		t.Error("a dry run must not add certificates")
+	}
+	shared, err := q.GetCertificateByHostname(app.ctx, "shared.example.com")
+	if err != nil {
+		t.Fatalf("GetCertificateByHostname() error: %v", err)
+	}
+	if shared.Note.String != "edited after backup" {
+		t.Errorf("a dry run must not replace certificates, note = %q", shared.Note.String)
+	}
+}
+
+func TestMergeFromBackupFile_KeepCurrent(t *testing.T) {
+	app, path, deletedKey := setupMergeScenario(t)
+
+	result, err := app.MergeFromBackupFile(path, testExportPassword, models.BackupMergeOptions{}, false)
+	if err != nil {
+		t.Fatalf("MergeFromBackupFile() error: %v", err)
+	}
+
+	if len(result.Added) != 1 || result.Added[0] != "deleted.example.com" {
+		t.Errorf("Added = %v, want [deleted.example.com]", result.Added)
+	}
+	if len(result.Kept) != 1 || result.Kept[0] != "shared.example.com" {
+		t.Errorf("Kept = %v, want [shared.example.com]", result.Kept)
+	}
+	if len(result.Replaced) != 0 || len(result.Failed) != 0 {
+		t.Errorf("unexpected replaced %v / failed %v", result.Replaced, result.Failed)
+	}
+
+	q := app.db.Queries()
+	if exists, _ := q.CertificateExists(app.ctx, "new.example.com"); exists != 1 {
+		t.Error("certificate created after the backup should be preserved")
+	}
+	shared, err := q.GetCertificateByHostname(app.ctx, "shared.example.com")
+	if err != nil {
+		t.Fatalf("GetCertificateByHostname() error: %v", err)
+	}
+	if shared.Note.String != "edited after backup" {
+		t.Errorf("current version should be kept, note = %q", shared.Note.String)
+	}
+
+	// The added certificate's key is re-encrypted to the current master key
+	restored, err := q.GetCertificateByHostname(app.ctx, "deleted.example.com")
+	if err != nil {
+		t.Fatalf("GetCertificateByHostname() error: %v", err)
+	}
+	decrypted, err := crypto.DecryptPrivateKey(restored.EncryptedPrivateKey, app.masterKey.Bytes())
+	if err != nil {
+		t.Fatalf("restored key should decrypt with the current master key: %v", err)
+	}
+	defer decrypted.Destroy()
+	if !bytes.Equal(decrypted.Bytes(), deletedKey) {
+		t.Error("restored key does not match the original")
+	}
+
+	history, err := app.GetCertificateHistory("deleted.example.com", 10)
+	if err != nil {
+		t.Fatalf("GetCertificateHistory() error: %v", err)
+	}
+	if len(history) == 0 || history[0].EventType != models.EventCertificateRestored {
+		t.Errorf("expected a %s history entry, got %+v", models.EventCertificateRestored, history)
+	}
+}
+
+func TestMergeFromBackupFile_OverrideUseBackup(t *testing.T) {
+	app, path, _ := setupMergeScenario(t)
+
+	result, err := app.MergeFromBackupFile(path, testExportPassword, models.BackupMergeOptions{
+		ConflictPolicy: models.MergeKeepCurrent,
+		Overrides:      map[string]string{"shared.example.com": models.MergeUseBackup},
+	}, false)
+	if err != nil {
+		t.Fatalf("MergeFromBackupFile() error: %v", err)
+	}
+
+	if len(result.Replaced) != 1 || result.Replaced[0] != "shared.example.com" {
+		t.Fatalf("Replaced = %v, want [shared.example.com]", result.Replaced)
+	}
+
+	shared, err := app.db.Queries().GetCertificateByHostname(app.ctx, "shared.example.com")
+	if err != nil {
+		t.Fatalf("GetCertificateByHostname() error: %v", err)
+	}
+	if shared.Note.Valid {
+		t.Errorf("backup version should win, note = %q", shared.Note.String)
+	}
+	if _, err := crypto.DecryptPrivateKey(shared.EncryptedPrivateKey, app.masterKey.Bytes()); err != nil {
+		t.Errorf("replaced key should decrypt with the current master key: %v", err)
+	}
+}
+
+func TestMergeFromBackupFile_KeepNewer(t *testing.T) {
+	app, path, _ := setupMergeScenario(t)
+
+	// Make the current version older than the backup's
+	if _, err := app.db.DB().Exec("UPDATE certificates SET last_modified = 1 WHERE hostname = ?", "shared.example.com"); err != nil {
+		t.Fatalf("failed to age certificate: %v", err)
+	}
+
+	result, err := app.MergeFromBackupFile(path, testExportPassword, models.BackupMergeOptions{
+		ConflictPolicy: models.MergeKeepNewer,
+	}, false)
+	if err != nil {
+		t.Fatalf("MergeFromBackupFile() error: %v", err)
+	}
+	if len(result.Replaced) != 1 || result.Replaced[0] != "shared.example.com" {
+		t.Errorf("Replaced = %v, want [shared.example.com]", result.Replaced)
+	}
+}
+
+func TestMergeFromBackupFile_ReadOnlyIsNotReplaced(t *testing.T) {
+	app, path, _ := setupMergeScenario(t)
+	if err := app.db.Queries().UpdateCertificateReadOnly(app.ctx, sqlc.UpdateCertificateReadOnlyParams{
+		ReadOnly: 1,
+		Hostname: "shared.example.com",
+	}); err != nil {
+		t.Fatalf("failed to set read-only: %v", err)
+	}
+
+	result, err := app.MergeFromBackupFile(path, testExportPassword, models.BackupMergeOptions{
+		ConflictPolicy: models.MergeUseBackup,
+	}, false)
+	if err != nil {
+		t.Fatalf("MergeFromBackupFile() error: %v", err)
+	}
+	if len(result.Failed) != 1 || result.Failed[0].Hostname != "shared.example.com" {
+		t.Fatalf("Failed = %v, want shared.example.com", result.Failed)
+	}
+	if len(result.Added) != 1 {
+		t.Errorf("other entries should still merge, Added = %v", result.Added)
+	}
+}
+
+func TestMergeFromBackupFile_KeepsDeploymentTargets(t *testing.T) {
+	app, _ := setupFileBasedApp(t)
+	insertEncryptedCert(t, app, "deployed.example.com")
+	q := app.db.Queries()
+	if err := q.UpsertDeploymentTarget(app.ctx, sqlc.UpsertDeploymentTargetParams{
+		Hostname:   "deployed.example.com",
+		Name:       "lb-01",
+		Location:   "dc1",
+		DeployedAt: 1700000200,
+	}); err != nil {
+		t.Fatalf("failed to record deployment target: %v", err)
+	}
+
+	path := exportTestBackup(t, app)
+	if err := q.DeleteCertificate(app.ctx, "deployed.example.com"); err != nil {
+		t.Fatalf("failed to delete certificate: %v", err)
+	}
+	app.autoBackupService = nil
+
+	result, err := app.MergeFromBackupFile(path, testExportPassword, models.BackupMergeOptions{}, false)
+	if err != nil {
+		t.Fatalf("MergeFromBackupFile() error: %v", err)
+	}
+	if len(result.Added) != 1 || result.Added[0] != "deployed.example.com" {
+		t.Fatalf("Added = %v, want [deployed.example.com]", result.Added)
+	}
+
+	targets, err := q.ListDeploymentTargets(app.ctx, "deployed.example.com")
+	if err != nil {
+		t.Fatalf("ListDeploymentTargets() error: %v", err)
+	}
+	if len(targets) != 1 || targets[0].Name != "lb-01" || targets[0].Location != "dc1" ||
+		targets[0].DeployedAt != 1700000200 {
+		t.Errorf("expected deployment target to survive the merge, got %+v", targets)
+	}
+}
+
+func TestMergeFromBackupFile_UnknownPolicy(t *testing.T) {
+	app, path, _ := setupMergeScenario(t)
+
+	if _, err := app.MergeFromBackupFile(path, testExportPassword, models.BackupMergeOptions{
+		ConflictPolicy: "newest_wins",
+	}, false); err == nil {
+		t.Fatal("expected error for unknown conflict policy")
+	}
+}
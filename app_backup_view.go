@@ -0,0 +1,183 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"paddockcontrol-desktop/internal/config"
+	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/services"
+)
+
+// ============================================================================
+// Read-Only Backup View
+// ============================================================================
+
+// backupView is a backup mounted for inspection. The backup file is copied to a
+// temporary directory and migrated there, so backups from older versions can be
+// browsed with the regular services while the original file stays untouched.
+// Only read operations are exposed; nothing is ever written back.
+type backupView struct {
+	path               string // original backup file
+	dir                string // temporary directory holding the migrated copy
+	db                 *db.Database
+	certificateService *services.CertificateService
+}
+
+// close releases the view's database and removes its temporary copy.
+func (v *backupView) close() error {
+	err := v.db.Close()
+	if rmErr := os.RemoveAll(v.dir); rmErr != nil && err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+// OpenBackupReadOnly mounts a backup file as a temporary read-only view, so its
+// certificates can be listed, inspected and downloaded without importing or
+// restoring anything. A previously opened view is closed first. Returns the
+// backup's summary.
+// Does NOT require encryption key - private keys are never read from the view
+func (a *App) OpenBackupReadOnly(path string) (*models.BackupPeekInfo, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+	if err := validateBackupPath(path); err != nil {
+		return nil, err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("opening backup read-only", slog.String("path", path))
+
+	info, err := peekBackupAtPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.SchemaVersion == 0 {
+		return nil, fmt.Errorf("unrecognized backup: file is not a valid PaddockControl database")
+	}
+
+	view, err := mountBackupView(path)
+	if err != nil {
+		log.Error("failed to mount backup view", logger.Err(err))
+		return nil, err
+	}
+
+	a.mu.Lock()
+	previous := a.backupView
+	a.backupView = view
+	a.mu.Unlock()
+
+	if previous != nil {
+		if err := previous.close(); err != nil {
+			log.Error("failed to close previous backup view", logger.Err(err))
+		}
+	}
+
+	log.Info("backup view opened", slog.Int("certificates", info.CertificateCount))
+	return info, nil
+}
+
+// mountBackupView copies a backup into a fresh temporary directory and opens it
+// with the current schema.
+func mountBackupView(path string) (*backupView, error) {
+	dir, err := os.MkdirTemp("", "paddockcontrol-backup-view-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+
+	if err := copyFile(path, filepath.Join(dir, "certificates.db")); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("failed to copy backup: %w", err)
+	}
+
+	database, err := db.NewDatabase(dir)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("failed to open backup: %w", err)
+	}
+
+	return &backupView{
+		path:               path,
+		dir:                dir,
+		db:                 database,
+		certificateService: services.NewCertificateService(database, config.NewService(database), Version),
+	}, nil
+}
+
+// CloseBackupView unmounts the backup opened with OpenBackupReadOnly and removes
+// its temporary copy. Closing when no view is open is a no-op.
+func (a *App) CloseBackupView() error {
+	a.mu.Lock()
+	view := a.backupView
+	a.backupView = nil
+	a.mu.Unlock()
+
+	if view == nil {
+		return nil
+	}
+
+	log := logger.WithComponent("app")
+	if err := view.close(); err != nil {
+		log.Error("failed to close backup view", logger.Err(err))
+		return fmt.Errorf("failed to close backup view: %w", err)
+	}
+
+	log.Info("backup view closed", slog.String("path", view.path))
+	return nil
+}
+
+// currentBackupView returns the certificate service of the open backup view.
+func (a *App) currentBackupView() (*services.CertificateService, error) {
+	a.mu.RLock()
+	view := a.backupView
+	a.mu.RUnlock()
+
+	if view == nil {
+		return nil, fmt.Errorf("no backup is open")
+	}
+	return view.certificateService, nil
+}
+
+// ListBackupViewCertificates lists the certificates of the open backup view.
+func (a *App) ListBackupViewCertificates(filter models.CertificateFilter) ([]*models.CertificateListItem, error) {
+	certificateService, err := a.currentBackupView()
+	if err != nil {
+		return nil, err
+	}
+	return certificateService.ListCertificates(a.ctx, filter)
+}
+
+// GetBackupViewCertificate returns the details of a certificate in the open
+// backup view.
+func (a *App) GetBackupViewCertificate(hostname string) (*models.Certificate, error) {
+	certificateService, err := a.currentBackupView()
+	if err != nil {
+		return nil, err
+	}
+	return certificateService.GetCertificate(a.ctx, hostname)
+}
+
+// SaveBackupViewCertificateToFile prompts the user to save the certificate PEM
+// of a certificate in the open backup view.
+func (a *App) SaveBackupViewCertificateToFile(hostname string) error {
+	certificateService, err := a.currentBackupView()
+	if err != nil {
+		return err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("downloading certificate from backup view", slog.String("hostname", hostname))
+
+	cert, err := certificateService.GetCertificateForDownload(a.ctx, hostname)
+	if err != nil {
+		log.Error("get certificate failed", slog.String("hostname", hostname), logger.Err(err))
+		return err
+	}
+
+	return a.saveCertificatePEM(hostname, cert)
+}
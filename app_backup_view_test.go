@@ -0,0 +1,135 @@
+package main
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"paddockcontrol-desktop/internal/models"
+)
+
+func TestOpenBackupReadOnly_ListsAndClosesView(t *testing.T) {
+	backupPath, _ := createTestBackupDB(t, testBackupDBOpts{
+		hostnames: []string{"old1.example.com", "old2.example.com"},
+	})
+	before, err := os.ReadFile(backupPath)
+	if err != nil {
+		t.Fatalf("failed to read backup: %v", err)
+	}
+
+	app, _ := setupFileBasedApp(t)
+	info, err := app.OpenBackupReadOnly(backupPath)
+	if err != nil {
+		t.Fatalf("OpenBackupReadOnly() error: %v", err)
+	}
+	if info.CertificateCount != 2 {
+		t.Errorf("CertificateCount = %d, want 2", info.CertificateCount)
+	}
+
+	certs, err := app.ListBackupViewCertificates(models.CertificateFilter{})
+	if err != nil {
+		t.Fatalf("ListBackupViewCertificates() error: %v", err)
+	}
+	if len(certs) != 2 {
+		t.Fatalf("expected 2 certificates in view, got %d", len(certs))
+	}
+
+	cert, err := app.GetBackupViewCertificate("old1.example.com")
+	if err != nil {
+		t.Fatalf("GetBackupViewCertificate() error: %v", err)
+	}
+	if cert.Hostname != "old1.example.com" {
+		t.Errorf("Hostname = %s, want old1.example.com", cert.Hostname)
+	}
+
+	// The live database is not affected by the view
+	if exists, _ := app.db.Queries().CertificateExists(app.ctx, "old1.example.com"); exists == 1 {
+		t.Error("browsing a backup must not import its certificates")
+	}
+
+	dir := app.backupView.dir
+	if err := app.CloseBackupView(); err != nil {
+		t.Fatalf("CloseBackupView() error: %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Error("temporary copy should be removed on close")
+	}
+	if _, err := app.ListBackupViewCertificates(models.CertificateFilter{}); err == nil {
+		t.Error("expected error when no backup is open")
+	}
+
+	after, err := os.ReadFile(backupPath)
+	if err != nil {
+		t.Fatalf("failed to read backup: %v", err)
+	}
+	if string(before) != string(after) {
+		t.Error("backup file must be left untouched")
+	}
+}
+
+func TestOpenBackupReadOnly_MigratesOlderBackup(t *testing.T) {
+	backupPath, _ := createTestBackupDB(t, testBackupDBOpts{
+		hostnames: []string{"legacy.example.com"},
+	})
+	// Apply the down migrations past v8 so the file looks like a schema v8 backup
+	backupDB, err := sql.Open("sqlite", backupPath)
+	if err != nil {
+		t.Fatalf("failed to open backup: %v", err)
+	}
+	downs, err := filepath.Glob(filepath.Join("internal", "db", "migrations", "*.down.sql"))
+	if err != nil {
+		t.Fatalf("failed to list down migrations: %v", err)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(downs)))
+	for _, path := range downs {
+		if filepath.Base(path) < "000009" {
+			break
+		}
+		stmts, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		if _, err := backupDB.Exec(string(stmts)); err != nil {
+			t.Fatalf("failed to apply %s: %v", path, err)
+		}
+	}
+	if _, err := backupDB.Exec("UPDATE schema_migrations SET version = 8"); err != nil {
+		t.Fatalf("failed to downgrade backup: %v", err)
+	}
+	backupDB.Close()
+
+	app, _ := setupFileBasedApp(t)
+	t.Cleanup(func() { app.CloseBackupView() })
+
+	if _, err := app.OpenBackupReadOnly(backupPath); err != nil {
+		t.Fatalf("OpenBackupReadOnly() error: %v", err)
+	}
+	if _, err := app.GetBackupViewCertificate("legacy.example.com"); err != nil {
+		t.Fatalf("GetBackupViewCertificate() error: %v", err)
+	}
+}
+
+func TestOpenBackupReadOnly_ReplacesPreviousView(t *testing.T) {
+	first, _ := createTestBackupDB(t, testBackupDBOpts{hostnames: []string{"first.example.com"}})
+	second, _ := createTestBackupDB(t, testBackupDBOpts{hostnames: []string{"second.example.com"}})
+
+	app, _ := setupFileBasedApp(t)
+	t.Cleanup(func() { app.CloseBackupView() })
+
+	if _, err := app.OpenBackupReadOnly(first); err != nil {
+		t.Fatalf("OpenBackupReadOnly(first) error: %v", err)
+	}
+	firstDir := app.backupView.dir
+
+	if _, err := app.OpenBackupReadOnly(second); err != nil {
+		t.Fatalf("OpenBackupReadOnly(second) error: %v", err)
+	}
+	if _, err := os.Stat(firstDir); !os.IsNotExist(err) {
+		t.Error("previous view should be closed")
+	}
+	if _, err := app.GetBackupViewCertificate("second.example.com"); err != nil {
+		t.Errorf("expected second backup to be mounted: %v", err)
+	}
+}
@@ -32,17 +32,32 @@ func validateLocalBackupFilename(filename string) error {
 	return nil
 }
 
-// ListLocalBackups returns metadata for all local database backup files.
-func (a *App) ListLocalBackups() ([]models.LocalBackupInfo, error) {
+// ListLocalBackups returns a page of local database backup files, filtered and
+// sorted per opts, with the total count and size of the matching backups.
+func (a *App) ListLocalBackups(opts models.BackupListOptions) (*models.BackupListPage, error) {
 	a.mu.RLock()
 	autoBackup := a.autoBackupService
 	a.mu.RUnlock()
 
 	if autoBackup == nil {
-		return []models.LocalBackupInfo{}, nil
+		return &models.BackupListPage{Backups: []models.LocalBackupInfo{}}, nil
 	}
 
-	return autoBackup.ListBackups()
+	return autoBackup.ListBackupsPage(opts)
+}
+
+// GetBackupStorageBreakdown returns the disk space used by local backups, split
+// between auto and manual backups.
+func (a *App) GetBackupStorageBreakdown() (*models.BackupStorageBreakdown, error) {
+	a.mu.RLock()
+	autoBackup := a.autoBackupService
+	a.mu.RUnlock()
+
+	if autoBackup == nil {
+		return &models.BackupStorageBreakdown{}, nil
+	}
+
+	return autoBackup.StorageBreakdown()
 }
 
 // PeekLocalBackup opens a local backup file read-only and returns a summary of
@@ -76,6 +91,7 @@ func (a *App) CreateManualBackup() error {
 
 	a.mu.RLock()
 	autoBackup := a.autoBackupService
+	database := a.db
 	a.mu.RUnlock()
 
 	if autoBackup == nil {
@@ -83,10 +99,14 @@ func (a *App) CreateManualBackup() error {
 	}
 
 	_, err := autoBackup.CreateManualBackup()
+	a.recordActivity("create_manual_backup", "", err)
 	if err != nil {
 		log.Error("manual backup creation failed", logger.Err(err))
 		return err
 	}
+	if database != nil {
+		a.recordBackup(database)
+	}
 
 	wailsruntime.EventsEmit(a.ctx, "backup:created", "manual", "")
 
@@ -95,25 +115,33 @@ func (a *App) CreateManualBackup() error {
 }
 
 // RestoreLocalBackup replaces the current database with a local backup file.
-// A safety auto-backup is created before the restore operation.
-func (a *App) RestoreLocalBackup(filename string) error {
+// A safety auto-backup is created before the restore operation. A dry run
+// validates the backup and returns the report without replacing anything.
+func (a *App) RestoreLocalBackup(filename string, dryRun bool) (*models.RestoreReport, error) {
 	if err := a.requireSetupOnly(); err != nil {
-		return err
+		return nil, err
 	}
 
-	a.mu.Lock()
-	defer a.mu.Unlock()
-
 	log := logger.WithComponent("app")
-	log.Info("restoring from local backup", slog.String("filename", filename))
+	log.Info("restoring from local backup", slog.String("filename", filename), slog.Bool("dry_run", dryRun))
 
 	if err := validateLocalBackupFilename(filename); err != nil {
-		return err
+		return nil, err
 	}
 
 	backupPath := filepath.Join(a.dataDir, "backups", filename)
-	if _, err := os.Stat(backupPath); os.IsNotExist(err) {
-		return fmt.Errorf("backup file not found")
+	report, err := inspectBackupForRestore(backupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	report.ReplacedCertificates = countCurrentCertificates(a.ctx, a.db)
+	if dryRun {
+		report.DryRun = true
+		return report, nil
 	}
 
 	// Create a safety backup before restore
@@ -124,6 +152,14 @@ func (a *App) RestoreLocalBackup(filename string) error {
 		}
 	}
 
+	// The background key migration writes to the database being replaced
+	a.stopKeyEnvelopeMigration()
+	a.stopKeyPool()
+	a.stopSyncServer()
+
+	// The audit log lives in the database being replaced
+	auditEntries := a.readAuditLog(a.db)
+
 	// Close the current database connection
 	if a.db != nil {
 		if err := a.db.Close(); err != nil {
@@ -139,16 +175,16 @@ func (a *App) RestoreLocalBackup(filename string) error {
 
 	if err := copyFile(backupPath, dbPath); err != nil {
 		log.Error("failed to copy backup file", logger.Err(err))
-		return fmt.Errorf("failed to restore backup: %w", err)
+		return nil, fmt.Errorf("failed to restore backup: %w", err)
 	}
 
 	// Re-initialize the database
-	var err error
 	a.db, err = db.NewDatabase(a.dataDir)
 	if err != nil {
 		log.Error("failed to reinitialize database after restore", logger.Err(err))
-		return fmt.Errorf("failed to reinitialize database: %w", err)
+		return nil, fmt.Errorf("failed to reinitialize database: %w", err)
 	}
+	a.carryAuditLog(a.db, auditEntries)
 
 	// Re-check configuration state
 	tmpConfigService := config.NewService(a.db)
@@ -158,20 +194,19 @@ func (a *App) RestoreLocalBackup(filename string) error {
 	}
 
 	// Clear encryption key — restored DB may not match in-memory key
-	if a.masterKey != nil {
-		for i := range a.masterKey {
-			a.masterKey[i] = 0
-		}
-		a.masterKey = nil
-	}
+	a.masterKey.Destroy()
+	a.masterKey = nil
 	a.isUnlocked = false
 	a.waitingForEncryptionKey = false
 
 	// Re-initialize all services
 	a.initializeServicesWithoutKey()
 
+	a.recordAudit(a.db, models.AuditBackupRestore, "", "Database restored from a local backup",
+		map[string]any{"source": "local_backup", "file": filename})
+	a.recordActivity("restore_local_backup", "", nil)
 	log.Info("local backup restored successfully", slog.String("filename", filename))
-	return nil
+	return report, nil
 }
 
 // DeleteLocalBackup removes a local backup file.
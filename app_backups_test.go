@@ -50,8 +50,8 @@ func TestPeekLocalBackup_ManualBackup(t *testing.T) {
 	if !info.HasSecurityKeys {
 		t.Fatal("expected has_security_keys=true")
 	}
-	if info.SchemaVersion != 4 {
-		t.Fatalf("expected schema_version=4, got %d", info.SchemaVersion)
+	if info.SchemaVersion != currentSchemaVersion {
+		t.Fatalf("expected schema_version=%d, got %d", currentSchemaVersion, info.SchemaVersion)
 	}
 
 	// Per-certificate details should be populated for the drawer.
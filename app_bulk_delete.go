@@ -0,0 +1,198 @@
+package main
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ============================================================================
+// Bulk Delete
+// ============================================================================
+
+// bulkDeleteTokenTTL is how long a bulk delete confirmation token stays valid.
+const bulkDeleteTokenTTL = 5 * time.Minute
+
+// bulkDeleteConfirmation is the confirmation token issued by the last bulk
+// delete preview, bound to the previewed hostnames.
+type bulkDeleteConfirmation struct {
+	token     string
+	hostnames []string // sorted, without duplicates
+	expiresAt time.Time
+}
+
+// PreviewBulkDelete lists what deleting the given certificates would remove
+// (status, private keys lost, read-only and deployed blockers) and issues a
+// confirmation token the user must type back to BulkDeleteCertificates. No
+// token is issued while a selected certificate is read-only or deployed. Each preview replaces the previous
+// token.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) PreviewBulkDelete(hostnames []string) (*models.BulkDeletePreview, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	log := logger.WithComponent("app")
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	items, err := certificateService.PreviewBulkDelete(a.ctx, hostnames)
+	if err != nil {
+		log.Error("bulk delete preview failed", logger.Err(err))
+		return nil, err
+	}
+
+	preview := &models.BulkDeletePreview{Items: items}
+	var confirmation *bulkDeleteConfirmation
+	if !slices.ContainsFunc(items, func(item models.BulkDeletePreviewItem) bool {
+		return item.ReadOnly || len(item.DeployedOn) > 0
+	}) {
+		confirmation = &bulkDeleteConfirmation{
+			token:     fmt.Sprintf("DELETE-%d-%s", len(items), rand.Text()[:4]),
+			hostnames: sortedHostnameSet(hostnames),
+			expiresAt: a.appClock().Now().Add(bulkDeleteTokenTTL),
+		}
+		preview.ConfirmationToken = confirmation.token
+		preview.ExpiresAt = confirmation.expiresAt.Unix()
+	}
+
+	a.mu.Lock()
+	a.bulkDelete = confirmation
+	a.mu.Unlock()
+
+	log.Info("bulk delete previewed",
+		slog.Int("count", len(items)),
+		slog.Bool("confirmable", confirmation != nil),
+	)
+	return preview, nil
+}
+
+// BulkDeleteCertificates deletes the certificates of the last PreviewBulkDelete
+// in one transaction. confirmationToken must be the token that preview issued,
+// for the same hostnames, before it expires. A backup is taken first and the
+// deletion is refused if it fails. A dry run checks the token without using
+// it up, deletes inside a transaction that is rolled back and takes no backup,
+// so the result tells whether the deletion would succeed.
+// Does NOT require encryption key - deletion doesn't need decryption
+func (a *App) BulkDeleteCertificates(hostnames []string, confirmationToken string, dryRun bool) (*models.BulkDeleteResult, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	if err := a.requireFreshBackup("bulk_delete"); err != nil {
+		return nil, err
+	}
+
+	if err := a.consumeBulkDeleteToken(hostnames, confirmationToken, !dryRun); err != nil {
+		return nil, err
+	}
+
+	_, log := logger.WithOperation(a.ctx, "bulk_delete")
+	log.Info("deleting certificates in bulk", slog.Int("count", len(hostnames)), slog.Bool("dry_run", dryRun))
+
+	unlock := a.lockHostnames(hostnames)
+	defer unlock()
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	autoBackup := a.autoBackupService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+	if autoBackup == nil {
+		return nil, fmt.Errorf("backup service not available; bulk delete requires a backup first")
+	}
+
+	if dryRun {
+		result, err := certificateService.BulkDeleteCertificates(a.ctx, hostnames, true)
+		if err != nil {
+			log.Error("bulk delete dry run failed", logger.Err(err))
+			return nil, err
+		}
+		log.Info("bulk delete dry run finished", slog.Bool("deletable", result.Deleted))
+		return result, nil
+	}
+
+	backupPath, err := autoBackup.CreateBackup("bulk_delete")
+	if err != nil {
+		log.Error("pre-delete backup failed, nothing deleted", logger.Err(err))
+		return nil, fmt.Errorf("failed to create backup before bulk delete: %w", err)
+	}
+	wailsruntime.EventsEmit(a.ctx, "backup:created", "auto", "bulk_delete")
+
+	result, err := certificateService.BulkDeleteCertificates(a.ctx, hostnames, false)
+	if err != nil {
+		a.recordActivity("bulk_delete", "", err)
+		log.Error("bulk delete failed", logger.Err(err))
+		return nil, err
+	}
+	result.BackupPath = backupPath
+	for _, r := range result.Results {
+		var hostErr error
+		if r.Error != "" {
+			hostErr = errors.New(r.Error)
+		} else if !r.Success {
+			hostErr = errors.New("not deleted")
+		}
+		a.recordActivity("bulk_delete", r.Hostname, hostErr)
+	}
+
+	log.Info("bulk delete finished",
+		slog.Bool("deleted", result.Deleted),
+		slog.Int("count", len(result.Results)),
+	)
+	return result, nil
+}
+
+// consumeBulkDeleteToken checks a typed confirmation token against the last
+// bulk delete preview. A matching token is used up when consume is set; a
+// preview for other hostnames, or an expired one, must be redone.
+func (a *App) consumeBulkDeleteToken(hostnames []string, token string, consume bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	pending := a.bulkDelete
+	if pending == nil {
+		return fmt.Errorf("preview the bulk delete first")
+	}
+	if a.appClock().Now().After(pending.expiresAt) {
+		a.bulkDelete = nil
+		return fmt.Errorf("confirmation token expired; preview the bulk delete again")
+	}
+	if !slices.Equal(sortedHostnameSet(hostnames), pending.hostnames) {
+		a.bulkDelete = nil
+		return fmt.Errorf("selection changed since the preview; preview the bulk delete again")
+	}
+	if !strings.EqualFold(strings.TrimSpace(token), pending.token) {
+		return fmt.Errorf("confirmation token does not match; type %s to confirm", pending.token)
+	}
+
+	if consume {
+		a.bulkDelete = nil
+	}
+	return nil
+}
+
+// sortedHostnameSet returns hostnames sorted, without duplicates or empty values.
+func sortedHostnameSet(hostnames []string) []string {
+	set := slices.DeleteFunc(slices.Clone(hostnames), func(h string) bool { return h == "" })
+	slices.Sort(set)
+	return slices.Compact(set)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/clock"
+	"paddockcontrol-desktop/internal/db/sqlc"
+)
+
+func TestBulkDeleteCertificates_RequiresConfirmation(t *testing.T) {
+	app := setupConfiguredApp(t)
+	fake := clock.NewFake(time.Now())
+	app.clock = fake
+	// Without a backup service the mandatory pre-delete backup cannot be taken
+	app.autoBackupService = nil
+
+	for _, hostname := range []string{"a.example.com", "b.example.com"} {
+		if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{Hostname: hostname}); err != nil {
+			t.Fatalf("failed to create %s: %v", hostname, err)
+		}
+	}
+	selection := []string{"a.example.com", "b.example.com"}
+
+	if _, err := app.BulkDeleteCertificates(selection, "DELETE-2-XXXX", false); err == nil || !strings.Contains(err.Error(), "preview") {
+		t.Fatalf("delete without a preview: error = %v", err)
+	}
+
+	preview, err := app.PreviewBulkDelete(selection)
+	if err != nil {
+		t.Fatalf("PreviewBulkDelete() error = %v", err)
+	}
+	if len(preview.Items) != 2 || !strings.HasPrefix(preview.ConfirmationToken, "DELETE-2-") {
+		t.Fatalf("unexpected preview: %+v", preview)
+	}
+
+	if _, err := app.BulkDeleteCertificates(selection, "DELETE-2-WRONG", false); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("wrong token: error = %v", err)
+	}
+	// A typo does not burn the token, but the backup is mandatory
+	if _, err := app.BulkDeleteCertificates([]string{"b.example.com", "a.example.com"}, strings.ToLower(preview.ConfirmationToken), false); err == nil || !strings.Contains(err.Error(), "backup") {
+		t.Fatalf("without a backup service: error = %v", err)
+	}
+	if n, err := app.db.Queries().CountCertificates(app.ctx); err != nil || n != 2 {
+		t.Fatalf("certificates = %d (%v), want both kept", n, err)
+	}
+
+	// The token was used up, and a token for another selection is refused
+	if _, err := app.BulkDeleteCertificates(selection, preview.ConfirmationToken, false); err == nil || !strings.Contains(err.Error(), "preview") {
+		t.Fatalf("reused token: error = %v", err)
+	}
+	preview, err = app.PreviewBulkDelete(selection)
+	if err != nil {
+		t.Fatalf("PreviewBulkDelete() error = %v", err)
+	}
+	if _, err := app.BulkDeleteCertificates([]string{"a.example.com"}, preview.ConfirmationToken, false); err == nil || !strings.Contains(err.Error(), "selection changed") {
+		t.Fatalf("other selection: error = %v", err)
+	}
+
+	// Tokens expire
+	preview, err = app.PreviewBulkDelete(selection)
+	if err != nil {
+		t.Fatalf("PreviewBulkDelete() error = %v", err)
+	}
+	fake.Advance(bulkDeleteTokenTTL + time.Second)
+	if _, err := app.BulkDeleteCertificates(selection, preview.ConfirmationToken, false); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Fatalf("expired token: error = %v", err)
+	}
+}
+
+func TestBulkDeleteCertificates_DryRunKeepsToken(t *testing.T) {
+	// File based, so the app has the backup service a deletion requires
+	app, _ := setupFileBasedApp(t)
+
+	for _, hostname := range []string{"a.example.com", "b.example.com"} {
+		if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{Hostname: hostname}); err != nil {
+			t.Fatalf("failed to create %s: %v", hostname, err)
+		}
+	}
+	selection := []string{"a.example.com", "b.example.com"}
+	preview, err := app.PreviewBulkDelete(selection)
+	if err != nil {
+		t.Fatalf("PreviewBulkDelete() error = %v", err)
+	}
+
+	result, err := app.BulkDeleteCertificates(selection, preview.ConfirmationToken, true)
+	if err != nil {
+		t.Fatalf("dry run error = %v", err)
+	}
+	if !result.DryRun || !result.Deleted || result.BackupPath != "" {
+		t.Fatalf("unexpected dry run result: %+v", result)
+	}
+	if n, err := app.db.Queries().CountCertificates(app.ctx); err != nil || n != 2 {
+		t.Fatalf("certificates = %d (%v), want both kept by the dry run", n, err)
+	}
+
+	// The dry run left the token usable
+	if _, err := app.BulkDeleteCertificates(selection, preview.ConfirmationToken, true); err != nil {
+		t.Fatalf("second dry run error = %v", err)
+	}
+}
+
+func TestPreviewBulkDelete_ReadOnlyBlocksToken(t *testing.T) {
+	app := setupConfiguredApp(t)
+
+	if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{
+		Hostname: "locked.example.com",
+		ReadOnly: 1,
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	preview, err := app.PreviewBulkDelete([]string{"locked.example.com"})
+	if err != nil {
+		t.Fatalf("PreviewBulkDelete() error = %v", err)
+	}
+	if preview.ConfirmationToken != "" || !preview.Items[0].ReadOnly {
+		t.Fatalf("a read-only selection must not be confirmable: %+v", preview)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// ============================================================================
+// Certificate Relations
+// ============================================================================
+
+// GetCertificateGraph returns the certificates that take part in a relation
+// (nodes, as in ListCertificates) and the relations between them (edges), for
+// the dependency view.
+// Does NOT require encryption key - read-only operation
+func (a *App) GetCertificateGraph() (*models.CertificateGraph, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	return certificateService.GetCertificateGraph(a.ctx)
+}
+
+// AddCertificateRelation declares that source depends on target:
+// relationType "client_of" when source is a client certificate talking to the
+// server of target, "shared_endpoint" when both are served by the same
+// endpoint, named by label. Renewing either certificate then reports the
+// other as a dependent endpoint.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) AddCertificateRelation(source, target, relationType, label string) error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("adding certificate relation",
+		slog.String("source", source),
+		slog.String("target", target),
+		slog.String("type", relationType),
+	)
+
+	unlock := a.lockHostnames([]string{source, target})
+	defer unlock()
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return fmt.Errorf("certificate service not initialized")
+	}
+
+	err := certificateService.AddCertificateRelation(a.ctx, source, target, relationType, label)
+	a.recordActivity("add_relation", source, err)
+	if err != nil {
+		log.Error("add certificate relation failed",
+			slog.String("source", source),
+			slog.String("target", target),
+			logger.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// DeleteCertificateRelation removes a relation (CertificateRelation.id)
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) DeleteCertificateRelation(id int64) error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("deleting certificate relation", slog.Int64("id", id))
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return fmt.Errorf("certificate service not initialized")
+	}
+
+	err := certificateService.DeleteCertificateRelation(a.ctx, id)
+	a.recordActivity("delete_relation", "", err)
+	if err != nil {
+		log.Error("delete certificate relation failed", slog.Int64("id", id), logger.Err(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// ============================================================================
+// Certificate Tags
+// ============================================================================
+
+// ListCertificateTags returns the tags of a certificate, sorted
+// Does NOT require encryption key - read-only operation
+func (a *App) ListCertificateTags(hostname string) ([]string, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	return certificateService.ListCertificateTags(a.ctx, hostname)
+}
+
+// ListTags returns every tag in use with the number of certificates carrying
+// it, for the tag filter of the certificate list
+// Does NOT require encryption key - read-only operation
+func (a *App) ListTags() ([]models.TagCount, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	return certificateService.ListTags(a.ctx)
+}
+
+// AddCertificateTag tags a certificate, e.g. "production" or "team-infra".
+// Tags are lowercased; adding a tag the certificate already has is a no-op.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) AddCertificateTag(hostname, tag string) error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("adding certificate tag",
+		slog.String("hostname", hostname),
+		slog.String("tag", tag),
+	)
+
+	unlock := a.lockHostname(hostname)
+	defer unlock()
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return fmt.Errorf("certificate service not initialized")
+	}
+
+	err := certificateService.AddCertificateTag(a.ctx, hostname, tag)
+	a.recordActivity("add_certificate_tag", hostname, err)
+	if err != nil {
+		log.Error("add certificate tag failed", slog.String("hostname", hostname), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+// RemoveCertificateTag removes a tag from a certificate
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) RemoveCertificateTag(hostname, tag string) error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("removing certificate tag",
+		slog.String("hostname", hostname),
+		slog.String("tag", tag),
+	)
+
+	unlock := a.lockHostname(hostname)
+	defer unlock()
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return fmt.Errorf("certificate service not initialized")
+	}
+
+	err := certificateService.RemoveCertificateTag(a.ctx, hostname, tag)
+	a.recordActivity("remove_certificate_tag", hostname, err)
+	if err != nil {
+		log.Error("remove certificate tag failed", slog.String("hostname", hostname), logger.Err(err))
+		return err
+	}
+	return nil
+}
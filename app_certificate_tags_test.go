@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"paddockcontrol-desktop/internal/models"
+)
+
+// setupTaggedBackup returns an unlocked app and a backup of it taken while
+// "web.example.com" was tagged "production" and "dmz". The certificate is
+// deleted after the backup.
+func setupTaggedBackup(t *testing.T) (*App, string) {
+	t.Helper()
+	app, _ := setupFileBasedApp(t)
+	insertEncryptedCert(t, app, "web.example.com")
+	for _, tag := range []string{"production", "dmz"} {
+		if err := app.AddCertificateTag("web.example.com", tag); err != nil {
+			t.Fatalf("AddCertificateTag(%s) error: %v", tag, err)
+		}
+	}
+
+	path := exportTestBackup(t, app)
+
+	if err := app.db.Queries().DeleteCertificate(app.ctx, "web.example.com"); err != nil {
+		t.Fatalf("failed to delete certificate: %v", err)
+	}
+	// performAutoBackup emits a Wails event, which needs the runtime context
+	app.autoBackupService = nil
+
+	return app, path
+}
+
+func TestImportCertificates_RestoresTags(t *testing.T) {
+	app, path := setupTaggedBackup(t)
+
+	result, err := app.ImportCertificatesFromBackup(path, testExportPassword, models.CertImportOptions{
+		HostnameMapping: map[string]string{"web.example.com": "web.example.org"},
+	}, false)
+	if err != nil {
+		t.Fatalf("ImportCertificatesFromBackup() error: %v", err)
+	}
+	if result.Imported != 1 {
+		t.Fatalf("expected 1 imported, got %d", result.Imported)
+	}
+
+	tags, err := app.ListCertificateTags("web.example.org")
+	if err != nil {
+		t.Fatalf("ListCertificateTags() error: %v", err)
+	}
+	if strings.Join(tags, ",") != "dmz,production" {
+		t.Errorf("expected the tags to follow the renamed certificate, got %v", tags)
+	}
+}
+
+func TestMergeFromBackupFile_RestoresTags(t *testing.T) {
+	app, path := setupTaggedBackup(t)
+
+	if _, err := app.MergeFromBackupFile(path, testExportPassword, models.BackupMergeOptions{}, false); err != nil {
+		t.Fatalf("MergeFromBackupFile() error: %v", err)
+	}
+
+	items, err := app.certificateService.ListCertificates(app.ctx, models.CertificateFilter{Tags: []string{"dmz"}})
+	if err != nil {
+		t.Fatalf("ListCertificates() error: %v", err)
+	}
+	if len(items) != 1 || items[0].Hostname != "web.example.com" || len(items[0].Tags) != 2 {
+		t.Errorf("expected web.example.com with both tags, got %+v", items)
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// ============================================================================
+// Certificate Trash
+// ============================================================================
+
+// ListDeletedCertificates lists the certificates in the trash, most recently
+// deleted first, with the time each one is purged
+// Does NOT require encryption key - read-only operation
+func (a *App) ListDeletedCertificates() ([]*models.DeletedCertificate, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	return certificateService.ListDeletedCertificates(a.ctx)
+}
+
+// RestoreDeletedCertificate takes a certificate out of the trash
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) RestoreDeletedCertificate(hostname string) error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	_, log := logger.WithOperation(a.ctx, "restore_deleted_certificate")
+	log = logger.WithHostname(log, hostname)
+
+	unlock := a.lockHostname(hostname)
+	defer unlock()
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return fmt.Errorf("certificate service not initialized")
+	}
+
+	err := certificateService.RestoreDeletedCertificate(a.ctx, hostname)
+	a.recordActivity("restore_deleted_certificate", hostname, err)
+	if err != nil {
+		log.Error("restore from trash failed", logger.Err(err))
+		return err
+	}
+
+	log.Info("certificate restored from the trash")
+	return nil
+}
+
+// PurgeDeletedCertificate permanently deletes a certificate in the trash,
+// with its history and keys. A backup is taken first.
+// Does NOT require encryption key - deletion doesn't need decryption
+func (a *App) PurgeDeletedCertificate(hostname string) error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	if err := a.requireFreshBackup("purge_certificate"); err != nil {
+		return err
+	}
+
+	_, log := logger.WithOperation(a.ctx, "purge_certificate")
+	log = logger.WithHostname(log, hostname)
+
+	unlock := a.lockHostname(hostname)
+	defer unlock()
+
+	a.performAutoBackup("purge_certificate")
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return fmt.Errorf("certificate service not initialized")
+	}
+
+	err := certificateService.PurgeDeletedCertificate(a.ctx, hostname)
+	a.recordActivity("purge_certificate", hostname, err)
+	if err != nil {
+		log.Error("purge failed", logger.Err(err))
+		return err
+	}
+
+	log.Info("certificate purged from the trash")
+	return nil
+}
+
+// purgeExpiredTrash is called at startup to permanently delete the
+// certificates kept in the trash longer than the configured retention
+func (a *App) purgeExpiredTrash() {
+	log := logger.WithComponent("app")
+	purged, err := a.certificateService.PurgeExpiredTrash(a.ctx)
+	if err != nil {
+		log.Error("failed to purge the trash", logger.Err(err))
+		return
+	}
+	if purged > 0 {
+		log.Info("purged expired certificates from the trash", slog.Int("count", purged))
+	}
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"paddockcontrol-desktop/internal/logger"
 	"paddockcontrol-desktop/internal/models"
@@ -26,56 +28,68 @@ func (a *App) GenerateCSR(req models.CSRRequest) (*models.CSRResponse, error) {
 		slog.Int("san_count", len(req.SANs)),
 	)
 
+	unlock := a.lockHostname(req.Hostname)
+	defer unlock()
+
 	a.mu.RLock()
 	certificateService := a.certificateService
-	encryptionKey := make([]byte, len(a.masterKey))
-	copy(encryptionKey, a.masterKey)
+	encryptionKey := a.masterKey.Clone()
 	a.mu.RUnlock()
+	defer encryptionKey.Destroy()
 
 	if certificateService == nil {
 		return nil, fmt.Errorf("certificate service not initialized")
 	}
 
-	resp, err := certificateService.GenerateCSR(a.ctx, req, encryptionKey)
+	resp, err := certificateService.GenerateCSR(a.ctx, req, encryptionKey.Bytes())
+	a.recordActivity("generate_csr", req.Hostname, err)
 	if err != nil {
 		log.Error("CSR generation failed", logger.Err(err))
 		return nil, err
 	}
 
-	log.Info("CSR generated successfully")
+	// Replace the pooled key it may have taken
+	a.refillKeyPool()
+
+	log.Info("CSR generated successfully", slog.Int("warnings", len(resp.Warnings.Warnings)))
 	return resp, nil
 }
 
 // UploadCertificate activates a signed certificate
 // Requires encryption key to validate cert matches pending private key
-func (a *App) UploadCertificate(hostname, certPEM string) error {
+func (a *App) UploadCertificate(hostname, certPEM string) (*models.CertificateResult, error) {
 	if err := a.requireSetupComplete(); err != nil {
-		return err
+		return nil, err
 	}
 
 	_, log := logger.WithOperation(a.ctx, "upload_certificate")
 	log = logger.WithHostname(log, hostname)
 	log.Info("uploading certificate")
 
+	unlock := a.lockHostname(hostname)
+	defer unlock()
+
 	a.performAutoBackup("upload_certificate")
 
 	a.mu.RLock()
 	certificateService := a.certificateService
-	encryptionKey := make([]byte, len(a.masterKey))
-	copy(encryptionKey, a.masterKey)
+	encryptionKey := a.masterKey.Clone()
 	a.mu.RUnlock()
+	defer encryptionKey.Destroy()
 
 	if certificateService == nil {
-		return fmt.Errorf("certificate service not initialized")
+		return nil, fmt.Errorf("certificate service not initialized")
 	}
 
-	if err := certificateService.UploadCertificate(a.ctx, hostname, certPEM, encryptionKey); err != nil {
+	result, err := certificateService.UploadCertificate(a.ctx, hostname, certPEM, encryptionKey.Bytes())
+	a.recordActivity("upload_certificate", hostname, err)
+	if err != nil {
 		log.Error("certificate upload failed", logger.Err(err))
-		return err
+		return nil, err
 	}
 
-	log.Info("certificate uploaded successfully")
-	return nil
+	log.Info("certificate uploaded successfully", slog.Int("warnings", len(result.Warnings.Warnings)))
+	return result, nil
 }
 
 // PreviewCertificateUpload validates a signed certificate and returns its metadata
@@ -91,15 +105,15 @@ func (a *App) PreviewCertificateUpload(hostname, certPEM string) (*models.Certif
 
 	a.mu.RLock()
 	certificateService := a.certificateService
-	encryptionKey := make([]byte, len(a.masterKey))
-	copy(encryptionKey, a.masterKey)
+	encryptionKey := a.masterKey.Clone()
 	a.mu.RUnlock()
+	defer encryptionKey.Destroy()
 
 	if certificateService == nil {
 		return nil, fmt.Errorf("certificate service not initialized")
 	}
 
-	preview, err := certificateService.PreviewCertificateUpload(a.ctx, hostname, certPEM, encryptionKey)
+	preview, err := certificateService.PreviewCertificateUpload(a.ctx, hostname, certPEM, encryptionKey.Bytes())
 	if err != nil {
 		log.Error("certificate upload preview failed", logger.Err(err))
 		return nil, err
@@ -110,9 +124,9 @@ func (a *App) PreviewCertificateUpload(hostname, certPEM string) (*models.Certif
 }
 
 // ImportCertificate imports certificate with private key
-func (a *App) ImportCertificate(req models.ImportRequest) error {
+func (a *App) ImportCertificate(req models.ImportRequest) (*models.CertificateResult, error) {
 	if err := a.requireSetupComplete(); err != nil {
-		return err
+		return nil, err
 	}
 
 	_, log := logger.WithOperation(a.ctx, "import_certificate")
@@ -120,21 +134,23 @@ func (a *App) ImportCertificate(req models.ImportRequest) error {
 
 	a.mu.RLock()
 	certificateService := a.certificateService
-	encryptionKey := make([]byte, len(a.masterKey))
-	copy(encryptionKey, a.masterKey)
+	encryptionKey := a.masterKey.Clone()
 	a.mu.RUnlock()
+	defer encryptionKey.Destroy()
 
 	if certificateService == nil {
-		return fmt.Errorf("certificate service not initialized")
+		return nil, fmt.Errorf("certificate service not initialized")
 	}
 
-	if err := certificateService.ImportCertificate(a.ctx, req, encryptionKey); err != nil {
+	result, err := certificateService.ImportCertificate(a.ctx, req, encryptionKey.Bytes())
+	a.recordActivity("import_certificate", "", err)
+	if err != nil {
 		log.Error("certificate import failed", logger.Err(err))
-		return err
+		return nil, err
 	}
 
-	log.Info("certificate imported successfully")
-	return nil
+	log.Info("certificate imported successfully", slog.Int("warnings", len(result.Warnings.Warnings)))
+	return result, nil
 }
 
 // ListCertificates returns filtered and sorted certificate list
@@ -165,6 +181,38 @@ func (a *App) ListCertificates(filter models.CertificateFilter) ([]*models.Certi
 	return certs, nil
 }
 
+// ListCertificatePage returns one page (filter.Offset, filter.Limit) of the
+// filtered and sorted certificate list with the number of matching certificates
+// Does NOT require encryption key - read-only operation
+func (a *App) ListCertificatePage(filter models.CertificateFilter) (*models.CertificatePage, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	log := logger.WithComponent("app")
+	log.Debug("listing certificate page", slog.Any("filter", filter))
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	page, err := certificateService.ListCertificatePage(a.ctx, filter)
+	if err != nil {
+		log.Error("list certificate page failed", logger.Err(err))
+		return nil, err
+	}
+
+	log.Debug("listed certificate page",
+		slog.Int("count", len(page.Certificates)),
+		slog.Int("total", page.Total),
+	)
+	return page, nil
+}
+
 // GetCertificate returns detailed certificate information
 // Does NOT require encryption key - read-only operation
 func (a *App) GetCertificate(hostname string) (*models.Certificate, error) {
@@ -192,10 +240,181 @@ func (a *App) GetCertificate(hostname string) (*models.Certificate, error) {
 	return cert, nil
 }
 
+// GetCertificateBySerial returns the certificate whose active certificate has the given
+// serial number (hex, separators allowed). Used when a browser error or log line gives the
+// serial but not the hostname.
+// Does NOT require encryption key - read-only operation
+func (a *App) GetCertificateBySerial(serial string) (*models.Certificate, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	log := logger.WithComponent("app")
+	log.Debug("looking up certificate by serial", slog.String("serial", serial))
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	cert, err := certificateService.GetCertificateBySerial(a.ctx, serial)
+	if err != nil {
+		log.Error("get certificate by serial failed", slog.String("serial", serial), logger.Err(err))
+		return nil, err
+	}
+
+	return cert, nil
+}
+
+// FindCertificatesBySAN returns the certificates whose active certificate or pending CSR
+// covers the given DNS name or IP address, including wildcard matches.
+// Does NOT require encryption key - read-only operation
+func (a *App) FindCertificatesBySAN(san string) ([]*models.CertificateListItem, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	log := logger.WithComponent("app")
+	log.Debug("finding certificates by SAN", slog.String("san", san))
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	certs, err := certificateService.FindCertificatesBySAN(a.ctx, san)
+	if err != nil {
+		log.Error("find certificates by SAN failed", slog.String("san", san), logger.Err(err))
+		return nil, err
+	}
+
+	log.Debug("found certificates by SAN", slog.Int("count", len(certs)))
+	return certs, nil
+}
+
+// GetIssuerExpiries returns the intermediate and root certificates that issued the stored
+// certificates, soonest expiry first, with the hostnames that depend on each.
+// AIA is only used for certificates without a stored chain, and never in air-gapped mode.
+// Does NOT require encryption key - read-only operation
+func (a *App) GetIssuerExpiries() ([]models.IssuerExpiry, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	log := logger.WithComponent("app")
+	log.Debug("getting issuer expiries")
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	issuers, err := certificateService.GetIssuerExpiries(a.ctx, a.aiaAllowed())
+	if err != nil {
+		log.Error("get issuer expiries failed", logger.Err(err))
+		return nil, err
+	}
+
+	return issuers, nil
+}
+
+// PreviewStatusAt returns what the certificate statuses would be at the given
+// date (Unix timestamp): which certificates will be expiring or expired, and
+// which of them have no renewal in progress yet. Nothing is modified.
+// Does NOT require encryption key - read-only operation
+func (a *App) PreviewStatusAt(date int64) (*models.StatusPreview, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+	if date <= 0 {
+		return nil, fmt.Errorf("preview date is required")
+	}
+
+	log := logger.WithComponent("app")
+	log.Debug("previewing certificate statuses", slog.Int64("date", date))
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	preview, err := certificateService.PreviewStatusAt(a.ctx, time.Unix(date, 0))
+	if err != nil {
+		log.Error("preview status failed", logger.Err(err))
+		return nil, err
+	}
+
+	return preview, nil
+}
+
+// EvaluateChainTrust validates the certificate's chain (stored at upload, or fetched via AIA
+// unless air-gapped) against the bundled Mozilla root snapshot and the OS trust store, and
+// reports which would trust it.
+// Does NOT require encryption key - read-only operation
+func (a *App) EvaluateChainTrust(hostname string) (*models.ChainTrustResult, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	_, log := logger.WithOperation(a.ctx, "evaluate_chain_trust")
+	log = logger.WithHostname(log, hostname)
+	log.Info("evaluating chain trust")
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	result, err := certificateService.EvaluateChainTrust(a.ctx, hostname, a.aiaAllowed())
+	if err != nil {
+		log.Error("evaluate chain trust failed", logger.Err(err))
+		return nil, err
+	}
+
+	for _, store := range result.Stores {
+		log.Info("chain trust evaluated",
+			slog.String("store", store.Store),
+			slog.Bool("trusted", store.Trusted),
+			slog.String("chain_source", result.ChainSource),
+		)
+	}
+	return result, nil
+}
+
+// aiaAllowed reports whether chains may be fetched over the network via AIA
+// (and endpoints probed). Disabled in air-gapped mode.
+func (a *App) aiaAllowed() bool {
+	a.mu.RLock()
+	configService := a.configService
+	a.mu.RUnlock()
+
+	if configService == nil {
+		return true
+	}
+	cfg, err := configService.GetConfig(a.ctx)
+	return err != nil || cfg.AirGapped != 1
+}
+
 // GetCertificateChain returns the certificate chain for a hostname
 // Fetches chain via AIA (Authority Information Access) from the leaf certificate
+// When the fetch fails, the partial chain comes with per-URL diagnostics
 // Does NOT require encryption key - read-only operation
-func (a *App) GetCertificateChain(hostname string) ([]models.ChainCertificateInfo, error) {
+func (a *App) GetCertificateChain(hostname string) (*models.CertificateChain, error) {
 	if err := a.requireSetupOnly(); err != nil {
 		return nil, err
 	}
@@ -217,21 +436,37 @@ func (a *App) GetCertificateChain(hostname string) ([]models.ChainCertificateInf
 		return nil, err
 	}
 
-	log.Debug("certificate chain retrieved", slog.String("hostname", hostname), slog.Int("count", len(chain)))
+	if chain.Diagnostics != nil {
+		log.Warn("certificate chain incomplete",
+			slog.String("hostname", hostname),
+			slog.String("cause", chain.Diagnostics.Cause),
+			slog.String("summary", chain.Diagnostics.Summary),
+		)
+	}
+
+	log.Debug("certificate chain retrieved", slog.String("hostname", hostname), slog.Int("count", len(chain.Certificates)))
 	return chain, nil
 }
 
-// DeleteCertificate deletes a certificate
+// DeleteCertificate moves a certificate to the trash, or deletes it
+// permanently when the trash retention is 0 days
 // Does NOT require encryption key - deletion doesn't need decryption
 func (a *App) DeleteCertificate(hostname string) error {
 	if err := a.requireSetupOnly(); err != nil {
 		return err
 	}
 
+	if err := a.requireFreshBackup("delete_certificate"); err != nil {
+		return err
+	}
+
 	_, log := logger.WithOperation(a.ctx, "delete_certificate")
 	log = logger.WithHostname(log, hostname)
 	log.Info("deleting certificate")
 
+	unlock := a.lockHostname(hostname)
+	defer unlock()
+
 	a.performAutoBackup("delete_certificate")
 
 	a.mu.RLock()
@@ -242,7 +477,9 @@ func (a *App) DeleteCertificate(hostname string) error {
 		return fmt.Errorf("certificate service not initialized")
 	}
 
-	if err := certificateService.DeleteCertificate(a.ctx, hostname); err != nil {
+	err := certificateService.DeleteCertificate(a.ctx, hostname)
+	a.recordActivity("delete_certificate", hostname, err)
+	if err != nil {
 		log.Error("delete certificate failed", logger.Err(err))
 		return err
 	}
@@ -251,7 +488,8 @@ func (a *App) DeleteCertificate(hostname string) error {
 	return nil
 }
 
-// ClearPendingCSR removes the pending CSR data from a certificate while keeping the active certificate
+// ClearPendingCSR cancels a renewal: removes the pending CSR data from a certificate while keeping
+// the active certificate. Entries without an active certificate must use CancelNewRequest.
 // Does NOT require encryption key - no decryption needed
 func (a *App) ClearPendingCSR(hostname string) error {
 	if err := a.requireSetupOnly(); err != nil {
@@ -262,17 +500,25 @@ func (a *App) ClearPendingCSR(hostname string) error {
 	log = logger.WithHostname(log, hostname)
 	log.Info("clearing pending CSR")
 
+	unlock := a.lockHostname(hostname)
+	defer unlock()
+
 	a.performAutoBackup("clear_pending_csr")
 
 	a.mu.RLock()
 	certificateService := a.certificateService
+	// Empty while locked; only used to recheck the key status
+	encryptionKey := a.masterKey.Clone()
 	a.mu.RUnlock()
+	defer encryptionKey.Destroy()
 
 	if certificateService == nil {
 		return fmt.Errorf("certificate service not initialized")
 	}
 
-	if err := certificateService.ClearPendingCSR(a.ctx, hostname); err != nil {
+	err := certificateService.ClearPendingCSR(a.ctx, hostname, encryptionKey.Bytes())
+	a.recordActivity("clear_pending_csr", hostname, err)
+	if err != nil {
 		log.Error("clear pending CSR failed", logger.Err(err))
 		return err
 	}
@@ -281,6 +527,42 @@ func (a *App) ClearPendingCSR(hostname string) error {
 	return nil
 }
 
+// CancelNewRequest removes a certificate entry whose first CSR has not been answered yet.
+// Refused when the entry has an active certificate (use ClearPendingCSR to cancel a renewal).
+// Does NOT require encryption key - no decryption needed
+func (a *App) CancelNewRequest(hostname string) error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	_, log := logger.WithOperation(a.ctx, "cancel_new_request")
+	log = logger.WithHostname(log, hostname)
+	log.Info("cancelling new certificate request")
+
+	unlock := a.lockHostname(hostname)
+	defer unlock()
+
+	a.performAutoBackup("cancel_new_request")
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return fmt.Errorf("certificate service not initialized")
+	}
+
+	err := certificateService.CancelNewRequest(a.ctx, hostname)
+	a.recordActivity("cancel_new_request", hostname, err)
+	if err != nil {
+		log.Error("cancel new request failed", logger.Err(err))
+		return err
+	}
+
+	log.Info("new certificate request cancelled successfully")
+	return nil
+}
+
 // SetCertificateReadOnly sets the read-only status of a certificate
 func (a *App) SetCertificateReadOnly(hostname string, readOnly bool) error {
 	if err := a.requireSetupOnly(); err != nil {
@@ -293,6 +575,9 @@ func (a *App) SetCertificateReadOnly(hostname string, readOnly bool) error {
 		slog.Bool("read_only", readOnly),
 	)
 
+	unlock := a.lockHostname(hostname)
+	defer unlock()
+
 	a.mu.RLock()
 	certificateService := a.certificateService
 	a.mu.RUnlock()
@@ -301,7 +586,9 @@ func (a *App) SetCertificateReadOnly(hostname string, readOnly bool) error {
 		return fmt.Errorf("certificate service not initialized")
 	}
 
-	if err := certificateService.SetCertificateReadOnly(a.ctx, hostname, readOnly); err != nil {
+	err := certificateService.SetCertificateReadOnly(a.ctx, hostname, readOnly)
+	a.recordActivity("set_read_only", hostname, err)
+	if err != nil {
 		log.Error("set certificate read-only failed",
 			slog.String("hostname", hostname),
 			logger.Err(err),
@@ -325,6 +612,9 @@ func (a *App) UpdateCertificateNote(hostname string, note string) error {
 	log := logger.WithComponent("app")
 	log.Info("updating certificate note", slog.String("hostname", hostname))
 
+	unlock := a.lockHostname(hostname)
+	defer unlock()
+
 	a.mu.RLock()
 	certificateService := a.certificateService
 	a.mu.RUnlock()
@@ -333,7 +623,9 @@ func (a *App) UpdateCertificateNote(hostname string, note string) error {
 		return fmt.Errorf("certificate service not initialized")
 	}
 
-	if err := certificateService.UpdateCertificateNote(a.ctx, hostname, note); err != nil {
+	err := certificateService.UpdateCertificateNote(a.ctx, hostname, note)
+	a.recordActivity("update_note", hostname, err)
+	if err != nil {
 		log.Error("update certificate note failed",
 			slog.String("hostname", hostname),
 			logger.Err(err),
@@ -354,6 +646,9 @@ func (a *App) UpdatePendingNote(hostname string, note string) error {
 	log := logger.WithComponent("app")
 	log.Info("updating pending note", slog.String("hostname", hostname))
 
+	unlock := a.lockHostname(hostname)
+	defer unlock()
+
 	a.mu.RLock()
 	certificateService := a.certificateService
 	a.mu.RUnlock()
@@ -362,7 +657,9 @@ func (a *App) UpdatePendingNote(hostname string, note string) error {
 		return fmt.Errorf("certificate service not initialized")
 	}
 
-	if err := certificateService.UpdatePendingNote(a.ctx, hostname, note); err != nil {
+	err := certificateService.UpdatePendingNote(a.ctx, hostname, note)
+	a.recordActivity("update_pending_note", hostname, err)
+	if err != nil {
 		log.Error("update pending note failed",
 			slog.String("hostname", hostname),
 			logger.Err(err),
@@ -374,6 +671,74 @@ func (a *App) UpdatePendingNote(hostname string, note string) error {
 	return nil
 }
 
+// MarkCSRSubmitted records that the pending CSR was submitted to the CA, with
+// the CA's order/reference ID if there is one. A submission time of 0 means now.
+// The submission is cleared when the signed certificate is uploaded or the CSR
+// is replaced.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) MarkCSRSubmitted(hostname string, submission models.CSRSubmission) error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("recording CSR submission", slog.String("hostname", hostname))
+
+	unlock := a.lockHostname(hostname)
+	defer unlock()
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return fmt.Errorf("certificate service not initialized")
+	}
+
+	err := certificateService.MarkCSRSubmitted(a.ctx, hostname, submission)
+	a.recordActivity("mark_csr_submitted", hostname, err)
+	if err != nil {
+		log.Error("record CSR submission failed",
+			slog.String("hostname", hostname),
+			logger.Err(err),
+		)
+		return err
+	}
+
+	log.Info("CSR submission recorded", slog.String("hostname", hostname))
+	return nil
+}
+
+// ScanNotesForSecrets reports stored notes that look like they hold a private key
+// or a password. New notes are checked when saved; this finds the older ones.
+func (a *App) ScanNotesForSecrets() (*models.NoteScanResult, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	log := logger.WithComponent("app")
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	result, err := certificateService.ScanNotesForSecrets(a.ctx)
+	if err != nil {
+		log.Error("note scan failed", logger.Err(err))
+		return nil, err
+	}
+
+	log.Info("notes scanned for secrets",
+		slog.Int("scanned", result.Scanned),
+		slog.Int("flagged", len(result.Reports)),
+	)
+	return result, nil
+}
+
 // GetCertificateHistory returns the activity history for a certificate
 func (a *App) GetCertificateHistory(hostname string, limit int) ([]models.HistoryEntry, error) {
 	if err := a.requireSetupOnly(); err != nil {
@@ -406,3 +771,231 @@ func (a *App) GetCertificateHistory(hostname string, limit int) ([]models.Histor
 	)
 	return history, nil
 }
+
+// GetGlobalHistory returns one page of the activity history across all
+// certificates, most recent first, filtered by event type and date range
+// (from inclusive, to exclusive, Unix seconds). limit is capped at 500.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) GetGlobalHistory(filter models.HistoryFilter, limit, offset int) (*models.HistoryPage, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	log := logger.WithComponent("app")
+	log.Debug("getting global history", slog.Int("limit", limit), slog.Int("offset", offset))
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	page, err := certificateService.ListHistory(a.ctx, filter, limit, offset)
+	if err != nil {
+		log.Error("get global history failed", logger.Err(err))
+		return nil, err
+	}
+	return page, nil
+}
+
+// GetRenewalLeadTimes returns how long each CA took, from the history, between
+// CSR generation and the upload of the signed certificate, with a suggested
+// "expiring soon" threshold for each
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) GetRenewalLeadTimes() (*models.LeadTimeReport, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	log := logger.WithComponent("app")
+	log.Debug("computing renewal lead times")
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	report, err := certificateService.GetRenewalLeadTimes(a.ctx)
+	if err != nil {
+		log.Error("compute renewal lead times failed", logger.Err(err))
+		return nil, err
+	}
+	return report, nil
+}
+
+// FindHostnameDuplicates returns stored hostnames that are not in normalized form,
+// grouped by normalized hostname, so the user can merge near-duplicates
+// Does NOT require encryption key - read-only operation
+func (a *App) FindHostnameDuplicates() ([]models.HostnameDuplicateGroup, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	log := logger.WithComponent("app")
+	log.Debug("finding hostname duplicates")
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	groups, err := certificateService.FindHostnameDuplicates(a.ctx)
+	if err != nil {
+		log.Error("find hostname duplicates failed", logger.Err(err))
+		return nil, err
+	}
+
+	log.Debug("hostname duplicates found", slog.Int("groups", len(groups)))
+	return groups, nil
+}
+
+// MergeHostnameDuplicates keeps the certificate stored under keep, renames it to its
+// normalized hostname, and deletes the other near-duplicates of the same group.
+// Returns the normalized hostname of the kept certificate.
+// Does NOT require encryption key - no decryption needed
+func (a *App) MergeHostnameDuplicates(keep string) (string, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return "", err
+	}
+
+	if err := a.requireFreshBackup("merge_hostname_duplicates"); err != nil {
+		return "", err
+	}
+
+	_, log := logger.WithOperation(a.ctx, "merge_hostname_duplicates")
+	log = logger.WithHostname(log, keep)
+	log.Info("merging hostname duplicates")
+
+	unlock := a.lockHostname(keep)
+	defer unlock()
+
+	a.performAutoBackup("merge_hostname_duplicates")
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return "", fmt.Errorf("certificate service not initialized")
+	}
+
+	normalized, err := certificateService.MergeHostnameDuplicates(a.ctx, keep)
+	a.recordActivity("merge_hostname_duplicates", keep, err)
+	if err != nil {
+		log.Error("merge hostname duplicates failed", logger.Err(err))
+		return "", err
+	}
+
+	log.Info("hostname duplicates merged", slog.String("normalized", normalized))
+	return normalized, nil
+}
+
+// BulkUpdateCertificates applies a patch (note replace/append, read-only flag) to
+// several certificates in one transaction and reports the outcome per hostname.
+// Nothing is applied when any certificate fails.
+// Does NOT require encryption key - no decryption needed
+func (a *App) BulkUpdateCertificates(hostnames []string, patch models.BulkPatch) (*models.BulkUpdateResult, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	_, log := logger.WithOperation(a.ctx, "bulk_update_certificates")
+	log.Info("updating certificates in bulk",
+		slog.Int("count", len(hostnames)),
+		slog.Bool("note", patch.Note != nil),
+		slog.Bool("read_only", patch.ReadOnly != nil),
+	)
+
+	unlock := a.lockHostnames(hostnames)
+	defer unlock()
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	result, err := certificateService.BulkUpdateCertificates(a.ctx, hostnames, patch)
+	if err != nil {
+		a.recordActivity("bulk_update", "", err)
+		log.Error("bulk update failed", logger.Err(err))
+		return nil, err
+	}
+	for _, r := range result.Results {
+		var hostErr error
+		if r.Error != "" {
+			hostErr = errors.New(r.Error)
+		} else if !r.Success {
+			hostErr = errors.New("not applied")
+		}
+		a.recordActivity("bulk_update", r.Hostname, hostErr)
+	}
+
+	log.Info("bulk update finished",
+		slog.Bool("applied", result.Applied),
+		slog.Int("count", len(result.Results)),
+	)
+	return result, nil
+}
+
+// GenerateCSRBulk generates keys and CSRs for many hostnames (a list, or a
+// pasted list or CSV with optional SANs) sharing one subject and key type, in
+// one transaction, and reports the outcome per hostname. Nothing is stored
+// when any hostname fails.
+func (a *App) GenerateCSRBulk(req models.BulkCSRRequest) (*models.BulkCSRResult, error) {
+	if err := a.requireSetupComplete(); err != nil {
+		return nil, err
+	}
+
+	_, log := logger.WithOperation(a.ctx, "generate_csr_bulk")
+	log.Info("generating CSRs in bulk",
+		slog.Int("hostnames", len(req.Hostnames)),
+		slog.Int("list_size", len(req.List)),
+		slog.Int("key_size", req.Template.KeySize),
+	)
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	encryptionKey := a.masterKey.Clone()
+	a.mu.RUnlock()
+	defer encryptionKey.Destroy()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	result, err := certificateService.GenerateCSRBatch(a.ctx, req, encryptionKey.Bytes())
+	if err != nil {
+		a.recordActivity("generate_csr_bulk", "", err)
+		log.Error("bulk CSR generation failed", logger.Err(err))
+		return nil, err
+	}
+	for _, r := range result.Results {
+		var hostErr error
+		if r.Error != "" {
+			hostErr = errors.New(r.Error)
+		} else if !r.Success {
+			hostErr = errors.New("not generated")
+		}
+		a.recordActivity("generate_csr_bulk", r.Hostname, hostErr)
+	}
+
+	// Replace the pooled keys it may have taken
+	a.refillKeyPool()
+
+	log.Info("bulk CSR generation finished",
+		slog.Bool("generated", result.Generated),
+		slog.Int("count", len(result.Results)),
+	)
+	return result, nil
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"log/slog"
+	"time"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/logger"
+)
+
+// ============================================================================
+// Chain Cache
+// ============================================================================
+
+// applyStoredChainCacheTTL applies the AIA cache TTL stored in config. A
+// database without a config row keeps the default.
+func (a *App) applyStoredChainCacheTTL() {
+	cfg, err := a.db.Queries().GetConfig(a.ctx)
+	if err != nil {
+		return
+	}
+	applyChainCacheTTL(int(cfg.AiaCacheTtlMinutes))
+}
+
+// applyChainCacheTTL changes how long issuer certificates fetched via AIA are
+// reused; 0 disables the cache.
+func applyChainCacheTTL(minutes int) {
+	ttl := time.Duration(minutes) * time.Minute
+	if crypto.AIACacheStats().TTLSeconds == int64(ttl/time.Second) {
+		return
+	}
+	crypto.SetAIACacheTTL(ttl)
+	logger.WithComponent("app").Info("chain cache TTL applied", slog.Int("ttl_minutes", minutes))
+}
+
+// ClearChainCache drops the issuer certificates cached from AIA fetches, so
+// chains are fetched again, e.g. after a CA rotated its intermediates. Returns
+// the number of certificates dropped.
+// Does NOT require encryption key - only in-memory public certificates
+func (a *App) ClearChainCache() (int, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return 0, err
+	}
+
+	removed := crypto.ClearAIACache()
+	logger.WithComponent("app").Info("chain cache cleared", slog.Int("removed", removed))
+	return removed, nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+
+	"paddockcontrol-desktop/internal/crypto"
+)
+
+func TestApplyStoredChainCacheTTL(t *testing.T) {
+	app := setupConfiguredApp(t)
+	t.Cleanup(func() { crypto.SetAIACacheTTL(crypto.DefaultAIACacheTTL) })
+
+	if _, err := app.db.DB().Exec("UPDATE config SET aia_cache_ttl_minutes = 5"); err != nil {
+		t.Fatalf("failed to set aia_cache_ttl_minutes: %v", err)
+	}
+	app.applyStoredChainCacheTTL()
+
+	if got := app.GetHealthStatus().ChainCache.TTLSeconds; got != 300 {
+		t.Errorf("chain cache TTL = %ds, want 300s", got)
+	}
+}
+
+func TestClearChainCache(t *testing.T) {
+	if _, err := setupTestApp(t).ClearChainCache(); err == nil {
+		t.Error("expected ClearChainCache to require setup")
+	}
+
+	app := setupConfiguredApp(t)
+	if _, err := app.ClearChainCache(); err != nil {
+		t.Fatalf("ClearChainCache() error = %v", err)
+	}
+	if entries := app.GetHealthStatus().ChainCache.Entries; entries != 0 {
+		t.Errorf("expected an empty chain cache, got %d entries", entries)
+	}
+}
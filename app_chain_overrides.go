@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// ============================================================================
+// Chain Overrides
+// ============================================================================
+
+// ListChainOverrides returns the chain overrides set for certificates and
+// issuing CAs.
+// Does NOT require encryption key - read-only operation
+func (a *App) ListChainOverrides() ([]models.ChainOverride, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	return certificateService.ListChainOverrides(a.ctx)
+}
+
+// SetCertificateChainOverride sets where the issuer of a certificate is taken
+// from when its chain is built, for CAs whose AIA URL is wrong or internal-only:
+// an issuer URL, or the pasted PEM of the issuer certificate. It is used before
+// the URL in the certificate; an empty override removes it.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) SetCertificateChainOverride(hostname, override string) error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("setting certificate chain override",
+		slog.String("hostname", hostname),
+		slog.Bool("clear", override == ""),
+	)
+
+	unlock := a.lockHostname(hostname)
+	defer unlock()
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return fmt.Errorf("certificate service not initialized")
+	}
+
+	err := certificateService.SetCertificateChainOverride(a.ctx, hostname, override)
+	a.recordActivity("set_chain_override", hostname, err)
+	if err != nil {
+		log.Error("set certificate chain override failed",
+			slog.String("hostname", hostname),
+			logger.Err(err),
+		)
+		return err
+	}
+	return nil
+}
+
+// SetIssuerChainOverride sets where the issuer of every certificate issued by
+// the CA with distinguished name issuerDN (ChainCertificateInfo.issuer_dn) is
+// taken from, like SetCertificateChainOverride. A certificate's own override
+// takes precedence. An empty override removes it.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) SetIssuerChainOverride(issuerDN, override string) error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("setting issuer chain override",
+		slog.String("issuer", issuerDN),
+		slog.Bool("clear", override == ""),
+	)
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return fmt.Errorf("certificate service not initialized")
+	}
+
+	err := certificateService.SetIssuerChainOverride(a.ctx, issuerDN, override)
+	a.recordActivity("set_issuer_chain_override", "", err)
+	if err != nil {
+		log.Error("set issuer chain override failed",
+			slog.String("issuer", issuerDN),
+			logger.Err(err),
+		)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/services"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ============================================================================
+// Certificate Request Files (intake)
+// ============================================================================
+
+// SelectCSRRequestFile opens a file dialog for the user to select a
+// certificate request file. Returns the selected file path, or empty string if
+// cancelled.
+func (a *App) SelectCSRRequestFile() (string, error) {
+	path, err := wailsruntime.OpenFileDialog(a.ctx, wailsruntime.OpenDialogOptions{
+		Title: "Select Certificate Request File",
+		Filters: []wailsruntime.FileFilter{
+			{DisplayName: "Request Files (*.yaml, *.yml, *.json)", Pattern: "*.yaml;*.yml;*.json"},
+			{DisplayName: "All Files (*.*)", Pattern: "*.*"},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("file dialog error: %w", err)
+	}
+	return path, nil
+}
+
+// ImportCSRRequestFile reads a request file filled by a colleague (hostname,
+// SANs, justification and requester, in YAML or JSON) and returns it checked,
+// to pre-fill the CSR form. Nothing is stored: the requester is recorded with
+// the certificate when the form sends it back in CSRRequest.Requester.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) ImportCSRRequestFile(path string) (*models.CSRIntake, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("importing certificate request file", slog.String("path", path))
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	data, err := readCSRRequestFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	intake, err := certificateService.ParseCSRIntake(a.ctx, data)
+	if err != nil {
+		log.Warn("certificate request file refused", slog.String("path", path), logger.Err(err))
+		return nil, err
+	}
+	return intake, nil
+}
+
+// readCSRRequestFile reads a request file, refusing one too large to be one
+func readCSRRequestFile(path string) ([]byte, error) {
+	if path == "" {
+		return nil, fmt.Errorf("request file path is empty")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open request file: %w", err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, services.MaxCSRIntakeFileSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request file: %w", err)
+	}
+	if len(data) > services.MaxCSRIntakeFileSize {
+		return nil, fmt.Errorf("request file is larger than %d KiB", services.MaxCSRIntakeFileSize>>10)
+	}
+	return data, nil
+}
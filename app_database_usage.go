@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// ============================================================================
+// Database Usage
+// ============================================================================
+
+// minHistoryRetentionDays is the youngest certificate history a cleanup may
+// delete, so recent activity always stays auditable
+const minHistoryRetentionDays = 30
+
+// GetDatabaseUsage reports the size of the database against the configured
+// soft quota, with the biggest contributors and the cleanup that shrinks each.
+// Does NOT require encryption key - read-only operation
+func (a *App) GetDatabaseUsage() (*models.DatabaseUsage, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	database := a.db
+	a.mu.RUnlock()
+
+	if database == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	return databaseUsage(a.ctx, database)
+}
+
+// CleanupDatabase runs one database cleanup action. Pruning actions delete
+// entries older than olderThanDays and then compact the file so the space is
+// released on disk; certificate history younger than 30 days is never pruned.
+// Does NOT require encryption key - no private key is read or written
+func (a *App) CleanupDatabase(action string, olderThanDays int) (*models.DatabaseCleanupResult, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	switch action {
+	case models.DatabaseCleanupPruneHistory:
+		if olderThanDays < minHistoryRetentionDays {
+			return nil, fmt.Errorf("history younger than %d days cannot be pruned", minHistoryRetentionDays)
+		}
+	case models.DatabaseCleanupPruneUpdates:
+		if olderThanDays < 0 {
+			return nil, fmt.Errorf("olderThanDays must not be negative")
+		}
+	case models.DatabaseCleanupCompact:
+	default:
+		return nil, fmt.Errorf("unknown cleanup action: %s", action)
+	}
+
+	_, log := logger.WithOperation(a.ctx, "cleanup_database")
+	log = log.With(slog.String("action", action))
+
+	a.mu.RLock()
+	database := a.db
+	a.mu.RUnlock()
+
+	if database == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	if action != models.DatabaseCleanupCompact {
+		a.performAutoBackup("cleanup_database")
+	}
+
+	result, err := cleanupDatabase(a.ctx, database, action, a.appClock().Now().AddDate(0, 0, -olderThanDays).Unix())
+	a.recordActivity("cleanup_database", "", err)
+	if err != nil {
+		log.Error("database cleanup failed", logger.Err(err))
+		return nil, err
+	}
+
+	log.Info("database cleanup completed",
+		slog.Int64("rows_deleted", result.RowsDeleted),
+		slog.Int64("size_before", result.SizeBefore),
+		slog.Int64("size_after", result.SizeAfter),
+	)
+	return result, nil
+}
+
+// cleanupDatabase deletes the rows an action targets (created before cutoff)
+// and compacts the file.
+func cleanupDatabase(ctx context.Context, database *db.Database, action string, cutoff int64) (*models.DatabaseCleanupResult, error) {
+	before, err := database.Usage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := &models.DatabaseCleanupResult{Action: action, SizeBefore: before.TotalBytes}
+
+	switch action {
+	case models.DatabaseCleanupPruneHistory:
+		result.RowsDeleted, err = database.Queries().DeleteHistoryBefore(ctx, cutoff)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prune history: %w", err)
+		}
+	case models.DatabaseCleanupPruneUpdates:
+		result.RowsDeleted, err = database.Queries().DeleteUpdateHistoryBefore(ctx, cutoff)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prune update history: %w", err)
+		}
+	}
+
+	if err := database.Compact(ctx); err != nil {
+		return nil, err
+	}
+
+	after, err := database.Usage(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result.SizeAfter = after.TotalBytes
+	return result, nil
+}
+
+// databaseUsage measures the database and groups its tables into the
+// contributors the user can act on.
+func databaseUsage(ctx context.Context, database *db.Database) (*models.DatabaseUsage, error) {
+	cfg, err := database.Queries().GetConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+	usage, err := database.Usage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.DatabaseUsage{
+		SizeBytes: usage.TotalBytes,
+		WarnBytes: cfg.DbSizeWarnMb << 20,
+	}
+	result.OverQuota = result.WarnBytes > 0 && result.SizeBytes > result.WarnBytes
+
+	bytes := map[string]int64{models.DatabaseContributorFree: usage.FreeBytes}
+	if usage.Tables == nil {
+		// Without dbstat only the totals are known
+		bytes[models.DatabaseContributorOther] = usage.TotalBytes - usage.FreeBytes
+	}
+	for _, table := range usage.Tables {
+		bytes[databaseContributor(table.Table)] += table.Bytes
+	}
+
+	rows := make(map[string]int64)
+	if rows[models.DatabaseContributorCertificates], err = database.Queries().CountCertificates(ctx); err != nil {
+		return nil, fmt.Errorf("failed to count certificates: %w", err)
+	}
+	if rows[models.DatabaseContributorHistory], err = database.Queries().CountHistory(ctx, sqlc.CountHistoryParams{}); err != nil {
+		return nil, fmt.Errorf("failed to count history: %w", err)
+	}
+	if rows[models.DatabaseContributorUpdates], err = database.Queries().CountUpdateHistory(ctx); err != nil {
+		return nil, fmt.Errorf("failed to count update history: %w", err)
+	}
+
+	cleanups := map[string]string{
+		models.DatabaseContributorHistory: models.DatabaseCleanupPruneHistory,
+		models.DatabaseContributorUpdates: models.DatabaseCleanupPruneUpdates,
+		models.DatabaseContributorFree:    models.DatabaseCleanupCompact,
+	}
+	result.Contributors = []models.DatabaseContributor{}
+	for name, size := range bytes {
+		if size == 0 {
+			continue
+		}
+		result.Contributors = append(result.Contributors, models.DatabaseContributor{
+			Name:    name,
+			Bytes:   size,
+			Rows:    rows[name],
+			Cleanup: cleanups[name],
+		})
+	}
+	sort.Slice(result.Contributors, func(i, j int) bool {
+		if result.Contributors[i].Bytes != result.Contributors[j].Bytes {
+			return result.Contributors[i].Bytes > result.Contributors[j].Bytes
+		}
+		return result.Contributors[i].Name < result.Contributors[j].Name
+	})
+
+	return result, nil
+}
+
+// databaseContributor maps a table to the contributor it is reported under.
+func databaseContributor(table string) string {
+	switch table {
+	case "certificates":
+		return models.DatabaseContributorCertificates
+	case "certificate_history":
+		return models.DatabaseContributorHistory
+	case "update_history":
+		return models.DatabaseContributorUpdates
+	default:
+		return models.DatabaseContributorOther
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// seedBulkyHistory adds count history entries of about 4 KiB each, created at
+// the given time.
+func seedBulkyHistory(t *testing.T, app *App, hostname string, count int, createdAt time.Time) {
+	t.Helper()
+	message := strings.Repeat("x", 4096)
+	for i := 0; i < count; i++ {
+		if _, err := app.db.DB().Exec(
+			"INSERT INTO certificate_history (hostname, event_type, message, created_at) VALUES (?, 'csr_generated', ?, ?)",
+			hostname, message, createdAt.Unix(),
+		); err != nil {
+			t.Fatalf("failed to insert history: %v", err)
+		}
+	}
+}
+
+func TestDatabaseUsage_WarnsOverQuota(t *testing.T) {
+	app := setupConfiguredApp(t)
+	if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{Hostname: "bloat.example.com"}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	seedBulkyHistory(t, app, "bloat.example.com", 400, time.Now())
+
+	usage, err := app.GetDatabaseUsage()
+	if err != nil {
+		t.Fatalf("GetDatabaseUsage() error: %v", err)
+	}
+	if usage.OverQuota || usage.WarnBytes != 100<<20 {
+		t.Fatalf("expected the default 100 MiB quota not to be reached: %+v", usage)
+	}
+	if len(usage.Contributors) == 0 {
+		t.Fatal("expected contributors")
+	}
+	top := usage.Contributors[0]
+	if top.Name != models.DatabaseContributorHistory || top.Rows != 400 || top.Cleanup != models.DatabaseCleanupPruneHistory {
+		t.Errorf("expected history as the biggest contributor, got %+v", top)
+	}
+
+	if _, err := app.db.DB().Exec("UPDATE config SET db_size_warn_mb = 1"); err != nil {
+		t.Fatalf("failed to lower the quota: %v", err)
+	}
+	health := app.GetHealthStatus()
+	if health.DatabaseUsage == nil || !health.DatabaseUsage.OverQuota {
+		t.Fatalf("health status should report the database over quota: %+v", health.DatabaseUsage)
+	}
+	found := false
+	for _, warning := range health.Warnings {
+		if strings.Contains(warning, "over the 1 MiB warning size (largest: history)") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a database size warning, got %v", health.Warnings)
+	}
+}
+
+func TestCleanupDatabase_PrunesOldHistory(t *testing.T) {
+	app := setupConfiguredApp(t)
+	if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{Hostname: "bloat.example.com"}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	now := app.appClock().Now()
+	seedBulkyHistory(t, app, "bloat.example.com", 200, now.AddDate(-2, 0, 0))
+	seedBulkyHistory(t, app, "bloat.example.com", 5, now.AddDate(0, 0, -1))
+
+	if _, err := app.CleanupDatabase(models.DatabaseCleanupPruneHistory, 7); err == nil {
+		t.Error("expected recent history to be protected from pruning")
+	}
+	if _, err := app.CleanupDatabase("drop_everything", 365); err == nil {
+		t.Error("expected an unknown action to be rejected")
+	}
+
+	result, err := app.CleanupDatabase(models.DatabaseCleanupPruneHistory, 365)
+	if err != nil {
+		t.Fatalf("CleanupDatabase() error: %v", err)
+	}
+	if result.RowsDeleted != 200 {
+		t.Errorf("expected 200 entries pruned, got %d", result.RowsDeleted)
+	}
+	if result.SizeAfter >= result.SizeBefore {
+		t.Errorf("expected the database to shrink: %d -> %d", result.SizeBefore, result.SizeAfter)
+	}
+
+	remaining, err := app.db.Queries().CountHistory(app.ctx, sqlc.CountHistoryParams{})
+	if err != nil {
+		t.Fatalf("CountHistory() error: %v", err)
+	}
+	if remaining != 5 {
+		t.Errorf("expected the recent entries to be kept, got %d", remaining)
+	}
+}
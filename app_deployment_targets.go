@@ -0,0 +1,134 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// ============================================================================
+// Deployment Targets
+// ============================================================================
+
+// ListDeploymentTargets returns where a certificate is deployed
+// Does NOT require encryption key - read-only operation
+func (a *App) ListDeploymentTargets(hostname string) ([]models.DeploymentTarget, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	return certificateService.ListDeploymentTargets(a.ctx, hostname)
+}
+
+// AddDeploymentTarget records that a certificate is deployed on the target
+// called name (a server, a load balancer), at an optional location such as a
+// file path. While a certificate has deployment targets, DeleteCertificate
+// and BulkDeleteCertificates refuse to delete it.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) AddDeploymentTarget(hostname, name, location string) error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("adding deployment target",
+		slog.String("hostname", hostname),
+		slog.String("target", name),
+	)
+
+	unlock := a.lockHostname(hostname)
+	defer unlock()
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return fmt.Errorf("certificate service not initialized")
+	}
+
+	err := certificateService.AddDeploymentTarget(a.ctx, hostname, name, location)
+	a.recordActivity("add_deployment_target", hostname, err)
+	if err != nil {
+		log.Error("add deployment target failed", slog.String("hostname", hostname), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+// RemoveDeploymentTarget removes a deployment target (DeploymentTarget.id),
+// e.g. once the certificate has been taken off that server
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) RemoveDeploymentTarget(id int64) error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("removing deployment target", slog.Int64("id", id))
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return fmt.Errorf("certificate service not initialized")
+	}
+
+	err := certificateService.RemoveDeploymentTarget(a.ctx, id)
+	a.recordActivity("remove_deployment_target", "", err)
+	if err != nil {
+		log.Error("remove deployment target failed", slog.Int64("id", id), logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+// ProbeEndpoint connects to host (hostname when empty) on port (443 when 0),
+// sending hostname as the TLS server name, and compares the certificate the
+// server presents with the stored one, confirming what is actually deployed.
+// The presented chain is also evaluated against each trust store. Refused in
+// air-gapped mode.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) ProbeEndpoint(hostname, host string, port int) (*models.EndpointProbe, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+	if !a.aiaAllowed() {
+		return nil, fmt.Errorf("endpoint probes are disabled in air-gapped mode")
+	}
+
+	_, log := logger.WithOperation(a.ctx, "probe_endpoint")
+	log = logger.WithHostname(log, hostname)
+	log.Info("probing endpoint", slog.String("host", host), slog.Int("port", port))
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	probe, err := certificateService.ProbeEndpoint(a.ctx, hostname, host, port)
+	if err != nil {
+		log.Error("endpoint probe failed", logger.Err(err))
+		return nil, err
+	}
+
+	log.Info("endpoint probed",
+		slog.String("address", probe.Address),
+		slog.Bool("matches", probe.Matches),
+	)
+	return probe, nil
+}
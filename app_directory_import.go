@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ============================================================================
+// Directory Import
+// ============================================================================
+
+// SelectCertificateDirectory opens a directory dialog for the user to select
+// a folder of certificate and key files. Returns the selected path, or empty
+// string if cancelled.
+func (a *App) SelectCertificateDirectory() (string, error) {
+	path, err := wailsruntime.OpenDirectoryDialog(a.ctx, wailsruntime.OpenDialogOptions{
+		Title: "Select Certificate Directory",
+	})
+	if err != nil {
+		return "", fmt.Errorf("directory dialog error: %w", err)
+	}
+	return path, nil
+}
+
+// PreviewDirectoryImport scans a directory (and its subdirectories) of PEM and
+// DER files, pairs each certificate with its private key by file name or
+// public key, and reports what ImportCertificatesFromDirectory would import,
+// what conflicts with stored hostnames, what is invalid and which files are
+// skipped. Nothing is stored.
+// Does NOT require encryption key - nothing is decrypted or stored
+func (a *App) PreviewDirectoryImport(path string) (*models.DirectoryImportPreview, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	_, log := logger.WithOperation(a.ctx, "preview_directory_import")
+	log.Info("previewing directory import", slog.String("path", path))
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	preview, err := certificateService.PreviewDirectoryImport(a.ctx, path)
+	if err != nil {
+		log.Error("directory import preview failed", logger.Err(err))
+		return nil, err
+	}
+
+	log.Info("directory import preview completed",
+		slog.Int("importable", len(preview.Importable)),
+		slog.Int("conflicting", len(preview.Conflicting)),
+		slog.Int("invalid", len(preview.Invalid)),
+		slog.Int("skipped", len(preview.Skipped)),
+	)
+	return preview, nil
+}
+
+// ImportCertificatesFromDirectory imports every certificate of a directory
+// paired with its private key, as PreviewDirectoryImport lists them. Each one
+// is imported on its own, like ImportCertificate: failures are reported per
+// certificate and stored hostnames are skipped as conflicts. An auto-backup is
+// taken first.
+func (a *App) ImportCertificatesFromDirectory(path string) (*models.CertImportResult, error) {
+	if err := a.requireSetupComplete(); err != nil {
+		return nil, err
+	}
+
+	_, log := logger.WithOperation(a.ctx, "import_directory")
+	log.Info("importing certificates from directory", slog.String("path", path))
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	configService := a.configService
+	encryptionKey := a.masterKey.Clone()
+	a.mu.RUnlock()
+	defer encryptionKey.Destroy()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	a.performAutoBackup("import_directory")
+
+	result, err := certificateService.ImportDirectory(a.ctx, path, encryptionKey.Bytes())
+	a.recordActivity("import_directory", "", err)
+	if err != nil {
+		log.Error("directory import failed", logger.Err(err))
+		return nil, err
+	}
+
+	// Propose a hostname suffix when the imported hostnames do not fit the
+	// configured one, as the backup import does
+	if configService != nil && result.Imported > 0 {
+		result.SuggestedHostnameSuffix, err = configService.SuggestHostnameSuffix(a.ctx)
+		if err != nil {
+			log.Warn("failed to infer hostname suffix", logger.Err(err))
+		}
+	}
+
+	log.Info("directory import completed",
+		slog.Int("imported", result.Imported),
+		slog.Int("skipped", result.Skipped),
+		slog.Int("failed", len(result.Failed)),
+	)
+	return result, nil
+}
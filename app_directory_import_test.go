@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPreviewDirectoryImport_DoesNotRequireUnlock(t *testing.T) {
+	app := setupConfiguredApp(t)
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a certificate"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	preview, err := app.PreviewDirectoryImport(dir)
+	if err != nil {
+		t.Fatalf("PreviewDirectoryImport failed: %v", err)
+	}
+	if len(preview.Importable) != 0 || len(preview.Skipped) != 1 {
+		t.Errorf("expected only notes.txt skipped, got %+v", preview)
+	}
+}
+
+func TestImportCertificatesFromDirectory_RequiresUnlock(t *testing.T) {
+	app := setupConfiguredApp(t)
+	if _, err := app.ImportCertificatesFromDirectory(t.TempDir()); err == nil {
+		t.Fatal("expected an error while locked")
+	}
+}
+
+func TestImportCertificatesFromDirectory_EmptyDirectory(t *testing.T) {
+	app := setupUnlockedApp(t)
+	result, err := app.ImportCertificatesFromDirectory(t.TempDir())
+	if err != nil {
+		t.Fatalf("ImportCertificatesFromDirectory failed: %v", err)
+	}
+	if result.Imported != 0 || len(result.Failed) != 0 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
@@ -7,10 +7,13 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"paddockcontrol-desktop/internal/crypto"
 	"paddockcontrol-desktop/internal/logger"
 	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/services"
 
 	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
@@ -43,14 +46,15 @@ func (a *App) SaveCSRToFile(hostname string) error {
 		return err
 	}
 
+	opts := a.downloadOptions()
 	path, err := wailsruntime.SaveFileDialog(a.ctx, wailsruntime.SaveDialogOptions{
-		DefaultFilename: hostname + ".csr",
+		DefaultFilename: downloadFilename(hostname+".csr", opts),
 		Title:           "Save Certificate Signing Request",
-		Filters: []wailsruntime.FileFilter{
+		Filters: downloadFilters(opts, []wailsruntime.FileFilter{
 			{DisplayName: "CSR Files (*.csr)", Pattern: "*.csr"},
 			{DisplayName: "PEM Files (*.pem)", Pattern: "*.pem"},
 			{DisplayName: "All Files (*.*)", Pattern: "*.*"},
-		},
+		}),
 	})
 
 	if err != nil {
@@ -63,7 +67,7 @@ func (a *App) SaveCSRToFile(hostname string) error {
 		return nil
 	}
 
-	if err := os.WriteFile(path, []byte(csr), 0600); err != nil {
+	if err := writeDownload(path, []byte(csr), 0600, opts); err != nil {
 		log.Error("failed to write CSR file", slog.String("path", path), logger.Err(err))
 		return fmt.Errorf("failed to write file: %w", err)
 	}
@@ -96,14 +100,23 @@ func (a *App) SaveCertificateToFile(hostname string) error {
 		return err
 	}
 
+	return a.saveCertificatePEM(hostname, cert)
+}
+
+// saveCertificatePEM prompts the user for a destination and writes a
+// certificate PEM there.
+func (a *App) saveCertificatePEM(hostname, cert string) error {
+	log := logger.WithComponent("app")
+
+	opts := a.downloadOptions()
 	path, err := wailsruntime.SaveFileDialog(a.ctx, wailsruntime.SaveDialogOptions{
-		DefaultFilename: hostname + ".crt",
+		DefaultFilename: downloadFilename(hostname+".crt", opts),
 		Title:           "Save Certificate",
-		Filters: []wailsruntime.FileFilter{
+		Filters: downloadFilters(opts, []wailsruntime.FileFilter{
 			{DisplayName: "Certificate Files (*.crt)", Pattern: "*.crt"},
 			{DisplayName: "PEM Files (*.pem)", Pattern: "*.pem"},
 			{DisplayName: "All Files (*.*)", Pattern: "*.*"},
-		},
+		}),
 	})
 
 	if err != nil {
@@ -116,7 +129,7 @@ func (a *App) SaveCertificateToFile(hostname string) error {
 		return nil
 	}
 
-	if err := os.WriteFile(path, []byte(cert), 0644); err != nil {
+	if err := writeDownload(path, []byte(cert), 0644, opts); err != nil {
 		log.Error("failed to write certificate file", slog.String("path", path), logger.Err(err))
 		return fmt.Errorf("failed to write file: %w", err)
 	}
@@ -126,15 +139,17 @@ func (a *App) SaveCertificateToFile(hostname string) error {
 }
 
 // SaveChainToFile prompts user to save certificate chain to file
-// Chain includes: leaf certificate + intermediate CAs + root CA
+// The variant selects the certificates saved (models.ChainVariant*): leaf only,
+// leaf + intermediates (fullchain), leaf + intermediates + root, or root only;
+// empty saves the whole chain
 // Does NOT require encryption key - certificates are not encrypted
-func (a *App) SaveChainToFile(hostname string) error {
+func (a *App) SaveChainToFile(hostname, variant string) error {
 	if err := a.requireSetupOnly(); err != nil {
 		return err
 	}
 
 	log := logger.WithComponent("app")
-	log.Info("downloading certificate chain", slog.String("hostname", hostname))
+	log.Info("downloading certificate chain", slog.String("hostname", hostname), slog.String("variant", variant))
 
 	a.mu.RLock()
 	certificateService := a.certificateService
@@ -144,20 +159,21 @@ func (a *App) SaveChainToFile(hostname string) error {
 		return fmt.Errorf("certificate service not initialized")
 	}
 
-	chainPEM, err := certificateService.GetChainPEMForDownload(a.ctx, hostname)
+	chainPEM, err := certificateService.GetChainPEMForDownload(a.ctx, hostname, variant)
 	if err != nil {
 		log.Error("get chain failed", slog.String("hostname", hostname), logger.Err(err))
 		return err
 	}
 
+	opts := a.downloadOptions()
 	path, err := wailsruntime.SaveFileDialog(a.ctx, wailsruntime.SaveDialogOptions{
-		DefaultFilename: hostname + "-chain.crt",
+		DefaultFilename: downloadFilename(chainFilename(hostname, variant), opts),
 		Title:           "Save Certificate Chain",
-		Filters: []wailsruntime.FileFilter{
+		Filters: downloadFilters(opts, []wailsruntime.FileFilter{
 			{DisplayName: "Certificate Files (*.crt)", Pattern: "*.crt"},
 			{DisplayName: "PEM Files (*.pem)", Pattern: "*.pem"},
 			{DisplayName: "All Files (*.*)", Pattern: "*.*"},
-		},
+		}),
 	})
 
 	if err != nil {
@@ -170,7 +186,7 @@ func (a *App) SaveChainToFile(hostname string) error {
 		return nil
 	}
 
-	if err := os.WriteFile(path, []byte(chainPEM), 0644); err != nil {
+	if err := writeDownload(path, []byte(chainPEM), 0644, opts); err != nil {
 		log.Error("failed to write chain file", slog.String("path", path), logger.Err(err))
 		return fmt.Errorf("failed to write file: %w", err)
 	}
@@ -179,6 +195,48 @@ func (a *App) SaveChainToFile(hostname string) error {
 	return nil
 }
 
+// chainFilename returns the default file name of a chain download variant
+func chainFilename(hostname, variant string) string {
+	switch variant {
+	case models.ChainVariantLeaf:
+		return hostname + "-leaf.crt"
+	case models.ChainVariantFullChain:
+		return hostname + "-fullchain.pem"
+	case models.ChainVariantRoot:
+		return hostname + "-root.crt"
+	default:
+		return hostname + "-chain.crt"
+	}
+}
+
+// GetCertificateQRCodes returns QR codes of a certificate's SHA-256 fingerprint
+// and, with includePEM, of its PEM split across frames, for checking a
+// deployment on a device where copy/paste isn't possible.
+// Does NOT require encryption key - the certificate is public
+func (a *App) GetCertificateQRCodes(hostname string, includePEM bool) (*models.CertificateQRCodes, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	log := logger.WithComponent("app")
+	log.Debug("generating certificate QR codes", slog.String("hostname", hostname), slog.Bool("include_pem", includePEM))
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	codes, err := certificateService.GetCertificateQRCodes(a.ctx, hostname, includePEM)
+	if err != nil {
+		log.Error("failed to generate QR codes", slog.String("hostname", hostname), logger.Err(err))
+		return nil, err
+	}
+	return codes, nil
+}
+
 // SavePrivateKeyToFile prompts user to save decrypted private key to file
 func (a *App) SavePrivateKeyToFile(hostname string) error {
 	if err := a.requireSetupComplete(); err != nil {
@@ -190,26 +248,86 @@ func (a *App) SavePrivateKeyToFile(hostname string) error {
 
 	a.mu.RLock()
 	certificateService := a.certificateService
-	encryptionKey := make([]byte, len(a.masterKey))
-	copy(encryptionKey, a.masterKey)
+	database := a.db
+	encryptionKey := a.masterKey.Clone()
 	a.mu.RUnlock()
+	defer encryptionKey.Destroy()
 
 	if certificateService == nil {
 		return fmt.Errorf("certificate service not initialized")
 	}
 
-	key, err := certificateService.GetPrivateKeyForDownload(a.ctx, hostname, encryptionKey)
+	opts := a.downloadOptions()
+	path, err := wailsruntime.SaveFileDialog(a.ctx, wailsruntime.SaveDialogOptions{
+		DefaultFilename: downloadFilename(hostname+".key", opts),
+		Title:           "Save Private Key",
+		Filters: downloadFilters(opts, []wailsruntime.FileFilter{
+			{DisplayName: "Key Files (*.key)", Pattern: "*.key"},
+			{DisplayName: "PEM Files (*.pem)", Pattern: "*.pem"},
+			{DisplayName: "All Files (*.*)", Pattern: "*.*"},
+		}),
+	})
+
+	if err != nil {
+		log.Error("file dialog error", logger.Err(err))
+		return fmt.Errorf("file dialog error: %w", err)
+	}
+
+	if path == "" {
+		log.Info("user cancelled private key save dialog")
+		return nil
+	}
+
+	// Decrypt only once the destination is known, so the plaintext is held just
+	// for the duration of the write
+	key, err := certificateService.GetPrivateKeyForDownload(a.ctx, hostname, encryptionKey.Bytes())
 	if err != nil {
 		log.Error("get private key failed", slog.String("hostname", hostname), logger.Err(err))
 		return err
 	}
 
+	if err := writePrivateKeyFile(path, key, opts); err != nil {
+		log.Error("failed to write private key file", slog.String("path", path), logger.Err(err))
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	a.recordAudit(database, models.AuditKeyExport, hostname, "Private key saved to a file",
+		map[string]any{"format": "pem"})
+	log.Info("private key saved", slog.String("path", path))
+	return nil
+}
+
+// SaveP12ToFile saves the active certificate, its private key and chain as a
+// PKCS#12 (.pfx) file protected by password, for Windows servers and Java
+// keystores. legacy writes 3DES with a SHA-1 MAC instead of AES-256, for
+// Windows Server 2016 and older and Java 8; it is refused in FIPS mode.
+func (a *App) SaveP12ToFile(hostname, password string, legacy bool) error {
+	if err := a.requireSetupComplete(); err != nil {
+		return err
+	}
+	if err := services.ValidatePKCS12Password(password); err != nil {
+		return err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("downloading PKCS#12 file", slog.String("hostname", hostname), slog.Bool("legacy", legacy))
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	database := a.db
+	encryptionKey := a.masterKey.Clone()
+	a.mu.RUnlock()
+	defer encryptionKey.Destroy()
+
+	if certificateService == nil {
+		return fmt.Errorf("certificate service not initialized")
+	}
+
 	path, err := wailsruntime.SaveFileDialog(a.ctx, wailsruntime.SaveDialogOptions{
-		DefaultFilename: hostname + ".key",
-		Title:           "Save Private Key",
+		DefaultFilename: hostname + ".pfx",
+		Title:           "Save PKCS#12 File",
 		Filters: []wailsruntime.FileFilter{
-			{DisplayName: "Key Files (*.key)", Pattern: "*.key"},
-			{DisplayName: "PEM Files (*.pem)", Pattern: "*.pem"},
+			{DisplayName: "PKCS#12 Files (*.pfx, *.p12)", Pattern: "*.pfx;*.p12"},
 			{DisplayName: "All Files (*.*)", Pattern: "*.*"},
 		},
 	})
@@ -220,16 +338,25 @@ func (a *App) SavePrivateKeyToFile(hostname string) error {
 	}
 
 	if path == "" {
-		log.Info("user cancelled private key save dialog")
+		log.Info("user cancelled PKCS#12 save dialog")
 		return nil
 	}
 
-	if err := os.WriteFile(path, []byte(key), 0600); err != nil {
-		log.Error("failed to write private key file", slog.String("path", path), logger.Err(err))
+	pfx, err := certificateService.BuildPKCS12ForDownload(a.ctx, hostname, password, legacy, encryptionKey.Bytes())
+	a.recordActivity("export_pkcs12", hostname, err)
+	if err != nil {
+		log.Error("build PKCS#12 file failed", slog.String("hostname", hostname), logger.Err(err))
+		return err
+	}
+
+	if err := os.WriteFile(path, pfx, 0600); err != nil {
+		log.Error("failed to write PKCS#12 file", slog.String("path", path), logger.Err(err))
 		return fmt.Errorf("failed to write file: %w", err)
 	}
 
-	log.Info("private key saved", slog.String("path", path))
+	a.recordAudit(database, models.AuditKeyExport, hostname, "Private key saved in a PKCS#12 file",
+		map[string]any{"format": "pkcs12", "legacy": legacy})
+	log.Info("PKCS#12 file saved", slog.String("path", path))
 	return nil
 }
 
@@ -244,21 +371,26 @@ func (a *App) GetPrivateKeyPEM(hostname string) (string, error) {
 
 	a.mu.RLock()
 	certificateService := a.certificateService
-	encryptionKey := make([]byte, len(a.masterKey))
-	copy(encryptionKey, a.masterKey)
+	database := a.db
+	encryptionKey := a.masterKey.Clone()
 	a.mu.RUnlock()
+	defer encryptionKey.Destroy()
 
 	if certificateService == nil {
 		return "", fmt.Errorf("certificate service not initialized")
 	}
 
-	privateKeyPEM, err := certificateService.GetPrivateKeyForDownload(a.ctx, hostname, encryptionKey)
+	privateKeyPEM, err := certificateService.GetPrivateKeyForDownload(a.ctx, hostname, encryptionKey.Bytes())
 	if err != nil {
 		log.Error("get private key PEM failed", slog.String("hostname", hostname), logger.Err(err))
 		return "", err
 	}
+	defer privateKeyPEM.Destroy()
+	a.recordAudit(database, models.AuditKeyExport, hostname, "Private key displayed",
+		map[string]any{"format": "display"})
 
-	return privateKeyPEM, nil
+	// The frontend binding needs a string; this is the only copy that cannot be wiped
+	return string(privateKeyPEM.Bytes()), nil
 }
 
 // GetPendingPrivateKeyPEM returns the decrypted pending private key PEM for display in UI
@@ -272,21 +404,26 @@ func (a *App) GetPendingPrivateKeyPEM(hostname string) (string, error) {
 
 	a.mu.RLock()
 	certificateService := a.certificateService
-	encryptionKey := make([]byte, len(a.masterKey))
-	copy(encryptionKey, a.masterKey)
+	database := a.db
+	encryptionKey := a.masterKey.Clone()
 	a.mu.RUnlock()
+	defer encryptionKey.Destroy()
 
 	if certificateService == nil {
 		return "", fmt.Errorf("certificate service not initialized")
 	}
 
-	privateKeyPEM, err := certificateService.GetPendingPrivateKeyForDownload(a.ctx, hostname, encryptionKey)
+	privateKeyPEM, err := certificateService.GetPendingPrivateKeyForDownload(a.ctx, hostname, encryptionKey.Bytes())
 	if err != nil {
 		log.Error("get pending private key PEM failed", slog.String("hostname", hostname), logger.Err(err))
 		return "", err
 	}
+	defer privateKeyPEM.Destroy()
+	a.recordAudit(database, models.AuditKeyExport, hostname, "Pending private key displayed",
+		map[string]any{"format": "display", "pending": true})
 
-	return privateKeyPEM, nil
+	// The frontend binding needs a string; this is the only copy that cannot be wiped
+	return string(privateKeyPEM.Bytes()), nil
 }
 
 // ============================================================================
@@ -312,12 +449,13 @@ func (a *App) ExportCertificateZip(hostname string, options models.ExportOptions
 
 	a.mu.RLock()
 	certificateService := a.certificateService
-	var encryptionKey []byte
+	database := a.db
+	var encryptionKey *crypto.SecretBuffer
 	if options.PrivateKey || options.PendingKey {
-		encryptionKey = make([]byte, len(a.masterKey))
-		copy(encryptionKey, a.masterKey)
+		encryptionKey = a.masterKey.Clone()
 	}
 	a.mu.RUnlock()
+	defer encryptionKey.Destroy()
 
 	if certificateService == nil {
 		return fmt.Errorf("certificate service not initialized")
@@ -326,7 +464,7 @@ func (a *App) ExportCertificateZip(hostname string, options models.ExportOptions
 	// Collect files to include in ZIP
 	type zipEntry struct {
 		name    string
-		content string
+		content []byte
 		mode    os.FileMode
 	}
 	var entries []zipEntry
@@ -337,25 +475,26 @@ func (a *App) ExportCertificateZip(hostname string, options models.ExportOptions
 			log.Error("get certificate failed", slog.String("hostname", hostname), logger.Err(err))
 			return fmt.Errorf("failed to get certificate: %w", err)
 		}
-		entries = append(entries, zipEntry{hostname + ".crt", cert, 0644})
+		entries = append(entries, zipEntry{hostname + ".crt", []byte(cert), 0644})
 	}
 
 	if options.Chain {
-		chainPEM, err := certificateService.GetChainPEMForDownload(a.ctx, hostname)
+		chainPEM, err := certificateService.GetChainPEMForDownload(a.ctx, hostname, options.ChainVariant)
 		if err != nil {
 			log.Error("get chain failed", slog.String("hostname", hostname), logger.Err(err))
 			return fmt.Errorf("failed to get certificate chain: %w", err)
 		}
-		entries = append(entries, zipEntry{hostname + "-chain.crt", chainPEM, 0644})
+		entries = append(entries, zipEntry{chainFilename(hostname, options.ChainVariant), []byte(chainPEM), 0644})
 	}
 
 	if options.PrivateKey {
-		key, err := certificateService.GetPrivateKeyForDownload(a.ctx, hostname, encryptionKey)
+		key, err := certificateService.GetPrivateKeyForDownload(a.ctx, hostname, encryptionKey.Bytes())
 		if err != nil {
 			log.Error("get private key failed", slog.String("hostname", hostname), logger.Err(err))
 			return fmt.Errorf("failed to get private key: %w", err)
 		}
-		entries = append(entries, zipEntry{hostname + ".key", key, 0600})
+		defer key.Destroy()
+		entries = append(entries, zipEntry{hostname + ".key", key.Bytes(), 0600})
 	}
 
 	if options.CSR {
@@ -364,22 +503,35 @@ func (a *App) ExportCertificateZip(hostname string, options models.ExportOptions
 			log.Error("get CSR failed", slog.String("hostname", hostname), logger.Err(err))
 			return fmt.Errorf("failed to get CSR: %w", err)
 		}
-		entries = append(entries, zipEntry{hostname + ".csr", csr, 0644})
+		entries = append(entries, zipEntry{hostname + ".csr", []byte(csr), 0644})
 	}
 
 	if options.PendingKey {
-		key, err := certificateService.GetPendingPrivateKeyForDownload(a.ctx, hostname, encryptionKey)
+		key, err := certificateService.GetPendingPrivateKeyForDownload(a.ctx, hostname, encryptionKey.Bytes())
 		if err != nil {
 			log.Error("get pending private key failed", slog.String("hostname", hostname), logger.Err(err))
 			return fmt.Errorf("failed to get pending private key: %w", err)
 		}
-		entries = append(entries, zipEntry{hostname + ".pending.key", key, 0600})
+		defer key.Destroy()
+		entries = append(entries, zipEntry{hostname + ".pending.key", key.Bytes(), 0600})
 	}
 
 	if len(entries) == 0 {
 		return fmt.Errorf("no items selected for export")
 	}
 
+	// Files are written like single downloads, in the configured format
+	opts := a.downloadOptions()
+	for i := range entries {
+		formatted, err := crypto.FormatDownload(entries[i].content, opts)
+		if err != nil {
+			return fmt.Errorf("failed to format %s: %w", entries[i].name, err)
+		}
+		defer crypto.Zero(formatted)
+		entries[i].name = downloadFilename(entries[i].name, opts)
+		entries[i].content = formatted
+	}
+
 	// Create temp ZIP file
 	tempFile := filepath.Join(os.TempDir(), fmt.Sprintf(
 		"%s-export-%s.zip",
@@ -407,7 +559,7 @@ func (a *App) ExportCertificateZip(hostname string, options models.ExportOptions
 			os.Remove(tempFile)
 			return fmt.Errorf("failed to create ZIP entry %s: %w", entry.name, err)
 		}
-		if _, err := w.Write([]byte(entry.content)); err != nil {
+		if _, err := w.Write(entry.content); err != nil {
 			zipWriter.Close()
 			zipFile.Close()
 			os.Remove(tempFile)
@@ -448,10 +600,281 @@ func (a *App) ExportCertificateZip(hostname string, options models.ExportOptions
 		return fmt.Errorf("failed to save export: %w", err)
 	}
 
+	if options.PrivateKey || options.PendingKey {
+		a.recordAudit(database, models.AuditKeyExport, hostname, "Private key exported in a ZIP archive",
+			map[string]any{"format": "zip", "private_key": options.PrivateKey, "pending_key": options.PendingKey})
+	}
 	log.Info("certificate export saved", slog.String("path", path), slog.Int("files", len(entries)))
 	return nil
 }
 
+// ============================================================================
+// Runbook Export
+// ============================================================================
+
+// ExportRunbook prompts the user to save a Markdown runbook for a certificate
+// (subject, SANs, expiry, renewal policy, contacts, recent history), to attach
+// to change tickets. Contains no private key material.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) ExportRunbook(hostname string) error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("exporting runbook", slog.String("hostname", hostname))
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return fmt.Errorf("certificate service not initialized")
+	}
+
+	runbook, err := certificateService.BuildRunbook(a.ctx, hostname)
+	if err != nil {
+		log.Error("build runbook failed", slog.String("hostname", hostname), logger.Err(err))
+		return err
+	}
+
+	path, err := wailsruntime.SaveFileDialog(a.ctx, wailsruntime.SaveDialogOptions{
+		DefaultFilename: hostname + "-runbook.md",
+		Title:           "Export Certificate Runbook",
+		Filters: []wailsruntime.FileFilter{
+			{DisplayName: "Markdown Files (*.md)", Pattern: "*.md"},
+			{DisplayName: "All Files (*.*)", Pattern: "*.*"},
+		},
+	})
+
+	if err != nil {
+		log.Error("file dialog error", logger.Err(err))
+		return fmt.Errorf("file dialog error: %w", err)
+	}
+
+	if path == "" {
+		log.Info("user cancelled runbook save dialog")
+		return nil
+	}
+
+	if err := os.WriteFile(path, []byte(runbook), 0644); err != nil {
+		log.Error("failed to write runbook", slog.String("path", path), logger.Err(err))
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	log.Info("runbook saved", slog.String("path", path))
+	return nil
+}
+
+// ============================================================================
+// Server Configuration Snippets
+// ============================================================================
+
+// GenerateServerConfigSnippet renders a ready-to-paste TLS configuration block
+// for a certificate on a web server (nginx, apache or haproxy), with the file
+// paths named like the downloads and recommended protocol and cipher settings.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) GenerateServerConfigSnippet(hostname, serverType string) (*models.ServerConfigSnippet, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	log := logger.WithComponent("app")
+	log.Debug("generating server config snippet", slog.String("hostname", hostname), slog.String("server_type", serverType))
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	snippet, err := certificateService.BuildServerConfigSnippet(a.ctx, hostname, serverType)
+	if err != nil {
+		log.Error("generate server config snippet failed", slog.String("hostname", hostname), logger.Err(err))
+		return nil, err
+	}
+	return snippet, nil
+}
+
+// GeneratePinningConfig renders a certificate pinning configuration for a
+// mobile app (android, okhttp or trustkit) pinning the active and pending
+// keys of the given certificates, so app teams ship the next key's pin ahead
+// of a rotation.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) GeneratePinningConfig(hostnames []string, format string) (*models.PinningConfig, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	log := logger.WithComponent("app")
+	log.Debug("generating pinning config", slog.Int("count", len(hostnames)), slog.String("format", format))
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	config, err := certificateService.BuildPinningConfig(a.ctx, hostnames, format)
+	if err != nil {
+		log.Error("generate pinning config failed", logger.Err(err))
+		return nil, err
+	}
+	return config, nil
+}
+
+// GetOpenSSLCommands returns the openssl commands verifying a certificate's
+// downloads and its deployment (x509, verify, s_client), with the file names
+// the downloads default to, so operators don't have to remember the flags.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) GetOpenSSLCommands(hostname string) (*models.OpenSSLCommands, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	log := logger.WithComponent("app")
+	log.Debug("generating openssl commands", slog.String("hostname", hostname))
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	commands, err := certificateService.BuildOpenSSLCommands(a.ctx, hostname, a.downloadOptions().Format)
+	if err != nil {
+		log.Error("generate openssl commands failed", slog.String("hostname", hostname), logger.Err(err))
+		return nil, err
+	}
+	return commands, nil
+}
+
+// ============================================================================
+// Inventory Export
+// ============================================================================
+
+// ExportCertificateInventory prompts the user to save the list of every
+// certificate (hostname, status, SANs, expiry, key, read-only flag, note) as a
+// CSV or XLSX report for audits. format is "csv" or "xlsx".
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) ExportCertificateInventory(format string) error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("exporting certificate inventory", slog.String("format", format))
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return fmt.Errorf("certificate service not initialized")
+	}
+
+	data, err := certificateService.BuildInventory(a.ctx, format)
+	if err != nil {
+		log.Error("build certificate inventory failed", logger.Err(err))
+		return err
+	}
+
+	filter := wailsruntime.FileFilter{DisplayName: "CSV Files (*.csv)", Pattern: "*.csv"}
+	if format == models.InventoryFormatXLSX {
+		filter = wailsruntime.FileFilter{DisplayName: "Excel Workbooks (*.xlsx)", Pattern: "*.xlsx"}
+	}
+	path, err := wailsruntime.SaveFileDialog(a.ctx, wailsruntime.SaveDialogOptions{
+		DefaultFilename: fmt.Sprintf("certificate-inventory-%s.%s", time.Now().Format("2006-01-02"), format),
+		Title:           "Export Certificate Inventory",
+		Filters: []wailsruntime.FileFilter{
+			filter,
+			{DisplayName: "All Files (*.*)", Pattern: "*.*"},
+		},
+	})
+
+	if err != nil {
+		log.Error("file dialog error", logger.Err(err))
+		return fmt.Errorf("file dialog error: %w", err)
+	}
+
+	if path == "" {
+		log.Info("user cancelled inventory save dialog")
+		return nil
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		log.Error("failed to write certificate inventory", slog.String("path", path), logger.Err(err))
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	log.Info("certificate inventory saved", slog.String("path", path))
+	return nil
+}
+
+// ============================================================================
+// History Export
+// ============================================================================
+
+// ExportHistoryCSV prompts the user to save the activity history across all
+// certificates matching the filter as CSV (e.g. everything that happened in a
+// given month).
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) ExportHistoryCSV(filter models.HistoryFilter) error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("exporting history as CSV")
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return fmt.Errorf("certificate service not initialized")
+	}
+
+	data, err := certificateService.BuildHistoryCSV(a.ctx, filter)
+	if err != nil {
+		log.Error("build history CSV failed", logger.Err(err))
+		return err
+	}
+
+	path, err := wailsruntime.SaveFileDialog(a.ctx, wailsruntime.SaveDialogOptions{
+		DefaultFilename: "certificate-history.csv",
+		Title:           "Export Certificate History",
+		Filters: []wailsruntime.FileFilter{
+			{DisplayName: "CSV Files (*.csv)", Pattern: "*.csv"},
+			{DisplayName: "All Files (*.*)", Pattern: "*.*"},
+		},
+	})
+
+	if err != nil {
+		log.Error("file dialog error", logger.Err(err))
+		return fmt.Errorf("file dialog error: %w", err)
+	}
+
+	if path == "" {
+		log.Info("user cancelled history save dialog")
+		return nil
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		log.Error("failed to write history CSV", slog.String("path", path), logger.Err(err))
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	log.Info("history CSV saved", slog.String("path", path))
+	return nil
+}
+
 // ============================================================================
 // Log Export Operations
 // ============================================================================
@@ -508,6 +931,66 @@ func (a *App) ExportLogs() error {
 	return nil
 }
 
+// writePrivateKeyFile writes a decrypted private key with owner-only permissions
+// and wipes the buffer, whether or not the write succeeds.
+func writePrivateKeyFile(path string, key *crypto.SecretBuffer, opts models.DownloadOptions) error {
+	defer key.Destroy()
+	return writeDownload(path, key.Bytes(), 0600, opts)
+}
+
+// downloadOptions returns the download settings of the config: how saved
+// certificates, CSRs, chains and keys are written. PEM with LF line endings
+// and no header when the config cannot be read.
+func (a *App) downloadOptions() models.DownloadOptions {
+	opts := models.DownloadOptions{LineEndings: models.LineEndingsLF, Format: models.DownloadFormatPEM}
+
+	a.mu.RLock()
+	database := a.db
+	a.mu.RUnlock()
+
+	if database == nil {
+		return opts
+	}
+	cfg, err := database.Queries().GetConfig(a.ctx)
+	if err != nil {
+		return opts
+	}
+	return models.DownloadOptions{
+		LineEndings: cfg.DownloadLineEndings,
+		TextHeader:  cfg.DownloadTextHeader == 1,
+		Format:      cfg.DownloadFormat,
+	}
+}
+
+// writeDownload writes PEM data to path in the format of the download options.
+// The formatted copy is wiped once written, as it may hold a private key.
+func writeDownload(path string, data []byte, perm os.FileMode, opts models.DownloadOptions) error {
+	formatted, err := crypto.FormatDownload(data, opts)
+	if err != nil {
+		return err
+	}
+	defer crypto.Zero(formatted)
+	return os.WriteFile(path, formatted, perm)
+}
+
+// downloadFilename swaps the extension of a default file name for .der when
+// downloads are DER-encoded
+func downloadFilename(name string, opts models.DownloadOptions) string {
+	if opts.Format != models.DownloadFormatDER {
+		return name
+	}
+	return strings.TrimSuffix(name, filepath.Ext(name)) + ".der"
+}
+
+// downloadFilters puts a DER filter first in a save dialog when downloads are
+// DER-encoded
+func downloadFilters(opts models.DownloadOptions, filters []wailsruntime.FileFilter) []wailsruntime.FileFilter {
+	if opts.Format != models.DownloadFormatDER {
+		return filters
+	}
+	return append([]wailsruntime.FileFilter{{DisplayName: "DER Files (*.der)", Pattern: "*.der"}}, filters...)
+}
+
 // copyFile copies a file from src to dst
 func copyFile(src, dst string) error {
 	sourceFile, err := os.Open(src)
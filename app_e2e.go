@@ -0,0 +1,209 @@
+//go:build e2e
+
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"paddockcontrol-desktop/internal/clock"
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// ============================================================================
+// End-to-End Test Bindings (built with -tags e2e only)
+// ============================================================================
+
+// defaultSeedValidityDays is the validity of seeded certificates when the
+// request does not set one
+const defaultSeedValidityDays = 365
+
+// e2eCA is the throwaway CA that signs seeded certificates, generated once per
+// process
+var e2eCA struct {
+	once sync.Once
+	cert *x509.Certificate
+	key  *rsa.PrivateKey
+	pem  string
+	err  error
+}
+
+// TestSeedData creates the requested certificates through the regular CSR and
+// upload paths, so the UI tests start from realistic data without clicking
+// through the forms. Pending certificates come with their CSR signed by a test
+// CA, for the upload step of the flow under test. Hostnames skip the suffix
+// validation.
+// Requires setup complete and unlocked.
+func (a *App) TestSeedData(req models.TestSeedRequest) (*models.TestSeedResult, error) {
+	if err := a.requireSetupComplete(); err != nil {
+		return nil, err
+	}
+	log := logger.WithComponent("e2e")
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	configService := a.configService
+	encryptionKey := a.masterKey.Clone()
+	a.mu.RUnlock()
+	defer encryptionKey.Destroy()
+
+	cfg, err := configService.GetConfig(a.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config: %w", err)
+	}
+
+	result := &models.TestSeedResult{Certificates: []models.TestSeededCertificate{}}
+	for _, seed := range req.Certificates {
+		if seed.Status != models.TestSeedPending && seed.Status != models.TestSeedActive {
+			return result, fmt.Errorf("invalid seed status for %s: %q", seed.Hostname, seed.Status)
+		}
+		keySize := seed.KeySize
+		if keySize == 0 {
+			keySize = int(cfg.DefaultKeySize)
+		}
+		validity := seed.ExpiresInDays
+		if validity == 0 {
+			validity = defaultSeedValidityDays
+		}
+
+		csr, err := certificateService.GenerateCSR(a.ctx, models.CSRRequest{
+			Hostname:             seed.Hostname,
+			Organization:         cfg.DefaultOrganization,
+			OrganizationalUnit:   cfg.DefaultOrganizationalUnit.String,
+			City:                 cfg.DefaultCity,
+			State:                cfg.DefaultState,
+			Country:              cfg.DefaultCountry,
+			KeySize:              keySize,
+			Note:                 seed.Note,
+			SkipSuffixValidation: true,
+		}, encryptionKey.Bytes())
+		if err != nil {
+			return result, fmt.Errorf("failed to seed %s: %w", seed.Hostname, err)
+		}
+
+		now := a.appClock().Now()
+		signed, err := signTestCSR(csr.CSR, now, now.Add(time.Duration(validity)*24*time.Hour))
+		if err != nil {
+			return result, fmt.Errorf("failed to sign %s: %w", csr.Hostname, err)
+		}
+
+		seeded := models.TestSeededCertificate{Hostname: csr.Hostname, Status: seed.Status}
+		if seed.Status == models.TestSeedActive {
+			if _, err := certificateService.UploadCertificate(a.ctx, csr.Hostname, signed, encryptionKey.Bytes()); err != nil {
+				return result, fmt.Errorf("failed to activate %s: %w", csr.Hostname, err)
+			}
+		} else {
+			seeded.SignedCertificatePEM = signed
+		}
+		result.Certificates = append(result.Certificates, seeded)
+	}
+
+	a.recordActivity("test_seed_data", "", nil)
+	log.Info("test data seeded", slog.Int("certificates", len(result.Certificates)))
+	return result, nil
+}
+
+// TestAdvanceClock moves the app clock forward by the given number of seconds
+// (backward when negative), so expiry windows, reminders and backup schedules
+// can be tested without waiting. Returns the new app time (Unix seconds).
+// Available before setup and unlock.
+func (a *App) TestAdvanceClock(seconds int64) (int64, error) {
+	offset, ok := a.clock.(*clock.Offset)
+	if !ok {
+		return 0, fmt.Errorf("app clock cannot be advanced")
+	}
+	offset.Advance(time.Duration(seconds) * time.Second)
+
+	now := offset.Now()
+	logger.WithComponent("e2e").Info("app clock advanced",
+		slog.Int64("seconds", seconds),
+		slog.Duration("offset", offset.Offset()),
+	)
+	a.refreshTray()
+	return now.Unix(), nil
+}
+
+// signTestCSR signs a CSR with the test CA and returns the leaf followed by
+// the CA certificate, as a CA response would.
+func signTestCSR(csrPEM string, notBefore, notAfter time.Time) (string, error) {
+	e2eCA.once.Do(func() {
+		e2eCA.cert, e2eCA.key, e2eCA.err = newTestCA()
+		if e2eCA.err == nil {
+			e2eCA.pem = string(crypto.CertificateToPEM(e2eCA.cert))
+		}
+	})
+	if e2eCA.err != nil {
+		return "", fmt.Errorf("failed to create test CA: %w", e2eCA.err)
+	}
+
+	csr, err := crypto.ParseCSR([]byte(csrPEM))
+	if err != nil {
+		return "", err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return "", err
+	}
+	// Backdated like a real issuance, so certificates seeded as expired are
+	// still valid for a moment before they expire
+	start := notBefore.Add(-time.Hour)
+	if notAfter.Before(start) {
+		start = notAfter.Add(-defaultSeedValidityDays * 24 * time.Hour)
+	}
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      csr.Subject,
+		DNSNames:     csr.DNSNames,
+		IPAddresses:  csr.IPAddresses,
+		NotBefore:    start,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, e2eCA.cert, csr.PublicKey, e2eCA.key)
+	if err != nil {
+		return "", err
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join([]string{string(crypto.CertificateToPEM(leaf)), e2eCA.pem}, ""), nil
+}
+
+// newTestCA creates the self-signed test CA.
+func newTestCA() (*x509.Certificate, *rsa.PrivateKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "PaddockControl E2E Test CA", Organization: []string{"PaddockControl Tests"}},
+		NotBefore:             now.Add(-20 * 365 * 24 * time.Hour),
+		NotAfter:              now.Add(20 * 365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cert, key, nil
+}
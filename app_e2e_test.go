@@ -0,0 +1,54 @@
+//go:build e2e
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/clock"
+	"paddockcontrol-desktop/internal/models"
+)
+
+func TestTestSeedData_AndAdvanceClock(t *testing.T) {
+	app := setupUnlockedApp(t)
+	app.clock = clock.NewOffset()
+	app.initializeServicesWithoutKey()
+
+	result, err := app.TestSeedData(models.TestSeedRequest{Certificates: []models.TestSeedCertificate{
+		{Hostname: "pending.e2e.test", Status: models.TestSeedPending},
+		{Hostname: "soon.e2e.test", Status: models.TestSeedActive, ExpiresInDays: 40},
+	}})
+	if err != nil {
+		t.Fatalf("TestSeedData() error = %v", err)
+	}
+	if len(result.Certificates) != 2 || result.Certificates[0].SignedCertificatePEM == "" || result.Certificates[1].SignedCertificatePEM != "" {
+		t.Fatalf("unexpected seed result %+v", result)
+	}
+
+	// The signed certificate handed back completes the upload step
+	if _, err := app.UploadCertificate("pending.e2e.test", result.Certificates[0].SignedCertificatePEM); err != nil {
+		t.Fatalf("UploadCertificate() error = %v", err)
+	}
+	if got := app.expiringCount(); got != 0 {
+		t.Fatalf("expiringCount() = %d, want 0", got)
+	}
+
+	before := time.Now()
+	now, err := app.TestAdvanceClock(15 * 24 * 3600)
+	if err != nil {
+		t.Fatalf("TestAdvanceClock() error = %v", err)
+	}
+	if now < before.Add(15*24*time.Hour).Unix() {
+		t.Errorf("TestAdvanceClock() = %d, want 15 days ahead", now)
+	}
+	if got := app.expiringCount(); got != 1 {
+		t.Errorf("expiringCount() after 15 days = %d, want 1", got)
+	}
+
+	if _, err := app.TestSeedData(models.TestSeedRequest{Certificates: []models.TestSeedCertificate{
+		{Hostname: "bad.e2e.test", Status: "revoked"},
+	}}); err == nil {
+		t.Error("expected an error for an unknown seed status")
+	}
+}
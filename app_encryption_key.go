@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -8,10 +9,13 @@ import (
 
 	"paddockcontrol-desktop/internal/config"
 	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db"
 	"paddockcontrol-desktop/internal/db/sqlc"
 	"paddockcontrol-desktop/internal/logger"
 	"paddockcontrol-desktop/internal/models"
 	"paddockcontrol-desktop/internal/services"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 // ============================================================================
@@ -32,6 +36,23 @@ func (a *App) IsUnlocked() bool {
 	return a.isUnlocked
 }
 
+// GetSessionState returns the setup and unlock state in one snapshot, taken
+// under a single lock acquisition. Prefer it over calling IsSetupComplete,
+// IsUnlocked and IsWaitingForEncryptionKey separately.
+func (a *App) GetSessionState() *models.SessionState {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return &models.SessionState{
+		Configured:              a.isConfigured,
+		Unlocked:                a.isUnlocked,
+		WaitingForEncryptionKey: a.waitingForEncryptionKey,
+		MigrationNeeded:         a.needsMigration,
+		LimitedMode:             a.isConfigured && !a.waitingForEncryptionKey && !a.isUnlocked,
+		Profile:                 a.profile,
+		Version:                 Version,
+	}
+}
+
 // SkipEncryptionKey allows user to skip encryption key entry and proceed with limited functionality
 func (a *App) SkipEncryptionKey() error {
 	a.mu.Lock()
@@ -90,12 +111,14 @@ func (a *App) ProvideEncryptionKey(password string) (*models.KeyValidationResult
 		return nil, fmt.Errorf("failed to check security keys: %w", err)
 	}
 
-	var masterKey []byte
+	var masterKey *crypto.SecretBuffer
 
 	if hasKeys == 1 {
 		// Path 3: Normal unlock — try to unwrap master key from password entries
 		masterKey, err = a.unlockWithPassword(log, password)
 		if err != nil {
+			a.recordAudit(a.db, models.AuditUnlockFailed, "", "Unlock with the password failed",
+				map[string]any{"method": models.SecurityKeyMethodPassword})
 			return &models.KeyValidationResult{Valid: false}, err
 		}
 	} else if a.isConfigured {
@@ -108,6 +131,8 @@ func (a *App) ProvideEncryptionKey(password string) (*models.KeyValidationResult
 			// Path 1: Migration from legacy SHA-256 format
 			masterKey, err = a.migrateLegacyEncryption(log, password)
 			if err != nil {
+				a.recordAudit(a.db, models.AuditUnlockFailed, "", "Unlock with the password failed",
+					map[string]any{"method": models.SecurityKeyMethodPassword})
 				return nil, err
 			}
 		} else {
@@ -125,18 +150,25 @@ func (a *App) ProvideEncryptionKey(password string) (*models.KeyValidationResult
 		}
 	}
 
-	// Store master key in memory
+	// Store master key in memory (in a locked, wipeable buffer)
+	a.masterKey.Destroy()
 	a.masterKey = masterKey
 	a.waitingForEncryptionKey = false
 	a.isUnlocked = true
 	a.needsMigration = false
+	a.recordAudit(a.db, models.AuditUnlock, "", "Unlocked with the password",
+		map[string]any{"method": models.SecurityKeyMethodPassword})
 
 	log.Info("password validated, initializing services")
 
 	// Initialize services
 	a.configService = config.NewService(a.db)
-	a.certificateService = services.NewCertificateService(a.db, a.configService)
+	a.certificateService = a.newCertificateService()
 	a.setupService = services.NewSetupService(a.db, a.configService)
+	a.startKeyEnvelopeMigration()
+	a.startKeyPoolFill()
+	a.startActivitySession()
+	a.resumeSyncServer()
 
 	log.Info("all services initialized successfully")
 
@@ -156,20 +188,104 @@ func (a *App) ClearEncryptionKey() error {
 
 	log.Info("clearing master key - returning to read-only mode")
 
-	// Zero out the master key for security
-	for i := range a.masterKey {
-		a.masterKey[i] = 0
+	// The background validation job holds a copy of the master key
+	if a.keyValidationCancel != nil {
+		a.keyValidationCancel()
+		a.keyValidationCancel = nil
 	}
+	a.stopKeyEnvelopeMigration()
+	a.stopKeyPool()
+	a.stopSyncServer()
+
+	// Zero out the master key for security
+	a.masterKey.Destroy()
 	a.masterKey = nil
 	a.isUnlocked = false
 	// Keep waitingForEncryptionKey = false (user can provide again from Settings)
 
+	a.refreshTray()
+
+	return nil
+}
+
+// StartKeyValidation decrypts every stored private key in the background and
+// reports the certificates whose keys no longer match the master key. Unlock only
+// validates the wrapped master key, so this full-inventory check is opt-in.
+// Emits "key-validation:progress" after each certificate and "key-validation:done"
+// with the result (or "key-validation:error" with the message) when finished.
+func (a *App) StartKeyValidation() error {
+	if err := a.requireUnlocked(); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	log := logger.WithComponent("app")
+
+	if a.certificateService == nil {
+		return fmt.Errorf("certificate service not initialized")
+	}
+	if a.keyValidationCancel != nil {
+		return fmt.Errorf("key validation is already running")
+	}
+
+	masterKey := a.masterKey.Clone()
+	certificateService := a.certificateService
+
+	ctx, cancel := context.WithCancel(a.ctx)
+	a.keyValidationCancel = cancel
+
+	log.Info("starting background key validation")
+
+	go func() {
+		defer masterKey.Destroy()
+		defer func() {
+			a.mu.Lock()
+			if ctx.Err() == nil {
+				a.keyValidationCancel = nil
+			}
+			a.mu.Unlock()
+			cancel()
+		}()
+
+		result, err := certificateService.ValidateEncryptedKeys(ctx, masterKey.Bytes(), func(p models.KeyValidationProgress) {
+			wailsruntime.EventsEmit(a.ctx, "key-validation:progress", p)
+		})
+		if err != nil {
+			log.Error("background key validation failed", logger.Err(err))
+			wailsruntime.EventsEmit(a.ctx, "key-validation:error", err.Error())
+			return
+		}
+
+		log.Info("background key validation completed",
+			slog.Bool("valid", result.Valid),
+			slog.Int("failed_count", len(result.FailedHostnames)),
+		)
+		wailsruntime.EventsEmit(a.ctx, "key-validation:done", result)
+	}()
+
+	return nil
+}
+
+// CancelKeyValidation stops a running background key validation.
+func (a *App) CancelKeyValidation() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.keyValidationCancel == nil {
+		return fmt.Errorf("no key validation is running")
+	}
+	a.keyValidationCancel()
+	a.keyValidationCancel = nil
 	return nil
 }
 
 // ChangeEncryptionKey changes the password by re-wrapping the master key.
 // No certificate re-encryption is needed — only the wrapping key changes.
-func (a *App) ChangeEncryptionKey(newPassword string) error {
+// A dry run re-wraps the key and replaces the password entry inside a
+// transaction that is rolled back, so the old password keeps working.
+func (a *App) ChangeEncryptionKey(newPassword string, dryRun bool) error {
 	if err := a.requireUnlocked(); err != nil {
 		return fmt.Errorf("current password required: %w", err)
 	}
@@ -177,15 +293,18 @@ func (a *App) ChangeEncryptionKey(newPassword string) error {
 	if len(newPassword) < 16 {
 		return fmt.Errorf("new password must be at least 16 characters")
 	}
+	if err := a.requireFreshBackup("change_password"); err != nil {
+		return err
+	}
 
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
 	_, log := logger.WithOperation(a.ctx, "change_password")
-	log.Info("changing password - re-wrapping master key")
+	log.Info("changing password - re-wrapping master key", slog.Bool("dry_run", dryRun))
 
 	// Derive new wrapping key from new password
-	params := crypto.DefaultArgon2idParams()
+	params := a.kdfParams(a.db)
 	salt, err := crypto.GenerateSalt(params.SaltLength)
 	if err != nil {
 		log.Error("failed to generate salt", logger.Err(err))
@@ -193,7 +312,8 @@ func (a *App) ChangeEncryptionKey(newPassword string) error {
 	}
 
 	wrappingKey := crypto.DeriveKeyFromPassword(newPassword, salt, params)
-	wrappedMasterKey, err := crypto.WrapMasterKey(a.masterKey, wrappingKey)
+	defer crypto.Zero(wrappingKey)
+	wrappedMasterKey, err := crypto.WrapMasterKey(a.masterKey.Bytes(), wrappingKey)
 	if err != nil {
 		log.Error("failed to wrap master key", logger.Err(err))
 		return fmt.Errorf("failed to wrap master key: %w", err)
@@ -212,38 +332,32 @@ func (a *App) ChangeEncryptionKey(newPassword string) error {
 	}
 
 	// Atomic transaction: delete old password entries, insert new one
-	tx, err := a.db.GetDB().BeginTx(a.ctx, nil)
-	if err != nil {
-		log.Error("failed to begin transaction", logger.Err(err))
-		return fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer tx.Rollback()
-
-	qtx := a.db.Queries().WithTx(tx)
-
-	if err := qtx.DeleteSecurityKeysByMethod(a.ctx, models.SecurityKeyMethodPassword); err != nil {
-		log.Error("failed to delete old password entries", logger.Err(err))
-		return fmt.Errorf("failed to delete old password entries: %w", err)
-	}
-
-	_, err = qtx.InsertSecurityKey(a.ctx, sqlc.InsertSecurityKeyParams{
-		Method:           models.SecurityKeyMethodPassword,
-		Label:            "Password",
-		WrappedMasterKey: wrappedMasterKey,
-		Metadata:         sql.NullString{String: string(metadataJSON), Valid: true},
-	})
-	if err != nil {
-		log.Error("failed to insert new password entry", logger.Err(err))
-		return fmt.Errorf("failed to insert new password entry: %w", err)
+	if err := a.db.WithDryRunTx(a.ctx, dryRun, func(q *sqlc.Queries) error {
+		if err := q.DeleteSecurityKeysByMethod(a.ctx, models.SecurityKeyMethodPassword); err != nil {
+			return fmt.Errorf("failed to delete old password entries: %w", err)
+		}
+		if _, err := q.InsertSecurityKey(a.ctx, sqlc.InsertSecurityKeyParams{
+			Method:           models.SecurityKeyMethodPassword,
+			Label:            "Password",
+			WrappedMasterKey: wrappedMasterKey,
+			Metadata:         sql.NullString{String: string(metadataJSON), Valid: true},
+		}); err != nil {
+			return fmt.Errorf("failed to insert new password entry: %w", err)
+		}
+		return nil
+	}); err != nil {
+		log.Error("failed to replace password entry", logger.Err(err))
+		return err
 	}
-
-	if err := tx.Commit(); err != nil {
-		log.Error("failed to commit transaction", logger.Err(err))
-		return fmt.Errorf("failed to commit transaction: %w", err)
+	if dryRun {
+		log.Info("password change dry run succeeded")
+		return nil
 	}
 
 	log.Info("password changed successfully (master key re-wrapped)")
 	logger.Audit("unlock_method.password_changed")
+	a.recordAudit(a.db, models.AuditPasswordChange, "", "Password changed", nil)
+	a.recordActivity("change_password", "", nil)
 	return nil
 }
 
@@ -253,7 +367,7 @@ func (a *App) ChangeEncryptionKey(newPassword string) error {
 
 // unlockWithPassword tries to unwrap the master key using a password against
 // all password-method security key entries.
-func (a *App) unlockWithPassword(log *slog.Logger, password string) ([]byte, error) {
+func (a *App) unlockWithPassword(log *slog.Logger, password string) (*crypto.SecretBuffer, error) {
 	keys, err := a.db.Queries().GetSecurityKeysByMethod(a.ctx, models.SecurityKeyMethodPassword)
 	if err != nil {
 		log.Error("failed to get password security keys", logger.Err(err))
@@ -289,6 +403,7 @@ func (a *App) unlockWithPassword(log *slog.Logger, password string) ([]byte, err
 
 		wrappingKey := crypto.DeriveKeyFromPassword(password, metadata.Salt, params)
 		masterKey, err := crypto.UnwrapMasterKey(key.WrappedMasterKey, wrappingKey)
+		crypto.Zero(wrappingKey)
 		if err != nil {
 			// Wrong password for this entry — try next
 			continue
@@ -306,103 +421,107 @@ func (a *App) unlockWithPassword(log *slog.Logger, password string) ([]byte, err
 
 // migrateLegacyEncryption handles migration from pre-v1.4.0 SHA-256 format.
 // DEPRECATED(v2.0.0): Remove this function and the legacy code path.
-func (a *App) migrateLegacyEncryption(log *slog.Logger, password string) ([]byte, error) {
+func (a *App) migrateLegacyEncryption(log *slog.Logger, password string) (*crypto.SecretBuffer, error) {
 	log.Info("migrating from legacy SHA-256 encryption format")
 
-	// First validate the password against all encrypted certs using legacy format
-	certs, err := a.db.Queries().ListAllCertificates(a.ctx)
-	if err != nil {
-		log.Error("failed to list certificates", logger.Err(err))
-		return nil, fmt.Errorf("failed to list certificates: %w", err)
-	}
-
-	var failedHostnames []string
-	for _, cert := range certs {
-		if len(cert.EncryptedPrivateKey) > 0 {
-			if _, err := crypto.DecryptPrivateKeyLegacy(cert.EncryptedPrivateKey, password); err != nil {
-				failedHostnames = append(failedHostnames, cert.Hostname)
-				continue
-			}
+	// The legacy format derives its key with a single SHA-256 of the password
+	if a.configService != nil {
+		cfg, err := a.configService.GetConfig(a.ctx)
+		if err != nil {
+			return nil, err
 		}
-		if len(cert.PendingEncryptedPrivateKey) > 0 {
-			if _, err := crypto.DecryptPrivateKeyLegacy(cert.PendingEncryptedPrivateKey, password); err != nil {
-				found := false
-				for _, h := range failedHostnames {
-					if h == cert.Hostname {
-						found = true
-						break
-					}
-				}
-				if !found {
-					failedHostnames = append(failedHostnames, cert.Hostname)
-				}
-			}
+		if cfg.FipsMode == 1 {
+			log.Warn("legacy encryption migration refused in FIPS mode")
+			return nil, fmt.Errorf("legacy SHA-256 password encryption is %w; disable FIPS mode to migrate this database", crypto.ErrFIPSViolation)
 		}
 	}
 
-	if len(failedHostnames) > 0 {
-		log.Error("legacy password validation failed",
-			slog.Int("failed_count", len(failedHostnames)),
-			slog.Any("failed_hostnames", failedHostnames),
-		)
-		return nil, fmt.Errorf("invalid password: failed to decrypt %d certificate(s)", len(failedHostnames))
+	certs, err := a.db.Queries().ListAllCertificatesIncludingDeleted(a.ctx)
+	if err != nil {
+		log.Error("failed to list certificates", logger.Err(err))
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
 	}
 
-	// Password validated. Generate new master key.
 	masterKey, err := crypto.GenerateMasterKey()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate master key: %w", err)
 	}
+	// Wipe the key on every failure path; only a successful run hands it over
+	handedOver := false
+	defer func() {
+		if !handedOver {
+			masterKey.Destroy()
+		}
+	}()
 
-	// Re-encrypt all certs: decrypt with legacy password, encrypt with new master key
+	// Re-encrypt all certs in a single pass: decrypt with legacy password, encrypt
+	// with new master key. A decryption failure doubles as password validation, so
+	// each key is only decrypted once (large inventories made unlock take minutes).
 	type reEncryptedCert struct {
 		Hostname                      string
 		NewEncryptedPrivateKey        []byte
 		NewPendingEncryptedPrivateKey []byte
 	}
 	var reEncrypted []reEncryptedCert
+	var failedHostnames []string
+
+	reEncrypt := func(encryptedKey []byte) ([]byte, bool, error) {
+		plaintext, err := crypto.DecryptPrivateKeyLegacy(encryptedKey, password)
+		if err != nil {
+			return nil, false, nil
+		}
+		encrypted, err := crypto.EncryptPrivateKey(plaintext, masterKey.Bytes())
+		crypto.Zero(plaintext)
+		return encrypted, true, err
+	}
 
 	for _, cert := range certs {
 		rec := reEncryptedCert{Hostname: cert.Hostname}
+		ok := true
 
 		if len(cert.EncryptedPrivateKey) > 0 {
-			plaintext, err := crypto.DecryptPrivateKeyLegacy(cert.EncryptedPrivateKey, password)
-			if err != nil {
-				return nil, fmt.Errorf("failed to decrypt key for %s during migration: %w", cert.Hostname, err)
-			}
-			encrypted, err := crypto.EncryptPrivateKey(plaintext, masterKey)
-			crypto.Zero(plaintext)
+			encrypted, decrypted, err := reEncrypt(cert.EncryptedPrivateKey)
 			if err != nil {
 				return nil, fmt.Errorf("failed to re-encrypt key for %s: %w", cert.Hostname, err)
 			}
+			ok = ok && decrypted
 			rec.NewEncryptedPrivateKey = encrypted
 		}
 
 		if len(cert.PendingEncryptedPrivateKey) > 0 {
-			plaintext, err := crypto.DecryptPrivateKeyLegacy(cert.PendingEncryptedPrivateKey, password)
-			if err != nil {
-				return nil, fmt.Errorf("failed to decrypt pending key for %s during migration: %w", cert.Hostname, err)
-			}
-			encrypted, err := crypto.EncryptPrivateKey(plaintext, masterKey)
-			crypto.Zero(plaintext)
+			encrypted, decrypted, err := reEncrypt(cert.PendingEncryptedPrivateKey)
 			if err != nil {
 				return nil, fmt.Errorf("failed to re-encrypt pending key for %s: %w", cert.Hostname, err)
 			}
+			ok = ok && decrypted
 			rec.NewPendingEncryptedPrivateKey = encrypted
 		}
 
+		if !ok {
+			failedHostnames = append(failedHostnames, cert.Hostname)
+			continue
+		}
 		reEncrypted = append(reEncrypted, rec)
 	}
 
+	if len(failedHostnames) > 0 {
+		log.Error("legacy password validation failed",
+			slog.Int("failed_count", len(failedHostnames)),
+			slog.Any("failed_hostnames", failedHostnames),
+		)
+		return nil, fmt.Errorf("invalid password: failed to decrypt %d certificate(s)", len(failedHostnames))
+	}
+
 	// Wrap master key with Argon2id(password)
-	params := crypto.DefaultArgon2idParams()
+	params := a.kdfParams(a.db)
 	salt, err := crypto.GenerateSalt(params.SaltLength)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate salt: %w", err)
 	}
 
 	wrappingKey := crypto.DeriveKeyFromPassword(password, salt, params)
-	wrappedMasterKey, err := crypto.WrapMasterKey(masterKey, wrappingKey)
+	defer crypto.Zero(wrappingKey)
+	wrappedMasterKey, err := crypto.WrapMasterKey(masterKey.Bytes(), wrappingKey)
 	if err != nil {
 		return nil, fmt.Errorf("failed to wrap master key: %w", err)
 	}
@@ -419,63 +538,85 @@ func (a *App) migrateLegacyEncryption(log *slog.Logger, password string) ([]byte
 	}
 
 	// Atomic transaction: update all certs + insert security key
-	tx, err := a.db.GetDB().BeginTx(a.ctx, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to begin migration transaction: %w", err)
-	}
-	defer tx.Rollback()
-
-	qtx := a.db.Queries().WithTx(tx)
-
-	for _, rec := range reEncrypted {
-		if err := qtx.UpdateEncryptedKeys(a.ctx, sqlc.UpdateEncryptedKeysParams{
-			EncryptedPrivateKey:        rec.NewEncryptedPrivateKey,
-			PendingEncryptedPrivateKey: rec.NewPendingEncryptedPrivateKey,
-			Hostname:                   rec.Hostname,
+	if err := a.db.WithTx(a.ctx, func(q *sqlc.Queries) error {
+		for _, rec := range reEncrypted {
+			if err := q.UpdateEncryptedKeys(a.ctx, sqlc.UpdateEncryptedKeysParams{
+				EncryptedPrivateKey:        rec.NewEncryptedPrivateKey,
+				PendingEncryptedPrivateKey: rec.NewPendingEncryptedPrivateKey,
+				Hostname:                   rec.Hostname,
+			}); err != nil {
+				return fmt.Errorf("failed to update keys for %s: %w", rec.Hostname, err)
+			}
+			if err := services.RefreshKeyStatusTx(a.ctx, q, rec.Hostname, masterKey.Bytes()); err != nil {
+				return err
+			}
+		}
+		if _, err := q.InsertSecurityKey(a.ctx, sqlc.InsertSecurityKeyParams{
+			Method:           models.SecurityKeyMethodPassword,
+			Label:            "Password",
+			WrappedMasterKey: wrappedMasterKey,
+			Metadata:         sql.NullString{String: string(metadataJSON), Valid: true},
 		}); err != nil {
-			return nil, fmt.Errorf("failed to update keys for %s: %w", rec.Hostname, err)
+			return fmt.Errorf("failed to insert security key: %w", err)
 		}
-	}
-
-	if _, err := qtx.InsertSecurityKey(a.ctx, sqlc.InsertSecurityKeyParams{
-		Method:           models.SecurityKeyMethodPassword,
-		Label:            "Password",
-		WrappedMasterKey: wrappedMasterKey,
-		Metadata:         sql.NullString{String: string(metadataJSON), Valid: true},
+		return nil
 	}); err != nil {
-		return nil, fmt.Errorf("failed to insert security key: %w", err)
-	}
-
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit migration transaction: %w", err)
+		return nil, fmt.Errorf("legacy encryption migration failed: %w", err)
 	}
 
 	log.Info("legacy encryption migration completed successfully",
 		slog.Int("re_encrypted_certs", len(reEncrypted)),
 	)
 
+	handedOver = true
 	return masterKey, nil
 }
 
 // createNewMasterKey generates a fresh master key and wraps it with the password.
-func (a *App) createNewMasterKey(log *slog.Logger, password string) ([]byte, error) {
+func (a *App) createNewMasterKey(log *slog.Logger, password string) (*crypto.SecretBuffer, error) {
 	log.Info("creating new master key")
 
+	masterKey, passwordKey, err := a.newPasswordMasterKey(password)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := a.db.Queries().InsertSecurityKey(a.ctx, passwordKey); err != nil {
+		masterKey.Destroy()
+		return nil, fmt.Errorf("failed to store security key: %w", err)
+	}
+
+	log.Info("new master key created and wrapped with password")
+	return masterKey, nil
+}
+
+// newPasswordMasterKey generates a fresh master key and the password unlock
+// method wrapping it, leaving the caller to store the method (on its own or
+// within the setup transaction).
+func (a *App) newPasswordMasterKey(password string) (*crypto.SecretBuffer, sqlc.InsertSecurityKeyParams, error) {
 	masterKey, err := crypto.GenerateMasterKey()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate master key: %w", err)
+		return nil, sqlc.InsertSecurityKeyParams{}, fmt.Errorf("failed to generate master key: %w", err)
 	}
+	// Wipe the key on every failure path; only a successful run hands it over
+	handedOver := false
+	defer func() {
+		if !handedOver {
+			masterKey.Destroy()
+		}
+	}()
 
-	params := crypto.DefaultArgon2idParams()
+	params := a.kdfParams(a.db)
 	salt, err := crypto.GenerateSalt(params.SaltLength)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate salt: %w", err)
+		return nil, sqlc.InsertSecurityKeyParams{}, fmt.Errorf("failed to generate salt: %w", err)
 	}
 
 	wrappingKey := crypto.DeriveKeyFromPassword(password, salt, params)
-	wrappedMasterKey, err := crypto.WrapMasterKey(masterKey, wrappingKey)
+	defer crypto.Zero(wrappingKey)
+	wrappedMasterKey, err := crypto.WrapMasterKey(masterKey.Bytes(), wrappingKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to wrap master key: %w", err)
+		return nil, sqlc.InsertSecurityKeyParams{}, fmt.Errorf("failed to wrap master key: %w", err)
 	}
 
 	metadata := models.PasswordMetadata{
@@ -486,26 +627,37 @@ func (a *App) createNewMasterKey(log *slog.Logger, password string) ([]byte, err
 	}
 	metadataJSON, err := json.Marshal(metadata)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+		return nil, sqlc.InsertSecurityKeyParams{}, fmt.Errorf("failed to marshal metadata: %w", err)
 	}
 
-	_, err = a.db.Queries().InsertSecurityKey(a.ctx, sqlc.InsertSecurityKeyParams{
+	handedOver = true
+	return masterKey, sqlc.InsertSecurityKeyParams{
 		Method:           models.SecurityKeyMethodPassword,
 		Label:            "Password",
 		WrappedMasterKey: wrappedMasterKey,
 		Metadata:         sql.NullString{String: string(metadataJSON), Valid: true},
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to store security key: %w", err)
-	}
+	}, nil
+}
 
-	log.Info("new master key created and wrapped with password")
-	return masterKey, nil
+// kdfParams returns the Argon2id parameters for a new password wrap.
+func (a *App) kdfParams(database *db.Database) crypto.Argon2idParams {
+	return crypto.Argon2idParamsForProfile(a.kdfProfile(database))
+}
+
+// kdfProfile returns the KDF profile stored at first run. Before setup, or when
+// the config cannot be read, the profile detected for this machine is used.
+func (a *App) kdfProfile(database *db.Database) string {
+	if database != nil {
+		if cfg, err := database.Queries().GetConfig(a.ctx); err == nil {
+			return cfg.KdfProfile
+		}
+	}
+	return crypto.DetectKDFProfile()
 }
 
 // hasEncryptedCertificates checks if any certificate has encrypted key data.
 func (a *App) hasEncryptedCertificates() (bool, error) {
-	certs, err := a.db.Queries().ListAllCertificates(a.ctx)
+	certs, err := a.db.Queries().ListAllCertificatesIncludingDeleted(a.ctx)
 	if err != nil {
 		return false, fmt.Errorf("failed to list certificates: %w", err)
 	}
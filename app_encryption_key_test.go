@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 
 	"paddockcontrol-desktop/internal/crypto"
@@ -31,8 +32,8 @@ func TestProvideEncryptionKey_Migration_ValidPassword(t *testing.T) {
 	if !app.isUnlocked {
 		t.Fatal("expected app to be unlocked")
 	}
-	if len(app.masterKey) != 32 {
-		t.Fatalf("expected 32-byte master key, got %d", len(app.masterKey))
+	if app.masterKey.Len() != 32 {
+		t.Fatalf("expected 32-byte master key, got %d", app.masterKey.Len())
 	}
 	if app.needsMigration {
 		t.Fatal("needsMigration should be false after migration")
@@ -52,7 +53,7 @@ func TestProvideEncryptionKey_Migration_ValidPassword(t *testing.T) {
 		if len(cert.EncryptedPrivateKey) == 0 {
 			t.Fatalf("cert %s has empty encrypted key after migration", cert.Hostname)
 		}
-		_, err := crypto.DecryptPrivateKey(cert.EncryptedPrivateKey, app.masterKey)
+		_, err := crypto.DecryptPrivateKey(cert.EncryptedPrivateKey, app.masterKey.Bytes())
 		if err != nil {
 			t.Fatalf("cert %s: failed to decrypt with new master key: %v", cert.Hostname, err)
 		}
@@ -125,8 +126,8 @@ func TestProvideEncryptionKey_FirstTime_CreatesSecurityKey(t *testing.T) {
 	}
 
 	// Master key should be in memory
-	if len(app.masterKey) != 32 {
-		t.Fatalf("expected 32-byte master key, got %d", len(app.masterKey))
+	if app.masterKey.Len() != 32 {
+		t.Fatalf("expected 32-byte master key, got %d", app.masterKey.Len())
 	}
 
 	// One password security key should exist
@@ -173,8 +174,8 @@ func TestProvideEncryptionKey_FirstTime_Unconfigured(t *testing.T) {
 func TestProvideEncryptionKey_NormalUnlock_CorrectPassword(t *testing.T) {
 	// First: set up an unlocked app, then lock it
 	app := setupUnlockedApp(t)
-	originalMasterKey := make([]byte, len(app.masterKey))
-	copy(originalMasterKey, app.masterKey)
+	originalMasterKey := make([]byte, app.masterKey.Len())
+	copy(originalMasterKey, app.masterKey.Bytes())
 
 	// Lock the app
 	if err := app.ClearEncryptionKey(); err != nil {
@@ -194,13 +195,13 @@ func TestProvideEncryptionKey_NormalUnlock_CorrectPassword(t *testing.T) {
 	}
 
 	// Master key should match the original
-	if len(app.masterKey) != 32 {
-		t.Fatalf("expected 32-byte master key, got %d", len(app.masterKey))
+	if app.masterKey.Len() != 32 {
+		t.Fatalf("expected 32-byte master key, got %d", app.masterKey.Len())
 	}
 
 	// The unwrapped master key should match what we had before locking
 	for i := range originalMasterKey {
-		if app.masterKey[i] != originalMasterKey[i] {
+		if app.masterKey.Bytes()[i] != originalMasterKey[i] {
 			t.Fatal("unwrapped master key doesn't match the original")
 		}
 	}
@@ -291,17 +292,17 @@ func TestProvideEncryptionKey_NilDatabase(t *testing.T) {
 
 func TestChangeEncryptionKey_Success(t *testing.T) {
 	app := setupUnlockedApp(t)
-	originalMasterKey := make([]byte, len(app.masterKey))
-	copy(originalMasterKey, app.masterKey)
+	originalMasterKey := make([]byte, app.masterKey.Len())
+	copy(originalMasterKey, app.masterKey.Bytes())
 
 	newPassword := "new-password-at-least-16-chars"
-	if err := app.ChangeEncryptionKey(newPassword); err != nil {
+	if err := app.ChangeEncryptionKey(newPassword, false); err != nil {
 		t.Fatalf("ChangeEncryptionKey() error: %v", err)
 	}
 
 	// Master key in memory should be unchanged
 	for i := range originalMasterKey {
-		if app.masterKey[i] != originalMasterKey[i] {
+		if app.masterKey.Bytes()[i] != originalMasterKey[i] {
 			t.Fatal("master key in memory should not change during password change")
 		}
 	}
@@ -329,13 +330,30 @@ func TestChangeEncryptionKey_Success(t *testing.T) {
 	}
 }
 
+func TestChangeEncryptionKey_DryRunKeepsPassword(t *testing.T) {
+	app := setupUnlockedApp(t)
+
+	if err := app.ChangeEncryptionKey("new-password-at-least-16-chars", true); err != nil {
+		t.Fatalf("ChangeEncryptionKey() dry run error: %v", err)
+	}
+	if err := app.ChangeEncryptionKey("short", true); err == nil {
+		t.Fatal("expected a dry run with a short password to fail")
+	}
+
+	app.ClearEncryptionKey()
+	result, err := app.ProvideEncryptionKey(testPassword)
+	if err != nil || !result.Valid {
+		t.Fatalf("the old password should still unlock after a dry run: %v", err)
+	}
+}
+
 func TestChangeEncryptionKey_NoCertReEncryption(t *testing.T) {
 	app := setupUnlockedApp(t)
 
 	// Generate a cert so there's something encrypted
 	key, _ := crypto.GenerateRSAKey(2048)
 	keyPEM, _ := crypto.PrivateKeyToPEM(key)
-	encrypted, _ := crypto.EncryptPrivateKey(keyPEM, app.masterKey)
+	encrypted, _ := crypto.EncryptPrivateKey(keyPEM, app.masterKey.Bytes())
 
 	app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{
 		Hostname:            "test.example.com",
@@ -348,7 +366,7 @@ func TestChangeEncryptionKey_NoCertReEncryption(t *testing.T) {
 	copy(encryptedBefore, certBefore.EncryptedPrivateKey)
 
 	// Change password
-	app.ChangeEncryptionKey("new-password-at-least-16-chars")
+	app.ChangeEncryptionKey("new-password-at-least-16-chars", false)
 
 	// Encrypted key should be IDENTICAL (no re-encryption)
 	certAfter, _ := app.db.Queries().GetCertificateByHostname(app.ctx, "test.example.com")
@@ -365,7 +383,7 @@ func TestChangeEncryptionKey_NoCertReEncryption(t *testing.T) {
 func TestChangeEncryptionKey_RequiresUnlocked(t *testing.T) {
 	app := setupConfiguredApp(t) // configured but locked
 
-	err := app.ChangeEncryptionKey("new-password-at-least-16-chars")
+	err := app.ChangeEncryptionKey("new-password-at-least-16-chars", false)
 	if err == nil {
 		t.Fatal("expected error when app is locked")
 	}
@@ -374,7 +392,7 @@ func TestChangeEncryptionKey_RequiresUnlocked(t *testing.T) {
 func TestChangeEncryptionKey_ShortPassword(t *testing.T) {
 	app := setupUnlockedApp(t)
 
-	err := app.ChangeEncryptionKey("short")
+	err := app.ChangeEncryptionKey("short", false)
 	if err == nil {
 		t.Fatal("expected error for short password")
 	}
@@ -386,7 +404,7 @@ func TestChangeEncryptionKey_ShortPassword(t *testing.T) {
 
 func TestClearEncryptionKey_ZeroesMasterKey(t *testing.T) {
 	app := setupUnlockedApp(t)
-	masterKeyRef := app.masterKey // shallow reference
+	masterKeyRef := app.masterKey.Bytes() // shallow reference
 
 	if err := app.ClearEncryptionKey(); err != nil {
 		t.Fatalf("ClearEncryptionKey() error: %v", err)
@@ -434,6 +452,37 @@ func TestEnrollPasswordMethod_AddsSecondEntry(t *testing.T) {
 	}
 }
 
+func TestEnrollPasswordMethod_UsesKDFProfile(t *testing.T) {
+	app := setupUnlockedApp(t)
+
+	if _, err := app.db.DB().Exec("UPDATE config SET kdf_profile = ?", crypto.KDFProfileConstrained); err != nil {
+		t.Fatalf("failed to set kdf_profile: %v", err)
+	}
+	if err := app.EnrollPasswordMethod("another-password-16char", "Constrained"); err != nil {
+		t.Fatalf("EnrollPasswordMethod() error: %v", err)
+	}
+
+	keys, err := app.db.Queries().GetSecurityKeysByMethod(app.ctx, models.SecurityKeyMethodPassword)
+	if err != nil {
+		t.Fatalf("failed to list password keys: %v", err)
+	}
+	var metadata models.PasswordMetadata
+	if err := json.Unmarshal([]byte(keys[len(keys)-1].Metadata.String), &metadata); err != nil {
+		t.Fatalf("failed to parse metadata: %v", err)
+	}
+	if want := crypto.Argon2idParamsForProfile(crypto.KDFProfileConstrained).Memory; metadata.Argon2Memory != want {
+		t.Errorf("Argon2Memory = %d, want %d", metadata.Argon2Memory, want)
+	}
+
+	// The new password unlocks with the parameters stored next to it
+	if err := app.ClearEncryptionKey(); err != nil {
+		t.Fatalf("ClearEncryptionKey() error: %v", err)
+	}
+	if result, err := app.ProvideEncryptionKey("another-password-16char"); err != nil || !result.Valid {
+		t.Fatalf("expected the constrained password to unlock, got %v", err)
+	}
+}
+
 func TestEnrollPasswordMethod_DefaultLabel(t *testing.T) {
 	app := setupUnlockedApp(t)
 
@@ -564,3 +613,28 @@ func TestListSecurityKeys_EmptyList(t *testing.T) {
 		t.Fatalf("expected 0 keys, got %d", len(keys))
 	}
 }
+
+func TestGetSessionState(t *testing.T) {
+	app := setupTestApp(t)
+	state := app.GetSessionState()
+	if state.Configured || state.Unlocked || state.LimitedMode {
+		t.Fatalf("unconfigured app: unexpected state %+v", state)
+	}
+	if state.Version != Version {
+		t.Errorf("Version = %q, want %q", state.Version, Version)
+	}
+
+	app = setupConfiguredApp(t)
+	state = app.GetSessionState()
+	if !state.Configured || state.Unlocked || !state.LimitedMode {
+		t.Fatalf("locked app: unexpected state %+v", state)
+	}
+
+	if _, err := app.ProvideEncryptionKey(testPassword); err != nil {
+		t.Fatalf("ProvideEncryptionKey() error: %v", err)
+	}
+	state = app.GetSessionState()
+	if !state.Configured || !state.Unlocked || state.LimitedMode || state.WaitingForEncryptionKey {
+		t.Fatalf("unlocked app: unexpected state %+v", state)
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/services"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ============================================================================
+// Full Export (break-glass)
+// ============================================================================
+
+// ExportEverything prompts the user to save every certificate, with its
+// decrypted private keys, in an AES-256 encrypted ZIP archive protected by
+// password, for decommissioning or emergency offline access. confirmation must
+// be models.FullExportConfirmation, typed by the user; once the archive is
+// saved, every exported certificate gets a history entry.
+func (a *App) ExportEverything(password, confirmation string) error {
+	if err := a.requireSetupComplete(); err != nil {
+		return err
+	}
+	if confirmation != models.FullExportConfirmation {
+		return fmt.Errorf("type %q to confirm the full export", models.FullExportConfirmation)
+	}
+	if len(password) < services.MinFullExportPasswordLength {
+		return fmt.Errorf("password must be at least %d characters", services.MinFullExportPasswordLength)
+	}
+
+	_, log := logger.WithOperation(a.ctx, "export_everything")
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	database := a.db
+	encryptionKey := a.masterKey.Clone()
+	a.mu.RUnlock()
+	defer encryptionKey.Destroy()
+
+	if certificateService == nil {
+		return fmt.Errorf("certificate service not initialized")
+	}
+
+	path, err := wailsruntime.SaveFileDialog(a.ctx, wailsruntime.SaveDialogOptions{
+		DefaultFilename: fmt.Sprintf("paddockcontrol-full-export-%s.zip", a.appClock().Now().Format("20060102-150405")),
+		Title:           "Save Full Export",
+		Filters: []wailsruntime.FileFilter{
+			{DisplayName: "ZIP Archives (*.zip)", Pattern: "*.zip"},
+		},
+	})
+	if err != nil {
+		log.Error("file dialog error", logger.Err(err))
+		return fmt.Errorf("file dialog error: %w", err)
+	}
+	if path == "" {
+		log.Info("user cancelled full export dialog")
+		return nil
+	}
+
+	data, hostnames, err := certificateService.BuildFullExport(a.ctx, password, encryptionKey.Bytes())
+	if err != nil {
+		a.recordActivity("export_everything", "", err)
+		log.Error("failed to build full export", logger.Err(err))
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		err = fmt.Errorf("failed to write file: %w", err)
+		a.recordActivity("export_everything", "", err)
+		log.Error("failed to write full export", slog.String("path", path), logger.Err(err))
+		return err
+	}
+
+	// The keys are out once the file is written: record the export even if a
+	// history entry then fails
+	a.recordAudit(database, models.AuditKeyExport, "", "Every private key exported in a full export",
+		map[string]any{"format": "full_export", "certificates": len(hostnames)})
+	err = certificateService.RecordFullExport(a.ctx, hostnames)
+	a.recordActivity("export_everything", "", err)
+	if err != nil {
+		log.Error("failed to record full export history", logger.Err(err))
+		return err
+	}
+	log.Warn("full export with decrypted private keys saved",
+		slog.String("path", path),
+		slog.Int("certificates", len(hostnames)),
+	)
+	return nil
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/services"
+	"paddockcontrol-desktop/internal/timing"
+)
+
+// ============================================================================
+// Health Status
+// ============================================================================
+
+// GetHealthStatus reports runtime conditions that make statuses or validations
+// unreliable, such as a skewed local clock or a misbehaving randomness source,
+// operations a crash left unfinished, how long slow-prone operations took
+// since startup, and how well the AIA chain cache performs.
+// Available before setup and unlock.
+func (a *App) GetHealthStatus() models.HealthStatus {
+	a.mu.RLock()
+	clockCheck := a.clockCheck
+	recovered := a.recoveredOperations
+	database := a.db
+	configured := a.isConfigured
+	a.mu.RUnlock()
+
+	status := models.HealthStatus{
+		ClockCheck:          clockCheck,
+		RecoveredOperations: recovered,
+		Warnings:            []string{},
+	}
+
+	if clockCheck != nil && clockCheck.Skewed {
+		skew := time.Duration(clockCheck.SkewSeconds) * time.Second
+		direction := "ahead of"
+		if skew < 0 {
+			direction = "behind"
+			skew = -skew
+		}
+		status.Warnings = append(status.Warnings, fmt.Sprintf(
+			"System clock is %s %s %s; certificate statuses and expiry checks may be wrong",
+			skew, direction, clockCheck.Source,
+		))
+	}
+
+	for _, op := range recovered {
+		if op.Outcome == models.IntentCompleted {
+			continue
+		}
+		status.Warnings = append(status.Warnings, fmt.Sprintf(
+			"The %s of %s was interrupted when the app last closed: %s",
+			strings.ReplaceAll(op.Operation, "_", " "), op.Hostname, op.Detail,
+		))
+	}
+
+	// Key generation runs the entropy self-test itself; run it here too so the
+	// result is known before the first key is generated.
+	entropyCheck := crypto.LastEntropyCheck()
+	if entropyCheck == nil {
+		_ = crypto.EntropySelfTest()
+		entropyCheck = crypto.LastEntropyCheck()
+	}
+	status.EntropyCheck = entropyCheck
+	if entropyCheck != nil && !entropyCheck.Passed {
+		status.Warnings = append(status.Warnings, fmt.Sprintf(
+			"Randomness self-test failed (%s); key generation is disabled until it passes",
+			entropyCheck.Error,
+		))
+	}
+
+	status.ChainCache = crypto.AIACacheStats()
+
+	// Only the latest run counts: one slow run in the past is not a condition
+	// worth a warning once the operation is fast again
+	status.Timings, status.SlowOperations = timing.Snapshot()
+	for _, t := range status.Timings {
+		if t.LastSlow {
+			status.Warnings = append(status.Warnings, fmt.Sprintf(
+				"The last %s took %s (slow above %s)",
+				strings.ReplaceAll(t.Operation, "_", " "),
+				time.Duration(t.LastMs)*time.Millisecond, time.Duration(t.ThresholdMs)*time.Millisecond,
+			))
+		}
+	}
+
+	if database != nil && configured {
+		freshness, err := backupFreshness(a.ctx, database)
+		if err != nil {
+			logger.WithComponent("app").Error("failed to check backup freshness", logger.Err(err))
+		} else {
+			status.BackupFreshness = freshness
+			if freshness.Stale {
+				since := "no manual backup or export has been made yet"
+				if freshness.LastBackupAt != nil {
+					since = "since the last manual backup or export"
+				}
+				status.Warnings = append(status.Warnings, fmt.Sprintf(
+					"%d certificate changes %s; create a backup to avoid losing private keys",
+					freshness.WritesSinceBackup, since,
+				))
+			}
+		}
+
+		usage, err := databaseUsage(a.ctx, database)
+		if err != nil {
+			logger.WithComponent("app").Error("failed to check database usage", logger.Err(err))
+		} else {
+			status.DatabaseUsage = usage
+			if usage.OverQuota {
+				biggest := ""
+				if len(usage.Contributors) > 0 {
+					biggest = fmt.Sprintf(" (largest: %s)", usage.Contributors[0].Name)
+				}
+				status.Warnings = append(status.Warnings, fmt.Sprintf(
+					"Database is %.1f MiB, over the %d MiB warning size%s; review the cleanup actions in Settings",
+					float64(usage.SizeBytes)/(1<<20), usage.WarnBytes>>20, biggest,
+				))
+			}
+		}
+	}
+
+	return status
+}
+
+// recoverOperations resolves the operations a previous run left unfinished
+// (crash, power loss) in the database just opened. Called with services
+// initialized, at startup and after the database is replaced.
+func (a *App) recoverOperations() {
+	recovered, err := a.certificateService.RecoverOperationIntents(a.ctx)
+	if err != nil {
+		logger.WithComponent("app").Error("failed to recover interrupted operations", logger.Err(err))
+	}
+	a.recoveredOperations = append(a.recoveredOperations, recovered...)
+}
+
+// startClockCheck is called from domReady to compare the local clock against the
+// configured reference in the background. Skipped in air-gapped mode.
+func (a *App) startClockCheck(ctx context.Context) {
+	go func() {
+		log := logger.WithComponent("app")
+
+		a.mu.RLock()
+		configSvc := a.configService
+		configured := a.isConfigured
+		a.mu.RUnlock()
+
+		var sourceURL string
+		if configSvc != nil && configured {
+			cfg, err := configSvc.GetConfig(ctx)
+			if err != nil {
+				log.Error("failed to read clock check configuration", logger.Err(err))
+				return
+			}
+			if cfg.AirGapped == 1 {
+				log.Info("clock check skipped (air-gapped mode)")
+				a.mu.Lock()
+				a.clockCheck = &models.ClockCheckResult{Skipped: true, CheckedAt: time.Now().Unix()}
+				a.mu.Unlock()
+				return
+			}
+			sourceURL = cfg.ClockCheckUrl
+		}
+
+		result := services.CheckClockSkew(ctx, sourceURL)
+		switch {
+		case result.Error != "":
+			log.Warn("clock check failed", slog.String("source", result.Source), slog.String("error", result.Error))
+		case result.Skewed:
+			log.Warn("local clock skew detected",
+				slog.String("source", result.Source),
+				slog.Int64("skew_seconds", result.SkewSeconds),
+			)
+		default:
+			log.Info("clock check passed", slog.Int64("skew_seconds", result.SkewSeconds))
+		}
+
+		a.mu.Lock()
+		a.clockCheck = result
+		a.mu.Unlock()
+	}()
+}
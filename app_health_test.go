@@ -0,0 +1,86 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/timing"
+)
+
+func TestGetHealthStatus_NoCheckYet(t *testing.T) {
+	app := setupTestApp(t)
+
+	status := app.GetHealthStatus()
+	if status.ClockCheck != nil {
+		t.Error("expected no clock check result before the check runs")
+	}
+	if len(status.Warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", status.Warnings)
+	}
+}
+
+func TestGetHealthStatus_ClockSkewWarning(t *testing.T) {
+	app := setupTestApp(t)
+	app.clockCheck = &models.ClockCheckResult{
+		Source:      "https://time.example.com",
+		SkewSeconds: -3600,
+		Skewed:      true,
+	}
+
+	status := app.GetHealthStatus()
+	if len(status.Warnings) != 1 {
+		t.Fatalf("expected 1 warning, got %v", status.Warnings)
+	}
+	if !strings.Contains(status.Warnings[0], "1h0m0s behind https://time.example.com") {
+		t.Errorf("unexpected warning: %s", status.Warnings[0])
+	}
+}
+
+func TestGetHealthStatus_SkippedCheckHasNoWarning(t *testing.T) {
+	app := setupTestApp(t)
+	app.clockCheck = &models.ClockCheckResult{Skipped: true}
+
+	if status := app.GetHealthStatus(); len(status.Warnings) != 0 {
+		t.Errorf("expected no warnings in air-gapped mode, got %v", status.Warnings)
+	}
+}
+
+func TestGetHealthStatus_ReportsEntropyCheck(t *testing.T) {
+	app := setupTestApp(t)
+
+	status := app.GetHealthStatus()
+	if status.EntropyCheck == nil || !status.EntropyCheck.Passed {
+		t.Fatalf("expected a passing entropy check, got %+v", status.EntropyCheck)
+	}
+	if len(status.Warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", status.Warnings)
+	}
+}
+
+func TestGetHealthStatus_SlowOperationWarning(t *testing.T) {
+	app := setupTestApp(t)
+
+	timing.Record(timing.Backup, 7*time.Second)
+	status := app.GetHealthStatus()
+	if len(status.Timings) != 1 || status.Timings[0].Operation != timing.Backup || !status.Timings[0].LastSlow {
+		t.Fatalf("expected a slow backup timing, got %+v", status.Timings)
+	}
+	if len(status.SlowOperations) != 1 || status.SlowOperations[0].DurationMs != 7000 {
+		t.Errorf("expected the slow run to be listed, got %+v", status.SlowOperations)
+	}
+	if len(status.Warnings) != 1 || status.Warnings[0] != "The last backup took 7s (slow above 5s)" {
+		t.Errorf("unexpected warnings: %v", status.Warnings)
+	}
+
+	// A fast run clears the warning but the slow run stays listed
+	timing.Record(timing.Backup, 100*time.Millisecond)
+	status = app.GetHealthStatus()
+	if len(status.Warnings) != 0 {
+		t.Errorf("expected no warnings after a fast run, got %v", status.Warnings)
+	}
+	if len(status.SlowOperations) != 1 {
+		t.Errorf("expected the slow run to stay listed, got %+v", status.SlowOperations)
+	}
+}
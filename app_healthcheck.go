@@ -0,0 +1,231 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"paddockcontrol-desktop/internal/config"
+	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/profiles"
+	"paddockcontrol-desktop/internal/services"
+)
+
+// ============================================================================
+// Health Check
+// ============================================================================
+
+// healthcheckFlag runs the executable as a health probe for endpoint
+// management: it checks the active profile, prints a JSON report on stdout and
+// exits, without starting the UI
+const healthcheckFlag = "--healthcheck"
+
+// Exit codes of the health probe, following the monitoring plugin convention
+const (
+	healthcheckExitOK       = 0
+	healthcheckExitWarning  = 1
+	healthcheckExitCritical = 2
+	healthcheckExitUnknown  = 3 // the probe itself could not run
+)
+
+// healthcheckMaxBackupAge is the age of the newest local backup past which
+// changes not backed up are reported. The scheduler takes one daily while
+// there are changes, so a week means it has not run.
+const healthcheckMaxBackupAge = 7 * 24 * time.Hour
+
+// healthcheckMaxProblems caps the integrity problems reported
+const healthcheckMaxProblems = 10
+
+// runHealthcheck runs the health probe on the data directory and prints its
+// report to out. Returns the process exit code.
+func (a *App) runHealthcheck(out io.Writer) int {
+	report := &models.HealthcheckReport{Version: Version, Checks: []models.HealthcheckResult{}}
+
+	rootDir, err := a.getDataDirectory()
+	if err != nil {
+		report.Status = models.HealthcheckCritical
+		report.ExitCode = healthcheckExitUnknown
+		report.Checks = append(report.Checks, models.HealthcheckResult{
+			Name: "data_dir", Status: models.HealthcheckCritical, Message: err.Error(),
+		})
+	} else {
+		report = a.healthcheck(context.Background(), rootDir)
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return healthcheckExitUnknown
+	}
+	return report.ExitCode
+}
+
+// healthcheck checks the active profile of rootDir without changing anything:
+// the database is opened read-only and neither migrated nor moved from the
+// layout from before profiles. Each check adds a result; the worst one sets
+// the report status and exit code.
+func (a *App) healthcheck(ctx context.Context, rootDir string) *models.HealthcheckReport {
+	report := &models.HealthcheckReport{
+		Version:   Version,
+		CheckedAt: a.appClock().Now().Unix(),
+		Checks:    []models.HealthcheckResult{},
+	}
+	add := func(name, status, message string) {
+		report.Checks = append(report.Checks, models.HealthcheckResult{Name: name, Status: status, Message: message})
+	}
+	skip := func(names ...string) {
+		for _, name := range names {
+			add(name, models.HealthcheckSkipped, "not checked")
+		}
+	}
+	defer finishHealthcheck(report)
+
+	report.Profile, report.DataDir = healthcheckProfile(rootDir)
+
+	database, err := db.OpenReadOnly(report.DataDir)
+	if err != nil {
+		if _, statErr := os.Stat(filepath.Join(report.DataDir, db.FileName)); os.IsNotExist(statErr) {
+			add("database", models.HealthcheckWarning, "no database yet: the app has not been started with this profile")
+		} else {
+			add("database", models.HealthcheckCritical, err.Error())
+		}
+		skip("integrity", "schema", "setup", "backup_freshness", "local_backups")
+		return report
+	}
+	defer database.Close()
+	add("database", models.HealthcheckOK, "opened read-only")
+
+	problems, err := database.IntegrityCheck(ctx, healthcheckMaxProblems)
+	switch {
+	case err != nil:
+		add("integrity", models.HealthcheckCritical, err.Error())
+	case len(problems) > 0:
+		add("integrity", models.HealthcheckCritical, fmt.Sprintf("database is corrupt: %v", problems))
+	default:
+		add("integrity", models.HealthcheckOK, "integrity check passed")
+	}
+
+	// The remaining checks query tables of the current schema
+	version, dirty, err := database.SchemaVersion(ctx)
+	if err != nil {
+		add("schema", models.HealthcheckCritical, err.Error())
+		skip("setup", "backup_freshness", "local_backups")
+		return report
+	}
+	report.SchemaVersion = version
+	latest, err := db.LatestSchemaVersion()
+	if err != nil {
+		add("schema", models.HealthcheckCritical, err.Error())
+		skip("setup", "backup_freshness", "local_backups")
+		return report
+	}
+	switch {
+	case dirty:
+		add("schema", models.HealthcheckCritical, fmt.Sprintf("migration %d failed halfway; the app cannot start", version))
+	case version > latest:
+		add("schema", models.HealthcheckCritical, fmt.Sprintf("schema version %d was written by a newer version than %s (%d)", version, Version, latest))
+	case version < latest:
+		add("schema", models.HealthcheckOK, fmt.Sprintf("schema version %d, migrated to %d at the next start", version, latest))
+	default:
+		add("schema", models.HealthcheckOK, fmt.Sprintf("schema version %d", version))
+	}
+	if dirty || version != latest {
+		skip("setup", "backup_freshness", "local_backups")
+		return report
+	}
+
+	configured, err := config.NewService(database).IsConfigured(ctx)
+	if err != nil {
+		add("setup", models.HealthcheckCritical, err.Error())
+		skip("backup_freshness", "local_backups")
+		return report
+	}
+	report.Configured = configured
+	if !configured {
+		add("setup", models.HealthcheckWarning, "setup has not been completed")
+		skip("backup_freshness", "local_backups")
+		return report
+	}
+	add("setup", models.HealthcheckOK, "configured")
+
+	certCount, err := database.Queries().CountCertificates(ctx)
+	if err != nil {
+		add("backup_freshness", models.HealthcheckCritical, err.Error())
+		skip("local_backups")
+		return report
+	}
+	report.CertificateCount = int(certCount)
+
+	freshness, err := backupFreshness(ctx, database)
+	if err != nil {
+		add("backup_freshness", models.HealthcheckCritical, err.Error())
+		skip("local_backups")
+		return report
+	}
+	report.BackupFreshness = freshness
+	if freshness.Stale {
+		add("backup_freshness", models.HealthcheckWarning, fmt.Sprintf(
+			"%d certificate changes since the last manual backup or export (threshold %d)",
+			freshness.WritesSinceBackup, freshness.MaxWrites))
+	} else {
+		add("backup_freshness", models.HealthcheckOK, fmt.Sprintf(
+			"%d certificate changes since the last manual backup or export", freshness.WritesSinceBackup))
+	}
+
+	newest, err := services.NewestLocalBackup(report.DataDir)
+	if err != nil {
+		add("local_backups", models.HealthcheckCritical, err.Error())
+		return report
+	}
+	if newest > 0 {
+		report.LastLocalBackupAt = &newest
+	}
+	age := time.Duration(report.CheckedAt-newest) * time.Second
+	switch {
+	case freshness.WritesSinceBackup == 0 || certCount == 0:
+		add("local_backups", models.HealthcheckOK, "no changes to back up")
+	case newest == 0:
+		add("local_backups", models.HealthcheckWarning, "certificates changed but there is no local backup")
+	case age > healthcheckMaxBackupAge:
+		add("local_backups", models.HealthcheckWarning, fmt.Sprintf(
+			"certificates changed and the newest local backup is %d days old", int(age.Hours()/24)))
+	default:
+		add("local_backups", models.HealthcheckOK, "newest local backup is recent")
+	}
+
+	return report
+}
+
+// healthcheckProfile returns the profile the app opens at the next start and
+// its directory. A database still in the layout from before profiles is
+// checked where it is: the app moves it at startup, the probe does not.
+func healthcheckProfile(rootDir string) (string, string) {
+	if _, err := os.Stat(filepath.Join(rootDir, db.FileName)); err == nil && !profiles.HasDatabase(rootDir, profiles.DefaultName) {
+		return profiles.DefaultName, rootDir
+	}
+	name := profiles.Active(rootDir)
+	return name, profiles.Dir(rootDir, name)
+}
+
+// finishHealthcheck sets the report status and exit code from its worst check
+func finishHealthcheck(report *models.HealthcheckReport) {
+	report.Status = models.HealthcheckOK
+	report.ExitCode = healthcheckExitOK
+	for _, check := range report.Checks {
+		switch check.Status {
+		case models.HealthcheckCritical:
+			report.Status = models.HealthcheckCritical
+			report.ExitCode = healthcheckExitCritical
+		case models.HealthcheckWarning:
+			if report.ExitCode == healthcheckExitOK {
+				report.Status = models.HealthcheckWarning
+				report.ExitCode = healthcheckExitWarning
+			}
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/profiles"
+)
+
+// createHealthcheckProfile creates the database of the default profile under
+// root, configured when configured is set, and closes it.
+func createHealthcheckProfile(t *testing.T, root string, configured bool, prepare func(*db.Database)) {
+	t.Helper()
+	ctx := context.Background()
+	database, err := db.NewDatabase(profiles.Dir(root, profiles.DefaultName))
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	defer database.Close()
+
+	if configured {
+		if err := database.Queries().CreateConfig(ctx, sqlc.CreateConfigParams{
+			OwnerEmail:         "test@example.com",
+			CaName:             "Test CA",
+			HostnameSuffix:     ".example.com",
+			DefaultCountry:     "FR",
+			DefaultKeySize:     2048,
+			ValidityPeriodDays: 365,
+			KdfProfile:         crypto.KDFProfileStandard,
+		}); err != nil {
+			t.Fatalf("failed to create config: %v", err)
+		}
+		if err := database.Queries().SetConfigured(ctx); err != nil {
+			t.Fatalf("failed to set configured: %v", err)
+		}
+	}
+	if prepare != nil {
+		prepare(database)
+	}
+}
+
+// healthcheckStatuses maps each check of a report to its status
+func healthcheckStatuses(report *models.HealthcheckReport) map[string]string {
+	statuses := map[string]string{}
+	for _, check := range report.Checks {
+		statuses[check.Name] = check.Status
+	}
+	return statuses
+}
+
+func TestHealthcheck_Healthy(t *testing.T) {
+	root := t.TempDir()
+	createHealthcheckProfile(t, root, true, nil)
+
+	report := (&App{}).healthcheck(context.Background(), root)
+	if report.ExitCode != healthcheckExitOK || report.Status != models.HealthcheckOK {
+		t.Fatalf("expected ok, got %s (%d): %+v", report.Status, report.ExitCode, report.Checks)
+	}
+	if !report.Configured || report.Profile != profiles.DefaultName {
+		t.Errorf("unexpected report: %+v", report)
+	}
+	if len(report.Checks) != 6 {
+		t.Errorf("expected 6 checks, got %+v", report.Checks)
+	}
+
+	// The probe must not leave anything behind, such as a backups directory
+	if _, err := os.Stat(filepath.Join(profiles.Dir(root, profiles.DefaultName), "backups")); !os.IsNotExist(err) {
+		t.Errorf("expected no backups directory, got %v", err)
+	}
+}
+
+func TestHealthcheck_NoDatabase(t *testing.T) {
+	report := (&App{}).healthcheck(context.Background(), t.TempDir())
+	if report.ExitCode != healthcheckExitWarning {
+		t.Fatalf("expected warning exit code, got %d: %+v", report.ExitCode, report.Checks)
+	}
+	if got := healthcheckStatuses(report)["integrity"]; got != models.HealthcheckSkipped {
+		t.Errorf("expected integrity skipped, got %s", got)
+	}
+}
+
+func TestHealthcheck_NotConfigured(t *testing.T) {
+	root := t.TempDir()
+	createHealthcheckProfile(t, root, false, nil)
+
+	report := (&App{}).healthcheck(context.Background(), root)
+	if report.ExitCode != healthcheckExitWarning {
+		t.Fatalf("expected warning exit code, got %d: %+v", report.ExitCode, report.Checks)
+	}
+	if got := healthcheckStatuses(report)["setup"]; got != models.HealthcheckWarning {
+		t.Errorf("expected setup warning, got %s", got)
+	}
+}
+
+func TestHealthcheck_CorruptDatabase(t *testing.T) {
+	root := t.TempDir()
+	dir := profiles.Dir(root, profiles.DefaultName)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, db.FileName), bytes.Repeat([]byte("not a database "), 512), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	report := (&App{}).healthcheck(context.Background(), root)
+	if report.ExitCode != healthcheckExitCritical || report.Status != models.HealthcheckCritical {
+		t.Fatalf("expected critical, got %s (%d): %+v", report.Status, report.ExitCode, report.Checks)
+	}
+}
+
+func TestHealthcheck_DirtyMigration(t *testing.T) {
+	root := t.TempDir()
+	createHealthcheckProfile(t, root, true, func(database *db.Database) {
+		if _, err := database.DB().Exec("UPDATE schema_migrations SET dirty = 1"); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	report := (&App{}).healthcheck(context.Background(), root)
+	if report.ExitCode != healthcheckExitCritical {
+		t.Fatalf("expected critical exit code, got %d: %+v", report.ExitCode, report.Checks)
+	}
+	statuses := healthcheckStatuses(report)
+	if statuses["schema"] != models.HealthcheckCritical || statuses["setup"] != models.HealthcheckSkipped {
+		t.Errorf("unexpected statuses: %v", statuses)
+	}
+}
+
+func TestHealthcheck_StaleBackups(t *testing.T) {
+	root := t.TempDir()
+	createHealthcheckProfile(t, root, true, func(database *db.Database) {
+		ctx := context.Background()
+		if _, err := database.DB().Exec("UPDATE config SET backup_freshness_max_writes = 2"); err != nil {
+			t.Fatal(err)
+		}
+		for _, hostname := range []string{"a.example.com", "b.example.com"} {
+			if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+				Hostname:            hostname,
+				EncryptedPrivateKey: []byte("key"),
+			}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
+	report := (&App{}).healthcheck(context.Background(), root)
+	if report.ExitCode != healthcheckExitWarning {
+		t.Fatalf("expected warning exit code, got %d: %+v", report.ExitCode, report.Checks)
+	}
+	statuses := healthcheckStatuses(report)
+	if statuses["backup_freshness"] != models.HealthcheckWarning || statuses["local_backups"] != models.HealthcheckWarning {
+		t.Errorf("unexpected statuses: %v", statuses)
+	}
+	if report.CertificateCount != 2 || report.BackupFreshness == nil || !report.BackupFreshness.Stale {
+		t.Errorf("unexpected report: %+v", report)
+	}
+}
+
+func TestRunHealthcheck_PrintsJSON(t *testing.T) {
+	root := t.TempDir()
+	createHealthcheckProfile(t, root, true, nil)
+	t.Setenv("PADDOCKCONTROL_DATA_DIR", root)
+
+	var out bytes.Buffer
+	code := (&App{}).runHealthcheck(&out)
+
+	var report models.HealthcheckReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	if code != healthcheckExitOK || report.ExitCode != code {
+		t.Errorf("expected exit code 0 in output and result, got %d and %d", report.ExitCode, code)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+
+	"paddockcontrol-desktop/internal/logger"
+)
+
+// ============================================================================
+// Key Envelope Migration
+// ============================================================================
+
+// startKeyEnvelopeMigration re-encrypts, in the background, the stored private
+// keys still in the pre-envelope format (see crypto/envelope.go). Started on
+// every unlock; it finds nothing to do once the database has been migrated.
+// It then computes the key status of certificates written while locked.
+// Callers hold a.mu.
+func (a *App) startKeyEnvelopeMigration() {
+	if a.certificateService == nil || a.keyEnvelopeCancel != nil {
+		return
+	}
+
+	log := logger.WithComponent("app")
+	masterKey := a.masterKey.Clone()
+	certificateService := a.certificateService
+
+	ctx, cancel := context.WithCancel(a.ctx)
+	done := make(chan struct{})
+	a.keyEnvelopeCancel = cancel
+	a.keyEnvelopeDone = done
+
+	go func() {
+		defer masterKey.Destroy()
+		defer func() {
+			a.mu.Lock()
+			if ctx.Err() == nil {
+				a.keyEnvelopeCancel = nil
+				a.keyEnvelopeDone = nil
+			}
+			a.mu.Unlock()
+			cancel()
+		}()
+
+		migrated, err := certificateService.MigrateKeyEnvelopes(ctx, masterKey.Bytes())
+		var checked int
+		var checkErr error
+		if err == nil {
+			checked, checkErr = certificateService.CheckUncheckedKeyStatuses(ctx, masterKey.Bytes())
+		}
+		// Closed before taking a.mu: stopKeyEnvelopeMigration waits on it while
+		// holding the lock
+		close(done)
+		if err != nil {
+			log.Error("key envelope migration failed", slog.Int("migrated", migrated), logger.Err(err))
+			return
+		}
+		if migrated > 0 {
+			log.Info("stored keys re-encrypted in the current envelope format", slog.Int("migrated", migrated))
+		}
+		if checkErr != nil {
+			log.Error("key status check failed", slog.Int("checked", checked), logger.Err(checkErr))
+		}
+	}()
+}
+
+// stopKeyEnvelopeMigration cancels a running key envelope migration and waits
+// until it no longer uses the database, before the master key is cleared or
+// the database replaced. Callers hold a.mu.
+func (a *App) stopKeyEnvelopeMigration() {
+	if a.keyEnvelopeCancel != nil {
+		a.keyEnvelopeCancel()
+		<-a.keyEnvelopeDone
+		a.keyEnvelopeCancel = nil
+		a.keyEnvelopeDone = nil
+	}
+}
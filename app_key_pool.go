@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"paddockcontrol-desktop/internal/logger"
+)
+
+// ============================================================================
+// Key Pool
+// ============================================================================
+
+// keyPoolIdleDelay is how long the key pool waits after an unlock or a CSR
+// before generating keys, so it does not compete with the work in progress
+const keyPoolIdleDelay = 3 * time.Second
+
+// startKeyPoolFill generates keys of the default size in the background until
+// the pool holds key_pool_size of them, encrypted under the master key. A
+// no-op while locked or while a fill is running; a disabled pool is emptied.
+// Callers hold a.mu.
+func (a *App) startKeyPoolFill() {
+	if !a.isUnlocked || a.db == nil {
+		return
+	}
+	cfg, err := a.db.Queries().GetConfig(a.ctx)
+	if err != nil {
+		return
+	}
+	if cfg.KeyPoolSize == 0 {
+		a.stopKeyPool()
+		return
+	}
+	if a.keyPoolCancel != nil {
+		return
+	}
+	keySize, target := int(cfg.DefaultKeySize), int(cfg.KeyPoolSize)
+	if a.keyPool.Available(keySize) >= target {
+		return
+	}
+
+	log := logger.WithComponent("app")
+	masterKey := a.masterKey.Clone()
+
+	ctx, cancel := context.WithCancel(a.ctx)
+	a.keyPoolCancel = cancel
+
+	go func() {
+		defer masterKey.Destroy()
+		defer func() {
+			a.mu.Lock()
+			if ctx.Err() == nil {
+				a.keyPoolCancel = nil
+			}
+			a.mu.Unlock()
+			cancel()
+		}()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(keyPoolIdleDelay):
+		}
+
+		added, err := a.keyPool.Fill(ctx, keySize, target, masterKey.Bytes())
+		if err != nil && ctx.Err() == nil {
+			log.Error("key pool generation failed", slog.Int("added", added), logger.Err(err))
+			return
+		}
+		if added > 0 {
+			log.Info("key pool replenished", slog.Int("added", added), slog.Int("key_size", keySize))
+		}
+	}()
+}
+
+// refillKeyPool starts replenishing the key pool after a key was taken or the
+// settings changed.
+func (a *App) refillKeyPool() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.startKeyPoolFill()
+}
+
+// stopKeyPool cancels a running fill and drops the pooled keys, when the
+// master key is cleared or the database replaced. A key being generated is
+// discarded once done. Callers hold a.mu.
+func (a *App) stopKeyPool() {
+	if a.keyPoolCancel != nil {
+		a.keyPoolCancel()
+		a.keyPoolCancel = nil
+	}
+	a.keyPool.Clear()
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestKeyPool_DisabledAndLocked(t *testing.T) {
+	app := setupUnlockedApp(t)
+
+	if _, err := app.keyPool.Fill(context.Background(), 2048, 1, app.masterKey.Bytes()); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+
+	// key_pool_size defaults to 0: the pool is disabled and emptied
+	app.refillKeyPool()
+	if app.keyPool.Available(2048) != 0 {
+		t.Error("expected a disabled pool to be emptied")
+	}
+
+	if _, err := app.keyPool.Fill(context.Background(), 2048, 1, app.masterKey.Bytes()); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	if err := app.ClearEncryptionKey(); err != nil {
+		t.Fatalf("ClearEncryptionKey failed: %v", err)
+	}
+	if app.keyPool.Available(2048) != 0 {
+		t.Error("expected clearing the master key to drop the pooled keys")
+	}
+}
+
+func TestKeyPool_StartsWhenEnabled(t *testing.T) {
+	app := setupUnlockedApp(t)
+
+	if _, err := app.db.DB().Exec("UPDATE config SET key_pool_size = 2"); err != nil {
+		t.Fatalf("failed to set key_pool_size: %v", err)
+	}
+	app.refillKeyPool()
+
+	app.mu.RLock()
+	running := app.keyPoolCancel != nil
+	app.mu.RUnlock()
+	if !running {
+		t.Error("expected a key pool fill to be running")
+	}
+}
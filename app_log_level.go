@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+
+	"paddockcontrol-desktop/internal/logger"
+)
+
+// ============================================================================
+// Log Levels
+// ============================================================================
+
+// logComponentPattern restricts component names for overrides to the shape
+// the code uses (lowercase, digits, dots, dashes and underscores)
+var logComponentPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// GetLogLevels returns the runtime log level, the per-component overrides and
+// the components seen since startup.
+// Available before setup and unlock.
+func (a *App) GetLogLevels() *logger.LevelSettings {
+	return logger.CurrentLevelSettings()
+}
+
+// SetLogLevel changes the minimum level logged by every component without an
+// override (debug, info, warn or error), effective immediately without a
+// restart. An empty level restores the build default. Once setup is complete
+// the level is persisted in config and applied at the next startup.
+// Available before setup and unlock.
+func (a *App) SetLogLevel(level string) error {
+	stored := ""
+	if level != "" {
+		parsed, err := logger.ParseLevel(level)
+		if err != nil {
+			return err
+		}
+		stored = logger.LevelName(parsed)
+		logger.SetLevel(parsed)
+	} else {
+		logger.ResetLevel()
+	}
+
+	logger.WithComponent("app").Info("log level changed", slog.String("level", logger.LevelName(logger.Level())))
+
+	err := a.persistLogLevel(stored)
+	a.recordActivity("set_log_level", "", err)
+	return err
+}
+
+// SetComponentLogLevel overrides the minimum level logged by one component,
+// e.g. "certificate" or "database", effective immediately. An empty level
+// removes the override so the component follows the global level. Overrides
+// are persisted in config once setup is complete.
+// Available before setup and unlock.
+func (a *App) SetComponentLogLevel(component, level string) error {
+	if !logComponentPattern.MatchString(component) {
+		return fmt.Errorf("invalid log component %q", component)
+	}
+
+	if level == "" {
+		logger.ClearComponentLevel(component)
+	} else {
+		parsed, err := logger.ParseLevel(level)
+		if err != nil {
+			return err
+		}
+		logger.SetComponentLevel(component, parsed)
+	}
+
+	logger.WithComponent("app").Info("component log level changed",
+		slog.String("log_component", component),
+		slog.String("level", level),
+	)
+
+	err := a.persistComponentLogLevels()
+	a.recordActivity("set_log_level", "", err)
+	return err
+}
+
+// persistLogLevel stores the global level. Before setup there is no config
+// row, and the level lasts until the app exits.
+func (a *App) persistLogLevel(level string) error {
+	a.mu.RLock()
+	database := a.db
+	a.mu.RUnlock()
+
+	if database == nil {
+		return nil
+	}
+	if err := database.Queries().UpdateLogLevel(a.ctx, level); err != nil {
+		return fmt.Errorf("failed to save log level: %w", err)
+	}
+	return nil
+}
+
+// persistComponentLogLevels stores the component overrides as a JSON object.
+func (a *App) persistComponentLogLevels() error {
+	a.mu.RLock()
+	database := a.db
+	a.mu.RUnlock()
+
+	if database == nil {
+		return nil
+	}
+
+	stored := ""
+	if overrides := logger.CurrentLevelSettings().Components; len(overrides) > 0 {
+		data, err := json.Marshal(overrides)
+		if err != nil {
+			return fmt.Errorf("failed to encode log levels: %w", err)
+		}
+		stored = string(data)
+	}
+	if err := database.Queries().UpdateComponentLogLevels(a.ctx, stored); err != nil {
+		return fmt.Errorf("failed to save log levels: %w", err)
+	}
+	return nil
+}
+
+// applyStoredLogLevels applies the levels persisted in config, replacing the
+// runtime ones. Invalid entries are logged and skipped; a database without a
+// config row keeps the build default.
+func (a *App) applyStoredLogLevels() {
+	log := logger.WithComponent("app")
+
+	logger.ResetLevel()
+	logger.SetComponentLevels(nil)
+
+	cfg, err := a.db.Queries().GetConfig(a.ctx)
+	if err != nil {
+		return
+	}
+
+	if cfg.LogLevel != "" {
+		if parsed, err := logger.ParseLevel(cfg.LogLevel); err != nil {
+			log.Warn("ignoring stored log level", slog.String("level", cfg.LogLevel), logger.Err(err))
+		} else {
+			logger.SetLevel(parsed)
+		}
+	}
+
+	overrides := make(map[string]slog.Level)
+	if cfg.LogComponentLevels != "" {
+		var stored map[string]string
+		if err := json.Unmarshal([]byte(cfg.LogComponentLevels), &stored); err != nil {
+			log.Warn("ignoring stored component log levels", logger.Err(err))
+		}
+		for component, level := range stored {
+			parsed, err := logger.ParseLevel(level)
+			if err != nil || !logComponentPattern.MatchString(component) {
+				log.Warn("ignoring stored component log level", slog.String("log_component", component), slog.String("level", level))
+				continue
+			}
+			overrides[component] = parsed
+		}
+	}
+	logger.SetComponentLevels(overrides)
+
+	if cfg.LogLevel != "" || len(overrides) > 0 {
+		log.Info("applied stored log levels",
+			slog.String("level", logger.LevelName(logger.Level())),
+			slog.Int("component_overrides", len(overrides)),
+		)
+	}
+}
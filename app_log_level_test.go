@@ -0,0 +1,56 @@
+package main
+
+import (
+	"log/slog"
+	"testing"
+
+	"paddockcontrol-desktop/internal/logger"
+)
+
+func TestSetLogLevel_PersistsAndReapplies(t *testing.T) {
+	app := setupConfiguredApp(t)
+	t.Cleanup(func() {
+		logger.ResetLevel()
+		logger.SetComponentLevels(nil)
+	})
+
+	if err := app.SetLogLevel("verbose"); err == nil {
+		t.Error("expected an unknown level to be rejected")
+	}
+	if err := app.SetComponentLogLevel("Bad Component", "debug"); err == nil {
+		t.Error("expected an invalid component name to be rejected")
+	}
+
+	if err := app.SetLogLevel("warn"); err != nil {
+		t.Fatalf("SetLogLevel() error: %v", err)
+	}
+	if err := app.SetComponentLogLevel("certificate", "debug"); err != nil {
+		t.Fatalf("SetComponentLogLevel() error: %v", err)
+	}
+	settings := app.GetLogLevels()
+	if settings.Level != "warn" || settings.Components["certificate"] != "debug" {
+		t.Fatalf("unexpected runtime levels: %+v", settings)
+	}
+
+	// A restart applies what was persisted
+	logger.ResetLevel()
+	logger.SetComponentLevels(nil)
+	app.applyStoredLogLevels()
+	if logger.Level() != slog.LevelWarn || logger.ComponentLevels()["certificate"] != slog.LevelDebug {
+		t.Errorf("stored levels not applied: %+v", app.GetLogLevels())
+	}
+
+	if err := app.SetLogLevel(""); err != nil {
+		t.Fatalf("SetLogLevel() error: %v", err)
+	}
+	if err := app.SetComponentLogLevel("certificate", ""); err != nil {
+		t.Fatalf("SetComponentLogLevel() error: %v", err)
+	}
+	cfg, err := app.db.Queries().GetConfig(app.ctx)
+	if err != nil {
+		t.Fatalf("GetConfig() error: %v", err)
+	}
+	if cfg.LogLevel != "" || cfg.LogComponentLevels != "" {
+		t.Errorf("expected the defaults to be stored, got %q and %q", cfg.LogLevel, cfg.LogComponentLevels)
+	}
+}
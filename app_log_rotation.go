@@ -0,0 +1,54 @@
+package main
+
+import (
+	"log/slog"
+
+	"paddockcontrol-desktop/internal/logger"
+)
+
+// ============================================================================
+// Log Rotation
+// ============================================================================
+
+// applyStoredLogRotation applies the log rotation settings stored in config
+// and removes the rotated log files they no longer keep. A database without a
+// config row keeps the defaults.
+func (a *App) applyStoredLogRotation() {
+	cfg, err := a.db.Queries().GetConfig(a.ctx)
+	if err != nil {
+		return
+	}
+	applyLogRotation(int(cfg.LogMaxSizeMb), int(cfg.LogMaxFiles), int(cfg.LogMaxAgeDays), cfg.LogCompress == 1)
+}
+
+// applyLogRotation changes the log rotation settings. Failures are logged:
+// the previous settings stay in place and logging goes on.
+func applyLogRotation(maxSizeMB, maxFiles, maxAgeDays int, compress bool) {
+	log := logger.WithComponent("app")
+
+	settings := logger.RotationSettings{
+		MaxSizeMB:  maxSizeMB,
+		MaxFiles:   maxFiles,
+		MaxAgeDays: maxAgeDays,
+		Compress:   compress,
+	}
+	changed := settings != logger.Rotation()
+
+	// Applied even when unchanged, so files left over from looser settings
+	// (or a clock change) are removed at startup
+	removed, err := logger.SetRotation(settings)
+	if err != nil {
+		log.Error("failed to apply log rotation settings", logger.Err(err))
+		return
+	}
+	if !changed && removed == 0 {
+		return
+	}
+	log.Info("log rotation settings applied",
+		slog.Int("max_size_mb", maxSizeMB),
+		slog.Int("max_files", maxFiles),
+		slog.Int("max_age_days", maxAgeDays),
+		slog.Bool("compress", compress),
+		slog.Int("removed_files", removed),
+	)
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bytes"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+
+	"github.com/wailsapp/wails/v2/pkg/options"
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ============================================================================
+// Opened Files (single instance, file associations)
+// ============================================================================
+
+// singleInstanceID identifies the running app to a second process, which hands
+// it its arguments and exits (see main.go)
+const singleInstanceID = "com.mokoguy.paddockcontrol-desktop"
+
+// maxOpenedPEMSize caps PEM files read when handed to the app
+const maxOpenedPEMSize = 1 << 20
+
+// sqliteHeader starts every SQLite database file
+var sqliteHeader = []byte("SQLite format 3\x00")
+
+// TakeOpenedFiles returns the files the app was launched with (file
+// associations) and those handed over before the frontend was ready, then
+// switches to delivering them as "file:opened" events.
+// Available before setup and unlock.
+func (a *App) TakeOpenedFiles() []*models.OpenedFile {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	files := a.openedFiles
+	a.openedFiles = nil
+	a.openedFilesReady = true
+	if files == nil {
+		files = []*models.OpenedFile{}
+	}
+	return files
+}
+
+// onSecondInstanceLaunch is called when the app is started again while running,
+// e.g. by double-clicking a backup or PEM file. It brings the window to the
+// front and opens the file arguments.
+func (a *App) onSecondInstanceLaunch(data options.SecondInstanceData) {
+	logger.WithComponent("app").Info("second instance launched", slog.Int("args", len(data.Args)))
+
+	wailsruntime.WindowUnminimise(a.ctx)
+	wailsruntime.WindowShow(a.ctx)
+
+	a.openFiles(data.Args, data.WorkingDirectory)
+}
+
+// onFileOpen receives the files macOS opens with the app (file associations),
+// at launch or while running.
+func (a *App) onFileOpen(path string) {
+	a.openFiles([]string{path}, "")
+}
+
+// openLaunchFiles opens the file arguments the app was started with: Windows
+// and Linux pass associated files on the command line.
+func (a *App) openLaunchFiles() {
+	wd, _ := os.Getwd()
+	a.openFiles(os.Args[1:], wd)
+}
+
+// openFiles classifies each file argument and delivers it to the frontend: as a
+// "file:opened" event once it took the launch files, queued for TakeOpenedFiles
+// before. Files that cannot be used are reported with "file:open-failed".
+func (a *App) openFiles(args []string, workingDir string) {
+	log := logger.WithComponent("app")
+
+	for _, arg := range args {
+		if arg == "" || strings.HasPrefix(arg, "-") {
+			continue
+		}
+		path := arg
+		if !filepath.IsAbs(path) && workingDir != "" {
+			path = filepath.Join(workingDir, path)
+		}
+
+		file, err := classifyOpenedFile(path)
+		if err != nil {
+			log.Warn("ignoring opened file", slog.String("path", path), logger.Err(err))
+			if a.ctx != nil {
+				wailsruntime.EventsEmit(a.ctx, "file:open-failed", err.Error())
+			}
+			continue
+		}
+		a.matchOpenedFile(file)
+		log.Info("file opened", slog.String("path", path), slog.String("kind", file.Kind), slog.String("hostname", file.Hostname))
+
+		a.mu.Lock()
+		ready := a.openedFilesReady
+		if !ready {
+			a.openedFiles = append(a.openedFiles, file)
+		}
+		a.mu.Unlock()
+		if ready {
+			wailsruntime.EventsEmit(a.ctx, "file:opened", file)
+		}
+	}
+}
+
+// matchOpenedFile sets the hostname whose pending CSR holds the public key of
+// an opened certificate (without private key) or CSR, so a certificate returned
+// by the CA opens the upload preview of the right entry.
+func (a *App) matchOpenedFile(file *models.OpenedFile) {
+	var pub any
+	switch {
+	case file.Kind == models.OpenedFileCSR:
+		csr, err := crypto.ParseCSR([]byte(file.CSRPEM))
+		if err != nil {
+			return
+		}
+		pub = csr.PublicKey
+	case file.Kind == models.OpenedFileCertificate && file.CertificatePEM != "" && file.PrivateKeyPEM == "":
+		cert, err := crypto.ParseCertificate([]byte(file.CertificatePEM))
+		if err != nil {
+			return
+		}
+		pub = cert.PublicKey
+	default:
+		return
+	}
+
+	fingerprint, err := crypto.PublicKeyFingerprint(pub)
+	if err != nil {
+		return
+	}
+
+	a.mu.RLock()
+	database := a.db
+	a.mu.RUnlock()
+	if database == nil {
+		return
+	}
+
+	certs, err := database.Queries().ListAllCertificates(a.ctx)
+	if err != nil {
+		logger.WithComponent("app").Error("failed to match opened file", logger.Err(err))
+		return
+	}
+	for _, c := range certs {
+		if !c.PendingCsrPem.Valid || c.PendingCsrPem.String == "" {
+			continue
+		}
+		csr, err := crypto.ParseCSR([]byte(c.PendingCsrPem.String))
+		if err != nil {
+			continue
+		}
+		if fp, err := crypto.PublicKeyFingerprint(csr.PublicKey); err == nil && fp == fingerprint {
+			file.Hostname = c.Hostname
+			return
+		}
+	}
+}
+
+// classifyOpenedFile tells a database backup from a PEM file by its content,
+// and splits a PEM file into its certificate, chain, private key or CSR.
+func classifyOpenedFile(path string) (*models.OpenedFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", filepath.Base(path), err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxOpenedPEMSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+
+	if bytes.HasPrefix(data, sqliteHeader) {
+		return &models.OpenedFile{Path: path, Kind: models.OpenedFileBackup}, nil
+	}
+	if len(data) > maxOpenedPEMSize {
+		return nil, fmt.Errorf("%s is not a backup and too large for a PEM file", filepath.Base(path))
+	}
+
+	file := &models.OpenedFile{Path: path, Kind: models.OpenedFileCertificate}
+	var chain []byte
+	for rest := data; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		switch {
+		case block.Type == "CERTIFICATE" && file.CertificatePEM == "":
+			file.CertificatePEM = string(pem.EncodeToMemory(block))
+		case block.Type == "CERTIFICATE":
+			chain = append(chain, pem.EncodeToMemory(block)...)
+		case strings.HasSuffix(block.Type, "PRIVATE KEY") && file.PrivateKeyPEM == "":
+			file.PrivateKeyPEM = string(pem.EncodeToMemory(block))
+		case strings.HasSuffix(block.Type, "CERTIFICATE REQUEST") && file.CSRPEM == "":
+			file.CSRPEM = string(pem.EncodeToMemory(block))
+		}
+	}
+	file.ChainPEM = string(chain)
+
+	switch {
+	case file.CertificatePEM != "" || file.PrivateKeyPEM != "":
+		file.CSRPEM = ""
+		return file, nil
+	case file.CSRPEM != "":
+		file.Kind = models.OpenedFileCSR
+		return file, nil
+	default:
+		return nil, fmt.Errorf("%s is not a backup, certificate, private key or CSR", filepath.Base(path))
+	}
+}
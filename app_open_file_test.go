@@ -0,0 +1,103 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+func writeOpenedFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestClassifyOpenedFile_Backup(t *testing.T) {
+	_, tmpDir := setupFileBasedApp(t)
+
+	file, err := classifyOpenedFile(filepath.Join(tmpDir, "certificates.db"))
+	if err != nil {
+		t.Fatalf("classifyOpenedFile() error: %v", err)
+	}
+	if file.Kind != models.OpenedFileBackup {
+		t.Errorf("Kind = %q, want %q", file.Kind, models.OpenedFileBackup)
+	}
+}
+
+func TestClassifyOpenedFile_SplitsPEM(t *testing.T) {
+	block := func(typ, body string) []byte {
+		return pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: []byte(body)})
+	}
+	var data []byte
+	data = append(data, block("PRIVATE KEY", "key")...)
+	data = append(data, block("CERTIFICATE", "leaf")...)
+	data = append(data, block("CERTIFICATE", "intermediate")...)
+
+	file, err := classifyOpenedFile(writeOpenedFile(t, "bundle.pem", data))
+	if err != nil {
+		t.Fatalf("classifyOpenedFile() error: %v", err)
+	}
+	if file.Kind != models.OpenedFileCertificate {
+		t.Errorf("Kind = %q, want %q", file.Kind, models.OpenedFileCertificate)
+	}
+	if file.CertificatePEM != string(block("CERTIFICATE", "leaf")) {
+		t.Errorf("CertificatePEM should be the first certificate, got %q", file.CertificatePEM)
+	}
+	if file.ChainPEM != string(block("CERTIFICATE", "intermediate")) {
+		t.Errorf("ChainPEM should hold the following certificates, got %q", file.ChainPEM)
+	}
+	if file.PrivateKeyPEM != string(block("PRIVATE KEY", "key")) {
+		t.Errorf("unexpected PrivateKeyPEM %q", file.PrivateKeyPEM)
+	}
+}
+
+func TestClassifyOpenedFile_RejectsOtherFiles(t *testing.T) {
+	_, err := classifyOpenedFile(writeOpenedFile(t, "notes.txt", []byte("not a certificate")))
+	if err == nil || !strings.Contains(err.Error(), "notes.txt") {
+		t.Errorf("expected an error naming the file, got %v", err)
+	}
+}
+
+func TestOpenFiles_QueuesUntilTakenAndMatchesPendingCSR(t *testing.T) {
+	app := setupTestApp(t)
+	csrPEM := newTestCSR(t, "web.example.com")
+	if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{
+		Hostname:      "web.example.com",
+		PendingCsrPem: sql.NullString{String: csrPEM, Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "web.csr")
+	if err := os.WriteFile(path, []byte(csrPEM), 0600); err != nil {
+		t.Fatalf("failed to write CSR: %v", err)
+	}
+	other := writeOpenedFile(t, "other.csr", []byte(newTestCSR(t, "other.example.com")))
+
+	// Relative paths resolve against the launching process's directory
+	app.openFiles([]string{"-flag", "web.csr", other}, dir)
+
+	files := app.TakeOpenedFiles()
+	if len(files) != 2 {
+		t.Fatalf("expected 2 queued files, got %+v", files)
+	}
+	if files[0].Path != path || files[0].Kind != models.OpenedFileCSR || files[0].Hostname != "web.example.com" {
+		t.Errorf("unexpected matched file: %+v", files[0])
+	}
+	if files[1].Hostname != "" {
+		t.Errorf("a CSR without pending entry should not match, got %q", files[1].Hostname)
+	}
+	if len(app.TakeOpenedFiles()) != 0 {
+		t.Error("taken files should not be returned again")
+	}
+}
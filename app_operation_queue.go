@@ -0,0 +1,93 @@
+package main
+
+import (
+	"slices"
+	"sync"
+
+	"paddockcontrol-desktop/internal/hostnames"
+)
+
+// ============================================================================
+// Per-Hostname Operation Queue
+// ============================================================================
+
+// hostnameLocks serializes write operations per hostname.
+// Overlapping UI actions on the same certificate (e.g. upload while a note
+// edit or a clear-pending is still in flight) wait for each other instead of
+// interleaving their reads and writes. Operations on different hostnames
+// still run concurrently.
+type hostnameLocks struct {
+	mu    sync.Mutex
+	locks map[string]*hostnameLock
+}
+
+// hostnameLock is a reference-counted mutex for a single hostname.
+// The entry is removed from the map once no operation holds or waits on it.
+type hostnameLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// lock blocks until no other operation holds the hostname and returns the
+// function that releases it.
+func (h *hostnameLocks) lock(hostname string) func() {
+	h.mu.Lock()
+	if h.locks == nil {
+		h.locks = make(map[string]*hostnameLock)
+	}
+	l, ok := h.locks[hostname]
+	if !ok {
+		l = &hostnameLock{}
+		h.locks[hostname] = l
+	}
+	l.refs++
+	h.mu.Unlock()
+
+	l.mu.Lock()
+
+	return func() {
+		l.mu.Unlock()
+
+		h.mu.Lock()
+		l.refs--
+		if l.refs == 0 {
+			delete(h.locks, hostname)
+		}
+		h.mu.Unlock()
+	}
+}
+
+// lockHostname serializes a write operation on hostname with any other
+// in-flight write on the same hostname. Call the returned function to release.
+func (a *App) lockHostname(hostname string) func() {
+	// Key by the normalized form, matching how hostnames are stored.
+	if normalized, err := hostnames.Normalize(hostname); err == nil {
+		hostname = normalized
+	}
+	return a.hostLocks.lock(hostname)
+}
+
+// lockHostnames locks several hostnames for a bulk operation. Locks are taken
+// in sorted order so two bulk operations cannot deadlock each other. Call the
+// returned function to release them all.
+func (a *App) lockHostnames(names []string) func() {
+	keys := make([]string, 0, len(names))
+	for _, hostname := range names {
+		if normalized, err := hostnames.Normalize(hostname); err == nil {
+			hostname = normalized
+		}
+		keys = append(keys, hostname)
+	}
+	slices.Sort(keys)
+	keys = slices.Compact(keys)
+
+	unlocks := make([]func(), 0, len(keys))
+	for _, key := range keys {
+		unlocks = append(unlocks, a.hostLocks.lock(key))
+	}
+	return func() {
+		for i := len(unlocks) - 1; i >= 0; i-- {
+			unlocks[i]()
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHostnameLocks_SerializesSameHostname(t *testing.T) {
+	var locks hostnameLocks
+	var active, maxActive int32
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := locks.lock("web.example.com")
+			defer unlock()
+
+			n := atomic.AddInt32(&active, 1)
+			for {
+				m := atomic.LoadInt32(&maxActive)
+				if n <= m || atomic.CompareAndSwapInt32(&maxActive, m, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&active, -1)
+		}()
+	}
+	wg.Wait()
+
+	if maxActive != 1 {
+		t.Fatalf("expected at most 1 concurrent operation, got %d", maxActive)
+	}
+	if len(locks.locks) != 0 {
+		t.Fatalf("expected lock map to be empty after release, got %d entries", len(locks.locks))
+	}
+}
+
+func TestHostnameLocks_DifferentHostnamesRunConcurrently(t *testing.T) {
+	var locks hostnameLocks
+
+	unlockA := locks.lock("a.example.com")
+	defer unlockA()
+
+	done := make(chan struct{})
+	go func() {
+		unlockB := locks.lock("b.example.com")
+		unlockB()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("lock on a different hostname should not block")
+	}
+}
+
+func TestLockHostnames_DuplicatesDoNotDeadlock(t *testing.T) {
+	app := &App{}
+	done := make(chan struct{})
+	go func() {
+		unlock := app.lockHostnames([]string{"Web.Example.com", "db.example.com", "web.example.com"})
+		unlock()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("lockHostnames deadlocked on hostnames with the same normalized form")
+	}
+	if len(app.hostLocks.locks) != 0 {
+		t.Fatalf("expected lock map to be empty after release, got %d entries", len(app.hostLocks.locks))
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/keystore"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// osNativeLabel is shown in the unlock methods list and, for the Secret
+// Service, in the user's keyring manager
+const osNativeLabel = "PaddockControl unlock key"
+
+// osKeystore returns the store holding os_native wrapping keys
+func (a *App) osKeystore() keystore.Store {
+	if a.keystore != nil {
+		return a.keystore
+	}
+	return keystore.Native()
+}
+
+// IsOSNativeUnlockAvailable reports whether this machine has an OS keystore
+// (DPAPI on Windows, a Secret Service on Linux) to enroll os_native unlock with.
+func (a *App) IsOSNativeUnlockAvailable() bool {
+	return a.osKeystore().Available()
+}
+
+// EnrollOSNativeMethod enrolls passwordless unlock on this machine: a random
+// wrapping key, protected by the OS for the current user, wraps the master key.
+// Anyone who can log in as this user can then open the app, so it is meant for
+// trusted machines only. Requires the app unlocked; one per keystore backend.
+func (a *App) EnrollOSNativeMethod() error {
+	if err := a.requireUnlocked(); err != nil {
+		return fmt.Errorf("app must be unlocked: %w", err)
+	}
+	store := a.osKeystore()
+	if !store.Available() {
+		return fmt.Errorf("OS-native unlock is not available on this machine")
+	}
+
+	a.mu.RLock()
+	masterKey := a.masterKey.Clone()
+	database := a.db
+	a.mu.RUnlock()
+	defer masterKey.Destroy()
+
+	if masterKey.Len() != 32 {
+		return fmt.Errorf("master key is not available")
+	}
+
+	keys, err := database.Queries().GetSecurityKeysByMethod(a.ctx, models.SecurityKeyMethodOSNative)
+	if err != nil {
+		return fmt.Errorf("failed to read unlock methods: %w", err)
+	}
+	for _, key := range keys {
+		if meta, ok := osNativeMetadata(key); ok && meta.Backend == store.Backend() {
+			return fmt.Errorf("OS-native unlock is already enabled on this machine")
+		}
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("enrolling OS-native unlock method", slog.String("backend", store.Backend()))
+
+	wrappingKey := make([]byte, 32)
+	if _, err := rand.Read(wrappingKey); err != nil {
+		return fmt.Errorf("failed to generate wrapping key: %w", err)
+	}
+	defer crypto.Zero(wrappingKey)
+
+	wrappedMasterKey, err := crypto.WrapMasterKey(masterKey.Bytes(), wrappingKey)
+	if err != nil {
+		return fmt.Errorf("failed to wrap master key: %w", err)
+	}
+
+	ref, err := store.Save(osNativeLabel, wrappingKey)
+	if err != nil {
+		return fmt.Errorf("failed to store the unlock key in the OS keystore: %w", err)
+	}
+
+	metaJSON, err := json.Marshal(models.OSNativeMetadata{Backend: store.Backend(), Ref: ref})
+	if err != nil {
+		_ = store.Delete(ref)
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	if err := database.WithTx(a.ctx, func(q *sqlc.Queries) error {
+		_, e := q.InsertSecurityKey(a.ctx, sqlc.InsertSecurityKeyParams{
+			Method:           models.SecurityKeyMethodOSNative,
+			Label:            osNativeLabel,
+			WrappedMasterKey: wrappedMasterKey,
+			Metadata:         sql.NullString{String: string(metaJSON), Valid: true},
+		})
+		return e
+	}); err != nil {
+		_ = store.Delete(ref)
+		return fmt.Errorf("failed to store security key: %w", err)
+	}
+
+	logger.Audit("unlock_method.os_native_enrolled", slog.String("backend", store.Backend()))
+	a.recordActivity("enroll_os_native", "", nil)
+	return nil
+}
+
+// TryAutoUnlock unlocks the app without a prompt when an os_native method is
+// enrolled for this machine's keystore. It returns false, with no error, when
+// there is nothing to try; a stored key that can no longer be read (keyring
+// reset, database copied to another machine) is logged and reported as false
+// so the password prompt is shown as usual.
+func (a *App) TryAutoUnlock() (bool, error) {
+	a.mu.RLock()
+	database := a.db
+	alreadyUnlocked := a.isUnlocked
+	a.mu.RUnlock()
+
+	if alreadyUnlocked {
+		return true, nil
+	}
+	if database == nil {
+		return false, nil
+	}
+
+	store := a.osKeystore()
+	if !store.Available() {
+		return false, nil
+	}
+
+	keys, err := database.Queries().GetSecurityKeysByMethod(a.ctx, models.SecurityKeyMethodOSNative)
+	if err != nil {
+		return false, fmt.Errorf("failed to read unlock methods: %w", err)
+	}
+
+	log := logger.WithComponent("app")
+	for _, key := range keys {
+		meta, ok := osNativeMetadata(key)
+		if !ok || meta.Backend != store.Backend() {
+			continue
+		}
+
+		wrappingKey, err := store.Load(meta.Ref)
+		if err != nil {
+			if errors.Is(err, keystore.ErrNotFound) {
+				log.Info("OS-native unlock key is gone from the keystore", slog.Int64("key_id", key.ID))
+			} else {
+				log.Warn("failed to read the OS-native unlock key", slog.Int64("key_id", key.ID), logger.Err(err))
+			}
+			continue
+		}
+		masterKey, err := crypto.UnwrapMasterKey(key.WrappedMasterKey, wrappingKey)
+		crypto.Zero(wrappingKey)
+		if err != nil {
+			log.Warn("OS-native unlock key does not unwrap the master key", slog.Int64("key_id", key.ID), logger.Err(err))
+			logger.Audit("unlock.os_native_failed", slog.Int64("key_id", key.ID))
+			a.recordAudit(database, models.AuditUnlockFailed, "", "Unlock with the OS keystore failed",
+				map[string]any{"method": models.SecurityKeyMethodOSNative, "label": key.Label})
+			continue
+		}
+
+		_ = database.Queries().UpdateSecurityKeyLastUsed(a.ctx, key.ID)
+		a.finalizeUnlock(masterKey)
+		logger.Audit("unlock.os_native_succeeded", slog.Int64("key_id", key.ID), slog.String("backend", store.Backend()))
+		a.recordAudit(database, models.AuditUnlock, "", "Unlocked with the OS keystore",
+			map[string]any{"method": models.SecurityKeyMethodOSNative, "label": key.Label})
+		return true, nil
+	}
+	return false, nil
+}
+
+// osNativeMetadata decodes the metadata of an os_native security key
+func osNativeMetadata(key sqlc.SecurityKey) (models.OSNativeMetadata, bool) {
+	var meta models.OSNativeMetadata
+	if !key.Metadata.Valid || json.Unmarshal([]byte(key.Metadata.String), &meta) != nil || len(meta.Ref) == 0 {
+		return meta, false
+	}
+	return meta, true
+}
+
+// forgetOSNativeKey deletes the wrapping key of a removed os_native method
+// from the keystore. Best effort: the row is already gone, so a leftover
+// secret only wraps nothing.
+func (a *App) forgetOSNativeKey(key sqlc.SecurityKey) {
+	meta, ok := osNativeMetadata(key)
+	store := a.osKeystore()
+	if !ok || meta.Backend != store.Backend() {
+		return
+	}
+	if err := store.Delete(meta.Ref); err != nil {
+		logger.WithComponent("app").Warn("failed to delete the OS-native unlock key", slog.Int64("key_id", key.ID), logger.Err(err))
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"testing"
+
+	"paddockcontrol-desktop/internal/keystore"
+	"paddockcontrol-desktop/internal/models"
+)
+
+func osNativeKeyID(t *testing.T, app *App) int64 {
+	t.Helper()
+	rows, err := app.db.Queries().GetSecurityKeysByMethod(app.ctx, models.SecurityKeyMethodOSNative)
+	if err != nil {
+		t.Fatalf("GetSecurityKeysByMethod(os_native): %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 os_native security key, got %d", len(rows))
+	}
+	return rows[0].ID
+}
+
+func TestTryAutoUnlock_NothingEnrolled(t *testing.T) {
+	app := setupConfiguredApp(t)
+	app.keystore = keystore.NewMemory()
+
+	ok, err := app.TryAutoUnlock()
+	if err != nil {
+		t.Fatalf("TryAutoUnlock() error: %v", err)
+	}
+	if ok || app.IsUnlocked() {
+		t.Error("expected no auto-unlock without an os_native method")
+	}
+}
+
+func TestOSNativeMethod_EnrollUnlockRemove(t *testing.T) {
+	app := setupUnlockedApp(t)
+	store := keystore.NewMemory()
+	app.keystore = store
+
+	if err := app.EnrollOSNativeMethod(); err != nil {
+		t.Fatalf("EnrollOSNativeMethod() error: %v", err)
+	}
+	if store.Len() != 1 {
+		t.Fatalf("expected the wrapping key in the keystore, got %d secrets", store.Len())
+	}
+	if err := app.EnrollOSNativeMethod(); err == nil {
+		t.Error("expected a second enrollment on the same keystore to be refused")
+	}
+	id := osNativeKeyID(t, app)
+
+	if err := app.ClearEncryptionKey(); err != nil {
+		t.Fatalf("ClearEncryptionKey() error: %v", err)
+	}
+	if app.IsUnlocked() {
+		t.Fatal("expected the app to be locked")
+	}
+	ok, err := app.TryAutoUnlock()
+	if err != nil {
+		t.Fatalf("TryAutoUnlock() error: %v", err)
+	}
+	if !ok || !app.IsUnlocked() {
+		t.Fatal("expected TryAutoUnlock to unlock the app")
+	}
+	key, err := app.db.Queries().GetSecurityKeyByID(app.ctx, id)
+	if err != nil {
+		t.Fatalf("GetSecurityKeyByID() error: %v", err)
+	}
+	if !key.LastUsedAt.Valid {
+		t.Error("expected last_used_at to be set after auto-unlock")
+	}
+
+	if err := app.RemoveSecurityKey(id); err != nil {
+		t.Fatalf("RemoveSecurityKey() error: %v", err)
+	}
+	if store.Len() != 0 {
+		t.Errorf("expected the wrapping key to be deleted from the keystore, %d left", store.Len())
+	}
+}
+
+func TestTryAutoUnlock_KeystoreLostSecret(t *testing.T) {
+	app := setupUnlockedApp(t)
+	app.keystore = keystore.NewMemory()
+
+	if err := app.EnrollOSNativeMethod(); err != nil {
+		t.Fatalf("EnrollOSNativeMethod() error: %v", err)
+	}
+
+	// e.g. the database was restored on another machine
+	app.keystore = keystore.NewMemory()
+	if err := app.ClearEncryptionKey(); err != nil {
+		t.Fatalf("ClearEncryptionKey() error: %v", err)
+	}
+
+	ok, err := app.TryAutoUnlock()
+	if err != nil {
+		t.Fatalf("TryAutoUnlock() error: %v", err)
+	}
+	if ok || app.IsUnlocked() {
+		t.Error("expected no auto-unlock when the keystore no longer holds the key")
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/profiles"
+)
+
+// ============================================================================
+// Profiles
+// ============================================================================
+
+// selectStartupProfile resolves the profile opened at startup: the one last
+// switched to, or the default profile. An installation from before profiles
+// has its database and backups moved into the default profile first.
+func (a *App) selectStartupProfile() error {
+	log := logger.WithComponent("app")
+
+	// An in-memory database has no directory to hold profiles
+	if a.rootDir == ":memory:" {
+		a.profile = profiles.DefaultName
+		a.dataDir = a.rootDir
+		return nil
+	}
+
+	moved, err := profiles.MigrateLegacyLayout(a.rootDir)
+	if err != nil {
+		return err
+	}
+	if moved {
+		log.Info("moved the database and backups to the default profile",
+			slog.String("path", profiles.Dir(a.rootDir, profiles.DefaultName)))
+	}
+
+	name := profiles.Active(a.rootDir)
+	dir := profiles.Dir(a.rootDir, name)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create profile directory: %w", err)
+	}
+	a.profile = name
+	a.dataDir = dir
+	return nil
+}
+
+// profilesRoot returns the data directory holding the profiles, or an error
+// when the app runs on an in-memory database
+func (a *App) profilesRoot() (string, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if a.rootDir == "" || a.rootDir == ":memory:" {
+		return "", fmt.Errorf("profiles are not available with an in-memory database")
+	}
+	return a.rootDir, nil
+}
+
+// ListProfiles lists the profiles of this installation, sorted by name, marking
+// the one currently open. An in-memory database only has the default profile.
+// Does NOT require setup - each profile is set up on its own
+func (a *App) ListProfiles() ([]models.Profile, error) {
+	a.mu.RLock()
+	rootDir, current, dataDir := a.rootDir, a.profile, a.dataDir
+	a.mu.RUnlock()
+
+	if rootDir == "" || rootDir == ":memory:" {
+		return []models.Profile{{Name: profiles.DefaultName, Path: dataDir, Active: true}}, nil
+	}
+
+	names, err := profiles.List(rootDir)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.Profile, 0, len(names))
+	for _, name := range names {
+		result = append(result, models.Profile{
+			Name:   name,
+			Path:   profiles.Dir(rootDir, name),
+			Active: name == current,
+		})
+	}
+	return result, nil
+}
+
+// CreateProfile creates an empty profile. It is set up, with its own
+// configuration and password, after switching to it with SwitchProfile.
+// Does NOT require setup - the current profile is left untouched
+func (a *App) CreateProfile(name string) error {
+	rootDir, err := a.profilesRoot()
+	if err != nil {
+		return err
+	}
+
+	_, log := logger.WithOperation(a.ctx, "create_profile")
+
+	err = profiles.Create(rootDir, name)
+	a.recordActivity("create_profile", "", err)
+	if err != nil {
+		log.Error("failed to create profile", slog.String("profile", name), logger.Err(err))
+		return err
+	}
+
+	log.Info("profile created", slog.String("profile", name))
+	return nil
+}
+
+// SwitchProfile closes the current database and opens the one of profile name,
+// which becomes the profile opened at the next startup. The app is locked: the
+// master key belongs to the previous profile. A profile that was never opened
+// starts at setup.
+// Does NOT require setup - the target profile may not be set up yet
+func (a *App) SwitchProfile(name string) error {
+	rootDir, err := a.profilesRoot()
+	if err != nil {
+		return err
+	}
+	if !profiles.Exists(rootDir, name) {
+		return fmt.Errorf("%w: %s", profiles.ErrNotFound, name)
+	}
+
+	_, log := logger.WithOperation(a.ctx, "switch_profile")
+
+	// The mounted backup belongs to the current profile
+	if err := a.CloseBackupView(); err != nil {
+		log.Error("backup view close error", logger.Err(err))
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if name == a.profile {
+		return nil
+	}
+
+	// Open the new database first, so a failure leaves the current one in use
+	dir := profiles.Dir(rootDir, name)
+	database, err := db.NewDatabase(dir)
+	if err != nil {
+		log.Error("failed to open profile database", slog.String("profile", name), logger.Err(err))
+		return fmt.Errorf("failed to open profile database: %w", err)
+	}
+
+	// The background jobs use the database and master key being replaced
+	if a.keyValidationCancel != nil {
+		a.keyValidationCancel()
+		a.keyValidationCancel = nil
+	}
+	a.stopKeyEnvelopeMigration()
+	a.stopKeyPool()
+	a.stopSyncServer()
+
+	if a.db != nil {
+		if err := a.db.Close(); err != nil {
+			log.Error("failed to close database", logger.Err(err))
+		}
+	}
+
+	previous := a.profile
+	a.db = database
+	a.profile = name
+	a.dataDir = dir
+
+	// Each profile has its own master key
+	a.masterKey.Destroy()
+	a.masterKey = nil
+	a.isUnlocked = false
+	a.waitingForEncryptionKey = false
+	a.bulkDelete = nil
+	a.recoveredOperations = nil
+
+	if err := a.detectDatabaseState(a.ctx); err != nil {
+		log.Error("configuration check failed after profile switch", logger.Err(err))
+	}
+
+	a.initializeServicesWithoutKey()
+
+	// The tray badge and menu still show the previous profile
+	a.refreshTray()
+
+	if err := profiles.SetActive(rootDir, name); err != nil {
+		log.Error("failed to record the active profile", logger.Err(err))
+	}
+
+	a.recordActivity("switch_profile", "", nil)
+	log.Info("profile switched",
+		slog.String("from", previous),
+		slog.String("to", name),
+		slog.Bool("configured", a.isConfigured),
+	)
+	return nil
+}
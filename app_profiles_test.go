@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/profiles"
+)
+
+// setupProfilesApp opens the startup profile of a fresh data directory, as
+// startup does, and sets it up and unlocks it
+func setupProfilesApp(t *testing.T) *App {
+	t.Helper()
+
+	app := &App{ctx: context.Background(), rootDir: t.TempDir()}
+	if err := app.selectStartupProfile(); err != nil {
+		t.Fatalf("selectStartupProfile failed: %v", err)
+	}
+	database, err := db.NewDatabase(app.dataDir)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	app.db = database
+	t.Cleanup(func() { app.db.Close() })
+	app.initializeServicesWithoutKey()
+
+	err = app.db.Queries().CreateConfig(app.ctx, sqlc.CreateConfigParams{
+		OwnerEmail:          "test@example.com",
+		CaName:              "Production CA",
+		HostnameSuffix:      ".example.com",
+		DefaultOrganization: "Test Org",
+		DefaultCity:         "Test City",
+		DefaultState:        "Test State",
+		DefaultCountry:      "FR",
+		DefaultKeySize:      2048,
+		ValidityPeriodDays:  365,
+		KdfProfile:          crypto.KDFProfileStandard,
+	})
+	if err != nil {
+		t.Fatalf("failed to create config: %v", err)
+	}
+	if err := app.db.Queries().SetConfigured(app.ctx); err != nil {
+		t.Fatalf("failed to set configured: %v", err)
+	}
+	app.isConfigured = true
+	if _, err := app.ProvideEncryptionKey(testPassword); err != nil {
+		t.Fatalf("failed to unlock: %v", err)
+	}
+	return app
+}
+
+func TestSwitchProfile(t *testing.T) {
+	app := setupProfilesApp(t)
+
+	if app.profile != profiles.DefaultName || app.dataDir != profiles.Dir(app.rootDir, profiles.DefaultName) {
+		t.Fatalf("startup profile = %q in %s, want the default profile", app.profile, app.dataDir)
+	}
+
+	if err := app.CreateProfile("Lab CA"); err != nil {
+		t.Fatalf("CreateProfile failed: %v", err)
+	}
+	if err := app.CreateProfile("lab ca"); err == nil {
+		t.Error("CreateProfile accepted a name differing only in case")
+	}
+	if err := app.SwitchProfile("missing"); err == nil {
+		t.Error("SwitchProfile accepted a missing profile")
+	}
+
+	if err := app.SwitchProfile("Lab CA"); err != nil {
+		t.Fatalf("SwitchProfile failed: %v", err)
+	}
+	state := app.GetSessionState()
+	if state.Profile != "Lab CA" || state.Configured || state.Unlocked {
+		t.Errorf("session state = %+v, want the new profile locked and awaiting setup", state)
+	}
+	if _, err := os.Stat(filepath.Join(profiles.Dir(app.rootDir, "Lab CA"), "certificates.db")); err != nil {
+		t.Errorf("profile database not created: %v", err)
+	}
+	if got := profiles.Active(app.rootDir); got != "Lab CA" {
+		t.Errorf("active profile = %q, want Lab CA recorded for the next startup", got)
+	}
+
+	list, err := app.ListProfiles()
+	if err != nil {
+		t.Fatalf("ListProfiles failed: %v", err)
+	}
+	if len(list) != 2 || list[0].Name != profiles.DefaultName || list[0].Active || !list[1].Active {
+		t.Errorf("profiles = %+v, want default and the active Lab CA", list)
+	}
+
+	// Switching back opens the configured database, still locked
+	if err := app.SwitchProfile(profiles.DefaultName); err != nil {
+		t.Fatalf("SwitchProfile back failed: %v", err)
+	}
+	state = app.GetSessionState()
+	if !state.Configured || state.Unlocked || !state.LimitedMode {
+		t.Errorf("session state = %+v, want the default profile configured and locked", state)
+	}
+	if _, err := app.ProvideEncryptionKey(testPassword); err != nil {
+		t.Errorf("failed to unlock the default profile again: %v", err)
+	}
+}
+
+func TestSwitchProfile_TrayFollowsProfile(t *testing.T) {
+	app := setupProfilesApp(t)
+	if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{
+		Hostname:       "soon.example.com",
+		CertificatePem: sql.NullString{String: "-----BEGIN CERTIFICATE-----", Valid: true},
+		ExpiresAt:      sql.NullInt64{Int64: time.Now().Add(5 * 24 * time.Hour).Unix(), Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	if got := app.expiringCount(); got != 1 {
+		t.Fatalf("expiringCount() = %d, want 1 before the switch", got)
+	}
+
+	if err := app.CreateProfile("Empty"); err != nil {
+		t.Fatalf("CreateProfile failed: %v", err)
+	}
+	if err := app.SwitchProfile("Empty"); err != nil {
+		t.Fatalf("SwitchProfile failed: %v", err)
+	}
+	if got := app.expiringCount(); got != 0 {
+		t.Errorf("expiringCount() = %d after switching to an empty profile, want 0", got)
+	}
+
+	// The tray menu follows the new profile: locked and not set up
+	app.mu.RLock()
+	unlocked, configured := app.isUnlocked, app.isConfigured
+	app.mu.RUnlock()
+	if unlocked || configured {
+		t.Errorf("tray state unlocked=%v configured=%v, want the empty profile locked and not set up", unlocked, configured)
+	}
+}
+
+func TestSelectStartupProfile_MigratesLegacyLayout(t *testing.T) {
+	rootDir := t.TempDir()
+	legacy, err := db.NewDatabase(rootDir)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	if err := legacy.Close(); err != nil {
+		t.Fatalf("failed to close database: %v", err)
+	}
+
+	app := &App{ctx: context.Background(), rootDir: rootDir}
+	if err := app.selectStartupProfile(); err != nil {
+		t.Fatalf("selectStartupProfile failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(app.dataDir, "certificates.db")); err != nil {
+		t.Errorf("database not moved to the default profile: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(rootDir, "certificates.db")); !os.IsNotExist(err) {
+		t.Errorf("database still in the data directory")
+	}
+}
+
+func TestProfiles_InMemory(t *testing.T) {
+	app := setupTestApp(t)
+
+	list, err := app.ListProfiles()
+	if err != nil {
+		t.Fatalf("ListProfiles failed: %v", err)
+	}
+	if len(list) != 1 || list[0].Name != profiles.DefaultName || !list[0].Active {
+		t.Errorf("profiles = %+v, want only the default profile", list)
+	}
+	if err := app.CreateProfile("Lab CA"); err == nil || !strings.Contains(err.Error(), "in-memory") {
+		t.Errorf("CreateProfile error = %v, want profiles unavailable", err)
+	}
+	if err := app.SwitchProfile("Lab CA"); err == nil {
+		t.Error("SwitchProfile succeeded on an in-memory database")
+	}
+}
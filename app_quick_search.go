@@ -0,0 +1,244 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/services"
+)
+
+// ============================================================================
+// Quick Search
+// ============================================================================
+
+const (
+	// maxQuickSearchResults caps the results returned for one query
+	maxQuickSearchResults = 20
+	// quickSearchIndexTTL bounds how long the index is reused without a
+	// mutation, so time-based statuses (expiring, expired) stay current
+	quickSearchIndexTTL = 5 * time.Minute
+)
+
+// quickSearchEntry is one certificate in the quick search index, with its
+// names lowercased once at build time
+type quickSearchEntry struct {
+	item    *models.CertificateListItem
+	host    string
+	display string
+	sans    []string
+}
+
+// quickSearchIndex keeps the hostnames and SANs of every certificate in memory
+// so quick-open searches don't reparse the inventory on each keystroke. Every
+// recorded operation and every database replacement invalidates it; it is
+// rebuilt on the next search.
+type quickSearchIndex struct {
+	mu         sync.Mutex
+	entries    []quickSearchEntry
+	builtAt    time.Time
+	valid      bool
+	generation uint64 // bumped on invalidation so a stale rebuild is not kept
+}
+
+// invalidate drops the index; the next search rebuilds it.
+func (x *quickSearchIndex) invalidate() {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.entries = nil
+	x.valid = false
+	x.generation++
+}
+
+// snapshot returns the index entries, rebuilding them from the certificate
+// service when the index was invalidated or is older than quickSearchIndexTTL.
+func (x *quickSearchIndex) snapshot(ctx context.Context, svc *services.CertificateService, now time.Time) ([]quickSearchEntry, error) {
+	x.mu.Lock()
+	if x.valid && now.Sub(x.builtAt) < quickSearchIndexTTL {
+		entries := x.entries
+		x.mu.Unlock()
+		return entries, nil
+	}
+	generation := x.generation
+	x.mu.Unlock()
+
+	// Build outside the lock: listing parses every certificate
+	items, err := svc.ListCertificates(ctx, models.CertificateFilter{SortBy: "hostname", SortOrder: "asc"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build search index: %w", err)
+	}
+	entries := make([]quickSearchEntry, 0, len(items))
+	for _, item := range items {
+		entry := quickSearchEntry{
+			item:    item,
+			host:    strings.ToLower(item.Hostname),
+			display: strings.ToLower(item.DisplayHostname),
+		}
+		for _, san := range item.SANs {
+			entry.sans = append(entry.sans, strings.ToLower(san))
+		}
+		entries = append(entries, entry)
+	}
+
+	x.mu.Lock()
+	if x.generation == generation {
+		x.entries = entries
+		x.builtAt = now
+		x.valid = true
+	}
+	x.mu.Unlock()
+	return entries, nil
+}
+
+// QuickSearch finds certificates for a command-palette style quick-open:
+// exact and prefix hostname matches rank first, then substring and SAN
+// matches, then fuzzy matches (the query's characters in order). At most 20
+// results are returned, best first.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) QuickSearch(query string) ([]models.QuickSearchResult, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return []models.QuickSearchResult{}, nil
+	}
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	entries, err := a.searchIndex.snapshot(a.ctx, certificateService, a.appClock().Now())
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]models.QuickSearchResult, 0)
+	for _, entry := range entries {
+		if result, ok := matchQuickSearch(query, entry); ok {
+			results = append(results, result)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Hostname < results[j].Hostname
+	})
+	if len(results) > maxQuickSearchResults {
+		results = results[:maxQuickSearchResults]
+	}
+	return results, nil
+}
+
+// matchQuickSearch scores an index entry against a lowercased query. The
+// hostname is matched in both its ASCII and unicode forms.
+func matchQuickSearch(query string, entry quickSearchEntry) (models.QuickSearchResult, bool) {
+	result := models.QuickSearchResult{
+		Hostname:        entry.item.Hostname,
+		DisplayHostname: entry.item.DisplayHostname,
+		Status:          entry.item.Status,
+	}
+
+	names := []string{entry.host}
+	if entry.display != entry.host {
+		names = append(names, entry.display)
+	}
+	for _, name := range names {
+		if score, match := scoreQuickName(query, name); score > result.Score {
+			result.Score, result.Match = score, match
+		}
+	}
+
+	// SAN hits rank below the same kind of hit on the hostname, and are only
+	// matched literally: fuzzy matching every SAN is too noisy
+	for _, san := range entry.sans {
+		if san == entry.host {
+			continue
+		}
+		score, match := scoreQuickName(query, san)
+		if match == models.QuickMatchFuzzy {
+			continue
+		}
+		if score -= 250; score > result.Score {
+			result.Score, result.Match, result.MatchedSAN = score, models.QuickMatchSAN, san
+		}
+	}
+
+	return result, result.Score > 0
+}
+
+// scoreQuickName scores a single name against the query; 0 means no match.
+func scoreQuickName(query, name string) (int, string) {
+	switch {
+	case name == query:
+		return 1000, models.QuickMatchExact
+	case strings.HasPrefix(name, query):
+		// Shorter names are closer to what was typed
+		return 900 - min(len(name)-len(query), 99), models.QuickMatchPrefix
+	}
+
+	if i := strings.Index(name, query); i >= 0 {
+		if isQuickLabelStart(name, i) {
+			return 700 - min(i, 99), models.QuickMatchPrefix
+		}
+		// A later label prefix beats an earlier mid-label hit
+		for j := i + 1; j <= len(name)-len(query); j++ {
+			if isQuickLabelStart(name, j) && strings.HasPrefix(name[j:], query) {
+				return 700 - min(j, 99), models.QuickMatchPrefix
+			}
+		}
+		return 600 - min(i, 99), models.QuickMatchSubstring
+	}
+
+	if score := fuzzyQuickScore(query, name); score > 0 {
+		return score, models.QuickMatchFuzzy
+	}
+	return 0, ""
+}
+
+// isQuickLabelStart reports whether position i starts a label or a word
+// within a label (after "." or "-").
+func isQuickLabelStart(name string, i int) bool {
+	return i == 0 || name[i-1] == '.' || name[i-1] == '-'
+}
+
+// fuzzyQuickScore matches the query's characters in order within name,
+// rewarding consecutive characters and label starts and penalizing gaps. It
+// returns 0 when the characters don't all appear, and stays below 500.
+func fuzzyQuickScore(query, name string) int {
+	q := []rune(query)
+	n := []rune(name)
+	score := 300
+	qi := 0
+	last := -1
+	for ni := 0; ni < len(n) && qi < len(q); ni++ {
+		if n[ni] != q[qi] {
+			continue
+		}
+		switch {
+		case last >= 0 && ni == last+1:
+			score += 15
+		case ni == 0 || n[ni-1] == '.' || n[ni-1] == '-':
+			score += 10
+		}
+		if last >= 0 {
+			score -= ni - last - 1
+		}
+		last = ni
+		qi++
+	}
+	if qi < len(q) {
+		return 0
+	}
+	return max(1, min(score, 499))
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"testing"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+func TestQuickSearch_RanksMatches(t *testing.T) {
+	app := setupConfiguredApp(t)
+
+	for _, hostname := range []string{
+		"api.example.com",
+		"api-gateway.example.com",
+		"payments-api.example.com",
+		"mail.example.com",
+		"xn--bcher-kva.example.com",
+	} {
+		if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{Hostname: hostname}); err != nil {
+			t.Fatalf("failed to create %s: %v", hostname, err)
+		}
+	}
+
+	results, err := app.QuickSearch("  API.example.com ")
+	if err != nil {
+		t.Fatalf("QuickSearch() error = %v", err)
+	}
+	if len(results) == 0 || results[0].Hostname != "api.example.com" || results[0].Match != models.QuickMatchExact {
+		t.Fatalf("expected the exact match first, got %+v", results)
+	}
+
+	results, err = app.QuickSearch("api")
+	if err != nil {
+		t.Fatalf("QuickSearch() error = %v", err)
+	}
+	want := []string{"api.example.com", "api-gateway.example.com", "payments-api.example.com"}
+	if len(results) != len(want) {
+		t.Fatalf("expected %d results, got %+v", len(want), results)
+	}
+	for i, hostname := range want {
+		if results[i].Hostname != hostname {
+			t.Errorf("result %d = %s, want %s", i, results[i].Hostname, hostname)
+		}
+	}
+
+	// Fuzzy: the characters in order, not contiguous
+	results, err = app.QuickSearch("mlex")
+	if err != nil {
+		t.Fatalf("QuickSearch() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Hostname != "mail.example.com" || results[0].Match != models.QuickMatchFuzzy {
+		t.Errorf("expected a fuzzy match on mail.example.com, got %+v", results)
+	}
+
+	// IDN hostnames match their unicode form
+	results, err = app.QuickSearch("bücher")
+	if err != nil {
+		t.Fatalf("QuickSearch() error = %v", err)
+	}
+	if len(results) != 1 || results[0].Hostname != "xn--bcher-kva.example.com" {
+		t.Errorf("expected the IDN hostname, got %+v", results)
+	}
+
+	if results, err := app.QuickSearch("   "); err != nil || len(results) != 0 {
+		t.Errorf("empty query: results = %+v, error = %v", results, err)
+	}
+}
+
+func TestQuickSearch_InvalidatedByMutations(t *testing.T) {
+	app := setupConfiguredApp(t)
+
+	if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{Hostname: "old.example.com"}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	if results, err := app.QuickSearch("example"); err != nil || len(results) != 1 {
+		t.Fatalf("results = %+v, error = %v", results, err)
+	}
+
+	// Not seen until an operation invalidates the index
+	if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{Hostname: "new.example.com"}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	if results, err := app.QuickSearch("example"); err != nil || len(results) != 1 {
+		t.Fatalf("expected the cached index, got %+v (%v)", results, err)
+	}
+
+	if err := app.UpdateCertificateNote("old.example.com", "owned by the web team"); err != nil {
+		t.Fatalf("UpdateCertificateNote() error = %v", err)
+	}
+	if results, err := app.QuickSearch("example"); err != nil || len(results) != 2 {
+		t.Errorf("expected the rebuilt index, got %+v (%v)", results, err)
+	}
+}
+
+func TestQuickSearch_SANMatch(t *testing.T) {
+	entry := quickSearchEntry{
+		item: &models.CertificateListItem{Hostname: "www.example.com", DisplayHostname: "www.example.com"},
+		host: "www.example.com",
+		sans: []string{"www.example.com", "shop.example.org"},
+	}
+
+	result, ok := matchQuickSearch("shop", entry)
+	if !ok || result.Match != models.QuickMatchSAN || result.MatchedSAN != "shop.example.org" {
+		t.Errorf("expected a SAN match, got %+v (%v)", result, ok)
+	}
+
+	hostResult, _ := matchQuickSearch("www", entry)
+	if hostResult.Score <= result.Score {
+		t.Errorf("a hostname prefix (%d) should rank above a SAN prefix (%d)", hostResult.Score, result.Score)
+	}
+
+	if _, ok := matchQuickSearch("zzz", entry); ok {
+		t.Error("expected no match")
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// ============================================================================
+// Renewal Checklist
+// ============================================================================
+
+// GetRenewalChecklist returns where the current renewal of a certificate stands
+// (CSR sent, certificate received, uploaded, deployed, verified).
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) GetRenewalChecklist(hostname string) (*models.RenewalChecklist, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	return certificateService.GetRenewalChecklist(a.ctx, hostname)
+}
+
+// SetRenewalStep marks a renewal checklist step as done or not done. Each
+// transition is recorded in the certificate history. Uploading the signed
+// certificate completes the "cert_received" and "uploaded" steps by itself.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) SetRenewalStep(hostname, step string, done bool) (*models.RenewalChecklist, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("updating renewal checklist",
+		slog.String("hostname", hostname),
+		slog.String("step", step),
+		slog.Bool("done", done),
+	)
+
+	unlock := a.lockHostname(hostname)
+	defer unlock()
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	checklist, err := certificateService.SetRenewalStep(a.ctx, hostname, step, done)
+	a.recordActivity("set_renewal_step", hostname, err)
+	if err != nil {
+		return nil, err
+	}
+	return checklist, nil
+}
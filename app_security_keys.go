@@ -92,20 +92,19 @@ func (a *App) EnrollPasswordMethod(password, label string) error {
 	}
 
 	a.mu.RLock()
-	masterKey := make([]byte, len(a.masterKey))
-	copy(masterKey, a.masterKey)
+	masterKey := a.masterKey.Clone()
 	database := a.db
 	a.mu.RUnlock()
-	defer crypto.Zero(masterKey)
+	defer masterKey.Destroy()
 
-	if len(masterKey) != 32 {
+	if masterKey.Len() != 32 {
 		return fmt.Errorf("master key is not available")
 	}
 
 	log := logger.WithComponent("app")
 	log.Info("enrolling new password method", slog.String("label", label))
 
-	params := crypto.DefaultArgon2idParams()
+	params := a.kdfParams(database)
 	salt, err := crypto.GenerateSalt(params.SaltLength)
 	if err != nil {
 		return fmt.Errorf("failed to generate salt: %w", err)
@@ -113,7 +112,7 @@ func (a *App) EnrollPasswordMethod(password, label string) error {
 
 	wrappingKey := crypto.DeriveKeyFromPassword(password, salt, params)
 	defer crypto.Zero(wrappingKey)
-	wrappedMasterKey, err := crypto.WrapMasterKey(masterKey, wrappingKey)
+	wrappedMasterKey, err := crypto.WrapMasterKey(masterKey.Bytes(), wrappingKey)
 	if err != nil {
 		return fmt.Errorf("failed to wrap master key: %w", err)
 	}
@@ -141,6 +140,7 @@ func (a *App) EnrollPasswordMethod(password, label string) error {
 
 	log.Info("password method enrolled successfully")
 	logger.Audit("unlock_method.password_enrolled", slog.String("label", label))
+	a.recordActivity("enroll_password", "", nil)
 	return nil
 }
 
@@ -170,8 +170,9 @@ func (a *App) RemoveSecurityKey(id int64) error {
 
 	// Password is the permanent root unlock method and can never be removed — it
 	// is changed (not removed) via ChangeEncryptionKey. Only convenience methods
-	// (passkeys) are revocable. Removing a passkey just drops the DB row; the
-	// credential is non-resident, so nothing is stored on the authenticator.
+	// (passkeys, OS-native) are revocable. Removing a passkey just drops the DB
+	// row; the credential is non-resident, so nothing is stored on the
+	// authenticator. An OS-native method also deletes its key from the keystore.
 	if key.Method == models.SecurityKeyMethodPassword {
 		return fmt.Errorf("the password unlock method cannot be removed; use Change Password instead")
 	}
@@ -179,6 +180,9 @@ func (a *App) RemoveSecurityKey(id int64) error {
 	if err := database.Queries().DeleteSecurityKey(a.ctx, id); err != nil {
 		return fmt.Errorf("failed to remove security key: %w", err)
 	}
+	if key.Method == models.SecurityKeyMethodOSNative {
+		a.forgetOSNativeKey(key)
+	}
 
 	log.Info("security key removed", slog.Int64("id", id), slog.String("method", key.Method))
 	logger.Audit("unlock_method.removed",
@@ -186,19 +190,25 @@ func (a *App) RemoveSecurityKey(id int64) error {
 		slog.String("method", key.Method),
 		slog.String("label", key.Label),
 	)
+	a.recordActivity("remove_unlock_method", "", nil)
 	return nil
 }
 
 // finalizeUnlock stores the master key in memory and (re)initializes the
 // key-dependent services. Shared by every non-password unlock path.
-func (a *App) finalizeUnlock(masterKey []byte) {
+func (a *App) finalizeUnlock(masterKey *crypto.SecretBuffer) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
+	a.masterKey.Destroy()
 	a.masterKey = masterKey
 	a.waitingForEncryptionKey = false
 	a.isUnlocked = true
 	a.needsMigration = false
 	a.configService = config.NewService(a.db)
-	a.certificateService = services.NewCertificateService(a.db, a.configService)
+	a.certificateService = a.newCertificateService()
 	a.setupService = services.NewSetupService(a.db, a.configService)
+	a.startKeyEnvelopeMigration()
+	a.startKeyPoolFill()
+	a.startActivitySession()
+	a.resumeSyncServer()
 }
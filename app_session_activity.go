@@ -0,0 +1,89 @@
+package main
+
+import (
+	"sync"
+
+	"paddockcontrol-desktop/internal/models"
+)
+
+// ============================================================================
+// Session Activity Log
+// ============================================================================
+
+// maxSessionActivity caps the entries kept in memory; the oldest are dropped.
+const maxSessionActivity = 500
+
+// sessionActivity records, in memory only, the operations performed since the
+// last unlock so the operator can review them (e.g. for a change ticket) before
+// locking. It starts over at each unlock and stays readable after locking.
+type sessionActivity struct {
+	mu        sync.Mutex
+	startedAt int64
+	entries   []models.SessionActivityEntry
+	truncated bool
+}
+
+// reset starts a new session at startedAt (Unix seconds).
+func (s *sessionActivity) reset(startedAt int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.startedAt = startedAt
+	s.entries = nil
+	s.truncated = false
+}
+
+// add appends an entry, dropping the oldest once maxSessionActivity is reached.
+func (s *sessionActivity) add(entry models.SessionActivityEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.entries) >= maxSessionActivity {
+		s.entries = s.entries[1:]
+		s.truncated = true
+	}
+	s.entries = append(s.entries, entry)
+}
+
+// snapshot returns a copy of the session log.
+func (s *sessionActivity) snapshot() *models.SessionActivity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make([]models.SessionActivityEntry, len(s.entries))
+	copy(entries, s.entries)
+	return &models.SessionActivity{
+		StartedAt: s.startedAt,
+		Entries:   entries,
+		Truncated: s.truncated,
+	}
+}
+
+// recordActivity adds a write operation to the session log. err is the outcome
+// of the operation (nil on success). hostname may be empty. Write operations
+// also invalidate the quick search index and refresh the tray badge.
+func (a *App) recordActivity(operation, hostname string, err error) {
+	entry := models.SessionActivityEntry{
+		Operation: operation,
+		Hostname:  hostname,
+		Timestamp: a.appClock().Now().Unix(),
+		Success:   err == nil,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	a.activity.add(entry)
+	a.searchIndex.invalidate()
+	a.refreshTray()
+}
+
+// startActivitySession clears the session log at unlock.
+func (a *App) startActivitySession() {
+	a.activity.reset(a.appClock().Now().Unix())
+	a.refreshTray()
+}
+
+// GetSessionActivity returns the operations performed since the app was last
+// unlocked (certificate, backup, configuration and unlock method changes), with
+// their outcome, oldest first. The log is kept in memory only; it is still
+// available after locking and starts over at the next unlock.
+func (a *App) GetSessionActivity() *models.SessionActivity {
+	return a.activity.snapshot()
+}
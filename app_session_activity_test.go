@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+func TestGetSessionActivity_RecordsOperations(t *testing.T) {
+	app := setupUnlockedApp(t)
+
+	if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{
+		Hostname: "activity.example.com",
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	if err := app.UpdateCertificateNote("activity.example.com", "owned by the web team"); err != nil {
+		t.Fatalf("UpdateCertificateNote() error = %v", err)
+	}
+	if err := app.UpdateCertificateNote("activity.example.com", "password=Hunter2x"); err == nil {
+		t.Fatal("expected a note with a password to be refused")
+	}
+
+	activity := app.GetSessionActivity()
+	if activity.StartedAt == 0 {
+		t.Error("StartedAt should be set at unlock")
+	}
+	if len(activity.Entries) != 2 {
+		t.Fatalf("expected 2 entries, got %+v", activity.Entries)
+	}
+	ok, failed := activity.Entries[0], activity.Entries[1]
+	if ok.Operation != "update_note" || ok.Hostname != "activity.example.com" || !ok.Success || ok.Timestamp == 0 {
+		t.Errorf("unexpected first entry: %+v", ok)
+	}
+	if failed.Success || failed.Error == "" {
+		t.Errorf("second entry should record the failure: %+v", failed)
+	}
+
+	// Still readable after locking, for writing the change ticket
+	if err := app.ClearEncryptionKey(); err != nil {
+		t.Fatalf("ClearEncryptionKey() error = %v", err)
+	}
+	if got := len(app.GetSessionActivity().Entries); got != 2 {
+		t.Errorf("after locking: %d entries, want 2", got)
+	}
+
+	// A new unlock starts a new session
+	if _, err := app.ProvideEncryptionKey(testPassword); err != nil {
+		t.Fatalf("ProvideEncryptionKey() error = %v", err)
+	}
+	if got := len(app.GetSessionActivity().Entries); got != 0 {
+		t.Errorf("after unlocking again: %d entries, want 0", got)
+	}
+}
+
+func TestSessionActivity_DropsOldestEntries(t *testing.T) {
+	var s sessionActivity
+	s.reset(1)
+	for i := 0; i < maxSessionActivity+5; i++ {
+		s.add(models.SessionActivityEntry{Operation: "op", Timestamp: int64(i)})
+	}
+
+	snapshot := s.snapshot()
+	if len(snapshot.Entries) != maxSessionActivity || !snapshot.Truncated {
+		t.Fatalf("got %d entries (truncated=%v), want %d truncated", len(snapshot.Entries), snapshot.Truncated, maxSessionActivity)
+	}
+	if snapshot.Entries[0].Timestamp != 5 {
+		t.Errorf("oldest kept entry = %d, want 5", snapshot.Entries[0].Timestamp)
+	}
+}
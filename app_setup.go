@@ -120,6 +120,7 @@ func (a *App) UpdateConfig(req models.UpdateConfigRequest) (*models.Config, erro
 	)
 
 	// Validate request
+	config.NormalizeSubjectDefaults(&req)
 	if err := config.ValidateConfigUpdate(&req); err != nil {
 		log.Error("config validation failed", logger.Err(err))
 		return nil, fmt.Errorf("validation failed: %w", err)
@@ -135,11 +136,158 @@ func (a *App) UpdateConfig(req models.UpdateConfigRequest) (*models.Config, erro
 
 	// Update configuration
 	updatedConfig, err := configService.UpdateConfig(a.ctx, &req)
+	a.recordActivity("update_config", "", err)
 	if err != nil {
 		log.Error("failed to update config", logger.Err(err))
 		return nil, fmt.Errorf("failed to update config: %w", err)
 	}
 
+	applyLogRotation(updatedConfig.LogMaxSizeMB, updatedConfig.LogMaxFiles, updatedConfig.LogMaxAgeDays, updatedConfig.LogCompress)
+	applyChainCacheTTL(updatedConfig.AIACacheTTLMinutes)
+	a.refillKeyPool()
+
 	log.Info("configuration updated successfully")
 	return updatedConfig, nil
 }
+
+// SetHostnameSuffix changes the hostname suffix alone, to accept the one
+// suggested after restoring a backup or importing certificates
+// (BackupPeekInfo.SuggestedHostnameSuffix, CertImportResult.SuggestedHostnameSuffix).
+// Available before unlock, right after a restore.
+func (a *App) SetHostnameSuffix(suffix string) error {
+	_, log := logger.WithOperation(a.ctx, "set_hostname_suffix")
+	log.Info("setting hostname suffix", slog.String("hostname_suffix", suffix))
+
+	a.mu.RLock()
+	configService := a.configService
+	a.mu.RUnlock()
+
+	if configService == nil {
+		return fmt.Errorf("config service not initialized")
+	}
+
+	err := configService.SetHostnameSuffix(a.ctx, suffix)
+	a.recordActivity("set_hostname_suffix", "", err)
+	if err != nil {
+		log.Error("failed to set hostname suffix", logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+// CompleteSetup creates the configuration and the master key wrapped with
+// password in one transaction, then unlocks the app. Unlike SaveSetup followed
+// by ProvideEncryptionKey, a failure or crash midway leaves the app in the
+// setup wizard rather than configured without a password.
+func (a *App) CompleteSetup(req models.SetupRequest, password string) error {
+	_, log := logger.WithOperation(a.ctx, "complete_setup")
+	log.Info("completing setup",
+		slog.String("owner_email", req.OwnerEmail),
+		slog.String("ca_name", req.CAName),
+	)
+
+	if password == "" {
+		return fmt.Errorf("password cannot be empty")
+	}
+	if len(password) < 16 {
+		return fmt.Errorf("password must be at least 16 characters")
+	}
+
+	a.mu.RLock()
+	setupService := a.setupService
+	a.mu.RUnlock()
+
+	if setupService == nil {
+		return fmt.Errorf("setup service not initialized")
+	}
+
+	masterKey, passwordKey, err := a.newPasswordMasterKey(password)
+	if err != nil {
+		log.Error("failed to create master key", logger.Err(err))
+		return err
+	}
+	if err := setupService.CompleteSetup(a.ctx, req, passwordKey); err != nil {
+		masterKey.Destroy()
+		log.Error("complete setup failed", logger.Err(err))
+		return err
+	}
+
+	a.mu.Lock()
+	a.isConfigured = true
+	a.mu.Unlock()
+	a.finalizeUnlock(masterKey)
+
+	log.Info("setup completed successfully")
+	return nil
+}
+
+// GetSetupProgress returns where the setup wizard stopped, or nil when there
+// is nothing to resume
+func (a *App) GetSetupProgress() (*models.SetupProgress, error) {
+	a.mu.RLock()
+	setupService := a.setupService
+	a.mu.RUnlock()
+
+	if setupService == nil {
+		return nil, fmt.Errorf("setup service not initialized")
+	}
+	return setupService.GetProgress(a.ctx)
+}
+
+// SaveSetupProgress records the wizard step shown and the values entered so
+// far (without the password), so the wizard resumes there after a restart
+func (a *App) SaveSetupProgress(progress models.SetupProgress) error {
+	a.mu.RLock()
+	setupService := a.setupService
+	a.mu.RUnlock()
+
+	if setupService == nil {
+		return fmt.Errorf("setup service not initialized")
+	}
+
+	if err := setupService.SaveProgress(a.ctx, progress); err != nil {
+		logger.WithComponent("app").Error("failed to save setup progress", logger.Err(err))
+		return err
+	}
+	return nil
+}
+
+// AbortSetup discards an incomplete setup (saved progress, a configuration
+// never marked complete, unlock methods created before setup finished) and
+// locks the app, so the wizard starts over. Refused once setup is complete.
+func (a *App) AbortSetup() error {
+	_, log := logger.WithOperation(a.ctx, "abort_setup")
+	log.Info("aborting setup")
+
+	a.mu.RLock()
+	setupService := a.setupService
+	a.mu.RUnlock()
+
+	if setupService == nil {
+		return fmt.Errorf("setup service not initialized")
+	}
+
+	a.mu.RLock()
+	configured, unlocked := a.isConfigured, a.isUnlocked
+	a.mu.RUnlock()
+	if configured {
+		return fmt.Errorf("setup is already complete")
+	}
+
+	// A master key unlocked before setup completed is about to wrap nothing;
+	// lock first so no background job keeps using it
+	if unlocked {
+		if err := a.ClearEncryptionKey(); err != nil {
+			log.Error("failed to lock before aborting setup", logger.Err(err))
+			return err
+		}
+	}
+
+	if err := setupService.Abort(a.ctx); err != nil {
+		log.Error("abort setup failed", logger.Err(err))
+		return err
+	}
+
+	log.Info("setup aborted")
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"testing"
+
+	"paddockcontrol-desktop/internal/models"
+)
+
+func testSetupRequest() models.SetupRequest {
+	return models.SetupRequest{
+		OwnerEmail:          "admin@example.com",
+		CAName:              "Test CA",
+		HostnameSuffix:      ".example.com",
+		ValidityPeriodDays:  365,
+		DefaultOrganization: "Test Org",
+		DefaultCity:         "Paris",
+		DefaultState:        "IDF",
+		DefaultCountry:      "FR",
+		DefaultKeySize:      2048,
+	}
+}
+
+func TestCompleteSetup_ConfiguresAndUnlocks(t *testing.T) {
+	app := setupTestApp(t)
+
+	if err := app.CompleteSetup(testSetupRequest(), "short"); err == nil {
+		t.Fatal("expected a short password to be rejected")
+	}
+	if configured, _ := app.IsSetupComplete(); configured {
+		t.Fatal("expected a rejected setup to leave the app unconfigured")
+	}
+
+	if err := app.CompleteSetup(testSetupRequest(), testPassword); err != nil {
+		t.Fatalf("CompleteSetup() error: %v", err)
+	}
+	if !app.isConfigured || !app.isUnlocked {
+		t.Fatalf("configured = %v, unlocked = %v; want both", app.isConfigured, app.isUnlocked)
+	}
+	if n := countPasswordKeys(t, app); n != 1 {
+		t.Fatalf("expected 1 password key, got %d", n)
+	}
+
+	// The password set during setup unlocks the app again
+	if err := app.ClearEncryptionKey(); err != nil {
+		t.Fatalf("ClearEncryptionKey() error: %v", err)
+	}
+	result, err := app.ProvideEncryptionKey(testPassword)
+	if err != nil || !result.Valid {
+		t.Fatalf("ProvideEncryptionKey() = %+v, %v; want valid", result, err)
+	}
+
+	if err := app.AbortSetup(); err == nil {
+		t.Error("expected AbortSetup to fail once setup is complete")
+	}
+}
+
+func TestAbortSetup_LocksAndResetsPartialSetup(t *testing.T) {
+	app := setupTestApp(t)
+
+	// The previous wizard saved the configuration, then unlocked with a
+	// password before anything marked setup complete
+	if err := app.SaveSetupProgress(models.SetupProgress{Step: "review", Draft: testSetupRequest()}); err != nil {
+		t.Fatalf("SaveSetupProgress() error: %v", err)
+	}
+	if _, err := app.ProvideEncryptionKey(testPassword); err != nil {
+		t.Fatalf("ProvideEncryptionKey() error: %v", err)
+	}
+
+	if err := app.AbortSetup(); err != nil {
+		t.Fatalf("AbortSetup() error: %v", err)
+	}
+	if app.isUnlocked {
+		t.Error("expected AbortSetup to lock the app")
+	}
+	if n := countSecurityKeys(t, app); n != 0 {
+		t.Errorf("expected no security keys after abort, got %d", n)
+	}
+	if progress, err := app.GetSetupProgress(); err != nil || progress != nil {
+		t.Errorf("GetSetupProgress() = %+v, %v; want nothing", progress, err)
+	}
+}
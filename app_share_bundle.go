@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ============================================================================
+// Certificate Share Bundles
+// ============================================================================
+
+// maxShareBundleSize bounds how much of a file is read as a share bundle. A
+// bundle holds one certificate, its chain and key, so real ones are a few KiB.
+const maxShareBundleSize = 1 << 20
+
+// CreateShareBundle prompts the user to save a password-protected, time-limited
+// bundle of a certificate (and its private key when includeKey is set) that
+// another PaddockControl installation can import until expiresHours have passed.
+func (a *App) CreateShareBundle(hostname string, includeKey bool, password string, expiresHours int) error {
+	if includeKey {
+		if err := a.requireSetupComplete(); err != nil {
+			return err
+		}
+	} else {
+		if err := a.requireSetupOnly(); err != nil {
+			return err
+		}
+	}
+	if len(password) < 16 {
+		return fmt.Errorf("password must be at least 16 characters")
+	}
+
+	_, log := logger.WithOperation(a.ctx, "create_share_bundle")
+	log.Info("creating share bundle",
+		slog.String("hostname", hostname),
+		slog.Bool("include_key", includeKey),
+		slog.Int("expires_hours", expiresHours),
+	)
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	database := a.db
+	var encryptionKey *crypto.SecretBuffer
+	if includeKey {
+		encryptionKey = a.masterKey.Clone()
+	}
+	a.mu.RUnlock()
+	defer encryptionKey.Destroy()
+
+	if certificateService == nil {
+		return fmt.Errorf("certificate service not initialized")
+	}
+
+	path, err := wailsruntime.SaveFileDialog(a.ctx, wailsruntime.SaveDialogOptions{
+		DefaultFilename: hostname + ".pcshare",
+		Title:           "Save Share Bundle",
+		Filters: []wailsruntime.FileFilter{
+			{DisplayName: "Share Bundles (*.pcshare)", Pattern: "*.pcshare"},
+		},
+	})
+	if err != nil {
+		log.Error("file dialog error", logger.Err(err))
+		return fmt.Errorf("file dialog error: %w", err)
+	}
+	if path == "" {
+		log.Info("user cancelled share bundle save dialog")
+		return nil
+	}
+
+	var keyBytes []byte
+	if includeKey {
+		keyBytes = encryptionKey.Bytes()
+	}
+	data, err := certificateService.CreateShareBundle(a.ctx, hostname, includeKey, password,
+		time.Duration(expiresHours)*time.Hour, keyBytes, a.kdfParams(database))
+	if err != nil {
+		log.Error("failed to create share bundle", logger.Err(err))
+		return err
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		log.Error("failed to write share bundle", slog.String("path", path), logger.Err(err))
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if includeKey {
+		a.recordAudit(database, models.AuditKeyExport, hostname, "Private key exported in a share bundle",
+			map[string]any{"format": "share_bundle", "expires_hours": expiresHours})
+	}
+	log.Info("share bundle saved", slog.String("path", path))
+	return nil
+}
+
+// SelectShareBundleFile opens a file dialog for the user to select a share
+// bundle. Returns the selected file path, or empty string if cancelled.
+func (a *App) SelectShareBundleFile() (string, error) {
+	path, err := wailsruntime.OpenFileDialog(a.ctx, wailsruntime.OpenDialogOptions{
+		Title: "Select Share Bundle",
+		Filters: []wailsruntime.FileFilter{
+			{DisplayName: "Share Bundles (*.pcshare)", Pattern: "*.pcshare"},
+			{DisplayName: "All Files (*.*)", Pattern: "*.*"},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("file dialog error: %w", err)
+	}
+	return path, nil
+}
+
+// PeekShareBundle decrypts a share bundle and describes its contents without
+// importing anything. Expired bundles are refused.
+func (a *App) PeekShareBundle(path string, password string) (*models.ShareBundleInfo, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	a.mu.RUnlock()
+
+	if certificateService == nil {
+		return nil, fmt.Errorf("certificate service not initialized")
+	}
+
+	data, err := readShareBundle(path)
+	if err != nil {
+		return nil, err
+	}
+	return certificateService.PeekShareBundle(data, password)
+}
+
+// ImportShareBundle imports the certificate and private key of a share bundle.
+// Returns the imported hostname.
+func (a *App) ImportShareBundle(path string, password string) (string, error) {
+	if err := a.requireSetupComplete(); err != nil {
+		return "", err
+	}
+
+	_, log := logger.WithOperation(a.ctx, "import_share_bundle")
+	log.Info("importing share bundle", slog.String("path", path))
+
+	a.mu.RLock()
+	certificateService := a.certificateService
+	encryptionKey := a.masterKey.Clone()
+	a.mu.RUnlock()
+	defer encryptionKey.Destroy()
+
+	if certificateService == nil {
+		return "", fmt.Errorf("certificate service not initialized")
+	}
+
+	data, err := readShareBundle(path)
+	if err != nil {
+		return "", err
+	}
+
+	hostname, err := certificateService.ImportShareBundle(a.ctx, data, password, encryptionKey.Bytes())
+	a.recordActivity("import_share_bundle", hostname, err)
+	if err != nil {
+		log.Error("share bundle import failed", logger.Err(err))
+		return "", err
+	}
+
+	log.Info("share bundle imported", slog.String("hostname", hostname))
+	return hostname, nil
+}
+
+// readShareBundle reads a share bundle file, refusing anything too large to be one.
+func readShareBundle(path string) ([]byte, error) {
+	if path == "" {
+		return nil, fmt.Errorf("share bundle path is empty")
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open share bundle: %w", err)
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxShareBundleSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read share bundle: %w", err)
+	}
+	if len(data) > maxShareBundleSize {
+		return nil, fmt.Errorf("not a PaddockControl share bundle")
+	}
+	return data, nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// ============================================================================
+// Subject Presets
+// ============================================================================
+
+// ListSubjectPresets returns the subject presets selectable when generating a CSR.
+// Does NOT require encryption key - presets hold no secrets
+func (a *App) ListSubjectPresets() ([]models.SubjectPreset, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	configService := a.configService
+	a.mu.RUnlock()
+
+	if configService == nil {
+		return nil, fmt.Errorf("config service not initialized")
+	}
+
+	return configService.ListSubjectPresets(a.ctx)
+}
+
+// SaveSubjectPreset creates a subject preset (ID 0) or updates an existing one.
+// Does NOT require encryption key - presets hold no secrets
+func (a *App) SaveSubjectPreset(preset models.SubjectPreset) (*models.SubjectPreset, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("saving subject preset",
+		slog.Int64("id", preset.ID),
+		slog.String("name", preset.Name),
+	)
+
+	a.mu.RLock()
+	configService := a.configService
+	a.mu.RUnlock()
+
+	if configService == nil {
+		return nil, fmt.Errorf("config service not initialized")
+	}
+
+	saved, err := configService.SaveSubjectPreset(a.ctx, preset)
+	a.recordActivity("save_subject_preset", "", err)
+	if err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// DeleteSubjectPreset removes a subject preset. Certificates generated from it
+// keep their subject.
+// Does NOT require encryption key - presets hold no secrets
+func (a *App) DeleteSubjectPreset(id int64) error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	a.mu.RLock()
+	configService := a.configService
+	a.mu.RUnlock()
+
+	if configService == nil {
+		return fmt.Errorf("config service not initialized")
+	}
+
+	err := configService.DeleteSubjectPreset(a.ctx, id)
+	a.recordActivity("delete_subject_preset", "", err)
+	return err
+}
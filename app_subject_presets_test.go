@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"paddockcontrol-desktop/internal/models"
+)
+
+func TestSubjectPresets_CRUD(t *testing.T) {
+	app := setupConfiguredApp(t)
+	if err := app.SkipEncryptionKey(); err != nil {
+		t.Fatalf("SkipEncryptionKey() error: %v", err)
+	}
+
+	created, err := app.SaveSubjectPreset(models.SubjectPreset{
+		Name:         " Legal Entity B ",
+		Organization: "Entity B SAS",
+		City:         "Lyon",
+		State:        "Auvergne-Rhône-Alpes",
+		Country:      "fr",
+	})
+	if err != nil {
+		t.Fatalf("SaveSubjectPreset() error: %v", err)
+	}
+	if created.ID == 0 || created.Name != "Legal Entity B" || created.Country != "FR" {
+		t.Errorf("unexpected preset: %+v", created)
+	}
+
+	if _, err := app.SaveSubjectPreset(models.SubjectPreset{
+		Name:         "legal entity b",
+		Organization: "Other",
+		City:         "Lyon",
+		State:        "ARA",
+		Country:      "FR",
+	}); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("expected a duplicate name error, got %v", err)
+	}
+
+	if _, err := app.SaveSubjectPreset(models.SubjectPreset{Name: "Incomplete", Organization: "Org"}); err == nil {
+		t.Fatal("expected a validation error for a preset without city")
+	}
+
+	created.OrganizationalUnit = "Security"
+	updated, err := app.SaveSubjectPreset(*created)
+	if err != nil {
+		t.Fatalf("SaveSubjectPreset() update error: %v", err)
+	}
+	if updated.ID != created.ID || updated.OrganizationalUnit != "Security" {
+		t.Errorf("unexpected updated preset: %+v", updated)
+	}
+
+	presets, err := app.ListSubjectPresets()
+	if err != nil {
+		t.Fatalf("ListSubjectPresets() error: %v", err)
+	}
+	if len(presets) != 1 {
+		t.Fatalf("expected 1 preset, got %d", len(presets))
+	}
+
+	if err := app.DeleteSubjectPreset(created.ID); err != nil {
+		t.Fatalf("DeleteSubjectPreset() error: %v", err)
+	}
+	presets, err = app.ListSubjectPresets()
+	if err != nil {
+		t.Fatalf("ListSubjectPresets() error: %v", err)
+	}
+	if len(presets) != 0 {
+		t.Errorf("expected no presets after delete, got %+v", presets)
+	}
+}
+
+func TestSubjectPresets_RequireSetup(t *testing.T) {
+	app := setupTestApp(t)
+	if _, err := app.ListSubjectPresets(); err == nil {
+		t.Fatal("expected an error before setup")
+	}
+}
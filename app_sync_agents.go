@@ -0,0 +1,573 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"paddockcontrol-desktop/internal/agentsync"
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/hostnames"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/services"
+)
+
+// ============================================================================
+// Sync Agents
+// ============================================================================
+
+// EnrollSyncAgent enrolls a companion agent allowed to pull the given
+// certificates (and their private keys) from the sync server. The returned
+// client certificate and key are what the agent authenticates with; the vault
+// only keeps the certificate's fingerprint, so they cannot be shown again.
+// The sync CA is created on the first enrollment.
+// Requires unlocked: the sync CA key is encrypted with the master key.
+func (a *App) EnrollSyncAgent(name string, hostnames []string) (*models.SyncAgentEnrollment, error) {
+	if err := a.requireSetupComplete(); err != nil {
+		return nil, err
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("agent name is required")
+	}
+
+	log := logger.WithComponent("app")
+	log.Info("enrolling sync agent", slog.String("agent", name), slog.Int("hostnames", len(hostnames)))
+
+	a.mu.RLock()
+	database := a.db
+	masterKey := a.masterKey.Clone()
+	a.mu.RUnlock()
+	defer masterKey.Destroy()
+
+	if database == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	var enrollment *models.SyncAgentEnrollment
+	err := database.WithTx(a.ctx, func(q *sqlc.Queries) error {
+		ca, caPEM, err := a.loadSyncCA(a.ctx, q, masterKey.Bytes())
+		if err != nil {
+			return err
+		}
+		issued, err := ca.IssueClient(name, a.appClock().Now())
+		if err != nil {
+			return err
+		}
+		defer crypto.Zero(issued.PrivateKeyPEM)
+
+		row, err := q.InsertSyncAgent(a.ctx, sqlc.InsertSyncAgentParams{
+			Name:            name,
+			CertFingerprint: issued.Fingerprint,
+			ExpiresAt:       issued.NotAfter.Unix(),
+		})
+		if err != nil {
+			if strings.Contains(err.Error(), "UNIQUE") {
+				return fmt.Errorf("an agent named %s is already enrolled", name)
+			}
+			return fmt.Errorf("failed to store agent: %w", err)
+		}
+		assigned, err := assignSyncAgentHostnames(a.ctx, q, row.ID, hostnames)
+		if err != nil {
+			return err
+		}
+
+		enrollment = &models.SyncAgentEnrollment{
+			Agent:            toSyncAgent(row, assigned),
+			CertificatePEM:   string(issued.CertificatePEM),
+			PrivateKeyPEM:    string(issued.PrivateKeyPEM),
+			CACertificatePEM: caPEM,
+		}
+		return nil
+	})
+	a.recordActivity("enroll_sync_agent", "", err)
+	if err != nil {
+		log.Error("sync agent enrollment failed", slog.String("agent", name), logger.Err(err))
+		return nil, err
+	}
+
+	a.recordAudit(database, models.AuditAgentEnroll, "", "Sync agent enrolled",
+		map[string]any{
+			"agent":       name,
+			"agent_id":    enrollment.Agent.ID,
+			"fingerprint": enrollment.Agent.CertFingerprint,
+			"hostnames":   enrollment.Agent.Hostnames,
+		})
+	logger.Audit("sync_agent.enrolled",
+		slog.Int64("id", enrollment.Agent.ID),
+		slog.String("agent", name),
+		slog.String("fingerprint", enrollment.Agent.CertFingerprint),
+		slog.Any("hostnames", enrollment.Agent.Hostnames),
+	)
+	return enrollment, nil
+}
+
+// RevokeSyncAgent revokes an agent: the sync server refuses its client
+// certificate from then on. The agent stays listed as revoked.
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) RevokeSyncAgent(id int64) error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	a.mu.RLock()
+	database := a.db
+	a.mu.RUnlock()
+
+	if database == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	name, err := database.Queries().RevokeSyncAgent(a.ctx, sqlc.RevokeSyncAgentParams{
+		RevokedAt: sql.NullInt64{Int64: a.appClock().Now().Unix(), Valid: true},
+		ID:        id,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		err = fmt.Errorf("agent not found or already revoked")
+	}
+	a.recordActivity("revoke_sync_agent", "", err)
+	if err != nil {
+		return err
+	}
+
+	a.recordAudit(database, models.AuditAgentRevoke, "", "Sync agent revoked",
+		map[string]any{"agent": name, "agent_id": id})
+	logger.WithComponent("app").Info("sync agent revoked", slog.Int64("id", id))
+	logger.Audit("sync_agent.revoked", slog.Int64("id", id), slog.String("agent", name))
+	return nil
+}
+
+// SetSyncAgentHostnames replaces the certificates an agent may pull
+// Does NOT require encryption key - nothing is decrypted
+func (a *App) SetSyncAgentHostnames(id int64, hostnames []string) error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	a.mu.RLock()
+	database := a.db
+	a.mu.RUnlock()
+
+	if database == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	var agent sqlc.SyncAgent
+	var assigned []string
+	err := database.WithTx(a.ctx, func(q *sqlc.Queries) error {
+		var err error
+		agent, err = q.GetActiveSyncAgent(a.ctx, id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("agent not found or revoked")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get agent: %w", err)
+		}
+		if err := q.DeleteSyncAgentHostnames(a.ctx, id); err != nil {
+			return fmt.Errorf("failed to clear agent certificates: %w", err)
+		}
+		assigned, err = assignSyncAgentHostnames(a.ctx, q, id, hostnames)
+		return err
+	})
+	a.recordActivity("set_sync_agent_hostnames", "", err)
+	if err != nil {
+		return err
+	}
+
+	a.recordAudit(database, models.AuditAgentHostnames, "", "Sync agent certificates changed",
+		map[string]any{"agent": agent.Name, "agent_id": id, "hostnames": assigned})
+	logger.Audit("sync_agent.hostnames_changed", slog.Int64("id", id), slog.Any("hostnames", assigned))
+	return nil
+}
+
+// ListSyncAgents returns the enrolled agents with their certificates, revoked
+// agents last
+// Does NOT require encryption key - read-only operation
+func (a *App) ListSyncAgents() ([]models.SyncAgent, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	database := a.db
+	a.mu.RUnlock()
+
+	if database == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	rows, err := database.Queries().ListSyncAgents(a.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agents: %w", err)
+	}
+	assignments, err := database.Queries().ListSyncAgentHostnames(a.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list agent certificates: %w", err)
+	}
+	byAgent := make(map[int64][]string)
+	for _, assignment := range assignments {
+		byAgent[assignment.AgentID] = append(byAgent[assignment.AgentID], assignment.Hostname)
+	}
+
+	agents := make([]models.SyncAgent, 0, len(rows))
+	for _, row := range rows {
+		agents = append(agents, toSyncAgent(row, byAgent[row.ID]))
+	}
+	return agents, nil
+}
+
+// StartSyncServer starts serving agents on address (host:port; ":8443" listens
+// on every interface). The address is remembered: the server stops when the
+// app is locked and starts again at the next unlock, until StopSyncServer.
+// Requires unlocked: the server hands out decrypted private keys.
+func (a *App) StartSyncServer(address string) (*models.SyncServerStatus, error) {
+	if err := a.requireSetupComplete(); err != nil {
+		return nil, err
+	}
+	address = strings.TrimSpace(address)
+	if address == "" {
+		return nil, fmt.Errorf("listen address is required")
+	}
+
+	a.mu.Lock()
+	a.stopSyncServer()
+	err := a.startSyncServer(address)
+	if err == nil {
+		err = a.db.Queries().SetSyncServerListenAddress(a.ctx, address)
+	}
+	a.mu.Unlock()
+
+	a.recordActivity("start_sync_server", "", err)
+	if err != nil {
+		logger.WithComponent("app").Error("failed to start sync server", slog.String("address", address), logger.Err(err))
+		return nil, err
+	}
+	logger.Audit("sync_server.started", slog.String("address", address))
+	return a.GetSyncServerStatus()
+}
+
+// StopSyncServer stops serving agents and forgets the listen address, so the
+// server stays off after the next unlock
+// Does NOT require encryption key
+func (a *App) StopSyncServer() error {
+	if err := a.requireSetupOnly(); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	a.stopSyncServer()
+	err := a.db.Queries().SetSyncServerListenAddress(a.ctx, "")
+	a.mu.Unlock()
+
+	a.recordActivity("stop_sync_server", "", err)
+	if err != nil {
+		return fmt.Errorf("failed to turn off the sync server: %w", err)
+	}
+	logger.Audit("sync_server.stopped")
+	return nil
+}
+
+// GetSyncServerStatus reports whether the sync server is running, where, and
+// the CA certificate agents verify it with
+// Does NOT require encryption key - read-only operation
+func (a *App) GetSyncServerStatus() (*models.SyncServerStatus, error) {
+	if err := a.requireSetupOnly(); err != nil {
+		return nil, err
+	}
+
+	a.mu.RLock()
+	database := a.db
+	server := a.syncServer
+	a.mu.RUnlock()
+
+	if database == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	status := &models.SyncServerStatus{Running: server != nil}
+	row, err := database.Queries().GetSyncServer(a.ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to read sync server: %w", err)
+	}
+	if err == nil {
+		status.ListenAddress = row.ListenAddress
+		status.CACertificatePEM = row.CaCertificatePem
+	}
+	if server != nil {
+		status.ListenAddress = server.Addr()
+	}
+	return status, nil
+}
+
+// startSyncServer issues a fresh server certificate from the sync CA and
+// starts serving on address. Callers hold a.mu and have stopped any running
+// server.
+func (a *App) startSyncServer(address string) error {
+	if !a.isUnlocked || a.db == nil {
+		return fmt.Errorf("app must be unlocked for this operation")
+	}
+	masterKey := a.masterKey.Clone()
+	defer masterKey.Destroy()
+
+	var ca *agentsync.CA
+	if err := a.db.WithTx(a.ctx, func(q *sqlc.Queries) error {
+		var err error
+		ca, _, err = a.loadSyncCA(a.ctx, q, masterKey.Bytes())
+		return err
+	}); err != nil {
+		return err
+	}
+
+	cert, err := ca.IssueServer(agentsync.ServerNames(address), a.appClock().Now())
+	if err != nil {
+		return err
+	}
+	server, err := agentsync.Listen(address, cert, ca.Pool(), &syncSource{app: a})
+	if err != nil {
+		return err
+	}
+	a.syncServer = server
+	logger.WithComponent("app").Info("sync server listening", slog.String("address", server.Addr()))
+	return nil
+}
+
+// resumeSyncServer starts the sync server again at unlock when it was left
+// turned on. Failures (address in use) are logged: the unlock goes on.
+// Callers hold a.mu.
+func (a *App) resumeSyncServer() {
+	if a.syncServer != nil || a.db == nil {
+		return
+	}
+	row, err := a.db.Queries().GetSyncServer(a.ctx)
+	if err != nil || row.ListenAddress == "" {
+		return
+	}
+	if err := a.startSyncServer(row.ListenAddress); err != nil {
+		logger.WithComponent("app").Error("failed to resume sync server",
+			slog.String("address", row.ListenAddress), logger.Err(err))
+	}
+}
+
+// stopSyncServer stops serving agents, when the master key is cleared or the
+// database replaced. The listen address is kept. Callers hold a.mu.
+func (a *App) stopSyncServer() {
+	if a.syncServer == nil {
+		return
+	}
+	if err := a.syncServer.Close(); err != nil {
+		logger.WithComponent("app").Warn("sync server close error", logger.Err(err))
+	}
+	a.syncServer = nil
+}
+
+// loadSyncCA returns the sync CA and its certificate, creating it on first use
+func (a *App) loadSyncCA(ctx context.Context, q *sqlc.Queries, masterKey []byte) (*agentsync.CA, string, error) {
+	row, err := q.GetSyncServer(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		certPEM, keyPEM, err := agentsync.NewCA(a.appClock().Now())
+		if err != nil {
+			return nil, "", err
+		}
+		defer crypto.Zero(keyPEM)
+		encryptedKey, err := crypto.EncryptPrivateKey(keyPEM, masterKey)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to encrypt sync CA key: %w", err)
+		}
+		if err := q.CreateSyncServer(ctx, sqlc.CreateSyncServerParams{
+			CaCertificatePem:      string(certPEM),
+			CaEncryptedPrivateKey: encryptedKey,
+		}); err != nil {
+			return nil, "", fmt.Errorf("failed to store sync CA: %w", err)
+		}
+		ca, err := agentsync.LoadCA(certPEM, keyPEM)
+		return ca, string(certPEM), err
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read sync CA: %w", err)
+	}
+
+	keyPEM, err := crypto.DecryptPrivateKey(row.CaEncryptedPrivateKey, masterKey)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decrypt sync CA key: %w", err)
+	}
+	defer keyPEM.Destroy()
+	ca, err := agentsync.LoadCA([]byte(row.CaCertificatePem), keyPEM.Bytes())
+	return ca, row.CaCertificatePem, err
+}
+
+// assignSyncAgentHostnames allows an agent to pull the given certificates and
+// returns the normalized, deduplicated hostnames
+func assignSyncAgentHostnames(ctx context.Context, q *sqlc.Queries, agentID int64, requested []string) ([]string, error) {
+	seen := make(map[string]bool, len(requested))
+	assigned := make([]string, 0, len(requested))
+	for _, hostname := range requested {
+		if strings.TrimSpace(hostname) == "" {
+			continue
+		}
+		hostname, err := hostnames.Normalize(hostname)
+		if err != nil {
+			return nil, err
+		}
+		if seen[hostname] {
+			continue
+		}
+		seen[hostname] = true
+		exists, err := q.CertificateExists(ctx, hostname)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check certificate %s: %w", hostname, err)
+		}
+		if exists == 0 {
+			return nil, fmt.Errorf("certificate not found: %s", hostname)
+		}
+		if err := q.AddSyncAgentHostname(ctx, sqlc.AddSyncAgentHostnameParams{
+			AgentID:  agentID,
+			Hostname: hostname,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to assign %s: %w", hostname, err)
+		}
+		assigned = append(assigned, hostname)
+	}
+	return assigned, nil
+}
+
+func toSyncAgent(row sqlc.SyncAgent, hostnames []string) models.SyncAgent {
+	agent := models.SyncAgent{
+		ID:              row.ID,
+		Name:            row.Name,
+		Hostnames:       hostnames,
+		CertFingerprint: row.CertFingerprint,
+		ExpiresAt:       row.ExpiresAt,
+		CreatedAt:       row.CreatedAt,
+	}
+	if agent.Hostnames == nil {
+		agent.Hostnames = []string{}
+	}
+	if row.RevokedAt.Valid {
+		agent.RevokedAt = &row.RevokedAt.Int64
+	}
+	if row.LastSeenAt.Valid {
+		agent.LastSeenAt = &row.LastSeenAt.Int64
+	}
+	return agent
+}
+
+// syncSource answers the sync server from the app's database. It reads the
+// app state on every request, so a lock or a restore takes effect at once.
+type syncSource struct {
+	app *App
+}
+
+func (s *syncSource) database() (*db.Database, error) {
+	s.app.mu.RLock()
+	defer s.app.mu.RUnlock()
+	if s.app.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	return s.app.db, nil
+}
+
+func (s *syncSource) Agent(ctx context.Context, fingerprint string) (agentsync.Agent, error) {
+	database, err := s.database()
+	if err != nil {
+		return agentsync.Agent{}, err
+	}
+	row, err := database.Queries().GetSyncAgentByFingerprint(ctx, fingerprint)
+	if errors.Is(err, sql.ErrNoRows) {
+		return agentsync.Agent{}, agentsync.ErrUnknownAgent
+	}
+	if err != nil {
+		return agentsync.Agent{}, err
+	}
+	now := s.app.appClock().Now().Unix()
+	if row.RevokedAt.Valid || row.ExpiresAt <= now {
+		return agentsync.Agent{}, agentsync.ErrUnknownAgent
+	}
+
+	if err := database.Queries().TouchSyncAgent(ctx, sqlc.TouchSyncAgentParams{
+		LastSeenAt: sql.NullInt64{Int64: now, Valid: true},
+		ID:         row.ID,
+	}); err != nil {
+		logger.WithComponent("app").Warn("failed to record sync agent activity", slog.Int64("id", row.ID), logger.Err(err))
+	}
+	return agentsync.Agent{ID: row.ID, Name: row.Name}, nil
+}
+
+func (s *syncSource) List(ctx context.Context, agent agentsync.Agent) ([]agentsync.CertificateInfo, error) {
+	database, err := s.database()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := database.Queries().ListSyncAgentCertificates(ctx, agent.ID)
+	if err != nil {
+		return nil, err
+	}
+	certificates := make([]agentsync.CertificateInfo, 0, len(rows))
+	for _, row := range rows {
+		certificates = append(certificates, agentsync.CertificateInfo{
+			Hostname:  row.Hostname,
+			Version:   agentsync.Version(row.CertificatePem.String),
+			ExpiresAt: row.ExpiresAt.Int64,
+		})
+	}
+	return certificates, nil
+}
+
+func (s *syncSource) Bundle(ctx context.Context, agent agentsync.Agent, hostname string) (*agentsync.Bundle, error) {
+	s.app.mu.RLock()
+	database := s.app.db
+	unlocked := s.app.isUnlocked
+	masterKey := s.app.masterKey.Clone()
+	s.app.mu.RUnlock()
+	defer masterKey.Destroy()
+
+	if database == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	row, err := database.Queries().GetSyncAgentCertificate(ctx, sqlc.GetSyncAgentCertificateParams{
+		AgentID:  agent.ID,
+		Hostname: hostname,
+	})
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && (!row.CertificatePem.Valid || row.EncryptedPrivateKey == nil)) {
+		return nil, agentsync.ErrNotAssigned
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !unlocked || masterKey.Len() == 0 {
+		return nil, agentsync.ErrLocked
+	}
+
+	keyPEM, err := crypto.DecryptPrivateKey(row.EncryptedPrivateKey, masterKey.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt private key of %s: %w", hostname, err)
+	}
+	defer keyPEM.Destroy()
+
+	message := fmt.Sprintf("Certificate and private key pulled by sync agent %s", agent.Name)
+	history := services.NewHistoryService(database, Version)
+	if err := history.LogEventDetailsTx(ctx, database.Queries(), row.Hostname, models.EventKeySyncedToAgent, message,
+		map[string]any{"agent": agent.Name}); err != nil {
+		logger.WithComponent("app").Warn("failed to log sync history", slog.String("hostname", row.Hostname), logger.Err(err))
+	}
+	s.app.recordAudit(database, models.AuditKeyExport, row.Hostname, "Private key pulled by a sync agent",
+		map[string]any{"format": "sync_agent", "agent": agent.Name, "agent_id": agent.ID})
+	logger.Audit("sync_agent.key_pulled",
+		slog.String("hostname", row.Hostname),
+		slog.Int64("agent_id", agent.ID),
+		slog.String("agent", agent.Name),
+	)
+
+	return &agentsync.Bundle{
+		Hostname:       row.Hostname,
+		Version:        agentsync.Version(row.CertificatePem.String),
+		CertificatePEM: row.CertificatePem.String,
+		ChainPEM:       row.ChainPem.String,
+		PrivateKeyPEM:  string(keyPEM.Bytes()),
+	}, nil
+}
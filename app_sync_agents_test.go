@@ -0,0 +1,246 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/agentsync"
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// insertSyncTestCert stores an issued certificate whose key is encrypted with
+// the app's master key and returns the key PEM
+func insertSyncTestCert(t *testing.T, app *App, hostname string) string {
+	t.Helper()
+	certPEM, keyPEM, err := agentsync.NewCA(time.Now())
+	if err != nil {
+		t.Fatalf("NewCA() error: %v", err)
+	}
+	masterKey := app.masterKey.Clone()
+	defer masterKey.Destroy()
+	encryptedKey, err := crypto.EncryptPrivateKey(keyPEM, masterKey.Bytes())
+	if err != nil {
+		t.Fatalf("EncryptPrivateKey() error: %v", err)
+	}
+	if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{
+		Hostname:            hostname,
+		EncryptedPrivateKey: encryptedKey,
+		CertificatePem:      sql.NullString{String: string(certPEM), Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to insert certificate: %v", err)
+	}
+	return string(keyPEM)
+}
+
+// syncAgentClient returns an HTTP client authenticating as the enrolled agent
+func syncAgentClient(t *testing.T, enrollment *models.SyncAgentEnrollment) *http.Client {
+	t.Helper()
+	pair, err := tls.X509KeyPair([]byte(enrollment.CertificatePEM), []byte(enrollment.PrivateKeyPEM))
+	if err != nil {
+		t.Fatalf("X509KeyPair() error: %v", err)
+	}
+	ca, err := crypto.ParseCertificate([]byte(enrollment.CACertificatePEM))
+	if err != nil {
+		t.Fatalf("ParseCertificate() error: %v", err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		RootCAs:      roots,
+		Certificates: []tls.Certificate{pair},
+	}}}
+}
+
+func TestSyncAgents_EnrollPullRevoke(t *testing.T) {
+	app := setupUnlockedApp(t)
+	keyPEM := insertSyncTestCert(t, app, "web01.example.com")
+	insertSyncTestCert(t, app, "db01.example.com")
+
+	enrollment, err := app.EnrollSyncAgent("web01", []string{"web01.example.com"})
+	if err != nil {
+		t.Fatalf("EnrollSyncAgent() error: %v", err)
+	}
+	status, err := app.StartSyncServer("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("StartSyncServer() error: %v", err)
+	}
+	client := syncAgentClient(t, enrollment)
+	base := "https://" + status.ListenAddress
+
+	resp, err := client.Get(base + "/v1/certificates/web01.example.com")
+	if err != nil {
+		t.Fatalf("GET bundle error: %v", err)
+	}
+	var bundle agentsync.Bundle
+	err = json.NewDecoder(resp.Body).Decode(&bundle)
+	resp.Body.Close()
+	if err != nil || bundle.PrivateKeyPEM != keyPEM {
+		t.Fatalf("bundle = %+v (%v), want the decrypted private key", bundle, err)
+	}
+
+	resp, err = client.Get(base + "/v1/certificates/db01.example.com")
+	if err != nil {
+		t.Fatalf("GET bundle error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unassigned certificate: status %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+
+	history, err := app.GetCertificateHistory("web01.example.com", 10)
+	if err != nil {
+		t.Fatalf("GetCertificateHistory() error: %v", err)
+	}
+	if len(history) == 0 || history[0].EventType != models.EventKeySyncedToAgent {
+		t.Errorf("expected a %s history entry, got %+v", models.EventKeySyncedToAgent, history)
+	}
+
+	if err := app.RevokeSyncAgent(enrollment.Agent.ID); err != nil {
+		t.Fatalf("RevokeSyncAgent() error: %v", err)
+	}
+	resp, err = client.Get(base + "/v1/certificates")
+	if err != nil {
+		t.Fatalf("GET listing error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("revoked agent: status %d, want %d", resp.StatusCode, http.StatusForbidden)
+	}
+
+	agents, err := app.ListSyncAgents()
+	if err != nil {
+		t.Fatalf("ListSyncAgents() error: %v", err)
+	}
+	if len(agents) != 1 || agents[0].RevokedAt == nil || agents[0].LastSeenAt == nil {
+		t.Errorf("agents = %+v, want one revoked agent that was seen", agents)
+	}
+
+	// The key pull is a key export; enrollment and revocation hand out and
+	// withdraw the credentials it needs
+	page, err := app.GetAuditLog(models.AuditFilter{
+		EventTypes: []string{models.AuditKeyExport, models.AuditAgentEnroll, models.AuditAgentRevoke},
+	}, 0, 0)
+	if err != nil {
+		t.Fatalf("GetAuditLog() error: %v", err)
+	}
+	want := []string{models.AuditAgentRevoke, models.AuditKeyExport, models.AuditAgentEnroll}
+	if len(page.Entries) != len(want) {
+		t.Fatalf("audit entries = %+v, want %v", page.Entries, want)
+	}
+	for i, e := range page.Entries {
+		if e.EventType != want[i] || e.Details["agent"] != "web01" {
+			t.Errorf("audit entry %d = %s %v, want %s by agent web01", i, e.EventType, e.Details, want[i])
+		}
+	}
+	if page.Entries[1].Hostname != "web01.example.com" {
+		t.Errorf("key pull hostname = %q, want web01.example.com", page.Entries[1].Hostname)
+	}
+}
+
+func TestSyncServer_StopsWhileLocked(t *testing.T) {
+	app := setupUnlockedApp(t)
+	insertSyncTestCert(t, app, "web01.example.com")
+	if _, err := app.EnrollSyncAgent("web01", []string{"web01.example.com"}); err != nil {
+		t.Fatalf("EnrollSyncAgent() error: %v", err)
+	}
+	if _, err := app.StartSyncServer("127.0.0.1:0"); err != nil {
+		t.Fatalf("StartSyncServer() error: %v", err)
+	}
+
+	if err := app.ClearEncryptionKey(); err != nil {
+		t.Fatalf("ClearEncryptionKey() error: %v", err)
+	}
+	status, err := app.GetSyncServerStatus()
+	if err != nil {
+		t.Fatalf("GetSyncServerStatus() error: %v", err)
+	}
+	if status.Running || status.ListenAddress == "" {
+		t.Fatalf("status = %+v, want stopped with the address kept", status)
+	}
+
+	if _, err := app.ProvideEncryptionKey(testPassword); err != nil {
+		t.Fatalf("ProvideEncryptionKey() error: %v", err)
+	}
+	if status, _ := app.GetSyncServerStatus(); !status.Running {
+		t.Error("expected the sync server to resume at unlock")
+	}
+
+	if err := app.StopSyncServer(); err != nil {
+		t.Fatalf("StopSyncServer() error: %v", err)
+	}
+	if status, _ := app.GetSyncServerStatus(); status.Running || status.ListenAddress != "" {
+		t.Errorf("status = %+v, want turned off", status)
+	}
+}
+
+func TestEnrollSyncAgent_Validation(t *testing.T) {
+	app := setupConfiguredApp(t)
+	if _, err := app.EnrollSyncAgent("web01", nil); err == nil {
+		t.Error("expected an error while locked")
+	}
+	if _, err := app.ProvideEncryptionKey(testPassword); err != nil {
+		t.Fatalf("ProvideEncryptionKey() error: %v", err)
+	}
+	if _, err := app.EnrollSyncAgent("web01", []string{"missing.example.com"}); err == nil {
+		t.Error("expected an error for an unknown certificate")
+	}
+	if agents, _ := app.ListSyncAgents(); len(agents) != 0 {
+		t.Errorf("a rejected enrollment should not be stored, got %+v", agents)
+	}
+	if _, err := app.EnrollSyncAgent("web01", nil); err != nil {
+		t.Fatalf("EnrollSyncAgent() error: %v", err)
+	}
+	if _, err := app.EnrollSyncAgent("web01", nil); err == nil {
+		t.Error("expected an error for a duplicate agent name")
+	}
+}
+
+func TestSetSyncAgentHostnames(t *testing.T) {
+	app := setupUnlockedApp(t)
+	insertSyncTestCert(t, app, "web01.example.com")
+
+	enrollment, err := app.EnrollSyncAgent("web01", nil)
+	if err != nil {
+		t.Fatalf("EnrollSyncAgent() error: %v", err)
+	}
+	id := enrollment.Agent.ID
+
+	if err := app.SetSyncAgentHostnames(id, []string{"WEB01.Example.com.", "web01.example.com"}); err != nil {
+		t.Fatalf("SetSyncAgentHostnames() error: %v", err)
+	}
+	agents, err := app.ListSyncAgents()
+	if err != nil {
+		t.Fatalf("ListSyncAgents() error: %v", err)
+	}
+	if len(agents) != 1 || len(agents[0].Hostnames) != 1 || agents[0].Hostnames[0] != "web01.example.com" {
+		t.Errorf("agents = %+v, want web01 assigned web01.example.com once", agents)
+	}
+
+	page, err := app.GetAuditLog(models.AuditFilter{EventTypes: []string{models.AuditAgentHostnames}}, 0, 0)
+	if err != nil {
+		t.Fatalf("GetAuditLog() error: %v", err)
+	}
+	if len(page.Entries) != 1 || page.Entries[0].Details["agent"] != "web01" {
+		t.Errorf("audit entries = %+v, want one change by agent web01", page.Entries)
+	}
+
+	if err := app.SetSyncAgentHostnames(id, []string{"not a hostname"}); err == nil {
+		t.Error("expected an error for an invalid hostname")
+	}
+	if err := app.SetSyncAgentHostnames(id+1, nil); err == nil {
+		t.Error("expected an error for an unknown agent")
+	}
+	if err := app.RevokeSyncAgent(id); err != nil {
+		t.Fatalf("RevokeSyncAgent() error: %v", err)
+	}
+	if err := app.SetSyncAgentHostnames(id, []string{"web01.example.com"}); err == nil {
+		t.Error("expected an error for a revoked agent")
+	}
+}
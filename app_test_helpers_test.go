@@ -9,10 +9,15 @@ import (
 	"paddockcontrol-desktop/internal/db"
 	"paddockcontrol-desktop/internal/db/sqlc"
 	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/timing"
 )
 
 const testPassword = "test-password-at-least-16-chars"
 
+// currentSchemaVersion is the latest embedded migration version; databases
+// created by db.NewDatabase are migrated up to it.
+const currentSchemaVersion = 37
+
 // setupTestApp creates a minimal App with an in-memory database for testing.
 // The app is NOT configured and NOT unlocked.
 func setupTestApp(t *testing.T) *App {
@@ -22,6 +27,8 @@ func setupTestApp(t *testing.T) *App {
 		t.Fatalf("failed to create database: %v", err)
 	}
 	t.Cleanup(func() { database.Close() })
+	// Slow runs recorded by an earlier test would show up as health warnings
+	timing.Reset()
 
 	app := &App{
 		ctx:     context.Background(),
@@ -29,6 +36,15 @@ func setupTestApp(t *testing.T) *App {
 		dataDir: ":memory:",
 	}
 	app.initializeServicesWithoutKey()
+	// Runs before the database is closed: a migration still running would keep
+	// the shared in-memory database, and its rows, alive into the next test
+	t.Cleanup(func() {
+		app.mu.Lock()
+		app.stopKeyEnvelopeMigration()
+		app.stopKeyPool()
+		app.stopSyncServer()
+		app.mu.Unlock()
+	})
 	return app
 }
 
@@ -48,6 +64,7 @@ func setupConfiguredApp(t *testing.T) *App {
 		DefaultCountry:            "FR",
 		DefaultKeySize:            2048,
 		ValidityPeriodDays:        365,
+		KdfProfile:                crypto.KDFProfileStandard,
 	})
 	if err != nil {
 		t.Fatalf("failed to create test config: %v", err)
@@ -0,0 +1,241 @@
+package main
+
+import (
+	_ "embed"
+	"fmt"
+	"image"
+	"log/slog"
+	"runtime"
+	"sync"
+
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/tray"
+
+	"fyne.io/systray"
+	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
+)
+
+// ============================================================================
+// System Tray
+// ============================================================================
+
+//go:embed build/appicon.png
+var appIconPNG []byte
+
+// trayState is the tray icon and menu, shared by the menu loop and the
+// refreshes triggered by write operations
+type trayState struct {
+	mu      sync.Mutex
+	running bool
+	base    *image.RGBA
+	count   int // badge count shown, -1 before the first refresh
+	stop    func()
+
+	// Set by quit, so beforeClose lets the app exit
+	quitting bool
+
+	open   *systray.MenuItem
+	lock   *systray.MenuItem
+	backup *systray.MenuItem
+	quit   *systray.MenuItem
+}
+
+// startTray shows the tray icon. It is called from main before wails.Run,
+// on the main thread macOS requires; a desktop without a tray (e.g. Linux
+// without a StatusNotifier host) only logs an error.
+func (a *App) startTray() {
+	base, err := tray.Base(appIconPNG)
+	if err != nil {
+		logger.WithComponent("tray").Error("tray icon unavailable", logger.Err(err))
+		return
+	}
+
+	a.tray.mu.Lock()
+	a.tray.base = base
+	a.tray.count = -1
+	a.tray.mu.Unlock()
+
+	stop := runTray(a.onTrayReady, func() {})
+
+	a.tray.mu.Lock()
+	a.tray.stop = stop
+	a.tray.mu.Unlock()
+}
+
+// stopTray removes the tray icon at shutdown.
+func (a *App) stopTray() {
+	a.tray.mu.Lock()
+	stop := a.tray.stop
+	a.tray.stop = nil
+	a.tray.running = false
+	a.tray.mu.Unlock()
+
+	if stop != nil {
+		stop()
+	}
+}
+
+// onTrayReady builds the tray menu and serves its clicks until the tray exits.
+func (a *App) onTrayReady() {
+	systray.SetTooltip("PaddockControl")
+	systray.SetOnTapped(a.showWindow)
+
+	a.tray.mu.Lock()
+	a.tray.open = systray.AddMenuItem("Open PaddockControl", "Show the main window")
+	systray.AddSeparator()
+	a.tray.lock = systray.AddMenuItem("Lock Now", "Clear the master key from memory")
+	a.tray.backup = systray.AddMenuItem("Create Backup", "Create a manual backup")
+	systray.AddSeparator()
+	a.tray.quit = systray.AddMenuItem("Quit", "Quit PaddockControl")
+	a.tray.running = true
+	open, lock, backup, quit := a.tray.open, a.tray.lock, a.tray.backup, a.tray.quit
+	a.tray.mu.Unlock()
+
+	a.updateTray()
+
+	for {
+		select {
+		case <-open.ClickedCh:
+			a.showWindow()
+		case <-lock.ClickedCh:
+			a.trayLock()
+		case <-backup.ClickedCh:
+			a.trayBackup()
+		case <-quit.ClickedCh:
+			a.quit()
+			return
+		}
+	}
+}
+
+// showWindow brings the main window back from the tray or the taskbar.
+func (a *App) showWindow() {
+	if a.ctx == nil {
+		return
+	}
+	wailsruntime.WindowShow(a.ctx)
+	wailsruntime.WindowUnminimise(a.ctx)
+}
+
+// trayLock locks the app from the tray menu and tells the frontend.
+func (a *App) trayLock() {
+	if err := a.ClearEncryptionKey(); err != nil {
+		logger.WithComponent("tray").Warn("lock from tray failed", logger.Err(err))
+		return
+	}
+	logger.WithComponent("tray").Info("locked from tray")
+	wailsruntime.EventsEmit(a.ctx, "app:locked")
+}
+
+// trayBackup creates a manual backup from the tray menu. Success is announced
+// by the "backup:created" event, failure by "tray:error".
+func (a *App) trayBackup() {
+	if err := a.CreateManualBackup(); err != nil {
+		wailsruntime.EventsEmit(a.ctx, "tray:error", fmt.Sprintf("Backup failed: %v", err))
+	}
+}
+
+// MinimizeWindow minimizes the main window, or hides it to the tray when
+// minimize_to_tray is enabled and the tray icon is shown.
+// Available before setup and unlock.
+func (a *App) MinimizeWindow() {
+	a.tray.mu.Lock()
+	running := a.tray.running
+	a.tray.mu.Unlock()
+
+	if running && a.minimizeToTray() {
+		wailsruntime.WindowHide(a.ctx)
+		return
+	}
+	wailsruntime.WindowMinimise(a.ctx)
+}
+
+// minimizeToTray reports the minimize_to_tray setting (false before setup).
+func (a *App) minimizeToTray() bool {
+	a.mu.RLock()
+	database := a.db
+	a.mu.RUnlock()
+
+	if database == nil {
+		return false
+	}
+	cfg, err := database.Queries().GetConfig(a.ctx)
+	return err == nil && cfg.MinimizeToTray == 1
+}
+
+// refreshTray updates the tray badge and menu in the background after a
+// change (write operation, lock, unlock) and on every background scheduler
+// run. A no-op while the tray is not shown.
+func (a *App) refreshTray() {
+	a.tray.mu.Lock()
+	running := a.tray.running
+	a.tray.mu.Unlock()
+
+	if running {
+		go a.updateTray()
+	}
+}
+
+// updateTray sets the badge to the number of certificates expiring within the
+// warning window and enables the menu entries that apply.
+func (a *App) updateTray() {
+	count := a.expiringCount()
+
+	a.mu.RLock()
+	unlocked := a.isUnlocked
+	configured := a.isConfigured
+	a.mu.RUnlock()
+
+	a.tray.mu.Lock()
+	defer a.tray.mu.Unlock()
+	if !a.tray.running {
+		return
+	}
+
+	setEnabled(a.tray.lock, unlocked)
+	setEnabled(a.tray.backup, configured)
+
+	if count == a.tray.count {
+		return
+	}
+	icon, err := tray.Encode(tray.Badge(a.tray.base, count), runtime.GOOS == "windows")
+	if err != nil {
+		logger.WithComponent("tray").Error("failed to render tray icon", logger.Err(err))
+		return
+	}
+	systray.SetIcon(icon)
+	if count == 0 {
+		systray.SetTooltip("PaddockControl")
+	} else {
+		systray.SetTooltip("PaddockControl: " + countLabel(count, "certificate expiring soon", "certificates expiring soon"))
+	}
+	a.tray.count = count
+	logger.WithComponent("tray").Debug("tray badge updated", slog.Int("expiring", count))
+}
+
+// expiringCount returns the number of certificates in the expiring status (0
+// before setup or when the count fails).
+func (a *App) expiringCount() int {
+	a.mu.RLock()
+	certificateService := a.certificateService
+	configured := a.isConfigured
+	a.mu.RUnlock()
+
+	if certificateService == nil || !configured || a.ctx == nil {
+		return 0
+	}
+	preview, err := certificateService.PreviewStatusAt(a.ctx, a.appClock().Now())
+	if err != nil {
+		logger.WithComponent("tray").Error("failed to count expiring certificates", logger.Err(err))
+		return 0
+	}
+	return preview.Expiring
+}
+
+func setEnabled(item *systray.MenuItem, enabled bool) {
+	if enabled {
+		item.Enable()
+	} else {
+		item.Disable()
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+)
+
+func TestExpiringCount(t *testing.T) {
+	app := setupTestApp(t)
+	if got := app.expiringCount(); got != 0 {
+		t.Errorf("expiringCount() before setup = %d, want 0", got)
+	}
+
+	app = setupConfiguredApp(t)
+	now := time.Now()
+	for hostname, expiresIn := range map[string]time.Duration{
+		"soon.example.com":    5 * 24 * time.Hour,
+		"later.example.com":   200 * 24 * time.Hour,
+		"expired.example.com": -24 * time.Hour,
+	} {
+		if err := app.db.Queries().CreateCertificate(app.ctx, sqlc.CreateCertificateParams{
+			Hostname:       hostname,
+			CertificatePem: sql.NullString{String: "-----BEGIN CERTIFICATE-----", Valid: true},
+			ExpiresAt:      sql.NullInt64{Int64: now.Add(expiresIn).Unix(), Valid: true},
+		}); err != nil {
+			t.Fatalf("failed to create %s: %v", hostname, err)
+		}
+	}
+
+	if got := app.expiringCount(); got != 1 {
+		t.Errorf("expiringCount() = %d, want 1", got)
+	}
+}
+
+func TestMinimizeToTray_FollowsConfig(t *testing.T) {
+	app := setupTestApp(t)
+	if app.minimizeToTray() {
+		t.Error("minimizeToTray() should be false before setup")
+	}
+
+	app = setupConfiguredApp(t)
+	if app.minimizeToTray() {
+		t.Error("minimizeToTray() should default to false")
+	}
+	if _, err := app.db.DB().Exec("UPDATE config SET minimize_to_tray = 1"); err != nil {
+		t.Fatalf("failed to enable minimize_to_tray: %v", err)
+	}
+	if !app.minimizeToTray() {
+		t.Error("minimizeToTray() should follow the config")
+	}
+}
@@ -106,8 +106,8 @@ func (a *App) RestartApp() error {
 		return fmt.Errorf("failed to start new process: %w", err)
 	}
 
-	// Quit the current instance
-	wailsruntime.Quit(a.ctx)
+	// Quit the current instance, even in background mode
+	a.quit()
 	return nil
 }
 
@@ -10,6 +10,7 @@ import (
 
 	"paddockcontrol-desktop/internal/db"
 	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
 
 	wailsruntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
@@ -87,16 +88,44 @@ func (a *App) OpenDataDirectory() error {
 	return nil
 }
 
-// GetBuildInfo returns version and build information
+// GetBuildInfo returns version and build information. cryptoMode is "fips" when
+// the FIPS-compatible crypto mode is enabled, "standard" otherwise; platform is
+// the OS and architecture of the build (e.g. windows/arm64) and kdfProfile the
+// Argon2id cost of new password wraps.
 func (a *App) GetBuildInfo() map[string]string {
+	cryptoMode := "standard"
+	if a.fipsModeEnabled() {
+		cryptoMode = "fips"
+	}
+
+	a.mu.RLock()
+	database := a.db
+	a.mu.RUnlock()
+
 	return map[string]string{
-		"version":   Version,
-		"buildTime": BuildTime,
-		"gitCommit": GitCommit,
-		"goVersion": runtime.Version(),
+		"version":    Version,
+		"buildTime":  BuildTime,
+		"gitCommit":  GitCommit,
+		"goVersion":  runtime.Version(),
+		"platform":   runtime.GOOS + "/" + runtime.GOARCH,
+		"cryptoMode": cryptoMode,
+		"kdfProfile": a.kdfProfile(database),
 	}
 }
 
+// fipsModeEnabled reports whether the FIPS-compatible crypto mode is configured
+func (a *App) fipsModeEnabled() bool {
+	a.mu.RLock()
+	configService := a.configService
+	a.mu.RUnlock()
+
+	if configService == nil {
+		return false
+	}
+	cfg, err := configService.GetConfig(a.ctx)
+	return err == nil && cfg.FipsMode == 1
+}
+
 // ResetDatabase deletes all data and reinitializes for a fresh start
 func (a *App) ResetDatabase() error {
 	a.mu.Lock()
@@ -114,6 +143,11 @@ func (a *App) ResetDatabase() error {
 		}
 	}
 
+	// The background key migration writes to the database being reset
+	a.stopKeyEnvelopeMigration()
+	a.stopKeyPool()
+	a.stopSyncServer()
+
 	// Handle in-memory database differently (for testing)
 	if a.dataDir == ":memory:" {
 		// For in-memory database, use migrations to reset schema
@@ -158,12 +192,8 @@ func (a *App) ResetDatabase() error {
 	}
 
 	// Clear encryption key from memory
-	if a.masterKey != nil {
-		for i := range a.masterKey {
-			a.masterKey[i] = 0
-		}
-		a.masterKey = nil
-	}
+	a.masterKey.Destroy()
+	a.masterKey = nil
 
 	// Reset state to initial values
 	a.isConfigured = false
@@ -173,6 +203,9 @@ func (a *App) ResetDatabase() error {
 	// Reinitialize services without encryption key
 	a.initializeServicesWithoutKey()
 
+	// The previous audit log is in the pre-reset backup
+	a.recordAudit(a.db, models.AuditDatabaseReset, "", "Database reset", nil)
+
 	log.Info("database reset complete - ready for fresh setup")
 
 	return nil
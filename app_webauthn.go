@@ -47,13 +47,12 @@ func (a *App) EnrollPasskey() error {
 	}
 
 	a.mu.RLock()
-	masterKey := make([]byte, len(a.masterKey))
-	copy(masterKey, a.masterKey)
+	masterKey := a.masterKey.Clone()
 	database := a.db
 	a.mu.RUnlock()
-	defer crypto.Zero(masterKey)
+	defer masterKey.Destroy()
 
-	if len(masterKey) != 32 {
+	if masterKey.Len() != 32 {
 		return fmt.Errorf("master key is not available")
 	}
 
@@ -81,7 +80,7 @@ func (a *App) EnrollPasskey() error {
 	}
 	defer crypto.Zero(cred.Secret)
 
-	wrappedMasterKey, err := crypto.WrapMasterKey(masterKey, cred.Secret)
+	wrappedMasterKey, err := crypto.WrapMasterKey(masterKey.Bytes(), cred.Secret)
 	if err != nil {
 		return fmt.Errorf("failed to wrap master key: %w", err)
 	}
@@ -111,6 +110,7 @@ func (a *App) EnrollPasskey() error {
 		slog.String("label", webauthn.LabelForTransports(cred.Transports)),
 		slog.Any("transports", cred.Transports),
 	)
+	a.recordActivity("enroll_passkey", "", nil)
 	return nil
 }
 
@@ -197,6 +197,8 @@ func (a *App) UnlockWithWebAuthn() (bool, error) {
 	if err != nil {
 		log.Debug("passkey assertion failed", logger.Err(err))
 		logger.Audit("unlock.passkey_failed", slog.Int("candidate_keys", len(allowed)))
+		a.recordAudit(database, models.AuditUnlockFailed, "", "Unlock with a passkey failed",
+			map[string]any{"method": models.SecurityKeyMethodFIDO2})
 		return false, fmt.Errorf("passkey unlock failed: %w", err)
 	}
 	defer crypto.Zero(secret)
@@ -207,11 +209,15 @@ func (a *App) UnlockWithWebAuthn() (bool, error) {
 	}
 	masterKey, uerr := crypto.UnwrapMasterKey(cand.wrapped, secret)
 	if uerr != nil {
+		a.recordAudit(database, models.AuditUnlockFailed, "", "Unlock with a passkey failed",
+			map[string]any{"method": models.SecurityKeyMethodFIDO2, "label": cand.label})
 		return false, fmt.Errorf("failed to unwrap master key: %w", uerr)
 	}
 
 	_ = database.Queries().UpdateSecurityKeyLastUsed(a.ctx, cand.id)
 	a.finalizeUnlock(masterKey)
 	logger.Audit("unlock.passkey_succeeded", slog.Int64("key_id", cand.id), slog.String("label", cand.label))
+	a.recordAudit(database, models.AuditUnlock, "", "Unlocked with a passkey",
+		map[string]any{"method": models.SecurityKeyMethodFIDO2, "label": cand.label})
 	return true, nil
 }
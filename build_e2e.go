@@ -0,0 +1,7 @@
+//go:build e2e
+
+package main
+
+// E2EMode builds the end-to-end test bindings (app_e2e.go) and drives the app
+// with a clock they can advance
+const E2EMode = true
@@ -0,0 +1,5 @@
+//go:build !e2e
+
+package main
+
+const E2EMode = false
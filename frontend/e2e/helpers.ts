@@ -138,3 +138,41 @@ export async function provideEncryptionKey(page: Page) {
     // Wait for dialog to close
     await page.getByRole("button", { name: "Provide Key" }).waitFor({ state: "hidden" });
 }
+
+export interface SeedCertificate {
+    hostname: string;
+    status: "pending" | "active";
+    expires_in_days?: number; // default 365, negative for expired
+    key_size?: number;
+    note?: string;
+}
+
+export interface SeededCertificate {
+    hostname: string;
+    status: string;
+    signed_certificate_pem?: string; // pending CSR signed by the test CA
+}
+
+// Seed certificates through the TestSeedData binding (e2e builds only).
+// Requires setup complete and unlocked; reload to show them.
+export async function seedData(
+    page: Page,
+    certificates: SeedCertificate[]
+): Promise<SeededCertificate[]> {
+    const result = await page.evaluate(
+        // eslint-disable-next-line @typescript-eslint/no-explicit-any
+        (certs) => (window as any).go.main.App.TestSeedData({ certificates: certs }),
+        certificates
+    );
+    return result.certificates;
+}
+
+// Move the app clock forward by a number of days (e2e builds only); returns the
+// new app time in Unix seconds. Reload to recompute the statuses shown.
+export async function advanceClock(page: Page, days: number): Promise<number> {
+    return page.evaluate(
+        // eslint-disable-next-line @typescript-eslint/no-explicit-any
+        (seconds) => (window as any).go.main.App.TestAdvanceClock(seconds),
+        days * 24 * 3600
+    );
+}
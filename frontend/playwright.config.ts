@@ -39,7 +39,7 @@ export default defineConfig({
   // Global teardown to kill Wails/Vite child processes
   globalTeardown: "./e2e/global-teardown.ts",
   webServer: {
-    command: `PADDOCKCONTROL_DATA_DIR=":memory:" VITE_DEV_SERVER_PORT=${VITE_PORT} wails dev -tags webkit2_41 -devserver "localhost:${TEST_PORT}"`,
+    command: `PADDOCKCONTROL_DATA_DIR=":memory:" VITE_DEV_SERVER_PORT=${VITE_PORT} wails dev -tags webkit2_41,e2e -devserver "localhost:${TEST_PORT}"`,
     url: `http://localhost:${TEST_PORT}`,
     // Always start fresh to ensure clean in-memory database state
     reuseExistingServer: false,
@@ -1,10 +1,11 @@
-import { useEffect, useCallback } from "react";
+import { useEffect, useCallback, useState } from "react";
 import {
     HashRouter as Router,
     Routes,
     Route,
     Navigate,
     useLocation,
+    useNavigate,
 } from "react-router-dom";
 import { AnimatePresence, motion } from "motion/react";
 import { toast, Toaster } from "sonner";
@@ -14,6 +15,7 @@ import { useAppStore } from "@/stores/useAppStore";
 import { useUpdateStore } from "@/stores/useUpdateStore";
 import { useKonamiCode } from "@/hooks/useKonamiCode";
 import { api } from "@/lib/api";
+import { OpenedFile } from "@/types";
 import { ErrorBoundary } from "@/components/shared/ErrorBoundary";
 import { LoadingSpinner } from "@/components/shared/LoadingSpinner";
 import { ProtectedRoute } from "@/components/layout/ProtectedRoute";
@@ -57,10 +59,12 @@ import { CertificateDetail } from "@/pages/CertificateDetail";
 import { GenerateCSR } from "@/pages/GenerateCSR";
 import { ImportCertificate } from "@/pages/ImportCertificate";
 import { Settings } from "@/pages/Settings";
+import { Activity } from "@/pages/Activity";
 
 function AppContent() {
     const {
         setIsUnlocked,
+        setIsWaitingForEncryptionKey,
         isSetupComplete,
         setIsSetupComplete,
         isLoading,
@@ -68,6 +72,8 @@ function AppContent() {
         setIsAdminModeEnabled,
     } = useAppStore();
     const location = useLocation();
+    const navigate = useNavigate();
+    const [launchFiles, setLaunchFiles] = useState<OpenedFile[]>([]);
 
     // Enable admin mode via Konami code (app-wide, except during setup)
     const isSetupPage = location.pathname.startsWith("/setup");
@@ -79,6 +85,31 @@ function AppContent() {
     }, [isSetupPage, setIsAdminModeEnabled]);
     useKonamiCode(handleKonamiSuccess);
 
+    // Files opened with the app (file associations) or double-clicked while
+    // it runs (handed over by the second instance): show the matching preview
+    const openFile = useCallback(
+        (file: OpenedFile, setupComplete: boolean) => {
+            const state = { openedFile: file };
+            if (file.kind === "backup") {
+                navigate(setupComplete ? "/settings" : "/setup/restore", {
+                    state,
+                });
+            } else if (!setupComplete) {
+                toast.error("Finish setup before importing certificates");
+            } else if (file.hostname) {
+                // Matched to a pending CSR by public key
+                navigate(`/certificates/${encodeURIComponent(file.hostname)}`, {
+                    state,
+                });
+            } else if (file.kind === "certificate") {
+                navigate("/certificates/import", { state });
+            } else {
+                toast.error("No pending CSR matches this file");
+            }
+        },
+        [navigate],
+    );
+
     useEffect(() => {
         // Check initial state on app load
         const checkInitialState = async () => {
@@ -86,16 +117,17 @@ function AppContent() {
                 // Wait for Wails bindings to be available
                 await waitForWails();
 
-                // First check if setup is complete
-                const setupComplete = await api.isSetupComplete();
-                setIsSetupComplete(setupComplete);
-
-                // The app starts locked; unlock is user-initiated (password or
-                // passkey). Reflect any existing unlocked state.
-                if (setupComplete) {
-                    const unlocked = await api.isUnlocked();
-                    setIsUnlocked(unlocked);
+                // Read setup and unlock state in one snapshot so they can't
+                // disagree. The app starts locked; unlock is user-initiated
+                // (password or passkey). Reflect any existing unlocked state.
+                const state = await api.getSessionState();
+                setIsSetupComplete(state.configured);
+                setIsWaitingForEncryptionKey(state.waiting_for_encryption_key);
+                if (state.configured) {
+                    setIsUnlocked(state.unlocked);
                 }
+
+                setLaunchFiles(await api.takeOpenedFiles());
             } catch (error) {
                 console.error("Failed to check initial state:", error);
             } finally {
@@ -104,7 +136,14 @@ function AppContent() {
         };
 
         checkInitialState();
-    }, [setIsUnlocked, setIsSetupComplete, setIsLoading]);
+    }, [setIsUnlocked, setIsWaitingForEncryptionKey, setIsSetupComplete, setIsLoading]);
+
+    // Files the app was launched with, opened once the routes are rendered
+    useEffect(() => {
+        if (isLoading || launchFiles.length === 0) return;
+        launchFiles.forEach((file) => openFile(file, isSetupComplete));
+        setLaunchFiles([]);
+    }, [isLoading, launchFiles, isSetupComplete, openFile]);
 
     // Listen for backup events from the backend
     useEffect(() => {
@@ -127,6 +166,36 @@ function AppContent() {
         return cleanup;
     }, []);
 
+    // Listen for background key validation results
+    useEffect(() => {
+        const cleanupDone = EventsOn(
+            "key-validation:done",
+            (result: import("@/types").KeyValidationResult) => {
+                if (result.valid) {
+                    toast.success("All stored private keys are valid");
+                } else {
+                    toast.error("Some private keys could not be decrypted", {
+                        description: (result.failed_hostnames ?? []).join(", "),
+                    });
+                }
+            },
+        );
+
+        const cleanupError = EventsOn(
+            "key-validation:error",
+            (errMsg: string) => {
+                toast.error("Key validation failed", {
+                    description: errMsg,
+                });
+            },
+        );
+
+        return () => {
+            cleanupDone();
+            cleanupError();
+        };
+    }, []);
+
     // Listen for update events from the backend
     useEffect(() => {
         const { setUpdateInfo, setErrorMessage, setUpdateState } =
@@ -165,6 +234,43 @@ function AppContent() {
         };
     }, []);
 
+    useEffect(() => {
+        const cleanupOpened = EventsOn("file:opened", (file: OpenedFile) =>
+            openFile(file, useAppStore.getState().isSetupComplete),
+        );
+
+        const cleanupFailed = EventsOn(
+            "file:open-failed",
+            (errMsg: string) => {
+                toast.error("Cannot open file", {
+                    description: errMsg,
+                });
+            },
+        );
+
+        return () => {
+            cleanupOpened();
+            cleanupFailed();
+        };
+    }, [openFile]);
+
+    // Listen for tray menu actions
+    useEffect(() => {
+        const cleanupLocked = EventsOn("app:locked", () => {
+            useAppStore.getState().setIsUnlocked(false);
+            toast.info("Locked from the tray");
+        });
+
+        const cleanupError = EventsOn("tray:error", (errMsg: string) => {
+            toast.error(errMsg);
+        });
+
+        return () => {
+            cleanupLocked();
+            cleanupError();
+        };
+    }, []);
+
     // Determine layout group for exit animations
     const isSetupSubPage =
         location.pathname === "/setup/wizard" ||
@@ -250,6 +356,14 @@ function AppContent() {
                             </ProtectedRoute>
                         }
                     />
+                    <Route
+                        path="/activity"
+                        element={
+                            <ProtectedRoute>
+                                <Activity />
+                            </ProtectedRoute>
+                        }
+                    />
                     <Route
                         path="/certificates/:hostname"
                         element={
@@ -0,0 +1,309 @@
+import { useEffect, useState } from "react";
+import { toast } from "sonner";
+import {
+    Dialog,
+    DialogContent,
+    DialogDescription,
+    DialogFooter,
+    DialogHeader,
+    DialogTitle,
+} from "@/components/ui/dialog";
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { Textarea } from "@/components/ui/textarea";
+import { StatusAlert } from "@/components/shared/StatusAlert";
+import { HugeiconsIcon } from "@hugeicons/react";
+import {
+    AlertCircleIcon,
+    Cancel01Icon,
+    CheckmarkCircle02Icon,
+} from "@hugeicons/core-free-icons";
+import { api } from "@/lib/api";
+import type { BulkCSRRequest, BulkCSRResult } from "@/types";
+
+// Key types offered, as "<algorithm>-<size>"
+const KEY_TYPES = [
+    { value: "rsa-2048", label: "RSA 2048 bits" },
+    { value: "rsa-3072", label: "RSA 3072 bits" },
+    { value: "rsa-4096", label: "RSA 4096 bits (Recommended)" },
+    { value: "ecdsa-256", label: "ECDSA P-256" },
+    { value: "ecdsa-384", label: "ECDSA P-384" },
+];
+
+interface BulkCSRDialogProps {
+    open: boolean;
+    onOpenChange: (open: boolean) => void;
+    onGenerated: () => void;
+}
+
+// Generates CSRs for a pasted list of hostnames (or a CSV with SANs), all
+// with the same subject and key type. Nothing is stored if any host fails.
+export function BulkCSRDialog({
+    open,
+    onOpenChange,
+    onGenerated,
+}: BulkCSRDialogProps) {
+    const [list, setList] = useState("");
+    const [organization, setOrganization] = useState("");
+    const [organizationalUnit, setOrganizationalUnit] = useState("");
+    const [city, setCity] = useState("");
+    const [state, setState] = useState("");
+    const [country, setCountry] = useState("");
+    const [keyType, setKeyType] = useState("rsa-4096");
+    const [note, setNote] = useState("");
+    const [result, setResult] = useState<BulkCSRResult | null>(null);
+    const [error, setError] = useState<string | null>(null);
+    const [isLoading, setIsLoading] = useState(false);
+
+    // The subject defaults to the configured one
+    useEffect(() => {
+        if (!open) return;
+        api.getConfig()
+            .then((config) => {
+                setOrganization(config.default_organization);
+                setOrganizationalUnit(config.default_organizational_unit ?? "");
+                setCity(config.default_city);
+                setState(config.default_state);
+                setCountry(config.default_country);
+                setKeyType(`rsa-${config.default_key_size || 4096}`);
+            })
+            .catch(() => {});
+    }, [open]);
+
+    const handleClose = (nextOpen: boolean) => {
+        if (!nextOpen) {
+            setList("");
+            setNote("");
+            setResult(null);
+            setError(null);
+        }
+        onOpenChange(nextOpen);
+    };
+
+    const handleGenerate = async () => {
+        const [keyAlgorithm, keySize] = keyType.split("-");
+        setIsLoading(true);
+        setError(null);
+        setResult(null);
+        try {
+            const generated = await api.generateCSRBulk({
+                list,
+                template: {
+                    hostname: "",
+                    organization,
+                    organizational_unit: organizationalUnit,
+                    city,
+                    state,
+                    country,
+                    key_algorithm: keyAlgorithm,
+                    key_size: Number(keySize),
+                    note,
+                },
+            } as BulkCSRRequest);
+            setResult(generated);
+            if (generated.generated) {
+                toast.success(`${generated.results.length} CSRs generated`);
+                onGenerated();
+            } else {
+                toast.error("No CSR generated: fix the hostnames below");
+            }
+        } catch (err) {
+            setError(err instanceof Error ? err.message : String(err));
+        } finally {
+            setIsLoading(false);
+        }
+    };
+
+    return (
+        <Dialog open={open} onOpenChange={handleClose}>
+            <DialogContent className="sm:max-w-[600px] max-h-[85vh] overflow-y-auto">
+                <DialogHeader>
+                    <DialogTitle>Bulk CSR Generation</DialogTitle>
+                    <DialogDescription>
+                        One hostname per line, optionally followed by its SANs
+                        (comma-separated, as in a CSV). Nothing is generated if
+                        any hostname is refused.
+                    </DialogDescription>
+                </DialogHeader>
+
+                <div className="space-y-4">
+                    {error && (
+                        <StatusAlert
+                            variant="destructive"
+                            icon={
+                                <HugeiconsIcon
+                                    icon={AlertCircleIcon}
+                                    className="size-4"
+                                    strokeWidth={2}
+                                />
+                            }
+                        >
+                            {error}
+                        </StatusAlert>
+                    )}
+
+                    <div className="space-y-2">
+                        <Label htmlFor="bulk_csr_list">Hostnames</Label>
+                        <Textarea
+                            id="bulk_csr_list"
+                            rows={6}
+                            className="font-mono text-xs"
+                            placeholder={"app1.example.com\napp2.example.com, www.app2.example.com"}
+                            value={list}
+                            onChange={(e) => setList(e.target.value)}
+                            disabled={isLoading}
+                        />
+                    </div>
+
+                    <div className="grid grid-cols-2 gap-3">
+                        <div className="space-y-2">
+                            <Label htmlFor="bulk_csr_org">Organization</Label>
+                            <Input
+                                id="bulk_csr_org"
+                                value={organization}
+                                onChange={(e) => setOrganization(e.target.value)}
+                                disabled={isLoading}
+                            />
+                        </div>
+                        <div className="space-y-2">
+                            <Label htmlFor="bulk_csr_ou">
+                                Organizational Unit
+                            </Label>
+                            <Input
+                                id="bulk_csr_ou"
+                                value={organizationalUnit}
+                                onChange={(e) =>
+                                    setOrganizationalUnit(e.target.value)
+                                }
+                                disabled={isLoading}
+                            />
+                        </div>
+                        <div className="space-y-2">
+                            <Label htmlFor="bulk_csr_city">City</Label>
+                            <Input
+                                id="bulk_csr_city"
+                                value={city}
+                                onChange={(e) => setCity(e.target.value)}
+                                disabled={isLoading}
+                            />
+                        </div>
+                        <div className="space-y-2">
+                            <Label htmlFor="bulk_csr_state">State</Label>
+                            <Input
+                                id="bulk_csr_state"
+                                value={state}
+                                onChange={(e) => setState(e.target.value)}
+                                disabled={isLoading}
+                            />
+                        </div>
+                        <div className="space-y-2">
+                            <Label htmlFor="bulk_csr_country">Country</Label>
+                            <Input
+                                id="bulk_csr_country"
+                                maxLength={2}
+                                value={country}
+                                onChange={(e) =>
+                                    setCountry(e.target.value.toUpperCase())
+                                }
+                                disabled={isLoading}
+                            />
+                        </div>
+                        <div className="space-y-2">
+                            <Label htmlFor="bulk_csr_key">Key</Label>
+                            <Select
+                                value={keyType}
+                                onValueChange={setKeyType}
+                                disabled={isLoading}
+                            >
+                                <SelectTrigger
+                                    id="bulk_csr_key"
+                                    className="w-full"
+                                >
+                                    <SelectValue />
+                                </SelectTrigger>
+                                <SelectContent>
+                                    {KEY_TYPES.map((t) => (
+                                        <SelectItem
+                                            key={t.value}
+                                            value={t.value}
+                                        >
+                                            {t.label}
+                                        </SelectItem>
+                                    ))}
+                                </SelectContent>
+                            </Select>
+                        </div>
+                    </div>
+
+                    <div className="space-y-2">
+                        <Label htmlFor="bulk_csr_note">Note</Label>
+                        <Input
+                            id="bulk_csr_note"
+                            placeholder="Added to every certificate (optional)"
+                            value={note}
+                            onChange={(e) => setNote(e.target.value)}
+                            disabled={isLoading}
+                        />
+                    </div>
+
+                    {result && (
+                        <div className="space-y-1 rounded-md border border-border p-3">
+                            {result.results.map((r) => (
+                                <div
+                                    key={r.hostname}
+                                    className="flex items-start gap-2 text-sm"
+                                >
+                                    <HugeiconsIcon
+                                        icon={
+                                            r.error
+                                                ? Cancel01Icon
+                                                : CheckmarkCircle02Icon
+                                        }
+                                        className={`size-4 mt-0.5 shrink-0 ${r.error ? "text-destructive" : "text-muted-foreground"}`}
+                                        strokeWidth={2}
+                                    />
+                                    <div>
+                                        <p className="font-mono text-xs">
+                                            {r.hostname}
+                                        </p>
+                                        {r.error && (
+                                            <p className="text-xs text-destructive">
+                                                {r.error}
+                                            </p>
+                                        )}
+                                    </div>
+                                </div>
+                            ))}
+                        </div>
+                    )}
+                </div>
+
+                <DialogFooter>
+                    <Button
+                        variant="outline"
+                        onClick={() => handleClose(false)}
+                        disabled={isLoading}
+                    >
+                        {result?.generated ? "Close" : "Cancel"}
+                    </Button>
+                    {!result?.generated && (
+                        <Button
+                            onClick={handleGenerate}
+                            disabled={isLoading || list.trim() === ""}
+                        >
+                            {isLoading ? "Generating..." : "Generate CSRs"}
+                        </Button>
+                    )}
+                </DialogFooter>
+            </DialogContent>
+        </Dialog>
+    );
+}
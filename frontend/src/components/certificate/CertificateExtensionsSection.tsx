@@ -0,0 +1,108 @@
+import {
+    Card,
+    CardContent,
+    CardDescription,
+    CardHeader,
+    CardTitle,
+} from "@/components/ui/card";
+import { Badge } from "@/components/ui/badge";
+import type { CertificateExtensions } from "@/types";
+
+interface CertificateExtensionsSectionProps {
+    extensions: CertificateExtensions;
+}
+
+function Field({ label, value }: { label: string; value?: string }) {
+    if (!value) return null;
+    return (
+        <div>
+            <p className="text-xs font-medium text-muted-foreground uppercase">
+                {label}
+            </p>
+            <p className="text-sm text-foreground font-mono break-all">
+                {value}
+            </p>
+        </div>
+    );
+}
+
+function BadgeList({ label, values }: { label: string; values?: string[] }) {
+    if (!values || values.length === 0) return null;
+    return (
+        <div>
+            <p className="text-xs font-medium text-muted-foreground uppercase mb-2">
+                {label}
+            </p>
+            <div className="flex flex-wrap gap-2">
+                {values.map((value) => (
+                    <Badge key={value} variant="secondary">
+                        {value}
+                    </Badge>
+                ))}
+            </div>
+        </div>
+    );
+}
+
+export function CertificateExtensionsSection({
+    extensions,
+}: CertificateExtensionsSectionProps) {
+    const basicConstraints = extensions.basic_constraints_valid
+        ? `CA: ${extensions.is_ca ? "Yes" : "No"}${
+              extensions.max_path_len >= 0
+                  ? `, Path length: ${extensions.max_path_len}`
+                  : ""
+          }`
+        : undefined;
+
+    return (
+        <Card className="mb-6 shadow-sm border-border">
+            <CardHeader>
+                <CardTitle>Extensions</CardTitle>
+                <CardDescription>
+                    Decoded X.509 extensions and fingerprints
+                </CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-4">
+                <div className="grid grid-cols-2 gap-4">
+                    <Field label="Serial Number" value={extensions.serial_number} />
+                    <Field
+                        label="Signature Algorithm"
+                        value={extensions.signature_algorithm}
+                    />
+                    <Field label="Basic Constraints" value={basicConstraints} />
+                </div>
+
+                <BadgeList label="Key Usage" values={extensions.key_usage} />
+                <BadgeList
+                    label="Extended Key Usage"
+                    values={extensions.ext_key_usage}
+                />
+
+                <Field label="Subject Key ID" value={extensions.subject_key_id} />
+                <Field
+                    label="Authority Key ID"
+                    value={extensions.authority_key_id}
+                />
+                <Field
+                    label="SHA-256 Fingerprint"
+                    value={extensions.fingerprint_sha256}
+                />
+                <Field
+                    label="SHA-1 Fingerprint"
+                    value={extensions.fingerprint_sha1}
+                />
+
+                <BadgeList label="OCSP" values={extensions.ocsp_servers} />
+                <BadgeList
+                    label="CA Issuers"
+                    values={extensions.issuing_certificate_urls}
+                />
+                <BadgeList
+                    label="CRL Distribution Points"
+                    values={extensions.crl_distribution_points}
+                />
+            </CardContent>
+        </Card>
+    );
+}
@@ -22,6 +22,9 @@ import {
     ArrowUp01Icon,
     Clock01Icon,
     Package01Icon,
+    Tick02Icon,
+    Share01Icon,
+    ServerStack01Icon,
 } from "@hugeicons/core-free-icons";
 import { getRelativeTime } from "@/lib/theme";
 import { cn } from "@/lib/utils";
@@ -54,6 +57,14 @@ function getEventIcon(eventType: string) {
             return { icon: LockIcon, color: "text-muted-foreground" };
         case "readonly_disabled":
             return { icon: LockKeyIcon, color: "text-muted-foreground" };
+        case "renewal_step_completed":
+            return { icon: Tick02Icon, color: "text-success" };
+        case "renewal_step_reopened":
+            return { icon: RefreshIcon, color: "text-muted-foreground" };
+        case "csr_submitted":
+            return { icon: Share01Icon, color: "text-info" };
+        case "key_synced_to_agent":
+            return { icon: ServerStack01Icon, color: "text-info" };
         default:
             return { icon: Clock01Icon, color: "text-muted-foreground" };
     }
@@ -157,6 +168,8 @@ export function CertificateHistoryCard({
                                         </p>
                                         <p className="text-xs text-muted-foreground mt-0.5">
                                             {getRelativeTime(entry.created_at)}
+                                            {entry.actor && ` · ${entry.actor}`}
+                                            {entry.app_version && ` · v${entry.app_version}`}
                                         </p>
                                     </div>
                                 </motion.div>
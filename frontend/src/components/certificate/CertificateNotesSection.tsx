@@ -1,3 +1,5 @@
+import type { ReactNode } from "react";
+import { useNavigate } from "react-router-dom";
 import {
     Card,
     CardContent,
@@ -5,17 +7,75 @@ import {
     CardHeader,
     CardTitle,
 } from "@/components/ui/card";
-import type { Certificate } from "@/types";
+import type { Certificate, NoteReference } from "@/types";
+import { BrowserOpenURL } from "../../../wailsjs/runtime/runtime";
 
 interface CertificateNotesSectionProps {
     certificate: Certificate;
 }
 
+// Splits a note around the references extracted from it (longest first, so a
+// ticket ID inside a URL stays part of the link) and renders URLs as external
+// links and ticket IDs as links to the certificates referencing them
+function NoteText({
+    text,
+    references,
+    onTicket,
+}: {
+    text: string;
+    references: NoteReference[];
+    onTicket: (ticket: string) => void;
+}) {
+    const sorted = [...references].sort((a, b) => b.value.length - a.value.length);
+    const parts: ReactNode[] = [];
+    let rest = text;
+    while (rest) {
+        let next: { index: number; ref: NoteReference } | null = null;
+        for (const ref of sorted) {
+            const index = rest.indexOf(ref.value);
+            if (index >= 0 && (next === null || index < next.index)) {
+                next = { index, ref };
+            }
+        }
+        if (!next) {
+            parts.push(rest);
+            break;
+        }
+        const { index, ref } = next;
+        if (index > 0) parts.push(rest.slice(0, index));
+        parts.push(
+            <button
+                key={parts.length}
+                type="button"
+                className="text-primary underline underline-offset-2 hover:no-underline break-all"
+                onClick={() =>
+                    ref.kind === "url" ? BrowserOpenURL(ref.value) : onTicket(ref.value)
+                }
+                title={
+                    ref.kind === "url"
+                        ? "Open in browser"
+                        : `Show certificates referencing ${ref.value}`
+                }
+            >
+                {ref.value}
+            </button>,
+        );
+        rest = rest.slice(index + ref.value.length);
+    }
+    return <p className="text-sm text-muted-foreground whitespace-pre-wrap">{parts}</p>;
+}
+
 export function CertificateNotesSection({ certificate }: CertificateNotesSectionProps) {
+    const navigate = useNavigate();
+
     if (!certificate.note && !certificate.pending_note) {
         return null;
     }
 
+    const references = certificate.note_references ?? [];
+    const showTicket = (ticket: string) =>
+        navigate(`/?reference=${encodeURIComponent(ticket)}`);
+
     return (
         <Card className="mb-6 shadow-sm border-border">
             <CardHeader>
@@ -30,9 +90,11 @@ export function CertificateNotesSection({ certificate }: CertificateNotesSection
                         <p className="text-xs font-medium text-muted-foreground uppercase mb-2">
                             Note
                         </p>
-                        <p className="text-sm text-muted-foreground">
-                            {certificate.note}
-                        </p>
+                        <NoteText
+                            text={certificate.note}
+                            references={references.filter((r) => r.source === "note")}
+                            onTicket={showTicket}
+                        />
                     </div>
                 )}
                 {certificate.pending_note && (
@@ -40,9 +102,11 @@ export function CertificateNotesSection({ certificate }: CertificateNotesSection
                         <p className="text-xs font-medium text-muted-foreground uppercase mb-2">
                             Pending Note
                         </p>
-                        <p className="text-sm text-muted-foreground">
-                            {certificate.pending_note}
-                        </p>
+                        <NoteText
+                            text={certificate.pending_note}
+                            references={references.filter((r) => r.source === "pending_note")}
+                            onTicket={showTicket}
+                        />
                     </div>
                 )}
             </CardContent>
@@ -14,8 +14,11 @@ import {
 import { Badge } from "@/components/ui/badge";
 import { Button } from "@/components/ui/button";
 import { LoadingSpinner } from "@/components/shared/LoadingSpinner";
-import { ChainCertificateInfo } from "@/types";
+import { ChainOverrideForm } from "@/components/certificate/ChainOverrideForm";
+import { ChainCertificateInfo, ChainFetchDiagnostics } from "@/types";
+import { api } from "@/lib/api";
 import { formatDateTime } from "@/lib/theme";
+import { toast } from "sonner";
 import { useCopyToClipboard } from "@/hooks/useCopyToClipboard";
 import { HugeiconsIcon } from "@hugeicons/react";
 import {
@@ -31,6 +34,119 @@ interface CertificatePathProps {
     chain: ChainCertificateInfo[];
     isLoading: boolean;
     error: string | null;
+    diagnostics?: ChainFetchDiagnostics | null;
+    hostname?: string;
+    onOverrideSaved?: () => void;
+}
+
+// What most likely broke an AIA chain fetch (ChainFetchDiagnostics.cause)
+const causeLabels: Record<string, string> = {
+    no_network: "No network",
+    ca_unavailable: "CA endpoint unavailable",
+    bad_url: "Bad issuer URL in certificate",
+    unknown: "Chain fetch failed",
+};
+
+function ChainDiagnosticsNotice({
+    diagnostics,
+}: {
+    diagnostics: ChainFetchDiagnostics;
+}) {
+    return (
+        <div className="mb-4 border border-warning/40 bg-warning/10 p-3 space-y-2">
+            <div className="flex items-center gap-2 text-warning">
+                <HugeiconsIcon
+                    icon={Alert02Icon}
+                    className="w-4 h-4"
+                    strokeWidth={2}
+                />
+                <p className="text-sm font-medium">
+                    Incomplete chain:{" "}
+                    {causeLabels[diagnostics.cause] ?? diagnostics.cause}
+                </p>
+            </div>
+            <p className="text-sm text-foreground">{diagnostics.summary}</p>
+            {diagnostics.attempts?.length > 0 && (
+                <ul className="space-y-1 text-xs">
+                    {diagnostics.attempts.map((attempt, index) => (
+                        <li
+                            key={`${attempt.url}-${index}`}
+                            className="flex flex-wrap gap-x-2"
+                        >
+                            <span className="font-mono break-all">
+                                {attempt.url}
+                            </span>
+                            <span className="text-muted-foreground">
+                                {attempt.outcome.replace(/_/g, " ")}
+                                {attempt.status_code
+                                    ? ` (HTTP ${attempt.status_code})`
+                                    : ""}
+                                {attempt.proxy ? ` via ${attempt.proxy}` : ""}
+                                {attempt.duration_ms
+                                    ? `, ${attempt.duration_ms} ms`
+                                    : ""}
+                            </span>
+                            {attempt.error && (
+                                <span className="w-full text-muted-foreground break-all">
+                                    {attempt.error}
+                                </span>
+                            )}
+                        </li>
+                    ))}
+                </ul>
+            )}
+        </div>
+    );
+}
+
+// Chain file variants offered for download (models.ChainVariant*)
+const chainVariants = [
+    { variant: "leaf", label: "Leaf only" },
+    { variant: "fullchain", label: "Full chain (no root)" },
+    { variant: "full", label: "Full chain + root" },
+    { variant: "root", label: "Root only" },
+] as const;
+
+function ChainDownloadButtons({
+    hostname,
+    hasRoot,
+}: {
+    hostname: string;
+    hasRoot: boolean;
+}) {
+    const [saving, setSaving] = useState(false);
+
+    const handleSave = async (variant: string) => {
+        setSaving(true);
+        try {
+            await api.saveChainToFile(hostname, variant);
+        } catch (err) {
+            toast.error(
+                err instanceof Error ? err.message : "Failed to save chain",
+            );
+        } finally {
+            setSaving(false);
+        }
+    };
+
+    return (
+        <div className="flex flex-wrap items-center gap-2">
+            <span className="text-sm text-muted-foreground mr-1">
+                Download:
+            </span>
+            {chainVariants.map(({ variant, label }) => (
+                <Button
+                    key={variant}
+                    size="sm"
+                    variant="outline"
+                    onClick={() => handleSave(variant)}
+                    disabled={saving || (variant === "root" && !hasRoot)}
+                >
+                    {label}
+                </Button>
+            ))}
+        </div>
+    );
 }
 
 // Color and label mappings for certificate types
@@ -159,6 +275,9 @@ export function CertificatePath({
     chain,
     isLoading,
     error,
+    diagnostics,
+    hostname,
+    onOverrideSaved,
 }: CertificatePathProps) {
     const { copy, isCopied } = useCopyToClipboard();
     const [isOpen, setIsOpen] = useState(false);
@@ -250,6 +369,11 @@ export function CertificatePath({
                         </CollapsibleTrigger>
                     </div>
                 </CardHeader>
+                {diagnostics && (
+                    <CardContent className="pb-0">
+                        <ChainDiagnosticsNotice diagnostics={diagnostics} />
+                    </CardContent>
+                )}
                 <CollapsibleContent>
                     <CardContent>
                         <div className="space-y-3">
@@ -261,6 +385,21 @@ export function CertificatePath({
                                     isCopied={isCopied}
                                 />
                             ))}
+                            {hostname && (
+                                <ChainDownloadButtons
+                                    hostname={hostname}
+                                    hasRoot={chain.some(
+                                        (cert) => cert.cert_type === "root",
+                                    )}
+                                />
+                            )}
+                            {hostname && onOverrideSaved && (
+                                <ChainOverrideForm
+                                    hostname={hostname}
+                                    issuerDN={chain[chain.length - 1].issuer_dn}
+                                    onSaved={onOverrideSaved}
+                                />
+                            )}
                         </div>
                     </CardContent>
                 </CollapsibleContent>
@@ -0,0 +1,190 @@
+import { useCallback, useEffect, useMemo, useState } from "react";
+import { Link } from "react-router-dom";
+import { toast } from "sonner";
+import {
+    Card,
+    CardContent,
+    CardDescription,
+    CardHeader,
+    CardTitle,
+} from "@/components/ui/card";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
+import { StatusBadge } from "@/components/certificate/StatusBadge";
+import { api } from "@/lib/api";
+import type { CertificateGraph, CertificateListItem } from "@/types";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { Cancel01Icon } from "@hugeicons/core-free-icons";
+
+// How a relation reads from the certificate shown, per type and direction
+function describeRelation(type: string, outgoing: boolean) {
+    if (type === "shared_endpoint") return "Shares an endpoint with";
+    return outgoing ? "Client of" : "Used by client";
+}
+
+interface CertificateRelationsCardProps {
+    hostname: string;
+}
+
+// Certificates this one depends on or that depend on it, so a renewal can be
+// checked against every related endpoint
+export function CertificateRelationsCard({ hostname }: CertificateRelationsCardProps) {
+    const [graph, setGraph] = useState<CertificateGraph | null>(null);
+    const [certificates, setCertificates] = useState<CertificateListItem[]>([]);
+    const [target, setTarget] = useState("");
+    const [relationType, setRelationType] = useState("client_of");
+    const [label, setLabel] = useState("");
+    const [isSaving, setIsSaving] = useState(false);
+
+    const load = useCallback(async () => {
+        try {
+            setGraph(await api.getCertificateGraph());
+        } catch (err) {
+            toast.error(
+                err instanceof Error ? err.message : "Failed to load related certificates",
+            );
+        }
+    }, []);
+
+    useEffect(() => {
+        load();
+        api.listCertificates({ sort_by: "hostname", sort_order: "asc" })
+            .then((certs) => setCertificates(certs || []))
+            .catch(() => setCertificates([]));
+    }, [load]);
+
+    const relations = useMemo(
+        () =>
+            (graph?.edges || []).filter(
+                (edge) => edge.source === hostname || edge.target === hostname,
+            ),
+        [graph, hostname],
+    );
+    const nodes = useMemo(
+        () => new Map((graph?.nodes || []).map((node) => [node.hostname, node])),
+        [graph],
+    );
+
+    const add = async () => {
+        setIsSaving(true);
+        try {
+            await api.addCertificateRelation(hostname, target, relationType, label.trim());
+            toast.success("Relation added");
+            setTarget("");
+            setLabel("");
+            await load();
+        } catch (err) {
+            toast.error(err instanceof Error ? err.message : "Failed to add relation");
+        } finally {
+            setIsSaving(false);
+        }
+    };
+
+    const remove = async (id: number) => {
+        setIsSaving(true);
+        try {
+            await api.deleteCertificateRelation(id);
+            await load();
+        } catch (err) {
+            toast.error(err instanceof Error ? err.message : "Failed to remove relation");
+        } finally {
+            setIsSaving(false);
+        }
+    };
+
+    if (!graph) return null;
+
+    return (
+        <Card className="shadow-sm border-border mb-6">
+            <CardHeader>
+                <CardTitle>Related Certificates</CardTitle>
+                <CardDescription>
+                    Endpoints to check when this certificate is renewed
+                </CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-3">
+                {relations.length === 0 && (
+                    <p className="text-sm text-muted-foreground">No related certificates</p>
+                )}
+                {relations.map((relation) => {
+                    const outgoing = relation.source === hostname;
+                    const other = outgoing ? relation.target : relation.source;
+                    const node = nodes.get(other);
+                    return (
+                        <div key={relation.id} className="flex items-center justify-between gap-3">
+                            <div className="flex items-center gap-2 min-w-0 text-sm">
+                                <span className="text-muted-foreground shrink-0">
+                                    {describeRelation(relation.type, outgoing)}
+                                </span>
+                                <Link
+                                    to={`/certificates/${encodeURIComponent(other)}`}
+                                    className="font-medium truncate hover:underline"
+                                >
+                                    {node?.display_hostname || other}
+                                </Link>
+                                {node && <StatusBadge status={node.status} />}
+                                {relation.label && (
+                                    <span className="text-xs text-muted-foreground truncate">
+                                        ({relation.label})
+                                    </span>
+                                )}
+                            </div>
+                            <Button
+                                variant="ghost"
+                                size="icon-sm"
+                                onClick={() => remove(relation.id)}
+                                disabled={isSaving}
+                                aria-label="Remove relation"
+                            >
+                                <HugeiconsIcon icon={Cancel01Icon} className="size-4" strokeWidth={2} />
+                            </Button>
+                        </div>
+                    );
+                })}
+                <div className="flex flex-wrap items-center gap-2 border-t border-border pt-3">
+                    <Select value={relationType} onValueChange={setRelationType} disabled={isSaving}>
+                        <SelectTrigger className="w-44">
+                            <SelectValue />
+                        </SelectTrigger>
+                        <SelectContent>
+                            <SelectItem value="client_of">Client of</SelectItem>
+                            <SelectItem value="shared_endpoint">Shares an endpoint with</SelectItem>
+                        </SelectContent>
+                    </Select>
+                    <Select value={target} onValueChange={setTarget} disabled={isSaving}>
+                        <SelectTrigger className="w-56">
+                            <SelectValue placeholder="Select a certificate" />
+                        </SelectTrigger>
+                        <SelectContent>
+                            {certificates
+                                .filter((cert) => cert.hostname !== hostname)
+                                .map((cert) => (
+                                    <SelectItem key={cert.hostname} value={cert.hostname}>
+                                        {cert.display_hostname || cert.hostname}
+                                    </SelectItem>
+                                ))}
+                        </SelectContent>
+                    </Select>
+                    <Input
+                        value={label}
+                        onChange={(e) => setLabel(e.target.value)}
+                        placeholder={relationType === "shared_endpoint" ? "Endpoint, e.g. lb-prod-1" : "Label (optional)"}
+                        className="w-48"
+                        maxLength={128}
+                        disabled={isSaving}
+                    />
+                    <Button size="sm" onClick={add} disabled={isSaving || !target}>
+                        Add
+                    </Button>
+                </div>
+            </CardContent>
+        </Card>
+    );
+}
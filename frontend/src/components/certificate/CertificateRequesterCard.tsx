@@ -0,0 +1,50 @@
+import {
+    Card,
+    CardContent,
+    CardDescription,
+    CardHeader,
+    CardTitle,
+} from "@/components/ui/card";
+import { formatDateTime } from "@/lib/theme";
+import type { CertificateRequester } from "@/types";
+
+interface CertificateRequesterCardProps {
+    requester: CertificateRequester;
+}
+
+// Who asked for the certificate, recorded from the request file it was
+// generated from.
+export function CertificateRequesterCard({
+    requester,
+}: CertificateRequesterCardProps) {
+    return (
+        <Card className="shadow-sm border-border mb-6">
+            <CardHeader>
+                <CardTitle>Requested By</CardTitle>
+                <CardDescription>
+                    Recorded from a request file on{" "}
+                    {formatDateTime(requester.recorded_at)}
+                </CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-2 text-sm">
+                <p>
+                    <span className="font-medium">{requester.name}</span>
+                    {requester.team && (
+                        <span className="text-muted-foreground">
+                            {" "}
+                            ({requester.team})
+                        </span>
+                    )}
+                </p>
+                {requester.email && (
+                    <p className="text-muted-foreground">{requester.email}</p>
+                )}
+                {requester.justification && (
+                    <p className="whitespace-pre-wrap">
+                        {requester.justification}
+                    </p>
+                )}
+            </CardContent>
+        </Card>
+    );
+}
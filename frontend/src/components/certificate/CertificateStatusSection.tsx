@@ -5,7 +5,7 @@ import {
     CardHeader,
     CardTitle,
 } from "@/components/ui/card";
-import { formatDateTime } from "@/lib/theme";
+import { formatDateTime, formatKeySize } from "@/lib/theme";
 import type { Certificate } from "@/types";
 
 interface CertificateStatusSectionProps {
@@ -15,7 +15,8 @@ interface CertificateStatusSectionProps {
 
 export function CertificateStatusSection({ certificate, variant = "active" }: CertificateStatusSectionProps) {
     const isPending = variant === "pending";
-    const keySize = isPending ? certificate.pending_key_size : certificate.key_size;
+    const subject = isPending ? certificate.pending : certificate.active;
+    const keySize = subject?.key_size;
 
     return (
         <Card className="mb-6 shadow-sm border-border">
@@ -43,6 +44,11 @@ export function CertificateStatusSection({ certificate, variant = "active" }: Ce
                             <p className="text-sm font-semibold text-foreground">
                                 {formatDateTime(certificate.expires_at)}
                             </p>
+                            {certificate.expires_at_utc && (
+                                <p className="text-xs text-muted-foreground">
+                                    {certificate.expires_at_utc}
+                                </p>
+                            )}
                         </div>
                     )}
                     {keySize && (
@@ -51,7 +57,7 @@ export function CertificateStatusSection({ certificate, variant = "active" }: Ce
                                 Key Size
                             </p>
                             <p className="text-sm font-semibold text-foreground">
-                                {keySize} bits
+                                {formatKeySize(keySize, subject?.key_algorithm)}
                             </p>
                         </div>
                     )}
@@ -15,12 +15,13 @@ interface CertificateSubjectInfoProps {
 
 export function CertificateSubjectInfo({ certificate, variant = "active" }: CertificateSubjectInfoProps) {
     const isPending = variant === "pending";
-    const organization = isPending ? certificate.pending_organization : certificate.organization;
-    const organizationalUnit = isPending ? certificate.pending_organizational_unit : certificate.organizational_unit;
-    const city = isPending ? certificate.pending_city : certificate.city;
-    const state = isPending ? certificate.pending_state : certificate.state;
-    const country = isPending ? certificate.pending_country : certificate.country;
-    const sans = isPending ? certificate.pending_sans : certificate.sans;
+    const subject = isPending ? certificate.pending : certificate.active;
+    const organization = subject?.organization;
+    const organizationalUnit = subject?.organizational_unit;
+    const city = subject?.city;
+    const state = subject?.state;
+    const country = subject?.country;
+    const sans = subject?.sans;
 
     return (
         <Card className="mb-6 shadow-sm border-border">
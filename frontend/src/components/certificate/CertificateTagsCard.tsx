@@ -0,0 +1,119 @@
+import { useCallback, useEffect, useState } from "react";
+import { toast } from "sonner";
+import {
+    Card,
+    CardContent,
+    CardDescription,
+    CardHeader,
+    CardTitle,
+} from "@/components/ui/card";
+import { Badge } from "@/components/ui/badge";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { api } from "@/lib/api";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { Cancel01Icon } from "@hugeicons/core-free-icons";
+
+interface CertificateTagsCardProps {
+    hostname: string;
+}
+
+// Free-form labels such as "production" or "team-infra", used to filter the
+// certificate list
+export function CertificateTagsCard({ hostname }: CertificateTagsCardProps) {
+    const [tags, setTags] = useState<string[] | null>(null);
+    const [tag, setTag] = useState("");
+    const [isSaving, setIsSaving] = useState(false);
+
+    const load = useCallback(async () => {
+        try {
+            setTags((await api.listCertificateTags(hostname)) || []);
+        } catch (err) {
+            toast.error(err instanceof Error ? err.message : "Failed to load tags");
+        }
+    }, [hostname]);
+
+    useEffect(() => {
+        load();
+    }, [load]);
+
+    const add = async () => {
+        setIsSaving(true);
+        try {
+            await api.addCertificateTag(hostname, tag.trim());
+            setTag("");
+            await load();
+        } catch (err) {
+            toast.error(err instanceof Error ? err.message : "Failed to add tag");
+        } finally {
+            setIsSaving(false);
+        }
+    };
+
+    const remove = async (value: string) => {
+        setIsSaving(true);
+        try {
+            await api.removeCertificateTag(hostname, value);
+            await load();
+        } catch (err) {
+            toast.error(err instanceof Error ? err.message : "Failed to remove tag");
+        } finally {
+            setIsSaving(false);
+        }
+    };
+
+    if (!tags) return null;
+
+    return (
+        <Card className="shadow-sm border-border mb-6">
+            <CardHeader>
+                <CardTitle>Tags</CardTitle>
+                <CardDescription>
+                    Filter the certificate list by tag from the dashboard
+                </CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-3">
+                {tags.length === 0 && (
+                    <p className="text-sm text-muted-foreground">No tags</p>
+                )}
+                {tags.length > 0 && (
+                    <div className="flex flex-wrap gap-2">
+                        {tags.map((value) => (
+                            <Badge key={value} variant="secondary" className="gap-1 pr-1">
+                                {value}
+                                <Button
+                                    variant="ghost"
+                                    size="icon-xs"
+                                    onClick={() => remove(value)}
+                                    disabled={isSaving}
+                                    aria-label={`Remove tag ${value}`}
+                                >
+                                    <HugeiconsIcon icon={Cancel01Icon} className="size-3" strokeWidth={2} />
+                                </Button>
+                            </Badge>
+                        ))}
+                    </div>
+                )}
+                <form
+                    className="flex items-center gap-2 border-t border-border pt-3"
+                    onSubmit={(e) => {
+                        e.preventDefault();
+                        if (tag.trim()) add();
+                    }}
+                >
+                    <Input
+                        value={tag}
+                        onChange={(e) => setTag(e.target.value)}
+                        placeholder="Tag, e.g. production"
+                        className="w-56"
+                        maxLength={64}
+                        disabled={isSaving}
+                    />
+                    <Button type="submit" size="sm" disabled={isSaving || !tag.trim()}>
+                        Add
+                    </Button>
+                </form>
+            </CardContent>
+        </Card>
+    );
+}
@@ -0,0 +1,129 @@
+import { useCallback, useEffect, useState } from "react";
+import { toast } from "sonner";
+import { Button } from "@/components/ui/button";
+import { Checkbox } from "@/components/ui/checkbox";
+import { Label } from "@/components/ui/label";
+import { Textarea } from "@/components/ui/textarea";
+import { api } from "@/lib/api";
+import { ChainOverride } from "@/types";
+
+interface ChainOverrideFormProps {
+    hostname: string;
+    // Issuer DN of the last certificate found: the CA a CA-wide override applies to
+    issuerDN: string;
+    onSaved: () => void;
+}
+
+function overrideValue(override?: ChainOverride) {
+    return override?.issuer_url || override?.issuer_pem || "";
+}
+
+// Issuer URL or pasted issuer certificate used instead of the AIA URL of the
+// certificate, or of every certificate issued by the same CA
+export function ChainOverrideForm({
+    hostname,
+    issuerDN,
+    onSaved,
+}: ChainOverrideFormProps) {
+    const [overrides, setOverrides] = useState<ChainOverride[]>([]);
+    const [value, setValue] = useState("");
+    const [forIssuer, setForIssuer] = useState(false);
+    const [isSaving, setIsSaving] = useState(false);
+
+    const certificateOverride = overrides.find((o) => o.hostname === hostname);
+    const issuerOverride = overrides.find((o) => o.issuer_dn === issuerDN);
+    const current = forIssuer ? issuerOverride : certificateOverride;
+
+    const load = useCallback(async () => {
+        try {
+            setOverrides((await api.listChainOverrides()) || []);
+        } catch (err) {
+            toast.error(
+                err instanceof Error ? err.message : "Failed to load chain overrides",
+            );
+        }
+    }, []);
+
+    useEffect(() => {
+        load();
+    }, [load]);
+
+    useEffect(() => {
+        setValue(overrideValue(current));
+    }, [current]);
+
+    const save = async (override: string) => {
+        setIsSaving(true);
+        try {
+            if (forIssuer) {
+                await api.setIssuerChainOverride(issuerDN, override);
+            } else {
+                await api.setCertificateChainOverride(hostname, override);
+            }
+            toast.success(override ? "Chain override saved" : "Chain override removed");
+            await load();
+            onSaved();
+        } catch (err) {
+            toast.error(
+                err instanceof Error ? err.message : "Failed to save chain override",
+            );
+        } finally {
+            setIsSaving(false);
+        }
+    };
+
+    return (
+        <div className="space-y-2 border border-border p-3">
+            <Label htmlFor="chain-override" className="text-sm">
+                Issuer override
+            </Label>
+            <p className="text-xs text-muted-foreground">
+                Issuer certificate URL, or the PEM of the issuer certificate,
+                used instead of the URL embedded in the certificate.
+            </p>
+            <Textarea
+                id="chain-override"
+                value={value}
+                onChange={(e) => setValue(e.target.value)}
+                placeholder="http://pki.example.com/issuing-ca.crt or -----BEGIN CERTIFICATE-----"
+                className="min-h-[60px] resize-y font-mono text-xs"
+                disabled={isSaving}
+            />
+            {issuerDN && (
+                <div className="flex items-center gap-3">
+                    <Checkbox
+                        id="chain-override-issuer"
+                        checked={forIssuer}
+                        onCheckedChange={(val) => setForIssuer(val === true)}
+                        disabled={isSaving}
+                    />
+                    <Label
+                        htmlFor="chain-override-issuer"
+                        className="text-xs cursor-pointer font-normal break-all"
+                    >
+                        Apply to every certificate issued by {issuerDN}
+                    </Label>
+                </div>
+            )}
+            <div className="flex justify-end gap-2">
+                {current && (
+                    <Button
+                        variant="outline"
+                        size="sm"
+                        onClick={() => save("")}
+                        disabled={isSaving}
+                    >
+                        Remove
+                    </Button>
+                )}
+                <Button
+                    size="sm"
+                    onClick={() => save(value.trim())}
+                    disabled={isSaving || !value.trim() || value === overrideValue(current)}
+                >
+                    {isSaving ? "Saving..." : "Save"}
+                </Button>
+            </div>
+        </div>
+    );
+}
@@ -0,0 +1,92 @@
+import { useState } from "react";
+import {
+    Card,
+    CardContent,
+    CardDescription,
+    CardHeader,
+    CardTitle,
+} from "@/components/ui/card";
+import { Button } from "@/components/ui/button";
+import { Badge } from "@/components/ui/badge";
+import { api } from "@/lib/api";
+import type { ChainTrustResult } from "@/types";
+
+interface ChainTrustSectionProps {
+    hostname: string;
+}
+
+const CHAIN_SOURCE_LABELS: Record<string, string> = {
+    stored: "chain stored at upload",
+    aia: "chain fetched via AIA",
+    leaf_only: "no chain available, leaf only",
+};
+
+export function ChainTrustSection({ hostname }: ChainTrustSectionProps) {
+    const [result, setResult] = useState<ChainTrustResult | null>(null);
+    const [isLoading, setIsLoading] = useState(false);
+    const [error, setError] = useState<string | null>(null);
+
+    const evaluate = async () => {
+        setIsLoading(true);
+        setError(null);
+        try {
+            setResult(await api.evaluateChainTrust(hostname));
+        } catch (err) {
+            setError(
+                err instanceof Error ? err.message : "Failed to evaluate chain trust",
+            );
+        } finally {
+            setIsLoading(false);
+        }
+    };
+
+    return (
+        <Card className="mb-6 shadow-sm border-border">
+            <CardHeader>
+                <div className="flex items-center justify-between gap-4">
+                    <div>
+                        <CardTitle>Chain Trust</CardTitle>
+                        <CardDescription>
+                            {result
+                                ? `Evaluated using the ${CHAIN_SOURCE_LABELS[result.chain_source] ?? result.chain_source}`
+                                : "Check which trust stores accept the delivered chain"}
+                        </CardDescription>
+                    </div>
+                    <Button
+                        variant="outline"
+                        size="sm"
+                        onClick={evaluate}
+                        disabled={isLoading}
+                    >
+                        {isLoading ? "Checking..." : result ? "Re-check" : "Check Trust"}
+                    </Button>
+                </div>
+            </CardHeader>
+            {(result || error) && (
+                <CardContent className="space-y-3">
+                    {error && <p className="text-sm text-destructive">{error}</p>}
+                    {result?.stores.map((store) => (
+                        <div key={store.store} className="flex items-start gap-3">
+                            <Badge
+                                variant={store.trusted ? "secondary" : "destructive"}
+                                className="shrink-0"
+                            >
+                                {store.trusted ? "Trusted" : "Not trusted"}
+                            </Badge>
+                            <div className="min-w-0">
+                                <p className="text-sm text-foreground">
+                                    {store.description}
+                                </p>
+                                {store.error && (
+                                    <p className="text-xs text-muted-foreground break-words">
+                                        {store.error}
+                                    </p>
+                                )}
+                            </div>
+                        </div>
+                    ))}
+                </CardContent>
+            )}
+        </Card>
+    );
+}
@@ -0,0 +1,201 @@
+import { useCallback, useEffect, useState } from "react";
+import { toast } from "sonner";
+import {
+    Card,
+    CardContent,
+    CardDescription,
+    CardHeader,
+    CardTitle,
+} from "@/components/ui/card";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { api } from "@/lib/api";
+import { formatDateTime } from "@/lib/theme";
+import type { DeploymentTarget, EndpointProbe } from "@/types";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { Cancel01Icon } from "@hugeicons/core-free-icons";
+
+interface DeploymentTargetsCardProps {
+    hostname: string;
+}
+
+// Where this certificate is deployed. While any target is listed, the
+// certificate cannot be deleted.
+export function DeploymentTargetsCard({ hostname }: DeploymentTargetsCardProps) {
+    const [targets, setTargets] = useState<DeploymentTarget[] | null>(null);
+    const [name, setName] = useState("");
+    const [location, setLocation] = useState("");
+    const [isSaving, setIsSaving] = useState(false);
+    const [probeHost, setProbeHost] = useState("");
+    const [port, setPort] = useState("443");
+    const [probe, setProbe] = useState<EndpointProbe | null>(null);
+    const [isProbing, setIsProbing] = useState(false);
+
+    const load = useCallback(async () => {
+        try {
+            setTargets((await api.listDeploymentTargets(hostname)) || []);
+        } catch (err) {
+            toast.error(
+                err instanceof Error ? err.message : "Failed to load deployment targets",
+            );
+        }
+    }, [hostname]);
+
+    useEffect(() => {
+        load();
+    }, [load]);
+
+    const add = async () => {
+        setIsSaving(true);
+        try {
+            await api.addDeploymentTarget(hostname, name.trim(), location.trim());
+            toast.success("Deployment target added");
+            setName("");
+            setLocation("");
+            await load();
+        } catch (err) {
+            toast.error(err instanceof Error ? err.message : "Failed to add deployment target");
+        } finally {
+            setIsSaving(false);
+        }
+    };
+
+    const remove = async (id: number) => {
+        setIsSaving(true);
+        try {
+            await api.removeDeploymentTarget(id);
+            await load();
+        } catch (err) {
+            toast.error(err instanceof Error ? err.message : "Failed to remove deployment target");
+        } finally {
+            setIsSaving(false);
+        }
+    };
+
+    // Connects to the live server (this hostname unless another host is given)
+    // and compares what it presents with the stored certificate
+    const runProbe = async () => {
+        setIsProbing(true);
+        setProbe(null);
+        try {
+            setProbe(await api.probeEndpoint(hostname, probeHost.trim(), Number(port) || 0));
+        } catch (err) {
+            toast.error(err instanceof Error ? err.message : typeof err === "string" ? err : "Failed to probe endpoint");
+        } finally {
+            setIsProbing(false);
+        }
+    };
+
+    if (!targets) return null;
+
+    const trustedBy = probe?.trust.stores.filter((s) => s.trusted).map((s) => s.store) ?? [];
+
+    return (
+        <Card className="shadow-sm border-border mb-6">
+            <CardHeader>
+                <CardTitle>Deployed On</CardTitle>
+                <CardDescription>
+                    A deployed certificate cannot be deleted until its targets are removed
+                </CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-3">
+                {targets.length === 0 && (
+                    <p className="text-sm text-muted-foreground">Not deployed anywhere</p>
+                )}
+                {targets.map((target) => (
+                    <div key={target.id} className="flex items-center justify-between gap-3">
+                        <div className="flex items-center gap-2 min-w-0 text-sm">
+                            <span className="font-medium truncate">{target.name}</span>
+                            {target.location && (
+                                <span className="font-mono text-xs text-muted-foreground truncate">
+                                    {target.location}
+                                </span>
+                            )}
+                            <span className="text-xs text-muted-foreground shrink-0">
+                                since {formatDateTime(target.deployed_at)}
+                            </span>
+                        </div>
+                        <Button
+                            variant="ghost"
+                            size="icon-sm"
+                            onClick={() => remove(target.id)}
+                            disabled={isSaving}
+                            aria-label="Remove deployment target"
+                        >
+                            <HugeiconsIcon icon={Cancel01Icon} className="size-4" strokeWidth={2} />
+                        </Button>
+                    </div>
+                ))}
+                <div className="flex flex-wrap items-center gap-2 border-t border-border pt-3">
+                    <Input
+                        value={name}
+                        onChange={(e) => setName(e.target.value)}
+                        placeholder="Target, e.g. web01"
+                        className="w-48"
+                        maxLength={128}
+                        disabled={isSaving}
+                    />
+                    <Input
+                        value={location}
+                        onChange={(e) => setLocation(e.target.value)}
+                        placeholder="Location (optional)"
+                        className="w-56"
+                        maxLength={512}
+                        disabled={isSaving}
+                    />
+                    <Button size="sm" onClick={add} disabled={isSaving || !name.trim()}>
+                        Add
+                    </Button>
+                </div>
+                <div className="space-y-2 border-t border-border pt-3">
+                    <div className="flex flex-wrap items-center gap-2">
+                        <span className="text-sm">Check the live server</span>
+                        <Input
+                            value={probeHost}
+                            onChange={(e) => setProbeHost(e.target.value)}
+                            placeholder={hostname.startsWith("*.") ? "Host to connect to" : hostname}
+                            className="w-56"
+                            maxLength={253}
+                            disabled={isProbing}
+                        />
+                        <span className="text-sm">on port</span>
+                        <Input
+                            value={port}
+                            onChange={(e) => setPort(e.target.value.replace(/\D/g, ""))}
+                            className="w-20"
+                            maxLength={5}
+                            inputMode="numeric"
+                            disabled={isProbing}
+                        />
+                        <Button size="sm" variant="outline" onClick={runProbe} disabled={isProbing}>
+                            {isProbing ? "Checking..." : "Check"}
+                        </Button>
+                    </div>
+                    {probe && (
+                        <div className="space-y-1 text-sm">
+                            <p className={probe.matches ? "text-success" : "text-destructive"}>
+                                {probe.matches
+                                    ? `${probe.address} serves this certificate`
+                                    : `${probe.address} serves a different certificate`}
+                            </p>
+                            {probe.mismatches.map((mismatch) => (
+                                <p key={mismatch} className="font-mono text-xs text-muted-foreground break-all">
+                                    {mismatch}
+                                </p>
+                            ))}
+                            <p className="text-xs text-muted-foreground">
+                                {probe.tls_version}, {probe.presented.length} certificate
+                                {probe.presented.length === 1 ? "" : "s"} presented
+                                {trustedBy.length > 0
+                                    ? `, trusted by ${trustedBy.join(", ")}`
+                                    : ", not trusted by any trust store"}
+                                {" · checked "}
+                                {formatDateTime(probe.probed_at)}
+                            </p>
+                        </div>
+                    )}
+                </div>
+            </CardContent>
+        </Card>
+    );
+}
@@ -10,6 +10,13 @@ import {
 import { Button } from "@/components/ui/button";
 import { Checkbox } from "@/components/ui/checkbox";
 import { Label } from "@/components/ui/label";
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
 import { StatusAlert } from "@/components/shared/StatusAlert";
 import { HugeiconsIcon } from "@hugeicons/react";
 import { AlertCircleIcon } from "@hugeicons/core-free-icons";
@@ -23,6 +30,14 @@ interface ExportDialogProps {
     isUnlocked: boolean;
 }
 
+// Chain file variants for the ZIP (models.ChainVariant*); the leaf is a
+// separate item
+const CHAIN_VARIANTS = [
+    { value: "full", label: "Leaf → Interm. → Root", suffix: "-chain.crt" },
+    { value: "fullchain", label: "Leaf → Interm. (no root)", suffix: "-fullchain.pem" },
+    { value: "root", label: "Root only", suffix: "-root.crt" },
+];
+
 interface ExportItem {
     key: string;
     label: string;
@@ -39,6 +54,7 @@ export function ExportDialog({
 }: ExportDialogProps) {
     const [isExporting, setIsExporting] = useState(false);
     const [exportError, setExportError] = useState<string | null>(null);
+    const [chainVariant, setChainVariant] = useState("full");
 
     const hostname = certificate.hostname;
 
@@ -54,10 +70,13 @@ export function ExportDialog({
                 requiresKey: false,
                 group: "certificate",
             });
+            const variant =
+                CHAIN_VARIANTS.find((v) => v.value === chainVariant) ??
+                CHAIN_VARIANTS[0];
             result.push({
                 key: "chain",
-                label: "Certificate Chain (Leaf → Interm. → Root)",
-                filename: `${hostname}-chain.crt`,
+                label: `Certificate Chain (${variant.label})`,
+                filename: `${hostname}${variant.suffix}`,
                 requiresKey: false,
                 group: "certificate",
             });
@@ -88,7 +107,7 @@ export function ExportDialog({
         }
 
         return result;
-    }, [certificate, hostname]);
+    }, [certificate, hostname, chainVariant]);
 
     // Default checked state: all items checked except keys when encryption key not provided
     const defaultChecked = useMemo(() => {
@@ -110,6 +129,7 @@ export function ExportDialog({
                     initial[item.key] = item.requiresKey ? isUnlocked : true;
                 }
                 setChecked(initial);
+                setChainVariant("full");
                 setExportError(null);
             }
             onOpenChange(open);
@@ -133,6 +153,7 @@ export function ExportDialog({
             await api.exportCertificateZip(hostname, {
                 certificate: !!checked.certificate,
                 chain: !!checked.chain,
+                chain_variant: chainVariant,
                 private_key: !!checked.privateKey,
                 csr: !!checked.csr,
                 pending_key: !!checked.pendingKey,
@@ -145,7 +166,22 @@ export function ExportDialog({
         } finally {
             setIsExporting(false);
         }
-    }, [hostname, checked, onOpenChange]);
+    }, [hostname, checked, chainVariant, onOpenChange]);
+
+    const handleExportRunbook = useCallback(async () => {
+        setIsExporting(true);
+        setExportError(null);
+        try {
+            await api.exportRunbook(hostname);
+            onOpenChange(false);
+        } catch (err) {
+            setExportError(
+                err instanceof Error ? err.message : String(err),
+            );
+        } finally {
+            setIsExporting(false);
+        }
+    }, [hostname, onOpenChange]);
 
     return (
         <Dialog open={open} onOpenChange={handleOpenChange}>
@@ -195,6 +231,29 @@ export function ExportDialog({
                                     }
                                 />
                             ))}
+                            {checked.chain && (
+                                <Select
+                                    value={chainVariant}
+                                    onValueChange={setChainVariant}
+                                >
+                                    <SelectTrigger
+                                        id="export-chain-variant"
+                                        className="w-full"
+                                    >
+                                        <SelectValue />
+                                    </SelectTrigger>
+                                    <SelectContent>
+                                        {CHAIN_VARIANTS.map((v) => (
+                                            <SelectItem
+                                                key={v.value}
+                                                value={v.value}
+                                            >
+                                                {v.label}
+                                            </SelectItem>
+                                        ))}
+                                    </SelectContent>
+                                </Select>
+                            )}
                         </div>
                     )}
 
@@ -225,6 +284,14 @@ export function ExportDialog({
                 </div>
 
                 <DialogFooter>
+                    <Button
+                        variant="ghost"
+                        className="sm:mr-auto"
+                        onClick={handleExportRunbook}
+                        disabled={isExporting}
+                    >
+                        Save Runbook
+                    </Button>
                     <Button
                         variant="outline"
                         onClick={() => onOpenChange(false)}
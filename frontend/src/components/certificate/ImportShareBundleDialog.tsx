@@ -0,0 +1,183 @@
+import { useState } from "react";
+import {
+    Dialog,
+    DialogContent,
+    DialogDescription,
+    DialogFooter,
+    DialogHeader,
+    DialogTitle,
+} from "@/components/ui/dialog";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { StatusAlert } from "@/components/shared/StatusAlert";
+import { ReviewField } from "@/components/shared/ReviewField";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { AlertCircleIcon } from "@hugeicons/core-free-icons";
+import { api } from "@/lib/api";
+import { formatDateTime } from "@/lib/theme";
+import type { ShareBundleInfo } from "@/types";
+
+interface ImportShareBundleDialogProps {
+    open: boolean;
+    onClose: () => void;
+    onImported: (hostname: string) => void;
+}
+
+function errorMessage(err: unknown, fallback: string): string {
+    if (typeof err === "string") return err;
+    if (err instanceof Error) return err.message;
+    return fallback;
+}
+
+// Opens a share bundle made by another installation, shows what it holds and
+// imports it. Expired bundles are refused by the backend.
+export function ImportShareBundleDialog({
+    open,
+    onClose,
+    onImported,
+}: ImportShareBundleDialogProps) {
+    const [path, setPath] = useState("");
+    const [password, setPassword] = useState("");
+    const [info, setInfo] = useState<ShareBundleInfo | null>(null);
+    const [error, setError] = useState<string | null>(null);
+    const [isProcessing, setIsProcessing] = useState(false);
+
+    const handleClose = () => {
+        setPath("");
+        setPassword("");
+        setInfo(null);
+        setError(null);
+        onClose();
+    };
+
+    const handleSelectFile = async () => {
+        setError(null);
+        try {
+            const selected = await api.selectShareBundleFile();
+            if (!selected) return;
+            setPath(selected);
+            setInfo(null);
+        } catch (err) {
+            setError(errorMessage(err, "Failed to select file"));
+        }
+    };
+
+    const handleOpen = async () => {
+        setError(null);
+        setIsProcessing(true);
+        try {
+            setInfo(await api.peekShareBundle(path, password));
+        } catch (err) {
+            setError(errorMessage(err, "Failed to open share bundle"));
+        } finally {
+            setIsProcessing(false);
+        }
+    };
+
+    const handleImport = async () => {
+        setError(null);
+        setIsProcessing(true);
+        try {
+            const hostname = await api.importShareBundle(path, password);
+            handleClose();
+            onImported(hostname);
+        } catch (err) {
+            setError(errorMessage(err, "Failed to import share bundle"));
+        } finally {
+            setIsProcessing(false);
+        }
+    };
+
+    return (
+        <Dialog open={open} onOpenChange={(o) => { if (!o) handleClose(); }}>
+            <DialogContent className="sm:max-w-[440px]">
+                <DialogHeader>
+                    <DialogTitle>Import Share Bundle</DialogTitle>
+                    <DialogDescription>
+                        Import a certificate shared from another PaddockControl
+                        installation.
+                    </DialogDescription>
+                </DialogHeader>
+
+                {error && (
+                    <StatusAlert
+                        variant="destructive"
+                        icon={
+                            <HugeiconsIcon
+                                icon={AlertCircleIcon}
+                                className="size-4"
+                                strokeWidth={2}
+                            />
+                        }
+                    >
+                        {error}
+                    </StatusAlert>
+                )}
+
+                {!info ? (
+                    <div className="space-y-4">
+                        <Button
+                            variant="outline"
+                            onClick={handleSelectFile}
+                            className="w-full"
+                        >
+                            {path ? path.split(/[\\/]/).pop() : "Select Share Bundle (.pcshare)"}
+                        </Button>
+                        <div className="space-y-2">
+                            <Label htmlFor="share-bundle-password">Password</Label>
+                            <Input
+                                id="share-bundle-password"
+                                type="password"
+                                value={password}
+                                onChange={(e) => setPassword(e.target.value)}
+                            />
+                        </div>
+                    </div>
+                ) : (
+                    <div className="space-y-2">
+                        <ReviewField label="Hostname" value={info.hostname} />
+                        <ReviewField
+                            label="Private Key"
+                            value={info.has_private_key ? "Included" : "Not included"}
+                        />
+                        <ReviewField
+                            label="Chain"
+                            value={info.has_chain ? "Included" : "Not included"}
+                        />
+                        {info.note && <ReviewField label="Note" value={info.note} />}
+                        <ReviewField label="Created" value={formatDateTime(info.created_at)} />
+                        <ReviewField label="Expires" value={formatDateTime(info.expires_at)} />
+                        {!info.has_private_key && (
+                            <p className="text-xs text-muted-foreground">
+                                This bundle has no private key, so it cannot be
+                                imported.
+                            </p>
+                        )}
+                    </div>
+                )}
+
+                <DialogFooter>
+                    <Button variant="outline" onClick={handleClose}>
+                        Cancel
+                    </Button>
+                    {!info ? (
+                        <Button
+                            onClick={handleOpen}
+                            disabled={!path || !password || isProcessing}
+                        >
+                            {isProcessing ? "Opening..." : "Open"}
+                        </Button>
+                    ) : (
+                        <Button
+                            onClick={handleImport}
+                            disabled={!info.has_private_key || isProcessing}
+                        >
+                            {isProcessing ? "Importing..." : "Import"}
+                        </Button>
+                    )}
+                </DialogFooter>
+            </DialogContent>
+        </Dialog>
+    );
+}
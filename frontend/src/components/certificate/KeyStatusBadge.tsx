@@ -0,0 +1,34 @@
+import { motion } from "motion/react";
+import { Badge } from "@/components/ui/badge";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { Key01Icon } from "@hugeicons/core-free-icons";
+
+// Labels of the key statuses that need attention; "ok" and unchecked ("")
+// show no badge
+const keyStatusLabels: Record<string, string> = {
+    mismatched: "Key mismatch",
+    undecryptable: "Key unreadable",
+    missing: "No key",
+};
+
+export function KeyStatusBadge({ status }: { status?: string }) {
+    const label = status ? keyStatusLabels[status] : undefined;
+    if (!label) return null;
+
+    return (
+        <motion.div
+            initial={{ opacity: 0, x: 20 }}
+            animate={{ opacity: 1, x: 0 }}
+            exit={{ opacity: 0, x: 20 }}
+            transition={{ type: 'spring', stiffness: 500, damping: 30 }}
+        >
+            <Badge
+                variant="outline"
+                className="inline-flex items-center gap-1 border-destructive/40 bg-destructive/10 text-destructive"
+            >
+                <HugeiconsIcon icon={Key01Icon} className="w-3.5 h-3.5" strokeWidth={2} />
+                {label}
+            </Badge>
+        </motion.div>
+    );
+}
@@ -0,0 +1,119 @@
+import { useEffect, useState } from "react";
+import {
+    Dialog,
+    DialogContent,
+    DialogDescription,
+    DialogHeader,
+    DialogTitle,
+} from "@/components/ui/dialog";
+import { CodeBlock } from "@/components/ui/code-block";
+import { StatusAlert } from "@/components/shared/StatusAlert";
+import { LoadingSpinner } from "@/components/shared/LoadingSpinner";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { AlertCircleIcon } from "@hugeicons/core-free-icons";
+import { api } from "@/lib/api";
+import type { OpenSSLCommands } from "@/types";
+
+interface OpenSSLCommandsDialogProps {
+    open: boolean;
+    onOpenChange: (open: boolean) => void;
+    hostname: string;
+}
+
+// Lists the openssl commands checking the downloaded files and the deployed
+// server, named like the downloads so they can be pasted as they are.
+export function OpenSSLCommandsDialog({
+    open,
+    onOpenChange,
+    hostname,
+}: OpenSSLCommandsDialogProps) {
+    const [result, setResult] = useState<OpenSSLCommands | null>(null);
+    const [error, setError] = useState<string | null>(null);
+    const [isLoading, setIsLoading] = useState(false);
+
+    useEffect(() => {
+        if (!open) return;
+        let cancelled = false;
+        setIsLoading(true);
+        setError(null);
+        api.getOpenSSLCommands(hostname)
+            .then((commands) => {
+                if (!cancelled) setResult(commands);
+            })
+            .catch((err) => {
+                if (!cancelled) {
+                    setError(err instanceof Error ? err.message : String(err));
+                }
+            })
+            .finally(() => {
+                if (!cancelled) setIsLoading(false);
+            });
+        return () => {
+            cancelled = true;
+        };
+    }, [open, hostname]);
+
+    return (
+        <Dialog open={open} onOpenChange={onOpenChange}>
+            <DialogContent className="sm:max-w-[640px] max-h-[85vh] overflow-y-auto">
+                <DialogHeader>
+                    <DialogTitle>Verify with OpenSSL</DialogTitle>
+                    <DialogDescription>
+                        Run these in the folder holding the downloaded files.
+                    </DialogDescription>
+                </DialogHeader>
+
+                {error && (
+                    <StatusAlert
+                        variant="destructive"
+                        icon={
+                            <HugeiconsIcon
+                                icon={AlertCircleIcon}
+                                className="size-4"
+                                strokeWidth={2}
+                            />
+                        }
+                    >
+                        {error}
+                    </StatusAlert>
+                )}
+
+                {isLoading && !result ? (
+                    <div className="flex items-center justify-center py-8">
+                        <LoadingSpinner text="Generating..." />
+                    </div>
+                ) : (
+                    result && (
+                        <div className="space-y-4">
+                            <p className="text-xs text-muted-foreground">
+                                Files:{" "}
+                                <span className="font-mono">
+                                    {result.files.join(", ")}
+                                </span>
+                            </p>
+                            {result.commands.map((command) => (
+                                <div key={command.title} className="space-y-1">
+                                    <p className="text-sm font-medium">
+                                        {command.title}
+                                    </p>
+                                    <p className="text-xs text-muted-foreground">
+                                        {command.description}
+                                    </p>
+                                    <CodeBlock content={command.command} />
+                                </div>
+                            ))}
+                            {result.notes?.map((note) => (
+                                <p
+                                    key={note}
+                                    className="text-xs text-muted-foreground"
+                                >
+                                    {note}
+                                </p>
+                            ))}
+                        </div>
+                    )
+                )}
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -0,0 +1,183 @@
+import { useState, useCallback } from "react";
+import { toast } from "sonner";
+import {
+    Dialog,
+    DialogContent,
+    DialogDescription,
+    DialogFooter,
+    DialogHeader,
+    DialogTitle,
+} from "@/components/ui/dialog";
+import { Button } from "@/components/ui/button";
+import { Checkbox } from "@/components/ui/checkbox";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { StatusAlert } from "@/components/shared/StatusAlert";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { AlertCircleIcon } from "@hugeicons/core-free-icons";
+import { api } from "@/lib/api";
+
+// Mirrors minPKCS12PasswordLength in the certificate service
+const MIN_PASSWORD_LENGTH = 8;
+
+interface PKCS12ExportDialogProps {
+    open: boolean;
+    onOpenChange: (open: boolean) => void;
+    hostname: string;
+    isUnlocked: boolean;
+}
+
+// Saves the certificate, private key and chain as a password-protected
+// PKCS#12 (.pfx) file for Windows servers and Java keystores.
+export function PKCS12ExportDialog({
+    open,
+    onOpenChange,
+    hostname,
+    isUnlocked,
+}: PKCS12ExportDialogProps) {
+    const [password, setPassword] = useState("");
+    const [confirmPassword, setConfirmPassword] = useState("");
+    const [legacy, setLegacy] = useState(false);
+    const [isSaving, setIsSaving] = useState(false);
+    const [error, setError] = useState<string | null>(null);
+
+    const handleOpenChange = useCallback(
+        (open: boolean) => {
+            if (open) {
+                setLegacy(false);
+                setError(null);
+            }
+            setPassword("");
+            setConfirmPassword("");
+            onOpenChange(open);
+        },
+        [onOpenChange],
+    );
+
+    const passwordError =
+        password.length > 0 && password.length < MIN_PASSWORD_LENGTH
+            ? `Password must be at least ${MIN_PASSWORD_LENGTH} characters`
+            : confirmPassword.length > 0 && password !== confirmPassword
+              ? "Passwords do not match"
+              : null;
+    const canSave =
+        isUnlocked &&
+        password.length >= MIN_PASSWORD_LENGTH &&
+        password === confirmPassword &&
+        !isSaving;
+
+    const handleSave = useCallback(async () => {
+        setIsSaving(true);
+        setError(null);
+        try {
+            await api.saveP12ToFile(hostname, password, legacy);
+            toast.success("PKCS#12 file saved");
+            handleOpenChange(false);
+        } catch (err) {
+            setError(err instanceof Error ? err.message : String(err));
+        } finally {
+            setIsSaving(false);
+        }
+    }, [hostname, password, legacy, handleOpenChange]);
+
+    return (
+        <Dialog open={open} onOpenChange={handleOpenChange}>
+            <DialogContent className="sm:max-w-[440px]">
+                <DialogHeader>
+                    <DialogTitle>Export PKCS#12 (.pfx)</DialogTitle>
+                    <DialogDescription>
+                        Save the certificate, private key and chain in one
+                        password-protected file for Windows or Java.
+                    </DialogDescription>
+                </DialogHeader>
+
+                {!isUnlocked && (
+                    <StatusAlert variant="warning">
+                        The private key can only be exported when the app is
+                        unlocked.
+                    </StatusAlert>
+                )}
+
+                {error && (
+                    <StatusAlert
+                        variant="destructive"
+                        icon={
+                            <HugeiconsIcon
+                                icon={AlertCircleIcon}
+                                className="size-4"
+                                strokeWidth={2}
+                            />
+                        }
+                    >
+                        {error}
+                    </StatusAlert>
+                )}
+
+                <div className="space-y-4">
+                    <div className="space-y-2">
+                        <Label htmlFor="p12-password">Password</Label>
+                        <Input
+                            id="p12-password"
+                            type="password"
+                            value={password}
+                            onChange={(e) => setPassword(e.target.value)}
+                            autoComplete="new-password"
+                            disabled={!isUnlocked}
+                        />
+                    </div>
+                    <div className="space-y-2">
+                        <Label htmlFor="p12-password-confirm">
+                            Confirm Password
+                        </Label>
+                        <Input
+                            id="p12-password-confirm"
+                            type="password"
+                            value={confirmPassword}
+                            onChange={(e) => setConfirmPassword(e.target.value)}
+                            autoComplete="new-password"
+                            disabled={!isUnlocked}
+                        />
+                        {passwordError && (
+                            <p className="text-sm text-destructive">
+                                {passwordError}
+                            </p>
+                        )}
+                    </div>
+
+                    <div className="flex items-center gap-3">
+                        <Checkbox
+                            id="p12-legacy"
+                            checked={legacy}
+                            onCheckedChange={(val) => setLegacy(val === true)}
+                            disabled={!isUnlocked}
+                        />
+                        <div className="flex-1 min-w-0">
+                            <Label
+                                htmlFor="p12-legacy"
+                                className="text-sm cursor-pointer"
+                            >
+                                Legacy encryption (3DES)
+                            </Label>
+                            <p className="text-xs text-muted-foreground">
+                                For Windows Server 2016 and older or Java 8.
+                                Not available in FIPS mode.
+                            </p>
+                        </div>
+                    </div>
+                </div>
+
+                <DialogFooter>
+                    <Button
+                        variant="outline"
+                        onClick={() => handleOpenChange(false)}
+                    >
+                        Cancel
+                    </Button>
+                    <Button onClick={handleSave} disabled={!canSave}>
+                        {isSaving ? "Saving..." : "Save .pfx"}
+                    </Button>
+                </DialogFooter>
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -13,6 +13,8 @@ import {
     CollapsibleTrigger,
 } from "@/components/ui/collapsible";
 import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
 import { CodeBlock } from "@/components/ui/code-block";
 import {
     Tooltip,
@@ -29,7 +31,8 @@ import {
     ArrowUp01Icon,
 } from "@hugeicons/core-free-icons";
 import type { Certificate } from "@/types";
-import { pendingCardStyles } from "@/lib/theme";
+import { formatDateTime, getRelativeTime, pendingCardStyles } from "@/lib/theme";
+import { toast } from "sonner";
 
 interface PendingCSRSectionProps {
     certificate: Certificate;
@@ -37,6 +40,8 @@ interface PendingCSRSectionProps {
     onCancelRenewal: () => void;
     cancelRenewalConfirming: boolean;
     setCancelRenewalConfirming: (value: boolean) => void;
+    onMarkSubmitted: (caReference: string) => Promise<void>;
+    isMarkingSubmitted?: boolean;
     isLoading?: boolean;
 }
 
@@ -46,9 +51,23 @@ export function PendingCSRSection({
     onCancelRenewal,
     cancelRenewalConfirming,
     setCancelRenewalConfirming,
+    onMarkSubmitted,
+    isMarkingSubmitted,
     isLoading,
 }: PendingCSRSectionProps) {
     const [isOpen, setIsOpen] = useState(false);
+    const [caReference, setCaReference] = useState(certificate.ca_reference ?? "");
+
+    const handleMarkSubmitted = async () => {
+        try {
+            await onMarkSubmitted(caReference.trim());
+            toast.success("CSR submission recorded");
+        } catch (err) {
+            toast.error(
+                err instanceof Error ? err.message : typeof err === "string" ? err : "Failed to record CSR submission",
+            );
+        }
+    };
 
     if (!certificate.pending_csr) {
         return null;
@@ -71,7 +90,9 @@ export function PendingCSRSection({
                                         Pending Certificate Signing Request
                                     </CardTitle>
                                     <CardDescription className={pendingCardStyles.description}>
-                                        This CSR is awaiting a signed certificate
+                                        {certificate.submitted_at
+                                            ? `Submitted to the CA ${getRelativeTime(certificate.submitted_at)}${certificate.ca_reference ? ` (reference ${certificate.ca_reference})` : ""}, awaiting a signed certificate`
+                                            : "This CSR is awaiting a signed certificate"}
                                     </CardDescription>
                                 </div>
                                 <HugeiconsIcon
@@ -124,7 +145,11 @@ export function PendingCSRSection({
                                             </span>
                                         </TooltipTrigger>
                                         <TooltipContent>
-                                            {certificate.read_only ? "Certificate is read-only" : "Cancel the pending renewal and delete the CSR"}
+                                            {certificate.read_only
+                                                ? "Certificate is read-only"
+                                                : certificate.certificate_pem
+                                                  ? "Cancel the pending renewal and delete the CSR"
+                                                  : "Cancel the request and remove this entry"}
                                         </TooltipContent>
                                     </Tooltip>
                                 </ReadOnlyFade>
@@ -132,7 +157,34 @@ export function PendingCSRSection({
                         </div>
                     </CardHeader>
                     <CollapsibleContent>
-                        <CardContent>
+                        <CardContent className="space-y-4">
+                            <div className="space-y-2">
+                                <Label htmlFor="ca_reference">CA Order / Reference ID</Label>
+                                <div className="flex gap-2">
+                                    <Input
+                                        id="ca_reference"
+                                        maxLength={128}
+                                        placeholder="Optional"
+                                        value={caReference}
+                                        onChange={(e) => setCaReference(e.target.value)}
+                                        disabled={certificate.read_only || isMarkingSubmitted}
+                                    />
+                                    <Button
+                                        variant="outline"
+                                        size="sm"
+                                        onClick={handleMarkSubmitted}
+                                        disabled={certificate.read_only || isMarkingSubmitted}
+                                        className="whitespace-nowrap"
+                                    >
+                                        {certificate.submitted_at ? "Update Submission" : "Mark as Submitted"}
+                                    </Button>
+                                </div>
+                                {certificate.submitted_at ? (
+                                    <p className="text-xs text-muted-foreground">
+                                        Submitted on {formatDateTime(certificate.submitted_at)}
+                                    </p>
+                                ) : null}
+                            </div>
                             <CodeBlock
                                 content={certificate.pending_csr}
                             />
@@ -143,9 +195,13 @@ export function PendingCSRSection({
 
             <ConfirmDialog
                 open={cancelRenewalConfirming}
-                title="Cancel Renewal"
-                description="This will permanently delete the pending CSR and its associated private key. The active certificate will not be affected. This action cannot be undone."
-                confirmText="Cancel Renewal"
+                title={certificate.certificate_pem ? "Cancel Renewal" : "Cancel Request"}
+                description={
+                    certificate.certificate_pem
+                        ? "This will permanently delete the pending CSR and its associated private key. The active certificate will not be affected. This action cannot be undone."
+                        : "This will permanently delete the CSR, its private key, and the certificate entry. This action cannot be undone."
+                }
+                confirmText={certificate.certificate_pem ? "Cancel Renewal" : "Cancel Request"}
                 cancelText="Keep"
                 isDestructive={true}
                 isLoading={isLoading}
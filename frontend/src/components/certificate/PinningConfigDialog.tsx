@@ -0,0 +1,180 @@
+import { useEffect, useState } from "react";
+import {
+    Dialog,
+    DialogContent,
+    DialogDescription,
+    DialogHeader,
+    DialogTitle,
+} from "@/components/ui/dialog";
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
+import { CodeBlock } from "@/components/ui/code-block";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { StatusAlert } from "@/components/shared/StatusAlert";
+import { LoadingSpinner } from "@/components/shared/LoadingSpinner";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { AlertCircleIcon } from "@hugeicons/core-free-icons";
+import { api } from "@/lib/api";
+import type { PinningConfig } from "@/types";
+
+const PIN_FORMATS = [
+    { value: "android", label: "Android network security config" },
+    { value: "okhttp", label: "OkHttp CertificatePinner" },
+    { value: "trustkit", label: "iOS TrustKit" },
+];
+
+function splitHostnames(value: string): string[] {
+    return value
+        .split(/[\s,]+/)
+        .map((h) => h.trim())
+        .filter(Boolean);
+}
+
+interface PinningConfigDialogProps {
+    open: boolean;
+    onOpenChange: (open: boolean) => void;
+    hostname: string;
+}
+
+// Shows a pinning configuration for mobile apps with the active and pending
+// keys of the certificates, so app teams pin the next key before a rotation.
+export function PinningConfigDialog({
+    open,
+    onOpenChange,
+    hostname,
+}: PinningConfigDialogProps) {
+    const [format, setFormat] = useState("android");
+    const [hostnames, setHostnames] = useState(hostname);
+    const [config, setConfig] = useState<PinningConfig | null>(null);
+    const [error, setError] = useState<string | null>(null);
+    const [isLoading, setIsLoading] = useState(false);
+
+    useEffect(() => {
+        if (open) setHostnames(hostname);
+    }, [open, hostname]);
+
+    useEffect(() => {
+        if (!open) return;
+        let cancelled = false;
+        setIsLoading(true);
+        setError(null);
+        api.generatePinningConfig(splitHostnames(hostnames), format)
+            .then((result) => {
+                if (!cancelled) setConfig(result);
+            })
+            .catch((err) => {
+                if (!cancelled) {
+                    setConfig(null);
+                    setError(err instanceof Error ? err.message : String(err));
+                }
+            })
+            .finally(() => {
+                if (!cancelled) setIsLoading(false);
+            });
+        return () => {
+            cancelled = true;
+        };
+    }, [open, hostnames, format]);
+
+    return (
+        <Dialog open={open} onOpenChange={onOpenChange}>
+            <DialogContent className="sm:max-w-[640px]">
+                <DialogHeader>
+                    <DialogTitle>Pinning Configuration</DialogTitle>
+                    <DialogDescription>
+                        Public key pins for mobile apps, including the key of
+                        each pending renewal.
+                    </DialogDescription>
+                </DialogHeader>
+
+                <div className="grid gap-4 sm:grid-cols-2">
+                    <div className="space-y-2">
+                        <Label htmlFor="pinning-hostnames">
+                            Certificates (hostnames, comma separated)
+                        </Label>
+                        <Input
+                            id="pinning-hostnames"
+                            value={hostnames}
+                            onChange={(e) => setHostnames(e.target.value)}
+                        />
+                    </div>
+                    <div className="space-y-2">
+                        <Label htmlFor="pinning-format">Format</Label>
+                        <Select value={format} onValueChange={setFormat}>
+                            <SelectTrigger
+                                id="pinning-format"
+                                className="w-full"
+                            >
+                                <SelectValue />
+                            </SelectTrigger>
+                            <SelectContent>
+                                {PIN_FORMATS.map((f) => (
+                                    <SelectItem key={f.value} value={f.value}>
+                                        {f.label}
+                                    </SelectItem>
+                                ))}
+                            </SelectContent>
+                        </Select>
+                    </div>
+                </div>
+
+                {error && (
+                    <StatusAlert
+                        variant="destructive"
+                        icon={
+                            <HugeiconsIcon
+                                icon={AlertCircleIcon}
+                                className="size-4"
+                                strokeWidth={2}
+                            />
+                        }
+                    >
+                        {error}
+                    </StatusAlert>
+                )}
+
+                {isLoading && !config ? (
+                    <div className="flex items-center justify-center py-8">
+                        <LoadingSpinner text="Generating..." />
+                    </div>
+                ) : (
+                    config && (
+                        <div className="space-y-3">
+                            {config.warnings.length > 0 && (
+                                <StatusAlert
+                                    variant="warning"
+                                    icon={
+                                        <HugeiconsIcon
+                                            icon={AlertCircleIcon}
+                                            className="size-4"
+                                            strokeWidth={2}
+                                        />
+                                    }
+                                >
+                                    <ul className="space-y-1">
+                                        {config.warnings.map((warning) => (
+                                            <li key={warning}>{warning}</li>
+                                        ))}
+                                    </ul>
+                                </StatusAlert>
+                            )}
+                            <p className="font-mono text-xs">
+                                {config.filename}
+                            </p>
+                            <CodeBlock
+                                content={config.config}
+                                maxHeight="max-h-80"
+                            />
+                        </div>
+                    )
+                )}
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -0,0 +1,149 @@
+import { useEffect, useState } from "react";
+import {
+    Dialog,
+    DialogContent,
+    DialogDescription,
+    DialogHeader,
+    DialogTitle,
+} from "@/components/ui/dialog";
+import { Button } from "@/components/ui/button";
+import { StatusAlert } from "@/components/shared/StatusAlert";
+import { LoadingSpinner } from "@/components/shared/LoadingSpinner";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { AlertCircleIcon } from "@hugeicons/core-free-icons";
+import { api } from "@/lib/api";
+import type { CertificateQRCodes } from "@/types";
+
+interface QRCodeDialogProps {
+    open: boolean;
+    onOpenChange: (open: boolean) => void;
+    hostname: string;
+}
+
+// Shows the certificate's SHA-256 fingerprint as a QR code, and optionally the
+// whole PEM as numbered frames, to verify a deployment from a phone.
+export function QRCodeDialog({ open, onOpenChange, hostname }: QRCodeDialogProps) {
+    const [codes, setCodes] = useState<CertificateQRCodes | null>(null);
+    const [showPEM, setShowPEM] = useState(false);
+    const [frame, setFrame] = useState(0);
+    const [error, setError] = useState<string | null>(null);
+    const [isLoading, setIsLoading] = useState(false);
+
+    useEffect(() => {
+        if (!open) return;
+        let cancelled = false;
+        setIsLoading(true);
+        setError(null);
+        api.getCertificateQRCodes(hostname, showPEM)
+            .then((result) => {
+                if (!cancelled) {
+                    setCodes(result);
+                    setFrame(0);
+                }
+            })
+            .catch((err) => {
+                if (!cancelled) {
+                    setError(err instanceof Error ? err.message : String(err));
+                }
+            })
+            .finally(() => {
+                if (!cancelled) setIsLoading(false);
+            });
+        return () => {
+            cancelled = true;
+        };
+    }, [open, hostname, showPEM]);
+
+    const handleOpenChange = (open: boolean) => {
+        if (!open) {
+            setCodes(null);
+            setShowPEM(false);
+        }
+        onOpenChange(open);
+    };
+
+    const frames = codes?.pem_frames ?? [];
+    const current = showPEM ? frames[frame] : codes?.fingerprint;
+
+    return (
+        <Dialog open={open} onOpenChange={handleOpenChange}>
+            <DialogContent className="sm:max-w-[420px]">
+                <DialogHeader>
+                    <DialogTitle>Certificate QR Code</DialogTitle>
+                    <DialogDescription>
+                        {showPEM
+                            ? "Scan each frame in order to transfer the certificate PEM."
+                            : "Scan to compare the SHA-256 fingerprint on another device."}
+                    </DialogDescription>
+                </DialogHeader>
+
+                {error && (
+                    <StatusAlert
+                        variant="destructive"
+                        icon={
+                            <HugeiconsIcon
+                                icon={AlertCircleIcon}
+                                className="size-4"
+                                strokeWidth={2}
+                            />
+                        }
+                    >
+                        {error}
+                    </StatusAlert>
+                )}
+
+                {isLoading && (
+                    <div className="flex items-center justify-center py-8">
+                        <LoadingSpinner text="Generating..." />
+                    </div>
+                )}
+
+                {!isLoading && current && (
+                    <div className="space-y-3">
+                        <img
+                            src={current.image}
+                            alt={showPEM ? `PEM frame ${frame + 1}` : "Fingerprint QR code"}
+                            className="mx-auto size-64 bg-white p-2"
+                        />
+                        {showPEM ? (
+                            <div className="flex items-center justify-between">
+                                <Button
+                                    variant="outline"
+                                    size="sm"
+                                    onClick={() => setFrame((f) => f - 1)}
+                                    disabled={frame === 0}
+                                >
+                                    Previous
+                                </Button>
+                                <span className="text-xs text-muted-foreground">
+                                    Frame {frame + 1} of {frames.length}
+                                </span>
+                                <Button
+                                    variant="outline"
+                                    size="sm"
+                                    onClick={() => setFrame((f) => f + 1)}
+                                    disabled={frame >= frames.length - 1}
+                                >
+                                    Next
+                                </Button>
+                            </div>
+                        ) : (
+                            <p className="text-xs font-mono break-all text-center text-muted-foreground">
+                                {codes?.fingerprint_sha256}
+                            </p>
+                        )}
+                    </div>
+                )}
+
+                <Button
+                    variant="outline"
+                    onClick={() => setShowPEM((v) => !v)}
+                    disabled={isLoading}
+                    className="w-full"
+                >
+                    {showPEM ? "Show Fingerprint" : "Show Full PEM"}
+                </Button>
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -0,0 +1,123 @@
+import { useCallback, useEffect, useState } from "react";
+import {
+    Card,
+    CardContent,
+    CardDescription,
+    CardHeader,
+    CardTitle,
+} from "@/components/ui/card";
+import { Checkbox } from "@/components/ui/checkbox";
+import { Label } from "@/components/ui/label";
+import { StatusAlert } from "@/components/shared/StatusAlert";
+import { api } from "@/lib/api";
+import { getRelativeTime } from "@/lib/theme";
+import type { RenewalChecklist } from "@/types";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { AlertCircleIcon } from "@hugeicons/core-free-icons";
+
+const STEP_LABELS: Record<string, string> = {
+    csr_sent: "CSR sent to CA",
+    cert_received: "Certificate received",
+    uploaded: "Certificate uploaded",
+    deployed: "Deployed",
+    verified: "Deployment verified",
+};
+
+interface RenewalChecklistCardProps {
+    hostname: string;
+    readOnly: boolean;
+    // Called after a step changed, e.g. to reload the history
+    onChange?: () => void;
+}
+
+export function RenewalChecklistCard({
+    hostname,
+    readOnly,
+    onChange,
+}: RenewalChecklistCardProps) {
+    const [checklist, setChecklist] = useState<RenewalChecklist | null>(null);
+    const [busyStep, setBusyStep] = useState<string | null>(null);
+    const [error, setError] = useState<string | null>(null);
+
+    const load = useCallback(async () => {
+        try {
+            setChecklist(await api.getRenewalChecklist(hostname));
+        } catch (err) {
+            setError(
+                err instanceof Error ? err.message : "Failed to load renewal checklist",
+            );
+        }
+    }, [hostname]);
+
+    useEffect(() => {
+        load();
+    }, [load]);
+
+    const handleToggle = async (step: string, done: boolean) => {
+        setBusyStep(step);
+        setError(null);
+        try {
+            setChecklist(await api.setRenewalStep(hostname, step, done));
+            onChange?.();
+        } catch (err) {
+            setError(
+                err instanceof Error ? err.message : typeof err === "string" ? err : "Failed to update renewal checklist",
+            );
+        } finally {
+            setBusyStep(null);
+        }
+    };
+
+    if (!checklist) return null;
+
+    return (
+        <Card className="shadow-sm border-border mb-6">
+            <CardHeader>
+                <CardTitle>Renewal Checklist</CardTitle>
+                <CardDescription>
+                    {checklist.completed} of {checklist.items.length} steps done
+                    for the current renewal
+                </CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-3">
+                {error && (
+                    <StatusAlert
+                        variant="destructive"
+                        icon={
+                            <HugeiconsIcon
+                                icon={AlertCircleIcon}
+                                className="size-4"
+                                strokeWidth={2}
+                            />
+                        }
+                    >
+                        {error}
+                    </StatusAlert>
+                )}
+                {checklist.items.map((item) => (
+                    <div key={item.step} className="flex items-center justify-between">
+                        <div className="flex items-center gap-3">
+                            <Checkbox
+                                id={`renewal-step-${item.step}`}
+                                checked={item.done}
+                                disabled={readOnly || busyStep !== null}
+                                onCheckedChange={(checked) =>
+                                    handleToggle(item.step, checked === true)
+                                }
+                            />
+                            <Label htmlFor={`renewal-step-${item.step}`}>
+                                {STEP_LABELS[item.step] ?? item.step}
+                            </Label>
+                        </div>
+                        {item.done && item.completed_at ? (
+                            <span className="text-xs text-muted-foreground">
+                                {getRelativeTime(item.completed_at)}
+                                {item.actor ? ` by ${item.actor}` : ""}
+                            </span>
+                        ) : null}
+                    </div>
+                ))}
+            </CardContent>
+        </Card>
+    );
+}
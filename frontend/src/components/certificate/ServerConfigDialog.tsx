@@ -0,0 +1,147 @@
+import { useEffect, useState } from "react";
+import {
+    Dialog,
+    DialogContent,
+    DialogDescription,
+    DialogHeader,
+    DialogTitle,
+} from "@/components/ui/dialog";
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
+import { CodeBlock } from "@/components/ui/code-block";
+import { Label } from "@/components/ui/label";
+import { StatusAlert } from "@/components/shared/StatusAlert";
+import { LoadingSpinner } from "@/components/shared/LoadingSpinner";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { AlertCircleIcon } from "@hugeicons/core-free-icons";
+import { api } from "@/lib/api";
+import type { ServerConfigSnippet } from "@/types";
+
+const SERVER_TYPES = [
+    { value: "nginx", label: "nginx" },
+    { value: "apache", label: "Apache httpd" },
+    { value: "haproxy", label: "HAProxy" },
+];
+
+interface ServerConfigDialogProps {
+    open: boolean;
+    onOpenChange: (open: boolean) => void;
+    hostname: string;
+}
+
+// Shows a ready-to-paste TLS configuration block for a web server, and the
+// files it expects, to avoid copy/paste mistakes after each renewal.
+export function ServerConfigDialog({
+    open,
+    onOpenChange,
+    hostname,
+}: ServerConfigDialogProps) {
+    const [serverType, setServerType] = useState("nginx");
+    const [snippet, setSnippet] = useState<ServerConfigSnippet | null>(null);
+    const [error, setError] = useState<string | null>(null);
+    const [isLoading, setIsLoading] = useState(false);
+
+    useEffect(() => {
+        if (!open) return;
+        let cancelled = false;
+        setIsLoading(true);
+        setError(null);
+        api.generateServerConfigSnippet(hostname, serverType)
+            .then((result) => {
+                if (!cancelled) setSnippet(result);
+            })
+            .catch((err) => {
+                if (!cancelled) {
+                    setError(err instanceof Error ? err.message : String(err));
+                }
+            })
+            .finally(() => {
+                if (!cancelled) setIsLoading(false);
+            });
+        return () => {
+            cancelled = true;
+        };
+    }, [open, hostname, serverType]);
+
+    return (
+        <Dialog open={open} onOpenChange={onOpenChange}>
+            <DialogContent className="sm:max-w-[640px]">
+                <DialogHeader>
+                    <DialogTitle>Server Configuration</DialogTitle>
+                    <DialogDescription>
+                        TLS settings to paste into your web server
+                        configuration.
+                    </DialogDescription>
+                </DialogHeader>
+
+                <div className="space-y-2">
+                    <Label htmlFor="server-config-type">Server</Label>
+                    <Select value={serverType} onValueChange={setServerType}>
+                        <SelectTrigger
+                            id="server-config-type"
+                            className="w-full"
+                        >
+                            <SelectValue />
+                        </SelectTrigger>
+                        <SelectContent>
+                            {SERVER_TYPES.map((t) => (
+                                <SelectItem key={t.value} value={t.value}>
+                                    {t.label}
+                                </SelectItem>
+                            ))}
+                        </SelectContent>
+                    </Select>
+                </div>
+
+                {error && (
+                    <StatusAlert
+                        variant="destructive"
+                        icon={
+                            <HugeiconsIcon
+                                icon={AlertCircleIcon}
+                                className="size-4"
+                                strokeWidth={2}
+                            />
+                        }
+                    >
+                        {error}
+                    </StatusAlert>
+                )}
+
+                {isLoading && !snippet ? (
+                    <div className="flex items-center justify-center py-8">
+                        <LoadingSpinner text="Generating..." />
+                    </div>
+                ) : (
+                    snippet && (
+                        <div className="space-y-3">
+                            <CodeBlock
+                                content={snippet.config}
+                                maxHeight="max-h-80"
+                            />
+                            <div className="space-y-1 text-sm">
+                                {snippet.files.map((file) => (
+                                    <div key={file.path}>
+                                        <p className="font-mono text-xs">
+                                            {file.path}
+                                        </p>
+                                        <p className="text-xs text-muted-foreground">
+                                            {file.source}
+                                            {file.private_key &&
+                                                " · readable by the server user only"}
+                                        </p>
+                                    </div>
+                                ))}
+                            </div>
+                        </div>
+                    )
+                )}
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -0,0 +1,215 @@
+import { useState, useCallback } from "react";
+import { toast } from "sonner";
+import {
+    Dialog,
+    DialogContent,
+    DialogDescription,
+    DialogFooter,
+    DialogHeader,
+    DialogTitle,
+} from "@/components/ui/dialog";
+import { Button } from "@/components/ui/button";
+import { Checkbox } from "@/components/ui/checkbox";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
+import { StatusAlert } from "@/components/shared/StatusAlert";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { AlertCircleIcon } from "@hugeicons/core-free-icons";
+import { api } from "@/lib/api";
+
+interface ShareBundleDialogProps {
+    open: boolean;
+    onOpenChange: (open: boolean) => void;
+    hostname: string;
+    isUnlocked: boolean;
+}
+
+const LIFETIMES = [
+    { hours: 1, label: "1 hour" },
+    { hours: 24, label: "1 day" },
+    { hours: 72, label: "3 days" },
+    { hours: 168, label: "7 days" },
+    { hours: 720, label: "30 days" },
+];
+
+// Creates a password-protected share bundle another PaddockControl
+// installation can import until it expires.
+export function ShareBundleDialog({
+    open,
+    onOpenChange,
+    hostname,
+    isUnlocked,
+}: ShareBundleDialogProps) {
+    const [includeKey, setIncludeKey] = useState(isUnlocked);
+    const [password, setPassword] = useState("");
+    const [confirmPassword, setConfirmPassword] = useState("");
+    const [expiresHours, setExpiresHours] = useState(24);
+    const [isCreating, setIsCreating] = useState(false);
+    const [error, setError] = useState<string | null>(null);
+
+    const handleOpenChange = useCallback(
+        (open: boolean) => {
+            if (open) {
+                setIncludeKey(isUnlocked);
+                setExpiresHours(24);
+                setError(null);
+            }
+            setPassword("");
+            setConfirmPassword("");
+            onOpenChange(open);
+        },
+        [onOpenChange, isUnlocked],
+    );
+
+    const passwordError =
+        password.length > 0 && password.length < 16
+            ? "Password must be at least 16 characters"
+            : confirmPassword.length > 0 && password !== confirmPassword
+              ? "Passwords do not match"
+              : null;
+    const canCreate =
+        password.length >= 16 && password === confirmPassword && !isCreating;
+
+    const handleCreate = useCallback(async () => {
+        setIsCreating(true);
+        setError(null);
+        try {
+            await api.createShareBundle(
+                hostname,
+                includeKey,
+                password,
+                expiresHours,
+            );
+            toast.success("Share bundle saved");
+            handleOpenChange(false);
+        } catch (err) {
+            setError(err instanceof Error ? err.message : String(err));
+        } finally {
+            setIsCreating(false);
+        }
+    }, [hostname, includeKey, password, expiresHours, handleOpenChange]);
+
+    return (
+        <Dialog open={open} onOpenChange={handleOpenChange}>
+            <DialogContent className="sm:max-w-[440px]">
+                <DialogHeader>
+                    <DialogTitle>Share Certificate</DialogTitle>
+                    <DialogDescription>
+                        Save an encrypted bundle another PaddockControl
+                        installation can import. Send the password separately.
+                    </DialogDescription>
+                </DialogHeader>
+
+                {error && (
+                    <StatusAlert
+                        variant="destructive"
+                        icon={
+                            <HugeiconsIcon
+                                icon={AlertCircleIcon}
+                                className="size-4"
+                                strokeWidth={2}
+                            />
+                        }
+                    >
+                        {error}
+                    </StatusAlert>
+                )}
+
+                <div className="space-y-4">
+                    <div className="flex items-center gap-3">
+                        <Checkbox
+                            id="share-include-key"
+                            checked={includeKey}
+                            onCheckedChange={(val) => setIncludeKey(val === true)}
+                            disabled={!isUnlocked}
+                        />
+                        <div className="flex-1 min-w-0">
+                            <Label
+                                htmlFor="share-include-key"
+                                className={`text-sm cursor-pointer ${!isUnlocked ? "text-muted-foreground" : ""}`}
+                            >
+                                Include private key
+                            </Label>
+                            <p className="text-xs text-muted-foreground">
+                                {isUnlocked
+                                    ? "Required for the recipient to import the certificate"
+                                    : "Unlock required"}
+                            </p>
+                        </div>
+                    </div>
+
+                    <div className="space-y-2">
+                        <Label htmlFor="share-expires">Expires after</Label>
+                        <Select
+                            value={expiresHours.toString()}
+                            onValueChange={(value) =>
+                                setExpiresHours(parseInt(value))
+                            }
+                        >
+                            <SelectTrigger id="share-expires" className="w-full">
+                                <SelectValue />
+                            </SelectTrigger>
+                            <SelectContent>
+                                {LIFETIMES.map((l) => (
+                                    <SelectItem
+                                        key={l.hours}
+                                        value={l.hours.toString()}
+                                    >
+                                        {l.label}
+                                    </SelectItem>
+                                ))}
+                            </SelectContent>
+                        </Select>
+                    </div>
+
+                    <div className="space-y-2">
+                        <Label htmlFor="share-password">Password</Label>
+                        <Input
+                            id="share-password"
+                            type="password"
+                            value={password}
+                            onChange={(e) => setPassword(e.target.value)}
+                            autoComplete="new-password"
+                        />
+                    </div>
+                    <div className="space-y-2">
+                        <Label htmlFor="share-password-confirm">
+                            Confirm Password
+                        </Label>
+                        <Input
+                            id="share-password-confirm"
+                            type="password"
+                            value={confirmPassword}
+                            onChange={(e) => setConfirmPassword(e.target.value)}
+                            autoComplete="new-password"
+                        />
+                        {passwordError && (
+                            <p className="text-sm text-destructive">
+                                {passwordError}
+                            </p>
+                        )}
+                    </div>
+                </div>
+
+                <DialogFooter>
+                    <Button
+                        variant="outline"
+                        onClick={() => handleOpenChange(false)}
+                    >
+                        Cancel
+                    </Button>
+                    <Button onClick={handleCreate} disabled={!canCreate}>
+                        {isCreating ? "Creating..." : "Create Bundle"}
+                    </Button>
+                </DialogFooter>
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -0,0 +1,156 @@
+import { useState } from "react";
+import { api } from "@/lib/api";
+import {
+    Dialog,
+    DialogContent,
+    DialogDescription,
+    DialogHeader,
+    DialogTitle,
+} from "@/components/ui/dialog";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { StatusAlert } from "@/components/shared/StatusAlert";
+import { StatusBadge } from "@/components/certificate/StatusBadge";
+import { formatDate } from "@/lib/theme";
+import { StatusPreview } from "@/types";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { AlertCircleIcon, ArrowRight01Icon } from "@hugeicons/core-free-icons";
+
+interface StatusPreviewDialogProps {
+    open: boolean;
+    onClose: () => void;
+}
+
+function errorMessage(err: unknown, fallback: string): string {
+    if (typeof err === "string") return err;
+    if (err instanceof Error) return err.message;
+    return fallback;
+}
+
+// Shows what the inventory would look like on a chosen date, to plan renewals
+// ahead of a maintenance window. Only certificates whose status changes by then
+// or that still need a renewal are listed.
+export function StatusPreviewDialog({ open, onClose }: StatusPreviewDialogProps) {
+    const [date, setDate] = useState("");
+    const [preview, setPreview] = useState<StatusPreview | null>(null);
+    const [error, setError] = useState<string | null>(null);
+    const [isLoading, setIsLoading] = useState(false);
+
+    const handleClose = () => {
+        setDate("");
+        setPreview(null);
+        setError(null);
+        onClose();
+    };
+
+    const handlePreview = async () => {
+        if (!date) return;
+        setError(null);
+        setIsLoading(true);
+        try {
+            // Evaluate at the end of the chosen day, local time
+            const at = new Date(`${date}T23:59:59`);
+            setPreview(await api.previewStatusAt(Math.floor(at.getTime() / 1000)));
+        } catch (err) {
+            setError(errorMessage(err, "Failed to preview statuses"));
+        } finally {
+            setIsLoading(false);
+        }
+    };
+
+    const relevant =
+        preview?.certificates.filter((c) => c.changed || c.needs_renewal) ?? [];
+
+    return (
+        <Dialog open={open} onOpenChange={(o) => { if (!o) handleClose(); }}>
+            <DialogContent className="sm:max-w-lg">
+                <DialogHeader>
+                    <DialogTitle>Preview Statuses</DialogTitle>
+                    <DialogDescription>
+                        See which certificates will be expiring or expired on a
+                        future date. Nothing is changed.
+                    </DialogDescription>
+                </DialogHeader>
+
+                <div className="space-y-4">
+                    <div className="flex gap-2">
+                        <Input
+                            type="date"
+                            value={date}
+                            onChange={(e) => setDate(e.target.value)}
+                        />
+                        <Button onClick={handlePreview} disabled={!date || isLoading}>
+                            {isLoading ? "Previewing..." : "Preview"}
+                        </Button>
+                    </div>
+
+                    {preview && (
+                        <>
+                            <p className="text-xs text-muted-foreground">
+                                On {formatDate(preview.at)}: {preview.active} active,{" "}
+                                {preview.expiring} expiring, {preview.expired} expired,{" "}
+                                {preview.pending} pending — {preview.needs_renewal}{" "}
+                                without a renewal in progress.
+                            </p>
+
+                            <div className="max-h-80 space-y-2 overflow-y-auto">
+                                {relevant.length === 0 && (
+                                    <p className="text-xs text-muted-foreground">
+                                        No status changes by this date.
+                                    </p>
+                                )}
+                                {relevant.map((cert) => (
+                                    <div
+                                        key={cert.hostname}
+                                        className="flex items-center justify-between gap-2 border border-border px-3 py-2"
+                                    >
+                                        <div className="min-w-0">
+                                            <p className="text-xs font-mono font-medium text-foreground truncate">
+                                                {cert.hostname}
+                                            </p>
+                                            <p className="text-xs text-muted-foreground">
+                                                {cert.expires_at
+                                                    ? `Expires: ${formatDate(cert.expires_at)}`
+                                                    : "No certificate yet"}
+                                                {cert.has_pending_csr && " · renewal in progress"}
+                                                {cert.needs_renewal && " · needs renewal"}
+                                            </p>
+                                        </div>
+                                        <div className="flex items-center gap-1 shrink-0">
+                                            <StatusBadge status={cert.current_status} />
+                                            {cert.changed && (
+                                                <>
+                                                    <HugeiconsIcon
+                                                        icon={ArrowRight01Icon}
+                                                        className="size-3 text-muted-foreground"
+                                                        strokeWidth={2}
+                                                    />
+                                                    <StatusBadge status={cert.status} />
+                                                </>
+                                            )}
+                                        </div>
+                                    </div>
+                                ))}
+                            </div>
+                        </>
+                    )}
+
+                    {error && (
+                        <StatusAlert
+                            variant="destructive"
+                            icon={
+                                <HugeiconsIcon
+                                    icon={AlertCircleIcon}
+                                    className="size-4"
+                                    strokeWidth={2}
+                                />
+                            }
+                        >
+                            {error}
+                        </StatusAlert>
+                    )}
+                </div>
+            </DialogContent>
+        </Dialog>
+    );
+}
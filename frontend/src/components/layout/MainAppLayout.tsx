@@ -3,6 +3,7 @@ import { AnimatePresence } from "motion/react";
 import { AppHeader } from "../shared/AppHeader";
 import { MatrixRain } from "../shared/MatrixRain";
 import { AnimatedOutlet } from "../shared/AnimatedOutlet";
+import { QuickSearchDialog } from "../shared/QuickSearchDialog";
 import { useAppStore } from "@/stores/useAppStore";
 
 /**
@@ -61,6 +62,9 @@ export function MainAppLayout() {
                 {/* Child routes render here with animation */}
                 <AnimatedOutlet className="max-w-4xl mx-auto px-4 sm:px-6 lg:px-8 py-8" />
             </main>
+
+            {/* Ctrl+K quick-open */}
+            <QuickSearchDialog />
         </div>
     );
 }
@@ -0,0 +1,185 @@
+import { useCallback, useEffect, useState } from "react";
+import {
+    Card,
+    CardContent,
+    CardDescription,
+    CardHeader,
+    CardTitle,
+} from "@/components/ui/card";
+import { Button } from "@/components/ui/button";
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
+import { api } from "@/lib/api";
+import { formatDateTime } from "@/lib/theme";
+import { AuditFilter, AuditLogPage } from "@/types";
+import { toast } from "sonner";
+
+const PAGE_SIZE = 20;
+
+const EVENT_TYPES: Record<string, string> = {
+    unlock: "Unlock",
+    unlock_failed: "Failed unlock",
+    key_export: "Key export",
+    password_change: "Password change",
+    backup_restore: "Backup restore",
+    database_reset: "Database reset",
+    agent_enroll: "Agent enrollment",
+    agent_revoke: "Agent revocation",
+    agent_hostnames: "Agent certificates",
+};
+
+export function AuditLogCard({ className }: { className?: string }) {
+    const [eventType, setEventType] = useState("all");
+    const [offset, setOffset] = useState(0);
+    const [page, setPage] = useState<AuditLogPage | null>(null);
+    const [isLoading, setIsLoading] = useState(false);
+    const [isExporting, setIsExporting] = useState(false);
+
+    const buildFilter = useCallback(
+        (): AuditFilter =>
+            ({
+                event_types: eventType === "all" ? undefined : [eventType],
+            }) as AuditFilter,
+        [eventType],
+    );
+
+    const load = useCallback(async () => {
+        setIsLoading(true);
+        try {
+            setPage(await api.getAuditLog(buildFilter(), PAGE_SIZE, offset));
+        } catch {
+            setPage(null);
+        } finally {
+            setIsLoading(false);
+        }
+    }, [buildFilter, offset]);
+
+    useEffect(() => {
+        load();
+    }, [load]);
+
+    // A new filter starts from the first page
+    useEffect(() => {
+        setOffset(0);
+    }, [eventType]);
+
+    const handleExport = async () => {
+        setIsExporting(true);
+        try {
+            await api.exportAuditLogCSV(buildFilter());
+        } catch (err) {
+            toast.error(
+                err instanceof Error ? err.message : "Failed to export audit log",
+            );
+        } finally {
+            setIsExporting(false);
+        }
+    };
+
+    const total = page?.total ?? 0;
+
+    return (
+        <Card className={`shadow-sm border-border ${className ?? ""}`}>
+            <CardHeader>
+                <CardTitle>Audit Log</CardTitle>
+                <CardDescription>
+                    Unlocks, key exports, password changes, restores and resets.
+                    The log is kept in backups and cannot be cleared.
+                </CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-4">
+                <div className="flex items-center justify-between gap-4">
+                    <Select value={eventType} onValueChange={setEventType}>
+                        <SelectTrigger size="sm" className="w-[220px]">
+                            <SelectValue />
+                        </SelectTrigger>
+                        <SelectContent>
+                            <SelectItem value="all">All events</SelectItem>
+                            {Object.entries(EVENT_TYPES).map(([value, label]) => (
+                                <SelectItem key={value} value={value}>
+                                    {label}
+                                </SelectItem>
+                            ))}
+                        </SelectContent>
+                    </Select>
+                    <Button
+                        size="sm"
+                        variant="outline"
+                        onClick={handleExport}
+                        disabled={isExporting || total === 0}
+                    >
+                        {isExporting ? "Exporting..." : "Export CSV"}
+                    </Button>
+                </div>
+
+                {page === null ? (
+                    <p className="text-sm text-muted-foreground">
+                        {isLoading ? "Loading..." : "Audit log unavailable"}
+                    </p>
+                ) : total === 0 ? (
+                    <p className="text-sm text-muted-foreground">
+                        No audit entries match this filter.
+                    </p>
+                ) : (
+                    <ul className="space-y-2">
+                        {page.entries.map((entry) => (
+                            <li
+                                key={entry.id}
+                                className="border border-border p-3 text-sm"
+                            >
+                                <div className="flex items-center justify-between gap-4">
+                                    <span className="font-medium">
+                                        {EVENT_TYPES[entry.event_type] ??
+                                            entry.event_type}
+                                        {entry.hostname && ` · ${entry.hostname}`}
+                                    </span>
+                                    <span className="shrink-0 text-muted-foreground">
+                                        {formatDateTime(entry.created_at)}
+                                    </span>
+                                </div>
+                                <div className="text-muted-foreground">
+                                    {entry.message}
+                                    {entry.actor && ` · ${entry.actor}`}
+                                </div>
+                            </li>
+                        ))}
+                    </ul>
+                )}
+
+                {total > PAGE_SIZE && (
+                    <div className="flex items-center justify-between">
+                        <span className="text-sm text-muted-foreground">
+                            {offset + 1}–{Math.min(offset + PAGE_SIZE, total)} of{" "}
+                            {total}
+                        </span>
+                        <div className="flex gap-2">
+                            <Button
+                                variant="outline"
+                                size="sm"
+                                disabled={offset === 0 || isLoading}
+                                onClick={() =>
+                                    setOffset(Math.max(offset - PAGE_SIZE, 0))
+                                }
+                            >
+                                Previous
+                            </Button>
+                            <Button
+                                variant="outline"
+                                size="sm"
+                                disabled={offset + PAGE_SIZE >= total || isLoading}
+                                onClick={() => setOffset(offset + PAGE_SIZE)}
+                            >
+                                Next
+                            </Button>
+                        </div>
+                    </div>
+                )}
+            </CardContent>
+        </Card>
+    );
+}
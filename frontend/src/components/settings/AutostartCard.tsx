@@ -0,0 +1,73 @@
+import { useEffect, useState } from "react";
+import {
+    Card,
+    CardContent,
+    CardDescription,
+    CardHeader,
+    CardTitle,
+} from "@/components/ui/card";
+import { Label } from "@/components/ui/label";
+import { Switch } from "@/components/ui/switch";
+import { api } from "@/lib/api";
+import { AutostartStatus } from "@/types";
+import { toast } from "sonner";
+
+export function AutostartCard({ className }: { className?: string }) {
+    const [status, setStatus] = useState<AutostartStatus | null>(null);
+    const [saving, setSaving] = useState(false);
+
+    useEffect(() => {
+        api.getAutostart()
+            .then(setStatus)
+            .catch((err) =>
+                toast.error(
+                    err instanceof Error
+                        ? err.message
+                        : "Failed to read start on login",
+                ),
+            );
+    }, []);
+
+    const handleChange = async (enabled: boolean) => {
+        setSaving(true);
+        try {
+            await api.setAutostart(enabled);
+            setStatus(await api.getAutostart());
+        } catch (err) {
+            toast.error(
+                err instanceof Error
+                    ? err.message
+                    : "Failed to change start on login",
+            );
+        } finally {
+            setSaving(false);
+        }
+    };
+
+    if (!status?.supported) return null;
+
+    return (
+        <Card className={`shadow-sm border-border ${className ?? ""}`}>
+            <CardHeader>
+                <CardTitle>Start on Login</CardTitle>
+                <CardDescription>
+                    Start hidden in the tray and locked when you log in, so
+                    expiry monitoring is always running
+                </CardDescription>
+            </CardHeader>
+            <CardContent>
+                <div className="flex items-center justify-between gap-4">
+                    <Label htmlFor="autostart">
+                        Start PaddockControl on login
+                    </Label>
+                    <Switch
+                        id="autostart"
+                        checked={status.enabled}
+                        onCheckedChange={handleChange}
+                        disabled={saving}
+                    />
+                </div>
+            </CardContent>
+        </Card>
+    );
+}
@@ -0,0 +1,196 @@
+import { useState } from "react";
+import { api } from "@/lib/api";
+import { toast } from "sonner";
+import {
+    Dialog,
+    DialogContent,
+    DialogDescription,
+    DialogHeader,
+    DialogTitle,
+} from "@/components/ui/dialog";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { StatusAlert } from "@/components/shared/StatusAlert";
+import { LoadingSpinner } from "@/components/shared/LoadingSpinner";
+import { StatusBadge } from "@/components/certificate/StatusBadge";
+import { formatDate } from "@/lib/theme";
+import { BackupPeekInfo, CertificateListItem } from "@/types";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { AlertCircleIcon, Download04Icon } from "@hugeicons/core-free-icons";
+
+interface BrowseBackupDialogProps {
+    open: boolean;
+    onClose: () => void;
+}
+
+function errorMessage(err: unknown, fallback: string): string {
+    if (typeof err === "string") return err;
+    if (err instanceof Error) return err.message;
+    return fallback;
+}
+
+// Mounts a backup file as a read-only view: its certificates can be searched
+// and downloaded without importing or restoring anything. The view is
+// unmounted when the dialog closes.
+export function BrowseBackupDialog({ open, onClose }: BrowseBackupDialogProps) {
+    const [info, setInfo] = useState<BackupPeekInfo | null>(null);
+    const [certificates, setCertificates] = useState<CertificateListItem[]>([]);
+    const [search, setSearch] = useState("");
+    const [error, setError] = useState<string | null>(null);
+    const [isProcessing, setIsProcessing] = useState(false);
+
+    const handleClose = () => {
+        if (info) {
+            api.closeBackupView().catch((err) =>
+                console.error("Failed to close backup view:", err),
+            );
+        }
+        setInfo(null);
+        setCertificates([]);
+        setSearch("");
+        setError(null);
+        onClose();
+    };
+
+    const handleSelectFile = async () => {
+        setError(null);
+        try {
+            const path = await api.selectBackupFile();
+            if (!path) return;
+
+            setIsProcessing(true);
+            const peek = await api.openBackupReadOnly(path);
+            const certs = await api.listBackupViewCertificates({
+                sort_by: "hostname",
+                sort_order: "asc",
+            });
+            setInfo(peek);
+            setCertificates(certs ?? []);
+        } catch (err) {
+            setError(errorMessage(err, "Failed to open backup"));
+        } finally {
+            setIsProcessing(false);
+        }
+    };
+
+    const handleDownload = async (hostname: string) => {
+        try {
+            await api.saveBackupViewCertificateToFile(hostname);
+        } catch (err) {
+            toast.error(errorMessage(err, "Failed to save certificate"));
+        }
+    };
+
+    const query = search.trim().toLowerCase();
+    const visible = query
+        ? certificates.filter(
+              (c) =>
+                  c.hostname.toLowerCase().includes(query) ||
+                  c.sans?.some((san) => san.toLowerCase().includes(query)),
+          )
+        : certificates;
+
+    return (
+        <Dialog open={open} onOpenChange={(o) => { if (!o) handleClose(); }}>
+            <DialogContent className="sm:max-w-lg">
+                <DialogHeader>
+                    <DialogTitle>Browse Backup</DialogTitle>
+                    <DialogDescription>
+                        {info
+                            ? `${info.certificate_count} certificate${info.certificate_count !== 1 ? "s" : ""}${info.ca_name ? ` · ${info.ca_name}` : ""} — read-only, nothing is imported.`
+                            : "Open a backup file to look through its certificates without restoring it."}
+                    </DialogDescription>
+                </DialogHeader>
+
+                <div className="space-y-4">
+                    {!info && (
+                        <Button
+                            onClick={handleSelectFile}
+                            disabled={isProcessing}
+                            className="w-full"
+                        >
+                            {isProcessing ? "Opening backup..." : "Select Backup File (.db)"}
+                        </Button>
+                    )}
+
+                    {info && (
+                        <>
+                            <Input
+                                placeholder="Search hostname or SAN"
+                                value={search}
+                                onChange={(e) => setSearch(e.target.value)}
+                                autoFocus
+                            />
+
+                            <div className="max-h-80 space-y-2 overflow-y-auto">
+                                {visible.length === 0 && (
+                                    <p className="text-xs text-muted-foreground">
+                                        No matching certificates in this backup.
+                                    </p>
+                                )}
+                                {visible.map((cert) => (
+                                    <div
+                                        key={cert.hostname}
+                                        className="flex items-center justify-between gap-2 border border-border px-3 py-2"
+                                    >
+                                        <div className="min-w-0">
+                                            <p className="text-xs font-mono font-medium text-foreground truncate">
+                                                {cert.hostname}
+                                            </p>
+                                            <p className="text-xs text-muted-foreground">
+                                                {cert.expires_at
+                                                    ? `Expires: ${formatDate(cert.expires_at)}`
+                                                    : `Created: ${formatDate(cert.created_at)}`}
+                                            </p>
+                                        </div>
+                                        <div className="flex items-center gap-2 shrink-0">
+                                            <StatusBadge status={cert.status} />
+                                            <Button
+                                                variant="ghost"
+                                                size="icon-xs"
+                                                onClick={() => handleDownload(cert.hostname)}
+                                                disabled={cert.status === "pending"}
+                                                title="Download certificate"
+                                            >
+                                                <HugeiconsIcon
+                                                    icon={Download04Icon}
+                                                    className="size-4"
+                                                    strokeWidth={2}
+                                                />
+                                            </Button>
+                                        </div>
+                                    </div>
+                                ))}
+                            </div>
+
+                            <Button variant="outline" onClick={handleClose} className="w-full">
+                                Close
+                            </Button>
+                        </>
+                    )}
+
+                    {error && (
+                        <StatusAlert
+                            variant="destructive"
+                            icon={
+                                <HugeiconsIcon
+                                    icon={AlertCircleIcon}
+                                    className="size-4"
+                                    strokeWidth={2}
+                                />
+                            }
+                        >
+                            {error}
+                        </StatusAlert>
+                    )}
+
+                    {isProcessing && (
+                        <div className="flex items-center justify-center py-2">
+                            <LoadingSpinner text="Processing..." />
+                        </div>
+                    )}
+                </div>
+            </DialogContent>
+        </Dialog>
+    );
+}
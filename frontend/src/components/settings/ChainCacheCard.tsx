@@ -0,0 +1,95 @@
+import { useCallback, useEffect, useState } from "react";
+import {
+    Card,
+    CardContent,
+    CardDescription,
+    CardHeader,
+    CardTitle,
+} from "@/components/ui/card";
+import { Button } from "@/components/ui/button";
+import { api } from "@/lib/api";
+import { ChainCacheStats } from "@/types";
+import { toast } from "sonner";
+
+export function ChainCacheCard({ className }: { className?: string }) {
+    const [stats, setStats] = useState<ChainCacheStats | null>(null);
+    const [clearing, setClearing] = useState(false);
+
+    const load = useCallback(async () => {
+        try {
+            setStats((await api.getHealthStatus()).chain_cache);
+        } catch {
+            setStats(null);
+        }
+    }, []);
+
+    useEffect(() => {
+        load();
+    }, [load]);
+
+    const handleClear = async () => {
+        setClearing(true);
+        try {
+            const removed = await api.clearChainCache();
+            toast.success(
+                `${removed} cached issuer certificate${removed === 1 ? "" : "s"} cleared`,
+            );
+            await load();
+        } catch (err) {
+            toast.error(
+                err instanceof Error ? err.message : "Failed to clear chain cache",
+            );
+        } finally {
+            setClearing(false);
+        }
+    };
+
+    const lookups = stats ? stats.hits + stats.misses : 0;
+
+    return (
+        <Card className={`shadow-sm border-border ${className ?? ""}`}>
+            <CardHeader>
+                <CardTitle>Chain Cache</CardTitle>
+                <CardDescription>
+                    Issuer certificates fetched from AIA URLs are reused until
+                    they expire from the cache
+                </CardDescription>
+            </CardHeader>
+            <CardContent>
+                <div className="flex items-center justify-between gap-4 text-sm">
+                    <div className="text-muted-foreground">
+                        {stats ? (
+                            stats.ttl_seconds === 0 ? (
+                                "Disabled in the configuration"
+                            ) : (
+                                <>
+                                    {stats.entries} of {stats.max_entries}{" "}
+                                    cached · kept{" "}
+                                    {Math.round(stats.ttl_seconds / 60)} min
+                                    {lookups > 0 &&
+                                        ` · ${Math.round((stats.hits / lookups) * 100)}% hits`}
+                                    {stats.evictions > 0 &&
+                                        ` · ${stats.evictions} evicted`}
+                                </>
+                            )
+                        ) : (
+                            "Cache statistics unavailable"
+                        )}
+                    </div>
+                    <Button
+                        size="sm"
+                        variant="outline"
+                        onClick={handleClear}
+                        disabled={clearing}
+                    >
+                        Clear
+                    </Button>
+                </div>
+                <p className="text-xs text-muted-foreground mt-2">
+                    Clear it when a CA rotated its intermediates, so chains
+                    are fetched again.
+                </p>
+            </CardContent>
+        </Card>
+    );
+}
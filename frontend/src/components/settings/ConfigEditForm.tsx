@@ -179,7 +179,7 @@ export function ConfigEditForm({
                                             required:
                                                 "Hostname suffix is required",
                                             pattern: {
-                                                value: /^\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*\.[a-zA-Z]{2,}$/,
+                                                value: /^\.[\p{L}\p{N}]([\p{L}\p{N}\p{M}-]{0,61}[\p{L}\p{N}\p{M}])?(\.[\p{L}\p{N}]([\p{L}\p{N}\p{M}-]{0,61}[\p{L}\p{N}\p{M}])?)*\.(\p{L}{2,}|xn--[a-zA-Z0-9-]+)$/u,
                                                 message:
                                                     "Hostname suffix must start with a dot and be a valid domain (e.g., .example.lan)",
                                             },
@@ -248,6 +248,44 @@ export function ConfigEditForm({
                                         ⚠️ Changes only affect new certificates
                                     </p>
                                 </div>
+
+                                <div className="space-y-2">
+                                    <Label htmlFor="expiring_threshold_days">
+                                        Expiring Soon Threshold (days) *
+                                    </Label>
+                                    <Input
+                                        id="expiring_threshold_days"
+                                        type="number"
+                                        {...register("expiring_threshold_days", {
+                                            required:
+                                                "Expiring threshold is required",
+                                            valueAsNumber: true,
+                                            min: {
+                                                value: 1,
+                                                message:
+                                                    "Expiring threshold must be at least 1 day",
+                                            },
+                                        })}
+                                        className={
+                                            errors.expiring_threshold_days
+                                                ? "border-destructive"
+                                                : ""
+                                        }
+                                        disabled={isLoading}
+                                    />
+                                    {errors.expiring_threshold_days && (
+                                        <p className="text-sm text-destructive mt-1">
+                                            {
+                                                errors.expiring_threshold_days
+                                                    .message
+                                            }
+                                        </p>
+                                    )}
+                                    <p className="text-xs text-muted-foreground mt-1">
+                                        Certificates are flagged as expiring
+                                        this many days before expiration
+                                    </p>
+                                </div>
                             </div>
                         </CardContent>
                     </Card>
@@ -271,9 +309,9 @@ export function ConfigEditForm({
                                             required:
                                                 "Organization is required",
                                             maxLength: {
-                                                value: 255,
+                                                value: 64,
                                                 message:
-                                                    "Organization must not exceed 255 characters",
+                                                    "Organization must not exceed 64 characters",
                                             },
                                         })}
                                         className={
@@ -303,9 +341,9 @@ export function ConfigEditForm({
                                             "default_organizational_unit",
                                             {
                                                 maxLength: {
-                                                    value: 255,
+                                                    value: 64,
                                                     message:
-                                                        "Organizational unit must not exceed 255 characters",
+                                                        "Organizational unit must not exceed 64 characters",
                                                 },
                                             },
                                         )}
@@ -334,9 +372,9 @@ export function ConfigEditForm({
                                         {...register("default_city", {
                                             required: "City is required",
                                             maxLength: {
-                                                value: 255,
+                                                value: 128,
                                                 message:
-                                                    "City must not exceed 255 characters",
+                                                    "City must not exceed 128 characters",
                                             },
                                         })}
                                         className={
@@ -363,9 +401,9 @@ export function ConfigEditForm({
                                             required:
                                                 "State/Province is required",
                                             maxLength: {
-                                                value: 255,
+                                                value: 128,
                                                 message:
-                                                    "State must not exceed 255 characters",
+                                                    "State must not exceed 128 characters",
                                             },
                                         })}
                                         className={
@@ -426,11 +464,22 @@ export function ConfigEditForm({
                                         control={control}
                                         rules={{
                                             required: "Key size is required",
-                                            validate: (value) =>
-                                                [2048, 3072, 4096].includes(
-                                                    value,
-                                                ) ||
-                                                "Key size must be 2048, 3072, or 4096",
+                                            validate: (value, values) => {
+                                                if (
+                                                    ![2048, 3072, 4096].includes(
+                                                        value,
+                                                    )
+                                                ) {
+                                                    return "Key size must be 2048, 3072, or 4096";
+                                                }
+                                                if (
+                                                    values.fips_mode &&
+                                                    value < 3072
+                                                ) {
+                                                    return "FIPS mode requires at least 3072 bits";
+                                                }
+                                                return true;
+                                            },
                                         }}
                                         render={({ field }) => (
                                             <Select
@@ -471,6 +520,610 @@ export function ConfigEditForm({
                                         </p>
                                     )}
                                 </div>
+
+                                <div className="space-y-2">
+                                    <Label htmlFor="key_pool_size">
+                                        Pre-generated Keys
+                                    </Label>
+                                    <Input
+                                        id="key_pool_size"
+                                        type="number"
+                                        {...register("key_pool_size", {
+                                            valueAsNumber: true,
+                                            min: {
+                                                value: 0,
+                                                message: "Must be 0 or more",
+                                            },
+                                            max: {
+                                                value: 10,
+                                                message: "Must be at most 10",
+                                            },
+                                        })}
+                                        className={
+                                            errors.key_pool_size
+                                                ? "border-destructive"
+                                                : ""
+                                        }
+                                        disabled={isLoading}
+                                    />
+                                    {errors.key_pool_size && (
+                                        <p className="text-sm text-destructive mt-1">
+                                            {errors.key_pool_size.message}
+                                        </p>
+                                    )}
+                                    <p className="text-xs text-muted-foreground mt-1">
+                                        Keys of the default size generated in
+                                        the background while unlocked, so new
+                                        CSRs are instant. Set to 0 to disable.
+                                    </p>
+                                </div>
+
+                                <div className="space-y-2">
+                                    <Label htmlFor="trash_retention_days">
+                                        Trash Retention (days)
+                                    </Label>
+                                    <Input
+                                        id="trash_retention_days"
+                                        type="number"
+                                        {...register("trash_retention_days", {
+                                            valueAsNumber: true,
+                                            min: {
+                                                value: 0,
+                                                message: "Must be 0 or more",
+                                            },
+                                            max: {
+                                                value: 365,
+                                                message: "Must be at most 365",
+                                            },
+                                        })}
+                                        className={
+                                            errors.trash_retention_days
+                                                ? "border-destructive"
+                                                : ""
+                                        }
+                                        disabled={isLoading}
+                                    />
+                                    {errors.trash_retention_days && (
+                                        <p className="text-sm text-destructive mt-1">
+                                            {errors.trash_retention_days.message}
+                                        </p>
+                                    )}
+                                    <p className="text-xs text-muted-foreground mt-1">
+                                        Deleted certificates can be restored
+                                        from the trash for this many days. Set
+                                        to 0 to delete them permanently.
+                                    </p>
+                                </div>
+                            </div>
+                        </CardContent>
+                    </Card>
+
+                    {/* Notes */}
+                    <Card>
+                        <CardHeader>
+                            <CardTitle className="text-lg">Notes</CardTitle>
+                        </CardHeader>
+                        <CardContent className="space-y-2">
+                            <Label htmlFor="ticket_pattern">
+                                Ticket ID Pattern
+                            </Label>
+                            <Input
+                                id="ticket_pattern"
+                                placeholder="CHG\d{7}"
+                                className="font-mono"
+                                maxLength={256}
+                                {...register("ticket_pattern")}
+                                disabled={isLoading}
+                            />
+                            <p className="text-xs text-muted-foreground mt-1">
+                                Regular expression matching ticket IDs in
+                                certificate notes. Matches become links to the
+                                certificates mentioning the same ticket. URLs
+                                are always linked.
+                            </p>
+                        </CardContent>
+                    </Card>
+
+                    {/* Network */}
+                    <Card>
+                        <CardHeader>
+                            <CardTitle className="text-lg">Network</CardTitle>
+                        </CardHeader>
+                        <CardContent className="space-y-4">
+                            <div className="space-y-2">
+                                <Label htmlFor="clock_check_url">
+                                    Clock Check URL
+                                </Label>
+                                <Input
+                                    id="clock_check_url"
+                                    placeholder="https://www.cloudflare.com"
+                                    {...register("clock_check_url")}
+                                    disabled={isLoading}
+                                />
+                                <p className="text-xs text-muted-foreground mt-1">
+                                    Its HTTP Date header is compared against the
+                                    local clock at startup. Leave empty for the
+                                    default.
+                                </p>
+                            </div>
+
+                            <div className="flex items-center gap-2">
+                                <input
+                                    id="air_gapped"
+                                    type="checkbox"
+                                    className="h-4 w-4"
+                                    {...register("air_gapped")}
+                                    disabled={isLoading}
+                                />
+                                <Label htmlFor="air_gapped">
+                                    Air-gapped mode (skip network checks)
+                                </Label>
+                            </div>
+                        </CardContent>
+                    </Card>
+
+                    {/* Security */}
+                    <Card>
+                        <CardHeader>
+                            <CardTitle className="text-lg">Security</CardTitle>
+                        </CardHeader>
+                        <CardContent className="space-y-2">
+                            <div className="flex items-center gap-2">
+                                <input
+                                    id="fips_mode"
+                                    type="checkbox"
+                                    className="h-4 w-4"
+                                    {...register("fips_mode", {
+                                        deps: ["default_key_size"],
+                                    })}
+                                    disabled={isLoading}
+                                />
+                                <Label htmlFor="fips_mode">
+                                    FIPS-compatible crypto mode
+                                </Label>
+                            </div>
+                            <p className="text-xs text-muted-foreground">
+                                Only FIPS-approved algorithms: RSA keys of 3072
+                                bits or more, ECDSA on P-256 or larger curves,
+                                SHA-2 signatures. Certificates that do not
+                                comply are refused on upload and import.
+                            </p>
+
+                            <div className="space-y-2 pt-2">
+                                <Label htmlFor="kdf_profile">
+                                    Password Key Derivation
+                                </Label>
+                                <Controller
+                                    name="kdf_profile"
+                                    control={control}
+                                    render={({ field }) => (
+                                        <Select
+                                            value={field.value}
+                                            onValueChange={field.onChange}
+                                            disabled={isLoading}
+                                        >
+                                            <SelectTrigger
+                                                id="kdf_profile"
+                                                className="w-full"
+                                            >
+                                                <SelectValue />
+                                            </SelectTrigger>
+                                            <SelectContent>
+                                                <SelectItem value="standard">
+                                                    Standard (64 MiB, 3 passes)
+                                                </SelectItem>
+                                                <SelectItem value="constrained">
+                                                    Constrained (32 MiB, 4
+                                                    passes)
+                                                </SelectItem>
+                                            </SelectContent>
+                                        </Select>
+                                    )}
+                                />
+                                <p className="text-xs text-muted-foreground">
+                                    Argon2id cost of passwords set from now on;
+                                    detected at first run, constrained on 32-bit
+                                    builds. Existing passwords keep their cost
+                                    until changed.
+                                </p>
+                            </div>
+                        </CardContent>
+                    </Card>
+
+                    {/* Backups */}
+                    <Card>
+                        <CardHeader>
+                            <CardTitle className="text-lg">Backups</CardTitle>
+                        </CardHeader>
+                        <CardContent className="space-y-4">
+                            <div className="space-y-2">
+                                <Label htmlFor="backup_freshness_max_writes">
+                                    Changes Before Backup Warning
+                                </Label>
+                                <Input
+                                    id="backup_freshness_max_writes"
+                                    type="number"
+                                    {...register("backup_freshness_max_writes", {
+                                        valueAsNumber: true,
+                                        min: {
+                                            value: 0,
+                                            message:
+                                                "Must be 0 or more",
+                                        },
+                                    })}
+                                    className={
+                                        errors.backup_freshness_max_writes
+                                            ? "border-destructive"
+                                            : ""
+                                    }
+                                    disabled={isLoading}
+                                />
+                                {errors.backup_freshness_max_writes && (
+                                    <p className="text-sm text-destructive mt-1">
+                                        {
+                                            errors.backup_freshness_max_writes
+                                                .message
+                                        }
+                                    </p>
+                                )}
+                                <p className="text-xs text-muted-foreground mt-1">
+                                    Warn after this many certificate changes
+                                    without a manual backup or export. Set to 0
+                                    to disable.
+                                </p>
+                            </div>
+
+                            <div className="flex items-center gap-2">
+                                <input
+                                    id="backup_freshness_block"
+                                    type="checkbox"
+                                    className="h-4 w-4"
+                                    {...register("backup_freshness_block")}
+                                    disabled={isLoading}
+                                />
+                                <Label htmlFor="backup_freshness_block">
+                                    Block deletes, merges and key changes until
+                                    a backup is taken
+                                </Label>
+                            </div>
+
+                            <div className="space-y-2">
+                                <Label htmlFor="db_size_warn_mb">
+                                    Database Size Warning (MiB)
+                                </Label>
+                                <Input
+                                    id="db_size_warn_mb"
+                                    type="number"
+                                    {...register("db_size_warn_mb", {
+                                        valueAsNumber: true,
+                                        min: {
+                                            value: 0,
+                                            message:
+                                                "Must be 0 or more",
+                                        },
+                                    })}
+                                    className={
+                                        errors.db_size_warn_mb
+                                            ? "border-destructive"
+                                            : ""
+                                    }
+                                    disabled={isLoading}
+                                />
+                                {errors.db_size_warn_mb && (
+                                    <p className="text-sm text-destructive mt-1">
+                                        {errors.db_size_warn_mb.message}
+                                    </p>
+                                )}
+                                <p className="text-xs text-muted-foreground mt-1">
+                                    Warn when the database grows past this size,
+                                    with the biggest contributors and cleanup
+                                    actions. Set to 0 to disable.
+                                </p>
+                            </div>
+
+                            <div className="space-y-2">
+                                <Label htmlFor="aia_cache_ttl_minutes">
+                                    Chain Cache Lifetime (minutes)
+                                </Label>
+                                <Input
+                                    id="aia_cache_ttl_minutes"
+                                    type="number"
+                                    {...register("aia_cache_ttl_minutes", {
+                                        valueAsNumber: true,
+                                        min: {
+                                            value: 0,
+                                            message:
+                                                "Must be 0 or more",
+                                        },
+                                        max: {
+                                            value: 10080,
+                                            message:
+                                                "Must be at most 10080 (a week)",
+                                        },
+                                    })}
+                                    className={
+                                        errors.aia_cache_ttl_minutes
+                                            ? "border-destructive"
+                                            : ""
+                                    }
+                                    disabled={isLoading}
+                                />
+                                {errors.aia_cache_ttl_minutes && (
+                                    <p className="text-sm text-destructive mt-1">
+                                        {errors.aia_cache_ttl_minutes.message}
+                                    </p>
+                                )}
+                                <p className="text-xs text-muted-foreground mt-1">
+                                    How long issuer certificates fetched from
+                                    AIA URLs are reused. Set to 0 to fetch them
+                                    every time.
+                                </p>
+                            </div>
+                        </CardContent>
+                    </Card>
+
+                    {/* Logs */}
+                    <Card>
+                        <CardHeader>
+                            <CardTitle className="text-lg">Logs</CardTitle>
+                        </CardHeader>
+                        <CardContent className="space-y-4">
+                            <div className="space-y-2">
+                                <Label htmlFor="log_max_size_mb">
+                                    Rotate Log At (MiB)
+                                </Label>
+                                <Input
+                                    id="log_max_size_mb"
+                                    type="number"
+                                    {...register("log_max_size_mb", {
+                                        valueAsNumber: true,
+                                        min: {
+                                            value: 1,
+                                            message:
+                                                "Must be 1 or more",
+                                        },
+                                    })}
+                                    className={
+                                        errors.log_max_size_mb
+                                            ? "border-destructive"
+                                            : ""
+                                    }
+                                    disabled={isLoading}
+                                />
+                                {errors.log_max_size_mb && (
+                                    <p className="text-sm text-destructive mt-1">
+                                        {errors.log_max_size_mb.message}
+                                    </p>
+                                )}
+                                <p className="text-xs text-muted-foreground mt-1">
+                                    Start a new log file once the current one
+                                    reaches this size.
+                                </p>
+                            </div>
+
+                            <div className="space-y-2">
+                                <Label htmlFor="log_max_files">
+                                    Rotated Log Files Kept
+                                </Label>
+                                <Input
+                                    id="log_max_files"
+                                    type="number"
+                                    {...register("log_max_files", {
+                                        valueAsNumber: true,
+                                        min: {
+                                            value: 0,
+                                            message:
+                                                "Must be 0 or more",
+                                        },
+                                    })}
+                                    className={
+                                        errors.log_max_files
+                                            ? "border-destructive"
+                                            : ""
+                                    }
+                                    disabled={isLoading}
+                                />
+                                {errors.log_max_files && (
+                                    <p className="text-sm text-destructive mt-1">
+                                        {errors.log_max_files.message}
+                                    </p>
+                                )}
+                                <p className="text-xs text-muted-foreground mt-1">
+                                    Older rotated files are deleted. Set to 0
+                                    to keep them all.
+                                </p>
+                            </div>
+
+                            <div className="space-y-2">
+                                <Label htmlFor="log_max_age_days">
+                                    Rotated Log Retention (days)
+                                </Label>
+                                <Input
+                                    id="log_max_age_days"
+                                    type="number"
+                                    {...register("log_max_age_days", {
+                                        valueAsNumber: true,
+                                        min: {
+                                            value: 0,
+                                            message:
+                                                "Must be 0 or more",
+                                        },
+                                    })}
+                                    className={
+                                        errors.log_max_age_days
+                                            ? "border-destructive"
+                                            : ""
+                                    }
+                                    disabled={isLoading}
+                                />
+                                {errors.log_max_age_days && (
+                                    <p className="text-sm text-destructive mt-1">
+                                        {errors.log_max_age_days.message}
+                                    </p>
+                                )}
+                                <p className="text-xs text-muted-foreground mt-1">
+                                    Rotated files older than this are deleted.
+                                    Set to 0 to keep them regardless of age.
+                                </p>
+                            </div>
+
+                            <div className="flex items-center gap-2">
+                                <input
+                                    id="log_compress"
+                                    type="checkbox"
+                                    className="h-4 w-4"
+                                    {...register("log_compress")}
+                                    disabled={isLoading}
+                                />
+                                <Label htmlFor="log_compress">
+                                    Compress rotated log files
+                                </Label>
+                            </div>
+                        </CardContent>
+                    </Card>
+
+                    {/* Desktop */}
+                    <Card>
+                        <CardHeader>
+                            <CardTitle className="text-lg">Desktop</CardTitle>
+                        </CardHeader>
+                        <CardContent className="space-y-2">
+                            <div className="flex items-center gap-2">
+                                <input
+                                    id="minimize_to_tray"
+                                    type="checkbox"
+                                    className="h-4 w-4"
+                                    {...register("minimize_to_tray")}
+                                    disabled={isLoading}
+                                />
+                                <Label htmlFor="minimize_to_tray">
+                                    Minimize to the system tray
+                                </Label>
+                            </div>
+                            <p className="text-xs text-muted-foreground">
+                                The minimize button hides the window; reopen it
+                                from the tray icon, whose badge counts the
+                                certificates expiring soon.
+                            </p>
+                            <div className="flex items-center gap-2 pt-2">
+                                <input
+                                    id="run_in_background"
+                                    type="checkbox"
+                                    className="h-4 w-4"
+                                    {...register("run_in_background")}
+                                    disabled={isLoading}
+                                />
+                                <Label htmlFor="run_in_background">
+                                    Keep running in the background when closed
+                                </Label>
+                            </div>
+                            <p className="text-xs text-muted-foreground">
+                                Closing the window leaves the app in the tray,
+                                where expiry checks and scheduled backups keep
+                                running. Use Quit in the tray menu to exit.
+                            </p>
+                            <div className="flex items-center gap-2 pt-2">
+                                <input
+                                    id="expiry_notifications"
+                                    type="checkbox"
+                                    className="h-4 w-4"
+                                    {...register("expiry_notifications")}
+                                    disabled={isLoading}
+                                />
+                                <Label htmlFor="expiry_notifications">
+                                    Notify when certificates start expiring or
+                                    expire
+                                </Label>
+                            </div>
+                        </CardContent>
+                    </Card>
+
+                    {/* Downloads */}
+                    <Card>
+                        <CardHeader>
+                            <CardTitle className="text-lg">Downloads</CardTitle>
+                        </CardHeader>
+                        <CardContent className="space-y-4">
+                            <div className="space-y-2">
+                                <Label htmlFor="download_format">File Format</Label>
+                                <Controller
+                                    name="download_format"
+                                    control={control}
+                                    render={({ field }) => (
+                                        <Select
+                                            value={field.value}
+                                            onValueChange={field.onChange}
+                                            disabled={isLoading}
+                                        >
+                                            <SelectTrigger
+                                                id="download_format"
+                                                className="w-full"
+                                            >
+                                                <SelectValue />
+                                            </SelectTrigger>
+                                            <SelectContent>
+                                                <SelectItem value="pem">
+                                                    PEM (text)
+                                                </SelectItem>
+                                                <SelectItem value="der">
+                                                    DER (binary)
+                                                </SelectItem>
+                                            </SelectContent>
+                                        </Select>
+                                    )}
+                                />
+                                <p className="text-xs text-muted-foreground mt-1">
+                                    DER holds a single certificate, CSR or key:
+                                    chain downloads need PEM.
+                                </p>
+                            </div>
+
+                            <div className="space-y-2">
+                                <Label htmlFor="download_line_endings">Line Endings</Label>
+                                <Controller
+                                    name="download_line_endings"
+                                    control={control}
+                                    render={({ field }) => (
+                                        <Select
+                                            value={field.value}
+                                            onValueChange={field.onChange}
+                                            disabled={isLoading}
+                                        >
+                                            <SelectTrigger
+                                                id="download_line_endings"
+                                                className="w-full"
+                                            >
+                                                <SelectValue />
+                                            </SelectTrigger>
+                                            <SelectContent>
+                                                <SelectItem value="lf">
+                                                    LF (Linux, macOS)
+                                                </SelectItem>
+                                                <SelectItem value="crlf">
+                                                    CRLF (Windows)
+                                                </SelectItem>
+                                            </SelectContent>
+                                        </Select>
+                                    )}
+                                />
+                                <p className="text-xs text-muted-foreground mt-1">
+                                    For appliances that reject PEM files with
+                                    Unix line endings.
+                                </p>
+                            </div>
+
+                            <div className="flex items-center gap-2">
+                                <input
+                                    id="download_text_header"
+                                    type="checkbox"
+                                    className="h-4 w-4"
+                                    {...register("download_text_header")}
+                                    disabled={isLoading}
+                                />
+                                <Label htmlFor="download_text_header">
+                                    Add subject and validity above each
+                                    certificate
+                                </Label>
                             </div>
                         </CardContent>
                     </Card>
@@ -0,0 +1,262 @@
+import { useCallback, useEffect, useState } from "react";
+import {
+    Card,
+    CardContent,
+    CardDescription,
+    CardHeader,
+    CardTitle,
+} from "@/components/ui/card";
+import { Button } from "@/components/ui/button";
+import { StatusAlert } from "@/components/shared/StatusAlert";
+import { ConfirmDialog } from "@/components/shared/ConfirmDialog";
+import { api } from "@/lib/api";
+import { formatFileSize } from "@/lib/theme";
+import { DatabaseContributor, DatabaseUsage } from "@/types";
+import { toast } from "sonner";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { AlertCircleIcon } from "@hugeicons/core-free-icons";
+
+// Entries younger than these are kept by the one-click cleanups
+const HISTORY_RETENTION_DAYS = 365;
+const UPDATE_HISTORY_RETENTION_DAYS = 90;
+
+const CONTRIBUTOR_LABELS: Record<string, string> = {
+    certificates: "Certificates and keys",
+    history: "Certificate history",
+    update_history: "Update history",
+    free: "Free space",
+    other: "Settings and other data",
+};
+
+interface CleanupAction {
+    label: string;
+    description: string;
+    days: number;
+}
+
+const CLEANUP_ACTIONS: Record<string, CleanupAction> = {
+    prune_history: {
+        label: "Prune",
+        description: `Delete certificate history older than ${HISTORY_RETENTION_DAYS} days, then compact the database. An automatic backup is taken first.`,
+        days: HISTORY_RETENTION_DAYS,
+    },
+    prune_update_history: {
+        label: "Prune",
+        description: `Delete update history older than ${UPDATE_HISTORY_RETENTION_DAYS} days, then compact the database. An automatic backup is taken first.`,
+        days: UPDATE_HISTORY_RETENTION_DAYS,
+    },
+    compact: {
+        label: "Compact",
+        description:
+            "Rebuild the database file to release the space left by deleted data.",
+        days: 0,
+    },
+};
+
+export function DatabaseUsageCard({ className }: { className?: string }) {
+    const [usage, setUsage] = useState<DatabaseUsage | null>(null);
+    const [error, setError] = useState<string | null>(null);
+    const [pending, setPending] = useState<DatabaseContributor | null>(null);
+    const [running, setRunning] = useState(false);
+    const [exporting, setExporting] = useState(false);
+
+    const load = useCallback(async () => {
+        try {
+            setUsage(await api.getDatabaseUsage());
+            setError(null);
+        } catch (err) {
+            setError(
+                err instanceof Error
+                    ? err.message
+                    : "Failed to load database usage",
+            );
+        }
+    }, []);
+
+    useEffect(() => {
+        load();
+    }, [load]);
+
+    const handleCleanup = async () => {
+        if (!pending?.cleanup) return;
+        const action = CLEANUP_ACTIONS[pending.cleanup];
+        setRunning(true);
+        try {
+            const result = await api.cleanupDatabase(pending.cleanup, action.days);
+            const reclaimed = Math.max(result.size_before - result.size_after, 0);
+            toast.success(
+                `${result.rows_deleted > 0 ? `${result.rows_deleted} entries removed, ` : ""}${formatFileSize(reclaimed)} reclaimed`,
+            );
+            await load();
+        } catch (err) {
+            toast.error(
+                err instanceof Error ? err.message : "Database cleanup failed",
+            );
+        } finally {
+            setRunning(false);
+            setPending(null);
+        }
+    };
+
+    const handleExportAnonymized = async () => {
+        setExporting(true);
+        try {
+            await api.exportAnonymizedDatabase();
+        } catch (err) {
+            toast.error(
+                err instanceof Error
+                    ? err.message
+                    : "Anonymized export failed",
+            );
+        } finally {
+            setExporting(false);
+        }
+    };
+
+    const handleExportAuditor = async () => {
+        setExporting(true);
+        try {
+            await api.exportAuditorSnapshot();
+        } catch (err) {
+            toast.error(
+                err instanceof Error
+                    ? err.message
+                    : "Auditor snapshot export failed",
+            );
+        } finally {
+            setExporting(false);
+        }
+    };
+
+    return (
+        <Card className={`shadow-sm border-border ${className ?? ""}`}>
+            <CardHeader>
+                <CardTitle>Database Storage</CardTitle>
+                <CardDescription>
+                    {usage
+                        ? `${formatFileSize(usage.size_bytes)} used${usage.warn_bytes > 0 ? ` of a ${formatFileSize(usage.warn_bytes)} warning size` : ""}`
+                        : "What takes up space in the database"}
+                </CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-3">
+                {error && (
+                    <StatusAlert
+                        variant="destructive"
+                        icon={
+                            <HugeiconsIcon
+                                icon={AlertCircleIcon}
+                                className="size-4"
+                                strokeWidth={2}
+                            />
+                        }
+                    >
+                        {error}
+                    </StatusAlert>
+                )}
+
+                {usage?.over_quota && (
+                    <StatusAlert
+                        variant="warning"
+                        icon={
+                            <HugeiconsIcon
+                                icon={AlertCircleIcon}
+                                className="size-4"
+                                strokeWidth={2}
+                            />
+                        }
+                    >
+                        The database is over its warning size. Prune old
+                        history or compact it below, or raise the limit in the
+                        configuration.
+                    </StatusAlert>
+                )}
+
+                {usage && (
+                    <ul className="space-y-2">
+                        {usage.contributors.map((contributor) => (
+                            <li
+                                key={contributor.name}
+                                className="flex items-center justify-between gap-4 text-sm"
+                            >
+                                <div>
+                                    <span className="font-medium">
+                                        {CONTRIBUTOR_LABELS[contributor.name] ??
+                                            contributor.name}
+                                    </span>
+                                    <span className="text-muted-foreground">
+                                        {" "}
+                                        · {formatFileSize(contributor.bytes)}
+                                        {contributor.rows > 0 &&
+                                            ` · ${contributor.rows} entries`}
+                                    </span>
+                                </div>
+                                {contributor.cleanup && (
+                                    <Button
+                                        size="sm"
+                                        variant="outline"
+                                        onClick={() => setPending(contributor)}
+                                        disabled={running}
+                                    >
+                                        {CLEANUP_ACTIONS[contributor.cleanup]
+                                            ?.label ?? contributor.cleanup}
+                                    </Button>
+                                )}
+                            </li>
+                        ))}
+                    </ul>
+                )}
+
+                <div className="flex items-center justify-between gap-4 border-t border-border pt-3 text-sm">
+                    <div>
+                        <span className="font-medium">Anonymized copy</span>
+                        <p className="text-muted-foreground">
+                            For bug reports: hostnames, notes and names are
+                            replaced, keys and certificates are removed.
+                        </p>
+                    </div>
+                    <Button
+                        size="sm"
+                        variant="outline"
+                        onClick={handleExportAnonymized}
+                        disabled={exporting}
+                    >
+                        Export
+                    </Button>
+                </div>
+
+                <div className="flex items-center justify-between gap-4 border-t border-border pt-3 text-sm">
+                    <div>
+                        <span className="font-medium">Auditor snapshot</span>
+                        <p className="text-muted-foreground">
+                            Read-only copy of the inventory and history, with
+                            every private key and unlock method removed.
+                        </p>
+                    </div>
+                    <Button
+                        size="sm"
+                        variant="outline"
+                        onClick={handleExportAuditor}
+                        disabled={exporting}
+                    >
+                        Export
+                    </Button>
+                </div>
+            </CardContent>
+
+            <ConfirmDialog
+                open={pending !== null}
+                title={`Clean up ${CONTRIBUTOR_LABELS[pending?.name ?? ""]?.toLowerCase() ?? "database"}?`}
+                description={
+                    pending?.cleanup
+                        ? CLEANUP_ACTIONS[pending.cleanup]?.description
+                        : undefined
+                }
+                confirmText="Clean Up"
+                isDestructive={pending?.cleanup !== "compact"}
+                isLoading={running}
+                onConfirm={handleCleanup}
+                onCancel={() => setPending(null)}
+            />
+        </Card>
+    );
+}
@@ -0,0 +1,161 @@
+import { useState } from "react";
+import { useForm } from "react-hook-form";
+import { zodResolver } from "@hookform/resolvers/zod";
+import { api } from "@/lib/api";
+import { toast } from "sonner";
+import {
+    exportBackupSchema,
+    type ExportBackupInput,
+} from "@/lib/validation";
+import {
+    Dialog,
+    DialogContent,
+    DialogDescription,
+    DialogFooter,
+    DialogHeader,
+    DialogTitle,
+} from "@/components/ui/dialog";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { StatusAlert } from "@/components/shared/StatusAlert";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { AlertCircleIcon } from "@hugeicons/core-free-icons";
+
+interface ExportBackupDialogProps {
+    open: boolean;
+    onClose: () => void;
+}
+
+export function ExportBackupDialog({ open, onClose }: ExportBackupDialogProps) {
+    const [isLoading, setIsLoading] = useState(false);
+    const [error, setError] = useState<string | null>(null);
+    const [showPasswords, setShowPasswords] = useState(false);
+
+    const {
+        register,
+        handleSubmit,
+        formState: { errors },
+        reset,
+    } = useForm<ExportBackupInput>({
+        resolver: zodResolver(exportBackupSchema),
+    });
+
+    const onSubmit = async (data: ExportBackupInput) => {
+        setIsLoading(true);
+        setError(null);
+        try {
+            await api.exportBackupWithPassword(data.export_password);
+            toast.success("Backup exported");
+            reset();
+            onClose();
+        } catch (err) {
+            const message =
+                typeof err === "string"
+                    ? err
+                    : err instanceof Error
+                      ? err.message
+                      : "Failed to export backup";
+            setError(message);
+            toast.error(message);
+        } finally {
+            setIsLoading(false);
+        }
+    };
+
+    const handleClose = () => {
+        reset();
+        setError(null);
+        onClose();
+    };
+
+    return (
+        <Dialog open={open} onOpenChange={handleClose}>
+            <DialogContent className="sm:max-w-[425px]">
+                <DialogHeader>
+                    <DialogTitle>Export with Password</DialogTitle>
+                    <DialogDescription>
+                        Save a backup protected by its own password, to hand to
+                        another team. Your unlock password and master key are not
+                        included.
+                    </DialogDescription>
+                </DialogHeader>
+
+                <form onSubmit={handleSubmit(onSubmit)} className="space-y-4">
+                    {error && (
+                        <StatusAlert
+                            variant="destructive"
+                            icon={
+                                <HugeiconsIcon
+                                    icon={AlertCircleIcon}
+                                    className="size-4"
+                                    strokeWidth={2}
+                                />
+                            }
+                        >
+                            {error}
+                        </StatusAlert>
+                    )}
+
+                    <div className="space-y-2">
+                        <Label htmlFor="export_password">Export Password</Label>
+                        <div className="relative">
+                            <Input
+                                id="export_password"
+                                type={showPasswords ? "text" : "password"}
+                                placeholder="Enter export password (min 16 chars)"
+                                disabled={isLoading}
+                                {...register("export_password")}
+                                className="pr-16"
+                            />
+                            <button
+                                type="button"
+                                onClick={() => setShowPasswords(!showPasswords)}
+                                className="absolute right-3 top-1/2 -translate-y-1/2 text-muted-foreground text-sm"
+                            >
+                                {showPasswords ? "Hide" : "Show"}
+                            </button>
+                        </div>
+                        {errors.export_password && (
+                            <p className="text-sm text-destructive">
+                                {errors.export_password.message}
+                            </p>
+                        )}
+                    </div>
+
+                    <div className="space-y-2">
+                        <Label htmlFor="export_password_confirm">
+                            Confirm Export Password
+                        </Label>
+                        <Input
+                            id="export_password_confirm"
+                            type={showPasswords ? "text" : "password"}
+                            placeholder="Confirm export password"
+                            disabled={isLoading}
+                            {...register("export_password_confirm")}
+                        />
+                        {errors.export_password_confirm && (
+                            <p className="text-sm text-destructive">
+                                {errors.export_password_confirm.message}
+                            </p>
+                        )}
+                    </div>
+
+                    <DialogFooter>
+                        <Button
+                            type="button"
+                            variant="outline"
+                            onClick={handleClose}
+                            disabled={isLoading}
+                        >
+                            Cancel
+                        </Button>
+                        <Button type="submit" disabled={isLoading}>
+                            {isLoading ? "Exporting..." : "Export"}
+                        </Button>
+                    </DialogFooter>
+                </form>
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -0,0 +1,262 @@
+import { useState } from "react";
+import { useForm } from "react-hook-form";
+import { zodResolver } from "@hookform/resolvers/zod";
+import { api } from "@/lib/api";
+import { toast } from "sonner";
+import {
+    FULL_EXPORT_CONFIRMATION,
+    fullExportSchema,
+    type FullExportInput,
+} from "@/lib/validation";
+import {
+    Dialog,
+    DialogContent,
+    DialogDescription,
+    DialogFooter,
+    DialogHeader,
+    DialogTitle,
+} from "@/components/ui/dialog";
+import { Button } from "@/components/ui/button";
+import { Checkbox } from "@/components/ui/checkbox";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { StatusAlert } from "@/components/shared/StatusAlert";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { Alert02Icon, AlertCircleIcon } from "@hugeicons/core-free-icons";
+
+interface FullExportDialogProps {
+    open: boolean;
+    onClose: () => void;
+}
+
+// Break-glass export wizard: acknowledge what the archive holds, then choose
+// its password and type the confirmation phrase.
+export function FullExportDialog({ open, onClose }: FullExportDialogProps) {
+    const [step, setStep] = useState<"warning" | "password">("warning");
+    const [acknowledged, setAcknowledged] = useState(false);
+    const [isLoading, setIsLoading] = useState(false);
+    const [error, setError] = useState<string | null>(null);
+    const [showPasswords, setShowPasswords] = useState(false);
+
+    const {
+        register,
+        handleSubmit,
+        formState: { errors },
+        reset,
+    } = useForm<FullExportInput>({
+        resolver: zodResolver(fullExportSchema),
+    });
+
+    const handleClose = () => {
+        reset();
+        setStep("warning");
+        setAcknowledged(false);
+        setError(null);
+        onClose();
+    };
+
+    const onSubmit = async (data: FullExportInput) => {
+        setIsLoading(true);
+        setError(null);
+        try {
+            await api.exportEverything(data.export_password, data.confirmation);
+            toast.success("Full export saved");
+            handleClose();
+        } catch (err) {
+            const message =
+                typeof err === "string"
+                    ? err
+                    : err instanceof Error
+                      ? err.message
+                      : "Failed to export";
+            setError(message);
+            toast.error(message);
+        } finally {
+            setIsLoading(false);
+        }
+    };
+
+    return (
+        <Dialog open={open} onOpenChange={handleClose}>
+            <DialogContent className="sm:max-w-[480px]">
+                <DialogHeader>
+                    <DialogTitle>Full Export</DialogTitle>
+                    <DialogDescription>
+                        Every certificate with its decrypted private keys, in a
+                        password-protected ZIP archive.
+                    </DialogDescription>
+                </DialogHeader>
+
+                {step === "warning" ? (
+                    <div className="space-y-4">
+                        <StatusAlert
+                            variant="destructive"
+                            icon={
+                                <HugeiconsIcon
+                                    icon={Alert02Icon}
+                                    className="size-4"
+                                    strokeWidth={2}
+                                />
+                            }
+                        >
+                            Use this only when decommissioning this
+                            installation or for emergency offline access.
+                            Anyone with the archive and its password holds every
+                            private key. Each exported certificate is recorded
+                            in its history.
+                        </StatusAlert>
+                        <ul className="list-disc pl-5 text-sm text-muted-foreground space-y-1">
+                            <li>
+                                Folder per certificate: certificate, chain,
+                                private key, pending CSR and key, note
+                            </li>
+                            <li>An inventory of every certificate (CSV)</li>
+                            <li>
+                                AES-256 encryption: open with 7-Zip or WinZip
+                            </li>
+                        </ul>
+                        <div className="flex items-start gap-2">
+                            <Checkbox
+                                id="full_export_ack"
+                                checked={acknowledged}
+                                onCheckedChange={(checked) =>
+                                    setAcknowledged(checked === true)
+                                }
+                            />
+                            <Label
+                                htmlFor="full_export_ack"
+                                className="text-sm font-normal leading-snug"
+                            >
+                                I will keep the archive offline and delete it
+                                once it is no longer needed
+                            </Label>
+                        </div>
+                        <DialogFooter>
+                            <Button
+                                type="button"
+                                variant="outline"
+                                onClick={handleClose}
+                            >
+                                Cancel
+                            </Button>
+                            <Button
+                                type="button"
+                                disabled={!acknowledged}
+                                onClick={() => setStep("password")}
+                            >
+                                Continue
+                            </Button>
+                        </DialogFooter>
+                    </div>
+                ) : (
+                    <form
+                        onSubmit={handleSubmit(onSubmit)}
+                        className="space-y-4"
+                    >
+                        {error && (
+                            <StatusAlert
+                                variant="destructive"
+                                icon={
+                                    <HugeiconsIcon
+                                        icon={AlertCircleIcon}
+                                        className="size-4"
+                                        strokeWidth={2}
+                                    />
+                                }
+                            >
+                                {error}
+                            </StatusAlert>
+                        )}
+
+                        <div className="space-y-2">
+                            <Label htmlFor="full_export_password">
+                                Archive Password
+                            </Label>
+                            <div className="relative">
+                                <Input
+                                    id="full_export_password"
+                                    type={showPasswords ? "text" : "password"}
+                                    placeholder="Enter archive password (min 16 chars)"
+                                    disabled={isLoading}
+                                    {...register("export_password")}
+                                    className="pr-16"
+                                />
+                                <button
+                                    type="button"
+                                    onClick={() =>
+                                        setShowPasswords(!showPasswords)
+                                    }
+                                    className="absolute right-3 top-1/2 -translate-y-1/2 text-muted-foreground text-sm"
+                                >
+                                    {showPasswords ? "Hide" : "Show"}
+                                </button>
+                            </div>
+                            {errors.export_password && (
+                                <p className="text-sm text-destructive">
+                                    {errors.export_password.message}
+                                </p>
+                            )}
+                        </div>
+
+                        <div className="space-y-2">
+                            <Label htmlFor="full_export_password_confirm">
+                                Confirm Archive Password
+                            </Label>
+                            <Input
+                                id="full_export_password_confirm"
+                                type={showPasswords ? "text" : "password"}
+                                placeholder="Confirm archive password"
+                                disabled={isLoading}
+                                {...register("export_password_confirm")}
+                            />
+                            {errors.export_password_confirm && (
+                                <p className="text-sm text-destructive">
+                                    {errors.export_password_confirm.message}
+                                </p>
+                            )}
+                        </div>
+
+                        <div className="space-y-2">
+                            <Label htmlFor="full_export_confirmation">
+                                Type{" "}
+                                <span className="font-mono">
+                                    {FULL_EXPORT_CONFIRMATION}
+                                </span>{" "}
+                                to confirm
+                            </Label>
+                            <Input
+                                id="full_export_confirmation"
+                                autoComplete="off"
+                                disabled={isLoading}
+                                {...register("confirmation")}
+                            />
+                            {errors.confirmation && (
+                                <p className="text-sm text-destructive">
+                                    {errors.confirmation.message}
+                                </p>
+                            )}
+                        </div>
+
+                        <DialogFooter>
+                            <Button
+                                type="button"
+                                variant="outline"
+                                onClick={() => setStep("warning")}
+                                disabled={isLoading}
+                            >
+                                Back
+                            </Button>
+                            <Button
+                                type="submit"
+                                variant="destructive"
+                                disabled={isLoading}
+                            >
+                                {isLoading ? "Exporting..." : "Export Everything"}
+                            </Button>
+                        </DialogFooter>
+                    </form>
+                )}
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -1,4 +1,4 @@
-import { useState } from "react";
+import { useEffect, useState } from "react";
 import {
     Dialog,
     DialogContent,
@@ -10,10 +10,18 @@ import { Button } from "@/components/ui/button";
 import { Input } from "@/components/ui/input";
 import { Label } from "@/components/ui/label";
 import { Badge } from "@/components/ui/badge";
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
 import { StatusAlert } from "@/components/shared/StatusAlert";
 import { LoadingSpinner } from "@/components/shared/LoadingSpinner";
 import { useBackup } from "@/hooks/useBackup";
-import { BackupPeekInfo, CertImportResult } from "@/types";
+import { api } from "@/lib/api";
+import { BackupMergeResult, BackupPeekInfo, CertImportResult } from "@/types";
 import { HugeiconsIcon } from "@hugeicons/react";
 import {
     AlertCircleIcon,
@@ -26,24 +34,46 @@ interface ImportCertificatesDialogProps {
     open: boolean;
     onOpenChange: (open: boolean) => void;
     onComplete: () => void;
+    // Backup handed over by the OS (double-clicked file), previewed on open
+    initialPath?: string | null;
 }
 
 type Step = "select" | "preview" | "password" | "result";
 
+// "skip" imports only new hostnames; the other choices run a merge-restore
+// with the matching conflict policy
+type ConflictChoice = "skip" | "use_backup" | "keep_newer";
+
+// "link" records backup entries whose public key is already used by another
+// hostname against that certificate instead of inserting them
+type KeyDuplicateChoice = "link" | "import";
+
 export function ImportCertificatesDialog({
     open,
     onOpenChange,
     onComplete,
+    initialPath,
 }: ImportCertificatesDialogProps) {
-    const { selectBackupFile, peekBackupInfo, importCertificatesFromBackup, isLoading } = useBackup();
+    const {
+        selectBackupFile,
+        peekBackupInfo,
+        importCertificatesFromBackup,
+        mergeFromBackupFile,
+        isLoading,
+    } = useBackup();
     const [step, setStep] = useState<Step>("select");
     const [backupPath, setBackupPath] = useState<string | null>(null);
     const [peekInfo, setPeekInfo] = useState<BackupPeekInfo | null>(null);
     const [password, setPassword] = useState("");
     const [showPassword, setShowPassword] = useState(false);
+    const [conflictChoice, setConflictChoice] = useState<ConflictChoice>("skip");
+    const [keyDuplicateChoice, setKeyDuplicateChoice] = useState<KeyDuplicateChoice>("link");
+    const [hostnameMapping, setHostnameMapping] = useState<Record<string, string> | null>(null);
     const [result, setResult] = useState<CertImportResult | null>(null);
+    const [mergeResult, setMergeResult] = useState<BackupMergeResult | null>(null);
     const [error, setError] = useState<string | null>(null);
     const [isProcessing, setIsProcessing] = useState(false);
+    const [suffixApplied, setSuffixApplied] = useState(false);
 
     const reset = () => {
         setStep("select");
@@ -51,13 +81,28 @@ export function ImportCertificatesDialog({
         setPeekInfo(null);
         setPassword("");
         setShowPassword(false);
+        setConflictChoice("skip");
+        setKeyDuplicateChoice("link");
+        setHostnameMapping(null);
         setResult(null);
+        setMergeResult(null);
         setError(null);
         setIsProcessing(false);
+        setSuffixApplied(false);
+    };
+
+    const handleUseSuggestedSuffix = async (suffix: string) => {
+        setError(null);
+        try {
+            await api.setHostnameSuffix(suffix);
+            setSuffixApplied(true);
+        } catch (err) {
+            setError(err instanceof Error ? err.message : "Failed to update the hostname suffix");
+        }
     };
 
     const handleClose = () => {
-        if (result) {
+        if (result || mergeResult) {
             onComplete();
         }
         reset();
@@ -68,7 +113,11 @@ export function ImportCertificatesDialog({
         setError(null);
         const path = await selectBackupFile();
         if (!path) return;
+        await loadBackup(path);
+    };
 
+    const loadBackup = async (path: string) => {
+        setError(null);
         setBackupPath(path);
         setIsProcessing(true);
         const info = await peekBackupInfo(path);
@@ -87,12 +136,54 @@ export function ImportCertificatesDialog({
         }
     };
 
+    useEffect(() => {
+        if (open && initialPath) {
+            reset();
+            loadBackup(initialPath);
+        }
+        // eslint-disable-next-line react-hooks/exhaustive-deps -- only when a new file is handed over
+    }, [open, initialPath]);
+
+    const handleSelectMapping = async () => {
+        setError(null);
+        try {
+            const path = await api.selectHostnameMappingFile();
+            if (!path) return;
+            setHostnameMapping(await api.readHostnameMappingFile(path));
+        } catch (err) {
+            setError(err instanceof Error ? err.message : String(err));
+        }
+    };
+
     const handleImport = async () => {
         if (!backupPath || !password) return;
 
         setError(null);
         setIsProcessing(true);
-        const importResult = await importCertificatesFromBackup(backupPath, password);
+
+        if (conflictChoice !== "skip") {
+            const merged = await mergeFromBackupFile(backupPath, password, {
+                conflict_policy: conflictChoice,
+                duplicate_key_policy: keyDuplicateChoice,
+            });
+            setIsProcessing(false);
+
+            if (!merged) {
+                setError("Import failed. Check your password and try again.");
+                return;
+            }
+
+            setMergeResult(merged);
+            setStep("result");
+            return;
+        }
+
+        const importResult = await importCertificatesFromBackup(
+            backupPath,
+            password,
+            keyDuplicateChoice,
+            hostnameMapping ?? undefined,
+        );
         setIsProcessing(false);
 
         if (!importResult) {
@@ -225,6 +316,79 @@ export function ImportCertificatesDialog({
                                 </p>
                             </div>
 
+                            <div className="space-y-2">
+                                <Label htmlFor="conflict-choice">Existing Hostnames</Label>
+                                <Select
+                                    value={conflictChoice}
+                                    onValueChange={(v) => setConflictChoice(v as ConflictChoice)}
+                                    disabled={isProcessing}
+                                >
+                                    <SelectTrigger id="conflict-choice" className="w-full">
+                                        <SelectValue />
+                                    </SelectTrigger>
+                                    <SelectContent>
+                                        <SelectItem value="skip">Keep current version</SelectItem>
+                                        <SelectItem value="use_backup">Replace with backup version</SelectItem>
+                                        <SelectItem value="keep_newer">Keep most recently modified</SelectItem>
+                                    </SelectContent>
+                                </Select>
+                                <p className="text-xs text-muted-foreground">
+                                    What to do when a certificate exists both here and in the backup
+                                </p>
+                            </div>
+
+                            <div className="space-y-2">
+                                <Label htmlFor="key-duplicate-choice">Shared Keys</Label>
+                                <Select
+                                    value={keyDuplicateChoice}
+                                    onValueChange={(v) => setKeyDuplicateChoice(v as KeyDuplicateChoice)}
+                                    disabled={isProcessing}
+                                >
+                                    <SelectTrigger id="key-duplicate-choice" className="w-full">
+                                        <SelectValue />
+                                    </SelectTrigger>
+                                    <SelectContent>
+                                        <SelectItem value="link">Link to existing certificate</SelectItem>
+                                        <SelectItem value="import">Import anyway</SelectItem>
+                                    </SelectContent>
+                                </Select>
+                                <p className="text-xs text-muted-foreground">
+                                    What to do when a backup entry uses the same key as a certificate under another hostname
+                                </p>
+                            </div>
+
+                            {conflictChoice === "skip" && (
+                                <div className="space-y-2">
+                                    <Label>Hostname Mapping</Label>
+                                    <div className="flex gap-2">
+                                        <Button
+                                            type="button"
+                                            variant="outline"
+                                            onClick={handleSelectMapping}
+                                            disabled={isProcessing}
+                                            className="flex-1"
+                                        >
+                                            {hostnameMapping
+                                                ? `${Object.keys(hostnameMapping).length} hostname${Object.keys(hostnameMapping).length !== 1 ? "s" : ""} renamed`
+                                                : "Select Mapping File (optional)"}
+                                        </Button>
+                                        {hostnameMapping && (
+                                            <Button
+                                                type="button"
+                                                variant="ghost"
+                                                onClick={() => setHostnameMapping(null)}
+                                                disabled={isProcessing}
+                                            >
+                                                Clear
+                                            </Button>
+                                        )}
+                                    </div>
+                                    <p className="text-xs text-muted-foreground">
+                                        A CSV of "old,new" lines renaming backup entries as they are imported
+                                    </p>
+                                </div>
+                            )}
+
                             <div className="flex gap-3">
                                 <Button
                                     type="button"
@@ -283,6 +447,123 @@ export function ImportCertificatesDialog({
                                         </div>
                                     </div>
                                 )}
+
+                                {result.linked && result.linked.length > 0 && (
+                                    <div className="space-y-1">
+                                        <p className="text-xs text-muted-foreground">Linked to existing (same key):</p>
+                                        {result.linked.map((l) => (
+                                            <p key={l.hostname} className="text-xs">
+                                                <span className="font-mono">{l.hostname}</span>
+                                                <span className="text-muted-foreground"> → {l.existing_hostname}</span>
+                                            </p>
+                                        ))}
+                                    </div>
+                                )}
+
+                                {result.renamed && result.renamed.length > 0 && (
+                                    <div className="space-y-1">
+                                        <p className="text-xs text-muted-foreground">Renamed:</p>
+                                        {result.renamed.map((r) => (
+                                            <p key={r.hostname} className="text-xs">
+                                                <span className="font-mono">{r.original_hostname}</span>
+                                                <span className="text-muted-foreground"> → {r.hostname}</span>
+                                            </p>
+                                        ))}
+                                    </div>
+                                )}
+                            </div>
+
+                            {result.suggested_hostname_suffix && (
+                                <div className="flex items-center justify-between gap-3 border border-border p-4">
+                                    <p className="text-sm">
+                                        {suffixApplied ? (
+                                            <>
+                                                Hostname suffix set to{" "}
+                                                <span className="font-mono">{result.suggested_hostname_suffix}</span>.
+                                            </>
+                                        ) : (
+                                            <>
+                                                Most certificates end with{" "}
+                                                <span className="font-mono">{result.suggested_hostname_suffix}</span>,
+                                                which the configured hostname suffix does not match. CSRs
+                                                for them are refused until it does.
+                                            </>
+                                        )}
+                                    </p>
+                                    {!suffixApplied && (
+                                        <Button
+                                            size="sm"
+                                            variant="outline"
+                                            onClick={() => handleUseSuggestedSuffix(result.suggested_hostname_suffix!)}
+                                        >
+                                            Use Suffix
+                                        </Button>
+                                    )}
+                                </div>
+                            )}
+
+                            <Button onClick={handleClose} className="w-full">
+                                Done
+                            </Button>
+                        </div>
+                    )}
+
+                    {/* Step: Merge Result */}
+                    {step === "result" && mergeResult && (
+                        <div className="space-y-4">
+                            <div className="border border-border p-4 space-y-3">
+                                <div className="flex items-center gap-2">
+                                    <HugeiconsIcon
+                                        icon={Tick02Icon}
+                                        className="size-5 text-success"
+                                        strokeWidth={2}
+                                    />
+                                    <span className="font-medium">
+                                        {mergeResult.added.length} added, {mergeResult.replaced.length} replaced
+                                    </span>
+                                </div>
+
+                                {mergeResult.kept.length > 0 && (
+                                    <p className="text-sm text-muted-foreground">
+                                        {mergeResult.kept.length} kept (current version is newer or preferred)
+                                    </p>
+                                )}
+
+                                {mergeResult.linked.length > 0 && (
+                                    <div className="space-y-1">
+                                        <p className="text-xs text-muted-foreground">Linked to existing (same key):</p>
+                                        {mergeResult.linked.map((l) => (
+                                            <p key={l.hostname} className="text-xs">
+                                                <span className="font-mono">{l.hostname}</span>
+                                                <span className="text-muted-foreground"> → {l.existing_hostname}</span>
+                                            </p>
+                                        ))}
+                                    </div>
+                                )}
+
+                                {result.renamed && result.renamed.length > 0 && (
+                                    <div className="space-y-1">
+                                        <p className="text-xs text-muted-foreground">Renamed:</p>
+                                        {result.renamed.map((r) => (
+                                            <p key={r.hostname} className="text-xs">
+                                                <span className="font-mono">{r.original_hostname}</span>
+                                                <span className="text-muted-foreground"> → {r.hostname}</span>
+                                            </p>
+                                        ))}
+                                    </div>
+                                )}
+
+                                {mergeResult.failed.length > 0 && (
+                                    <div className="space-y-1">
+                                        <p className="text-xs text-muted-foreground">Not merged:</p>
+                                        {mergeResult.failed.map((f) => (
+                                            <p key={f.hostname} className="text-xs">
+                                                <span className="font-mono">{f.hostname}</span>
+                                                <span className="text-muted-foreground"> — {f.error}</span>
+                                            </p>
+                                        ))}
+                                    </div>
+                                )}
                             </div>
 
                             <Button onClick={handleClose} className="w-full">
@@ -0,0 +1,318 @@
+import { useState } from "react";
+import {
+    Dialog,
+    DialogContent,
+    DialogDescription,
+    DialogHeader,
+    DialogTitle,
+} from "@/components/ui/dialog";
+import { Button } from "@/components/ui/button";
+import { Badge } from "@/components/ui/badge";
+import { StatusAlert } from "@/components/shared/StatusAlert";
+import { LoadingSpinner } from "@/components/shared/LoadingSpinner";
+import { api } from "@/lib/api";
+import { formatDate } from "@/lib/theme";
+import {
+    CertImportResult,
+    DirectoryImportEntry,
+    DirectoryImportPreview,
+} from "@/types";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { AlertCircleIcon, Tick02Icon } from "@hugeicons/core-free-icons";
+
+interface ImportDirectoryDialogProps {
+    open: boolean;
+    onOpenChange: (open: boolean) => void;
+    onComplete: () => void;
+}
+
+type Step = "select" | "preview" | "result";
+
+function EntryList({
+    title,
+    entries,
+}: {
+    title: string;
+    entries: DirectoryImportEntry[];
+}) {
+    if (entries.length === 0) return null;
+    return (
+        <div className="space-y-1">
+            <p className="text-xs text-muted-foreground">
+                {title} ({entries.length})
+            </p>
+            <ul className="max-h-48 overflow-y-auto space-y-1">
+                {entries.map((entry) => (
+                    <li
+                        key={entry.certificate_file}
+                        className="border border-border p-2 text-xs"
+                    >
+                        <div className="flex items-center justify-between gap-2">
+                            <span className="font-mono truncate">
+                                {entry.hostname || entry.certificate_file}
+                            </span>
+                            {entry.status && (
+                                <Badge variant="outline" className="text-xs">
+                                    {entry.status}
+                                </Badge>
+                            )}
+                        </div>
+                        <div className="text-muted-foreground">
+                            {entry.certificate_file}
+                            {entry.key_file &&
+                                ` + ${entry.key_file} (${entry.matched_by === "filename" ? "same name" : "same public key"})`}
+                            {entry.expires_at > 0 &&
+                                ` · expires ${formatDate(entry.expires_at)}`}
+                        </div>
+                        {entry.reason && (
+                            <div className="text-destructive">{entry.reason}</div>
+                        )}
+                    </li>
+                ))}
+            </ul>
+        </div>
+    );
+}
+
+export function ImportDirectoryDialog({
+    open,
+    onOpenChange,
+    onComplete,
+}: ImportDirectoryDialogProps) {
+    const [step, setStep] = useState<Step>("select");
+    const [path, setPath] = useState<string | null>(null);
+    const [preview, setPreview] = useState<DirectoryImportPreview | null>(null);
+    const [result, setResult] = useState<CertImportResult | null>(null);
+    const [error, setError] = useState<string | null>(null);
+    const [isProcessing, setIsProcessing] = useState(false);
+
+    const reset = () => {
+        setStep("select");
+        setPath(null);
+        setPreview(null);
+        setResult(null);
+        setError(null);
+    };
+
+    const handleClose = () => {
+        if (result) {
+            onComplete();
+        }
+        reset();
+        onOpenChange(false);
+    };
+
+    const handleSelect = async () => {
+        setError(null);
+        try {
+            const selected = await api.selectCertificateDirectory();
+            if (!selected) return;
+            setPath(selected);
+            setIsProcessing(true);
+            setPreview(await api.previewDirectoryImport(selected));
+            setStep("preview");
+        } catch (err) {
+            setError(
+                err instanceof Error ? err.message : "Failed to scan the directory",
+            );
+        } finally {
+            setIsProcessing(false);
+        }
+    };
+
+    const handleImport = async () => {
+        if (!path) return;
+        setError(null);
+        setIsProcessing(true);
+        try {
+            setResult(await api.importCertificatesFromDirectory(path));
+            setStep("result");
+        } catch (err) {
+            setError(
+                err instanceof Error ? err.message : "Failed to import certificates",
+            );
+        } finally {
+            setIsProcessing(false);
+        }
+    };
+
+    return (
+        <Dialog
+            open={open}
+            onOpenChange={(next) => (next ? onOpenChange(true) : handleClose())}
+        >
+            <DialogContent className="sm:max-w-xl">
+                <DialogHeader>
+                    <DialogTitle>Import Certificates from a Folder</DialogTitle>
+                    <DialogDescription>
+                        PEM and DER certificates are paired with their private
+                        keys by file name or public key
+                    </DialogDescription>
+                </DialogHeader>
+
+                <div className="space-y-4">
+                    {/* Step: Select */}
+                    {step === "select" && (
+                        <Button
+                            onClick={handleSelect}
+                            disabled={isProcessing}
+                            className="w-full"
+                        >
+                            {isProcessing ? "Scanning..." : "Choose Folder"}
+                        </Button>
+                    )}
+
+                    {/* Step: Preview */}
+                    {step === "preview" && preview && (
+                        <div className="space-y-4">
+                            <p className="text-sm text-muted-foreground break-all">
+                                {preview.path}
+                            </p>
+                            {preview.importable.length === 0 && (
+                                <p className="text-sm">
+                                    No certificate with a private key to import.
+                                </p>
+                            )}
+                            <EntryList
+                                title="Will be imported"
+                                entries={preview.importable}
+                            />
+                            <EntryList
+                                title="Already stored"
+                                entries={preview.conflicting}
+                            />
+                            <EntryList title="Invalid" entries={preview.invalid} />
+                            {preview.skipped.length > 0 && (
+                                <div className="space-y-1">
+                                    <p className="text-xs text-muted-foreground">
+                                        Skipped files ({preview.skipped.length})
+                                    </p>
+                                    <ul className="max-h-32 overflow-y-auto space-y-1">
+                                        {preview.skipped.map((file) => (
+                                            <li key={file.file} className="text-xs">
+                                                <span className="font-mono">
+                                                    {file.file}
+                                                </span>
+                                                <span className="text-muted-foreground">
+                                                    {" "}
+                                                    — {file.reason}
+                                                </span>
+                                            </li>
+                                        ))}
+                                    </ul>
+                                </div>
+                            )}
+
+                            <div className="flex gap-3">
+                                <Button
+                                    variant="outline"
+                                    onClick={reset}
+                                    disabled={isProcessing}
+                                    className="flex-1"
+                                >
+                                    Back
+                                </Button>
+                                <Button
+                                    onClick={handleImport}
+                                    disabled={
+                                        isProcessing ||
+                                        preview.importable.length === 0
+                                    }
+                                    className="flex-1"
+                                >
+                                    {isProcessing
+                                        ? "Importing..."
+                                        : `Import ${preview.importable.length}`}
+                                </Button>
+                            </div>
+                        </div>
+                    )}
+
+                    {/* Step: Result */}
+                    {step === "result" && result && (
+                        <div className="space-y-4">
+                            <div className="border border-border p-4 space-y-3">
+                                <div className="flex items-center gap-2">
+                                    <HugeiconsIcon
+                                        icon={Tick02Icon}
+                                        className="size-5 text-success"
+                                        strokeWidth={2}
+                                    />
+                                    <span className="font-medium">
+                                        {result.imported} certificate
+                                        {result.imported !== 1 ? "s" : ""} imported
+                                    </span>
+                                </div>
+                                {result.skipped > 0 && (
+                                    <p className="text-sm text-muted-foreground">
+                                        {result.skipped} skipped (already exist)
+                                    </p>
+                                )}
+                                {result.failed && result.failed.length > 0 && (
+                                    <div className="space-y-1">
+                                        <p className="text-xs text-muted-foreground">
+                                            Not imported:
+                                        </p>
+                                        {result.failed.map((f) => (
+                                            <p key={f.hostname} className="text-xs">
+                                                <span className="font-mono">
+                                                    {f.hostname}
+                                                </span>
+                                                <span className="text-muted-foreground">
+                                                    {" "}
+                                                    — {f.error}
+                                                </span>
+                                            </p>
+                                        ))}
+                                    </div>
+                                )}
+                            </div>
+                            {result.warnings && result.warnings.length > 0 && (
+                                <StatusAlert
+                                    variant="warning"
+                                    icon={
+                                        <HugeiconsIcon
+                                            icon={AlertCircleIcon}
+                                            className="size-4"
+                                            strokeWidth={2}
+                                        />
+                                    }
+                                >
+                                    <ul className="max-h-32 overflow-y-auto space-y-1">
+                                        {result.warnings.map((warning) => (
+                                            <li key={warning}>{warning}</li>
+                                        ))}
+                                    </ul>
+                                </StatusAlert>
+                            )}
+                            <Button onClick={handleClose} className="w-full">
+                                Done
+                            </Button>
+                        </div>
+                    )}
+
+                    {error && (
+                        <StatusAlert
+                            variant="destructive"
+                            icon={
+                                <HugeiconsIcon
+                                    icon={AlertCircleIcon}
+                                    className="size-4"
+                                    strokeWidth={2}
+                                />
+                            }
+                        >
+                            {error}
+                        </StatusAlert>
+                    )}
+
+                    {isProcessing && step === "preview" && (
+                        <div className="flex items-center justify-center py-2">
+                            <LoadingSpinner text="Processing..." />
+                        </div>
+                    )}
+                </div>
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -1,4 +1,4 @@
-import { useState } from "react";
+import { useEffect, useState } from "react";
 import {
     Card,
     CardContent,
@@ -12,9 +12,12 @@ import { ConfirmDialog } from "@/components/shared/ConfirmDialog";
 import { LoadingSpinner } from "@/components/shared/LoadingSpinner";
 import { EmptyState } from "@/components/shared/EmptyState";
 import { ImportCertificatesDialog } from "@/components/settings/ImportCertificatesDialog";
+import { ImportDirectoryDialog } from "@/components/settings/ImportDirectoryDialog";
+import { ExportBackupDialog } from "@/components/settings/ExportBackupDialog";
+import { BrowseBackupDialog } from "@/components/settings/BrowseBackupDialog";
 import { BackupDetailsDrawer } from "@/components/settings/BackupDetailsDrawer";
 import { formatDateTime, getRelativeTime, formatFileSize } from "@/lib/theme";
-import { LocalBackupInfo } from "@/types";
+import { BackupStorageBreakdown, LocalBackupInfo } from "@/types";
 import { HugeiconsIcon } from "@hugeicons/react";
 import {
     DatabaseIcon,
@@ -23,6 +26,9 @@ import {
 
 interface LocalBackupsCardProps {
     localBackups: LocalBackupInfo[];
+    localBackupsTotal: number;
+    backupStorage: BackupStorageBreakdown | null;
+    onLoadMore: () => Promise<void>;
     isLoading: boolean;
     isLoadingBackups: boolean;
     error: string | null;
@@ -30,10 +36,15 @@ interface LocalBackupsCardProps {
     onRestoreBackup: (filename: string) => Promise<void>;
     onDeleteBackup: (filename: string) => Promise<void>;
     isUnlocked: boolean;
+    // Backup handed over by the OS, opened in the import dialog
+    openedBackupPath?: string | null;
 }
 
 export function LocalBackupsCard({
     localBackups,
+    localBackupsTotal,
+    backupStorage,
+    onLoadMore,
     isLoading,
     isLoadingBackups,
     error,
@@ -41,6 +52,7 @@ export function LocalBackupsCard({
     onRestoreBackup,
     onDeleteBackup,
     isUnlocked,
+    openedBackupPath,
 }: LocalBackupsCardProps) {
     const [restoreTarget, setRestoreTarget] = useState<string | null>(null);
     const [deleteTarget, setDeleteTarget] = useState<string | null>(null);
@@ -48,8 +60,15 @@ export function LocalBackupsCard({
         null,
     );
     const [importOpen, setImportOpen] = useState(false);
+    const [importDirectoryOpen, setImportDirectoryOpen] = useState(false);
+    const [exportOpen, setExportOpen] = useState(false);
+    const [browseOpen, setBrowseOpen] = useState(false);
     const [isCreating, setIsCreating] = useState(false);
 
+    useEffect(() => {
+        if (openedBackupPath) setImportOpen(true);
+    }, [openedBackupPath]);
+
     const handleCreate = async () => {
         setIsCreating(true);
         try {
@@ -87,9 +106,25 @@ export function LocalBackupsCard({
                             <CardDescription>
                                 Database snapshots created automatically before
                                 destructive operations or manually by you.
+                                {backupStorage && backupStorage.total.count > 0 && (
+                                    <>
+                                        {" "}
+                                        {formatFileSize(backupStorage.total.size)} in{" "}
+                                        {backupStorage.total.count} backups (auto{" "}
+                                        {formatFileSize(backupStorage.auto.size)}, manual{" "}
+                                        {formatFileSize(backupStorage.manual.size)}).
+                                    </>
+                                )}
                             </CardDescription>
                         </div>
                         <div className="flex gap-2 shrink-0">
+                            <Button
+                                variant="outline"
+                                size="sm"
+                                onClick={() => setBrowseOpen(true)}
+                            >
+                                Browse Backup
+                            </Button>
                             <Button
                                 variant="outline"
                                 size="sm"
@@ -99,6 +134,24 @@ export function LocalBackupsCard({
                             >
                                 Import Certificates
                             </Button>
+                            <Button
+                                variant="outline"
+                                size="sm"
+                                onClick={() => setImportDirectoryOpen(true)}
+                                disabled={!isUnlocked}
+                                title={!isUnlocked ? "Unlock the app first to import certificates" : undefined}
+                            >
+                                Import Folder
+                            </Button>
+                            <Button
+                                variant="outline"
+                                size="sm"
+                                onClick={() => setExportOpen(true)}
+                                disabled={!isUnlocked}
+                                title={!isUnlocked ? "Unlock the app first to export a backup" : undefined}
+                            >
+                                Export with Password
+                            </Button>
                             <Button
                                 size="sm"
                                 onClick={handleCreate}
@@ -186,6 +239,12 @@ export function LocalBackupsCard({
                                                         {backup.ca_name}
                                                     </>
                                                 )}
+                                                {backup.operation && (
+                                                    <>
+                                                        {" "}&middot;{" "}
+                                                        {backup.operation.replace(/_/g, " ")}
+                                                    </>
+                                                )}
                                             </p>
                                         </div>
                                     </div>
@@ -196,6 +255,16 @@ export function LocalBackupsCard({
                                     />
                                 </div>
                             ))}
+                            {localBackups.length < localBackupsTotal && (
+                                <Button
+                                    variant="ghost"
+                                    size="sm"
+                                    className="w-full"
+                                    onClick={onLoadMore}
+                                >
+                                    Show more ({localBackupsTotal - localBackups.length} remaining)
+                                </Button>
+                            )}
                         </div>
                     )}
                 </CardContent>
@@ -248,10 +317,32 @@ export function LocalBackupsCard({
             <ImportCertificatesDialog
                 open={importOpen}
                 onOpenChange={setImportOpen}
+                initialPath={openedBackupPath}
+                onComplete={() => {
+                    // Certificates imported — caller can refresh if needed
+                }}
+            />
+
+            {/* Certificate and key files of a folder */}
+            <ImportDirectoryDialog
+                open={importDirectoryOpen}
+                onOpenChange={setImportDirectoryOpen}
                 onComplete={() => {
                     // Certificates imported — caller can refresh if needed
                 }}
             />
+
+            {/* Password-Protected Export Dialog */}
+            <ExportBackupDialog
+                open={exportOpen}
+                onClose={() => setExportOpen(false)}
+            />
+
+            {/* Read-Only Backup Browser */}
+            <BrowseBackupDialog
+                open={browseOpen}
+                onClose={() => setBrowseOpen(false)}
+            />
         </>
     );
 }
@@ -0,0 +1,162 @@
+import { useCallback, useEffect, useState } from "react";
+import {
+    Card,
+    CardContent,
+    CardDescription,
+    CardHeader,
+    CardTitle,
+} from "@/components/ui/card";
+import { Button } from "@/components/ui/button";
+import { Label } from "@/components/ui/label";
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
+import { api } from "@/lib/api";
+import { LogLevelSettings } from "@/types";
+import { toast } from "sonner";
+
+const LEVELS = ["debug", "info", "warn", "error"];
+
+// Select values cannot be empty, so "default" stands for "no override"
+const DEFAULT_VALUE = "default";
+
+export function LogLevelsCard({ className }: { className?: string }) {
+    const [settings, setSettings] = useState<LogLevelSettings | null>(null);
+    const [saving, setSaving] = useState(false);
+
+    const load = useCallback(async () => {
+        try {
+            setSettings(await api.getLogLevels());
+        } catch (err) {
+            toast.error(
+                err instanceof Error ? err.message : "Failed to load log levels",
+            );
+        }
+    }, []);
+
+    useEffect(() => {
+        load();
+    }, [load]);
+
+    const apply = async (change: () => Promise<void>) => {
+        setSaving(true);
+        try {
+            await change();
+            await load();
+        } catch (err) {
+            toast.error(
+                err instanceof Error
+                    ? err.message
+                    : "Failed to change the log level",
+            );
+        } finally {
+            setSaving(false);
+        }
+    };
+
+    if (!settings) return null;
+
+    const overrides = settings.components ?? {};
+    const components = Array.from(
+        new Set([...(settings.known_components ?? []), ...Object.keys(overrides)]),
+    ).sort();
+    const isDefault = settings.level === settings.default_level;
+
+    return (
+        <Card className={`shadow-sm border-border ${className ?? ""}`}>
+            <CardHeader>
+                <div className="flex items-center justify-between">
+                    <div>
+                        <CardTitle>Log Levels</CardTitle>
+                        <CardDescription>
+                            Changes apply immediately and are kept across
+                            restarts
+                        </CardDescription>
+                    </div>
+                    <Button
+                        variant="outline"
+                        size="sm"
+                        onClick={() => apply(() => api.setLogLevel(""))}
+                        disabled={saving || isDefault}
+                    >
+                        Reset to {settings.default_level}
+                    </Button>
+                </div>
+            </CardHeader>
+            <CardContent className="space-y-4">
+                <div className="flex items-center justify-between gap-4">
+                    <Label>Global level</Label>
+                    <Select
+                        value={settings.level}
+                        onValueChange={(value) =>
+                            apply(() => api.setLogLevel(value))
+                        }
+                        disabled={saving}
+                    >
+                        <SelectTrigger className="w-40">
+                            <SelectValue />
+                        </SelectTrigger>
+                        <SelectContent>
+                            {LEVELS.map((level) => (
+                                <SelectItem key={level} value={level}>
+                                    {level}
+                                </SelectItem>
+                            ))}
+                        </SelectContent>
+                    </Select>
+                </div>
+
+                {components.length > 0 && (
+                    <div className="space-y-2">
+                        <p className="text-xs font-medium text-muted-foreground uppercase">
+                            Component Overrides
+                        </p>
+                        {components.map((component) => (
+                            <div
+                                key={component}
+                                className="flex items-center justify-between gap-4 text-sm"
+                            >
+                                <span className="font-mono">{component}</span>
+                                <Select
+                                    value={overrides[component] ?? DEFAULT_VALUE}
+                                    onValueChange={(value) =>
+                                        apply(() =>
+                                            api.setComponentLogLevel(
+                                                component,
+                                                value === DEFAULT_VALUE
+                                                    ? ""
+                                                    : value,
+                                            ),
+                                        )
+                                    }
+                                    disabled={saving}
+                                >
+                                    <SelectTrigger className="w-40">
+                                        <SelectValue />
+                                    </SelectTrigger>
+                                    <SelectContent>
+                                        <SelectItem value={DEFAULT_VALUE}>
+                                            global ({settings.level})
+                                        </SelectItem>
+                                        {LEVELS.map((level) => (
+                                            <SelectItem
+                                                key={level}
+                                                value={level}
+                                            >
+                                                {level}
+                                            </SelectItem>
+                                        ))}
+                                    </SelectContent>
+                                </Select>
+                            </div>
+                        ))}
+                    </div>
+                )}
+            </CardContent>
+        </Card>
+    );
+}
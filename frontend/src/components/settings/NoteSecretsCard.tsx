@@ -0,0 +1,146 @@
+import { useState } from "react";
+import {
+    Card,
+    CardContent,
+    CardDescription,
+    CardHeader,
+    CardTitle,
+} from "@/components/ui/card";
+import { Button } from "@/components/ui/button";
+import { Badge } from "@/components/ui/badge";
+import { StatusAlert } from "@/components/shared/StatusAlert";
+import { api } from "@/lib/api";
+import { NoteScanResult } from "@/types";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { AlertCircleIcon, Tick02Icon } from "@hugeicons/core-free-icons";
+
+export function NoteSecretsCard({ className }: { className?: string }) {
+    const [result, setResult] = useState<NoteScanResult | null>(null);
+    const [scanning, setScanning] = useState(false);
+    const [error, setError] = useState<string | null>(null);
+
+    const handleScan = async () => {
+        setScanning(true);
+        setError(null);
+        try {
+            setResult(await api.scanNotesForSecrets());
+        } catch (err) {
+            setError(
+                err instanceof Error ? err.message : "Failed to scan notes",
+            );
+        } finally {
+            setScanning(false);
+        }
+    };
+
+    return (
+        <Card className={`shadow-sm border-border ${className ?? ""}`}>
+            <CardHeader>
+                <div className="flex items-center justify-between">
+                    <div>
+                        <CardTitle>Secrets in Notes</CardTitle>
+                        <CardDescription>
+                            Find private keys, passwords and tokens pasted into
+                            certificate notes
+                        </CardDescription>
+                    </div>
+                    <Button
+                        size="sm"
+                        variant="outline"
+                        onClick={handleScan}
+                        disabled={scanning}
+                    >
+                        {scanning && (
+                            <span className="mr-2 inline-block h-3 w-3 animate-spin rounded-full border-2 border-current border-r-transparent" />
+                        )}
+                        {scanning ? "Scanning..." : "Scan Notes"}
+                    </Button>
+                </div>
+            </CardHeader>
+            {(error || result) && (
+                <CardContent className="space-y-3">
+                    {error && (
+                        <StatusAlert
+                            variant="destructive"
+                            icon={
+                                <HugeiconsIcon
+                                    icon={AlertCircleIcon}
+                                    className="size-4"
+                                    strokeWidth={2}
+                                />
+                            }
+                        >
+                            {error}
+                        </StatusAlert>
+                    )}
+
+                    {result && result.reports.length === 0 && (
+                        <div className="flex items-center gap-2 text-sm text-muted-foreground">
+                            <HugeiconsIcon
+                                icon={Tick02Icon}
+                                className="size-4 shrink-0 text-emerald-600"
+                                strokeWidth={2}
+                            />
+                            No secrets found in {result.scanned} note
+                            {result.scanned === 1 ? "" : "s"}.
+                        </div>
+                    )}
+
+                    {result && result.reports.length > 0 && (
+                        <>
+                            <StatusAlert
+                                variant="warning"
+                                icon={
+                                    <HugeiconsIcon
+                                        icon={AlertCircleIcon}
+                                        className="size-4"
+                                        strokeWidth={2}
+                                    />
+                                }
+                            >
+                                {result.reports.length} of {result.scanned}{" "}
+                                notes look like they contain secrets. Edit them
+                                to remove the secrets, then rotate anything that
+                                was exposed.
+                            </StatusAlert>
+                            <ul className="space-y-2">
+                                {result.reports.map((report) => (
+                                    <li
+                                        key={`${report.hostname}-${report.field}`}
+                                        className="border border-border p-3 text-sm"
+                                    >
+                                        <div className="flex items-center gap-2">
+                                            <span className="font-medium">
+                                                {report.hostname}
+                                            </span>
+                                            <Badge
+                                                variant="secondary"
+                                                className="text-xs"
+                                            >
+                                                {report.field === "pending_note"
+                                                    ? "Pending note"
+                                                    : "Note"}
+                                            </Badge>
+                                        </div>
+                                        <ul className="mt-1 space-y-1 text-muted-foreground">
+                                            {report.findings.map((finding, i) => (
+                                                <li key={i}>
+                                                    {finding.description}
+                                                    {finding.excerpt && (
+                                                        <code className="ml-2 font-mono text-xs">
+                                                            {finding.excerpt}
+                                                        </code>
+                                                    )}
+                                                </li>
+                                            ))}
+                                        </ul>
+                                    </li>
+                                ))}
+                            </ul>
+                        </>
+                    )}
+                </CardContent>
+            )}
+        </Card>
+    );
+}
@@ -0,0 +1,172 @@
+import { useCallback, useEffect, useState } from "react";
+import {
+    Card,
+    CardContent,
+    CardDescription,
+    CardHeader,
+    CardTitle,
+} from "@/components/ui/card";
+import { Button } from "@/components/ui/button";
+import { Badge } from "@/components/ui/badge";
+import { Input } from "@/components/ui/input";
+import { ConfirmDialog } from "@/components/shared/ConfirmDialog";
+import { api } from "@/lib/api";
+import { Profile } from "@/types";
+import { toast } from "sonner";
+
+export function ProfilesCard({
+    className,
+    onSwitched,
+}: {
+    className?: string;
+    onSwitched: () => Promise<void>;
+}) {
+    const [profiles, setProfiles] = useState<Profile[] | null>(null);
+    const [newName, setNewName] = useState("");
+    const [busy, setBusy] = useState(false);
+    const [switchTarget, setSwitchTarget] = useState<string | null>(null);
+
+    const load = useCallback(async () => {
+        try {
+            setProfiles(await api.listProfiles());
+        } catch {
+            setProfiles(null);
+        }
+    }, []);
+
+    useEffect(() => {
+        load();
+    }, [load]);
+
+    const handleCreate = async () => {
+        const name = newName.trim();
+        if (!name) return;
+        setBusy(true);
+        try {
+            await api.createProfile(name);
+            toast.success(`Profile ${name} created`);
+            setNewName("");
+            await load();
+        } catch (err) {
+            toast.error(
+                err instanceof Error ? err.message : "Failed to create profile",
+            );
+        } finally {
+            setBusy(false);
+        }
+    };
+
+    const handleSwitch = async (name: string) => {
+        setBusy(true);
+        try {
+            await api.switchProfile(name);
+            toast.success(`Switched to ${name}`);
+            await load();
+            await onSwitched();
+        } catch (err) {
+            toast.error(
+                err instanceof Error ? err.message : "Failed to switch profile",
+            );
+        } finally {
+            setBusy(false);
+            setSwitchTarget(null);
+        }
+    };
+
+    return (
+        <Card className={`shadow-sm border-border ${className ?? ""}`}>
+            <CardHeader>
+                <CardTitle>Profiles</CardTitle>
+                <CardDescription>
+                    Each profile has its own database, configuration, password
+                    and backups, e.g. one per CA or environment
+                </CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-4">
+                {profiles === null ? (
+                    <p className="text-sm text-muted-foreground">
+                        Profiles unavailable
+                    </p>
+                ) : (
+                    <ul className="space-y-2">
+                        {profiles.map((profile) => (
+                            <li
+                                key={profile.name}
+                                className="flex items-center justify-between gap-4 border border-border p-3 text-sm"
+                            >
+                                <div className="min-w-0">
+                                    <div className="flex items-center gap-2">
+                                        <span className="font-medium truncate">
+                                            {profile.name}
+                                        </span>
+                                        {profile.active && (
+                                            <Badge
+                                                variant="secondary"
+                                                className="text-xs"
+                                            >
+                                                Open
+                                            </Badge>
+                                        )}
+                                    </div>
+                                    <div className="text-muted-foreground font-mono truncate">
+                                        {profile.path}
+                                    </div>
+                                </div>
+                                {!profile.active && (
+                                    <Button
+                                        size="sm"
+                                        variant="outline"
+                                        onClick={() =>
+                                            setSwitchTarget(profile.name)
+                                        }
+                                        disabled={busy}
+                                    >
+                                        Switch
+                                    </Button>
+                                )}
+                            </li>
+                        ))}
+                    </ul>
+                )}
+
+                <form
+                    className="flex gap-2"
+                    onSubmit={(e) => {
+                        e.preventDefault();
+                        handleCreate();
+                    }}
+                >
+                    <Input
+                        value={newName}
+                        onChange={(e) => setNewName(e.target.value)}
+                        placeholder="New profile name, e.g. Lab CA"
+                        maxLength={64}
+                        disabled={busy || profiles === null}
+                    />
+                    <Button
+                        type="submit"
+                        variant="outline"
+                        disabled={busy || !newName.trim() || profiles === null}
+                    >
+                        Create
+                    </Button>
+                </form>
+            </CardContent>
+
+            <ConfirmDialog
+                open={switchTarget !== null}
+                title="Switch profile"
+                description={`This closes the current database and opens ${switchTarget ?? ""}. The app is locked; a profile that was never opened starts at setup.`}
+                confirmText="Switch"
+                cancelText="Cancel"
+                isLoading={busy}
+                onConfirm={async () => {
+                    if (switchTarget !== null) {
+                        await handleSwitch(switchTarget);
+                    }
+                }}
+                onCancel={() => setSwitchTarget(null)}
+            />
+        </Card>
+    );
+}
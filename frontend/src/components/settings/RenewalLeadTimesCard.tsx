@@ -0,0 +1,116 @@
+import { useEffect, useState } from "react";
+import {
+    Card,
+    CardContent,
+    CardDescription,
+    CardHeader,
+    CardTitle,
+} from "@/components/ui/card";
+import { api } from "@/lib/api";
+import { LeadTimeReport } from "@/types";
+
+function formatDays(days: number): string {
+    const rounded = Math.round(days * 10) / 10;
+    return `${rounded} day${rounded === 1 ? "" : "s"}`;
+}
+
+// How long each CA took from CSR generation to the signed certificate, to
+// pick an "expiring soon" threshold that leaves time for a renewal.
+export function RenewalLeadTimesCard({ className }: { className?: string }) {
+    const [report, setReport] = useState<LeadTimeReport | null>(null);
+    const [error, setError] = useState<string | null>(null);
+
+    useEffect(() => {
+        api.getRenewalLeadTimes()
+            .then((r) => {
+                setReport(r);
+                setError(null);
+            })
+            .catch((err) =>
+                setError(
+                    err instanceof Error
+                        ? err.message
+                        : "Failed to load renewal lead times",
+                ),
+            );
+    }, []);
+
+    const slowest = report?.issuers.reduce(
+        (max, issuer) => Math.max(max, issuer.recommended_warning_days),
+        0,
+    );
+
+    return (
+        <Card className={`shadow-sm border-border ${className ?? ""}`}>
+            <CardHeader>
+                <CardTitle>Renewal Lead Times</CardTitle>
+                <CardDescription>
+                    Time from CSR generation to the signed certificate, per
+                    CA, from the certificate history
+                </CardDescription>
+            </CardHeader>
+            <CardContent className="space-y-3 text-sm">
+                {error ? (
+                    <p className="text-destructive">{error}</p>
+                ) : !report ? (
+                    <p className="text-muted-foreground">Loading...</p>
+                ) : report.issuers.length === 0 ? (
+                    <p className="text-muted-foreground">
+                        No certificate has been issued from a CSR generated
+                        here yet.
+                    </p>
+                ) : (
+                    <>
+                        <div className="divide-y divide-border">
+                            {report.issuers.map((issuer) => (
+                                <div
+                                    key={issuer.issuer}
+                                    className="flex items-center justify-between gap-4 py-2"
+                                >
+                                    <div className="min-w-0">
+                                        <p className="font-medium truncate">
+                                            {issuer.issuer}
+                                        </p>
+                                        <p className="text-xs text-muted-foreground">
+                                            {issuer.samples} issued · median{" "}
+                                            {formatDays(issuer.median_days)} ·
+                                            slowest{" "}
+                                            {formatDays(issuer.max_days)}
+                                        </p>
+                                    </div>
+                                    <div className="text-right shrink-0">
+                                        <p>
+                                            {formatDays(issuer.average_days)}{" "}
+                                            on average
+                                        </p>
+                                        <p className="text-xs text-muted-foreground">
+                                            warn at{" "}
+                                            {issuer.recommended_warning_days}{" "}
+                                            days
+                                        </p>
+                                    </div>
+                                </div>
+                            ))}
+                        </div>
+                        {slowest !== undefined &&
+                            slowest > report.expiring_threshold_days && (
+                                <p className="text-xs text-warning">
+                                    The expiring soon threshold (
+                                    {report.expiring_threshold_days} days) is
+                                    shorter than the {slowest} days suggested
+                                    for your slowest CA.
+                                </p>
+                            )}
+                        {report.pending > 0 && (
+                            <p className="text-xs text-muted-foreground">
+                                {report.pending} CSR
+                                {report.pending === 1 ? " is" : "s are"} still
+                                awaiting a certificate.
+                            </p>
+                        )}
+                    </>
+                )}
+            </CardContent>
+        </Card>
+    );
+}
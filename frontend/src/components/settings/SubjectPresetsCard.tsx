@@ -0,0 +1,267 @@
+import { useEffect, useState } from "react";
+import {
+    Card,
+    CardContent,
+    CardDescription,
+    CardHeader,
+    CardTitle,
+} from "@/components/ui/card";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { StatusAlert } from "@/components/shared/StatusAlert";
+import { ConfirmDialog } from "@/components/shared/ConfirmDialog";
+import { api } from "@/lib/api";
+import { SubjectPreset } from "@/types";
+import { toast } from "sonner";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { AlertCircleIcon } from "@hugeicons/core-free-icons";
+
+const emptyPreset = {
+    id: 0,
+    name: "",
+    organization: "",
+    organizational_unit: "",
+    city: "",
+    state: "",
+    country: "",
+    created_at: 0,
+    last_modified: 0,
+} as SubjectPreset;
+
+export function SubjectPresetsCard({ className }: { className?: string }) {
+    const [presets, setPresets] = useState<SubjectPreset[]>([]);
+    const [editing, setEditing] = useState<SubjectPreset | null>(null);
+    const [deleteTarget, setDeleteTarget] = useState<SubjectPreset | null>(null);
+    const [busy, setBusy] = useState(false);
+    const [error, setError] = useState<string | null>(null);
+
+    const load = async () => {
+        try {
+            setPresets((await api.listSubjectPresets()) ?? []);
+        } catch (err) {
+            setError(
+                err instanceof Error ? err.message : "Failed to load subject presets",
+            );
+        }
+    };
+
+    useEffect(() => {
+        load();
+    }, []);
+
+    const update = (field: keyof SubjectPreset, value: string) => {
+        setEditing((prev) => (prev ? ({ ...prev, [field]: value } as SubjectPreset) : prev));
+    };
+
+    const handleSave = async () => {
+        if (!editing) return;
+        setBusy(true);
+        setError(null);
+        try {
+            await api.saveSubjectPreset(editing);
+            toast.success(editing.id ? "Subject preset updated" : "Subject preset created");
+            setEditing(null);
+            await load();
+        } catch (err) {
+            setError(
+                err instanceof Error ? err.message : typeof err === "string" ? err : "Failed to save subject preset",
+            );
+        } finally {
+            setBusy(false);
+        }
+    };
+
+    return (
+        <>
+            <Card className={`shadow-sm border-border ${className ?? ""}`}>
+                <CardHeader>
+                    <div className="flex items-center justify-between">
+                        <div>
+                            <CardTitle>Subject Presets</CardTitle>
+                            <CardDescription>
+                                Organization, unit and location bundles
+                                selectable when generating a CSR
+                            </CardDescription>
+                        </div>
+                        {!editing && (
+                            <Button
+                                size="sm"
+                                variant="outline"
+                                onClick={() => setEditing({ ...emptyPreset } as SubjectPreset)}
+                            >
+                                Add Preset
+                            </Button>
+                        )}
+                    </div>
+                </CardHeader>
+                <CardContent className="space-y-3">
+                    {error && (
+                        <StatusAlert
+                            variant="destructive"
+                            icon={
+                                <HugeiconsIcon
+                                    icon={AlertCircleIcon}
+                                    className="size-4"
+                                    strokeWidth={2}
+                                />
+                            }
+                        >
+                            {error}
+                        </StatusAlert>
+                    )}
+
+                    {editing && (
+                        <div className="space-y-3 rounded-md border border-border p-3">
+                            <div className="space-y-2">
+                                <Label htmlFor="preset_name">Name *</Label>
+                                <Input
+                                    id="preset_name"
+                                    maxLength={64}
+                                    value={editing.name}
+                                    onChange={(e) => update("name", e.target.value)}
+                                />
+                            </div>
+                            <div className="grid grid-cols-2 gap-3">
+                                <div className="space-y-2">
+                                    <Label htmlFor="preset_organization">Organization *</Label>
+                                    <Input
+                                        id="preset_organization"
+                                        maxLength={64}
+                                        value={editing.organization}
+                                        onChange={(e) => update("organization", e.target.value)}
+                                    />
+                                </div>
+                                <div className="space-y-2">
+                                    <Label htmlFor="preset_ou">Organizational Unit</Label>
+                                    <Input
+                                        id="preset_ou"
+                                        maxLength={64}
+                                        value={editing.organizational_unit ?? ""}
+                                        onChange={(e) => update("organizational_unit", e.target.value)}
+                                    />
+                                </div>
+                                <div className="space-y-2">
+                                    <Label htmlFor="preset_city">City *</Label>
+                                    <Input
+                                        id="preset_city"
+                                        maxLength={128}
+                                        value={editing.city}
+                                        onChange={(e) => update("city", e.target.value)}
+                                    />
+                                </div>
+                                <div className="space-y-2">
+                                    <Label htmlFor="preset_state">State *</Label>
+                                    <Input
+                                        id="preset_state"
+                                        maxLength={128}
+                                        value={editing.state}
+                                        onChange={(e) => update("state", e.target.value)}
+                                    />
+                                </div>
+                                <div className="space-y-2">
+                                    <Label htmlFor="preset_country">Country Code *</Label>
+                                    <Input
+                                        id="preset_country"
+                                        maxLength={2}
+                                        value={editing.country}
+                                        onChange={(e) => update("country", e.target.value.toUpperCase())}
+                                    />
+                                </div>
+                            </div>
+                            <div className="flex justify-end gap-2">
+                                <Button
+                                    size="sm"
+                                    variant="ghost"
+                                    onClick={() => {
+                                        setEditing(null);
+                                        setError(null);
+                                    }}
+                                    disabled={busy}
+                                >
+                                    Cancel
+                                </Button>
+                                <Button size="sm" onClick={handleSave} disabled={busy}>
+                                    {busy ? "Saving..." : "Save Preset"}
+                                </Button>
+                            </div>
+                        </div>
+                    )}
+
+                    {presets.length === 0 && !editing && (
+                        <p className="text-sm text-muted-foreground">
+                            No presets. CSRs use the default subject from the
+                            configuration.
+                        </p>
+                    )}
+
+                    {presets.map((preset) => (
+                        <div
+                            key={preset.id}
+                            className="flex items-center justify-between rounded-md border border-border p-3"
+                        >
+                            <div>
+                                <p className="text-sm font-medium">{preset.name}</p>
+                                <p className="text-xs text-muted-foreground">
+                                    {[
+                                        preset.organization,
+                                        preset.organizational_unit,
+                                        preset.city,
+                                        preset.state,
+                                        preset.country,
+                                    ]
+                                        .filter(Boolean)
+                                        .join(", ")}
+                                </p>
+                            </div>
+                            <div className="flex gap-2">
+                                <Button
+                                    size="sm"
+                                    variant="ghost"
+                                    onClick={() => setEditing({ ...preset } as SubjectPreset)}
+                                    disabled={busy || editing !== null}
+                                >
+                                    Edit
+                                </Button>
+                                <Button
+                                    size="sm"
+                                    variant="ghost"
+                                    onClick={() => setDeleteTarget(preset)}
+                                    disabled={busy}
+                                >
+                                    Delete
+                                </Button>
+                            </div>
+                        </div>
+                    ))}
+                </CardContent>
+            </Card>
+
+            <ConfirmDialog
+                open={deleteTarget !== null}
+                title="Delete subject preset"
+                description={`This deletes the "${deleteTarget?.name ?? ""}" preset. Certificates generated from it keep their subject.`}
+                confirmText="Delete"
+                cancelText="Cancel"
+                isDestructive
+                isLoading={busy}
+                onConfirm={async () => {
+                    const id = deleteTarget?.id;
+                    if (id !== undefined) {
+                        try {
+                            await api.deleteSubjectPreset(id);
+                            toast.success("Subject preset deleted");
+                            await load();
+                        } catch (err) {
+                            setError(
+                                err instanceof Error ? err.message : "Failed to delete subject preset",
+                            );
+                        }
+                    }
+                    setDeleteTarget(null);
+                }}
+                onCancel={() => setDeleteTarget(null)}
+            />
+        </>
+    );
+}
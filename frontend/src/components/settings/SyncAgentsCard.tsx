@@ -0,0 +1,321 @@
+import { useEffect, useState } from "react";
+import {
+    Card,
+    CardContent,
+    CardDescription,
+    CardHeader,
+    CardTitle,
+} from "@/components/ui/card";
+import { Badge } from "@/components/ui/badge";
+import { Button } from "@/components/ui/button";
+import { CodeBlock } from "@/components/ui/code-block";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { StatusAlert } from "@/components/shared/StatusAlert";
+import { ConfirmDialog } from "@/components/shared/ConfirmDialog";
+import { api } from "@/lib/api";
+import { formatDate, getRelativeTime } from "@/lib/theme";
+import { SyncAgent, SyncAgentEnrollment, SyncServerStatus } from "@/types";
+import { toast } from "sonner";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { AlertCircleIcon } from "@hugeicons/core-free-icons";
+
+const DEFAULT_LISTEN_ADDRESS = ":8443";
+
+function splitHostnames(value: string): string[] {
+    return value
+        .split(/[\s,]+/)
+        .map((h) => h.trim())
+        .filter(Boolean);
+}
+
+function errorMessage(err: unknown, fallback: string): string {
+    return err instanceof Error ? err.message : typeof err === "string" ? err : fallback;
+}
+
+export function SyncAgentsCard({
+    className,
+    isUnlocked,
+}: {
+    className?: string;
+    isUnlocked: boolean;
+}) {
+    const [agents, setAgents] = useState<SyncAgent[]>([]);
+    const [status, setStatus] = useState<SyncServerStatus | null>(null);
+    const [address, setAddress] = useState(DEFAULT_LISTEN_ADDRESS);
+    const [enrolling, setEnrolling] = useState(false);
+    const [name, setName] = useState("");
+    const [hostnames, setHostnames] = useState("");
+    const [enrollment, setEnrollment] = useState<SyncAgentEnrollment | null>(null);
+    const [revokeTarget, setRevokeTarget] = useState<SyncAgent | null>(null);
+    const [busy, setBusy] = useState(false);
+    const [error, setError] = useState<string | null>(null);
+
+    const load = async () => {
+        try {
+            const [agentList, serverStatus] = await Promise.all([
+                api.listSyncAgents(),
+                api.getSyncServerStatus(),
+            ]);
+            setAgents(agentList ?? []);
+            setStatus(serverStatus);
+            if (serverStatus.listen_address) {
+                setAddress(serverStatus.listen_address);
+            }
+        } catch (err) {
+            setError(errorMessage(err, "Failed to load sync agents"));
+        }
+    };
+
+    useEffect(() => {
+        load();
+    }, [isUnlocked]);
+
+    const handleEnroll = async () => {
+        setBusy(true);
+        setError(null);
+        try {
+            const result = await api.enrollSyncAgent(name, splitHostnames(hostnames));
+            setEnrollment(result);
+            setEnrolling(false);
+            setName("");
+            setHostnames("");
+            toast.success(`Agent ${result.agent.name} enrolled`);
+            await load();
+        } catch (err) {
+            setError(errorMessage(err, "Failed to enroll agent"));
+        } finally {
+            setBusy(false);
+        }
+    };
+
+    const handleToggleServer = async () => {
+        setBusy(true);
+        setError(null);
+        try {
+            if (status?.running) {
+                await api.stopSyncServer();
+                toast.success("Sync server stopped");
+            } else {
+                const started = await api.startSyncServer(address);
+                toast.success(`Sync server listening on ${started.listen_address}`);
+            }
+            await load();
+        } catch (err) {
+            setError(errorMessage(err, "Failed to change the sync server"));
+        } finally {
+            setBusy(false);
+        }
+    };
+
+    return (
+        <>
+            <Card className={`shadow-sm border-border ${className ?? ""}`}>
+                <CardHeader>
+                    <div className="flex items-center justify-between">
+                        <div>
+                            <CardTitle>Sync Agents</CardTitle>
+                            <CardDescription>
+                                Agents on your servers pull their certificates
+                                and keys over mutual TLS once a renewal is
+                                activated
+                            </CardDescription>
+                        </div>
+                        {!enrolling && (
+                            <Button
+                                size="sm"
+                                variant="outline"
+                                onClick={() => setEnrolling(true)}
+                                disabled={!isUnlocked}
+                            >
+                                Enroll Agent
+                            </Button>
+                        )}
+                    </div>
+                </CardHeader>
+                <CardContent className="space-y-3">
+                    {error && (
+                        <StatusAlert
+                            variant="destructive"
+                            icon={
+                                <HugeiconsIcon
+                                    icon={AlertCircleIcon}
+                                    className="size-4"
+                                    strokeWidth={2}
+                                />
+                            }
+                        >
+                            {error}
+                        </StatusAlert>
+                    )}
+
+                    <div className="flex items-end gap-2 rounded-md border border-border p-3">
+                        <div className="flex-1 space-y-2">
+                            <Label htmlFor="sync_listen_address">
+                                Listen address
+                            </Label>
+                            <Input
+                                id="sync_listen_address"
+                                value={address}
+                                onChange={(e) => setAddress(e.target.value)}
+                                disabled={status?.running || busy}
+                            />
+                        </div>
+                        <Button
+                            size="sm"
+                            variant={status?.running ? "outline" : "default"}
+                            onClick={handleToggleServer}
+                            disabled={busy || (!status?.running && !isUnlocked)}
+                        >
+                            {status?.running ? "Stop Server" : "Start Server"}
+                        </Button>
+                    </div>
+                    {status && !status.running && status.listen_address && (
+                        <p className="text-xs text-muted-foreground">
+                            The server stops while the app is locked and
+                            starts again at the next unlock.
+                        </p>
+                    )}
+
+                    {enrolling && (
+                        <div className="space-y-3 rounded-md border border-border p-3">
+                            <div className="space-y-2">
+                                <Label htmlFor="sync_agent_name">Name *</Label>
+                                <Input
+                                    id="sync_agent_name"
+                                    maxLength={64}
+                                    placeholder="web01"
+                                    value={name}
+                                    onChange={(e) => setName(e.target.value)}
+                                />
+                            </div>
+                            <div className="space-y-2">
+                                <Label htmlFor="sync_agent_hostnames">
+                                    Certificates (hostnames, comma separated)
+                                </Label>
+                                <Input
+                                    id="sync_agent_hostnames"
+                                    value={hostnames}
+                                    onChange={(e) => setHostnames(e.target.value)}
+                                />
+                            </div>
+                            <div className="flex justify-end gap-2">
+                                <Button
+                                    size="sm"
+                                    variant="ghost"
+                                    onClick={() => {
+                                        setEnrolling(false);
+                                        setError(null);
+                                    }}
+                                    disabled={busy}
+                                >
+                                    Cancel
+                                </Button>
+                                <Button
+                                    size="sm"
+                                    onClick={handleEnroll}
+                                    disabled={busy || !name.trim()}
+                                >
+                                    {busy ? "Enrolling..." : "Enroll"}
+                                </Button>
+                            </div>
+                        </div>
+                    )}
+
+                    {enrollment && (
+                        <div className="space-y-3 rounded-md border border-border p-3">
+                            <p className="text-sm">
+                                Install these on{" "}
+                                <span className="font-medium">
+                                    {enrollment.agent.name}
+                                </span>
+                                . The private key is not kept and cannot be
+                                shown again.
+                            </p>
+                            <Label>Client certificate</Label>
+                            <CodeBlock content={enrollment.certificate_pem} />
+                            <Label>Client private key</Label>
+                            <CodeBlock content={enrollment.private_key_pem} />
+                            <Label>Sync CA certificate</Label>
+                            <CodeBlock content={enrollment.ca_certificate_pem} />
+                            <div className="flex justify-end">
+                                <Button size="sm" onClick={() => setEnrollment(null)}>
+                                    Done
+                                </Button>
+                            </div>
+                        </div>
+                    )}
+
+                    {agents.length === 0 && !enrolling && (
+                        <p className="text-sm text-muted-foreground">
+                            No agents enrolled.
+                        </p>
+                    )}
+
+                    {agents.map((agent) => (
+                        <div
+                            key={agent.id}
+                            className="flex items-center justify-between rounded-md border border-border p-3"
+                        >
+                            <div>
+                                <p className="flex items-center gap-2 text-sm font-medium">
+                                    {agent.name}
+                                    {agent.revoked_at && (
+                                        <Badge variant="outline">Revoked</Badge>
+                                    )}
+                                </p>
+                                <p className="text-xs text-muted-foreground">
+                                    {agent.hostnames.length > 0
+                                        ? agent.hostnames.join(", ")
+                                        : "No certificates assigned"}
+                                </p>
+                                <p className="text-xs text-muted-foreground">
+                                    {agent.last_seen_at
+                                        ? `Last seen ${getRelativeTime(agent.last_seen_at)}`
+                                        : "Never connected"}
+                                    {" · "}
+                                    Client certificate expires{" "}
+                                    {formatDate(agent.expires_at)}
+                                </p>
+                            </div>
+                            {!agent.revoked_at && (
+                                <Button
+                                    size="sm"
+                                    variant="ghost"
+                                    onClick={() => setRevokeTarget(agent)}
+                                    disabled={busy}
+                                >
+                                    Revoke
+                                </Button>
+                            )}
+                        </div>
+                    ))}
+                </CardContent>
+            </Card>
+
+            <ConfirmDialog
+                open={revokeTarget !== null}
+                title="Revoke sync agent"
+                description={`The sync server refuses "${revokeTarget?.name ?? ""}" from now on. Certificates already installed on its server are left in place.`}
+                confirmText="Revoke"
+                cancelText="Cancel"
+                isDestructive
+                isLoading={busy}
+                onConfirm={async () => {
+                    const id = revokeTarget?.id;
+                    if (id !== undefined) {
+                        try {
+                            await api.revokeSyncAgent(id);
+                            toast.success("Agent revoked");
+                            await load();
+                        } catch (err) {
+                            setError(errorMessage(err, "Failed to revoke agent"));
+                        }
+                    }
+                    setRevokeTarget(null);
+                }}
+                onCancel={() => setRevokeTarget(null)}
+            />
+        </>
+    );
+}
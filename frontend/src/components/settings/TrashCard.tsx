@@ -0,0 +1,156 @@
+import { useCallback, useEffect, useState } from "react";
+import {
+    Card,
+    CardContent,
+    CardDescription,
+    CardHeader,
+    CardTitle,
+} from "@/components/ui/card";
+import { Button } from "@/components/ui/button";
+import { Badge } from "@/components/ui/badge";
+import { ConfirmDialog } from "@/components/shared/ConfirmDialog";
+import { api } from "@/lib/api";
+import { formatDate, formatDateTime } from "@/lib/theme";
+import { DeletedCertificate } from "@/types";
+import { toast } from "sonner";
+
+export function TrashCard({ className }: { className?: string }) {
+    const [deleted, setDeleted] = useState<DeletedCertificate[] | null>(null);
+    const [busy, setBusy] = useState<string | null>(null);
+    const [purgeTarget, setPurgeTarget] = useState<string | null>(null);
+
+    const load = useCallback(async () => {
+        try {
+            setDeleted(await api.listDeletedCertificates());
+        } catch {
+            setDeleted(null);
+        }
+    }, []);
+
+    useEffect(() => {
+        load();
+    }, [load]);
+
+    const handleRestore = async (hostname: string) => {
+        setBusy(hostname);
+        try {
+            await api.restoreDeletedCertificate(hostname);
+            toast.success(`${hostname} restored`);
+            await load();
+        } catch (err) {
+            toast.error(
+                err instanceof Error ? err.message : "Failed to restore certificate",
+            );
+        } finally {
+            setBusy(null);
+        }
+    };
+
+    const handlePurge = async (hostname: string) => {
+        setBusy(hostname);
+        try {
+            await api.purgeDeletedCertificate(hostname);
+            toast.success(`${hostname} permanently deleted`);
+            await load();
+        } catch (err) {
+            toast.error(
+                err instanceof Error ? err.message : "Failed to purge certificate",
+            );
+        } finally {
+            setBusy(null);
+            setPurgeTarget(null);
+        }
+    };
+
+    return (
+        <Card className={`shadow-sm border-border ${className ?? ""}`}>
+            <CardHeader>
+                <CardTitle>Trash</CardTitle>
+                <CardDescription>
+                    Deleted certificates keep their keys and history until they
+                    are purged
+                </CardDescription>
+            </CardHeader>
+            <CardContent>
+                {deleted === null ? (
+                    <p className="text-sm text-muted-foreground">
+                        Trash unavailable
+                    </p>
+                ) : deleted.length === 0 ? (
+                    <p className="text-sm text-muted-foreground">
+                        The trash is empty.
+                    </p>
+                ) : (
+                    <ul className="space-y-2">
+                        {deleted.map((cert) => (
+                            <li
+                                key={cert.hostname}
+                                className="flex items-center justify-between gap-4 border border-border p-3 text-sm"
+                            >
+                                <div className="min-w-0">
+                                    <div className="flex items-center gap-2">
+                                        <span className="font-medium truncate">
+                                            {cert.display_hostname}
+                                        </span>
+                                        {cert.has_private_key && (
+                                            <Badge
+                                                variant="secondary"
+                                                className="text-xs"
+                                            >
+                                                Private key
+                                            </Badge>
+                                        )}
+                                    </div>
+                                    <div className="text-muted-foreground">
+                                        Deleted {formatDateTime(cert.deleted_at)}{" "}
+                                        · purged {formatDate(cert.purge_at)}
+                                        {cert.expires_at &&
+                                            ` · expires ${formatDate(cert.expires_at)}`}
+                                    </div>
+                                </div>
+                                <div className="flex shrink-0 gap-2">
+                                    <Button
+                                        size="sm"
+                                        variant="outline"
+                                        onClick={() =>
+                                            handleRestore(cert.hostname)
+                                        }
+                                        disabled={busy !== null}
+                                    >
+                                        Restore
+                                    </Button>
+                                    <Button
+                                        size="sm"
+                                        variant="destructive"
+                                        onClick={() =>
+                                            setPurgeTarget(cert.hostname)
+                                        }
+                                        disabled={busy !== null}
+                                    >
+                                        Purge
+                                    </Button>
+                                </div>
+                            </li>
+                        ))}
+                    </ul>
+                )}
+            </CardContent>
+
+            <ConfirmDialog
+                open={purgeTarget !== null}
+                title="Purge certificate"
+                description={`This permanently deletes ${purgeTarget ?? ""} with its private keys and history. A backup is taken first.`}
+                confirmText="Purge"
+                cancelText="Cancel"
+                isDestructive
+                isLoading={busy !== null}
+                onConfirm={async () => {
+                    if (purgeTarget !== null) {
+                        await handlePurge(purgeTarget);
+                    }
+                }}
+                onCancel={() => setPurgeTarget(null)}
+            />
+        </Card>
+    );
+}
@@ -11,7 +11,9 @@ import { Badge } from "@/components/ui/badge";
 import { AdminGatedButton } from "@/components/shared/AdminGatedButton";
 import { ConfirmDialog } from "@/components/shared/ConfirmDialog";
 import { useSecurityKeys } from "@/hooks/useSecurityKeys";
-import { SecurityKeyInfo } from "@/types";
+import { api } from "@/lib/api";
+import { KeyValidationProgress, SecurityKeyInfo } from "@/types";
+import { EventsOn } from "../../../wailsjs/runtime/runtime";
 
 interface UnlockMethodsCardProps {
     onChangePassword: () => void;
@@ -51,18 +53,51 @@ export function UnlockMethodsCard({
     onChangePassword,
     className,
 }: UnlockMethodsCardProps) {
-    const { methods, webAuthnAvailable, refresh, enrollPasskey, remove } =
-        useSecurityKeys();
+    const {
+        methods,
+        webAuthnAvailable,
+        osNativeAvailable,
+        refresh,
+        enrollPasskey,
+        enrollOSNative,
+        remove,
+    } = useSecurityKeys();
 
     const [removeTarget, setRemoveTarget] = useState<SecurityKeyInfo | null>(
         null,
     );
     const [busy, setBusy] = useState(false);
+    const [validation, setValidation] = useState<KeyValidationProgress | null>(
+        null,
+    );
 
     useEffect(() => {
         void refresh();
     }, [refresh]);
 
+    // Progress of the background validation of every stored private key
+    useEffect(() => {
+        const cleanups = [
+            EventsOn("key-validation:progress", (p: KeyValidationProgress) =>
+                setValidation(p),
+            ),
+            EventsOn("key-validation:done", () => setValidation(null)),
+            EventsOn("key-validation:error", () => setValidation(null)),
+        ];
+        return () => cleanups.forEach((cleanup) => cleanup());
+    }, []);
+
+    const startValidation = async () => {
+        try {
+            await api.startKeyValidation();
+            setValidation({ checked: 0, total: 0 });
+        } catch (err) {
+            toast.error(
+                typeof err === "string" ? err : "Failed to start key validation",
+            );
+        }
+    };
+
     const run = async (fn: () => Promise<void>, ok: string) => {
         setBusy(true);
         try {
@@ -83,6 +118,7 @@ export function UnlockMethodsCard({
     };
 
     const passkeys = methods.filter((m) => m.method === "fido2");
+    const osNative = methods.find((m) => m.method === "os_native");
 
     return (
         <>
@@ -182,6 +218,76 @@ export function UnlockMethodsCard({
                             }
                         />
                     )}
+
+                    {/* Passwordless unlock through the OS keystore (DPAPI /
+                        Secret Service) — trusted machines only */}
+                    {(osNative || osNativeAvailable) && (
+                        <MethodRow
+                            title="Unlock with this computer"
+                            description="Opens without a prompt for anyone logged in as you — trusted machines only"
+                            badge={
+                                osNative && (
+                                    <Badge
+                                        variant="outline"
+                                        className="border-success/40 bg-success/10 text-success"
+                                    >
+                                        Enabled
+                                    </Badge>
+                                )
+                            }
+                            action={
+                                <AdminGatedButton
+                                    variant="outline"
+                                    size="sm"
+                                    requireAdminMode={false}
+                                    requireUnlocked
+                                    className={
+                                        osNative
+                                            ? "text-destructive hover:bg-destructive/10"
+                                            : undefined
+                                    }
+                                    onClick={() =>
+                                        osNative
+                                            ? setRemoveTarget(osNative)
+                                            : run(
+                                                  enrollOSNative,
+                                                  "Unlock with this computer enabled",
+                                              )
+                                    }
+                                    disabled={busy}
+                                >
+                                    {osNative ? "Remove" : "Enable"}
+                                </AdminGatedButton>
+                            }
+                        />
+                    )}
+
+                    {/* Full-inventory check — unlock only verifies the master key */}
+                    <MethodRow
+                        title="Verify stored keys"
+                        description={
+                            validation
+                                ? `Checking ${validation.checked} of ${validation.total || "…"} certificates`
+                                : "Decrypt every stored private key in the background"
+                        }
+                        action={
+                            <AdminGatedButton
+                                variant="outline"
+                                size="sm"
+                                requireAdminMode={false}
+                                requireUnlocked
+                                onClick={() =>
+                                    validation
+                                        ? void api
+                                              .cancelKeyValidation()
+                                              .finally(() => setValidation(null))
+                                        : void startValidation()
+                                }
+                            >
+                                {validation ? "Cancel" : "Verify"}
+                            </AdminGatedButton>
+                        }
+                    />
                 </CardContent>
             </Card>
 
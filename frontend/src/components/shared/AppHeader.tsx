@@ -2,10 +2,11 @@ import { useEffect, useState, useRef, useMemo } from "react";
 import { motion, AnimatePresence } from "motion/react";
 import { useTheme } from "next-themes";
 import { useAppStore } from "@/stores/useAppStore";
+import { api } from "@/lib/api";
 import { Button } from "@/components/ui/button";
 import { HugeiconsIcon } from "@hugeicons/react";
 import { Cancel01Icon, MinusSignIcon, SquareIcon, Bug01Icon, Copy01Icon } from "@hugeicons/core-free-icons";
-import { Quit, WindowMinimise, WindowToggleMaximise, WindowIsMaximised } from "../../../wailsjs/runtime/runtime";
+import { Quit, WindowToggleMaximise, WindowIsMaximised } from "../../../wailsjs/runtime/runtime";
 import { GetBuildInfo, OpenBugReport } from "../../../wailsjs/go/main/App";
 import { ThemeToggle } from "./ThemeToggle";
 import { EncryptionKeyButton } from "../layout/EncryptionKeyButton";
@@ -117,7 +118,7 @@ export function AppHeader({
                             <Button
                                 variant="ghost"
                                 size="icon"
-                                onClick={() => WindowMinimise()}
+                                onClick={() => api.minimizeWindow()}
                                 title="Minimize"
                                 className="text-muted-foreground"
                             >
@@ -308,7 +309,7 @@ export function AppHeader({
                             <Button
                                 variant="ghost"
                                 size="icon"
-                                onClick={() => WindowMinimise()}
+                                onClick={() => api.minimizeWindow()}
                                 title="Minimize"
                                 className="text-muted-foreground hover:bg-transparent dark:hover:bg-transparent"
                             >
@@ -42,6 +42,18 @@ export function EncryptionKeyDialog({ open, onClose }: EncryptionKeyDialogProps)
         if (!open) return;
         let cancelled = false;
         void (async () => {
+            // An os_native method enrolled on this machine unlocks silently
+            try {
+                if (await api.tryAutoUnlock()) {
+                    if (!cancelled) {
+                        setIsUnlocked(true);
+                        onClose();
+                    }
+                    return;
+                }
+            } catch {
+                // Fall back to the password / passkey prompt
+            }
             try {
                 const [available, keys] = await Promise.all([
                     api.isWebAuthnAvailable(),
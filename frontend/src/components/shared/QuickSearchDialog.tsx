@@ -0,0 +1,130 @@
+import { useEffect, useState } from "react";
+import { useNavigate } from "react-router-dom";
+import {
+    Dialog,
+    DialogContent,
+    DialogDescription,
+    DialogHeader,
+    DialogTitle,
+} from "@/components/ui/dialog";
+import { Input } from "@/components/ui/input";
+import { StatusBadge } from "@/components/certificate/StatusBadge";
+import { api } from "@/lib/api";
+import { cn } from "@/lib/utils";
+import type { QuickSearchResult } from "@/types";
+
+/**
+ * QuickSearchDialog - Command-palette style quick-open for certificates,
+ * opened with Ctrl+K (Cmd+K on macOS) from any main app page
+ */
+export function QuickSearchDialog() {
+    const navigate = useNavigate();
+    const [open, setOpen] = useState(false);
+    const [query, setQuery] = useState("");
+    const [results, setResults] = useState<QuickSearchResult[]>([]);
+    const [selected, setSelected] = useState(0);
+
+    useEffect(() => {
+        const handleKeyDown = (e: KeyboardEvent) => {
+            if ((e.ctrlKey || e.metaKey) && e.key.toLowerCase() === "k") {
+                e.preventDefault();
+                setOpen((prev) => !prev);
+            }
+        };
+        window.addEventListener("keydown", handleKeyDown);
+        return () => window.removeEventListener("keydown", handleKeyDown);
+    }, []);
+
+    useEffect(() => {
+        if (!open) return;
+        let cancelled = false;
+        api.quickSearch(query)
+            .then((found) => {
+                if (!cancelled) {
+                    setResults(found ?? []);
+                    setSelected(0);
+                }
+            })
+            .catch(() => {
+                if (!cancelled) setResults([]);
+            });
+        return () => {
+            cancelled = true;
+        };
+    }, [open, query]);
+
+    const close = () => {
+        setOpen(false);
+        setQuery("");
+        setResults([]);
+    };
+
+    const jumpTo = (hostname: string) => {
+        close();
+        navigate(`/certificates/${hostname}`);
+    };
+
+    const handleKeyDown = (e: React.KeyboardEvent) => {
+        if (e.key === "ArrowDown") {
+            e.preventDefault();
+            setSelected((i) => Math.min(i + 1, results.length - 1));
+        } else if (e.key === "ArrowUp") {
+            e.preventDefault();
+            setSelected((i) => Math.max(i - 1, 0));
+        } else if (e.key === "Enter" && results[selected]) {
+            e.preventDefault();
+            jumpTo(results[selected].hostname);
+        }
+    };
+
+    return (
+        <Dialog open={open} onOpenChange={(value) => (value ? setOpen(true) : close())}>
+            <DialogContent className="sm:max-w-[520px]">
+                <DialogHeader>
+                    <DialogTitle>Go to Certificate</DialogTitle>
+                    <DialogDescription>
+                        Search by hostname or SAN
+                    </DialogDescription>
+                </DialogHeader>
+                <Input
+                    autoFocus
+                    placeholder="Type a hostname..."
+                    value={query}
+                    onChange={(e) => setQuery(e.target.value)}
+                    onKeyDown={handleKeyDown}
+                />
+                <div className="max-h-80 overflow-y-auto space-y-1">
+                    {query.trim() !== "" && results.length === 0 && (
+                        <p className="text-sm text-muted-foreground px-2 py-1">
+                            No matching certificate
+                        </p>
+                    )}
+                    {results.map((result, i) => (
+                        <button
+                            key={result.hostname}
+                            type="button"
+                            className={cn(
+                                "w-full flex items-center justify-between rounded-md px-2 py-1.5 text-left text-sm",
+                                i === selected ? "bg-accent" : "hover:bg-accent/50",
+                            )}
+                            onMouseEnter={() => setSelected(i)}
+                            onClick={() => jumpTo(result.hostname)}
+                        >
+                            <span className="min-w-0">
+                                <span className="block truncate font-medium">
+                                    {result.display_hostname || result.hostname}
+                                </span>
+                                {result.matched_san && (
+                                    <span className="block truncate text-xs text-muted-foreground">
+                                        SAN: {result.matched_san}
+                                    </span>
+                                )}
+                            </span>
+                            <StatusBadge status={result.status} />
+                        </button>
+                    ))}
+                </div>
+            </DialogContent>
+        </Dialog>
+    );
+}
@@ -1,6 +1,16 @@
 import { useState } from "react";
 import { api } from "@/lib/api";
-import { LocalBackupInfo, BackupPeekInfo, CertImportResult } from "@/types";
+
+// Number of local backups fetched per page
+const BACKUPS_PAGE_SIZE = 20;
+import {
+    LocalBackupInfo,
+    BackupStorageBreakdown,
+    BackupPeekInfo,
+    CertImportResult,
+    BackupMergeOptions,
+    BackupMergeResult,
+} from "@/types";
 
 interface UseBackupReturn {
     isLoading: boolean;
@@ -10,7 +20,14 @@ interface UseBackupReturn {
     importCertificatesFromBackup: (
         path: string,
         password: string,
+        duplicateKeyPolicy?: string,
+        hostnameMapping?: Record<string, string>,
     ) => Promise<CertImportResult | null>;
+    mergeFromBackupFile: (
+        path: string,
+        password: string,
+        options: BackupMergeOptions,
+    ) => Promise<BackupMergeResult | null>;
     peekBackupInfo: (path: string) => Promise<BackupPeekInfo | null>;
     peekLocalBackup: (filename: string) => Promise<BackupPeekInfo | null>;
     selectBackupFile: () => Promise<string | null>;
@@ -19,8 +36,11 @@ interface UseBackupReturn {
 
     // Local backup operations
     localBackups: LocalBackupInfo[];
+    localBackupsTotal: number;
+    backupStorage: BackupStorageBreakdown | null;
     isLoadingBackups: boolean;
     listLocalBackups: () => Promise<void>;
+    loadMoreLocalBackups: () => Promise<void>;
     createManualBackup: () => Promise<void>;
     restoreLocalBackup: (filename: string) => Promise<void>;
     deleteLocalBackup: (filename: string) => Promise<void>;
@@ -33,6 +53,9 @@ export function useBackup(): UseBackupReturn {
     const [isLoading, setIsLoading] = useState(false);
     const [error, setError] = useState<string | null>(null);
     const [localBackups, setLocalBackups] = useState<LocalBackupInfo[]>([]);
+    const [localBackupsTotal, setLocalBackupsTotal] = useState(0);
+    const [backupStorage, setBackupStorage] =
+        useState<BackupStorageBreakdown | null>(null);
     const [isLoadingBackups, setIsLoadingBackups] = useState(false);
 
     const handleError = (err: unknown) => {
@@ -45,11 +68,34 @@ export function useBackup(): UseBackupReturn {
     const importCertificatesFromBackup = async (
         path: string,
         password: string,
+        duplicateKeyPolicy?: string,
+        hostnameMapping?: Record<string, string>,
     ): Promise<CertImportResult | null> => {
         setIsLoading(true);
         setError(null);
         try {
-            return await api.importCertificatesFromBackup(path, password);
+            return await api.importCertificatesFromBackup(path, password, {
+                best_effort: false,
+                duplicate_key_policy: duplicateKeyPolicy,
+                hostname_mapping: hostnameMapping,
+            });
+        } catch (err) {
+            handleError(err);
+            return null;
+        } finally {
+            setIsLoading(false);
+        }
+    };
+
+    const mergeFromBackupFile = async (
+        path: string,
+        password: string,
+        options: BackupMergeOptions,
+    ): Promise<BackupMergeResult | null> => {
+        setIsLoading(true);
+        setError(null);
+        try {
+            return await api.mergeFromBackupFile(path, password, options);
         } catch (err) {
             handleError(err);
             return null;
@@ -116,8 +162,13 @@ export function useBackup(): UseBackupReturn {
         setIsLoadingBackups(true);
         setError(null);
         try {
-            const backups = await api.listLocalBackups();
-            setLocalBackups(backups || []);
+            const [page, storage] = await Promise.all([
+                api.listLocalBackups({ limit: BACKUPS_PAGE_SIZE }),
+                api.getBackupStorageBreakdown(),
+            ]);
+            setLocalBackups(page.backups || []);
+            setLocalBackupsTotal(page.total);
+            setBackupStorage(storage);
         } catch (err) {
             handleError(err);
         } finally {
@@ -125,6 +176,20 @@ export function useBackup(): UseBackupReturn {
         }
     };
 
+    const loadMoreLocalBackups = async () => {
+        setError(null);
+        try {
+            const page = await api.listLocalBackups({
+                offset: localBackups.length,
+                limit: BACKUPS_PAGE_SIZE,
+            });
+            setLocalBackups([...localBackups, ...(page.backups || [])]);
+            setLocalBackupsTotal(page.total);
+        } catch (err) {
+            handleError(err);
+        }
+    };
+
     const createManualBackup = async () => {
         setIsLoading(true);
         setError(null);
@@ -168,14 +233,18 @@ export function useBackup(): UseBackupReturn {
         isLoading,
         error,
         importCertificatesFromBackup,
+        mergeFromBackupFile,
         peekBackupInfo,
         peekLocalBackup,
         selectBackupFile,
         copyToClipboard,
         getDataDirectory,
         localBackups,
+        localBackupsTotal,
+        backupStorage,
         isLoadingBackups,
         listLocalBackups,
+        loadMoreLocalBackups,
         createManualBackup,
         restoreLocalBackup,
         deleteLocalBackup,
@@ -2,10 +2,12 @@ import { useEffect, useState } from "react";
 import { useForm } from "react-hook-form";
 import { zodResolver } from "@hookform/resolvers/zod";
 import { useNavigate } from "react-router-dom";
+import { toast } from "sonner";
 import { useCertificates } from "@/hooks/useCertificates";
 import { useConfigStore } from "@/stores/useConfigStore";
 import { useAppStore } from "@/stores/useAppStore";
 import { api } from "@/lib/api";
+import { showWarnings } from "@/lib/warnings";
 import {
     csrRequestSchema,
     type CSRRequestInput,
@@ -14,16 +16,18 @@ import {
     hasSuffix,
 } from "@/lib/validation";
 import { parseBackendError } from "@/lib/error-parser";
-import type { Certificate, CSRRequest } from "@/types";
+import type { Certificate, CSRIntake, CSRRequest, SubjectPreset } from "@/types";
 import type { SANInputEntry } from "@/components/certificate/SANEditor";
 
 interface UseCSRFormOptions {
     renewalHostname?: string;
     regenerateHostname?: string;
+    // Request file loaded before switching to renewal mode
+    intake?: CSRIntake;
 }
 
 export function useCSRForm(options: UseCSRFormOptions = {}) {
-    const { renewalHostname, regenerateHostname } = options;
+    const { renewalHostname, regenerateHostname, intake: loadedIntake } = options;
     const navigate = useNavigate();
     const { config, setConfig } = useConfigStore();
     const { isUnlocked } = useAppStore();
@@ -35,6 +39,10 @@ export function useCSRForm(options: UseCSRFormOptions = {}) {
     const [generalError, setGeneralError] = useState<string | null>(null);
     const [existingCertificate, setExistingCertificate] = useState<Certificate | null>(null);
     const [certLoading, setCertLoading] = useState(false);
+    const [subjectPresets, setSubjectPresets] = useState<SubjectPreset[]>([]);
+    const [presetId, setPresetId] = useState<number>(0);
+
+    const [intake, setIntake] = useState<CSRIntake | null>(loadedIntake ?? null);
 
     const isRenewalMode = !!renewalHostname;
     const isRegenerateMode = !!regenerateHostname;
@@ -49,6 +57,7 @@ export function useCSRForm(options: UseCSRFormOptions = {}) {
             city: config?.default_city ?? "",
             state: config?.default_state ?? "",
             country: config?.default_country ?? "",
+            key_algorithm: "rsa",
             key_size: config?.default_key_size ?? 4096,
             note: "",
         },
@@ -70,9 +79,17 @@ export function useCSRForm(options: UseCSRFormOptions = {}) {
             }
         };
         loadConfig();
+        api.listSubjectPresets()
+            .then((presets) => setSubjectPresets(presets ?? []))
+            .catch((err) => console.error("Failed to load subject presets:", err));
         // eslint-disable-next-line react-hooks/exhaustive-deps
     }, []);
 
+    // The page stays mounted when a request file switches it to renewal mode
+    useEffect(() => {
+        if (loadedIntake) setIntake(loadedIntake);
+    }, [loadedIntake]);
+
     // Load existing certificate for renewal/regenerate
     useEffect(() => {
         const loadExistingCertificate = async () => {
@@ -101,7 +118,7 @@ export function useCSRForm(options: UseCSRFormOptions = {}) {
 
         if (isRegenerateMode && existingCertificate) {
             const baseHostname = existingCertificate.hostname;
-            const additionalSans = (existingCertificate.pending_sans || []).filter(
+            const additionalSans = (existingCertificate.pending?.sans || []).filter(
                 (san) => san !== existingCertificate.hostname
             );
             setSanInputs(
@@ -113,19 +130,20 @@ export function useCSRForm(options: UseCSRFormOptions = {}) {
 
             const formValues = {
                 hostname: baseHostname,
-                organization: existingCertificate.pending_organization || config.default_organization,
-                organizational_unit: existingCertificate.pending_organizational_unit || config.default_organizational_unit || "",
-                city: existingCertificate.pending_city || config.default_city,
-                state: existingCertificate.pending_state || config.default_state,
-                country: existingCertificate.pending_country || config.default_country,
-                key_size: existingCertificate.pending_key_size ?? config.default_key_size ?? 4096,
+                organization: existingCertificate.pending?.organization || config.default_organization,
+                organizational_unit: existingCertificate.pending?.organizational_unit || config.default_organizational_unit || "",
+                city: existingCertificate.pending?.city || config.default_city,
+                state: existingCertificate.pending?.state || config.default_state,
+                country: existingCertificate.pending?.country || config.default_country,
+                key_algorithm: existingCertificate.pending?.key_algorithm === "ecdsa" ? "ecdsa" as const : "rsa" as const,
+                key_size: existingCertificate.pending?.key_size ?? config.default_key_size ?? 4096,
                 note: "",
             };
             reset(formValues);
             setTimeout(() => setValue("key_size", formValues.key_size), 0);
         } else if (isRenewalMode && existingCertificate) {
             const baseHostname = existingCertificate.hostname;
-            const additionalSans = (existingCertificate.sans || []).filter(
+            const additionalSans = (existingCertificate.active?.sans || []).filter(
                 (san) => san !== existingCertificate.hostname
             );
             setSanInputs(
@@ -137,12 +155,13 @@ export function useCSRForm(options: UseCSRFormOptions = {}) {
 
             const formValues = {
                 hostname: baseHostname,
-                organization: existingCertificate.organization || config.default_organization,
-                organizational_unit: existingCertificate.organizational_unit || config.default_organizational_unit || "",
-                city: existingCertificate.city || config.default_city,
-                state: existingCertificate.state || config.default_state,
-                country: existingCertificate.country || config.default_country,
-                key_size: existingCertificate.key_size ?? config.default_key_size ?? 4096,
+                organization: existingCertificate.active?.organization || config.default_organization,
+                organizational_unit: existingCertificate.active?.organizational_unit || config.default_organizational_unit || "",
+                city: existingCertificate.active?.city || config.default_city,
+                state: existingCertificate.active?.state || config.default_state,
+                country: existingCertificate.active?.country || config.default_country,
+                key_algorithm: existingCertificate.active?.key_algorithm === "ecdsa" ? "ecdsa" as const : "rsa" as const,
+                key_size: existingCertificate.active?.key_size ?? config.default_key_size ?? 4096,
                 note: "",
             };
             reset(formValues);
@@ -155,11 +174,25 @@ export function useCSRForm(options: UseCSRFormOptions = {}) {
                 city: config.default_city,
                 state: config.default_state,
                 country: config.default_country,
+                key_algorithm: "rsa",
                 key_size: config.default_key_size ?? 4096,
                 note: "",
             });
         }
-    }, [config, reset, setValue, isRenewalMode, isRegenerateMode, existingCertificate]);
+
+        // A request file replaces the hostname and SANs it names
+        if (intake) {
+            setValue("hostname", intake.hostname);
+            if (intake.sans?.length) {
+                setSanInputs(
+                    intake.sans.map((san) => ({
+                        value: san.value,
+                        type: san.type === "ip" ? "ip" as const : "dns" as const,
+                    }))
+                );
+            }
+        }
+    }, [config, reset, setValue, isRenewalMode, isRegenerateMode, existingCertificate, intake]);
 
     // Route protection: redirect if encryption key not provided
     useEffect(() => {
@@ -181,6 +214,53 @@ export function useCSRForm(options: UseCSRFormOptions = {}) {
         }
     }, [isRenewalMode, isRegenerateMode, existingCertificate, existingHostname, navigate]);
 
+    // Fill the subject fields from a preset (0 restores the configuration defaults)
+    const selectPreset = (id: number) => {
+        setPresetId(id);
+        const preset = subjectPresets.find((p) => p.id === id);
+        const source = preset
+            ? preset
+            : {
+                  organization: config?.default_organization ?? "",
+                  organizational_unit: config?.default_organizational_unit ?? "",
+                  city: config?.default_city ?? "",
+                  state: config?.default_state ?? "",
+                  country: config?.default_country ?? "",
+              };
+        setValue("organization", source.organization, { shouldValidate: true });
+        setValue("organizational_unit", source.organizational_unit ?? "");
+        setValue("city", source.city, { shouldValidate: true });
+        setValue("state", source.state, { shouldValidate: true });
+        setValue("country", source.country, { shouldValidate: true });
+    };
+
+    // Load a request file filled by a colleague. A request for an existing
+    // certificate reopens the form in renewal mode.
+    const importRequestFile = async () => {
+        setGeneralError(null);
+        try {
+            const path = await api.selectCSRRequestFile();
+            if (!path) return;
+            const loaded = await api.importCSRRequestFile(path);
+            if (existingHostname) {
+                if (loaded.hostname !== existingHostname) {
+                    setGeneralError(`The request file is for ${loaded.hostname}, not ${existingHostname}`);
+                    return;
+                }
+            } else if (loaded.is_renewal) {
+                navigate("/certificates/generate", {
+                    replace: true,
+                    state: { renewal: loaded.hostname, intake: loaded },
+                });
+                return;
+            }
+            setIntake(loaded);
+            toast.success(`Request from ${loaded.requester.name} loaded`);
+        } catch (err) {
+            setGeneralError(err instanceof Error ? err.message : String(err));
+        }
+    };
+
     const onSubmit = async (data: CSRRequestInput) => {
         setGeneralError(null);
         setSanError(null);
@@ -225,15 +305,24 @@ export function useCSRForm(options: UseCSRFormOptions = {}) {
             city: data.city,
             state: data.state,
             country: data.country,
+            key_algorithm: data.key_algorithm,
             key_size: data.key_size,
             note: data.note || "",
             is_renewal: isRenewalMode || isRegenerateMode,
             skip_suffix_validation: skipSuffixValidation,
+            preset_id: presetId || undefined,
+            requester: intake?.requester,
         } as CSRRequest;
 
         try {
             const result = await generateCSR(csrRequest);
             if (result) {
+                showWarnings(result);
+                if (result.dependent_certificates?.length) {
+                    toast.warning(
+                        `Check the related endpoints once the renewed certificate is deployed: ${result.dependent_certificates.join(", ")}`,
+                    );
+                }
                 navigate(`/certificates/${encodeURIComponent(result.hostname)}`);
             }
         } catch (err) {
@@ -276,6 +365,12 @@ export function useCSRForm(options: UseCSRFormOptions = {}) {
         existingHostname,
         isLoading,
         config,
+        subjectPresets,
+        presetId,
+        selectPreset,
+        intake,
+        importRequestFile,
+        clearIntake: () => setIntake(null),
         onSubmit,
     };
 }
@@ -4,7 +4,7 @@ import { useCertificates } from "@/hooks/useCertificates";
 import { useBackup } from "@/hooks/useBackup";
 import { useAppStore } from "@/stores/useAppStore";
 import { api } from "@/lib/api";
-import type { Certificate, ChainCertificateInfo, HistoryEntry, CertificateUploadPreview } from "@/types";
+import type { Certificate, ChainCertificateInfo, ChainFetchDiagnostics, HistoryEntry, CertificateUploadPreview, CSRSubmission } from "@/types";
 
 interface UseCertificateDetailOptions {
     hostname?: string;
@@ -43,6 +43,8 @@ export function useCertificateDetail({ hostname }: UseCertificateDetailOptions)
     const [chain, setChain] = useState<ChainCertificateInfo[]>([]);
     const [chainLoading, setChainLoading] = useState(false);
     const [chainError, setChainError] = useState<string | null>(null);
+    const [chainDiagnostics, setChainDiagnostics] =
+        useState<ChainFetchDiagnostics | null>(null);
 
     // Active private key state
     const [privateKeyPEM, setPrivateKeyPEM] = useState<string | null>(null);
@@ -66,6 +68,9 @@ export function useCertificateDetail({ hostname }: UseCertificateDetailOptions)
     // Note saving state
     const [isSavingNote, setIsSavingNote] = useState(false);
 
+    // CSR submission state
+    const [isMarkingSubmitted, setIsMarkingSubmitted] = useState(false);
+
     // History state
     const [history, setHistory] = useState<HistoryEntry[]>([]);
     const [historyLoading, setHistoryLoading] = useState(false);
@@ -100,9 +105,11 @@ export function useCertificateDetail({ hostname }: UseCertificateDetailOptions)
 
         setChainLoading(true);
         setChainError(null);
+        setChainDiagnostics(null);
         try {
             const chainData = await api.getCertificateChain(hostname);
-            setChain(chainData || []);
+            setChain(chainData?.certificates || []);
+            setChainDiagnostics(chainData?.diagnostics ?? null);
         } catch (err) {
             setChainError(
                 err instanceof Error
@@ -229,6 +236,13 @@ export function useCertificateDetail({ hostname }: UseCertificateDetailOptions)
     const handleCancelRenewal = useCallback(async () => {
         if (!hostname) return;
         try {
+            if (!certificate?.certificate_pem) {
+                // First CSR: nothing to keep, remove the whole entry
+                await api.cancelNewRequest(hostname);
+                setCancelRenewalConfirming(false);
+                navigate("/", { replace: true });
+                return;
+            }
             await api.clearPendingCSR(hostname);
             setCancelRenewalConfirming(false);
             await loadCertificate();
@@ -240,7 +254,7 @@ export function useCertificateDetail({ hostname }: UseCertificateDetailOptions)
                     : "Failed to cancel renewal",
             );
         }
-    }, [hostname, loadCertificate, loadHistory]);
+    }, [hostname, certificate, navigate, loadCertificate, loadHistory]);
 
     const handlePreviewUpload = useCallback(async () => {
         if (!hostname || !uploadCertPEM.trim()) return;
@@ -338,6 +352,23 @@ export function useCertificateDetail({ hostname }: UseCertificateDetailOptions)
         [hostname, certificate]
     );
 
+    const handleMarkCSRSubmitted = useCallback(
+        async (caReference: string) => {
+            if (!hostname) return;
+            setIsMarkingSubmitted(true);
+            try {
+                await api.markCSRSubmitted(hostname, {
+                    ca_reference: caReference,
+                } as CSRSubmission);
+                await loadCertificate();
+                await loadHistory();
+            } finally {
+                setIsMarkingSubmitted(false);
+            }
+        },
+        [hostname, loadCertificate, loadHistory]
+    );
+
     const closeUploadDialog = useCallback(() => {
         setUploadDialogOpen(false);
         setUploadCertPEM("");
@@ -360,6 +391,8 @@ export function useCertificateDetail({ hostname }: UseCertificateDetailOptions)
         chain,
         chainLoading,
         chainError,
+        chainDiagnostics,
+        loadCertificateChain,
 
         // Active private key data
         privateKeyPEM,
@@ -375,6 +408,7 @@ export function useCertificateDetail({ hostname }: UseCertificateDetailOptions)
         history,
         historyLoading,
         historyError,
+        loadHistory,
 
         // Dialog states
         deleteConfirming,
@@ -403,6 +437,9 @@ export function useCertificateDetail({ hostname }: UseCertificateDetailOptions)
         // Note state
         isSavingNote,
 
+        // CSR submission state
+        isMarkingSubmitted,
+
         // Handlers
         handleDelete,
         handleCancelRenewal,
@@ -411,6 +448,7 @@ export function useCertificateDetail({ hostname }: UseCertificateDetailOptions)
         handleToggleReadOnly,
         handleSaveCurrentNote,
         handleSavePendingNote,
+        handleMarkCSRSubmitted,
         closeUploadDialog,
         navigate,
     };
@@ -1,5 +1,6 @@
 import { useState, useCallback } from "react";
 import { api } from "@/lib/api";
+import { showWarnings } from "@/lib/warnings";
 import {
     Certificate,
     CertificateListItem,
@@ -16,7 +17,11 @@ interface UseCertificatesReturn {
     error: string | null;
 
     // Operations
+    // Matching certificates across all pages, after listCertificatePage
+    total: number | null;
     listCertificates: (filter?: CertificateFilter) => Promise<void>;
+    // Loads one page; append adds it to the certificates already listed
+    listCertificatePage: (filter: CertificateFilter, append?: boolean) => Promise<void>;
     getCertificate: (hostname: string) => Promise<Certificate | null>;
     generateCSR: (req: CSRRequest) => Promise<CSRResponse | null>;
     uploadCertificate: (hostname: string, certPEM: string) => Promise<void>;
@@ -35,6 +40,7 @@ interface UseCertificatesReturn {
 export function useCertificates(): UseCertificatesReturn {
     const [isLoading, setIsLoading] = useState(false);
     const [error, setError] = useState<string | null>(null);
+    const [total, setTotal] = useState<number | null>(null);
     const { certificates, setCertificates } = useCertificateStore();
 
     const handleError = useCallback((err: unknown) => {
@@ -60,6 +66,28 @@ export function useCertificates(): UseCertificatesReturn {
         }
     }, [setCertificates, handleError]);
 
+    const listCertificatePage = useCallback(async (
+        filter: CertificateFilter,
+        append = false,
+    ) => {
+        setIsLoading(true);
+        setError(null);
+        try {
+            const page = await api.listCertificatePage(filter);
+            const certs = page.certificates || [];
+            setCertificates(
+                append
+                    ? [...useCertificateStore.getState().certificates, ...certs]
+                    : certs,
+            );
+            setTotal(page.total);
+        } catch (err) {
+            handleError(err);
+        } finally {
+            setIsLoading(false);
+        }
+    }, [setCertificates, handleError]);
+
     const getCertificate = useCallback(async (
         hostname: string,
     ): Promise<Certificate | null> => {
@@ -94,7 +122,7 @@ export function useCertificates(): UseCertificatesReturn {
         setIsLoading(true);
         setError(null);
         try {
-            await api.uploadCertificate(hostname, certPEM);
+            showWarnings(await api.uploadCertificate(hostname, certPEM));
             const certs = await api.listCertificates({});
             setCertificates(certs || []);
         } catch (err) {
@@ -108,7 +136,7 @@ export function useCertificates(): UseCertificatesReturn {
         setIsLoading(true);
         setError(null);
         try {
-            await api.importCertificate(req);
+            showWarnings(await api.importCertificate(req));
             const certs = await api.listCertificates({});
             setCertificates(certs || []);
         } catch (err) {
@@ -182,7 +210,9 @@ export function useCertificates(): UseCertificatesReturn {
         certificates,
         isLoading,
         error,
+        total,
         listCertificates,
+        listCertificatePage,
         getCertificate,
         generateCSR,
         uploadCertificate,
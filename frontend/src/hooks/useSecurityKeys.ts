@@ -2,22 +2,25 @@ import { useCallback, useState } from "react";
 import { api } from "@/lib/api";
 import { SecurityKeyInfo } from "@/types";
 
-// useSecurityKeys manages the app's unlock methods (password / passkey) and
-// platform availability.
+// useSecurityKeys manages the app's unlock methods (password / passkey /
+// OS-native) and platform availability.
 export function useSecurityKeys() {
     const [methods, setMethods] = useState<SecurityKeyInfo[]>([]);
     const [webAuthnAvailable, setWebAuthnAvailable] = useState(false);
+    const [osNativeAvailable, setOSNativeAvailable] = useState(false);
     const [isLoading, setIsLoading] = useState(false);
 
     const refresh = useCallback(async () => {
         setIsLoading(true);
         try {
-            const [list, wa] = await Promise.all([
+            const [list, wa, osNative] = await Promise.all([
                 api.listSecurityKeys(),
                 api.isWebAuthnAvailable(),
+                api.isOSNativeUnlockAvailable(),
             ]);
             setMethods(list || []);
             setWebAuthnAvailable(wa);
+            setOSNativeAvailable(osNative);
         } finally {
             setIsLoading(false);
         }
@@ -28,6 +31,11 @@ export function useSecurityKeys() {
         await refresh();
     }, [refresh]);
 
+    const enrollOSNative = useCallback(async () => {
+        await api.enrollOSNativeMethod();
+        await refresh();
+    }, [refresh]);
+
     const remove = useCallback(
         async (id: number) => {
             await api.removeSecurityKey(id);
@@ -39,9 +47,11 @@ export function useSecurityKeys() {
     return {
         methods,
         webAuthnAvailable,
+        osNativeAvailable,
         isLoading,
         refresh,
         enrollPasskey,
+        enrollOSNative,
         remove,
     };
 }
@@ -12,8 +12,9 @@ interface UseSetupReturn {
     loadConfig: () => Promise<void>;
     loadDefaults: () => Promise<void>;
     saveSetup: (req: SetupRequest) => Promise<void>;
+    completeSetup: (req: SetupRequest, password: string) => Promise<boolean>;
     peekBackupInfo: (path: string) => Promise<BackupPeekInfo | null>;
-    restoreFromBackupFile: (path: string) => Promise<void>;
+    restoreFromBackupFile: (path: string, password?: string, keepUnlockMethods?: boolean) => Promise<boolean>;
     selectBackupFile: () => Promise<string | null>;
 
     // Utilities
@@ -75,6 +76,24 @@ export function useSetup(): UseSetupReturn {
         }
     };
 
+    // Creates the configuration and the password in one step and leaves the
+    // app unlocked; a failure leaves nothing half-configured
+    const completeSetup = async (req: SetupRequest, password: string) => {
+        setIsLoading(true);
+        setError(null);
+        try {
+            await api.completeSetup(req, password);
+            await loadConfig();
+            setIsSetupComplete(true);
+            return true;
+        } catch (err) {
+            handleError(err);
+            return false;
+        } finally {
+            setIsLoading(false);
+        }
+    };
+
     const peekBackupInfo = async (
         path: string,
     ): Promise<BackupPeekInfo | null> => {
@@ -90,15 +109,27 @@ export function useSetup(): UseSetupReturn {
         }
     };
 
-    const restoreFromBackupFile = async (path: string) => {
+    // With a password, the backend validates it against the backup before
+    // restoring and leaves the app unlocked; without one the app is locked.
+    // keepUnlockMethods re-keys the backup to the current master key so the
+    // current unlock methods keep working (requires the app to be unlocked).
+    const restoreFromBackupFile = async (path: string, password?: string, keepUnlockMethods?: boolean) => {
         setIsLoading(true);
         setError(null);
         try {
-            await api.restoreFromBackupFile(path);
+            if (password && keepUnlockMethods) {
+                await api.restoreFromBackupFileKeepingUnlockMethods(path, password);
+            } else if (password) {
+                await api.restoreFromBackupFileWithPassword(path, password);
+            } else {
+                await api.restoreFromBackupFile(path);
+            }
             await loadConfig();
             setIsSetupComplete(true);
+            return true;
         } catch (err) {
             handleError(err);
+            return false;
         } finally {
             setIsLoading(false);
         }
@@ -122,6 +153,7 @@ export function useSetup(): UseSetupReturn {
         loadConfig,
         loadDefaults,
         saveSetup,
+        completeSetup,
         peekBackupInfo,
         restoreFromBackupFile,
         selectBackupFile,
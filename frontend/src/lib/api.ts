@@ -7,22 +7,76 @@ import {
     CertificateListItem,
     CSRRequest,
     CSRResponse,
+    CertificateResult,
+    CSRIntake,
     ImportRequest,
     CertificateFilter,
+    CertificatePage,
+    QuickSearchResult,
     SetupRequest,
+    SetupProgress,
     SetupDefaults,
     CertImportResult,
+    DirectoryImportPreview,
+    BackupMergeOptions,
+    BackupMergeResult,
+    RestoreReport,
     BackupPeekInfo,
+    BackupPasswordCheck,
     KeyValidationResult,
-    ChainCertificateInfo,
+    CertificateChain,
+    ChainOverride,
+    CertificateGraph,
+    DeploymentTarget,
+    EndpointProbe,
+    TagCount,
+    DeletedCertificate,
+    Profile,
+    SyncAgent,
+    SyncAgentEnrollment,
+    SyncServerStatus,
+    IssuerExpiry,
+    StatusPreview,
+    ChainTrustResult,
     HistoryEntry,
+    AuditFilter,
+    AuditLogPage,
+    HistoryFilter,
+    HistoryPage,
+    LeadTimeReport,
     Config,
     UpdateConfigRequest,
     CertificateUploadPreview,
-    LocalBackupInfo,
+    BackupListOptions,
+    BackupListPage,
+    BackupStorageBreakdown,
     UpdateInfo,
+    HealthStatus,
+    ShareBundleInfo,
+    CertificateQRCodes,
+    ServerConfigSnippet,
+    PinningConfig,
+    OpenSSLCommands,
+    BackupFreshness,
+    DatabaseUsage,
+    DatabaseCleanupResult,
+    LogLevelSettings,
+    OpenedFile,
+    AutostartStatus,
     UpdateHistoryEntry,
     SecurityKeyInfo,
+    NoteScanResult,
+    SessionActivity,
+    BulkPatch,
+    BulkUpdateResult,
+    BulkCSRRequest,
+    BulkCSRResult,
+    BulkDeletePreview,
+    BulkDeleteResult,
+    SessionState,
+    SubjectPreset,
+    CSRSubmission,
+    RenewalChecklist,
 } from "../types";
 
 // Encryption Key Management
@@ -30,11 +84,19 @@ export const api = {
     // Key management
     isWaitingForEncryptionKey: () => App.IsWaitingForEncryptionKey(),
     isUnlocked: () => App.IsUnlocked(),
+    getSessionState: () => App.GetSessionState() as Promise<SessionState>,
+    listProfiles: () => App.ListProfiles() as Promise<Profile[]>,
+    createProfile: (name: string) => App.CreateProfile(name),
+    switchProfile: (name: string) => App.SwitchProfile(name),
     provideEncryptionKey: (key: string) =>
         App.ProvideEncryptionKey(key) as Promise<KeyValidationResult>,
     skipEncryptionKey: () => App.SkipEncryptionKey(),
     clearEncryptionKey: () => App.ClearEncryptionKey(),
-    changeEncryptionKey: (newKey: string) => App.ChangeEncryptionKey(newKey),
+    getSessionActivity: () =>
+        App.GetSessionActivity() as Promise<SessionActivity>,
+    changeEncryptionKey: (newKey: string, dryRun = false) => App.ChangeEncryptionKey(newKey, dryRun),
+    startKeyValidation: () => App.StartKeyValidation(),
+    cancelKeyValidation: () => App.CancelKeyValidation(),
 
     // Security Key Management
     listSecurityKeys: () => App.ListSecurityKeys() as Promise<SecurityKeyInfo[]>,
@@ -44,40 +106,162 @@ export const api = {
     isWebAuthnAvailable: () => App.IsWebAuthnAvailable() as Promise<boolean>,
     enrollPasskey: () => App.EnrollPasskey(),
     unlockWithWebAuthn: () => App.UnlockWithWebAuthn() as Promise<boolean>,
+    isOSNativeUnlockAvailable: () =>
+        App.IsOSNativeUnlockAvailable() as Promise<boolean>,
+    enrollOSNativeMethod: () => App.EnrollOSNativeMethod(),
+    tryAutoUnlock: () => App.TryAutoUnlock() as Promise<boolean>,
 
     // Setup
     isSetupComplete: () => App.IsSetupComplete(),
     saveSetup: (req: SetupRequest) => App.SaveSetup(req),
+    completeSetup: (req: SetupRequest, password: string) =>
+        App.CompleteSetup(req, password),
+    getSetupProgress: () =>
+        App.GetSetupProgress() as Promise<SetupProgress | null>,
+    saveSetupProgress: (progress: SetupProgress) =>
+        App.SaveSetupProgress(progress),
+    abortSetup: () => App.AbortSetup(),
     getSetupDefaults: () => App.GetSetupDefaults() as Promise<SetupDefaults>,
 
     // Config management
     getConfig: () => App.GetConfig() as Promise<Config>,
     updateConfig: (req: UpdateConfigRequest) =>
         App.UpdateConfig(req) as Promise<Config>,
+    setHostnameSuffix: (suffix: string) => App.SetHostnameSuffix(suffix),
+    listSubjectPresets: () =>
+        App.ListSubjectPresets() as Promise<SubjectPreset[]>,
+    saveSubjectPreset: (preset: SubjectPreset) =>
+        App.SaveSubjectPreset(preset) as Promise<SubjectPreset>,
+    deleteSubjectPreset: (id: number) => App.DeleteSubjectPreset(id),
 
     // Backup import and restore
     peekBackupInfo: (path: string) =>
         App.PeekBackupInfo(path) as Promise<BackupPeekInfo>,
     peekLocalBackup: (filename: string) =>
         App.PeekLocalBackup(filename) as Promise<BackupPeekInfo>,
-    importCertificatesFromBackup: (path: string, password: string) =>
-        App.ImportCertificatesFromBackup(path, password) as Promise<CertImportResult>,
-    restoreFromBackupFile: (path: string) => App.RestoreFromBackupFile(path),
+    importCertificatesFromBackup: (
+        path: string,
+        password: string,
+        options: {
+            best_effort: boolean;
+            duplicate_key_policy?: string;
+            hostname_mapping?: Record<string, string>;
+        } = { best_effort: false },
+        dryRun = false,
+    ) =>
+        App.ImportCertificatesFromBackup(path, password, options, dryRun) as Promise<CertImportResult>,
+    selectCertificateDirectory: () =>
+        App.SelectCertificateDirectory() as Promise<string>,
+    previewDirectoryImport: (path: string) =>
+        App.PreviewDirectoryImport(path) as Promise<DirectoryImportPreview>,
+    importCertificatesFromDirectory: (path: string) =>
+        App.ImportCertificatesFromDirectory(path) as Promise<CertImportResult>,
+    selectHostnameMappingFile: () => App.SelectHostnameMappingFile() as Promise<string>,
+    readHostnameMappingFile: (path: string) =>
+        App.ReadHostnameMappingFile(path) as Promise<Record<string, string>>,
+    mergeFromBackupFile: (
+        path: string,
+        password: string,
+        options: BackupMergeOptions,
+        dryRun = false,
+    ) =>
+        App.MergeFromBackupFile(path, password, options, dryRun) as Promise<BackupMergeResult>,
+    restoreFromBackupFile: (path: string, dryRun = false) =>
+        App.RestoreFromBackupFile(path, dryRun) as Promise<RestoreReport>,
+    restoreFromBackupFileWithPassword: (path: string, password: string, dryRun = false) =>
+        App.RestoreFromBackupFileWithPassword(path, password, dryRun) as Promise<RestoreReport>,
+    restoreFromBackupFileKeepingUnlockMethods: (
+        path: string,
+        backupPassword: string,
+        dryRun = false,
+    ) =>
+        App.RestoreFromBackupFileKeepingUnlockMethods(
+            path,
+            backupPassword,
+            dryRun,
+        ) as Promise<RestoreReport>,
+    testBackupPassword: (path: string, password: string) =>
+        App.TestBackupPassword(path, password) as Promise<BackupPasswordCheck>,
     selectBackupFile: () => App.SelectBackupFile() as Promise<string>,
 
+    // Read-only backup browsing
+    openBackupReadOnly: (path: string) =>
+        App.OpenBackupReadOnly(path) as Promise<BackupPeekInfo>,
+    listBackupViewCertificates: (filter: CertificateFilter) =>
+        App.ListBackupViewCertificates(filter) as Promise<CertificateListItem[]>,
+    getBackupViewCertificate: (hostname: string) =>
+        App.GetBackupViewCertificate(hostname) as Promise<Certificate>,
+    saveBackupViewCertificateToFile: (hostname: string) =>
+        App.SaveBackupViewCertificateToFile(hostname),
+    closeBackupView: () => App.CloseBackupView(),
+
     // Certificate operations
     generateCSR: (req: CSRRequest) =>
         App.GenerateCSR(req) as Promise<CSRResponse>,
+    selectCSRRequestFile: () => App.SelectCSRRequestFile(),
+    importCSRRequestFile: (path: string) =>
+        App.ImportCSRRequestFile(path) as Promise<CSRIntake>,
     uploadCertificate: (hostname: string, certPEM: string) =>
-        App.UploadCertificate(hostname, certPEM),
+        App.UploadCertificate(hostname, certPEM) as Promise<CertificateResult>,
     importCertificate: (req: ImportRequest) =>
-        App.ImportCertificate(req),
+        App.ImportCertificate(req) as Promise<CertificateResult>,
     listCertificates: (filter: CertificateFilter) =>
         App.ListCertificates(filter) as Promise<CertificateListItem[]>,
+    listCertificatePage: (filter: CertificateFilter) =>
+        App.ListCertificatePage(filter) as Promise<CertificatePage>,
+    quickSearch: (query: string) =>
+        App.QuickSearch(query) as Promise<QuickSearchResult[]>,
     getCertificate: (hostname: string) =>
         App.GetCertificate(hostname) as Promise<Certificate>,
+    getCertificateBySerial: (serial: string) =>
+        App.GetCertificateBySerial(serial) as Promise<Certificate>,
+    findCertificatesBySAN: (san: string) =>
+        App.FindCertificatesBySAN(san) as Promise<CertificateListItem[]>,
     getCertificateChain: (hostname: string) =>
-        App.GetCertificateChain(hostname) as Promise<ChainCertificateInfo[]>,
+        App.GetCertificateChain(hostname) as Promise<CertificateChain>,
+    listChainOverrides: () =>
+        App.ListChainOverrides() as Promise<ChainOverride[]>,
+    setCertificateChainOverride: (hostname: string, override: string) =>
+        App.SetCertificateChainOverride(hostname, override),
+    setIssuerChainOverride: (issuerDN: string, override: string) =>
+        App.SetIssuerChainOverride(issuerDN, override),
+    getCertificateGraph: () =>
+        App.GetCertificateGraph() as Promise<CertificateGraph>,
+    addCertificateRelation: (source: string, target: string, relationType: string, label: string) =>
+        App.AddCertificateRelation(source, target, relationType, label),
+    deleteCertificateRelation: (id: number) => App.DeleteCertificateRelation(id),
+    listDeploymentTargets: (hostname: string) =>
+        App.ListDeploymentTargets(hostname) as Promise<DeploymentTarget[]>,
+    addDeploymentTarget: (hostname: string, name: string, location: string) =>
+        App.AddDeploymentTarget(hostname, name, location),
+    removeDeploymentTarget: (id: number) => App.RemoveDeploymentTarget(id),
+    probeEndpoint: (hostname: string, host: string, port: number) =>
+        App.ProbeEndpoint(hostname, host, port) as Promise<EndpointProbe>,
+    listCertificateTags: (hostname: string) =>
+        App.ListCertificateTags(hostname) as Promise<string[]>,
+    addCertificateTag: (hostname: string, tag: string) =>
+        App.AddCertificateTag(hostname, tag),
+    removeCertificateTag: (hostname: string, tag: string) =>
+        App.RemoveCertificateTag(hostname, tag),
+    listTags: () => App.ListTags() as Promise<TagCount[]>,
+    listSyncAgents: () => App.ListSyncAgents() as Promise<SyncAgent[]>,
+    enrollSyncAgent: (name: string, hostnames: string[]) =>
+        App.EnrollSyncAgent(name, hostnames) as Promise<SyncAgentEnrollment>,
+    setSyncAgentHostnames: (id: number, hostnames: string[]) =>
+        App.SetSyncAgentHostnames(id, hostnames),
+    revokeSyncAgent: (id: number) => App.RevokeSyncAgent(id),
+    getSyncServerStatus: () =>
+        App.GetSyncServerStatus() as Promise<SyncServerStatus>,
+    startSyncServer: (address: string) =>
+        App.StartSyncServer(address) as Promise<SyncServerStatus>,
+    stopSyncServer: () => App.StopSyncServer(),
+    clearChainCache: () => App.ClearChainCache() as Promise<number>,
+    evaluateChainTrust: (hostname: string) =>
+        App.EvaluateChainTrust(hostname) as Promise<ChainTrustResult>,
+    getIssuerExpiries: () =>
+        App.GetIssuerExpiries() as Promise<IssuerExpiry[]>,
+    previewStatusAt: (date: number) =>
+        App.PreviewStatusAt(date) as Promise<StatusPreview>,
     getPrivateKeyPEM: (hostname: string) =>
         App.GetPrivateKeyPEM(hostname) as Promise<string>,
     getPendingPrivateKeyPEM: (hostname: string) =>
@@ -85,23 +269,66 @@ export const api = {
     previewCertificateUpload: (hostname: string, certPEM: string) =>
         App.PreviewCertificateUpload(hostname, certPEM) as Promise<CertificateUploadPreview>,
     deleteCertificate: (hostname: string) => App.DeleteCertificate(hostname),
+    listDeletedCertificates: () =>
+        App.ListDeletedCertificates() as Promise<DeletedCertificate[]>,
+    restoreDeletedCertificate: (hostname: string) =>
+        App.RestoreDeletedCertificate(hostname),
+    purgeDeletedCertificate: (hostname: string) =>
+        App.PurgeDeletedCertificate(hostname),
     clearPendingCSR: (hostname: string) => App.ClearPendingCSR(hostname),
+    cancelNewRequest: (hostname: string) => App.CancelNewRequest(hostname),
     setCertificateReadOnly: (hostname: string, readOnly: boolean) =>
         App.SetCertificateReadOnly(hostname, readOnly),
     updateCertificateNote: (hostname: string, note: string) =>
         App.UpdateCertificateNote(hostname, note),
     updatePendingNote: (hostname: string, note: string) =>
         App.UpdatePendingNote(hostname, note),
+    markCSRSubmitted: (hostname: string, submission: CSRSubmission) =>
+        App.MarkCSRSubmitted(hostname, submission),
+    scanNotesForSecrets: () =>
+        App.ScanNotesForSecrets() as Promise<NoteScanResult>,
     getCertificateHistory: (hostname: string, limit?: number) =>
         App.GetCertificateHistory(hostname, limit || 50) as Promise<HistoryEntry[]>,
+    getGlobalHistory: (filter: HistoryFilter, limit: number, offset: number) =>
+        App.GetGlobalHistory(filter, limit, offset) as Promise<HistoryPage>,
+    exportHistoryCSV: (filter: HistoryFilter) => App.ExportHistoryCSV(filter),
+    getAuditLog: (filter: AuditFilter, limit: number, offset: number) =>
+        App.GetAuditLog(filter, limit, offset) as Promise<AuditLogPage>,
+    exportAuditLogCSV: (filter: AuditFilter) => App.ExportAuditLogCSV(filter),
+    exportCertificateInventory: (format: string) =>
+        App.ExportCertificateInventory(format),
+    getRenewalLeadTimes: () =>
+        App.GetRenewalLeadTimes() as Promise<LeadTimeReport>,
+    getRenewalChecklist: (hostname: string) =>
+        App.GetRenewalChecklist(hostname) as Promise<RenewalChecklist>,
+    setRenewalStep: (hostname: string, step: string, done: boolean) =>
+        App.SetRenewalStep(hostname, step, done) as Promise<RenewalChecklist>,
+    findHostnameDuplicates: () => App.FindHostnameDuplicates(),
+    mergeHostnameDuplicates: (keep: string) =>
+        App.MergeHostnameDuplicates(keep),
+    bulkUpdateCertificates: (hostnames: string[], patch: BulkPatch) =>
+        App.BulkUpdateCertificates(hostnames, patch) as Promise<BulkUpdateResult>,
+    previewBulkDelete: (hostnames: string[]) =>
+        App.PreviewBulkDelete(hostnames) as Promise<BulkDeletePreview>,
+    bulkDeleteCertificates: (hostnames: string[], confirmationToken: string, dryRun = false) =>
+        App.BulkDeleteCertificates(
+            hostnames,
+            confirmationToken,
+            dryRun,
+        ) as Promise<BulkDeleteResult>,
+    generateCSRBulk: (req: BulkCSRRequest) =>
+        App.GenerateCSRBulk(req) as Promise<BulkCSRResult>,
 
     // File operations
     saveCSRToFile: (hostname: string) => App.SaveCSRToFile(hostname),
     saveCertificateToFile: (hostname: string) =>
         App.SaveCertificateToFile(hostname),
-    saveChainToFile: (hostname: string) => App.SaveChainToFile(hostname),
+    saveChainToFile: (hostname: string, variant: string) =>
+        App.SaveChainToFile(hostname, variant),
     savePrivateKeyToFile: (hostname: string) =>
         App.SavePrivateKeyToFile(hostname),
+    saveP12ToFile: (hostname: string, password: string, legacy: boolean) =>
+        App.SaveP12ToFile(hostname, password, legacy),
     exportCertificateZip: (
         hostname: string,
         options: {
@@ -112,16 +339,62 @@ export const api = {
             pending_key: boolean;
         },
     ) => App.ExportCertificateZip(hostname, options),
+    exportRunbook: (hostname: string) => App.ExportRunbook(hostname),
+    getCertificateQRCodes: (hostname: string, includePEM: boolean) =>
+        App.GetCertificateQRCodes(hostname, includePEM) as Promise<CertificateQRCodes>,
+    generateServerConfigSnippet: (hostname: string, serverType: string) =>
+        App.GenerateServerConfigSnippet(hostname, serverType) as Promise<ServerConfigSnippet>,
+    generatePinningConfig: (hostnames: string[], format: string) =>
+        App.GeneratePinningConfig(hostnames, format) as Promise<PinningConfig>,
+    getOpenSSLCommands: (hostname: string) =>
+        App.GetOpenSSLCommands(hostname) as Promise<OpenSSLCommands>,
+
+    // Share bundles
+    createShareBundle: (
+        hostname: string,
+        includeKey: boolean,
+        password: string,
+        expiresHours: number,
+    ) => App.CreateShareBundle(hostname, includeKey, password, expiresHours),
+    selectShareBundleFile: () =>
+        App.SelectShareBundleFile() as Promise<string>,
+    peekShareBundle: (path: string, password: string) =>
+        App.PeekShareBundle(path, password) as Promise<ShareBundleInfo>,
+    importShareBundle: (path: string, password: string) =>
+        App.ImportShareBundle(path, password) as Promise<string>,
 
     // Local backup management
-    listLocalBackups: () =>
-        App.ListLocalBackups() as Promise<LocalBackupInfo[]>,
+    listLocalBackups: (options: BackupListOptions) =>
+        App.ListLocalBackups(options) as Promise<BackupListPage>,
+    getBackupStorageBreakdown: () =>
+        App.GetBackupStorageBreakdown() as Promise<BackupStorageBreakdown>,
     createManualBackup: () => App.CreateManualBackup(),
-    restoreLocalBackup: (filename: string) =>
-        App.RestoreLocalBackup(filename),
+    exportBackupWithPassword: (password: string) =>
+        App.ExportBackupWithPassword(password),
+    exportEverything: (password: string, confirmation: string) =>
+        App.ExportEverything(password, confirmation),
+    exportAnonymizedDatabase: () => App.ExportAnonymizedDatabase(),
+    exportAuditorSnapshot: () => App.ExportAuditorSnapshot(),
+    restoreLocalBackup: (filename: string, dryRun = false) =>
+        App.RestoreLocalBackup(filename, dryRun) as Promise<RestoreReport>,
     deleteLocalBackup: (filename: string) =>
         App.DeleteLocalBackup(filename),
 
+    // Health
+    getHealthStatus: () => App.GetHealthStatus() as Promise<HealthStatus>,
+    getBackupFreshness: () =>
+        App.GetBackupFreshness() as Promise<BackupFreshness>,
+    getDatabaseUsage: () =>
+        App.GetDatabaseUsage() as Promise<DatabaseUsage>,
+    cleanupDatabase: (action: string, olderThanDays: number) =>
+        App.CleanupDatabase(action, olderThanDays) as Promise<DatabaseCleanupResult>,
+
+    // Logging
+    getLogLevels: () => App.GetLogLevels() as Promise<LogLevelSettings>,
+    setLogLevel: (level: string) => App.SetLogLevel(level),
+    setComponentLogLevel: (component: string, level: string) =>
+        App.SetComponentLogLevel(component, level),
+
     // Update operations
     checkForUpdate: () => App.CheckForUpdate() as Promise<UpdateInfo>,
     checkForUpdateManual: () =>
@@ -131,6 +404,16 @@ export const api = {
     getUpdateHistory: (limit: number) =>
         App.GetUpdateHistory(limit) as Promise<UpdateHistoryEntry[]>,
 
+    // Files opened with the app (file associations, second launch)
+    takeOpenedFiles: () => App.TakeOpenedFiles() as Promise<OpenedFile[]>,
+
+    // Window (minimizes to the tray when enabled in settings)
+    minimizeWindow: () => App.MinimizeWindow(),
+
+    // Start on login
+    getAutostart: () => App.GetAutostart() as Promise<AutostartStatus>,
+    setAutostart: (enabled: boolean) => App.SetAutostart(enabled),
+
     // Utilities
     copyToClipboard: (text: string) => App.CopyToClipboard(text),
     getDataDirectory: () => App.GetDataDirectory() as Promise<string>,
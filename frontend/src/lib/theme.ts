@@ -53,6 +53,14 @@ export function formatFileSize(bytes: number): string {
   return parseFloat((bytes / Math.pow(k, i)).toFixed(2)) + ' ' + sizes[i];
 }
 
+/**
+ * Format a key as "4096 bits" for RSA or "ECDSA P-256" for ECDSA
+ */
+export function formatKeySize(keySize: number, keyAlgorithm?: string): string {
+  if (keyAlgorithm === 'ecdsa') return `ECDSA P-${keySize}`;
+  return `${keySize} bits`;
+}
+
 /**
  * Get computed RGB color from CSS variable
  * Works with OKLCH values by using browser's color computation
@@ -26,6 +26,53 @@ export const changePasswordSchema = z.object({
 
 export type ChangePasswordInput = z.infer<typeof changePasswordSchema>;
 
+// Password-protected backup export (one-off password, independent of unlock)
+export const exportBackupSchema = z.object({
+  export_password: z
+    .string()
+    .min(16, 'Export password must be at least 16 characters')
+    .max(256, 'Password is too long'),
+  export_password_confirm: z
+    .string()
+    .min(1, 'Please confirm the export password'),
+}).refine((data) => data.export_password === data.export_password_confirm, {
+  message: "Passwords do not match",
+  path: ["export_password_confirm"],
+});
+
+export type ExportBackupInput = z.infer<typeof exportBackupSchema>;
+
+// Phrase typed to confirm a full export (models.FullExportConfirmation)
+export const FULL_EXPORT_CONFIRMATION = 'EXPORT ALL PRIVATE KEYS';
+
+// Full export: archive password, plus the typed confirmation phrase
+export const fullExportSchema = z.object({
+  export_password: z
+    .string()
+    .min(16, 'Archive password must be at least 16 characters')
+    .max(256, 'Password is too long'),
+  export_password_confirm: z
+    .string()
+    .min(1, 'Please confirm the archive password'),
+  confirmation: z
+    .string()
+    .refine((value) => value === FULL_EXPORT_CONFIRMATION, {
+      message: `Type ${FULL_EXPORT_CONFIRMATION} to confirm`,
+    }),
+}).refine((data) => data.export_password === data.export_password_confirm, {
+  message: "Passwords do not match",
+  path: ["export_password_confirm"],
+});
+
+export type FullExportInput = z.infer<typeof fullExportSchema>;
+
+// Subject attributes (RFC 5280 upper bounds: organization and unit 64
+// characters, city and state 128). Control, formatting (bidi overrides,
+// zero-width) and private-use characters are refused by the backend.
+const forbiddenSubjectChars = /[\p{Cc}\p{Cf}\p{Co}]/u;
+const noForbiddenChars = (value: string) => !forbiddenSubjectChars.test(value);
+const forbiddenCharsMessage = 'Contains an invisible or control character';
+
 // Setup Request
 export const setupRequestSchema = z.object({
   owner_email: z
@@ -47,24 +94,27 @@ export const setupRequestSchema = z.object({
   default_organization: z
     .string()
     .min(1, 'Organization is required')
-    .max(255, 'Organization is too long'),
+    .max(64, 'Organization must not exceed 64 characters')
+    .refine(noForbiddenChars, forbiddenCharsMessage),
   default_organizational_unit: z
     .string()
-    .max(255, 'Organizational unit is too long')
+    .max(64, 'Organizational unit must not exceed 64 characters')
+    .refine(noForbiddenChars, forbiddenCharsMessage)
     .optional()
     .or(z.literal('')),
   default_city: z
     .string()
     .min(1, 'City is required')
-    .max(255, 'City is too long'),
+    .max(128, 'City must not exceed 128 characters')
+    .refine(noForbiddenChars, forbiddenCharsMessage),
   default_state: z
     .string()
     .min(1, 'State is required')
-    .max(255, 'State is too long'),
+    .max(128, 'State must not exceed 128 characters')
+    .refine(noForbiddenChars, forbiddenCharsMessage),
   default_country: z
     .string()
-    .min(2, 'Country code must be 2 characters')
-    .max(2, 'Country code must be 2 characters'),
+    .regex(/^[A-Za-z]{2}$/, 'Country code must be 2 letters (ISO 3166-1)'),
   default_key_size: z
     .number()
     .int('Must be a whole number')
@@ -114,7 +164,9 @@ export type SANEntry = z.infer<typeof sanEntrySchema>;
 // Validation regex patterns
 const ipv4Regex = /^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$/;
 const ipv6Regex = /^(?:[a-fA-F0-9]{1,4}:){7}[a-fA-F0-9]{1,4}$|^::(?:[a-fA-F0-9]{1,4}:){0,6}[a-fA-F0-9]{1,4}$|^[a-fA-F0-9]{1,4}::(?:[a-fA-F0-9]{1,4}:){0,5}[a-fA-F0-9]{1,4}$|^(?:[a-fA-F0-9]{1,4}:){1,6}::[a-fA-F0-9]{1,4}$|^(?:[a-fA-F0-9]{1,4}:){1,7}:$/;
-const dnsLabelRegex = /^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$/;
+// Unicode letters are allowed for internationalized names; the backend converts
+// them to punycode before encoding the CSR.
+const dnsLabelRegex = /^[\p{L}\p{N}]([\p{L}\p{N}\p{M}-]{0,61}[\p{L}\p{N}\p{M}])?$/u;
 
 /**
  * Validate a DNS hostname
@@ -200,35 +252,46 @@ export const csrRequestSchema = z.object({
   organization: z
     .string()
     .min(1, 'Organization is required')
-    .max(255, 'Organization is too long'),
+    .max(64, 'Organization must not exceed 64 characters')
+    .refine(noForbiddenChars, forbiddenCharsMessage),
   organizational_unit: z
     .string()
-    .max(255, 'Organizational unit is too long')
+    .max(64, 'Organizational unit must not exceed 64 characters')
+    .refine(noForbiddenChars, forbiddenCharsMessage)
     .optional()
     .or(z.literal('')),
   city: z
     .string()
     .min(1, 'City is required')
-    .max(255, 'City is too long'),
+    .max(128, 'City must not exceed 128 characters')
+    .refine(noForbiddenChars, forbiddenCharsMessage),
   state: z
     .string()
     .min(1, 'State is required')
-    .max(255, 'State is too long'),
+    .max(128, 'State must not exceed 128 characters')
+    .refine(noForbiddenChars, forbiddenCharsMessage),
   country: z
     .string()
-    .min(2, 'Country code must be 2 characters')
-    .max(2, 'Country code must be 2 characters'),
+    .regex(/^[A-Za-z]{2}$/, 'Country code must be 2 letters (ISO 3166-1)'),
+  key_algorithm: z.enum(['rsa', 'ecdsa']),
   key_size: z
     .number()
     .int('Must be a whole number')
-    .min(2048, 'Key size must be at least 2048 bits')
     .max(8192, 'Key size cannot exceed 8192 bits'),
   note: z
     .string()
     .max(500, 'Note is too long')
     .optional()
     .or(z.literal('')),
-});
+}).refine(
+  (data) => data.key_algorithm === 'ecdsa'
+    ? data.key_size === 256 || data.key_size === 384
+    : data.key_size >= 2048,
+  {
+    message: 'Use 2048 bits or more for RSA, P-256 or P-384 for ECDSA',
+    path: ['key_size'],
+  },
+);
 
 export type CSRRequestInput = z.infer<typeof csrRequestSchema>;
 
@@ -0,0 +1,9 @@
+import { toast } from "sonner";
+
+// Shows the warnings of an operation that succeeded with caveats (SANs the
+// CA dropped, no chain bundled, suffix check skipped)
+export function showWarnings(result?: { warnings?: string[] } | null) {
+    for (const warning of result?.warnings ?? []) {
+        toast.warning(warning);
+    }
+}
@@ -0,0 +1,285 @@
+import { useCallback, useEffect, useState } from "react";
+import { useNavigate } from "react-router-dom";
+import {
+    Card,
+    CardContent,
+    CardDescription,
+    CardHeader,
+    CardTitle,
+} from "@/components/ui/card";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import {
+    Select,
+    SelectContent,
+    SelectItem,
+    SelectTrigger,
+    SelectValue,
+} from "@/components/ui/select";
+import {
+    Table,
+    TableBody,
+    TableCell,
+    TableHead,
+    TableHeader,
+    TableRow,
+} from "@/components/ui/table";
+import { StatusAlert } from "@/components/shared/StatusAlert";
+import { LoadingSpinner } from "@/components/shared/LoadingSpinner";
+import { api } from "@/lib/api";
+import { formatDateTime } from "@/lib/theme";
+import type { HistoryFilter, HistoryPage } from "@/types";
+import { toast } from "sonner";
+import { HugeiconsIcon } from "@hugeicons/react";
+import { AlertCircleIcon } from "@hugeicons/core-free-icons";
+
+const PAGE_SIZE = 50;
+
+const EVENT_TYPES: Record<string, string> = {
+    csr_generated: "CSR generated",
+    csr_regenerated: "CSR regenerated",
+    csr_submitted: "CSR submitted",
+    certificate_uploaded: "Certificate uploaded",
+    certificate_imported: "Certificate imported",
+    certificate_restored: "Certificate restored",
+    pending_csr_removed: "Pending CSR removed",
+    readonly_enabled: "Read-only enabled",
+    readonly_disabled: "Read-only disabled",
+    hostname_merged: "Hostname merged",
+    share_bundle_created: "Share bundle created",
+    key_linked: "Key linked",
+    renewal_step_completed: "Renewal step completed",
+    renewal_step_reopened: "Renewal step reopened",
+    full_export: "Full export",
+    key_synced_to_agent: "Key synced to agent",
+};
+
+// toUnix converts a yyyy-mm-dd date input (local midnight) to Unix seconds
+function toUnix(date: string): number {
+    return date ? Math.floor(new Date(`${date}T00:00:00`).getTime() / 1000) : 0;
+}
+
+export function Activity() {
+    const navigate = useNavigate();
+    const [eventType, setEventType] = useState("all");
+    const [fromDate, setFromDate] = useState("");
+    const [toDate, setToDate] = useState("");
+    const [offset, setOffset] = useState(0);
+    const [page, setPage] = useState<HistoryPage | null>(null);
+    const [isLoading, setIsLoading] = useState(false);
+    const [isExporting, setIsExporting] = useState(false);
+    const [error, setError] = useState<string | null>(null);
+
+    // The "to" date is inclusive in the UI, exclusive in the filter
+    const buildFilter = useCallback(
+        (): HistoryFilter =>
+            ({
+                event_types: eventType === "all" ? undefined : [eventType],
+                from: toUnix(fromDate) || undefined,
+                to: toDate ? toUnix(toDate) + 24 * 60 * 60 : undefined,
+            }) as HistoryFilter,
+        [eventType, fromDate, toDate],
+    );
+
+    const load = useCallback(async () => {
+        setIsLoading(true);
+        setError(null);
+        try {
+            setPage(await api.getGlobalHistory(buildFilter(), PAGE_SIZE, offset));
+        } catch (err) {
+            setError(
+                err instanceof Error ? err.message : typeof err === "string" ? err : "Failed to load history",
+            );
+        } finally {
+            setIsLoading(false);
+        }
+    }, [buildFilter, offset]);
+
+    useEffect(() => {
+        load();
+    }, [load]);
+
+    // A new filter starts from the first page
+    useEffect(() => {
+        setOffset(0);
+    }, [eventType, fromDate, toDate]);
+
+    const handleExport = async () => {
+        setIsExporting(true);
+        try {
+            await api.exportHistoryCSV(buildFilter());
+        } catch (err) {
+            toast.error(
+                err instanceof Error ? err.message : typeof err === "string" ? err : "Failed to export history",
+            );
+        } finally {
+            setIsExporting(false);
+        }
+    };
+
+    const total = page?.total ?? 0;
+
+    return (
+        <>
+            {/* Page Header */}
+            <div className="flex items-center justify-between mb-8">
+                <div>
+                    <h1 className="text-3xl font-bold text-foreground">
+                        Activity
+                    </h1>
+                    <p className="text-muted-foreground mt-1">
+                        History across all certificates
+                    </p>
+                </div>
+                <Button variant="outline" onClick={() => navigate("/")}>
+                    ← Back
+                </Button>
+            </div>
+
+            <Card className="mb-6 shadow-sm border-border">
+                <CardHeader>
+                    <CardTitle>Filters</CardTitle>
+                    <CardDescription>
+                        Narrow by event type and date range, then export the
+                        result as CSV
+                    </CardDescription>
+                </CardHeader>
+                <CardContent>
+                    <div className="flex flex-wrap items-end gap-4">
+                        <div className="space-y-2">
+                            <Label>Event</Label>
+                            <Select value={eventType} onValueChange={setEventType}>
+                                <SelectTrigger size="sm" className="w-[220px]">
+                                    <SelectValue />
+                                </SelectTrigger>
+                                <SelectContent>
+                                    <SelectItem value="all">All events</SelectItem>
+                                    {Object.entries(EVENT_TYPES).map(([value, label]) => (
+                                        <SelectItem key={value} value={value}>
+                                            {label}
+                                        </SelectItem>
+                                    ))}
+                                </SelectContent>
+                            </Select>
+                        </div>
+                        <div className="space-y-2">
+                            <Label htmlFor="history_from">From</Label>
+                            <Input
+                                id="history_from"
+                                type="date"
+                                value={fromDate}
+                                onChange={(e) => setFromDate(e.target.value)}
+                            />
+                        </div>
+                        <div className="space-y-2">
+                            <Label htmlFor="history_to">To</Label>
+                            <Input
+                                id="history_to"
+                                type="date"
+                                value={toDate}
+                                onChange={(e) => setToDate(e.target.value)}
+                            />
+                        </div>
+                        <Button
+                            variant="outline"
+                            size="sm"
+                            onClick={handleExport}
+                            disabled={isExporting || total === 0}
+                        >
+                            {isExporting ? "Exporting..." : "Export CSV"}
+                        </Button>
+                    </div>
+                </CardContent>
+            </Card>
+
+            {error && (
+                <StatusAlert
+                    variant="destructive"
+                    className="mb-6"
+                    icon={
+                        <HugeiconsIcon
+                            icon={AlertCircleIcon}
+                            className="size-4"
+                            strokeWidth={2}
+                        />
+                    }
+                >
+                    {error}
+                </StatusAlert>
+            )}
+
+            {isLoading && !page ? (
+                <div className="flex items-center justify-center py-12">
+                    <LoadingSpinner text="Loading history..." />
+                </div>
+            ) : (
+                <Card className="shadow-sm border-border">
+                    <CardContent>
+                        {total === 0 ? (
+                            <p className="text-sm text-muted-foreground py-4">
+                                No history matches these filters.
+                            </p>
+                        ) : (
+                            <Table>
+                                <TableHeader>
+                                    <TableRow>
+                                        <TableHead>Date</TableHead>
+                                        <TableHead>Certificate</TableHead>
+                                        <TableHead>Event</TableHead>
+                                        <TableHead>By</TableHead>
+                                    </TableRow>
+                                </TableHeader>
+                                <TableBody>
+                                    {page?.entries.map((entry) => (
+                                        <TableRow
+                                            key={entry.id}
+                                            className="cursor-pointer"
+                                            onClick={() => navigate(`/certificates/${entry.hostname}`)}
+                                        >
+                                            <TableCell className="whitespace-nowrap">
+                                                {formatDateTime(entry.created_at)}
+                                            </TableCell>
+                                            <TableCell className="font-medium">
+                                                {entry.hostname}
+                                            </TableCell>
+                                            <TableCell>{entry.message}</TableCell>
+                                            <TableCell className="text-muted-foreground">
+                                                {entry.actor}
+                                            </TableCell>
+                                        </TableRow>
+                                    ))}
+                                </TableBody>
+                            </Table>
+                        )}
+                        {total > PAGE_SIZE && (
+                            <div className="flex items-center justify-between pt-4">
+                                <span className="text-sm text-muted-foreground">
+                                    {offset + 1}–{Math.min(offset + PAGE_SIZE, total)} of {total}
+                                </span>
+                                <div className="flex gap-2">
+                                    <Button
+                                        variant="outline"
+                                        size="sm"
+                                        disabled={offset === 0 || isLoading}
+                                        onClick={() => setOffset(Math.max(offset - PAGE_SIZE, 0))}
+                                    >
+                                        Previous
+                                    </Button>
+                                    <Button
+                                        variant="outline"
+                                        size="sm"
+                                        disabled={offset + PAGE_SIZE >= total || isLoading}
+                                        onClick={() => setOffset(offset + PAGE_SIZE)}
+                                    >
+                                        Next
+                                    </Button>
+                                </div>
+                            </div>
+                        )}
+                    </CardContent>
+                </Card>
+            )}
+        </>
+    );
+}
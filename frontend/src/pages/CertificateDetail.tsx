@@ -1,5 +1,5 @@
-import { useState, useMemo } from "react";
-import { useParams } from "react-router-dom";
+import { useEffect, useState, useMemo } from "react";
+import { useLocation, useParams } from "react-router-dom";
 import { motion, AnimatePresence } from "motion/react";
 import {
     Dialog,
@@ -18,15 +18,28 @@ import { EncryptionKeyDialog } from "@/components/shared/EncryptionKeyDialog";
 import { StatusAlert } from "@/components/shared/StatusAlert";
 import { LimitedModeNotice } from "@/components/shared/LimitedModeNotice";
 import { CertificatePath } from "@/components/certificate/CertificatePath";
+import { ChainTrustSection } from "@/components/certificate/ChainTrustSection";
 import { CertificateStatusSection } from "@/components/certificate/CertificateStatusSection";
 import { CertificateSubjectInfo } from "@/components/certificate/CertificateSubjectInfo";
+import { CertificateExtensionsSection } from "@/components/certificate/CertificateExtensionsSection";
 import { PendingCSRSection } from "@/components/certificate/PendingCSRSection";
 import { CertificatePEMSection } from "@/components/certificate/CertificatePEMSection";
 import { PrivateKeySection } from "@/components/certificate/PrivateKeySection";
 import { PendingPrivateKeySection } from "@/components/certificate/PendingPrivateKeySection";
 import { CertificateDescriptionEditor } from "@/components/certificate/CertificateDescriptionEditor";
 import { CertificateHistoryCard } from "@/components/certificate/CertificateHistoryCard";
+import { RenewalChecklistCard } from "@/components/certificate/RenewalChecklistCard";
+import { CertificateRelationsCard } from "@/components/certificate/CertificateRelationsCard";
+import { CertificateRequesterCard } from "@/components/certificate/CertificateRequesterCard";
+import { DeploymentTargetsCard } from "@/components/certificate/DeploymentTargetsCard";
+import { CertificateTagsCard } from "@/components/certificate/CertificateTagsCard";
 import { ExportDialog } from "@/components/certificate/ExportDialog";
+import { ShareBundleDialog } from "@/components/certificate/ShareBundleDialog";
+import { PKCS12ExportDialog } from "@/components/certificate/PKCS12ExportDialog";
+import { QRCodeDialog } from "@/components/certificate/QRCodeDialog";
+import { ServerConfigDialog } from "@/components/certificate/ServerConfigDialog";
+import { PinningConfigDialog } from "@/components/certificate/PinningConfigDialog";
+import { OpenSSLCommandsDialog } from "@/components/certificate/OpenSSLCommandsDialog";
 import { useCertificateDetail } from "@/hooks/useCertificateDetail";
 import {
     Tooltip,
@@ -45,6 +58,10 @@ import {
     Download04Icon,
     SquareLock02Icon,
     SquareUnlock02Icon,
+    Share01Icon,
+    QrCodeIcon,
+    SourceCodeIcon,
+    Key01Icon,
 } from "@hugeicons/core-free-icons";
 import { StatusBadge } from "@/components/certificate/StatusBadge";
 import { RenewalBadge } from "@/components/certificate/RenewalBadge";
@@ -52,6 +69,7 @@ import { ReadOnlyBadge } from "@/components/certificate/ReadOnlyBadge";
 import { formatDateTime, pendingCardStyles } from "@/lib/theme";
 import { tabTransition } from "@/lib/animations";
 import { ReadOnlyFade } from "@/components/shared/ReadOnlyFade";
+import { OpenedFile } from "@/types";
 
 export function CertificateDetail() {
     const { hostname } = useParams<{ hostname: string }>();
@@ -66,6 +84,8 @@ export function CertificateDetail() {
         chain,
         chainLoading,
         chainError,
+        chainDiagnostics,
+        loadCertificateChain,
         privateKeyPEM,
         privateKeyLoading,
         privateKeyError,
@@ -95,6 +115,7 @@ export function CertificateDetail() {
         showKeyDialog,
         setShowKeyDialog,
         isTogglingReadOnly,
+        isMarkingSubmitted,
         handleDelete,
         handleCancelRenewal,
         handlePreviewUpload,
@@ -102,12 +123,43 @@ export function CertificateDetail() {
         handleToggleReadOnly,
         handleSaveCurrentNote,
         handleSavePendingNote,
+        handleMarkCSRSubmitted,
         closeUploadDialog,
+        loadHistory,
         navigate,
     } = useCertificateDetail({ hostname });
 
+    // Certificate returned by the CA and opened from the file manager, matched
+    // to this entry's pending CSR by public key (see App.tsx): preview its upload
+    const location = useLocation();
+    const openedFile = (location.state as { openedFile?: OpenedFile } | null)
+        ?.openedFile;
+    const [autoPreview, setAutoPreview] = useState(false);
+    useEffect(() => {
+        if (openedFile?.kind !== "certificate" || !openedFile.certificate_pem) {
+            return;
+        }
+        setUploadCertPEM(
+            openedFile.certificate_pem + (openedFile.chain_pem ?? ""),
+        );
+        setUploadStep("input");
+        setUploadDialogOpen(true);
+        setAutoPreview(true);
+    }, [openedFile, setUploadCertPEM, setUploadStep, setUploadDialogOpen]);
+    useEffect(() => {
+        if (!autoPreview || !uploadCertPEM) return;
+        setAutoPreview(false);
+        handlePreviewUpload();
+    }, [autoPreview, uploadCertPEM, handlePreviewUpload]);
+
     // Tab state - user selection with automatic fallback when tab becomes invalid
     const [selectedTab, setSelectedTab] = useState<string | null>(null);
+    const [shareDialogOpen, setShareDialogOpen] = useState(false);
+    const [qrDialogOpen, setQrDialogOpen] = useState(false);
+    const [serverConfigDialogOpen, setServerConfigDialogOpen] = useState(false);
+    const [pinningDialogOpen, setPinningDialogOpen] = useState(false);
+    const [opensslDialogOpen, setOpensslDialogOpen] = useState(false);
+    const [p12DialogOpen, setP12DialogOpen] = useState(false);
 
     const activeTab = useMemo(() => {
         if (!certificate) return "activity";
@@ -156,7 +208,7 @@ export function CertificateDetail() {
             {/* Header */}
             <div className="flex items-center justify-between mb-6">
                 <h1 className="text-3xl font-bold text-foreground">
-                    {certificate.hostname}
+                    {certificate.display_hostname || certificate.hostname}
                 </h1>
                 <Button
                     variant="outline"
@@ -246,6 +298,86 @@ export function CertificateDetail() {
                             />
                             Export
                         </Button>
+                        {certificate.certificate_pem && (
+                            <Button
+                                variant="outline"
+                                size="sm"
+                                onClick={() => setP12DialogOpen(true)}
+                            >
+                                <HugeiconsIcon
+                                    icon={Key01Icon}
+                                    className="w-4 h-4 mr-1"
+                                    strokeWidth={2}
+                                />
+                                PFX
+                            </Button>
+                        )}
+                        {certificate.certificate_pem && (
+                            <Button
+                                variant="outline"
+                                size="sm"
+                                onClick={() => setShareDialogOpen(true)}
+                            >
+                                <HugeiconsIcon
+                                    icon={Share01Icon}
+                                    className="w-4 h-4 mr-1"
+                                    strokeWidth={2}
+                                />
+                                Share
+                            </Button>
+                        )}
+                        {certificate.certificate_pem && (
+                            <Button
+                                variant="outline"
+                                size="sm"
+                                onClick={() => setQrDialogOpen(true)}
+                            >
+                                <HugeiconsIcon
+                                    icon={QrCodeIcon}
+                                    className="w-4 h-4 mr-1"
+                                    strokeWidth={2}
+                                />
+                                QR
+                            </Button>
+                        )}
+                        <Button
+                            variant="outline"
+                            size="sm"
+                            onClick={() => setServerConfigDialogOpen(true)}
+                        >
+                            <HugeiconsIcon
+                                icon={SourceCodeIcon}
+                                className="w-4 h-4 mr-1"
+                                strokeWidth={2}
+                            />
+                            Config
+                        </Button>
+                        <Button
+                            variant="outline"
+                            size="sm"
+                            onClick={() => setPinningDialogOpen(true)}
+                        >
+                            <HugeiconsIcon
+                                icon={Key01Icon}
+                                className="w-4 h-4 mr-1"
+                                strokeWidth={2}
+                            />
+                            Pins
+                        </Button>
+                        {certificate.certificate_pem && (
+                            <Button
+                                variant="outline"
+                                size="sm"
+                                onClick={() => setOpensslDialogOpen(true)}
+                            >
+                                <HugeiconsIcon
+                                    icon={CheckmarkCircle02Icon}
+                                    className="w-4 h-4 mr-1"
+                                    strokeWidth={2}
+                                />
+                                Verify
+                            </Button>
+                        )}
                         <ReadOnlyFade readOnly={certificate.read_only}>
                             <AdminGatedButton
                                 variant="outline"
@@ -316,12 +448,23 @@ export function CertificateDetail() {
 
                         <CertificateSubjectInfo certificate={certificate} />
 
+                        {certificate.extensions && (
+                            <CertificateExtensionsSection
+                                extensions={certificate.extensions}
+                            />
+                        )}
+
                         <CertificatePath
                             chain={chain}
                             isLoading={chainLoading}
                             error={chainError}
+                            diagnostics={chainDiagnostics}
+                            hostname={certificate.hostname}
+                            onOverrideSaved={loadCertificateChain}
                         />
 
+                        <ChainTrustSection hostname={certificate.hostname} />
+
                         <CertificatePEMSection
                             certificatePEM={certificate.certificate_pem}
                         />
@@ -376,6 +519,8 @@ export function CertificateDetail() {
                             onCancelRenewal={handleCancelRenewal}
                             cancelRenewalConfirming={cancelRenewalConfirming}
                             setCancelRenewalConfirming={setCancelRenewalConfirming}
+                            onMarkSubmitted={handleMarkCSRSubmitted}
+                            isMarkingSubmitted={isMarkingSubmitted}
                             isLoading={certLoading}
                         />
 
@@ -413,6 +558,17 @@ export function CertificateDetail() {
                             key="activity"
                             {...tabTransition}
                         >
+                            <RenewalChecklistCard
+                                hostname={certificate.hostname}
+                                readOnly={certificate.read_only}
+                                onChange={loadHistory}
+                            />
+                            {certificate.requester && (
+                                <CertificateRequesterCard requester={certificate.requester} />
+                            )}
+                            <CertificateTagsCard hostname={certificate.hostname} />
+                            <DeploymentTargetsCard hostname={certificate.hostname} />
+                            <CertificateRelationsCard hostname={certificate.hostname} />
                             <CertificateHistoryCard
                                 history={history}
                                 isLoading={historyLoading}
@@ -428,7 +584,7 @@ export function CertificateDetail() {
             <ConfirmDialog
                 open={deleteConfirming}
                 title="Delete Certificate"
-                description={`Are you sure you want to delete ${certificate.hostname}? This action cannot be undone.`}
+                description={`Are you sure you want to delete ${certificate.hostname}? It is moved to the trash and can be restored from Settings until it is purged.`}
                 confirmText="Delete"
                 cancelText="Cancel"
                 isDestructive={true}
@@ -553,6 +709,14 @@ export function CertificateDetail() {
                                                     <span>{uploadPreview.sans.join(", ")}</span>
                                                 </>
                                             )}
+                                            {uploadPreview.chain_count > 0 && (
+                                                <>
+                                                    <span className="text-muted-foreground">Chain</span>
+                                                    <span>
+                                                        {uploadPreview.chain_count} issuer certificate{uploadPreview.chain_count !== 1 ? "s" : ""} included (stored with the certificate)
+                                                    </span>
+                                                </>
+                                            )}
                                         </div>
                                     </div>
                                 )}
@@ -589,6 +753,50 @@ export function CertificateDetail() {
                 isUnlocked={isUnlocked}
             />
 
+            {/* PKCS#12 Export Dialog */}
+            <PKCS12ExportDialog
+                open={p12DialogOpen}
+                onOpenChange={setP12DialogOpen}
+                hostname={certificate.hostname}
+                isUnlocked={isUnlocked}
+            />
+
+            {/* Share Bundle Dialog */}
+            <ShareBundleDialog
+                open={shareDialogOpen}
+                onOpenChange={setShareDialogOpen}
+                hostname={certificate.hostname}
+                isUnlocked={isUnlocked}
+            />
+
+            {/* QR Code Dialog */}
+            <QRCodeDialog
+                open={qrDialogOpen}
+                onOpenChange={setQrDialogOpen}
+                hostname={certificate.hostname}
+            />
+
+            {/* Server Configuration Dialog */}
+            <ServerConfigDialog
+                open={serverConfigDialogOpen}
+                onOpenChange={setServerConfigDialogOpen}
+                hostname={certificate.hostname}
+            />
+
+            {/* Pinning Configuration Dialog */}
+            <PinningConfigDialog
+                open={pinningDialogOpen}
+                onOpenChange={setPinningDialogOpen}
+                hostname={certificate.hostname}
+            />
+
+            {/* OpenSSL Verification Commands Dialog */}
+            <OpenSSLCommandsDialog
+                open={opensslDialogOpen}
+                onOpenChange={setOpensslDialogOpen}
+                hostname={certificate.hostname}
+            />
+
             {/* Encryption Key Dialog */}
             <EncryptionKeyDialog
                 open={showKeyDialog}
@@ -1,11 +1,12 @@
 import { useEffect, useState, useCallback } from "react";
 import { AnimatePresence, motion } from "motion/react";
-import { useNavigate } from "react-router-dom";
+import { useNavigate, useSearchParams } from "react-router-dom";
 import { toast } from "sonner";
 import { useCertificates } from "@/hooks/useCertificates";
 import { useAppStore } from "@/stores/useAppStore";
 import { useCertificateStore } from "@/stores/useCertificateStore";
 import { Card, CardContent } from "@/components/ui/card";
+import { Badge } from "@/components/ui/badge";
 import { Button } from "@/components/ui/button";
 import {
     InputGroup,
@@ -19,6 +20,12 @@ import {
     SelectTrigger,
     SelectValue,
 } from "@/components/ui/select";
+import {
+    DropdownMenu,
+    DropdownMenuContent,
+    DropdownMenuItem,
+    DropdownMenuTrigger,
+} from "@/components/ui/dropdown-menu";
 import { ToggleGroup, ToggleGroupItem } from "@/components/ui/toggle-group";
 import { LoadingSpinner } from "@/components/shared/LoadingSpinner";
 import { AdminGatedButton } from "@/components/shared/AdminGatedButton";
@@ -28,9 +35,13 @@ import { StatusAlert } from "@/components/shared/StatusAlert";
 import { LimitedModeNotice } from "@/components/shared/LimitedModeNotice";
 import { StatusBadge } from "@/components/certificate/StatusBadge";
 import { ReadOnlyBadge } from "@/components/certificate/ReadOnlyBadge";
+import { KeyStatusBadge } from "@/components/certificate/KeyStatusBadge";
 import { RenewalBadge } from "@/components/certificate/RenewalBadge";
-import { formatDate } from "@/lib/theme";
-import { CertificateFilter, CertificateListItem } from "@/types";
+import { StatusPreviewDialog } from "@/components/certificate/StatusPreviewDialog";
+import { BulkCSRDialog } from "@/components/certificate/BulkCSRDialog";
+import { formatDate, formatKeySize } from "@/lib/theme";
+import { api } from "@/lib/api";
+import { CertificateFilter, CertificateListItem, IssuerExpiry, TagCount } from "@/types";
 import { HugeiconsIcon } from "@hugeicons/react";
 import {
     Certificate02Icon,
@@ -39,14 +50,26 @@ import {
     AlertCircleIcon,
 } from "@hugeicons/core-free-icons";
 
+// Certificates listed per page of the dashboard
+const PAGE_SIZE = 100;
+
 export function Dashboard() {
     const navigate = useNavigate();
-    const { certificates, isLoading, error, listCertificates, setCertificateReadOnly } =
-        useCertificates();
+    const {
+        certificates,
+        total,
+        isLoading,
+        error,
+        listCertificatePage,
+        setCertificateReadOnly,
+    } = useCertificates();
     const { isUnlocked } = useAppStore();
     const { updateCertificate } = useCertificateStore();
 
     const [searchTerm, setSearchTerm] = useState("");
+    // The search runs in the backend once typing pauses
+    const [debouncedSearch, setDebouncedSearch] = useState("");
+    const [issuerAlerts, setIssuerAlerts] = useState<IssuerExpiry[]>([]);
     const [statusFilter, setStatusFilter] = useState<
         "all" | "pending" | "active" | "expiring" | "expired"
     >("all");
@@ -54,7 +77,17 @@ export function Dashboard() {
         "created",
     );
     const [sortOrder, setSortOrder] = useState<"asc" | "desc">("desc");
+    // Days since the CSR was submitted to the CA without an answer; 0 shows all
+    const [awaitingDays, setAwaitingDays] = useState(0);
+    // Tag the listed certificates must carry; empty shows all
+    const [tagFilter, setTagFilter] = useState("");
+    const [tags, setTags] = useState<TagCount[]>([]);
+    // URL or ticket ID the notes must mention, set from a certificate's notes
+    const [searchParams, setSearchParams] = useSearchParams();
+    const referenceFilter = searchParams.get("reference") ?? "";
     const [showKeyDialog, setShowKeyDialog] = useState(false);
+    const [showStatusPreview, setShowStatusPreview] = useState(false);
+    const [showBulkCSR, setShowBulkCSR] = useState(false);
     const [selectedHostname, setSelectedHostname] = useState<string | null>(null);
 
     // Handle card click with exit animation
@@ -66,6 +99,16 @@ export function Dashboard() {
         }, 300);
     };
 
+    const handleExportInventory = async (format: "csv" | "xlsx") => {
+        try {
+            await api.exportCertificateInventory(format);
+        } catch (err) {
+            toast.error(
+                err instanceof Error ? err.message : "Inventory export failed",
+            );
+        }
+    };
+
     // Alt + Right Click to toggle read-only state
     const handleContextMenu = useCallback(async (e: React.MouseEvent, cert: CertificateListItem) => {
         if (!e.altKey) return;
@@ -83,20 +126,49 @@ export function Dashboard() {
         }
     }, [setCertificateReadOnly, updateCertificate]);
 
-    const loadCertificates = async () => {
+    // Certificates are loaded a page at a time; "Show more" appends the next
+    const loadCertificates = async (offset = 0) => {
         const filter: CertificateFilter = {
             status: statusFilter,
             sort_by: sortBy,
             sort_order: sortOrder,
+            awaiting_response_days: awaitingDays || undefined,
+            tags: tagFilter ? [tagFilter] : undefined,
+            reference: referenceFilter || undefined,
+            search: debouncedSearch.trim() || undefined,
+            offset: offset || undefined,
+            limit: PAGE_SIZE,
         };
-        await listCertificates(filter);
+        await listCertificatePage(filter, offset > 0);
     };
 
     // eslint-disable-next-line react-hooks/exhaustive-deps -- load once on mount
     useEffect(() => { loadCertificates(); }, []);
 
+    // CA certificates (intermediates/roots) expiring soon affect every dependent cert
+    useEffect(() => {
+        api.getIssuerExpiries()
+            .then((issuers) =>
+                setIssuerAlerts(
+                    (issuers ?? []).filter((i) => i.status !== "active"),
+                ),
+            )
+            .catch(() => setIssuerAlerts([]));
+    }, []);
+
+    useEffect(() => {
+        api.listTags()
+            .then((list) => setTags(list ?? []))
+            .catch(() => setTags([]));
+    }, []);
+
+    useEffect(() => {
+        const timer = setTimeout(() => setDebouncedSearch(searchTerm), 250);
+        return () => clearTimeout(timer);
+    }, [searchTerm]);
+
     // eslint-disable-next-line react-hooks/exhaustive-deps -- reload when filters change, loadCertificates is stable
-    useEffect(() => { loadCertificates(); }, [statusFilter, sortBy, sortOrder]);
+    useEffect(() => { loadCertificates(); }, [statusFilter, sortBy, sortOrder, awaitingDays, tagFilter, referenceFilter, debouncedSearch]);
 
     const handleStatusFilterChange = (status: string) => {
         setSelectedHostname(null);
@@ -110,15 +182,17 @@ export function Dashboard() {
         setStatusFilter("all");
         setSortBy("created");
         setSortOrder("desc");
+        setAwaitingDays(0);
+        setTagFilter("");
+        setSearchParams({});
     };
 
-    const filteredCerts = certificates.filter(
-        (cert) =>
-            cert.hostname.toLowerCase().includes(searchTerm.toLowerCase()) ||
-            cert.sans?.some((san) =>
-                san.toLowerCase().includes(searchTerm.toLowerCase()),
-            ),
-    );
+    const hasFilters =
+        debouncedSearch.trim() !== "" ||
+        statusFilter !== "all" ||
+        awaitingDays > 0 ||
+        tagFilter !== "" ||
+        referenceFilter !== "";
 
     // Animation values
     const isAnimatingOut = selectedHostname !== null;
@@ -155,6 +229,14 @@ export function Dashboard() {
                     >
                         Generate CSR
                     </AdminGatedButton>
+                    <AdminGatedButton
+                        variant="outline"
+                        requireAdminMode={false}
+                        requireUnlocked
+                        onClick={() => setShowBulkCSR(true)}
+                    >
+                        Bulk CSR
+                    </AdminGatedButton>
                     <AdminGatedButton
                         variant="outline"
                         requireAdminMode={false}
@@ -163,6 +245,35 @@ export function Dashboard() {
                     >
                         Import Certificate
                     </AdminGatedButton>
+                    <Button
+                        variant="outline"
+                        onClick={() => setShowStatusPreview(true)}
+                    >
+                        Preview Date
+                    </Button>
+                    <DropdownMenu>
+                        <DropdownMenuTrigger asChild>
+                            <Button variant="outline">Export</Button>
+                        </DropdownMenuTrigger>
+                        <DropdownMenuContent align="end">
+                            <DropdownMenuItem
+                                onClick={() => handleExportInventory("csv")}
+                            >
+                                Inventory as CSV
+                            </DropdownMenuItem>
+                            <DropdownMenuItem
+                                onClick={() => handleExportInventory("xlsx")}
+                            >
+                                Inventory as Excel
+                            </DropdownMenuItem>
+                        </DropdownMenuContent>
+                    </DropdownMenu>
+                    <Button
+                        variant="outline"
+                        onClick={() => navigate("/activity")}
+                    >
+                        Activity
+                    </Button>
                     <Button
                         variant="outline"
                         onClick={() => navigate("/settings")}
@@ -189,6 +300,34 @@ export function Dashboard() {
                 </StatusAlert>
             )}
 
+            {/* Issuer Expiry Alerts */}
+            {issuerAlerts.map((issuer) => (
+                <StatusAlert
+                    key={issuer.fingerprint_sha256}
+                    variant={issuer.status === "expired" ? "destructive" : "warning"}
+                    className="mb-6"
+                    title={`${issuer.cert_type === "root" ? "Root" : "Intermediate"} CA ${issuer.subject_cn} ${
+                        issuer.status === "expired"
+                            ? "has expired"
+                            : `expires in ${issuer.days_until_expiration} days`
+                    }`}
+                    icon={
+                        <HugeiconsIcon
+                            icon={AlertCircleIcon}
+                            className="size-4"
+                            strokeWidth={2}
+                        />
+                    }
+                >
+                    {issuer.hostnames.length} certificate
+                    {issuer.hostnames.length !== 1 ? "s depend" : " depends"} on it
+                    {issuer.outlived_by && issuer.outlived_by.length > 0
+                        ? `; ${issuer.outlived_by.length} will outlive it and need reissuing`
+                        : ""}
+                    : {issuer.hostnames.join(", ")}
+                </StatusAlert>
+            ))}
+
             {/* Limited Mode Notice */}
             {!isUnlocked && (
                 <LimitedModeNotice
@@ -211,7 +350,7 @@ export function Dashboard() {
                                 />
                             </InputGroupAddon>
                             <InputGroupInput
-                                placeholder="Search by hostname or SAN..."
+                                placeholder="Search hostnames, SANs and notes..."
                                 value={searchTerm}
                                 onChange={(e) => { setSelectedHostname(null); setSearchTerm(e.target.value); }}
                             />
@@ -244,6 +383,62 @@ export function Dashboard() {
                             {/* Vertical Separator */}
                             <div className="border-l border-border h-8"></div>
 
+                            {/* CA Response Filter */}
+                            <div className="flex items-center gap-2">
+                                <label className="text-sm font-medium text-muted-foreground">
+                                    Awaiting CA
+                                </label>
+                                <Select
+                                    value={String(awaitingDays)}
+                                    onValueChange={(value) => setAwaitingDays(Number(value))}
+                                >
+                                    <SelectTrigger size="sm" className="w-[120px]">
+                                        <SelectValue placeholder="Any" />
+                                    </SelectTrigger>
+                                    <SelectContent>
+                                        <SelectItem value="0">Any</SelectItem>
+                                        <SelectItem value="7">7+ days</SelectItem>
+                                        <SelectItem value="14">14+ days</SelectItem>
+                                        <SelectItem value="30">30+ days</SelectItem>
+                                    </SelectContent>
+                                </Select>
+                            </div>
+
+                            {tags.length > 0 && (
+                                <>
+                                    {/* Vertical Separator */}
+                                    <div className="border-l border-border h-8"></div>
+
+                                    {/* Tag Filter */}
+                                    <div className="flex items-center gap-2">
+                                        <label className="text-sm font-medium text-muted-foreground">
+                                            Tag
+                                        </label>
+                                        <Select
+                                            value={tagFilter || "any"}
+                                            onValueChange={(value) =>
+                                                setTagFilter(value === "any" ? "" : value)
+                                            }
+                                        >
+                                            <SelectTrigger size="sm" className="w-[140px]">
+                                                <SelectValue placeholder="Any" />
+                                            </SelectTrigger>
+                                            <SelectContent>
+                                                <SelectItem value="any">Any</SelectItem>
+                                                {tags.map((t) => (
+                                                    <SelectItem key={t.tag} value={t.tag}>
+                                                        {t.tag} ({t.certificates})
+                                                    </SelectItem>
+                                                ))}
+                                            </SelectContent>
+                                        </Select>
+                                    </div>
+                                </>
+                            )}
+
+                            {/* Vertical Separator */}
+                            <div className="border-l border-border h-8"></div>
+
                             {/* Sort Controls */}
                             <div className="flex items-center gap-2">
                                 <label className="text-sm font-medium text-muted-foreground">
@@ -295,6 +490,26 @@ export function Dashboard() {
                                 </div>
                             </div>
 
+                            {referenceFilter && (
+                                <>
+                                    {/* Vertical Separator */}
+                                    <div className="border-l border-border h-8"></div>
+
+                                    {/* Note Reference Filter */}
+                                    <Badge variant="secondary" className="gap-1 pr-1">
+                                        Notes mention {referenceFilter}
+                                        <Button
+                                            variant="ghost"
+                                            size="icon-xs"
+                                            onClick={() => setSearchParams({})}
+                                            aria-label="Clear reference filter"
+                                        >
+                                            ×
+                                        </Button>
+                                    </Badge>
+                                </>
+                            )}
+
                             {/* Vertical Separator */}
                             <div className="border-l border-border h-8"></div>
 
@@ -317,7 +532,7 @@ export function Dashboard() {
                 <div className="flex items-center justify-center py-12">
                     <LoadingSpinner text="Loading certificates..." />
                 </div>
-            ) : filteredCerts.length === 0 ? (
+            ) : certificates.length === 0 ? (
                 <Card className="shadow-sm border-border">
                     <CardContent>
                         <EmptyState
@@ -329,17 +544,17 @@ export function Dashboard() {
                                 />
                             }
                             title={
-                                certificates.length === 0
+                                !hasFilters
                                     ? "No certificates yet"
                                     : "No results"
                             }
                             description={
-                                certificates.length === 0
+                                !hasFilters
                                     ? "Create your first certificate by generating a CSR or importing an existing one."
                                     : "Try adjusting your filters or search term."
                             }
                             action={
-                                certificates.length === 0
+                                !hasFilters
                                     ? {
                                           label: "Generate CSR",
                                           onClick: () =>
@@ -355,7 +570,7 @@ export function Dashboard() {
             ) : (
                 <div className="space-y-3">
                     <AnimatePresence mode="sync">
-                        {filteredCerts.map((cert) => {
+                        {certificates.map((cert) => {
                             const isSelected = selectedHostname === cert.hostname;
 
                             return (
@@ -399,7 +614,8 @@ export function Dashboard() {
                                                             strokeWidth={2}
                                                         />
                                                         <h3 className="text-lg font-semibold text-foreground">
-                                                            {cert.hostname}
+                                                            {cert.display_hostname ||
+                                                                cert.hostname}
                                                         </h3>
                                                         <AnimatePresence mode="sync">
                                                             <StatusBadge
@@ -415,6 +631,12 @@ export function Dashboard() {
                                                             {cert.read_only && (
                                                                 <ReadOnlyBadge key="read-only-badge" />
                                                             )}
+                                                            {cert.key_status && cert.key_status !== "ok" && (
+                                                                <KeyStatusBadge
+                                                                    key="key-status-badge"
+                                                                    status={cert.key_status}
+                                                                />
+                                                            )}
                                                         </AnimatePresence>
                                                     </div>
 
@@ -424,6 +646,16 @@ export function Dashboard() {
                                                         </div>
                                                     )}
 
+                                                    {cert.tags && cert.tags.length > 0 && (
+                                                        <div className="flex flex-wrap gap-1">
+                                                            {cert.tags.map((tag) => (
+                                                                <Badge key={tag} variant="secondary">
+                                                                    {tag}
+                                                                </Badge>
+                                                            ))}
+                                                        </div>
+                                                    )}
+
                                                     <div className="flex flex-wrap gap-4 text-xs text-muted-foreground">
                                                         <div>
                                                             <span className="font-medium">
@@ -446,7 +678,7 @@ export function Dashboard() {
                                                                 <span className="font-medium">
                                                                     Key Size:
                                                                 </span>{" "}
-                                                                {cert.key_size} bits
+                                                                {formatKeySize(cert.key_size, cert.key_algorithm)}
                                                             </div>
                                                         )}
                                                     </div>
@@ -470,9 +702,28 @@ export function Dashboard() {
             )}
 
                 {/* Certificates Count */}
-                <div className="mt-8 text-center text-sm text-muted-foreground">
-                    Showing {filteredCerts.length} of {certificates.length}{" "}
-                    certificates
+                <div className="mt-8 flex flex-col items-center gap-3 text-sm text-muted-foreground">
+                    {total !== null && total > certificates.length ? (
+                        <>
+                            <span>
+                                Showing {certificates.length} of {total}{" "}
+                                certificates
+                            </span>
+                            <Button
+                                variant="outline"
+                                size="sm"
+                                onClick={() => loadCertificates(certificates.length)}
+                                disabled={isLoading}
+                            >
+                                {isLoading ? "Loading..." : "Show more"}
+                            </Button>
+                        </>
+                    ) : (
+                        <span>
+                            Showing {certificates.length} certificate
+                            {certificates.length === 1 ? "" : "s"}
+                        </span>
+                    )}
                 </div>
             </motion.div>
 
@@ -481,6 +732,19 @@ export function Dashboard() {
                 open={showKeyDialog}
                 onClose={() => setShowKeyDialog(false)}
             />
+
+            {/* Bulk CSR Dialog */}
+            <BulkCSRDialog
+                open={showBulkCSR}
+                onOpenChange={setShowBulkCSR}
+                onGenerated={() => loadCertificates()}
+            />
+
+            {/* Status Preview Dialog */}
+            <StatusPreviewDialog
+                open={showStatusPreview}
+                onClose={() => setShowStatusPreview(false)}
+            />
         </>
     );
 }
@@ -38,11 +38,13 @@ import {
 } from "@/components/ui/input-group";
 import { SANEditor } from "@/components/certificate/SANEditor";
 import { HugeiconsIcon } from "@hugeicons/react";
-import { AlertCircleIcon } from "@hugeicons/core-free-icons";
+import { AlertCircleIcon, Cancel01Icon, FileImportIcon } from "@hugeicons/core-free-icons";
+import type { CSRIntake } from "@/types";
 
 interface LocationState {
     renewal?: string;
     regenerate?: string;
+    intake?: CSRIntake;
 }
 
 export function GenerateCSR() {
@@ -71,8 +73,18 @@ export function GenerateCSR() {
         existingHostname,
         isLoading,
         config,
+        subjectPresets,
+        presetId,
+        selectPreset,
+        intake,
+        importRequestFile,
+        clearIntake,
         onSubmit,
-    } = useCSRForm({ renewalHostname, regenerateHostname });
+    } = useCSRForm({
+        renewalHostname,
+        regenerateHostname,
+        intake: locationState?.intake,
+    });
 
     const {
         register,
@@ -122,17 +134,32 @@ export function GenerateCSR() {
                     <h1 className="text-3xl font-bold text-foreground">{pageTitle}</h1>
                     <p className="text-muted-foreground mt-1">{pageDescription}</p>
                 </div>
-                <Button
-                    variant="outline"
-                    size="sm"
-                    onClick={() =>
-                        existingHostname
-                            ? navigate(`/certificates/${encodeURIComponent(existingHostname)}`)
-                            : navigate("/")
-                    }
-                >
-                    ← Back
-                </Button>
+                <div className="flex gap-2">
+                    <Button
+                        variant="outline"
+                        size="sm"
+                        onClick={importRequestFile}
+                        disabled={isSubmitting || isLoading}
+                    >
+                        <HugeiconsIcon
+                            icon={FileImportIcon}
+                            className="w-4 h-4 mr-1"
+                            strokeWidth={2}
+                        />
+                        Load Request File
+                    </Button>
+                    <Button
+                        variant="outline"
+                        size="sm"
+                        onClick={() =>
+                            existingHostname
+                                ? navigate(`/certificates/${encodeURIComponent(existingHostname)}`)
+                                : navigate("/")
+                        }
+                    >
+                        ← Back
+                    </Button>
+                </div>
             </div>
 
             {generalError && (
@@ -170,7 +197,11 @@ export function GenerateCSR() {
                             >
                                 <LoadingSpinner
                                     size="lg"
-                                    text={`Generating ${watch("key_size")}-bit key... This may take a few seconds`}
+                                    text={
+                                        watch("key_algorithm") === "ecdsa"
+                                            ? `Generating ECDSA P-${watch("key_size")} key...`
+                                            : `Generating ${watch("key_size")}-bit key... This may take a few seconds`
+                                    }
                                 />
                             </motion.div>
                         </motion.div>
@@ -184,6 +215,36 @@ export function GenerateCSR() {
 
                 <CardContent>
                     <form onSubmit={handleSubmit(onSubmit)} className="space-y-6">
+                        {/* Requester from a request file */}
+                        {intake && (
+                            <div className="flex items-start justify-between gap-3 border border-border bg-muted/50 p-3 text-sm">
+                                <div className="min-w-0 space-y-1">
+                                    <p className="font-medium">
+                                        Requested by {intake.requester.name}
+                                        {intake.requester.team && ` (${intake.requester.team})`}
+                                    </p>
+                                    {intake.requester.email && (
+                                        <p className="text-muted-foreground">{intake.requester.email}</p>
+                                    )}
+                                    {intake.requester.justification && (
+                                        <p className="text-muted-foreground whitespace-pre-wrap">
+                                            {intake.requester.justification}
+                                        </p>
+                                    )}
+                                </div>
+                                <Button
+                                    type="button"
+                                    variant="ghost"
+                                    size="icon-sm"
+                                    onClick={clearIntake}
+                                    disabled={isSubmitting || isLoading}
+                                    aria-label="Remove requester"
+                                >
+                                    <HugeiconsIcon icon={Cancel01Icon} className="size-4" strokeWidth={2} />
+                                </Button>
+                            </div>
+                        )}
+
                         {/* Admin Bypass Checkbox */}
                         <div className="flex items-center space-x-2">
                             <Tooltip>
@@ -272,6 +333,30 @@ export function GenerateCSR() {
                             error={sanError}
                         />
 
+                        {/* Subject Preset */}
+                        {subjectPresets.length > 0 && (
+                            <div className="space-y-2">
+                                <Label htmlFor="subject_preset">Subject Preset</Label>
+                                <Select
+                                    value={String(presetId)}
+                                    onValueChange={(value) => selectPreset(Number(value))}
+                                    disabled={isSubmitting || isLoading}
+                                >
+                                    <SelectTrigger id="subject_preset" className="w-full">
+                                        <SelectValue placeholder="Configuration defaults" />
+                                    </SelectTrigger>
+                                    <SelectContent>
+                                        <SelectItem value="0">Configuration defaults</SelectItem>
+                                        {subjectPresets.map((preset) => (
+                                            <SelectItem key={preset.id} value={String(preset.id)}>
+                                                {preset.name}
+                                            </SelectItem>
+                                        ))}
+                                    </SelectContent>
+                                </Select>
+                            </div>
+                        )}
+
                         {/* Organization */}
                         <div className="space-y-2">
                             <Label htmlFor="organization">Organization *</Label>
@@ -342,9 +427,37 @@ export function GenerateCSR() {
                             )}
                         </div>
 
+                        {/* Key Algorithm */}
+                        <div className="space-y-2">
+                            <Label htmlFor="key_algorithm">Key Algorithm *</Label>
+                            <Controller
+                                name="key_algorithm"
+                                control={control}
+                                render={({ field }) => (
+                                    <Select
+                                        value={field.value}
+                                        onValueChange={(value) => {
+                                            field.onChange(value);
+                                            // Key sizes differ between algorithms
+                                            setValue("key_size", value === "ecdsa" ? 256 : 4096);
+                                        }}
+                                        disabled={isSubmitting || isLoading}
+                                    >
+                                        <SelectTrigger id="key_algorithm" className="w-full">
+                                            <SelectValue placeholder="Select key algorithm" />
+                                        </SelectTrigger>
+                                        <SelectContent>
+                                            <SelectItem value="rsa">RSA</SelectItem>
+                                            <SelectItem value="ecdsa">ECDSA</SelectItem>
+                                        </SelectContent>
+                                    </Select>
+                                )}
+                            />
+                        </div>
+
                         {/* Key Size */}
                         <div className="space-y-2">
-                            <Label htmlFor="key_size">Key Size (bits) *</Label>
+                            <Label htmlFor="key_size">Key Size *</Label>
                             <Controller
                                 name="key_size"
                                 control={control}
@@ -363,9 +476,18 @@ export function GenerateCSR() {
                                             <SelectValue placeholder="Select key size" />
                                         </SelectTrigger>
                                         <SelectContent>
-                                            <SelectItem value="2048">2048 bits</SelectItem>
-                                            <SelectItem value="3072">3072 bits</SelectItem>
-                                            <SelectItem value="4096">4096 bits (Recommended)</SelectItem>
+                                            {watch("key_algorithm") === "ecdsa" ? (
+                                                <>
+                                                    <SelectItem value="256">P-256 (Recommended)</SelectItem>
+                                                    <SelectItem value="384">P-384</SelectItem>
+                                                </>
+                                            ) : (
+                                                <>
+                                                    <SelectItem value="2048">2048 bits</SelectItem>
+                                                    <SelectItem value="3072">3072 bits</SelectItem>
+                                                    <SelectItem value="4096">4096 bits (Recommended)</SelectItem>
+                                                </>
+                                            )}
                                         </SelectContent>
                                     </Select>
                                 )}
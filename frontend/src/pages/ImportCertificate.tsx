@@ -1,5 +1,5 @@
-import { useState } from "react";
-import { useNavigate } from "react-router-dom";
+import { useEffect, useState } from "react";
+import { useLocation, useNavigate } from "react-router-dom";
 import { useForm, Controller } from "react-hook-form";
 import { zodResolver } from "@hookform/resolvers/zod";
 import { useCertificates } from "@/hooks/useCertificates";
@@ -19,13 +19,17 @@ import { Textarea } from "@/components/ui/textarea";
 import { Label } from "@/components/ui/label";
 import { FileDropTextarea } from "@/components/shared/FileDropTextarea";
 import { StatusAlert } from "@/components/shared/StatusAlert";
+import { ImportShareBundleDialog } from "@/components/certificate/ImportShareBundleDialog";
 import { HugeiconsIcon } from "@hugeicons/react";
 import { AlertCircleIcon } from "@hugeicons/core-free-icons";
+import { OpenedFile } from "@/types";
 
 export function ImportCertificate() {
     const navigate = useNavigate();
+    const location = useLocation();
     const { importCertificate, isLoading, error } = useCertificates();
     const [step, setStep] = useState<"form" | "confirm">("form");
+    const [shareBundleOpen, setShareBundleOpen] = useState(false);
 
     const {
         register,
@@ -33,10 +37,29 @@ export function ImportCertificate() {
         formState: { errors, isSubmitting },
         watch,
         control,
+        setValue,
     } = useForm<ImportCertificateInput>({
         resolver: zodResolver(importCertificateSchema),
     });
 
+    // PEM file double-clicked while the app was running (see App.tsx)
+    const openedFile = (location.state as { openedFile?: OpenedFile } | null)
+        ?.openedFile;
+    useEffect(() => {
+        if (openedFile?.kind !== "certificate") return;
+        setStep("form");
+        if (openedFile.certificate_pem) {
+            setValue("certificate_pem", openedFile.certificate_pem, {
+                shouldDirty: true,
+            });
+        }
+        if (openedFile.private_key_pem) {
+            setValue("private_key_pem", openedFile.private_key_pem, {
+                shouldDirty: true,
+            });
+        }
+    }, [openedFile, setValue]);
+
     // eslint-disable-next-line react-hooks/incompatible-library -- react-hook-form's watch() is inherently non-memoizable
     const certificatePem = watch("certificate_pem");
     const privateKeyPem = watch("private_key_pem");
@@ -133,15 +156,34 @@ export function ImportCertificate() {
                         key
                     </p>
                 </div>
-                <Button
-                    variant="outline"
-                    size="sm"
-                    onClick={() => navigate("/")}
-                >
-                    ← Back
-                </Button>
+                <div className="flex gap-2">
+                    <Button
+                        variant="outline"
+                        size="sm"
+                        onClick={() => setShareBundleOpen(true)}
+                    >
+                        From Share Bundle
+                    </Button>
+                    <Button
+                        variant="outline"
+                        size="sm"
+                        onClick={() => navigate("/")}
+                    >
+                        ← Back
+                    </Button>
+                </div>
             </div>
 
+            <ImportShareBundleDialog
+                open={shareBundleOpen}
+                onClose={() => setShareBundleOpen(false)}
+                onImported={(hostname) =>
+                    navigate(`/certificates/${encodeURIComponent(hostname)}`, {
+                        replace: true,
+                    })
+                }
+            />
+
             {error && (
                 <StatusAlert
                     variant="destructive"
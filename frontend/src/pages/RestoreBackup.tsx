@@ -1,25 +1,37 @@
-import { useState } from "react";
-import { useNavigate } from "react-router-dom";
+import { useEffect, useState } from "react";
+import { useLocation, useNavigate } from "react-router-dom";
 import { motion, AnimatePresence } from "motion/react";
 import { stepAnimations } from "@/lib/animations";
 import { useSetup } from "@/hooks/useSetup";
+import { toast } from "sonner";
 import { useAppStore } from "@/stores/useAppStore";
 import { Card, CardContent } from "@/components/ui/card";
 import { Button } from "@/components/ui/button";
 import { Badge } from "@/components/ui/badge";
 import { Label } from "@/components/ui/label";
+import { Input } from "@/components/ui/input";
+import { Checkbox } from "@/components/ui/checkbox";
 import { LoadingSpinner } from "@/components/shared/LoadingSpinner";
-import { BackupPeekInfo } from "@/types";
+import { api } from "@/lib/api";
+import { BackupPasswordCheck, BackupPeekInfo, OpenedFile } from "@/types";
 import { HugeiconsIcon } from "@hugeicons/react";
 import { AlertCircleIcon } from "@hugeicons/core-free-icons";
 import { StatusAlert } from "@/components/shared/StatusAlert";
 import { ReviewSection, ReviewField } from "@/components/shared/ReviewField";
 
+const UNLOCK_METHOD_LABELS: Record<string, string> = {
+  password: "Password",
+  os_native: "OS keyring",
+  fido2: "Passkey",
+};
+
 export function RestoreBackup() {
   const navigate = useNavigate();
+  const location = useLocation();
   const {
     setIsSetupComplete,
     setIsWaitingForEncryptionKey,
+    isUnlocked,
     setIsUnlocked,
   } = useAppStore();
   const { isLoading, error, peekBackupInfo, restoreFromBackupFile, selectBackupFile, clearError } = useSetup();
@@ -27,6 +39,12 @@ export function RestoreBackup() {
   const [backupPath, setBackupPath] = useState<string | null>(null);
   const [peekInfo, setPeekInfo] = useState<BackupPeekInfo | null>(null);
   const [isSelecting, setIsSelecting] = useState(false);
+  const [backupPassword, setBackupPassword] = useState("");
+  const [passwordCheck, setPasswordCheck] = useState<BackupPasswordCheck | null>(null);
+  const [isTesting, setIsTesting] = useState(false);
+  const [keepUnlockMethods, setKeepUnlockMethods] = useState(false);
+  // Replace the backup's hostname suffix with the one its hostnames share
+  const [useSuggestedSuffix, setUseSuggestedSuffix] = useState(true);
 
   const handleSelectFile = async () => {
     clearError();
@@ -37,40 +55,97 @@ export function RestoreBackup() {
         setIsSelecting(false);
         return;
       }
+      await loadBackup(path);
+    } catch {
+      setIsSelecting(false);
+    }
+  };
 
+  const loadBackup = async (path: string) => {
+    setIsSelecting(true);
+    try {
       setBackupPath(path);
       const info = await peekBackupInfo(path);
-      setIsSelecting(false);
-
       if (!info) return; // error is set by the hook
       setPeekInfo(info);
+      setUseSuggestedSuffix(true);
       setStep("confirm");
-    } catch {
+    } finally {
       setIsSelecting(false);
     }
   };
 
+  // Backup double-clicked while the app was running (see App.tsx)
+  const openedFile = (location.state as { openedFile?: OpenedFile } | null)
+    ?.openedFile;
+  useEffect(() => {
+    if (openedFile?.kind === "backup") {
+      clearError();
+      loadBackup(openedFile.path);
+    }
+    // eslint-disable-next-line react-hooks/exhaustive-deps
+  }, [openedFile]);
+
   const handleRestore = async () => {
     if (!backupPath) return;
 
-    await restoreFromBackupFile(backupPath);
+    const restored = await restoreFromBackupFile(
+      backupPath,
+      backupPassword || undefined,
+      canKeepUnlockMethods && keepUnlockMethods,
+    );
+    if (!restored) return; // error is set by the hook
+
+    const suggestedSuffix = peekInfo?.suggested_hostname_suffix;
+    if (suggestedSuffix && useSuggestedSuffix) {
+      try {
+        await api.setHostnameSuffix(suggestedSuffix);
+      } catch (err) {
+        toast.error(
+          err instanceof Error
+            ? `Hostname suffix not updated: ${err.message}`
+            : "Hostname suffix not updated",
+        );
+      }
+    }
 
-    // After restore, app needs to be unlocked with the backup's password
+    // Without a password the app needs to be unlocked with the backup's
+    // password; with one it was validated and the app is already unlocked
     setIsSetupComplete(true);
     setIsWaitingForEncryptionKey(false);
-    setIsUnlocked(false);
+    setIsUnlocked(backupPassword !== "");
+    setBackupPassword("");
 
     // Navigate to dashboard — the app will detect locked state
     // and prompt for the password
     navigate("/", { replace: true });
   };
 
+  // Keeping the current unlock methods re-keys the backup to the master key in
+  // memory, so it needs the app unlocked and the backup password
+  const canKeepUnlockMethods = isUnlocked && backupPassword !== "";
+
+  const handleTestPassword = async () => {
+    if (!backupPath || !backupPassword) return;
+    clearError();
+    setIsTesting(true);
+    try {
+      setPasswordCheck(await api.testBackupPassword(backupPath, backupPassword));
+    } catch (err) {
+      setPasswordCheck(null);
+      toast.error(err instanceof Error ? err.message : "Password test failed");
+    } finally {
+      setIsTesting(false);
+    }
+  };
+
   const handleBack = () => {
     if (step === "file") {
       navigate("/setup", { replace: true });
     } else {
       setBackupPath(null);
       setPeekInfo(null);
+      setPasswordCheck(null);
       clearError();
       setStep("file");
     }
@@ -181,6 +256,19 @@ export function RestoreBackup() {
                   {peekInfo.ca_name && (
                     <ReviewField label="CA Name" value={peekInfo.ca_name} />
                   )}
+                  <ReviewField
+                    label="Hostname Suffix"
+                    value={peekInfo.hostname_suffix || "Not set"}
+                  />
+                  {peekInfo.metadata && (
+                    <>
+                      <ReviewField
+                        label="Created By"
+                        value={peekInfo.metadata.operation.replace(/_/g, " ")}
+                      />
+                      <ReviewField label="App Version" value={peekInfo.metadata.app_version} />
+                    </>
+                  )}
                   <div className="flex justify-between gap-4">
                     <span className="text-muted-foreground">Security Keys</span>
                     <Badge variant={peekInfo.has_security_keys ? "default" : "secondary"}>
@@ -189,6 +277,27 @@ export function RestoreBackup() {
                   </div>
                 </ReviewSection>
 
+                {peekInfo.unlock_methods.length > 0 && (
+                  <div className="space-y-2">
+                    <Label className="text-xs text-muted-foreground">
+                      Unlock methods after restore
+                    </Label>
+                    <ul className="space-y-1 text-sm">
+                      {peekInfo.unlock_methods.map((method) => (
+                        <li
+                          key={method.id}
+                          className="flex justify-between gap-4"
+                        >
+                          <span>{method.label}</span>
+                          <span className="text-muted-foreground">
+                            {UNLOCK_METHOD_LABELS[method.method] ?? method.method}
+                          </span>
+                        </li>
+                      ))}
+                    </ul>
+                  </div>
+                )}
+
                 {peekInfo.hostnames.length > 0 && (
                   <div className="space-y-2">
                     <Label className="text-xs text-muted-foreground">Hostnames</Label>
@@ -206,9 +315,96 @@ export function RestoreBackup() {
                   </div>
                 )}
 
+                {peekInfo.suggested_hostname_suffix && (
+                  <div className="flex items-center gap-3">
+                    <Checkbox
+                      id="use_suggested_suffix"
+                      checked={useSuggestedSuffix}
+                      onCheckedChange={(val) => setUseSuggestedSuffix(val === true)}
+                      disabled={isLoading}
+                    />
+                    <div className="flex-1 min-w-0">
+                      <Label
+                        htmlFor="use_suggested_suffix"
+                        className="text-sm cursor-pointer"
+                      >
+                        Use{" "}
+                        <span className="font-mono">
+                          {peekInfo.suggested_hostname_suffix}
+                        </span>{" "}
+                        as the hostname suffix
+                      </Label>
+                      <p className="text-xs text-muted-foreground">
+                        Most hostnames in this backup end with it. CSRs can
+                        only be generated for hostnames ending with the
+                        configured suffix.
+                      </p>
+                    </div>
+                  </div>
+                )}
+
+                <div className="space-y-2">
+                  <Label htmlFor="backup_password">Backup Password (optional)</Label>
+                  <div className="flex gap-2">
+                    <Input
+                      id="backup_password"
+                      type="password"
+                      placeholder="Validate before restoring and unlock right away"
+                      value={backupPassword}
+                      onChange={(e) => {
+                        setBackupPassword(e.target.value);
+                        setPasswordCheck(null);
+                      }}
+                      disabled={isLoading}
+                    />
+                    <Button
+                      type="button"
+                      variant="outline"
+                      onClick={handleTestPassword}
+                      disabled={!backupPassword || isTesting || isLoading}
+                    >
+                      {isTesting ? "Testing..." : "Test"}
+                    </Button>
+                  </div>
+                  {passwordCheck && (
+                    <p
+                      className={`text-sm ${passwordCheck.valid ? "text-muted-foreground" : "text-destructive"}`}
+                    >
+                      {passwordCheck.valid
+                        ? `This password unlocks the backup (${passwordCheck.label}).${passwordCheck.same_master_key ? " The backup uses the same master key as this installation." : ""}`
+                        : "This password does not unlock the backup. You will not be able to unlock it after restoring without its password or another listed method."}
+                    </p>
+                  )}
+                </div>
+
+                {isUnlocked && (
+                  <div className="flex items-center gap-3">
+                    <Checkbox
+                      id="keep_unlock_methods"
+                      checked={keepUnlockMethods}
+                      onCheckedChange={(val) => setKeepUnlockMethods(val === true)}
+                      disabled={!canKeepUnlockMethods || isLoading}
+                    />
+                    <div className="flex-1 min-w-0">
+                      <Label
+                        htmlFor="keep_unlock_methods"
+                        className={`text-sm cursor-pointer ${!canKeepUnlockMethods ? "text-muted-foreground" : ""}`}
+                      >
+                        Keep current unlock methods
+                      </Label>
+                      <p className="text-xs text-muted-foreground">
+                        {canKeepUnlockMethods
+                          ? "Re-encrypt the backup with this installation's key, so your current password and security keys keep working"
+                          : "Enter the backup password to keep your current unlock methods"}
+                      </p>
+                    </div>
+                  </div>
+                )}
+
                 <StatusAlert variant="warning">
-                  This will replace your current database. After restore, you will
-                  need to enter the backup's password to unlock.
+                  This will replace your current database. Without a password,
+                  you will need to enter the backup's password to unlock after
+                  restore.
                 </StatusAlert>
 
                 <div className="flex gap-3">
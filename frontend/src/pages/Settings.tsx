@@ -4,14 +4,14 @@ import {
     CollapsibleContent,
     CollapsibleTrigger,
 } from "@/components/ui/collapsible";
-import { useNavigate } from "react-router-dom";
+import { useLocation, useNavigate } from "react-router-dom";
 import { useSetup } from "@/hooks/useSetup";
 import { useBackup } from "@/hooks/useBackup";
 import { useConfigStore } from "@/stores/useConfigStore";
 import { useAppStore } from "@/stores/useAppStore";
 import { api } from "@/lib/api";
 import { toast } from "sonner";
-import { UpdateConfigRequest } from "@/types";
+import { OpenedFile, UpdateConfigRequest } from "@/types";
 import {
     Card,
     CardContent,
@@ -46,11 +46,27 @@ import { ChangePasswordDialog } from "@/components/settings/ChangePasswordDialog
 import { UnlockMethodsCard } from "@/components/settings/UnlockMethodsCard";
 import { LocalBackupsCard } from "@/components/settings/LocalBackupsCard";
 import { UpdateCard } from "@/components/settings/UpdateCard";
+import { NoteSecretsCard } from "@/components/settings/NoteSecretsCard";
+import { DatabaseUsageCard } from "@/components/settings/DatabaseUsageCard";
+import { ChainCacheCard } from "@/components/settings/ChainCacheCard";
+import { TrashCard } from "@/components/settings/TrashCard";
+import { AuditLogCard } from "@/components/settings/AuditLogCard";
+import { ProfilesCard } from "@/components/settings/ProfilesCard";
+import { RenewalLeadTimesCard } from "@/components/settings/RenewalLeadTimesCard";
+import { LogLevelsCard } from "@/components/settings/LogLevelsCard";
+import { AutostartCard } from "@/components/settings/AutostartCard";
+import { SubjectPresetsCard } from "@/components/settings/SubjectPresetsCard";
+import { SyncAgentsCard } from "@/components/settings/SyncAgentsCard";
 import { DangerZoneCard } from "@/components/shared/DangerZoneCard";
+import { FullExportDialog } from "@/components/settings/FullExportDialog";
 import { ReviewSection, ReviewField } from "@/components/shared/ReviewField";
 
 export function Settings() {
     const navigate = useNavigate();
+    const location = useLocation();
+    // Backup double-clicked while the app was running (see App.tsx)
+    const openedFile = (location.state as { openedFile?: OpenedFile } | null)
+        ?.openedFile;
     const { config, setConfig } = useConfigStore();
     const {
         isAdminModeEnabled,
@@ -69,8 +85,11 @@ export function Settings() {
         error: backupError,
         getDataDirectory,
         localBackups,
+        localBackupsTotal,
+        backupStorage,
         isLoadingBackups,
         listLocalBackups,
+        loadMoreLocalBackups,
         createManualBackup,
         restoreLocalBackup,
         deleteLocalBackup,
@@ -79,6 +98,7 @@ export function Settings() {
     const [resetConfirming, setResetConfirming] = useState(false);
     const [resetLoading, setResetLoading] = useState(false);
     const [changeKeyOpen, setChangeKeyOpen] = useState(false);
+    const [fullExportOpen, setFullExportOpen] = useState(false);
     const [configOpen, setConfigOpen] = useState(false);
     const { copy, isCopied } = useCopyToClipboard();
     const [buildInfo, setBuildInfo] = useState<Record<string, string> | null>(
@@ -152,6 +172,25 @@ export function Settings() {
         await listLocalBackups();
     };
 
+    const handleProfileSwitched = async () => {
+        // The new profile has its own setup state and master key
+        const state = await api.getSessionState();
+        setIsAdminModeEnabled(false);
+        setIsUnlocked(false);
+        setIsWaitingForEncryptionKey(state.waiting_for_encryption_key);
+        setIsSetupComplete(state.configured);
+        if (!state.configured) {
+            navigate("/setup", { replace: true });
+            return;
+        }
+        try {
+            setConfig(await api.getConfig());
+        } catch {
+            // Config may not exist in the new profile
+        }
+        await listLocalBackups();
+    };
+
     const handleResetDatabase = async () => {
         setResetLoading(true);
         try {
@@ -285,6 +324,8 @@ export function Settings() {
                                 <ReviewSection title="Certificate Defaults">
                                     <ReviewField label="Validity Period" value={`${config.validity_period_days} days`} />
                                     <ReviewField label="Key Size" value={`${config.default_key_size} bits`} />
+                                    <ReviewField label="Expiring Soon Threshold" value={`${config.expiring_threshold_days} days`} />
+                                    <ReviewField label="Trash Retention" value={config.trash_retention_days > 0 ? `${config.trash_retention_days} days` : "Disabled"} />
                                 </ReviewSection>
 
                                 <ReviewSection title="Organization">
@@ -305,6 +346,9 @@ export function Settings() {
                 </Collapsible>
             )}
 
+            {/* Renewal Lead Times */}
+            {config && <RenewalLeadTimesCard className="mb-6" />}
+
             {/* Edit Configuration Modal */}
             {config && (
                 <ConfigEditForm
@@ -320,6 +364,30 @@ export function Settings() {
                         default_state: config.default_state,
                         default_country: config.default_country,
                         default_key_size: config.default_key_size,
+                        expiring_threshold_days:
+                            config.expiring_threshold_days,
+                        clock_check_url: config.clock_check_url,
+                        air_gapped: config.air_gapped,
+                        backup_freshness_max_writes:
+                            config.backup_freshness_max_writes,
+                        backup_freshness_block: config.backup_freshness_block,
+                        fips_mode: config.fips_mode,
+                        db_size_warn_mb: config.db_size_warn_mb,
+                        aia_cache_ttl_minutes: config.aia_cache_ttl_minutes,
+                        key_pool_size: config.key_pool_size,
+                        kdf_profile: config.kdf_profile,
+                        ticket_pattern: config.ticket_pattern,
+                        trash_retention_days: config.trash_retention_days,
+                        download_line_endings: config.download_line_endings,
+                        download_text_header: config.download_text_header,
+                        download_format: config.download_format,
+                        log_max_size_mb: config.log_max_size_mb,
+                        log_max_files: config.log_max_files,
+                        log_max_age_days: config.log_max_age_days,
+                        log_compress: config.log_compress,
+                        minimize_to_tray: config.minimize_to_tray,
+                        run_in_background: config.run_in_background,
+                        expiry_notifications: config.expiry_notifications,
                     }}
                     onSave={handleEditConfig}
                     onCancel={() => setIsEditMode(false)}
@@ -382,6 +450,9 @@ export function Settings() {
             {/* Local Backups */}
             <LocalBackupsCard
                 localBackups={localBackups}
+                localBackupsTotal={localBackupsTotal}
+                backupStorage={backupStorage}
+                onLoadMore={loadMoreLocalBackups}
                 isLoading={backupLoading}
                 isLoadingBackups={isLoadingBackups}
                 error={backupError}
@@ -389,8 +460,26 @@ export function Settings() {
                 onRestoreBackup={handleRestoreBackup}
                 onDeleteBackup={deleteLocalBackup}
                 isUnlocked={isUnlocked}
+                openedBackupPath={
+                    openedFile?.kind === "backup" ? openedFile.path : null
+                }
             />
 
+            {/* Profiles */}
+            <ProfilesCard
+                className="mt-6"
+                onSwitched={handleProfileSwitched}
+            />
+
+            {/* Database Storage */}
+            <DatabaseUsageCard className="mt-6" />
+
+            {/* Trash */}
+            <TrashCard className="mt-6" />
+
+            {/* Chain Cache */}
+            <ChainCacheCard className="mt-6" />
+
             {/* Application Logs */}
             {logInfo && (
                 <Card className="mt-6 shadow-sm border-border">
@@ -453,6 +542,12 @@ export function Settings() {
                 </Card>
             )}
 
+            {/* Log Levels */}
+            <LogLevelsCard className="mt-6" />
+
+            {/* Start on Login */}
+            <AutostartCard className="mt-6" />
+
             {/* Build Information */}
             {buildInfo && (
                 <Card className="mt-6 shadow-sm border-border">
@@ -496,11 +591,32 @@ export function Settings() {
                                     {buildInfo.goVersion}
                                 </p>
                             </div>
+                            <div>
+                                <p className="text-xs font-medium text-muted-foreground uppercase mb-1">
+                                    Platform
+                                </p>
+                                <p className="font-mono text-muted-foreground">
+                                    {buildInfo.platform}
+                                </p>
+                            </div>
+                            <div>
+                                <p className="text-xs font-medium text-muted-foreground uppercase mb-1">
+                                    Crypto Mode
+                                </p>
+                                <p className="font-mono text-muted-foreground">
+                                    {buildInfo.cryptoMode === "fips"
+                                        ? "FIPS"
+                                        : "Standard"}
+                                </p>
+                            </div>
                         </div>
                     </CardContent>
                 </Card>
             )}
 
+            {/* Subject presets selectable when generating a CSR */}
+            <SubjectPresetsCard className="mb-6" />
+
             {/* Application Updates */}
             <UpdateCard />
 
@@ -510,6 +626,26 @@ export function Settings() {
                 onChangePassword={() => setChangeKeyOpen(true)}
             />
 
+            {/* Security-sensitive operations (unlocks, key exports, restores) */}
+            <AuditLogCard className="mt-6" />
+
+            {/* Secrets pasted into certificate notes */}
+            <NoteSecretsCard className="mt-6" />
+
+            {/* Companion agents pulling certificates over mutual TLS */}
+            <SyncAgentsCard className="mt-6" isUnlocked={isUnlocked} />
+
+            {/* Danger Zone - Full Export */}
+            <DangerZoneCard
+                className="mt-4"
+                title="Danger Zone - Full Export"
+                description="Export every certificate with its decrypted private keys in a password-protected archive, when decommissioning or for emergency offline access."
+                buttonLabel="Export Everything"
+                onClick={() => setFullExportOpen(true)}
+                isAdminModeEnabled={isAdminModeEnabled}
+                requireUnlocked
+            />
+
             {/* Danger Zone - Reset Database */}
             <DangerZoneCard
                 className="mt-4"
@@ -542,6 +678,12 @@ export function Settings() {
                 onCancel={() => setResetConfirming(false)}
             />
 
+            {/* Full Export Dialog */}
+            <FullExportDialog
+                open={fullExportOpen}
+                onClose={() => setFullExportOpen(false)}
+            />
+
             {/* Change Password Dialog */}
             <ChangePasswordDialog
                 open={changeKeyOpen}
@@ -6,6 +6,7 @@ import { motion, AnimatePresence } from "motion/react";
 import { useSetup } from "@/hooks/useSetup";
 import { useAppStore } from "@/stores/useAppStore";
 import { api } from "@/lib/api";
+import type { SetupProgress, SetupRequest } from "@/types";
 import {
     setupRequestSchema,
     setupStepFields,
@@ -39,6 +40,28 @@ function getNextStep(current: WizardStep): WizardStep {
     return current;
 }
 
+// The password is never saved with the progress, so a wizard stopped on the
+// review step resumes on the password step
+function getResumeStep(step: string): WizardStep {
+    if (step === "review") return "password";
+    return STEPS.some((s) => s.id === step) ? (step as WizardStep) : "email";
+}
+
+function toSetupRequest(data: SetupRequestInput): SetupRequest {
+    return {
+        owner_email: data.owner_email,
+        ca_name: data.ca_name,
+        hostname_suffix: data.hostname_suffix,
+        validity_period_days: data.validity_period_days,
+        default_organization: data.default_organization,
+        default_organizational_unit: data.default_organizational_unit,
+        default_city: data.default_city,
+        default_state: data.default_state,
+        default_country: data.default_country,
+        default_key_size: data.default_key_size,
+    };
+}
+
 function getPreviousStep(current: WizardStep): WizardStep {
     const currentIndex = getStepIndex(current);
     if (currentIndex > 0) {
@@ -49,10 +72,12 @@ function getPreviousStep(current: WizardStep): WizardStep {
 
 export function SetupWizard() {
     const navigate = useNavigate();
-    const { defaults, isLoading, error, loadDefaults, saveSetup, clearError } = useSetup();
+    const { defaults, isLoading, error, loadDefaults, completeSetup, clearError } = useSetup();
     const { setIsUnlocked } = useAppStore();
     const [currentStep, setCurrentStep] = useState<WizardStep>("email");
     const [submitError, setSubmitError] = useState<string | null>(null);
+    // Set when the form was restored from a setup started earlier
+    const [resumed, setResumed] = useState(false);
 
     // eslint-disable-next-line react-hooks/exhaustive-deps -- load once on mount
     useEffect(() => { loadDefaults(); }, []);
@@ -71,23 +96,65 @@ export function SetupWizard() {
         mode: "onChange",
     });
 
-    // Reset form with defaults when they load
+    const defaultValues = (): Partial<SetupRequestInput> => ({
+        validity_period_days: defaults?.validity_period_days,
+        default_key_size: defaults?.default_key_size,
+        default_country: defaults?.default_country,
+        default_organization: defaults?.default_organization,
+        default_organizational_unit: defaults?.default_organizational_unit || "",
+        default_city: defaults?.default_city,
+        default_state: defaults?.default_state,
+        password: "",
+        password_confirm: "",
+    });
+
+    // Reset form with defaults when they load, then restore the values of a
+    // setup interrupted earlier (fields left empty keep their default)
     useEffect(() => {
-        if (defaults) {
-            reset({
-                validity_period_days: defaults.validity_period_days,
-                default_key_size: defaults.default_key_size,
-                default_country: defaults.default_country,
-                default_organization: defaults.default_organization,
-                default_organizational_unit: defaults.default_organizational_unit || "",
-                default_city: defaults.default_city,
-                default_state: defaults.default_state,
-                password: "",
-                password_confirm: "",
-            });
-        }
+        if (!defaults) return;
+        reset(defaultValues());
+        api.getSetupProgress()
+            .then((progress) => {
+                if (!progress) return;
+                const entered = Object.fromEntries(
+                    Object.entries(progress.draft).filter(
+                        ([, value]) => value !== "" && value !== 0 && value != null,
+                    ),
+                );
+                reset({ ...defaultValues(), ...entered });
+                setCurrentStep(getResumeStep(progress.step));
+                setResumed(true);
+            })
+            .catch((err) => console.error("Failed to load setup progress:", err));
+        // eslint-disable-next-line react-hooks/exhaustive-deps -- defaultValues only reads defaults
     }, [defaults, reset]);
 
+    // Moves to a step and saves the progress so the wizard resumes there
+    const goToStep = (step: WizardStep) => {
+        setCurrentStep(step);
+        api.saveSetupProgress({
+            step,
+            draft: toSetupRequest(getValues()),
+        } as SetupProgress).catch((err) =>
+            console.error("Failed to save setup progress:", err),
+        );
+    };
+
+    const handleStartOver = async () => {
+        setSubmitError(null);
+        clearError();
+        try {
+            await api.abortSetup();
+            reset(defaultValues());
+            setCurrentStep("email");
+            setResumed(false);
+        } catch (err) {
+            setSubmitError(
+                err instanceof Error ? err.message : typeof err === "string" ? err : "Failed to start over",
+            );
+        }
+    };
+
     const handleNext = async (e: React.MouseEvent) => {
         e.preventDefault();
         e.stopPropagation();
@@ -97,7 +164,7 @@ export function SetupWizard() {
             const isValid = await trigger(fields);
             if (!isValid) return;
         }
-        setCurrentStep(getNextStep(currentStep));
+        goToStep(getNextStep(currentStep));
     };
 
     const handlePrevious = () => {
@@ -134,7 +201,7 @@ export function SetupWizard() {
             const isValid = await trigger(fields);
             if (!isValid) return;
         }
-        setCurrentStep(getNextStep(currentStep));
+        goToStep(getNextStep(currentStep));
     };
 
     const onSubmit = async (data: SetupRequestInput) => {
@@ -147,35 +214,11 @@ export function SetupWizard() {
         setSubmitError(null);
         clearError();
 
-        try {
-            // First, save the setup configuration (without encryption key)
-            const setupData = {
-                owner_email: data.owner_email,
-                ca_name: data.ca_name,
-                hostname_suffix: data.hostname_suffix,
-                validity_period_days: data.validity_period_days,
-                default_organization: data.default_organization,
-                default_organizational_unit: data.default_organizational_unit,
-                default_city: data.default_city,
-                default_state: data.default_state,
-                default_country: data.default_country,
-                default_key_size: data.default_key_size,
-            };
-
-            await saveSetup(setupData);
-
-            // Then, provide the password
-            const keyResult = await api.provideEncryptionKey(data.password);
-
-            if (keyResult.valid) {
-                setIsUnlocked(true);
-                navigate("/", { replace: true });
-            } else {
-                setSubmitError("Failed to set password. Please try again.");
-            }
-        } catch (err) {
-            console.error("Setup error:", err);
-            setSubmitError(err instanceof Error ? err.message : "Setup failed. Please try again.");
+        // The configuration and the password are stored together, so a failure
+        // leaves the wizard resumable rather than half-configured
+        if (await completeSetup(toSetupRequest(data), data.password)) {
+            setIsUnlocked(true);
+            navigate("/", { replace: true });
         }
     };
 
@@ -224,6 +267,26 @@ export function SetupWizard() {
                             </StatusAlert>
                         )}
 
+                        {resumed && (
+                            <StatusAlert
+                                variant="warning"
+                                action={
+                                    <Button
+                                        type="button"
+                                        variant="outline"
+                                        size="sm"
+                                        onClick={handleStartOver}
+                                        disabled={isSubmitting}
+                                    >
+                                        Start Over
+                                    </Button>
+                                }
+                            >
+                                Resuming the setup you started earlier. The
+                                password is never saved, so enter it again.
+                            </StatusAlert>
+                        )}
+
                         <AnimatePresence mode="wait">
                             {currentStep === "email" && (
                                 <motion.div
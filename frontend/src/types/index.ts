@@ -1,28 +1,114 @@
 // Re-export types from Wails-generated bindings
 // Source of truth: Go structs in internal/models/ -> Wails generates wailsjs/go/models.ts
-import { models } from "../../wailsjs/go/models";
+import { logger, models } from "../../wailsjs/go/models";
 
 // Re-export Wails-generated types as type aliases
 export type Certificate = models.Certificate;
 export type CertificateListItem = models.CertificateListItem;
+export type CertificateExtensions = models.CertificateExtensions;
+export type CertificateSubject = models.CertificateSubject;
 export type CSRRequest = models.CSRRequest;
+export type CSRSubmission = models.CSRSubmission;
 export type CSRResponse = models.CSRResponse;
+export type CertificateResult = models.CertificateResult;
 export type SANEntry = models.SANEntry;
 export type ImportRequest = models.ImportRequest;
 export type CertificateFilter = models.CertificateFilter;
+export type CertificatePage = models.CertificatePage;
+export type QuickSearchResult = models.QuickSearchResult;
+export type HistoryFilter = models.HistoryFilter;
+export type HistoryPage = models.HistoryPage;
+export type AuditEntry = models.AuditEntry;
+export type AuditFilter = models.AuditFilter;
+export type AuditLogPage = models.AuditLogPage;
+export type CALeadTime = models.CALeadTime;
+export type LeadTimeReport = models.LeadTimeReport;
 export type Config = models.Config;
 export type SetupRequest = models.SetupRequest;
+export type SetupProgress = models.SetupProgress;
 export type UpdateConfigRequest = models.UpdateConfigRequest;
 export type SetupDefaults = models.SetupDefaults;
 export type CertImportResult = models.CertImportResult;
+export type DirectoryImportEntry = models.DirectoryImportEntry;
+export type DirectoryImportSkippedFile = models.DirectoryImportSkippedFile;
+export type DirectoryImportPreview = models.DirectoryImportPreview;
+export type CertKeyLink = models.CertKeyLink;
+export type BackupMergeOptions = models.BackupMergeOptions;
+export type BackupMergeResult = models.BackupMergeResult;
+export type RestoreReport = models.RestoreReport;
 export type BackupPeekInfo = models.BackupPeekInfo;
+export type BackupPasswordCheck = models.BackupPasswordCheck;
+export type ShareBundleInfo = models.ShareBundleInfo;
+export type CertificateQRCodes = models.CertificateQRCodes;
+export type ServerConfigSnippet = models.ServerConfigSnippet;
+export type ServerConfigFile = models.ServerConfigFile;
+export type PinningConfig = models.PinningConfig;
+export type CertificatePins = models.CertificatePins;
+export type OpenSSLCommands = models.OpenSSLCommands;
+export type OpenSSLCommand = models.OpenSSLCommand;
 export type BackupCertificateInfo = models.BackupCertificateInfo;
 export type KeyValidationResult = models.KeyValidationResult;
 export type ChainCertificateInfo = models.ChainCertificateInfo;
+export type CertificateChain = models.CertificateChain;
+export type ChainFetchDiagnostics = models.ChainFetchDiagnostics;
+export type ChainFetchAttempt = models.ChainFetchAttempt;
+export type ChainOverride = models.ChainOverride;
+export type CertificateRelation = models.CertificateRelation;
+export type CertificateGraph = models.CertificateGraph;
+export type DeploymentTarget = models.DeploymentTarget;
+export type EndpointProbe = models.EndpointProbe;
+export type TagCount = models.TagCount;
+export type DeletedCertificate = models.DeletedCertificate;
+export type NoteReference = models.NoteReference;
+export type SyncAgent = models.SyncAgent;
+export type SyncAgentEnrollment = models.SyncAgentEnrollment;
+export type SyncServerStatus = models.SyncServerStatus;
+export type CertificateRequester = models.CertificateRequester;
+export type CSRIntake = models.CSRIntake;
+export type IssuerExpiry = models.IssuerExpiry;
+export type StatusPreview = models.StatusPreview;
+export type StatusPreviewEntry = models.StatusPreviewEntry;
+export type ChainTrustResult = models.ChainTrustResult;
+export type TrustStoreResult = models.TrustStoreResult;
 export type HistoryEntry = models.HistoryEntry;
 export type LocalBackupInfo = models.LocalBackupInfo;
+export type BackupListOptions = models.BackupListOptions;
+export type BackupListPage = models.BackupListPage;
+export type BackupStorageBreakdown = models.BackupStorageBreakdown;
 export type UpdateInfo = models.UpdateInfo;
 export type UpdateHistoryEntry = models.UpdateHistoryEntry;
+export type HealthStatus = models.HealthStatus;
+export type ChainCacheStats = models.ChainCacheStats;
+export type BackupFreshness = models.BackupFreshness;
+export type DatabaseUsage = models.DatabaseUsage;
+export type DatabaseContributor = models.DatabaseContributor;
+export type DatabaseCleanupResult = models.DatabaseCleanupResult;
+export type ClockCheckResult = models.ClockCheckResult;
+export type EntropyCheckResult = models.EntropyCheckResult;
+export type OperationTiming = models.OperationTiming;
+export type SlowOperation = models.SlowOperation;
+export type LogLevelSettings = logger.LevelSettings;
+export type OpenedFile = models.OpenedFile;
+export type AutostartStatus = models.AutostartStatus;
+export type SecretFinding = models.SecretFinding;
+export type NoteSecretReport = models.NoteSecretReport;
+export type NoteScanResult = models.NoteScanResult;
+export type SessionActivityEntry = models.SessionActivityEntry;
+export type SessionActivity = models.SessionActivity;
+export type SessionState = models.SessionState;
+export type Profile = models.Profile;
+export type BulkPatch = models.BulkPatch;
+export type BulkHostResult = models.BulkHostResult;
+export type BulkUpdateResult = models.BulkUpdateResult;
+export type BulkDeletePreviewItem = models.BulkDeletePreviewItem;
+export type BulkDeletePreview = models.BulkDeletePreview;
+export type BulkDeleteResult = models.BulkDeleteResult;
+export type BulkCSRRequest = models.BulkCSRRequest;
+export type BulkCSRHostResult = models.BulkCSRHostResult;
+export type BulkCSRResult = models.BulkCSRResult;
+export type SubjectPreset = models.SubjectPreset;
+export type RenewalChecklistItem = models.RenewalChecklistItem;
+export type RenewalChecklist = models.RenewalChecklist;
 
 // Stricter type definitions for status/enum fields
 // (Wails generates 'string', these provide better type safety)
@@ -45,6 +131,14 @@ export interface CertificateUploadPreview {
     key_match: boolean;
 }
 
+// Background key validation progress, sent with the "key-validation:progress"
+// event (matches Go models.KeyValidationProgress; no binding returns it, so
+// Wails does not generate it)
+export interface KeyValidationProgress {
+    checked: number;
+    total: number;
+}
+
 // Security key types
 export type SecurityKeyMethod = "password" | "os_native" | "fido2";
 
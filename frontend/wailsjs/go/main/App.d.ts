@@ -3,63 +3,175 @@
 import {models} from '../models';
 import {logger} from '../models';
 
-export function ChangeEncryptionKey(arg1:string):Promise<void>;
+export function AbortSetup():Promise<void>;
+
+export function AddCertificateRelation(arg1:string,arg2:string,arg3:string,arg4:string):Promise<void>;
+
+export function AddCertificateTag(arg1:string,arg2:string):Promise<void>;
+
+export function AddDeploymentTarget(arg1:string,arg2:string,arg3:string):Promise<void>;
+
+export function BulkDeleteCertificates(arg1:Array<string>,arg2:string,arg3:boolean):Promise<models.BulkDeleteResult>;
+
+export function BulkUpdateCertificates(arg1:Array<string>,arg2:models.BulkPatch):Promise<models.BulkUpdateResult>;
+
+export function CancelKeyValidation():Promise<void>;
+
+export function CancelNewRequest(arg1:string):Promise<void>;
+
+export function ChangeEncryptionKey(arg1:string,arg2:boolean):Promise<void>;
 
 export function CheckForUpdate():Promise<models.UpdateInfo>;
 
 export function CheckForUpdateManual():Promise<models.UpdateInfo>;
 
+export function CleanupDatabase(arg1:string,arg2:number):Promise<models.DatabaseCleanupResult>;
+
+export function ClearChainCache():Promise<number>;
+
 export function ClearEncryptionKey():Promise<void>;
 
 export function ClearPendingCSR(arg1:string):Promise<void>;
 
+export function CloseBackupView():Promise<void>;
+
+export function CompleteSetup(arg1:models.SetupRequest,arg2:string):Promise<void>;
+
 export function CopyToClipboard(arg1:string):Promise<void>;
 
 export function CreateManualBackup():Promise<void>;
 
+export function CreateProfile(arg1:string):Promise<void>;
+
+export function CreateShareBundle(arg1:string,arg2:boolean,arg3:string,arg4:number):Promise<void>;
+
 export function DeleteCertificate(arg1:string):Promise<void>;
 
+export function DeleteCertificateRelation(arg1:number):Promise<void>;
+
 export function DeleteLocalBackup(arg1:string):Promise<void>;
 
+export function DeleteSubjectPreset(arg1:number):Promise<void>;
+
 export function DownloadAndApplyUpdate():Promise<void>;
 
+export function EnrollOSNativeMethod():Promise<void>;
+
 export function EnrollPasskey():Promise<void>;
 
 export function EnrollPasswordMethod(arg1:string,arg2:string):Promise<void>;
 
+export function EnrollSyncAgent(arg1:string,arg2:Array<string>):Promise<models.SyncAgentEnrollment>;
+
+export function EvaluateChainTrust(arg1:string):Promise<models.ChainTrustResult>;
+
+export function ExportAnonymizedDatabase():Promise<void>;
+
+export function ExportAuditLogCSV(arg1:models.AuditFilter):Promise<void>;
+
+export function ExportAuditorSnapshot():Promise<void>;
+
+export function ExportBackupWithPassword(arg1:string):Promise<void>;
+
+export function ExportCertificateInventory(arg1:string):Promise<void>;
+
 export function ExportCertificateZip(arg1:string,arg2:models.ExportOptions):Promise<void>;
 
+export function ExportEverything(arg1:string,arg2:string):Promise<void>;
+
+export function ExportHistoryCSV(arg1:models.HistoryFilter):Promise<void>;
+
 export function ExportLogs():Promise<void>;
 
+export function ExportRunbook(arg1:string):Promise<void>;
+
+export function FindCertificatesBySAN(arg1:string):Promise<Array<models.CertificateListItem>>;
+
+export function FindHostnameDuplicates():Promise<Array<models.HostnameDuplicateGroup>>;
+
 export function GenerateCSR(arg1:models.CSRRequest):Promise<models.CSRResponse>;
 
+export function GenerateCSRBulk(arg1:models.BulkCSRRequest):Promise<models.BulkCSRResult>;
+
+export function GeneratePinningConfig(arg1:Array<string>,arg2:string):Promise<models.PinningConfig>;
+
+export function GenerateServerConfigSnippet(arg1:string,arg2:string):Promise<models.ServerConfigSnippet>;
+
+export function GetAuditLog(arg1:models.AuditFilter,arg2:number,arg3:number):Promise<models.AuditLogPage>;
+
+export function GetAutostart():Promise<models.AutostartStatus>;
+
+export function GetBackupFreshness():Promise<models.BackupFreshness>;
+
+export function GetBackupStorageBreakdown():Promise<models.BackupStorageBreakdown>;
+
+export function GetBackupViewCertificate(arg1:string):Promise<models.Certificate>;
+
 export function GetBuildInfo():Promise<Record<string, string>>;
 
 export function GetCertificate(arg1:string):Promise<models.Certificate>;
 
-export function GetCertificateChain(arg1:string):Promise<Array<models.ChainCertificateInfo>>;
+export function GetCertificateBySerial(arg1:string):Promise<models.Certificate>;
+
+export function GetCertificateChain(arg1:string):Promise<models.CertificateChain>;
+
+export function GetCertificateGraph():Promise<models.CertificateGraph>;
 
 export function GetCertificateHistory(arg1:string,arg2:number):Promise<Array<models.HistoryEntry>>;
 
+export function GetCertificateQRCodes(arg1:string,arg2:boolean):Promise<models.CertificateQRCodes>;
+
 export function GetConfig():Promise<models.Config>;
 
 export function GetDataDirectory():Promise<string>;
 
+export function GetDatabaseUsage():Promise<models.DatabaseUsage>;
+
+export function GetGlobalHistory(arg1:models.HistoryFilter,arg2:number,arg3:number):Promise<models.HistoryPage>;
+
+export function GetHealthStatus():Promise<models.HealthStatus>;
+
+export function GetIssuerExpiries():Promise<Array<models.IssuerExpiry>>;
+
 export function GetLogInfo():Promise<logger.LogFileInfo>;
 
+export function GetLogLevels():Promise<logger.LevelSettings>;
+
+export function GetOpenSSLCommands(arg1:string):Promise<models.OpenSSLCommands>;
+
 export function GetPendingPrivateKeyPEM(arg1:string):Promise<string>;
 
 export function GetPrivateKeyPEM(arg1:string):Promise<string>;
 
+export function GetRenewalChecklist(arg1:string):Promise<models.RenewalChecklist>;
+
+export function GetRenewalLeadTimes():Promise<models.LeadTimeReport>;
+
+export function GetSessionActivity():Promise<models.SessionActivity>;
+
+export function GetSessionState():Promise<models.SessionState>;
+
 export function GetSetupDefaults():Promise<models.SetupDefaults>;
 
+export function GetSetupProgress():Promise<models.SetupProgress>;
+
+export function GetSyncServerStatus():Promise<models.SyncServerStatus>;
+
 export function GetUpdateHistory(arg1:number):Promise<Array<models.UpdateHistoryEntry>>;
 
 export function HasSecurityKeys():Promise<boolean>;
 
-export function ImportCertificate(arg1:models.ImportRequest):Promise<void>;
+export function ImportCSRRequestFile(arg1:string):Promise<models.CSRIntake>;
+
+export function ImportCertificate(arg1:models.ImportRequest):Promise<models.CertificateResult>;
+
+export function ImportCertificatesFromBackup(arg1:string,arg2:string,arg3:models.CertImportOptions,arg4:boolean):Promise<models.CertImportResult>;
+
+export function ImportCertificatesFromDirectory(arg1:string):Promise<models.CertImportResult>;
 
-export function ImportCertificatesFromBackup(arg1:string,arg2:string):Promise<models.CertImportResult>;
+export function ImportShareBundle(arg1:string,arg2:string):Promise<string>;
+
+export function IsOSNativeUnlockAvailable():Promise<boolean>;
 
 export function IsSetupComplete():Promise<boolean>;
 
@@ -69,14 +181,44 @@ export function IsWaitingForEncryptionKey():Promise<boolean>;
 
 export function IsWebAuthnAvailable():Promise<boolean>;
 
+export function ListBackupViewCertificates(arg1:models.CertificateFilter):Promise<Array<models.CertificateListItem>>;
+
+export function ListCertificatePage(arg1:models.CertificateFilter):Promise<models.CertificatePage>;
+
+export function ListCertificateTags(arg1:string):Promise<Array<string>>;
+
 export function ListCertificates(arg1:models.CertificateFilter):Promise<Array<models.CertificateListItem>>;
 
-export function ListLocalBackups():Promise<Array<models.LocalBackupInfo>>;
+export function ListChainOverrides():Promise<Array<models.ChainOverride>>;
+
+export function ListDeletedCertificates():Promise<Array<models.DeletedCertificate>>;
+
+export function ListDeploymentTargets(arg1:string):Promise<Array<models.DeploymentTarget>>;
+
+export function ListLocalBackups(arg1:models.BackupListOptions):Promise<models.BackupListPage>;
+
+export function ListProfiles():Promise<Array<models.Profile>>;
 
 export function ListSecurityKeys():Promise<Array<models.SecurityKeyInfo>>;
 
+export function ListSubjectPresets():Promise<Array<models.SubjectPreset>>;
+
+export function ListSyncAgents():Promise<Array<models.SyncAgent>>;
+
+export function ListTags():Promise<Array<models.TagCount>>;
+
+export function MarkCSRSubmitted(arg1:string,arg2:models.CSRSubmission):Promise<void>;
+
+export function MergeFromBackupFile(arg1:string,arg2:string,arg3:models.BackupMergeOptions,arg4:boolean):Promise<models.BackupMergeResult>;
+
+export function MergeHostnameDuplicates(arg1:string):Promise<string>;
+
+export function MinimizeWindow():Promise<void>;
+
 export function NeedsMigration():Promise<boolean>;
 
+export function OpenBackupReadOnly(arg1:string):Promise<models.BackupPeekInfo>;
+
 export function OpenBugReport():Promise<void>;
 
 export function OpenDataDirectory():Promise<void>;
@@ -87,36 +229,114 @@ export function PeekBackupInfo(arg1:string):Promise<models.BackupPeekInfo>;
 
 export function PeekLocalBackup(arg1:string):Promise<models.BackupPeekInfo>;
 
+export function PeekShareBundle(arg1:string,arg2:string):Promise<models.ShareBundleInfo>;
+
+export function PreviewBulkDelete(arg1:Array<string>):Promise<models.BulkDeletePreview>;
+
+export function PreviewCertificateImport(arg1:string,arg2:string):Promise<models.CertImportPreview>;
+
 export function PreviewCertificateUpload(arg1:string,arg2:string):Promise<models.CertificateUploadPreview>;
 
+export function PreviewDirectoryImport(arg1:string):Promise<models.DirectoryImportPreview>;
+
+export function PreviewStatusAt(arg1:number):Promise<models.StatusPreview>;
+
+export function ProbeEndpoint(arg1:string,arg2:string,arg3:number):Promise<models.EndpointProbe>;
+
 export function ProvideEncryptionKey(arg1:string):Promise<models.KeyValidationResult>;
 
+export function PurgeDeletedCertificate(arg1:string):Promise<void>;
+
+export function QuickSearch(arg1:string):Promise<Array<models.QuickSearchResult>>;
+
+export function ReadHostnameMappingFile(arg1:string):Promise<Record<string, string>>;
+
+export function RemoveCertificateTag(arg1:string,arg2:string):Promise<void>;
+
+export function RemoveDeploymentTarget(arg1:number):Promise<void>;
+
 export function RemoveSecurityKey(arg1:number):Promise<void>;
 
 export function ResetDatabase():Promise<void>;
 
 export function RestartApp():Promise<void>;
 
-export function RestoreFromBackupFile(arg1:string):Promise<void>;
+export function RestoreDeletedCertificate(arg1:string):Promise<void>;
+
+export function RestoreFromBackupFile(arg1:string,arg2:boolean):Promise<models.RestoreReport>;
 
-export function RestoreLocalBackup(arg1:string):Promise<void>;
+export function RestoreFromBackupFileKeepingUnlockMethods(arg1:string,arg2:string,arg3:boolean):Promise<models.RestoreReport>;
+
+export function RestoreFromBackupFileWithPassword(arg1:string,arg2:string,arg3:boolean):Promise<models.RestoreReport>;
+
+export function RestoreLocalBackup(arg1:string,arg2:boolean):Promise<models.RestoreReport>;
+
+export function RevokeSyncAgent(arg1:number):Promise<void>;
+
+export function SaveBackupViewCertificateToFile(arg1:string):Promise<void>;
 
 export function SaveCSRToFile(arg1:string):Promise<void>;
 
 export function SaveCertificateToFile(arg1:string):Promise<void>;
 
-export function SaveChainToFile(arg1:string):Promise<void>;
+export function SaveChainToFile(arg1:string,arg2:string):Promise<void>;
+
+export function SaveP12ToFile(arg1:string,arg2:string,arg3:boolean):Promise<void>;
 
 export function SavePrivateKeyToFile(arg1:string):Promise<void>;
 
 export function SaveSetup(arg1:models.SetupRequest):Promise<void>;
 
+export function SaveSetupProgress(arg1:models.SetupProgress):Promise<void>;
+
+export function SaveSubjectPreset(arg1:models.SubjectPreset):Promise<models.SubjectPreset>;
+
+export function ScanNotesForSecrets():Promise<models.NoteScanResult>;
+
 export function SelectBackupFile():Promise<string>;
 
+export function SelectCSRRequestFile():Promise<string>;
+
+export function SelectCertificateDirectory():Promise<string>;
+
+export function SelectHostnameMappingFile():Promise<string>;
+
+export function SelectShareBundleFile():Promise<string>;
+
+export function SetAutostart(arg1:boolean):Promise<void>;
+
+export function SetCertificateChainOverride(arg1:string,arg2:string):Promise<void>;
+
 export function SetCertificateReadOnly(arg1:string,arg2:boolean):Promise<void>;
 
+export function SetComponentLogLevel(arg1:string,arg2:string):Promise<void>;
+
+export function SetHostnameSuffix(arg1:string):Promise<void>;
+
+export function SetIssuerChainOverride(arg1:string,arg2:string):Promise<void>;
+
+export function SetLogLevel(arg1:string):Promise<void>;
+
+export function SetRenewalStep(arg1:string,arg2:string,arg3:boolean):Promise<models.RenewalChecklist>;
+
+export function SetSyncAgentHostnames(arg1:number,arg2:Array<string>):Promise<void>;
+
 export function SkipEncryptionKey():Promise<void>;
 
+export function StartKeyValidation():Promise<void>;
+
+export function StartSyncServer(arg1:string):Promise<models.SyncServerStatus>;
+
+export function StopSyncServer():Promise<void>;
+
+export function SwitchProfile(arg1:string):Promise<void>;
+
+export function TakeOpenedFiles():Promise<Array<models.OpenedFile>>;
+
+export function TestBackupPassword(arg1:string,arg2:string):Promise<models.BackupPasswordCheck>;
+
+export function TryAutoUnlock():Promise<boolean>;
+
 export function UnlockWithWebAuthn():Promise<boolean>;
 
 export function UpdateCertificateNote(arg1:string,arg2:string):Promise<void>;
@@ -125,4 +345,4 @@ export function UpdateConfig(arg1:models.UpdateConfigRequest):Promise<models.Con
 
 export function UpdatePendingNote(arg1:string,arg2:string):Promise<void>;
 
-export function UploadCertificate(arg1:string,arg2:string):Promise<void>;
+export function UploadCertificate(arg1:string,arg2:string):Promise<models.CertificateResult>;
@@ -2,8 +2,40 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
-export function ChangeEncryptionKey(arg1) {
-  return window['go']['main']['App']['ChangeEncryptionKey'](arg1);
+export function AbortSetup() {
+  return window['go']['main']['App']['AbortSetup']();
+}
+
+export function AddCertificateRelation(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['AddCertificateRelation'](arg1, arg2, arg3, arg4);
+}
+
+export function AddCertificateTag(arg1, arg2) {
+  return window['go']['main']['App']['AddCertificateTag'](arg1, arg2);
+}
+
+export function AddDeploymentTarget(arg1, arg2, arg3) {
+  return window['go']['main']['App']['AddDeploymentTarget'](arg1, arg2, arg3);
+}
+
+export function BulkDeleteCertificates(arg1, arg2, arg3) {
+  return window['go']['main']['App']['BulkDeleteCertificates'](arg1, arg2, arg3);
+}
+
+export function BulkUpdateCertificates(arg1, arg2) {
+  return window['go']['main']['App']['BulkUpdateCertificates'](arg1, arg2);
+}
+
+export function CancelKeyValidation() {
+  return window['go']['main']['App']['CancelKeyValidation']();
+}
+
+export function CancelNewRequest(arg1) {
+  return window['go']['main']['App']['CancelNewRequest'](arg1);
+}
+
+export function ChangeEncryptionKey(arg1, arg2) {
+  return window['go']['main']['App']['ChangeEncryptionKey'](arg1, arg2);
 }
 
 export function CheckForUpdate() {
@@ -14,6 +46,14 @@ export function CheckForUpdateManual() {
   return window['go']['main']['App']['CheckForUpdateManual']();
 }
 
+export function CleanupDatabase(arg1, arg2) {
+  return window['go']['main']['App']['CleanupDatabase'](arg1, arg2);
+}
+
+export function ClearChainCache() {
+  return window['go']['main']['App']['ClearChainCache']();
+}
+
 export function ClearEncryptionKey() {
   return window['go']['main']['App']['ClearEncryptionKey']();
 }
@@ -22,6 +62,14 @@ export function ClearPendingCSR(arg1) {
   return window['go']['main']['App']['ClearPendingCSR'](arg1);
 }
 
+export function CloseBackupView() {
+  return window['go']['main']['App']['CloseBackupView']();
+}
+
+export function CompleteSetup(arg1, arg2) {
+  return window['go']['main']['App']['CompleteSetup'](arg1, arg2);
+}
+
 export function CopyToClipboard(arg1) {
   return window['go']['main']['App']['CopyToClipboard'](arg1);
 }
@@ -30,18 +78,38 @@ export function CreateManualBackup() {
   return window['go']['main']['App']['CreateManualBackup']();
 }
 
+export function CreateProfile(arg1) {
+  return window['go']['main']['App']['CreateProfile'](arg1);
+}
+
+export function CreateShareBundle(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['CreateShareBundle'](arg1, arg2, arg3, arg4);
+}
+
 export function DeleteCertificate(arg1) {
   return window['go']['main']['App']['DeleteCertificate'](arg1);
 }
 
+export function DeleteCertificateRelation(arg1) {
+  return window['go']['main']['App']['DeleteCertificateRelation'](arg1);
+}
+
 export function DeleteLocalBackup(arg1) {
   return window['go']['main']['App']['DeleteLocalBackup'](arg1);
 }
 
+export function DeleteSubjectPreset(arg1) {
+  return window['go']['main']['App']['DeleteSubjectPreset'](arg1);
+}
+
 export function DownloadAndApplyUpdate() {
   return window['go']['main']['App']['DownloadAndApplyUpdate']();
 }
 
+export function EnrollOSNativeMethod() {
+  return window['go']['main']['App']['EnrollOSNativeMethod']();
+}
+
 export function EnrollPasskey() {
   return window['go']['main']['App']['EnrollPasskey']();
 }
@@ -50,18 +118,98 @@ export function EnrollPasswordMethod(arg1, arg2) {
   return window['go']['main']['App']['EnrollPasswordMethod'](arg1, arg2);
 }
 
+export function EnrollSyncAgent(arg1, arg2) {
+  return window['go']['main']['App']['EnrollSyncAgent'](arg1, arg2);
+}
+
+export function EvaluateChainTrust(arg1) {
+  return window['go']['main']['App']['EvaluateChainTrust'](arg1);
+}
+
+export function ExportAnonymizedDatabase() {
+  return window['go']['main']['App']['ExportAnonymizedDatabase']();
+}
+
+export function ExportAuditLogCSV(arg1) {
+  return window['go']['main']['App']['ExportAuditLogCSV'](arg1);
+}
+
+export function ExportAuditorSnapshot() {
+  return window['go']['main']['App']['ExportAuditorSnapshot']();
+}
+
+export function ExportBackupWithPassword(arg1) {
+  return window['go']['main']['App']['ExportBackupWithPassword'](arg1);
+}
+
+export function ExportCertificateInventory(arg1) {
+  return window['go']['main']['App']['ExportCertificateInventory'](arg1);
+}
+
 export function ExportCertificateZip(arg1, arg2) {
   return window['go']['main']['App']['ExportCertificateZip'](arg1, arg2);
 }
 
+export function ExportEverything(arg1, arg2) {
+  return window['go']['main']['App']['ExportEverything'](arg1, arg2);
+}
+
+export function ExportHistoryCSV(arg1) {
+  return window['go']['main']['App']['ExportHistoryCSV'](arg1);
+}
+
 export function ExportLogs() {
   return window['go']['main']['App']['ExportLogs']();
 }
 
+export function ExportRunbook(arg1) {
+  return window['go']['main']['App']['ExportRunbook'](arg1);
+}
+
+export function FindCertificatesBySAN(arg1) {
+  return window['go']['main']['App']['FindCertificatesBySAN'](arg1);
+}
+
+export function FindHostnameDuplicates() {
+  return window['go']['main']['App']['FindHostnameDuplicates']();
+}
+
 export function GenerateCSR(arg1) {
   return window['go']['main']['App']['GenerateCSR'](arg1);
 }
 
+export function GenerateCSRBulk(arg1) {
+  return window['go']['main']['App']['GenerateCSRBulk'](arg1);
+}
+
+export function GeneratePinningConfig(arg1, arg2) {
+  return window['go']['main']['App']['GeneratePinningConfig'](arg1, arg2);
+}
+
+export function GenerateServerConfigSnippet(arg1, arg2) {
+  return window['go']['main']['App']['GenerateServerConfigSnippet'](arg1, arg2);
+}
+
+export function GetAuditLog(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetAuditLog'](arg1, arg2, arg3);
+}
+
+export function GetAutostart() {
+  return window['go']['main']['App']['GetAutostart']();
+}
+
+export function GetBackupFreshness() {
+  return window['go']['main']['App']['GetBackupFreshness']();
+}
+
+export function GetBackupStorageBreakdown() {
+  return window['go']['main']['App']['GetBackupStorageBreakdown']();
+}
+
+export function GetBackupViewCertificate(arg1) {
+  return window['go']['main']['App']['GetBackupViewCertificate'](arg1);
+}
+
 export function GetBuildInfo() {
   return window['go']['main']['App']['GetBuildInfo']();
 }
@@ -70,14 +218,26 @@ export function GetCertificate(arg1) {
   return window['go']['main']['App']['GetCertificate'](arg1);
 }
 
+export function GetCertificateBySerial(arg1) {
+  return window['go']['main']['App']['GetCertificateBySerial'](arg1);
+}
+
 export function GetCertificateChain(arg1) {
   return window['go']['main']['App']['GetCertificateChain'](arg1);
 }
 
+export function GetCertificateGraph() {
+  return window['go']['main']['App']['GetCertificateGraph']();
+}
+
 export function GetCertificateHistory(arg1, arg2) {
   return window['go']['main']['App']['GetCertificateHistory'](arg1, arg2);
 }
 
+export function GetCertificateQRCodes(arg1, arg2) {
+  return window['go']['main']['App']['GetCertificateQRCodes'](arg1, arg2);
+}
+
 export function GetConfig() {
   return window['go']['main']['App']['GetConfig']();
 }
@@ -86,10 +246,34 @@ export function GetDataDirectory() {
   return window['go']['main']['App']['GetDataDirectory']();
 }
 
+export function GetDatabaseUsage() {
+  return window['go']['main']['App']['GetDatabaseUsage']();
+}
+
+export function GetGlobalHistory(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetGlobalHistory'](arg1, arg2, arg3);
+}
+
+export function GetHealthStatus() {
+  return window['go']['main']['App']['GetHealthStatus']();
+}
+
+export function GetIssuerExpiries() {
+  return window['go']['main']['App']['GetIssuerExpiries']();
+}
+
 export function GetLogInfo() {
   return window['go']['main']['App']['GetLogInfo']();
 }
 
+export function GetLogLevels() {
+  return window['go']['main']['App']['GetLogLevels']();
+}
+
+export function GetOpenSSLCommands(arg1) {
+  return window['go']['main']['App']['GetOpenSSLCommands'](arg1);
+}
+
 export function GetPendingPrivateKeyPEM(arg1) {
   return window['go']['main']['App']['GetPendingPrivateKeyPEM'](arg1);
 }
@@ -98,10 +282,34 @@ export function GetPrivateKeyPEM(arg1) {
   return window['go']['main']['App']['GetPrivateKeyPEM'](arg1);
 }
 
+export function GetRenewalChecklist(arg1) {
+  return window['go']['main']['App']['GetRenewalChecklist'](arg1);
+}
+
+export function GetRenewalLeadTimes() {
+  return window['go']['main']['App']['GetRenewalLeadTimes']();
+}
+
+export function GetSessionActivity() {
+  return window['go']['main']['App']['GetSessionActivity']();
+}
+
+export function GetSessionState() {
+  return window['go']['main']['App']['GetSessionState']();
+}
+
 export function GetSetupDefaults() {
   return window['go']['main']['App']['GetSetupDefaults']();
 }
 
+export function GetSetupProgress() {
+  return window['go']['main']['App']['GetSetupProgress']();
+}
+
+export function GetSyncServerStatus() {
+  return window['go']['main']['App']['GetSyncServerStatus']();
+}
+
 export function GetUpdateHistory(arg1) {
   return window['go']['main']['App']['GetUpdateHistory'](arg1);
 }
@@ -110,12 +318,28 @@ export function HasSecurityKeys() {
   return window['go']['main']['App']['HasSecurityKeys']();
 }
 
+export function ImportCSRRequestFile(arg1) {
+  return window['go']['main']['App']['ImportCSRRequestFile'](arg1);
+}
+
 export function ImportCertificate(arg1) {
   return window['go']['main']['App']['ImportCertificate'](arg1);
 }
 
-export function ImportCertificatesFromBackup(arg1, arg2) {
-  return window['go']['main']['App']['ImportCertificatesFromBackup'](arg1, arg2);
+export function ImportCertificatesFromBackup(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['ImportCertificatesFromBackup'](arg1, arg2, arg3, arg4);
+}
+
+export function ImportCertificatesFromDirectory(arg1) {
+  return window['go']['main']['App']['ImportCertificatesFromDirectory'](arg1);
+}
+
+export function ImportShareBundle(arg1, arg2) {
+  return window['go']['main']['App']['ImportShareBundle'](arg1, arg2);
+}
+
+export function IsOSNativeUnlockAvailable() {
+  return window['go']['main']['App']['IsOSNativeUnlockAvailable']();
 }
 
 export function IsSetupComplete() {
@@ -134,22 +358,82 @@ export function IsWebAuthnAvailable() {
   return window['go']['main']['App']['IsWebAuthnAvailable']();
 }
 
+export function ListBackupViewCertificates(arg1) {
+  return window['go']['main']['App']['ListBackupViewCertificates'](arg1);
+}
+
+export function ListCertificatePage(arg1) {
+  return window['go']['main']['App']['ListCertificatePage'](arg1);
+}
+
+export function ListCertificateTags(arg1) {
+  return window['go']['main']['App']['ListCertificateTags'](arg1);
+}
+
 export function ListCertificates(arg1) {
   return window['go']['main']['App']['ListCertificates'](arg1);
 }
 
-export function ListLocalBackups() {
-  return window['go']['main']['App']['ListLocalBackups']();
+export function ListChainOverrides() {
+  return window['go']['main']['App']['ListChainOverrides']();
+}
+
+export function ListDeletedCertificates() {
+  return window['go']['main']['App']['ListDeletedCertificates']();
+}
+
+export function ListDeploymentTargets(arg1) {
+  return window['go']['main']['App']['ListDeploymentTargets'](arg1);
+}
+
+export function ListLocalBackups(arg1) {
+  return window['go']['main']['App']['ListLocalBackups'](arg1);
+}
+
+export function ListProfiles() {
+  return window['go']['main']['App']['ListProfiles']();
 }
 
 export function ListSecurityKeys() {
   return window['go']['main']['App']['ListSecurityKeys']();
 }
 
+export function ListSubjectPresets() {
+  return window['go']['main']['App']['ListSubjectPresets']();
+}
+
+export function ListSyncAgents() {
+  return window['go']['main']['App']['ListSyncAgents']();
+}
+
+export function ListTags() {
+  return window['go']['main']['App']['ListTags']();
+}
+
+export function MarkCSRSubmitted(arg1, arg2) {
+  return window['go']['main']['App']['MarkCSRSubmitted'](arg1, arg2);
+}
+
+export function MergeFromBackupFile(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['MergeFromBackupFile'](arg1, arg2, arg3, arg4);
+}
+
+export function MergeHostnameDuplicates(arg1) {
+  return window['go']['main']['App']['MergeHostnameDuplicates'](arg1);
+}
+
+export function MinimizeWindow() {
+  return window['go']['main']['App']['MinimizeWindow']();
+}
+
 export function NeedsMigration() {
   return window['go']['main']['App']['NeedsMigration']();
 }
 
+export function OpenBackupReadOnly(arg1) {
+  return window['go']['main']['App']['OpenBackupReadOnly'](arg1);
+}
+
 export function OpenBugReport() {
   return window['go']['main']['App']['OpenBugReport']();
 }
@@ -170,14 +454,58 @@ export function PeekLocalBackup(arg1) {
   return window['go']['main']['App']['PeekLocalBackup'](arg1);
 }
 
+export function PeekShareBundle(arg1, arg2) {
+  return window['go']['main']['App']['PeekShareBundle'](arg1, arg2);
+}
+
+export function PreviewBulkDelete(arg1) {
+  return window['go']['main']['App']['PreviewBulkDelete'](arg1);
+}
+
+export function PreviewCertificateImport(arg1, arg2) {
+  return window['go']['main']['App']['PreviewCertificateImport'](arg1, arg2);
+}
+
 export function PreviewCertificateUpload(arg1, arg2) {
   return window['go']['main']['App']['PreviewCertificateUpload'](arg1, arg2);
 }
 
+export function PreviewDirectoryImport(arg1) {
+  return window['go']['main']['App']['PreviewDirectoryImport'](arg1);
+}
+
+export function PreviewStatusAt(arg1) {
+  return window['go']['main']['App']['PreviewStatusAt'](arg1);
+}
+
+export function ProbeEndpoint(arg1, arg2, arg3) {
+  return window['go']['main']['App']['ProbeEndpoint'](arg1, arg2, arg3);
+}
+
 export function ProvideEncryptionKey(arg1) {
   return window['go']['main']['App']['ProvideEncryptionKey'](arg1);
 }
 
+export function PurgeDeletedCertificate(arg1) {
+  return window['go']['main']['App']['PurgeDeletedCertificate'](arg1);
+}
+
+export function QuickSearch(arg1) {
+  return window['go']['main']['App']['QuickSearch'](arg1);
+}
+
+export function ReadHostnameMappingFile(arg1) {
+  return window['go']['main']['App']['ReadHostnameMappingFile'](arg1);
+}
+
+export function RemoveCertificateTag(arg1, arg2) {
+  return window['go']['main']['App']['RemoveCertificateTag'](arg1, arg2);
+}
+
+export function RemoveDeploymentTarget(arg1) {
+  return window['go']['main']['App']['RemoveDeploymentTarget'](arg1);
+}
+
 export function RemoveSecurityKey(arg1) {
   return window['go']['main']['App']['RemoveSecurityKey'](arg1);
 }
@@ -190,12 +518,32 @@ export function RestartApp() {
   return window['go']['main']['App']['RestartApp']();
 }
 
-export function RestoreFromBackupFile(arg1) {
-  return window['go']['main']['App']['RestoreFromBackupFile'](arg1);
+export function RestoreDeletedCertificate(arg1) {
+  return window['go']['main']['App']['RestoreDeletedCertificate'](arg1);
+}
+
+export function RestoreFromBackupFile(arg1, arg2) {
+  return window['go']['main']['App']['RestoreFromBackupFile'](arg1, arg2);
+}
+
+export function RestoreFromBackupFileKeepingUnlockMethods(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RestoreFromBackupFileKeepingUnlockMethods'](arg1, arg2, arg3);
+}
+
+export function RestoreFromBackupFileWithPassword(arg1, arg2, arg3) {
+  return window['go']['main']['App']['RestoreFromBackupFileWithPassword'](arg1, arg2, arg3);
+}
+
+export function RestoreLocalBackup(arg1, arg2) {
+  return window['go']['main']['App']['RestoreLocalBackup'](arg1, arg2);
 }
 
-export function RestoreLocalBackup(arg1) {
-  return window['go']['main']['App']['RestoreLocalBackup'](arg1);
+export function RevokeSyncAgent(arg1) {
+  return window['go']['main']['App']['RevokeSyncAgent'](arg1);
+}
+
+export function SaveBackupViewCertificateToFile(arg1) {
+  return window['go']['main']['App']['SaveBackupViewCertificateToFile'](arg1);
 }
 
 export function SaveCSRToFile(arg1) {
@@ -206,8 +554,12 @@ export function SaveCertificateToFile(arg1) {
   return window['go']['main']['App']['SaveCertificateToFile'](arg1);
 }
 
-export function SaveChainToFile(arg1) {
-  return window['go']['main']['App']['SaveChainToFile'](arg1);
+export function SaveChainToFile(arg1, arg2) {
+  return window['go']['main']['App']['SaveChainToFile'](arg1, arg2);
+}
+
+export function SaveP12ToFile(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SaveP12ToFile'](arg1, arg2, arg3);
 }
 
 export function SavePrivateKeyToFile(arg1) {
@@ -218,18 +570,106 @@ export function SaveSetup(arg1) {
   return window['go']['main']['App']['SaveSetup'](arg1);
 }
 
+export function SaveSetupProgress(arg1) {
+  return window['go']['main']['App']['SaveSetupProgress'](arg1);
+}
+
+export function SaveSubjectPreset(arg1) {
+  return window['go']['main']['App']['SaveSubjectPreset'](arg1);
+}
+
+export function ScanNotesForSecrets() {
+  return window['go']['main']['App']['ScanNotesForSecrets']();
+}
+
 export function SelectBackupFile() {
   return window['go']['main']['App']['SelectBackupFile']();
 }
 
+export function SelectCSRRequestFile() {
+  return window['go']['main']['App']['SelectCSRRequestFile']();
+}
+
+export function SelectCertificateDirectory() {
+  return window['go']['main']['App']['SelectCertificateDirectory']();
+}
+
+export function SelectHostnameMappingFile() {
+  return window['go']['main']['App']['SelectHostnameMappingFile']();
+}
+
+export function SelectShareBundleFile() {
+  return window['go']['main']['App']['SelectShareBundleFile']();
+}
+
+export function SetAutostart(arg1) {
+  return window['go']['main']['App']['SetAutostart'](arg1);
+}
+
+export function SetCertificateChainOverride(arg1, arg2) {
+  return window['go']['main']['App']['SetCertificateChainOverride'](arg1, arg2);
+}
+
 export function SetCertificateReadOnly(arg1, arg2) {
   return window['go']['main']['App']['SetCertificateReadOnly'](arg1, arg2);
 }
 
+export function SetComponentLogLevel(arg1, arg2) {
+  return window['go']['main']['App']['SetComponentLogLevel'](arg1, arg2);
+}
+
+export function SetHostnameSuffix(arg1) {
+  return window['go']['main']['App']['SetHostnameSuffix'](arg1);
+}
+
+export function SetIssuerChainOverride(arg1, arg2) {
+  return window['go']['main']['App']['SetIssuerChainOverride'](arg1, arg2);
+}
+
+export function SetLogLevel(arg1) {
+  return window['go']['main']['App']['SetLogLevel'](arg1);
+}
+
+export function SetRenewalStep(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetRenewalStep'](arg1, arg2, arg3);
+}
+
+export function SetSyncAgentHostnames(arg1, arg2) {
+  return window['go']['main']['App']['SetSyncAgentHostnames'](arg1, arg2);
+}
+
 export function SkipEncryptionKey() {
   return window['go']['main']['App']['SkipEncryptionKey']();
 }
 
+export function StartKeyValidation() {
+  return window['go']['main']['App']['StartKeyValidation']();
+}
+
+export function StartSyncServer(arg1) {
+  return window['go']['main']['App']['StartSyncServer'](arg1);
+}
+
+export function StopSyncServer() {
+  return window['go']['main']['App']['StopSyncServer']();
+}
+
+export function SwitchProfile(arg1) {
+  return window['go']['main']['App']['SwitchProfile'](arg1);
+}
+
+export function TakeOpenedFiles() {
+  return window['go']['main']['App']['TakeOpenedFiles']();
+}
+
+export function TestBackupPassword(arg1, arg2) {
+  return window['go']['main']['App']['TestBackupPassword'](arg1, arg2);
+}
+
+export function TryAutoUnlock() {
+  return window['go']['main']['App']['TryAutoUnlock']();
+}
+
 export function UnlockWithWebAuthn() {
   return window['go']['main']['App']['UnlockWithWebAuthn']();
 }
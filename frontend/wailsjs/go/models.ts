@@ -1,5 +1,23 @@
 export namespace logger {
 	
+	export class LevelSettings {
+	    level: string;
+	    default_level: string;
+	    components: Record<string, string>;
+	    known_components: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new LevelSettings(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.level = source["level"];
+	        this.default_level = source["default_level"];
+	        this.components = source["components"];
+	        this.known_components = source["known_components"];
+	    }
+	}
 	export class LogFileInfo {
 	    currentLogSize: number;
 	    rotatedLogCount: number;
@@ -25,48 +43,62 @@ export namespace logger {
 
 export namespace models {
 	
-	export class BackupCertificateInfo {
+	export class AuditEntry {
+	    id: number;
+	    event_type: string;
 	    hostname: string;
-	    status: string;
-	    sans?: string[];
-	    key_size?: number;
+	    message: string;
+	    actor: string;
+	    app_version: string;
 	    created_at: number;
-	    expires_at?: number;
+	    details?: Record<string, any>;
 	
 	    static createFrom(source: any = {}) {
-	        return new BackupCertificateInfo(source);
+	        return new AuditEntry(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.event_type = source["event_type"];
 	        this.hostname = source["hostname"];
-	        this.status = source["status"];
-	        this.sans = source["sans"];
-	        this.key_size = source["key_size"];
+	        this.message = source["message"];
+	        this.actor = source["actor"];
+	        this.app_version = source["app_version"];
 	        this.created_at = source["created_at"];
-	        this.expires_at = source["expires_at"];
+	        this.details = source["details"];
 	    }
 	}
-	export class BackupPeekInfo {
-	    certificate_count: number;
-	    ca_name: string;
-	    has_security_keys: boolean;
-	    hostnames: string[];
-	    certificates: BackupCertificateInfo[];
-	    schema_version: number;
+	export class AuditFilter {
+	    event_types?: string[];
+	    hostname?: string;
+	    from?: number;
+	    to?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new BackupPeekInfo(source);
+	        return new AuditFilter(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.certificate_count = source["certificate_count"];
-	        this.ca_name = source["ca_name"];
-	        this.has_security_keys = source["has_security_keys"];
-	        this.hostnames = source["hostnames"];
-	        this.certificates = this.convertValues(source["certificates"], BackupCertificateInfo);
-	        this.schema_version = source["schema_version"];
+	        this.event_types = source["event_types"];
+	        this.hostname = source["hostname"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	    }
+	}
+	export class AuditLogPage {
+	    entries: AuditEntry[];
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AuditLogPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.entries = this.convertValues(source["entries"], AuditEntry);
+	        this.total = source["total"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -87,50 +119,122 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class SANEntry {
-	    value: string;
-	    type: string;
+	export class AutostartStatus {
+	    supported: boolean;
+	    enabled: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new SANEntry(source);
+	        return new AutostartStatus(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.value = source["value"];
-	        this.type = source["type"];
+	        this.supported = source["supported"];
+	        this.enabled = source["enabled"];
 	    }
 	}
-	export class CSRRequest {
+	export class BackupCertificateInfo {
 	    hostname: string;
-	    sans?: SANEntry[];
-	    organization: string;
-	    organizational_unit?: string;
-	    city: string;
-	    state: string;
-	    country: string;
-	    key_size: number;
-	    note?: string;
-	    is_renewal?: boolean;
-	    skip_suffix_validation?: boolean;
+	    status: string;
+	    sans?: string[];
+	    key_size?: number;
+	    created_at: number;
+	    expires_at?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new CSRRequest(source);
+	        return new BackupCertificateInfo(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.hostname = source["hostname"];
-	        this.sans = this.convertValues(source["sans"], SANEntry);
-	        this.organization = source["organization"];
-	        this.organizational_unit = source["organizational_unit"];
-	        this.city = source["city"];
-	        this.state = source["state"];
-	        this.country = source["country"];
+	        this.status = source["status"];
+	        this.sans = source["sans"];
 	        this.key_size = source["key_size"];
-	        this.note = source["note"];
-	        this.is_renewal = source["is_renewal"];
-	        this.skip_suffix_validation = source["skip_suffix_validation"];
+	        this.created_at = source["created_at"];
+	        this.expires_at = source["expires_at"];
+	    }
+	}
+	export class BackupFreshness {
+	    last_backup_at?: number;
+	    writes_since_backup: number;
+	    max_writes: number;
+	    stale: boolean;
+	    blocking: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new BackupFreshness(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.last_backup_at = source["last_backup_at"];
+	        this.writes_since_backup = source["writes_since_backup"];
+	        this.max_writes = source["max_writes"];
+	        this.stale = source["stale"];
+	        this.blocking = source["blocking"];
+	    }
+	}
+	export class BackupListOptions {
+	    type?: string;
+	    sort_by?: string;
+	    sort_order?: string;
+	    offset?: number;
+	    limit?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BackupListOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.sort_by = source["sort_by"];
+	        this.sort_order = source["sort_order"];
+	        this.offset = source["offset"];
+	        this.limit = source["limit"];
+	    }
+	}
+	export class LocalBackupInfo {
+	    filename: string;
+	    type: string;
+	    timestamp: number;
+	    size: number;
+	    certificate_count: number;
+	    ca_name?: string;
+	    operation?: string;
+	    app_version?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new LocalBackupInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.filename = source["filename"];
+	        this.type = source["type"];
+	        this.timestamp = source["timestamp"];
+	        this.size = source["size"];
+	        this.certificate_count = source["certificate_count"];
+	        this.ca_name = source["ca_name"];
+	        this.operation = source["operation"];
+	        this.app_version = source["app_version"];
+	    }
+	}
+	export class BackupListPage {
+	    backups: LocalBackupInfo[];
+	    total: number;
+	    total_size: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BackupListPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.backups = this.convertValues(source["backups"], LocalBackupInfo);
+	        this.total = source["total"];
+	        this.total_size = source["total_size"];
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -151,200 +255,2627 @@ export namespace models {
 		    return a;
 		}
 	}
-	export class CSRResponse {
-	    hostname: string;
-	    csr: string;
-	    message: string;
+	export class BackupMergeOptions {
+	    conflict_policy: string;
+	    overrides?: Record<string, string>;
+	    duplicate_key_policy?: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new CSRResponse(source);
+	        return new BackupMergeOptions(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.hostname = source["hostname"];
-	        this.csr = source["csr"];
-	        this.message = source["message"];
+	        this.conflict_policy = source["conflict_policy"];
+	        this.overrides = source["overrides"];
+	        this.duplicate_key_policy = source["duplicate_key_policy"];
 	    }
 	}
-	export class CertImportResult {
-	    imported: number;
-	    skipped: number;
-	    conflicts?: string[];
+	export class CertKeyLink {
+	    hostname: string;
+	    existing_hostname: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new CertImportResult(source);
+	        return new CertKeyLink(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.imported = source["imported"];
-	        this.skipped = source["skipped"];
-	        this.conflicts = source["conflicts"];
+	        this.hostname = source["hostname"];
+	        this.existing_hostname = source["existing_hostname"];
 	    }
 	}
-	export class Certificate {
+	export class CertImportFailure {
 	    hostname: string;
-	    pending_csr?: string;
-	    certificate_pem?: string;
-	    created_at: number;
-	    expires_at?: number;
-	    note?: string;
-	    pending_note?: string;
-	    read_only: boolean;
-	    status: string;
-	    sans?: string[];
-	    organization?: string;
-	    organizational_unit?: string;
-	    city?: string;
-	    state?: string;
-	    country?: string;
-	    key_size?: number;
-	    days_until_expiration?: number;
-	    pending_sans?: string[];
-	    pending_organization?: string;
-	    pending_organizational_unit?: string;
-	    pending_city?: string;
-	    pending_state?: string;
-	    pending_country?: string;
-	    pending_key_size?: number;
+	    error: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new Certificate(source);
+	        return new CertImportFailure(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.hostname = source["hostname"];
-	        this.pending_csr = source["pending_csr"];
-	        this.certificate_pem = source["certificate_pem"];
-	        this.created_at = source["created_at"];
-	        this.expires_at = source["expires_at"];
-	        this.note = source["note"];
-	        this.pending_note = source["pending_note"];
-	        this.read_only = source["read_only"];
-	        this.status = source["status"];
-	        this.sans = source["sans"];
-	        this.organization = source["organization"];
-	        this.organizational_unit = source["organizational_unit"];
-	        this.city = source["city"];
-	        this.state = source["state"];
-	        this.country = source["country"];
-	        this.key_size = source["key_size"];
-	        this.days_until_expiration = source["days_until_expiration"];
-	        this.pending_sans = source["pending_sans"];
-	        this.pending_organization = source["pending_organization"];
-	        this.pending_organizational_unit = source["pending_organizational_unit"];
-	        this.pending_city = source["pending_city"];
-	        this.pending_state = source["pending_state"];
-	        this.pending_country = source["pending_country"];
-	        this.pending_key_size = source["pending_key_size"];
+	        this.error = source["error"];
 	    }
 	}
-	export class CertificateFilter {
-	    status?: string;
-	    sort_by?: string;
-	    sort_order?: string;
+	export class BackupMergeResult {
+	    added: string[];
+	    replaced: string[];
+	    kept: string[];
+	    failed: CertImportFailure[];
+	    linked: CertKeyLink[];
+	    dry_run: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new CertificateFilter(source);
+	        return new BackupMergeResult(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.status = source["status"];
-	        this.sort_by = source["sort_by"];
-	        this.sort_order = source["sort_order"];
+	        this.added = source["added"];
+	        this.replaced = source["replaced"];
+	        this.kept = source["kept"];
+	        this.failed = this.convertValues(source["failed"], CertImportFailure);
+	        this.linked = this.convertValues(source["linked"], CertKeyLink);
+	        this.dry_run = source["dry_run"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class CertificateListItem {
-	    hostname: string;
-	    status: string;
-	    sans?: string[];
-	    key_size?: number;
+	export class BackupMetadata {
+	    operation: string;
+	    app_version: string;
+	    certificate_count: number;
 	    created_at: number;
-	    expires_at?: number;
-	    days_until_expiration?: number;
-	    read_only: boolean;
-	    has_pending_csr: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new CertificateListItem(source);
+	        return new BackupMetadata(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.hostname = source["hostname"];
-	        this.status = source["status"];
-	        this.sans = source["sans"];
-	        this.key_size = source["key_size"];
+	        this.operation = source["operation"];
+	        this.app_version = source["app_version"];
+	        this.certificate_count = source["certificate_count"];
 	        this.created_at = source["created_at"];
-	        this.expires_at = source["expires_at"];
-	        this.days_until_expiration = source["days_until_expiration"];
-	        this.read_only = source["read_only"];
-	        this.has_pending_csr = source["has_pending_csr"];
 	    }
 	}
-	export class CertificateUploadPreview {
-	    hostname: string;
-	    issuer_cn: string;
-	    issuer_o: string;
-	    not_before: number;
-	    not_after: number;
-	    sans?: string[];
-	    key_size: number;
-	    csr_match: boolean;
-	    key_match: boolean;
+	export class BackupPasswordCheck {
+	    valid: boolean;
+	    label?: string;
+	    same_master_key: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new CertificateUploadPreview(source);
+	        return new BackupPasswordCheck(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.hostname = source["hostname"];
-	        this.issuer_cn = source["issuer_cn"];
-	        this.issuer_o = source["issuer_o"];
-	        this.not_before = source["not_before"];
-	        this.not_after = source["not_after"];
-	        this.sans = source["sans"];
-	        this.key_size = source["key_size"];
-	        this.csr_match = source["csr_match"];
-	        this.key_match = source["key_match"];
-	    }
+	        this.valid = source["valid"];
+	        this.label = source["label"];
+	        this.same_master_key = source["same_master_key"];
+	    }
+	}
+	export class SecurityKeyInfo {
+	    id: number;
+	    method: string;
+	    label: string;
+	    created_at: number;
+	    last_used_at?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SecurityKeyInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.method = source["method"];
+	        this.label = source["label"];
+	        this.created_at = source["created_at"];
+	        this.last_used_at = source["last_used_at"];
+	    }
+	}
+	export class BackupPeekInfo {
+	    certificate_count: number;
+	    ca_name: string;
+	    has_security_keys: boolean;
+	    hostnames: string[];
+	    certificates: BackupCertificateInfo[];
+	    schema_version: number;
+	    metadata?: BackupMetadata;
+	    unlock_methods: SecurityKeyInfo[];
+	    hostname_suffix: string;
+	    suggested_hostname_suffix?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BackupPeekInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.certificate_count = source["certificate_count"];
+	        this.ca_name = source["ca_name"];
+	        this.has_security_keys = source["has_security_keys"];
+	        this.hostnames = source["hostnames"];
+	        this.certificates = this.convertValues(source["certificates"], BackupCertificateInfo);
+	        this.schema_version = source["schema_version"];
+	        this.metadata = this.convertValues(source["metadata"], BackupMetadata);
+	        this.unlock_methods = this.convertValues(source["unlock_methods"], SecurityKeyInfo);
+	        this.hostname_suffix = source["hostname_suffix"];
+	        this.suggested_hostname_suffix = source["suggested_hostname_suffix"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BackupStorageUsage {
+	    count: number;
+	    size: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BackupStorageUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.count = source["count"];
+	        this.size = source["size"];
+	    }
+	}
+	export class BackupStorageBreakdown {
+	    auto: BackupStorageUsage;
+	    manual: BackupStorageUsage;
+	    total: BackupStorageUsage;
+	    max_auto_backups: number;
+	    oldest_timestamp?: number;
+	    newest_timestamp?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BackupStorageBreakdown(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.auto = this.convertValues(source["auto"], BackupStorageUsage);
+	        this.manual = this.convertValues(source["manual"], BackupStorageUsage);
+	        this.total = this.convertValues(source["total"], BackupStorageUsage);
+	        this.max_auto_backups = source["max_auto_backups"];
+	        this.oldest_timestamp = source["oldest_timestamp"];
+	        this.newest_timestamp = source["newest_timestamp"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class BulkCSRHostResult {
+	    hostname: string;
+	    success: boolean;
+	    error?: string;
+	    csr?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BulkCSRHostResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.success = source["success"];
+	        this.error = source["error"];
+	        this.csr = source["csr"];
+	    }
+	}
+	export class CertificateRequester {
+	    name: string;
+	    email?: string;
+	    team?: string;
+	    justification?: string;
+	    recorded_at?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CertificateRequester(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.email = source["email"];
+	        this.team = source["team"];
+	        this.justification = source["justification"];
+	        this.recorded_at = source["recorded_at"];
+	    }
+	}
+	export class SANEntry {
+	    value: string;
+	    type: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SANEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.value = source["value"];
+	        this.type = source["type"];
+	    }
+	}
+	export class CSRRequest {
+	    hostname: string;
+	    sans?: SANEntry[];
+	    organization: string;
+	    organizational_unit?: string;
+	    city: string;
+	    state: string;
+	    country: string;
+	    key_algorithm?: string;
+	    key_size: number;
+	    note?: string;
+	    is_renewal?: boolean;
+	    skip_suffix_validation?: boolean;
+	    preset_id?: number;
+	    inherit_sans?: boolean;
+	    requester?: CertificateRequester;
+	
+	    static createFrom(source: any = {}) {
+	        return new CSRRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.sans = this.convertValues(source["sans"], SANEntry);
+	        this.organization = source["organization"];
+	        this.organizational_unit = source["organizational_unit"];
+	        this.city = source["city"];
+	        this.state = source["state"];
+	        this.country = source["country"];
+	        this.key_algorithm = source["key_algorithm"];
+	        this.key_size = source["key_size"];
+	        this.note = source["note"];
+	        this.is_renewal = source["is_renewal"];
+	        this.skip_suffix_validation = source["skip_suffix_validation"];
+	        this.preset_id = source["preset_id"];
+	        this.inherit_sans = source["inherit_sans"];
+	        this.requester = this.convertValues(source["requester"], CertificateRequester);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BulkCSRRequest {
+	    hostnames?: string[];
+	    list?: string;
+	    template: CSRRequest;
+	
+	    static createFrom(source: any = {}) {
+	        return new BulkCSRRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostnames = source["hostnames"];
+	        this.list = source["list"];
+	        this.template = this.convertValues(source["template"], CSRRequest);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BulkCSRResult {
+	    generated: boolean;
+	    results: BulkCSRHostResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BulkCSRResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.generated = source["generated"];
+	        this.results = this.convertValues(source["results"], BulkCSRHostResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class BulkDeletePreviewItem {
+	    hostname: string;
+	    status: string;
+	    has_private_key: boolean;
+	    has_pending_key: boolean;
+	    read_only: boolean;
+	    deployed_on?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BulkDeletePreviewItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.status = source["status"];
+	        this.has_private_key = source["has_private_key"];
+	        this.has_pending_key = source["has_pending_key"];
+	        this.read_only = source["read_only"];
+	        this.deployed_on = source["deployed_on"];
+	    }
+	}
+	export class BulkDeletePreview {
+	    items: BulkDeletePreviewItem[];
+	    confirmation_token?: string;
+	    expires_at?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BulkDeletePreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.items = this.convertValues(source["items"], BulkDeletePreviewItem);
+	        this.confirmation_token = source["confirmation_token"];
+	        this.expires_at = source["expires_at"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class BulkHostResult {
+	    hostname: string;
+	    success: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new BulkHostResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.success = source["success"];
+	        this.error = source["error"];
+	    }
+	}
+	export class BulkDeleteResult {
+	    deleted: boolean;
+	    results: BulkHostResult[];
+	    backup_path: string;
+	    dry_run: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new BulkDeleteResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.deleted = source["deleted"];
+	        this.results = this.convertValues(source["results"], BulkHostResult);
+	        this.backup_path = source["backup_path"];
+	        this.dry_run = source["dry_run"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class BulkPatch {
+	    note?: string;
+	    note_mode?: string;
+	    read_only?: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new BulkPatch(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.note = source["note"];
+	        this.note_mode = source["note_mode"];
+	        this.read_only = source["read_only"];
+	    }
+	}
+	export class BulkUpdateResult {
+	    applied: boolean;
+	    results: BulkHostResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new BulkUpdateResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.applied = source["applied"];
+	        this.results = this.convertValues(source["results"], BulkHostResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CALeadTime {
+	    issuer: string;
+	    samples: number;
+	    average_days: number;
+	    median_days: number;
+	    min_days: number;
+	    max_days: number;
+	    recommended_warning_days: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CALeadTime(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.issuer = source["issuer"];
+	        this.samples = source["samples"];
+	        this.average_days = source["average_days"];
+	        this.median_days = source["median_days"];
+	        this.min_days = source["min_days"];
+	        this.max_days = source["max_days"];
+	        this.recommended_warning_days = source["recommended_warning_days"];
+	    }
+	}
+	export class CSRIntake {
+	    hostname: string;
+	    sans?: SANEntry[];
+	    requester: CertificateRequester;
+	    is_renewal: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new CSRIntake(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.sans = this.convertValues(source["sans"], SANEntry);
+	        this.requester = this.convertValues(source["requester"], CertificateRequester);
+	        this.is_renewal = source["is_renewal"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class CSRResponse {
+	    hostname: string;
+	    csr: string;
+	    message: string;
+	    sans_inherited?: boolean;
+	    inherited_sans?: string[];
+	    dependent_certificates?: string[];
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CSRResponse(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.csr = source["csr"];
+	        this.message = source["message"];
+	        this.sans_inherited = source["sans_inherited"];
+	        this.inherited_sans = source["inherited_sans"];
+	        this.dependent_certificates = source["dependent_certificates"];
+	        this.warnings = source["warnings"];
+	    }
+	}
+	export class CSRSubmission {
+	    ca_reference?: string;
+	    submitted_at?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CSRSubmission(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.ca_reference = source["ca_reference"];
+	        this.submitted_at = source["submitted_at"];
+	    }
+	}
+	
+	export class CertImportOptions {
+	    best_effort: boolean;
+	    duplicate_key_policy?: string;
+	    hostname_mapping?: Record<string, string>;
+	
+	    static createFrom(source: any = {}) {
+	        return new CertImportOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.best_effort = source["best_effort"];
+	        this.duplicate_key_policy = source["duplicate_key_policy"];
+	        this.hostname_mapping = source["hostname_mapping"];
+	    }
+	}
+	export class CertImportPreviewEntry {
+	    hostname: string;
+	    status: string;
+	    reason?: string;
+	    linked_to?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CertImportPreviewEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.status = source["status"];
+	        this.reason = source["reason"];
+	        this.linked_to = source["linked_to"];
+	    }
+	}
+	export class CertImportPreview {
+	    importable: CertImportPreviewEntry[];
+	    conflicting: CertImportPreviewEntry[];
+	    invalid: CertImportPreviewEntry[];
+	    key_duplicates: CertImportPreviewEntry[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CertImportPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.importable = this.convertValues(source["importable"], CertImportPreviewEntry);
+	        this.conflicting = this.convertValues(source["conflicting"], CertImportPreviewEntry);
+	        this.invalid = this.convertValues(source["invalid"], CertImportPreviewEntry);
+	        this.key_duplicates = this.convertValues(source["key_duplicates"], CertImportPreviewEntry);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class CertRename {
+	    original_hostname: string;
+	    hostname: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new CertRename(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.original_hostname = source["original_hostname"];
+	        this.hostname = source["hostname"];
+	    }
+	}
+	export class CertImportResult {
+	    imported: number;
+	    skipped: number;
+	    conflicts?: string[];
+	    failed?: CertImportFailure[];
+	    linked?: CertKeyLink[];
+	    renamed?: CertRename[];
+	    suggested_hostname_suffix?: string;
+	    dry_run: boolean;
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CertImportResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.imported = source["imported"];
+	        this.skipped = source["skipped"];
+	        this.conflicts = source["conflicts"];
+	        this.failed = this.convertValues(source["failed"], CertImportFailure);
+	        this.linked = this.convertValues(source["linked"], CertKeyLink);
+	        this.renamed = this.convertValues(source["renamed"], CertRename);
+	        this.suggested_hostname_suffix = source["suggested_hostname_suffix"];
+	        this.dry_run = source["dry_run"];
+	        this.warnings = source["warnings"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class NoteReference {
+	    source: string;
+	    kind: string;
+	    value: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new NoteReference(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.source = source["source"];
+	        this.kind = source["kind"];
+	        this.value = source["value"];
+	    }
+	}
+	export class CertificateExtensions {
+	    serial_number: string;
+	    signature_algorithm: string;
+	    fingerprint_sha1: string;
+	    fingerprint_sha256: string;
+	    key_usage?: string[];
+	    ext_key_usage?: string[];
+	    basic_constraints_valid: boolean;
+	    is_ca: boolean;
+	    max_path_len: number;
+	    subject_key_id?: string;
+	    authority_key_id?: string;
+	    ocsp_servers?: string[];
+	    issuing_certificate_urls?: string[];
+	    crl_distribution_points?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CertificateExtensions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.serial_number = source["serial_number"];
+	        this.signature_algorithm = source["signature_algorithm"];
+	        this.fingerprint_sha1 = source["fingerprint_sha1"];
+	        this.fingerprint_sha256 = source["fingerprint_sha256"];
+	        this.key_usage = source["key_usage"];
+	        this.ext_key_usage = source["ext_key_usage"];
+	        this.basic_constraints_valid = source["basic_constraints_valid"];
+	        this.is_ca = source["is_ca"];
+	        this.max_path_len = source["max_path_len"];
+	        this.subject_key_id = source["subject_key_id"];
+	        this.authority_key_id = source["authority_key_id"];
+	        this.ocsp_servers = source["ocsp_servers"];
+	        this.issuing_certificate_urls = source["issuing_certificate_urls"];
+	        this.crl_distribution_points = source["crl_distribution_points"];
+	    }
+	}
+	export class CertificateSubject {
+	    sans?: string[];
+	    organization?: string;
+	    organizational_unit?: string;
+	    city?: string;
+	    state?: string;
+	    country?: string;
+	    key_algorithm?: string;
+	    key_size?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CertificateSubject(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.sans = source["sans"];
+	        this.organization = source["organization"];
+	        this.organizational_unit = source["organizational_unit"];
+	        this.city = source["city"];
+	        this.state = source["state"];
+	        this.country = source["country"];
+	        this.key_algorithm = source["key_algorithm"];
+	        this.key_size = source["key_size"];
+	    }
+	}
+	export class Certificate {
+	    hostname: string;
+	    pending_csr?: string;
+	    certificate_pem?: string;
+	    created_at: number;
+	    expires_at?: number;
+	    expires_at_utc?: string;
+	    expires_at_local?: string;
+	    note?: string;
+	    pending_note?: string;
+	    read_only: boolean;
+	    ca_reference?: string;
+	    submitted_at?: number;
+	    status: string;
+	    display_hostname: string;
+	    days_until_expiration?: number;
+	    active?: CertificateSubject;
+	    pending?: CertificateSubject;
+	    extensions?: CertificateExtensions;
+	    requester?: CertificateRequester;
+	    tags?: string[];
+	    note_references?: NoteReference[];
+	
+	    static createFrom(source: any = {}) {
+	        return new Certificate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.pending_csr = source["pending_csr"];
+	        this.certificate_pem = source["certificate_pem"];
+	        this.created_at = source["created_at"];
+	        this.expires_at = source["expires_at"];
+	        this.expires_at_utc = source["expires_at_utc"];
+	        this.expires_at_local = source["expires_at_local"];
+	        this.note = source["note"];
+	        this.pending_note = source["pending_note"];
+	        this.read_only = source["read_only"];
+	        this.ca_reference = source["ca_reference"];
+	        this.submitted_at = source["submitted_at"];
+	        this.status = source["status"];
+	        this.display_hostname = source["display_hostname"];
+	        this.days_until_expiration = source["days_until_expiration"];
+	        this.active = this.convertValues(source["active"], CertificateSubject);
+	        this.pending = this.convertValues(source["pending"], CertificateSubject);
+	        this.extensions = this.convertValues(source["extensions"], CertificateExtensions);
+	        this.requester = this.convertValues(source["requester"], CertificateRequester);
+	        this.tags = source["tags"];
+	        this.note_references = this.convertValues(source["note_references"], NoteReference);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ChainFetchAttempt {
+	    url: string;
+	    depth: number;
+	    outcome: string;
+	    status_code?: number;
+	    error?: string;
+	    proxy?: string;
+	    override?: boolean;
+	    duration_ms: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChainFetchAttempt(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.url = source["url"];
+	        this.depth = source["depth"];
+	        this.outcome = source["outcome"];
+	        this.status_code = source["status_code"];
+	        this.error = source["error"];
+	        this.proxy = source["proxy"];
+	        this.override = source["override"];
+	        this.duration_ms = source["duration_ms"];
+	    }
+	}
+	export class ChainFetchDiagnostics {
+	    cause: string;
+	    summary: string;
+	    attempts: ChainFetchAttempt[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ChainFetchDiagnostics(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.cause = source["cause"];
+	        this.summary = source["summary"];
+	        this.attempts = this.convertValues(source["attempts"], ChainFetchAttempt);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ChainCertificateInfo {
+	    subject_cn: string;
+	    subject_o: string;
+	    issuer_cn: string;
+	    issuer_o: string;
+	    issuer_dn: string;
+	    not_before_timestamp: number;
+	    not_after_timestamp: number;
+	    serial_number: string;
+	    cert_type: string;
+	    depth: number;
+	    pem?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChainCertificateInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.subject_cn = source["subject_cn"];
+	        this.subject_o = source["subject_o"];
+	        this.issuer_cn = source["issuer_cn"];
+	        this.issuer_o = source["issuer_o"];
+	        this.issuer_dn = source["issuer_dn"];
+	        this.not_before_timestamp = source["not_before_timestamp"];
+	        this.not_after_timestamp = source["not_after_timestamp"];
+	        this.serial_number = source["serial_number"];
+	        this.cert_type = source["cert_type"];
+	        this.depth = source["depth"];
+	        this.pem = source["pem"];
+	    }
+	}
+	export class CertificateChain {
+	    certificates: ChainCertificateInfo[];
+	    diagnostics?: ChainFetchDiagnostics;
+	
+	    static createFrom(source: any = {}) {
+	        return new CertificateChain(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.certificates = this.convertValues(source["certificates"], ChainCertificateInfo);
+	        this.diagnostics = this.convertValues(source["diagnostics"], ChainFetchDiagnostics);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class CertificateFilter {
+	    status?: string;
+	    sort_by?: string;
+	    sort_order?: string;
+	    awaiting_response_days?: number;
+	    tags?: string[];
+	    reference?: string;
+	    search?: string;
+	    hostname?: string;
+	    offset?: number;
+	    limit?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CertificateFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.status = source["status"];
+	        this.sort_by = source["sort_by"];
+	        this.sort_order = source["sort_order"];
+	        this.awaiting_response_days = source["awaiting_response_days"];
+	        this.tags = source["tags"];
+	        this.reference = source["reference"];
+	        this.search = source["search"];
+	        this.hostname = source["hostname"];
+	        this.offset = source["offset"];
+	        this.limit = source["limit"];
+	    }
+	}
+	export class CertificateRelation {
+	    id: number;
+	    source: string;
+	    target: string;
+	    type: string;
+	    label?: string;
+	    created_at: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CertificateRelation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.source = source["source"];
+	        this.target = source["target"];
+	        this.type = source["type"];
+	        this.label = source["label"];
+	        this.created_at = source["created_at"];
+	    }
+	}
+	export class CertificateListItem {
+	    hostname: string;
+	    status: string;
+	    display_hostname: string;
+	    sans?: string[];
+	    key_algorithm?: string;
+	    key_size?: number;
+	    created_at: number;
+	    expires_at?: number;
+	    expires_at_utc?: string;
+	    expires_at_local?: string;
+	    days_until_expiration?: number;
+	    read_only: boolean;
+	    has_pending_csr: boolean;
+	    ca_reference?: string;
+	    submitted_at?: number;
+	    key_status?: string;
+	    tags?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CertificateListItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.status = source["status"];
+	        this.display_hostname = source["display_hostname"];
+	        this.sans = source["sans"];
+	        this.key_algorithm = source["key_algorithm"];
+	        this.key_size = source["key_size"];
+	        this.created_at = source["created_at"];
+	        this.expires_at = source["expires_at"];
+	        this.expires_at_utc = source["expires_at_utc"];
+	        this.expires_at_local = source["expires_at_local"];
+	        this.days_until_expiration = source["days_until_expiration"];
+	        this.read_only = source["read_only"];
+	        this.has_pending_csr = source["has_pending_csr"];
+	        this.ca_reference = source["ca_reference"];
+	        this.submitted_at = source["submitted_at"];
+	        this.key_status = source["key_status"];
+	        this.tags = source["tags"];
+	    }
+	}
+	export class CertificateGraph {
+	    nodes: CertificateListItem[];
+	    edges: CertificateRelation[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CertificateGraph(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.nodes = this.convertValues(source["nodes"], CertificateListItem);
+	        this.edges = this.convertValues(source["edges"], CertificateRelation);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class CertificatePage {
+	    certificates: CertificateListItem[];
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CertificatePage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.certificates = this.convertValues(source["certificates"], CertificateListItem);
+	        this.total = source["total"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class CertificatePins {
+	    hostname: string;
+	    active_pin?: string;
+	    pending_pin?: string;
+	    expires_at?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CertificatePins(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.active_pin = source["active_pin"];
+	        this.pending_pin = source["pending_pin"];
+	        this.expires_at = source["expires_at"];
+	    }
+	}
+	export class QRFrame {
+	    content: string;
+	    image: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new QRFrame(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.content = source["content"];
+	        this.image = source["image"];
+	    }
+	}
+	export class CertificateQRCodes {
+	    hostname: string;
+	    fingerprint_sha256: string;
+	    fingerprint: QRFrame;
+	    pem_frames?: QRFrame[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CertificateQRCodes(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.fingerprint_sha256 = source["fingerprint_sha256"];
+	        this.fingerprint = this.convertValues(source["fingerprint"], QRFrame);
+	        this.pem_frames = this.convertValues(source["pem_frames"], QRFrame);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class CertificateResult {
+	    hostname: string;
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new CertificateResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.warnings = source["warnings"];
+	    }
+	}
+	
+	export class CertificateUploadPreview {
+	    hostname: string;
+	    issuer_cn: string;
+	    issuer_o: string;
+	    not_before: number;
+	    not_after: number;
+	    sans?: string[];
+	    key_size: number;
+	    csr_match: boolean;
+	    key_match: boolean;
+	    chain_count: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CertificateUploadPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.issuer_cn = source["issuer_cn"];
+	        this.issuer_o = source["issuer_o"];
+	        this.not_before = source["not_before"];
+	        this.not_after = source["not_after"];
+	        this.sans = source["sans"];
+	        this.key_size = source["key_size"];
+	        this.csr_match = source["csr_match"];
+	        this.key_match = source["key_match"];
+	        this.chain_count = source["chain_count"];
+	    }
+	}
+	export class ChainCacheStats {
+	    entries: number;
+	    max_entries: number;
+	    ttl_seconds: number;
+	    hits: number;
+	    misses: number;
+	    evictions: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChainCacheStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.entries = source["entries"];
+	        this.max_entries = source["max_entries"];
+	        this.ttl_seconds = source["ttl_seconds"];
+	        this.hits = source["hits"];
+	        this.misses = source["misses"];
+	        this.evictions = source["evictions"];
+	    }
+	}
+	
+	
+	
+	export class ChainOverride {
+	    hostname?: string;
+	    issuer_dn?: string;
+	    issuer_url?: string;
+	    issuer_pem?: string;
+	    issuer_subject?: string;
+	    created_at: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ChainOverride(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.issuer_dn = source["issuer_dn"];
+	        this.issuer_url = source["issuer_url"];
+	        this.issuer_pem = source["issuer_pem"];
+	        this.issuer_subject = source["issuer_subject"];
+	        this.created_at = source["created_at"];
+	    }
+	}
+	export class TrustStoreResult {
+	    store: string;
+	    description: string;
+	    trusted: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new TrustStoreResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.store = source["store"];
+	        this.description = source["description"];
+	        this.trusted = source["trusted"];
+	        this.error = source["error"];
+	    }
+	}
+	export class ChainTrustResult {
+	    hostname: string;
+	    chain_source: string;
+	    chain_length: number;
+	    evaluated_at: number;
+	    stores: TrustStoreResult[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ChainTrustResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.chain_source = source["chain_source"];
+	        this.chain_length = source["chain_length"];
+	        this.evaluated_at = source["evaluated_at"];
+	        this.stores = this.convertValues(source["stores"], TrustStoreResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class ClockCheckResult {
+	    source: string;
+	    checked_at: number;
+	    skew_seconds: number;
+	    skewed: boolean;
+	    skipped: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ClockCheckResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.source = source["source"];
+	        this.checked_at = source["checked_at"];
+	        this.skew_seconds = source["skew_seconds"];
+	        this.skewed = source["skewed"];
+	        this.skipped = source["skipped"];
+	        this.error = source["error"];
+	    }
+	}
+	export class Config {
+	    id: number;
+	    owner_email: string;
+	    ca_name: string;
+	    hostname_suffix: string;
+	    validity_period_days: number;
+	    default_organization: string;
+	    default_organizational_unit?: string;
+	    default_city: string;
+	    default_state: string;
+	    default_country: string;
+	    default_key_size: number;
+	    expiring_threshold_days: number;
+	    clock_check_url: string;
+	    air_gapped: boolean;
+	    backup_freshness_max_writes: number;
+	    backup_freshness_block: boolean;
+	    fips_mode: boolean;
+	    db_size_warn_mb: number;
+	    log_max_size_mb: number;
+	    log_max_files: number;
+	    log_max_age_days: number;
+	    log_compress: boolean;
+	    minimize_to_tray: boolean;
+	    run_in_background: boolean;
+	    expiry_notifications: boolean;
+	    aia_cache_ttl_minutes: number;
+	    download_line_endings: string;
+	    download_text_header: boolean;
+	    download_format: string;
+	    key_pool_size: number;
+	    kdf_profile: string;
+	    ticket_pattern: string;
+	    trash_retention_days: number;
+	    is_configured: number;
+	    created_at: number;
+	    last_modified: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new Config(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.owner_email = source["owner_email"];
+	        this.ca_name = source["ca_name"];
+	        this.hostname_suffix = source["hostname_suffix"];
+	        this.validity_period_days = source["validity_period_days"];
+	        this.default_organization = source["default_organization"];
+	        this.default_organizational_unit = source["default_organizational_unit"];
+	        this.default_city = source["default_city"];
+	        this.default_state = source["default_state"];
+	        this.default_country = source["default_country"];
+	        this.default_key_size = source["default_key_size"];
+	        this.expiring_threshold_days = source["expiring_threshold_days"];
+	        this.clock_check_url = source["clock_check_url"];
+	        this.air_gapped = source["air_gapped"];
+	        this.backup_freshness_max_writes = source["backup_freshness_max_writes"];
+	        this.backup_freshness_block = source["backup_freshness_block"];
+	        this.fips_mode = source["fips_mode"];
+	        this.db_size_warn_mb = source["db_size_warn_mb"];
+	        this.log_max_size_mb = source["log_max_size_mb"];
+	        this.log_max_files = source["log_max_files"];
+	        this.log_max_age_days = source["log_max_age_days"];
+	        this.log_compress = source["log_compress"];
+	        this.minimize_to_tray = source["minimize_to_tray"];
+	        this.run_in_background = source["run_in_background"];
+	        this.expiry_notifications = source["expiry_notifications"];
+	        this.aia_cache_ttl_minutes = source["aia_cache_ttl_minutes"];
+	        this.download_line_endings = source["download_line_endings"];
+	        this.download_text_header = source["download_text_header"];
+	        this.download_format = source["download_format"];
+	        this.key_pool_size = source["key_pool_size"];
+	        this.kdf_profile = source["kdf_profile"];
+	        this.ticket_pattern = source["ticket_pattern"];
+	        this.trash_retention_days = source["trash_retention_days"];
+	        this.is_configured = source["is_configured"];
+	        this.created_at = source["created_at"];
+	        this.last_modified = source["last_modified"];
+	    }
+	}
+	export class DatabaseCleanupResult {
+	    action: string;
+	    rows_deleted: number;
+	    size_before: number;
+	    size_after: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DatabaseCleanupResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.action = source["action"];
+	        this.rows_deleted = source["rows_deleted"];
+	        this.size_before = source["size_before"];
+	        this.size_after = source["size_after"];
+	    }
+	}
+	export class DatabaseContributor {
+	    name: string;
+	    bytes: number;
+	    rows: number;
+	    cleanup?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DatabaseContributor(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.bytes = source["bytes"];
+	        this.rows = source["rows"];
+	        this.cleanup = source["cleanup"];
+	    }
+	}
+	export class DatabaseUsage {
+	    size_bytes: number;
+	    warn_bytes: number;
+	    over_quota: boolean;
+	    contributors: DatabaseContributor[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DatabaseUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.size_bytes = source["size_bytes"];
+	        this.warn_bytes = source["warn_bytes"];
+	        this.over_quota = source["over_quota"];
+	        this.contributors = this.convertValues(source["contributors"], DatabaseContributor);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DeletedCertificate {
+	    hostname: string;
+	    display_hostname: string;
+	    expires_at?: number;
+	    note?: string;
+	    has_private_key: boolean;
+	    deleted_at: number;
+	    purge_at: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeletedCertificate(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.display_hostname = source["display_hostname"];
+	        this.expires_at = source["expires_at"];
+	        this.note = source["note"];
+	        this.has_private_key = source["has_private_key"];
+	        this.deleted_at = source["deleted_at"];
+	        this.purge_at = source["purge_at"];
+	    }
+	}
+	export class DeploymentTarget {
+	    id: number;
+	    hostname: string;
+	    name: string;
+	    location?: string;
+	    deployed_at: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DeploymentTarget(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.hostname = source["hostname"];
+	        this.name = source["name"];
+	        this.location = source["location"];
+	        this.deployed_at = source["deployed_at"];
+	    }
+	}
+	export class DirectoryImportEntry {
+	    hostname: string;
+	    certificate_file: string;
+	    key_file?: string;
+	    matched_by?: string;
+	    chain_count: number;
+	    expires_at: number;
+	    status: string;
+	    reason?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DirectoryImportEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.certificate_file = source["certificate_file"];
+	        this.key_file = source["key_file"];
+	        this.matched_by = source["matched_by"];
+	        this.chain_count = source["chain_count"];
+	        this.expires_at = source["expires_at"];
+	        this.status = source["status"];
+	        this.reason = source["reason"];
+	    }
+	}
+	export class DirectoryImportSkippedFile {
+	    file: string;
+	    reason: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new DirectoryImportSkippedFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.file = source["file"];
+	        this.reason = source["reason"];
+	    }
+	}
+	export class DirectoryImportPreview {
+	    path: string;
+	    importable: DirectoryImportEntry[];
+	    conflicting: DirectoryImportEntry[];
+	    invalid: DirectoryImportEntry[];
+	    skipped: DirectoryImportSkippedFile[];
+	
+	    static createFrom(source: any = {}) {
+	        return new DirectoryImportPreview(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.importable = this.convertValues(source["importable"], DirectoryImportEntry);
+	        this.conflicting = this.convertValues(source["conflicting"], DirectoryImportEntry);
+	        this.invalid = this.convertValues(source["invalid"], DirectoryImportEntry);
+	        this.skipped = this.convertValues(source["skipped"], DirectoryImportSkippedFile);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class EndpointProbe {
+	    hostname: string;
+	    address: string;
+	    tls_version: string;
+	    probed_at: number;
+	    presented: ChainCertificateInfo[];
+	    presented_sha256: string;
+	    stored_sha256: string;
+	    stored_serial: string;
+	    stored_not_after: number;
+	    matches: boolean;
+	    mismatches: string[];
+	    trust?: ChainTrustResult;
+	
+	    static createFrom(source: any = {}) {
+	        return new EndpointProbe(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.address = source["address"];
+	        this.tls_version = source["tls_version"];
+	        this.probed_at = source["probed_at"];
+	        this.presented = this.convertValues(source["presented"], ChainCertificateInfo);
+	        this.presented_sha256 = source["presented_sha256"];
+	        this.stored_sha256 = source["stored_sha256"];
+	        this.stored_serial = source["stored_serial"];
+	        this.stored_not_after = source["stored_not_after"];
+	        this.matches = source["matches"];
+	        this.mismatches = source["mismatches"];
+	        this.trust = this.convertValues(source["trust"], ChainTrustResult);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class EntropyCheckResult {
+	    checked_at: number;
+	    passed: boolean;
+	    runs: number;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new EntropyCheckResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.checked_at = source["checked_at"];
+	        this.passed = source["passed"];
+	        this.runs = source["runs"];
+	        this.error = source["error"];
+	    }
+	}
+	export class ExportOptions {
+	    certificate: boolean;
+	    chain: boolean;
+	    chain_variant?: string;
+	    private_key: boolean;
+	    csr: boolean;
+	    pending_key: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ExportOptions(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.certificate = source["certificate"];
+	        this.chain = source["chain"];
+	        this.chain_variant = source["chain_variant"];
+	        this.private_key = source["private_key"];
+	        this.csr = source["csr"];
+	        this.pending_key = source["pending_key"];
+	    }
+	}
+	export class SlowOperation {
+	    operation: string;
+	    duration_ms: number;
+	    at: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SlowOperation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.operation = source["operation"];
+	        this.duration_ms = source["duration_ms"];
+	        this.at = source["at"];
+	    }
+	}
+	export class OperationTiming {
+	    operation: string;
+	    count: number;
+	    avg_ms: number;
+	    max_ms: number;
+	    last_ms: number;
+	    last_at: number;
+	    last_slow: boolean;
+	    slow_count: number;
+	    threshold_ms: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new OperationTiming(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.operation = source["operation"];
+	        this.count = source["count"];
+	        this.avg_ms = source["avg_ms"];
+	        this.max_ms = source["max_ms"];
+	        this.last_ms = source["last_ms"];
+	        this.last_at = source["last_at"];
+	        this.last_slow = source["last_slow"];
+	        this.slow_count = source["slow_count"];
+	        this.threshold_ms = source["threshold_ms"];
+	    }
+	}
+	export class RecoveredOperation {
+	    id: number;
+	    operation: string;
+	    hostname: string;
+	    started_at: number;
+	    outcome: string;
+	    detail: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RecoveredOperation(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.operation = source["operation"];
+	        this.hostname = source["hostname"];
+	        this.started_at = source["started_at"];
+	        this.outcome = source["outcome"];
+	        this.detail = source["detail"];
+	    }
+	}
+	export class HealthStatus {
+	    clock_check?: ClockCheckResult;
+	    recovered_operations?: RecoveredOperation[];
+	    backup_freshness?: BackupFreshness;
+	    entropy_check?: EntropyCheckResult;
+	    database_usage?: DatabaseUsage;
+	    chain_cache: ChainCacheStats;
+	    timings: OperationTiming[];
+	    slow_operations: SlowOperation[];
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new HealthStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.clock_check = this.convertValues(source["clock_check"], ClockCheckResult);
+	        this.recovered_operations = this.convertValues(source["recovered_operations"], RecoveredOperation);
+	        this.backup_freshness = this.convertValues(source["backup_freshness"], BackupFreshness);
+	        this.entropy_check = this.convertValues(source["entropy_check"], EntropyCheckResult);
+	        this.database_usage = this.convertValues(source["database_usage"], DatabaseUsage);
+	        this.chain_cache = this.convertValues(source["chain_cache"], ChainCacheStats);
+	        this.timings = this.convertValues(source["timings"], OperationTiming);
+	        this.slow_operations = this.convertValues(source["slow_operations"], SlowOperation);
+	        this.warnings = source["warnings"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HistoryEntry {
+	    id: number;
+	    hostname: string;
+	    event_type: string;
+	    message: string;
+	    created_at: number;
+	    actor: string;
+	    app_version: string;
+	    details?: Record<string, any>;
+	
+	    static createFrom(source: any = {}) {
+	        return new HistoryEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.hostname = source["hostname"];
+	        this.event_type = source["event_type"];
+	        this.message = source["message"];
+	        this.created_at = source["created_at"];
+	        this.actor = source["actor"];
+	        this.app_version = source["app_version"];
+	        this.details = source["details"];
+	    }
+	}
+	export class HistoryFilter {
+	    event_types?: string[];
+	    from?: number;
+	    to?: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HistoryFilter(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.event_types = source["event_types"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	    }
+	}
+	export class HistoryPage {
+	    entries: HistoryEntry[];
+	    total: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HistoryPage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.entries = this.convertValues(source["entries"], HistoryEntry);
+	        this.total = source["total"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class HostnameDuplicateGroup {
+	    normalized: string;
+	    hostnames: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new HostnameDuplicateGroup(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.normalized = source["normalized"];
+	        this.hostnames = source["hostnames"];
+	    }
+	}
+	export class ImportRequest {
+	    certificate_pem: string;
+	    private_key_pem: string;
+	    cert_chain_pem?: string;
+	    note?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ImportRequest(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.certificate_pem = source["certificate_pem"];
+	        this.private_key_pem = source["private_key_pem"];
+	        this.cert_chain_pem = source["cert_chain_pem"];
+	        this.note = source["note"];
+	    }
+	}
+	export class IssuerExpiry {
+	    subject_cn: string;
+	    subject_o: string;
+	    fingerprint_sha256: string;
+	    cert_type: string;
+	    not_after_timestamp: number;
+	    days_until_expiration: number;
+	    status: string;
+	    hostnames: string[];
+	    outlived_by?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new IssuerExpiry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.subject_cn = source["subject_cn"];
+	        this.subject_o = source["subject_o"];
+	        this.fingerprint_sha256 = source["fingerprint_sha256"];
+	        this.cert_type = source["cert_type"];
+	        this.not_after_timestamp = source["not_after_timestamp"];
+	        this.days_until_expiration = source["days_until_expiration"];
+	        this.status = source["status"];
+	        this.hostnames = source["hostnames"];
+	        this.outlived_by = source["outlived_by"];
+	    }
+	}
+	export class KeyValidationResult {
+	    valid: boolean;
+	    failed_hostnames?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new KeyValidationResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.valid = source["valid"];
+	        this.failed_hostnames = source["failed_hostnames"];
+	    }
+	}
+	export class LeadTimeReport {
+	    issuers: CALeadTime[];
+	    overall?: CALeadTime;
+	    pending: number;
+	    expiring_threshold_days: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LeadTimeReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.issuers = this.convertValues(source["issuers"], CALeadTime);
+	        this.overall = this.convertValues(source["overall"], CALeadTime);
+	        this.pending = source["pending"];
+	        this.expiring_threshold_days = source["expiring_threshold_days"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	
+	export class SecretFinding {
+	    kind: string;
+	    description: string;
+	    excerpt: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SecretFinding(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.kind = source["kind"];
+	        this.description = source["description"];
+	        this.excerpt = source["excerpt"];
+	    }
+	}
+	export class NoteSecretReport {
+	    hostname: string;
+	    field: string;
+	    findings: SecretFinding[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NoteSecretReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.field = source["field"];
+	        this.findings = this.convertValues(source["findings"], SecretFinding);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class NoteScanResult {
+	    scanned: number;
+	    reports: NoteSecretReport[];
+	
+	    static createFrom(source: any = {}) {
+	        return new NoteScanResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.scanned = source["scanned"];
+	        this.reports = this.convertValues(source["reports"], NoteSecretReport);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class OpenSSLCommand {
+	    title: string;
+	    description: string;
+	    command: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new OpenSSLCommand(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.title = source["title"];
+	        this.description = source["description"];
+	        this.command = source["command"];
+	    }
+	}
+	export class OpenSSLCommands {
+	    hostname: string;
+	    fingerprint_sha256: string;
+	    files: string[];
+	    commands: OpenSSLCommand[];
+	    notes?: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new OpenSSLCommands(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.fingerprint_sha256 = source["fingerprint_sha256"];
+	        this.files = source["files"];
+	        this.commands = this.convertValues(source["commands"], OpenSSLCommand);
+	        this.notes = source["notes"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class OpenedFile {
+	    path: string;
+	    kind: string;
+	    certificate_pem?: string;
+	    chain_pem?: string;
+	    private_key_pem?: string;
+	    csr_pem?: string;
+	    hostname?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new OpenedFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.kind = source["kind"];
+	        this.certificate_pem = source["certificate_pem"];
+	        this.chain_pem = source["chain_pem"];
+	        this.private_key_pem = source["private_key_pem"];
+	        this.csr_pem = source["csr_pem"];
+	        this.hostname = source["hostname"];
+	    }
+	}
+	
+	export class PinningConfig {
+	    format: string;
+	    filename: string;
+	    config: string;
+	    certificates: CertificatePins[];
+	    warnings: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new PinningConfig(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.format = source["format"];
+	        this.filename = source["filename"];
+	        this.config = source["config"];
+	        this.certificates = this.convertValues(source["certificates"], CertificatePins);
+	        this.warnings = source["warnings"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class Profile {
+	    name: string;
+	    path: string;
+	    active: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new Profile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.path = source["path"];
+	        this.active = source["active"];
+	    }
+	}
+	
+	export class QuickSearchResult {
+	    hostname: string;
+	    display_hostname: string;
+	    status: string;
+	    match: string;
+	    matched_san?: string;
+	    score: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new QuickSearchResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.display_hostname = source["display_hostname"];
+	        this.status = source["status"];
+	        this.match = source["match"];
+	        this.matched_san = source["matched_san"];
+	        this.score = source["score"];
+	    }
+	}
+	
+	export class RenewalChecklistItem {
+	    step: string;
+	    done: boolean;
+	    completed_at?: number;
+	    actor?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new RenewalChecklistItem(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.step = source["step"];
+	        this.done = source["done"];
+	        this.completed_at = source["completed_at"];
+	        this.actor = source["actor"];
+	    }
+	}
+	export class RenewalChecklist {
+	    hostname: string;
+	    items: RenewalChecklistItem[];
+	    completed: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new RenewalChecklist(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.items = this.convertValues(source["items"], RenewalChecklistItem);
+	        this.completed = source["completed"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class RestoreReport {
+	    schema_version: number;
+	    certificates: number;
+	    replaced_certificates: number;
+	    dry_run: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new RestoreReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.schema_version = source["schema_version"];
+	        this.certificates = source["certificates"];
+	        this.replaced_certificates = source["replaced_certificates"];
+	        this.dry_run = source["dry_run"];
+	    }
+	}
+	
+	
+	
+	export class ServerConfigFile {
+	    path: string;
+	    source: string;
+	    private_key: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new ServerConfigFile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.path = source["path"];
+	        this.source = source["source"];
+	        this.private_key = source["private_key"];
+	    }
+	}
+	export class ServerConfigSnippet {
+	    hostname: string;
+	    server_type: string;
+	    config: string;
+	    files: ServerConfigFile[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ServerConfigSnippet(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hostname = source["hostname"];
+	        this.server_type = source["server_type"];
+	        this.config = source["config"];
+	        this.files = this.convertValues(source["files"], ServerConfigFile);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SessionActivityEntry {
+	    operation: string;
+	    hostname?: string;
+	    timestamp: number;
+	    success: boolean;
+	    error?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionActivityEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.operation = source["operation"];
+	        this.hostname = source["hostname"];
+	        this.timestamp = source["timestamp"];
+	        this.success = source["success"];
+	        this.error = source["error"];
+	    }
+	}
+	export class SessionActivity {
+	    started_at: number;
+	    entries: SessionActivityEntry[];
+	    truncated: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionActivity(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.started_at = source["started_at"];
+	        this.entries = this.convertValues(source["entries"], SessionActivityEntry);
+	        this.truncated = source["truncated"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	
+	export class SessionState {
+	    configured: boolean;
+	    unlocked: boolean;
+	    waiting_for_encryption_key: boolean;
+	    migration_needed: boolean;
+	    limited_mode: boolean;
+	    profile: string;
+	    version: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SessionState(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.configured = source["configured"];
+	        this.unlocked = source["unlocked"];
+	        this.waiting_for_encryption_key = source["waiting_for_encryption_key"];
+	        this.migration_needed = source["migration_needed"];
+	        this.limited_mode = source["limited_mode"];
+	        this.profile = source["profile"];
+	        this.version = source["version"];
+	    }
 	}
-	export class ChainCertificateInfo {
-	    subject_cn: string;
-	    subject_o: string;
-	    issuer_cn: string;
-	    issuer_o: string;
-	    not_before_timestamp: number;
-	    not_after_timestamp: number;
-	    serial_number: string;
-	    cert_type: string;
-	    depth: number;
-	    pem?: string;
+	export class SetupDefaults {
+	    validity_period_days: number;
+	    default_key_size: number;
+	    default_country: string;
+	    default_organization: string;
+	    default_organizational_unit?: string;
+	    default_city: string;
+	    default_state: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new ChainCertificateInfo(source);
+	        return new SetupDefaults(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.subject_cn = source["subject_cn"];
-	        this.subject_o = source["subject_o"];
-	        this.issuer_cn = source["issuer_cn"];
-	        this.issuer_o = source["issuer_o"];
-	        this.not_before_timestamp = source["not_before_timestamp"];
-	        this.not_after_timestamp = source["not_after_timestamp"];
-	        this.serial_number = source["serial_number"];
-	        this.cert_type = source["cert_type"];
-	        this.depth = source["depth"];
-	        this.pem = source["pem"];
+	        this.validity_period_days = source["validity_period_days"];
+	        this.default_key_size = source["default_key_size"];
+	        this.default_country = source["default_country"];
+	        this.default_organization = source["default_organization"];
+	        this.default_organizational_unit = source["default_organizational_unit"];
+	        this.default_city = source["default_city"];
+	        this.default_state = source["default_state"];
 	    }
 	}
-	export class Config {
-	    id: number;
+	export class SetupRequest {
 	    owner_email: string;
 	    ca_name: string;
 	    hostname_suffix: string;
@@ -355,17 +2886,13 @@ export namespace models {
 	    default_state: string;
 	    default_country: string;
 	    default_key_size: number;
-	    is_configured: number;
-	    created_at: number;
-	    last_modified: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new Config(source);
+	        return new SetupRequest(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
 	        this.owner_email = source["owner_email"];
 	        this.ca_name = source["ca_name"];
 	        this.hostname_suffix = source["hostname_suffix"];
@@ -376,180 +2903,258 @@ export namespace models {
 	        this.default_state = source["default_state"];
 	        this.default_country = source["default_country"];
 	        this.default_key_size = source["default_key_size"];
-	        this.is_configured = source["is_configured"];
-	        this.created_at = source["created_at"];
-	        this.last_modified = source["last_modified"];
 	    }
 	}
-	export class ExportOptions {
-	    certificate: boolean;
-	    chain: boolean;
-	    private_key: boolean;
-	    csr: boolean;
-	    pending_key: boolean;
+	export class SetupProgress {
+	    step: string;
+	    draft: SetupRequest;
+	    updated_at?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new ExportOptions(source);
+	        return new SetupProgress(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.certificate = source["certificate"];
-	        this.chain = source["chain"];
-	        this.private_key = source["private_key"];
-	        this.csr = source["csr"];
-	        this.pending_key = source["pending_key"];
+	        this.step = source["step"];
+	        this.draft = this.convertValues(source["draft"], SetupRequest);
+	        this.updated_at = source["updated_at"];
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class HistoryEntry {
-	    id: number;
+	
+	export class ShareBundleInfo {
 	    hostname: string;
-	    event_type: string;
-	    message: string;
+	    has_private_key: boolean;
+	    has_chain: boolean;
+	    note?: string;
 	    created_at: number;
+	    expires_at: number;
+	    app_version: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new HistoryEntry(source);
+	        return new ShareBundleInfo(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.id = source["id"];
 	        this.hostname = source["hostname"];
-	        this.event_type = source["event_type"];
-	        this.message = source["message"];
+	        this.has_private_key = source["has_private_key"];
+	        this.has_chain = source["has_chain"];
+	        this.note = source["note"];
 	        this.created_at = source["created_at"];
+	        this.expires_at = source["expires_at"];
+	        this.app_version = source["app_version"];
 	    }
 	}
-	export class ImportRequest {
-	    certificate_pem: string;
-	    private_key_pem: string;
-	    cert_chain_pem?: string;
-	    note?: string;
+	
+	export class StatusPreviewEntry {
+	    hostname: string;
+	    current_status: string;
+	    status: string;
+	    changed: boolean;
+	    expires_at?: number;
+	    days_until_expiration: number;
+	    has_pending_csr: boolean;
+	    needs_renewal: boolean;
 	
 	    static createFrom(source: any = {}) {
-	        return new ImportRequest(source);
+	        return new StatusPreviewEntry(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.certificate_pem = source["certificate_pem"];
-	        this.private_key_pem = source["private_key_pem"];
-	        this.cert_chain_pem = source["cert_chain_pem"];
-	        this.note = source["note"];
+	        this.hostname = source["hostname"];
+	        this.current_status = source["current_status"];
+	        this.status = source["status"];
+	        this.changed = source["changed"];
+	        this.expires_at = source["expires_at"];
+	        this.days_until_expiration = source["days_until_expiration"];
+	        this.has_pending_csr = source["has_pending_csr"];
+	        this.needs_renewal = source["needs_renewal"];
 	    }
 	}
-	export class KeyValidationResult {
-	    valid: boolean;
-	    failed_hostnames?: string[];
+	export class StatusPreview {
+	    at: number;
+	    active: number;
+	    expiring: number;
+	    expired: number;
+	    pending: number;
+	    needs_renewal: number;
+	    certificates: StatusPreviewEntry[];
 	
 	    static createFrom(source: any = {}) {
-	        return new KeyValidationResult(source);
+	        return new StatusPreview(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.valid = source["valid"];
-	        this.failed_hostnames = source["failed_hostnames"];
+	        this.at = source["at"];
+	        this.active = source["active"];
+	        this.expiring = source["expiring"];
+	        this.expired = source["expired"];
+	        this.pending = source["pending"];
+	        this.needs_renewal = source["needs_renewal"];
+	        this.certificates = this.convertValues(source["certificates"], StatusPreviewEntry);
 	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
 	}
-	export class LocalBackupInfo {
-	    filename: string;
-	    type: string;
-	    timestamp: number;
-	    size: number;
-	    certificate_count: number;
-	    ca_name?: string;
+	
+	export class SubjectPreset {
+	    id: number;
+	    name: string;
+	    organization: string;
+	    organizational_unit?: string;
+	    city: string;
+	    state: string;
+	    country: string;
+	    created_at: number;
+	    last_modified: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new LocalBackupInfo(source);
+	        return new SubjectPreset(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.filename = source["filename"];
-	        this.type = source["type"];
-	        this.timestamp = source["timestamp"];
-	        this.size = source["size"];
-	        this.certificate_count = source["certificate_count"];
-	        this.ca_name = source["ca_name"];
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.organization = source["organization"];
+	        this.organizational_unit = source["organizational_unit"];
+	        this.city = source["city"];
+	        this.state = source["state"];
+	        this.country = source["country"];
+	        this.created_at = source["created_at"];
+	        this.last_modified = source["last_modified"];
 	    }
 	}
-	
-	export class SecurityKeyInfo {
+	export class SyncAgent {
 	    id: number;
-	    method: string;
-	    label: string;
+	    name: string;
+	    hostnames: string[];
+	    cert_fingerprint: string;
+	    expires_at: number;
 	    created_at: number;
-	    last_used_at?: number;
+	    revoked_at?: number;
+	    last_seen_at?: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new SecurityKeyInfo(source);
+	        return new SyncAgent(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.id = source["id"];
-	        this.method = source["method"];
-	        this.label = source["label"];
+	        this.name = source["name"];
+	        this.hostnames = source["hostnames"];
+	        this.cert_fingerprint = source["cert_fingerprint"];
+	        this.expires_at = source["expires_at"];
 	        this.created_at = source["created_at"];
-	        this.last_used_at = source["last_used_at"];
+	        this.revoked_at = source["revoked_at"];
+	        this.last_seen_at = source["last_seen_at"];
 	    }
 	}
-	export class SetupDefaults {
-	    validity_period_days: number;
-	    default_key_size: number;
-	    default_country: string;
-	    default_organization: string;
-	    default_organizational_unit?: string;
-	    default_city: string;
-	    default_state: string;
+	export class SyncAgentEnrollment {
+	    agent: SyncAgent;
+	    certificate_pem: string;
+	    private_key_pem: string;
+	    ca_certificate_pem: string;
 	
 	    static createFrom(source: any = {}) {
-	        return new SetupDefaults(source);
+	        return new SyncAgentEnrollment(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.validity_period_days = source["validity_period_days"];
-	        this.default_key_size = source["default_key_size"];
-	        this.default_country = source["default_country"];
-	        this.default_organization = source["default_organization"];
-	        this.default_organizational_unit = source["default_organizational_unit"];
-	        this.default_city = source["default_city"];
-	        this.default_state = source["default_state"];
+	        this.agent = this.convertValues(source["agent"], SyncAgent);
+	        this.certificate_pem = source["certificate_pem"];
+	        this.private_key_pem = source["private_key_pem"];
+	        this.ca_certificate_pem = source["ca_certificate_pem"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SyncServerStatus {
+	    running: boolean;
+	    listen_address: string;
+	    ca_certificate_pem?: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new SyncServerStatus(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.running = source["running"];
+	        this.listen_address = source["listen_address"];
+	        this.ca_certificate_pem = source["ca_certificate_pem"];
 	    }
 	}
-	export class SetupRequest {
-	    owner_email: string;
-	    ca_name: string;
-	    hostname_suffix: string;
-	    validity_period_days: number;
-	    default_organization: string;
-	    default_organizational_unit?: string;
-	    default_city: string;
-	    default_state: string;
-	    default_country: string;
-	    default_key_size: number;
+	export class TagCount {
+	    tag: string;
+	    certificates: number;
 	
 	    static createFrom(source: any = {}) {
-	        return new SetupRequest(source);
+	        return new TagCount(source);
 	    }
 	
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.owner_email = source["owner_email"];
-	        this.ca_name = source["ca_name"];
-	        this.hostname_suffix = source["hostname_suffix"];
-	        this.validity_period_days = source["validity_period_days"];
-	        this.default_organization = source["default_organization"];
-	        this.default_organizational_unit = source["default_organizational_unit"];
-	        this.default_city = source["default_city"];
-	        this.default_state = source["default_state"];
-	        this.default_country = source["default_country"];
-	        this.default_key_size = source["default_key_size"];
+	        this.tag = source["tag"];
+	        this.certificates = source["certificates"];
 	    }
 	}
+	
 	export class UpdateConfigRequest {
 	    owner_email: string;
 	    ca_name: string;
@@ -561,6 +3166,28 @@ export namespace models {
 	    default_state: string;
 	    default_country: string;
 	    default_key_size: number;
+	    expiring_threshold_days: number;
+	    clock_check_url: string;
+	    air_gapped: boolean;
+	    backup_freshness_max_writes: number;
+	    backup_freshness_block: boolean;
+	    fips_mode: boolean;
+	    db_size_warn_mb: number;
+	    log_max_size_mb: number;
+	    log_max_files: number;
+	    log_max_age_days: number;
+	    log_compress: boolean;
+	    minimize_to_tray: boolean;
+	    run_in_background: boolean;
+	    expiry_notifications: boolean;
+	    aia_cache_ttl_minutes: number;
+	    download_line_endings: string;
+	    download_text_header: boolean;
+	    download_format: string;
+	    key_pool_size: number;
+	    kdf_profile: string;
+	    ticket_pattern: string;
+	    trash_retention_days: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new UpdateConfigRequest(source);
@@ -578,6 +3205,28 @@ export namespace models {
 	        this.default_state = source["default_state"];
 	        this.default_country = source["default_country"];
 	        this.default_key_size = source["default_key_size"];
+	        this.expiring_threshold_days = source["expiring_threshold_days"];
+	        this.clock_check_url = source["clock_check_url"];
+	        this.air_gapped = source["air_gapped"];
+	        this.backup_freshness_max_writes = source["backup_freshness_max_writes"];
+	        this.backup_freshness_block = source["backup_freshness_block"];
+	        this.fips_mode = source["fips_mode"];
+	        this.db_size_warn_mb = source["db_size_warn_mb"];
+	        this.log_max_size_mb = source["log_max_size_mb"];
+	        this.log_max_files = source["log_max_files"];
+	        this.log_max_age_days = source["log_max_age_days"];
+	        this.log_compress = source["log_compress"];
+	        this.minimize_to_tray = source["minimize_to_tray"];
+	        this.run_in_background = source["run_in_background"];
+	        this.expiry_notifications = source["expiry_notifications"];
+	        this.aia_cache_ttl_minutes = source["aia_cache_ttl_minutes"];
+	        this.download_line_endings = source["download_line_endings"];
+	        this.download_text_header = source["download_text_header"];
+	        this.download_format = source["download_format"];
+	        this.key_pool_size = source["key_pool_size"];
+	        this.kdf_profile = source["kdf_profile"];
+	        this.ticket_pattern = source["ticket_pattern"];
+	        this.trash_retention_days = source["trash_retention_days"];
 	    }
 	}
 	export class UpdateHistoryEntry {
@@ -0,0 +1,229 @@
+// Package aeszip writes (and reads back) password-protected ZIP archives using WinZip's AES-256
+// encryption (AE-2), which 7-Zip, WinZip and most archive managers open. Entries
+// are deflated, then encrypted with AES-256-CTR and authenticated with
+// HMAC-SHA1; keys come from PBKDF2-HMAC-SHA1 over the password and a random
+// per-entry salt, as the format requires.
+package aeszip
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"paddockcontrol-desktop/internal/crypto"
+)
+
+const (
+	methodAES        = 99     // Compression method marking an AES-encrypted entry
+	extraIDAES       = 0x9901 // Extra field holding the AES parameters
+	vendorVersion    = 2      // AE-2: no CRC, the HMAC authenticates the data
+	strengthAES256   = 3
+	saltLength       = 16 // For AES-256
+	keyLength        = 32
+	verifierLength   = 2
+	authCodeLength   = 10
+	pbkdf2Iterations = 1000
+	flagEncrypted    = 0x1
+)
+
+// Writer writes an encrypted ZIP archive. Entries are buffered in memory until
+// the next Create or Close, so it suits archives of small files.
+type Writer struct {
+	zw       *zip.Writer
+	password string
+	modified time.Time
+	pending  *entry
+}
+
+type entry struct {
+	name string
+	mode os.FileMode
+	data bytes.Buffer
+}
+
+// NewWriter returns a Writer encrypting every entry with password
+func NewWriter(w io.Writer, password string) *Writer {
+	return &Writer{zw: zip.NewWriter(w), password: password, modified: time.Now()}
+}
+
+// Create adds an entry; its content is written to the returned writer
+func (w *Writer) Create(name string, mode os.FileMode) (io.Writer, error) {
+	if err := w.flush(); err != nil {
+		return nil, err
+	}
+	w.pending = &entry{name: name, mode: mode}
+	return &w.pending.data, nil
+}
+
+// Close writes the last entry and the central directory. It does not close the
+// underlying writer.
+func (w *Writer) Close() error {
+	if err := w.flush(); err != nil {
+		return err
+	}
+	return w.zw.Close()
+}
+
+// flush compresses, encrypts and writes the pending entry
+func (w *Writer) flush() error {
+	e := w.pending
+	if e == nil {
+		return nil
+	}
+	w.pending = nil
+	defer crypto.Zero(e.data.Bytes())
+
+	var compressed bytes.Buffer
+	fw, err := flate.NewWriter(&compressed, flate.DefaultCompression)
+	if err != nil {
+		return fmt.Errorf("failed to compress %s: %w", e.name, err)
+	}
+	if _, err := fw.Write(e.data.Bytes()); err != nil {
+		return fmt.Errorf("failed to compress %s: %w", e.name, err)
+	}
+	if err := fw.Close(); err != nil {
+		return fmt.Errorf("failed to compress %s: %w", e.name, err)
+	}
+	defer crypto.Zero(compressed.Bytes())
+
+	sealed, err := encrypt(compressed.Bytes(), w.password)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt %s: %w", e.name, err)
+	}
+
+	extra := make([]byte, 11)
+	binary.LittleEndian.PutUint16(extra[0:], extraIDAES)
+	binary.LittleEndian.PutUint16(extra[2:], 7)
+	binary.LittleEndian.PutUint16(extra[4:], vendorVersion)
+	copy(extra[6:], "AE")
+	extra[8] = strengthAES256
+	binary.LittleEndian.PutUint16(extra[9:], zip.Deflate)
+
+	header := &zip.FileHeader{
+		Name:               e.name,
+		Method:             methodAES,
+		Flags:              flagEncrypted,
+		Modified:           w.modified,
+		Extra:              extra,
+		CompressedSize64:   uint64(len(sealed)),
+		UncompressedSize64: uint64(e.data.Len()),
+	}
+	header.SetMode(e.mode)
+	out, err := w.zw.CreateRaw(header)
+	if err != nil {
+		return fmt.Errorf("failed to add %s: %w", e.name, err)
+	}
+	if _, err := out.Write(sealed); err != nil {
+		return fmt.Errorf("failed to write %s: %w", e.name, err)
+	}
+	return nil
+}
+
+// ReadFile decrypts, authenticates and inflates an entry written by Writer
+func ReadFile(f *zip.File, password string) ([]byte, error) {
+	if f.Method != methodAES || f.Flags&flagEncrypted == 0 {
+		return nil, fmt.Errorf("%s is not AES-encrypted", f.Name)
+	}
+	extra := f.Extra
+	if len(extra) != 11 || binary.LittleEndian.Uint16(extra) != extraIDAES || string(extra[6:8]) != "AE" ||
+		extra[8] != strengthAES256 || binary.LittleEndian.Uint16(extra[9:]) != zip.Deflate {
+		return nil, fmt.Errorf("%s has unsupported AES parameters", f.Name)
+	}
+
+	r, err := f.OpenRaw()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < saltLength+verifierLength+authCodeLength {
+		return nil, fmt.Errorf("%s is truncated", f.Name)
+	}
+	salt := sealed[:saltLength]
+	ciphertext := sealed[saltLength+verifierLength : len(sealed)-authCodeLength]
+	aesKey, macKey, verifier, err := deriveKeys(password, salt)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(verifier, sealed[saltLength:saltLength+verifierLength]) {
+		return nil, fmt.Errorf("wrong password for %s", f.Name)
+	}
+	mac := hmac.New(sha1.New, macKey)
+	mac.Write(ciphertext)
+	if !hmac.Equal(mac.Sum(nil)[:authCodeLength], sealed[len(sealed)-authCodeLength:]) {
+		return nil, fmt.Errorf("%s failed authentication: corrupted or tampered with", f.Name)
+	}
+
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, err
+	}
+	compressed := bytes.Clone(ciphertext)
+	xorKeyStream(block, compressed)
+	return io.ReadAll(flate.NewReader(bytes.NewReader(compressed)))
+}
+
+// encrypt returns salt || password verifier || AES-CTR(data) || authentication
+// code, the AE-2 layout of an entry's data
+func encrypt(data []byte, password string) ([]byte, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+	aesKey, macKey, verifier, err := deriveKeys(password, salt)
+	if err != nil {
+		return nil, err
+	}
+	defer crypto.Zero(aesKey)
+	defer crypto.Zero(macKey)
+
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, saltLength+verifierLength+len(data)+authCodeLength)
+	out = append(out, salt...)
+	out = append(out, verifier...)
+	start := len(out)
+	out = append(out, data...)
+	xorKeyStream(block, out[start:])
+
+	mac := hmac.New(sha1.New, macKey)
+	mac.Write(out[start:])
+	return append(out, mac.Sum(nil)[:authCodeLength]...), nil
+}
+
+// deriveKeys derives the AES key, HMAC key and password verifier of an entry
+func deriveKeys(password string, salt []byte) (aesKey, macKey, verifier []byte, err error) {
+	derived, err := pbkdf2.Key(sha1.New, password, salt, pbkdf2Iterations, 2*keyLength+verifierLength)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to derive keys: %w", err)
+	}
+	return derived[:keyLength], derived[keyLength : 2*keyLength], derived[2*keyLength:], nil
+}
+
+// xorKeyStream applies AES-CTR in place with WinZip's counter: a little-endian
+// block number starting at 1
+func xorKeyStream(block cipher.Block, data []byte) {
+	var counter, stream [aes.BlockSize]byte
+	for i, n := 0, uint64(1); i < len(data); i, n = i+aes.BlockSize, n+1 {
+		binary.LittleEndian.PutUint64(counter[:], n)
+		block.Encrypt(stream[:], counter[:])
+		for j := 0; j < aes.BlockSize && i+j < len(data); j++ {
+			data[i+j] ^= stream[j]
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Package agentsync implements the protocol companion agents use to pull the
+// certificates and private keys assigned to them, so a renewed certificate
+// reaches its servers without anyone copying files around.
+//
+// The vault runs a small HTTPS server that only speaks TLS 1.3 and requires a
+// client certificate signed by its sync CA. An agent is identified by the
+// SHA-256 fingerprint of that certificate and can only read what is assigned
+// to it:
+//
+//	GET /v1/certificates             assigned certificates and their versions
+//	GET /v1/certificates/{hostname}  certificate, chain and private key (PEM)
+//
+// An agent polls the listing and fetches a bundle whenever the version of a
+// certificate (the SHA-256 of its PEM) changes, e.g. after a renewal was
+// activated. Responses are JSON. Errors are {"error": "..."} with 403 for an
+// unknown or revoked agent, 404 for a certificate that is not assigned or not
+// issued yet, and 503 while the vault is locked.
+package agentsync
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"paddockcontrol-desktop/internal/logger"
+)
+
+var (
+	// ErrUnknownAgent is returned for a client certificate that belongs to no
+	// enrolled agent, or to a revoked one
+	ErrUnknownAgent = errors.New("unknown or revoked agent")
+	// ErrNotAssigned is returned for a certificate the agent may not pull, or
+	// that has not been issued yet
+	ErrNotAssigned = errors.New("certificate not assigned to this agent")
+	// ErrLocked is returned while the vault is locked: private keys cannot be
+	// decrypted
+	ErrLocked = errors.New("vault is locked")
+)
+
+// Agent is an enrolled agent as seen by the server
+type Agent struct {
+	ID   int64
+	Name string
+}
+
+// CertificateInfo describes a certificate in the listing
+type CertificateInfo struct {
+	Hostname  string `json:"hostname"`
+	Version   string `json:"version"`
+	ExpiresAt int64  `json:"expires_at,omitempty"`
+}
+
+// Listing is the response of GET /v1/certificates
+type Listing struct {
+	Certificates []CertificateInfo `json:"certificates"`
+}
+
+// Bundle is the response of GET /v1/certificates/{hostname}
+type Bundle struct {
+	Hostname       string `json:"hostname"`
+	Version        string `json:"version"`
+	CertificatePEM string `json:"certificate_pem"`
+	ChainPEM       string `json:"chain_pem,omitempty"`
+	PrivateKeyPEM  string `json:"private_key_pem"`
+}
+
+// Source is where the server looks up agents and their certificates
+type Source interface {
+	// Agent returns the active agent holding the client certificate with the
+	// given fingerprint, or ErrUnknownAgent
+	Agent(ctx context.Context, fingerprint string) (Agent, error)
+	// List returns the issued certificates assigned to the agent
+	List(ctx context.Context, agent Agent) ([]CertificateInfo, error)
+	// Bundle returns an assigned certificate with its decrypted private key,
+	// or ErrNotAssigned / ErrLocked
+	Bundle(ctx context.Context, agent Agent, hostname string) (*Bundle, error)
+}
+
+// Version identifies the content of a certificate, so agents can tell when a
+// renewal replaced it
+func Version(certificatePEM string) string {
+	sum := sha256.Sum256([]byte(certificatePEM))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewHandler returns the HTTP handler of the protocol. It expects to be served
+// over TLS with verified client certificates (see Listen).
+func NewHandler(src Source) http.Handler {
+	h := &handler{src: src}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/certificates", h.list)
+	mux.HandleFunc("GET /v1/certificates/{hostname}", h.bundle)
+	return mux
+}
+
+type handler struct {
+	src Source
+}
+
+// agent identifies the caller from its verified client certificate
+func (h *handler) agent(w http.ResponseWriter, r *http.Request) (Agent, bool) {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		writeError(w, ErrUnknownAgent)
+		return Agent{}, false
+	}
+	agent, err := h.src.Agent(r.Context(), Fingerprint(r.TLS.PeerCertificates[0]))
+	if err != nil {
+		writeError(w, err)
+		return Agent{}, false
+	}
+	return agent, true
+}
+
+func (h *handler) list(w http.ResponseWriter, r *http.Request) {
+	agent, ok := h.agent(w, r)
+	if !ok {
+		return
+	}
+	certificates, err := h.src.List(r.Context(), agent)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if certificates == nil {
+		certificates = []CertificateInfo{}
+	}
+	writeJSON(w, http.StatusOK, Listing{Certificates: certificates})
+}
+
+func (h *handler) bundle(w http.ResponseWriter, r *http.Request) {
+	agent, ok := h.agent(w, r)
+	if !ok {
+		return
+	}
+	bundle, err := h.src.Bundle(r.Context(), agent, r.PathValue("hostname"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, bundle)
+}
+
+// writeError maps the protocol errors to their status; anything else is an
+// internal error whose details stay in the log
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	message := "internal error"
+	switch {
+	case errors.Is(err, ErrUnknownAgent):
+		status, message = http.StatusForbidden, ErrUnknownAgent.Error()
+	case errors.Is(err, ErrNotAssigned):
+		status, message = http.StatusNotFound, ErrNotAssigned.Error()
+	case errors.Is(err, ErrLocked):
+		status, message = http.StatusServiceUnavailable, ErrLocked.Error()
+	default:
+		logger.WithComponent("agentsync").Error("sync request failed", logger.Err(err))
+	}
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.WithComponent("agentsync").Debug("failed to write response", slog.Int("status", status), logger.Err(err))
+	}
+}
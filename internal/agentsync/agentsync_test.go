@@ -0,0 +1,151 @@
+package agentsync
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type fakeSource struct {
+	agents map[string]Agent // fingerprint → agent
+	locked bool
+}
+
+func (f *fakeSource) Agent(_ context.Context, fingerprint string) (Agent, error) {
+	agent, ok := f.agents[fingerprint]
+	if !ok {
+		return Agent{}, ErrUnknownAgent
+	}
+	return agent, nil
+}
+
+func (f *fakeSource) List(_ context.Context, agent Agent) ([]CertificateInfo, error) {
+	return []CertificateInfo{{Hostname: agent.Name + ".example.com", Version: Version("cert")}}, nil
+}
+
+func (f *fakeSource) Bundle(_ context.Context, agent Agent, hostname string) (*Bundle, error) {
+	if hostname != agent.Name+".example.com" {
+		return nil, ErrNotAssigned
+	}
+	if f.locked {
+		return nil, ErrLocked
+	}
+	return &Bundle{Hostname: hostname, Version: Version("cert"), CertificatePEM: "cert", PrivateKeyPEM: "key"}, nil
+}
+
+// startTestServer starts a server on localhost and returns it with a client
+// holding the certificate of an enrolled agent "web" and a client holding a
+// certificate of the same CA that belongs to no agent
+func startTestServer(t *testing.T, src *fakeSource) (*Server, *http.Client, *http.Client) {
+	t.Helper()
+	now := time.Now()
+	caPEM, caKeyPEM, err := NewCA(now)
+	if err != nil {
+		t.Fatalf("NewCA() error: %v", err)
+	}
+	ca, err := LoadCA(caPEM, caKeyPEM)
+	if err != nil {
+		t.Fatalf("LoadCA() error: %v", err)
+	}
+	serverCert, err := ca.IssueServer([]string{"127.0.0.1"}, now)
+	if err != nil {
+		t.Fatalf("IssueServer() error: %v", err)
+	}
+
+	client := func(name string) *http.Client {
+		issued, err := ca.IssueClient(name, now)
+		if err != nil {
+			t.Fatalf("IssueClient() error: %v", err)
+		}
+		pair, err := tls.X509KeyPair(issued.CertificatePEM, issued.PrivateKeyPEM)
+		if err != nil {
+			t.Fatalf("X509KeyPair() error: %v", err)
+		}
+		if name == "web" {
+			src.agents[issued.Fingerprint] = Agent{ID: 1, Name: name}
+		}
+		return &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+			RootCAs:      ca.Pool(),
+			Certificates: []tls.Certificate{pair},
+		}}}
+	}
+	agent, stranger := client("web"), client("stranger")
+
+	srv, err := Listen("127.0.0.1:0", serverCert, ca.Pool(), src)
+	if err != nil {
+		t.Fatalf("Listen() error: %v", err)
+	}
+	t.Cleanup(func() { srv.Close() })
+	return srv, agent, stranger
+}
+
+func TestServer_ServesAssignedCertificates(t *testing.T) {
+	src := &fakeSource{agents: make(map[string]Agent)}
+	srv, agent, _ := startTestServer(t, src)
+	base := "https://" + srv.Addr()
+
+	resp, err := agent.Get(base + "/v1/certificates")
+	if err != nil {
+		t.Fatalf("GET /v1/certificates error: %v", err)
+	}
+	var listing Listing
+	err = json.NewDecoder(resp.Body).Decode(&listing)
+	resp.Body.Close()
+	if err != nil || len(listing.Certificates) != 1 || listing.Certificates[0].Hostname != "web.example.com" {
+		t.Fatalf("listing = %+v (%v), want web.example.com", listing, err)
+	}
+
+	resp, err = agent.Get(base + "/v1/certificates/web.example.com")
+	if err != nil {
+		t.Fatalf("GET bundle error: %v", err)
+	}
+	var bundle Bundle
+	err = json.NewDecoder(resp.Body).Decode(&bundle)
+	resp.Body.Close()
+	if err != nil || bundle.PrivateKeyPEM != "key" {
+		t.Fatalf("bundle = %+v (%v), want the private key", bundle, err)
+	}
+}
+
+func TestServer_StatusCodes(t *testing.T) {
+	src := &fakeSource{agents: make(map[string]Agent)}
+	srv, agent, stranger := startTestServer(t, src)
+	base := "https://" + srv.Addr()
+
+	status := func(client *http.Client, path string) int {
+		t.Helper()
+		resp, err := client.Get(base + path)
+		if err != nil {
+			t.Fatalf("GET %s error: %v", path, err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	if got := status(stranger, "/v1/certificates"); got != http.StatusForbidden {
+		t.Errorf("unknown agent: status %d, want %d", got, http.StatusForbidden)
+	}
+	if got := status(agent, "/v1/certificates/other.example.com"); got != http.StatusNotFound {
+		t.Errorf("unassigned certificate: status %d, want %d", got, http.StatusNotFound)
+	}
+	src.locked = true
+	if got := status(agent, "/v1/certificates/web.example.com"); got != http.StatusServiceUnavailable {
+		t.Errorf("locked vault: status %d, want %d", got, http.StatusServiceUnavailable)
+	}
+}
+
+func TestServer_RequiresClientCertificate(t *testing.T) {
+	src := &fakeSource{agents: make(map[string]Agent)}
+	srv, agent, _ := startTestServer(t, src)
+
+	anonymous := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+		RootCAs: agent.Transport.(*http.Transport).TLSClientConfig.RootCAs,
+	}}}
+	if resp, err := anonymous.Get("https://" + srv.Addr() + "/v1/certificates"); err == nil {
+		resp.Body.Close()
+		t.Fatal("expected the handshake to fail without a client certificate")
+	}
+}
@@ -0,0 +1,170 @@
+package agentsync
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+
+	"paddockcontrol-desktop/internal/crypto"
+)
+
+// Validity of the certificates the sync CA issues. Agent certificates outlive
+// a few renewal cycles; the server certificate is issued again on every start.
+const (
+	caValidity     = 10 * 365 * 24 * time.Hour
+	clientValidity = 2 * 365 * 24 * time.Hour
+	serverValidity = 90 * 24 * time.Hour
+)
+
+// CA is the certificate authority that signs agent client certificates and the
+// sync server certificate. It only exists for this protocol: nothing else
+// trusts it.
+type CA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// Issued is a certificate issued by the CA with its private key
+type Issued struct {
+	CertificatePEM []byte
+	PrivateKeyPEM  []byte // Caller should crypto.Zero it once handed over
+	Fingerprint    string
+	NotAfter       time.Time
+}
+
+// NewCA creates a CA with an ECDSA P-256 key and returns its certificate and
+// private key as PEM
+func NewCA(now time.Time) (certPEM, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate CA key: %w", err)
+	}
+	template := &x509.Certificate{
+		Subject:               pkix.Name{CommonName: "PaddockControl agent sync CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(caValidity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	cert, err := sign(template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyPEM, err = crypto.PrivateKeyToPEM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	return crypto.CertificateToPEM(cert), keyPEM, nil
+}
+
+// LoadCA parses a CA created by NewCA
+func LoadCA(certPEM, keyPEM []byte) (*CA, error) {
+	cert, err := crypto.ParseCertificate(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sync CA certificate: %w", err)
+	}
+	signer, err := crypto.ParsePrivateKeyFromPEM(keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sync CA key: %w", err)
+	}
+	key, ok := signer.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("sync CA key is not an ECDSA key")
+	}
+	return &CA{cert: cert, key: key}, nil
+}
+
+// Pool returns a pool holding the CA certificate, to verify what it issued
+func (ca *CA) Pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+// IssueClient issues the client certificate of an agent
+func (ca *CA) IssueClient(name string, now time.Time) (*Issued, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate agent key: %w", err)
+	}
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: name},
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    now.Add(clientValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	cert, err := sign(template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := crypto.PrivateKeyToPEM(key)
+	if err != nil {
+		return nil, err
+	}
+	return &Issued{
+		CertificatePEM: crypto.CertificateToPEM(cert),
+		PrivateKeyPEM:  keyPEM,
+		Fingerprint:    Fingerprint(cert),
+		NotAfter:       cert.NotAfter,
+	}, nil
+}
+
+// IssueServer issues a server certificate valid for the given DNS names and IP
+// addresses, ready to be served
+func (ca *CA) IssueServer(names []string, now time.Time) (tls.Certificate, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to generate server key: %w", err)
+	}
+	template := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "PaddockControl agent sync"},
+		NotBefore:   now.Add(-time.Hour),
+		NotAfter:    now.Add(serverValidity),
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, name := range names {
+		if ip := net.ParseIP(name); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, name)
+		}
+	}
+	cert, err := sign(template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{cert.Raw}, PrivateKey: key, Leaf: cert}, nil
+}
+
+// Fingerprint returns the hex SHA-256 of a certificate, which identifies an
+// agent
+func Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+func sign(template, parent *x509.Certificate, pub *ecdsa.PublicKey, key *ecdsa.PrivateKey) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+	template.SerialNumber = serial
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, pub, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign certificate: %w", err)
+	}
+	return x509.ParseCertificate(der)
+}
@@ -0,0 +1,87 @@
+package agentsync
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"paddockcontrol-desktop/internal/logger"
+)
+
+// Server serves the protocol over mutual TLS
+type Server struct {
+	srv *http.Server
+	ln  net.Listener
+}
+
+// Listen starts serving on addr (host:port, port 0 picks a free one) with the
+// given server certificate. Only clients presenting a certificate signed by a
+// CA in clientCAs complete the handshake.
+func Listen(addr string, cert tls.Certificate, clientCAs *x509.CertPool, src Source) (*Server, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}
+	ln, err := tls.Listen("tcp", addr, tlsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	s := &Server{
+		ln: ln,
+		srv: &http.Server{
+			Handler:           NewHandler(src),
+			ReadHeaderTimeout: 10 * time.Second,
+			IdleTimeout:       time.Minute,
+			ErrorLog:          slog.NewLogLogger(logger.WithComponent("agentsync").Handler(), slog.LevelDebug),
+		},
+	}
+	go func() {
+		if err := s.srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.WithComponent("agentsync").Error("sync server stopped", logger.Err(err))
+		}
+	}()
+	return s, nil
+}
+
+// Addr returns the address the server listens on
+func (s *Server) Addr() string {
+	return s.ln.Addr().String()
+}
+
+// Close stops the server and drops open connections without waiting for
+// requests in progress
+func (s *Server) Close() error {
+	return s.srv.Close()
+}
+
+// ServerNames returns the names agents may use to reach a server listening on
+// addr: the host of addr, or for a wildcard address the machine's hostname,
+// localhost and the addresses of its network interfaces
+func ServerNames(addr string) []string {
+	host, _, err := net.SplitHostPort(addr)
+	if err == nil && host != "" && !net.ParseIP(host).IsUnspecified() {
+		return []string{host}
+	}
+
+	names := []string{"localhost"}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		names = append(names, hostname)
+	}
+	if addrs, err := net.InterfaceAddrs(); err == nil {
+		for _, a := range addrs {
+			if ipNet, ok := a.(*net.IPNet); ok && !ipNet.IP.IsLinkLocalUnicast() {
+				names = append(names, ipNet.IP.String())
+			}
+		}
+	}
+	return names
+}
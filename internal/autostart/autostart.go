@@ -0,0 +1,36 @@
+// Package autostart registers the application to start when the user logs
+// in: a Run registry value on Windows and an XDG autostart entry on Linux.
+package autostart
+
+import "errors"
+
+// Flag is passed to the executable started at login, so it starts hidden in
+// the tray instead of showing its window
+const Flag = "--autostart"
+
+// entryName names the registry value and the desktop entry
+const entryName = "PaddockControl"
+
+// ErrUnsupported is returned on platforms without an implementation (macOS
+// handles login items through the system settings)
+var ErrUnsupported = errors.New("start on login is not supported on this platform")
+
+// Supported reports whether start on login can be managed on this platform.
+func Supported() bool {
+	return supported
+}
+
+// Enable starts exePath with Flag at login, replacing any previous entry.
+func Enable(exePath string) error {
+	return enable(exePath)
+}
+
+// Disable removes the login entry. Removing a missing entry is not an error.
+func Disable() error {
+	return disable()
+}
+
+// Enabled reports whether the login entry exists.
+func Enabled() (bool, error) {
+	return enabled()
+}
@@ -0,0 +1,95 @@
+//go:build linux
+
+package autostart
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const supported = true
+
+// desktopFileName is the XDG autostart entry of the app
+const desktopFileName = "paddockcontrol.desktop"
+
+// desktopFile returns the autostart entry path: $XDG_CONFIG_HOME/autostart,
+// ~/.config/autostart by default.
+func desktopFile() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the config directory: %w", err)
+	}
+	return filepath.Join(configDir, "autostart", desktopFileName), nil
+}
+
+func enable(exePath string) error {
+	path, err := desktopFile()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create the autostart directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(desktopEntry(exePath)), 0o644); err != nil {
+		return fmt.Errorf("failed to register start on login: %w", err)
+	}
+	return nil
+}
+
+func disable() error {
+	path, err := desktopFile()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to unregister start on login: %w", err)
+	}
+	return nil
+}
+
+func enabled() (bool, error) {
+	path, err := desktopFile()
+	if err != nil {
+		return false, err
+	}
+	if _, err := os.Stat(path); err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to read start on login: %w", err)
+	}
+	return true, nil
+}
+
+// desktopEntry returns the autostart desktop entry starting exePath with Flag.
+func desktopEntry(exePath string) string {
+	var b strings.Builder
+	b.WriteString("[Desktop Entry]\n")
+	b.WriteString("Type=Application\n")
+	b.WriteString("Name=" + entryName + "\n")
+	b.WriteString("Comment=Certificate expiry monitoring in the system tray\n")
+	b.WriteString("Exec=" + quoteExec(exePath) + " " + Flag + "\n")
+	b.WriteString("Terminal=false\n")
+	b.WriteString("X-GNOME-Autostart-enabled=true\n")
+	return b.String()
+}
+
+// quoteExec quotes a path for the Exec key of a desktop entry: double quotes,
+// with ", `, $ and \ escaped by a backslash, and % doubled as field codes.
+func quoteExec(path string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range path {
+		switch r {
+		case '"', '`', '$', '\\':
+			b.WriteByte('\\')
+		case '%':
+			b.WriteByte('%')
+		}
+		b.WriteRune(r)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
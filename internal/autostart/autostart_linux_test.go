@@ -0,0 +1,51 @@
+//go:build linux
+
+package autostart
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEnableDisable_Linux(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	if on, err := Enabled(); err != nil || on {
+		t.Fatalf("Enabled() = %v, %v before Enable", on, err)
+	}
+	if err := Disable(); err != nil {
+		t.Fatalf("Disable() without an entry error = %v", err)
+	}
+
+	if err := Enable("/opt/Paddock Control/paddockcontrol"); err != nil {
+		t.Fatalf("Enable() error = %v", err)
+	}
+	if on, err := Enabled(); err != nil || !on {
+		t.Fatalf("Enabled() = %v, %v after Enable", on, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "autostart", desktopFileName))
+	if err != nil {
+		t.Fatalf("failed to read desktop entry: %v", err)
+	}
+	if !strings.Contains(string(data), "Exec=\"/opt/Paddock Control/paddockcontrol\" --autostart\n") {
+		t.Errorf("unexpected desktop entry:\n%s", data)
+	}
+
+	if err := Disable(); err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+	if on, _ := Enabled(); on {
+		t.Error("Enabled() should be false after Disable")
+	}
+}
+
+func TestQuoteExec(t *testing.T) {
+	got := quoteExec(`/home/a"b/$x%y\z`)
+	want := `"/home/a\"b/\$x%%y\\z"`
+	if got != want {
+		t.Errorf("quoteExec() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,17 @@
+//go:build !windows && !linux
+
+package autostart
+
+const supported = false
+
+func enable(exePath string) error {
+	return ErrUnsupported
+}
+
+func disable() error {
+	return ErrUnsupported
+}
+
+func enabled() (bool, error) {
+	return false, nil
+}
@@ -0,0 +1,64 @@
+//go:build windows
+
+package autostart
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+const supported = true
+
+// runKey lists the programs started at login of the current user
+const runKey = `Software\Microsoft\Windows\CurrentVersion\Run`
+
+func enable(exePath string) error {
+	key, _, err := registry.CreateKey(registry.CURRENT_USER, runKey, registry.SET_VALUE)
+	if err != nil {
+		return fmt.Errorf("failed to open the Run registry key: %w", err)
+	}
+	defer key.Close()
+
+	if err := key.SetStringValue(entryName, fmt.Sprintf(`"%s" %s`, exePath, Flag)); err != nil {
+		return fmt.Errorf("failed to register start on login: %w", err)
+	}
+	return nil
+}
+
+func disable() error {
+	key, err := registry.OpenKey(registry.CURRENT_USER, runKey, registry.SET_VALUE)
+	if errors.Is(err, registry.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open the Run registry key: %w", err)
+	}
+	defer key.Close()
+
+	if err := key.DeleteValue(entryName); err != nil && !errors.Is(err, registry.ErrNotExist) {
+		return fmt.Errorf("failed to unregister start on login: %w", err)
+	}
+	return nil
+}
+
+func enabled() (bool, error) {
+	key, err := registry.OpenKey(registry.CURRENT_USER, runKey, registry.QUERY_VALUE)
+	if errors.Is(err, registry.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to open the Run registry key: %w", err)
+	}
+	defer key.Close()
+
+	_, _, err = key.GetStringValue(entryName)
+	if errors.Is(err, registry.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read start on login: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,89 @@
+// Package clock abstracts the current time so that status computation, expiry
+// math and backup timestamps can be driven by tests and date simulation instead
+// of the wall clock.
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the current time.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+// System returns the wall clock.
+func System() Clock {
+	return systemClock{}
+}
+
+// Fake is a manually driven clock. It only moves when Set or Advance is called.
+// Safe for concurrent use.
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFake returns a fake clock stopped at now.
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now returns the fake clock's current time.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// Set moves the fake clock to now.
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now
+}
+
+// Advance moves the fake clock forward by d.
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
+
+// Offset is the wall clock shifted by an adjustable offset: time keeps flowing,
+// but it can jump forward to simulate days passing. Safe for concurrent use.
+type Offset struct {
+	mu     sync.Mutex
+	offset time.Duration
+}
+
+// NewOffset returns a clock that starts at the wall clock time.
+func NewOffset() *Offset {
+	return &Offset{}
+}
+
+// Now returns the wall clock time plus the offset.
+func (o *Offset) Now() time.Time {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return time.Now().Add(o.offset)
+}
+
+// Advance moves the clock forward by d (backward when d is negative).
+func (o *Offset) Advance(d time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.offset += d
+}
+
+// Offset returns how far the clock is from the wall clock.
+func (o *Offset) Offset() time.Duration {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.offset
+}
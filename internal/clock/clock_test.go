@@ -0,0 +1,52 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFake_SetAndAdvance(t *testing.T) {
+	start := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := NewFake(start)
+
+	if !f.Now().Equal(start) {
+		t.Fatalf("Now() = %v, want %v", f.Now(), start)
+	}
+
+	f.Advance(36 * time.Hour)
+	if want := start.Add(36 * time.Hour); !f.Now().Equal(want) {
+		t.Errorf("after Advance, Now() = %v, want %v", f.Now(), want)
+	}
+
+	later := time.Date(2031, 6, 1, 12, 0, 0, 0, time.UTC)
+	f.Set(later)
+	if !f.Now().Equal(later) {
+		t.Errorf("after Set, Now() = %v, want %v", f.Now(), later)
+	}
+}
+
+func TestSystem_FollowsWallClock(t *testing.T) {
+	before := time.Now()
+	now := System().Now()
+	after := time.Now()
+
+	if now.Before(before) || now.After(after) {
+		t.Errorf("System().Now() = %v, want between %v and %v", now, before, after)
+	}
+}
+
+func TestOffset_Advance(t *testing.T) {
+	o := NewOffset()
+	if d := time.Since(o.Now()); d < 0 || d > time.Second {
+		t.Fatalf("Now() = %v, want the wall clock", o.Now())
+	}
+
+	o.Advance(30 * 24 * time.Hour)
+	o.Advance(-24 * time.Hour)
+	if got, want := o.Offset(), 29*24*time.Hour; got != want {
+		t.Errorf("Offset() = %v, want %v", got, want)
+	}
+	if d := o.Now().Sub(time.Now()) - 29*24*time.Hour; d < -time.Second || d > time.Second {
+		t.Errorf("Now() is %v off the shifted wall clock", d)
+	}
+}
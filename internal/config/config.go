@@ -3,10 +3,14 @@ package config
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+
 	"paddockcontrol-desktop/internal/db"
 	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/hostnames"
 	"paddockcontrol-desktop/internal/logger"
 	"paddockcontrol-desktop/internal/models"
 )
@@ -56,6 +60,28 @@ func (s *Service) SaveConfig(ctx context.Context, cfg *sqlc.Config) error {
 		DefaultCountry:            cfg.DefaultCountry,
 		DefaultKeySize:            cfg.DefaultKeySize,
 		ValidityPeriodDays:        cfg.ValidityPeriodDays,
+		ExpiringThresholdDays:     cfg.ExpiringThresholdDays,
+		ClockCheckUrl:             cfg.ClockCheckUrl,
+		AirGapped:                 cfg.AirGapped,
+		BackupFreshnessMaxWrites:  cfg.BackupFreshnessMaxWrites,
+		BackupFreshnessBlock:      cfg.BackupFreshnessBlock,
+		FipsMode:                  cfg.FipsMode,
+		DbSizeWarnMb:              cfg.DbSizeWarnMb,
+		LogMaxSizeMb:              cfg.LogMaxSizeMb,
+		LogMaxFiles:               cfg.LogMaxFiles,
+		LogMaxAgeDays:             cfg.LogMaxAgeDays,
+		LogCompress:               cfg.LogCompress,
+		MinimizeToTray:            cfg.MinimizeToTray,
+		RunInBackground:           cfg.RunInBackground,
+		ExpiryNotifications:       cfg.ExpiryNotifications,
+		AiaCacheTtlMinutes:        cfg.AiaCacheTtlMinutes,
+		DownloadLineEndings:       cfg.DownloadLineEndings,
+		DownloadTextHeader:        cfg.DownloadTextHeader,
+		DownloadFormat:            cfg.DownloadFormat,
+		KeyPoolSize:               cfg.KeyPoolSize,
+		KdfProfile:                cfg.KdfProfile,
+		TicketPattern:             cfg.TicketPattern,
+		TrashRetentionDays:        cfg.TrashRetentionDays,
 	})
 
 	if err != nil {
@@ -115,10 +141,32 @@ func (s *Service) UpdateConfig(ctx context.Context, req *models.UpdateConfigRequ
 			String: req.DefaultOrganizationalUnit,
 			Valid:  req.DefaultOrganizationalUnit != "",
 		},
-		DefaultCity:    req.DefaultCity,
-		DefaultState:   req.DefaultState,
-		DefaultCountry: req.DefaultCountry,
-		DefaultKeySize: int64(req.DefaultKeySize),
+		DefaultCity:              req.DefaultCity,
+		DefaultState:             req.DefaultState,
+		DefaultCountry:           req.DefaultCountry,
+		DefaultKeySize:           int64(req.DefaultKeySize),
+		ExpiringThresholdDays:    int64(req.ExpiringThresholdDays),
+		ClockCheckUrl:            strings.TrimSpace(req.ClockCheckURL),
+		AirGapped:                boolToInt64(req.AirGapped),
+		BackupFreshnessMaxWrites: int64(req.BackupFreshnessMaxWrites),
+		BackupFreshnessBlock:     boolToInt64(req.BackupFreshnessBlock),
+		FipsMode:                 boolToInt64(req.FIPSMode),
+		DbSizeWarnMb:             int64(req.DBSizeWarnMB),
+		LogMaxSizeMb:             int64(req.LogMaxSizeMB),
+		LogMaxFiles:              int64(req.LogMaxFiles),
+		LogMaxAgeDays:            int64(req.LogMaxAgeDays),
+		LogCompress:              boolToInt64(req.LogCompress),
+		MinimizeToTray:           boolToInt64(req.MinimizeToTray),
+		RunInBackground:          boolToInt64(req.RunInBackground),
+		ExpiryNotifications:      boolToInt64(req.ExpiryNotifications),
+		AiaCacheTtlMinutes:       int64(req.AIACacheTTLMinutes),
+		DownloadLineEndings:      req.DownloadLineEndings,
+		DownloadTextHeader:       boolToInt64(req.DownloadTextHeader),
+		DownloadFormat:           req.DownloadFormat,
+		KeyPoolSize:              int64(req.KeyPoolSize),
+		KdfProfile:               req.KDFProfile,
+		TicketPattern:            strings.TrimSpace(req.TicketPattern),
+		TrashRetentionDays:       int64(req.TrashRetentionDays),
 	}
 
 	// Update configuration
@@ -141,6 +189,42 @@ func (s *Service) UpdateConfig(ctx context.Context, req *models.UpdateConfigRequ
 	return convertSqlcToModelsConfig(&cfg), nil
 }
 
+// SetHostnameSuffix changes only the hostname suffix, e.g. to accept the one
+// inferred after restoring or importing certificates
+func (s *Service) SetHostnameSuffix(ctx context.Context, suffix string) error {
+	suffix = strings.TrimSpace(suffix)
+	if err := validateHostnameSuffix(suffix); err != nil {
+		return fmt.Errorf("validation failed: %w", err)
+	}
+
+	if err := s.db.Queries().UpdateHostnameSuffix(ctx, suffix); err != nil {
+		s.log.Error("failed to update hostname suffix", logger.Err(err))
+		return fmt.Errorf("failed to update hostname suffix: %w", err)
+	}
+
+	s.log.Info("hostname suffix updated", slog.String("hostname_suffix", suffix))
+	return nil
+}
+
+// SuggestHostnameSuffix returns the suffix most stored hostnames share when the
+// configured one is blank or fits fewer of them, and "" otherwise
+func (s *Service) SuggestHostnameSuffix(ctx context.Context) (string, error) {
+	cfg, err := s.db.Queries().GetConfig(ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("failed to get config: %w", err)
+	}
+	certs, err := s.db.Queries().ListAllCertificates(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	names := make([]string, 0, len(certs))
+	for _, cert := range certs {
+		names = append(names, cert.Hostname)
+	}
+	return hostnames.SuggestSuffix(cfg.HostnameSuffix, names), nil
+}
+
 // GetDefaults returns default values for setup
 func (s *Service) GetDefaults() *ConfigDefaults {
 	return &ConfigDefaults{
@@ -171,8 +255,38 @@ func convertSqlcToModelsConfig(cfg *sqlc.Config) *models.Config {
 		DefaultState:              cfg.DefaultState,
 		DefaultCountry:            cfg.DefaultCountry,
 		DefaultKeySize:            int(cfg.DefaultKeySize),
+		ExpiringThresholdDays:     int(cfg.ExpiringThresholdDays),
+		ClockCheckURL:             cfg.ClockCheckUrl,
+		AirGapped:                 cfg.AirGapped == 1,
+		BackupFreshnessMaxWrites:  int(cfg.BackupFreshnessMaxWrites),
+		BackupFreshnessBlock:      cfg.BackupFreshnessBlock == 1,
+		FIPSMode:                  cfg.FipsMode == 1,
+		DBSizeWarnMB:              int(cfg.DbSizeWarnMb),
+		LogMaxSizeMB:              int(cfg.LogMaxSizeMb),
+		LogMaxFiles:               int(cfg.LogMaxFiles),
+		LogMaxAgeDays:             int(cfg.LogMaxAgeDays),
+		LogCompress:               cfg.LogCompress == 1,
+		MinimizeToTray:            cfg.MinimizeToTray == 1,
+		RunInBackground:           cfg.RunInBackground == 1,
+		ExpiryNotifications:       cfg.ExpiryNotifications == 1,
+		AIACacheTTLMinutes:        int(cfg.AiaCacheTtlMinutes),
+		DownloadLineEndings:       cfg.DownloadLineEndings,
+		DownloadTextHeader:        cfg.DownloadTextHeader == 1,
+		DownloadFormat:            cfg.DownloadFormat,
+		KeyPoolSize:               int(cfg.KeyPoolSize),
+		KDFProfile:                cfg.KdfProfile,
+		TicketPattern:             cfg.TicketPattern,
+		TrashRetentionDays:        int(cfg.TrashRetentionDays),
 		IsConfigured:              int(cfg.IsConfigured),
 		CreatedAt:                 cfg.CreatedAt,
 		LastModified:              cfg.LastModified,
 	}
 }
+
+// boolToInt64 converts a boolean to the 0/1 integer SQLite stores for flags
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
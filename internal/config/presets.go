@@ -0,0 +1,170 @@
+package config
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/subject"
+)
+
+// maxPresetNameLength bounds subject preset names
+const maxPresetNameLength = 64
+
+// ListSubjectPresets returns every subject preset ordered by name
+func (s *Service) ListSubjectPresets(ctx context.Context) ([]models.SubjectPreset, error) {
+	rows, err := s.db.Queries().ListSubjectPresets(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subject presets: %w", err)
+	}
+
+	presets := make([]models.SubjectPreset, 0, len(rows))
+	for _, row := range rows {
+		presets = append(presets, convertSqlcToModelsPreset(row))
+	}
+	return presets, nil
+}
+
+// GetSubjectPreset returns the subject preset with the given ID
+func (s *Service) GetSubjectPreset(ctx context.Context, id int64) (*models.SubjectPreset, error) {
+	row, err := s.db.Queries().GetSubjectPresetByID(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("subject preset not found: %d", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subject preset: %w", err)
+	}
+	preset := convertSqlcToModelsPreset(row)
+	return &preset, nil
+}
+
+// SaveSubjectPreset creates a subject preset (ID 0) or updates an existing one.
+// Fields are normalized and validated like the configuration defaults, and
+// preset names must be unique (case-insensitively).
+func (s *Service) SaveSubjectPreset(ctx context.Context, preset models.SubjectPreset) (*models.SubjectPreset, error) {
+	ctx, log := logger.WithOperation(ctx, "save_subject_preset")
+
+	preset.Name = strings.TrimSpace(preset.Name)
+	fields := subject.Normalize(subject.Fields{
+		Organization:       preset.Organization,
+		OrganizationalUnit: preset.OrganizationalUnit,
+		City:               preset.City,
+		State:              preset.State,
+		Country:            preset.Country,
+	})
+	if err := validatePresetName(preset.Name); err != nil {
+		return nil, err
+	}
+	if err := subject.Validate(fields, ""); err != nil {
+		return nil, err
+	}
+
+	ou := sql.NullString{String: fields.OrganizationalUnit, Valid: fields.OrganizationalUnit != ""}
+
+	var saved sqlc.SubjectPreset
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		existing, err := q.ListSubjectPresets(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list subject presets: %w", err)
+		}
+		found := preset.ID == 0
+		for _, p := range existing {
+			if p.ID == preset.ID {
+				found = true
+				continue
+			}
+			if strings.EqualFold(p.Name, preset.Name) {
+				return fmt.Errorf("a subject preset named %q already exists", p.Name)
+			}
+		}
+		if !found {
+			return fmt.Errorf("subject preset not found: %d", preset.ID)
+		}
+
+		if preset.ID == 0 {
+			saved, err = q.InsertSubjectPreset(ctx, sqlc.InsertSubjectPresetParams{
+				Name:               preset.Name,
+				Organization:       fields.Organization,
+				OrganizationalUnit: ou,
+				City:               fields.City,
+				State:              fields.State,
+				Country:            fields.Country,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create subject preset: %w", err)
+			}
+			return nil
+		}
+
+		if err := q.UpdateSubjectPreset(ctx, sqlc.UpdateSubjectPresetParams{
+			Name:               preset.Name,
+			Organization:       fields.Organization,
+			OrganizationalUnit: ou,
+			City:               fields.City,
+			State:              fields.State,
+			Country:            fields.Country,
+			ID:                 preset.ID,
+		}); err != nil {
+			return fmt.Errorf("failed to update subject preset: %w", err)
+		}
+		saved, err = q.GetSubjectPresetByID(ctx, preset.ID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch updated subject preset: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error("failed to save subject preset", logger.Err(err))
+		return nil, err
+	}
+
+	log.Info("subject preset saved",
+		slog.Int64("id", saved.ID),
+		slog.String("name", saved.Name),
+	)
+	result := convertSqlcToModelsPreset(saved)
+	return &result, nil
+}
+
+// DeleteSubjectPreset removes a subject preset. Certificates generated from it
+// keep their subject.
+func (s *Service) DeleteSubjectPreset(ctx context.Context, id int64) error {
+	if err := s.db.Queries().DeleteSubjectPreset(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete subject preset: %w", err)
+	}
+	s.log.Info("subject preset deleted", slog.Int64("id", id))
+	return nil
+}
+
+// validatePresetName checks a trimmed subject preset name
+func validatePresetName(name string) error {
+	if name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if n := utf8.RuneCountInString(name); n > maxPresetNameLength {
+		return fmt.Errorf("name must not exceed %d characters, got %d", maxPresetNameLength, n)
+	}
+	return subject.ValidateValue("name", name, maxPresetNameLength)
+}
+
+// convertSqlcToModelsPreset converts sqlc.SubjectPreset to models.SubjectPreset
+func convertSqlcToModelsPreset(p sqlc.SubjectPreset) models.SubjectPreset {
+	return models.SubjectPreset{
+		ID:                 p.ID,
+		Name:               p.Name,
+		Organization:       p.Organization,
+		OrganizationalUnit: p.OrganizationalUnit.String,
+		City:               p.City,
+		State:              p.State,
+		Country:            p.Country,
+		CreatedAt:          p.CreatedAt,
+		LastModified:       p.LastModified,
+	}
+}
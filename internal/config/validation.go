@@ -3,19 +3,20 @@ package config
 import (
 	"fmt"
 	"net/mail"
+	"net/url"
 	"regexp"
 	"strings"
 
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/hostnames"
 	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/noterefs"
+	"paddockcontrol-desktop/internal/subject"
 )
 
-var (
-	// ISO 3166-1 alpha-2 country code pattern (2 uppercase letters)
-	countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
-
-	// Hostname suffix must start with a dot
-	hostnameSuffixPattern = regexp.MustCompile(`^\.([a-zA-Z0-9]([a-zA-Z0-9\-]{0,61}[a-zA-Z0-9])?\.)*[a-zA-Z]{2,}$`)
-)
+// Hostname suffix must start with a dot. Matched against the punycode form, so
+// the TLD may be an internationalized "xn--" label.
+var hostnameSuffixPattern = regexp.MustCompile(`^\.([a-zA-Z0-9]([a-zA-Z0-9\-]{0,61}[a-zA-Z0-9])?\.)*([a-zA-Z]{2,}|xn--[a-zA-Z0-9\-]+)$`)
 
 // ValidateConfigUpdate validates an UpdateConfigRequest
 func ValidateConfigUpdate(req *models.UpdateConfigRequest) error {
@@ -43,38 +44,84 @@ func ValidateConfigUpdate(req *models.UpdateConfigRequest) error {
 		return err
 	}
 
-	// Validate default_organization
-	if err := validateRequiredString(req.DefaultOrganization, "default_organization", 255); err != nil {
+	// Validate the default subject fields (RFC 5280 bounds, country code)
+	if err := subject.Validate(subject.Fields{
+		Organization:       req.DefaultOrganization,
+		OrganizationalUnit: req.DefaultOrganizationalUnit,
+		City:               req.DefaultCity,
+		State:              req.DefaultState,
+		Country:            req.DefaultCountry,
+	}, "default_"); err != nil {
 		return err
 	}
 
-	// Validate default_organizational_unit (optional)
-	if req.DefaultOrganizationalUnit != "" {
-		if err := validateOptionalString(req.DefaultOrganizationalUnit, "default_organizational_unit", 255); err != nil {
-			return err
-		}
+	// Validate default_key_size
+	if err := validateKeySize(req.DefaultKeySize); err != nil {
+		return err
 	}
 
-	// Validate default_city
-	if err := validateRequiredString(req.DefaultCity, "default_city", 255); err != nil {
+	// Validate expiring_threshold_days
+	if err := validateExpiringThreshold(req.ExpiringThresholdDays, req.ValidityPeriodDays); err != nil {
 		return err
 	}
 
-	// Validate default_state
-	if err := validateRequiredString(req.DefaultState, "default_state", 255); err != nil {
+	// Validate clock_check_url (optional, empty uses the built-in default)
+	if err := validateClockCheckURL(req.ClockCheckURL); err != nil {
 		return err
 	}
 
-	// Validate default_country
-	if err := validateCountryCode(req.DefaultCountry); err != nil {
+	// Validate backup_freshness_max_writes (0 disables the policy)
+	if err := validateBackupFreshnessMaxWrites(req.BackupFreshnessMaxWrites); err != nil {
 		return err
 	}
 
-	// Validate default_key_size
-	if err := validateKeySize(req.DefaultKeySize); err != nil {
+	// Validate db_size_warn_mb (0 disables the warning)
+	if err := validateDBSizeWarnMB(req.DBSizeWarnMB); err != nil {
 		return err
 	}
 
+	// Validate log_max_size_mb, log_max_files and log_max_age_days
+	if err := validateLogRotation(req.LogMaxSizeMB, req.LogMaxFiles, req.LogMaxAgeDays); err != nil {
+		return err
+	}
+
+	// Validate aia_cache_ttl_minutes (0 disables the cache)
+	if err := validateAIACacheTTL(req.AIACacheTTLMinutes); err != nil {
+		return err
+	}
+
+	// Validate download_line_endings and download_format
+	if err := validateDownloadOptions(req.DownloadLineEndings, req.DownloadFormat); err != nil {
+		return err
+	}
+
+	// Validate key_pool_size (0 disables the pool)
+	if err := validateKeyPoolSize(req.KeyPoolSize); err != nil {
+		return err
+	}
+
+	// Validate kdf_profile
+	if err := validateKDFProfile(req.KDFProfile); err != nil {
+		return err
+	}
+
+	// Validate trash_retention_days (0 deletes certificates permanently)
+	if err := validateTrashRetentionDays(req.TrashRetentionDays); err != nil {
+		return err
+	}
+
+	// Validate ticket_pattern (optional, empty extracts URLs only)
+	if _, err := noterefs.CompileTicketPattern(req.TicketPattern); err != nil {
+		return fmt.Errorf("ticket_pattern: %w", err)
+	}
+
+	// FIPS mode only allows RSA keys of FIPSMinRSAKeySize bits or more
+	if req.FIPSMode {
+		if err := crypto.CheckFIPSKeySize(req.DefaultKeySize); err != nil {
+			return fmt.Errorf("default_key_size: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -104,30 +151,14 @@ func ValidateSetupRequest(req *models.SetupRequest) error {
 		return err
 	}
 
-	// Validate default_organization
-	if err := validateRequiredString(req.DefaultOrganization, "default_organization", 255); err != nil {
-		return err
-	}
-
-	// Validate default_organizational_unit (optional)
-	if req.DefaultOrganizationalUnit != "" {
-		if err := validateOptionalString(req.DefaultOrganizationalUnit, "default_organizational_unit", 255); err != nil {
-			return err
-		}
-	}
-
-	// Validate default_city
-	if err := validateRequiredString(req.DefaultCity, "default_city", 255); err != nil {
-		return err
-	}
-
-	// Validate default_state
-	if err := validateRequiredString(req.DefaultState, "default_state", 255); err != nil {
-		return err
-	}
-
-	// Validate default_country
-	if err := validateCountryCode(req.DefaultCountry); err != nil {
+	// Validate the default subject fields (RFC 5280 bounds, country code)
+	if err := subject.Validate(subject.Fields{
+		Organization:       req.DefaultOrganization,
+		OrganizationalUnit: req.DefaultOrganizationalUnit,
+		City:               req.DefaultCity,
+		State:              req.DefaultState,
+		Country:            req.DefaultCountry,
+	}, "default_"); err != nil {
 		return err
 	}
 
@@ -170,15 +201,6 @@ func validateRequiredString(value, fieldName string, maxLength int) error {
 	return nil
 }
 
-// validateOptionalString validates an optional string field
-func validateOptionalString(value, fieldName string, maxLength int) error {
-	if len(value) > maxLength {
-		return fmt.Errorf("%s must not exceed %d characters", fieldName, maxLength)
-	}
-
-	return nil
-}
-
 // validateHostnameSuffix validates the hostname suffix format
 func validateHostnameSuffix(suffix string) error {
 	if strings.TrimSpace(suffix) == "" {
@@ -193,34 +215,138 @@ func validateHostnameSuffix(suffix string) error {
 		return fmt.Errorf("hostname_suffix must start with a dot (e.g., .example.com)")
 	}
 
-	if !hostnameSuffixPattern.MatchString(suffix) {
+	// Unicode suffixes (e.g., .münchen.de) are accepted and validated in punycode form
+	ascii, err := hostnames.NormalizeSuffix(suffix)
+	if err != nil || !hostnameSuffixPattern.MatchString(ascii) {
 		return fmt.Errorf("hostname_suffix must be a valid domain suffix (e.g., .example.com)")
 	}
 
 	return nil
 }
 
-// validateValidityPeriod validates the certificate validity period
-func validateValidityPeriod(days int) error {
+// validateExpiringThreshold validates the "expiring soon" warning threshold
+func validateExpiringThreshold(days int, validityPeriodDays int) error {
 	if days < 1 {
-		return fmt.Errorf("validity_period_days must be at least 1 day")
+		return fmt.Errorf("expiring_threshold_days must be at least 1 day")
 	}
 
-	if days > 3650 {
-		return fmt.Errorf("validity_period_days must not exceed 3650 days (10 years)")
+	if days >= validityPeriodDays {
+		return fmt.Errorf("expiring_threshold_days must be shorter than validity_period_days")
 	}
 
 	return nil
 }
 
-// validateCountryCode validates ISO 3166-1 alpha-2 country code
-func validateCountryCode(code string) error {
-	if strings.TrimSpace(code) == "" {
-		return fmt.Errorf("country code is required")
+// validateBackupFreshnessMaxWrites validates the number of certificate writes
+// allowed since the last backup before it is reported as stale
+func validateBackupFreshnessMaxWrites(writes int) error {
+	if writes < 0 || writes > 10000 {
+		return fmt.Errorf("backup_freshness_max_writes must be between 0 and 10000")
 	}
 
-	if !countryCodePattern.MatchString(code) {
-		return fmt.Errorf("country code must be exactly 2 uppercase letters (ISO 3166-1 alpha-2)")
+	return nil
+}
+
+// validateDBSizeWarnMB validates the database size, in MiB, above which a
+// health warning is raised
+func validateDBSizeWarnMB(mb int) error {
+	if mb < 0 || mb > 100000 {
+		return fmt.Errorf("db_size_warn_mb must be between 0 and 100000")
+	}
+
+	return nil
+}
+
+// validateAIACacheTTL validates how long, in minutes, issuer certificates
+// fetched via AIA are cached (at most a week)
+func validateAIACacheTTL(minutes int) error {
+	if minutes < 0 || minutes > 10080 {
+		return fmt.Errorf("aia_cache_ttl_minutes must be between 0 and 10080")
+	}
+
+	return nil
+}
+
+// validateDownloadOptions validates the line endings and encoding of downloads
+func validateDownloadOptions(lineEndings, format string) error {
+	if lineEndings != models.LineEndingsLF && lineEndings != models.LineEndingsCRLF {
+		return fmt.Errorf("download_line_endings must be lf or crlf")
+	}
+	if format != models.DownloadFormatPEM && format != models.DownloadFormatDER {
+		return fmt.Errorf("download_format must be pem or der")
+	}
+
+	return nil
+}
+
+// validateKeyPoolSize validates how many keys are generated ahead of time
+func validateKeyPoolSize(size int) error {
+	if size < 0 || size > 10 {
+		return fmt.Errorf("key_pool_size must be between 0 and 10")
+	}
+
+	return nil
+}
+
+// validateTrashRetentionDays validates how long deleted certificates stay in
+// the trash
+func validateTrashRetentionDays(days int) error {
+	if days < 0 || days > 365 {
+		return fmt.Errorf("trash_retention_days must be between 0 and 365")
+	}
+
+	return nil
+}
+
+// validateKDFProfile validates the Argon2id cost profile of new password wraps
+func validateKDFProfile(profile string) error {
+	if profile != crypto.KDFProfileStandard && profile != crypto.KDFProfileConstrained {
+		return fmt.Errorf("kdf_profile must be standard or constrained")
+	}
+
+	return nil
+}
+
+// validateLogRotation validates the log file rotation settings: the rotation
+// size in MiB, and how many rotated files are kept and for how many days
+// (0 disables either limit)
+func validateLogRotation(maxSizeMB, maxFiles, maxAgeDays int) error {
+	if maxSizeMB < 1 || maxSizeMB > 1000 {
+		return fmt.Errorf("log_max_size_mb must be between 1 and 1000")
+	}
+	if maxFiles < 0 || maxFiles > 1000 {
+		return fmt.Errorf("log_max_files must be between 0 and 1000")
+	}
+	if maxAgeDays < 0 || maxAgeDays > 3650 {
+		return fmt.Errorf("log_max_age_days must be between 0 and 3650")
+	}
+
+	return nil
+}
+
+// validateClockCheckURL validates the optional clock sanity check reference URL
+func validateClockCheckURL(raw string) error {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("clock_check_url must be an http(s) URL (e.g., https://example.com)")
+	}
+
+	return nil
+}
+
+// validateValidityPeriod validates the certificate validity period
+func validateValidityPeriod(days int) error {
+	if days < 1 {
+		return fmt.Errorf("validity_period_days must be at least 1 day")
+	}
+
+	if days > 3650 {
+		return fmt.Errorf("validity_period_days must not exceed 3650 days (10 years)")
 	}
 
 	return nil
@@ -238,3 +364,20 @@ func validateKeySize(size int) error {
 
 	return fmt.Errorf("key size must be one of: 2048, 3072, or 4096 bits")
 }
+
+// NormalizeSubjectDefaults trims and Unicode-normalizes the default subject
+// fields of a configuration update before validation (see subject.Normalize).
+func NormalizeSubjectDefaults(req *models.UpdateConfigRequest) {
+	f := subject.Normalize(subject.Fields{
+		Organization:       req.DefaultOrganization,
+		OrganizationalUnit: req.DefaultOrganizationalUnit,
+		City:               req.DefaultCity,
+		State:              req.DefaultState,
+		Country:            req.DefaultCountry,
+	})
+	req.DefaultOrganization = f.Organization
+	req.DefaultOrganizationalUnit = f.OrganizationalUnit
+	req.DefaultCity = f.City
+	req.DefaultState = f.State
+	req.DefaultCountry = f.Country
+}
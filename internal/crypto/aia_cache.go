@@ -0,0 +1,161 @@
+package crypto
+
+import (
+	"container/list"
+	"crypto/x509"
+	"sync"
+	"time"
+
+	"paddockcontrol-desktop/internal/models"
+)
+
+// aiaCacheMaxEntries caps the issuer certificates kept by the AIA cache; the
+// least recently used one is evicted to make room
+const aiaCacheMaxEntries = 256
+
+// DefaultAIACacheTTL is how long a fetched issuer certificate is reused before
+// it is fetched again, until the stored setting is applied
+const DefaultAIACacheTTL = time.Hour
+
+// aiaCacheEntry represents a cached AIA certificate fetch result
+type aiaCacheEntry struct {
+	url       string
+	cert      *x509.Certificate
+	fetchedAt time.Time
+}
+
+// lruCache stores fetched certificates by URL, most recently used first, with
+// a TTL and a size cap. Safe for concurrent use.
+type lruCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	max     int
+	order   *list.List // of *aiaCacheEntry, most recently used at the front
+	entries map[string]*list.Element
+	now     func() time.Time
+
+	hits      int64
+	misses    int64
+	evictions int64
+}
+
+// aiaCache is shared by every chain build
+var aiaCache = newLRUCache(aiaCacheMaxEntries, DefaultAIACacheTTL)
+
+func newLRUCache(max int, ttl time.Duration) *lruCache {
+	return &lruCache{
+		ttl:     ttl,
+		max:     max,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// get returns the certificate cached for url, unless it is older than the TTL
+// (an expired entry is dropped).
+func (c *lruCache) get(url string) (*x509.Certificate, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[url]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	entry := elem.Value.(*aiaCacheEntry)
+	if c.now().Sub(entry.fetchedAt) >= c.ttl {
+		c.remove(elem)
+		c.misses++
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	c.hits++
+	return entry.cert, true
+}
+
+// put caches cert for url, evicting the least recently used entries beyond the
+// size cap. Nothing is cached while the TTL is 0.
+func (c *lruCache) put(url string, cert *x509.Certificate) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ttl <= 0 {
+		return
+	}
+	if elem, ok := c.entries[url]; ok {
+		entry := elem.Value.(*aiaCacheEntry)
+		entry.cert = cert
+		entry.fetchedAt = c.now()
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[url] = c.order.PushFront(&aiaCacheEntry{url: url, cert: cert, fetchedAt: c.now()})
+	for c.order.Len() > c.max {
+		c.remove(c.order.Back())
+		c.evictions++
+	}
+}
+
+// remove drops an entry; the caller holds c.mu.
+func (c *lruCache) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*aiaCacheEntry).url)
+}
+
+// setTTL changes the TTL of cached and future entries; 0 disables the cache
+// and empties it.
+func (c *lruCache) setTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ttl = ttl
+	if ttl <= 0 {
+		c.order.Init()
+		clear(c.entries)
+	}
+}
+
+// clear empties the cache and returns the number of entries removed. The hit,
+// miss and eviction counters are kept.
+func (c *lruCache) clear() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := c.order.Len()
+	c.order.Init()
+	clear(c.entries)
+	return n
+}
+
+func (c *lruCache) stats() models.ChainCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return models.ChainCacheStats{
+		Entries:    c.order.Len(),
+		MaxEntries: c.max,
+		TTLSeconds: int64(c.ttl / time.Second),
+		Hits:       c.hits,
+		Misses:     c.misses,
+		Evictions:  c.evictions,
+	}
+}
+
+// SetAIACacheTTL changes how long fetched issuer certificates are reused; 0
+// disables the cache.
+func SetAIACacheTTL(ttl time.Duration) {
+	aiaCache.setTTL(ttl)
+}
+
+// ClearAIACache drops every cached issuer certificate, so the next chain build
+// fetches them again (e.g. after a CA rotated its intermediates). Returns the
+// number of entries removed.
+func ClearAIACache() int {
+	return aiaCache.clear()
+}
+
+// AIACacheStats reports the size and hit rate of the AIA cache since startup
+func AIACacheStats() models.ChainCacheStats {
+	return aiaCache.stats()
+}
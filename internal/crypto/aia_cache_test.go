@@ -0,0 +1,79 @@
+package crypto
+
+import (
+	"crypto/x509"
+	"testing"
+	"time"
+)
+
+func TestLRUCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := newLRUCache(2, time.Hour)
+	a, b, d := &x509.Certificate{}, &x509.Certificate{}, &x509.Certificate{}
+
+	c.put("a", a)
+	c.put("b", b)
+	if got, ok := c.get("a"); !ok || got != a {
+		t.Fatal("expected a to be cached")
+	}
+	// b is now the least recently used
+	c.put("d", d)
+
+	if _, ok := c.get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	if _, ok := c.get("a"); !ok {
+		t.Error("expected a to stay cached")
+	}
+	if _, ok := c.get("d"); !ok {
+		t.Error("expected d to be cached")
+	}
+
+	stats := c.stats()
+	if stats.Entries != 2 || stats.MaxEntries != 2 || stats.Evictions != 1 || stats.Hits != 3 || stats.Misses != 1 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestLRUCache_TTL(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	c := newLRUCache(10, time.Hour)
+	c.now = func() time.Time { return now }
+
+	c.put("a", &x509.Certificate{})
+	now = now.Add(59 * time.Minute)
+	if _, ok := c.get("a"); !ok {
+		t.Fatal("expected a to be cached within the TTL")
+	}
+	now = now.Add(time.Minute)
+	if _, ok := c.get("a"); ok {
+		t.Fatal("expected a to expire after the TTL")
+	}
+	if stats := c.stats(); stats.Entries != 0 {
+		t.Errorf("expired entry should be dropped, got %d entries", stats.Entries)
+	}
+
+	// A TTL of 0 disables the cache and empties it
+	c.put("b", &x509.Certificate{})
+	c.setTTL(0)
+	c.put("c", &x509.Certificate{})
+	if stats := c.stats(); stats.Entries != 0 || stats.TTLSeconds != 0 {
+		t.Errorf("disabled cache should stay empty, got %+v", stats)
+	}
+}
+
+func TestLRUCache_Clear(t *testing.T) {
+	c := newLRUCache(10, time.Hour)
+	c.put("a", &x509.Certificate{})
+	c.put("b", &x509.Certificate{})
+	c.get("a")
+
+	if removed := c.clear(); removed != 2 {
+		t.Errorf("clear() = %d, want 2", removed)
+	}
+	if _, ok := c.get("a"); ok {
+		t.Error("expected cache to be empty after clear")
+	}
+	if stats := c.stats(); stats.Hits != 1 || stats.Misses != 1 {
+		t.Errorf("counters should survive clear, got %+v", stats)
+	}
+}
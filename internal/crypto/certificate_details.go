@@ -1,10 +1,11 @@
 package crypto
 
 import (
-	"crypto/rsa"
 	"crypto/x509"
 	"fmt"
 	"net"
+
+	"paddockcontrol-desktop/internal/models"
 )
 
 // CertificateDetails represents extracted information from a certificate
@@ -16,7 +17,11 @@ type CertificateDetails struct {
 	City               string
 	State              string
 	Country            string
+	KeyAlgorithm       string // models.KeyAlgorithmRSA or models.KeyAlgorithmECDSA
 	KeySize            int
+
+	// Extensions is only set for issued certificates (nil for CSRs)
+	Extensions *models.CertificateExtensions
 }
 
 // combineAllSANs combines DNS names and IP addresses into a single string slice
@@ -32,8 +37,9 @@ func combineAllSANs(dnsNames []string, ipAddresses []net.IP) []string {
 // ExtractCertificateDetails extracts all relevant details from a parsed certificate
 func ExtractCertificateDetails(cert *x509.Certificate) (*CertificateDetails, error) {
 	details := &CertificateDetails{
-		Hostname: cert.Subject.CommonName,
-		SANs:     combineAllSANs(cert.DNSNames, cert.IPAddresses),
+		Hostname:   cert.Subject.CommonName,
+		SANs:       combineAllSANs(cert.DNSNames, cert.IPAddresses),
+		Extensions: ExtractCertificateExtensions(cert),
 	}
 
 	// Extract organization details (take first element from arrays)
@@ -53,11 +59,10 @@ func ExtractCertificateDetails(cert *x509.Certificate) (*CertificateDetails, err
 		details.Country = cert.Subject.Country[0]
 	}
 
-	// Extract key size
-	if pubKey, ok := cert.PublicKey.(*rsa.PublicKey); ok {
-		details.KeySize = pubKey.N.BitLen()
-	} else {
-		// Default to 4096 for non-RSA keys (ECC, etc.)
+	// Extract key algorithm and size
+	details.KeyAlgorithm, details.KeySize = PublicKeyInfo(cert.PublicKey)
+	if details.KeyAlgorithm == "" {
+		// Default to 4096 for other key types (Ed25519, etc.)
 		details.KeySize = 4096
 	}
 
@@ -93,11 +98,10 @@ func ExtractCSRDetails(csr *x509.CertificateRequest) (*CertificateDetails, error
 		details.Country = csr.Subject.Country[0]
 	}
 
-	// Extract key size
-	if pubKey, ok := csr.PublicKey.(*rsa.PublicKey); ok {
-		details.KeySize = pubKey.N.BitLen()
-	} else {
-		// Default to 4096 for non-RSA keys (ECC, etc.)
+	// Extract key algorithm and size
+	details.KeyAlgorithm, details.KeySize = PublicKeyInfo(csr.PublicKey)
+	if details.KeyAlgorithm == "" {
+		// Default to 4096 for other key types (Ed25519, etc.)
 		details.KeySize = 4096
 	}
 
@@ -0,0 +1,104 @@
+package crypto
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"paddockcontrol-desktop/internal/models"
+)
+
+// keyUsageNames maps key usage bits to their display names, in bit order
+var keyUsageNames = []struct {
+	usage x509.KeyUsage
+	name  string
+}{
+	{x509.KeyUsageDigitalSignature, "Digital Signature"},
+	{x509.KeyUsageContentCommitment, "Content Commitment"},
+	{x509.KeyUsageKeyEncipherment, "Key Encipherment"},
+	{x509.KeyUsageDataEncipherment, "Data Encipherment"},
+	{x509.KeyUsageKeyAgreement, "Key Agreement"},
+	{x509.KeyUsageCertSign, "Certificate Sign"},
+	{x509.KeyUsageCRLSign, "CRL Sign"},
+	{x509.KeyUsageEncipherOnly, "Encipher Only"},
+	{x509.KeyUsageDecipherOnly, "Decipher Only"},
+}
+
+// extKeyUsageNames maps extended key usages to their display names
+var extKeyUsageNames = map[x509.ExtKeyUsage]string{
+	x509.ExtKeyUsageAny:                            "Any",
+	x509.ExtKeyUsageServerAuth:                     "Server Authentication",
+	x509.ExtKeyUsageClientAuth:                     "Client Authentication",
+	x509.ExtKeyUsageCodeSigning:                    "Code Signing",
+	x509.ExtKeyUsageEmailProtection:                "Email Protection",
+	x509.ExtKeyUsageIPSECEndSystem:                 "IPSec End System",
+	x509.ExtKeyUsageIPSECTunnel:                    "IPSec Tunnel",
+	x509.ExtKeyUsageIPSECUser:                      "IPSec User",
+	x509.ExtKeyUsageTimeStamping:                   "Time Stamping",
+	x509.ExtKeyUsageOCSPSigning:                    "OCSP Signing",
+	x509.ExtKeyUsageMicrosoftServerGatedCrypto:     "Microsoft Server Gated Crypto",
+	x509.ExtKeyUsageNetscapeServerGatedCrypto:      "Netscape Server Gated Crypto",
+	x509.ExtKeyUsageMicrosoftCommercialCodeSigning: "Microsoft Commercial Code Signing",
+	x509.ExtKeyUsageMicrosoftKernelCodeSigning:     "Microsoft Kernel Code Signing",
+}
+
+// ExtractCertificateExtensions decodes the X.509 extensions, signature algorithm
+// and fingerprints of a certificate for display
+func ExtractCertificateExtensions(cert *x509.Certificate) *models.CertificateExtensions {
+	sha1Sum := sha1.Sum(cert.Raw)
+	sha256Sum := sha256.Sum256(cert.Raw)
+
+	ext := &models.CertificateExtensions{
+		SerialNumber:           fmt.Sprintf("%X", cert.SerialNumber),
+		SignatureAlgorithm:     cert.SignatureAlgorithm.String(),
+		FingerprintSHA1:        FormatHexColon(sha1Sum[:]),
+		FingerprintSHA256:      FormatHexColon(sha256Sum[:]),
+		BasicConstraintsValid:  cert.BasicConstraintsValid,
+		IsCA:                   cert.IsCA,
+		MaxPathLen:             -1,
+		SubjectKeyID:           FormatHexColon(cert.SubjectKeyId),
+		AuthorityKeyID:         FormatHexColon(cert.AuthorityKeyId),
+		OCSPServers:            cert.OCSPServer,
+		IssuingCertificateURLs: cert.IssuingCertificateURL,
+		CRLDistributionPoints:  cert.CRLDistributionPoints,
+	}
+
+	// MaxPathLen is only meaningful when set; 0 with MaxPathLenZero means "no intermediates"
+	if cert.BasicConstraintsValid && (cert.MaxPathLen > 0 || cert.MaxPathLenZero) {
+		ext.MaxPathLen = cert.MaxPathLen
+	}
+
+	for _, ku := range keyUsageNames {
+		if cert.KeyUsage&ku.usage != 0 {
+			ext.KeyUsage = append(ext.KeyUsage, ku.name)
+		}
+	}
+
+	for _, eku := range cert.ExtKeyUsage {
+		name, ok := extKeyUsageNames[eku]
+		if !ok {
+			name = fmt.Sprintf("Unknown (%d)", eku)
+		}
+		ext.ExtKeyUsage = append(ext.ExtKeyUsage, name)
+	}
+	for _, oid := range cert.UnknownExtKeyUsage {
+		ext.ExtKeyUsage = append(ext.ExtKeyUsage, oid.String())
+	}
+
+	return ext
+}
+
+// FormatHexColon formats bytes as uppercase colon-separated hex ("AB:CD:EF").
+// Returns an empty string for empty input.
+func FormatHexColon(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	parts := make([]string, len(b))
+	for i, v := range b {
+		parts[i] = fmt.Sprintf("%02X", v)
+	}
+	return strings.Join(parts, ":")
+}
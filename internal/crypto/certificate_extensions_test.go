@@ -0,0 +1,78 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestExtractCertificateExtensions(t *testing.T) {
+	key, err := GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(0xABCDEF),
+		Subject:               pkix.Name{CommonName: "test.example.com"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		SubjectKeyId:          []byte{0x01, 0x02, 0xAB},
+		OCSPServer:            []string{"http://ocsp.example.com"},
+		IssuingCertificateURL: []string{"http://ca.example.com/ca.crt"},
+		CRLDistributionPoints: []string{"http://ca.example.com/ca.crl"},
+		DNSNames:              []string{"test.example.com"},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	ext := ExtractCertificateExtensions(cert)
+
+	if ext.SerialNumber != "ABCDEF" {
+		t.Errorf("expected serial ABCDEF, got %s", ext.SerialNumber)
+	}
+	if ext.SignatureAlgorithm != "SHA256-RSA" {
+		t.Errorf("expected SHA256-RSA, got %s", ext.SignatureAlgorithm)
+	}
+	sum := sha256.Sum256(certDER)
+	if ext.FingerprintSHA256 != FormatHexColon(sum[:]) {
+		t.Errorf("unexpected SHA-256 fingerprint: %s", ext.FingerprintSHA256)
+	}
+	if len(ext.KeyUsage) != 2 || ext.KeyUsage[0] != "Digital Signature" || ext.KeyUsage[1] != "Key Encipherment" {
+		t.Errorf("unexpected key usage: %v", ext.KeyUsage)
+	}
+	if len(ext.ExtKeyUsage) != 2 || ext.ExtKeyUsage[0] != "Server Authentication" {
+		t.Errorf("unexpected extended key usage: %v", ext.ExtKeyUsage)
+	}
+	if !ext.BasicConstraintsValid || ext.IsCA || ext.MaxPathLen != -1 {
+		t.Errorf("unexpected basic constraints: valid=%v ca=%v maxPathLen=%d", ext.BasicConstraintsValid, ext.IsCA, ext.MaxPathLen)
+	}
+	if ext.SubjectKeyID != "01:02:AB" {
+		t.Errorf("expected SKI 01:02:AB, got %s", ext.SubjectKeyID)
+	}
+	if len(ext.OCSPServers) != 1 || len(ext.IssuingCertificateURLs) != 1 || len(ext.CRLDistributionPoints) != 1 {
+		t.Errorf("expected AIA and CRL URLs, got %+v", ext)
+	}
+}
+
+func TestFormatHexColon(t *testing.T) {
+	if got := FormatHexColon(nil); got != "" {
+		t.Errorf("expected empty string, got %q", got)
+	}
+	if got := FormatHexColon([]byte{0x0a, 0xff}); got != "0A:FF" {
+		t.Errorf("expected 0A:FF, got %q", got)
+	}
+}
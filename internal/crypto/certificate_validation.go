@@ -1,38 +1,75 @@
 package crypto
 
 import (
+	"crypto"
+	"crypto/ecdsa"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
 )
 
-// ExtractPublicKey extracts the public key from a certificate
-func ExtractPublicKey(cert *x509.Certificate) (*rsa.PublicKey, error) {
-	pubKey, ok := cert.PublicKey.(*rsa.PublicKey)
-	if !ok {
-		return nil, fmt.Errorf("certificate does not contain an RSA public key")
+// ExtractPublicKey extracts the RSA or ECDSA public key from a certificate
+func ExtractPublicKey(cert *x509.Certificate) (crypto.PublicKey, error) {
+	switch cert.PublicKey.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		return cert.PublicKey, nil
 	}
-	return pubKey, nil
+	return nil, fmt.Errorf("certificate does not contain an RSA or ECDSA public key")
 }
 
-// ComparePublicKeys compares two RSA public keys for equality
-func ComparePublicKeys(key1, key2 *rsa.PublicKey) bool {
-	return key1.N.Cmp(key2.N) == 0 && key1.E == key2.E
+// ComparePublicKeys compares two public keys for equality. Keys of different
+// algorithms never match.
+func ComparePublicKeys(key1, key2 crypto.PublicKey) bool {
+	k, ok := key1.(interface{ Equal(crypto.PublicKey) bool })
+	return ok && k.Equal(key2)
+}
+
+// PublicKeyFingerprint returns the hex SHA-256 of a public key's DER-encoded
+// SubjectPublicKeyInfo. A certificate and the CSR it was issued from share the
+// same fingerprint.
+func PublicKeyFingerprint(pub crypto.PublicKey) (string, error) {
+	sum, err := publicKeySHA256(pub)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(sum), nil
+}
+
+// PublicKeyPin returns the base64 SHA-256 of a public key's DER-encoded
+// SubjectPublicKeyInfo, the pin format of RFC 7469 used by Android, OkHttp and
+// TrustKit.
+func PublicKeyPin(pub crypto.PublicKey) (string, error) {
+	sum, err := publicKeySHA256(pub)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(sum), nil
+}
+
+func publicKeySHA256(pub crypto.PublicKey) ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode public key: %w", err)
+	}
+	sum := sha256.Sum256(der)
+	return sum[:], nil
 }
 
 // ValidateCSRMatch validates that a certificate matches a CSR (same public key)
 func ValidateCSRMatch(csr *x509.CertificateRequest, cert *x509.Certificate) error {
-	csrPubKey, ok := csr.PublicKey.(*rsa.PublicKey)
-	if !ok {
-		return fmt.Errorf("CSR does not contain an RSA public key")
+	if algorithm, _ := PublicKeyInfo(csr.PublicKey); algorithm == "" {
+		return fmt.Errorf("CSR does not contain an RSA or ECDSA public key")
 	}
 
-	certPubKey, ok := cert.PublicKey.(*rsa.PublicKey)
-	if !ok {
-		return fmt.Errorf("certificate does not contain an RSA public key")
+	certPubKey, err := ExtractPublicKey(cert)
+	if err != nil {
+		return err
 	}
 
-	if !ComparePublicKeys(csrPubKey, certPubKey) {
+	if !ComparePublicKeys(csr.PublicKey, certPubKey) {
 		return fmt.Errorf("certificate public key does not match CSR public key")
 	}
 
@@ -60,7 +97,7 @@ func ValidateCertificateAndKey(certPEM, keyPEM string) error {
 	}
 
 	// Compare public keys
-	if !ComparePublicKeys(certPubKey, &privateKey.PublicKey) {
+	if !ComparePublicKeys(certPubKey, privateKey.Public()) {
 		return fmt.Errorf("private key does not match certificate")
 	}
 
@@ -97,16 +134,17 @@ func ValidateKeyMatches(encryptedKey []byte, csrPEM *string, certPEM *string, en
 		result.Error = fmt.Sprintf("failed to decrypt private key: %v", err)
 		return result
 	}
+	defer decryptedKeyPEM.Destroy()
 
 	// Parse the private key
-	privateKey, err := ParsePrivateKeyFromPEM(decryptedKeyPEM)
+	privateKey, err := ParsePrivateKeyFromPEM(decryptedKeyPEM.Bytes())
 	if err != nil {
 		result.Error = fmt.Sprintf("failed to parse private key: %v", err)
 		return result
 	}
 
 	// Get the public key from the private key
-	privateKeyPublicKey := &privateKey.PublicKey
+	privateKeyPublicKey := privateKey.Public()
 
 	// Validate against CSR if present
 	if csrPEM != nil && *csrPEM != "" {
@@ -119,12 +157,12 @@ func ValidateKeyMatches(encryptedKey []byte, csrPEM *string, certPEM *string, en
 			keyMatches := false
 			result.KeyMatchesCSR = &keyMatches
 		} else {
-			csrPubKey, ok := csr.PublicKey.(*rsa.PublicKey)
-			if !ok {
+			csrPubKey := csr.PublicKey
+			if algorithm, _ := PublicKeyInfo(csrPubKey); algorithm == "" {
 				if result.Error != "" {
 					result.Error += "; "
 				}
-				result.Error += "CSR does not contain an RSA public key"
+				result.Error += "CSR does not contain an RSA or ECDSA public key"
 				keyMatches := false
 				result.KeyMatchesCSR = &keyMatches
 			} else {
@@ -151,12 +189,12 @@ func ValidateKeyMatches(encryptedKey []byte, csrPEM *string, certPEM *string, en
 			keyMatches := false
 			result.KeyMatchesCert = &keyMatches
 		} else {
-			certPubKey, ok := cert.PublicKey.(*rsa.PublicKey)
-			if !ok {
+			certPubKey, err := ExtractPublicKey(cert)
+			if err != nil {
 				if result.Error != "" {
 					result.Error += "; "
 				}
-				result.Error += "certificate does not contain an RSA public key"
+				result.Error += err.Error()
 				keyMatches := false
 				result.KeyMatchesCert = &keyMatches
 			} else {
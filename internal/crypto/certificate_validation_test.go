@@ -3,6 +3,8 @@ package crypto
 import (
 	"crypto/rand"
 	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/pem"
 	"math/big"
 	"testing"
@@ -157,3 +159,136 @@ func TestValidateKeyMatches_NoKey(t *testing.T) {
 		t.Error("expected an error about missing key")
 	}
 }
+
+func TestPublicKeyFingerprint(t *testing.T) {
+	key, err := GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	other, err := GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	csrPEM, err := CreateCSR(CSRRequest{CommonName: "test.example.com", Country: "FR"}, key)
+	if err != nil {
+		t.Fatalf("failed to create CSR: %v", err)
+	}
+	csr, err := ParseCSR(csrPEM)
+	if err != nil {
+		t.Fatalf("failed to parse CSR: %v", err)
+	}
+
+	fromKey, err := PublicKeyFingerprint(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("PublicKeyFingerprint() error = %v", err)
+	}
+	fromCSR, err := PublicKeyFingerprint(csr.PublicKey)
+	if err != nil {
+		t.Fatalf("PublicKeyFingerprint() error = %v", err)
+	}
+	if fromKey != fromCSR {
+		t.Errorf("key and CSR fingerprints differ: %s != %s", fromKey, fromCSR)
+	}
+
+	fromOther, err := PublicKeyFingerprint(&other.PublicKey)
+	if err != nil {
+		t.Fatalf("PublicKeyFingerprint() error = %v", err)
+	}
+	if fromOther == fromKey {
+		t.Error("different keys have the same fingerprint")
+	}
+}
+
+func TestPublicKeyPin(t *testing.T) {
+	key, err := GenerateECDSAKey(256)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	pin, err := PublicKeyPin(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("PublicKeyPin() error = %v", err)
+	}
+	fingerprint, err := PublicKeyFingerprint(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("PublicKeyFingerprint() error = %v", err)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(pin)
+	if err != nil {
+		t.Fatalf("pin %q is not base64: %v", pin, err)
+	}
+	if hex.EncodeToString(decoded) != fingerprint {
+		t.Errorf("pin %q does not encode the fingerprint %s", pin, fingerprint)
+	}
+}
+
+func TestValidateKeyMatches_ECDSA(t *testing.T) {
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	key, err := GenerateECDSAKey(256)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	csrPEM, err := CreateCSR(CSRRequest{CommonName: "test.example.com", Country: "FR"}, key)
+	if err != nil {
+		t.Fatalf("failed to create CSR: %v", err)
+	}
+	csr, err := ParseCSR(csrPEM)
+	if err != nil {
+		t.Fatalf("failed to parse CSR: %v", err)
+	}
+	if csr.SignatureAlgorithm != x509.ECDSAWithSHA256 {
+		t.Errorf("expected ECDSAWithSHA256, got %v", csr.SignatureAlgorithm)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		DNSNames:     []string{"test.example.com"},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	if err := ValidateCSRMatch(csr, cert); err != nil {
+		t.Errorf("ValidateCSRMatch() error = %v", err)
+	}
+
+	keyPEM, err := PrivateKeyToPEM(key)
+	if err != nil {
+		t.Fatalf("failed to encode key: %v", err)
+	}
+	if err := ValidateCertificateAndKey(certPEM, string(keyPEM)); err != nil {
+		t.Errorf("ValidateCertificateAndKey() error = %v", err)
+	}
+	encryptedKey, err := EncryptPrivateKey(keyPEM, encryptionKey)
+	if err != nil {
+		t.Fatalf("failed to encrypt key: %v", err)
+	}
+	csrStr := string(csrPEM)
+	result := ValidateKeyMatches(encryptedKey, &csrStr, &certPEM, encryptionKey)
+	if result.KeyMatchesCSR == nil || !*result.KeyMatchesCSR || result.KeyMatchesCert == nil || !*result.KeyMatchesCert {
+		t.Errorf("expected the ECDSA key to match its CSR and certificate: %+v", result)
+	}
+
+	// An RSA key never matches an ECDSA certificate
+	rsaKey, err := GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	rsaPEM, err := PrivateKeyToPEM(rsaKey)
+	if err != nil {
+		t.Fatalf("failed to encode key: %v", err)
+	}
+	if err := ValidateCertificateAndKey(certPEM, string(rsaPEM)); err == nil {
+		t.Error("expected an RSA key not to match an ECDSA certificate")
+	}
+}
@@ -6,25 +6,16 @@ import (
 	"encoding/pem"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
-	"sync"
 	"time"
 
 	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/timing"
 )
 
-// aiaCacheEntry represents a cached AIA certificate fetch result
-type aiaCacheEntry struct {
-	cert      *x509.Certificate
-	fetchedAt time.Time
-}
-
-// aiaCache stores fetched certificates by URL with TTL
-var (
-	aiaCache      = make(map[string]*aiaCacheEntry)
-	aiaCacheMutex sync.RWMutex
-	aiaCacheTTL   = 1 * time.Hour // Cache certificates for 1 hour
-)
+// aiaFetchTimeout bounds each AIA request
+const aiaFetchTimeout = 10 * time.Second
 
 // FetchCertificateChain fetches CA certificates from the provided URLs
 // Returns PEM-encoded certificates in order
@@ -149,93 +140,180 @@ func ChainFromJSON(jsonData string) ([]string, error) {
 // Returns the parsed certificate or an error
 // Uses an in-memory cache to avoid repeated network calls for the same URL
 func FetchCertificateFromAIAURL(url string) (*x509.Certificate, error) {
+	cert, _, err := fetchAIACertificate(url)
+	return cert, err
+}
+
+// fetchAIACertificate is FetchCertificateFromAIAURL, also reporting how the
+// fetch went for chain diagnostics
+func fetchAIACertificate(rawURL string) (*x509.Certificate, models.ChainFetchAttempt, error) {
+	attempt := models.ChainFetchAttempt{URL: rawURL}
+
 	// Check cache first
-	aiaCacheMutex.RLock()
-	if entry, ok := aiaCache[url]; ok {
-		if time.Since(entry.fetchedAt) < aiaCacheTTL {
-			aiaCacheMutex.RUnlock()
-			return entry.cert, nil
-		}
+	if cert, ok := aiaCache.get(rawURL); ok {
+		attempt.Outcome = models.ChainFetchCached
+		return cert, attempt, nil
+	}
+
+	req, err := newAIARequest(rawURL)
+	if err != nil {
+		attempt.Outcome = models.ChainFetchInvalidURL
+		attempt.Error = err.Error()
+		return nil, attempt, fmt.Errorf("invalid AIA URL %s: %w", rawURL, err)
+	}
+	if proxy, err := http.ProxyFromEnvironment(req); err == nil && proxy != nil {
+		attempt.Proxy = proxy.Redacted()
 	}
-	aiaCacheMutex.RUnlock()
 
 	// Not in cache or expired, fetch from network
+	defer timing.Start(timing.ChainFetch, slog.String("url", rawURL))()
+
 	client := &http.Client{
-		Timeout: 10 * time.Second,
+		Timeout: aiaFetchTimeout,
 	}
 
-	resp, err := client.Get(url)
+	started := time.Now()
+	resp, err := client.Do(req)
+	attempt.DurationMs = time.Since(started).Milliseconds()
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch certificate from %s: %w", url, err)
+		attempt.Outcome = classifyFetchError(err)
+		attempt.Error = err.Error()
+		return nil, attempt, fmt.Errorf("failed to fetch certificate from %s: %w", rawURL, err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to fetch certificate from %s: HTTP %d", url, resp.StatusCode)
+		attempt.Outcome = models.ChainFetchHTTPStatus
+		attempt.StatusCode = resp.StatusCode
+		return nil, attempt, fmt.Errorf("failed to fetch certificate from %s: HTTP %d", rawURL, resp.StatusCode)
 	}
+	attempt.StatusCode = resp.StatusCode
 
 	certPEM, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read certificate from %s: %w", url, err)
+		attempt.Outcome = classifyFetchError(err)
+		attempt.Error = err.Error()
+		return nil, attempt, fmt.Errorf("failed to read certificate from %s: %w", rawURL, err)
 	}
 
 	// Parse and validate certificate
 	cert, err := ParseCertificate(certPEM)
 	if err != nil {
-		return nil, fmt.Errorf("invalid certificate from %s: %w", url, err)
+		attempt.Outcome = models.ChainFetchInvalidCertificate
+		attempt.Error = err.Error()
+		return nil, attempt, fmt.Errorf("invalid certificate from %s: %w", rawURL, err)
 	}
 
 	// Store in cache
-	aiaCacheMutex.Lock()
-	aiaCache[url] = &aiaCacheEntry{
-		cert:      cert,
-		fetchedAt: time.Now(),
-	}
-	aiaCacheMutex.Unlock()
+	aiaCache.put(rawURL, cert)
 
-	return cert, nil
+	attempt.Outcome = models.ChainFetchOK
+	return cert, attempt, nil
 }
 
+// IssuerOverride replaces the AIA URL a certificate names for its issuer: the
+// issuer is fetched from URL instead, or is Certificate when it was pasted
+type IssuerOverride struct {
+	URL         string
+	Certificate *x509.Certificate
+}
+
+// IssuerOverrides returns the override for the issuer of cert, if any
+type IssuerOverrides func(cert *x509.Certificate) (IssuerOverride, bool)
+
 // BuildChainFromAIA builds a certificate chain by following AIA (Authority Information Access) URLs
 // Starting from the leaf certificate, it follows IssuingCertificateURL until it reaches the root
 // Returns the chain in order: [intermediate1, intermediate2, ..., root]
 // Returns an empty chain if the certificate is self-signed or has no AIA extension
+// On failure the chain found so far is returned with a *ChainFetchError
 func BuildChainFromAIA(leafCert *x509.Certificate) ([]*x509.Certificate, error) {
+	return BuildChainWithOverrides(leafCert, nil)
+}
+
+// BuildChainWithOverrides is BuildChainFromAIA, except that the issuer of each
+// certificate with an override is taken from it before the certificate's own
+// AIA URL is considered. overrides may be nil.
+func BuildChainWithOverrides(leafCert *x509.Certificate, overrides IssuerOverrides) ([]*x509.Certificate, error) {
 	var chain []*x509.Certificate
+	var attempts []models.ChainFetchAttempt
 	visited := make(map[string]bool) // Track visited URLs to prevent infinite loops
 	currentCert := leafCert
 
 	for {
+		var override IssuerOverride
+		hasOverride := false
+		if overrides != nil && !isSelfSigned(currentCert) {
+			override, hasOverride = overrides(currentCert)
+		}
+
+		// A pasted issuer needs no fetch
+		if hasOverride && override.Certificate != nil {
+			attempts = append(attempts, models.ChainFetchAttempt{
+				Depth:    len(chain) + 1,
+				Outcome:  models.ChainFetchPasted,
+				Override: true,
+			})
+			if override.Certificate.Subject.String() != currentCert.Issuer.String() {
+				attempts[len(attempts)-1].Outcome = models.ChainFetchIssuerMismatch
+				return chain, newChainFetchError(attempts, models.ChainCauseBadURL,
+					fmt.Sprintf("The pasted issuer certificate %q is not the issuer of %s", override.Certificate.Subject.CommonName, currentCert.Subject.CommonName),
+					fmt.Errorf("issuer certificate mismatch: expected issuer %s, got %s", currentCert.Issuer.String(), override.Certificate.Subject.String()))
+			}
+			chain = append(chain, override.Certificate)
+			if isSelfSigned(override.Certificate) {
+				return chain, nil
+			}
+			currentCert = override.Certificate
+			continue
+		}
+
 		// Check if current certificate has AIA extension with IssuingCertificateURL
-		if len(currentCert.IssuingCertificateURL) == 0 {
+		if !hasOverride && len(currentCert.IssuingCertificateURL) == 0 {
 			// No AIA URL - check if it's self-signed (root)
 			if currentCert.Subject.String() == currentCert.Issuer.String() {
 				// Self-signed root - we're done
 				return chain, nil
 			}
 			// Not self-signed but no AIA URL - can't continue
-			return chain, fmt.Errorf("certificate has no AIA IssuingCertificateURL and is not self-signed")
+			return chain, newChainFetchError(attempts, models.ChainCauseBadURL,
+				fmt.Sprintf("%s has no issuer URL (AIA) and is not self-signed, so its issuer cannot be fetched", describeCert(currentCert)),
+				fmt.Errorf("certificate has no AIA IssuingCertificateURL and is not self-signed"))
 		}
 
-		// Use the first AIA URL (certificates may have multiple)
-		aiaURL := currentCert.IssuingCertificateURL[0]
+		// Use the override URL, or the first AIA URL (certificates may have multiple)
+		var aiaURL string
+		if hasOverride {
+			aiaURL = override.URL
+		} else {
+			aiaURL = currentCert.IssuingCertificateURL[0]
+		}
 
 		// Check for circular references
 		if visited[aiaURL] {
-			return chain, fmt.Errorf("circular reference detected in AIA chain at URL: %s", aiaURL)
+			return chain, newChainFetchError(attempts, models.ChainCauseBadURL,
+				fmt.Sprintf("The issuer URL %s points back to a certificate already in the chain", aiaURL),
+				fmt.Errorf("circular reference detected in AIA chain at URL: %s", aiaURL))
 		}
 		visited[aiaURL] = true
 
 		// Fetch issuer certificate from AIA URL
-		issuerCert, err := FetchCertificateFromAIAURL(aiaURL)
+		issuerCert, attempt, err := fetchAIACertificate(aiaURL)
+		attempt.Depth = len(chain) + 1
+		attempt.Override = hasOverride
+		attempts = append(attempts, attempt)
 		if err != nil {
-			return chain, fmt.Errorf("failed to fetch issuer from AIA URL %s: %w", aiaURL, err)
+			cause, summary := diagnoseAttempt(attempt)
+			return chain, newChainFetchError(attempts, cause, summary,
+				fmt.Errorf("failed to fetch issuer from AIA URL %s: %w", aiaURL, err))
 		}
 
 		// Validate that fetched certificate matches the issuer
 		// Check if issuer's Subject matches current cert's Issuer DN
 		if issuerCert.Subject.String() != currentCert.Issuer.String() {
-			return chain, fmt.Errorf("issuer certificate mismatch: expected issuer %s, got %s", currentCert.Issuer.String(), issuerCert.Subject.String())
+			attempts[len(attempts)-1].Outcome = models.ChainFetchIssuerMismatch
+			cause, summary := diagnoseAttempt(attempts[len(attempts)-1])
+			return chain, newChainFetchError(attempts, cause, summary,
+				fmt.Errorf("issuer certificate mismatch: expected issuer %s, got %s", currentCert.Issuer.String(), issuerCert.Subject.String()))
 		}
 
 		// Add issuer to chain
@@ -296,6 +374,7 @@ func ExtractChainInfo(cert *x509.Certificate, certType string, depth int) models
 	if len(cert.Issuer.Organization) > 0 {
 		info.IssuerO = cert.Issuer.Organization[0]
 	}
+	info.IssuerDN = cert.Issuer.String()
 
 	// Validity dates (as Unix timestamps for JS timezone formatting)
 	info.NotBeforeTimestamp = cert.NotBefore.Unix()
@@ -316,36 +395,93 @@ func ExtractChainInfo(cert *x509.Certificate, certType string, depth int) models
 
 // BuildChainInfoFromLeaf builds chain metadata for visualization
 // Returns []ChainCertificateInfo with leaf at index 0, root at the end
-func BuildChainInfoFromLeaf(leafCert *x509.Certificate) ([]models.ChainCertificateInfo, error) {
-	var chainInfo []models.ChainCertificateInfo
-
+// overrides (may be nil) replace the AIA URLs of the certificates they cover
+func BuildChainInfoFromLeaf(leafCert *x509.Certificate, overrides IssuerOverrides) ([]models.ChainCertificateInfo, error) {
 	// Check if self-signed (leaf is also root)
-	isSelfSigned := leafCert.Subject.String() == leafCert.Issuer.String()
-
-	if isSelfSigned {
+	if isSelfSigned(leafCert) {
 		// Self-signed certificate - single node marked as root
-		chainInfo = append(chainInfo, ExtractChainInfo(leafCert, "root", 0))
-		return chainInfo, nil
+		return []models.ChainCertificateInfo{ExtractChainInfo(leafCert, "root", 0)}, nil
 	}
 
-	// Add leaf certificate (depth 0)
-	chainInfo = append(chainInfo, ExtractChainInfo(leafCert, "leaf", 0))
-
 	// Build chain from AIA
-	chain, err := BuildChainFromAIA(leafCert)
+	chain, err := BuildChainWithOverrides(leafCert, overrides)
 	if err != nil {
-		// Return leaf-only with error
-		return chainInfo, err
+		// Return the leaf and the issuers fetched before the failure
+		return BuildChainInfo(leafCert, chain), err
+	}
+
+	return BuildChainInfo(leafCert, chain), nil
+}
+
+// BuildChainInfo builds chain metadata for a leaf and an already known issuer chain
+// (intermediates first). The last certificate is marked as root only if self-signed.
+func BuildChainInfo(leafCert *x509.Certificate, chain []*x509.Certificate) []models.ChainCertificateInfo {
+	if isSelfSigned(leafCert) {
+		return []models.ChainCertificateInfo{ExtractChainInfo(leafCert, "root", 0)}
 	}
 
-	// Add intermediates and root
+	chainInfo := []models.ChainCertificateInfo{ExtractChainInfo(leafCert, "leaf", 0)}
 	for i, cert := range chain {
 		certType := "intermediate"
-		if i == len(chain)-1 {
+		if i == len(chain)-1 && isSelfSigned(cert) {
 			certType = "root"
 		}
 		chainInfo = append(chainInfo, ExtractChainInfo(cert, certType, i+1))
 	}
 
-	return chainInfo, nil
+	return chainInfo
+}
+
+// SplitCertificateBundle separates a PEM bundle returned by a CA into the leaf
+// certificate and the issuer certificates included with it. The leaf is the first
+// non-CA certificate (or the first certificate if all are CAs). The remaining
+// certificates are ordered from the leaf's issuer upwards; certificates that are
+// not part of the leaf's issuer path are appended in their original order.
+func SplitCertificateBundle(pemData []byte) (*x509.Certificate, []*x509.Certificate, error) {
+	certs, err := ParseMultipleCertificates(pemData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	leafIdx := 0
+	for i, cert := range certs {
+		if !cert.IsCA {
+			leafIdx = i
+			break
+		}
+	}
+	leaf := certs[leafIdx]
+
+	var rest []*x509.Certificate
+	for i, cert := range certs {
+		if i != leafIdx && !cert.Equal(leaf) {
+			rest = append(rest, cert)
+		}
+	}
+
+	// Walk the issuer path from the leaf, picking certificates out of rest
+	var chain []*x509.Certificate
+	current := leaf
+	for len(rest) > 0 && !isSelfSigned(current) {
+		next := -1
+		for i, cert := range rest {
+			if cert.Subject.String() == current.Issuer.String() {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			break
+		}
+		current = rest[next]
+		chain = append(chain, current)
+		rest = append(rest[:next], rest[next+1:]...)
+	}
+	chain = append(chain, rest...)
+
+	return leaf, chain, nil
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return cert.Subject.String() == cert.Issuer.String()
 }
@@ -0,0 +1,161 @@
+package crypto
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+
+	"paddockcontrol-desktop/internal/models"
+)
+
+// ChainFetchError is returned by BuildChainFromAIA when the chain could not be
+// completed. Diagnostics lists every URL tried and what most likely went wrong:
+// no network, the CA endpoint, or the URL in the certificate.
+type ChainFetchError struct {
+	Diagnostics models.ChainFetchDiagnostics
+	err         error
+}
+
+func (e *ChainFetchError) Error() string { return e.err.Error() }
+
+func (e *ChainFetchError) Unwrap() error { return e.err }
+
+func newChainFetchError(attempts []models.ChainFetchAttempt, cause, summary string, err error) *ChainFetchError {
+	return &ChainFetchError{
+		Diagnostics: models.ChainFetchDiagnostics{
+			Cause:    cause,
+			Summary:  summary,
+			Attempts: attempts,
+		},
+		err: err,
+	}
+}
+
+// ChainDiagnostics returns the diagnostics carried by an error from
+// BuildChainFromAIA, or nil for any other error.
+func ChainDiagnostics(err error) *models.ChainFetchDiagnostics {
+	var fetchErr *ChainFetchError
+	if !errors.As(err, &fetchErr) {
+		return nil
+	}
+	return &fetchErr.Diagnostics
+}
+
+// ValidateAIAURL checks that an issuer URL can be fetched: only absolute
+// http(s) URLs are (AIA may also carry ldap:// URLs, which are not supported).
+func ValidateAIAURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("missing host")
+	}
+	return nil
+}
+
+// newAIARequest builds the GET request for an AIA URL
+func newAIARequest(rawURL string) (*http.Request, error) {
+	if err := ValidateAIAURL(rawURL); err != nil {
+		return nil, err
+	}
+	return http.NewRequest(http.MethodGet, rawURL, nil)
+}
+
+// classifyFetchError maps a failed AIA request to a ChainFetch* outcome.
+func classifyFetchError(err error) string {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "proxyconnect" {
+		return models.ChainFetchProxyError
+	}
+
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		if dnsErr.IsNotFound {
+			return models.ChainFetchDNSNotFound
+		}
+		return models.ChainFetchDNSError
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return models.ChainFetchTimeout
+	}
+
+	// Windows socket errors are not the syscall constants, hence the messages
+	msg := err.Error()
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED), strings.Contains(msg, "actively refused"):
+		return models.ChainFetchConnectionRefused
+	case errors.Is(err, syscall.ENETUNREACH), errors.Is(err, syscall.EHOSTUNREACH),
+		strings.Contains(msg, "unreachable network"), strings.Contains(msg, "unreachable host"):
+		return models.ChainFetchNetworkUnreachable
+	}
+
+	var verifyErr *tls.CertificateVerificationError
+	var recordErr tls.RecordHeaderError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	if errors.As(err, &verifyErr) || errors.As(err, &recordErr) || errors.As(err, &authorityErr) ||
+		errors.As(err, &hostnameErr) || strings.Contains(msg, "tls: ") {
+		return models.ChainFetchTLSError
+	}
+
+	return models.ChainFetchFailed
+}
+
+// diagnoseAttempt tells which side is most likely at fault for a failed AIA
+// fetch and explains it in words.
+func diagnoseAttempt(a models.ChainFetchAttempt) (cause, summary string) {
+	host := a.URL
+	if u, err := url.Parse(a.URL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+
+	switch a.Outcome {
+	case models.ChainFetchInvalidURL:
+		return models.ChainCauseBadURL, fmt.Sprintf("The issuer URL %s in the certificate is not a valid HTTP URL", a.URL)
+	case models.ChainFetchDNSNotFound:
+		return models.ChainCauseBadURL, fmt.Sprintf("The host %s in the certificate's issuer URL does not exist", host)
+	case models.ChainFetchDNSError:
+		return models.ChainCauseNoNetwork, fmt.Sprintf("%s could not be resolved: check the network connection and DNS settings", host)
+	case models.ChainFetchNetworkUnreachable:
+		return models.ChainCauseNoNetwork, fmt.Sprintf("%s is unreachable from this machine: check the network connection", host)
+	case models.ChainFetchProxyError:
+		return models.ChainCauseNoNetwork, fmt.Sprintf("The proxy %s could not be reached: check the proxy settings", a.Proxy)
+	case models.ChainFetchConnectionRefused:
+		return models.ChainCauseCAUnavailable, fmt.Sprintf("The CA endpoint %s refused the connection", host)
+	case models.ChainFetchTimeout:
+		return models.ChainCauseCAUnavailable, fmt.Sprintf("The CA endpoint %s did not answer within %s", host, aiaFetchTimeout)
+	case models.ChainFetchTLSError:
+		return models.ChainCauseCAUnavailable, fmt.Sprintf("The secure connection to %s failed", host)
+	case models.ChainFetchHTTPStatus:
+		if a.StatusCode == http.StatusNotFound || a.StatusCode == http.StatusGone {
+			return models.ChainCauseBadURL, fmt.Sprintf("The CA endpoint %s answered HTTP %d: the issuer URL in the certificate is likely wrong", host, a.StatusCode)
+		}
+		return models.ChainCauseCAUnavailable, fmt.Sprintf("The CA endpoint %s answered HTTP %d", host, a.StatusCode)
+	case models.ChainFetchInvalidCertificate:
+		return models.ChainCauseBadURL, fmt.Sprintf("%s did not return a certificate", a.URL)
+	case models.ChainFetchIssuerMismatch:
+		return models.ChainCauseBadURL, fmt.Sprintf("%s returned a certificate for a different issuer", a.URL)
+	}
+	return models.ChainCauseUnknown, fmt.Sprintf("Fetching %s failed", a.URL)
+}
+
+// describeCert names a certificate in diagnostics
+func describeCert(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" {
+		return fmt.Sprintf("The certificate %q", cert.Subject.CommonName)
+	}
+	return "The certificate"
+}
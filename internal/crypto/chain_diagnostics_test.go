@@ -0,0 +1,129 @@
+package crypto
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"syscall"
+	"testing"
+
+	"paddockcontrol-desktop/internal/models"
+)
+
+func TestClassifyFetchError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"host not found", &net.DNSError{Err: "no such host", Name: "ca.invalid", IsNotFound: true}, models.ChainFetchDNSNotFound},
+		{"resolver failure", &net.DNSError{Err: "server misbehaving", Name: "ca.example.com"}, models.ChainFetchDNSError},
+		{"proxy", &net.OpError{Op: "proxyconnect", Net: "tcp", Err: syscall.ECONNREFUSED}, models.ChainFetchProxyError},
+		{"refused", &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, models.ChainFetchConnectionRefused},
+		{"unreachable", &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ENETUNREACH)}, models.ChainFetchNetworkUnreachable},
+		{"timeout", fmt.Errorf("get: %w", context.DeadlineExceeded), models.ChainFetchTimeout},
+		{"tls", fmt.Errorf("get: %w", x509.UnknownAuthorityError{}), models.ChainFetchTLSError},
+		{"other", errors.New("boom"), models.ChainFetchFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyFetchError(tt.err); got != tt.want {
+				t.Errorf("classifyFetchError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// aiaLeaf returns an unsigned certificate whose issuer is fetched from url;
+// BuildChainFromAIA only reads the names and the AIA URL.
+func aiaLeaf(url string) *x509.Certificate {
+	leaf := &x509.Certificate{
+		Subject: pkix.Name{CommonName: "leaf.example.com"},
+		Issuer:  pkix.Name{CommonName: "Test Issuing CA"},
+	}
+	if url != "" {
+		leaf.IssuingCertificateURL = []string{url}
+	}
+	return leaf
+}
+
+func TestBuildChainFromAIA_Diagnostics(t *testing.T) {
+	other, _ := newTestCert(t, "Another CA", true, nil, nil)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/down", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "maintenance", http.StatusServiceUnavailable)
+	})
+	mux.HandleFunc("/garbage", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<html>not a certificate</html>"))
+	})
+	mux.HandleFunc("/other", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(toPEM(other))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closedURL := closed.URL + "/ca.crt"
+	closed.Close()
+
+	tests := []struct {
+		name       string
+		url        string
+		cause      string
+		outcome    string
+		statusCode int
+	}{
+		{"wrong path", srv.URL + "/missing", models.ChainCauseBadURL, models.ChainFetchHTTPStatus, http.StatusNotFound},
+		{"endpoint erroring", srv.URL + "/down", models.ChainCauseCAUnavailable, models.ChainFetchHTTPStatus, http.StatusServiceUnavailable},
+		{"endpoint down", closedURL, models.ChainCauseCAUnavailable, models.ChainFetchConnectionRefused, 0},
+		{"not a certificate", srv.URL + "/garbage", models.ChainCauseBadURL, models.ChainFetchInvalidCertificate, http.StatusOK},
+		{"wrong issuer", srv.URL + "/other", models.ChainCauseBadURL, models.ChainFetchIssuerMismatch, http.StatusOK},
+		{"unsupported scheme", "ldap://ca.example.com/cn=CA", models.ChainCauseBadURL, models.ChainFetchInvalidURL, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain, err := BuildChainFromAIA(aiaLeaf(tt.url))
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if len(chain) != 0 {
+				t.Errorf("expected an empty chain, got %d certificates", len(chain))
+			}
+			d := ChainDiagnostics(err)
+			if d == nil {
+				t.Fatalf("expected diagnostics, got %v", err)
+			}
+			if d.Cause != tt.cause || d.Summary == "" {
+				t.Errorf("cause = %q (%q), want %q", d.Cause, d.Summary, tt.cause)
+			}
+			if len(d.Attempts) != 1 {
+				t.Fatalf("expected one attempt, got %+v", d.Attempts)
+			}
+			a := d.Attempts[0]
+			if a.URL != tt.url || a.Depth != 1 || a.Outcome != tt.outcome || a.StatusCode != tt.statusCode {
+				t.Errorf("unexpected attempt %+v", a)
+			}
+		})
+	}
+}
+
+func TestBuildChainFromAIA_NoIssuerURL(t *testing.T) {
+	_, err := BuildChainFromAIA(aiaLeaf(""))
+	d := ChainDiagnostics(err)
+	if d == nil || d.Cause != models.ChainCauseBadURL || len(d.Attempts) != 0 {
+		t.Fatalf("unexpected diagnostics %+v for %v", d, err)
+	}
+}
+
+func TestChainDiagnostics_OtherErrors(t *testing.T) {
+	if d := ChainDiagnostics(errors.New("boom")); d != nil {
+		t.Errorf("expected no diagnostics, got %+v", d)
+	}
+}
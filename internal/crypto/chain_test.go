@@ -0,0 +1,106 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func newTestCert(t *testing.T, cn string, isCA bool, parent *x509.Certificate, parentKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	return cert, key
+}
+
+func toPEM(certs ...*x509.Certificate) []byte {
+	var out []byte
+	for _, c := range certs {
+		out = append(out, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Raw})...)
+	}
+	return out
+}
+
+func TestSplitCertificateBundle(t *testing.T) {
+	root, rootKey := newTestCert(t, "Root", true, nil, nil)
+	intermediate, intermediateKey := newTestCert(t, "Intermediate", true, root, rootKey)
+	leaf, _ := newTestCert(t, "leaf.example.com", false, intermediate, intermediateKey)
+
+	tests := []struct {
+		name   string
+		bundle []byte
+		chain  []*x509.Certificate
+	}{
+		{"leaf only", toPEM(leaf), nil},
+		{"ordered", toPEM(leaf, intermediate, root), []*x509.Certificate{intermediate, root}},
+		{"shuffled", toPEM(root, leaf, intermediate), []*x509.Certificate{intermediate, root}},
+		{"duplicate leaf", toPEM(leaf, intermediate, leaf), []*x509.Certificate{intermediate}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotLeaf, gotChain, err := SplitCertificateBundle(tt.bundle)
+			if err != nil {
+				t.Fatalf("SplitCertificateBundle failed: %v", err)
+			}
+			if !gotLeaf.Equal(leaf) {
+				t.Errorf("expected leaf %s, got %s", leaf.Subject.CommonName, gotLeaf.Subject.CommonName)
+			}
+			if len(gotChain) != len(tt.chain) {
+				t.Fatalf("expected %d chain certificates, got %d", len(tt.chain), len(gotChain))
+			}
+			for i := range tt.chain {
+				if !gotChain[i].Equal(tt.chain[i]) {
+					t.Errorf("chain[%d]: expected %s, got %s", i, tt.chain[i].Subject.CommonName, gotChain[i].Subject.CommonName)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitCertificateBundle_NoCertificates(t *testing.T) {
+	if _, _, err := SplitCertificateBundle([]byte("not a certificate")); err == nil {
+		t.Fatal("expected error for input without certificates, got nil")
+	}
+}
+
+func TestBuildChainInfo_MarksRootOnlyWhenSelfSigned(t *testing.T) {
+	root, rootKey := newTestCert(t, "Root", true, nil, nil)
+	intermediate, intermediateKey := newTestCert(t, "Intermediate", true, root, rootKey)
+	leaf, _ := newTestCert(t, "leaf.example.com", false, intermediate, intermediateKey)
+
+	info := BuildChainInfo(leaf, []*x509.Certificate{intermediate})
+	if len(info) != 2 || info[0].CertType != "leaf" || info[1].CertType != "intermediate" {
+		t.Errorf("expected leaf + intermediate, got %+v", info)
+	}
+
+	info = BuildChainInfo(leaf, []*x509.Certificate{intermediate, root})
+	if len(info) != 3 || info[2].CertType != "root" {
+		t.Errorf("expected root as last entry, got %+v", info)
+	}
+}
@@ -1,8 +1,9 @@
 package crypto
 
 import (
+	"crypto"
+	"crypto/ecdsa"
 	"crypto/rand"
-	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/pem"
@@ -22,8 +23,9 @@ type CSRRequest struct {
 	IPSANs             []net.IP // IP Address Subject Alternative Names
 }
 
-// CreateCSR creates a new Certificate Signing Request
-func CreateCSR(req CSRRequest, privateKey *rsa.PrivateKey) ([]byte, error) {
+// CreateCSR creates a new Certificate Signing Request signed with an RSA or
+// ECDSA private key
+func CreateCSR(req CSRRequest, privateKey crypto.Signer) ([]byte, error) {
 	// Create subject
 	subject := pkix.Name{
 		CommonName:   req.CommonName,
@@ -40,7 +42,7 @@ func CreateCSR(req CSRRequest, privateKey *rsa.PrivateKey) ([]byte, error) {
 	// Create CSR template
 	template := x509.CertificateRequest{
 		Subject:            subject,
-		SignatureAlgorithm: x509.SHA256WithRSA,
+		SignatureAlgorithm: csrSignatureAlgorithm(privateKey),
 		DNSNames:           req.DNSSANs,
 		IPAddresses:        req.IPSANs,
 	}
@@ -60,6 +62,18 @@ func CreateCSR(req CSRRequest, privateKey *rsa.PrivateKey) ([]byte, error) {
 	return csrPEM, nil
 }
 
+// csrSignatureAlgorithm picks the CSR signature algorithm for a key: SHA-256
+// with RSA, or ECDSA with the hash matching the curve (SHA-384 on P-384)
+func csrSignatureAlgorithm(key crypto.Signer) x509.SignatureAlgorithm {
+	if pub, ok := key.Public().(*ecdsa.PublicKey); ok {
+		if pub.Curve.Params().BitSize > 256 {
+			return x509.ECDSAWithSHA384
+		}
+		return x509.ECDSAWithSHA256
+	}
+	return x509.SHA256WithRSA
+}
+
 // ParseCSR parses a CSR from PEM format
 func ParseCSR(csrPEM []byte) (*x509.CertificateRequest, error) {
 	block, _ := pem.Decode(csrPEM)
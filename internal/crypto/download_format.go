@@ -0,0 +1,84 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+
+	"paddockcontrol-desktop/internal/models"
+)
+
+// FormatDownload rewrites PEM data about to be saved to a file according to the
+// download options: DER encoding (a single item only), a text header before
+// each certificate and CSR, and CRLF line endings. The PEM is re-encoded, so
+// stray whitespace and long lines are normalized.
+//
+// The result may hold private key material: callers wipe it with Zero once
+// written. With DER, it shares the backing array of data.
+func FormatDownload(data []byte, opts models.DownloadOptions) ([]byte, error) {
+	var blocks []*pem.Block
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		blocks = append(blocks, block)
+	}
+	if len(blocks) == 0 {
+		return nil, fmt.Errorf("no PEM data to download")
+	}
+
+	if opts.Format == models.DownloadFormatDER {
+		if len(blocks) != 1 {
+			return nil, fmt.Errorf("DER holds a single certificate or key, this download has %d; use PEM instead", len(blocks))
+		}
+		return blocks[0].Bytes, nil
+	}
+
+	// Sized up front so the buffer never grows, leaving no stray copy of a key
+	var buf bytes.Buffer
+	buf.Grow(2*len(data) + 512*len(blocks))
+	for _, block := range blocks {
+		if opts.TextHeader {
+			buf.WriteString(pemTextHeader(block))
+		}
+		if err := pem.Encode(&buf, block); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", block.Type, err)
+		}
+	}
+
+	out := buf.Bytes()
+	if opts.LineEndings == models.LineEndingsCRLF {
+		crlf := bytes.ReplaceAll(out, []byte("\n"), []byte("\r\n"))
+		Zero(out)
+		out = crlf
+	}
+	return out, nil
+}
+
+// pemTextHeader describes a certificate or CSR in the openssl style, for
+// people reading the file; other blocks (keys) get no header.
+func pemTextHeader(block *pem.Block) string {
+	const opensslTime = "Jan _2 15:04:05 2006 GMT"
+
+	switch block.Type {
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return ""
+		}
+		return fmt.Sprintf("subject=%s\nissuer=%s\nnotBefore=%s\nnotAfter=%s\n",
+			cert.Subject, cert.Issuer,
+			cert.NotBefore.UTC().Format(opensslTime), cert.NotAfter.UTC().Format(opensslTime))
+	case "CERTIFICATE REQUEST", "NEW CERTIFICATE REQUEST":
+		csr, err := x509.ParseCertificateRequest(block.Bytes)
+		if err != nil {
+			return ""
+		}
+		return fmt.Sprintf("subject=%s\n", csr.Subject)
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+package crypto
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"paddockcontrol-desktop/internal/models"
+)
+
+func TestFormatDownload(t *testing.T) {
+	root, rootKey := newTestCert(t, "Test Root", true, nil, nil)
+	leaf, _ := newTestCert(t, "leaf.example.com", false, root, rootKey)
+	bundle := toPEM(leaf, root)
+	pemLF := models.DownloadOptions{LineEndings: models.LineEndingsLF, Format: models.DownloadFormatPEM}
+
+	// Trailing whitespace and CRLF input are normalized
+	messy := strings.ReplaceAll(string(bundle), "\n", " \r\n")
+	got, err := FormatDownload([]byte(messy), pemLF)
+	if err != nil {
+		t.Fatalf("FormatDownload failed: %v", err)
+	}
+	if !bytes.Equal(got, bundle) {
+		t.Error("expected the PEM to be re-encoded with clean LF line endings")
+	}
+
+	crlf := pemLF
+	crlf.LineEndings = models.LineEndingsCRLF
+	got, err = FormatDownload(bundle, crlf)
+	if err != nil {
+		t.Fatalf("FormatDownload failed: %v", err)
+	}
+	if strings.Count(string(got), "\r\n") != strings.Count(string(bundle), "\n") || strings.Contains(strings.ReplaceAll(string(got), "\r\n", ""), "\n") {
+		t.Error("expected every line to end with CRLF")
+	}
+
+	header := pemLF
+	header.TextHeader = true
+	got, err = FormatDownload(bundle, header)
+	if err != nil {
+		t.Fatalf("FormatDownload failed: %v", err)
+	}
+	if !strings.HasPrefix(string(got), "subject=CN=leaf.example.com\nissuer=CN=Test Root\nnotBefore=") {
+		t.Errorf("expected a text header before the leaf, got %q", string(got)[:80])
+	}
+	if strings.Count(string(got), "subject=") != 2 {
+		t.Error("expected a text header before each certificate")
+	}
+	if _, err := ParseMultipleCertificates(got); err != nil {
+		t.Errorf("headers should not prevent parsing: %v", err)
+	}
+}
+
+func TestFormatDownload_DER(t *testing.T) {
+	root, rootKey := newTestCert(t, "Test Root", true, nil, nil)
+	leaf, _ := newTestCert(t, "leaf.example.com", false, root, rootKey)
+	der := models.DownloadOptions{LineEndings: models.LineEndingsLF, Format: models.DownloadFormatDER}
+
+	got, err := FormatDownload(toPEM(leaf), der)
+	if err != nil {
+		t.Fatalf("FormatDownload failed: %v", err)
+	}
+	if !bytes.Equal(got, leaf.Raw) {
+		t.Error("expected the DER encoding of the certificate")
+	}
+
+	if _, err := FormatDownload(toPEM(leaf, root), der); err == nil {
+		t.Error("expected error for a DER download of several certificates, got nil")
+	}
+	if _, err := FormatDownload([]byte("not PEM"), der); err == nil {
+		t.Error("expected error for data without PEM blocks, got nil")
+	}
+}
@@ -76,7 +76,7 @@ func EncryptPrivateKeyLegacy(pemData []byte, password string) ([]byte, error) {
 
 // EncryptPrivateKey encrypts private key PEM data using AES-256-GCM.
 // The key must be exactly 32 bytes (the raw master key).
-// Returns: nonce (12 bytes) + ciphertext.
+// Returns a current-version envelope (see envelope.go).
 //
 // The caller retains ownership of pemData and should wipe it with Zero once it
 // is no longer needed — this function does not mutate or clear its input.
@@ -109,8 +109,9 @@ func EncryptPrivateKey(pemData []byte, key []byte) ([]byte, error) {
 		return nil, fmt.Errorf("failed to generate nonce: %w", err)
 	}
 
-	// Encrypt (nonce will be prepended to ciphertext by Seal)
-	ciphertext := gcm.Seal(nonce, nonce, pemData, nil)
+	// Encrypt (the authenticated header is prepended to the ciphertext by Seal)
+	header := newEnvelopeHeader(nonce)
+	ciphertext := gcm.Seal(header, nonce, pemData, header)
 
 	log.Debug("private key encrypted successfully", slog.Int("encrypted_size", len(ciphertext)))
 	return ciphertext, nil
@@ -118,8 +119,12 @@ func EncryptPrivateKey(pemData []byte, key []byte) ([]byte, error) {
 
 // DecryptPrivateKey decrypts private key data using AES-256-GCM.
 // The key must be exactly 32 bytes (the raw master key).
-// Expects: nonce (12 bytes) + ciphertext.
-func DecryptPrivateKey(encryptedData []byte, key []byte) ([]byte, error) {
+// Expects an envelope, or a blob in the pre-envelope format: nonce (12 bytes) +
+// ciphertext.
+//
+// The PEM is decrypted straight into a SecretBuffer, which the caller owns and
+// must Destroy once done with it.
+func DecryptPrivateKey(encryptedData []byte, key []byte) (*SecretBuffer, error) {
 	log := logger.WithComponent("crypto")
 	log.Debug("decrypting private key", slog.Int("encrypted_size", len(encryptedData)))
 
@@ -141,6 +146,15 @@ func DecryptPrivateKey(encryptedData []byte, key []byte) ([]byte, error) {
 		return nil, fmt.Errorf("failed to create GCM: %w", err)
 	}
 
+	if hasEnvelopeMagic(encryptedData) {
+		plaintext, err := openEnvelope(gcm, encryptedData)
+		if err == nil {
+			log.Debug("private key decrypted successfully", slog.Int("decrypted_size", plaintext.Len()))
+			return plaintext, nil
+		}
+		log.Debug("envelope did not open, trying the pre-envelope format", logger.Err(err))
+	}
+
 	// Check minimum length
 	nonceSize := gcm.NonceSize()
 	if len(encryptedData) < nonceSize {
@@ -152,12 +166,30 @@ func DecryptPrivateKey(encryptedData []byte, key []byte) ([]byte, error) {
 	nonce, ciphertext := encryptedData[:nonceSize], encryptedData[nonceSize:]
 
 	// Decrypt
-	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	plaintext, err := openIntoSecret(gcm, nonce, ciphertext, nil)
 	if err != nil {
 		log.Error("failed to decrypt", logger.Err(err))
 		return nil, fmt.Errorf("failed to decrypt: %w", err)
 	}
 
-	log.Debug("private key decrypted successfully", slog.Int("decrypted_size", len(plaintext)))
+	log.Debug("private key decrypted successfully", slog.Int("decrypted_size", plaintext.Len()))
 	return plaintext, nil
 }
+
+// openEnvelope decrypts a private key envelope with gcm.
+func openEnvelope(gcm cipher.AEAD, blob []byte) (*SecretBuffer, error) {
+	env, err := parseEnvelope(blob)
+	if err != nil {
+		return nil, err
+	}
+	if env.version != EnvelopeVersion {
+		return nil, fmt.Errorf("unsupported envelope version %d", env.version)
+	}
+	if env.algorithm != EnvelopeAlgAES256GCM {
+		return nil, fmt.Errorf("unsupported envelope algorithm %d", env.algorithm)
+	}
+	if len(env.nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid envelope nonce size %d", len(env.nonce))
+	}
+	return openIntoSecret(gcm, env.nonce, env.ciphertext, env.header)
+}
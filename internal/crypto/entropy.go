@@ -0,0 +1,170 @@
+package crypto
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/bits"
+	"sync"
+	"time"
+
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+)
+
+const (
+	// entropySampleSize is the size of each sample: 20,000 bits, as in the
+	// FIPS 140-2 statistical tests.
+	entropySampleSize = 2500
+	// entropyMonobitMin and entropyMonobitMax bound the number of one bits in a
+	// sample. This is the FIPS 140-2 monobit test widened to about six standard
+	// deviations, so a healthy source practically never fails it.
+	entropyMonobitMin = 9575
+	entropyMonobitMax = 10425
+	// entropyMaxRepeat is the longest run of one byte value tolerated. Six equal
+	// bytes in a row occur by chance about once in 10^9 samples.
+	entropyMaxRepeat = 6
+	// entropyMaxProportion caps how often a single byte value may appear in a
+	// sample; about 10 is expected.
+	entropyMaxProportion = 40
+)
+
+// entropyState remembers the last self-test, so repeated output across runs is
+// detected and the result can be reported in health status.
+var entropyState struct {
+	mu         sync.Mutex
+	lastDigest [sha256.Size]byte
+	result     *models.EntropyCheckResult
+}
+
+// EntropySelfTest checks that the system randomness source behaves before it is
+// used for key material: two samples are read and each must pass basic
+// statistical sanity tests, differ from the other, and differ from the samples
+// of the previous run. The outcome is logged and kept for LastEntropyCheck.
+// Key generation refuses to proceed when it fails.
+func EntropySelfTest() error {
+	entropyState.mu.Lock()
+	defer entropyState.mu.Unlock()
+
+	digest, err := checkEntropySource(rand.Reader, entropyState.lastDigest)
+
+	runs := 1
+	if entropyState.result != nil {
+		runs = entropyState.result.Runs + 1
+	}
+	result := &models.EntropyCheckResult{
+		CheckedAt: time.Now().Unix(),
+		Passed:    err == nil,
+		Runs:      runs,
+	}
+
+	log := logger.WithComponent("crypto")
+	if err != nil {
+		result.Error = err.Error()
+		log.Error("entropy self-test failed", logger.Err(err))
+	} else {
+		entropyState.lastDigest = digest
+		log.Debug("entropy self-test passed", slog.Int("runs", runs))
+	}
+	entropyState.result = result
+
+	return err
+}
+
+// LastEntropyCheck returns the outcome of the last entropy self-test, or nil if
+// none has run yet.
+func LastEntropyCheck() *models.EntropyCheckResult {
+	entropyState.mu.Lock()
+	defer entropyState.mu.Unlock()
+	if entropyState.result == nil {
+		return nil
+	}
+	result := *entropyState.result
+	return &result
+}
+
+// checkEntropySource reads two samples from src and runs the self-test on them.
+// previous is the digest returned by the last successful run (zero on the
+// first). Returns the digest of this run's samples.
+func checkEntropySource(src io.Reader, previous [sha256.Size]byte) ([sha256.Size]byte, error) {
+	var digest [sha256.Size]byte
+
+	first := make([]byte, entropySampleSize)
+	second := make([]byte, entropySampleSize)
+	defer Zero(first)
+	defer Zero(second)
+
+	if _, err := io.ReadFull(src, first); err != nil {
+		return digest, fmt.Errorf("failed to read from entropy source: %w", err)
+	}
+	if _, err := io.ReadFull(src, second); err != nil {
+		return digest, fmt.Errorf("failed to read from entropy source: %w", err)
+	}
+
+	for _, sample := range [][]byte{first, second} {
+		if err := checkEntropySample(sample); err != nil {
+			return digest, err
+		}
+	}
+
+	if bytes.Equal(first, second) {
+		return digest, fmt.Errorf("entropy source returned the same sample twice")
+	}
+
+	h := sha256.New()
+	h.Write(first)
+	h.Write(second)
+	copy(digest[:], h.Sum(nil))
+	if digest == previous {
+		return digest, fmt.Errorf("entropy source repeated the output of the previous run")
+	}
+
+	return digest, nil
+}
+
+// checkEntropySample runs the statistical sanity tests on one sample.
+func checkEntropySample(sample []byte) error {
+	ones := 0
+	var counts [256]int
+	run := 1
+	for i, b := range sample {
+		ones += bits.OnesCount8(b)
+		counts[b]++
+		if i > 0 && b == sample[i-1] {
+			run++
+			if run >= entropyMaxRepeat {
+				return fmt.Errorf("entropy sample repeats byte 0x%02x %d times in a row", b, run)
+			}
+		} else {
+			run = 1
+		}
+	}
+
+	if ones <= entropyMonobitMin || ones >= entropyMonobitMax {
+		return fmt.Errorf("entropy sample is biased: %d of %d bits set", ones, len(sample)*8)
+	}
+	for value, count := range counts {
+		if count > entropyMaxProportion {
+			return fmt.Errorf("entropy sample is biased: byte 0x%02x appears %d times", value, count)
+		}
+	}
+
+	// Random data does not compress; any structure a compressor finds means
+	// the source is predictable.
+	var compressed bytes.Buffer
+	w, err := flate.NewWriter(&compressed, flate.BestCompression)
+	if err != nil {
+		return fmt.Errorf("failed to create compressor: %w", err)
+	}
+	w.Write(sample)
+	w.Close()
+	if compressed.Len() < len(sample)*95/100 {
+		return fmt.Errorf("entropy sample is compressible (%d to %d bytes)", len(sample), compressed.Len())
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// counterReader yields 0, 1, 2, ... 255, 0, 1, ... — balanced but predictable.
+type counterReader struct{ next byte }
+
+func (r *counterReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = r.next
+		r.next++
+	}
+	return len(p), nil
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("device unavailable") }
+
+func TestCheckEntropySource_SystemRandomPasses(t *testing.T) {
+	first, err := checkEntropySource(rand.Reader, [sha256.Size]byte{})
+	if err != nil {
+		t.Fatalf("system randomness should pass: %v", err)
+	}
+	if _, err := checkEntropySource(rand.Reader, first); err != nil {
+		t.Fatalf("second run should pass: %v", err)
+	}
+}
+
+func TestCheckEntropySource_RejectsBadSources(t *testing.T) {
+	random := make([]byte, entropySampleSize)
+	if _, err := rand.Read(random); err != nil {
+		t.Fatalf("failed to read randomness: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		src    io.Reader
+		reason string
+	}{
+		{"zeros", bytes.NewReader(make([]byte, 2*entropySampleSize)), "in a row"},
+		{"counter", &counterReader{}, "compressible"},
+		{"same sample twice", io.MultiReader(bytes.NewReader(random), bytes.NewReader(random)), "same sample"},
+		{"short read", bytes.NewReader(random), "failed to read"},
+		{"read error", failingReader{}, "failed to read"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := checkEntropySource(tt.src, [sha256.Size]byte{})
+			if err == nil {
+				t.Fatal("expected the self-test to fail")
+			}
+			if !strings.Contains(err.Error(), tt.reason) {
+				t.Errorf("error = %v, want it to mention %q", err, tt.reason)
+			}
+		})
+	}
+}
+
+func TestCheckEntropySample_Biased(t *testing.T) {
+	// Every byte has six of eight bits set, in varied positions so no other
+	// test trips first
+	values := []byte{0x3f, 0x7e, 0xfc, 0xf9, 0xf3, 0xe7, 0xcf, 0x9f}
+	sample := make([]byte, entropySampleSize)
+	for i := range sample {
+		sample[i] = values[(i*5+i/8)%len(values)]
+	}
+	if err := checkEntropySample(sample); err == nil {
+		t.Fatal("expected a biased sample to fail")
+	}
+}
+
+func TestCheckEntropySource_RejectsRepeatedRun(t *testing.T) {
+	replay := make([]byte, 2*entropySampleSize)
+	if _, err := rand.Read(replay); err != nil {
+		t.Fatalf("failed to read randomness: %v", err)
+	}
+
+	digest, err := checkEntropySource(bytes.NewReader(replay), [sha256.Size]byte{})
+	if err != nil {
+		t.Fatalf("first run should pass: %v", err)
+	}
+	if _, err := checkEntropySource(bytes.NewReader(replay), digest); err == nil {
+		t.Fatal("a source replaying the previous run's output should fail")
+	}
+}
+
+func TestEntropySelfTest_RecordsResult(t *testing.T) {
+	before := LastEntropyCheck()
+
+	if err := EntropySelfTest(); err != nil {
+		t.Fatalf("EntropySelfTest() error: %v", err)
+	}
+
+	result := LastEntropyCheck()
+	if result == nil || !result.Passed || result.CheckedAt == 0 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if before != nil && result.Runs != before.Runs+1 {
+		t.Errorf("Runs = %d, want %d", result.Runs, before.Runs+1)
+	}
+}
@@ -0,0 +1,77 @@
+package crypto
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Stored private keys are sealed in a versioned envelope, so the cipher can be
+// changed later without guessing the format of existing blobs:
+//
+//	magic "PCKE" | version (1 byte) | algorithm (1 byte) | nonce length (1 byte) | nonce | ciphertext
+//
+// The header up to and including the nonce is authenticated as additional data.
+// Blobs written before the envelope existed (nonce | ciphertext, AES-256-GCM) are
+// still read; MigrateKeyEnvelopes in the services package rewrites them.
+const (
+	envelopeMagic = "PCKE"
+
+	// EnvelopeVersion is the envelope version written by EncryptPrivateKey.
+	EnvelopeVersion = 1
+
+	// EnvelopeAlgAES256GCM identifies AES-256-GCM with a 12-byte nonce.
+	EnvelopeAlgAES256GCM = 1
+
+	envelopeFixedHeaderSize = len(envelopeMagic) + 3
+)
+
+// envelope is a parsed envelope. header covers everything before the ciphertext.
+type envelope struct {
+	version    byte
+	algorithm  byte
+	header     []byte
+	nonce      []byte
+	ciphertext []byte
+}
+
+// hasEnvelopeMagic reports whether blob starts like an envelope. A blob in the
+// old format starts with a random nonce, so it matches by chance once in 2^32;
+// callers fall back to the old format when the envelope does not open.
+func hasEnvelopeMagic(blob []byte) bool {
+	return bytes.HasPrefix(blob, []byte(envelopeMagic))
+}
+
+// parseEnvelope splits an envelope into its header fields and ciphertext.
+func parseEnvelope(blob []byte) (*envelope, error) {
+	if !hasEnvelopeMagic(blob) || len(blob) < envelopeFixedHeaderSize {
+		return nil, fmt.Errorf("not an encryption envelope")
+	}
+	env := &envelope{
+		version:   blob[len(envelopeMagic)],
+		algorithm: blob[len(envelopeMagic)+1],
+	}
+	nonceSize := int(blob[len(envelopeMagic)+2])
+	headerSize := envelopeFixedHeaderSize + nonceSize
+	if len(blob) < headerSize {
+		return nil, fmt.Errorf("encryption envelope too short")
+	}
+	env.header = blob[:headerSize]
+	env.nonce = blob[envelopeFixedHeaderSize:headerSize]
+	env.ciphertext = blob[headerSize:]
+	return env, nil
+}
+
+// newEnvelopeHeader builds the header of a current-version envelope for nonce.
+func newEnvelopeHeader(nonce []byte) []byte {
+	header := make([]byte, 0, envelopeFixedHeaderSize+len(nonce))
+	header = append(header, envelopeMagic...)
+	header = append(header, EnvelopeVersion, EnvelopeAlgAES256GCM, byte(len(nonce)))
+	return append(header, nonce...)
+}
+
+// IsCurrentEnvelope reports whether an encrypted key blob already uses the
+// current envelope version and algorithm, i.e. needs no re-encryption.
+func IsCurrentEnvelope(blob []byte) bool {
+	env, err := parseEnvelope(blob)
+	return err == nil && env.version == EnvelopeVersion && env.algorithm == EnvelopeAlgAES256GCM
+}
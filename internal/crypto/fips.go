@@ -0,0 +1,89 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+)
+
+// FIPSMinRSAKeySize is the smallest RSA modulus accepted in FIPS mode.
+const FIPSMinRSAKeySize = 3072
+
+// ErrFIPSViolation is wrapped by every error returned for an operation that the
+// FIPS-compatible crypto mode forbids.
+var ErrFIPSViolation = errors.New("not allowed in FIPS mode")
+
+// fipsSignatureAlgorithms are the certificate signature algorithms accepted in
+// FIPS mode: RSA (PKCS#1 v1.5 or PSS) and ECDSA with SHA-2.
+var fipsSignatureAlgorithms = map[x509.SignatureAlgorithm]bool{
+	x509.SHA256WithRSA:    true,
+	x509.SHA384WithRSA:    true,
+	x509.SHA512WithRSA:    true,
+	x509.SHA256WithRSAPSS: true,
+	x509.SHA384WithRSAPSS: true,
+	x509.SHA512WithRSAPSS: true,
+	x509.ECDSAWithSHA256:  true,
+	x509.ECDSAWithSHA384:  true,
+	x509.ECDSAWithSHA512:  true,
+}
+
+// CheckFIPSKeySize rejects RSA key sizes below FIPSMinRSAKeySize.
+func CheckFIPSKeySize(keySize int) error {
+	if keySize < FIPSMinRSAKeySize {
+		return fmt.Errorf("%d-bit RSA keys are %w (minimum %d)", keySize, ErrFIPSViolation, FIPSMinRSAKeySize)
+	}
+	return nil
+}
+
+// CheckFIPSPublicKey rejects public keys outside the FIPS mode subset: RSA of at
+// least FIPSMinRSAKeySize bits, or ECDSA on P-256, P-384 or P-521.
+func CheckFIPSPublicKey(pub any) error {
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		return CheckFIPSKeySize(key.N.BitLen())
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256(), elliptic.P384(), elliptic.P521():
+			return nil
+		}
+		return fmt.Errorf("ECDSA curve %s is %w", key.Curve.Params().Name, ErrFIPSViolation)
+	default:
+		return fmt.Errorf("%T keys are %w", pub, ErrFIPSViolation)
+	}
+}
+
+// CheckFIPSCertificate rejects a certificate whose public key or signature
+// algorithm is outside the FIPS mode subset.
+func CheckFIPSCertificate(cert *x509.Certificate) error {
+	if err := CheckFIPSPublicKey(cert.PublicKey); err != nil {
+		return fmt.Errorf("certificate %q: %w", cert.Subject.CommonName, err)
+	}
+	if !fipsSignatureAlgorithms[cert.SignatureAlgorithm] {
+		return fmt.Errorf("certificate %q: %s signatures are %w", cert.Subject.CommonName, cert.SignatureAlgorithm, ErrFIPSViolation)
+	}
+	return nil
+}
+
+// CheckFIPSBundle applies CheckFIPSCertificate to a leaf and its issuer chain.
+// The signature of a self-signed root is never verified, so only its key is
+// checked.
+func CheckFIPSBundle(leaf *x509.Certificate, chain []*x509.Certificate) error {
+	if err := CheckFIPSCertificate(leaf); err != nil {
+		return err
+	}
+	for _, cert := range chain {
+		if isSelfSigned(cert) {
+			if err := CheckFIPSPublicKey(cert.PublicKey); err != nil {
+				return fmt.Errorf("certificate %q: %w", cert.Subject.CommonName, err)
+			}
+			continue
+		}
+		if err := CheckFIPSCertificate(cert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
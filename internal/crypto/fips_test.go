@@ -0,0 +1,88 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestCheckFIPSPublicKey(t *testing.T) {
+	rsa2048, err := GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatalf("GenerateRSAKey() error = %v", err)
+	}
+	rsa3072, err := GenerateRSAKey(3072)
+	if err != nil {
+		t.Fatalf("GenerateRSAKey() error = %v", err)
+	}
+	p224, _ := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	p256, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	edPub, _, _ := ed25519.GenerateKey(rand.Reader)
+
+	tests := []struct {
+		name    string
+		pub     any
+		allowed bool
+	}{
+		{"RSA 2048", &rsa2048.PublicKey, false},
+		{"RSA 3072", &rsa3072.PublicKey, true},
+		{"ECDSA P-224", &p224.PublicKey, false},
+		{"ECDSA P-256", &p256.PublicKey, true},
+		{"Ed25519", edPub, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckFIPSPublicKey(tt.pub)
+			if tt.allowed && err != nil {
+				t.Errorf("expected key to be allowed, got %v", err)
+			}
+			if !tt.allowed && !errors.Is(err, ErrFIPSViolation) {
+				t.Errorf("expected ErrFIPSViolation, got %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckFIPSBundle(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	root, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	if err := CheckFIPSBundle(root, nil); err != nil {
+		t.Errorf("ECDSA P-256 with SHA-256 should be allowed: %v", err)
+	}
+
+	// A SHA-1 signature is refused on a leaf, but not on a self-signed root
+	sha1 := *root
+	sha1.SignatureAlgorithm = x509.ECDSAWithSHA1
+	if err := CheckFIPSBundle(&sha1, nil); !errors.Is(err, ErrFIPSViolation) {
+		t.Errorf("expected ErrFIPSViolation for a SHA-1 leaf, got %v", err)
+	}
+	if err := CheckFIPSBundle(root, []*x509.Certificate{&sha1}); err != nil {
+		t.Errorf("self-signed root signatures should not be checked: %v", err)
+	}
+}
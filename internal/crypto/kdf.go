@@ -4,6 +4,9 @@ import (
 	"crypto/rand"
 	"fmt"
 	"io"
+	"strconv"
+
+	"paddockcontrol-desktop/internal/timing"
 
 	"golang.org/x/crypto/argon2"
 )
@@ -28,6 +31,35 @@ func DefaultArgon2idParams() Argon2idParams {
 	}
 }
 
+// KDF profiles (config.kdf_profile) select the Argon2id cost of new password
+// wraps. Existing wraps keep the parameters stored with them.
+const (
+	KDFProfileStandard    = "standard"
+	KDFProfileConstrained = "constrained" // 32-bit builds, whose address space is small
+)
+
+// DetectKDFProfile returns the KDF profile suited to the running build, stored
+// at first run.
+func DetectKDFProfile() string {
+	if strconv.IntSize == 32 {
+		return KDFProfileConstrained
+	}
+	return KDFProfileStandard
+}
+
+// Argon2idParamsForProfile returns the Argon2id parameters of a KDF profile.
+// The constrained profile trades memory for passes, staying above the OWASP
+// minimum; unknown profiles get the defaults.
+func Argon2idParamsForProfile(profile string) Argon2idParams {
+	params := DefaultArgon2idParams()
+	if profile == KDFProfileConstrained {
+		params.Memory = 32 * 1024 // 32 MiB
+		params.Iterations = 4
+		params.Parallelism = 2
+	}
+	return params
+}
+
 // Validate enforces lower bounds on Argon2id parameters. It guards against
 // derivation from absent/zero metadata (which would otherwise panic inside
 // argon2.IDKey when iterations or parallelism are 0) and against a tampered
@@ -63,6 +95,8 @@ func GenerateSalt(length uint32) ([]byte, error) {
 
 // DeriveKeyFromPassword derives a wrapping key from a password using Argon2id.
 func DeriveKeyFromPassword(password string, salt []byte, params Argon2idParams) []byte {
+	defer timing.Start(timing.PasswordKDF)()
+
 	return argon2.IDKey(
 		[]byte(password),
 		salt,
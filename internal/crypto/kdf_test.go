@@ -1,6 +1,7 @@
 package crypto
 
 import (
+	"strconv"
 	"testing"
 )
 
@@ -42,3 +43,25 @@ func TestArgon2idParams_Validate(t *testing.T) {
 		}
 	}
 }
+
+func TestArgon2idParamsForProfile(t *testing.T) {
+	if got := Argon2idParamsForProfile(KDFProfileStandard); got != DefaultArgon2idParams() {
+		t.Errorf("standard profile = %+v, want the defaults", got)
+	}
+
+	constrained := Argon2idParamsForProfile(KDFProfileConstrained)
+	if err := constrained.Validate(); err != nil {
+		t.Fatalf("constrained params should be valid, got %v", err)
+	}
+	if constrained.Memory >= DefaultArgon2idParams().Memory {
+		t.Errorf("expected the constrained profile to use less memory, got %d KiB", constrained.Memory)
+	}
+
+	want := KDFProfileStandard
+	if strconv.IntSize == 32 {
+		want = KDFProfileConstrained
+	}
+	if got := DetectKDFProfile(); got != want {
+		t.Errorf("DetectKDFProfile() = %q, want %q", got, want)
+	}
+}
@@ -1,6 +1,10 @@
 package crypto
 
 import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
 	"testing"
 )
 
@@ -11,6 +15,79 @@ func TestGenerateRSAKey_RejectsUndersizedKey(t *testing.T) {
 	}
 }
 
+func TestParsePrivateKeyFromPEM_LeavesInputIntact(t *testing.T) {
+	key, err := GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatalf("GenerateRSAKey() error = %v", err)
+	}
+	pemData, err := PrivateKeyToPEM(key)
+	if err != nil {
+		t.Fatalf("PrivateKeyToPEM() error = %v", err)
+	}
+	original := bytes.Clone(pemData)
+
+	// The decoded DER is wiped internally; the caller's PEM must not be touched
+	parsed, err := ParsePrivateKeyFromPEM(pemData)
+	if err != nil {
+		t.Fatalf("ParsePrivateKeyFromPEM() error = %v", err)
+	}
+	if !bytes.Equal(pemData, original) {
+		t.Fatal("ParsePrivateKeyFromPEM() should not modify its input")
+	}
+	if !key.Equal(parsed) {
+		t.Fatal("parsed key does not match the original")
+	}
+}
+
+func TestGenerateECDSAKey_RoundTrip(t *testing.T) {
+	for _, bits := range []int{256, 384} {
+		key, err := GenerateKey("ecdsa", bits)
+		if err != nil {
+			t.Fatalf("GenerateKey(ecdsa, %d) error = %v", bits, err)
+		}
+		if algorithm, size := PublicKeyInfo(key.Public()); algorithm != "ecdsa" || size != bits {
+			t.Errorf("PublicKeyInfo() = %s %d, want ecdsa %d", algorithm, size, bits)
+		}
+
+		pemData, err := PrivateKeyToPEM(key)
+		if err != nil {
+			t.Fatalf("PrivateKeyToPEM() error = %v", err)
+		}
+		if !bytes.Contains(pemData, []byte("EC PRIVATE KEY")) {
+			t.Errorf("expected an EC PRIVATE KEY block, got %s", pemData)
+		}
+		parsed, err := ParsePrivateKeyFromPEM(pemData)
+		if err != nil {
+			t.Fatalf("ParsePrivateKeyFromPEM() error = %v", err)
+		}
+		if !key.(*ecdsa.PrivateKey).Equal(parsed) {
+			t.Errorf("P-%d key does not survive a PEM round trip", bits)
+		}
+
+		// Keys exported by other tools are often PKCS#8
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatalf("MarshalPKCS8PrivateKey() error = %v", err)
+		}
+		parsed, err = ParsePrivateKeyFromPEM(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+		if err != nil {
+			t.Fatalf("ParsePrivateKeyFromPEM(PKCS#8) error = %v", err)
+		}
+		if !key.(*ecdsa.PrivateKey).Equal(parsed) {
+			t.Errorf("P-%d PKCS#8 key does not match the original", bits)
+		}
+	}
+}
+
+func TestGenerateKey_RejectsUnsupported(t *testing.T) {
+	if _, err := GenerateECDSAKey(521); err == nil {
+		t.Error("GenerateECDSAKey(521) should reject curves other than P-256 and P-384")
+	}
+	if _, err := GenerateKey("ed25519", 256); err == nil {
+		t.Error("GenerateKey should reject unknown algorithms")
+	}
+}
+
 func BenchmarkGenerateRSAKey2048(b *testing.B) {
 	for b.Loop() {
 		_, _ = GenerateRSAKey(2048)
@@ -8,10 +8,17 @@ import (
 	"io"
 )
 
-// GenerateMasterKey generates a cryptographically random 32-byte master key.
-func GenerateMasterKey() ([]byte, error) {
-	key := make([]byte, 32)
-	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+// GenerateMasterKey generates a cryptographically random 32-byte master key,
+// after the entropy self-test passes.
+// The caller owns the returned buffer and must Destroy it.
+func GenerateMasterKey() (*SecretBuffer, error) {
+	if err := EntropySelfTest(); err != nil {
+		return nil, fmt.Errorf("refusing to generate master key: %w", err)
+	}
+
+	key := NewSecretBuffer(32)
+	if _, err := io.ReadFull(rand.Reader, key.Bytes()); err != nil {
+		key.Destroy()
 		return nil, fmt.Errorf("failed to generate master key: %w", err)
 	}
 	return key, nil
@@ -43,8 +50,9 @@ func WrapMasterKey(masterKey, wrappingKey []byte) ([]byte, error) {
 }
 
 // UnwrapMasterKey decrypts the master key with a wrapping key using AES-256-GCM.
-// Expects: nonce (12 bytes) + ciphertext.
-func UnwrapMasterKey(wrappedKey, wrappingKey []byte) ([]byte, error) {
+// Expects: nonce (12 bytes) + ciphertext. The key is decrypted straight into a
+// SecretBuffer, which the caller owns and must Destroy.
+func UnwrapMasterKey(wrappedKey, wrappingKey []byte) (*SecretBuffer, error) {
 	if len(wrappingKey) != 32 {
 		return nil, fmt.Errorf("wrapping key must be exactly 32 bytes, got %d", len(wrappingKey))
 	}
@@ -65,10 +73,24 @@ func UnwrapMasterKey(wrappedKey, wrappingKey []byte) ([]byte, error) {
 	}
 
 	nonce, ciphertext := wrappedKey[:nonceSize], wrappedKey[nonceSize:]
-	masterKey, err := gcm.Open(nil, nonce, ciphertext, nil)
+	masterKey, err := openIntoSecret(gcm, nonce, ciphertext, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to unwrap master key: %w", err)
 	}
 
 	return masterKey, nil
 }
+
+// openIntoSecret decrypts an AES-GCM ciphertext directly into a SecretBuffer so
+// the plaintext never exists in an ordinary heap slice.
+func openIntoSecret(gcm cipher.AEAD, nonce, ciphertext, additionalData []byte) (*SecretBuffer, error) {
+	if len(ciphertext) < gcm.Overhead() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	plaintext := NewSecretBuffer(len(ciphertext) - gcm.Overhead())
+	if _, err := gcm.Open(plaintext.Bytes()[:0], nonce, ciphertext, additionalData); err != nil {
+		plaintext.Destroy()
+		return nil, err
+	}
+	return plaintext, nil
+}
@@ -7,7 +7,7 @@ import (
 func TestWrapMasterKeyInvalidKeyLength(t *testing.T) {
 	masterKey, _ := GenerateMasterKey()
 
-	_, err := WrapMasterKey(masterKey, []byte("short"))
+	_, err := WrapMasterKey(masterKey.Bytes(), []byte("short"))
 	if err == nil {
 		t.Fatal("WrapMasterKey() should fail with short wrapping key")
 	}
@@ -21,7 +21,7 @@ func TestWrapMasterKeyInvalidKeyLength(t *testing.T) {
 func TestUnwrapMasterKeyTooShort(t *testing.T) {
 	wrappingKey, _ := GenerateMasterKey()
 
-	_, err := UnwrapMasterKey([]byte("short"), wrappingKey)
+	_, err := UnwrapMasterKey([]byte("short"), wrappingKey.Bytes())
 	if err == nil {
 		t.Fatal("UnwrapMasterKey() should fail with too-short wrapped data")
 	}
@@ -0,0 +1,453 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/hmac"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"hash"
+	"math/big"
+	"unicode/utf16"
+)
+
+// PKCS#12 (RFC 7292) encoding, for servers and keystores that only import
+// .pfx files. Only what EncodePKCS12 writes is implemented; there is no decoder.
+
+var (
+	oidData                  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidEncryptedData         = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 6}
+	oidCertBag               = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 3}
+	oidPKCS8ShroudedKeyBag   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 10, 1, 2}
+	oidX509Certificate       = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 22, 1}
+	oidFriendlyName          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 20}
+	oidLocalKeyID            = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 21}
+	oidPBES2                 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2                = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACWithSHA256        = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidAES256CBC             = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	oidPBEWithSHAAnd3KeyTDES = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 12, 1, 3}
+	oidSHA1                  = asn1.ObjectIdentifier{1, 3, 14, 3, 2, 26}
+	oidSHA256                = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+)
+
+const (
+	pkcs12ModernIterations = 10000
+	pkcs12LegacyIterations = 2048
+	pkcs12SaltSize         = 16
+
+	// Purpose IDs of the PKCS#12 KDF (RFC 7292 appendix B.3)
+	pkcs12KDFKeyMaterial byte = 1
+	pkcs12KDFIV          byte = 2
+	pkcs12KDFMAC         byte = 3
+)
+
+// PKCS12Options selects how a PKCS#12 file is protected
+type PKCS12Options struct {
+	// Legacy uses 3DES and a SHA-1 MAC, for Windows Server 2016 and older and
+	// Java 8 keystores; otherwise AES-256-CBC with PBKDF2-SHA256 and a SHA-256
+	// MAC, as written by OpenSSL 3
+	Legacy bool
+	// FriendlyName labels the certificate and key (the name shown by the
+	// Windows certificate store and the keystore alias)
+	FriendlyName string
+}
+
+type pkcs12ContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue // [0] EXPLICIT, see explicitContent
+}
+
+type pkcs12EncryptedData struct {
+	Version              int
+	EncryptedContentInfo pkcs12EncryptedContentInfo
+}
+
+type pkcs12EncryptedContentInfo struct {
+	ContentType                asn1.ObjectIdentifier
+	ContentEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedContent           []byte `asn1:"tag:0,optional"`
+}
+
+type pkcs12SafeBag struct {
+	ID         asn1.ObjectIdentifier
+	Value      asn1.RawValue     // [0] EXPLICIT, see explicitContent
+	Attributes []pkcs12Attribute `asn1:"set,optional"`
+}
+
+type pkcs12Attribute struct {
+	ID     asn1.ObjectIdentifier
+	Values asn1.RawValue // SET OF, tagged by hand
+}
+
+type pkcs12CertBag struct {
+	ID   asn1.ObjectIdentifier
+	Data []byte `asn1:"tag:0,explicit"`
+}
+
+type pkcs12EncryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+type pkcs12DigestInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	Digest    []byte
+}
+
+type pkcs12MacData struct {
+	Mac        pkcs12DigestInfo
+	MacSalt    []byte
+	Iterations int `asn1:"optional,default:1"`
+}
+
+type pkcs12PFX struct {
+	Version  int
+	AuthSafe pkcs12ContentInfo
+	MacData  pkcs12MacData `asn1:"optional"`
+}
+
+type pkcs12PBEParams struct {
+	Salt       []byte
+	Iterations int
+}
+
+type pkcs12PBES2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pkcs12PBKDF2Params struct {
+	Salt       []byte
+	Iterations int
+	KeyLength  int `asn1:"optional"`
+	PRF        pkix.AlgorithmIdentifier
+}
+
+// EncodePKCS12 builds a password-protected PKCS#12 file holding the private
+// key, its certificate and the chain (intermediates and root, leaf excluded).
+// The key and certificate are linked by a localKeyId so Windows and Java pair
+// them on import.
+func EncodePKCS12(key crypto.Signer, leaf *x509.Certificate, chain []*x509.Certificate, password string, opts PKCS12Options) ([]byte, error) {
+	if !ComparePublicKeys(key.Public(), leaf.PublicKey) {
+		return nil, fmt.Errorf("private key does not match the certificate")
+	}
+
+	localKeyID := sha1.Sum(leaf.Raw)
+	leafAttributes, err := pkcs12BagAttributes(localKeyID[:], opts.FriendlyName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Certificates: the leaf first, tagged with the key's ID, then the chain
+	var certBags []pkcs12SafeBag
+	for i, cert := range append([]*x509.Certificate{leaf}, chain...) {
+		bag, err := pkcs12Bag(oidCertBag, pkcs12CertBag{ID: oidX509Certificate, Data: cert.Raw})
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			bag.Attributes = leafAttributes
+		}
+		certBags = append(certBags, bag)
+	}
+	certContents, err := asn1.Marshal(certBags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode certificates: %w", err)
+	}
+	certAlgorithm, encryptedCerts, err := pkcs12Encrypt(certContents, password, opts.Legacy)
+	if err != nil {
+		return nil, err
+	}
+	encryptedData, err := asn1.Marshal(pkcs12EncryptedData{
+		EncryptedContentInfo: pkcs12EncryptedContentInfo{
+			ContentType:                oidData,
+			ContentEncryptionAlgorithm: certAlgorithm,
+			EncryptedContent:           encryptedCerts,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode certificates: %w", err)
+	}
+
+	// Private key, encrypted as a PKCS#8 shrouded key bag
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+	keyAlgorithm, encryptedKey, err := pkcs12Encrypt(keyDER, password, opts.Legacy)
+	Zero(keyDER)
+	if err != nil {
+		return nil, err
+	}
+	keyBag, err := pkcs12Bag(oidPKCS8ShroudedKeyBag, pkcs12EncryptedPrivateKeyInfo{
+		Algorithm:     keyAlgorithm,
+		EncryptedData: encryptedKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	keyBag.Attributes = leafAttributes
+	keyContents, err := asn1.Marshal([]pkcs12SafeBag{keyBag})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode private key: %w", err)
+	}
+
+	certsInfo := pkcs12ContentInfo{ContentType: oidEncryptedData, Content: explicitContent(encryptedData)}
+	keyInfo := pkcs12ContentInfo{ContentType: oidData}
+	if keyInfo.Content, err = octetStringContent(keyContents); err != nil {
+		return nil, err
+	}
+	authSafe, err := asn1.Marshal([]pkcs12ContentInfo{certsInfo, keyInfo})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode PKCS#12 contents: %w", err)
+	}
+
+	macData, err := pkcs12MAC(authSafe, password, opts.Legacy)
+	if err != nil {
+		return nil, err
+	}
+	pfx := pkcs12PFX{
+		Version:  3,
+		AuthSafe: pkcs12ContentInfo{ContentType: oidData},
+		MacData:  macData,
+	}
+	if pfx.AuthSafe.Content, err = octetStringContent(authSafe); err != nil {
+		return nil, err
+	}
+	out, err := asn1.Marshal(pfx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode PKCS#12 file: %w", err)
+	}
+	return out, nil
+}
+
+func pkcs12Bag(id asn1.ObjectIdentifier, value any) (pkcs12SafeBag, error) {
+	der, err := asn1.Marshal(value)
+	if err != nil {
+		return pkcs12SafeBag{}, fmt.Errorf("failed to encode PKCS#12 bag: %w", err)
+	}
+	return pkcs12SafeBag{ID: id, Value: explicitContent(der)}, nil
+}
+
+// pkcs12BagAttributes returns the localKeyId and, if set, friendlyName
+// attributes shared by the leaf certificate and key bags
+func pkcs12BagAttributes(localKeyID []byte, friendlyName string) ([]pkcs12Attribute, error) {
+	id, err := asn1.Marshal(localKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode local key ID: %w", err)
+	}
+	attributes := []pkcs12Attribute{{ID: oidLocalKeyID, Values: asn1.RawValue{Tag: asn1.TagSet, Class: asn1.ClassUniversal, IsCompound: true, Bytes: id}}}
+	if friendlyName != "" {
+		name, err := asn1.Marshal(asn1.RawValue{Tag: asn1.TagBMPString, Class: asn1.ClassUniversal, Bytes: bmpString(friendlyName, false)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode friendly name: %w", err)
+		}
+		attributes = append(attributes, pkcs12Attribute{ID: oidFriendlyName, Values: asn1.RawValue{Tag: asn1.TagSet, Class: asn1.ClassUniversal, IsCompound: true, Bytes: name}})
+	}
+	return attributes, nil
+}
+
+// explicitContent wraps DER in a [0] EXPLICIT tag, as the content of a
+// ContentInfo or the value of a SafeBag. encoding/asn1 ignores struct tags on
+// RawValue fields, so the wrapper is built by hand.
+func explicitContent(der []byte) asn1.RawValue {
+	return asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: der}
+}
+
+// octetStringContent wraps data in an OCTET STRING as the [0] EXPLICIT
+// content of a ContentInfo of type data
+func octetStringContent(data []byte) (asn1.RawValue, error) {
+	octets, err := asn1.Marshal(data)
+	if err != nil {
+		return asn1.RawValue{}, fmt.Errorf("failed to encode PKCS#12 contents: %w", err)
+	}
+	return explicitContent(octets), nil
+}
+
+// pkcs12Encrypt encrypts data with a fresh salt: PBES2 (PBKDF2-SHA256,
+// AES-256-CBC) or, for legacy files, pbeWithSHAAnd3-KeyTripleDES-CBC
+func pkcs12Encrypt(data []byte, password string, legacy bool) (pkix.AlgorithmIdentifier, []byte, error) {
+	salt := make([]byte, pkcs12SaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return pkix.AlgorithmIdentifier{}, nil, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	var block cipher.Block
+	var iv []byte
+	var algorithm pkix.AlgorithmIdentifier
+	if legacy {
+		bmpPassword := bmpString(password, true)
+		key := pkcs12KDF(sha1.New, pkcs12KDFKeyMaterial, bmpPassword, salt, pkcs12LegacyIterations, 24)
+		iv = pkcs12KDF(sha1.New, pkcs12KDFIV, bmpPassword, salt, pkcs12LegacyIterations, des.BlockSize)
+		Zero(bmpPassword)
+		var err error
+		block, err = des.NewTripleDESCipher(key)
+		Zero(key)
+		if err != nil {
+			return pkix.AlgorithmIdentifier{}, nil, fmt.Errorf("failed to create cipher: %w", err)
+		}
+		params, err := asn1.Marshal(pkcs12PBEParams{Salt: salt, Iterations: pkcs12LegacyIterations})
+		if err != nil {
+			return pkix.AlgorithmIdentifier{}, nil, fmt.Errorf("failed to encode PBE parameters: %w", err)
+		}
+		algorithm = pkix.AlgorithmIdentifier{Algorithm: oidPBEWithSHAAnd3KeyTDES, Parameters: asn1.RawValue{FullBytes: params}}
+	} else {
+		key, err := pbkdf2.Key(sha256.New, password, salt, pkcs12ModernIterations, 32)
+		if err != nil {
+			return pkix.AlgorithmIdentifier{}, nil, fmt.Errorf("failed to derive key: %w", err)
+		}
+		block, err = aes.NewCipher(key)
+		Zero(key)
+		if err != nil {
+			return pkix.AlgorithmIdentifier{}, nil, fmt.Errorf("failed to create cipher: %w", err)
+		}
+		iv = make([]byte, aes.BlockSize)
+		if _, err := rand.Read(iv); err != nil {
+			return pkix.AlgorithmIdentifier{}, nil, fmt.Errorf("failed to generate IV: %w", err)
+		}
+		if algorithm, err = pbes2Algorithm(salt, iv); err != nil {
+			return pkix.AlgorithmIdentifier{}, nil, err
+		}
+	}
+
+	// PKCS#7 padding, always at least one byte
+	padding := block.BlockSize() - len(data)%block.BlockSize()
+	ciphertext := make([]byte, len(data)+padding)
+	copy(ciphertext, data)
+	copy(ciphertext[len(data):], bytes.Repeat([]byte{byte(padding)}, padding))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, ciphertext)
+	return algorithm, ciphertext, nil
+}
+
+func pbes2Algorithm(salt, iv []byte) (pkix.AlgorithmIdentifier, error) {
+	kdfParams, err := asn1.Marshal(pkcs12PBKDF2Params{
+		Salt:       salt,
+		Iterations: pkcs12ModernIterations,
+		KeyLength:  32,
+		PRF:        pkix.AlgorithmIdentifier{Algorithm: oidHMACWithSHA256, Parameters: asn1.NullRawValue},
+	})
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, fmt.Errorf("failed to encode PBKDF2 parameters: %w", err)
+	}
+	ivParam, err := asn1.Marshal(iv)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, fmt.Errorf("failed to encode IV: %w", err)
+	}
+	params, err := asn1.Marshal(pkcs12PBES2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{Algorithm: oidPBKDF2, Parameters: asn1.RawValue{FullBytes: kdfParams}},
+		EncryptionScheme:  pkix.AlgorithmIdentifier{Algorithm: oidAES256CBC, Parameters: asn1.RawValue{FullBytes: ivParam}},
+	})
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, fmt.Errorf("failed to encode PBES2 parameters: %w", err)
+	}
+	return pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: asn1.RawValue{FullBytes: params}}, nil
+}
+
+// pkcs12MAC computes the integrity MAC over the authenticated safe: HMAC with
+// a key from the PKCS#12 KDF, SHA-256 or SHA-1 for legacy files
+func pkcs12MAC(authSafe []byte, password string, legacy bool) (pkcs12MacData, error) {
+	salt := make([]byte, pkcs12SaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return pkcs12MacData{}, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	h, digestOID, iterations := sha256.New, oidSHA256, pkcs12ModernIterations
+	if legacy {
+		h, digestOID, iterations = sha1.New, oidSHA1, pkcs12LegacyIterations
+	}
+
+	bmpPassword := bmpString(password, true)
+	key := pkcs12KDF(h, pkcs12KDFMAC, bmpPassword, salt, iterations, h().Size())
+	Zero(bmpPassword)
+	mac := hmac.New(h, key)
+	mac.Write(authSafe)
+	Zero(key)
+
+	return pkcs12MacData{
+		Mac: pkcs12DigestInfo{
+			Algorithm: pkix.AlgorithmIdentifier{Algorithm: digestOID, Parameters: asn1.NullRawValue},
+			Digest:    mac.Sum(nil),
+		},
+		MacSalt:    salt,
+		Iterations: iterations,
+	}, nil
+}
+
+// pkcs12KDF is the key derivation of RFC 7292 appendix B.2. id selects key
+// material (1), IV (2) or MAC key (3); password is a BMPString.
+func pkcs12KDF(h func() hash.Hash, id byte, password, salt []byte, iterations, size int) []byte {
+	const v = 64 // block size of SHA-1 and SHA-256
+	u := h().Size()
+
+	D := bytes.Repeat([]byte{id}, v)
+	fill := func(b []byte) []byte {
+		if len(b) == 0 {
+			return nil
+		}
+		out := make([]byte, v*((len(b)+v-1)/v))
+		for i := range out {
+			out[i] = b[i%len(b)]
+		}
+		return out
+	}
+	I := append(fill(salt), fill(password)...)
+	defer Zero(I)
+
+	one := big.NewInt(1)
+	out := make([]byte, 0, size+u)
+	for len(out) < size {
+		digest := h()
+		digest.Write(D)
+		digest.Write(I)
+		A := digest.Sum(nil)
+		for j := 1; j < iterations; j++ {
+			digest.Reset()
+			digest.Write(A)
+			A = digest.Sum(A[:0])
+		}
+		out = append(out, A...)
+		if len(out) >= size {
+			break
+		}
+
+		// I_j = (I_j + B + 1) mod 2^(v*8) for each v-byte block of I
+		B := new(big.Int).SetBytes(fill(A))
+		B.Add(B, one)
+		for j := 0; j < len(I); j += v {
+			Ij := new(big.Int).SetBytes(I[j : j+v])
+			Ij.Add(Ij, B)
+			sum := Ij.Bytes()
+			if len(sum) > v {
+				sum = sum[len(sum)-v:]
+			}
+			block := I[j : j+v]
+			clear(block)
+			copy(block[v-len(sum):], sum)
+		}
+	}
+	return out[:size]
+}
+
+// bmpString encodes s as UTF-16 big-endian, with the two-byte terminator the
+// PKCS#12 KDF expects when terminated is set
+func bmpString(s string, terminated bool) []byte {
+	units := utf16.Encode([]rune(s))
+	out := make([]byte, 0, 2*len(units)+2)
+	for _, unit := range units {
+		out = append(out, byte(unit>>8), byte(unit))
+	}
+	if terminated {
+		out = append(out, 0, 0)
+	}
+	return out
+}
@@ -0,0 +1,127 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/pkcs12"
+)
+
+func TestEncodePKCS12_Legacy(t *testing.T) {
+	root, rootKey := newTestCert(t, "Test Root", true, nil, nil)
+	intermediate, intermediateKey := newTestCert(t, "Test Intermediate", true, root, rootKey)
+	leaf, leafKey := newTestCert(t, "leaf.example.com", false, intermediate, intermediateKey)
+
+	pfx, err := EncodePKCS12(leafKey, leaf, []*x509.Certificate{intermediate, root}, "s3cret", PKCS12Options{Legacy: true, FriendlyName: "leaf.example.com"})
+	if err != nil {
+		t.Fatalf("EncodePKCS12 failed: %v", err)
+	}
+
+	blocks, err := pkcs12.ToPEM(pfx, "s3cret")
+	if err != nil {
+		t.Fatalf("failed to decode PKCS#12: %v", err)
+	}
+	var certs []*x509.Certificate
+	var keys int
+	for _, block := range blocks {
+		switch block.Type {
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				t.Fatalf("failed to parse certificate: %v", err)
+			}
+			certs = append(certs, cert)
+		case "PRIVATE KEY":
+			keys++
+			key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+			if err != nil {
+				t.Fatalf("failed to parse private key: %v", err)
+			}
+			if !key.Equal(leafKey) {
+				t.Error("decoded key does not match the leaf key")
+			}
+			if block.Headers["friendlyName"] != "leaf.example.com" {
+				t.Errorf("unexpected key friendly name: %q", block.Headers["friendlyName"])
+			}
+		}
+	}
+	if keys != 1 || len(certs) != 3 {
+		t.Fatalf("expected 1 key and 3 certificates, got %d and %d", keys, len(certs))
+	}
+	if !certs[0].Equal(leaf) || !certs[1].Equal(intermediate) || !certs[2].Equal(root) {
+		t.Error("certificates are not in leaf, intermediate, root order")
+	}
+
+	if _, err := pkcs12.ToPEM(pfx, "wrong"); err == nil {
+		t.Error("expected a wrong password to be rejected")
+	}
+}
+
+func TestEncodePKCS12_ModernOpenSSL(t *testing.T) {
+	openssl, err := exec.LookPath("openssl")
+	if err != nil {
+		t.Skip("openssl not available")
+	}
+
+	root, rootKey := newTestCert(t, "Test Root", true, nil, nil)
+	template := *root
+	template.Subject = pkix.Name{CommonName: "leaf.example.com"}
+	template.IsCA = false
+	key, err := GenerateECDSAKey(384)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, root, key.Public(), rootKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	pfx, err := EncodePKCS12(key, leaf, []*x509.Certificate{root}, "pässword", PKCS12Options{FriendlyName: "leaf"})
+	if err != nil {
+		t.Fatalf("EncodePKCS12 failed: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "bundle.pfx")
+	if err := os.WriteFile(path, pfx, 0600); err != nil {
+		t.Fatalf("failed to write bundle: %v", err)
+	}
+
+	out, err := exec.Command(openssl, "pkcs12", "-in", path, "-passin", "pass:pässword", "-nodes", "-info").CombinedOutput()
+	if err != nil {
+		t.Fatalf("openssl rejected the bundle: %v\n%s", err, out)
+	}
+	output := string(out)
+	for _, want := range []string{"MAC: sha256", "PBES2, PBKDF2, AES-256-CBC", "BEGIN PRIVATE KEY", "friendlyName: leaf"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected openssl output to contain %q:\n%s", want, output)
+		}
+	}
+	if n := strings.Count(output, "BEGIN CERTIFICATE"); n != 2 {
+		t.Errorf("expected 2 certificates, got %d", n)
+	}
+
+	if out, err := exec.Command(openssl, "pkcs12", "-in", path, "-passin", "pass:wrong", "-nodes").CombinedOutput(); err == nil {
+		t.Errorf("expected openssl to reject a wrong password:\n%s", out)
+	}
+}
+
+func TestEncodePKCS12_RejectsMismatchedKey(t *testing.T) {
+	leaf, _ := newTestCert(t, "leaf.example.com", false, nil, nil)
+	other, err := GenerateECDSAKey(256)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	if _, err := EncodePKCS12(other, leaf, nil, "s3cret", PKCS12Options{}); err == nil {
+		t.Error("expected a key that does not match the certificate to be rejected")
+	}
+}
@@ -0,0 +1,150 @@
+package crypto
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"unsafe"
+)
+
+const redacted = "[REDACTED]"
+
+// SecretBuffer holds sensitive bytes — the master key, unwrapped keys, decrypted
+// private key PEMs — outside of ordinary Go slices:
+//
+//   - the bytes live on their own memory pages, locked against swapping where the
+//     platform allows it (mlock on unix, VirtualLock on Windows);
+//   - Destroy wipes and unlocks the pages; buffers that are never destroyed are
+//     wiped when garbage collected;
+//   - the contents never leak through fmt, slog or JSON: all render "[REDACTED]".
+//
+// Locking is best-effort: if the OS refuses (e.g. RLIMIT_MEMLOCK exhausted) the
+// buffer still works, unlocked. Bytes exposes the contents for crypto calls; do
+// not retain the returned slice past Destroy.
+type SecretBuffer struct {
+	data    []byte
+	page    *secretPage
+	cleanup runtime.Cleanup
+}
+
+// secretPage is the page-aligned region backing a SecretBuffer. It is kept
+// separate so the GC cleanup can wipe it without referencing the buffer itself.
+type secretPage struct {
+	mem    []byte
+	locked bool
+}
+
+func (p *secretPage) release() {
+	clear(p.mem)
+	if p.locked {
+		_ = unlockMemory(p.mem)
+		p.locked = false
+	}
+}
+
+// NewSecretBuffer allocates a zeroed secret buffer of size bytes.
+func NewSecretBuffer(size int) *SecretBuffer {
+	if size <= 0 {
+		return &SecretBuffer{data: []byte{}}
+	}
+
+	// Give every buffer pages of its own so unlocking one buffer never unlocks
+	// another buffer's memory (mlock is not reference counted). Go's GC does not
+	// move heap objects, so the aligned region stays put.
+	pageSize := os.Getpagesize()
+	n := (size + pageSize - 1) / pageSize * pageSize
+	raw := make([]byte, n+pageSize)
+	off := 0
+	if rem := int(uintptr(unsafe.Pointer(&raw[0])) % uintptr(pageSize)); rem != 0 {
+		off = pageSize - rem
+	}
+
+	page := &secretPage{mem: raw[off : off+n : off+n]}
+	page.locked = lockMemory(page.mem) == nil
+
+	s := &SecretBuffer{
+		data: page.mem[:size:size],
+		page: page,
+	}
+	s.cleanup = runtime.AddCleanup(s, func(p *secretPage) { p.release() }, page)
+	return s
+}
+
+// NewSecretBufferFrom copies src into a new secret buffer and wipes src.
+func NewSecretBufferFrom(src []byte) *SecretBuffer {
+	s := NewSecretBuffer(len(src))
+	copy(s.data, src)
+	Zero(src)
+	return s
+}
+
+// Bytes returns the buffer contents. The slice aliases the protected memory: it
+// must not be retained after Destroy. Returns nil once the buffer is destroyed.
+func (s *SecretBuffer) Bytes() []byte {
+	if s == nil {
+		return nil
+	}
+	return s.data
+}
+
+// Len returns the buffer size in bytes (0 once destroyed).
+func (s *SecretBuffer) Len() int {
+	if s == nil {
+		return 0
+	}
+	return len(s.data)
+}
+
+// Locked reports whether the buffer's memory is locked against swapping.
+func (s *SecretBuffer) Locked() bool {
+	return s != nil && s.page != nil && s.page.locked
+}
+
+// Clone returns an independent copy of the buffer, to hand secret material to
+// code that outlives the caller's lock (the copy is destroyed separately).
+func (s *SecretBuffer) Clone() *SecretBuffer {
+	c := NewSecretBuffer(s.Len())
+	copy(c.data, s.Bytes())
+	return c
+}
+
+// Destroy wipes and unlocks the buffer. It is safe to call more than once and
+// on a nil buffer.
+func (s *SecretBuffer) Destroy() {
+	if s == nil || s.page == nil {
+		if s != nil {
+			s.data = nil
+		}
+		return
+	}
+	s.cleanup.Stop()
+	s.page.release()
+	s.page = nil
+	s.data = nil
+}
+
+// String implements fmt.Stringer without revealing the contents.
+func (s *SecretBuffer) String() string {
+	return redacted
+}
+
+// GoString implements fmt.GoStringer without revealing the contents.
+func (s *SecretBuffer) GoString() string {
+	return redacted
+}
+
+// Format makes every fmt verb (%s, %v, %x, %q...) print "[REDACTED]".
+func (s *SecretBuffer) Format(f fmt.State, _ rune) {
+	_, _ = f.Write([]byte(redacted))
+}
+
+// LogValue keeps the contents out of structured logs.
+func (s *SecretBuffer) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+// MarshalJSON keeps the contents out of JSON output.
+func (s *SecretBuffer) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + redacted + `"`), nil
+}
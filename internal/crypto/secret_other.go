@@ -0,0 +1,13 @@
+//go:build !unix && !windows
+
+package crypto
+
+import "errors"
+
+func lockMemory([]byte) error {
+	return errors.New("memory locking is not supported on this platform")
+}
+
+func unlockMemory([]byte) error {
+	return nil
+}
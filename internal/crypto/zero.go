@@ -6,9 +6,10 @@ package crypto
 // memory.
 //
 // This is best-effort: Go's GC may have already copied the backing array, and
-// there is no protection against swap or core dumps. For stronger guarantees on
-// supported platforms, sensitive regions could later be wrapped in
-// runtime/secret.Do (Go 1.26, experimental, linux only).
+// there is no protection against swap or core dumps. Long-lived secrets (the
+// master key, unwrapped keys, decrypted PEMs) belong in a SecretBuffer instead.
+// For stronger guarantees on supported platforms, sensitive regions could later
+// be wrapped in runtime/secret.Do (Go 1.26, experimental, linux only).
 // TODO(go1.26): evaluate runtime/secret for the re-encryption hot paths.
 func Zero(b []byte) {
 	clear(b)
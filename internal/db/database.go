@@ -57,7 +57,7 @@ func NewDatabase(dataDir string) (*Database, error) {
 			log.Error("failed to create data directory", logger.Err(err))
 			return nil, fmt.Errorf("failed to create data directory: %w", err)
 		}
-		dbPath = filepath.Join(dataDir, "certificates.db") + "?_pragma=journal_mode(WAL)&" + commonPragmas
+		dbPath = filepath.Join(dataDir, FileName) + "?_pragma=journal_mode(WAL)&" + commonPragmas
 		log.Debug("database path", slog.String("path", dbPath))
 	}
 
@@ -233,6 +233,29 @@ func (d *Database) WithTx(ctx context.Context, fn func(q *sqlc.Queries) error) (
 	return nil
 }
 
+// errDryRun rolls back the transaction of a dry run once fn has succeeded
+var errDryRun = errors.New("dry run")
+
+// WithDryRunTx runs fn like WithTx but, when dryRun is set, rolls the
+// transaction back after fn succeeds instead of committing it. Operations use
+// it to run every check and write and report what they would do, leaving the
+// database unchanged.
+func (d *Database) WithDryRunTx(ctx context.Context, dryRun bool, fn func(q *sqlc.Queries) error) error {
+	if !dryRun {
+		return d.WithTx(ctx, fn)
+	}
+	err := d.WithTx(ctx, func(q *sqlc.Queries) error {
+		if err := fn(q); err != nil {
+			return err
+		}
+		return errDryRun
+	})
+	if errors.Is(err, errDryRun) {
+		return nil
+	}
+	return err
+}
+
 // ensureDir ensures a directory exists, creating it if necessary
 func ensureDir(path string) error {
 	return os.MkdirAll(path, 0700)
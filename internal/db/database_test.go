@@ -7,6 +7,10 @@ import (
 	"testing"
 
 	"paddockcontrol-desktop/internal/db/sqlc"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/sqlite3"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 )
 
 func TestConnectionPragmas(t *testing.T) {
@@ -111,6 +115,35 @@ func TestWithTx_RollsBackOnPanic(t *testing.T) {
 	})
 }
 
+func TestWithDryRunTx_RollsBackOnSuccess(t *testing.T) {
+	database, _ := newMemDB(t)
+	create := func(hostname string) func(q *sqlc.Queries) error {
+		return func(q *sqlc.Queries) error {
+			return q.CreateCertificate(context.Background(), sqlc.CreateCertificateParams{Hostname: hostname})
+		}
+	}
+
+	if err := database.WithDryRunTx(context.Background(), true, create("dry.test.local")); err != nil {
+		t.Fatalf("WithDryRunTx: %v", err)
+	}
+	if certExists(t, database, "dry.test.local") {
+		t.Fatal("dry run write was committed")
+	}
+
+	if err := database.WithDryRunTx(context.Background(), false, create("wet.test.local")); err != nil {
+		t.Fatalf("WithDryRunTx: %v", err)
+	}
+	if !certExists(t, database, "wet.test.local") {
+		t.Fatal("committed certificate not found")
+	}
+
+	// A failing dry run still reports its error
+	err := database.WithDryRunTx(context.Background(), true, create("wet.test.local"))
+	if err == nil {
+		t.Fatal("expected the duplicate insert to fail")
+	}
+}
+
 func newMemDB(t *testing.T) (*Database, string) {
 	t.Helper()
 	database, err := NewDatabase(":memory:")
@@ -191,3 +224,209 @@ func TestResetWithMigrations(t *testing.T) {
 		t.Fatalf("Certificates table should exist after reset: %v", err)
 	}
 }
+
+func TestMigration_NormalizesHostnames(t *testing.T) {
+	dir := t.TempDir()
+	database, err := NewDatabase(dir)
+	if err != nil {
+		t.Fatalf("NewDatabase: %v", err)
+	}
+
+	// Roll back to the schema preceding the normalization migration so it runs
+	// again on reopen
+	migrateTo(t, database, 4)
+
+	// Raw inserts: the generated queries target the latest schema
+	for _, h := range []string{"Mixed.Example.com", "Dup.example.com", "dup.example.com"} {
+		if _, err := database.DB().Exec("INSERT INTO certificates (hostname) VALUES (?)", h); err != nil {
+			t.Fatalf("insert certificate %s: %v", h, err)
+		}
+	}
+	if _, err := database.DB().Exec("INSERT INTO certificate_history (hostname, event_type, message) VALUES ('Mixed.Example.com', 'x', 'm')"); err != nil {
+		t.Fatalf("insert history: %v", err)
+	}
+	database.Close()
+
+	database, err = NewDatabase(dir)
+	if err != nil {
+		t.Fatalf("NewDatabase (reopen): %v", err)
+	}
+	defer database.Close()
+
+	if certExists(t, database, "Mixed.Example.com") || !certExists(t, database, "mixed.example.com") {
+		t.Error("expected Mixed.Example.com to be renamed to mixed.example.com")
+	}
+	if !certExists(t, database, "Dup.example.com") || !certExists(t, database, "dup.example.com") {
+		t.Error("expected colliding hostnames to be left for the merge tool")
+	}
+
+	var hist int
+	_ = database.DB().QueryRow("SELECT COUNT(*) FROM certificate_history WHERE hostname='mixed.example.com'").Scan(&hist)
+	if hist != 1 {
+		t.Errorf("expected history to follow the renamed certificate, got %d rows", hist)
+	}
+}
+
+// migrateTo moves the database schema to the given migration version
+func migrateTo(t *testing.T, d *Database, version uint) {
+	t.Helper()
+	driver, err := sqlite3.WithInstance(d.DB(), &sqlite3.Config{})
+	if err != nil {
+		t.Fatalf("migration driver: %v", err)
+	}
+	source, err := iofs.New(migrations, "migrations")
+	if err != nil {
+		t.Fatalf("migration source: %v", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", source, "sqlite3", driver)
+	if err != nil {
+		t.Fatalf("migrator: %v", err)
+	}
+	if err := m.Migrate(version); err != nil && err != migrate.ErrNoChange {
+		t.Fatalf("migrate to %d: %v", version, err)
+	}
+}
+
+func TestMigration_QueuesNotesForReferenceExtraction(t *testing.T) {
+	dir := t.TempDir()
+	database, err := NewDatabase(dir)
+	if err != nil {
+		t.Fatalf("NewDatabase: %v", err)
+	}
+
+	// Roll back to the schema preceding note references so the backfill runs
+	// again on reopen
+	migrateTo(t, database, 31)
+
+	for _, row := range [][2]any{
+		{"noted.example.com", "see https://wiki.example.com"},
+		{"bare.example.com", nil},
+	} {
+		if _, err := database.DB().Exec("INSERT INTO certificates (hostname, note) VALUES (?, ?)", row[0], row[1]); err != nil {
+			t.Fatalf("insert certificate %s: %v", row[0], err)
+		}
+	}
+	database.Close()
+
+	database, err = NewDatabase(dir)
+	if err != nil {
+		t.Fatalf("NewDatabase (reopen): %v", err)
+	}
+	defer database.Close()
+
+	pending, err := database.Queries().ListPendingNoteReferences(context.Background())
+	if err != nil {
+		t.Fatalf("ListPendingNoteReferences: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Hostname != "noted.example.com" {
+		t.Errorf("expected only the certificate with a note to be queued, got %+v", pending)
+	}
+}
+
+func TestMigration_IndexesExistingCertificatesForSearch(t *testing.T) {
+	dir := t.TempDir()
+	database, err := NewDatabase(dir)
+	if err != nil {
+		t.Fatalf("NewDatabase: %v", err)
+	}
+
+	// Roll back to the schema preceding the search index so the backfill runs
+	// again on reopen
+	migrateTo(t, database, 32)
+
+	if _, err := database.DB().Exec("INSERT INTO certificates (hostname, note) VALUES ('web.example.com', 'Owned by the payments team')"); err != nil {
+		t.Fatalf("insert certificate: %v", err)
+	}
+	database.Close()
+
+	database, err = NewDatabase(dir)
+	if err != nil {
+		t.Fatalf("NewDatabase (reopen): %v", err)
+	}
+	defer database.Close()
+
+	hostnames, err := database.Queries().SearchCertificateHostnames(context.Background(), `"payments"`)
+	if err != nil {
+		t.Fatalf("SearchCertificateHostnames: %v", err)
+	}
+	if len(hostnames) != 1 || hostnames[0] != "web.example.com" {
+		t.Errorf("expected the existing certificate to be searchable, got %v", hostnames)
+	}
+}
+
+func TestMigration_QueuesExistingCertificatesForMetadata(t *testing.T) {
+	dir := t.TempDir()
+	database, err := NewDatabase(dir)
+	if err != nil {
+		t.Fatalf("NewDatabase: %v", err)
+	}
+
+	// Roll back to the schema preceding the metadata columns so the backfill
+	// runs again on reopen
+	migrateTo(t, database, 33)
+
+	if _, err := database.DB().Exec("INSERT INTO certificates (hostname, pending_csr_pem) VALUES ('web.example.com', 'csr'), ('empty.example.com', NULL)"); err != nil {
+		t.Fatalf("insert certificates: %v", err)
+	}
+	database.Close()
+
+	database, err = NewDatabase(dir)
+	if err != nil {
+		t.Fatalf("NewDatabase (reopen): %v", err)
+	}
+	defer database.Close()
+
+	pending, err := database.Queries().ListPendingCertificateMetadata(context.Background())
+	if err != nil {
+		t.Fatalf("ListPendingCertificateMetadata: %v", err)
+	}
+	if len(pending) != 1 || pending[0].Hostname != "web.example.com" {
+		t.Errorf("expected only the certificate with a PEM to be queued, got %v", pending)
+	}
+}
+
+func TestOpenReadOnly(t *testing.T) {
+	dir := t.TempDir()
+	database, err := NewDatabase(dir)
+	if err != nil {
+		t.Fatalf("NewDatabase: %v", err)
+	}
+	database.Close()
+
+	ro, err := OpenReadOnly(dir)
+	if err != nil {
+		t.Fatalf("OpenReadOnly: %v", err)
+	}
+	defer ro.Close()
+	ctx := context.Background()
+
+	problems, err := ro.IntegrityCheck(ctx, 10)
+	if err != nil {
+		t.Fatalf("IntegrityCheck: %v", err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("expected a sound database, got %v", problems)
+	}
+
+	version, dirty, err := ro.SchemaVersion(ctx)
+	if err != nil {
+		t.Fatalf("SchemaVersion: %v", err)
+	}
+	latest, err := LatestSchemaVersion()
+	if err != nil {
+		t.Fatalf("LatestSchemaVersion: %v", err)
+	}
+	if version != latest || dirty {
+		t.Errorf("expected version %d clean, got %d dirty=%v", latest, version, dirty)
+	}
+
+	if err := ro.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{Hostname: "h.test.local"}); err == nil {
+		t.Error("expected a write to fail on a read-only database")
+	}
+}
+
+func TestOpenReadOnly_Missing(t *testing.T) {
+	if _, err := OpenReadOnly(t.TempDir()); err == nil {
+		t.Fatal("expected an error for a missing database")
+	}
+}
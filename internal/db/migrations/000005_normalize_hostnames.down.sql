@@ -0,0 +1,2 @@
+-- Hostname normalization is not reversible (original casing is not retained)
+SELECT 1;
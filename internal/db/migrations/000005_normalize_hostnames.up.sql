@@ -0,0 +1,28 @@
+-- Normalize stored hostnames (trim surrounding whitespace, lowercase).
+-- Only rows whose normalized form does not collide with another row are renamed;
+-- near-duplicates ("Web.example.com" vs "web.example.com") are left untouched and
+-- resolved by the user through the hostname merge tool.
+-- Punycode conversion of internationalized names cannot be expressed in SQL and is
+-- also handled by the merge tool.
+PRAGMA defer_foreign_keys = ON;
+
+UPDATE certificate_history
+SET hostname = lower(trim(hostname))
+WHERE hostname IN (
+    SELECT c.hostname FROM certificates c
+    WHERE c.hostname <> lower(trim(c.hostname))
+      AND NOT EXISTS (
+          SELECT 1 FROM certificates o
+          WHERE o.hostname <> c.hostname
+            AND lower(trim(o.hostname)) = lower(trim(c.hostname))
+      )
+);
+
+UPDATE certificates
+SET hostname = lower(trim(hostname))
+WHERE hostname <> lower(trim(hostname))
+  AND NOT EXISTS (
+      SELECT 1 FROM certificates o
+      WHERE o.hostname <> certificates.hostname
+        AND lower(trim(o.hostname)) = lower(trim(certificates.hostname))
+  );
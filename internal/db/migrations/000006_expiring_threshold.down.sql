@@ -0,0 +1 @@
+ALTER TABLE config DROP COLUMN expiring_threshold_days;
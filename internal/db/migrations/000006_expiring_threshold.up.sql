@@ -0,0 +1,2 @@
+-- Configurable "expiring soon" warning threshold (previously hard-coded to 30 days)
+ALTER TABLE config ADD COLUMN expiring_threshold_days INTEGER NOT NULL DEFAULT 30 CHECK(expiring_threshold_days >= 1);
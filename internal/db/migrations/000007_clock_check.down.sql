@@ -0,0 +1,2 @@
+ALTER TABLE config DROP COLUMN air_gapped;
+ALTER TABLE config DROP COLUMN clock_check_url;
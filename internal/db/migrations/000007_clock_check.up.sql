@@ -0,0 +1,4 @@
+-- Startup clock sanity check: reference URL whose HTTP Date header is compared
+-- against the local clock, and an air-gapped flag that disables the check
+ALTER TABLE config ADD COLUMN clock_check_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE config ADD COLUMN air_gapped INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+ALTER TABLE certificate_history DROP COLUMN details;
+ALTER TABLE certificate_history DROP COLUMN app_version;
+ALTER TABLE certificate_history DROP COLUMN actor;
@@ -0,0 +1,5 @@
+-- History enrichment: who performed the action (OS username), which app version
+-- wrote the entry, and a JSON object with event-specific details
+ALTER TABLE certificate_history ADD COLUMN actor TEXT NOT NULL DEFAULT '';
+ALTER TABLE certificate_history ADD COLUMN app_version TEXT NOT NULL DEFAULT '';
+ALTER TABLE certificate_history ADD COLUMN details TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+-- Intermediate (and root) certificates supplied with the signed certificate,
+-- stored as concatenated PEM so chain downloads don't depend on AIA fetching
+ALTER TABLE certificates ADD COLUMN chain_pem TEXT;
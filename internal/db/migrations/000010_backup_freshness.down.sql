@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS count_certificate_delete;
+DROP TRIGGER IF EXISTS count_certificate_update;
+DROP TRIGGER IF EXISTS count_certificate_insert;
+ALTER TABLE config DROP COLUMN last_backup_at;
+ALTER TABLE config DROP COLUMN writes_since_backup;
+ALTER TABLE config DROP COLUMN backup_freshness_block;
+ALTER TABLE config DROP COLUMN backup_freshness_max_writes;
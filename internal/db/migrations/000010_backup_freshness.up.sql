@@ -0,0 +1,23 @@
+-- Backup freshness policy: certificate rows written since the last manual backup
+-- or export, when that was, the threshold that raises a warning, and whether
+-- risky operations are refused while the threshold is exceeded
+ALTER TABLE config ADD COLUMN backup_freshness_max_writes INTEGER NOT NULL DEFAULT 20 CHECK(backup_freshness_max_writes >= 0);
+ALTER TABLE config ADD COLUMN backup_freshness_block INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE config ADD COLUMN writes_since_backup INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE config ADD COLUMN last_backup_at INTEGER;
+
+-- Count every certificate write, whichever code path performs it
+CREATE TRIGGER count_certificate_insert AFTER INSERT ON certificates
+BEGIN
+    UPDATE config SET writes_since_backup = writes_since_backup + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER count_certificate_update AFTER UPDATE ON certificates
+BEGIN
+    UPDATE config SET writes_since_backup = writes_since_backup + 1 WHERE id = 1;
+END;
+
+CREATE TRIGGER count_certificate_delete AFTER DELETE ON certificates
+BEGIN
+    UPDATE config SET writes_since_backup = writes_since_backup + 1 WHERE id = 1;
+END;
@@ -0,0 +1,3 @@
+-- FIPS-compatible crypto mode: restricts key generation, imports and unlock to
+-- FIPS-approved algorithms
+ALTER TABLE config ADD COLUMN fips_mode INTEGER NOT NULL DEFAULT 0;
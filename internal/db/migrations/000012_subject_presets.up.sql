@@ -0,0 +1,13 @@
+-- Named subject presets (organization, unit, locality, country) selectable when
+-- generating a CSR, for teams issuing under different legal entities
+CREATE TABLE subject_presets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE,
+    organization TEXT NOT NULL,
+    organizational_unit TEXT,
+    city TEXT NOT NULL,
+    state TEXT NOT NULL,
+    country TEXT NOT NULL CHECK(length(country) = 2),
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    last_modified INTEGER NOT NULL DEFAULT (unixepoch())
+);
@@ -0,0 +1,11 @@
+-- Renewal checklist: the steps of the current renewal completed so far, so
+-- several operators can see where a renewal stands. Cleared when a new
+-- renewal CSR is generated.
+CREATE TABLE renewal_checklist (
+    hostname TEXT NOT NULL,
+    step TEXT NOT NULL CHECK(step IN ('csr_sent', 'cert_received', 'uploaded', 'deployed', 'verified')),
+    completed_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    actor TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (hostname, step),
+    FOREIGN KEY (hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+ALTER TABLE certificates DROP COLUMN submitted_at;
+ALTER TABLE certificates DROP COLUMN ca_reference;
@@ -0,0 +1,5 @@
+-- Tracking of the pending CSR once it has been handed to the CA: the CA's
+-- order/reference ID and when it was submitted. Both are cleared whenever the
+-- pending CSR is replaced, cancelled or answered.
+ALTER TABLE certificates ADD COLUMN ca_reference TEXT;
+ALTER TABLE certificates ADD COLUMN submitted_at INTEGER;
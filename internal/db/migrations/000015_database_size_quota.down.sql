@@ -0,0 +1 @@
+ALTER TABLE config DROP COLUMN db_size_warn_mb;
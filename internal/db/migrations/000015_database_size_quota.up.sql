@@ -0,0 +1,3 @@
+-- Soft quota on the database file size, in MiB: above it a health warning
+-- lists the biggest contributors and the cleanups available (0 disables)
+ALTER TABLE config ADD COLUMN db_size_warn_mb INTEGER NOT NULL DEFAULT 100 CHECK(db_size_warn_mb >= 0);
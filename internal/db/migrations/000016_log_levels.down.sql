@@ -0,0 +1,2 @@
+ALTER TABLE config DROP COLUMN log_component_levels;
+ALTER TABLE config DROP COLUMN log_level;
@@ -0,0 +1,4 @@
+-- Runtime log levels: the global level ('' keeps the build default) and the
+-- per-component overrides as a JSON object of component name to level
+ALTER TABLE config ADD COLUMN log_level TEXT NOT NULL DEFAULT '';
+ALTER TABLE config ADD COLUMN log_component_levels TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,4 @@
+ALTER TABLE config DROP COLUMN log_compress;
+ALTER TABLE config DROP COLUMN log_max_age_days;
+ALTER TABLE config DROP COLUMN log_max_files;
+ALTER TABLE config DROP COLUMN log_max_size_mb;
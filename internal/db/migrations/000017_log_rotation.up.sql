@@ -0,0 +1,7 @@
+-- Log file rotation: size in MiB at which the log rotates, rotated files kept
+-- (0 keeps all), age in days after which they are removed (0 disables it) and
+-- whether rotated files are gzipped
+ALTER TABLE config ADD COLUMN log_max_size_mb INTEGER NOT NULL DEFAULT 10 CHECK(log_max_size_mb >= 1);
+ALTER TABLE config ADD COLUMN log_max_files INTEGER NOT NULL DEFAULT 5 CHECK(log_max_files >= 0);
+ALTER TABLE config ADD COLUMN log_max_age_days INTEGER NOT NULL DEFAULT 30 CHECK(log_max_age_days >= 0);
+ALTER TABLE config ADD COLUMN log_compress INTEGER NOT NULL DEFAULT 1 CHECK(log_compress IN (0, 1));
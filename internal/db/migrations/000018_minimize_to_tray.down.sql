@@ -0,0 +1 @@
+ALTER TABLE config DROP COLUMN minimize_to_tray;
@@ -0,0 +1,2 @@
+-- Hide the window to the system tray instead of minimizing it to the taskbar
+ALTER TABLE config ADD COLUMN minimize_to_tray INTEGER NOT NULL DEFAULT 0 CHECK(minimize_to_tray IN (0, 1));
@@ -0,0 +1,2 @@
+ALTER TABLE config DROP COLUMN expiry_notifications;
+ALTER TABLE config DROP COLUMN run_in_background;
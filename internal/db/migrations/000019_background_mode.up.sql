@@ -0,0 +1,4 @@
+-- Background mode: keep running in the tray when the window is closed, and
+-- send desktop notifications as certificates enter the expiring window or expire
+ALTER TABLE config ADD COLUMN run_in_background INTEGER NOT NULL DEFAULT 0 CHECK(run_in_background IN (0, 1));
+ALTER TABLE config ADD COLUMN expiry_notifications INTEGER NOT NULL DEFAULT 1 CHECK(expiry_notifications IN (0, 1));
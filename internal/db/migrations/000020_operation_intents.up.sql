@@ -0,0 +1,10 @@
+-- Write-ahead intents of multi-step operations: recorded before the first step
+-- and removed after the last, so a row left at startup marks an operation the
+-- process did not finish (crash, power loss) and is completed or rolled back
+CREATE TABLE operation_intents (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    operation TEXT NOT NULL,
+    hostname TEXT NOT NULL DEFAULT '',
+    payload TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
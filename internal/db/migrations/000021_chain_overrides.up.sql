@@ -0,0 +1,15 @@
+-- Chain overrides for certificates whose AIA URL is wrong or internal-only:
+-- the issuer of one certificate (hostname set) or of every certificate issued
+-- by a CA (issuer_dn set) is fetched from issuer_url, or is the pasted
+-- issuer_pem, before the URL embedded in the certificate is tried
+CREATE TABLE chain_overrides (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    hostname TEXT UNIQUE,
+    issuer_dn TEXT UNIQUE,
+    issuer_url TEXT NOT NULL DEFAULT '',
+    issuer_pem TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    CHECK ((hostname IS NULL) != (issuer_dn IS NULL)),
+    CHECK ((issuer_url = '') != (issuer_pem = '')),
+    FOREIGN KEY (hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
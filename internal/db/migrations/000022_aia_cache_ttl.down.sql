@@ -0,0 +1 @@
+ALTER TABLE config DROP COLUMN aia_cache_ttl_minutes;
@@ -0,0 +1,2 @@
+-- How long issuer certificates fetched via AIA are cached, in minutes (0 disables the cache)
+ALTER TABLE config ADD COLUMN aia_cache_ttl_minutes INTEGER NOT NULL DEFAULT 60 CHECK(aia_cache_ttl_minutes BETWEEN 0 AND 10080);
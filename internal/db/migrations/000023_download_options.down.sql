@@ -0,0 +1,3 @@
+ALTER TABLE config DROP COLUMN download_format;
+ALTER TABLE config DROP COLUMN download_text_header;
+ALTER TABLE config DROP COLUMN download_line_endings;
@@ -0,0 +1,4 @@
+-- How downloaded certificates, CSRs, chains and keys are written
+ALTER TABLE config ADD COLUMN download_line_endings TEXT NOT NULL DEFAULT 'lf' CHECK(download_line_endings IN ('lf', 'crlf'));
+ALTER TABLE config ADD COLUMN download_text_header INTEGER NOT NULL DEFAULT 0 CHECK(download_text_header IN (0, 1));
+ALTER TABLE config ADD COLUMN download_format TEXT NOT NULL DEFAULT 'pem' CHECK(download_format IN ('pem', 'der'));
@@ -0,0 +1,2 @@
+-- Keys of the default size generated ahead of time while unlocked (0 disables the pool)
+ALTER TABLE config ADD COLUMN key_pool_size INTEGER NOT NULL DEFAULT 0 CHECK(key_pool_size BETWEEN 0 AND 10);
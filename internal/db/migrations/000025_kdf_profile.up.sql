@@ -0,0 +1,3 @@
+-- Argon2id cost used for new password wraps. Existing installations keep the
+-- standard profile; new ones get the profile detected for their architecture.
+ALTER TABLE config ADD COLUMN kdf_profile TEXT NOT NULL DEFAULT 'standard' CHECK(kdf_profile IN ('standard', 'constrained'));
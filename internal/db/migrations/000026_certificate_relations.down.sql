@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_certificate_relations_target;
+DROP TABLE IF EXISTS certificate_relations;
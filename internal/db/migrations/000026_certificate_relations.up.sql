@@ -0,0 +1,19 @@
+-- Relations between certificates, so a renewal can warn about the endpoints
+-- that depend on the certificate: a client certificate talking to a server
+-- (client_of, from source to target) or certificates served by the same
+-- endpoint (shared_endpoint, undirected; label names the endpoint, e.g. a
+-- load balancer)
+CREATE TABLE certificate_relations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    source_hostname TEXT NOT NULL,
+    target_hostname TEXT NOT NULL,
+    relation_type TEXT NOT NULL CHECK(relation_type IN ('client_of', 'shared_endpoint')),
+    label TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    CHECK (source_hostname != target_hostname),
+    UNIQUE (source_hostname, target_hostname, relation_type),
+    FOREIGN KEY (source_hostname) REFERENCES certificates(hostname) ON DELETE CASCADE,
+    FOREIGN KEY (target_hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_certificate_relations_target ON certificate_relations(target_hostname);
@@ -0,0 +1,12 @@
+-- Who asked for a certificate, from a request (intake) file filled by a
+-- colleague: kept with the certificate so the operator knows whom to contact
+-- at renewal. Replaced when a renewal is generated from a new request file.
+CREATE TABLE certificate_requesters (
+    hostname TEXT PRIMARY KEY,
+    requester_name TEXT NOT NULL,
+    requester_email TEXT NOT NULL DEFAULT '',
+    team TEXT NOT NULL DEFAULT '',
+    justification TEXT NOT NULL DEFAULT '',
+    recorded_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    FOREIGN KEY (hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
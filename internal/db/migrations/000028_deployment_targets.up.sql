@@ -0,0 +1,14 @@
+-- Where a certificate is currently deployed (a server, a load balancer, an
+-- appliance), so deleting it from the vault while it still serves traffic is
+-- refused until the deployment is removed. One row per target and certificate.
+CREATE TABLE deployment_targets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    hostname TEXT NOT NULL,
+    name TEXT NOT NULL,
+    location TEXT NOT NULL DEFAULT '',
+    deployed_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    UNIQUE (hostname, name),
+    FOREIGN KEY (hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_deployment_targets_hostname ON deployment_targets(hostname);
@@ -0,0 +1,5 @@
+-- Health of a certificate's key pairs, recomputed on every write that affects a
+-- key: '' until checked (set on the next unlock), then ok, mismatched,
+-- undecryptable or missing
+ALTER TABLE certificates ADD COLUMN key_status TEXT NOT NULL DEFAULT ''
+    CHECK(key_status IN ('', 'ok', 'mismatched', 'undecryptable', 'missing'));
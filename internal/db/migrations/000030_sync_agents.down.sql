@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS sync_agent_hostnames;
+DROP TABLE IF EXISTS sync_agents;
+DROP TABLE IF EXISTS sync_server;
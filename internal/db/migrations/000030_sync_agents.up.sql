@@ -0,0 +1,37 @@
+-- Companion agents that pull their assigned certificates and keys over mutual
+-- TLS (see internal/agentsync). The single sync_server row holds the CA that
+-- signs agent client certificates and the server certificate, its private key
+-- encrypted with the master key, and the address the server listens on once
+-- started ('' while stopped).
+CREATE TABLE sync_server (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    ca_certificate_pem TEXT NOT NULL,
+    ca_encrypted_private_key BLOB NOT NULL,
+    listen_address TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
+
+-- An enrolled agent, identified by the SHA-256 fingerprint of its client
+-- certificate. Revoked agents are kept for the record and refused.
+CREATE TABLE sync_agents (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    cert_fingerprint TEXT NOT NULL UNIQUE,
+    expires_at INTEGER NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    revoked_at INTEGER,
+    last_seen_at INTEGER
+);
+
+CREATE UNIQUE INDEX idx_sync_agents_active_name ON sync_agents(name) WHERE revoked_at IS NULL;
+
+-- The certificates each agent may pull
+CREATE TABLE sync_agent_hostnames (
+    agent_id INTEGER NOT NULL,
+    hostname TEXT NOT NULL,
+    PRIMARY KEY (agent_id, hostname),
+    FOREIGN KEY (agent_id) REFERENCES sync_agents(id) ON DELETE CASCADE,
+    FOREIGN KEY (hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_sync_agent_hostnames_hostname ON sync_agent_hostnames(hostname);
@@ -0,0 +1,11 @@
+-- Free-form labels on certificates ("production", "dmz", "team-infra"), used
+-- to filter the certificate list. Tags are stored lowercase.
+CREATE TABLE certificate_tags (
+    hostname TEXT NOT NULL,
+    tag TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    PRIMARY KEY (hostname, tag),
+    FOREIGN KEY (hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_certificate_tags_tag ON certificate_tags(tag);
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS note_references_on_ticket_pattern;
+DROP TRIGGER IF EXISTS note_references_on_update;
+DROP TRIGGER IF EXISTS note_references_on_insert;
+DROP TABLE IF EXISTS note_references_pending;
+DROP INDEX IF EXISTS idx_certificate_note_references_value;
+DROP TABLE IF EXISTS certificate_note_references;
+ALTER TABLE config DROP COLUMN ticket_pattern;
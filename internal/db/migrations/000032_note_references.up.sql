@@ -0,0 +1,59 @@
+-- Ticket IDs to look for in certificate notes: a Go regular expression such
+-- as CHG\d{7}; empty extracts URLs only
+ALTER TABLE config ADD COLUMN ticket_pattern TEXT NOT NULL DEFAULT '';
+
+-- URLs and ticket IDs found in certificate notes, so the UI can link them and
+-- certificates can be found by the ticket they reference. Extraction runs in
+-- Go; certificates whose notes changed wait in note_references_pending.
+CREATE TABLE certificate_note_references (
+    hostname TEXT NOT NULL,
+    source TEXT NOT NULL CHECK(source IN ('note', 'pending_note')),
+    kind TEXT NOT NULL CHECK(kind IN ('url', 'ticket')),
+    value TEXT NOT NULL,
+    PRIMARY KEY (hostname, source, kind, value),
+    FOREIGN KEY (hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_certificate_note_references_value ON certificate_note_references(value COLLATE NOCASE);
+
+-- Certificates whose note references must be extracted again. The triggers
+-- check for an existing row rather than using INSERT OR IGNORE, since an
+-- upsert on certificates would impose its own conflict policy on them.
+CREATE TABLE note_references_pending (
+    hostname TEXT PRIMARY KEY,
+    FOREIGN KEY (hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
+
+CREATE TRIGGER note_references_on_insert
+AFTER INSERT ON certificates
+WHEN NEW.note IS NOT NULL OR NEW.pending_note IS NOT NULL
+BEGIN
+    INSERT INTO note_references_pending (hostname)
+    SELECT NEW.hostname
+    WHERE NOT EXISTS (SELECT 1 FROM note_references_pending WHERE hostname = NEW.hostname);
+END;
+
+CREATE TRIGGER note_references_on_update
+AFTER UPDATE OF note, pending_note ON certificates
+WHEN OLD.note IS NOT NEW.note OR OLD.pending_note IS NOT NEW.pending_note
+BEGIN
+    INSERT INTO note_references_pending (hostname)
+    SELECT NEW.hostname
+    WHERE NOT EXISTS (SELECT 1 FROM note_references_pending WHERE hostname = NEW.hostname);
+END;
+
+-- A new ticket pattern changes what every note references
+CREATE TRIGGER note_references_on_ticket_pattern
+AFTER UPDATE OF ticket_pattern ON config
+WHEN OLD.ticket_pattern IS NOT NEW.ticket_pattern
+BEGIN
+    INSERT INTO note_references_pending (hostname)
+    SELECT hostname FROM certificates
+    WHERE (note IS NOT NULL OR pending_note IS NOT NULL)
+      AND hostname NOT IN (SELECT hostname FROM note_references_pending);
+END;
+
+-- Backfill: extract the references of every existing note
+INSERT INTO note_references_pending (hostname)
+SELECT hostname FROM certificates
+WHERE note IS NOT NULL OR pending_note IS NOT NULL;
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS certificate_search_on_delete;
+DROP TRIGGER IF EXISTS certificate_search_on_pem_update;
+DROP TRIGGER IF EXISTS certificate_search_on_update;
+DROP TRIGGER IF EXISTS certificate_search_on_insert;
+DROP TRIGGER IF EXISTS certificate_search_content_update;
+DROP TRIGGER IF EXISTS certificate_search_content_delete;
+DROP TRIGGER IF EXISTS certificate_search_content_insert;
+DROP TABLE IF EXISTS certificate_search;
+DROP TABLE IF EXISTS certificate_search_content;
@@ -0,0 +1,81 @@
+-- Full-text search over hostnames, SANs and notes. certificate_search_content
+-- holds one row per certificate and is the external content of the FTS5 index;
+-- the trigram tokenizer makes any 3+ character substring searchable.
+-- Hostnames and notes are kept in sync by triggers on certificates. SANs are
+-- parsed from the PEM in Go: a changed certificate or CSR marks sans_stale.
+CREATE TABLE certificate_search_content (
+    id INTEGER PRIMARY KEY,
+    hostname TEXT NOT NULL UNIQUE,
+    sans TEXT NOT NULL DEFAULT '',
+    note TEXT NOT NULL DEFAULT '',
+    pending_note TEXT NOT NULL DEFAULT '',
+    sans_stale INTEGER NOT NULL DEFAULT 1 CHECK(sans_stale IN (0, 1))
+);
+
+CREATE VIRTUAL TABLE certificate_search USING fts5(
+    hostname, sans, note, pending_note,
+    content = 'certificate_search_content',
+    content_rowid = 'id',
+    tokenize = 'trigram'
+);
+
+-- Keep the index in step with its content table
+CREATE TRIGGER certificate_search_content_insert
+AFTER INSERT ON certificate_search_content
+BEGIN
+    INSERT INTO certificate_search (rowid, hostname, sans, note, pending_note)
+    VALUES (NEW.id, NEW.hostname, NEW.sans, NEW.note, NEW.pending_note);
+END;
+
+CREATE TRIGGER certificate_search_content_delete
+AFTER DELETE ON certificate_search_content
+BEGIN
+    INSERT INTO certificate_search (certificate_search, rowid, hostname, sans, note, pending_note)
+    VALUES ('delete', OLD.id, OLD.hostname, OLD.sans, OLD.note, OLD.pending_note);
+END;
+
+CREATE TRIGGER certificate_search_content_update
+AFTER UPDATE ON certificate_search_content
+BEGIN
+    INSERT INTO certificate_search (certificate_search, rowid, hostname, sans, note, pending_note)
+    VALUES ('delete', OLD.id, OLD.hostname, OLD.sans, OLD.note, OLD.pending_note);
+    INSERT INTO certificate_search (rowid, hostname, sans, note, pending_note)
+    VALUES (NEW.id, NEW.hostname, NEW.sans, NEW.note, NEW.pending_note);
+END;
+
+-- Keep the content table in step with certificates
+CREATE TRIGGER certificate_search_on_insert
+AFTER INSERT ON certificates
+BEGIN
+    DELETE FROM certificate_search_content WHERE hostname = NEW.hostname;
+    INSERT INTO certificate_search_content (hostname, note, pending_note)
+    VALUES (NEW.hostname, COALESCE(NEW.note, ''), COALESCE(NEW.pending_note, ''));
+END;
+
+CREATE TRIGGER certificate_search_on_update
+AFTER UPDATE OF hostname, note, pending_note ON certificates
+WHEN OLD.hostname IS NOT NEW.hostname OR OLD.note IS NOT NEW.note OR OLD.pending_note IS NOT NEW.pending_note
+BEGIN
+    UPDATE certificate_search_content
+    SET hostname = NEW.hostname,
+        note = COALESCE(NEW.note, ''),
+        pending_note = COALESCE(NEW.pending_note, '')
+    WHERE hostname = OLD.hostname;
+END;
+
+CREATE TRIGGER certificate_search_on_pem_update
+AFTER UPDATE OF certificate_pem, pending_csr_pem ON certificates
+WHEN OLD.certificate_pem IS NOT NEW.certificate_pem OR OLD.pending_csr_pem IS NOT NEW.pending_csr_pem
+BEGIN
+    UPDATE certificate_search_content SET sans_stale = 1 WHERE hostname = NEW.hostname;
+END;
+
+CREATE TRIGGER certificate_search_on_delete
+AFTER DELETE ON certificates
+BEGIN
+    DELETE FROM certificate_search_content WHERE hostname = OLD.hostname;
+END;
+
+-- Backfill: index every existing certificate, SANs to be parsed on first search
+INSERT INTO certificate_search_content (hostname, note, pending_note)
+SELECT hostname, COALESCE(note, ''), COALESCE(pending_note, '') FROM certificates;
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS certificate_metadata_on_update;
+DROP TRIGGER IF EXISTS certificate_metadata_on_insert;
+DROP TABLE IF EXISTS certificate_metadata_pending;
+ALTER TABLE certificates DROP COLUMN key_size;
+ALTER TABLE certificates DROP COLUMN key_algorithm;
+ALTER TABLE certificates DROP COLUMN sans;
@@ -0,0 +1,37 @@
+-- Metadata parsed from the issued certificate (or the pending CSR before one is
+-- issued), so listing certificates does not parse every PEM: the SANs as a JSON
+-- array, the key algorithm and the key size
+ALTER TABLE certificates ADD COLUMN sans TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE certificates ADD COLUMN key_algorithm TEXT NOT NULL DEFAULT '';
+ALTER TABLE certificates ADD COLUMN key_size INTEGER NOT NULL DEFAULT 0;
+
+-- Certificates whose PEMs changed since their metadata was parsed. Parsing runs
+-- in Go, so triggers only queue the hostname. They insert with WHERE NOT EXISTS
+-- rather than OR IGNORE: an upsert's conflict policy overrides the trigger's.
+CREATE TABLE certificate_metadata_pending (
+    hostname TEXT PRIMARY KEY,
+    FOREIGN KEY (hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
+
+CREATE TRIGGER certificate_metadata_on_insert
+AFTER INSERT ON certificates
+WHEN NEW.certificate_pem IS NOT NULL OR NEW.pending_csr_pem IS NOT NULL
+BEGIN
+    INSERT INTO certificate_metadata_pending (hostname)
+    SELECT NEW.hostname
+    WHERE NOT EXISTS (SELECT 1 FROM certificate_metadata_pending WHERE hostname = NEW.hostname);
+END;
+
+CREATE TRIGGER certificate_metadata_on_update
+AFTER UPDATE OF certificate_pem, pending_csr_pem ON certificates
+WHEN OLD.certificate_pem IS NOT NEW.certificate_pem OR OLD.pending_csr_pem IS NOT NEW.pending_csr_pem
+BEGIN
+    INSERT INTO certificate_metadata_pending (hostname)
+    SELECT NEW.hostname
+    WHERE NOT EXISTS (SELECT 1 FROM certificate_metadata_pending WHERE hostname = NEW.hostname);
+END;
+
+-- Backfill: parse every existing certificate on the next listing
+INSERT INTO certificate_metadata_pending (hostname)
+SELECT hostname FROM certificates
+WHERE certificate_pem IS NOT NULL OR pending_csr_pem IS NOT NULL;
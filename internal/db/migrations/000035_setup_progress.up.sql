@@ -0,0 +1,10 @@
+-- Progress of the setup wizard, so it resumes where it stopped after a crash
+-- or restart: the step shown last and the form values entered so far (a JSON
+-- models.SetupRequest, never the password). Removed once setup completes or
+-- is aborted.
+CREATE TABLE setup_progress (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    step TEXT NOT NULL,
+    draft TEXT NOT NULL DEFAULT '{}',
+    updated_at INTEGER NOT NULL DEFAULT (unixepoch('now'))
+);
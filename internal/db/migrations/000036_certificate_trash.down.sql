@@ -0,0 +1,5 @@
+-- Certificates in the trash were deleted; the old schema cannot hide them
+DELETE FROM certificates WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_certificates_deleted_at;
+ALTER TABLE certificates DROP COLUMN deleted_at;
+ALTER TABLE config DROP COLUMN trash_retention_days;
@@ -0,0 +1,10 @@
+-- Deleted certificates stay in the table, with their history, tags and keys,
+-- until purged: deleted_at is when they were moved to the trash, NULL for
+-- live certificates
+ALTER TABLE certificates ADD COLUMN deleted_at INTEGER;
+
+CREATE INDEX idx_certificates_deleted_at ON certificates(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Days a deleted certificate stays in the trash before it is purged; 0
+-- deletes certificates permanently right away
+ALTER TABLE config ADD COLUMN trash_retention_days INTEGER NOT NULL DEFAULT 30 CHECK(trash_retention_days BETWEEN 0 AND 365);
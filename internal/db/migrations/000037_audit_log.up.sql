@@ -0,0 +1,16 @@
+-- Security-sensitive operations (unlocks, failed unlocks, key exports,
+-- password changes, restores, resets), kept apart from the per-certificate
+-- history: entries are never rewritten or cleaned up, and survive the
+-- deletion of the certificate they name
+CREATE TABLE audit_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_type TEXT NOT NULL,
+    hostname TEXT NOT NULL DEFAULT '',
+    message TEXT NOT NULL,
+    actor TEXT NOT NULL DEFAULT '',
+    app_version TEXT NOT NULL DEFAULT '',
+    details TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
+
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at);
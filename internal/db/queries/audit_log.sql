@@ -0,0 +1,49 @@
+-- Audit log queries
+
+-- name: AddAuditEntry :exec
+-- Append an entry to the audit log
+INSERT INTO audit_log (event_type, hostname, message, actor, app_version, details)
+VALUES (?, ?, ?, ?, ?, ?);
+
+-- name: ListAuditLog :many
+-- List audit log entries, most recent first. event_types is a comma-separated
+-- list (empty for all), hostname is empty for all; created_to is exclusive (0
+-- for no bound).
+SELECT id, event_type, hostname, message, actor, app_version, details, created_at
+FROM audit_log
+WHERE (CAST(sqlc.arg(event_types) AS TEXT) = '' OR instr(',' || sqlc.arg(event_types) || ',', ',' || event_type || ',') > 0)
+  AND (CAST(sqlc.arg(hostname) AS TEXT) = '' OR hostname = sqlc.arg(hostname))
+  AND created_at >= CAST(sqlc.arg(created_from) AS INTEGER)
+  AND (CAST(sqlc.arg(created_to) AS INTEGER) = 0 OR created_at < sqlc.arg(created_to))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountAuditLog :one
+-- Count the audit log entries ListAuditLog pages through
+SELECT COUNT(*) AS count
+FROM audit_log
+WHERE (CAST(sqlc.arg(event_types) AS TEXT) = '' OR instr(',' || sqlc.arg(event_types) || ',', ',' || event_type || ',') > 0)
+  AND (CAST(sqlc.arg(hostname) AS TEXT) = '' OR hostname = sqlc.arg(hostname))
+  AND created_at >= CAST(sqlc.arg(created_from) AS INTEGER)
+  AND (CAST(sqlc.arg(created_to) AS INTEGER) = 0 OR created_at < sqlc.arg(created_to));
+
+-- name: ListAllAuditEntries :many
+-- List every audit log entry, oldest first (carried over a restore)
+SELECT id, event_type, hostname, message, actor, app_version, details, created_at
+FROM audit_log
+ORDER BY created_at, id;
+
+-- name: CarryAuditEntry :exec
+-- Insert an audit log entry with its original timestamp, unless the log
+-- already holds it under the same id (a restored backup of this database holds
+-- the entries recorded before it)
+INSERT INTO audit_log (event_type, hostname, message, actor, app_version, details, created_at)
+SELECT sqlc.arg(event_type), sqlc.arg(hostname), sqlc.arg(message), sqlc.arg(actor),
+       sqlc.arg(app_version), sqlc.arg(details), sqlc.arg(created_at)
+WHERE NOT EXISTS (
+    SELECT 1 FROM audit_log
+    WHERE id = sqlc.arg(id)
+      AND event_type = sqlc.arg(event_type) AND hostname = sqlc.arg(hostname)
+      AND message = sqlc.arg(message) AND actor = sqlc.arg(actor)
+      AND details = sqlc.arg(details) AND created_at = sqlc.arg(created_at)
+);
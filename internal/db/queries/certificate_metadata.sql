@@ -0,0 +1,18 @@
+-- Certificate metadata queries (SANs and key parameters parsed from the PEMs)
+
+-- name: ListPendingCertificateMetadata :many
+-- List the certificates whose PEMs changed since their metadata was parsed
+SELECT p.hostname, c.certificate_pem, c.pending_csr_pem
+FROM certificate_metadata_pending p
+JOIN certificates c ON c.hostname = p.hostname
+ORDER BY p.hostname;
+
+-- name: UpdateCertificateMetadata :exec
+-- Store the metadata parsed from a certificate's PEMs
+UPDATE certificates
+SET sans = ?, key_algorithm = ?, key_size = ?
+WHERE hostname = ?;
+
+-- name: ClearPendingCertificateMetadata :exec
+-- Mark the metadata of a certificate as parsed
+DELETE FROM certificate_metadata_pending WHERE hostname = ?;
@@ -0,0 +1,33 @@
+-- Certificate relation queries
+
+-- name: ListCertificateRelations :many
+-- List every relation between certificates
+SELECT id, source_hostname, target_hostname, relation_type, label, created_at
+FROM certificate_relations
+ORDER BY id ASC;
+
+-- name: ListCertificateRelationsForHostname :many
+-- List the relations a certificate takes part in, on either side
+SELECT id, source_hostname, target_hostname, relation_type, label, created_at
+FROM certificate_relations
+WHERE source_hostname = sqlc.arg(hostname) OR target_hostname = sqlc.arg(hostname)
+ORDER BY id ASC;
+
+-- name: UpsertCertificateRelation :exec
+-- Declare a relation between two certificates, or relabel it
+INSERT INTO certificate_relations (source_hostname, target_hostname, relation_type, label)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (source_hostname, target_hostname, relation_type) DO UPDATE SET
+    label = excluded.label;
+
+-- name: DeleteCertificateRelation :execrows
+-- Remove a relation
+DELETE FROM certificate_relations WHERE id = ?;
+
+-- name: ReassignCertificateRelationSources :exec
+-- Move the relations starting from a certificate to another hostname (used when renaming)
+UPDATE certificate_relations SET source_hostname = sqlc.arg(new_hostname) WHERE source_hostname = sqlc.arg(old_hostname);
+
+-- name: ReassignCertificateRelationTargets :exec
+-- Move the relations pointing to a certificate to another hostname (used when renaming)
+UPDATE certificate_relations SET target_hostname = sqlc.arg(new_hostname) WHERE target_hostname = sqlc.arg(old_hostname);
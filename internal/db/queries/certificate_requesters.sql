@@ -0,0 +1,22 @@
+-- Certificate requester queries
+
+-- name: GetCertificateRequester :one
+-- Get who requested a certificate
+SELECT hostname, requester_name, requester_email, team, justification, recorded_at
+FROM certificate_requesters
+WHERE hostname = ?;
+
+-- name: UpsertCertificateRequester :exec
+-- Record who requested a certificate, replacing an earlier request
+INSERT INTO certificate_requesters (hostname, requester_name, requester_email, team, justification, recorded_at)
+VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (hostname) DO UPDATE SET
+    requester_name = excluded.requester_name,
+    requester_email = excluded.requester_email,
+    team = excluded.team,
+    justification = excluded.justification,
+    recorded_at = excluded.recorded_at;
+
+-- name: ReassignCertificateRequester :exec
+-- Move the requester of a certificate to another hostname (used when renaming)
+UPDATE certificate_requesters SET hostname = sqlc.arg(new_hostname) WHERE hostname = sqlc.arg(old_hostname);
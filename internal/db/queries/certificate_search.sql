@@ -0,0 +1,30 @@
+-- Certificate search queries (FTS5 index over hostnames, SANs and notes)
+
+-- name: SearchCertificateHostnames :many
+-- List the certificates matching an FTS5 query
+SELECT c.hostname
+FROM certificate_search s
+JOIN certificate_search_content c ON c.id = s.rowid
+WHERE certificate_search MATCH ?
+ORDER BY c.hostname;
+
+-- name: SearchCertificateHostnamesLike :many
+-- List the certificates whose indexed text contains a LIKE pattern (for terms
+-- too short for the trigram index)
+SELECT hostname FROM certificate_search_content
+WHERE hostname LIKE ?1 ESCAPE '\'
+   OR sans LIKE ?1 ESCAPE '\'
+   OR note LIKE ?1 ESCAPE '\'
+   OR pending_note LIKE ?1 ESCAPE '\'
+ORDER BY hostname;
+
+-- name: ListStaleSearchSANs :many
+-- List the certificates whose SANs must be parsed again, with their PEMs
+SELECT s.id, c.certificate_pem, c.pending_csr_pem
+FROM certificate_search_content s
+JOIN certificates c ON c.hostname = s.hostname
+WHERE s.sans_stale = 1;
+
+-- name: SetSearchSANs :exec
+-- Store the parsed SANs of a certificate in the search index
+UPDATE certificate_search_content SET sans = ?, sans_stale = 0 WHERE id = ?;
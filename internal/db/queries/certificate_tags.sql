@@ -0,0 +1,33 @@
+-- Certificate tag queries
+
+-- name: AddCertificateTag :exec
+-- Tag a certificate (no-op when it already has the tag)
+INSERT OR IGNORE INTO certificate_tags (hostname, tag) VALUES (?, ?);
+
+-- name: RemoveCertificateTag :execrows
+-- Remove a tag from a certificate
+DELETE FROM certificate_tags WHERE hostname = ? AND tag = ?;
+
+-- name: ListCertificateTags :many
+-- List the tags of a certificate
+SELECT tag FROM certificate_tags
+WHERE hostname = ?
+ORDER BY tag ASC;
+
+-- name: ListAllCertificateTags :many
+-- List the tags of every certificate
+SELECT * FROM certificate_tags
+ORDER BY hostname, tag;
+
+-- name: ListTagCounts :many
+-- List every tag in use with the number of certificates carrying it
+SELECT tag, COUNT(*) AS certificates
+FROM certificate_tags
+WHERE hostname IN (SELECT hostname FROM certificates WHERE deleted_at IS NULL)
+GROUP BY tag
+ORDER BY tag ASC;
+
+-- name: ReassignCertificateTags :exec
+-- Move the tags of a certificate to another hostname (used when renaming or
+-- merging; a tag the other hostname already has is left behind)
+UPDATE OR IGNORE certificate_tags SET hostname = sqlc.arg(new_hostname) WHERE hostname = sqlc.arg(old_hostname);
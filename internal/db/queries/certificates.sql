@@ -9,8 +9,9 @@ INSERT INTO certificates (
     expires_at,
     note,
     pending_note,
-    read_only
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+    read_only,
+    chain_pem
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: ImportCertificate :exec
 -- Insert a certificate preserving its original created_at (used by backup import)
@@ -24,24 +25,36 @@ INSERT INTO certificates (
     expires_at,
     note,
     pending_note,
-    read_only
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+    read_only,
+    chain_pem,
+    ca_reference,
+    submitted_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: GetCertificateByHostname :one
--- Get a certificate by hostname
-SELECT * FROM certificates WHERE hostname = ? LIMIT 1;
+-- Get a certificate by hostname (not one in the trash)
+SELECT * FROM certificates WHERE hostname = ? AND deleted_at IS NULL LIMIT 1;
 
 -- name: ListAllCertificates :many
--- List all certificates ordered by creation date
+-- List all certificates ordered by creation date (not those in the trash)
+SELECT * FROM certificates
+WHERE deleted_at IS NULL
+ORDER BY created_at DESC;
+
+-- name: ListAllCertificatesIncludingDeleted :many
+-- List all certificates, including those in the trash (for key maintenance)
 SELECT * FROM certificates
 ORDER BY created_at DESC;
 
 -- name: UpdatePendingCSR :exec
 -- Store or update pending CSR and key (unified for initial generation or renewal)
+-- A new CSR has not been submitted to the CA yet, so its tracking is reset
 UPDATE certificates
 SET pending_csr_pem = ?,
     pending_encrypted_private_key = ?,
     pending_note = ?,
+    ca_reference = NULL,
+    submitted_at = NULL,
     last_modified = unixepoch('now')
 WHERE hostname = ?;
 
@@ -52,9 +65,12 @@ WHERE hostname = ?;
 UPDATE certificates
 SET encrypted_private_key = COALESCE(pending_encrypted_private_key, encrypted_private_key),
     certificate_pem = ?,
+    chain_pem = ?,
     pending_csr_pem = NULL,
     pending_encrypted_private_key = NULL,
     pending_note = NULL,
+    ca_reference = NULL,
+    submitted_at = NULL,
     expires_at = ?,
     last_modified = unixepoch('now')
 WHERE hostname = ?;
@@ -68,9 +84,14 @@ DELETE FROM certificates WHERE hostname = ?;
 DELETE FROM certificates;
 
 -- name: CertificateExists :one
--- Check if certificate exists by hostname
+-- Check if certificate exists by hostname (a certificate in the trash still
+-- holds its hostname)
 SELECT CASE WHEN COUNT(*) > 0 THEN 1 ELSE 0 END AS cert_exists FROM certificates WHERE hostname = ?;
 
+-- name: CountCertificates :one
+-- Count all certificates, including those in the trash
+SELECT COUNT(*) AS count FROM certificates;
+
 -- name: UpdateCertificateNote :exec
 -- Update the note field for a certificate
 UPDATE certificates
@@ -84,6 +105,8 @@ UPDATE certificates
 SET pending_csr_pem = NULL,
     pending_encrypted_private_key = NULL,
     pending_note = NULL,
+    ca_reference = NULL,
+    submitted_at = NULL,
     last_modified = unixepoch('now')
 WHERE hostname = ?;
 
@@ -94,6 +117,14 @@ SET pending_note = ?,
     last_modified = unixepoch('now')
 WHERE hostname = ?;
 
+-- name: UpdateCSRSubmission :exec
+-- Record that the pending CSR was submitted to the CA
+UPDATE certificates
+SET ca_reference = ?,
+    submitted_at = ?,
+    last_modified = unixepoch('now')
+WHERE hostname = ?;
+
 -- name: UpdateCertificateReadOnly :exec
 -- Mark certificate as read-only
 UPDATE certificates
@@ -109,6 +140,19 @@ SET encrypted_private_key = ?,
     last_modified = unixepoch('now')
 WHERE hostname = ?;
 
+-- name: ReplaceEncryptedPrivateKey :execrows
+-- Swap the active key blob for a re-encrypted copy, only if it is unchanged since
+-- it was read. The key itself is the same, so last_modified is left alone.
+UPDATE certificates
+SET encrypted_private_key = sqlc.arg(new_key)
+WHERE hostname = sqlc.arg(hostname) AND encrypted_private_key = sqlc.arg(old_key);
+
+-- name: ReplacePendingEncryptedPrivateKey :execrows
+-- Same as ReplaceEncryptedPrivateKey for the pending key blob
+UPDATE certificates
+SET pending_encrypted_private_key = sqlc.arg(new_key)
+WHERE hostname = sqlc.arg(hostname) AND pending_encrypted_private_key = sqlc.arg(old_key);
+
 -- name: RestoreCertificate :exec
 -- Restore a complete certificate from backup in a single operation
 INSERT INTO certificates (
@@ -122,8 +166,11 @@ INSERT INTO certificates (
     last_modified,
     note,
     pending_note,
-    read_only
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    read_only,
+    chain_pem,
+    ca_reference,
+    submitted_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(hostname) DO UPDATE SET
     encrypted_private_key = excluded.encrypted_private_key,
     pending_encrypted_private_key = excluded.pending_encrypted_private_key,
@@ -133,4 +180,156 @@ ON CONFLICT(hostname) DO UPDATE SET
     last_modified = excluded.last_modified,
     note = excluded.note,
     pending_note = excluded.pending_note,
-    read_only = excluded.read_only;
+    read_only = excluded.read_only,
+    chain_pem = excluded.chain_pem,
+    ca_reference = excluded.ca_reference,
+    submitted_at = excluded.submitted_at,
+    key_status = '';
+
+-- name: CopyCertificateToHostname :exec
+-- Duplicate a certificate row under a new hostname (used to rename a certificate:
+-- copy, reassign history, then delete the old row)
+INSERT INTO certificates (
+    hostname,
+    encrypted_private_key,
+    pending_encrypted_private_key,
+    pending_csr_pem,
+    certificate_pem,
+    created_at,
+    expires_at,
+    last_modified,
+    note,
+    pending_note,
+    read_only,
+    chain_pem,
+    ca_reference,
+    submitted_at,
+    key_status
+)
+SELECT sqlc.arg(new_hostname),
+    encrypted_private_key,
+    pending_encrypted_private_key,
+    pending_csr_pem,
+    certificate_pem,
+    created_at,
+    expires_at,
+    unixepoch('now'),
+    note,
+    pending_note,
+    read_only,
+    chain_pem,
+    ca_reference,
+    submitted_at,
+    key_status
+FROM certificates
+WHERE certificates.hostname = sqlc.arg(old_hostname);
+
+-- name: UpdateCertificateKeyStatus :execrows
+-- Store the recomputed key pair health; a row already holding it is left
+-- untouched, so an unchanged status is not counted as a write
+UPDATE certificates
+SET key_status = sqlc.arg(key_status)
+WHERE hostname = sqlc.arg(hostname) AND key_status <> sqlc.arg(key_status);
+
+-- name: ListUncheckedKeyStatusHostnames :many
+-- Certificates whose key pair health has not been computed yet
+SELECT hostname FROM certificates
+WHERE key_status = '' AND deleted_at IS NULL
+ORDER BY hostname;
+
+-- name: ListCertificatePage :many
+-- List one page of the certificates matching a filter, sorted, with their
+-- status computed at now for an "expiring soon" window of threshold days.
+-- Empty or zero filter arguments match everything; hostnames (a JSON array)
+-- applies only when restrict_hostnames is 1, and tags (a JSON array) keeps
+-- certificates carrying every tag. A negative limit returns every row.
+SELECT hostname, created_at, expires_at, read_only, ca_reference, submitted_at,
+    key_status, sans, key_algorithm, key_size, has_pending_csr, status
+FROM (
+    SELECT hostname, created_at, expires_at, read_only, ca_reference, submitted_at,
+        key_status, sans, key_algorithm, key_size,
+        COALESCE(pending_csr_pem, '') <> '' AS has_pending_csr,
+        CASE
+            WHEN COALESCE(certificate_pem, '') = '' THEN 'pending'
+            WHEN expires_at IS NULL THEN 'active'
+            WHEN expires_at < sqlc.arg(now) THEN 'expired'
+            WHEN expires_at < sqlc.arg(now) + (sqlc.arg(threshold) + 1) * 86400 THEN 'expiring'
+            ELSE 'active'
+        END AS status
+    FROM certificates
+    WHERE deleted_at IS NULL
+) c
+WHERE (sqlc.arg(status) = '' OR status = sqlc.arg(status))
+  AND (sqlc.arg(hostname_pattern) = '' OR hostname LIKE sqlc.arg(hostname_pattern) ESCAPE '\')
+  AND (sqlc.arg(awaiting_before) = 0 OR (has_pending_csr AND submitted_at <= sqlc.arg(awaiting_before)))
+  AND (sqlc.arg(restrict_hostnames) = 0 OR hostname IN (SELECT value FROM json_each(sqlc.arg(hostnames))))
+  AND NOT EXISTS (
+    SELECT 1 FROM json_each(sqlc.arg(tags)) t
+    WHERE t.value NOT IN (SELECT tag FROM certificate_tags g WHERE g.hostname = c.hostname)
+  )
+ORDER BY
+    CASE WHEN sqlc.arg(sort_by) = 'hostname' AND NOT sqlc.arg(sort_desc) THEN hostname END ASC,
+    CASE WHEN sqlc.arg(sort_by) = 'hostname' AND sqlc.arg(sort_desc) THEN hostname END DESC,
+    CASE WHEN sqlc.arg(sort_by) = 'expiring' AND NOT sqlc.arg(sort_desc) THEN COALESCE(expires_at, 0) END ASC,
+    CASE WHEN sqlc.arg(sort_by) = 'expiring' AND sqlc.arg(sort_desc) THEN COALESCE(expires_at, 0) END DESC,
+    CASE WHEN sqlc.arg(sort_by) = 'created' AND NOT sqlc.arg(sort_desc) THEN created_at END ASC,
+    CASE WHEN sqlc.arg(sort_by) = 'created' AND sqlc.arg(sort_desc) THEN created_at END DESC,
+    hostname ASC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountCertificatePage :one
+-- Count the certificates matching a filter (same arguments as ListCertificatePage)
+SELECT COUNT(*)
+FROM (
+    SELECT hostname, submitted_at,
+        COALESCE(pending_csr_pem, '') <> '' AS has_pending_csr,
+        CASE
+            WHEN COALESCE(certificate_pem, '') = '' THEN 'pending'
+            WHEN expires_at IS NULL THEN 'active'
+            WHEN expires_at < sqlc.arg(now) THEN 'expired'
+            WHEN expires_at < sqlc.arg(now) + (sqlc.arg(threshold) + 1) * 86400 THEN 'expiring'
+            ELSE 'active'
+        END AS status
+    FROM certificates
+    WHERE deleted_at IS NULL
+) c
+WHERE (sqlc.arg(status) = '' OR status = sqlc.arg(status))
+  AND (sqlc.arg(hostname_pattern) = '' OR hostname LIKE sqlc.arg(hostname_pattern) ESCAPE '\')
+  AND (sqlc.arg(awaiting_before) = 0 OR (has_pending_csr AND submitted_at <= sqlc.arg(awaiting_before)))
+  AND (sqlc.arg(restrict_hostnames) = 0 OR hostname IN (SELECT value FROM json_each(sqlc.arg(hostnames))))
+  AND NOT EXISTS (
+    SELECT 1 FROM json_each(sqlc.arg(tags)) t
+    WHERE t.value NOT IN (SELECT tag FROM certificate_tags g WHERE g.hostname = c.hostname)
+  );
+
+-- name: TrashCertificate :execrows
+-- Move a certificate to the trash; its history, tags and keys are kept
+UPDATE certificates
+SET deleted_at = sqlc.arg(deleted_at)
+WHERE hostname = sqlc.arg(hostname) AND deleted_at IS NULL;
+
+-- name: RestoreDeletedCertificate :execrows
+-- Take a certificate out of the trash
+UPDATE certificates
+SET deleted_at = NULL
+WHERE hostname = ? AND deleted_at IS NOT NULL;
+
+-- name: ListDeletedCertificates :many
+-- List the certificates in the trash, most recently deleted first
+SELECT hostname, expires_at, note, deleted_at,
+    encrypted_private_key IS NOT NULL OR pending_encrypted_private_key IS NOT NULL AS has_private_key
+FROM certificates
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, hostname ASC;
+
+-- name: CertificateInTrash :one
+-- Check if the certificate holding a hostname is in the trash
+SELECT COUNT(*) FROM certificates WHERE hostname = ? AND deleted_at IS NOT NULL;
+
+-- name: PurgeDeletedCertificate :execrows
+-- Permanently delete a certificate from the trash
+DELETE FROM certificates WHERE hostname = ? AND deleted_at IS NOT NULL;
+
+-- name: PurgeExpiredTrash :execrows
+-- Permanently delete the certificates moved to the trash at or before a time
+DELETE FROM certificates WHERE deleted_at IS NOT NULL AND deleted_at <= ?;
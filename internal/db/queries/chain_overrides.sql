@@ -0,0 +1,37 @@
+-- Chain override queries
+
+-- name: ListChainOverrides :many
+-- List the chain overrides of certificates and issuing CAs
+SELECT id, hostname, issuer_dn, issuer_url, issuer_pem, created_at
+FROM chain_overrides
+ORDER BY id ASC;
+
+-- name: UpsertCertificateChainOverride :exec
+-- Set the issuer URL or certificate used for one certificate's chain
+INSERT INTO chain_overrides (hostname, issuer_url, issuer_pem)
+VALUES (?, ?, ?)
+ON CONFLICT (hostname) DO UPDATE SET
+    issuer_url = excluded.issuer_url,
+    issuer_pem = excluded.issuer_pem,
+    created_at = unixepoch();
+
+-- name: UpsertIssuerChainOverride :exec
+-- Set the issuer URL or certificate used for every certificate issued by a CA
+INSERT INTO chain_overrides (issuer_dn, issuer_url, issuer_pem)
+VALUES (?, ?, ?)
+ON CONFLICT (issuer_dn) DO UPDATE SET
+    issuer_url = excluded.issuer_url,
+    issuer_pem = excluded.issuer_pem,
+    created_at = unixepoch();
+
+-- name: DeleteCertificateChainOverride :execrows
+-- Remove the chain override of a certificate
+DELETE FROM chain_overrides WHERE hostname = ?;
+
+-- name: DeleteIssuerChainOverride :execrows
+-- Remove the chain override of an issuing CA
+DELETE FROM chain_overrides WHERE issuer_dn = ?;
+
+-- name: ReassignChainOverride :exec
+-- Move a certificate's chain override to another hostname (used when renaming)
+UPDATE chain_overrides SET hostname = sqlc.arg(new_hostname) WHERE hostname = sqlc.arg(old_hostname);
@@ -4,7 +4,12 @@ SELECT id, owner_email, ca_name, hostname_suffix, validity_period_days,
        default_organization, default_organizational_unit,
        default_city, default_state, default_country, default_key_size,
        is_configured,
-       created_at, last_modified
+       created_at, last_modified, expiring_threshold_days,
+       clock_check_url, air_gapped,
+       backup_freshness_max_writes, backup_freshness_block,
+       writes_since_backup, last_backup_at, fips_mode, db_size_warn_mb,
+       log_level, log_component_levels,
+       log_max_size_mb, log_max_files, log_max_age_days, log_compress, minimize_to_tray, run_in_background, expiry_notifications, aia_cache_ttl_minutes, download_line_endings, download_text_header, download_format, key_pool_size, kdf_profile, ticket_pattern, trash_retention_days
 FROM config WHERE id = 1 LIMIT 1;
 
 -- name: ConfigExists :one
@@ -16,8 +21,9 @@ SELECT CASE WHEN COUNT(*) > 0 THEN 1 ELSE 0 END AS config_exists FROM config WHE
 INSERT INTO config (
     id, owner_email, ca_name, hostname_suffix, validity_period_days,
     default_organization, default_organizational_unit,
-    default_city, default_state, default_country, default_key_size
-) VALUES (1, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+    default_city, default_state, default_country, default_key_size,
+    kdf_profile
+) VALUES (1, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 
 -- name: UpdateConfig :exec
 -- Update configuration (preserves is_configured flag)
@@ -32,6 +38,28 @@ SET owner_email = ?,
     default_state = ?,
     default_country = ?,
     default_key_size = ?,
+    expiring_threshold_days = ?,
+    clock_check_url = ?,
+    air_gapped = ?,
+    backup_freshness_max_writes = ?,
+    backup_freshness_block = ?,
+    fips_mode = ?,
+    db_size_warn_mb = ?,
+    log_max_size_mb = ?,
+    log_max_files = ?,
+    log_max_age_days = ?,
+    log_compress = ?,
+    minimize_to_tray = ?,
+    run_in_background = ?,
+    expiry_notifications = ?,
+    aia_cache_ttl_minutes = ?,
+    download_line_endings = ?,
+    download_text_header = ?,
+    download_format = ?,
+    key_pool_size = ?,
+    kdf_profile = ?,
+    ticket_pattern = ?,
+    trash_retention_days = ?,
     last_modified = unixepoch('now')
 WHERE id = 1;
 
@@ -45,3 +73,32 @@ UPDATE config
 SET is_configured = 1,
     last_modified = unixepoch('now')
 WHERE id = 1;
+
+-- name: RecordBackup :exec
+-- Reset the backup freshness counter after a manual backup or export
+UPDATE config
+SET writes_since_backup = 0,
+    last_backup_at = ?
+WHERE id = 1;
+
+-- name: DiscountCertificateWrite :exec
+-- Undo the backup freshness count of a certificate write that left its data
+-- unchanged (re-encrypting a key blob)
+UPDATE config
+SET writes_since_backup = MAX(writes_since_backup - 1, 0)
+WHERE id = 1;
+
+-- name: UpdateLogLevel :exec
+-- Persist the runtime log level (empty keeps the build default)
+UPDATE config SET log_level = ? WHERE id = 1;
+
+-- name: UpdateComponentLogLevels :exec
+-- Persist the per-component log level overrides (JSON object)
+UPDATE config SET log_component_levels = ? WHERE id = 1;
+
+-- name: UpdateHostnameSuffix :exec
+-- Set the suffix generated hostnames must end with
+UPDATE config
+SET hostname_suffix = ?,
+    last_modified = unixepoch('now')
+WHERE id = 1;
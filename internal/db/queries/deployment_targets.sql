@@ -0,0 +1,25 @@
+-- Deployment target queries
+
+-- name: ListDeploymentTargets :many
+-- List where a certificate is deployed
+SELECT id, hostname, name, location, deployed_at
+FROM deployment_targets
+WHERE hostname = ?
+ORDER BY name ASC;
+
+-- name: UpsertDeploymentTarget :exec
+-- Record that a certificate is deployed on a target, or update its location
+INSERT INTO deployment_targets (hostname, name, location, deployed_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (hostname, name) DO UPDATE SET
+    location = excluded.location,
+    deployed_at = excluded.deployed_at;
+
+-- name: DeleteDeploymentTarget :execrows
+-- Remove a deployment target
+DELETE FROM deployment_targets WHERE id = ?;
+
+-- name: ReassignDeploymentTargets :exec
+-- Move the deployment targets of a certificate to another hostname (used when renaming
+-- or merging; a target the other hostname already has is left behind)
+UPDATE OR IGNORE deployment_targets SET hostname = sqlc.arg(new_hostname) WHERE hostname = sqlc.arg(old_hostname);
@@ -2,12 +2,12 @@
 
 -- name: AddHistoryEntry :exec
 -- Add a new history entry for a certificate
-INSERT INTO certificate_history (hostname, event_type, message)
-VALUES (?, ?, ?);
+INSERT INTO certificate_history (hostname, event_type, message, actor, app_version, details)
+VALUES (?, ?, ?, ?, ?, ?);
 
 -- name: GetCertificateHistory :many
 -- Get history entries for a certificate, ordered by most recent first
-SELECT id, hostname, event_type, message, created_at
+SELECT id, hostname, event_type, message, created_at, actor, app_version, details
 FROM certificate_history
 WHERE hostname = ?
 ORDER BY created_at DESC
@@ -16,3 +16,30 @@ LIMIT ?;
 -- name: DeleteCertificateHistory :exec
 -- Delete all history entries for a certificate (used when certificate is deleted)
 DELETE FROM certificate_history WHERE hostname = ?;
+
+-- name: ReassignCertificateHistory :exec
+-- Move history entries from one hostname to another (used when renaming or merging)
+UPDATE certificate_history SET hostname = sqlc.arg(new_hostname) WHERE hostname = sqlc.arg(old_hostname);
+
+-- name: ListHistory :many
+-- List history entries across all certificates, most recent first. event_types is
+-- a comma-separated list (empty for all); created_to is exclusive (0 for no bound).
+SELECT id, hostname, event_type, message, created_at, actor, app_version, details
+FROM certificate_history
+WHERE (CAST(sqlc.arg(event_types) AS TEXT) = '' OR instr(',' || sqlc.arg(event_types) || ',', ',' || event_type || ',') > 0)
+  AND created_at >= CAST(sqlc.arg(created_from) AS INTEGER)
+  AND (CAST(sqlc.arg(created_to) AS INTEGER) = 0 OR created_at < sqlc.arg(created_to))
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(page_limit) OFFSET sqlc.arg(page_offset);
+
+-- name: CountHistory :one
+-- Count the history entries ListHistory pages through
+SELECT COUNT(*) AS count
+FROM certificate_history
+WHERE (CAST(sqlc.arg(event_types) AS TEXT) = '' OR instr(',' || sqlc.arg(event_types) || ',', ',' || event_type || ',') > 0)
+  AND created_at >= CAST(sqlc.arg(created_from) AS INTEGER)
+  AND (CAST(sqlc.arg(created_to) AS INTEGER) = 0 OR created_at < sqlc.arg(created_to));
+
+-- name: DeleteHistoryBefore :execrows
+-- Delete history entries older than a cutoff (database cleanup)
+DELETE FROM certificate_history WHERE created_at < ?;
@@ -0,0 +1,38 @@
+-- Note reference queries (URLs and ticket IDs found in certificate notes)
+
+-- name: GetTicketPattern :one
+-- Get the regular expression ticket IDs are extracted with
+SELECT ticket_pattern FROM config WHERE id = 1;
+
+-- name: ListPendingNoteReferences :many
+-- List the certificates whose notes changed since their references were
+-- extracted, with their notes
+SELECT p.hostname, c.note, c.pending_note
+FROM note_references_pending p
+JOIN certificates c ON c.hostname = p.hostname
+ORDER BY p.hostname;
+
+-- name: ClearPendingNoteReferences :exec
+-- Mark the references of a certificate as extracted
+DELETE FROM note_references_pending WHERE hostname = ?;
+
+-- name: DeleteNoteReferences :exec
+-- Remove the extracted references of a certificate
+DELETE FROM certificate_note_references WHERE hostname = ?;
+
+-- name: AddNoteReference :exec
+-- Record a URL or ticket ID found in a certificate note
+INSERT OR IGNORE INTO certificate_note_references (hostname, source, kind, value)
+VALUES (?, ?, ?, ?);
+
+-- name: ListNoteReferences :many
+-- List the references found in the notes of a certificate
+SELECT * FROM certificate_note_references
+WHERE hostname = ?
+ORDER BY source, kind, value;
+
+-- name: ListHostnamesByNoteReference :many
+-- List the certificates whose notes reference a URL or ticket ID (case-insensitive)
+SELECT DISTINCT hostname FROM certificate_note_references
+WHERE value = ? COLLATE NOCASE
+ORDER BY hostname;
@@ -0,0 +1,15 @@
+-- name: CreateOperationIntent :one
+-- Record the intent of a multi-step operation before its first step
+INSERT INTO operation_intents (operation, hostname, payload)
+VALUES (?, ?, ?)
+RETURNING id;
+
+-- name: DeleteOperationIntent :exec
+-- Remove an intent once its operation finished or was resolved
+DELETE FROM operation_intents WHERE id = ?;
+
+-- name: ListOperationIntents :many
+-- List the intents left by unfinished operations, oldest first
+SELECT id, operation, hostname, payload, created_at
+FROM operation_intents
+ORDER BY id ASC;
@@ -0,0 +1,32 @@
+-- Renewal checklist queries
+
+-- name: ListRenewalChecklist :many
+-- Get the completed renewal steps of a certificate
+SELECT hostname, step, completed_at, actor
+FROM renewal_checklist
+WHERE hostname = ?;
+
+-- name: CompleteRenewalStep :exec
+-- Mark a renewal step as completed; completing it again keeps the first completion
+INSERT INTO renewal_checklist (hostname, step, actor)
+VALUES (?, ?, ?)
+ON CONFLICT (hostname, step) DO NOTHING;
+
+-- name: ReopenRenewalStep :execrows
+-- Mark a renewal step as not completed
+DELETE FROM renewal_checklist WHERE hostname = ? AND step = ?;
+
+-- name: ClearRenewalChecklist :exec
+-- Clear the checklist of a certificate when a new renewal starts
+DELETE FROM renewal_checklist WHERE hostname = ?;
+
+-- name: ReassignRenewalChecklist :exec
+-- Move checklist entries from one hostname to another (used when renaming)
+UPDATE renewal_checklist SET hostname = sqlc.arg(new_hostname) WHERE hostname = sqlc.arg(old_hostname);
+
+-- name: RestoreRenewalStep :exec
+-- Restore a completed renewal step from a backup, keeping when and by whom it
+-- was completed
+INSERT INTO renewal_checklist (hostname, step, completed_at, actor)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (hostname, step) DO NOTHING;
@@ -0,0 +1,26 @@
+-- Setup wizard progress queries
+
+-- name: GetSetupProgress :one
+-- Get the saved progress of the setup wizard
+SELECT id, step, draft, updated_at FROM setup_progress WHERE id = 1;
+
+-- name: SaveSetupProgress :exec
+-- Save the progress of the setup wizard
+INSERT INTO setup_progress (id, step, draft, updated_at)
+VALUES (1, ?, ?, unixepoch('now'))
+ON CONFLICT (id) DO UPDATE SET
+    step = excluded.step,
+    draft = excluded.draft,
+    updated_at = excluded.updated_at;
+
+-- name: DeleteSetupProgress :exec
+-- Forget the progress of the setup wizard
+DELETE FROM setup_progress;
+
+-- name: DeleteIncompleteConfig :execrows
+-- Remove a configuration left behind by an interrupted setup
+DELETE FROM config WHERE is_configured = 0;
+
+-- name: DeleteAllSecurityKeys :exec
+-- Remove every unlock method (used only to abort an incomplete setup)
+DELETE FROM security_keys;
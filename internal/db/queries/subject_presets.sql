@@ -0,0 +1,33 @@
+-- name: ListSubjectPresets :many
+-- List all subject presets ordered by name
+SELECT id, name, organization, organizational_unit, city, state, country, created_at, last_modified
+FROM subject_presets
+ORDER BY name COLLATE NOCASE ASC;
+
+-- name: GetSubjectPresetByID :one
+-- Get a single subject preset by ID
+SELECT id, name, organization, organizational_unit, city, state, country, created_at, last_modified
+FROM subject_presets
+WHERE id = ?;
+
+-- name: InsertSubjectPreset :one
+-- Insert a new subject preset and return the created row
+INSERT INTO subject_presets (name, organization, organizational_unit, city, state, country)
+VALUES (?, ?, ?, ?, ?, ?)
+RETURNING id, name, organization, organizational_unit, city, state, country, created_at, last_modified;
+
+-- name: UpdateSubjectPreset :exec
+-- Update a subject preset
+UPDATE subject_presets
+SET name = ?,
+    organization = ?,
+    organizational_unit = ?,
+    city = ?,
+    state = ?,
+    country = ?,
+    last_modified = unixepoch()
+WHERE id = ?;
+
+-- name: DeleteSubjectPreset :exec
+-- Delete a subject preset by ID
+DELETE FROM subject_presets WHERE id = ?;
@@ -0,0 +1,77 @@
+-- Companion agent sync queries
+
+-- name: GetSyncServer :one
+-- Get the sync CA and listen address
+SELECT * FROM sync_server WHERE id = 1;
+
+-- name: CreateSyncServer :exec
+-- Store the sync CA, created on the first agent enrollment
+INSERT INTO sync_server (id, ca_certificate_pem, ca_encrypted_private_key)
+VALUES (1, ?, ?);
+
+-- name: SetSyncServerListenAddress :exec
+-- Record the address the sync server listens on (empty once stopped)
+UPDATE sync_server SET listen_address = ? WHERE id = 1;
+
+-- name: InsertSyncAgent :one
+-- Enroll an agent and return the created row
+INSERT INTO sync_agents (name, cert_fingerprint, expires_at)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: ListSyncAgents :many
+-- List enrolled agents, revoked ones last
+SELECT * FROM sync_agents
+ORDER BY revoked_at IS NOT NULL, name ASC;
+
+-- name: GetActiveSyncAgent :one
+-- Get an agent that has not been revoked
+SELECT * FROM sync_agents WHERE id = ? AND revoked_at IS NULL;
+
+-- name: GetSyncAgentByFingerprint :one
+-- Find the agent a client certificate was issued to
+SELECT * FROM sync_agents WHERE cert_fingerprint = ?;
+
+-- name: RevokeSyncAgent :one
+-- Revoke an agent and return its name; its client certificate is refused from
+-- then on
+UPDATE sync_agents SET revoked_at = sqlc.arg(revoked_at)
+WHERE id = sqlc.arg(id) AND revoked_at IS NULL
+RETURNING name;
+
+-- name: TouchSyncAgent :exec
+-- Record when an agent last talked to the sync server
+UPDATE sync_agents SET last_seen_at = ? WHERE id = ?;
+
+-- name: AddSyncAgentHostname :exec
+-- Allow an agent to pull a certificate
+INSERT OR IGNORE INTO sync_agent_hostnames (agent_id, hostname) VALUES (?, ?);
+
+-- name: DeleteSyncAgentHostnames :exec
+-- Remove every certificate assigned to an agent
+DELETE FROM sync_agent_hostnames WHERE agent_id = ?;
+
+-- name: ListSyncAgentHostnames :many
+-- List the certificate assignments of every agent
+SELECT * FROM sync_agent_hostnames
+ORDER BY agent_id, hostname;
+
+-- name: ListSyncAgentCertificates :many
+-- List the issued certificates an agent may pull
+SELECT c.hostname, c.certificate_pem, c.expires_at
+FROM sync_agent_hostnames a
+JOIN certificates c ON c.hostname = a.hostname
+WHERE a.agent_id = ? AND c.certificate_pem IS NOT NULL AND c.deleted_at IS NULL
+ORDER BY c.hostname ASC;
+
+-- name: GetSyncAgentCertificate :one
+-- Get a certificate assigned to an agent, with its encrypted key
+SELECT c.hostname, c.certificate_pem, c.chain_pem, c.encrypted_private_key
+FROM sync_agent_hostnames a
+JOIN certificates c ON c.hostname = a.hostname
+WHERE a.agent_id = sqlc.arg(agent_id) AND a.hostname = sqlc.arg(hostname) AND c.deleted_at IS NULL;
+
+-- name: ReassignSyncAgentHostnames :exec
+-- Move the agent assignments of a certificate to another hostname (used when renaming
+-- or merging; an agent the other hostname is already assigned to is left behind)
+UPDATE OR IGNORE sync_agent_hostnames SET hostname = sqlc.arg(new_hostname) WHERE hostname = sqlc.arg(old_hostname);
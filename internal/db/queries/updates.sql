@@ -11,3 +11,11 @@ SELECT id, from_version, to_version, status, error_message, created_at
 FROM update_history
 ORDER BY created_at DESC
 LIMIT ?;
+
+-- name: CountUpdateHistory :one
+-- Count update history entries
+SELECT COUNT(*) AS count FROM update_history;
+
+-- name: DeleteUpdateHistoryBefore :execrows
+-- Delete update history entries older than a cutoff (database cleanup)
+DELETE FROM update_history WHERE created_at < ?;
@@ -0,0 +1,110 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+// FileName is the database file in a data directory
+const FileName = "certificates.db"
+
+// OpenReadOnly opens the database of dataDir without creating, migrating or
+// writing to it, for the health check run next to (or instead of) the app.
+// The database must exist.
+func OpenReadOnly(dataDir string) (*Database, error) {
+	path := filepath.Join(dataDir, FileName)
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("database not found: %w", err)
+	}
+
+	// query_only refuses writes even where the file itself is writable, e.g.
+	// when SQLite needs write access to the WAL index
+	db, err := sql.Open("sqlite", "file:"+filepath.ToSlash(path)+"?mode=ro&_pragma=busy_timeout(5000)&_pragma=query_only(1)")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	return &Database{
+		db:      db,
+		queries: sqlc.New(db),
+	}, nil
+}
+
+// IntegrityCheck runs SQLite's integrity check and returns the problems it
+// reports, none for a sound database. At most maxProblems are returned.
+func (d *Database) IntegrityCheck(ctx context.Context, maxProblems int) ([]string, error) {
+	rows, err := d.db.QueryContext(ctx, fmt.Sprintf("PRAGMA integrity_check(%d)", maxProblems))
+	if err != nil {
+		return nil, fmt.Errorf("integrity check failed: %w", err)
+	}
+	defer rows.Close()
+
+	problems := []string{}
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return nil, fmt.Errorf("integrity check failed: %w", err)
+		}
+		if line != "ok" {
+			problems = append(problems, line)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("integrity check failed: %w", err)
+	}
+	return problems, nil
+}
+
+// SchemaVersion returns the last migration applied to the database, and
+// whether it failed halfway (dirty). A database never migrated is at 0.
+func (d *Database) SchemaVersion(ctx context.Context) (uint, bool, error) {
+	var version int64
+	var dirty bool
+	err := d.db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return uint(version), dirty, nil
+}
+
+// LatestSchemaVersion returns the version of the last migration embedded in
+// this build, the one NewDatabase migrates to
+func LatestSchemaVersion() (uint, error) {
+	source, err := iofs.New(migrations, "migrations")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	defer source.Close()
+
+	version, err := source.First()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migrations: %w", err)
+	}
+	for {
+		next, err := source.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read migrations: %w", err)
+		}
+		version = next
+	}
+}
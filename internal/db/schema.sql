@@ -19,12 +19,21 @@ CREATE TABLE certificates (
     last_modified INTEGER NOT NULL DEFAULT (unixepoch()),
     note TEXT,
     pending_note TEXT,
-    read_only INTEGER NOT NULL DEFAULT 0
+    read_only INTEGER NOT NULL DEFAULT 0,
+    chain_pem TEXT,
+    ca_reference TEXT,
+    submitted_at INTEGER,
+    key_status TEXT NOT NULL DEFAULT '' CHECK(key_status IN ('', 'ok', 'mismatched', 'undecryptable', 'missing')),
+    sans TEXT NOT NULL DEFAULT '[]',
+    key_algorithm TEXT NOT NULL DEFAULT '',
+    key_size INTEGER NOT NULL DEFAULT 0,
+    deleted_at INTEGER
 );
 
 -- Create indexes for common queries
 CREATE INDEX idx_certificates_expires_at ON certificates(expires_at) WHERE expires_at IS NOT NULL;
 CREATE INDEX idx_certificates_created_at ON certificates(created_at);
+CREATE INDEX idx_certificates_deleted_at ON certificates(deleted_at) WHERE deleted_at IS NOT NULL;
 
 -- Create config table
 CREATE TABLE config (
@@ -41,7 +50,33 @@ CREATE TABLE config (
     default_key_size INTEGER NOT NULL DEFAULT 4096 CHECK(default_key_size >= 2048),
     is_configured INTEGER NOT NULL DEFAULT 0,
     created_at INTEGER NOT NULL DEFAULT (unixepoch()),
-    last_modified INTEGER NOT NULL DEFAULT (unixepoch())
+    last_modified INTEGER NOT NULL DEFAULT (unixepoch()),
+    expiring_threshold_days INTEGER NOT NULL DEFAULT 30 CHECK(expiring_threshold_days >= 1),
+    clock_check_url TEXT NOT NULL DEFAULT '',
+    air_gapped INTEGER NOT NULL DEFAULT 0,
+    backup_freshness_max_writes INTEGER NOT NULL DEFAULT 20 CHECK(backup_freshness_max_writes >= 0),
+    backup_freshness_block INTEGER NOT NULL DEFAULT 0,
+    writes_since_backup INTEGER NOT NULL DEFAULT 0,
+    last_backup_at INTEGER,
+    fips_mode INTEGER NOT NULL DEFAULT 0,
+    db_size_warn_mb INTEGER NOT NULL DEFAULT 100 CHECK(db_size_warn_mb >= 0),
+    log_level TEXT NOT NULL DEFAULT '',
+    log_component_levels TEXT NOT NULL DEFAULT '',
+    log_max_size_mb INTEGER NOT NULL DEFAULT 10 CHECK(log_max_size_mb >= 1),
+    log_max_files INTEGER NOT NULL DEFAULT 5 CHECK(log_max_files >= 0),
+    log_max_age_days INTEGER NOT NULL DEFAULT 30 CHECK(log_max_age_days >= 0),
+    log_compress INTEGER NOT NULL DEFAULT 1 CHECK(log_compress IN (0, 1)),
+    minimize_to_tray INTEGER NOT NULL DEFAULT 0 CHECK(minimize_to_tray IN (0, 1)),
+    run_in_background INTEGER NOT NULL DEFAULT 0 CHECK(run_in_background IN (0, 1)),
+    expiry_notifications INTEGER NOT NULL DEFAULT 1 CHECK(expiry_notifications IN (0, 1)),
+    aia_cache_ttl_minutes INTEGER NOT NULL DEFAULT 60 CHECK(aia_cache_ttl_minutes BETWEEN 0 AND 10080),
+    download_line_endings TEXT NOT NULL DEFAULT 'lf' CHECK(download_line_endings IN ('lf', 'crlf')),
+    download_text_header INTEGER NOT NULL DEFAULT 0 CHECK(download_text_header IN (0, 1)),
+    download_format TEXT NOT NULL DEFAULT 'pem' CHECK(download_format IN ('pem', 'der')),
+    key_pool_size INTEGER NOT NULL DEFAULT 0 CHECK(key_pool_size BETWEEN 0 AND 10),
+    kdf_profile TEXT NOT NULL DEFAULT 'standard' CHECK(kdf_profile IN ('standard', 'constrained')),
+    ticket_pattern TEXT NOT NULL DEFAULT '',
+    trash_retention_days INTEGER NOT NULL DEFAULT 30 CHECK(trash_retention_days BETWEEN 0 AND 365)
 );
 
 -- Enforce single config row
@@ -59,6 +94,9 @@ CREATE TABLE certificate_history (
     event_type TEXT NOT NULL,
     message TEXT NOT NULL,
     created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    actor TEXT NOT NULL DEFAULT '',
+    app_version TEXT NOT NULL DEFAULT '',
+    details TEXT NOT NULL DEFAULT '',
     FOREIGN KEY (hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
 );
 
@@ -88,3 +126,200 @@ CREATE TABLE security_keys (
     last_used_at INTEGER
 );
 CREATE INDEX idx_security_keys_method ON security_keys(method);
+
+-- Create subject_presets table for named CSR subject bundles
+CREATE TABLE subject_presets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL UNIQUE,
+    organization TEXT NOT NULL,
+    organizational_unit TEXT,
+    city TEXT NOT NULL,
+    state TEXT NOT NULL,
+    country TEXT NOT NULL CHECK(length(country) = 2),
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    last_modified INTEGER NOT NULL DEFAULT (unixepoch())
+);
+
+-- Create renewal_checklist table for the steps of the current renewal
+CREATE TABLE renewal_checklist (
+    hostname TEXT NOT NULL,
+    step TEXT NOT NULL CHECK(step IN ('csr_sent', 'cert_received', 'uploaded', 'deployed', 'verified')),
+    completed_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    actor TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (hostname, step),
+    FOREIGN KEY (hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
+
+-- Create operation_intents table for write-ahead records of multi-step operations
+CREATE TABLE operation_intents (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    operation TEXT NOT NULL,
+    hostname TEXT NOT NULL DEFAULT '',
+    payload TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
+
+-- Create chain_overrides table for issuer URLs or certificates replacing AIA
+CREATE TABLE chain_overrides (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    hostname TEXT UNIQUE,
+    issuer_dn TEXT UNIQUE,
+    issuer_url TEXT NOT NULL DEFAULT '',
+    issuer_pem TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    CHECK ((hostname IS NULL) != (issuer_dn IS NULL)),
+    CHECK ((issuer_url = '') != (issuer_pem = '')),
+    FOREIGN KEY (hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
+
+-- Create certificate_relations table for dependencies between certificates
+CREATE TABLE certificate_relations (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    source_hostname TEXT NOT NULL,
+    target_hostname TEXT NOT NULL,
+    relation_type TEXT NOT NULL CHECK(relation_type IN ('client_of', 'shared_endpoint')),
+    label TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    CHECK (source_hostname != target_hostname),
+    UNIQUE (source_hostname, target_hostname, relation_type),
+    FOREIGN KEY (source_hostname) REFERENCES certificates(hostname) ON DELETE CASCADE,
+    FOREIGN KEY (target_hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_certificate_relations_target ON certificate_relations(target_hostname);
+
+-- Create certificate_requesters table for requester info from intake files
+CREATE TABLE certificate_requesters (
+    hostname TEXT PRIMARY KEY,
+    requester_name TEXT NOT NULL,
+    requester_email TEXT NOT NULL DEFAULT '',
+    team TEXT NOT NULL DEFAULT '',
+    justification TEXT NOT NULL DEFAULT '',
+    recorded_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    FOREIGN KEY (hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
+
+-- Create deployment_targets table for where each certificate is deployed
+CREATE TABLE deployment_targets (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    hostname TEXT NOT NULL,
+    name TEXT NOT NULL,
+    location TEXT NOT NULL DEFAULT '',
+    deployed_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    UNIQUE (hostname, name),
+    FOREIGN KEY (hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_deployment_targets_hostname ON deployment_targets(hostname);
+
+-- Create sync_server table for the CA of companion agents (single row)
+CREATE TABLE sync_server (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    ca_certificate_pem TEXT NOT NULL,
+    ca_encrypted_private_key BLOB NOT NULL,
+    listen_address TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
+
+-- Create sync_agents table for enrolled companion agents
+CREATE TABLE sync_agents (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL,
+    cert_fingerprint TEXT NOT NULL UNIQUE,
+    expires_at INTEGER NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    revoked_at INTEGER,
+    last_seen_at INTEGER
+);
+
+CREATE UNIQUE INDEX idx_sync_agents_active_name ON sync_agents(name) WHERE revoked_at IS NULL;
+
+-- Create sync_agent_hostnames table for the certificates each agent may pull
+CREATE TABLE sync_agent_hostnames (
+    agent_id INTEGER NOT NULL,
+    hostname TEXT NOT NULL,
+    PRIMARY KEY (agent_id, hostname),
+    FOREIGN KEY (agent_id) REFERENCES sync_agents(id) ON DELETE CASCADE,
+    FOREIGN KEY (hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_sync_agent_hostnames_hostname ON sync_agent_hostnames(hostname);
+
+-- Create certificate_tags table for labels used to filter certificates
+CREATE TABLE certificate_tags (
+    hostname TEXT NOT NULL,
+    tag TEXT NOT NULL,
+    created_at INTEGER NOT NULL DEFAULT (unixepoch()),
+    PRIMARY KEY (hostname, tag),
+    FOREIGN KEY (hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_certificate_tags_tag ON certificate_tags(tag);
+
+-- Create certificate_note_references table for URLs and ticket IDs found in notes
+CREATE TABLE certificate_note_references (
+    hostname TEXT NOT NULL,
+    source TEXT NOT NULL CHECK(source IN ('note', 'pending_note')),
+    kind TEXT NOT NULL CHECK(kind IN ('url', 'ticket')),
+    value TEXT NOT NULL,
+    PRIMARY KEY (hostname, source, kind, value),
+    FOREIGN KEY (hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_certificate_note_references_value ON certificate_note_references(value COLLATE NOCASE);
+
+-- Create note_references_pending table for certificates whose notes changed
+-- (filled by triggers, drained by the reference extractor)
+CREATE TABLE note_references_pending (
+    hostname TEXT PRIMARY KEY,
+    FOREIGN KEY (hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
+
+-- Create certificate_search_content table, the searchable text of each
+-- certificate (kept in sync by triggers; sans filled in Go)
+CREATE TABLE certificate_search_content (
+    id INTEGER PRIMARY KEY,
+    hostname TEXT NOT NULL UNIQUE,
+    sans TEXT NOT NULL DEFAULT '',
+    note TEXT NOT NULL DEFAULT '',
+    pending_note TEXT NOT NULL DEFAULT '',
+    sans_stale INTEGER NOT NULL DEFAULT 1 CHECK(sans_stale IN (0, 1))
+);
+
+-- Create certificate_search FTS5 index over certificate_search_content
+CREATE VIRTUAL TABLE certificate_search USING fts5(
+    hostname, sans, note, pending_note,
+    content = 'certificate_search_content',
+    content_rowid = 'id',
+    tokenize = 'trigram'
+);
+
+-- Create certificate_metadata_pending table, the certificates whose parsed
+-- metadata (sans, key_algorithm, key_size) is out of date
+CREATE TABLE certificate_metadata_pending (
+    hostname TEXT PRIMARY KEY,
+    FOREIGN KEY (hostname) REFERENCES certificates(hostname) ON DELETE CASCADE
+);
+
+-- Create setup_progress table, where the setup wizard resumes from (single
+-- row, removed once setup completes or is aborted)
+CREATE TABLE setup_progress (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    step TEXT NOT NULL,
+    draft TEXT NOT NULL DEFAULT '{}',
+    updated_at INTEGER NOT NULL DEFAULT (unixepoch('now'))
+);
+
+-- Create audit_log table, the security-sensitive operations (never rewritten
+-- or cleaned up, no foreign key so entries outlive their certificate)
+CREATE TABLE audit_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_type TEXT NOT NULL,
+    hostname TEXT NOT NULL DEFAULT '',
+    message TEXT NOT NULL,
+    actor TEXT NOT NULL DEFAULT '',
+    app_version TEXT NOT NULL DEFAULT '',
+    details TEXT NOT NULL DEFAULT '',
+    created_at INTEGER NOT NULL DEFAULT (unixepoch())
+);
+CREATE INDEX idx_audit_log_created_at ON audit_log(created_at);
@@ -14,9 +14,12 @@ const activateCertificate = `-- name: ActivateCertificate :exec
 UPDATE certificates
 SET encrypted_private_key = COALESCE(pending_encrypted_private_key, encrypted_private_key),
     certificate_pem = ?,
+    chain_pem = ?,
     pending_csr_pem = NULL,
     pending_encrypted_private_key = NULL,
     pending_note = NULL,
+    ca_reference = NULL,
+    submitted_at = NULL,
     expires_at = ?,
     last_modified = unixepoch('now')
 WHERE hostname = ?
@@ -24,6 +27,7 @@ WHERE hostname = ?
 
 type ActivateCertificateParams struct {
 	CertificatePem sql.NullString `json:"certificate_pem"`
+	ChainPem       sql.NullString `json:"chain_pem"`
 	ExpiresAt      sql.NullInt64  `json:"expires_at"`
 	Hostname       string         `json:"hostname"`
 }
@@ -32,7 +36,12 @@ type ActivateCertificateParams struct {
 // Move pending key to active column, store certificate, clear pending columns
 // COALESCE ensures existing key is preserved if pending key is somehow NULL
 func (q *Queries) ActivateCertificate(ctx context.Context, arg ActivateCertificateParams) error {
-	_, err := q.exec(ctx, q.activateCertificateStmt, activateCertificate, arg.CertificatePem, arg.ExpiresAt, arg.Hostname)
+	_, err := q.exec(ctx, q.activateCertificateStmt, activateCertificate,
+		arg.CertificatePem,
+		arg.ChainPem,
+		arg.ExpiresAt,
+		arg.Hostname,
+	)
 	return err
 }
 
@@ -40,7 +49,8 @@ const certificateExists = `-- name: CertificateExists :one
 SELECT CASE WHEN COUNT(*) > 0 THEN 1 ELSE 0 END AS cert_exists FROM certificates WHERE hostname = ?
 `
 
-// Check if certificate exists by hostname
+// Check if certificate exists by hostname (a certificate in the trash still
+// holds its hostname)
 func (q *Queries) CertificateExists(ctx context.Context, hostname string) (int64, error) {
 	row := q.queryRow(ctx, q.certificateExistsStmt, certificateExists, hostname)
 	var cert_exists int64
@@ -53,6 +63,8 @@ UPDATE certificates
 SET pending_csr_pem = NULL,
     pending_encrypted_private_key = NULL,
     pending_note = NULL,
+    ca_reference = NULL,
+    submitted_at = NULL,
     last_modified = unixepoch('now')
 WHERE hostname = ?
 `
@@ -63,6 +75,120 @@ func (q *Queries) ClearPendingCSR(ctx context.Context, hostname string) error {
 	return err
 }
 
+const copyCertificateToHostname = `-- name: CopyCertificateToHostname :exec
+INSERT INTO certificates (
+    hostname,
+    encrypted_private_key,
+    pending_encrypted_private_key,
+    pending_csr_pem,
+    certificate_pem,
+    created_at,
+    expires_at,
+    last_modified,
+    note,
+    pending_note,
+    read_only,
+    chain_pem,
+    ca_reference,
+    submitted_at,
+    key_status
+)
+SELECT ?1,
+    encrypted_private_key,
+    pending_encrypted_private_key,
+    pending_csr_pem,
+    certificate_pem,
+    created_at,
+    expires_at,
+    unixepoch('now'),
+    note,
+    pending_note,
+    read_only,
+    chain_pem,
+    ca_reference,
+    submitted_at,
+    key_status
+FROM certificates
+WHERE certificates.hostname = ?2
+`
+
+type CopyCertificateToHostnameParams struct {
+	NewHostname string `json:"new_hostname"`
+	OldHostname string `json:"old_hostname"`
+}
+
+// Duplicate a certificate row under a new hostname (used to rename a certificate:
+// copy, reassign history, then delete the old row)
+func (q *Queries) CopyCertificateToHostname(ctx context.Context, arg CopyCertificateToHostnameParams) error {
+	_, err := q.exec(ctx, q.copyCertificateToHostnameStmt, copyCertificateToHostname, arg.NewHostname, arg.OldHostname)
+	return err
+}
+
+const countCertificatePage = `-- name: CountCertificatePage :one
+SELECT COUNT(*)
+FROM (
+    SELECT hostname, submitted_at,
+        COALESCE(pending_csr_pem, '') <> '' AS has_pending_csr,
+        CASE
+            WHEN COALESCE(certificate_pem, '') = '' THEN 'pending'
+            WHEN expires_at IS NULL THEN 'active'
+            WHEN expires_at < ?1 THEN 'expired'
+            WHEN expires_at < ?1 + (?2 + 1) * 86400 THEN 'expiring'
+            ELSE 'active'
+        END AS status
+    FROM certificates
+    WHERE deleted_at IS NULL
+) c
+WHERE (?3 = '' OR status = ?3)
+  AND (?4 = '' OR hostname LIKE ?4 ESCAPE '\')
+  AND (?5 = 0 OR (has_pending_csr AND submitted_at <= ?5))
+  AND (?6 = 0 OR hostname IN (SELECT value FROM json_each(?7)))
+  AND NOT EXISTS (
+    SELECT 1 FROM json_each(?8) t
+    WHERE t.value NOT IN (SELECT tag FROM certificate_tags g WHERE g.hostname = c.hostname)
+  )
+`
+
+type CountCertificatePageParams struct {
+	Now               int64  `json:"now"`
+	Threshold         int64  `json:"threshold"`
+	Status            string `json:"status"`
+	HostnamePattern   string `json:"hostname_pattern"`
+	AwaitingBefore    int64  `json:"awaiting_before"`
+	RestrictHostnames int64  `json:"restrict_hostnames"`
+	Hostnames         string `json:"hostnames"`
+	Tags              string `json:"tags"`
+}
+
+// Count the certificates matching a filter (same arguments as ListCertificatePage)
+func (q *Queries) CountCertificatePage(ctx context.Context, arg CountCertificatePageParams) (int64, error) {
+	row := q.queryRow(ctx, q.countCertificatePageStmt, countCertificatePage,
+		arg.Now,
+		arg.Threshold,
+		arg.Status,
+		arg.HostnamePattern,
+		arg.AwaitingBefore,
+		arg.RestrictHostnames,
+		arg.Hostnames,
+		arg.Tags,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countCertificates = `-- name: CountCertificates :one
+SELECT COUNT(*) AS count FROM certificates
+`
+
+// Count all certificates, including those in the trash
+func (q *Queries) CountCertificates(ctx context.Context) (int64, error) {
+	row := q.queryRow(ctx, q.countCertificatesStmt, countCertificates)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createCertificate = `-- name: CreateCertificate :exec
 INSERT INTO certificates (
     hostname,
@@ -73,8 +199,9 @@ INSERT INTO certificates (
     expires_at,
     note,
     pending_note,
-    read_only
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+    read_only,
+    chain_pem
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateCertificateParams struct {
@@ -87,6 +214,7 @@ type CreateCertificateParams struct {
 	Note                       sql.NullString `json:"note"`
 	PendingNote                sql.NullString `json:"pending_note"`
 	ReadOnly                   int64          `json:"read_only"`
+	ChainPem                   sql.NullString `json:"chain_pem"`
 }
 
 // Create a new certificate entry with all fields
@@ -101,6 +229,7 @@ func (q *Queries) CreateCertificate(ctx context.Context, arg CreateCertificatePa
 		arg.Note,
 		arg.PendingNote,
 		arg.ReadOnly,
+		arg.ChainPem,
 	)
 	return err
 }
@@ -126,10 +255,10 @@ func (q *Queries) DeleteCertificate(ctx context.Context, hostname string) error
 }
 
 const getCertificateByHostname = `-- name: GetCertificateByHostname :one
-SELECT hostname, encrypted_private_key, pending_csr_pem, certificate_pem, pending_encrypted_private_key, created_at, expires_at, last_modified, note, pending_note, read_only FROM certificates WHERE hostname = ? LIMIT 1
+SELECT hostname, encrypted_private_key, pending_csr_pem, certificate_pem, pending_encrypted_private_key, created_at, expires_at, last_modified, note, pending_note, read_only, chain_pem, ca_reference, submitted_at, key_status, sans, key_algorithm, key_size, deleted_at FROM certificates WHERE hostname = ? AND deleted_at IS NULL LIMIT 1
 `
 
-// Get a certificate by hostname
+// Get a certificate by hostname (not one in the trash)
 func (q *Queries) GetCertificateByHostname(ctx context.Context, hostname string) (Certificate, error) {
 	row := q.queryRow(ctx, q.getCertificateByHostnameStmt, getCertificateByHostname, hostname)
 	var i Certificate
@@ -145,6 +274,14 @@ func (q *Queries) GetCertificateByHostname(ctx context.Context, hostname string)
 		&i.Note,
 		&i.PendingNote,
 		&i.ReadOnly,
+		&i.ChainPem,
+		&i.CaReference,
+		&i.SubmittedAt,
+		&i.KeyStatus,
+		&i.Sans,
+		&i.KeyAlgorithm,
+		&i.KeySize,
+		&i.DeletedAt,
 	)
 	return i, err
 }
@@ -160,8 +297,11 @@ INSERT INTO certificates (
     expires_at,
     note,
     pending_note,
-    read_only
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    read_only,
+    chain_pem,
+    ca_reference,
+    submitted_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type ImportCertificateParams struct {
@@ -175,6 +315,9 @@ type ImportCertificateParams struct {
 	Note                       sql.NullString `json:"note"`
 	PendingNote                sql.NullString `json:"pending_note"`
 	ReadOnly                   int64          `json:"read_only"`
+	ChainPem                   sql.NullString `json:"chain_pem"`
+	CaReference                sql.NullString `json:"ca_reference"`
+	SubmittedAt                sql.NullInt64  `json:"submitted_at"`
 }
 
 // Insert a certificate preserving its original created_at (used by backup import)
@@ -190,16 +333,20 @@ func (q *Queries) ImportCertificate(ctx context.Context, arg ImportCertificatePa
 		arg.Note,
 		arg.PendingNote,
 		arg.ReadOnly,
+		arg.ChainPem,
+		arg.CaReference,
+		arg.SubmittedAt,
 	)
 	return err
 }
 
 const listAllCertificates = `-- name: ListAllCertificates :many
-SELECT hostname, encrypted_private_key, pending_csr_pem, certificate_pem, pending_encrypted_private_key, created_at, expires_at, last_modified, note, pending_note, read_only FROM certificates
+SELECT hostname, encrypted_private_key, pending_csr_pem, certificate_pem, pending_encrypted_private_key, created_at, expires_at, last_modified, note, pending_note, read_only, chain_pem, ca_reference, submitted_at, key_status, sans, key_algorithm, key_size, deleted_at FROM certificates
+WHERE deleted_at IS NULL
 ORDER BY created_at DESC
 `
 
-// List all certificates ordered by creation date
+// List all certificates ordered by creation date (not those in the trash)
 func (q *Queries) ListAllCertificates(ctx context.Context) ([]Certificate, error) {
 	rows, err := q.query(ctx, q.listAllCertificatesStmt, listAllCertificates)
 	if err != nil {
@@ -221,6 +368,14 @@ func (q *Queries) ListAllCertificates(ctx context.Context) ([]Certificate, error
 			&i.Note,
 			&i.PendingNote,
 			&i.ReadOnly,
+			&i.ChainPem,
+			&i.CaReference,
+			&i.SubmittedAt,
+			&i.KeyStatus,
+			&i.Sans,
+			&i.KeyAlgorithm,
+			&i.KeySize,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -235,6 +390,218 @@ func (q *Queries) ListAllCertificates(ctx context.Context) ([]Certificate, error
 	return items, nil
 }
 
+const listAllCertificatesIncludingDeleted = `-- name: ListAllCertificatesIncludingDeleted :many
+SELECT hostname, encrypted_private_key, pending_csr_pem, certificate_pem, pending_encrypted_private_key, created_at, expires_at, last_modified, note, pending_note, read_only, chain_pem, ca_reference, submitted_at, key_status, sans, key_algorithm, key_size, deleted_at FROM certificates
+ORDER BY created_at DESC
+`
+
+// List all certificates, including those in the trash (for key maintenance)
+func (q *Queries) ListAllCertificatesIncludingDeleted(ctx context.Context) ([]Certificate, error) {
+	rows, err := q.query(ctx, q.listAllCertificatesIncludingDeletedStmt, listAllCertificatesIncludingDeleted)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Certificate
+	for rows.Next() {
+		var i Certificate
+		if err := rows.Scan(
+			&i.Hostname,
+			&i.EncryptedPrivateKey,
+			&i.PendingCsrPem,
+			&i.CertificatePem,
+			&i.PendingEncryptedPrivateKey,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.LastModified,
+			&i.Note,
+			&i.PendingNote,
+			&i.ReadOnly,
+			&i.ChainPem,
+			&i.CaReference,
+			&i.SubmittedAt,
+			&i.KeyStatus,
+			&i.Sans,
+			&i.KeyAlgorithm,
+			&i.KeySize,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCertificatePage = `-- name: ListCertificatePage :many
+SELECT hostname, created_at, expires_at, read_only, ca_reference, submitted_at,
+    key_status, sans, key_algorithm, key_size, has_pending_csr, status
+FROM (
+    SELECT hostname, created_at, expires_at, read_only, ca_reference, submitted_at,
+        key_status, sans, key_algorithm, key_size,
+        COALESCE(pending_csr_pem, '') <> '' AS has_pending_csr,
+        CASE
+            WHEN COALESCE(certificate_pem, '') = '' THEN 'pending'
+            WHEN expires_at IS NULL THEN 'active'
+            WHEN expires_at < ?1 THEN 'expired'
+            WHEN expires_at < ?1 + (?2 + 1) * 86400 THEN 'expiring'
+            ELSE 'active'
+        END AS status
+    FROM certificates
+    WHERE deleted_at IS NULL
+) c
+WHERE (?3 = '' OR status = ?3)
+  AND (?4 = '' OR hostname LIKE ?4 ESCAPE '\')
+  AND (?5 = 0 OR (has_pending_csr AND submitted_at <= ?5))
+  AND (?6 = 0 OR hostname IN (SELECT value FROM json_each(?7)))
+  AND NOT EXISTS (
+    SELECT 1 FROM json_each(?8) t
+    WHERE t.value NOT IN (SELECT tag FROM certificate_tags g WHERE g.hostname = c.hostname)
+  )
+ORDER BY
+    CASE WHEN ?9 = 'hostname' AND NOT ?10 THEN hostname END ASC,
+    CASE WHEN ?9 = 'hostname' AND ?10 THEN hostname END DESC,
+    CASE WHEN ?9 = 'expiring' AND NOT ?10 THEN COALESCE(expires_at, 0) END ASC,
+    CASE WHEN ?9 = 'expiring' AND ?10 THEN COALESCE(expires_at, 0) END DESC,
+    CASE WHEN ?9 = 'created' AND NOT ?10 THEN created_at END ASC,
+    CASE WHEN ?9 = 'created' AND ?10 THEN created_at END DESC,
+    hostname ASC
+LIMIT ?11 OFFSET ?12
+`
+
+type ListCertificatePageParams struct {
+	Now               int64  `json:"now"`
+	Threshold         int64  `json:"threshold"`
+	Status            string `json:"status"`
+	HostnamePattern   string `json:"hostname_pattern"`
+	AwaitingBefore    int64  `json:"awaiting_before"`
+	RestrictHostnames int64  `json:"restrict_hostnames"`
+	Hostnames         string `json:"hostnames"`
+	Tags              string `json:"tags"`
+	SortBy            string `json:"sort_by"`
+	SortDesc          int64  `json:"sort_desc"`
+	PageLimit         int64  `json:"page_limit"`
+	PageOffset        int64  `json:"page_offset"`
+}
+
+type ListCertificatePageRow struct {
+	Hostname      string         `json:"hostname"`
+	CreatedAt     int64          `json:"created_at"`
+	ExpiresAt     sql.NullInt64  `json:"expires_at"`
+	ReadOnly      int64          `json:"read_only"`
+	CaReference   sql.NullString `json:"ca_reference"`
+	SubmittedAt   sql.NullInt64  `json:"submitted_at"`
+	KeyStatus     string         `json:"key_status"`
+	Sans          string         `json:"sans"`
+	KeyAlgorithm  string         `json:"key_algorithm"`
+	KeySize       int64          `json:"key_size"`
+	HasPendingCsr int64          `json:"has_pending_csr"`
+	Status        string         `json:"status"`
+}
+
+// List one page of the certificates matching a filter, sorted, with their
+// status computed at now for an "expiring soon" window of threshold days.
+// Empty or zero filter arguments match everything; hostnames (a JSON array)
+// applies only when restrict_hostnames is 1, and tags (a JSON array) keeps
+// certificates carrying every tag. A negative limit returns every row.
+func (q *Queries) ListCertificatePage(ctx context.Context, arg ListCertificatePageParams) ([]ListCertificatePageRow, error) {
+	rows, err := q.query(ctx, q.listCertificatePageStmt, listCertificatePage,
+		arg.Now,
+		arg.Threshold,
+		arg.Status,
+		arg.HostnamePattern,
+		arg.AwaitingBefore,
+		arg.RestrictHostnames,
+		arg.Hostnames,
+		arg.Tags,
+		arg.SortBy,
+		arg.SortDesc,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCertificatePageRow
+	for rows.Next() {
+		var i ListCertificatePageRow
+		if err := rows.Scan(
+			&i.Hostname,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.ReadOnly,
+			&i.CaReference,
+			&i.SubmittedAt,
+			&i.KeyStatus,
+			&i.Sans,
+			&i.KeyAlgorithm,
+			&i.KeySize,
+			&i.HasPendingCsr,
+			&i.Status,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const replaceEncryptedPrivateKey = `-- name: ReplaceEncryptedPrivateKey :execrows
+UPDATE certificates
+SET encrypted_private_key = ?
+WHERE hostname = ? AND encrypted_private_key = ?
+`
+
+type ReplaceEncryptedPrivateKeyParams struct {
+	NewKey   []byte `json:"new_key"`
+	Hostname string `json:"hostname"`
+	OldKey   []byte `json:"old_key"`
+}
+
+// Swap the active key blob for a re-encrypted copy, only if it is unchanged since
+// it was read. The key itself is the same, so last_modified is left alone.
+func (q *Queries) ReplaceEncryptedPrivateKey(ctx context.Context, arg ReplaceEncryptedPrivateKeyParams) (int64, error) {
+	result, err := q.exec(ctx, q.replaceEncryptedPrivateKeyStmt, replaceEncryptedPrivateKey, arg.NewKey, arg.Hostname, arg.OldKey)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const replacePendingEncryptedPrivateKey = `-- name: ReplacePendingEncryptedPrivateKey :execrows
+UPDATE certificates
+SET pending_encrypted_private_key = ?
+WHERE hostname = ? AND pending_encrypted_private_key = ?
+`
+
+type ReplacePendingEncryptedPrivateKeyParams struct {
+	NewKey   []byte `json:"new_key"`
+	Hostname string `json:"hostname"`
+	OldKey   []byte `json:"old_key"`
+}
+
+// Same as ReplaceEncryptedPrivateKey for the pending key blob
+func (q *Queries) ReplacePendingEncryptedPrivateKey(ctx context.Context, arg ReplacePendingEncryptedPrivateKeyParams) (int64, error) {
+	result, err := q.exec(ctx, q.replacePendingEncryptedPrivateKeyStmt, replacePendingEncryptedPrivateKey, arg.NewKey, arg.Hostname, arg.OldKey)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const restoreCertificate = `-- name: RestoreCertificate :exec
 INSERT INTO certificates (
     hostname,
@@ -247,8 +614,11 @@ INSERT INTO certificates (
     last_modified,
     note,
     pending_note,
-    read_only
-) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    read_only,
+    chain_pem,
+    ca_reference,
+    submitted_at
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 ON CONFLICT(hostname) DO UPDATE SET
     encrypted_private_key = excluded.encrypted_private_key,
     pending_encrypted_private_key = excluded.pending_encrypted_private_key,
@@ -258,7 +628,11 @@ ON CONFLICT(hostname) DO UPDATE SET
     last_modified = excluded.last_modified,
     note = excluded.note,
     pending_note = excluded.pending_note,
-    read_only = excluded.read_only
+    read_only = excluded.read_only,
+    chain_pem = excluded.chain_pem,
+    ca_reference = excluded.ca_reference,
+    submitted_at = excluded.submitted_at,
+    key_status = ''
 `
 
 type RestoreCertificateParams struct {
@@ -273,6 +647,9 @@ type RestoreCertificateParams struct {
 	Note                       sql.NullString `json:"note"`
 	PendingNote                sql.NullString `json:"pending_note"`
 	ReadOnly                   int64          `json:"read_only"`
+	ChainPem                   sql.NullString `json:"chain_pem"`
+	CaReference                sql.NullString `json:"ca_reference"`
+	SubmittedAt                sql.NullInt64  `json:"submitted_at"`
 }
 
 // Restore a complete certificate from backup in a single operation
@@ -289,10 +666,33 @@ func (q *Queries) RestoreCertificate(ctx context.Context, arg RestoreCertificate
 		arg.Note,
 		arg.PendingNote,
 		arg.ReadOnly,
+		arg.ChainPem,
+		arg.CaReference,
+		arg.SubmittedAt,
 	)
 	return err
 }
 
+const updateCSRSubmission = `-- name: UpdateCSRSubmission :exec
+UPDATE certificates
+SET ca_reference = ?,
+    submitted_at = ?,
+    last_modified = unixepoch('now')
+WHERE hostname = ?
+`
+
+type UpdateCSRSubmissionParams struct {
+	CaReference sql.NullString `json:"ca_reference"`
+	SubmittedAt sql.NullInt64  `json:"submitted_at"`
+	Hostname    string         `json:"hostname"`
+}
+
+// Record that the pending CSR was submitted to the CA
+func (q *Queries) UpdateCSRSubmission(ctx context.Context, arg UpdateCSRSubmissionParams) error {
+	_, err := q.exec(ctx, q.updateCSRSubmissionStmt, updateCSRSubmission, arg.CaReference, arg.SubmittedAt, arg.Hostname)
+	return err
+}
+
 const updateCertificateNote = `-- name: UpdateCertificateNote :exec
 UPDATE certificates
 SET note = ?,
@@ -354,6 +754,8 @@ UPDATE certificates
 SET pending_csr_pem = ?,
     pending_encrypted_private_key = ?,
     pending_note = ?,
+    ca_reference = NULL,
+    submitted_at = NULL,
     last_modified = unixepoch('now')
 WHERE hostname = ?
 `
@@ -366,6 +768,7 @@ type UpdatePendingCSRParams struct {
 }
 
 // Store or update pending CSR and key (unified for initial generation or renewal)
+// A new CSR has not been submitted to the CA yet, so its tracking is reset
 func (q *Queries) UpdatePendingCSR(ctx context.Context, arg UpdatePendingCSRParams) error {
 	_, err := q.exec(ctx, q.updatePendingCSRStmt, updatePendingCSR,
 		arg.PendingCsrPem,
@@ -393,3 +796,173 @@ func (q *Queries) UpdatePendingNote(ctx context.Context, arg UpdatePendingNotePa
 	_, err := q.exec(ctx, q.updatePendingNoteStmt, updatePendingNote, arg.PendingNote, arg.Hostname)
 	return err
 }
+
+const listUncheckedKeyStatusHostnames = `-- name: ListUncheckedKeyStatusHostnames :many
+SELECT hostname FROM certificates
+WHERE key_status = '' AND deleted_at IS NULL
+ORDER BY hostname
+`
+
+// Certificates whose key pair health has not been computed yet
+func (q *Queries) ListUncheckedKeyStatusHostnames(ctx context.Context) ([]string, error) {
+	rows, err := q.query(ctx, q.listUncheckedKeyStatusHostnamesStmt, listUncheckedKeyStatusHostnames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var hostname string
+		if err := rows.Scan(&hostname); err != nil {
+			return nil, err
+		}
+		items = append(items, hostname)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateCertificateKeyStatus = `-- name: UpdateCertificateKeyStatus :execrows
+UPDATE certificates
+SET key_status = ?1
+WHERE hostname = ?2 AND key_status <> ?1
+`
+
+type UpdateCertificateKeyStatusParams struct {
+	KeyStatus string `json:"key_status"`
+	Hostname  string `json:"hostname"`
+}
+
+// Store the recomputed key pair health; a row already holding it is left
+// untouched, so an unchanged status is not counted as a write
+func (q *Queries) UpdateCertificateKeyStatus(ctx context.Context, arg UpdateCertificateKeyStatusParams) (int64, error) {
+	result, err := q.exec(ctx, q.updateCertificateKeyStatusStmt, updateCertificateKeyStatus, arg.KeyStatus, arg.Hostname)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const trashCertificate = `-- name: TrashCertificate :execrows
+UPDATE certificates
+SET deleted_at = ?1
+WHERE hostname = ?2 AND deleted_at IS NULL
+`
+
+type TrashCertificateParams struct {
+	DeletedAt sql.NullInt64 `json:"deleted_at"`
+	Hostname  string        `json:"hostname"`
+}
+
+// Move a certificate to the trash; its history, tags and keys are kept
+func (q *Queries) TrashCertificate(ctx context.Context, arg TrashCertificateParams) (int64, error) {
+	result, err := q.exec(ctx, q.trashCertificateStmt, trashCertificate, arg.DeletedAt, arg.Hostname)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreDeletedCertificate = `-- name: RestoreDeletedCertificate :execrows
+UPDATE certificates
+SET deleted_at = NULL
+WHERE hostname = ? AND deleted_at IS NOT NULL
+`
+
+// Take a certificate out of the trash
+func (q *Queries) RestoreDeletedCertificate(ctx context.Context, hostname string) (int64, error) {
+	result, err := q.exec(ctx, q.restoreDeletedCertificateStmt, restoreDeletedCertificate, hostname)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const listDeletedCertificates = `-- name: ListDeletedCertificates :many
+SELECT hostname, expires_at, note, deleted_at,
+    encrypted_private_key IS NOT NULL OR pending_encrypted_private_key IS NOT NULL AS has_private_key
+FROM certificates
+WHERE deleted_at IS NOT NULL
+ORDER BY deleted_at DESC, hostname ASC
+`
+
+type ListDeletedCertificatesRow struct {
+	Hostname      string         `json:"hostname"`
+	ExpiresAt     sql.NullInt64  `json:"expires_at"`
+	Note          sql.NullString `json:"note"`
+	DeletedAt     sql.NullInt64  `json:"deleted_at"`
+	HasPrivateKey int64          `json:"has_private_key"`
+}
+
+// List the certificates in the trash, most recently deleted first
+func (q *Queries) ListDeletedCertificates(ctx context.Context) ([]ListDeletedCertificatesRow, error) {
+	rows, err := q.query(ctx, q.listDeletedCertificatesStmt, listDeletedCertificates)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDeletedCertificatesRow
+	for rows.Next() {
+		var i ListDeletedCertificatesRow
+		if err := rows.Scan(
+			&i.Hostname,
+			&i.ExpiresAt,
+			&i.Note,
+			&i.DeletedAt,
+			&i.HasPrivateKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const certificateInTrash = `-- name: CertificateInTrash :one
+SELECT COUNT(*) FROM certificates WHERE hostname = ? AND deleted_at IS NOT NULL
+`
+
+// Check if the certificate holding a hostname is in the trash
+func (q *Queries) CertificateInTrash(ctx context.Context, hostname string) (int64, error) {
+	row := q.queryRow(ctx, q.certificateInTrashStmt, certificateInTrash, hostname)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const purgeDeletedCertificate = `-- name: PurgeDeletedCertificate :execrows
+DELETE FROM certificates WHERE hostname = ? AND deleted_at IS NOT NULL
+`
+
+// Permanently delete a certificate from the trash
+func (q *Queries) PurgeDeletedCertificate(ctx context.Context, hostname string) (int64, error) {
+	result, err := q.exec(ctx, q.purgeDeletedCertificateStmt, purgeDeletedCertificate, hostname)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeExpiredTrash = `-- name: PurgeExpiredTrash :execrows
+DELETE FROM certificates WHERE deleted_at IS NOT NULL AND deleted_at <= ?
+`
+
+// Permanently delete the certificates moved to the trash at or before a time
+func (q *Queries) PurgeExpiredTrash(ctx context.Context, deletedAt sql.NullInt64) (int64, error) {
+	result, err := q.exec(ctx, q.purgeExpiredTrashStmt, purgeExpiredTrash, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
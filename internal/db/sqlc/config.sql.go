@@ -26,8 +26,9 @@ const createConfig = `-- name: CreateConfig :exec
 INSERT INTO config (
     id, owner_email, ca_name, hostname_suffix, validity_period_days,
     default_organization, default_organizational_unit,
-    default_city, default_state, default_country, default_key_size
-) VALUES (1, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+    default_city, default_state, default_country, default_key_size,
+    kdf_profile
+) VALUES (1, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateConfigParams struct {
@@ -41,6 +42,7 @@ type CreateConfigParams struct {
 	DefaultState              string         `json:"default_state"`
 	DefaultCountry            string         `json:"default_country"`
 	DefaultKeySize            int64          `json:"default_key_size"`
+	KdfProfile                string         `json:"kdf_profile"`
 }
 
 // Create the initial configuration
@@ -56,16 +58,35 @@ func (q *Queries) CreateConfig(ctx context.Context, arg CreateConfigParams) erro
 		arg.DefaultState,
 		arg.DefaultCountry,
 		arg.DefaultKeySize,
+		arg.KdfProfile,
 	)
 	return err
 }
 
+const discountCertificateWrite = `-- name: DiscountCertificateWrite :exec
+UPDATE config
+SET writes_since_backup = MAX(writes_since_backup - 1, 0)
+WHERE id = 1
+`
+
+// Undo the backup freshness count of a certificate write that left its data
+// unchanged (re-encrypting a key blob)
+func (q *Queries) DiscountCertificateWrite(ctx context.Context) error {
+	_, err := q.exec(ctx, q.discountCertificateWriteStmt, discountCertificateWrite)
+	return err
+}
+
 const getConfig = `-- name: GetConfig :one
 SELECT id, owner_email, ca_name, hostname_suffix, validity_period_days,
        default_organization, default_organizational_unit,
        default_city, default_state, default_country, default_key_size,
        is_configured,
-       created_at, last_modified
+       created_at, last_modified, expiring_threshold_days,
+       clock_check_url, air_gapped,
+       backup_freshness_max_writes, backup_freshness_block,
+       writes_since_backup, last_backup_at, fips_mode, db_size_warn_mb,
+       log_level, log_component_levels,
+       log_max_size_mb, log_max_files, log_max_age_days, log_compress, minimize_to_tray, run_in_background, expiry_notifications, aia_cache_ttl_minutes, download_line_endings, download_text_header, download_format, key_pool_size, kdf_profile, ticket_pattern, trash_retention_days
 FROM config WHERE id = 1 LIMIT 1
 `
 
@@ -88,6 +109,32 @@ func (q *Queries) GetConfig(ctx context.Context) (Config, error) {
 		&i.IsConfigured,
 		&i.CreatedAt,
 		&i.LastModified,
+		&i.ExpiringThresholdDays,
+		&i.ClockCheckUrl,
+		&i.AirGapped,
+		&i.BackupFreshnessMaxWrites,
+		&i.BackupFreshnessBlock,
+		&i.WritesSinceBackup,
+		&i.LastBackupAt,
+		&i.FipsMode,
+		&i.DbSizeWarnMb,
+		&i.LogLevel,
+		&i.LogComponentLevels,
+		&i.LogMaxSizeMb,
+		&i.LogMaxFiles,
+		&i.LogMaxAgeDays,
+		&i.LogCompress,
+		&i.MinimizeToTray,
+		&i.RunInBackground,
+		&i.ExpiryNotifications,
+		&i.AiaCacheTtlMinutes,
+		&i.DownloadLineEndings,
+		&i.DownloadTextHeader,
+		&i.DownloadFormat,
+		&i.KeyPoolSize,
+		&i.KdfProfile,
+		&i.TicketPattern,
+		&i.TrashRetentionDays,
 	)
 	return i, err
 }
@@ -104,6 +151,19 @@ func (q *Queries) IsConfigured(ctx context.Context) (int64, error) {
 	return is_configured, err
 }
 
+const recordBackup = `-- name: RecordBackup :exec
+UPDATE config
+SET writes_since_backup = 0,
+    last_backup_at = ?
+WHERE id = 1
+`
+
+// Reset the backup freshness counter after a manual backup or export
+func (q *Queries) RecordBackup(ctx context.Context, lastBackupAt sql.NullInt64) error {
+	_, err := q.exec(ctx, q.recordBackupStmt, recordBackup, lastBackupAt)
+	return err
+}
+
 const setConfigured = `-- name: SetConfigured :exec
 UPDATE config
 SET is_configured = 1,
@@ -117,6 +177,16 @@ func (q *Queries) SetConfigured(ctx context.Context) error {
 	return err
 }
 
+const updateComponentLogLevels = `-- name: UpdateComponentLogLevels :exec
+UPDATE config SET log_component_levels = ? WHERE id = 1
+`
+
+// Persist the per-component log level overrides (JSON object)
+func (q *Queries) UpdateComponentLogLevels(ctx context.Context, logComponentLevels string) error {
+	_, err := q.exec(ctx, q.updateComponentLogLevelsStmt, updateComponentLogLevels, logComponentLevels)
+	return err
+}
+
 const updateConfig = `-- name: UpdateConfig :exec
 UPDATE config
 SET owner_email = ?,
@@ -129,6 +199,28 @@ SET owner_email = ?,
     default_state = ?,
     default_country = ?,
     default_key_size = ?,
+    expiring_threshold_days = ?,
+    clock_check_url = ?,
+    air_gapped = ?,
+    backup_freshness_max_writes = ?,
+    backup_freshness_block = ?,
+    fips_mode = ?,
+    db_size_warn_mb = ?,
+    log_max_size_mb = ?,
+    log_max_files = ?,
+    log_max_age_days = ?,
+    log_compress = ?,
+    minimize_to_tray = ?,
+    run_in_background = ?,
+    expiry_notifications = ?,
+    aia_cache_ttl_minutes = ?,
+    download_line_endings = ?,
+    download_text_header = ?,
+    download_format = ?,
+    key_pool_size = ?,
+    kdf_profile = ?,
+    ticket_pattern = ?,
+    trash_retention_days = ?,
     last_modified = unixepoch('now')
 WHERE id = 1
 `
@@ -144,6 +236,28 @@ type UpdateConfigParams struct {
 	DefaultState              string         `json:"default_state"`
 	DefaultCountry            string         `json:"default_country"`
 	DefaultKeySize            int64          `json:"default_key_size"`
+	ExpiringThresholdDays     int64          `json:"expiring_threshold_days"`
+	ClockCheckUrl             string         `json:"clock_check_url"`
+	AirGapped                 int64          `json:"air_gapped"`
+	BackupFreshnessMaxWrites  int64          `json:"backup_freshness_max_writes"`
+	BackupFreshnessBlock      int64          `json:"backup_freshness_block"`
+	FipsMode                  int64          `json:"fips_mode"`
+	DbSizeWarnMb              int64          `json:"db_size_warn_mb"`
+	LogMaxSizeMb              int64          `json:"log_max_size_mb"`
+	LogMaxFiles               int64          `json:"log_max_files"`
+	LogMaxAgeDays             int64          `json:"log_max_age_days"`
+	LogCompress               int64          `json:"log_compress"`
+	MinimizeToTray            int64          `json:"minimize_to_tray"`
+	RunInBackground           int64          `json:"run_in_background"`
+	ExpiryNotifications       int64          `json:"expiry_notifications"`
+	AiaCacheTtlMinutes        int64          `json:"aia_cache_ttl_minutes"`
+	DownloadLineEndings       string         `json:"download_line_endings"`
+	DownloadTextHeader        int64          `json:"download_text_header"`
+	DownloadFormat            string         `json:"download_format"`
+	KeyPoolSize               int64          `json:"key_pool_size"`
+	KdfProfile                string         `json:"kdf_profile"`
+	TicketPattern             string         `json:"ticket_pattern"`
+	TrashRetentionDays        int64          `json:"trash_retention_days"`
 }
 
 // Update configuration (preserves is_configured flag)
@@ -159,6 +273,51 @@ func (q *Queries) UpdateConfig(ctx context.Context, arg UpdateConfigParams) erro
 		arg.DefaultState,
 		arg.DefaultCountry,
 		arg.DefaultKeySize,
+		arg.ExpiringThresholdDays,
+		arg.ClockCheckUrl,
+		arg.AirGapped,
+		arg.BackupFreshnessMaxWrites,
+		arg.BackupFreshnessBlock,
+		arg.FipsMode,
+		arg.DbSizeWarnMb,
+		arg.LogMaxSizeMb,
+		arg.LogMaxFiles,
+		arg.LogMaxAgeDays,
+		arg.LogCompress,
+		arg.MinimizeToTray,
+		arg.RunInBackground,
+		arg.ExpiryNotifications,
+		arg.AiaCacheTtlMinutes,
+		arg.DownloadLineEndings,
+		arg.DownloadTextHeader,
+		arg.DownloadFormat,
+		arg.KeyPoolSize,
+		arg.KdfProfile,
+		arg.TicketPattern,
+		arg.TrashRetentionDays,
 	)
 	return err
 }
+
+const updateHostnameSuffix = `-- name: UpdateHostnameSuffix :exec
+UPDATE config
+SET hostname_suffix = ?,
+    last_modified = unixepoch('now')
+WHERE id = 1
+`
+
+// Set the suffix generated hostnames must end with
+func (q *Queries) UpdateHostnameSuffix(ctx context.Context, hostnameSuffix string) error {
+	_, err := q.exec(ctx, q.updateHostnameSuffixStmt, updateHostnameSuffix, hostnameSuffix)
+	return err
+}
+
+const updateLogLevel = `-- name: UpdateLogLevel :exec
+UPDATE config SET log_level = ? WHERE id = 1
+`
+
+// Persist the runtime log level (empty keeps the build default)
+func (q *Queries) UpdateLogLevel(ctx context.Context, logLevel string) error {
+	_, err := q.exec(ctx, q.updateLogLevelStmt, updateLogLevel, logLevel)
+	return err
+}
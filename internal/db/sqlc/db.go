@@ -27,51 +27,153 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.activateCertificateStmt, err = db.PrepareContext(ctx, activateCertificate); err != nil {
 		return nil, fmt.Errorf("error preparing query ActivateCertificate: %w", err)
 	}
+	if q.addAuditEntryStmt, err = db.PrepareContext(ctx, addAuditEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query AddAuditEntry: %w", err)
+	}
+	if q.addCertificateTagStmt, err = db.PrepareContext(ctx, addCertificateTag); err != nil {
+		return nil, fmt.Errorf("error preparing query AddCertificateTag: %w", err)
+	}
 	if q.addHistoryEntryStmt, err = db.PrepareContext(ctx, addHistoryEntry); err != nil {
 		return nil, fmt.Errorf("error preparing query AddHistoryEntry: %w", err)
 	}
+	if q.addNoteReferenceStmt, err = db.PrepareContext(ctx, addNoteReference); err != nil {
+		return nil, fmt.Errorf("error preparing query AddNoteReference: %w", err)
+	}
+	if q.addSyncAgentHostnameStmt, err = db.PrepareContext(ctx, addSyncAgentHostname); err != nil {
+		return nil, fmt.Errorf("error preparing query AddSyncAgentHostname: %w", err)
+	}
+	if q.carryAuditEntryStmt, err = db.PrepareContext(ctx, carryAuditEntry); err != nil {
+		return nil, fmt.Errorf("error preparing query CarryAuditEntry: %w", err)
+	}
 	if q.certificateExistsStmt, err = db.PrepareContext(ctx, certificateExists); err != nil {
 		return nil, fmt.Errorf("error preparing query CertificateExists: %w", err)
 	}
+	if q.certificateInTrashStmt, err = db.PrepareContext(ctx, certificateInTrash); err != nil {
+		return nil, fmt.Errorf("error preparing query CertificateInTrash: %w", err)
+	}
 	if q.clearPendingCSRStmt, err = db.PrepareContext(ctx, clearPendingCSR); err != nil {
 		return nil, fmt.Errorf("error preparing query ClearPendingCSR: %w", err)
 	}
+	if q.clearPendingCertificateMetadataStmt, err = db.PrepareContext(ctx, clearPendingCertificateMetadata); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearPendingCertificateMetadata: %w", err)
+	}
+	if q.clearPendingNoteReferencesStmt, err = db.PrepareContext(ctx, clearPendingNoteReferences); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearPendingNoteReferences: %w", err)
+	}
+	if q.clearRenewalChecklistStmt, err = db.PrepareContext(ctx, clearRenewalChecklist); err != nil {
+		return nil, fmt.Errorf("error preparing query ClearRenewalChecklist: %w", err)
+	}
+	if q.completeRenewalStepStmt, err = db.PrepareContext(ctx, completeRenewalStep); err != nil {
+		return nil, fmt.Errorf("error preparing query CompleteRenewalStep: %w", err)
+	}
 	if q.configExistsStmt, err = db.PrepareContext(ctx, configExists); err != nil {
 		return nil, fmt.Errorf("error preparing query ConfigExists: %w", err)
 	}
+	if q.copyCertificateToHostnameStmt, err = db.PrepareContext(ctx, copyCertificateToHostname); err != nil {
+		return nil, fmt.Errorf("error preparing query CopyCertificateToHostname: %w", err)
+	}
 	if q.countAllSecurityKeysStmt, err = db.PrepareContext(ctx, countAllSecurityKeys); err != nil {
 		return nil, fmt.Errorf("error preparing query CountAllSecurityKeys: %w", err)
 	}
+	if q.countAuditLogStmt, err = db.PrepareContext(ctx, countAuditLog); err != nil {
+		return nil, fmt.Errorf("error preparing query CountAuditLog: %w", err)
+	}
+	if q.countCertificatePageStmt, err = db.PrepareContext(ctx, countCertificatePage); err != nil {
+		return nil, fmt.Errorf("error preparing query CountCertificatePage: %w", err)
+	}
+	if q.countCertificatesStmt, err = db.PrepareContext(ctx, countCertificates); err != nil {
+		return nil, fmt.Errorf("error preparing query CountCertificates: %w", err)
+	}
+	if q.countHistoryStmt, err = db.PrepareContext(ctx, countHistory); err != nil {
+		return nil, fmt.Errorf("error preparing query CountHistory: %w", err)
+	}
 	if q.countSecurityKeysByMethodStmt, err = db.PrepareContext(ctx, countSecurityKeysByMethod); err != nil {
 		return nil, fmt.Errorf("error preparing query CountSecurityKeysByMethod: %w", err)
 	}
+	if q.countUpdateHistoryStmt, err = db.PrepareContext(ctx, countUpdateHistory); err != nil {
+		return nil, fmt.Errorf("error preparing query CountUpdateHistory: %w", err)
+	}
 	if q.createCertificateStmt, err = db.PrepareContext(ctx, createCertificate); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateCertificate: %w", err)
 	}
 	if q.createConfigStmt, err = db.PrepareContext(ctx, createConfig); err != nil {
 		return nil, fmt.Errorf("error preparing query CreateConfig: %w", err)
 	}
+	if q.createOperationIntentStmt, err = db.PrepareContext(ctx, createOperationIntent); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateOperationIntent: %w", err)
+	}
+	if q.createSyncServerStmt, err = db.PrepareContext(ctx, createSyncServer); err != nil {
+		return nil, fmt.Errorf("error preparing query CreateSyncServer: %w", err)
+	}
 	if q.deleteAllCertificatesStmt, err = db.PrepareContext(ctx, deleteAllCertificates); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteAllCertificates: %w", err)
 	}
+	if q.deleteAllSecurityKeysStmt, err = db.PrepareContext(ctx, deleteAllSecurityKeys); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteAllSecurityKeys: %w", err)
+	}
 	if q.deleteCertificateStmt, err = db.PrepareContext(ctx, deleteCertificate); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteCertificate: %w", err)
 	}
+	if q.deleteCertificateChainOverrideStmt, err = db.PrepareContext(ctx, deleteCertificateChainOverride); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCertificateChainOverride: %w", err)
+	}
 	if q.deleteCertificateHistoryStmt, err = db.PrepareContext(ctx, deleteCertificateHistory); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteCertificateHistory: %w", err)
 	}
+	if q.deleteCertificateRelationStmt, err = db.PrepareContext(ctx, deleteCertificateRelation); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteCertificateRelation: %w", err)
+	}
+	if q.deleteDeploymentTargetStmt, err = db.PrepareContext(ctx, deleteDeploymentTarget); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteDeploymentTarget: %w", err)
+	}
+	if q.deleteHistoryBeforeStmt, err = db.PrepareContext(ctx, deleteHistoryBefore); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteHistoryBefore: %w", err)
+	}
+	if q.deleteIncompleteConfigStmt, err = db.PrepareContext(ctx, deleteIncompleteConfig); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteIncompleteConfig: %w", err)
+	}
+	if q.deleteIssuerChainOverrideStmt, err = db.PrepareContext(ctx, deleteIssuerChainOverride); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteIssuerChainOverride: %w", err)
+	}
+	if q.deleteNoteReferencesStmt, err = db.PrepareContext(ctx, deleteNoteReferences); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteNoteReferences: %w", err)
+	}
+	if q.deleteOperationIntentStmt, err = db.PrepareContext(ctx, deleteOperationIntent); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteOperationIntent: %w", err)
+	}
 	if q.deleteSecurityKeyStmt, err = db.PrepareContext(ctx, deleteSecurityKey); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteSecurityKey: %w", err)
 	}
 	if q.deleteSecurityKeysByMethodStmt, err = db.PrepareContext(ctx, deleteSecurityKeysByMethod); err != nil {
 		return nil, fmt.Errorf("error preparing query DeleteSecurityKeysByMethod: %w", err)
 	}
+	if q.deleteSetupProgressStmt, err = db.PrepareContext(ctx, deleteSetupProgress); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSetupProgress: %w", err)
+	}
+	if q.deleteSubjectPresetStmt, err = db.PrepareContext(ctx, deleteSubjectPreset); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSubjectPreset: %w", err)
+	}
+	if q.deleteSyncAgentHostnamesStmt, err = db.PrepareContext(ctx, deleteSyncAgentHostnames); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteSyncAgentHostnames: %w", err)
+	}
+	if q.deleteUpdateHistoryBeforeStmt, err = db.PrepareContext(ctx, deleteUpdateHistoryBefore); err != nil {
+		return nil, fmt.Errorf("error preparing query DeleteUpdateHistoryBefore: %w", err)
+	}
+	if q.discountCertificateWriteStmt, err = db.PrepareContext(ctx, discountCertificateWrite); err != nil {
+		return nil, fmt.Errorf("error preparing query DiscountCertificateWrite: %w", err)
+	}
+	if q.getActiveSyncAgentStmt, err = db.PrepareContext(ctx, getActiveSyncAgent); err != nil {
+		return nil, fmt.Errorf("error preparing query GetActiveSyncAgent: %w", err)
+	}
 	if q.getCertificateByHostnameStmt, err = db.PrepareContext(ctx, getCertificateByHostname); err != nil {
 		return nil, fmt.Errorf("error preparing query GetCertificateByHostname: %w", err)
 	}
 	if q.getCertificateHistoryStmt, err = db.PrepareContext(ctx, getCertificateHistory); err != nil {
 		return nil, fmt.Errorf("error preparing query GetCertificateHistory: %w", err)
 	}
+	if q.getCertificateRequesterStmt, err = db.PrepareContext(ctx, getCertificateRequester); err != nil {
+		return nil, fmt.Errorf("error preparing query GetCertificateRequester: %w", err)
+	}
 	if q.getConfigStmt, err = db.PrepareContext(ctx, getConfig); err != nil {
 		return nil, fmt.Errorf("error preparing query GetConfig: %w", err)
 	}
@@ -81,6 +183,24 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.getSecurityKeysByMethodStmt, err = db.PrepareContext(ctx, getSecurityKeysByMethod); err != nil {
 		return nil, fmt.Errorf("error preparing query GetSecurityKeysByMethod: %w", err)
 	}
+	if q.getSetupProgressStmt, err = db.PrepareContext(ctx, getSetupProgress); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSetupProgress: %w", err)
+	}
+	if q.getSubjectPresetByIDStmt, err = db.PrepareContext(ctx, getSubjectPresetByID); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSubjectPresetByID: %w", err)
+	}
+	if q.getSyncAgentByFingerprintStmt, err = db.PrepareContext(ctx, getSyncAgentByFingerprint); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSyncAgentByFingerprint: %w", err)
+	}
+	if q.getSyncAgentCertificateStmt, err = db.PrepareContext(ctx, getSyncAgentCertificate); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSyncAgentCertificate: %w", err)
+	}
+	if q.getSyncServerStmt, err = db.PrepareContext(ctx, getSyncServer); err != nil {
+		return nil, fmt.Errorf("error preparing query GetSyncServer: %w", err)
+	}
+	if q.getTicketPatternStmt, err = db.PrepareContext(ctx, getTicketPattern); err != nil {
+		return nil, fmt.Errorf("error preparing query GetTicketPattern: %w", err)
+	}
 	if q.getUpdateHistoryStmt, err = db.PrepareContext(ctx, getUpdateHistory); err != nil {
 		return nil, fmt.Errorf("error preparing query GetUpdateHistory: %w", err)
 	}
@@ -93,36 +213,213 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.insertSecurityKeyStmt, err = db.PrepareContext(ctx, insertSecurityKey); err != nil {
 		return nil, fmt.Errorf("error preparing query InsertSecurityKey: %w", err)
 	}
+	if q.insertSubjectPresetStmt, err = db.PrepareContext(ctx, insertSubjectPreset); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertSubjectPreset: %w", err)
+	}
+	if q.insertSyncAgentStmt, err = db.PrepareContext(ctx, insertSyncAgent); err != nil {
+		return nil, fmt.Errorf("error preparing query InsertSyncAgent: %w", err)
+	}
 	if q.isConfiguredStmt, err = db.PrepareContext(ctx, isConfigured); err != nil {
 		return nil, fmt.Errorf("error preparing query IsConfigured: %w", err)
 	}
+	if q.listAllAuditEntriesStmt, err = db.PrepareContext(ctx, listAllAuditEntries); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAllAuditEntries: %w", err)
+	}
+	if q.listAllCertificateTagsStmt, err = db.PrepareContext(ctx, listAllCertificateTags); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAllCertificateTags: %w", err)
+	}
 	if q.listAllCertificatesStmt, err = db.PrepareContext(ctx, listAllCertificates); err != nil {
 		return nil, fmt.Errorf("error preparing query ListAllCertificates: %w", err)
 	}
+	if q.listAllCertificatesIncludingDeletedStmt, err = db.PrepareContext(ctx, listAllCertificatesIncludingDeleted); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAllCertificatesIncludingDeleted: %w", err)
+	}
+	if q.listAuditLogStmt, err = db.PrepareContext(ctx, listAuditLog); err != nil {
+		return nil, fmt.Errorf("error preparing query ListAuditLog: %w", err)
+	}
+	if q.listCertificatePageStmt, err = db.PrepareContext(ctx, listCertificatePage); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCertificatePage: %w", err)
+	}
+	if q.listCertificateRelationsStmt, err = db.PrepareContext(ctx, listCertificateRelations); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCertificateRelations: %w", err)
+	}
+	if q.listCertificateRelationsForHostnameStmt, err = db.PrepareContext(ctx, listCertificateRelationsForHostname); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCertificateRelationsForHostname: %w", err)
+	}
+	if q.listCertificateTagsStmt, err = db.PrepareContext(ctx, listCertificateTags); err != nil {
+		return nil, fmt.Errorf("error preparing query ListCertificateTags: %w", err)
+	}
+	if q.listChainOverridesStmt, err = db.PrepareContext(ctx, listChainOverrides); err != nil {
+		return nil, fmt.Errorf("error preparing query ListChainOverrides: %w", err)
+	}
+	if q.listDeletedCertificatesStmt, err = db.PrepareContext(ctx, listDeletedCertificates); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDeletedCertificates: %w", err)
+	}
+	if q.listDeploymentTargetsStmt, err = db.PrepareContext(ctx, listDeploymentTargets); err != nil {
+		return nil, fmt.Errorf("error preparing query ListDeploymentTargets: %w", err)
+	}
+	if q.listHistoryStmt, err = db.PrepareContext(ctx, listHistory); err != nil {
+		return nil, fmt.Errorf("error preparing query ListHistory: %w", err)
+	}
+	if q.listHostnamesByNoteReferenceStmt, err = db.PrepareContext(ctx, listHostnamesByNoteReference); err != nil {
+		return nil, fmt.Errorf("error preparing query ListHostnamesByNoteReference: %w", err)
+	}
+	if q.listNoteReferencesStmt, err = db.PrepareContext(ctx, listNoteReferences); err != nil {
+		return nil, fmt.Errorf("error preparing query ListNoteReferences: %w", err)
+	}
+	if q.listOperationIntentsStmt, err = db.PrepareContext(ctx, listOperationIntents); err != nil {
+		return nil, fmt.Errorf("error preparing query ListOperationIntents: %w", err)
+	}
+	if q.listPendingCertificateMetadataStmt, err = db.PrepareContext(ctx, listPendingCertificateMetadata); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPendingCertificateMetadata: %w", err)
+	}
+	if q.listPendingNoteReferencesStmt, err = db.PrepareContext(ctx, listPendingNoteReferences); err != nil {
+		return nil, fmt.Errorf("error preparing query ListPendingNoteReferences: %w", err)
+	}
+	if q.listRenewalChecklistStmt, err = db.PrepareContext(ctx, listRenewalChecklist); err != nil {
+		return nil, fmt.Errorf("error preparing query ListRenewalChecklist: %w", err)
+	}
 	if q.listSecurityKeysStmt, err = db.PrepareContext(ctx, listSecurityKeys); err != nil {
 		return nil, fmt.Errorf("error preparing query ListSecurityKeys: %w", err)
 	}
+	if q.listStaleSearchSANsStmt, err = db.PrepareContext(ctx, listStaleSearchSANs); err != nil {
+		return nil, fmt.Errorf("error preparing query ListStaleSearchSANs: %w", err)
+	}
+	if q.listSubjectPresetsStmt, err = db.PrepareContext(ctx, listSubjectPresets); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSubjectPresets: %w", err)
+	}
+	if q.listSyncAgentCertificatesStmt, err = db.PrepareContext(ctx, listSyncAgentCertificates); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSyncAgentCertificates: %w", err)
+	}
+	if q.listSyncAgentHostnamesStmt, err = db.PrepareContext(ctx, listSyncAgentHostnames); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSyncAgentHostnames: %w", err)
+	}
+	if q.listSyncAgentsStmt, err = db.PrepareContext(ctx, listSyncAgents); err != nil {
+		return nil, fmt.Errorf("error preparing query ListSyncAgents: %w", err)
+	}
+	if q.listTagCountsStmt, err = db.PrepareContext(ctx, listTagCounts); err != nil {
+		return nil, fmt.Errorf("error preparing query ListTagCounts: %w", err)
+	}
+	if q.listUncheckedKeyStatusHostnamesStmt, err = db.PrepareContext(ctx, listUncheckedKeyStatusHostnames); err != nil {
+		return nil, fmt.Errorf("error preparing query ListUncheckedKeyStatusHostnames: %w", err)
+	}
+	if q.purgeDeletedCertificateStmt, err = db.PrepareContext(ctx, purgeDeletedCertificate); err != nil {
+		return nil, fmt.Errorf("error preparing query PurgeDeletedCertificate: %w", err)
+	}
+	if q.purgeExpiredTrashStmt, err = db.PrepareContext(ctx, purgeExpiredTrash); err != nil {
+		return nil, fmt.Errorf("error preparing query PurgeExpiredTrash: %w", err)
+	}
+	if q.reassignCertificateHistoryStmt, err = db.PrepareContext(ctx, reassignCertificateHistory); err != nil {
+		return nil, fmt.Errorf("error preparing query ReassignCertificateHistory: %w", err)
+	}
+	if q.reassignCertificateRelationSourcesStmt, err = db.PrepareContext(ctx, reassignCertificateRelationSources); err != nil {
+		return nil, fmt.Errorf("error preparing query ReassignCertificateRelationSources: %w", err)
+	}
+	if q.reassignCertificateRelationTargetsStmt, err = db.PrepareContext(ctx, reassignCertificateRelationTargets); err != nil {
+		return nil, fmt.Errorf("error preparing query ReassignCertificateRelationTargets: %w", err)
+	}
+	if q.reassignCertificateRequesterStmt, err = db.PrepareContext(ctx, reassignCertificateRequester); err != nil {
+		return nil, fmt.Errorf("error preparing query ReassignCertificateRequester: %w", err)
+	}
+	if q.reassignCertificateTagsStmt, err = db.PrepareContext(ctx, reassignCertificateTags); err != nil {
+		return nil, fmt.Errorf("error preparing query ReassignCertificateTags: %w", err)
+	}
+	if q.reassignChainOverrideStmt, err = db.PrepareContext(ctx, reassignChainOverride); err != nil {
+		return nil, fmt.Errorf("error preparing query ReassignChainOverride: %w", err)
+	}
+	if q.reassignDeploymentTargetsStmt, err = db.PrepareContext(ctx, reassignDeploymentTargets); err != nil {
+		return nil, fmt.Errorf("error preparing query ReassignDeploymentTargets: %w", err)
+	}
+	if q.reassignRenewalChecklistStmt, err = db.PrepareContext(ctx, reassignRenewalChecklist); err != nil {
+		return nil, fmt.Errorf("error preparing query ReassignRenewalChecklist: %w", err)
+	}
+	if q.reassignSyncAgentHostnamesStmt, err = db.PrepareContext(ctx, reassignSyncAgentHostnames); err != nil {
+		return nil, fmt.Errorf("error preparing query ReassignSyncAgentHostnames: %w", err)
+	}
+	if q.recordBackupStmt, err = db.PrepareContext(ctx, recordBackup); err != nil {
+		return nil, fmt.Errorf("error preparing query RecordBackup: %w", err)
+	}
 	if q.recordUpdateStmt, err = db.PrepareContext(ctx, recordUpdate); err != nil {
 		return nil, fmt.Errorf("error preparing query RecordUpdate: %w", err)
 	}
+	if q.removeCertificateTagStmt, err = db.PrepareContext(ctx, removeCertificateTag); err != nil {
+		return nil, fmt.Errorf("error preparing query RemoveCertificateTag: %w", err)
+	}
+	if q.reopenRenewalStepStmt, err = db.PrepareContext(ctx, reopenRenewalStep); err != nil {
+		return nil, fmt.Errorf("error preparing query ReopenRenewalStep: %w", err)
+	}
+	if q.replaceEncryptedPrivateKeyStmt, err = db.PrepareContext(ctx, replaceEncryptedPrivateKey); err != nil {
+		return nil, fmt.Errorf("error preparing query ReplaceEncryptedPrivateKey: %w", err)
+	}
+	if q.replacePendingEncryptedPrivateKeyStmt, err = db.PrepareContext(ctx, replacePendingEncryptedPrivateKey); err != nil {
+		return nil, fmt.Errorf("error preparing query ReplacePendingEncryptedPrivateKey: %w", err)
+	}
 	if q.restoreCertificateStmt, err = db.PrepareContext(ctx, restoreCertificate); err != nil {
 		return nil, fmt.Errorf("error preparing query RestoreCertificate: %w", err)
 	}
+	if q.restoreDeletedCertificateStmt, err = db.PrepareContext(ctx, restoreDeletedCertificate); err != nil {
+		return nil, fmt.Errorf("error preparing query RestoreDeletedCertificate: %w", err)
+	}
+	if q.restoreRenewalStepStmt, err = db.PrepareContext(ctx, restoreRenewalStep); err != nil {
+		return nil, fmt.Errorf("error preparing query RestoreRenewalStep: %w", err)
+	}
+	if q.revokeSyncAgentStmt, err = db.PrepareContext(ctx, revokeSyncAgent); err != nil {
+		return nil, fmt.Errorf("error preparing query RevokeSyncAgent: %w", err)
+	}
+	if q.saveSetupProgressStmt, err = db.PrepareContext(ctx, saveSetupProgress); err != nil {
+		return nil, fmt.Errorf("error preparing query SaveSetupProgress: %w", err)
+	}
+	if q.searchCertificateHostnamesStmt, err = db.PrepareContext(ctx, searchCertificateHostnames); err != nil {
+		return nil, fmt.Errorf("error preparing query SearchCertificateHostnames: %w", err)
+	}
+	if q.searchCertificateHostnamesLikeStmt, err = db.PrepareContext(ctx, searchCertificateHostnamesLike); err != nil {
+		return nil, fmt.Errorf("error preparing query SearchCertificateHostnamesLike: %w", err)
+	}
 	if q.setConfiguredStmt, err = db.PrepareContext(ctx, setConfigured); err != nil {
 		return nil, fmt.Errorf("error preparing query SetConfigured: %w", err)
 	}
+	if q.setSearchSANsStmt, err = db.PrepareContext(ctx, setSearchSANs); err != nil {
+		return nil, fmt.Errorf("error preparing query SetSearchSANs: %w", err)
+	}
+	if q.setSyncServerListenAddressStmt, err = db.PrepareContext(ctx, setSyncServerListenAddress); err != nil {
+		return nil, fmt.Errorf("error preparing query SetSyncServerListenAddress: %w", err)
+	}
+	if q.touchSyncAgentStmt, err = db.PrepareContext(ctx, touchSyncAgent); err != nil {
+		return nil, fmt.Errorf("error preparing query TouchSyncAgent: %w", err)
+	}
+	if q.trashCertificateStmt, err = db.PrepareContext(ctx, trashCertificate); err != nil {
+		return nil, fmt.Errorf("error preparing query TrashCertificate: %w", err)
+	}
+	if q.updateCSRSubmissionStmt, err = db.PrepareContext(ctx, updateCSRSubmission); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateCSRSubmission: %w", err)
+	}
+	if q.updateCertificateKeyStatusStmt, err = db.PrepareContext(ctx, updateCertificateKeyStatus); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateCertificateKeyStatus: %w", err)
+	}
+	if q.updateCertificateMetadataStmt, err = db.PrepareContext(ctx, updateCertificateMetadata); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateCertificateMetadata: %w", err)
+	}
 	if q.updateCertificateNoteStmt, err = db.PrepareContext(ctx, updateCertificateNote); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateCertificateNote: %w", err)
 	}
 	if q.updateCertificateReadOnlyStmt, err = db.PrepareContext(ctx, updateCertificateReadOnly); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateCertificateReadOnly: %w", err)
 	}
+	if q.updateComponentLogLevelsStmt, err = db.PrepareContext(ctx, updateComponentLogLevels); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateComponentLogLevels: %w", err)
+	}
 	if q.updateConfigStmt, err = db.PrepareContext(ctx, updateConfig); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateConfig: %w", err)
 	}
 	if q.updateEncryptedKeysStmt, err = db.PrepareContext(ctx, updateEncryptedKeys); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateEncryptedKeys: %w", err)
 	}
+	if q.updateHostnameSuffixStmt, err = db.PrepareContext(ctx, updateHostnameSuffix); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateHostnameSuffix: %w", err)
+	}
+	if q.updateLogLevelStmt, err = db.PrepareContext(ctx, updateLogLevel); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateLogLevel: %w", err)
+	}
 	if q.updatePendingCSRStmt, err = db.PrepareContext(ctx, updatePendingCSR); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdatePendingCSR: %w", err)
 	}
@@ -132,6 +429,24 @@ func Prepare(ctx context.Context, db DBTX) (*Queries, error) {
 	if q.updateSecurityKeyLastUsedStmt, err = db.PrepareContext(ctx, updateSecurityKeyLastUsed); err != nil {
 		return nil, fmt.Errorf("error preparing query UpdateSecurityKeyLastUsed: %w", err)
 	}
+	if q.updateSubjectPresetStmt, err = db.PrepareContext(ctx, updateSubjectPreset); err != nil {
+		return nil, fmt.Errorf("error preparing query UpdateSubjectPreset: %w", err)
+	}
+	if q.upsertCertificateChainOverrideStmt, err = db.PrepareContext(ctx, upsertCertificateChainOverride); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertCertificateChainOverride: %w", err)
+	}
+	if q.upsertCertificateRelationStmt, err = db.PrepareContext(ctx, upsertCertificateRelation); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertCertificateRelation: %w", err)
+	}
+	if q.upsertCertificateRequesterStmt, err = db.PrepareContext(ctx, upsertCertificateRequester); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertCertificateRequester: %w", err)
+	}
+	if q.upsertDeploymentTargetStmt, err = db.PrepareContext(ctx, upsertDeploymentTarget); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertDeploymentTarget: %w", err)
+	}
+	if q.upsertIssuerChainOverrideStmt, err = db.PrepareContext(ctx, upsertIssuerChainOverride); err != nil {
+		return nil, fmt.Errorf("error preparing query UpsertIssuerChainOverride: %w", err)
+	}
 	return &q, nil
 }
 
@@ -142,36 +457,116 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing activateCertificateStmt: %w", cerr)
 		}
 	}
+	if q.addAuditEntryStmt != nil {
+		if cerr := q.addAuditEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addAuditEntryStmt: %w", cerr)
+		}
+	}
+	if q.addCertificateTagStmt != nil {
+		if cerr := q.addCertificateTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addCertificateTagStmt: %w", cerr)
+		}
+	}
 	if q.addHistoryEntryStmt != nil {
 		if cerr := q.addHistoryEntryStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing addHistoryEntryStmt: %w", cerr)
 		}
 	}
+	if q.addNoteReferenceStmt != nil {
+		if cerr := q.addNoteReferenceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addNoteReferenceStmt: %w", cerr)
+		}
+	}
+	if q.addSyncAgentHostnameStmt != nil {
+		if cerr := q.addSyncAgentHostnameStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing addSyncAgentHostnameStmt: %w", cerr)
+		}
+	}
+	if q.carryAuditEntryStmt != nil {
+		if cerr := q.carryAuditEntryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing carryAuditEntryStmt: %w", cerr)
+		}
+	}
 	if q.certificateExistsStmt != nil {
 		if cerr := q.certificateExistsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing certificateExistsStmt: %w", cerr)
 		}
 	}
+	if q.certificateInTrashStmt != nil {
+		if cerr := q.certificateInTrashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing certificateInTrashStmt: %w", cerr)
+		}
+	}
 	if q.clearPendingCSRStmt != nil {
 		if cerr := q.clearPendingCSRStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing clearPendingCSRStmt: %w", cerr)
 		}
 	}
+	if q.clearPendingCertificateMetadataStmt != nil {
+		if cerr := q.clearPendingCertificateMetadataStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearPendingCertificateMetadataStmt: %w", cerr)
+		}
+	}
+	if q.clearPendingNoteReferencesStmt != nil {
+		if cerr := q.clearPendingNoteReferencesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearPendingNoteReferencesStmt: %w", cerr)
+		}
+	}
+	if q.clearRenewalChecklistStmt != nil {
+		if cerr := q.clearRenewalChecklistStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing clearRenewalChecklistStmt: %w", cerr)
+		}
+	}
+	if q.completeRenewalStepStmt != nil {
+		if cerr := q.completeRenewalStepStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing completeRenewalStepStmt: %w", cerr)
+		}
+	}
 	if q.configExistsStmt != nil {
 		if cerr := q.configExistsStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing configExistsStmt: %w", cerr)
 		}
 	}
+	if q.copyCertificateToHostnameStmt != nil {
+		if cerr := q.copyCertificateToHostnameStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing copyCertificateToHostnameStmt: %w", cerr)
+		}
+	}
 	if q.countAllSecurityKeysStmt != nil {
 		if cerr := q.countAllSecurityKeysStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing countAllSecurityKeysStmt: %w", cerr)
 		}
 	}
+	if q.countAuditLogStmt != nil {
+		if cerr := q.countAuditLogStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countAuditLogStmt: %w", cerr)
+		}
+	}
+	if q.countCertificatePageStmt != nil {
+		if cerr := q.countCertificatePageStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countCertificatePageStmt: %w", cerr)
+		}
+	}
+	if q.countCertificatesStmt != nil {
+		if cerr := q.countCertificatesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countCertificatesStmt: %w", cerr)
+		}
+	}
+	if q.countHistoryStmt != nil {
+		if cerr := q.countHistoryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countHistoryStmt: %w", cerr)
+		}
+	}
 	if q.countSecurityKeysByMethodStmt != nil {
 		if cerr := q.countSecurityKeysByMethodStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing countSecurityKeysByMethodStmt: %w", cerr)
 		}
 	}
+	if q.countUpdateHistoryStmt != nil {
+		if cerr := q.countUpdateHistoryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing countUpdateHistoryStmt: %w", cerr)
+		}
+	}
 	if q.createCertificateStmt != nil {
 		if cerr := q.createCertificateStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing createCertificateStmt: %w", cerr)
@@ -182,21 +577,76 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing createConfigStmt: %w", cerr)
 		}
 	}
+	if q.createOperationIntentStmt != nil {
+		if cerr := q.createOperationIntentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createOperationIntentStmt: %w", cerr)
+		}
+	}
+	if q.createSyncServerStmt != nil {
+		if cerr := q.createSyncServerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing createSyncServerStmt: %w", cerr)
+		}
+	}
 	if q.deleteAllCertificatesStmt != nil {
 		if cerr := q.deleteAllCertificatesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteAllCertificatesStmt: %w", cerr)
 		}
 	}
+	if q.deleteAllSecurityKeysStmt != nil {
+		if cerr := q.deleteAllSecurityKeysStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteAllSecurityKeysStmt: %w", cerr)
+		}
+	}
 	if q.deleteCertificateStmt != nil {
 		if cerr := q.deleteCertificateStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteCertificateStmt: %w", cerr)
 		}
 	}
+	if q.deleteCertificateChainOverrideStmt != nil {
+		if cerr := q.deleteCertificateChainOverrideStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCertificateChainOverrideStmt: %w", cerr)
+		}
+	}
 	if q.deleteCertificateHistoryStmt != nil {
 		if cerr := q.deleteCertificateHistoryStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteCertificateHistoryStmt: %w", cerr)
 		}
 	}
+	if q.deleteCertificateRelationStmt != nil {
+		if cerr := q.deleteCertificateRelationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteCertificateRelationStmt: %w", cerr)
+		}
+	}
+	if q.deleteDeploymentTargetStmt != nil {
+		if cerr := q.deleteDeploymentTargetStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteDeploymentTargetStmt: %w", cerr)
+		}
+	}
+	if q.deleteHistoryBeforeStmt != nil {
+		if cerr := q.deleteHistoryBeforeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteHistoryBeforeStmt: %w", cerr)
+		}
+	}
+	if q.deleteIncompleteConfigStmt != nil {
+		if cerr := q.deleteIncompleteConfigStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteIncompleteConfigStmt: %w", cerr)
+		}
+	}
+	if q.deleteIssuerChainOverrideStmt != nil {
+		if cerr := q.deleteIssuerChainOverrideStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteIssuerChainOverrideStmt: %w", cerr)
+		}
+	}
+	if q.deleteNoteReferencesStmt != nil {
+		if cerr := q.deleteNoteReferencesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteNoteReferencesStmt: %w", cerr)
+		}
+	}
+	if q.deleteOperationIntentStmt != nil {
+		if cerr := q.deleteOperationIntentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteOperationIntentStmt: %w", cerr)
+		}
+	}
 	if q.deleteSecurityKeyStmt != nil {
 		if cerr := q.deleteSecurityKeyStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing deleteSecurityKeyStmt: %w", cerr)
@@ -207,6 +657,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing deleteSecurityKeysByMethodStmt: %w", cerr)
 		}
 	}
+	if q.deleteSetupProgressStmt != nil {
+		if cerr := q.deleteSetupProgressStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSetupProgressStmt: %w", cerr)
+		}
+	}
+	if q.deleteSubjectPresetStmt != nil {
+		if cerr := q.deleteSubjectPresetStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSubjectPresetStmt: %w", cerr)
+		}
+	}
+	if q.deleteSyncAgentHostnamesStmt != nil {
+		if cerr := q.deleteSyncAgentHostnamesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteSyncAgentHostnamesStmt: %w", cerr)
+		}
+	}
+	if q.deleteUpdateHistoryBeforeStmt != nil {
+		if cerr := q.deleteUpdateHistoryBeforeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing deleteUpdateHistoryBeforeStmt: %w", cerr)
+		}
+	}
+	if q.discountCertificateWriteStmt != nil {
+		if cerr := q.discountCertificateWriteStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing discountCertificateWriteStmt: %w", cerr)
+		}
+	}
+	if q.getActiveSyncAgentStmt != nil {
+		if cerr := q.getActiveSyncAgentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getActiveSyncAgentStmt: %w", cerr)
+		}
+	}
 	if q.getCertificateByHostnameStmt != nil {
 		if cerr := q.getCertificateByHostnameStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getCertificateByHostnameStmt: %w", cerr)
@@ -217,6 +697,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getCertificateHistoryStmt: %w", cerr)
 		}
 	}
+	if q.getCertificateRequesterStmt != nil {
+		if cerr := q.getCertificateRequesterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getCertificateRequesterStmt: %w", cerr)
+		}
+	}
 	if q.getConfigStmt != nil {
 		if cerr := q.getConfigStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getConfigStmt: %w", cerr)
@@ -232,6 +717,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing getSecurityKeysByMethodStmt: %w", cerr)
 		}
 	}
+	if q.getSetupProgressStmt != nil {
+		if cerr := q.getSetupProgressStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSetupProgressStmt: %w", cerr)
+		}
+	}
+	if q.getSubjectPresetByIDStmt != nil {
+		if cerr := q.getSubjectPresetByIDStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSubjectPresetByIDStmt: %w", cerr)
+		}
+	}
+	if q.getSyncAgentByFingerprintStmt != nil {
+		if cerr := q.getSyncAgentByFingerprintStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSyncAgentByFingerprintStmt: %w", cerr)
+		}
+	}
+	if q.getSyncAgentCertificateStmt != nil {
+		if cerr := q.getSyncAgentCertificateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSyncAgentCertificateStmt: %w", cerr)
+		}
+	}
+	if q.getSyncServerStmt != nil {
+		if cerr := q.getSyncServerStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getSyncServerStmt: %w", cerr)
+		}
+	}
+	if q.getTicketPatternStmt != nil {
+		if cerr := q.getTicketPatternStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing getTicketPatternStmt: %w", cerr)
+		}
+	}
 	if q.getUpdateHistoryStmt != nil {
 		if cerr := q.getUpdateHistoryStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing getUpdateHistoryStmt: %w", cerr)
@@ -252,36 +767,316 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing insertSecurityKeyStmt: %w", cerr)
 		}
 	}
+	if q.insertSubjectPresetStmt != nil {
+		if cerr := q.insertSubjectPresetStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertSubjectPresetStmt: %w", cerr)
+		}
+	}
+	if q.insertSyncAgentStmt != nil {
+		if cerr := q.insertSyncAgentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing insertSyncAgentStmt: %w", cerr)
+		}
+	}
 	if q.isConfiguredStmt != nil {
 		if cerr := q.isConfiguredStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing isConfiguredStmt: %w", cerr)
 		}
 	}
+	if q.listAllAuditEntriesStmt != nil {
+		if cerr := q.listAllAuditEntriesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAllAuditEntriesStmt: %w", cerr)
+		}
+	}
+	if q.listAllCertificateTagsStmt != nil {
+		if cerr := q.listAllCertificateTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAllCertificateTagsStmt: %w", cerr)
+		}
+	}
 	if q.listAllCertificatesStmt != nil {
 		if cerr := q.listAllCertificatesStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listAllCertificatesStmt: %w", cerr)
 		}
 	}
+	if q.listAllCertificatesIncludingDeletedStmt != nil {
+		if cerr := q.listAllCertificatesIncludingDeletedStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAllCertificatesIncludingDeletedStmt: %w", cerr)
+		}
+	}
+	if q.listAuditLogStmt != nil {
+		if cerr := q.listAuditLogStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listAuditLogStmt: %w", cerr)
+		}
+	}
+	if q.listCertificatePageStmt != nil {
+		if cerr := q.listCertificatePageStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCertificatePageStmt: %w", cerr)
+		}
+	}
+	if q.listCertificateRelationsStmt != nil {
+		if cerr := q.listCertificateRelationsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCertificateRelationsStmt: %w", cerr)
+		}
+	}
+	if q.listCertificateRelationsForHostnameStmt != nil {
+		if cerr := q.listCertificateRelationsForHostnameStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCertificateRelationsForHostnameStmt: %w", cerr)
+		}
+	}
+	if q.listCertificateTagsStmt != nil {
+		if cerr := q.listCertificateTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listCertificateTagsStmt: %w", cerr)
+		}
+	}
+	if q.listChainOverridesStmt != nil {
+		if cerr := q.listChainOverridesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listChainOverridesStmt: %w", cerr)
+		}
+	}
+	if q.listDeletedCertificatesStmt != nil {
+		if cerr := q.listDeletedCertificatesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDeletedCertificatesStmt: %w", cerr)
+		}
+	}
+	if q.listDeploymentTargetsStmt != nil {
+		if cerr := q.listDeploymentTargetsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listDeploymentTargetsStmt: %w", cerr)
+		}
+	}
+	if q.listHistoryStmt != nil {
+		if cerr := q.listHistoryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listHistoryStmt: %w", cerr)
+		}
+	}
+	if q.listHostnamesByNoteReferenceStmt != nil {
+		if cerr := q.listHostnamesByNoteReferenceStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listHostnamesByNoteReferenceStmt: %w", cerr)
+		}
+	}
+	if q.listNoteReferencesStmt != nil {
+		if cerr := q.listNoteReferencesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listNoteReferencesStmt: %w", cerr)
+		}
+	}
+	if q.listOperationIntentsStmt != nil {
+		if cerr := q.listOperationIntentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listOperationIntentsStmt: %w", cerr)
+		}
+	}
+	if q.listPendingCertificateMetadataStmt != nil {
+		if cerr := q.listPendingCertificateMetadataStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPendingCertificateMetadataStmt: %w", cerr)
+		}
+	}
+	if q.listPendingNoteReferencesStmt != nil {
+		if cerr := q.listPendingNoteReferencesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listPendingNoteReferencesStmt: %w", cerr)
+		}
+	}
+	if q.listRenewalChecklistStmt != nil {
+		if cerr := q.listRenewalChecklistStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listRenewalChecklistStmt: %w", cerr)
+		}
+	}
 	if q.listSecurityKeysStmt != nil {
 		if cerr := q.listSecurityKeysStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing listSecurityKeysStmt: %w", cerr)
 		}
 	}
+	if q.listStaleSearchSANsStmt != nil {
+		if cerr := q.listStaleSearchSANsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listStaleSearchSANsStmt: %w", cerr)
+		}
+	}
+	if q.listSubjectPresetsStmt != nil {
+		if cerr := q.listSubjectPresetsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSubjectPresetsStmt: %w", cerr)
+		}
+	}
+	if q.listSyncAgentCertificatesStmt != nil {
+		if cerr := q.listSyncAgentCertificatesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSyncAgentCertificatesStmt: %w", cerr)
+		}
+	}
+	if q.listSyncAgentHostnamesStmt != nil {
+		if cerr := q.listSyncAgentHostnamesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSyncAgentHostnamesStmt: %w", cerr)
+		}
+	}
+	if q.listSyncAgentsStmt != nil {
+		if cerr := q.listSyncAgentsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listSyncAgentsStmt: %w", cerr)
+		}
+	}
+	if q.listTagCountsStmt != nil {
+		if cerr := q.listTagCountsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listTagCountsStmt: %w", cerr)
+		}
+	}
+	if q.listUncheckedKeyStatusHostnamesStmt != nil {
+		if cerr := q.listUncheckedKeyStatusHostnamesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing listUncheckedKeyStatusHostnamesStmt: %w", cerr)
+		}
+	}
+	if q.purgeDeletedCertificateStmt != nil {
+		if cerr := q.purgeDeletedCertificateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing purgeDeletedCertificateStmt: %w", cerr)
+		}
+	}
+	if q.purgeExpiredTrashStmt != nil {
+		if cerr := q.purgeExpiredTrashStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing purgeExpiredTrashStmt: %w", cerr)
+		}
+	}
+	if q.reassignCertificateHistoryStmt != nil {
+		if cerr := q.reassignCertificateHistoryStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reassignCertificateHistoryStmt: %w", cerr)
+		}
+	}
+	if q.reassignCertificateRelationSourcesStmt != nil {
+		if cerr := q.reassignCertificateRelationSourcesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reassignCertificateRelationSourcesStmt: %w", cerr)
+		}
+	}
+	if q.reassignCertificateRelationTargetsStmt != nil {
+		if cerr := q.reassignCertificateRelationTargetsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reassignCertificateRelationTargetsStmt: %w", cerr)
+		}
+	}
+	if q.reassignCertificateRequesterStmt != nil {
+		if cerr := q.reassignCertificateRequesterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reassignCertificateRequesterStmt: %w", cerr)
+		}
+	}
+	if q.reassignCertificateTagsStmt != nil {
+		if cerr := q.reassignCertificateTagsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reassignCertificateTagsStmt: %w", cerr)
+		}
+	}
+	if q.reassignChainOverrideStmt != nil {
+		if cerr := q.reassignChainOverrideStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reassignChainOverrideStmt: %w", cerr)
+		}
+	}
+	if q.reassignDeploymentTargetsStmt != nil {
+		if cerr := q.reassignDeploymentTargetsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reassignDeploymentTargetsStmt: %w", cerr)
+		}
+	}
+	if q.reassignRenewalChecklistStmt != nil {
+		if cerr := q.reassignRenewalChecklistStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reassignRenewalChecklistStmt: %w", cerr)
+		}
+	}
+	if q.reassignSyncAgentHostnamesStmt != nil {
+		if cerr := q.reassignSyncAgentHostnamesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reassignSyncAgentHostnamesStmt: %w", cerr)
+		}
+	}
+	if q.recordBackupStmt != nil {
+		if cerr := q.recordBackupStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing recordBackupStmt: %w", cerr)
+		}
+	}
 	if q.recordUpdateStmt != nil {
 		if cerr := q.recordUpdateStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing recordUpdateStmt: %w", cerr)
 		}
 	}
+	if q.removeCertificateTagStmt != nil {
+		if cerr := q.removeCertificateTagStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing removeCertificateTagStmt: %w", cerr)
+		}
+	}
+	if q.reopenRenewalStepStmt != nil {
+		if cerr := q.reopenRenewalStepStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing reopenRenewalStepStmt: %w", cerr)
+		}
+	}
+	if q.replaceEncryptedPrivateKeyStmt != nil {
+		if cerr := q.replaceEncryptedPrivateKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing replaceEncryptedPrivateKeyStmt: %w", cerr)
+		}
+	}
+	if q.replacePendingEncryptedPrivateKeyStmt != nil {
+		if cerr := q.replacePendingEncryptedPrivateKeyStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing replacePendingEncryptedPrivateKeyStmt: %w", cerr)
+		}
+	}
 	if q.restoreCertificateStmt != nil {
 		if cerr := q.restoreCertificateStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing restoreCertificateStmt: %w", cerr)
 		}
 	}
+	if q.restoreDeletedCertificateStmt != nil {
+		if cerr := q.restoreDeletedCertificateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing restoreDeletedCertificateStmt: %w", cerr)
+		}
+	}
+	if q.restoreRenewalStepStmt != nil {
+		if cerr := q.restoreRenewalStepStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing restoreRenewalStepStmt: %w", cerr)
+		}
+	}
+	if q.revokeSyncAgentStmt != nil {
+		if cerr := q.revokeSyncAgentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing revokeSyncAgentStmt: %w", cerr)
+		}
+	}
+	if q.saveSetupProgressStmt != nil {
+		if cerr := q.saveSetupProgressStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing saveSetupProgressStmt: %w", cerr)
+		}
+	}
+	if q.searchCertificateHostnamesStmt != nil {
+		if cerr := q.searchCertificateHostnamesStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing searchCertificateHostnamesStmt: %w", cerr)
+		}
+	}
+	if q.searchCertificateHostnamesLikeStmt != nil {
+		if cerr := q.searchCertificateHostnamesLikeStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing searchCertificateHostnamesLikeStmt: %w", cerr)
+		}
+	}
 	if q.setConfiguredStmt != nil {
 		if cerr := q.setConfiguredStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing setConfiguredStmt: %w", cerr)
 		}
 	}
+	if q.setSearchSANsStmt != nil {
+		if cerr := q.setSearchSANsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setSearchSANsStmt: %w", cerr)
+		}
+	}
+	if q.setSyncServerListenAddressStmt != nil {
+		if cerr := q.setSyncServerListenAddressStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing setSyncServerListenAddressStmt: %w", cerr)
+		}
+	}
+	if q.touchSyncAgentStmt != nil {
+		if cerr := q.touchSyncAgentStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing touchSyncAgentStmt: %w", cerr)
+		}
+	}
+	if q.trashCertificateStmt != nil {
+		if cerr := q.trashCertificateStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing trashCertificateStmt: %w", cerr)
+		}
+	}
+	if q.updateCSRSubmissionStmt != nil {
+		if cerr := q.updateCSRSubmissionStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateCSRSubmissionStmt: %w", cerr)
+		}
+	}
+	if q.updateCertificateKeyStatusStmt != nil {
+		if cerr := q.updateCertificateKeyStatusStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateCertificateKeyStatusStmt: %w", cerr)
+		}
+	}
+	if q.updateCertificateMetadataStmt != nil {
+		if cerr := q.updateCertificateMetadataStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateCertificateMetadataStmt: %w", cerr)
+		}
+	}
 	if q.updateCertificateNoteStmt != nil {
 		if cerr := q.updateCertificateNoteStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateCertificateNoteStmt: %w", cerr)
@@ -292,6 +1087,11 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateCertificateReadOnlyStmt: %w", cerr)
 		}
 	}
+	if q.updateComponentLogLevelsStmt != nil {
+		if cerr := q.updateComponentLogLevelsStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateComponentLogLevelsStmt: %w", cerr)
+		}
+	}
 	if q.updateConfigStmt != nil {
 		if cerr := q.updateConfigStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updateConfigStmt: %w", cerr)
@@ -302,6 +1102,16 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateEncryptedKeysStmt: %w", cerr)
 		}
 	}
+	if q.updateHostnameSuffixStmt != nil {
+		if cerr := q.updateHostnameSuffixStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateHostnameSuffixStmt: %w", cerr)
+		}
+	}
+	if q.updateLogLevelStmt != nil {
+		if cerr := q.updateLogLevelStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateLogLevelStmt: %w", cerr)
+		}
+	}
 	if q.updatePendingCSRStmt != nil {
 		if cerr := q.updatePendingCSRStmt.Close(); cerr != nil {
 			err = fmt.Errorf("error closing updatePendingCSRStmt: %w", cerr)
@@ -317,6 +1127,36 @@ func (q *Queries) Close() error {
 			err = fmt.Errorf("error closing updateSecurityKeyLastUsedStmt: %w", cerr)
 		}
 	}
+	if q.updateSubjectPresetStmt != nil {
+		if cerr := q.updateSubjectPresetStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing updateSubjectPresetStmt: %w", cerr)
+		}
+	}
+	if q.upsertCertificateChainOverrideStmt != nil {
+		if cerr := q.upsertCertificateChainOverrideStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertCertificateChainOverrideStmt: %w", cerr)
+		}
+	}
+	if q.upsertCertificateRelationStmt != nil {
+		if cerr := q.upsertCertificateRelationStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertCertificateRelationStmt: %w", cerr)
+		}
+	}
+	if q.upsertCertificateRequesterStmt != nil {
+		if cerr := q.upsertCertificateRequesterStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertCertificateRequesterStmt: %w", cerr)
+		}
+	}
+	if q.upsertDeploymentTargetStmt != nil {
+		if cerr := q.upsertDeploymentTargetStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertDeploymentTargetStmt: %w", cerr)
+		}
+	}
+	if q.upsertIssuerChainOverrideStmt != nil {
+		if cerr := q.upsertIssuerChainOverrideStmt.Close(); cerr != nil {
+			err = fmt.Errorf("error closing upsertIssuerChainOverrideStmt: %w", cerr)
+		}
+	}
 	return err
 }
 
@@ -354,85 +1194,295 @@ func (q *Queries) queryRow(ctx context.Context, stmt *sql.Stmt, query string, ar
 }
 
 type Queries struct {
-	db                             DBTX
-	tx                             *sql.Tx
-	activateCertificateStmt        *sql.Stmt
-	addHistoryEntryStmt            *sql.Stmt
-	certificateExistsStmt          *sql.Stmt
-	clearPendingCSRStmt            *sql.Stmt
-	configExistsStmt               *sql.Stmt
-	countAllSecurityKeysStmt       *sql.Stmt
-	countSecurityKeysByMethodStmt  *sql.Stmt
-	createCertificateStmt          *sql.Stmt
-	createConfigStmt               *sql.Stmt
-	deleteAllCertificatesStmt      *sql.Stmt
-	deleteCertificateStmt          *sql.Stmt
-	deleteCertificateHistoryStmt   *sql.Stmt
-	deleteSecurityKeyStmt          *sql.Stmt
-	deleteSecurityKeysByMethodStmt *sql.Stmt
-	getCertificateByHostnameStmt   *sql.Stmt
-	getCertificateHistoryStmt      *sql.Stmt
-	getConfigStmt                  *sql.Stmt
-	getSecurityKeyByIDStmt         *sql.Stmt
-	getSecurityKeysByMethodStmt    *sql.Stmt
-	getUpdateHistoryStmt           *sql.Stmt
-	hasAnySecurityKeysStmt         *sql.Stmt
-	importCertificateStmt          *sql.Stmt
-	insertSecurityKeyStmt          *sql.Stmt
-	isConfiguredStmt               *sql.Stmt
-	listAllCertificatesStmt        *sql.Stmt
-	listSecurityKeysStmt           *sql.Stmt
-	recordUpdateStmt               *sql.Stmt
-	restoreCertificateStmt         *sql.Stmt
-	setConfiguredStmt              *sql.Stmt
-	updateCertificateNoteStmt      *sql.Stmt
-	updateCertificateReadOnlyStmt  *sql.Stmt
-	updateConfigStmt               *sql.Stmt
-	updateEncryptedKeysStmt        *sql.Stmt
-	updatePendingCSRStmt           *sql.Stmt
-	updatePendingNoteStmt          *sql.Stmt
-	updateSecurityKeyLastUsedStmt  *sql.Stmt
+	db                                      DBTX
+	tx                                      *sql.Tx
+	activateCertificateStmt                 *sql.Stmt
+	addAuditEntryStmt                       *sql.Stmt
+	addCertificateTagStmt                   *sql.Stmt
+	addHistoryEntryStmt                     *sql.Stmt
+	addNoteReferenceStmt                    *sql.Stmt
+	addSyncAgentHostnameStmt                *sql.Stmt
+	carryAuditEntryStmt                     *sql.Stmt
+	certificateExistsStmt                   *sql.Stmt
+	certificateInTrashStmt                  *sql.Stmt
+	clearPendingCSRStmt                     *sql.Stmt
+	clearPendingCertificateMetadataStmt     *sql.Stmt
+	clearPendingNoteReferencesStmt          *sql.Stmt
+	clearRenewalChecklistStmt               *sql.Stmt
+	completeRenewalStepStmt                 *sql.Stmt
+	configExistsStmt                        *sql.Stmt
+	copyCertificateToHostnameStmt           *sql.Stmt
+	countAllSecurityKeysStmt                *sql.Stmt
+	countAuditLogStmt                       *sql.Stmt
+	countCertificatePageStmt                *sql.Stmt
+	countCertificatesStmt                   *sql.Stmt
+	countHistoryStmt                        *sql.Stmt
+	countSecurityKeysByMethodStmt           *sql.Stmt
+	countUpdateHistoryStmt                  *sql.Stmt
+	createCertificateStmt                   *sql.Stmt
+	createConfigStmt                        *sql.Stmt
+	createOperationIntentStmt               *sql.Stmt
+	createSyncServerStmt                    *sql.Stmt
+	deleteAllCertificatesStmt               *sql.Stmt
+	deleteAllSecurityKeysStmt               *sql.Stmt
+	deleteCertificateStmt                   *sql.Stmt
+	deleteCertificateChainOverrideStmt      *sql.Stmt
+	deleteCertificateHistoryStmt            *sql.Stmt
+	deleteCertificateRelationStmt           *sql.Stmt
+	deleteDeploymentTargetStmt              *sql.Stmt
+	deleteHistoryBeforeStmt                 *sql.Stmt
+	deleteIncompleteConfigStmt              *sql.Stmt
+	deleteIssuerChainOverrideStmt           *sql.Stmt
+	deleteNoteReferencesStmt                *sql.Stmt
+	deleteOperationIntentStmt               *sql.Stmt
+	deleteSecurityKeyStmt                   *sql.Stmt
+	deleteSecurityKeysByMethodStmt          *sql.Stmt
+	deleteSetupProgressStmt                 *sql.Stmt
+	deleteSubjectPresetStmt                 *sql.Stmt
+	deleteSyncAgentHostnamesStmt            *sql.Stmt
+	deleteUpdateHistoryBeforeStmt           *sql.Stmt
+	discountCertificateWriteStmt            *sql.Stmt
+	getActiveSyncAgentStmt                  *sql.Stmt
+	getCertificateByHostnameStmt            *sql.Stmt
+	getCertificateHistoryStmt               *sql.Stmt
+	getCertificateRequesterStmt             *sql.Stmt
+	getConfigStmt                           *sql.Stmt
+	getSecurityKeyByIDStmt                  *sql.Stmt
+	getSecurityKeysByMethodStmt             *sql.Stmt
+	getSetupProgressStmt                    *sql.Stmt
+	getSubjectPresetByIDStmt                *sql.Stmt
+	getSyncAgentByFingerprintStmt           *sql.Stmt
+	getSyncAgentCertificateStmt             *sql.Stmt
+	getSyncServerStmt                       *sql.Stmt
+	getTicketPatternStmt                    *sql.Stmt
+	getUpdateHistoryStmt                    *sql.Stmt
+	hasAnySecurityKeysStmt                  *sql.Stmt
+	importCertificateStmt                   *sql.Stmt
+	insertSecurityKeyStmt                   *sql.Stmt
+	insertSubjectPresetStmt                 *sql.Stmt
+	insertSyncAgentStmt                     *sql.Stmt
+	isConfiguredStmt                        *sql.Stmt
+	listAllAuditEntriesStmt                 *sql.Stmt
+	listAllCertificateTagsStmt              *sql.Stmt
+	listAllCertificatesStmt                 *sql.Stmt
+	listAllCertificatesIncludingDeletedStmt *sql.Stmt
+	listAuditLogStmt                        *sql.Stmt
+	listCertificatePageStmt                 *sql.Stmt
+	listCertificateRelationsStmt            *sql.Stmt
+	listCertificateRelationsForHostnameStmt *sql.Stmt
+	listCertificateTagsStmt                 *sql.Stmt
+	listChainOverridesStmt                  *sql.Stmt
+	listDeletedCertificatesStmt             *sql.Stmt
+	listDeploymentTargetsStmt               *sql.Stmt
+	listHistoryStmt                         *sql.Stmt
+	listHostnamesByNoteReferenceStmt        *sql.Stmt
+	listNoteReferencesStmt                  *sql.Stmt
+	listOperationIntentsStmt                *sql.Stmt
+	listPendingCertificateMetadataStmt      *sql.Stmt
+	listPendingNoteReferencesStmt           *sql.Stmt
+	listRenewalChecklistStmt                *sql.Stmt
+	listSecurityKeysStmt                    *sql.Stmt
+	listStaleSearchSANsStmt                 *sql.Stmt
+	listSubjectPresetsStmt                  *sql.Stmt
+	listSyncAgentCertificatesStmt           *sql.Stmt
+	listSyncAgentHostnamesStmt              *sql.Stmt
+	listSyncAgentsStmt                      *sql.Stmt
+	listTagCountsStmt                       *sql.Stmt
+	listUncheckedKeyStatusHostnamesStmt     *sql.Stmt
+	purgeDeletedCertificateStmt             *sql.Stmt
+	purgeExpiredTrashStmt                   *sql.Stmt
+	reassignCertificateHistoryStmt          *sql.Stmt
+	reassignCertificateRelationSourcesStmt  *sql.Stmt
+	reassignCertificateRelationTargetsStmt  *sql.Stmt
+	reassignCertificateRequesterStmt        *sql.Stmt
+	reassignCertificateTagsStmt             *sql.Stmt
+	reassignChainOverrideStmt               *sql.Stmt
+	reassignDeploymentTargetsStmt           *sql.Stmt
+	reassignRenewalChecklistStmt            *sql.Stmt
+	reassignSyncAgentHostnamesStmt          *sql.Stmt
+	recordBackupStmt                        *sql.Stmt
+	recordUpdateStmt                        *sql.Stmt
+	removeCertificateTagStmt                *sql.Stmt
+	reopenRenewalStepStmt                   *sql.Stmt
+	replaceEncryptedPrivateKeyStmt          *sql.Stmt
+	replacePendingEncryptedPrivateKeyStmt   *sql.Stmt
+	restoreCertificateStmt                  *sql.Stmt
+	restoreDeletedCertificateStmt           *sql.Stmt
+	restoreRenewalStepStmt                  *sql.Stmt
+	revokeSyncAgentStmt                     *sql.Stmt
+	saveSetupProgressStmt                   *sql.Stmt
+	searchCertificateHostnamesStmt          *sql.Stmt
+	searchCertificateHostnamesLikeStmt      *sql.Stmt
+	setConfiguredStmt                       *sql.Stmt
+	setSearchSANsStmt                       *sql.Stmt
+	setSyncServerListenAddressStmt          *sql.Stmt
+	touchSyncAgentStmt                      *sql.Stmt
+	trashCertificateStmt                    *sql.Stmt
+	updateCSRSubmissionStmt                 *sql.Stmt
+	updateCertificateKeyStatusStmt          *sql.Stmt
+	updateCertificateMetadataStmt           *sql.Stmt
+	updateCertificateNoteStmt               *sql.Stmt
+	updateCertificateReadOnlyStmt           *sql.Stmt
+	updateComponentLogLevelsStmt            *sql.Stmt
+	updateConfigStmt                        *sql.Stmt
+	updateEncryptedKeysStmt                 *sql.Stmt
+	updateHostnameSuffixStmt                *sql.Stmt
+	updateLogLevelStmt                      *sql.Stmt
+	updatePendingCSRStmt                    *sql.Stmt
+	updatePendingNoteStmt                   *sql.Stmt
+	updateSecurityKeyLastUsedStmt           *sql.Stmt
+	updateSubjectPresetStmt                 *sql.Stmt
+	upsertCertificateChainOverrideStmt      *sql.Stmt
+	upsertCertificateRelationStmt           *sql.Stmt
+	upsertCertificateRequesterStmt          *sql.Stmt
+	upsertDeploymentTargetStmt              *sql.Stmt
+	upsertIssuerChainOverrideStmt           *sql.Stmt
 }
 
 func (q *Queries) WithTx(tx *sql.Tx) *Queries {
 	return &Queries{
-		db:                             tx,
-		tx:                             tx,
-		activateCertificateStmt:        q.activateCertificateStmt,
-		addHistoryEntryStmt:            q.addHistoryEntryStmt,
-		certificateExistsStmt:          q.certificateExistsStmt,
-		clearPendingCSRStmt:            q.clearPendingCSRStmt,
-		configExistsStmt:               q.configExistsStmt,
-		countAllSecurityKeysStmt:       q.countAllSecurityKeysStmt,
-		countSecurityKeysByMethodStmt:  q.countSecurityKeysByMethodStmt,
-		createCertificateStmt:          q.createCertificateStmt,
-		createConfigStmt:               q.createConfigStmt,
-		deleteAllCertificatesStmt:      q.deleteAllCertificatesStmt,
-		deleteCertificateStmt:          q.deleteCertificateStmt,
-		deleteCertificateHistoryStmt:   q.deleteCertificateHistoryStmt,
-		deleteSecurityKeyStmt:          q.deleteSecurityKeyStmt,
-		deleteSecurityKeysByMethodStmt: q.deleteSecurityKeysByMethodStmt,
-		getCertificateByHostnameStmt:   q.getCertificateByHostnameStmt,
-		getCertificateHistoryStmt:      q.getCertificateHistoryStmt,
-		getConfigStmt:                  q.getConfigStmt,
-		getSecurityKeyByIDStmt:         q.getSecurityKeyByIDStmt,
-		getSecurityKeysByMethodStmt:    q.getSecurityKeysByMethodStmt,
-		getUpdateHistoryStmt:           q.getUpdateHistoryStmt,
-		hasAnySecurityKeysStmt:         q.hasAnySecurityKeysStmt,
-		importCertificateStmt:          q.importCertificateStmt,
-		insertSecurityKeyStmt:          q.insertSecurityKeyStmt,
-		isConfiguredStmt:               q.isConfiguredStmt,
-		listAllCertificatesStmt:        q.listAllCertificatesStmt,
-		listSecurityKeysStmt:           q.listSecurityKeysStmt,
-		recordUpdateStmt:               q.recordUpdateStmt,
-		restoreCertificateStmt:         q.restoreCertificateStmt,
-		setConfiguredStmt:              q.setConfiguredStmt,
-		updateCertificateNoteStmt:      q.updateCertificateNoteStmt,
-		updateCertificateReadOnlyStmt:  q.updateCertificateReadOnlyStmt,
-		updateConfigStmt:               q.updateConfigStmt,
-		updateEncryptedKeysStmt:        q.updateEncryptedKeysStmt,
-		updatePendingCSRStmt:           q.updatePendingCSRStmt,
-		updatePendingNoteStmt:          q.updatePendingNoteStmt,
-		updateSecurityKeyLastUsedStmt:  q.updateSecurityKeyLastUsedStmt,
+		db:                                      tx,
+		tx:                                      tx,
+		activateCertificateStmt:                 q.activateCertificateStmt,
+		addAuditEntryStmt:                       q.addAuditEntryStmt,
+		addCertificateTagStmt:                   q.addCertificateTagStmt,
+		addHistoryEntryStmt:                     q.addHistoryEntryStmt,
+		addNoteReferenceStmt:                    q.addNoteReferenceStmt,
+		addSyncAgentHostnameStmt:                q.addSyncAgentHostnameStmt,
+		carryAuditEntryStmt:                     q.carryAuditEntryStmt,
+		certificateExistsStmt:                   q.certificateExistsStmt,
+		certificateInTrashStmt:                  q.certificateInTrashStmt,
+		clearPendingCSRStmt:                     q.clearPendingCSRStmt,
+		clearPendingCertificateMetadataStmt:     q.clearPendingCertificateMetadataStmt,
+		clearPendingNoteReferencesStmt:          q.clearPendingNoteReferencesStmt,
+		clearRenewalChecklistStmt:               q.clearRenewalChecklistStmt,
+		completeRenewalStepStmt:                 q.completeRenewalStepStmt,
+		configExistsStmt:                        q.configExistsStmt,
+		copyCertificateToHostnameStmt:           q.copyCertificateToHostnameStmt,
+		countAllSecurityKeysStmt:                q.countAllSecurityKeysStmt,
+		countAuditLogStmt:                       q.countAuditLogStmt,
+		countCertificatePageStmt:                q.countCertificatePageStmt,
+		countCertificatesStmt:                   q.countCertificatesStmt,
+		countHistoryStmt:                        q.countHistoryStmt,
+		countSecurityKeysByMethodStmt:           q.countSecurityKeysByMethodStmt,
+		countUpdateHistoryStmt:                  q.countUpdateHistoryStmt,
+		createCertificateStmt:                   q.createCertificateStmt,
+		createConfigStmt:                        q.createConfigStmt,
+		createOperationIntentStmt:               q.createOperationIntentStmt,
+		createSyncServerStmt:                    q.createSyncServerStmt,
+		deleteAllCertificatesStmt:               q.deleteAllCertificatesStmt,
+		deleteAllSecurityKeysStmt:               q.deleteAllSecurityKeysStmt,
+		deleteCertificateStmt:                   q.deleteCertificateStmt,
+		deleteCertificateChainOverrideStmt:      q.deleteCertificateChainOverrideStmt,
+		deleteCertificateHistoryStmt:            q.deleteCertificateHistoryStmt,
+		deleteCertificateRelationStmt:           q.deleteCertificateRelationStmt,
+		deleteDeploymentTargetStmt:              q.deleteDeploymentTargetStmt,
+		deleteHistoryBeforeStmt:                 q.deleteHistoryBeforeStmt,
+		deleteIncompleteConfigStmt:              q.deleteIncompleteConfigStmt,
+		deleteIssuerChainOverrideStmt:           q.deleteIssuerChainOverrideStmt,
+		deleteNoteReferencesStmt:                q.deleteNoteReferencesStmt,
+		deleteOperationIntentStmt:               q.deleteOperationIntentStmt,
+		deleteSecurityKeyStmt:                   q.deleteSecurityKeyStmt,
+		deleteSecurityKeysByMethodStmt:          q.deleteSecurityKeysByMethodStmt,
+		deleteSetupProgressStmt:                 q.deleteSetupProgressStmt,
+		deleteSubjectPresetStmt:                 q.deleteSubjectPresetStmt,
+		deleteSyncAgentHostnamesStmt:            q.deleteSyncAgentHostnamesStmt,
+		deleteUpdateHistoryBeforeStmt:           q.deleteUpdateHistoryBeforeStmt,
+		discountCertificateWriteStmt:            q.discountCertificateWriteStmt,
+		getActiveSyncAgentStmt:                  q.getActiveSyncAgentStmt,
+		getCertificateByHostnameStmt:            q.getCertificateByHostnameStmt,
+		getCertificateHistoryStmt:               q.getCertificateHistoryStmt,
+		getCertificateRequesterStmt:             q.getCertificateRequesterStmt,
+		getConfigStmt:                           q.getConfigStmt,
+		getSecurityKeyByIDStmt:                  q.getSecurityKeyByIDStmt,
+		getSecurityKeysByMethodStmt:             q.getSecurityKeysByMethodStmt,
+		getSetupProgressStmt:                    q.getSetupProgressStmt,
+		getSubjectPresetByIDStmt:                q.getSubjectPresetByIDStmt,
+		getSyncAgentByFingerprintStmt:           q.getSyncAgentByFingerprintStmt,
+		getSyncAgentCertificateStmt:             q.getSyncAgentCertificateStmt,
+		getSyncServerStmt:                       q.getSyncServerStmt,
+		getTicketPatternStmt:                    q.getTicketPatternStmt,
+		getUpdateHistoryStmt:                    q.getUpdateHistoryStmt,
+		hasAnySecurityKeysStmt:                  q.hasAnySecurityKeysStmt,
+		importCertificateStmt:                   q.importCertificateStmt,
+		insertSecurityKeyStmt:                   q.insertSecurityKeyStmt,
+		insertSubjectPresetStmt:                 q.insertSubjectPresetStmt,
+		insertSyncAgentStmt:                     q.insertSyncAgentStmt,
+		isConfiguredStmt:                        q.isConfiguredStmt,
+		listAllAuditEntriesStmt:                 q.listAllAuditEntriesStmt,
+		listAllCertificateTagsStmt:              q.listAllCertificateTagsStmt,
+		listAllCertificatesStmt:                 q.listAllCertificatesStmt,
+		listAllCertificatesIncludingDeletedStmt: q.listAllCertificatesIncludingDeletedStmt,
+		listAuditLogStmt:                        q.listAuditLogStmt,
+		listCertificatePageStmt:                 q.listCertificatePageStmt,
+		listCertificateRelationsStmt:            q.listCertificateRelationsStmt,
+		listCertificateRelationsForHostnameStmt: q.listCertificateRelationsForHostnameStmt,
+		listCertificateTagsStmt:                 q.listCertificateTagsStmt,
+		listChainOverridesStmt:                  q.listChainOverridesStmt,
+		listDeletedCertificatesStmt:             q.listDeletedCertificatesStmt,
+		listDeploymentTargetsStmt:               q.listDeploymentTargetsStmt,
+		listHistoryStmt:                         q.listHistoryStmt,
+		listHostnamesByNoteReferenceStmt:        q.listHostnamesByNoteReferenceStmt,
+		listNoteReferencesStmt:                  q.listNoteReferencesStmt,
+		listOperationIntentsStmt:                q.listOperationIntentsStmt,
+		listPendingCertificateMetadataStmt:      q.listPendingCertificateMetadataStmt,
+		listPendingNoteReferencesStmt:           q.listPendingNoteReferencesStmt,
+		listRenewalChecklistStmt:                q.listRenewalChecklistStmt,
+		listSecurityKeysStmt:                    q.listSecurityKeysStmt,
+		listStaleSearchSANsStmt:                 q.listStaleSearchSANsStmt,
+		listSubjectPresetsStmt:                  q.listSubjectPresetsStmt,
+		listSyncAgentCertificatesStmt:           q.listSyncAgentCertificatesStmt,
+		listSyncAgentHostnamesStmt:              q.listSyncAgentHostnamesStmt,
+		listSyncAgentsStmt:                      q.listSyncAgentsStmt,
+		listTagCountsStmt:                       q.listTagCountsStmt,
+		listUncheckedKeyStatusHostnamesStmt:     q.listUncheckedKeyStatusHostnamesStmt,
+		purgeDeletedCertificateStmt:             q.purgeDeletedCertificateStmt,
+		purgeExpiredTrashStmt:                   q.purgeExpiredTrashStmt,
+		reassignCertificateHistoryStmt:          q.reassignCertificateHistoryStmt,
+		reassignCertificateRelationSourcesStmt:  q.reassignCertificateRelationSourcesStmt,
+		reassignCertificateRelationTargetsStmt:  q.reassignCertificateRelationTargetsStmt,
+		reassignCertificateRequesterStmt:        q.reassignCertificateRequesterStmt,
+		reassignCertificateTagsStmt:             q.reassignCertificateTagsStmt,
+		reassignChainOverrideStmt:               q.reassignChainOverrideStmt,
+		reassignDeploymentTargetsStmt:           q.reassignDeploymentTargetsStmt,
+		reassignRenewalChecklistStmt:            q.reassignRenewalChecklistStmt,
+		reassignSyncAgentHostnamesStmt:          q.reassignSyncAgentHostnamesStmt,
+		recordBackupStmt:                        q.recordBackupStmt,
+		recordUpdateStmt:                        q.recordUpdateStmt,
+		removeCertificateTagStmt:                q.removeCertificateTagStmt,
+		reopenRenewalStepStmt:                   q.reopenRenewalStepStmt,
+		replaceEncryptedPrivateKeyStmt:          q.replaceEncryptedPrivateKeyStmt,
+		replacePendingEncryptedPrivateKeyStmt:   q.replacePendingEncryptedPrivateKeyStmt,
+		restoreCertificateStmt:                  q.restoreCertificateStmt,
+		restoreDeletedCertificateStmt:           q.restoreDeletedCertificateStmt,
+		restoreRenewalStepStmt:                  q.restoreRenewalStepStmt,
+		revokeSyncAgentStmt:                     q.revokeSyncAgentStmt,
+		saveSetupProgressStmt:                   q.saveSetupProgressStmt,
+		searchCertificateHostnamesStmt:          q.searchCertificateHostnamesStmt,
+		searchCertificateHostnamesLikeStmt:      q.searchCertificateHostnamesLikeStmt,
+		setConfiguredStmt:                       q.setConfiguredStmt,
+		setSearchSANsStmt:                       q.setSearchSANsStmt,
+		setSyncServerListenAddressStmt:          q.setSyncServerListenAddressStmt,
+		touchSyncAgentStmt:                      q.touchSyncAgentStmt,
+		trashCertificateStmt:                    q.trashCertificateStmt,
+		updateCSRSubmissionStmt:                 q.updateCSRSubmissionStmt,
+		updateCertificateKeyStatusStmt:          q.updateCertificateKeyStatusStmt,
+		updateCertificateMetadataStmt:           q.updateCertificateMetadataStmt,
+		updateCertificateNoteStmt:               q.updateCertificateNoteStmt,
+		updateCertificateReadOnlyStmt:           q.updateCertificateReadOnlyStmt,
+		updateComponentLogLevelsStmt:            q.updateComponentLogLevelsStmt,
+		updateConfigStmt:                        q.updateConfigStmt,
+		updateEncryptedKeysStmt:                 q.updateEncryptedKeysStmt,
+		updateHostnameSuffixStmt:                q.updateHostnameSuffixStmt,
+		updateLogLevelStmt:                      q.updateLogLevelStmt,
+		updatePendingCSRStmt:                    q.updatePendingCSRStmt,
+		updatePendingNoteStmt:                   q.updatePendingNoteStmt,
+		updateSecurityKeyLastUsedStmt:           q.updateSecurityKeyLastUsedStmt,
+		updateSubjectPresetStmt:                 q.updateSubjectPresetStmt,
+		upsertCertificateChainOverrideStmt:      q.upsertCertificateChainOverrideStmt,
+		upsertCertificateRelationStmt:           q.upsertCertificateRelationStmt,
+		upsertCertificateRequesterStmt:          q.upsertCertificateRequesterStmt,
+		upsertDeploymentTargetStmt:              q.upsertDeploymentTargetStmt,
+		upsertIssuerChainOverrideStmt:           q.upsertIssuerChainOverrideStmt,
 	}
 }
@@ -11,23 +11,55 @@ import (
 
 const addHistoryEntry = `-- name: AddHistoryEntry :exec
 
-INSERT INTO certificate_history (hostname, event_type, message)
-VALUES (?, ?, ?)
+INSERT INTO certificate_history (hostname, event_type, message, actor, app_version, details)
+VALUES (?, ?, ?, ?, ?, ?)
 `
 
 type AddHistoryEntryParams struct {
-	Hostname  string `json:"hostname"`
-	EventType string `json:"event_type"`
-	Message   string `json:"message"`
+	Hostname   string `json:"hostname"`
+	EventType  string `json:"event_type"`
+	Message    string `json:"message"`
+	Actor      string `json:"actor"`
+	AppVersion string `json:"app_version"`
+	Details    string `json:"details"`
 }
 
 // Certificate history queries
 // Add a new history entry for a certificate
 func (q *Queries) AddHistoryEntry(ctx context.Context, arg AddHistoryEntryParams) error {
-	_, err := q.exec(ctx, q.addHistoryEntryStmt, addHistoryEntry, arg.Hostname, arg.EventType, arg.Message)
+	_, err := q.exec(ctx, q.addHistoryEntryStmt, addHistoryEntry,
+		arg.Hostname,
+		arg.EventType,
+		arg.Message,
+		arg.Actor,
+		arg.AppVersion,
+		arg.Details,
+	)
 	return err
 }
 
+const countHistory = `-- name: CountHistory :one
+SELECT COUNT(*) AS count
+FROM certificate_history
+WHERE (CAST(?1 AS TEXT) = '' OR instr(',' || ?1 || ',', ',' || event_type || ',') > 0)
+  AND created_at >= CAST(?2 AS INTEGER)
+  AND (CAST(?3 AS INTEGER) = 0 OR created_at < ?3)
+`
+
+type CountHistoryParams struct {
+	EventTypes  string `json:"event_types"`
+	CreatedFrom int64  `json:"created_from"`
+	CreatedTo   int64  `json:"created_to"`
+}
+
+// Count the history entries ListHistory pages through
+func (q *Queries) CountHistory(ctx context.Context, arg CountHistoryParams) (int64, error) {
+	row := q.queryRow(ctx, q.countHistoryStmt, countHistory, arg.EventTypes, arg.CreatedFrom, arg.CreatedTo)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const deleteCertificateHistory = `-- name: DeleteCertificateHistory :exec
 DELETE FROM certificate_history WHERE hostname = ?
 `
@@ -38,8 +70,21 @@ func (q *Queries) DeleteCertificateHistory(ctx context.Context, hostname string)
 	return err
 }
 
+const deleteHistoryBefore = `-- name: DeleteHistoryBefore :execrows
+DELETE FROM certificate_history WHERE created_at < ?
+`
+
+// Delete history entries older than a cutoff (database cleanup)
+func (q *Queries) DeleteHistoryBefore(ctx context.Context, createdAt int64) (int64, error) {
+	result, err := q.exec(ctx, q.deleteHistoryBeforeStmt, deleteHistoryBefore, createdAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getCertificateHistory = `-- name: GetCertificateHistory :many
-SELECT id, hostname, event_type, message, created_at
+SELECT id, hostname, event_type, message, created_at, actor, app_version, details
 FROM certificate_history
 WHERE hostname = ?
 ORDER BY created_at DESC
@@ -67,6 +112,67 @@ func (q *Queries) GetCertificateHistory(ctx context.Context, arg GetCertificateH
 			&i.EventType,
 			&i.Message,
 			&i.CreatedAt,
+			&i.Actor,
+			&i.AppVersion,
+			&i.Details,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listHistory = `-- name: ListHistory :many
+SELECT id, hostname, event_type, message, created_at, actor, app_version, details
+FROM certificate_history
+WHERE (CAST(?1 AS TEXT) = '' OR instr(',' || ?1 || ',', ',' || event_type || ',') > 0)
+  AND created_at >= CAST(?2 AS INTEGER)
+  AND (CAST(?3 AS INTEGER) = 0 OR created_at < ?3)
+ORDER BY created_at DESC, id DESC
+LIMIT ?4 OFFSET ?5
+`
+
+type ListHistoryParams struct {
+	EventTypes  string `json:"event_types"`
+	CreatedFrom int64  `json:"created_from"`
+	CreatedTo   int64  `json:"created_to"`
+	PageLimit   int64  `json:"page_limit"`
+	PageOffset  int64  `json:"page_offset"`
+}
+
+// List history entries across all certificates, most recent first. event_types is
+// a comma-separated list (empty for all); created_to is exclusive (0 for no bound).
+func (q *Queries) ListHistory(ctx context.Context, arg ListHistoryParams) ([]CertificateHistory, error) {
+	rows, err := q.query(ctx, q.listHistoryStmt, listHistory,
+		arg.EventTypes,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CertificateHistory
+	for rows.Next() {
+		var i CertificateHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.Hostname,
+			&i.EventType,
+			&i.Message,
+			&i.CreatedAt,
+			&i.Actor,
+			&i.AppVersion,
+			&i.Details,
 		); err != nil {
 			return nil, err
 		}
@@ -80,3 +186,18 @@ func (q *Queries) GetCertificateHistory(ctx context.Context, arg GetCertificateH
 	}
 	return items, nil
 }
+
+const reassignCertificateHistory = `-- name: ReassignCertificateHistory :exec
+UPDATE certificate_history SET hostname = ?1 WHERE hostname = ?2
+`
+
+type ReassignCertificateHistoryParams struct {
+	NewHostname string `json:"new_hostname"`
+	OldHostname string `json:"old_hostname"`
+}
+
+// Move history entries from one hostname to another (used when renaming or merging)
+func (q *Queries) ReassignCertificateHistory(ctx context.Context, arg ReassignCertificateHistoryParams) error {
+	_, err := q.exec(ctx, q.reassignCertificateHistoryStmt, reassignCertificateHistory, arg.NewHostname, arg.OldHostname)
+	return err
+}
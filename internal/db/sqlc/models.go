@@ -8,6 +8,17 @@ import (
 	"database/sql"
 )
 
+type AuditLog struct {
+	ID         int64  `json:"id"`
+	EventType  string `json:"event_type"`
+	Hostname   string `json:"hostname"`
+	Message    string `json:"message"`
+	Actor      string `json:"actor"`
+	AppVersion string `json:"app_version"`
+	Details    string `json:"details"`
+	CreatedAt  int64  `json:"created_at"`
+}
+
 type Certificate struct {
 	Hostname                   string         `json:"hostname"`
 	EncryptedPrivateKey        []byte         `json:"encrypted_private_key"`
@@ -20,16 +31,87 @@ type Certificate struct {
 	Note                       sql.NullString `json:"note"`
 	PendingNote                sql.NullString `json:"pending_note"`
 	ReadOnly                   int64          `json:"read_only"`
+	ChainPem                   sql.NullString `json:"chain_pem"`
+	CaReference                sql.NullString `json:"ca_reference"`
+	SubmittedAt                sql.NullInt64  `json:"submitted_at"`
+	KeyStatus                  string         `json:"key_status"`
+	Sans                       string         `json:"sans"`
+	KeyAlgorithm               string         `json:"key_algorithm"`
+	KeySize                    int64          `json:"key_size"`
+	DeletedAt                  sql.NullInt64  `json:"deleted_at"`
 }
 
 type CertificateHistory struct {
-	ID        int64  `json:"id"`
+	ID         int64  `json:"id"`
+	Hostname   string `json:"hostname"`
+	EventType  string `json:"event_type"`
+	Message    string `json:"message"`
+	CreatedAt  int64  `json:"created_at"`
+	Actor      string `json:"actor"`
+	AppVersion string `json:"app_version"`
+	Details    string `json:"details"`
+}
+
+type CertificateMetadataPending struct {
+	Hostname string `json:"hostname"`
+}
+
+type CertificateNoteReference struct {
+	Hostname string `json:"hostname"`
+	Source   string `json:"source"`
+	Kind     string `json:"kind"`
+	Value    string `json:"value"`
+}
+
+type CertificateRelation struct {
+	ID             int64  `json:"id"`
+	SourceHostname string `json:"source_hostname"`
+	TargetHostname string `json:"target_hostname"`
+	RelationType   string `json:"relation_type"`
+	Label          string `json:"label"`
+	CreatedAt      int64  `json:"created_at"`
+}
+
+type CertificateRequester struct {
+	Hostname       string `json:"hostname"`
+	RequesterName  string `json:"requester_name"`
+	RequesterEmail string `json:"requester_email"`
+	Team           string `json:"team"`
+	Justification  string `json:"justification"`
+	RecordedAt     int64  `json:"recorded_at"`
+}
+
+type CertificateSearch struct {
+	Hostname    string `json:"hostname"`
+	Sans        string `json:"sans"`
+	Note        string `json:"note"`
+	PendingNote string `json:"pending_note"`
+}
+
+type CertificateSearchContent struct {
+	ID          int64  `json:"id"`
+	Hostname    string `json:"hostname"`
+	Sans        string `json:"sans"`
+	Note        string `json:"note"`
+	PendingNote string `json:"pending_note"`
+	SansStale   int64  `json:"sans_stale"`
+}
+
+type CertificateTag struct {
 	Hostname  string `json:"hostname"`
-	EventType string `json:"event_type"`
-	Message   string `json:"message"`
+	Tag       string `json:"tag"`
 	CreatedAt int64  `json:"created_at"`
 }
 
+type ChainOverride struct {
+	ID        int64          `json:"id"`
+	Hostname  sql.NullString `json:"hostname"`
+	IssuerDn  sql.NullString `json:"issuer_dn"`
+	IssuerUrl string         `json:"issuer_url"`
+	IssuerPem string         `json:"issuer_pem"`
+	CreatedAt int64          `json:"created_at"`
+}
+
 type Config struct {
 	ID                        int64          `json:"id"`
 	OwnerEmail                string         `json:"owner_email"`
@@ -45,6 +127,59 @@ type Config struct {
 	IsConfigured              int64          `json:"is_configured"`
 	CreatedAt                 int64          `json:"created_at"`
 	LastModified              int64          `json:"last_modified"`
+	ExpiringThresholdDays     int64          `json:"expiring_threshold_days"`
+	ClockCheckUrl             string         `json:"clock_check_url"`
+	AirGapped                 int64          `json:"air_gapped"`
+	BackupFreshnessMaxWrites  int64          `json:"backup_freshness_max_writes"`
+	BackupFreshnessBlock      int64          `json:"backup_freshness_block"`
+	WritesSinceBackup         int64          `json:"writes_since_backup"`
+	LastBackupAt              sql.NullInt64  `json:"last_backup_at"`
+	FipsMode                  int64          `json:"fips_mode"`
+	DbSizeWarnMb              int64          `json:"db_size_warn_mb"`
+	LogLevel                  string         `json:"log_level"`
+	LogComponentLevels        string         `json:"log_component_levels"`
+	LogMaxSizeMb              int64          `json:"log_max_size_mb"`
+	LogMaxFiles               int64          `json:"log_max_files"`
+	LogMaxAgeDays             int64          `json:"log_max_age_days"`
+	LogCompress               int64          `json:"log_compress"`
+	MinimizeToTray            int64          `json:"minimize_to_tray"`
+	RunInBackground           int64          `json:"run_in_background"`
+	ExpiryNotifications       int64          `json:"expiry_notifications"`
+	AiaCacheTtlMinutes        int64          `json:"aia_cache_ttl_minutes"`
+	DownloadLineEndings       string         `json:"download_line_endings"`
+	DownloadTextHeader        int64          `json:"download_text_header"`
+	DownloadFormat            string         `json:"download_format"`
+	KeyPoolSize               int64          `json:"key_pool_size"`
+	KdfProfile                string         `json:"kdf_profile"`
+	TicketPattern             string         `json:"ticket_pattern"`
+	TrashRetentionDays        int64          `json:"trash_retention_days"`
+}
+
+type DeploymentTarget struct {
+	ID         int64  `json:"id"`
+	Hostname   string `json:"hostname"`
+	Name       string `json:"name"`
+	Location   string `json:"location"`
+	DeployedAt int64  `json:"deployed_at"`
+}
+
+type NoteReferencesPending struct {
+	Hostname string `json:"hostname"`
+}
+
+type OperationIntent struct {
+	ID        int64  `json:"id"`
+	Operation string `json:"operation"`
+	Hostname  string `json:"hostname"`
+	Payload   string `json:"payload"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+type RenewalChecklist struct {
+	Hostname    string `json:"hostname"`
+	Step        string `json:"step"`
+	CompletedAt int64  `json:"completed_at"`
+	Actor       string `json:"actor"`
 }
 
 type SecurityKey struct {
@@ -57,6 +192,48 @@ type SecurityKey struct {
 	LastUsedAt       sql.NullInt64  `json:"last_used_at"`
 }
 
+type SetupProgress struct {
+	ID        int64  `json:"id"`
+	Step      string `json:"step"`
+	Draft     string `json:"draft"`
+	UpdatedAt int64  `json:"updated_at"`
+}
+
+type SubjectPreset struct {
+	ID                 int64          `json:"id"`
+	Name               string         `json:"name"`
+	Organization       string         `json:"organization"`
+	OrganizationalUnit sql.NullString `json:"organizational_unit"`
+	City               string         `json:"city"`
+	State              string         `json:"state"`
+	Country            string         `json:"country"`
+	CreatedAt          int64          `json:"created_at"`
+	LastModified       int64          `json:"last_modified"`
+}
+
+type SyncAgent struct {
+	ID              int64         `json:"id"`
+	Name            string        `json:"name"`
+	CertFingerprint string        `json:"cert_fingerprint"`
+	ExpiresAt       int64         `json:"expires_at"`
+	CreatedAt       int64         `json:"created_at"`
+	RevokedAt       sql.NullInt64 `json:"revoked_at"`
+	LastSeenAt      sql.NullInt64 `json:"last_seen_at"`
+}
+
+type SyncAgentHostname struct {
+	AgentID  int64  `json:"agent_id"`
+	Hostname string `json:"hostname"`
+}
+
+type SyncServer struct {
+	ID                    int64  `json:"id"`
+	CaCertificatePem      string `json:"ca_certificate_pem"`
+	CaEncryptedPrivateKey []byte `json:"ca_encrypted_private_key"`
+	ListenAddress         string `json:"listen_address"`
+	CreatedAt             int64  `json:"created_at"`
+}
+
 type UpdateHistory struct {
 	ID           int64          `json:"id"`
 	FromVersion  string         `json:"from_version"`
@@ -6,6 +6,7 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
 )
 
 type Querier interface {
@@ -13,43 +14,128 @@ type Querier interface {
 	// Move pending key to active column, store certificate, clear pending columns
 	// COALESCE ensures existing key is preserved if pending key is somehow NULL
 	ActivateCertificate(ctx context.Context, arg ActivateCertificateParams) error
+	// Audit log queries
+	// Append an entry to the audit log
+	AddAuditEntry(ctx context.Context, arg AddAuditEntryParams) error
+	// Tag a certificate (no-op when it already has the tag)
+	AddCertificateTag(ctx context.Context, arg AddCertificateTagParams) error
 	// Certificate history queries
 	// Add a new history entry for a certificate
 	AddHistoryEntry(ctx context.Context, arg AddHistoryEntryParams) error
+	// Record a URL or ticket ID found in a certificate note
+	AddNoteReference(ctx context.Context, arg AddNoteReferenceParams) error
+	// Allow an agent to pull a certificate
+	AddSyncAgentHostname(ctx context.Context, arg AddSyncAgentHostnameParams) error
+	// Insert an audit log entry with its original timestamp, unless the log
+	// already holds it under the same id (a restored backup of this database holds
+	// the entries recorded before it)
+	CarryAuditEntry(ctx context.Context, arg CarryAuditEntryParams) error
 	// Check if certificate exists by hostname
 	CertificateExists(ctx context.Context, hostname string) (int64, error)
+	// Check if the certificate holding a hostname is in the trash
+	CertificateInTrash(ctx context.Context, hostname string) (int64, error)
 	// Clear pending CSR and pending key without deleting the certificate
 	ClearPendingCSR(ctx context.Context, hostname string) error
+	// Mark the metadata of a certificate as parsed
+	ClearPendingCertificateMetadata(ctx context.Context, hostname string) error
+	// Mark the references of a certificate as extracted
+	ClearPendingNoteReferences(ctx context.Context, hostname string) error
+	// Clear the checklist of a certificate when a new renewal starts
+	ClearRenewalChecklist(ctx context.Context, hostname string) error
+	// Mark a renewal step as completed; completing it again keeps the first completion
+	CompleteRenewalStep(ctx context.Context, arg CompleteRenewalStepParams) error
 	// Check if configuration exists
 	ConfigExists(ctx context.Context) (int64, error)
+	// Duplicate a certificate row under a new hostname (used to rename a certificate:
+	// copy, reassign history, then delete the old row)
+	CopyCertificateToHostname(ctx context.Context, arg CopyCertificateToHostnameParams) error
 	// Count all security keys
 	CountAllSecurityKeys(ctx context.Context) (int64, error)
+	// Count the audit log entries ListAuditLog pages through
+	CountAuditLog(ctx context.Context, arg CountAuditLogParams) (int64, error)
+	// Count the certificates matching a filter (same arguments as ListCertificatePage)
+	CountCertificatePage(ctx context.Context, arg CountCertificatePageParams) (int64, error)
+	// Count all certificates, including those in the trash
+	CountCertificates(ctx context.Context) (int64, error)
+	// Count the history entries ListHistory pages through
+	CountHistory(ctx context.Context, arg CountHistoryParams) (int64, error)
 	// Count security keys of a specific method
 	CountSecurityKeysByMethod(ctx context.Context, method string) (int64, error)
+	// Count update history entries
+	CountUpdateHistory(ctx context.Context) (int64, error)
 	// Create a new certificate entry with all fields
 	CreateCertificate(ctx context.Context, arg CreateCertificateParams) error
 	// Create the initial configuration
 	CreateConfig(ctx context.Context, arg CreateConfigParams) error
+	// Record the intent of a multi-step operation before its first step
+	CreateOperationIntent(ctx context.Context, arg CreateOperationIntentParams) (int64, error)
+	// Store the sync CA, created on the first agent enrollment
+	CreateSyncServer(ctx context.Context, arg CreateSyncServerParams) error
 	// Delete all certificates
 	DeleteAllCertificates(ctx context.Context) error
+	// Remove every unlock method (used only to abort an incomplete setup)
+	DeleteAllSecurityKeys(ctx context.Context) error
 	// Delete a certificate
 	DeleteCertificate(ctx context.Context, hostname string) error
+	// Remove the chain override of a certificate
+	DeleteCertificateChainOverride(ctx context.Context, hostname sql.NullString) (int64, error)
 	// Delete all history entries for a certificate (used when certificate is deleted)
 	DeleteCertificateHistory(ctx context.Context, hostname string) error
+	// Remove a relation
+	DeleteCertificateRelation(ctx context.Context, id int64) (int64, error)
+	// Remove a deployment target
+	DeleteDeploymentTarget(ctx context.Context, id int64) (int64, error)
+	// Delete history entries older than a cutoff (database cleanup)
+	DeleteHistoryBefore(ctx context.Context, createdAt int64) (int64, error)
+	// Remove a configuration left behind by an interrupted setup
+	DeleteIncompleteConfig(ctx context.Context) (int64, error)
+	// Remove the chain override of an issuing CA
+	DeleteIssuerChainOverride(ctx context.Context, issuerDn sql.NullString) (int64, error)
+	// Remove the extracted references of a certificate
+	DeleteNoteReferences(ctx context.Context, hostname string) error
+	// Remove an intent once its operation finished or was resolved
+	DeleteOperationIntent(ctx context.Context, id int64) error
 	// Delete a security key by ID
 	DeleteSecurityKey(ctx context.Context, id int64) error
 	// Delete all security keys of a specific method
 	DeleteSecurityKeysByMethod(ctx context.Context, method string) error
+	// Forget the progress of the setup wizard
+	DeleteSetupProgress(ctx context.Context) error
+	// Delete a subject preset by ID
+	DeleteSubjectPreset(ctx context.Context, id int64) error
+	// Remove every certificate assigned to an agent
+	DeleteSyncAgentHostnames(ctx context.Context, agentID int64) error
+	// Delete update history entries older than a cutoff (database cleanup)
+	DeleteUpdateHistoryBefore(ctx context.Context, createdAt int64) (int64, error)
+	// Undo the backup freshness count of a certificate write that left its data
+	// unchanged (re-encrypting a key blob)
+	DiscountCertificateWrite(ctx context.Context) error
+	// Get an agent that has not been revoked
+	GetActiveSyncAgent(ctx context.Context, id int64) (SyncAgent, error)
 	// Get a certificate by hostname
 	GetCertificateByHostname(ctx context.Context, hostname string) (Certificate, error)
 	// Get history entries for a certificate, ordered by most recent first
 	GetCertificateHistory(ctx context.Context, arg GetCertificateHistoryParams) ([]CertificateHistory, error)
+	// Get who requested a certificate
+	GetCertificateRequester(ctx context.Context, hostname string) (CertificateRequester, error)
 	// Get the configuration (single row)
 	GetConfig(ctx context.Context) (Config, error)
 	// Get a single security key by ID
 	GetSecurityKeyByID(ctx context.Context, id int64) (SecurityKey, error)
 	// Get security keys filtered by method type
 	GetSecurityKeysByMethod(ctx context.Context, method string) ([]SecurityKey, error)
+	// Get the saved progress of the setup wizard
+	GetSetupProgress(ctx context.Context) (SetupProgress, error)
+	// Get a single subject preset by ID
+	GetSubjectPresetByID(ctx context.Context, id int64) (SubjectPreset, error)
+	// Find the agent a client certificate was issued to
+	GetSyncAgentByFingerprint(ctx context.Context, certFingerprint string) (SyncAgent, error)
+	// Get a certificate assigned to an agent, with its encrypted key
+	GetSyncAgentCertificate(ctx context.Context, arg GetSyncAgentCertificateParams) (GetSyncAgentCertificateRow, error)
+	// Get the sync CA and listen address
+	GetSyncServer(ctx context.Context) (SyncServer, error)
+	// Get the regular expression ticket IDs are extracted with
+	GetTicketPattern(ctx context.Context) (string, error)
 	// Get recent update history entries, newest first
 	GetUpdateHistory(ctx context.Context, limit int64) ([]UpdateHistory, error)
 	// Check if any security keys exist
@@ -58,33 +144,180 @@ type Querier interface {
 	ImportCertificate(ctx context.Context, arg ImportCertificateParams) error
 	// Insert a new security key and return the created row
 	InsertSecurityKey(ctx context.Context, arg InsertSecurityKeyParams) (SecurityKey, error)
+	// Insert a new subject preset and return the created row
+	InsertSubjectPreset(ctx context.Context, arg InsertSubjectPresetParams) (SubjectPreset, error)
+	// Enroll an agent and return the created row
+	InsertSyncAgent(ctx context.Context, arg InsertSyncAgentParams) (SyncAgent, error)
 	// Check if initial setup is complete
 	IsConfigured(ctx context.Context) (int64, error)
+	// List every audit log entry, oldest first (carried over a restore)
+	ListAllAuditEntries(ctx context.Context) ([]AuditLog, error)
+	// List the tags of every certificate
+	ListAllCertificateTags(ctx context.Context) ([]CertificateTag, error)
 	// List all certificates ordered by creation date
 	ListAllCertificates(ctx context.Context) ([]Certificate, error)
+	// List all certificates, including those in the trash (for key maintenance)
+	ListAllCertificatesIncludingDeleted(ctx context.Context) ([]Certificate, error)
+	// List audit log entries, most recent first. event_types is a comma-separated
+	// list (empty for all), hostname is empty for all; created_to is exclusive (0
+	// for no bound).
+	ListAuditLog(ctx context.Context, arg ListAuditLogParams) ([]AuditLog, error)
+	// List one page of the certificates matching a filter, sorted, with their
+	// status computed at now for an "expiring soon" window of threshold days.
+	// Empty or zero filter arguments match everything; hostnames (a JSON array)
+	// applies only when restrict_hostnames is 1, and tags (a JSON array) keeps
+	// certificates carrying every tag. A negative limit returns every row.
+	ListCertificatePage(ctx context.Context, arg ListCertificatePageParams) ([]ListCertificatePageRow, error)
+	// List every relation between certificates
+	ListCertificateRelations(ctx context.Context) ([]CertificateRelation, error)
+	// List the relations a certificate takes part in, on either side
+	ListCertificateRelationsForHostname(ctx context.Context, hostname string) ([]CertificateRelation, error)
+	// List the tags of a certificate
+	ListCertificateTags(ctx context.Context, hostname string) ([]string, error)
+	// List the chain overrides of certificates and issuing CAs
+	ListChainOverrides(ctx context.Context) ([]ChainOverride, error)
+	// List the certificates in the trash, most recently deleted first
+	ListDeletedCertificates(ctx context.Context) ([]ListDeletedCertificatesRow, error)
+	// List where a certificate is deployed
+	ListDeploymentTargets(ctx context.Context, hostname string) ([]DeploymentTarget, error)
+	// List history entries across all certificates, most recent first. event_types is
+	// a comma-separated list (empty for all); created_to is exclusive (0 for no bound).
+	ListHistory(ctx context.Context, arg ListHistoryParams) ([]CertificateHistory, error)
+	// List the certificates whose notes reference a URL or ticket ID (case-insensitive)
+	ListHostnamesByNoteReference(ctx context.Context, value string) ([]string, error)
+	// List the references found in the notes of a certificate
+	ListNoteReferences(ctx context.Context, hostname string) ([]CertificateNoteReference, error)
+	// List the intents left by unfinished operations, oldest first
+	ListOperationIntents(ctx context.Context) ([]OperationIntent, error)
+	// List the certificates whose PEMs changed since their metadata was parsed
+	ListPendingCertificateMetadata(ctx context.Context) ([]ListPendingCertificateMetadataRow, error)
+	// List the certificates whose notes changed since their references were
+	// extracted, with their notes
+	ListPendingNoteReferences(ctx context.Context) ([]ListPendingNoteReferencesRow, error)
+	// Get the completed renewal steps of a certificate
+	ListRenewalChecklist(ctx context.Context, hostname string) ([]RenewalChecklist, error)
 	// List all security keys ordered by creation date
 	ListSecurityKeys(ctx context.Context) ([]SecurityKey, error)
+	// List the certificates whose SANs must be parsed again, with their PEMs
+	ListStaleSearchSANs(ctx context.Context) ([]ListStaleSearchSANsRow, error)
+	// List all subject presets ordered by name
+	ListSubjectPresets(ctx context.Context) ([]SubjectPreset, error)
+	// List the issued certificates an agent may pull
+	ListSyncAgentCertificates(ctx context.Context, agentID int64) ([]ListSyncAgentCertificatesRow, error)
+	// List the certificate assignments of every agent
+	ListSyncAgentHostnames(ctx context.Context) ([]SyncAgentHostname, error)
+	// List enrolled agents, revoked ones last
+	ListSyncAgents(ctx context.Context) ([]SyncAgent, error)
+	// List every tag in use with the number of certificates carrying it
+	ListTagCounts(ctx context.Context) ([]ListTagCountsRow, error)
+	// Certificates whose key pair health has not been computed yet
+	ListUncheckedKeyStatusHostnames(ctx context.Context) ([]string, error)
+	// Permanently delete a certificate from the trash
+	PurgeDeletedCertificate(ctx context.Context, hostname string) (int64, error)
+	// Permanently delete the certificates moved to the trash at or before a time
+	PurgeExpiredTrash(ctx context.Context, deletedAt sql.NullInt64) (int64, error)
+	// Move history entries from one hostname to another (used when renaming or merging)
+	ReassignCertificateHistory(ctx context.Context, arg ReassignCertificateHistoryParams) error
+	// Move the relations starting from a certificate to another hostname (used when renaming)
+	ReassignCertificateRelationSources(ctx context.Context, arg ReassignCertificateRelationSourcesParams) error
+	// Move the relations pointing to a certificate to another hostname (used when renaming)
+	ReassignCertificateRelationTargets(ctx context.Context, arg ReassignCertificateRelationTargetsParams) error
+	// Move the requester of a certificate to another hostname (used when renaming)
+	ReassignCertificateRequester(ctx context.Context, arg ReassignCertificateRequesterParams) error
+	// Move the tags of a certificate to another hostname (used when renaming or
+	// merging; a tag the other hostname already has is left behind)
+	ReassignCertificateTags(ctx context.Context, arg ReassignCertificateTagsParams) error
+	// Move a certificate's chain override to another hostname (used when renaming)
+	ReassignChainOverride(ctx context.Context, arg ReassignChainOverrideParams) error
+	// Move the deployment targets of a certificate to another hostname (used when renaming
+	// or merging; a target the other hostname already has is left behind)
+	ReassignDeploymentTargets(ctx context.Context, arg ReassignDeploymentTargetsParams) error
+	// Move checklist entries from one hostname to another (used when renaming)
+	ReassignRenewalChecklist(ctx context.Context, arg ReassignRenewalChecklistParams) error
+	// Move the agent assignments of a certificate to another hostname (used when renaming
+	// or merging; an agent the other hostname is already assigned to is left behind)
+	ReassignSyncAgentHostnames(ctx context.Context, arg ReassignSyncAgentHostnamesParams) error
+	// Reset the backup freshness counter after a manual backup or export
+	RecordBackup(ctx context.Context, lastBackupAt sql.NullInt64) error
 	// Update history queries
 	// Record an update attempt (success or failure)
 	RecordUpdate(ctx context.Context, arg RecordUpdateParams) error
+	// Remove a tag from a certificate
+	RemoveCertificateTag(ctx context.Context, arg RemoveCertificateTagParams) (int64, error)
+	// Mark a renewal step as not completed
+	ReopenRenewalStep(ctx context.Context, arg ReopenRenewalStepParams) (int64, error)
+	// Swap the active key blob for a re-encrypted copy, only if it is unchanged since
+	// it was read. The key itself is the same, so last_modified is left alone.
+	ReplaceEncryptedPrivateKey(ctx context.Context, arg ReplaceEncryptedPrivateKeyParams) (int64, error)
+	// Same as ReplaceEncryptedPrivateKey for the pending key blob
+	ReplacePendingEncryptedPrivateKey(ctx context.Context, arg ReplacePendingEncryptedPrivateKeyParams) (int64, error)
 	// Restore a complete certificate from backup in a single operation
 	RestoreCertificate(ctx context.Context, arg RestoreCertificateParams) error
+	// Take a certificate out of the trash
+	RestoreDeletedCertificate(ctx context.Context, hostname string) (int64, error)
+	// Restore a completed renewal step from a backup, keeping when and by whom it
+	// was completed
+	RestoreRenewalStep(ctx context.Context, arg RestoreRenewalStepParams) error
+	// Revoke an agent and return its name; its client certificate is refused from
+	// then on
+	RevokeSyncAgent(ctx context.Context, arg RevokeSyncAgentParams) (string, error)
+	// Save the progress of the setup wizard
+	SaveSetupProgress(ctx context.Context, arg SaveSetupProgressParams) error
+	// List the certificates matching an FTS5 query
+	SearchCertificateHostnames(ctx context.Context, certificateSearch string) ([]string, error)
+	// List the certificates whose indexed text contains a LIKE pattern (for terms
+	// too short for the trigram index)
+	SearchCertificateHostnamesLike(ctx context.Context, pattern string) ([]string, error)
 	// Mark setup as complete
 	SetConfigured(ctx context.Context) error
+	// Store the parsed SANs of a certificate in the search index
+	SetSearchSANs(ctx context.Context, arg SetSearchSANsParams) error
+	// Record the address the sync server listens on (empty once stopped)
+	SetSyncServerListenAddress(ctx context.Context, listenAddress string) error
+	// Record when an agent last talked to the sync server
+	TouchSyncAgent(ctx context.Context, arg TouchSyncAgentParams) error
+	// Move a certificate to the trash; its history, tags and keys are kept
+	TrashCertificate(ctx context.Context, arg TrashCertificateParams) (int64, error)
+	// Record that the pending CSR was submitted to the CA
+	UpdateCSRSubmission(ctx context.Context, arg UpdateCSRSubmissionParams) error
+	// Store the recomputed key pair health; a row already holding it is left
+	// untouched, so an unchanged status is not counted as a write
+	UpdateCertificateKeyStatus(ctx context.Context, arg UpdateCertificateKeyStatusParams) (int64, error)
+	// Store the metadata parsed from a certificate's PEMs
+	UpdateCertificateMetadata(ctx context.Context, arg UpdateCertificateMetadataParams) error
 	// Update the note field for a certificate
 	UpdateCertificateNote(ctx context.Context, arg UpdateCertificateNoteParams) error
 	// Mark certificate as read-only
 	UpdateCertificateReadOnly(ctx context.Context, arg UpdateCertificateReadOnlyParams) error
+	// Persist the per-component log level overrides (JSON object)
+	UpdateComponentLogLevels(ctx context.Context, logComponentLevels string) error
 	// Update configuration (preserves is_configured flag)
 	UpdateConfig(ctx context.Context, arg UpdateConfigParams) error
 	// Update encrypted private key fields (for key rotation)
 	UpdateEncryptedKeys(ctx context.Context, arg UpdateEncryptedKeysParams) error
+	// Set the suffix generated hostnames must end with
+	UpdateHostnameSuffix(ctx context.Context, hostnameSuffix string) error
+	// Persist the runtime log level (empty keeps the build default)
+	UpdateLogLevel(ctx context.Context, logLevel string) error
 	// Store or update pending CSR and key (unified for initial generation or renewal)
+	// A new CSR has not been submitted to the CA yet, so its tracking is reset
 	UpdatePendingCSR(ctx context.Context, arg UpdatePendingCSRParams) error
 	// Update the pending note field
 	UpdatePendingNote(ctx context.Context, arg UpdatePendingNoteParams) error
 	// Update the last_used_at timestamp for a security key
 	UpdateSecurityKeyLastUsed(ctx context.Context, id int64) error
+	// Update a subject preset
+	UpdateSubjectPreset(ctx context.Context, arg UpdateSubjectPresetParams) error
+	// Set the issuer URL or certificate used for one certificate's chain
+	UpsertCertificateChainOverride(ctx context.Context, arg UpsertCertificateChainOverrideParams) error
+	// Declare a relation between two certificates, or relabel it
+	UpsertCertificateRelation(ctx context.Context, arg UpsertCertificateRelationParams) error
+	// Record who requested a certificate, replacing an earlier request
+	UpsertCertificateRequester(ctx context.Context, arg UpsertCertificateRequesterParams) error
+	// Record that a certificate is deployed on a target, or update its location
+	UpsertDeploymentTarget(ctx context.Context, arg UpsertDeploymentTargetParams) error
+	// Set the issuer URL or certificate used for every certificate issued by a CA
+	UpsertIssuerChainOverride(ctx context.Context, arg UpsertIssuerChainOverrideParams) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -10,6 +10,31 @@ import (
 	"database/sql"
 )
 
+const countUpdateHistory = `-- name: CountUpdateHistory :one
+SELECT COUNT(*) AS count FROM update_history
+`
+
+// Count update history entries
+func (q *Queries) CountUpdateHistory(ctx context.Context) (int64, error) {
+	row := q.queryRow(ctx, q.countUpdateHistoryStmt, countUpdateHistory)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const deleteUpdateHistoryBefore = `-- name: DeleteUpdateHistoryBefore :execrows
+DELETE FROM update_history WHERE created_at < ?
+`
+
+// Delete update history entries older than a cutoff (database cleanup)
+func (q *Queries) DeleteUpdateHistoryBefore(ctx context.Context, createdAt int64) (int64, error) {
+	result, err := q.exec(ctx, q.deleteUpdateHistoryBeforeStmt, deleteUpdateHistoryBefore, createdAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getUpdateHistory = `-- name: GetUpdateHistory :many
 SELECT id, from_version, to_version, status, error_message, created_at
 FROM update_history
@@ -16,9 +16,22 @@ const (
 	StatusExpired  CertificateStatus = "expired"
 )
 
+// DefaultExpiringThresholdDays is the "expiring soon" window used when no
+// configured threshold is available (e.g. for certificates read from a backup)
+const DefaultExpiringThresholdDays = 30
+
 // ComputeStatus determines the certificate status based on its data
-// Status is computed dynamically, not stored in the database
-func ComputeStatus(cert *sqlc.Certificate) CertificateStatus {
+// Status is computed dynamically, not stored in the database. A certificate is
+// "expiring" once it is within expiringThresholdDays of its expiration date.
+// All comparisons are made in UTC so the result does not depend on the local
+// time zone.
+func ComputeStatus(cert *sqlc.Certificate, expiringThresholdDays int) CertificateStatus {
+	return ComputeStatusAt(cert, expiringThresholdDays, time.Now())
+}
+
+// ComputeStatusAt is ComputeStatus evaluated at the given instant instead of
+// the current time.
+func ComputeStatusAt(cert *sqlc.Certificate, expiringThresholdDays int, at time.Time) CertificateStatus {
 	// If certificate PEM exists, compute status based on expiration
 	if cert.CertificatePem.Valid && cert.CertificatePem.String != "" {
 		if !cert.ExpiresAt.Valid {
@@ -26,17 +39,17 @@ func ComputeStatus(cert *sqlc.Certificate) CertificateStatus {
 			return StatusActive
 		}
 
-		expiresTime := time.Unix(cert.ExpiresAt.Int64, 0)
-		now := time.Now()
+		expiresTime := time.Unix(cert.ExpiresAt.Int64, 0).UTC()
+		now := at.UTC()
 
 		// Check if expired
 		if now.After(expiresTime) {
 			return StatusExpired
 		}
 
-		// Check if expiring soon (within 30 days)
-		daysUntilExpiration := int(time.Until(expiresTime).Hours() / 24)
-		if daysUntilExpiration <= 30 {
+		// Check if expiring soon (within the configured threshold)
+		daysUntilExpiration := int(expiresTime.Sub(now).Hours() / 24)
+		if daysUntilExpiration <= expiringThresholdDays {
 			return StatusExpiring
 		}
 
@@ -60,8 +73,8 @@ func DaysUntilExpiration(expiresAt int64) int {
 		return 0
 	}
 
-	expiresTime := time.Unix(expiresAt, 0)
-	duration := time.Until(expiresTime)
+	expiresTime := time.Unix(expiresAt, 0).UTC()
+	duration := expiresTime.Sub(time.Now().UTC())
 	days := int(duration.Hours() / 24)
 
 	if days < 0 {
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+)
+
+func certExpiringIn(d time.Duration) *sqlc.Certificate {
+	return &sqlc.Certificate{
+		CertificatePem: sql.NullString{String: "pem", Valid: true},
+		ExpiresAt:      sql.NullInt64{Int64: time.Now().Add(d).Unix(), Valid: true},
+	}
+}
+
+func TestComputeStatus_Threshold(t *testing.T) {
+	cert := certExpiringIn(45 * 24 * time.Hour)
+
+	if got := ComputeStatus(cert, DefaultExpiringThresholdDays); got != StatusActive {
+		t.Errorf("45 days out with default threshold: got %s, want %s", got, StatusActive)
+	}
+	if got := ComputeStatus(cert, 60); got != StatusExpiring {
+		t.Errorf("45 days out with 60-day threshold: got %s, want %s", got, StatusExpiring)
+	}
+	if got := ComputeStatus(certExpiringIn(-time.Hour), 60); got != StatusExpired {
+		t.Errorf("past expiry: got %s, want %s", got, StatusExpired)
+	}
+	if got := ComputeStatus(&sqlc.Certificate{PendingCsrPem: sql.NullString{String: "csr", Valid: true}}, 60); got != StatusPending {
+		t.Errorf("CSR only: got %s, want %s", got, StatusPending)
+	}
+}
+
+func TestComputeStatusAt_UsesGivenInstant(t *testing.T) {
+	expires := time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)
+	cert := &sqlc.Certificate{
+		CertificatePem: sql.NullString{String: "pem", Valid: true},
+		ExpiresAt:      sql.NullInt64{Int64: expires.Unix(), Valid: true},
+	}
+
+	cases := []struct {
+		at   time.Time
+		want CertificateStatus
+	}{
+		{expires.AddDate(0, -3, 0), StatusActive},
+		{expires.AddDate(0, 0, -10), StatusExpiring},
+		{expires.Add(time.Hour), StatusExpired},
+	}
+	for _, c := range cases {
+		if got := ComputeStatusAt(cert, DefaultExpiringThresholdDays, c.at); got != c.want {
+			t.Errorf("at %s: got %s, want %s", c.at.Format(time.DateOnly), got, c.want)
+		}
+	}
+}
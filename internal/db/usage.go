@@ -0,0 +1,85 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// TableUsage is the space a table takes in the database file, its indexes
+// included
+type TableUsage struct {
+	Table string
+	Bytes int64
+}
+
+// Usage describes the size of the database file and what takes up the space
+type Usage struct {
+	TotalBytes int64        // page_count * page_size, excluding the WAL file
+	FreeBytes  int64        // pages on the freelist, reclaimed by Compact
+	Tables     []TableUsage // largest first; nil when dbstat is unavailable
+}
+
+// Usage measures the database. Per-table sizes come from the dbstat virtual
+// table; when the SQLite build lacks it only the totals are reported.
+func (d *Database) Usage(ctx context.Context) (*Usage, error) {
+	var pageSize, pageCount, freePages int64
+	for pragma, dest := range map[string]*int64{
+		"page_size":      &pageSize,
+		"page_count":     &pageCount,
+		"freelist_count": &freePages,
+	} {
+		if err := d.db.QueryRowContext(ctx, "PRAGMA "+pragma).Scan(dest); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", pragma, err)
+		}
+	}
+
+	usage := &Usage{
+		TotalBytes: pageSize * pageCount,
+		FreeBytes:  pageSize * freePages,
+	}
+
+	// Indexes are attributed to the table they belong to
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT COALESCE(m.tbl_name, s.name), s.pgsize
+		FROM dbstat AS s LEFT JOIN sqlite_schema AS m ON m.name = s.name
+		WHERE s.aggregate = TRUE`)
+	if err != nil {
+		return usage, nil // dbstat is optional in SQLite builds
+	}
+	defer rows.Close()
+
+	byTable := make(map[string]int64)
+	for rows.Next() {
+		var table string
+		var bytes int64
+		if err := rows.Scan(&table, &bytes); err != nil {
+			return nil, fmt.Errorf("failed to read table usage: %w", err)
+		}
+		byTable[table] += bytes
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read table usage: %w", err)
+	}
+
+	for table, bytes := range byTable {
+		usage.Tables = append(usage.Tables, TableUsage{Table: table, Bytes: bytes})
+	}
+	sort.Slice(usage.Tables, func(i, j int) bool {
+		if usage.Tables[i].Bytes != usage.Tables[j].Bytes {
+			return usage.Tables[i].Bytes > usage.Tables[j].Bytes
+		}
+		return usage.Tables[i].Table < usage.Tables[j].Table
+	})
+	return usage, nil
+}
+
+// Compact rebuilds the database file with VACUUM so deleted rows stop taking
+// space on disk. VACUUM cannot run inside a transaction; with the single
+// connection pool it waits for any running one to finish.
+func (d *Database) Compact(ctx context.Context) error {
+	if _, err := d.db.ExecContext(ctx, "VACUUM"); err != nil {
+		return fmt.Errorf("failed to compact database: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package hostnames
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/idna"
+)
+
+// profile converts hostnames to their ASCII (punycode) form. STD3 rules are
+// relaxed so wildcard labels ("*.example.com") and underscores used by some
+// internal names are accepted.
+var profile = idna.New(
+	idna.MapForLookup(),
+	idna.Transitional(false),
+	idna.StrictDomainName(false),
+)
+
+// Normalize returns the canonical form of a hostname: surrounding whitespace and
+// the trailing root dot removed, lowercased, and internationalized labels encoded
+// as punycode. Every entry point that stores a hostname must go through Normalize
+// so near-duplicates ("Web.example.com", "web.example.com ") cannot coexist.
+func Normalize(name string) (string, error) {
+	name = strings.TrimSuffix(strings.TrimSpace(name), ".")
+	if name == "" {
+		return "", fmt.Errorf("hostname cannot be empty")
+	}
+
+	ascii, err := profile.ToASCII(name)
+	if err != nil {
+		return "", fmt.Errorf("invalid hostname %q: %w", name, err)
+	}
+
+	return strings.ToLower(ascii), nil
+}
+
+// NormalizeSuffix returns the canonical form of a hostname suffix such as
+// ".example.com", keeping its leading dot. Internationalized suffixes are encoded
+// as punycode so they compare correctly against normalized hostnames.
+func NormalizeSuffix(suffix string) (string, error) {
+	suffix = strings.TrimSpace(suffix)
+	if !strings.HasPrefix(suffix, ".") {
+		return "", fmt.Errorf("hostname suffix must start with a dot")
+	}
+
+	normalized, err := Normalize(strings.TrimPrefix(suffix, "."))
+	if err != nil {
+		return "", err
+	}
+
+	return "." + normalized, nil
+}
+
+// ToUnicode returns the display form of a normalized hostname, decoding punycode
+// labels ("xn--mnchen-3ya.de" becomes "münchen.de"). Names that cannot be decoded
+// are returned unchanged.
+func ToUnicode(name string) string {
+	unicode, err := profile.ToUnicode(name)
+	if err != nil {
+		return name
+	}
+	return unicode
+}
@@ -0,0 +1,78 @@
+package hostnames
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"web.example.com", "web.example.com"},
+		{"  Web.Example.COM  ", "web.example.com"},
+		{"web.example.com.", "web.example.com"},
+		{"*.Example.com", "*.example.com"},
+		{"münchen.example.com", "xn--mnchen-3ya.example.com"},
+		{"MÜNCHEN.example.com", "xn--mnchen-3ya.example.com"},
+		{"xn--mnchen-3ya.example.com", "xn--mnchen-3ya.example.com"},
+	}
+
+	for _, tc := range cases {
+		got, err := Normalize(tc.in)
+		if err != nil {
+			t.Fatalf("Normalize(%q) error: %v", tc.in, err)
+		}
+		if got != tc.want {
+			t.Fatalf("Normalize(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestNormalize_RejectsEmpty(t *testing.T) {
+	for _, in := range []string{"", "   ", "."} {
+		if _, err := Normalize(in); err == nil {
+			t.Fatalf("expected error for %q", in)
+		}
+	}
+}
+
+func TestNormalizeSuffix(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{".example.com", ".example.com"},
+		{" .Example.COM ", ".example.com"},
+		{".münchen.de", ".xn--mnchen-3ya.de"},
+	}
+
+	for _, tc := range cases {
+		got, err := NormalizeSuffix(tc.in)
+		if err != nil {
+			t.Fatalf("NormalizeSuffix(%q) error: %v", tc.in, err)
+		}
+		if got != tc.want {
+			t.Fatalf("NormalizeSuffix(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+
+	if _, err := NormalizeSuffix("example.com"); err == nil {
+		t.Fatal("expected error for suffix without leading dot")
+	}
+}
+
+func TestToUnicode(t *testing.T) {
+	cases := []struct {
+		in   string
+		want string
+	}{
+		{"web.example.com", "web.example.com"},
+		{"xn--mnchen-3ya.example.com", "münchen.example.com"},
+		{"*.xn--mnchen-3ya.de", "*.münchen.de"},
+	}
+
+	for _, tc := range cases {
+		if got := ToUnicode(tc.in); got != tc.want {
+			t.Fatalf("ToUnicode(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package hostnames
+
+import (
+	"regexp"
+	"strings"
+)
+
+// suffixLabel is a label a proposed suffix may contain: wildcard and
+// underscore labels are left out so the proposal passes config validation
+var suffixLabel = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// InferSuffix returns the domain suffix shared by the most hostnames, such as
+// ".example.com", or "" when none has at least two labels. Among suffixes
+// shared by as many hostnames, the longest wins, so web.corp.example.com and
+// db.corp.example.com give ".corp.example.com" rather than ".example.com".
+// Hostnames are normalized first; invalid ones are ignored.
+func InferSuffix(names []string) string {
+	counts := make(map[string]int)
+	seen := make(map[string]bool)
+	for _, name := range names {
+		normalized, err := Normalize(name)
+		if err != nil || seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+
+		// Every parent domain of at least two labels, from the TLD up to the
+		// first label a suffix cannot hold
+		labels := strings.Split(normalized, ".")
+		for i := len(labels) - 1; i >= 1; i-- {
+			if !suffixLabel.MatchString(labels[i]) {
+				break
+			}
+			if i <= len(labels)-2 {
+				counts["."+strings.Join(labels[i:], ".")]++
+			}
+		}
+	}
+
+	best, bestCount := "", 0
+	for suffix, count := range counts {
+		if count < bestCount {
+			continue
+		}
+		// Ties go to the longer suffix, then alphabetically so the result
+		// does not depend on map order
+		if count == bestCount {
+			if longer, shorter := strings.Count(suffix, "."), strings.Count(best, "."); longer < shorter ||
+				(longer == shorter && suffix > best) {
+				continue
+			}
+		}
+		best, bestCount = suffix, count
+	}
+	return best
+}
+
+// SuggestSuffix returns the suffix inferred from names when it fits them
+// better than current: current is empty, invalid, or ends fewer of them.
+// It returns "" when current should be kept.
+func SuggestSuffix(current string, names []string) string {
+	inferred := InferSuffix(names)
+	if inferred == "" {
+		return ""
+	}
+	normalized, err := NormalizeSuffix(current)
+	if err != nil {
+		return inferred
+	}
+	if normalized == inferred || countWithSuffix(names, normalized) >= countWithSuffix(names, inferred) {
+		return ""
+	}
+	return inferred
+}
+
+// countWithSuffix returns how many of names end with suffix, a normalized
+// suffix with its leading dot
+func countWithSuffix(names []string, suffix string) int {
+	n := 0
+	for _, name := range names {
+		if normalized, err := Normalize(name); err == nil && strings.HasSuffix(normalized, suffix) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,50 @@
+package hostnames
+
+import "testing"
+
+func TestInferSuffix(t *testing.T) {
+	cases := []struct {
+		names []string
+		want  string
+	}{
+		{nil, ""},
+		{[]string{"localhost"}, ""},
+		{[]string{"example.com"}, ""},
+		{[]string{"web.example.com"}, ".example.com"},
+		{[]string{"web.corp.example.com", "DB.corp.example.com."}, ".corp.example.com"},
+		{[]string{"web.corp.example.com", "db.corp.example.com", "mail.example.com"}, ".example.com"},
+		{[]string{"a.example.com", "b.example.com", "c.example.org"}, ".example.com"},
+		{[]string{"*.apps.example.com", "_dmarc.example.com"}, ".example.com"},
+		{[]string{"web.münchen.de", "db.münchen.de"}, ".xn--mnchen-3ya.de"},
+	}
+
+	for _, tc := range cases {
+		if got := InferSuffix(tc.names); got != tc.want {
+			t.Errorf("InferSuffix(%q) = %q, want %q", tc.names, got, tc.want)
+		}
+	}
+}
+
+func TestSuggestSuffix(t *testing.T) {
+	names := []string{"web.corp.example.com", "db.corp.example.com", "mail.example.com"}
+	cases := []struct {
+		current string
+		want    string
+	}{
+		{"", ".example.com"},
+		{"not a suffix", ".example.com"},
+		{".old.example.net", ".example.com"},
+		{".corp.example.com", ".example.com"},
+		{".example.com", ""},
+		{".Example.COM", ""},
+	}
+
+	for _, tc := range cases {
+		if got := SuggestSuffix(tc.current, names); got != tc.want {
+			t.Errorf("SuggestSuffix(%q) = %q, want %q", tc.current, got, tc.want)
+		}
+	}
+	if got := SuggestSuffix("", nil); got != "" {
+		t.Errorf("SuggestSuffix without hostnames = %q, want none", got)
+	}
+}
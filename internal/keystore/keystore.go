@@ -0,0 +1,111 @@
+// Package keystore protects a small secret with the current user's operating
+// system credentials, for passwordless unlock on trusted machines: DPAPI on
+// Windows, the freedesktop Secret Service (GNOME Keyring, KWallet, reached
+// over D-Bus like libsecret) on Linux. Other platforms get a stub that
+// reports unavailable.
+//
+// A store hands back an opaque reference on Save (the DPAPI blob, or the id of
+// the Secret Service item) that the caller keeps and passes to Load and Delete.
+package keystore
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sync"
+)
+
+// ErrUnsupported is returned on platforms without an OS-native keystore.
+var ErrUnsupported = errors.New("no OS-native keystore is available on this platform")
+
+// ErrNotFound is returned by Load when the secret is no longer in the store
+// (e.g. a keyring reset, or a backup restored on another machine or account).
+var ErrNotFound = errors.New("secret not found in the OS keystore")
+
+// Store keeps secrets protected by the OS for the current user.
+type Store interface {
+	// Backend names the mechanism ("dpapi", "secret_service"), recorded with
+	// the secret so a reference is only read back by the store that made it.
+	Backend() string
+	// Available reports whether the store can be used right now (e.g. a
+	// Secret Service is running on the session bus).
+	Available() bool
+	// Save protects secret and returns the reference to load it with. label
+	// is shown to the user where the OS lists stored secrets.
+	Save(label string, secret []byte) (ref []byte, err error)
+	// Load returns the secret saved under ref. The caller zeroes it.
+	Load(ref []byte) ([]byte, error)
+	// Delete removes the secret saved under ref; deleting a missing secret
+	// is not an error.
+	Delete(ref []byte) error
+}
+
+// Native returns the OS-native store of this platform. Its Available reports
+// false where there is none.
+func Native() Store {
+	return native()
+}
+
+// newItemID returns a random identifier for a stored secret
+func newItemID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Memory is an in-process Store for tests; secrets do not survive the process.
+type Memory struct {
+	mu    sync.Mutex
+	items map[string][]byte
+}
+
+// NewMemory returns an empty in-memory store
+func NewMemory() *Memory {
+	return &Memory{items: map[string][]byte{}}
+}
+
+// Backend implements Store
+func (m *Memory) Backend() string { return "memory" }
+
+// Available implements Store
+func (m *Memory) Available() bool { return true }
+
+// Save implements Store
+func (m *Memory) Save(label string, secret []byte) ([]byte, error) {
+	id, err := newItemID()
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.items[id] = append([]byte(nil), secret...)
+	return []byte(id), nil
+}
+
+// Load implements Store
+func (m *Memory) Load(ref []byte) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	secret, ok := m.items[string(ref)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return append([]byte(nil), secret...), nil
+}
+
+// Delete implements Store
+func (m *Memory) Delete(ref []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.items, string(ref))
+	return nil
+}
+
+// Len returns the number of secrets held
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.items)
+}
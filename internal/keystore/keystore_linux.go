@@ -0,0 +1,255 @@
+//go:build linux
+
+package keystore
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Secret Service (org.freedesktop.secrets) D-Bus names
+const (
+	secretsService    = "org.freedesktop.secrets"
+	secretsPath       = dbus.ObjectPath("/org/freedesktop/secrets")
+	defaultCollection = dbus.ObjectPath("/org/freedesktop/secrets/aliases/default")
+
+	serviceInterface    = "org.freedesktop.Secret.Service"
+	collectionInterface = "org.freedesktop.Secret.Collection"
+	itemInterface       = "org.freedesktop.Secret.Item"
+	promptInterface     = "org.freedesktop.Secret.Prompt"
+)
+
+// itemApplication tags every item we store, so SearchItems only sees ours
+const itemApplication = "paddockcontrol-desktop"
+
+// promptTimeout bounds how long we wait for the user to answer a keyring
+// unlock prompt
+const promptTimeout = 2 * time.Minute
+
+// secret is the Secret Service (oayays) secret structure
+type secret struct {
+	Session     dbus.ObjectPath
+	Parameters  []byte
+	Value       []byte
+	ContentType string
+}
+
+// secretServiceStore keeps secrets in the default collection of the Secret
+// Service. The reference is the random id stored in the item's attributes.
+type secretServiceStore struct{}
+
+func native() Store { return secretServiceStore{} }
+
+func (secretServiceStore) Backend() string { return "secret_service" }
+
+func (secretServiceStore) Available() bool {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return false
+	}
+	var hasOwner bool
+	if err := conn.BusObject().Call("org.freedesktop.DBus.NameHasOwner", 0, secretsService).Store(&hasOwner); err == nil && hasOwner {
+		return true
+	}
+	// The service may be D-Bus activatable without running yet
+	var activatable []string
+	if err := conn.BusObject().Call("org.freedesktop.DBus.ListActivatableNames", 0).Store(&activatable); err != nil {
+		return false
+	}
+	for _, name := range activatable {
+		if name == secretsService {
+			return true
+		}
+	}
+	return false
+}
+
+func (secretServiceStore) Save(label string, value []byte) ([]byte, error) {
+	s, err := openSecretSession()
+	if err != nil {
+		return nil, err
+	}
+	defer s.close()
+
+	if err := s.unlock([]dbus.ObjectPath{defaultCollection}); err != nil {
+		return nil, err
+	}
+
+	id, err := newItemID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate item id: %w", err)
+	}
+	props := map[string]dbus.Variant{
+		itemInterface + ".Label":      dbus.MakeVariant(label),
+		itemInterface + ".Attributes": dbus.MakeVariant(itemAttributes(id)),
+	}
+	sec := secret{Session: s.session, Value: value, ContentType: "application/octet-stream"}
+
+	var item, prompt dbus.ObjectPath
+	if err := s.conn.Object(secretsService, defaultCollection).
+		Call(collectionInterface+".CreateItem", 0, props, sec, true).
+		Store(&item, &prompt); err != nil {
+		return nil, fmt.Errorf("failed to store secret: %w", err)
+	}
+	if _, err := s.prompt(prompt); err != nil {
+		return nil, err
+	}
+	return []byte(id), nil
+}
+
+func (secretServiceStore) Load(ref []byte) ([]byte, error) {
+	s, err := openSecretSession()
+	if err != nil {
+		return nil, err
+	}
+	defer s.close()
+
+	item, err := s.find(string(ref))
+	if err != nil {
+		return nil, err
+	}
+
+	var sec secret
+	if err := s.conn.Object(secretsService, item).
+		Call(itemInterface+".GetSecret", 0, s.session).
+		Store(&sec); err != nil {
+		return nil, fmt.Errorf("failed to read secret: %w", err)
+	}
+	return sec.Value, nil
+}
+
+func (secretServiceStore) Delete(ref []byte) error {
+	s, err := openSecretSession()
+	if err != nil {
+		return err
+	}
+	defer s.close()
+
+	item, err := s.find(string(ref))
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var prompt dbus.ObjectPath
+	if err := s.conn.Object(secretsService, item).Call(itemInterface+".Delete", 0).Store(&prompt); err != nil {
+		return fmt.Errorf("failed to delete secret: %w", err)
+	}
+	_, err = s.prompt(prompt)
+	return err
+}
+
+func itemAttributes(id string) map[string]string {
+	return map[string]string{"application": itemApplication, "id": id}
+}
+
+// secretSession is an open "plain" transfer session; the secret travels
+// unencrypted over the private session bus, as with libsecret's default
+type secretSession struct {
+	conn    *dbus.Conn
+	session dbus.ObjectPath
+}
+
+func openSecretSession() (*secretSession, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the session bus: %w", err)
+	}
+	var output dbus.Variant
+	var session dbus.ObjectPath
+	if err := conn.Object(secretsService, secretsPath).
+		Call(serviceInterface+".OpenSession", 0, "plain", dbus.MakeVariant("")).
+		Store(&output, &session); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+	}
+	return &secretSession{conn: conn, session: session}, nil
+}
+
+func (s *secretSession) close() {
+	s.conn.Object(secretsService, s.session).Call("org.freedesktop.Secret.Session.Close", 0)
+}
+
+// find returns the item holding id, unlocking it if needed
+func (s *secretSession) find(id string) (dbus.ObjectPath, error) {
+	var unlocked, locked []dbus.ObjectPath
+	if err := s.conn.Object(secretsService, secretsPath).
+		Call(serviceInterface+".SearchItems", 0, itemAttributes(id)).
+		Store(&unlocked, &locked); err != nil {
+		return "", fmt.Errorf("failed to search secrets: %w", err)
+	}
+	if len(unlocked) > 0 {
+		return unlocked[0], nil
+	}
+	if len(locked) == 0 {
+		return "", ErrNotFound
+	}
+	if err := s.unlock(locked[:1]); err != nil {
+		return "", err
+	}
+	return locked[0], nil
+}
+
+// unlock unlocks collections or items, showing the keyring's prompt when the
+// service asks for one
+func (s *secretSession) unlock(objects []dbus.ObjectPath) error {
+	var unlocked []dbus.ObjectPath
+	var prompt dbus.ObjectPath
+	if err := s.conn.Object(secretsService, secretsPath).
+		Call(serviceInterface+".Unlock", 0, objects).
+		Store(&unlocked, &prompt); err != nil {
+		return fmt.Errorf("failed to unlock the keyring: %w", err)
+	}
+	dismissed, err := s.prompt(prompt)
+	if err != nil {
+		return err
+	}
+	if dismissed {
+		return fmt.Errorf("the keyring unlock was dismissed")
+	}
+	return nil
+}
+
+// prompt shows a Secret Service prompt ("/" means none is needed) and waits
+// for it to complete. It reports whether the user dismissed it.
+func (s *secretSession) prompt(path dbus.ObjectPath) (bool, error) {
+	if path == "" || path == "/" {
+		return false, nil
+	}
+
+	match := []dbus.MatchOption{
+		dbus.WithMatchObjectPath(path),
+		dbus.WithMatchInterface(promptInterface),
+		dbus.WithMatchMember("Completed"),
+	}
+	if err := s.conn.AddMatchSignal(match...); err != nil {
+		return false, fmt.Errorf("failed to watch the keyring prompt: %w", err)
+	}
+	defer s.conn.RemoveMatchSignal(match...)
+
+	signals := make(chan *dbus.Signal, 1)
+	s.conn.Signal(signals)
+	defer s.conn.RemoveSignal(signals)
+
+	if err := s.conn.Object(secretsService, path).Call(promptInterface+".Prompt", 0, "").Err; err != nil {
+		return false, fmt.Errorf("failed to show the keyring prompt: %w", err)
+	}
+
+	timeout := time.After(promptTimeout)
+	for {
+		select {
+		case sig := <-signals:
+			if sig.Path != path || sig.Name != promptInterface+".Completed" || len(sig.Body) == 0 {
+				continue
+			}
+			dismissed, _ := sig.Body[0].(bool)
+			return dismissed, nil
+		case <-timeout:
+			return false, fmt.Errorf("timed out waiting for the keyring prompt")
+		}
+	}
+}
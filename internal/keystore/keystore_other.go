@@ -0,0 +1,19 @@
+//go:build !windows && !linux
+
+package keystore
+
+type unsupportedStore struct{}
+
+func native() Store { return unsupportedStore{} }
+
+func (unsupportedStore) Backend() string { return "none" }
+
+func (unsupportedStore) Available() bool { return false }
+
+func (unsupportedStore) Save(label string, secret []byte) ([]byte, error) {
+	return nil, ErrUnsupported
+}
+
+func (unsupportedStore) Load(ref []byte) ([]byte, error) { return nil, ErrUnsupported }
+
+func (unsupportedStore) Delete(ref []byte) error { return ErrUnsupported }
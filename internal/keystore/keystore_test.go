@@ -0,0 +1,33 @@
+package keystore
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestMemory_RoundTrip(t *testing.T) {
+	m := NewMemory()
+
+	ref, err := m.Save("test", []byte("wrapping-key"))
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	got, err := m.Load(ref)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if !bytes.Equal(got, []byte("wrapping-key")) {
+		t.Errorf("Load = %q, want %q", got, "wrapping-key")
+	}
+
+	if err := m.Delete(ref); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := m.Load(ref); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after Delete, got %v", err)
+	}
+	if err := m.Delete(ref); err != nil {
+		t.Errorf("deleting a missing secret should not fail: %v", err)
+	}
+}
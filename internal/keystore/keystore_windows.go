@@ -0,0 +1,67 @@
+//go:build windows
+
+package keystore
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// dpapiEntropy is mixed into every DPAPI blob so that other programs running
+// as the same user cannot decrypt it by simply calling CryptUnprotectData
+var dpapiEntropy = []byte("paddockcontrol/os-native-unlock/v1")
+
+// dpapiStore protects secrets with DPAPI for the current user. The
+// reference is the encrypted blob itself; nothing is stored by Windows.
+type dpapiStore struct{}
+
+func native() Store { return dpapiStore{} }
+
+func (dpapiStore) Backend() string { return "dpapi" }
+
+func (dpapiStore) Available() bool { return true }
+
+func (dpapiStore) Save(label string, secret []byte) ([]byte, error) {
+	name, err := windows.UTF16PtrFromString(label)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label: %w", err)
+	}
+	var out windows.DataBlob
+	if err := windows.CryptProtectData(blobOf(secret), name, blobOf(dpapiEntropy), 0, nil, windows.CRYPTPROTECT_UI_FORBIDDEN, &out); err != nil {
+		return nil, fmt.Errorf("CryptProtectData failed: %w", err)
+	}
+	return takeBlob(&out), nil
+}
+
+func (dpapiStore) Load(ref []byte) ([]byte, error) {
+	if len(ref) == 0 {
+		return nil, ErrNotFound
+	}
+	var out windows.DataBlob
+	if err := windows.CryptUnprotectData(blobOf(ref), nil, blobOf(dpapiEntropy), 0, nil, windows.CRYPTPROTECT_UI_FORBIDDEN, &out); err != nil {
+		// A blob from another user or machine (e.g. a restored backup)
+		return nil, fmt.Errorf("%w: %v", ErrNotFound, err)
+	}
+	return takeBlob(&out), nil
+}
+
+// Delete is a no-op: the blob is the only copy and the caller drops it.
+func (dpapiStore) Delete(ref []byte) error { return nil }
+
+func blobOf(b []byte) *windows.DataBlob {
+	if len(b) == 0 {
+		return &windows.DataBlob{}
+	}
+	return &windows.DataBlob{Size: uint32(len(b)), Data: &b[0]}
+}
+
+// takeBlob copies a DPAPI output blob into Go memory, zeroes it and frees it
+func takeBlob(blob *windows.DataBlob) []byte {
+	defer windows.LocalFree(windows.Handle(unsafe.Pointer(blob.Data)))
+	src := unsafe.Slice(blob.Data, blob.Size)
+	out := append([]byte(nil), src...)
+	clear(src)
+	return out
+}
@@ -40,6 +40,7 @@ func WithOperation(ctx context.Context, operation string) (context.Context, *slo
 // WithComponent returns a logger with a component name attached.
 // Use this for logging within a specific package/service.
 func WithComponent(component string) *slog.Logger {
+	seenComponents.Store(component, struct{}{})
 	return Default().With(slog.String("component", component))
 }
 
@@ -0,0 +1,178 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	// level is the minimum level logged by components without an override
+	level = new(slog.LevelVar)
+
+	// defaultLevel is the build default set by Initialize (Info in production,
+	// Debug in development)
+	defaultLevel = slog.LevelInfo
+
+	// componentLevels overrides the level per component
+	componentMu     sync.RWMutex
+	componentLevels = make(map[string]slog.Level)
+
+	// seenComponents records every component a logger was created for, so the
+	// UI can offer them for overrides
+	seenComponents sync.Map
+)
+
+// levelHandler filters records by the runtime log level, or by the level
+// overridden for the component the logger was created with. The handlers it
+// wraps accept every level.
+type levelHandler struct {
+	slog.Handler
+	component string
+}
+
+func (h *levelHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return l >= effectiveLevel(h.component) && h.Handler.Enabled(ctx, l)
+}
+
+func (h *levelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	component := h.component
+	for _, attr := range attrs {
+		if attr.Key == "component" {
+			component = attr.Value.String()
+		}
+	}
+	return &levelHandler{Handler: h.Handler.WithAttrs(attrs), component: component}
+}
+
+func (h *levelHandler) WithGroup(name string) slog.Handler {
+	return &levelHandler{Handler: h.Handler.WithGroup(name), component: h.component}
+}
+
+// effectiveLevel returns the minimum level logged for a component
+func effectiveLevel(component string) slog.Level {
+	if component != "" {
+		componentMu.RLock()
+		l, ok := componentLevels[component]
+		componentMu.RUnlock()
+		if ok {
+			return l
+		}
+	}
+	return level.Level()
+}
+
+// ParseLevel parses a level name: debug, info, warn (or warning) or error,
+// case-insensitive.
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q (expected debug, info, warn or error)", name)
+	}
+}
+
+// LevelName returns the lowercase name ParseLevel accepts for a level.
+func LevelName(l slog.Level) string {
+	return strings.ToLower(l.String())
+}
+
+// Level returns the minimum level logged by components without an override.
+func Level() slog.Level {
+	return level.Level()
+}
+
+// DefaultLevel returns the build default level.
+func DefaultLevel() slog.Level {
+	return defaultLevel
+}
+
+// SetLevel changes the minimum level logged by components without an
+// override. It takes effect immediately, for existing loggers too.
+func SetLevel(l slog.Level) {
+	level.Set(l)
+}
+
+// ResetLevel restores the build default level.
+func ResetLevel() {
+	level.Set(defaultLevel)
+}
+
+// SetComponentLevel overrides the minimum level logged by one component.
+func SetComponentLevel(component string, l slog.Level) {
+	componentMu.Lock()
+	defer componentMu.Unlock()
+	componentLevels[component] = l
+}
+
+// ClearComponentLevel removes a component's override.
+func ClearComponentLevel(component string) {
+	componentMu.Lock()
+	defer componentMu.Unlock()
+	delete(componentLevels, component)
+}
+
+// SetComponentLevels replaces every component override.
+func SetComponentLevels(levels map[string]slog.Level) {
+	componentMu.Lock()
+	defer componentMu.Unlock()
+	componentLevels = make(map[string]slog.Level, len(levels))
+	for component, l := range levels {
+		componentLevels[component] = l
+	}
+}
+
+// ComponentLevels returns a copy of the component overrides.
+func ComponentLevels() map[string]slog.Level {
+	componentMu.RLock()
+	defer componentMu.RUnlock()
+	levels := make(map[string]slog.Level, len(componentLevels))
+	for component, l := range componentLevels {
+		levels[component] = l
+	}
+	return levels
+}
+
+// Components returns the components loggers were created for since startup,
+// sorted.
+func Components() []string {
+	var components []string
+	seenComponents.Range(func(key, _ any) bool {
+		components = append(components, key.(string))
+		return true
+	})
+	sort.Strings(components)
+	return components
+}
+
+// LevelSettings describes the runtime log levels for display in the UI
+type LevelSettings struct {
+	Level           string            `json:"level"`
+	DefaultLevel    string            `json:"default_level"`
+	Components      map[string]string `json:"components"`       // overrides by component
+	KnownComponents []string          `json:"known_components"` // components seen since startup
+}
+
+// CurrentLevelSettings returns the runtime log levels.
+func CurrentLevelSettings() *LevelSettings {
+	settings := &LevelSettings{
+		Level:           LevelName(Level()),
+		DefaultLevel:    LevelName(DefaultLevel()),
+		Components:      make(map[string]string),
+		KnownComponents: Components(),
+	}
+	for component, l := range ComponentLevels() {
+		settings.Components[component] = LevelName(l)
+	}
+	return settings
+}
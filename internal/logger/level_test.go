@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+// captureLogs routes the default logger through a level handler writing to a
+// buffer, and restores the previous state when the test ends.
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	previous := defaultLogger
+	var buf bytes.Buffer
+	defaultLogger = slog.New(&levelHandler{Handler: slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})})
+	ResetLevel()
+	SetComponentLevels(nil)
+	t.Cleanup(func() {
+		defaultLogger = previous
+		ResetLevel()
+		SetComponentLevels(nil)
+	})
+	return &buf
+}
+
+func TestSetLevel_AppliesToExistingLoggers(t *testing.T) {
+	buf := captureLogs(t)
+	log := WithComponent("certificate")
+
+	log.Debug("hidden")
+	SetLevel(slog.LevelDebug)
+	log.Debug("shown")
+
+	if strings.Contains(buf.String(), "hidden") || !strings.Contains(buf.String(), "shown") {
+		t.Errorf("unexpected output: %s", buf.String())
+	}
+}
+
+func TestSetComponentLevel_OverridesGlobalLevel(t *testing.T) {
+	buf := captureLogs(t)
+	SetComponentLevel("certificate", slog.LevelDebug)
+	SetComponentLevel("database", slog.LevelError)
+
+	WithComponent("certificate").Debug("certificate debug")
+	WithComponent("app").Debug("app debug")
+	WithComponent("database").Warn("database warning")
+	WithComponent("app").Info("app info")
+
+	out := buf.String()
+	if !strings.Contains(out, "certificate debug") || !strings.Contains(out, "app info") {
+		t.Errorf("expected the override and the global level to apply: %s", out)
+	}
+	if strings.Contains(out, "app debug") || strings.Contains(out, "database warning") {
+		t.Errorf("unexpected records: %s", out)
+	}
+
+	ClearComponentLevel("certificate")
+	WithComponent("certificate").Debug("after clear")
+	if strings.Contains(buf.String(), "after clear") {
+		t.Error("expected the component to follow the global level after clearing")
+	}
+
+	settings := CurrentLevelSettings()
+	if settings.Level != "info" || settings.Components["database"] != "error" || len(settings.Components) != 1 {
+		t.Errorf("unexpected settings: %+v", settings)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for name, want := range map[string]slog.Level{
+		"debug": slog.LevelDebug, " INFO ": slog.LevelInfo, "warning": slog.LevelWarn, "error": slog.LevelError,
+	} {
+		if got, err := ParseLevel(name); err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v; want %v", name, got, err, want)
+		}
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("expected an unknown level to be rejected")
+	}
+}
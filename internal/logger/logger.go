@@ -7,8 +7,6 @@ import (
 	"log/slog"
 	"os"
 	"path/filepath"
-
-	"gopkg.in/natefinch/lumberjack.v2"
 )
 
 var (
@@ -18,11 +16,13 @@ var (
 	// logsDir stores the logs directory path for export functionality
 	logsDir string
 
-	// logWriter is the lumberjack writer for production mode
-	logWriter *lumberjack.Logger
+	// logWriter is the rotating log file writer (nil when file logging is off)
+	logWriter *rotatingWriter
 )
 
-// Initialize sets up structured logging based on build mode.
+// Initialize sets up structured logging based on build mode. The build mode
+// only sets the default level (Info in production, Debug in development);
+// SetLevel and SetComponentLevel change it at runtime.
 // When dataDir is ":memory:" (e.g. during e2e tests), file logging is
 // skipped entirely to avoid creating a literal ":memory:" directory on disk.
 func Initialize(dataDir string, production bool) error {
@@ -34,7 +34,7 @@ func Initialize(dataDir string, production bool) error {
 		// In-memory mode: stdout only, no file logging
 		if production {
 			handler = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-				Level:     slog.LevelInfo,
+				Level:     slog.LevelDebug,
 				AddSource: true,
 			})
 		} else {
@@ -50,18 +50,14 @@ func Initialize(dataDir string, production bool) error {
 			return fmt.Errorf("failed to create logs directory: %w", err)
 		}
 
-		logWriter = &lumberjack.Logger{
-			Filename:   filepath.Join(logsDir, "paddockcontrol.log"),
-			MaxSize:    10,   // MB
-			MaxBackups: 5,    // Keep 5 old log files
-			MaxAge:     30,   // Days
-			Compress:   true, // Compress old logs
-		}
+		// The configured rotation settings are applied with SetRotation once
+		// the database is open
+		logWriter = newRotatingWriter(logsDir, DefaultRotation)
 
 		if production {
 			// Production: JSON logs to file only
 			handler = slog.NewJSONHandler(logWriter, &slog.HandlerOptions{
-				Level:     slog.LevelInfo,
+				Level:     slog.LevelDebug,
 				AddSource: true,
 			})
 		} else {
@@ -79,7 +75,14 @@ func Initialize(dataDir string, production bool) error {
 		}
 	}
 
-	defaultLogger = slog.New(handler)
+	defaultLevel = slog.LevelDebug
+	if production {
+		defaultLevel = slog.LevelInfo
+	}
+	level.Set(defaultLevel)
+	SetComponentLevels(nil)
+
+	defaultLogger = slog.New(&levelHandler{Handler: handler})
 	slog.SetDefault(defaultLogger)
 
 	return nil
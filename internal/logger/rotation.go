@@ -0,0 +1,172 @@
+package logger
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	// logFileName is the current log file; lumberjack renames it to
+	// paddockcontrol-<timestamp>.log when rotating
+	logFileName = "paddockcontrol.log"
+
+	// rotatedTimeFormat is the timestamp lumberjack puts in rotated file names
+	rotatedTimeFormat = "2006-01-02T15-04-05.000"
+)
+
+// RotationSettings are the log file rotation parameters
+type RotationSettings struct {
+	MaxSizeMB  int  // size at which the current log is rotated
+	MaxFiles   int  // rotated files kept; 0 keeps them all
+	MaxAgeDays int  // rotated files older than this are removed; 0 disables it
+	Compress   bool // gzip rotated files
+}
+
+// DefaultRotation is used until the configuration is loaded
+var DefaultRotation = RotationSettings{
+	MaxSizeMB:  10,
+	MaxFiles:   5,
+	MaxAgeDays: 30,
+	Compress:   true,
+}
+
+// rotatingWriter lets the rotation settings change while handlers write to
+// the log file: lumberjack reads its settings on every write without a lock
+// callers can take, so changing them means swapping the lumberjack logger.
+type rotatingWriter struct {
+	mu       sync.Mutex
+	out      *lumberjack.Logger
+	settings RotationSettings
+}
+
+func newRotatingWriter(dir string, settings RotationSettings) *rotatingWriter {
+	w := &rotatingWriter{}
+	w.apply(dir, settings)
+	return w
+}
+
+// apply replaces the lumberjack logger. Callers hold w.mu or own w.
+func (w *rotatingWriter) apply(dir string, settings RotationSettings) {
+	if w.out != nil {
+		_ = w.out.Close()
+	}
+	w.settings = settings
+	w.out = &lumberjack.Logger{
+		Filename:   filepath.Join(dir, logFileName),
+		MaxSize:    settings.MaxSizeMB,
+		MaxBackups: settings.MaxFiles,
+		MaxAge:     settings.MaxAgeDays,
+		Compress:   settings.Compress,
+	}
+}
+
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.out.Write(p)
+}
+
+func (w *rotatingWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.out.Close()
+}
+
+// Rotation returns the rotation settings in use.
+func Rotation() RotationSettings {
+	if logWriter == nil {
+		return DefaultRotation
+	}
+	logWriter.mu.Lock()
+	defer logWriter.mu.Unlock()
+	return logWriter.settings
+}
+
+// SetRotation changes the rotation settings of the log file and removes the
+// rotated files they no longer keep. Lumberjack compresses rotated files
+// itself, on the next write. A no-op when file logging is disabled.
+func SetRotation(settings RotationSettings) (int, error) {
+	if settings.MaxSizeMB <= 0 || settings.MaxFiles < 0 || settings.MaxAgeDays < 0 {
+		return 0, fmt.Errorf("invalid log rotation settings: %+v", settings)
+	}
+	if logWriter == nil {
+		return 0, nil
+	}
+
+	logWriter.mu.Lock()
+	logWriter.apply(logsDir, settings)
+	logWriter.mu.Unlock()
+
+	return pruneRotatedLogs(logsDir, settings, time.Now())
+}
+
+// rotatedLog is a log file lumberjack rotated out
+type rotatedLog struct {
+	path      string
+	rotatedAt time.Time
+}
+
+// pruneRotatedLogs removes the rotated files beyond settings.MaxFiles (newest
+// kept) and those rotated more than settings.MaxAgeDays ago, the way lumberjack
+// does after a rotation, and returns how many it removed.
+func pruneRotatedLogs(dir string, settings RotationSettings, now time.Time) (int, error) {
+	rotated, err := listRotatedLogs(dir)
+	if err != nil {
+		return 0, err
+	}
+
+	cutoff := time.Time{}
+	if settings.MaxAgeDays > 0 {
+		cutoff = now.Add(-time.Duration(settings.MaxAgeDays) * 24 * time.Hour)
+	}
+
+	removed := 0
+	for i, f := range rotated {
+		tooMany := settings.MaxFiles > 0 && i >= settings.MaxFiles
+		tooOld := !cutoff.IsZero() && f.rotatedAt.Before(cutoff)
+		if !tooMany && !tooOld {
+			continue
+		}
+		if err := os.Remove(f.path); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("failed to remove old log file: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// listRotatedLogs returns the rotated log files in dir, newest first.
+func listRotatedLogs(dir string) ([]rotatedLog, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read logs directory: %w", err)
+	}
+
+	prefix := strings.TrimSuffix(logFileName, filepath.Ext(logFileName)) + "-"
+	var rotated []rotatedLog
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimSuffix(name, ".gz"), ".log")
+		rotatedAt, err := time.Parse(rotatedTimeFormat, strings.TrimPrefix(stamp, prefix))
+		if err != nil {
+			continue
+		}
+		rotated = append(rotated, rotatedLog{path: filepath.Join(dir, name), rotatedAt: rotatedAt})
+	}
+
+	sort.Slice(rotated, func(i, j int) bool { return rotated[i].rotatedAt.After(rotated[j].rotatedAt) })
+	return rotated, nil
+}
@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPruneRotatedLogs_EnforcesCountAndAge(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	write := func(name string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("log"), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	stamp := func(daysAgo int) string {
+		return now.Add(-time.Duration(daysAgo) * 24 * time.Hour).Format(rotatedTimeFormat)
+	}
+
+	write(logFileName)
+	write("paddockcontrol-" + stamp(1) + ".log")
+	write("paddockcontrol-" + stamp(2) + ".log.gz")
+	write("paddockcontrol-" + stamp(3) + ".log.gz")
+	write("paddockcontrol-" + stamp(40) + ".log.gz")
+	write("unrelated.txt")
+
+	removed, err := pruneRotatedLogs(dir, RotationSettings{MaxSizeMB: 10, MaxFiles: 2, MaxAgeDays: 30}, now)
+	if err != nil {
+		t.Fatalf("pruneRotatedLogs() error: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("removed = %d, want 2", removed)
+	}
+
+	for name, kept := range map[string]bool{
+		logFileName:                               true,
+		"paddockcontrol-" + stamp(1) + ".log":     true,
+		"paddockcontrol-" + stamp(2) + ".log.gz":  true,
+		"paddockcontrol-" + stamp(3) + ".log.gz":  false,
+		"paddockcontrol-" + stamp(40) + ".log.gz": false,
+		"unrelated.txt":                           true,
+	} {
+		_, err := os.Stat(filepath.Join(dir, name))
+		if exists := err == nil; exists != kept {
+			t.Errorf("%s: exists = %v, want %v", name, exists, kept)
+		}
+	}
+}
+
+func TestPruneRotatedLogs_RemovesOldFilesWithinCount(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now().UTC()
+	recent := "paddockcontrol-" + now.Add(-24*time.Hour).Format(rotatedTimeFormat) + ".log.gz"
+	old := "paddockcontrol-" + now.Add(-40*24*time.Hour).Format(rotatedTimeFormat) + ".log.gz"
+	for _, name := range []string{recent, old} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("log"), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	if _, err := pruneRotatedLogs(dir, RotationSettings{MaxSizeMB: 10, MaxFiles: 5, MaxAgeDays: 30}, now); err != nil {
+		t.Fatalf("pruneRotatedLogs() error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, recent)); err != nil {
+		t.Errorf("recent file should be kept: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, old)); !os.IsNotExist(err) {
+		t.Errorf("file older than MaxAgeDays should be removed, got %v", err)
+	}
+}
+
+func TestPruneRotatedLogs_ZeroKeepsEverything(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now().UTC()
+	for _, daysAgo := range []int{1, 100, 1000} {
+		name := "paddockcontrol-" + now.Add(-time.Duration(daysAgo)*24*time.Hour).Format(rotatedTimeFormat) + ".log.gz"
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("log"), 0600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	removed, err := pruneRotatedLogs(dir, RotationSettings{MaxSizeMB: 10}, now)
+	if err != nil {
+		t.Fatalf("pruneRotatedLogs() error: %v", err)
+	}
+	if removed != 0 {
+		t.Errorf("removed = %d, want 0", removed)
+	}
+}
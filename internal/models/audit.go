@@ -0,0 +1,40 @@
+package models
+
+// AuditEntry is one security-sensitive operation recorded in the audit log
+type AuditEntry struct {
+	ID         int64          `json:"id"`
+	EventType  string         `json:"event_type"` // Audit* constant
+	Hostname   string         `json:"hostname"`   // Empty for operations not tied to one certificate
+	Message    string         `json:"message"`
+	Actor      string         `json:"actor"` // OS username
+	AppVersion string         `json:"app_version"`
+	CreatedAt  int64          `json:"created_at"`
+	Details    map[string]any `json:"details,omitempty"` // e.g. the unlock method or export format
+}
+
+// Audit event type constants
+const (
+	AuditUnlock         = "unlock"
+	AuditUnlockFailed   = "unlock_failed"
+	AuditKeyExport      = "key_export"
+	AuditPasswordChange = "password_change"
+	AuditBackupRestore  = "backup_restore"
+	AuditDatabaseReset  = "database_reset"
+	AuditAgentEnroll    = "agent_enroll" // a sync agent was given a client certificate to pull keys with
+	AuditAgentRevoke    = "agent_revoke"
+	AuditAgentHostnames = "agent_hostnames" // the certificates a sync agent may pull were changed
+)
+
+// AuditFilter narrows the audit log
+type AuditFilter struct {
+	EventTypes []string `json:"event_types,omitempty"` // Audit* constants; empty for all
+	Hostname   string   `json:"hostname,omitempty"`    // Empty for all
+	From       int64    `json:"from,omitempty"`        // Unix seconds, inclusive (0 for no bound)
+	To         int64    `json:"to,omitempty"`          // Unix seconds, exclusive (0 for no bound)
+}
+
+// AuditLogPage is one page of audit log entries
+type AuditLogPage struct {
+	Entries []AuditEntry `json:"entries"`
+	Total   int          `json:"total"` // Entries matching the filter, across all pages
+}
@@ -0,0 +1,84 @@
+package models
+
+// Note modes of a BulkPatch
+const (
+	BulkNoteReplace = "replace"
+	BulkNoteAppend  = "append"
+)
+
+// BulkPatch is a change applied to several certificates at once. Nil fields are
+// left unchanged.
+type BulkPatch struct {
+	Note     *string `json:"note,omitempty"`
+	NoteMode string  `json:"note_mode,omitempty"` // "replace" (default) or "append" (added on a new line)
+	ReadOnly *bool   `json:"read_only,omitempty"`
+}
+
+// BulkHostResult is the outcome of a bulk operation for one certificate
+type BulkHostResult struct {
+	Hostname string `json:"hostname"`
+	Success  bool   `json:"success"`
+	Error    string `json:"error,omitempty"`
+}
+
+// BulkUpdateResult reports a bulk update. The update runs in one transaction:
+// when any certificate fails, nothing is applied and Applied is false.
+type BulkUpdateResult struct {
+	Applied bool             `json:"applied"`
+	Results []BulkHostResult `json:"results"`
+}
+
+// BulkDeletePreviewItem describes one certificate selected for bulk deletion
+type BulkDeletePreviewItem struct {
+	Hostname      string   `json:"hostname"`
+	Status        string   `json:"status"`
+	HasPrivateKey bool     `json:"has_private_key"`
+	HasPendingKey bool     `json:"has_pending_key"`
+	ReadOnly      bool     `json:"read_only"`             // Read-only certificates block the deletion
+	DeployedOn    []string `json:"deployed_on,omitempty"` // Deployment targets, which block the deletion
+}
+
+// BulkDeletePreview lists what a bulk deletion would remove. The user must type
+// ConfirmationToken back to BulkDeleteCertificates before ExpiresAt; it is
+// empty when a selected certificate is read-only or deployed.
+type BulkDeletePreview struct {
+	Items             []BulkDeletePreviewItem `json:"items"`
+	ConfirmationToken string                  `json:"confirmation_token,omitempty"`
+	ExpiresAt         int64                   `json:"expires_at,omitempty"`
+}
+
+// BulkDeleteResult reports a bulk deletion. Certificates are deleted in one
+// transaction: when any fails, none is deleted and Deleted is false.
+type BulkDeleteResult struct {
+	Deleted    bool             `json:"deleted"`
+	Results    []BulkHostResult `json:"results"`
+	BackupPath string           `json:"backup_path"` // Backup taken before the deletion
+	DryRun     bool             `json:"dry_run"`     // Nothing was deleted; Deleted tells whether it would be
+}
+
+// BulkCSRRequest generates new CSRs for several hostnames at once, all with the
+// subject, key and note of Template (its Hostname, SANs, IsRenewal and
+// Requester are ignored). Hostnames come from Hostnames and List.
+type BulkCSRRequest struct {
+	Hostnames []string `json:"hostnames,omitempty"`
+	// Pasted list or CSV: one hostname per line, optionally followed by its
+	// SANs as more comma-separated fields. A "hostname" header line and lines
+	// starting with # are skipped.
+	List     string     `json:"list,omitempty"`
+	Template CSRRequest `json:"template"`
+}
+
+// BulkCSRHostResult is the outcome of a bulk CSR generation for one hostname
+type BulkCSRHostResult struct {
+	Hostname string `json:"hostname"`
+	Success  bool   `json:"success"`
+	Error    string `json:"error,omitempty"`
+	CSR      string `json:"csr,omitempty"`
+}
+
+// BulkCSRResult reports a bulk CSR generation. The CSRs are stored in one
+// transaction: when any hostname fails, none is stored and Generated is false.
+type BulkCSRResult struct {
+	Generated bool                `json:"generated"`
+	Results   []BulkCSRHostResult `json:"results"`
+}
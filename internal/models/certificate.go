@@ -10,50 +10,110 @@ type Certificate struct {
 	PendingEncryptedKey []byte `json:"-"` // Never expose to frontend
 	CreatedAt           int64  `json:"created_at"`
 	ExpiresAt           *int64 `json:"expires_at,omitempty"`
+	ExpiresAtUTC        string `json:"expires_at_utc,omitempty"`   // RFC 3339, UTC
+	ExpiresAtLocal      string `json:"expires_at_local,omitempty"` // RFC 3339, local time zone
 	Note                string `json:"note,omitempty"`
 	PendingNote         string `json:"pending_note,omitempty"`
 	ReadOnly            bool   `json:"read_only"`
+	CAReference         string `json:"ca_reference,omitempty"` // CA order/reference ID of the pending CSR
+	SubmittedAt         *int64 `json:"submitted_at,omitempty"` // when the pending CSR was submitted to the CA
 
 	// Computed fields (not in DB, calculated at runtime)
-	Status              string   `json:"status"` // pending, active, expiring, expired
-	SANs                []string `json:"sans,omitempty"`
-	Organization        string   `json:"organization,omitempty"`
-	OrganizationalUnit  string   `json:"organizational_unit,omitempty"`
-	City                string   `json:"city,omitempty"`
-	State               string   `json:"state,omitempty"`
-	Country             string   `json:"country,omitempty"`
-	KeySize             int      `json:"key_size,omitempty"`
-	DaysUntilExpiration int      `json:"days_until_expiration,omitempty"`
+	Status              string `json:"status"`           // pending, active, expiring, expired
+	DisplayHostname     string `json:"display_hostname"` // unicode form of an IDN hostname
+	DaysUntilExpiration int    `json:"days_until_expiration,omitempty"`
+
+	// Subject details of the issued certificate (nil while only a CSR exists)
+	Active *CertificateSubject `json:"active,omitempty"`
+	// Subject details requested by the pending CSR (nil when there is none).
+	// During a renewal both are set, so the UI can show what changes.
+	Pending *CertificateSubject `json:"pending,omitempty"`
+
+	// Computed from the issued certificate only (nil while pending)
+	Extensions *CertificateExtensions `json:"extensions,omitempty"`
+
+	// Who asked for the certificate (nil unless generated from a request file)
+	Requester *CertificateRequester `json:"requester,omitempty"`
+
+	// Labels such as "production" or "team-infra", sorted
+	Tags []string `json:"tags,omitempty"`
+
+	// URLs and ticket IDs found in the note and pending note
+	NoteReferences []NoteReference `json:"note_references,omitempty"`
+}
+
+// Where a note reference was found, and what it is
+const (
+	NoteSourceNote        = "note"
+	NoteSourcePendingNote = "pending_note"
+
+	NoteReferenceURL    = "url"
+	NoteReferenceTicket = "ticket"
+)
 
-	// Computed fields from pending CSR (when both cert and CSR exist, for regenerate functionality)
-	PendingSANs               []string `json:"pending_sans,omitempty"`
-	PendingOrganization       string   `json:"pending_organization,omitempty"`
-	PendingOrganizationalUnit string   `json:"pending_organizational_unit,omitempty"`
-	PendingCity               string   `json:"pending_city,omitempty"`
-	PendingState              string   `json:"pending_state,omitempty"`
-	PendingCountry            string   `json:"pending_country,omitempty"`
-	PendingKeySize            int      `json:"pending_key_size,omitempty"`
+// NoteReference is a URL or ticket ID found in a certificate's notes
+type NoteReference struct {
+	Source string `json:"source"` // NoteSource*
+	Kind   string `json:"kind"`   // NoteReference*
+	Value  string `json:"value"`
+}
+
+// CertificateSubject holds the subject, SANs and key size parsed from either an
+// issued certificate or a CSR
+type CertificateSubject struct {
+	SANs               []string `json:"sans,omitempty"`
+	Organization       string   `json:"organization,omitempty"`
+	OrganizationalUnit string   `json:"organizational_unit,omitempty"`
+	City               string   `json:"city,omitempty"`
+	State              string   `json:"state,omitempty"`
+	Country            string   `json:"country,omitempty"`
+	KeyAlgorithm       string   `json:"key_algorithm,omitempty"` // "rsa" or "ecdsa"
+	KeySize            int      `json:"key_size,omitempty"`
 }
 
 // CertificateListItem represents a certificate in a list view
 type CertificateListItem struct {
 	Hostname            string   `json:"hostname"`
-	Status              string   `json:"status"` // computed
+	Status              string   `json:"status"`           // computed
+	DisplayHostname     string   `json:"display_hostname"` // computed, unicode form of an IDN hostname
 	SANs                []string `json:"sans,omitempty"`
+	KeyAlgorithm        string   `json:"key_algorithm,omitempty"` // "rsa" or "ecdsa"
 	KeySize             int      `json:"key_size,omitempty"`
 	CreatedAt           int64    `json:"created_at"`
 	ExpiresAt           *int64   `json:"expires_at,omitempty"`
+	ExpiresAtUTC        string   `json:"expires_at_utc,omitempty"`   // RFC 3339, UTC
+	ExpiresAtLocal      string   `json:"expires_at_local,omitempty"` // RFC 3339, local time zone
 	DaysUntilExpiration int      `json:"days_until_expiration,omitempty"`
 	ReadOnly            bool     `json:"read_only"`
 	HasPendingCSR       bool     `json:"has_pending_csr"`
+	CAReference         string   `json:"ca_reference,omitempty"`
+	SubmittedAt         *int64   `json:"submitted_at,omitempty"`
+	KeyStatus           string   `json:"key_status,omitempty"` // KeyStatus*; empty until checked
+	Tags                []string `json:"tags,omitempty"`
 }
 
+// Key pair health of a certificate (CertificateListItem.KeyStatus), stored and
+// recomputed whenever a key, certificate or CSR is written
+const (
+	KeyStatusOK            = "ok"
+	KeyStatusMismatched    = "mismatched"    // a key does not match its certificate or CSR
+	KeyStatusUndecryptable = "undecryptable" // a key does not decrypt with the master key
+	KeyStatusMissing       = "missing"       // a certificate or CSR has no stored key
+)
+
 // SANType constants for Subject Alternative Name types
 const (
 	SANTypeDNS = "dns"
 	SANTypeIP  = "ip"
 )
 
+// Key algorithms of generated CSRs (CSRRequest.KeyAlgorithm) and parsed
+// certificates
+const (
+	KeyAlgorithmRSA   = "rsa"
+	KeyAlgorithmECDSA = "ecdsa" // key size is the curve size: 256 (P-256) or 384 (P-384)
+)
+
 // SANEntry represents a typed Subject Alternative Name entry
 type SANEntry struct {
 	Value string `json:"value"`
@@ -69,17 +129,34 @@ type CSRRequest struct {
 	City                 string     `json:"city"`
 	State                string     `json:"state"`
 	Country              string     `json:"country"`
-	KeySize              int        `json:"key_size"`
+	KeyAlgorithm         string     `json:"key_algorithm,omitempty"` // "rsa" (default) or "ecdsa"
+	KeySize              int        `json:"key_size"`                // RSA modulus or ECDSA curve size in bits
 	Note                 string     `json:"note,omitempty"`
 	IsRenewal            bool       `json:"is_renewal,omitempty"`
 	SkipSuffixValidation bool       `json:"skip_suffix_validation,omitempty"`
+	PresetID             int64      `json:"preset_id,omitempty"`    // Subject preset filling the subject fields left empty
+	InheritSANs          bool       `json:"inherit_sans,omitempty"` // Add the active certificate's SANs to SANs (implied on renewal without SANs)
+	// Who asked for the certificate, from a request file (ImportCSRRequestFile)
+	Requester *CertificateRequester `json:"requester,omitempty"`
 }
 
 // CSRResponse represents the response from CSR generation
 type CSRResponse struct {
-	Hostname string `json:"hostname"`
-	CSR      string `json:"csr"`
-	Message  string `json:"message"`
+	Hostname      string   `json:"hostname"`
+	CSR           string   `json:"csr"`
+	Message       string   `json:"message"`
+	SANsInherited bool     `json:"sans_inherited,omitempty"` // SANs were copied from the active certificate
+	InheritedSANs []string `json:"inherited_sans,omitempty"`
+	// Certificates related to a renewed one (CertificateRelation on either
+	// side), whose endpoints may need attention once it is replaced
+	DependentCertificates []string `json:"dependent_certificates,omitempty"`
+	Warnings
+}
+
+// CSRSubmission records that a pending CSR was handed to the CA
+type CSRSubmission struct {
+	CAReference string `json:"ca_reference,omitempty"` // the CA's order or ticket ID, if it gave one
+	SubmittedAt int64  `json:"submitted_at,omitempty"` // Unix time; 0 means now
 }
 
 // ImportRequest represents a request to import a certificate with its private key
@@ -90,18 +167,171 @@ type ImportRequest struct {
 	Note           string `json:"note,omitempty"`
 }
 
+// HostnameDuplicateGroup represents stored hostnames that share the same
+// normalized form and must be merged (or a single hostname that must be renamed)
+type HostnameDuplicateGroup struct {
+	Normalized string   `json:"normalized"`
+	Hostnames  []string `json:"hostnames"`
+}
+
 // CertificateFilter represents filtering options for certificate listings
 type CertificateFilter struct {
 	Status    string `json:"status,omitempty"`     // all, pending, active, expiring, expired
 	SortBy    string `json:"sort_by,omitempty"`    // created, expiring, hostname
 	SortOrder string `json:"sort_order,omitempty"` // asc, desc
+	// AwaitingResponseDays keeps only pending CSRs submitted to the CA at least
+	// this many days ago (0 disables the filter)
+	AwaitingResponseDays int `json:"awaiting_response_days,omitempty"`
+	// Tags keeps only certificates carrying every one of these tags
+	Tags []string `json:"tags,omitempty"`
+	// Reference keeps only certificates whose notes mention this URL or
+	// ticket ID (case-insensitive), e.g. "CHG0012345"
+	Reference string `json:"reference,omitempty"`
+	// Search keeps only certificates whose hostname, SANs, note or pending
+	// note contain every whitespace-separated word (case-insensitive)
+	Search string `json:"search,omitempty"`
+	// Hostname keeps only certificates whose hostname contains this text
+	// (case-insensitive)
+	Hostname string `json:"hostname,omitempty"`
+	Offset   int    `json:"offset,omitempty"`
+	Limit    int    `json:"limit,omitempty"` // 0 returns every remaining certificate
+}
+
+// CertificatePage is one page of a certificate listing
+type CertificatePage struct {
+	Certificates []*CertificateListItem `json:"certificates"`
+	Total        int                    `json:"total"` // Certificates matching the filter, across all pages
+}
+
+// TagCount is a tag in use and how many certificates carry it
+type TagCount struct {
+	Tag          string `json:"tag"`
+	Certificates int    `json:"certificates"`
+}
+
+// DeletedCertificate is a certificate in the trash
+type DeletedCertificate struct {
+	Hostname        string `json:"hostname"`
+	DisplayHostname string `json:"display_hostname"` // computed, unicode form of an IDN hostname
+	ExpiresAt       *int64 `json:"expires_at,omitempty"`
+	Note            string `json:"note,omitempty"`
+	HasPrivateKey   bool   `json:"has_private_key"`
+	DeletedAt       int64  `json:"deleted_at"`
+	PurgeAt         int64  `json:"purge_at"` // when the automatic purge deletes it permanently
+}
+
+// CertImportOptions controls how certificates are imported from a backup
+type CertImportOptions struct {
+	// BestEffort imports each certificate in its own transaction and reports
+	// per-entry failures instead of rolling back the whole import.
+	BestEffort bool `json:"best_effort"`
+	// DuplicateKeyPolicy decides what happens to a backup entry whose public key
+	// already belongs to another hostname. Empty means KeyDuplicateLink.
+	DuplicateKeyPolicy string `json:"duplicate_key_policy,omitempty"`
+	// HostnameMapping renames backup entries during the import (old → new
+	// hostname). The original name is kept in the note and in history.
+	HostnameMapping map[string]string `json:"hostname_mapping,omitempty"`
+}
+
+// Policies for a backup entry that shares its public key with a certificate
+// under another hostname (e.g. the same wildcard key in several backups)
+const (
+	KeyDuplicateLink   = "link"   // don't insert it; record it against the existing certificate (default)
+	KeyDuplicateImport = "import" // insert it anyway, under its own hostname
+)
+
+// CertKeyLink records a backup entry that was linked to an existing certificate
+// with the same public key instead of being inserted
+type CertKeyLink struct {
+	Hostname         string `json:"hostname"`          // hostname of the backup entry
+	ExistingHostname string `json:"existing_hostname"` // certificate already holding the key
 }
 
 // CertImportResult represents the result of importing certificates from a backup
 type CertImportResult struct {
-	Imported  int      `json:"imported"`
-	Skipped   int      `json:"skipped"`
-	Conflicts []string `json:"conflicts,omitempty"`
+	Imported  int                 `json:"imported"`
+	Skipped   int                 `json:"skipped"`
+	Conflicts []string            `json:"conflicts,omitempty"`
+	Failed    []CertImportFailure `json:"failed,omitempty"`  // best-effort mode only
+	Linked    []CertKeyLink       `json:"linked,omitempty"`  // shared a public key with an existing certificate
+	Renamed   []CertRename        `json:"renamed,omitempty"` // imported under a mapped hostname
+	// SuggestedHostnameSuffix is the suffix most certificates now share, set
+	// when the configured one is blank or fits fewer of them
+	SuggestedHostnameSuffix string `json:"suggested_hostname_suffix,omitempty"`
+	// DryRun is set when nothing was written: the counts are what the import
+	// would have done
+	DryRun bool `json:"dry_run"`
+	// Caveats of the certificates imported, each prefixed with its hostname
+	Warnings
+}
+
+// CertRename records a backup entry imported under another hostname
+type CertRename struct {
+	OriginalHostname string `json:"original_hostname"` // hostname in the backup
+	Hostname         string `json:"hostname"`          // hostname it was imported as
+}
+
+// CertImportFailure represents a certificate that could not be imported in best-effort mode
+type CertImportFailure struct {
+	Hostname string `json:"hostname"`
+	Error    string `json:"error"`
+}
+
+// CertImportPreview represents the validation result of a backup certificate
+// import, computed without writing to the database
+type CertImportPreview struct {
+	Importable    []CertImportPreviewEntry `json:"importable"`
+	Conflicting   []CertImportPreviewEntry `json:"conflicting"`
+	Invalid       []CertImportPreviewEntry `json:"invalid"`
+	KeyDuplicates []CertImportPreviewEntry `json:"key_duplicates"` // would be linked to an existing certificate
+}
+
+// CertImportPreviewEntry represents a single backup certificate in an import preview
+type CertImportPreviewEntry struct {
+	Hostname string `json:"hostname"`
+	Status   string `json:"status"`              // computed: pending/active/expiring/expired
+	Reason   string `json:"reason,omitempty"`    // why the entry conflicts or is invalid
+	LinkedTo string `json:"linked_to,omitempty"` // existing hostname with the same public key
+}
+
+// Conflict policies for a merge-restore, deciding which version wins when a
+// hostname exists both in the current database and in the backup
+const (
+	MergeKeepCurrent = "keep_current" // leave the current certificate untouched (default)
+	MergeUseBackup   = "use_backup"   // overwrite the current certificate with the backup's
+	MergeKeepNewer   = "keep_newer"   // keep whichever was modified last
+)
+
+// BackupMergeOptions controls how a backup is merged into the current database
+type BackupMergeOptions struct {
+	// ConflictPolicy applies to every conflicting hostname without an override.
+	// Empty means MergeKeepCurrent.
+	ConflictPolicy string `json:"conflict_policy"`
+	// Overrides sets the policy for individual conflicting hostnames.
+	Overrides map[string]string `json:"overrides,omitempty"`
+	// DuplicateKeyPolicy applies to backup-only entries whose public key already
+	// belongs to another hostname. Empty means KeyDuplicateLink.
+	DuplicateKeyPolicy string `json:"duplicate_key_policy,omitempty"`
+}
+
+// BackupMergeResult summarizes a merge-restore
+type BackupMergeResult struct {
+	Added    []string            `json:"added"`    // only in the backup, inserted
+	Replaced []string            `json:"replaced"` // conflicting, backup version won
+	Kept     []string            `json:"kept"`     // conflicting, current version won
+	Failed   []CertImportFailure `json:"failed"`   // could not be merged
+	Linked   []CertKeyLink       `json:"linked"`   // only in the backup, but its key is already held by another hostname
+	DryRun   bool                `json:"dry_run"`  // nothing was written; the lists are what the merge would do
+}
+
+// RestoreReport describes a restore that replaces the whole database. A dry
+// run validates the backup (and its password) and reports without replacing
+// anything.
+type RestoreReport struct {
+	SchemaVersion        uint `json:"schema_version"`        // migrated to the current version when restored
+	Certificates         int  `json:"certificates"`          // in the backup
+	ReplacedCertificates int  `json:"replaced_certificates"` // in the current database, all replaced
+	DryRun               bool `json:"dry_run"`
 }
 
 // BackupPeekInfo represents a summary of a backup file's contents
@@ -112,6 +342,29 @@ type BackupPeekInfo struct {
 	Hostnames        []string                `json:"hostnames"`
 	Certificates     []BackupCertificateInfo `json:"certificates"`
 	SchemaVersion    int                     `json:"schema_version"`
+	Metadata         *BackupMetadata         `json:"metadata,omitempty"` // nil for backups written before metadata was recorded
+	UnlockMethods    []SecurityKeyInfo       `json:"unlock_methods"`     // unlock methods that will apply after a restore
+	HostnameSuffix   string                  `json:"hostname_suffix"`    // suffix configured in the backup
+	// SuggestedHostnameSuffix is the suffix most of the backup's hostnames
+	// share, set when the configured one is blank or fits fewer of them
+	SuggestedHostnameSuffix string `json:"suggested_hostname_suffix,omitempty"`
+}
+
+// BackupPasswordCheck is the outcome of testing a password against a backup
+// before restoring it
+type BackupPasswordCheck struct {
+	Valid         bool   `json:"valid"`
+	Label         string `json:"label,omitempty"` // label of the password method it unlocks
+	SameMasterKey bool   `json:"same_master_key"` // the backup uses the master key of the unlocked app
+}
+
+// BackupMetadata describes how a backup file was produced. It is embedded in the
+// backup itself, in the backup_metadata table.
+type BackupMetadata struct {
+	Operation        string `json:"operation"` // Operation that triggered the backup, e.g. "delete_certificate" or "manual"
+	AppVersion       string `json:"app_version"`
+	CertificateCount int    `json:"certificate_count"`
+	CreatedAt        int64  `json:"created_at"`
 }
 
 // BackupCertificateInfo represents a single certificate inside a backup file,
@@ -127,12 +380,46 @@ type BackupCertificateInfo struct {
 
 // LocalBackupInfo represents metadata about a local database backup file
 type LocalBackupInfo struct {
-	Filename         string `json:"filename"`                    // Full filename
-	Type             string `json:"type"`                        // "auto" or "manual"
-	Timestamp        int64  `json:"timestamp"`                   // Unix timestamp parsed from filename
-	Size             int64  `json:"size"`                        // File size in bytes
-	CertificateCount int    `json:"certificate_count"`           // Number of certificates in backup
-	CAName           string `json:"ca_name,omitempty"`           // CA name from config table
+	Filename         string `json:"filename"`              // Full filename
+	Type             string `json:"type"`                  // "auto" or "manual"
+	Timestamp        int64  `json:"timestamp"`             // Unix timestamp parsed from filename
+	Size             int64  `json:"size"`                  // File size in bytes
+	CertificateCount int    `json:"certificate_count"`     // Number of certificates in backup
+	CAName           string `json:"ca_name,omitempty"`     // CA name from config table
+	Operation        string `json:"operation,omitempty"`   // Operation that triggered the backup
+	AppVersion       string `json:"app_version,omitempty"` // App version that wrote the backup
+}
+
+// BackupListOptions selects a page of local backups
+type BackupListOptions struct {
+	Type      string `json:"type,omitempty"`       // "auto", "manual" or empty for both
+	SortBy    string `json:"sort_by,omitempty"`    // "timestamp" (default) or "size"
+	SortOrder string `json:"sort_order,omitempty"` // "desc" (default) or "asc"
+	Offset    int    `json:"offset,omitempty"`
+	Limit     int    `json:"limit,omitempty"` // 0 returns every remaining backup
+}
+
+// BackupListPage is one page of local backups
+type BackupListPage struct {
+	Backups   []LocalBackupInfo `json:"backups"`
+	Total     int               `json:"total"`      // Backups matching the filter, across all pages
+	TotalSize int64             `json:"total_size"` // Combined size in bytes of the matching backups
+}
+
+// BackupStorageUsage is the number and combined size of a set of backups
+type BackupStorageUsage struct {
+	Count int   `json:"count"`
+	Size  int64 `json:"size"` // Bytes
+}
+
+// BackupStorageBreakdown summarizes the disk space used by local backups
+type BackupStorageBreakdown struct {
+	Auto            BackupStorageUsage `json:"auto"`
+	Manual          BackupStorageUsage `json:"manual"`
+	Total           BackupStorageUsage `json:"total"`
+	MaxAutoBackups  int                `json:"max_auto_backups"`           // Auto-backups kept before rotation
+	OldestTimestamp int64              `json:"oldest_timestamp,omitempty"` // 0 when there are no backups
+	NewestTimestamp int64              `json:"newest_timestamp,omitempty"`
 }
 
 // CertificateUploadPreview represents a preview of a signed certificate before upload
@@ -146,6 +433,84 @@ type CertificateUploadPreview struct {
 	KeySize   int      `json:"key_size"`
 	CSRMatch  bool     `json:"csr_match"`
 	KeyMatch  bool     `json:"key_match"` // cert public key matches pending private key
+	// ChainCount is the number of issuer certificates bundled with the leaf;
+	// they are stored as the certificate's chain on upload
+	ChainCount int `json:"chain_count"`
+}
+
+// CertificateExtensions holds the decoded X.509 extensions, signature algorithm
+// and fingerprints of an issued certificate
+type CertificateExtensions struct {
+	SerialNumber           string   `json:"serial_number"`                      // Hex formatted
+	SignatureAlgorithm     string   `json:"signature_algorithm"`                // e.g. "SHA256-RSA"
+	FingerprintSHA1        string   `json:"fingerprint_sha1"`                   // Colon-separated hex
+	FingerprintSHA256      string   `json:"fingerprint_sha256"`                 // Colon-separated hex
+	KeyUsage               []string `json:"key_usage,omitempty"`                // e.g. "Digital Signature"
+	ExtKeyUsage            []string `json:"ext_key_usage,omitempty"`            // e.g. "Server Authentication"
+	BasicConstraintsValid  bool     `json:"basic_constraints_valid"`            // Basic constraints extension present
+	IsCA                   bool     `json:"is_ca"`                              // CA flag from basic constraints
+	MaxPathLen             int      `json:"max_path_len"`                       // -1 when unset
+	SubjectKeyID           string   `json:"subject_key_id,omitempty"`           // Colon-separated hex
+	AuthorityKeyID         string   `json:"authority_key_id,omitempty"`         // Colon-separated hex
+	OCSPServers            []string `json:"ocsp_servers,omitempty"`             // AIA OCSP responders
+	IssuingCertificateURLs []string `json:"issuing_certificate_urls,omitempty"` // AIA CA issuers
+	CRLDistributionPoints  []string `json:"crl_distribution_points,omitempty"`  // CRL URLs
+}
+
+// IssuerExpiry tracks the expiry of a CA certificate (intermediate or root) found in
+// the chains of stored certificates, with the certificates that depend on it
+type IssuerExpiry struct {
+	SubjectCN           string   `json:"subject_cn"`
+	SubjectO            string   `json:"subject_o"`
+	FingerprintSHA256   string   `json:"fingerprint_sha256"` // Colon-separated hex, identifies the CA certificate
+	CertType            string   `json:"cert_type"`          // "intermediate" or "root"
+	NotAfterTimestamp   int64    `json:"not_after_timestamp"`
+	DaysUntilExpiration int      `json:"days_until_expiration"`
+	Status              string   `json:"status"`                // "active", "expiring" or "expired"
+	Hostnames           []string `json:"hostnames"`             // Certificates issued under this CA
+	OutlivedBy          []string `json:"outlived_by,omitempty"` // Dependent certificates that expire after this CA
+}
+
+// StatusPreview is the certificate inventory as it would look at a given date,
+// used to plan renewals ahead of a maintenance window
+type StatusPreview struct {
+	At           int64                `json:"at"` // Unix timestamp the statuses are evaluated at
+	Active       int                  `json:"active"`
+	Expiring     int                  `json:"expiring"`
+	Expired      int                  `json:"expired"`
+	Pending      int                  `json:"pending"`
+	NeedsRenewal int                  `json:"needs_renewal"`
+	Certificates []StatusPreviewEntry `json:"certificates"`
+}
+
+// StatusPreviewEntry is one certificate's status now and at the preview date
+type StatusPreviewEntry struct {
+	Hostname            string `json:"hostname"`
+	CurrentStatus       string `json:"current_status"`
+	Status              string `json:"status"`  // Status at the preview date
+	Changed             bool   `json:"changed"` // Status differs from the current one
+	ExpiresAt           *int64 `json:"expires_at,omitempty"`
+	DaysUntilExpiration int    `json:"days_until_expiration"` // Counted from the preview date
+	HasPendingCSR       bool   `json:"has_pending_csr"`       // A renewal is already in progress
+	NeedsRenewal        bool   `json:"needs_renewal"`         // Expiring or expired by then, with no renewal in progress
+}
+
+// ChainTrustResult reports whether a certificate's delivered chain validates
+// against each bundled or system trust store
+type ChainTrustResult struct {
+	Hostname    string             `json:"hostname"`
+	ChainSource string             `json:"chain_source"` // "stored", "aia", "leaf_only" or "served" (endpoint probe)
+	ChainLength int                `json:"chain_length"` // Issuer certificates evaluated with the leaf
+	EvaluatedAt int64              `json:"evaluated_at"`
+	Stores      []TrustStoreResult `json:"stores"`
+}
+
+// TrustStoreResult is the outcome of validating a chain against one trust store
+type TrustStoreResult struct {
+	Store       string `json:"store"`       // e.g. "mozilla", "system"
+	Description string `json:"description"` // Which platforms use this store
+	Trusted     bool   `json:"trusted"`
+	Error       string `json:"error,omitempty"` // Why validation failed, or why the store is unavailable
 }
 
 // ChainCertificateInfo represents metadata for a single certificate in the chain
@@ -154,6 +519,7 @@ type ChainCertificateInfo struct {
 	SubjectO           string `json:"subject_o"`            // Subject Organization
 	IssuerCN           string `json:"issuer_cn"`            // Issuer Common Name
 	IssuerO            string `json:"issuer_o"`             // Issuer Organization
+	IssuerDN           string `json:"issuer_dn"`            // Issuer distinguished name (key of issuer chain overrides)
 	NotBeforeTimestamp int64  `json:"not_before_timestamp"` // Validity start date (Unix timestamp)
 	NotAfterTimestamp  int64  `json:"not_after_timestamp"`  // Validity end date (Unix timestamp)
 	SerialNumber       string `json:"serial_number"`        // Serial number (hex formatted)
@@ -161,3 +527,69 @@ type ChainCertificateInfo struct {
 	Depth              int    `json:"depth"`                // Depth in chain (0 = leaf)
 	PEM                string `json:"pem,omitempty"`        // Certificate PEM data (for export)
 }
+
+// CertificateChain is the chain shown for a certificate, leaf first. Diagnostics
+// is set when the chain could not be completed via AIA; Certificates then holds
+// what was found (at least the leaf).
+type CertificateChain struct {
+	Certificates []ChainCertificateInfo `json:"certificates"`
+	Diagnostics  *ChainFetchDiagnostics `json:"diagnostics,omitempty"`
+}
+
+// ChainFetchDiagnostics explains why an AIA chain fetch failed
+type ChainFetchDiagnostics struct {
+	Cause    string              `json:"cause"`    // ChainCause* value
+	Summary  string              `json:"summary"`  // What failed, in words
+	Attempts []ChainFetchAttempt `json:"attempts"` // Every URL tried, in order
+}
+
+// ChainFetchAttempt is the outcome of fetching one AIA URL
+type ChainFetchAttempt struct {
+	URL        string `json:"url"`
+	Depth      int    `json:"depth"`                 // Chain depth of the certificate fetched (1 = leaf's issuer)
+	Outcome    string `json:"outcome"`               // ChainFetch* value
+	StatusCode int    `json:"status_code,omitempty"` // HTTP status, when the server answered
+	Error      string `json:"error,omitempty"`
+	Proxy      string `json:"proxy,omitempty"`    // Proxy the request went through, if any
+	Override   bool   `json:"override,omitempty"` // The URL or certificate came from a chain override
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// AIA fetch outcomes (ChainFetchAttempt.Outcome)
+const (
+	ChainFetchOK                 = "ok"
+	ChainFetchCached             = "cached"
+	ChainFetchPasted             = "pasted" // Issuer certificate pasted in a chain override
+	ChainFetchInvalidURL         = "invalid_url"
+	ChainFetchDNSNotFound        = "dns_not_found"
+	ChainFetchDNSError           = "dns_error"
+	ChainFetchNetworkUnreachable = "network_unreachable"
+	ChainFetchProxyError         = "proxy_error"
+	ChainFetchConnectionRefused  = "connection_refused"
+	ChainFetchTimeout            = "timeout"
+	ChainFetchTLSError           = "tls_error"
+	ChainFetchHTTPStatus         = "http_status"
+	ChainFetchInvalidCertificate = "invalid_certificate"
+	ChainFetchIssuerMismatch     = "issuer_mismatch"
+	ChainFetchFailed             = "failed"
+)
+
+// Causes of a failed chain fetch (ChainFetchDiagnostics.Cause)
+const (
+	ChainCauseNoNetwork     = "no_network"     // DNS, routing or proxy failure on this machine's side
+	ChainCauseCAUnavailable = "ca_unavailable" // The CA endpoint is down, slow or erroring
+	ChainCauseBadURL        = "bad_url"        // The certificate's AIA data is wrong or missing
+	ChainCauseUnknown       = "unknown"
+)
+
+// ChainOverride replaces the AIA URL of one certificate (Hostname) or of every
+// certificate issued by a CA (IssuerDN): the issuer is fetched from IssuerURL,
+// or is the pasted IssuerPEM
+type ChainOverride struct {
+	Hostname      string `json:"hostname,omitempty"`
+	IssuerDN      string `json:"issuer_dn,omitempty"`
+	IssuerURL     string `json:"issuer_url,omitempty"`
+	IssuerPEM     string `json:"issuer_pem,omitempty"`
+	IssuerSubject string `json:"issuer_subject,omitempty"` // Subject CN of the pasted issuer
+	CreatedAt     int64  `json:"created_at"`
+}
@@ -13,6 +13,28 @@ type Config struct {
 	DefaultState              string `json:"default_state"`
 	DefaultCountry            string `json:"default_country"`
 	DefaultKeySize            int    `json:"default_key_size"`
+	ExpiringThresholdDays     int    `json:"expiring_threshold_days"`
+	ClockCheckURL             string `json:"clock_check_url"`
+	AirGapped                 bool   `json:"air_gapped"`
+	BackupFreshnessMaxWrites  int    `json:"backup_freshness_max_writes"` // 0 disables the backup freshness warning
+	BackupFreshnessBlock      bool   `json:"backup_freshness_block"`      // Refuse risky operations while the backup is stale
+	FIPSMode                  bool   `json:"fips_mode"`                   // Restrict crypto to FIPS-approved algorithms and key sizes
+	DBSizeWarnMB              int    `json:"db_size_warn_mb"`             // Database size warning threshold in MiB; 0 disables it
+	LogMaxSizeMB              int    `json:"log_max_size_mb"`             // Size in MiB at which the log file is rotated
+	LogMaxFiles               int    `json:"log_max_files"`               // Rotated log files kept; 0 keeps them all
+	LogMaxAgeDays             int    `json:"log_max_age_days"`            // Rotated log files older than this are removed; 0 disables it
+	LogCompress               bool   `json:"log_compress"`                // Gzip rotated log files
+	MinimizeToTray            bool   `json:"minimize_to_tray"`            // Hide the window to the system tray when minimized
+	RunInBackground           bool   `json:"run_in_background"`           // Keep running in the tray when the window is closed
+	ExpiryNotifications       bool   `json:"expiry_notifications"`        // Desktop notifications when certificates start expiring or expire
+	AIACacheTTLMinutes        int    `json:"aia_cache_ttl_minutes"`       // Minutes issuer certificates fetched via AIA stay cached; 0 disables the cache
+	DownloadLineEndings       string `json:"download_line_endings"`       // Line endings of downloaded PEM files: lf or crlf
+	DownloadTextHeader        bool   `json:"download_text_header"`        // Prefix downloaded certificates and CSRs with their subject and validity
+	DownloadFormat            string `json:"download_format"`             // Encoding of downloads: pem, or der for single items
+	KeyPoolSize               int    `json:"key_pool_size"`               // Keys of the default size generated ahead of time while unlocked; 0 disables the pool
+	KDFProfile                string `json:"kdf_profile"`                 // Argon2id cost for new password wraps: standard, or constrained on 32-bit machines
+	TicketPattern             string `json:"ticket_pattern"`              // Regular expression for ticket IDs in notes (e.g. CHG\d{7}); empty extracts URLs only
+	TrashRetentionDays        int    `json:"trash_retention_days"`        // Days deleted certificates stay in the trash before they are purged; 0 deletes them permanently
 	IsConfigured              int    `json:"is_configured"`
 	CreatedAt                 int64  `json:"created_at"`
 	LastModified              int64  `json:"last_modified"`
@@ -32,6 +54,14 @@ type SetupRequest struct {
 	DefaultKeySize            int    `json:"default_key_size"`
 }
 
+// SetupProgress is where the setup wizard stopped, saved so it can resume
+// after a crash or restart
+type SetupProgress struct {
+	Step      string       `json:"step"`  // wizard step, e.g. "organization"
+	Draft     SetupRequest `json:"draft"` // values entered so far; never the password
+	UpdatedAt int64        `json:"updated_at,omitempty"`
+}
+
 // UpdateConfigRequest represents a request to update the application configuration
 type UpdateConfigRequest struct {
 	OwnerEmail                string `json:"owner_email"`
@@ -44,6 +74,28 @@ type UpdateConfigRequest struct {
 	DefaultState              string `json:"default_state"`
 	DefaultCountry            string `json:"default_country"`
 	DefaultKeySize            int    `json:"default_key_size"`
+	ExpiringThresholdDays     int    `json:"expiring_threshold_days"`
+	ClockCheckURL             string `json:"clock_check_url"`
+	AirGapped                 bool   `json:"air_gapped"`
+	BackupFreshnessMaxWrites  int    `json:"backup_freshness_max_writes"`
+	BackupFreshnessBlock      bool   `json:"backup_freshness_block"`
+	FIPSMode                  bool   `json:"fips_mode"`
+	DBSizeWarnMB              int    `json:"db_size_warn_mb"`
+	LogMaxSizeMB              int    `json:"log_max_size_mb"`
+	LogMaxFiles               int    `json:"log_max_files"`
+	LogMaxAgeDays             int    `json:"log_max_age_days"`
+	LogCompress               bool   `json:"log_compress"`
+	MinimizeToTray            bool   `json:"minimize_to_tray"`
+	RunInBackground           bool   `json:"run_in_background"`
+	ExpiryNotifications       bool   `json:"expiry_notifications"`
+	AIACacheTTLMinutes        int    `json:"aia_cache_ttl_minutes"`
+	DownloadLineEndings       string `json:"download_line_endings"`
+	DownloadTextHeader        bool   `json:"download_text_header"`
+	DownloadFormat            string `json:"download_format"`
+	KeyPoolSize               int    `json:"key_pool_size"`
+	KDFProfile                string `json:"kdf_profile"`
+	TicketPattern             string `json:"ticket_pattern"`
+	TrashRetentionDays        int    `json:"trash_retention_days"`
 }
 
 // SetupDefaults represents default values for setup form
@@ -56,3 +108,17 @@ type SetupDefaults struct {
 	DefaultCity               string `json:"default_city"`
 	DefaultState              string `json:"default_state"`
 }
+
+// SubjectPreset is a named bundle of subject fields selectable when generating
+// a CSR, e.g. one per legal entity issuing certificates
+type SubjectPreset struct {
+	ID                 int64  `json:"id"`
+	Name               string `json:"name"`
+	Organization       string `json:"organization"`
+	OrganizationalUnit string `json:"organizational_unit,omitempty"`
+	City               string `json:"city"`
+	State              string `json:"state"`
+	Country            string `json:"country"`
+	CreatedAt          int64  `json:"created_at"`
+	LastModified       int64  `json:"last_modified"`
+}
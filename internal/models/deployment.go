@@ -0,0 +1,29 @@
+package models
+
+// DeploymentTarget records where a certificate is deployed (a server, a load
+// balancer, an appliance). A certificate with deployment targets cannot be
+// deleted until they are removed.
+type DeploymentTarget struct {
+	ID         int64  `json:"id"`
+	Hostname   string `json:"hostname"`
+	Name       string `json:"name"`               // e.g. "web01" or "prod load balancer"
+	Location   string `json:"location,omitempty"` // e.g. "/etc/nginx/ssl/"
+	DeployedAt int64  `json:"deployed_at"`
+}
+
+// EndpointProbe is the result of connecting to a server and comparing the
+// certificate it presents with the one stored for its hostname
+type EndpointProbe struct {
+	Hostname        string                 `json:"hostname"`
+	Address         string                 `json:"address"`     // host:port connected to
+	TLSVersion      string                 `json:"tls_version"` // e.g. "TLS 1.3"
+	ProbedAt        int64                  `json:"probed_at"`
+	Presented       []ChainCertificateInfo `json:"presented"`        // chain sent by the server, leaf first
+	PresentedSHA256 string                 `json:"presented_sha256"` // fingerprint of the presented leaf
+	StoredSHA256    string                 `json:"stored_sha256"`    // empty when no certificate is stored
+	StoredSerial    string                 `json:"stored_serial"`    // hex, as in ChainCertificateInfo
+	StoredNotAfter  int64                  `json:"stored_not_after"` // Unix timestamp, 0 when none is stored
+	Matches         bool                   `json:"matches"`          // the server presents the stored certificate
+	Mismatches      []string               `json:"mismatches"`       // what differs, in words
+	Trust           *ChainTrustResult      `json:"trust"`            // presented chain against each trust store
+}
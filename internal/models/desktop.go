@@ -0,0 +1,7 @@
+package models
+
+// AutostartStatus reports whether the app is registered to start on login
+type AutostartStatus struct {
+	Supported bool `json:"supported"` // false on platforms without an implementation (macOS)
+	Enabled   bool `json:"enabled"`
+}
@@ -0,0 +1,38 @@
+package models
+
+// How a directory import paired a certificate with its private key
+const (
+	KeyMatchedByFilename  = "filename"   // same file, or same name with another extension
+	KeyMatchedByPublicKey = "public_key" // found among the other key files of the directory
+)
+
+// DirectoryImportEntry is a certificate found by a directory scan, with the
+// private key file paired with it. Files are relative to the scanned directory.
+type DirectoryImportEntry struct {
+	Hostname        string `json:"hostname"`
+	CertificateFile string `json:"certificate_file"`
+	KeyFile         string `json:"key_file,omitempty"`
+	MatchedBy       string `json:"matched_by,omitempty"` // KeyMatchedByFilename or KeyMatchedByPublicKey
+	ChainCount      int    `json:"chain_count"`          // issuer certificates bundled or found in the directory
+	ExpiresAt       int64  `json:"expires_at"`
+	Status          string `json:"status"`           // computed: active/expiring/expired
+	Reason          string `json:"reason,omitempty"` // why the entry conflicts or is invalid
+}
+
+// DirectoryImportSkippedFile is a file of the directory that will not be
+// imported, such as a certificate without a key or an unrelated file
+type DirectoryImportSkippedFile struct {
+	File   string `json:"file"`
+	Reason string `json:"reason"`
+}
+
+// DirectoryImportPreview is what ImportCertificatesFromDirectory would do with
+// a directory: importable pairs are imported, conflicting ones (hostname
+// already stored or in the trash) and invalid ones are left out
+type DirectoryImportPreview struct {
+	Path        string                       `json:"path"`
+	Importable  []DirectoryImportEntry       `json:"importable"`
+	Conflicting []DirectoryImportEntry       `json:"conflicting"`
+	Invalid     []DirectoryImportEntry       `json:"invalid"`
+	Skipped     []DirectoryImportSkippedFile `json:"skipped"`
+}
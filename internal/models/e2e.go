@@ -0,0 +1,35 @@
+package models
+
+// Statuses a certificate can be seeded in by the end-to-end test bindings
+const (
+	TestSeedPending = "pending" // CSR generated, waiting for the signed certificate
+	TestSeedActive  = "active"  // signed certificate uploaded
+)
+
+// TestSeedRequest lists the certificates to create for an end-to-end test
+type TestSeedRequest struct {
+	Certificates []TestSeedCertificate `json:"certificates"`
+}
+
+// TestSeedCertificate is one certificate to seed
+type TestSeedCertificate struct {
+	Hostname      string `json:"hostname"`
+	Status        string `json:"status"`                    // TestSeed* constant
+	ExpiresInDays int    `json:"expires_in_days,omitempty"` // validity from the app clock's now; negative for expired (default 365)
+	KeySize       int    `json:"key_size,omitempty"`        // default: the configured key size
+	Note          string `json:"note,omitempty"`
+}
+
+// TestSeedResult reports the seeded certificates
+type TestSeedResult struct {
+	Certificates []TestSeededCertificate `json:"certificates"`
+}
+
+// TestSeededCertificate is one seeded certificate. For pending certificates,
+// SignedCertificatePEM is the pending CSR signed by the test CA (leaf and CA),
+// ready to be pasted in the upload form.
+type TestSeededCertificate struct {
+	Hostname             string `json:"hostname"`
+	Status               string `json:"status"`
+	SignedCertificatePEM string `json:"signed_certificate_pem,omitempty"`
+}
@@ -1,10 +1,146 @@
 package models
 
+// Chain download variants: which certificates a chain file holds
+const (
+	ChainVariantLeaf      = "leaf"      // leaf only
+	ChainVariantFullChain = "fullchain" // leaf + intermediates, as nginx and HAProxy expect
+	ChainVariantFull      = "full"      // leaf + intermediates + root
+	ChainVariantRoot      = "root"      // root only
+)
+
+// Download line endings (config.download_line_endings)
+const (
+	LineEndingsLF   = "lf"
+	LineEndingsCRLF = "crlf"
+)
+
+// Download encodings (config.download_format)
+const (
+	DownloadFormatPEM = "pem"
+	DownloadFormatDER = "der" // binary, a single certificate, CSR or key per file
+)
+
+// DownloadOptions controls how certificates, CSRs, chains and keys are written
+// when saved to files, from the download settings of the config
+type DownloadOptions struct {
+	LineEndings string `json:"line_endings"`
+	TextHeader  bool   `json:"text_header"` // subject and validity above each certificate and CSR
+	Format      string `json:"format"`
+}
+
 // ExportOptions specifies which items to include in a certificate ZIP export
 type ExportOptions struct {
-	Certificate bool `json:"certificate"`
-	Chain       bool `json:"chain"`
-	PrivateKey  bool `json:"private_key"`
-	CSR         bool `json:"csr"`
-	PendingKey  bool `json:"pending_key"`
+	Certificate  bool   `json:"certificate"`
+	Chain        bool   `json:"chain"`
+	ChainVariant string `json:"chain_variant,omitempty"` // certificates in the chain file; empty is ChainVariantFull
+	PrivateKey   bool   `json:"private_key"`
+	CSR          bool   `json:"csr"`
+	PendingKey   bool   `json:"pending_key"`
+}
+
+// ShareBundleInfo describes the contents of an opened share bundle. The private
+// key itself is never returned to the frontend.
+type ShareBundleInfo struct {
+	Hostname      string `json:"hostname"`
+	HasPrivateKey bool   `json:"has_private_key"`
+	HasChain      bool   `json:"has_chain"`
+	Note          string `json:"note,omitempty"`
+	CreatedAt     int64  `json:"created_at"`
+	ExpiresAt     int64  `json:"expires_at"`
+	AppVersion    string `json:"app_version"`
+}
+
+// QRFrame is one QR code image and the text it encodes.
+type QRFrame struct {
+	Content string `json:"content"`
+	Image   string `json:"image"` // PNG data URL
+}
+
+// CertificateQRCodes holds QR codes for checking a deployed certificate on a
+// device without copy/paste: its SHA-256 fingerprint and, when requested, the
+// certificate PEM split across numbered frames ("PCQR <n>/<total>" header line
+// followed by the chunk).
+type CertificateQRCodes struct {
+	Hostname          string    `json:"hostname"`
+	FingerprintSHA256 string    `json:"fingerprint_sha256"` // Colon-separated hex
+	Fingerprint       QRFrame   `json:"fingerprint"`
+	PEMFrames         []QRFrame `json:"pem_frames,omitempty"`
+}
+
+// Web servers configuration snippets are rendered for
+const (
+	ServerTypeNginx   = "nginx"
+	ServerTypeApache  = "apache"
+	ServerTypeHAProxy = "haproxy"
+)
+
+// ServerConfigFile is a file a configuration snippet points to, and how to
+// produce it from the app's downloads
+type ServerConfigFile struct {
+	Path       string `json:"path"`
+	Source     string `json:"source"`
+	PrivateKey bool   `json:"private_key"` // holds the private key: restrict its permissions
 }
+
+// ServerConfigSnippet is a TLS configuration block ready to paste into the
+// configuration of a web server
+type ServerConfigSnippet struct {
+	Hostname   string             `json:"hostname"`
+	ServerType string             `json:"server_type"`
+	Config     string             `json:"config"`
+	Files      []ServerConfigFile `json:"files"`
+}
+
+// Mobile pinning configurations BuildPinningConfig renders
+const (
+	PinFormatAndroid  = "android"  // network_security_config.xml
+	PinFormatOkHttp   = "okhttp"   // OkHttp CertificatePinner (Kotlin)
+	PinFormatTrustKit = "trustkit" // iOS TrustKit configuration (Swift)
+)
+
+// CertificatePins are the SPKI SHA-256 pins (base64) of a certificate's
+// active and pending keys
+type CertificatePins struct {
+	Hostname   string `json:"hostname"`
+	ActivePin  string `json:"active_pin,omitempty"`
+	PendingPin string `json:"pending_pin,omitempty"` // key of the pending renewal CSR
+	ExpiresAt  *int64 `json:"expires_at,omitempty"`  // active certificate expiry
+}
+
+// PinningConfig is a certificate pinning configuration for a mobile app,
+// pinning the active and upcoming keys of the selected certificates
+type PinningConfig struct {
+	Format       string            `json:"format"`
+	Filename     string            `json:"filename"` // where the configuration goes in the app
+	Config       string            `json:"config"`
+	Certificates []CertificatePins `json:"certificates"`
+	Warnings
+}
+
+// Certificate inventory export formats
+const (
+	InventoryFormatCSV  = "csv"
+	InventoryFormatXLSX = "xlsx"
+)
+
+// OpenSSLCommand is one openssl command line checking a certificate or its
+// deployment
+type OpenSSLCommand struct {
+	Title       string `json:"title"`
+	Description string `json:"description"` // what to look for in the output
+	Command     string `json:"command"`
+}
+
+// OpenSSLCommands are the commands verifying a downloaded certificate and the
+// server it is deployed on
+type OpenSSLCommands struct {
+	Hostname          string           `json:"hostname"`
+	FingerprintSHA256 string           `json:"fingerprint_sha256"` // Colon-separated hex, as openssl prints it
+	Files             []string         `json:"files"`              // Downloads the commands read
+	Commands          []OpenSSLCommand `json:"commands"`
+	Notes             []string         `json:"notes,omitempty"`
+}
+
+// FullExportConfirmation must be typed to confirm a full export, which writes
+// every private key out of the encrypted database
+const FullExportConfirmation = "EXPORT ALL PRIVATE KEYS"
@@ -0,0 +1,148 @@
+package models
+
+// ClockCheckResult is the outcome of the startup clock sanity check
+type ClockCheckResult struct {
+	Source      string `json:"source"`          // reference URL whose Date header was used
+	CheckedAt   int64  `json:"checked_at"`      // local Unix time of the check
+	SkewSeconds int64  `json:"skew_seconds"`    // local clock minus reference clock
+	Skewed      bool   `json:"skewed"`          // true when the skew exceeds the tolerance
+	Skipped     bool   `json:"skipped"`         // true in air-gapped mode
+	Error       string `json:"error,omitempty"` // set when the reference could not be reached
+}
+
+// HealthStatus summarizes runtime conditions the user should be warned about
+type HealthStatus struct {
+	ClockCheck          *ClockCheckResult    `json:"clock_check,omitempty"`
+	RecoveredOperations []RecoveredOperation `json:"recovered_operations,omitempty"` // unfinished operations found at startup
+	BackupFreshness     *BackupFreshness     `json:"backup_freshness,omitempty"`     // nil before setup
+	EntropyCheck        *EntropyCheckResult  `json:"entropy_check,omitempty"`        // nil until the first self-test
+	DatabaseUsage       *DatabaseUsage       `json:"database_usage,omitempty"`       // nil before setup
+	ChainCache          ChainCacheStats      `json:"chain_cache"`                    // issuer certificates fetched via AIA
+	Timings             []OperationTiming    `json:"timings"`                        // operations run since startup
+	SlowOperations      []SlowOperation      `json:"slow_operations"`                // most recent first
+	Warnings            []string             `json:"warnings"`
+}
+
+// BackupFreshness reports how much changed since the last manual backup or
+// password-protected export. Auto-backups do not count: they stay on this disk.
+type BackupFreshness struct {
+	LastBackupAt      *int64 `json:"last_backup_at,omitempty"` // nil when no backup was ever taken
+	WritesSinceBackup int    `json:"writes_since_backup"`      // certificate rows inserted, updated or deleted
+	MaxWrites         int    `json:"max_writes"`               // threshold; 0 disables the policy
+	Stale             bool   `json:"stale"`                    // certificates exist and the threshold is reached
+	Blocking          bool   `json:"blocking"`                 // risky operations are refused until a backup is taken
+}
+
+// ChainCacheStats reports the in-memory cache of issuer certificates fetched
+// via AIA since startup
+type ChainCacheStats struct {
+	Entries    int   `json:"entries"`     // certificates cached now
+	MaxEntries int   `json:"max_entries"` // size cap; the least recently used is evicted beyond it
+	TTLSeconds int64 `json:"ttl_seconds"` // age at which an entry is fetched again; 0 disables the cache
+	Hits       int64 `json:"hits"`
+	Misses     int64 `json:"misses"`    // absent or expired entries
+	Evictions  int64 `json:"evictions"` // entries dropped to honor the size cap
+}
+
+// EntropyCheckResult is the outcome of the randomness self-test run before key
+// generation
+type EntropyCheckResult struct {
+	CheckedAt int64  `json:"checked_at"`      // local Unix time of the last run
+	Passed    bool   `json:"passed"`          // false blocks key generation
+	Runs      int    `json:"runs"`            // self-tests run since startup
+	Error     string `json:"error,omitempty"` // failed test, when not passed
+}
+
+// What takes up space in the database
+const (
+	DatabaseContributorCertificates = "certificates"   // certificates, CSRs, chains and encrypted keys
+	DatabaseContributorHistory      = "history"        // certificate history entries
+	DatabaseContributorUpdates      = "update_history" // application update attempts
+	DatabaseContributorFree         = "free"           // pages left empty by deleted rows
+	DatabaseContributorOther        = "other"          // configuration, unlock methods, presets, checklists
+)
+
+// Database cleanup actions
+const (
+	DatabaseCleanupPruneHistory = "prune_history"        // delete history entries older than a number of days
+	DatabaseCleanupPruneUpdates = "prune_update_history" // delete update history entries older than a number of days
+	DatabaseCleanupCompact      = "compact"              // rebuild the file to release free pages
+)
+
+// DatabaseUsage reports the size of the database against the configured soft
+// quota, and the biggest contributors to it
+type DatabaseUsage struct {
+	SizeBytes    int64                 `json:"size_bytes"`
+	WarnBytes    int64                 `json:"warn_bytes"` // soft quota; 0 disables the warning
+	OverQuota    bool                  `json:"over_quota"`
+	Contributors []DatabaseContributor `json:"contributors"` // largest first
+}
+
+// DatabaseContributor is one category of data in the database
+type DatabaseContributor struct {
+	Name    string `json:"name"` // DatabaseContributor* constant
+	Bytes   int64  `json:"bytes"`
+	Rows    int64  `json:"rows"`              // 0 for free pages and other data
+	Cleanup string `json:"cleanup,omitempty"` // DatabaseCleanup* action that shrinks it, if any
+}
+
+// DatabaseCleanupResult is the outcome of a database cleanup action
+type DatabaseCleanupResult struct {
+	Action      string `json:"action"`
+	RowsDeleted int64  `json:"rows_deleted"`
+	SizeBefore  int64  `json:"size_before"`
+	SizeAfter   int64  `json:"size_after"`
+}
+
+// OperationTiming summarizes how long one kind of operation took since startup
+type OperationTiming struct {
+	Operation   string `json:"operation"` // timing.* operation name
+	Count       int64  `json:"count"`
+	AvgMs       int64  `json:"avg_ms"`
+	MaxMs       int64  `json:"max_ms"`
+	LastMs      int64  `json:"last_ms"`
+	LastAt      int64  `json:"last_at"`   // Unix time the last run ended
+	LastSlow    bool   `json:"last_slow"` // the last run exceeded the threshold
+	SlowCount   int64  `json:"slow_count"`
+	ThresholdMs int64  `json:"threshold_ms"` // runs at or above this are slow
+}
+
+// SlowOperation is one run of an operation that exceeded its threshold
+type SlowOperation struct {
+	Operation  string `json:"operation"`
+	DurationMs int64  `json:"duration_ms"`
+	At         int64  `json:"at"` // Unix time the run ended
+}
+
+// Health check statuses, from best to worst. A skipped check could not run
+// because an earlier one failed.
+const (
+	HealthcheckOK       = "ok"
+	HealthcheckSkipped  = "skipped"
+	HealthcheckWarning  = "warning"
+	HealthcheckCritical = "critical"
+)
+
+// HealthcheckResult is one check of the --healthcheck probe
+type HealthcheckResult struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"` // ok, skipped, warning or critical
+	Message string `json:"message"`
+}
+
+// HealthcheckReport is what the --healthcheck probe prints for endpoint
+// management and fleet monitoring
+type HealthcheckReport struct {
+	Status            string              `json:"status"`    // worst status of the checks
+	ExitCode          int                 `json:"exit_code"` // 0 ok, 1 warning, 2 critical, 3 probe failed
+	Version           string              `json:"version"`
+	CheckedAt         int64               `json:"checked_at"`
+	DataDir           string              `json:"data_dir"` // directory of the profile checked
+	Profile           string              `json:"profile"`
+	SchemaVersion     uint                `json:"schema_version"`
+	Configured        bool                `json:"configured"`
+	CertificateCount  int                 `json:"certificate_count"`
+	BackupFreshness   *BackupFreshness    `json:"backup_freshness,omitempty"`     // nil before setup
+	LastLocalBackupAt *int64              `json:"last_local_backup_at,omitempty"` // newest auto or manual backup
+	Checks            []HealthcheckResult `json:"checks"`
+}
@@ -7,6 +7,14 @@ type HistoryEntry struct {
 	EventType string `json:"event_type"`
 	Message   string `json:"message"`
 	CreatedAt int64  `json:"created_at"`
+	// Actor is the OS username that performed the action (empty for entries
+	// recorded before actors were tracked)
+	Actor string `json:"actor"`
+	// AppVersion is the application version that recorded the entry
+	AppVersion string `json:"app_version"`
+	// Details holds event-specific structured data, e.g. old and new expiry
+	// dates on a renewal. Nil when the event has no details.
+	Details map[string]any `json:"details,omitempty"`
 }
 
 // Event type constants
@@ -20,4 +28,51 @@ const (
 	EventReadOnlyEnabled       = "readonly_enabled"
 	EventReadOnlyDisabled      = "readonly_disabled"
 	EventPendingCSRRemoved     = "pending_csr_removed"
+	EventHostnameMerged        = "hostname_merged"
+	EventShareBundleCreated    = "share_bundle_created"
+	EventKeyLinked             = "key_linked"
+	EventRenewalStepCompleted  = "renewal_step_completed"
+	EventRenewalStepReopened   = "renewal_step_reopened"
+	EventCSRSubmitted          = "csr_submitted"
+	EventFullExport            = "full_export"
+	EventKeySyncedToAgent      = "key_synced_to_agent"
 )
+
+// HistoryFilter narrows the history listed across all certificates
+type HistoryFilter struct {
+	EventTypes []string `json:"event_types,omitempty"` // Event* constants; empty for all
+	From       int64    `json:"from,omitempty"`        // Unix seconds, inclusive (0 for no bound)
+	To         int64    `json:"to,omitempty"`          // Unix seconds, exclusive (0 for no bound)
+}
+
+// HistoryPage is one page of history entries across all certificates
+type HistoryPage struct {
+	Entries []HistoryEntry `json:"entries"`
+	Total   int            `json:"total"` // Entries matching the filter, across all pages
+}
+
+// CALeadTime is how long one CA took to issue certificates, measured from
+// CSR generation to the upload that activated the certificate
+type CALeadTime struct {
+	Issuer      string  `json:"issuer"` // Issuer common name, UnknownIssuer when not recorded
+	Samples     int     `json:"samples"`
+	AverageDays float64 `json:"average_days"`
+	MedianDays  float64 `json:"median_days"`
+	MinDays     float64 `json:"min_days"`
+	MaxDays     float64 `json:"max_days"`
+	// RecommendedWarningDays is an "expiring soon" threshold leaving time
+	// for a slow issuance: twice the average plus three days, and never less
+	// than the slowest issuance seen
+	RecommendedWarningDays int `json:"recommended_warning_days"`
+}
+
+// UnknownIssuer groups lead times whose issuing CA was not recorded
+const UnknownIssuer = "Unknown CA"
+
+// LeadTimeReport is the CSR-to-activation lead time of each CA, from history
+type LeadTimeReport struct {
+	Issuers               []CALeadTime `json:"issuers"`           // Most samples first
+	Overall               *CALeadTime  `json:"overall,omitempty"` // Nil when nothing was measured
+	Pending               int          `json:"pending"`           // CSRs still awaiting a certificate
+	ExpiringThresholdDays int          `json:"expiring_threshold_days"`
+}
@@ -0,0 +1,21 @@
+package models
+
+// CertificateRequester is the colleague who asked for a certificate through a
+// request (intake) file, kept with the certificate
+type CertificateRequester struct {
+	Name          string `json:"name"`
+	Email         string `json:"email,omitempty"`
+	Team          string `json:"team,omitempty"`
+	Justification string `json:"justification,omitempty"`
+	RecordedAt    int64  `json:"recorded_at,omitempty"` // when the CSR was generated from the request
+}
+
+// CSRIntake is a checked request file, ready to pre-fill the CSR form. The
+// form sends Requester back in CSRRequest.Requester.
+type CSRIntake struct {
+	Hostname  string               `json:"hostname"`
+	SANs      []SANEntry           `json:"sans,omitempty"`
+	Requester CertificateRequester `json:"requester"`
+	// A certificate already exists for the hostname: the request is a renewal
+	IsRenewal bool `json:"is_renewal"`
+}
@@ -0,0 +1,24 @@
+package models
+
+// Operations recorded as write-ahead intents before their first step
+const (
+	IntentUploadCertificate = "upload_certificate"
+)
+
+// Outcomes of an operation found unfinished at startup
+const (
+	IntentCompleted  = "completed"   // every step had been committed; only the intent was left
+	IntentRolledBack = "rolled_back" // no step had been committed; the operation must be run again
+	IntentUnknown    = "unknown"     // operation not known to this version; the intent is dropped
+)
+
+// RecoveredOperation is an operation the process did not finish (crash, power
+// loss, kill), found and resolved at startup
+type RecoveredOperation struct {
+	ID        int64  `json:"id"`
+	Operation string `json:"operation"` // Intent* constant
+	Hostname  string `json:"hostname"`
+	StartedAt int64  `json:"started_at"` // Unix time the intent was recorded
+	Outcome   string `json:"outcome"`    // Intent* outcome constant
+	Detail    string `json:"detail"`
+}
@@ -5,3 +5,10 @@ type KeyValidationResult struct {
 	Valid           bool     `json:"valid"`
 	FailedHostnames []string `json:"failed_hostnames,omitempty"`
 }
+
+// KeyValidationProgress reports the progress of a background validation of every
+// stored private key against the master key
+type KeyValidationProgress struct {
+	Checked int `json:"checked"`
+	Total   int `json:"total"`
+}
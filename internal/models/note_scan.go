@@ -0,0 +1,21 @@
+package models
+
+// SecretFinding is something in a note that looks like a secret
+type SecretFinding struct {
+	Kind        string `json:"kind"`        // "private_key", "password" or "high_entropy"
+	Description string `json:"description"` // Human-readable reason
+	Excerpt     string `json:"excerpt"`     // Masked excerpt locating the match
+}
+
+// NoteSecretReport lists the suspected secrets in one note of a certificate
+type NoteSecretReport struct {
+	Hostname string          `json:"hostname"`
+	Field    string          `json:"field"` // "note" or "pending_note"
+	Findings []SecretFinding `json:"findings"`
+}
+
+// NoteScanResult is the outcome of scanning every stored note for secrets
+type NoteScanResult struct {
+	Scanned int                `json:"scanned"` // Number of non-empty notes checked
+	Reports []NoteSecretReport `json:"reports"`
+}
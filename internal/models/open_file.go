@@ -0,0 +1,23 @@
+package models
+
+// Kinds of files the app can be handed by the OS (file associations, or a
+// double-click while running)
+const (
+	OpenedFileBackup      = "backup"      // SQLite database backup or export (.pcbackup, .db)
+	OpenedFileCertificate = "certificate" // PEM file with a certificate and/or private key (.crt, .pem)
+	OpenedFileCSR         = "csr"         // PEM certificate signing request (.csr)
+)
+
+// OpenedFile is a file handed to the app, announced to the frontend so it can
+// show the matching import, restore or upload preview.
+type OpenedFile struct {
+	Path           string `json:"path"`
+	Kind           string `json:"kind"`
+	CertificatePEM string `json:"certificate_pem,omitempty"` // first certificate of a PEM file
+	ChainPEM       string `json:"chain_pem,omitempty"`       // the certificates after it
+	PrivateKeyPEM  string `json:"private_key_pem,omitempty"`
+	CSRPEM         string `json:"csr_pem,omitempty"`
+	// Hostname whose pending CSR holds the public key of a certificate without
+	// private key, or of a CSR (empty when none matches)
+	Hostname string `json:"hostname,omitempty"`
+}
@@ -0,0 +1,9 @@
+package models
+
+// Profile is a separate database, with its own configuration, keys and
+// backups, e.g. one per CA or environment
+type Profile struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`   // Directory holding the database and backups
+	Active bool   `json:"active"` // The profile currently open
+}
@@ -0,0 +1,35 @@
+package models
+
+// Relation types between certificates
+const (
+	// RelationClientOf: Source is a client certificate talking to the server
+	// that uses Target
+	RelationClientOf = "client_of"
+	// RelationSharedEndpoint: Source and Target are served by the same
+	// endpoint (a load balancer, a reverse proxy), named by the label. The
+	// relation has no direction.
+	RelationSharedEndpoint = "shared_endpoint"
+)
+
+// RelationTypes lists the relation types between certificates
+var RelationTypes = []string{
+	RelationClientOf,
+	RelationSharedEndpoint,
+}
+
+// CertificateRelation is a declared dependency between two certificates
+type CertificateRelation struct {
+	ID        int64  `json:"id"`
+	Source    string `json:"source"`
+	Target    string `json:"target"`
+	Type      string `json:"type"`            // client_of or shared_endpoint
+	Label     string `json:"label,omitempty"` // e.g. the shared load balancer
+	CreatedAt int64  `json:"created_at"`
+}
+
+// CertificateGraph is every certificate that takes part in a relation (the
+// nodes) and the relations between them (the edges)
+type CertificateGraph struct {
+	Nodes []*CertificateListItem `json:"nodes"`
+	Edges []CertificateRelation  `json:"edges"`
+}
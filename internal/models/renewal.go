@@ -0,0 +1,35 @@
+package models
+
+// Renewal checklist steps, in the order a renewal goes through them
+const (
+	RenewalStepCSRSent      = "csr_sent"
+	RenewalStepCertReceived = "cert_received"
+	RenewalStepUploaded     = "uploaded"
+	RenewalStepDeployed     = "deployed"
+	RenewalStepVerified     = "verified"
+)
+
+// RenewalSteps lists the renewal checklist steps in order
+var RenewalSteps = []string{
+	RenewalStepCSRSent,
+	RenewalStepCertReceived,
+	RenewalStepUploaded,
+	RenewalStepDeployed,
+	RenewalStepVerified,
+}
+
+// RenewalChecklistItem is the state of one renewal step
+type RenewalChecklistItem struct {
+	Step        string `json:"step"`
+	Done        bool   `json:"done"`
+	CompletedAt int64  `json:"completed_at,omitempty"` // Unix seconds, 0 while not done
+	Actor       string `json:"actor,omitempty"`        // OS username that completed the step
+}
+
+// RenewalChecklist is where the current renewal of a certificate stands. It is
+// cleared when a new renewal CSR is generated.
+type RenewalChecklist struct {
+	Hostname  string                 `json:"hostname"`
+	Items     []RenewalChecklistItem `json:"items"` // Every step, in RenewalSteps order
+	Completed int                    `json:"completed"`
+}
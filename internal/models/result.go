@@ -0,0 +1,28 @@
+package models
+
+import "fmt"
+
+// Warnings lists the caveats of an operation that succeeded: the result is
+// usable, but the operator should know (SANs the CA dropped, no chain
+// bundled, suffix check skipped). Results embed it so the frontend shows
+// warnings the same way for every binding. Never nil, so it encodes as [].
+type Warnings struct {
+	Warnings []string `json:"warnings"`
+}
+
+// NewWarnings returns an empty warning list
+func NewWarnings() Warnings {
+	return Warnings{Warnings: []string{}}
+}
+
+// Warn records a warning
+func (w *Warnings) Warn(format string, args ...any) {
+	w.Warnings = append(w.Warnings, fmt.Sprintf(format, args...))
+}
+
+// CertificateResult is returned by bindings that have no other result than the
+// certificate they acted on and their warnings
+type CertificateResult struct {
+	Hostname string `json:"hostname"`
+	Warnings
+}
@@ -0,0 +1,21 @@
+package models
+
+// How a quick search result matched the query, best first
+const (
+	QuickMatchExact     = "exact"     // the hostname is the query
+	QuickMatchPrefix    = "prefix"    // the hostname, or one of its labels, starts with the query
+	QuickMatchSubstring = "substring" // the hostname contains the query
+	QuickMatchSAN       = "san"       // one of the certificate's SANs matches the query
+	QuickMatchFuzzy     = "fuzzy"     // the query's characters appear in order in the hostname
+)
+
+// QuickSearchResult is one certificate matching a quick-open query, for a
+// command-palette style search
+type QuickSearchResult struct {
+	Hostname        string `json:"hostname"`
+	DisplayHostname string `json:"display_hostname"` // unicode form of an IDN hostname
+	Status          string `json:"status"`
+	Match           string `json:"match"`                 // QuickMatch* constant
+	MatchedSAN      string `json:"matched_san,omitempty"` // set when Match is "san"
+	Score           int    `json:"score"`                 // higher ranks first
+}
@@ -1,11 +1,12 @@
 package models
 
 // SecurityKeyMethod constants for unlock method types.
-// (The DB CHECK still allows the deprecated "os_native" value, but the app no
-// longer uses it — only password and passkey/FIDO2.)
 const (
 	SecurityKeyMethodPassword = "password"
 	SecurityKeyMethodFIDO2    = "fido2"
+	// SecurityKeyMethodOSNative unlocks without a prompt on a trusted machine:
+	// the wrapping key is kept by the OS (DPAPI, Secret Service).
+	SecurityKeyMethodOSNative = "os_native"
 )
 
 // SecurityKeyInfo is the frontend-safe representation of a security key.
@@ -35,3 +36,11 @@ type WebAuthnMetadata struct {
 	Salt         []byte   `json:"salt"`
 	Transports   []string `json:"transports,omitempty"`
 }
+
+// OSNativeMetadata locates the wrapping key of an "os_native" method, stored as
+// JSON in security_keys.metadata. Ref is the keystore's reference (the DPAPI
+// blob, or the Secret Service item id); Backend names the store that made it.
+type OSNativeMetadata struct {
+	Backend string `json:"backend"`
+	Ref     []byte `json:"ref"`
+}
@@ -0,0 +1,30 @@
+package models
+
+// SessionActivityEntry is one operation performed during the current session
+type SessionActivityEntry struct {
+	Operation string `json:"operation"`          // Binding operation name, e.g. "upload_certificate"
+	Hostname  string `json:"hostname,omitempty"` // Empty for operations not tied to one certificate
+	Timestamp int64  `json:"timestamp"`          // Unix seconds
+	Success   bool   `json:"success"`
+	Error     string `json:"error,omitempty"`
+}
+
+// SessionActivity lists the operations performed since the app was last
+// unlocked, oldest first
+type SessionActivity struct {
+	StartedAt int64                  `json:"started_at"` // Unlock time (0 if never unlocked)
+	Entries   []SessionActivityEntry `json:"entries"`
+	Truncated bool                   `json:"truncated"` // Older entries were dropped
+}
+
+// SessionState is a consistent snapshot of the app's setup and unlock state,
+// read under a single lock so the frontend does not race between separate calls
+type SessionState struct {
+	Configured              bool   `json:"configured"`
+	Unlocked                bool   `json:"unlocked"`
+	WaitingForEncryptionKey bool   `json:"waiting_for_encryption_key"`
+	MigrationNeeded         bool   `json:"migration_needed"` // Legacy-format keys will be migrated at the next unlock
+	LimitedMode             bool   `json:"limited_mode"`     // Configured but locked: read-only features only
+	Profile                 string `json:"profile"`          // Name of the open profile
+	Version                 string `json:"version"`
+}
@@ -0,0 +1,32 @@
+package models
+
+// SyncAgent is a companion agent enrolled to pull its assigned certificates
+// and private keys from the vault over mutual TLS
+type SyncAgent struct {
+	ID              int64    `json:"id"`
+	Name            string   `json:"name"`             // e.g. "web01"
+	Hostnames       []string `json:"hostnames"`        // Certificates the agent may pull
+	CertFingerprint string   `json:"cert_fingerprint"` // SHA-256 of its client certificate
+	ExpiresAt       int64    `json:"expires_at"`       // When its client certificate expires
+	CreatedAt       int64    `json:"created_at"`
+	RevokedAt       *int64   `json:"revoked_at,omitempty"`
+	LastSeenAt      *int64   `json:"last_seen_at,omitempty"`
+}
+
+// SyncAgentEnrollment is what an agent is installed with. It is only returned
+// at enrollment: the vault does not keep the agent's private key.
+type SyncAgentEnrollment struct {
+	Agent            SyncAgent `json:"agent"`
+	CertificatePEM   string    `json:"certificate_pem"`
+	PrivateKeyPEM    string    `json:"private_key_pem"`
+	CACertificatePEM string    `json:"ca_certificate_pem"` // Verifies the sync server
+}
+
+// SyncServerStatus describes the server agents pull from
+type SyncServerStatus struct {
+	Running bool `json:"running"`
+	// Address the server listens on, or is restarted on at the next unlock
+	// while stopped by a lock ('' when turned off)
+	ListenAddress    string `json:"listen_address"`
+	CACertificatePEM string `json:"ca_certificate_pem,omitempty"` // '' until the first enrollment
+}
@@ -0,0 +1,95 @@
+package noterefs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Kinds of reference found in a note
+const (
+	KindURL    = "url"
+	KindTicket = "ticket"
+)
+
+// maxTicketPatternLength bounds the configurable ticket ID pattern
+const maxTicketPatternLength = 256
+
+// urlPattern finds http(s) URLs in free text. Brackets, quotes and angle
+// brackets end a URL so markdown links ("[doc](https://...)") and quoted
+// URLs are extracted without their delimiters.
+var urlPattern = regexp.MustCompile(`(?i)\bhttps?://[^\s<>"'()\[\]{}]+`)
+
+// Reference is a URL or ticket ID found in a note
+type Reference struct {
+	Kind  string
+	Value string
+}
+
+// CompileTicketPattern compiles the configured ticket ID pattern (a Go regular
+// expression such as `CHG\d{7}`). An empty pattern returns nil: only URLs are
+// extracted. A pattern that matches the empty string is refused since it would
+// match everywhere.
+func CompileTicketPattern(pattern string) (*regexp.Regexp, error) {
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return nil, nil
+	}
+	if len(pattern) > maxTicketPatternLength {
+		return nil, fmt.Errorf("ticket pattern must not exceed %d characters", maxTicketPatternLength)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ticket pattern: %w", err)
+	}
+	if re.MatchString("") {
+		return nil, fmt.Errorf("ticket pattern must not match an empty string")
+	}
+	return re, nil
+}
+
+// Extract returns the URLs and, when ticket is not nil, the ticket IDs found
+// in text, in order of first appearance and without duplicates. Ticket IDs
+// inside a URL are not reported separately.
+func Extract(text string, ticket *regexp.Regexp) []Reference {
+	var refs []Reference
+	seen := make(map[Reference]bool)
+	add := func(kind, value string) {
+		ref := Reference{Kind: kind, Value: value}
+		if value == "" || seen[ref] {
+			return
+		}
+		seen[ref] = true
+		refs = append(refs, ref)
+	}
+
+	urls := urlPattern.FindAllStringIndex(text, -1)
+	for _, loc := range urls {
+		add(KindURL, trimURL(text[loc[0]:loc[1]]))
+	}
+
+	if ticket != nil {
+		for _, loc := range ticket.FindAllStringIndex(text, -1) {
+			if insideAny(loc, urls) {
+				continue
+			}
+			add(KindTicket, text[loc[0]:loc[1]])
+		}
+	}
+	return refs
+}
+
+// trimURL drops punctuation that ends a sentence rather than the URL
+func trimURL(url string) string {
+	return strings.TrimRight(url, ".,;:!?*_~")
+}
+
+// insideAny reports whether the match at loc lies within one of spans
+func insideAny(loc []int, spans [][]int) bool {
+	for _, span := range spans {
+		if loc[0] >= span[0] && loc[1] <= span[1] {
+			return true
+		}
+	}
+	return false
+}
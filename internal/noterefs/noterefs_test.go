@@ -0,0 +1,41 @@
+package noterefs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtract(t *testing.T) {
+	ticket, err := CompileTicketPattern(`CHG\d{7}`)
+	if err != nil {
+		t.Fatalf("CompileTicketPattern() error: %v", err)
+	}
+
+	text := "Renewed under CHG0012345, see [runbook](https://wiki.example.com/pki/renew).\n" +
+		"Old ticket: https://itsm.example.com/change/CHG0000001. Again CHG0012345 and http://intranet/a?b=c!"
+	got := Extract(text, ticket)
+	want := []Reference{
+		{Kind: KindURL, Value: "https://wiki.example.com/pki/renew"},
+		{Kind: KindURL, Value: "https://itsm.example.com/change/CHG0000001"},
+		{Kind: KindURL, Value: "http://intranet/a?b=c"},
+		{Kind: KindTicket, Value: "CHG0012345"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Extract() = %+v, want %+v", got, want)
+	}
+
+	if got := Extract("CHG0012345 only", nil); len(got) != 0 {
+		t.Errorf("Extract() without a ticket pattern = %+v, want none", got)
+	}
+}
+
+func TestCompileTicketPattern(t *testing.T) {
+	if re, err := CompileTicketPattern("  "); re != nil || err != nil {
+		t.Errorf("empty pattern: got %v, %v; want nil, nil", re, err)
+	}
+	for _, bad := range []string{`CHG[`, `\d*`, `(INC|)`} {
+		if _, err := CompileTicketPattern(bad); err == nil {
+			t.Errorf("CompileTicketPattern(%q): expected an error", bad)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+// Package notify shows desktop notifications with the native mechanism of
+// each platform: the freedesktop notification service on Linux, Notification
+// Center on macOS and toast notifications on Windows.
+package notify
+
+// AppName is shown as the notification source where the platform allows it
+const AppName = "PaddockControl"
+
+// Send shows a desktop notification. It returns an error when the platform
+// has no notification service available (e.g. a Linux session without one).
+func Send(title, body string) error {
+	return send(title, body)
+}
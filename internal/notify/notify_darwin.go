@@ -0,0 +1,18 @@
+//go:build darwin
+
+package notify
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+)
+
+func send(title, body string) error {
+	// strconv.Quote escapes quotes and backslashes the way AppleScript expects
+	script := fmt.Sprintf("display notification %s with title %s", strconv.Quote(body), strconv.Quote(title))
+	if out, err := exec.Command("osascript", "-e", script).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to send notification: %w: %s", err, out)
+	}
+	return nil
+}
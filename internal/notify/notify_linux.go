@@ -0,0 +1,27 @@
+//go:build linux
+
+package notify
+
+import (
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// expireTimeout lets the notification server pick how long it stays visible
+const expireTimeout = int32(-1)
+
+func send(title, body string) error {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return fmt.Errorf("failed to connect to the session bus: %w", err)
+	}
+
+	obj := conn.Object("org.freedesktop.Notifications", "/org/freedesktop/Notifications")
+	call := obj.Call("org.freedesktop.Notifications.Notify", 0,
+		AppName, uint32(0), "", title, body, []string{}, map[string]dbus.Variant{}, expireTimeout)
+	if call.Err != nil {
+		return fmt.Errorf("failed to send notification: %w", call.Err)
+	}
+	return nil
+}
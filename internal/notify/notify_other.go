@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !windows
+
+package notify
+
+import "errors"
+
+func send(title, body string) error {
+	return errors.New("desktop notifications are not supported on this platform")
+}
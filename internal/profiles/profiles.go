@@ -0,0 +1,194 @@
+// Package profiles lays out the data directory of an installation managing
+// several CAs or environments: each profile has its own database, with its
+// own configuration and keys, and its own backups under
+// <data_dir>/profiles/<name>. Logs stay in the data directory, shared by all
+// profiles. The profile opened last is recorded in <data_dir>/active_profile.
+package profiles
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// DefaultName is the profile of a new installation, and the one an
+// installation from before profiles is moved to
+const DefaultName = "default"
+
+const (
+	// profilesDir holds one directory per profile
+	profilesDir = "profiles"
+	// activeFile records the profile to open at startup
+	activeFile = "active_profile"
+	// databaseFile is the database of a profile
+	databaseFile = "certificates.db"
+	// maxNameLength bounds profile names, which are directory names
+	maxNameLength = 64
+)
+
+// namePattern allows names like "Production CA" or "lab-2": letters, digits,
+// spaces, dots, dashes and underscores, starting with a letter or digit and
+// not ending with a space or a dot (which Windows drops from file names)
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9 ._-]*[A-Za-z0-9_-])?$`)
+
+// reservedNames cannot be used as file names on Windows
+var reservedNames = []string{
+	"con", "prn", "aux", "nul",
+	"com1", "com2", "com3", "com4", "com5", "com6", "com7", "com8", "com9",
+	"lpt1", "lpt2", "lpt3", "lpt4", "lpt5", "lpt6", "lpt7", "lpt8", "lpt9",
+}
+
+// ErrExists is returned by Create when a profile of that name exists,
+// compared case-insensitively
+var ErrExists = errors.New("profile already exists")
+
+// ErrNotFound is returned for a profile that has no directory
+var ErrNotFound = errors.New("profile not found")
+
+// ValidateName checks that name can be used as a profile directory name
+func ValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("profile name is required")
+	}
+	if len(name) > maxNameLength {
+		return fmt.Errorf("profile name must be at most %d characters", maxNameLength)
+	}
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("profile name may only contain letters, digits, spaces, dots, dashes and underscores, and must start with a letter or digit")
+	}
+	if slices.Contains(reservedNames, strings.ToLower(name)) {
+		return fmt.Errorf("%q is reserved and cannot be used as a profile name", name)
+	}
+	return nil
+}
+
+// Dir returns the directory of profile name
+func Dir(root, name string) string {
+	return filepath.Join(root, profilesDir, name)
+}
+
+// List returns the names of the profiles, sorted case-insensitively.
+// Directories that are not valid profile names are ignored.
+func List(root string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(root, profilesDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list profiles: %w", err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() && ValidateName(entry.Name()) == nil {
+			names = append(names, entry.Name())
+		}
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	return names, nil
+}
+
+// Exists reports whether profile name has a directory
+func Exists(root, name string) bool {
+	if ValidateName(name) != nil {
+		return false
+	}
+	info, err := os.Stat(Dir(root, name))
+	return err == nil && info.IsDir()
+}
+
+// HasDatabase reports whether profile name has a database, i.e. was opened
+// at least once
+func HasDatabase(root, name string) bool {
+	_, err := os.Stat(filepath.Join(Dir(root, name), databaseFile))
+	return err == nil
+}
+
+// Create makes the directory of a new profile. Its database is created, and
+// set up, the first time it is opened.
+func Create(root, name string) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+	existing, err := List(root)
+	if err != nil {
+		return err
+	}
+	for _, other := range existing {
+		if strings.EqualFold(other, name) {
+			return fmt.Errorf("%w: %s", ErrExists, other)
+		}
+	}
+	if err := os.MkdirAll(Dir(root, name), 0700); err != nil {
+		return fmt.Errorf("failed to create profile directory: %w", err)
+	}
+	return nil
+}
+
+// Active returns the profile to open: the one recorded by SetActive when it
+// still exists, DefaultName otherwise
+func Active(root string) string {
+	data, err := os.ReadFile(filepath.Join(root, activeFile))
+	if err != nil {
+		return DefaultName
+	}
+	name := strings.TrimSpace(string(data))
+	if !Exists(root, name) {
+		return DefaultName
+	}
+	return name
+}
+
+// SetActive records name as the profile to open at the next startup
+func SetActive(root, name string) error {
+	if !Exists(root, name) {
+		return fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	path := filepath.Join(root, activeFile)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(name+"\n"), 0600); err != nil {
+		return fmt.Errorf("failed to record the active profile: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to record the active profile: %w", err)
+	}
+	return nil
+}
+
+// MigrateLegacyLayout moves the database and backups of an installation from
+// before profiles, kept directly in root, into the default profile. It does
+// nothing when root has no database or the default profile already has one,
+// and reports whether anything was moved.
+func MigrateLegacyLayout(root string) (bool, error) {
+	legacyDB := filepath.Join(root, databaseFile)
+	if _, err := os.Stat(legacyDB); err != nil {
+		return false, nil
+	}
+	if HasDatabase(root, DefaultName) {
+		return false, nil
+	}
+
+	dir := Dir(root, DefaultName)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return false, fmt.Errorf("failed to create default profile: %w", err)
+	}
+	// The WAL files go first: a database moved without them would lose the
+	// transactions not yet checkpointed
+	for _, name := range []string{databaseFile + "-wal", databaseFile + "-shm", "backups", databaseFile} {
+		from := filepath.Join(root, name)
+		if _, err := os.Stat(from); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err := os.Rename(from, filepath.Join(dir, name)); err != nil {
+			return false, fmt.Errorf("failed to move %s to the default profile: %w", name, err)
+		}
+	}
+	return true, nil
+}
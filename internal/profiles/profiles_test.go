@@ -0,0 +1,128 @@
+package profiles
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestValidateName(t *testing.T) {
+	valid := []string{"default", "Production CA", "lab-2", "eu_west.1", "A"}
+	for _, name := range valid {
+		if err := ValidateName(name); err != nil {
+			t.Errorf("ValidateName(%q) error = %v", name, err)
+		}
+	}
+
+	invalid := []string{"", " lab", "lab ", "lab.", ".hidden", "../escape", "a/b", `a\b`, "CON", "lpt1", strings.Repeat("a", maxNameLength+1)}
+	for _, name := range invalid {
+		if err := ValidateName(name); err == nil {
+			t.Errorf("ValidateName(%q) succeeded, want an error", name)
+		}
+	}
+}
+
+func TestCreateListActive(t *testing.T) {
+	root := t.TempDir()
+
+	if names, err := List(root); err != nil || len(names) != 0 {
+		t.Fatalf("List() = %v, %v on an empty root", names, err)
+	}
+	if got := Active(root); got != DefaultName {
+		t.Errorf("Active() = %q, want %q without a record", got, DefaultName)
+	}
+
+	for _, name := range []string{"default", "Production CA", "lab"} {
+		if err := Create(root, name); err != nil {
+			t.Fatalf("Create(%q) error = %v", name, err)
+		}
+	}
+	if err := Create(root, "LAB"); !errors.Is(err, ErrExists) {
+		t.Errorf("Create(LAB) error = %v, want ErrExists", err)
+	}
+	if err := Create(root, "../lab"); err == nil {
+		t.Error("Create(../lab) succeeded, want an error")
+	}
+
+	// Stray files and invalid directory names are not profiles
+	if err := os.WriteFile(filepath.Join(root, profilesDir, "notes.txt"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(root, profilesDir, ".cache"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	names, err := List(root)
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if want := []string{"default", "lab", "Production CA"}; !slices.Equal(names, want) {
+		t.Errorf("List() = %v, want %v", names, want)
+	}
+
+	if err := SetActive(root, "lab"); err != nil {
+		t.Fatalf("SetActive() error = %v", err)
+	}
+	if got := Active(root); got != "lab" {
+		t.Errorf("Active() = %q, want lab", got)
+	}
+	if err := SetActive(root, "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("SetActive(missing) error = %v, want ErrNotFound", err)
+	}
+
+	// A recorded profile whose directory was removed falls back to the default
+	if err := os.RemoveAll(Dir(root, "lab")); err != nil {
+		t.Fatal(err)
+	}
+	if got := Active(root); got != DefaultName {
+		t.Errorf("Active() = %q after removing lab, want %q", got, DefaultName)
+	}
+}
+
+func TestMigrateLegacyLayout(t *testing.T) {
+	root := t.TempDir()
+
+	if moved, err := MigrateLegacyLayout(root); err != nil || moved {
+		t.Fatalf("MigrateLegacyLayout() = %v, %v without a legacy database", moved, err)
+	}
+
+	for _, name := range []string{databaseFile, databaseFile + "-wal"} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(name), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(root, "backups"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "backups", "backup.db"), nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	moved, err := MigrateLegacyLayout(root)
+	if err != nil || !moved {
+		t.Fatalf("MigrateLegacyLayout() = %v, %v, want the legacy layout moved", moved, err)
+	}
+	dir := Dir(root, DefaultName)
+	for _, name := range []string{databaseFile, databaseFile + "-wal", filepath.Join("backups", "backup.db")} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("%s not moved to the default profile: %v", name, err)
+		}
+		if _, err := os.Stat(filepath.Join(root, name)); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s still in the data directory", name)
+		}
+	}
+
+	// A database reappearing in root never overwrites the default profile
+	if err := os.WriteFile(filepath.Join(root, databaseFile), []byte("stale"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if moved, err := MigrateLegacyLayout(root); err != nil || moved {
+		t.Fatalf("MigrateLegacyLayout() = %v, %v with a default profile database", moved, err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, databaseFile))
+	if err != nil || string(data) != databaseFile {
+		t.Errorf("default profile database = %q, %v, want it untouched", data, err)
+	}
+}
@@ -0,0 +1,228 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/hostnames"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// AuditService records security-sensitive operations (unlocks, key exports,
+// password changes, restores, resets, sync agent enrollments) in the audit log. Unlike the certificate
+// history, the log is append-only: nothing rewrites or cleans it up, and it is
+// part of every backup since backups copy the database.
+type AuditService struct {
+	db         *db.Database
+	actor      string
+	appVersion string
+}
+
+// NewAuditService creates a new audit service. Every entry it records is
+// stamped with the current OS username and the given application version.
+func NewAuditService(database *db.Database, appVersion string) *AuditService {
+	return &AuditService{
+		db:         database,
+		actor:      currentActor(),
+		appVersion: appVersion,
+	}
+}
+
+// Record appends an entry to the audit log. hostname is empty for operations
+// not tied to one certificate; details are stored as JSON.
+func (s *AuditService) Record(ctx context.Context, eventType, hostname, message string, details map[string]any) error {
+	var encoded string
+	if len(details) > 0 {
+		data, err := json.Marshal(details)
+		if err != nil {
+			return fmt.Errorf("failed to encode audit details: %w", err)
+		}
+		encoded = string(data)
+	}
+
+	if err := s.db.Queries().AddAuditEntry(ctx, sqlc.AddAuditEntryParams{
+		EventType:  eventType,
+		Hostname:   hostname,
+		Message:    message,
+		Actor:      s.actor,
+		AppVersion: s.appVersion,
+		Details:    encoded,
+	}); err != nil {
+		return fmt.Errorf("failed to record audit entry: %w", err)
+	}
+	return nil
+}
+
+// List returns one page of the audit log, most recent first, with the number
+// of entries matching the filter. A non-positive limit defaults to 50.
+func (s *AuditService) List(ctx context.Context, filter models.AuditFilter, limit, offset int) (*models.AuditLogPage, error) {
+	params, err := auditFilterParams(filter)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	limit = min(limit, maxHistoryPageSize)
+	offset = max(offset, 0)
+
+	total, err := s.db.Queries().CountAuditLog(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count audit log: %w", err)
+	}
+	entries, err := s.db.Queries().ListAuditLog(ctx, sqlc.ListAuditLogParams{
+		EventTypes:  params.EventTypes,
+		Hostname:    params.Hostname,
+		CreatedFrom: params.CreatedFrom,
+		CreatedTo:   params.CreatedTo,
+		PageLimit:   int64(limit),
+		PageOffset:  int64(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit log: %w", err)
+	}
+
+	page := &models.AuditLogPage{
+		Entries: make([]models.AuditEntry, len(entries)),
+		Total:   int(total),
+	}
+	for i, e := range entries {
+		page.Entries[i] = toAuditEntry(e)
+	}
+	return page, nil
+}
+
+// BuildCSV renders every audit log entry matching the filter as CSV, most
+// recent first. Timestamps are RFC 3339 in UTC and details are JSON.
+func (s *AuditService) BuildCSV(ctx context.Context, filter models.AuditFilter) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{"timestamp", "event_type", "hostname", "actor", "app_version", "message", "details"}); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	for offset := 0; ; offset += maxHistoryPageSize {
+		page, err := s.List(ctx, filter, maxHistoryPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range page.Entries {
+			details := ""
+			if len(e.Details) > 0 {
+				data, err := json.Marshal(e.Details)
+				if err != nil {
+					return nil, fmt.Errorf("failed to encode audit details: %w", err)
+				}
+				details = string(data)
+			}
+			if err := w.Write([]string{
+				time.Unix(e.CreatedAt, 0).UTC().Format(time.RFC3339),
+				e.EventType,
+				e.Hostname,
+				csvCell(e.Actor),
+				e.AppVersion,
+				csvCell(e.Message),
+				csvCell(details),
+			}); err != nil {
+				return nil, fmt.Errorf("failed to write CSV: %w", err)
+			}
+		}
+		if len(page.Entries) < maxHistoryPageSize {
+			break
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Entries returns every audit log entry, oldest first. A restore reads them
+// before replacing the database, to carry them into the restored one.
+func (s *AuditService) Entries(ctx context.Context) ([]sqlc.AuditLog, error) {
+	entries, err := s.db.Queries().ListAllAuditEntries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// Carry inserts entries read from another database with their original actor,
+// version and timestamp. Entries the log already holds under the same id, those
+// recorded before the backup being restored was taken, are not duplicated.
+func (s *AuditService) Carry(ctx context.Context, entries []sqlc.AuditLog) error {
+	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		for _, e := range entries {
+			if err := q.CarryAuditEntry(ctx, sqlc.CarryAuditEntryParams{
+				EventType:  e.EventType,
+				Hostname:   e.Hostname,
+				Message:    e.Message,
+				Actor:      e.Actor,
+				AppVersion: e.AppVersion,
+				Details:    e.Details,
+				CreatedAt:  e.CreatedAt,
+				ID:         e.ID,
+			}); err != nil {
+				return fmt.Errorf("failed to carry audit entry: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// auditFilterParams validates an audit filter and converts it to query
+// parameters. Event types and dates follow the history filter rules.
+func auditFilterParams(filter models.AuditFilter) (sqlc.CountAuditLogParams, error) {
+	params, err := historyFilterParams(models.HistoryFilter{
+		EventTypes: filter.EventTypes,
+		From:       filter.From,
+		To:         filter.To,
+	})
+	if err != nil {
+		return sqlc.CountAuditLogParams{}, err
+	}
+
+	hostname := ""
+	if strings.TrimSpace(filter.Hostname) != "" {
+		if hostname, err = hostnames.Normalize(filter.Hostname); err != nil {
+			return sqlc.CountAuditLogParams{}, err
+		}
+	}
+
+	return sqlc.CountAuditLogParams{
+		EventTypes:  params.EventTypes,
+		Hostname:    hostname,
+		CreatedFrom: params.CreatedFrom,
+		CreatedTo:   params.CreatedTo,
+	}, nil
+}
+
+// toAuditEntry converts an audit log row, decoding its details
+func toAuditEntry(e sqlc.AuditLog) models.AuditEntry {
+	entry := models.AuditEntry{
+		ID:         e.ID,
+		EventType:  e.EventType,
+		Hostname:   e.Hostname,
+		Message:    e.Message,
+		Actor:      e.Actor,
+		AppVersion: e.AppVersion,
+		CreatedAt:  e.CreatedAt,
+	}
+	if e.Details != "" {
+		// A malformed value is dropped rather than failing the whole listing
+		var details map[string]any
+		if err := json.Unmarshal([]byte(e.Details), &details); err == nil {
+			entry.Details = details
+		}
+	}
+	return entry
+}
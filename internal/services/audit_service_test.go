@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/models"
+)
+
+func setupAuditService(t *testing.T) (*AuditService, *db.Database) {
+	t.Helper()
+	database, err := db.NewDatabase(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+	return NewAuditService(database, "test"), database
+}
+
+// recordAuditAt records an audit entry and backdates it to createdAt
+func recordAuditAt(t *testing.T, svc *AuditService, database *db.Database, eventType, hostname string, createdAt int64) {
+	t.Helper()
+	ctx := context.Background()
+	if err := svc.Record(ctx, eventType, hostname, eventType, map[string]any{"method": "password"}); err != nil {
+		t.Fatalf("Record failed: %v", err)
+	}
+	if _, err := database.DB().ExecContext(ctx,
+		"UPDATE audit_log SET created_at = ? WHERE id = (SELECT MAX(id) FROM audit_log)", createdAt,
+	); err != nil {
+		t.Fatalf("failed to backdate audit entry: %v", err)
+	}
+}
+
+func TestAuditList_FiltersAndPages(t *testing.T) {
+	svc, database := setupAuditService(t)
+	ctx := context.Background()
+
+	feb := time.Date(2026, 2, 15, 12, 0, 0, 0, time.UTC).Unix()
+	mar1 := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC).Unix()
+	apr1 := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC).Unix()
+	recordAuditAt(t, svc, database, models.AuditUnlockFailed, "", feb)
+	recordAuditAt(t, svc, database, models.AuditUnlock, "", mar1)
+	recordAuditAt(t, svc, database, models.AuditKeyExport, "web.example.com", mar1+3600)
+	recordAuditAt(t, svc, database, models.AuditKeyExport, "api.example.com", apr1)
+
+	page, err := svc.List(ctx, models.AuditFilter{From: mar1, To: apr1}, 0, 0)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if page.Total != 2 || page.Entries[0].EventType != models.AuditKeyExport || page.Entries[1].EventType != models.AuditUnlock {
+		t.Fatalf("expected the 2 March entries, most recent first, got total %d: %+v", page.Total, page.Entries)
+	}
+	if page.Entries[0].Actor == "" && currentActor() != "" {
+		t.Error("expected the entry stamped with the OS username")
+	}
+	if page.Entries[0].Details["method"] != "password" || page.Entries[0].AppVersion != "test" {
+		t.Errorf("unexpected entry: %+v", page.Entries[0])
+	}
+
+	page, err = svc.List(ctx, models.AuditFilter{EventTypes: []string{models.AuditKeyExport}}, 1, 1)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if page.Total != 2 || len(page.Entries) != 1 || page.Entries[0].Hostname != "web.example.com" {
+		t.Errorf("expected the older key export on page 2, got total %d: %+v", page.Total, page.Entries)
+	}
+
+	page, err = svc.List(ctx, models.AuditFilter{Hostname: " API.example.com "}, 10, 0)
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if page.Total != 1 || page.Entries[0].Hostname != "api.example.com" {
+		t.Errorf("expected the entry of api.example.com, got %+v", page.Entries)
+	}
+
+	if _, err := svc.List(ctx, models.AuditFilter{From: apr1, To: mar1}, 10, 0); err == nil {
+		t.Error("expected an inverted date range to be refused")
+	}
+}
+
+func TestAuditBuildCSV(t *testing.T) {
+	svc, database := setupAuditService(t)
+	ctx := context.Background()
+	recordAuditAt(t, svc, database, models.AuditUnlock, "", time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC).Unix())
+	recordAuditAt(t, svc, database, models.AuditDatabaseReset, "", time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC).Unix())
+
+	data, err := svc.BuildCSV(ctx, models.AuditFilter{EventTypes: []string{models.AuditUnlock}})
+	if err != nil {
+		t.Fatalf("BuildCSV failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected a header and 1 row, got:\n%s", data)
+	}
+	if !strings.HasPrefix(lines[1], "2026-03-01T09:30:00Z,unlock,,") || !strings.Contains(lines[1], `""method"":""password""`) {
+		t.Errorf("unexpected row: %s", lines[1])
+	}
+}
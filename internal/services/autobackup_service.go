@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log/slog"
@@ -10,8 +11,10 @@ import (
 	"strings"
 	"time"
 
+	"paddockcontrol-desktop/internal/clock"
 	"paddockcontrol-desktop/internal/logger"
 	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/timing"
 )
 
 const (
@@ -34,11 +37,14 @@ type AutoBackupService struct {
 	dataDir    string
 	backupsDir string
 	maxKeep    int
+	version    string
+	clock      clock.Clock
 	log        *slog.Logger
 }
 
-// NewAutoBackupService creates a new auto-backup service
-func NewAutoBackupService(db *sql.DB, dataDir string) *AutoBackupService {
+// NewAutoBackupService creates a new auto-backup service. version is recorded
+// in the metadata of every backup it writes.
+func NewAutoBackupService(db *sql.DB, dataDir string, version string) *AutoBackupService {
 	log := logger.WithComponent("autobackup")
 	backupsDir := filepath.Join(dataDir, "backups")
 
@@ -51,6 +57,8 @@ func NewAutoBackupService(db *sql.DB, dataDir string) *AutoBackupService {
 		dataDir:    dataDir,
 		backupsDir: backupsDir,
 		maxKeep:    DefaultMaxAutoBackups,
+		version:    version,
+		clock:      clock.System(),
 		log:        log,
 	}
 
@@ -60,12 +68,21 @@ func NewAutoBackupService(db *sql.DB, dataDir string) *AutoBackupService {
 	return s
 }
 
+// SetClock replaces the clock used to timestamp backup files.
+func (s *AutoBackupService) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
 // CreateBackup creates a consistent snapshot of the database file using VACUUM INTO.
-// The operation parameter is used for logging context (e.g., "upload_certificate").
+// The operation parameter (e.g., "upload_certificate") is appended to the filename
+// and recorded in the backup's metadata.
 // Errors are returned so callers can log them, but callers should not block on errors.
 func (s *AutoBackupService) CreateBackup(operation string) (string, error) {
-	timestamp := time.Now().Format(timestampFormat)
-	backupName := autoBackupPrefix + timestamp
+	now := s.clock.Now()
+	backupName := autoBackupPrefix + now.Format(timestampFormat)
+	if suffix := backupFilenameOperation(operation); suffix != "" {
+		backupName += "." + suffix
+	}
 	backupPath := filepath.Join(s.backupsDir, backupName)
 
 	s.log.Info("creating auto-backup",
@@ -73,17 +90,14 @@ func (s *AutoBackupService) CreateBackup(operation string) (string, error) {
 		slog.String("path", backupPath),
 	)
 
-	// VACUUM INTO produces a consistent, self-contained copy that works correctly
-	// with WAL mode without needing to copy .wal/.shm files or force a checkpoint.
-	// The path is constructed internally from dataDir (not user input), so this is safe.
-	_, err := s.db.Exec(fmt.Sprintf(`VACUUM INTO '%s'`, backupPath))
-	if err != nil {
+	if err := s.snapshot(backupPath); err != nil {
 		s.log.Error("auto-backup failed",
 			slog.String("operation", operation),
 			logger.Err(err),
 		)
 		return "", fmt.Errorf("auto-backup failed: %w", err)
 	}
+	s.writeMetadata(backupPath, operation, now)
 
 	s.log.Info("auto-backup created successfully",
 		slog.String("operation", operation),
@@ -96,6 +110,33 @@ func (s *AutoBackupService) CreateBackup(operation string) (string, error) {
 	return backupPath, nil
 }
 
+// snapshot writes a consistent copy of the live database to backupPath.
+// VACUUM INTO reads the database inside a single read transaction, so writers
+// on other connections never have to be paused and the copy never mixes states;
+// it works with WAL mode without copying .wal/.shm files or forcing a checkpoint.
+// The path is bound as a parameter so data directories containing quotes work.
+func (s *AutoBackupService) snapshot(backupPath string) error {
+	defer timing.Start(timing.Backup, slog.String("path", backupPath))()
+
+	_, err := s.db.Exec("VACUUM INTO ?", backupPath)
+	return err
+}
+
+// writeMetadata embeds the backup metadata table in a freshly written backup.
+// Failures are logged only: the backup itself is still usable.
+func (s *AutoBackupService) writeMetadata(backupPath, operation string, createdAt time.Time) {
+	backupDB, err := sql.Open("sqlite", backupPath)
+	if err != nil {
+		s.log.Error("failed to open backup for metadata", slog.String("path", backupPath), logger.Err(err))
+		return
+	}
+	defer backupDB.Close()
+
+	if err := WriteBackupMetadata(context.Background(), backupDB, operation, s.version, createdAt); err != nil {
+		s.log.Error("failed to write backup metadata", slog.String("path", backupPath), logger.Err(err))
+	}
+}
+
 // rotateBackups removes the oldest backups if count exceeds maxKeep
 func (s *AutoBackupService) rotateBackups() {
 	pattern := filepath.Join(s.backupsDir, autoBackupPrefix+"*")
@@ -129,17 +170,17 @@ func (s *AutoBackupService) rotateBackups() {
 // CreateManualBackup creates a user-initiated database backup snapshot.
 // Manual backups are not subject to automatic rotation.
 func (s *AutoBackupService) CreateManualBackup() (string, error) {
-	timestamp := time.Now().Format(timestampFormat)
-	backupName := manualBackupPrefix + timestamp
+	now := s.clock.Now()
+	backupName := manualBackupPrefix + now.Format(timestampFormat)
 	backupPath := filepath.Join(s.backupsDir, backupName)
 
 	s.log.Info("creating manual backup", slog.String("path", backupPath))
 
-	_, err := s.db.Exec(fmt.Sprintf(`VACUUM INTO '%s'`, backupPath))
-	if err != nil {
+	if err := s.snapshot(backupPath); err != nil {
 		s.log.Error("manual backup failed", logger.Err(err))
 		return "", fmt.Errorf("manual backup failed: %w", err)
 	}
+	s.writeMetadata(backupPath, "manual", now)
 
 	s.log.Info("manual backup created successfully", slog.String("path", backupPath))
 	return backupPath, nil
@@ -148,7 +189,137 @@ func (s *AutoBackupService) CreateManualBackup() (string, error) {
 // ListBackups returns metadata for all local backup files (both auto and manual).
 // Results are sorted by timestamp descending (newest first).
 func (s *AutoBackupService) ListBackups() ([]models.LocalBackupInfo, error) {
-	var results []models.LocalBackupInfo
+	page, err := s.ListBackupsPage(models.BackupListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return page.Backups, nil
+}
+
+// ListBackupsPage returns one page of local backups, filtered by type and sorted
+// by timestamp (default) or size, newest/largest first unless SortOrder is "asc".
+// Total and TotalSize cover every backup matching the filter, not just the page.
+// Backup contents are only read for the backups on the page.
+func (s *AutoBackupService) ListBackupsPage(opts models.BackupListOptions) (*models.BackupListPage, error) {
+	if opts.Offset < 0 || opts.Limit < 0 {
+		return nil, fmt.Errorf("offset and limit must not be negative")
+	}
+
+	files, err := s.scanBackups()
+	if err != nil {
+		return nil, err
+	}
+
+	matching := files[:0]
+	page := &models.BackupListPage{Backups: []models.LocalBackupInfo{}}
+	for _, f := range files {
+		if opts.Type != "" && f.info.Type != opts.Type {
+			continue
+		}
+		matching = append(matching, f)
+		page.TotalSize += f.info.Size
+	}
+	page.Total = len(matching)
+
+	less := func(a, b models.LocalBackupInfo) bool {
+		if opts.SortBy == "size" && a.Size != b.Size {
+			return a.Size < b.Size
+		}
+		if a.Timestamp != b.Timestamp {
+			return a.Timestamp < b.Timestamp
+		}
+		return a.Filename < b.Filename
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		if opts.SortOrder == "asc" {
+			return less(matching[i].info, matching[j].info)
+		}
+		return less(matching[j].info, matching[i].info)
+	})
+
+	if opts.Offset >= len(matching) {
+		return page, nil
+	}
+	matching = matching[opts.Offset:]
+	if opts.Limit > 0 && opts.Limit < len(matching) {
+		matching = matching[:opts.Limit]
+	}
+
+	for _, f := range matching {
+		backup := f.info
+		backup.CertificateCount, backup.CAName = s.peekBackupContent(f.path)
+		if meta := s.peekBackupMetadata(f.path); meta != nil {
+			backup.Operation = meta.Operation
+			backup.AppVersion = meta.AppVersion
+		}
+		page.Backups = append(page.Backups, backup)
+	}
+
+	return page, nil
+}
+
+// NewestLocalBackup returns the timestamp of the newest auto or manual backup
+// of dataDir, 0 when there is none. Unlike NewAutoBackupService, it neither
+// creates the backups directory nor moves backups into it.
+func NewestLocalBackup(dataDir string) (int64, error) {
+	s := &AutoBackupService{
+		dataDir:    dataDir,
+		backupsDir: filepath.Join(dataDir, "backups"),
+		log:        logger.WithComponent("autobackup"),
+	}
+	files, err := s.scanBackups()
+	if err != nil {
+		return 0, err
+	}
+	var newest int64
+	for _, f := range files {
+		newest = max(newest, f.info.Timestamp)
+	}
+	return newest, nil
+}
+
+// StorageBreakdown summarizes the disk space used by local backups, per type.
+func (s *AutoBackupService) StorageBreakdown() (*models.BackupStorageBreakdown, error) {
+	files, err := s.scanBackups()
+	if err != nil {
+		return nil, err
+	}
+
+	breakdown := &models.BackupStorageBreakdown{MaxAutoBackups: s.maxKeep}
+	for _, f := range files {
+		usage := &breakdown.Manual
+		if f.info.Type == "auto" {
+			usage = &breakdown.Auto
+		}
+		usage.Count++
+		usage.Size += f.info.Size
+
+		if breakdown.OldestTimestamp == 0 || f.info.Timestamp < breakdown.OldestTimestamp {
+			breakdown.OldestTimestamp = f.info.Timestamp
+		}
+		if f.info.Timestamp > breakdown.NewestTimestamp {
+			breakdown.NewestTimestamp = f.info.Timestamp
+		}
+	}
+	breakdown.Total = models.BackupStorageUsage{
+		Count: breakdown.Auto.Count + breakdown.Manual.Count,
+		Size:  breakdown.Auto.Size + breakdown.Manual.Size,
+	}
+
+	return breakdown, nil
+}
+
+// backupFile is a local backup found on disk, with the fields that can be
+// derived without opening it.
+type backupFile struct {
+	path string
+	info models.LocalBackupInfo
+}
+
+// scanBackups lists the auto and manual backup files with their type, timestamp
+// and size. The operation is taken from the filename; contents are not read.
+func (s *AutoBackupService) scanBackups() ([]backupFile, error) {
+	var results []backupFile
 
 	prefixes := []struct {
 		prefix     string
@@ -175,7 +346,8 @@ func (s *AutoBackupService) ListBackups() ([]models.LocalBackupInfo, error) {
 			}
 
 			filename := filepath.Base(match)
-			timestampStr := strings.TrimPrefix(filename, p.prefix)
+			// Auto-backups carry the triggering operation after the timestamp
+			timestampStr, operation, _ := strings.Cut(strings.TrimPrefix(filename, p.prefix), ".")
 
 			t, err := time.Parse(timestampFormat, timestampStr)
 			if err != nil {
@@ -184,23 +356,19 @@ func (s *AutoBackupService) ListBackups() ([]models.LocalBackupInfo, error) {
 				continue
 			}
 
-			certCount, caName := s.peekBackupContent(match)
-
-			results = append(results, models.LocalBackupInfo{
-				Filename:         filename,
-				Type:             p.backupType,
-				Timestamp:        t.Unix(),
-				Size:             info.Size(),
-				CertificateCount: certCount,
-				CAName:           caName,
+			results = append(results, backupFile{
+				path: match,
+				info: models.LocalBackupInfo{
+					Filename:  filename,
+					Type:      p.backupType,
+					Timestamp: t.Unix(),
+					Size:      info.Size(),
+					Operation: operation,
+				},
 			})
 		}
 	}
 
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Timestamp > results[j].Timestamp
-	})
-
 	return results, nil
 }
 
@@ -219,6 +387,18 @@ func (s *AutoBackupService) peekBackupContent(path string) (certCount int, caNam
 	return certCount, caName
 }
 
+// peekBackupMetadata reads the metadata embedded in a backup file, or nil when
+// the backup has none or cannot be opened.
+func (s *AutoBackupService) peekBackupMetadata(path string) *models.BackupMetadata {
+	db, err := sql.Open("sqlite", path+"?mode=ro&_journal_mode=OFF")
+	if err != nil {
+		return nil
+	}
+	defer db.Close()
+
+	return ReadBackupMetadata(db)
+}
+
 // DeleteBackup removes a local backup file.
 // Only files matching known backup prefixes are allowed.
 func (s *AutoBackupService) DeleteBackup(filename string) error {
@@ -9,7 +9,9 @@ import (
 	"testing"
 	"time"
 
+	"paddockcontrol-desktop/internal/clock"
 	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/models"
 	"paddockcontrol-desktop/internal/testutil"
 )
 
@@ -26,10 +28,17 @@ func setupAutoBackupTest(t *testing.T) (*AutoBackupService, *db.Database, string
 	}
 	t.Cleanup(func() { database.Close() })
 
-	svc := NewAutoBackupService(database.DB(), tmpDir)
+	svc := NewAutoBackupService(database.DB(), tmpDir, "1.2.3")
+	svc.SetClock(clock.NewFake(time.Now()))
 	return svc, database, tmpDir
 }
 
+// tick advances the service's fake clock by one second, the resolution of
+// backup filename timestamps.
+func tick(svc *AutoBackupService) {
+	svc.clock.(*clock.Fake).Advance(time.Second)
+}
+
 // seedTestData inserts a config row and N certificate rows into the database.
 func seedTestData(t *testing.T, database *db.Database, certCount int) {
 	t.Helper()
@@ -166,8 +175,8 @@ func TestCreateBackup_RotatesOldBackups(t *testing.T) {
 		if err != nil {
 			t.Fatalf("CreateBackup %d failed: %v", i, err)
 		}
-		// Small delay to ensure unique timestamps
-		time.Sleep(1100 * time.Millisecond)
+		// Move to the next second so timestamps are unique
+		tick(svc)
 	}
 
 	count := countAutoBackups(t, tmpDir)
@@ -187,7 +196,7 @@ func TestCreateBackup_KeepsNewestAfterRotation(t *testing.T) {
 			t.Fatalf("CreateBackup %d failed: %v", i, err)
 		}
 		allPaths = append(allPaths, path)
-		time.Sleep(1100 * time.Millisecond)
+		tick(svc)
 	}
 
 	// The oldest 2 should have been removed
@@ -263,6 +272,106 @@ func TestCreateBackup_BackupIsIndependentFromSource(t *testing.T) {
 	}
 }
 
+func TestCreateBackup_ConsistentUnderConcurrentWrites(t *testing.T) {
+	svc, database, tmpDir := setupAutoBackupTest(t)
+	seedTestData(t, database, 5)
+
+	// A second connection keeps writing while the snapshot runs. Each insert
+	// also bumps config.writes_since_backup through a trigger, so a consistent
+	// snapshot always has exactly as many certificates as counted writes.
+	writer, err := sql.Open("sqlite", filepath.Join(tmpDir, "certificates.db")+"?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		t.Fatalf("failed to open writer connection: %v", err)
+	}
+	defer writer.Close()
+
+	stop := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				done <- nil
+				return
+			default:
+			}
+			if _, err := writer.Exec(
+				`INSERT INTO certificates (hostname, encrypted_private_key) VALUES (?, ?)`,
+				fmt.Sprintf("concurrent%d.test.local", i), "key",
+			); err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+
+	var paths []string
+	for i := 0; i < 3; i++ {
+		path, err := svc.CreateBackup("concurrent")
+		if err != nil {
+			close(stop)
+			t.Fatalf("CreateBackup failed: %v", err)
+		}
+		paths = append(paths, path)
+		tick(svc)
+	}
+	close(stop)
+	if err := <-done; err != nil {
+		t.Fatalf("concurrent writer failed: %v", err)
+	}
+
+	for _, path := range paths {
+		backupDB, err := sql.Open("sqlite", path)
+		if err != nil {
+			t.Fatalf("failed to open backup: %v", err)
+		}
+
+		var integrity string
+		if err := backupDB.QueryRow("PRAGMA integrity_check").Scan(&integrity); err != nil {
+			t.Fatalf("integrity_check failed: %v", err)
+		}
+		if integrity != "ok" {
+			t.Errorf("%s: integrity_check = %q", filepath.Base(path), integrity)
+		}
+
+		var certs, writes int
+		if err := backupDB.QueryRow("SELECT COUNT(*) FROM certificates").Scan(&certs); err != nil {
+			t.Fatalf("failed to count certificates: %v", err)
+		}
+		if err := backupDB.QueryRow("SELECT writes_since_backup FROM config WHERE id = 1").Scan(&writes); err != nil {
+			t.Fatalf("failed to read write counter: %v", err)
+		}
+		if certs != writes {
+			t.Errorf("%s: %d certificates but %d counted writes, snapshot is not consistent", filepath.Base(path), certs, writes)
+		}
+		backupDB.Close()
+	}
+}
+
+func TestCreateBackup_DataDirWithQuote(t *testing.T) {
+	tmpDir := filepath.Join(t.TempDir(), "o'brien")
+	if err := os.MkdirAll(tmpDir, 0o755); err != nil {
+		t.Fatalf("failed to create data dir: %v", err)
+	}
+	database, err := db.NewDatabase(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+	seedTestData(t, database, 1)
+
+	svc := NewAutoBackupService(database.DB(), tmpDir, "1.2.3")
+	if _, err := svc.CreateBackup("quoted_path"); err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+	if _, err := svc.CreateManualBackup(); err != nil {
+		t.Fatalf("CreateManualBackup failed: %v", err)
+	}
+	if got := countAllBackups(t, tmpDir); got != 2 {
+		t.Errorf("expected 2 backups, got %d", got)
+	}
+}
+
 func TestCreateBackup_DoesNotAffectExistingFiles(t *testing.T) {
 	svc, database, tmpDir := setupAutoBackupTest(t)
 	seedTestData(t, database, 1)
@@ -279,7 +388,7 @@ func TestCreateBackup_DoesNotAffectExistingFiles(t *testing.T) {
 		if err != nil {
 			t.Fatalf("CreateBackup %d failed: %v", i, err)
 		}
-		time.Sleep(1100 * time.Millisecond)
+		tick(svc)
 	}
 
 	// Other file should not be touched
@@ -366,7 +475,7 @@ func TestCreateManualBackup_NotSubjectToAutoRotation(t *testing.T) {
 		if err != nil {
 			t.Fatalf("CreateManualBackup %d failed: %v", i, err)
 		}
-		time.Sleep(1100 * time.Millisecond)
+		tick(svc)
 	}
 
 	count := countManualBackups(t, tmpDir)
@@ -383,7 +492,7 @@ func TestCreateManualBackup_CoexistsWithAutoBackups(t *testing.T) {
 	if err != nil {
 		t.Fatalf("CreateBackup failed: %v", err)
 	}
-	time.Sleep(1100 * time.Millisecond)
+	tick(svc)
 
 	_, err = svc.CreateManualBackup()
 	if err != nil {
@@ -425,7 +534,7 @@ func TestListBackups_ReturnsAutoBackups(t *testing.T) {
 		if _, err := svc.CreateBackup(fmt.Sprintf("op_%d", i)); err != nil {
 			t.Fatalf("CreateBackup %d failed: %v", i, err)
 		}
-		time.Sleep(1100 * time.Millisecond)
+		tick(svc)
 	}
 
 	backups, err := svc.ListBackups()
@@ -450,7 +559,7 @@ func TestListBackups_ReturnsManualBackups(t *testing.T) {
 		if _, err := svc.CreateManualBackup(); err != nil {
 			t.Fatalf("CreateManualBackup %d failed: %v", i, err)
 		}
-		time.Sleep(1100 * time.Millisecond)
+		tick(svc)
 	}
 
 	backups, err := svc.ListBackups()
@@ -474,7 +583,7 @@ func TestListBackups_ReturnsBothTypes(t *testing.T) {
 	if _, err := svc.CreateBackup("auto_op"); err != nil {
 		t.Fatalf("CreateBackup failed: %v", err)
 	}
-	time.Sleep(1100 * time.Millisecond)
+	tick(svc)
 
 	if _, err := svc.CreateManualBackup(); err != nil {
 		t.Fatalf("CreateManualBackup failed: %v", err)
@@ -507,7 +616,7 @@ func TestListBackups_SortedNewestFirst(t *testing.T) {
 	if _, err := svc.CreateBackup("op_1"); err != nil {
 		t.Fatalf("CreateBackup failed: %v", err)
 	}
-	time.Sleep(1100 * time.Millisecond)
+	tick(svc)
 
 	if _, err := svc.CreateManualBackup(); err != nil {
 		t.Fatalf("CreateManualBackup failed: %v", err)
@@ -589,6 +698,230 @@ func TestListBackups_IgnoresUnrelatedFiles(t *testing.T) {
 	}
 }
 
+func TestCreateBackup_EmbedsMetadata(t *testing.T) {
+	svc, database, _ := setupAutoBackupTest(t)
+	seedTestData(t, database, 2)
+
+	path, err := svc.CreateBackup("delete_certificate")
+	if err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+	if !strings.HasSuffix(path, ".delete_certificate") {
+		t.Errorf("expected operation in filename, got %s", filepath.Base(path))
+	}
+
+	backupDB, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("failed to open backup: %v", err)
+	}
+	defer backupDB.Close()
+
+	meta := ReadBackupMetadata(backupDB)
+	if meta == nil {
+		t.Fatal("expected backup metadata")
+	}
+	if meta.Operation != "delete_certificate" || meta.AppVersion != "1.2.3" || meta.CertificateCount != 2 {
+		t.Errorf("unexpected metadata: %+v", meta)
+	}
+	if meta.CreatedAt != svc.clock.Now().Unix() {
+		t.Errorf("CreatedAt = %d, want %d", meta.CreatedAt, svc.clock.Now().Unix())
+	}
+
+	// The live database does not get the table
+	if ReadBackupMetadata(database.DB()) != nil {
+		t.Error("metadata should only be written to the backup")
+	}
+}
+
+func TestListBackups_ReportsOperation(t *testing.T) {
+	svc, database, tmpDir := setupAutoBackupTest(t)
+	seedTestData(t, database, 1)
+
+	if _, err := svc.CreateBackup("upload_certificate"); err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+	tick(svc)
+	if _, err := svc.CreateManualBackup(); err != nil {
+		t.Fatalf("CreateManualBackup failed: %v", err)
+	}
+	tick(svc)
+
+	// A backup from before metadata was recorded
+	legacy := filepath.Join(tmpDir, testutil.BackupsSubdir, autoBackupPrefix+svc.clock.Now().Format(timestampFormat))
+	if _, err := database.DB().Exec(fmt.Sprintf(`VACUUM INTO '%s'`, legacy)); err != nil {
+		t.Fatalf("failed to write legacy backup: %v", err)
+	}
+
+	backups, err := svc.ListBackups()
+	if err != nil {
+		t.Fatalf("ListBackups failed: %v", err)
+	}
+	if len(backups) != 3 {
+		t.Fatalf("expected 3 backups, got %d", len(backups))
+	}
+
+	if backups[0].Operation != "" || backups[0].AppVersion != "" {
+		t.Errorf("legacy backup should have no metadata: %+v", backups[0])
+	}
+	if backups[1].Operation != "manual" || backups[1].AppVersion != "1.2.3" {
+		t.Errorf("unexpected manual backup: %+v", backups[1])
+	}
+	if backups[2].Operation != "upload_certificate" || backups[2].AppVersion != "1.2.3" {
+		t.Errorf("unexpected auto-backup: %+v", backups[2])
+	}
+}
+
+func TestBackupFilenameOperation(t *testing.T) {
+	tests := []struct {
+		operation string
+		want      string
+	}{
+		{"upload_certificate", "upload_certificate"},
+		{"Restore Local/Backup", "restore_local_backup"},
+		{"../../etc", "etc"},
+		{"", ""},
+		{strings.Repeat("a", 60), strings.Repeat("a", 40)},
+	}
+	for _, tt := range tests {
+		if got := backupFilenameOperation(tt.operation); got != tt.want {
+			t.Errorf("backupFilenameOperation(%q) = %q, want %q", tt.operation, got, tt.want)
+		}
+	}
+}
+
+func TestListBackupsPage_PaginatesAndSorts(t *testing.T) {
+	svc, database, _ := setupAutoBackupTest(t)
+	seedTestData(t, database, 1)
+
+	var created []string
+	for i := 0; i < 3; i++ {
+		path, err := svc.CreateBackup("test_operation")
+		if err != nil {
+			t.Fatalf("CreateBackup failed: %v", err)
+		}
+		created = append(created, filepath.Base(path))
+		tick(svc)
+	}
+	manual, err := svc.CreateManualBackup()
+	if err != nil {
+		t.Fatalf("CreateManualBackup failed: %v", err)
+	}
+
+	page, err := svc.ListBackupsPage(models.BackupListOptions{Offset: 1, Limit: 2})
+	if err != nil {
+		t.Fatalf("ListBackupsPage failed: %v", err)
+	}
+	if page.Total != 4 {
+		t.Errorf("Total = %d, want 4", page.Total)
+	}
+	if len(page.Backups) != 2 || page.Backups[0].Filename != created[2] || page.Backups[1].Filename != created[1] {
+		t.Errorf("unexpected page: %+v", page.Backups)
+	}
+	if page.Backups[0].CertificateCount != 1 {
+		t.Errorf("page entries should include content summary, got %+v", page.Backups[0])
+	}
+
+	all, err := svc.ListBackups()
+	if err != nil {
+		t.Fatalf("ListBackups failed: %v", err)
+	}
+	var totalSize int64
+	for _, b := range all {
+		totalSize += b.Size
+	}
+	if page.TotalSize != totalSize {
+		t.Errorf("TotalSize = %d, want %d", page.TotalSize, totalSize)
+	}
+
+	page, err = svc.ListBackupsPage(models.BackupListOptions{Type: "auto", SortOrder: "asc"})
+	if err != nil {
+		t.Fatalf("ListBackupsPage failed: %v", err)
+	}
+	if page.Total != 3 || len(page.Backups) != 3 || page.Backups[0].Filename != created[0] {
+		t.Errorf("expected auto-backups oldest first, got %+v", page.Backups)
+	}
+	for _, b := range page.Backups {
+		if b.Filename == filepath.Base(manual) {
+			t.Error("manual backup should be filtered out")
+		}
+	}
+
+	page, err = svc.ListBackupsPage(models.BackupListOptions{Offset: 10})
+	if err != nil {
+		t.Fatalf("ListBackupsPage failed: %v", err)
+	}
+	if len(page.Backups) != 0 || page.Total != 4 {
+		t.Errorf("expected an empty page past the end, got %d of %d", len(page.Backups), page.Total)
+	}
+
+	if _, err := svc.ListBackupsPage(models.BackupListOptions{Limit: -1}); err == nil {
+		t.Error("expected error for negative limit")
+	}
+}
+
+func TestListBackupsPage_SortsBySize(t *testing.T) {
+	svc, database, _ := setupAutoBackupTest(t)
+
+	small, err := svc.CreateBackup("small")
+	if err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+	tick(svc)
+	seedTestData(t, database, 200)
+	large, err := svc.CreateBackup("large")
+	if err != nil {
+		t.Fatalf("CreateBackup failed: %v", err)
+	}
+
+	page, err := svc.ListBackupsPage(models.BackupListOptions{SortBy: "size", SortOrder: "asc"})
+	if err != nil {
+		t.Fatalf("ListBackupsPage failed: %v", err)
+	}
+	if len(page.Backups) != 2 || page.Backups[0].Filename != filepath.Base(small) || page.Backups[1].Filename != filepath.Base(large) {
+		t.Errorf("expected smallest first, got %+v", page.Backups)
+	}
+}
+
+func TestStorageBreakdown(t *testing.T) {
+	svc, database, _ := setupAutoBackupTest(t)
+	seedTestData(t, database, 1)
+
+	breakdown, err := svc.StorageBreakdown()
+	if err != nil {
+		t.Fatalf("StorageBreakdown failed: %v", err)
+	}
+	if breakdown.Total.Count != 0 || breakdown.OldestTimestamp != 0 {
+		t.Errorf("expected empty breakdown, got %+v", breakdown)
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := svc.CreateBackup("test_operation"); err != nil {
+			t.Fatalf("CreateBackup failed: %v", err)
+		}
+		tick(svc)
+	}
+	if _, err := svc.CreateManualBackup(); err != nil {
+		t.Fatalf("CreateManualBackup failed: %v", err)
+	}
+
+	breakdown, err = svc.StorageBreakdown()
+	if err != nil {
+		t.Fatalf("StorageBreakdown failed: %v", err)
+	}
+	if breakdown.Auto.Count != 2 || breakdown.Manual.Count != 1 || breakdown.Total.Count != 3 {
+		t.Errorf("unexpected counts: %+v", breakdown)
+	}
+	if breakdown.Total.Size != breakdown.Auto.Size+breakdown.Manual.Size || breakdown.Manual.Size <= 0 {
+		t.Errorf("unexpected sizes: %+v", breakdown)
+	}
+	if breakdown.NewestTimestamp-breakdown.OldestTimestamp != 2 {
+		t.Errorf("expected backups two seconds apart, got %d..%d", breakdown.OldestTimestamp, breakdown.NewestTimestamp)
+	}
+	if breakdown.MaxAutoBackups != DefaultMaxAutoBackups {
+		t.Errorf("MaxAutoBackups = %d, want %d", breakdown.MaxAutoBackups, DefaultMaxAutoBackups)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // peekBackupContent tests
 // ---------------------------------------------------------------------------
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"paddockcontrol-desktop/internal/models"
+)
+
+// backupMetadataSchema describes the table embedded in backup files. It is not
+// part of the migrated schema: it only exists in files written by a backup, and
+// a database restored from one carries it along until the next backup
+// overwrites the row.
+const backupMetadataSchema = `CREATE TABLE IF NOT EXISTS backup_metadata (
+	id INTEGER PRIMARY KEY CHECK (id = 1),
+	operation TEXT NOT NULL,
+	app_version TEXT NOT NULL,
+	certificate_count INTEGER NOT NULL,
+	created_at INTEGER NOT NULL
+)`
+
+// WriteBackupMetadata records in a backup file which operation produced it, the
+// app version and its certificate count, replacing any row copied from the
+// source database.
+func WriteBackupMetadata(ctx context.Context, backup *sql.DB, operation, appVersion string, createdAt time.Time) error {
+	if _, err := backup.ExecContext(ctx, backupMetadataSchema); err != nil {
+		return fmt.Errorf("failed to create backup metadata table: %w", err)
+	}
+
+	var certCount int
+	if err := backup.QueryRowContext(ctx, "SELECT COUNT(*) FROM certificates").Scan(&certCount); err != nil {
+		return fmt.Errorf("failed to count certificates: %w", err)
+	}
+
+	if _, err := backup.ExecContext(ctx,
+		`INSERT OR REPLACE INTO backup_metadata (id, operation, app_version, certificate_count, created_at)
+		VALUES (1, ?, ?, ?, ?)`,
+		operation, appVersion, certCount, createdAt.Unix(),
+	); err != nil {
+		return fmt.Errorf("failed to write backup metadata: %w", err)
+	}
+	return nil
+}
+
+// ReadBackupMetadata returns the metadata embedded in a backup file, or nil for
+// backups written before it was recorded.
+func ReadBackupMetadata(backup *sql.DB) *models.BackupMetadata {
+	var meta models.BackupMetadata
+	err := backup.QueryRow(
+		"SELECT operation, app_version, certificate_count, created_at FROM backup_metadata WHERE id = 1",
+	).Scan(&meta.Operation, &meta.AppVersion, &meta.CertificateCount, &meta.CreatedAt)
+	if err != nil {
+		return nil
+	}
+	return &meta
+}
+
+// backupFilenameOperation turns an operation name into a filename-safe suffix:
+// lowercase letters, digits and underscores, at most 40 characters.
+func backupFilenameOperation(operation string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(operation) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+		if b.Len() == 40 {
+			break
+		}
+	}
+	return strings.Trim(b.String(), "_")
+}
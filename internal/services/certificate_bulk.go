@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// errBulkRollback aborts a bulk transaction once a certificate has failed; the
+// per-certificate errors are reported in the result instead.
+var errBulkRollback = errors.New("bulk operation rolled back")
+
+// BulkUpdateCertificates applies a patch (note replace/append, read-only flag) to
+// several certificates in one transaction. When any certificate fails (e.g. it
+// does not exist), nothing is applied and the result says which ones failed.
+// Errors are returned only for an invalid patch or a database failure.
+func (s *CertificateService) BulkUpdateCertificates(ctx context.Context, hostnames []string, patch models.BulkPatch) (*models.BulkUpdateResult, error) {
+	if patch.Note == nil && patch.ReadOnly == nil {
+		return nil, fmt.Errorf("bulk update has no changes")
+	}
+	switch patch.NoteMode {
+	case "", models.BulkNoteReplace, models.BulkNoteAppend:
+	default:
+		return nil, fmt.Errorf("unknown note mode %q", patch.NoteMode)
+	}
+	if patch.Note != nil {
+		if err := checkNoteForSecrets(*patch.Note); err != nil {
+			return nil, err
+		}
+	}
+
+	hostnames = uniqueStrings(hostnames)
+	if len(hostnames) == 0 {
+		return nil, fmt.Errorf("no certificates selected")
+	}
+
+	result := &models.BulkUpdateResult{Results: make([]models.BulkHostResult, len(hostnames))}
+	failed := false
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		for i, hostname := range hostnames {
+			result.Results[i].Hostname = hostname
+			if err := s.applyBulkPatch(ctx, q, hostname, patch); err != nil {
+				result.Results[i].Error = err.Error()
+				failed = true
+			}
+		}
+		if failed {
+			return errBulkRollback
+		}
+		return nil
+	})
+	if failed {
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply bulk update: %w", err)
+	}
+
+	result.Applied = true
+	for i := range result.Results {
+		result.Results[i].Success = true
+	}
+	return result, nil
+}
+
+// applyBulkPatch applies a bulk patch to one certificate within the caller's
+// transaction.
+func (s *CertificateService) applyBulkPatch(ctx context.Context, q *sqlc.Queries, hostname string, patch models.BulkPatch) error {
+	cert, err := q.GetCertificateByHostname(ctx, hostname)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("certificate not found")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get certificate: %w", err)
+	}
+
+	if patch.Note != nil {
+		note := *patch.Note
+		if patch.NoteMode == models.BulkNoteAppend && cert.Note.String != "" {
+			note = cert.Note.String + "\n" + note
+		}
+		if err := q.UpdateCertificateNote(ctx, sqlc.UpdateCertificateNoteParams{
+			Note:     sql.NullString{String: note, Valid: note != ""},
+			Hostname: hostname,
+		}); err != nil {
+			return fmt.Errorf("failed to update note: %w", err)
+		}
+	}
+
+	if patch.ReadOnly != nil && *patch.ReadOnly != (cert.ReadOnly == 1) {
+		if err := s.setReadOnlyTx(ctx, q, hostname, *patch.ReadOnly); err != nil {
+			return fmt.Errorf("failed to update read-only flag: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// uniqueStrings returns values without duplicates, keeping the first occurrence.
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		out = append(out, v)
+	}
+	return out
+}
+
+// PreviewBulkDelete describes the certificates a bulk deletion would remove:
+// status, whether private keys would be lost, and the read-only or deployed
+// ones that block it. Nothing is modified.
+func (s *CertificateService) PreviewBulkDelete(ctx context.Context, hostnames []string) ([]models.BulkDeletePreviewItem, error) {
+	hostnames = uniqueStrings(hostnames)
+	if len(hostnames) == 0 {
+		return nil, fmt.Errorf("no certificates selected")
+	}
+
+	threshold := s.expiringThresholdDays(ctx)
+	now := s.clock.Now()
+	items := make([]models.BulkDeletePreviewItem, 0, len(hostnames))
+	for _, hostname := range hostnames {
+		cert, err := s.db.Queries().GetCertificateByHostname(ctx, hostname)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("certificate not found: %s", hostname)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get certificate %s: %w", hostname, err)
+		}
+		deployedOn, err := deploymentTargetNames(ctx, s.db.Queries(), hostname)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, models.BulkDeletePreviewItem{
+			Hostname:      cert.Hostname,
+			Status:        string(db.ComputeStatusAt(&cert, threshold, now)),
+			HasPrivateKey: len(cert.EncryptedPrivateKey) > 0,
+			HasPendingKey: len(cert.PendingEncryptedPrivateKey) > 0,
+			ReadOnly:      cert.ReadOnly == 1,
+			DeployedOn:    deployedOn,
+		})
+	}
+	return items, nil
+}
+
+// BulkDeleteCertificates deletes several certificates in one transaction,
+// moving them to the trash like DeleteCertificate. When any certificate fails
+// (missing, read-only or deployed), nothing is deleted and the result says
+// which ones failed. A dry run deletes them and rolls back, reporting whether
+// the deletion would succeed.
+func (s *CertificateService) BulkDeleteCertificates(ctx context.Context, hostnames []string, dryRun bool) (*models.BulkDeleteResult, error) {
+	hostnames = uniqueStrings(hostnames)
+	if len(hostnames) == 0 {
+		return nil, fmt.Errorf("no certificates selected")
+	}
+
+	result := &models.BulkDeleteResult{Results: make([]models.BulkHostResult, len(hostnames)), DryRun: dryRun}
+	failed := false
+	trash := s.trashRetentionDays(ctx) > 0
+	err := s.db.WithDryRunTx(ctx, dryRun, func(q *sqlc.Queries) error {
+		for i, hostname := range hostnames {
+			result.Results[i].Hostname = hostname
+			if err := s.deleteCertificateTx(ctx, q, hostname, trash); err != nil {
+				result.Results[i].Error = err.Error()
+				failed = true
+			}
+		}
+		if failed {
+			return errBulkRollback
+		}
+		return nil
+	})
+	if failed {
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete certificates: %w", err)
+	}
+
+	result.Deleted = true
+	for i := range result.Results {
+		result.Results[i].Success = true
+	}
+	return result, nil
+}
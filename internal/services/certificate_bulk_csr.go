@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"runtime"
+	"strings"
+	"sync"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/hostnames"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// MaxBulkCSRHosts bounds the hostnames of one bulk CSR generation
+const MaxBulkCSRHosts = 200
+
+// maxBulkCSRWorkers bounds the key pairs generated in parallel
+const maxBulkCSRWorkers = 4
+
+// bulkCSRHost is one hostname of a bulk CSR request, with its SANs. err is set
+// when the hostname is invalid.
+type bulkCSRHost struct {
+	hostname string
+	sans     []models.SANEntry
+	err      error
+}
+
+// GenerateCSRBatch generates a key pair and a CSR for each hostname of a bulk
+// request, with the template's subject and key, and stores them all in one
+// transaction. When any hostname fails (invalid, already exists, refused by
+// the suffix policy), nothing is stored and the result says which ones failed.
+// Errors are returned only for an unreadable list, too many hostnames or a
+// database failure.
+func (s *CertificateService) GenerateCSRBatch(ctx context.Context, req models.BulkCSRRequest, encryptionKey []byte) (*models.BulkCSRResult, error) {
+	ctx, log := logger.WithOperation(ctx, "generate_csr_batch")
+
+	hosts, err := parseBulkCSRHosts(req)
+	if err != nil {
+		return nil, err
+	}
+	if len(hosts) == 0 {
+		return nil, fmt.Errorf("no hostnames given")
+	}
+	if len(hosts) > MaxBulkCSRHosts {
+		return nil, fmt.Errorf("at most %d hostnames can be generated at once, got %d", MaxBulkCSRHosts, len(hosts))
+	}
+	log.Info("starting bulk CSR generation", slog.Int("hosts", len(hosts)))
+
+	template := req.Template
+	template.IsRenewal = false
+	template.InheritSANs = false
+	template.Requester = nil
+
+	// Key generation dominates, so hosts are prepared in parallel
+	result := &models.BulkCSRResult{Results: make([]models.BulkCSRHostResult, len(hosts))}
+	prepared := make([]*preparedCSR, len(hosts))
+	workers := make(chan struct{}, min(runtime.NumCPU(), maxBulkCSRWorkers))
+	var wg sync.WaitGroup
+	for i, host := range hosts {
+		result.Results[i].Hostname = host.hostname
+		if host.err != nil {
+			result.Results[i].Error = host.err.Error()
+			continue
+		}
+		hostReq := template
+		hostReq.Hostname = host.hostname
+		hostReq.SANs = host.sans
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			workers <- struct{}{}
+			defer func() { <-workers }()
+			p, err := s.prepareCSR(ctx, log, hostReq, encryptionKey)
+			if err != nil {
+				result.Results[i].Error = err.Error()
+				return
+			}
+			prepared[i] = p
+		}()
+	}
+	wg.Wait()
+
+	for _, r := range result.Results {
+		if r.Error != "" {
+			log.Warn("bulk CSR generation refused", slog.String("hostname", r.Hostname), slog.String("reason", r.Error))
+			return result, nil
+		}
+	}
+
+	failed := false
+	err = s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		for i, p := range prepared {
+			if _, err := s.storeCSRTx(ctx, q, p, encryptionKey); err != nil {
+				result.Results[i].Error = err.Error()
+				failed = true
+			}
+		}
+		if failed {
+			return errBulkRollback
+		}
+		return nil
+	})
+	if failed {
+		return result, nil
+	}
+	if err != nil {
+		log.Error("failed to store CSRs", logger.Err(err))
+		return nil, fmt.Errorf("failed to store CSRs: %w", err)
+	}
+
+	result.Generated = true
+	for i, p := range prepared {
+		result.Results[i].Success = true
+		result.Results[i].CSR = string(p.csrPEM)
+	}
+	log.Info("bulk CSR generation completed", slog.Int("hosts", len(hosts)))
+	return result, nil
+}
+
+// parseBulkCSRHosts collects the hostnames of a bulk CSR request, normalized
+// and without duplicates. Invalid hostnames are kept, with their error.
+func parseBulkCSRHosts(req models.BulkCSRRequest) ([]bulkCSRHost, error) {
+	records := make([][]string, 0, len(req.Hostnames))
+	for _, hostname := range req.Hostnames {
+		records = append(records, []string{hostname})
+	}
+
+	r := csv.NewReader(strings.NewReader(req.List))
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	r.Comment = '#'
+	for first := true; ; first = false {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid hostname list: %w", err)
+		}
+		if first && strings.EqualFold(strings.TrimSpace(record[0]), "hostname") {
+			continue
+		}
+		records = append(records, record)
+	}
+
+	var hosts []bulkCSRHost
+	seen := make(map[string]bool)
+	for _, record := range records {
+		raw := strings.TrimSpace(record[0])
+		if raw == "" {
+			continue
+		}
+		hostname, err := hostnames.Normalize(raw)
+		if err != nil {
+			hosts = append(hosts, bulkCSRHost{hostname: raw, err: err})
+			continue
+		}
+		if seen[hostname] {
+			continue
+		}
+		seen[hostname] = true
+
+		// The hostname is the first SAN, as the CSR form adds it
+		host := bulkCSRHost{hostname: hostname, sans: []models.SANEntry{{Value: hostname, Type: models.SANTypeDNS}}}
+		for _, field := range record[1:] {
+			// SANs may also share a field, separated by ; or spaces
+			for _, value := range strings.FieldsFunc(field, func(r rune) bool { return r == ';' || r == ' ' }) {
+				if strings.EqualFold(value, hostname) {
+					continue
+				}
+				sanType := models.SANTypeDNS
+				if net.ParseIP(value) != nil {
+					sanType = models.SANTypeIP
+				}
+				host.sans = append(host.sans, models.SANEntry{Value: value, Type: sanType})
+			}
+		}
+		hosts = append(hosts, host)
+	}
+	return hosts, nil
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+func bulkCSRTemplate() models.CSRRequest {
+	return models.CSRRequest{
+		Organization: "Test Org",
+		City:         "Paris",
+		State:        "IDF",
+		Country:      "FR",
+		KeyAlgorithm: models.KeyAlgorithmECDSA,
+		KeySize:      256,
+		Note:         "rollout",
+	}
+}
+
+func TestGenerateCSRBatch(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+
+	result, err := svc.GenerateCSRBatch(ctx, models.BulkCSRRequest{
+		Hostnames: []string{"a.example.com"},
+		List:      "hostname,sans\n# web tier\nB.example.com, www.b.example.com;10.0.0.5\n\na.example.com\n",
+		Template:  bulkCSRTemplate(),
+	}, testutil.RandomMasterKey(t))
+	if err != nil {
+		t.Fatalf("GenerateCSRBatch failed: %v", err)
+	}
+	if !result.Generated || len(result.Results) != 2 {
+		t.Fatalf("expected 2 generated CSRs, got %+v", result)
+	}
+	for _, r := range result.Results {
+		if !r.Success || r.CSR == "" {
+			t.Errorf("expected %s to succeed with a CSR, got %+v", r.Hostname, r)
+		}
+	}
+
+	csr, err := crypto.ParseCSR([]byte(result.Results[1].CSR))
+	if err != nil {
+		t.Fatalf("invalid CSR: %v", err)
+	}
+	if csr.Subject.CommonName != "b.example.com" || strings.Join(csr.DNSNames, ",") != "b.example.com,www.b.example.com" || len(csr.IPAddresses) != 1 {
+		t.Errorf("unexpected CSR: CN %q, DNS %v, IP %v", csr.Subject.CommonName, csr.DNSNames, csr.IPAddresses)
+	}
+
+	cert, err := svc.GetCertificate(ctx, "a.example.com")
+	if err != nil {
+		t.Fatalf("GetCertificate failed: %v", err)
+	}
+	if cert.PendingCSR == "" || cert.Note != "rollout" {
+		t.Errorf("expected a pending CSR with the template note, got %+v", cert)
+	}
+}
+
+func TestGenerateCSRBatch_NothingStoredOnFailure(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	seedCert(t, database, "taken.example.com")
+
+	result, err := svc.GenerateCSRBatch(ctx, models.BulkCSRRequest{
+		List:     "new.example.com\ntaken.example.com\nother.org\nbad host\n",
+		Template: bulkCSRTemplate(),
+	}, testutil.RandomMasterKey(t))
+	if err != nil {
+		t.Fatalf("GenerateCSRBatch failed: %v", err)
+	}
+	if result.Generated {
+		t.Fatal("expected nothing to be generated")
+	}
+
+	errors := make(map[string]string)
+	for _, r := range result.Results {
+		errors[r.Hostname] = r.Error
+	}
+	if errors["new.example.com"] != "" {
+		t.Errorf("expected new.example.com to be valid, got %q", errors["new.example.com"])
+	}
+	if !strings.Contains(errors["taken.example.com"], "already exists") {
+		t.Errorf("expected taken.example.com to be refused as existing, got %q", errors["taken.example.com"])
+	}
+	if errors["other.org"] == "" || errors["bad host"] == "" {
+		t.Errorf("expected the suffix and invalid hostname to be refused, got %v", errors)
+	}
+
+	if _, err := svc.GetCertificate(ctx, "new.example.com"); err == nil {
+		t.Error("expected new.example.com not to be stored")
+	}
+}
+
+func TestGenerateCSRBatch_Limits(t *testing.T) {
+	svc, _ := setupTestService(t)
+	key := testutil.RandomMasterKey(t)
+
+	if _, err := svc.GenerateCSRBatch(context.Background(), models.BulkCSRRequest{List: "\n# nothing\n"}, key); err == nil {
+		t.Error("expected an empty list to be rejected")
+	}
+	var list strings.Builder
+	for i := 0; i <= MaxBulkCSRHosts; i++ {
+		fmt.Fprintf(&list, "host-%d.example.com\n", i)
+	}
+	if _, err := svc.GenerateCSRBatch(context.Background(), models.BulkCSRRequest{List: list.String()}, key); err == nil {
+		t.Error("expected too many hostnames to be rejected")
+	}
+}
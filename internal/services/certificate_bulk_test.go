@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+func createBulkTestCertificates(t *testing.T, svc *CertificateService, notes map[string]string) {
+	t.Helper()
+	for hostname, note := range notes {
+		if err := svc.db.Queries().CreateCertificate(context.Background(), sqlc.CreateCertificateParams{
+			Hostname: hostname,
+			Note:     sql.NullString{String: note, Valid: note != ""},
+		}); err != nil {
+			t.Fatalf("failed to create %s: %v", hostname, err)
+		}
+	}
+}
+
+func TestBulkUpdateCertificates(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	createBulkTestCertificates(t, svc, map[string]string{
+		"a.example.com": "owned by web",
+		"b.example.com": "",
+	})
+
+	note := "migrated to new LB"
+	readOnly := true
+	result, err := svc.BulkUpdateCertificates(ctx, []string{"a.example.com", "b.example.com", "a.example.com"}, models.BulkPatch{
+		Note:     &note,
+		NoteMode: models.BulkNoteAppend,
+		ReadOnly: &readOnly,
+	})
+	if err != nil {
+		t.Fatalf("BulkUpdateCertificates() error = %v", err)
+	}
+	if !result.Applied || len(result.Results) != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	for _, r := range result.Results {
+		if !r.Success || r.Error != "" {
+			t.Errorf("unexpected host result: %+v", r)
+		}
+	}
+
+	for hostname, want := range map[string]string{
+		"a.example.com": "owned by web\nmigrated to new LB",
+		"b.example.com": "migrated to new LB",
+	} {
+		cert, err := database.Queries().GetCertificateByHostname(ctx, hostname)
+		if err != nil {
+			t.Fatalf("failed to get %s: %v", hostname, err)
+		}
+		if cert.Note.String != want {
+			t.Errorf("%s note = %q, want %q", hostname, cert.Note.String, want)
+		}
+		if cert.ReadOnly != 1 {
+			t.Errorf("%s should be read-only", hostname)
+		}
+		history, err := svc.history.GetHistory(ctx, hostname, 10)
+		if err != nil {
+			t.Fatalf("failed to get history: %v", err)
+		}
+		if len(history) != 1 || history[0].EventType != models.EventReadOnlyEnabled {
+			t.Errorf("%s history = %+v, want one read-only event", hostname, history)
+		}
+	}
+}
+
+func TestBulkUpdateCertificates_RollsBackOnFailure(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	createBulkTestCertificates(t, svc, map[string]string{"a.example.com": "keep me"})
+
+	note := "replaced"
+	result, err := svc.BulkUpdateCertificates(ctx, []string{"a.example.com", "missing.example.com"}, models.BulkPatch{Note: &note})
+	if err != nil {
+		t.Fatalf("BulkUpdateCertificates() error = %v", err)
+	}
+	if result.Applied {
+		t.Fatal("expected the update not to be applied")
+	}
+	if r := result.Results[0]; r.Success || r.Error != "" {
+		t.Errorf("a.example.com should be rolled back without an error of its own: %+v", r)
+	}
+	if r := result.Results[1]; r.Success || r.Error == "" {
+		t.Errorf("missing.example.com should report an error: %+v", r)
+	}
+
+	cert, err := database.Queries().GetCertificateByHostname(ctx, "a.example.com")
+	if err != nil {
+		t.Fatalf("failed to get certificate: %v", err)
+	}
+	if cert.Note.String != "keep me" {
+		t.Errorf("note = %q, want it unchanged", cert.Note.String)
+	}
+}
+
+func TestBulkUpdateCertificates_InvalidPatch(t *testing.T) {
+	svc, _ := setupTestService(t)
+	ctx := context.Background()
+	secret := "password=Hunter2x"
+
+	tests := []struct {
+		name      string
+		hostnames []string
+		patch     models.BulkPatch
+	}{
+		{"no changes", []string{"a.example.com"}, models.BulkPatch{}},
+		{"unknown note mode", []string{"a.example.com"}, models.BulkPatch{Note: &secret, NoteMode: "prepend"}},
+		{"no hostnames", nil, models.BulkPatch{Note: new(string)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.BulkUpdateCertificates(ctx, tt.hostnames, tt.patch); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+
+	if _, err := svc.BulkUpdateCertificates(ctx, []string{"a.example.com"}, models.BulkPatch{Note: &secret}); !errors.Is(err, ErrNoteContainsSecret) {
+		t.Errorf("error = %v, want ErrNoteContainsSecret", err)
+	}
+}
+
+func TestPreviewBulkDelete(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:                   "pending.example.com",
+		PendingCsrPem:              sql.NullString{String: "csr", Valid: true},
+		PendingEncryptedPrivateKey: []byte("key"),
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname: "locked.example.com",
+		ReadOnly: 1,
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	items, err := svc.PreviewBulkDelete(ctx, []string{"pending.example.com", "locked.example.com"})
+	if err != nil {
+		t.Fatalf("PreviewBulkDelete() error = %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 items, got %+v", items)
+	}
+	if it := items[0]; it.Status != "pending" || !it.HasPendingKey || it.HasPrivateKey || it.ReadOnly {
+		t.Errorf("unexpected pending item: %+v", it)
+	}
+	if !items[1].ReadOnly {
+		t.Errorf("locked.example.com should be reported read-only: %+v", items[1])
+	}
+
+	if _, err := svc.PreviewBulkDelete(ctx, []string{"missing.example.com"}); err == nil {
+		t.Error("expected an error for an unknown hostname")
+	}
+}
+
+func TestBulkDeleteCertificates(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	createBulkTestCertificates(t, svc, map[string]string{"a.example.com": "", "b.example.com": ""})
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname: "locked.example.com",
+		ReadOnly: 1,
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	// A read-only certificate in the selection rolls back the whole deletion
+	result, err := svc.BulkDeleteCertificates(ctx, []string{"a.example.com", "locked.example.com"}, false)
+	if err != nil {
+		t.Fatalf("BulkDeleteCertificates() error = %v", err)
+	}
+	if result.Deleted || result.Results[1].Error == "" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if n, _ := database.Queries().CountCertificates(ctx); n != 3 {
+		t.Fatalf("certificates = %d, want 3 after rollback", n)
+	}
+
+	// A dry run reports the deletion without making it
+	result, err = svc.BulkDeleteCertificates(ctx, []string{"a.example.com", "b.example.com"}, true)
+	if err != nil {
+		t.Fatalf("BulkDeleteCertificates() error = %v", err)
+	}
+	if !result.Deleted || !result.DryRun || !result.Results[0].Success {
+		t.Fatalf("unexpected dry run result: %+v", result)
+	}
+	if n, _ := database.Queries().CountCertificates(ctx); n != 3 {
+		t.Fatalf("certificates = %d, want 3 after a dry run", n)
+	}
+
+	result, err = svc.BulkDeleteCertificates(ctx, []string{"a.example.com", "b.example.com"}, false)
+	if err != nil {
+		t.Fatalf("BulkDeleteCertificates() error = %v", err)
+	}
+	if !result.Deleted || !result.Results[0].Success || !result.Results[1].Success {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if certs, _ := database.Queries().ListAllCertificates(ctx); len(certs) != 1 {
+		t.Fatalf("certificates = %d, want 1", len(certs))
+	}
+	if trashed, _ := svc.ListDeletedCertificates(ctx); len(trashed) != 2 {
+		t.Fatalf("trash = %d, want the 2 deleted certificates", len(trashed))
+	}
+}
@@ -2,16 +2,23 @@ package services
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
+	"time"
 
 	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
 	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/truststores"
 )
 
 // GetCertificateChain retrieves the certificate chain for a hostname
 // Returns empty chain for pending certificates (no signed cert yet)
-// Fetches chain via AIA (Authority Information Access) from the leaf certificate
-func (s *CertificateService) GetCertificateChain(ctx context.Context, hostname string) ([]models.ChainCertificateInfo, error) {
+// Uses the chain stored at upload when the CA bundled its intermediates,
+// otherwise fetches it via AIA (Authority Information Access) from the leaf certificate.
+// A failed AIA fetch is not an error: the partial chain (at minimum the leaf) is
+// returned with diagnostics telling what went wrong.
+func (s *CertificateService) GetCertificateChain(ctx context.Context, hostname string) (*models.CertificateChain, error) {
 	// Get certificate from database
 	dbCert, err := s.db.Queries().GetCertificateByHostname(ctx, hostname)
 	if err != nil {
@@ -20,7 +27,7 @@ func (s *CertificateService) GetCertificateChain(ctx context.Context, hostname s
 
 	// No chain for pending certificates (no signed cert)
 	if !dbCert.CertificatePem.Valid || dbCert.CertificatePem.String == "" {
-		return []models.ChainCertificateInfo{}, nil
+		return &models.CertificateChain{Certificates: []models.ChainCertificateInfo{}}, nil
 	}
 
 	// Parse the leaf certificate
@@ -29,19 +36,58 @@ func (s *CertificateService) GetCertificateChain(ctx context.Context, hostname s
 		return nil, fmt.Errorf("failed to parse certificate: %w", err)
 	}
 
-	// Build chain info from leaf (includes AIA fetching)
-	chainInfo, err := crypto.BuildChainInfoFromLeaf(leafCert)
+	overrides, err := s.loadChainOverrides(ctx)
 	if err != nil {
-		// Return partial chain (at minimum the leaf) even if AIA fetch fails
-		return chainInfo, nil
+		return nil, err
+	}
+	chainOverrides := overrides.forCertificate(hostname, leafCert)
+
+	if chain := storedChain(&dbCert); len(chain) > 0 {
+		complete, err := completeChain(chain, chainOverrides)
+		return &models.CertificateChain{
+			Certificates: crypto.BuildChainInfo(leafCert, complete),
+			Diagnostics:  chainDiagnostics(err, len(chain)),
+		}, nil
 	}
 
-	return chainInfo, nil
+	// Build chain info from leaf (includes AIA fetching)
+	chainInfo, err := crypto.BuildChainInfoFromLeaf(leafCert, chainOverrides)
+	return &models.CertificateChain{
+		Certificates: chainInfo,
+		Diagnostics:  chainDiagnostics(err, 0),
+	}, nil
 }
 
-// GetChainPEMForDownload returns the full certificate chain as concatenated PEM
-// Chain order: leaf + intermediates + root
-func (s *CertificateService) GetChainPEMForDownload(ctx context.Context, hostname string) (string, error) {
+// chainDiagnostics returns the diagnostics of a failed AIA fetch, with depths
+// shifted by the number of stored issuer certificates the fetch started from.
+func chainDiagnostics(err error, storedDepth int) *models.ChainFetchDiagnostics {
+	if err == nil {
+		return nil
+	}
+	diagnostics := crypto.ChainDiagnostics(err)
+	if diagnostics == nil {
+		return &models.ChainFetchDiagnostics{Cause: models.ChainCauseUnknown, Summary: err.Error()}
+	}
+	for i := range diagnostics.Attempts {
+		diagnostics.Attempts[i].Depth += storedDepth
+	}
+	return diagnostics
+}
+
+// GetChainPEMForDownload returns the certificate chain as concatenated PEM, in
+// the order leaf + intermediates + root, limited to the certificates variant
+// selects (models.ChainVariant*; empty is the whole chain). Without a
+// complete chain the whole-chain variants return what was found, at minimum
+// the leaf, while the root-only variant fails.
+func (s *CertificateService) GetChainPEMForDownload(ctx context.Context, hostname, variant string) (string, error) {
+	switch variant {
+	case "":
+		variant = models.ChainVariantFull
+	case models.ChainVariantLeaf, models.ChainVariantFullChain, models.ChainVariantFull, models.ChainVariantRoot:
+	default:
+		return "", fmt.Errorf("unknown chain variant: %s", variant)
+	}
+
 	dbCert, err := s.db.Queries().GetCertificateByHostname(ctx, hostname)
 	if err != nil {
 		return "", fmt.Errorf("certificate not found: %w", err)
@@ -50,20 +96,41 @@ func (s *CertificateService) GetChainPEMForDownload(ctx context.Context, hostnam
 	if !dbCert.CertificatePem.Valid || dbCert.CertificatePem.String == "" {
 		return "", fmt.Errorf("no certificate for hostname: %s", hostname)
 	}
+	if variant == models.ChainVariantLeaf {
+		return dbCert.CertificatePem.String, nil
+	}
 
 	leafCert, err := crypto.ParseCertificate([]byte(dbCert.CertificatePem.String))
 	if err != nil {
 		return "", fmt.Errorf("failed to parse certificate: %w", err)
 	}
 
-	// Build chain from AIA
-	chain, err := crypto.BuildChainFromAIA(leafCert)
+	chain, err := s.downloadChain(ctx, &dbCert, leafCert)
 	if err != nil {
-		// Return just the leaf cert if chain building fails
-		return dbCert.CertificatePem.String, nil
+		return "", err
 	}
 
-	// Concatenate: leaf + chain (intermediates + root)
+	// Roots are self-signed; anything else is an intermediate
+	var intermediates, roots []*x509.Certificate
+	for _, cert := range chain {
+		if cert.Subject.String() == cert.Issuer.String() {
+			roots = append(roots, cert)
+		} else {
+			intermediates = append(intermediates, cert)
+		}
+	}
+
+	switch variant {
+	case models.ChainVariantRoot:
+		if len(roots) == 0 {
+			return "", fmt.Errorf("no root certificate found in the chain of %s", hostname)
+		}
+		return crypto.ConvertChainToPEM(roots[len(roots)-1:])[0], nil
+	case models.ChainVariantFullChain:
+		chain = intermediates
+	}
+
+	// Concatenate: leaf + chain
 	result := dbCert.CertificatePem.String
 	chainPEMs := crypto.ConvertChainToPEM(chain)
 	for _, pem := range chainPEMs {
@@ -72,3 +139,132 @@ func (s *CertificateService) GetChainPEMForDownload(ctx context.Context, hostnam
 
 	return result, nil
 }
+
+// downloadChain returns the issuers of leafCert for an export: the stored
+// chain completed through AIA, or the chain built from AIA. Chain building
+// failures are not errors; whatever was found, possibly nothing, is returned.
+func (s *CertificateService) downloadChain(ctx context.Context, dbCert *sqlc.Certificate, leafCert *x509.Certificate) ([]*x509.Certificate, error) {
+	overrides, err := s.loadChainOverrides(ctx)
+	if err != nil {
+		return nil, err
+	}
+	chainOverrides := overrides.forCertificate(dbCert.Hostname, leafCert)
+
+	if chain := storedChain(dbCert); len(chain) > 0 {
+		chain, _ = completeChain(chain, chainOverrides)
+		return chain, nil
+	}
+	chain, _ := crypto.BuildChainWithOverrides(leafCert, chainOverrides)
+	return chain, nil
+}
+
+// storedChain returns the issuer certificates saved from the uploaded bundle, or
+// nil when none were stored (or they can no longer be parsed).
+func storedChain(cert *sqlc.Certificate) []*x509.Certificate {
+	if !cert.ChainPem.Valid || cert.ChainPem.String == "" {
+		return nil
+	}
+	chain, err := crypto.ParseMultipleCertificates([]byte(cert.ChainPem.String))
+	if err != nil {
+		return nil
+	}
+	return chain
+}
+
+// completeChain appends the root via AIA when the stored chain stops at an
+// intermediate (CAs commonly omit the root from their bundles). The stored
+// certificates are returned, with whatever was fetched, along with the fetch
+// error if any.
+func completeChain(chain []*x509.Certificate, overrides crypto.IssuerOverrides) ([]*x509.Certificate, error) {
+	last := chain[len(chain)-1]
+	if last.Subject.String() == last.Issuer.String() {
+		return chain, nil
+	}
+	if _, ok := lookupOverride(overrides, last); !ok && len(last.IssuingCertificateURL) == 0 {
+		return chain, nil
+	}
+	rest, err := crypto.BuildChainWithOverrides(last, overrides)
+	return append(chain, rest...), err
+}
+
+// EvaluateChainTrust validates a certificate's chain against the bundled and
+// system trust stores and reports which ones would trust it. The chain stored
+// at upload is used when present; otherwise it is fetched via AIA if fetchAIA
+// is true. Only the issuers the server would deliver are used as intermediates,
+// so a chain missing an intermediate fails even where a browser might repair it.
+func (s *CertificateService) EvaluateChainTrust(ctx context.Context, hostname string, fetchAIA bool) (*models.ChainTrustResult, error) {
+	dbCert, err := s.db.Queries().GetCertificateByHostname(ctx, hostname)
+	if err != nil {
+		return nil, fmt.Errorf("certificate not found: %w", err)
+	}
+
+	if !dbCert.CertificatePem.Valid || dbCert.CertificatePem.String == "" {
+		return nil, fmt.Errorf("no certificate for hostname: %s", hostname)
+	}
+
+	leafCert, err := crypto.ParseCertificate([]byte(dbCert.CertificatePem.String))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	source := "stored"
+	chain := storedChain(&dbCert)
+	if len(chain) == 0 {
+		source = "leaf_only"
+		if fetchAIA {
+			overrides, err := s.loadChainOverrides(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if fetched, _ := crypto.BuildChainWithOverrides(leafCert, overrides.forCertificate(hostname, leafCert)); len(fetched) > 0 {
+				source = "aia"
+				chain = fetched
+			}
+		}
+	}
+
+	result := evaluateChainTrust(leafCert, chain, truststores.Stores(), s.clock.Now())
+	result.Hostname = hostname
+	result.ChainSource = source
+	return result, nil
+}
+
+// evaluateChainTrust verifies leaf with chain as intermediates against each store
+func evaluateChainTrust(leaf *x509.Certificate, chain []*x509.Certificate, stores []truststores.Store, now time.Time) *models.ChainTrustResult {
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain {
+		// Roots shipped in the chain are not trust anchors; the store decides
+		if cert.Subject.String() != cert.Issuer.String() {
+			intermediates.AddCert(cert)
+		}
+	}
+
+	result := &models.ChainTrustResult{
+		ChainLength: len(chain),
+		EvaluatedAt: now.Unix(),
+		Stores:      make([]models.TrustStoreResult, 0, len(stores)),
+	}
+	for _, store := range stores {
+		entry := models.TrustStoreResult{Store: store.Name, Description: store.Description}
+		roots, err := store.Pool()
+		if err != nil {
+			entry.Error = fmt.Sprintf("trust store unavailable: %v", err)
+			result.Stores = append(result.Stores, entry)
+			continue
+		}
+		_, err = leaf.Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			CurrentTime:   now,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		})
+		if err != nil {
+			entry.Error = err.Error()
+		} else {
+			entry.Trusted = true
+		}
+		result.Stores = append(result.Stores, entry)
+	}
+
+	return result
+}
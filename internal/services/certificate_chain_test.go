@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"crypto/x509"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/testutil"
+	"paddockcontrol-desktop/internal/truststores"
+)
+
+func TestEvaluateChainTrust_PerStore(t *testing.T) {
+	encryptionKey := testutil.RandomMasterKey(t)
+	root, rootKey := newTestCA(t, "Test Root", time.Now().Add(10*365*24*time.Hour), nil, nil)
+	intermediate, intermediateKey := newTestCA(t, "Test Intermediate", time.Now().Add(5*365*24*time.Hour), root, rootKey)
+	csrPEM, _, _ := generateTestCSRAndKey(t, "trust.example.com", encryptionKey)
+	leaf, err := crypto.ParseCertificate([]byte(signCSRWithCA(t, csrPEM, intermediate, intermediateKey)))
+	if err != nil {
+		t.Fatalf("failed to parse leaf: %v", err)
+	}
+
+	private := x509.NewCertPool()
+	private.AddCert(root)
+	stores := []truststores.Store{
+		{Name: "private", Pool: func() (*x509.CertPool, error) { return private, nil }},
+		{Name: "mozilla", Pool: truststores.Mozilla},
+		{Name: "missing", Pool: func() (*x509.CertPool, error) { return nil, errors.New("not available") }},
+	}
+
+	result := evaluateChainTrust(leaf, []*x509.Certificate{intermediate, root}, stores, time.Now())
+	if len(result.Stores) != 3 {
+		t.Fatalf("expected 3 store results, got %d", len(result.Stores))
+	}
+	if !result.Stores[0].Trusted {
+		t.Errorf("expected private store to trust the chain, got error: %s", result.Stores[0].Error)
+	}
+	if result.Stores[1].Trusted {
+		t.Error("expected Mozilla store not to trust a private root")
+	}
+	if result.Stores[2].Trusted || !containsSubstring(result.Stores[2].Error, "unavailable") {
+		t.Errorf("expected unavailable store to be reported, got %+v", result.Stores[2])
+	}
+
+	// Without the intermediate the delivered chain is incomplete
+	result = evaluateChainTrust(leaf, nil, stores[:1], time.Now())
+	if result.Stores[0].Trusted {
+		t.Error("expected chain without intermediate to be untrusted")
+	}
+}
+
+func TestEvaluateChainTrust_UsesStoredChain(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	hostname := "stored.example.com"
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	root, rootKey := newTestCA(t, "Test Root", time.Now().Add(10*365*24*time.Hour), nil, nil)
+	intermediate, intermediateKey := newTestCA(t, "Test Intermediate", time.Now().Add(5*365*24*time.Hour), root, rootKey)
+	csrPEM, _, _ := generateTestCSRAndKey(t, hostname, encryptionKey)
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:       hostname,
+		CertificatePem: sql.NullString{String: signCSRWithCA(t, csrPEM, intermediate, intermediateKey), Valid: true},
+		ChainPem:       sql.NullString{String: crypto.ConvertChainToPEM([]*x509.Certificate{intermediate})[0], Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	result, err := svc.EvaluateChainTrust(ctx, hostname, false)
+	if err != nil {
+		t.Fatalf("EvaluateChainTrust failed: %v", err)
+	}
+	if result.ChainSource != "stored" || result.ChainLength != 1 {
+		t.Errorf("expected stored chain of length 1, got %s/%d", result.ChainSource, result.ChainLength)
+	}
+	for _, store := range result.Stores {
+		if store.Trusted {
+			t.Errorf("expected private CA chain to be untrusted by %s", store.Store)
+		}
+	}
+
+	if _, err := svc.EvaluateChainTrust(ctx, "missing.example.com", false); err == nil {
+		t.Error("expected error for unknown hostname, got nil")
+	}
+}
+
+func TestGetChainPEMForDownload_Variants(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	hostname := "variants.example.com"
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	root, rootKey := newTestCA(t, "Test Root", time.Now().Add(10*365*24*time.Hour), nil, nil)
+	intermediate, intermediateKey := newTestCA(t, "Test Intermediate", time.Now().Add(5*365*24*time.Hour), root, rootKey)
+	csrPEM, _, _ := generateTestCSRAndKey(t, hostname, encryptionKey)
+	leafPEM := signCSRWithCA(t, csrPEM, intermediate, intermediateKey)
+	intermediatePEM := crypto.ConvertChainToPEM([]*x509.Certificate{intermediate})[0]
+	rootPEM := crypto.ConvertChainToPEM([]*x509.Certificate{root})[0]
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:       hostname,
+		CertificatePem: sql.NullString{String: leafPEM, Valid: true},
+		ChainPem:       sql.NullString{String: intermediatePEM + rootPEM, Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	for variant, want := range map[string]string{
+		models.ChainVariantLeaf:      leafPEM,
+		models.ChainVariantFullChain: leafPEM + "\n" + intermediatePEM,
+		models.ChainVariantFull:      leafPEM + "\n" + intermediatePEM + "\n" + rootPEM,
+		models.ChainVariantRoot:      rootPEM,
+		"":                           leafPEM + "\n" + intermediatePEM + "\n" + rootPEM,
+	} {
+		got, err := svc.GetChainPEMForDownload(ctx, hostname, variant)
+		if err != nil {
+			t.Fatalf("GetChainPEMForDownload(%q) failed: %v", variant, err)
+		}
+		if got != want {
+			t.Errorf("GetChainPEMForDownload(%q) returned unexpected certificates", variant)
+		}
+	}
+
+	if _, err := svc.GetChainPEMForDownload(ctx, hostname, "everything"); err == nil {
+		t.Error("expected error for unknown variant, got nil")
+	}
+
+	// Without a root in the chain (the test CAs have no AIA URLs to fetch it)
+	if _, err := database.DB().Exec("UPDATE certificates SET chain_pem = ? WHERE hostname = ?", intermediatePEM, hostname); err != nil {
+		t.Fatalf("failed to update chain: %v", err)
+	}
+	if _, err := svc.GetChainPEMForDownload(ctx, hostname, models.ChainVariantRoot); err == nil {
+		t.Error("expected error for root variant without a root, got nil")
+	}
+	if got, err := svc.GetChainPEMForDownload(ctx, hostname, models.ChainVariantFull); err != nil || got != leafPEM+"\n"+intermediatePEM {
+		t.Errorf("expected the partial chain for the full variant, got err=%v", err)
+	}
+}
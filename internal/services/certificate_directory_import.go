@@ -0,0 +1,479 @@
+package services
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	gocrypto "crypto"
+	"crypto/x509"
+	"database/sql"
+	"encoding/pem"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/hostnames"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/subject"
+)
+
+// Limits of a directory scan, so picking a home directory by mistake fails
+// fast instead of reading everything below it
+const (
+	MaxDirectoryImportFiles    = 5000
+	MaxDirectoryImportFileSize = 1 << 20
+)
+
+// maxDirectoryChainDepth bounds the issuer chain completed from the CA
+// certificates found in the directory
+const maxDirectoryChainDepth = 10
+
+// directoryCert is a certificate file of a scanned directory: its leaf and the
+// issuer certificates bundled with it
+type directoryCert struct {
+	file  string
+	leaf  *x509.Certificate
+	chain []*x509.Certificate
+}
+
+// directoryKey is a private key file of a scanned directory
+type directoryKey struct {
+	file        string
+	key         gocrypto.Signer
+	fingerprint string
+}
+
+// directoryScan is what a directory holds, before pairing
+type directoryScan struct {
+	certs   []directoryCert
+	keys    []directoryKey
+	cas     []*x509.Certificate // CA certificates found alone, to complete chains
+	skipped []models.DirectoryImportSkippedFile
+}
+
+// directoryImportPair is a certificate paired with its key, ready to import
+type directoryImportPair struct {
+	entry  models.DirectoryImportEntry
+	bundle []*x509.Certificate // leaf then issuers
+	key    gocrypto.Signer
+}
+
+// PreviewDirectoryImport scans dir and its subdirectories for PEM and DER
+// certificates and private keys, pairs each certificate with its key and
+// reports what ImportDirectory would import, leave out as a conflict or
+// invalid, and skip. Nothing is stored.
+func (s *CertificateService) PreviewDirectoryImport(ctx context.Context, dir string) (*models.DirectoryImportPreview, error) {
+	_, preview, err := s.planDirectoryImport(ctx, dir)
+	return preview, err
+}
+
+// ImportDirectory imports every importable certificate of dir with its key,
+// as ImportCertificate does. The directory is scanned again, so the result
+// reflects its current content. Each certificate is imported on its own: a
+// failure is reported in Failed and does not stop the others, and its
+// warnings are collected in the result prefixed with its hostname. Conflicting
+// hostnames are counted as skipped; invalid pairs are reported as failed.
+func (s *CertificateService) ImportDirectory(ctx context.Context, dir string, encryptionKey []byte) (*models.CertImportResult, error) {
+	pairs, preview, err := s.planDirectoryImport(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.CertImportResult{
+		Conflicts: []string{},
+		Failed:    []models.CertImportFailure{},
+		Warnings:  models.NewWarnings(),
+	}
+	for _, entry := range preview.Conflicting {
+		result.Skipped++
+		result.Conflicts = append(result.Conflicts, entry.Hostname)
+	}
+	for _, entry := range preview.Invalid {
+		result.Failed = append(result.Failed, models.CertImportFailure{
+			Hostname: cmp.Or(entry.Hostname, entry.CertificateFile),
+			Error:    entry.Reason,
+		})
+	}
+
+	for _, pair := range pairs {
+		keyPEM, err := crypto.PrivateKeyToPEM(pair.key)
+		if err != nil {
+			result.Failed = append(result.Failed, models.CertImportFailure{Hostname: pair.entry.Hostname, Error: err.Error()})
+			continue
+		}
+		imported, err := s.ImportCertificate(ctx, models.ImportRequest{
+			CertificatePEM: string(crypto.ChainToPEM(pair.bundle)),
+			PrivateKeyPEM:  string(keyPEM),
+		}, encryptionKey)
+		crypto.Zero(keyPEM)
+		if err != nil {
+			result.Failed = append(result.Failed, models.CertImportFailure{Hostname: pair.entry.Hostname, Error: err.Error()})
+			continue
+		}
+		result.Imported++
+		for _, warning := range imported.Warnings.Warnings {
+			result.Warn("%s: %s", imported.Hostname, warning)
+		}
+	}
+	return result, nil
+}
+
+// planDirectoryImport scans dir, pairs certificates with keys and checks the
+// pairs against the database. It returns the importable pairs with the preview.
+func (s *CertificateService) planDirectoryImport(ctx context.Context, dir string) ([]directoryImportPair, *models.DirectoryImportPreview, error) {
+	scan, err := scanCertificateDirectory(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	preview := &models.DirectoryImportPreview{
+		Path:        dir,
+		Importable:  []models.DirectoryImportEntry{},
+		Conflicting: []models.DirectoryImportEntry{},
+		Invalid:     []models.DirectoryImportEntry{},
+		Skipped:     scan.skipped,
+	}
+
+	candidates, invalid, skipped := scan.pair()
+	preview.Invalid = append(preview.Invalid, invalid...)
+	preview.Skipped = append(preview.Skipped, skipped...)
+
+	threshold := s.expiringThresholdDays(ctx)
+	now := s.clock.Now()
+	var pairs []directoryImportPair
+	for _, pair := range candidates {
+		pair.entry.Status = string(db.ComputeStatusAt(&sqlc.Certificate{
+			CertificatePem: sql.NullString{String: "set", Valid: true},
+			ExpiresAt:      sql.NullInt64{Int64: pair.entry.ExpiresAt, Valid: true},
+		}, threshold, now))
+
+		exists, err := s.db.Queries().CertificateExists(ctx, pair.entry.Hostname)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to check certificate existence for %s: %w", pair.entry.Hostname, err)
+		}
+		if exists == 1 {
+			pair.entry.Reason = "a certificate with this hostname already exists"
+			if err := checkNotInTrash(ctx, s.db.Queries(), pair.entry.Hostname); err != nil {
+				pair.entry.Reason = err.Error()
+			}
+			preview.Conflicting = append(preview.Conflicting, pair.entry)
+			continue
+		}
+		preview.Importable = append(preview.Importable, pair.entry)
+		pairs = append(pairs, pair)
+	}
+
+	slices.SortFunc(preview.Skipped, func(a, b models.DirectoryImportSkippedFile) int {
+		return strings.Compare(a.File, b.File)
+	})
+	return pairs, preview, nil
+}
+
+// scanCertificateDirectory reads the certificates and private keys of dir and
+// its subdirectories. Hidden files and directories and symbolic links are not
+// followed; files that are neither are listed as skipped.
+func scanCertificateDirectory(dir string) (*directoryScan, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", dir)
+	}
+
+	scan := &directoryScan{skipped: []models.DirectoryImportSkippedFile{}}
+	files := 0
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if p == dir {
+			return err
+		}
+		rel, relErr := filepath.Rel(dir, p)
+		if relErr != nil {
+			return relErr
+		}
+		rel = filepath.ToSlash(rel)
+		if err != nil {
+			scan.skip(rel, err.Error())
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || !d.Type().IsRegular() {
+			return nil
+		}
+
+		files++
+		if files > MaxDirectoryImportFiles {
+			return fmt.Errorf("directory has more than %d files; pick the folder holding the certificates", MaxDirectoryImportFiles)
+		}
+		fileInfo, err := d.Info()
+		if err != nil {
+			scan.skip(rel, err.Error())
+			return nil
+		}
+		if fileInfo.Size() > MaxDirectoryImportFileSize {
+			scan.skip(rel, fmt.Sprintf("larger than %d KiB", MaxDirectoryImportFileSize>>10))
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			scan.skip(rel, err.Error())
+			return nil
+		}
+		scan.addFile(rel, data)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return scan, nil
+}
+
+// skip lists file as not imported
+func (scan *directoryScan) skip(file, reason string) {
+	scan.skipped = append(scan.skipped, models.DirectoryImportSkippedFile{File: file, Reason: reason})
+}
+
+// addFile records the certificates and keys of one file. A file holding only
+// CA certificates completes the chains of the others rather than being
+// imported.
+func (scan *directoryScan) addFile(file string, data []byte) {
+	certs, keys, reason := parseCertificateFile(data)
+	if len(certs) == 0 && len(keys) == 0 {
+		scan.skip(file, reason)
+		return
+	}
+
+	for _, key := range keys {
+		fingerprint, err := crypto.PublicKeyFingerprint(key.Public())
+		if err != nil {
+			scan.skip(file, err.Error())
+			continue
+		}
+		scan.keys = append(scan.keys, directoryKey{file: file, key: key, fingerprint: fingerprint})
+	}
+	if len(certs) == 0 {
+		return
+	}
+
+	leaf, chain, err := crypto.SplitCertificateBundle(crypto.ChainToPEM(certs))
+	if err != nil {
+		scan.skip(file, err.Error())
+		return
+	}
+	if leaf.IsCA && len(keys) == 0 {
+		scan.cas = append(scan.cas, certs...)
+		scan.skip(file, "CA certificate, used to complete the chains of the other certificates")
+		return
+	}
+	scan.certs = append(scan.certs, directoryCert{file: file, leaf: leaf, chain: chain})
+}
+
+// parseCertificateFile reads the certificates and private keys of a PEM file
+// (any number of blocks) or a DER file (one certificate or key). reason tells
+// why nothing was found.
+func parseCertificateFile(data []byte) (certs []*x509.Certificate, keys []gocrypto.Signer, reason string) {
+	if !bytes.Contains(data, []byte("-----BEGIN ")) {
+		if cert, err := x509.ParseCertificate(data); err == nil {
+			return []*x509.Certificate{cert}, nil, ""
+		}
+		if key := parseDERPrivateKey(data); key != nil {
+			return nil, []gocrypto.Signer{key}, ""
+		}
+		return nil, nil, "not a PEM or DER certificate or private key"
+	}
+
+	reason = "no certificate or private key found"
+	rest := data
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		switch block.Type {
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				reason = fmt.Sprintf("invalid certificate: %v", err)
+				continue
+			}
+			certs = append(certs, cert)
+		case "RSA PRIVATE KEY", "EC PRIVATE KEY", "PRIVATE KEY":
+			if strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED") {
+				reason = "encrypted private key; decrypt it before importing"
+				continue
+			}
+			key, err := crypto.ParsePrivateKeyFromPEM(pem.EncodeToMemory(block))
+			if err != nil {
+				reason = fmt.Sprintf("invalid private key: %v", err)
+				continue
+			}
+			keys = append(keys, key)
+		case "ENCRYPTED PRIVATE KEY":
+			reason = "encrypted private key; decrypt it before importing"
+		}
+	}
+	return certs, keys, reason
+}
+
+// parseDERPrivateKey parses a DER private key in PKCS#8, PKCS#1 or SEC 1
+// form, or returns nil
+func parseDERPrivateKey(der []byte) gocrypto.Signer {
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		if signer, ok := key.(gocrypto.Signer); ok {
+			return signer
+		}
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key
+	}
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key
+	}
+	return nil
+}
+
+// pair matches each certificate with a private key holding its public key,
+// preferring a key in the same file or one named like the certificate
+// (app.crt and app.key). A certificate whose like-named key does not match is
+// invalid; one without any key, and keys without a certificate, are skipped.
+// When several certificates share a hostname, the one expiring last is kept.
+func (scan *directoryScan) pair() ([]directoryImportPair, []models.DirectoryImportEntry, []models.DirectoryImportSkippedFile) {
+	byFingerprint := map[string][]int{}
+	for i, key := range scan.keys {
+		byFingerprint[key.fingerprint] = append(byFingerprint[key.fingerprint], i)
+	}
+	used := make([]bool, len(scan.keys))
+
+	var candidates []directoryImportPair
+	invalid := []models.DirectoryImportEntry{}
+	skipped := []models.DirectoryImportSkippedFile{}
+	for _, cert := range scan.certs {
+		chain := completeDirectoryChain(cert.leaf, cert.chain, scan.cas)
+		entry := models.DirectoryImportEntry{
+			CertificateFile: cert.file,
+			ChainCount:      len(chain),
+			ExpiresAt:       cert.leaf.NotAfter.Unix(),
+		}
+
+		keyIdx := -1
+		fingerprint, err := crypto.PublicKeyFingerprint(cert.leaf.PublicKey)
+		if err == nil {
+			for _, i := range byFingerprint[fingerprint] {
+				if sameFileStem(cert.file, scan.keys[i].file) {
+					keyIdx = i
+					entry.MatchedBy = models.KeyMatchedByFilename
+					break
+				}
+			}
+			if keyIdx < 0 && len(byFingerprint[fingerprint]) > 0 {
+				keyIdx = byFingerprint[fingerprint][0]
+				entry.MatchedBy = models.KeyMatchedByPublicKey
+			}
+		}
+		if keyIdx < 0 {
+			if i := slices.IndexFunc(scan.keys, func(k directoryKey) bool { return sameFileStem(cert.file, k.file) }); i >= 0 {
+				used[i] = true
+				entry.KeyFile = scan.keys[i].file
+				entry.Reason = fmt.Sprintf("private key %s does not match the certificate", scan.keys[i].file)
+				invalid = append(invalid, entry)
+				continue
+			}
+			skipped = append(skipped, models.DirectoryImportSkippedFile{File: cert.file, Reason: "no matching private key in the directory"})
+			continue
+		}
+		used[keyIdx] = true
+		entry.KeyFile = scan.keys[keyIdx].file
+
+		if cert.leaf.Subject.CommonName == "" {
+			entry.Reason = "certificate has no common name"
+			invalid = append(invalid, entry)
+			continue
+		}
+		hostname, err := hostnames.Normalize(cert.leaf.Subject.CommonName)
+		if err != nil {
+			entry.Reason = fmt.Sprintf("invalid certificate common name: %v", err)
+			invalid = append(invalid, entry)
+			continue
+		}
+		entry.Hostname = hostname
+		if err := subject.ValidateName(cert.leaf.Subject); err != nil {
+			entry.Reason = fmt.Sprintf("invalid certificate subject: %v", err)
+			invalid = append(invalid, entry)
+			continue
+		}
+
+		candidates = append(candidates, directoryImportPair{
+			entry:  entry,
+			bundle: append([]*x509.Certificate{cert.leaf}, chain...),
+			key:    scan.keys[keyIdx].key,
+		})
+	}
+
+	for i, key := range scan.keys {
+		if !used[i] {
+			skipped = append(skipped, models.DirectoryImportSkippedFile{File: key.file, Reason: "private key without a matching certificate"})
+		}
+	}
+
+	// Renewed certificates often sit next to the ones they replaced
+	latest := map[string]int{}
+	for i, pair := range candidates {
+		if j, ok := latest[pair.entry.Hostname]; !ok || pair.entry.ExpiresAt > candidates[j].entry.ExpiresAt {
+			latest[pair.entry.Hostname] = i
+		}
+	}
+	kept := candidates[:0:0]
+	for i, pair := range candidates {
+		if j := latest[pair.entry.Hostname]; j != i {
+			skipped = append(skipped, models.DirectoryImportSkippedFile{
+				File:   pair.entry.CertificateFile,
+				Reason: fmt.Sprintf("older certificate for %s than %s", pair.entry.Hostname, candidates[j].entry.CertificateFile),
+			})
+			continue
+		}
+		kept = append(kept, pair)
+	}
+	return kept, invalid, skipped
+}
+
+// completeDirectoryChain returns the issuers bundled with leaf or, when there are
+// none, the issuer path built from the CA certificates of the directory
+func completeDirectoryChain(leaf *x509.Certificate, chain, cas []*x509.Certificate) []*x509.Certificate {
+	if len(chain) > 0 || len(cas) == 0 {
+		return chain
+	}
+	current := leaf
+	for len(chain) < maxDirectoryChainDepth && current.Subject.String() != current.Issuer.String() {
+		i := slices.IndexFunc(cas, func(ca *x509.Certificate) bool {
+			return ca.Subject.String() == current.Issuer.String() && current.CheckSignatureFrom(ca) == nil
+		})
+		if i < 0 {
+			break
+		}
+		current = cas[i]
+		chain = append(chain, current)
+	}
+	return chain
+}
+
+// sameFileStem reports whether two files of the directory share their path
+// without extension, as app.example.com.crt and app.example.com.key do
+func sameFileStem(a, b string) bool {
+	return strings.TrimSuffix(a, path.Ext(a)) == strings.TrimSuffix(b, path.Ext(b))
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+// writeDirectoryFile writes a file of the directory being imported
+func writeDirectoryFile(t *testing.T, dir, name string, data []byte) {
+	t.Helper()
+	p := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// issueDirectoryCert issues a certificate for hostname from ca and returns it
+// with its key
+func issueDirectoryCert(t *testing.T, hostname string, ca *x509.Certificate, caKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey) {
+	t.Helper()
+	csrPEM, _, key := generateTestCSRAndKey(t, hostname, testutil.RandomMasterKey(t))
+	cert, err := crypto.ParseCertificate([]byte(signCSRWithCA(t, csrPEM, ca, caKey)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+// keyPEM encodes key as PEM
+func keyPEM(t *testing.T, key *rsa.PrivateKey) []byte {
+	t.Helper()
+	data, err := crypto.PrivateKeyToPEM(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// directoryEntry finds the entry of a certificate file
+func directoryEntry(entries []models.DirectoryImportEntry, file string) *models.DirectoryImportEntry {
+	for i := range entries {
+		if entries[i].CertificateFile == file {
+			return &entries[i]
+		}
+	}
+	return nil
+}
+
+func TestDirectoryImport_PreviewAndImport(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	masterKey := testutil.RandomMasterKey(t)
+	dir := t.TempDir()
+
+	ca, caKey := newTestCA(t, "Directory Test CA", time.Now().Add(5*365*24*time.Hour), nil, nil)
+	writeDirectoryFile(t, dir, "ca.pem", crypto.CertificateToPEM(ca))
+
+	// Paired by file name, chain completed from ca.pem
+	a, aKey := issueDirectoryCert(t, "a.example.com", ca, caKey)
+	writeDirectoryFile(t, dir, "a.example.com.crt", crypto.CertificateToPEM(a))
+	writeDirectoryFile(t, dir, "a.example.com.key", keyPEM(t, aKey))
+
+	// DER files in separate folders, paired by public key
+	b, bKey := issueDirectoryCert(t, "b.example.com", ca, caKey)
+	bDER, err := x509.MarshalPKCS8PrivateKey(bKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeDirectoryFile(t, dir, "certs/b.cer", b.Raw)
+	writeDirectoryFile(t, dir, "keys/other.der", bDER)
+
+	// Like-named key of another certificate
+	c, _ := issueDirectoryCert(t, "c.example.com", ca, caKey)
+	_, wrongKey := issueDirectoryCert(t, "wrong.example.com", ca, caKey)
+	writeDirectoryFile(t, dir, "c.example.com.crt", crypto.CertificateToPEM(c))
+	writeDirectoryFile(t, dir, "c.example.com.key", keyPEM(t, wrongKey))
+
+	// Already stored
+	e, eKey := issueDirectoryCert(t, "e.example.com", ca, caKey)
+	writeDirectoryFile(t, dir, "e.pem", append(crypto.CertificateToPEM(e), keyPEM(t, eKey)...))
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:       "e.example.com",
+		CertificatePem: sql.NullString{String: string(crypto.CertificateToPEM(e)), Valid: true},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Certificate without key, and unrelated files
+	d, _ := issueDirectoryCert(t, "d.example.com", ca, caKey)
+	writeDirectoryFile(t, dir, "d.example.com.crt", crypto.CertificateToPEM(d))
+	writeDirectoryFile(t, dir, "README.txt", []byte("certificates exported from the old server"))
+	writeDirectoryFile(t, dir, ".git/config", []byte("[core]"))
+
+	preview, err := svc.PreviewDirectoryImport(ctx, dir)
+	if err != nil {
+		t.Fatalf("PreviewDirectoryImport failed: %v", err)
+	}
+
+	if len(preview.Importable) != 2 {
+		t.Fatalf("expected 2 importable entries, got %+v", preview.Importable)
+	}
+	if entry := directoryEntry(preview.Importable, "a.example.com.crt"); entry == nil ||
+		entry.KeyFile != "a.example.com.key" || entry.MatchedBy != models.KeyMatchedByFilename || entry.ChainCount != 1 {
+		t.Errorf("unexpected entry for a.example.com: %+v", entry)
+	}
+	if entry := directoryEntry(preview.Importable, "certs/b.cer"); entry == nil ||
+		entry.Hostname != "b.example.com" || entry.KeyFile != "keys/other.der" || entry.MatchedBy != models.KeyMatchedByPublicKey {
+		t.Errorf("unexpected entry for b.example.com: %+v", entry)
+	}
+	if len(preview.Invalid) != 1 || preview.Invalid[0].CertificateFile != "c.example.com.crt" {
+		t.Errorf("expected c.example.com.crt invalid, got %+v", preview.Invalid)
+	}
+	if len(preview.Conflicting) != 1 || preview.Conflicting[0].Hostname != "e.example.com" {
+		t.Errorf("expected e.example.com conflicting, got %+v", preview.Conflicting)
+	}
+
+	skipped := map[string]bool{}
+	for _, s := range preview.Skipped {
+		skipped[s.File] = true
+	}
+	for _, file := range []string{"ca.pem", "d.example.com.crt", "README.txt"} {
+		if !skipped[file] {
+			t.Errorf("expected %s skipped, got %+v", file, preview.Skipped)
+		}
+	}
+	if skipped[".git/config"] {
+		t.Error("hidden directories should not be scanned")
+	}
+
+	result, err := svc.ImportDirectory(ctx, dir, masterKey)
+	if err != nil {
+		t.Fatalf("ImportDirectory failed: %v", err)
+	}
+	if result.Imported != 2 || result.Skipped != 1 || len(result.Failed) != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	stored, err := database.Queries().GetCertificateByHostname(ctx, "a.example.com")
+	if err != nil {
+		t.Fatalf("a.example.com not imported: %v", err)
+	}
+	if !stored.ChainPem.Valid {
+		t.Error("expected the chain completed from ca.pem to be stored")
+	}
+}
+
+func TestDirectoryImport_KeepsLatestCertificatePerHostname(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	dir := t.TempDir()
+
+	ca, caKey := newTestCA(t, "Directory Test CA", time.Now().Add(5*365*24*time.Hour), nil, nil)
+	csrPEM, _, key := generateTestCSRAndKey(t, "app.example.com", testutil.RandomMasterKey(t))
+	renewed := signCSRWithCA(t, csrPEM, ca, caKey)
+	previous, err := selfSignCertFromCSR(csrPEM, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The self-signed one expires after a year, the CA-issued one after 90 days
+	writeDirectoryFile(t, dir, "2024/app.crt", []byte(renewed))
+	writeDirectoryFile(t, dir, "2025/app.crt", []byte(previous))
+	writeDirectoryFile(t, dir, "app.key", keyPEM(t, key))
+
+	preview, err := svc.PreviewDirectoryImport(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("PreviewDirectoryImport failed: %v", err)
+	}
+	if len(preview.Importable) != 1 || preview.Importable[0].CertificateFile != "2025/app.crt" {
+		t.Fatalf("expected only 2025/app.crt importable, got %+v", preview.Importable)
+	}
+	if len(preview.Skipped) != 1 || preview.Skipped[0].File != "2024/app.crt" {
+		t.Errorf("expected 2024/app.crt skipped, got %+v", preview.Skipped)
+	}
+}
+
+func TestDirectoryImport_CollectsWarnings(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	dir := t.TempDir()
+
+	// The issuer is not in the directory, so no chain is bundled
+	ca, caKey := newTestCA(t, "Directory Test CA", time.Now().Add(5*365*24*time.Hour), nil, nil)
+	cert, key := issueDirectoryCert(t, "nochain.example.com", ca, caKey)
+	writeDirectoryFile(t, dir, "nochain.crt", crypto.CertificateToPEM(cert))
+	writeDirectoryFile(t, dir, "nochain.key", keyPEM(t, key))
+
+	result, err := svc.ImportDirectory(context.Background(), dir, testutil.RandomMasterKey(t))
+	if err != nil {
+		t.Fatalf("ImportDirectory failed: %v", err)
+	}
+	if result.Imported != 1 {
+		t.Fatalf("expected 1 imported, got %+v", result)
+	}
+	if len(result.Warnings.Warnings) != 1 || !strings.HasPrefix(result.Warnings.Warnings[0], "nochain.example.com: No issuer certificates") {
+		t.Errorf("expected the missing chain warning prefixed with the hostname, got %q", result.Warnings.Warnings)
+	}
+}
+
+func TestDirectoryImport_NotADirectory(t *testing.T) {
+	svc, _ := setupTestService(t)
+	file := filepath.Join(t.TempDir(), "cert.pem")
+	if err := os.WriteFile(file, []byte("x"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.PreviewDirectoryImport(context.Background(), file); err == nil {
+		t.Fatal("expected an error for a file")
+	}
+}
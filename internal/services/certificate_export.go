@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"unicode/utf8"
 
 	"paddockcontrol-desktop/internal/crypto"
 )
@@ -35,42 +36,116 @@ func (s *CertificateService) GetCertificateForDownload(ctx context.Context, host
 	return cert.CertificatePem.String, nil
 }
 
-// GetPrivateKeyForDownload returns the decrypted active private key PEM for download
-func (s *CertificateService) GetPrivateKeyForDownload(ctx context.Context, hostname string, encryptionKey []byte) (string, error) {
+// GetPrivateKeyForDownload returns the decrypted active private key PEM for download.
+// The caller owns the returned buffer and must Destroy it once written.
+func (s *CertificateService) GetPrivateKeyForDownload(ctx context.Context, hostname string, encryptionKey []byte) (*crypto.SecretBuffer, error) {
 	cert, err := s.db.Queries().GetCertificateByHostname(ctx, hostname)
 	if err != nil {
-		return "", fmt.Errorf("failed to get certificate: %w", err)
+		return nil, fmt.Errorf("failed to get certificate: %w", err)
 	}
 
 	if len(cert.EncryptedPrivateKey) == 0 {
-		return "", fmt.Errorf("no private key for hostname: %s", hostname)
+		return nil, fmt.Errorf("no private key for hostname: %s", hostname)
 	}
 
 	// Decrypt private key
 	decryptedKey, err := crypto.DecryptPrivateKey(cert.EncryptedPrivateKey, encryptionKey)
 	if err != nil {
-		return "", fmt.Errorf("failed to decrypt private key: %w", err)
+		return nil, fmt.Errorf("failed to decrypt private key: %w", err)
 	}
 
-	return string(decryptedKey), nil
+	return decryptedKey, nil
 }
 
-// GetPendingPrivateKeyForDownload returns the decrypted pending private key PEM for download
-func (s *CertificateService) GetPendingPrivateKeyForDownload(ctx context.Context, hostname string, encryptionKey []byte) (string, error) {
+// GetPendingPrivateKeyForDownload returns the decrypted pending private key PEM for download.
+// The caller owns the returned buffer and must Destroy it once written.
+func (s *CertificateService) GetPendingPrivateKeyForDownload(ctx context.Context, hostname string, encryptionKey []byte) (*crypto.SecretBuffer, error) {
 	cert, err := s.db.Queries().GetCertificateByHostname(ctx, hostname)
 	if err != nil {
-		return "", fmt.Errorf("failed to get certificate: %w", err)
+		return nil, fmt.Errorf("failed to get certificate: %w", err)
 	}
 
 	if len(cert.PendingEncryptedPrivateKey) == 0 {
-		return "", fmt.Errorf("no pending private key for hostname: %s", hostname)
+		return nil, fmt.Errorf("no pending private key for hostname: %s", hostname)
 	}
 
 	// Decrypt pending private key
 	decryptedKey, err := crypto.DecryptPrivateKey(cert.PendingEncryptedPrivateKey, encryptionKey)
 	if err != nil {
-		return "", fmt.Errorf("failed to decrypt pending private key: %w", err)
+		return nil, fmt.Errorf("failed to decrypt pending private key: %w", err)
+	}
+
+	return decryptedKey, nil
+}
+
+// minPKCS12PasswordLength is the shortest password accepted for a PKCS#12
+// export; the file holds the private key, so it is never written unprotected
+const minPKCS12PasswordLength = 8
+
+// BuildPKCS12ForDownload returns a password-protected PKCS#12 (.pfx) file with
+// the active certificate, its private key and the chain, for servers and
+// keystores that only import PKCS#12. legacy selects 3DES and a SHA-1 MAC for
+// older importers, which FIPS mode refuses. The caller writes the returned
+// bytes; the decrypted key never leaves this method.
+func (s *CertificateService) BuildPKCS12ForDownload(ctx context.Context, hostname, password string, legacy bool, encryptionKey []byte) ([]byte, error) {
+	if err := ValidatePKCS12Password(password); err != nil {
+		return nil, err
+	}
+	if legacy {
+		fips, err := s.fipsMode(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if fips {
+			return nil, fmt.Errorf("legacy PKCS#12 encryption (3DES, SHA-1) is %w", crypto.ErrFIPSViolation)
+		}
+	}
+
+	dbCert, err := s.db.Queries().GetCertificateByHostname(ctx, hostname)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get certificate: %w", err)
+	}
+	if !dbCert.CertificatePem.Valid || dbCert.CertificatePem.String == "" {
+		return nil, fmt.Errorf("no certificate for hostname: %s", hostname)
+	}
+	if len(dbCert.EncryptedPrivateKey) == 0 {
+		return nil, fmt.Errorf("no private key for hostname: %s", hostname)
 	}
 
-	return string(decryptedKey), nil
+	leafCert, err := crypto.ParseCertificate([]byte(dbCert.CertificatePem.String))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+	chain, err := s.downloadChain(ctx, &dbCert, leafCert)
+	if err != nil {
+		return nil, err
+	}
+
+	keyPEM, err := crypto.DecryptPrivateKey(dbCert.EncryptedPrivateKey, encryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt private key: %w", err)
+	}
+	defer keyPEM.Destroy()
+	key, err := crypto.ParsePrivateKeyFromPEM(keyPEM.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	pfx, err := crypto.EncodePKCS12(key, leafCert, chain, password, crypto.PKCS12Options{
+		Legacy:       legacy,
+		FriendlyName: hostname,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build PKCS#12 file: %w", err)
+	}
+	return pfx, nil
+}
+
+// ValidatePKCS12Password checks a PKCS#12 export password before anything is
+// decrypted
+func ValidatePKCS12Password(password string) error {
+	if utf8.RuneCountInString(password) < minPKCS12PasswordLength {
+		return fmt.Errorf("password must be at least %d characters", minPKCS12PasswordLength)
+	}
+	return nil
 }
@@ -1,14 +1,21 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"crypto/x509"
 	"database/sql"
+	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db"
 	"paddockcontrol-desktop/internal/db/sqlc"
 	"paddockcontrol-desktop/internal/testutil"
+
+	"golang.org/x/crypto/pkcs12"
 )
 
 func TestGetCSRForDownload_Success(t *testing.T) {
@@ -163,9 +170,15 @@ func TestGetPrivateKeyForDownload_Success(t *testing.T) {
 		t.Fatalf("GetPrivateKeyForDownload failed: %v", err)
 	}
 
-	if !strings.Contains(result, "PRIVATE KEY") {
+	pemBytes := result.Bytes()
+	if !strings.Contains(string(pemBytes), "PRIVATE KEY") {
 		t.Error("expected private key PEM header")
 	}
+
+	result.Destroy()
+	if !bytes.Equal(pemBytes, make([]byte, len(pemBytes))) {
+		t.Error("decrypted key should be zeroed after Destroy")
+	}
 }
 
 func TestGetPrivateKeyForDownload_NoKey_ReturnsError(t *testing.T) {
@@ -250,9 +263,15 @@ func TestGetPendingPrivateKeyForDownload_Success(t *testing.T) {
 		t.Fatalf("GetPendingPrivateKeyForDownload failed: %v", err)
 	}
 
-	if !strings.Contains(result, "PRIVATE KEY") {
+	pemBytes := result.Bytes()
+	if !strings.Contains(string(pemBytes), "PRIVATE KEY") {
 		t.Error("expected private key PEM header")
 	}
+
+	result.Destroy()
+	if !bytes.Equal(pemBytes, make([]byte, len(pemBytes))) {
+		t.Error("decrypted key should be zeroed after Destroy")
+	}
 }
 
 func TestGetPendingPrivateKeyForDownload_NoKey_ReturnsError(t *testing.T) {
@@ -303,3 +322,84 @@ func TestGetPendingPrivateKeyForDownload_WrongKey_ReturnsError(t *testing.T) {
 		t.Errorf("expected decrypt error, got: %v", err)
 	}
 }
+
+// storePKCS12TestCertificate stores an active certificate issued by a test
+// root, with the root saved as its chain
+func storePKCS12TestCertificate(t *testing.T, database *db.Database, hostname string, encryptionKey []byte) *x509.Certificate {
+	t.Helper()
+	root, rootKey := newTestCA(t, "Test Root", time.Now().Add(24*time.Hour), nil, nil)
+	csrPEM, encryptedKey, _ := generateTestCSRAndKey(t, hostname, encryptionKey)
+	certPEM := signCSRWithCA(t, csrPEM, root, rootKey)
+
+	err := database.Queries().CreateCertificate(context.Background(), sqlc.CreateCertificateParams{
+		Hostname:            hostname,
+		EncryptedPrivateKey: encryptedKey,
+		CertificatePem:      sql.NullString{String: certPEM, Valid: true},
+		ChainPem:            sql.NullString{String: string(crypto.CertificateToPEM(root)), Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	return root
+}
+
+func TestBuildPKCS12ForDownload_Success(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	hostname := "test.example.com"
+	encryptionKey := testutil.RandomMasterKey(t)
+	root := storePKCS12TestCertificate(t, database, hostname, encryptionKey)
+
+	pfx, err := svc.BuildPKCS12ForDownload(ctx, hostname, "correct horse", true, encryptionKey)
+	if err != nil {
+		t.Fatalf("BuildPKCS12ForDownload failed: %v", err)
+	}
+
+	blocks, err := pkcs12.ToPEM(pfx, "correct horse")
+	if err != nil {
+		t.Fatalf("failed to decode PKCS#12: %v", err)
+	}
+	var certs, keys int
+	for _, block := range blocks {
+		switch block.Type {
+		case "CERTIFICATE":
+			certs++
+			if certs == 2 && !bytes.Equal(block.Bytes, root.Raw) {
+				t.Error("expected the root as the second certificate")
+			}
+		case "PRIVATE KEY":
+			keys++
+		}
+	}
+	if certs != 2 || keys != 1 {
+		t.Errorf("expected 2 certificates and 1 key, got %d and %d", certs, keys)
+	}
+}
+
+func TestBuildPKCS12ForDownload_Rejects(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	encryptionKey := testutil.RandomMasterKey(t)
+	storePKCS12TestCertificate(t, database, "test.example.com", encryptionKey)
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{Hostname: "pending.example.com"}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	if _, err := svc.BuildPKCS12ForDownload(ctx, "test.example.com", "short", false, encryptionKey); err == nil {
+		t.Error("expected a short password to be rejected")
+	}
+	if _, err := svc.BuildPKCS12ForDownload(ctx, "pending.example.com", "correct horse", false, encryptionKey); err == nil {
+		t.Error("expected a certificate without a signed certificate to be rejected")
+	}
+
+	if _, err := database.DB().Exec("UPDATE config SET fips_mode = 1"); err != nil {
+		t.Fatalf("failed to enable FIPS mode: %v", err)
+	}
+	if _, err := svc.BuildPKCS12ForDownload(ctx, "test.example.com", "correct horse", true, encryptionKey); !errors.Is(err, crypto.ErrFIPSViolation) {
+		t.Errorf("expected legacy encryption to be refused in FIPS mode, got %v", err)
+	}
+	if _, err := svc.BuildPKCS12ForDownload(ctx, "test.example.com", "correct horse", false, encryptionKey); err != nil {
+		t.Errorf("expected modern encryption to be allowed in FIPS mode, got %v", err)
+	}
+}
@@ -2,7 +2,9 @@ package services
 
 import (
 	"context"
+	gocrypto "crypto"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
@@ -11,22 +13,85 @@ import (
 
 	"paddockcontrol-desktop/internal/crypto"
 	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/hostnames"
 	"paddockcontrol-desktop/internal/logger"
 	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/subject"
 )
 
+// preparedCSR is a generated CSR and encrypted key, ready to be stored
+type preparedCSR struct {
+	req          models.CSRRequest // Normalized request
+	csrPEM       []byte
+	encryptedKey []byte
+	inherited    []models.SANEntry // SANs copied from the active certificate
+	warnings     models.Warnings
+}
+
 // GenerateCSR generates a new Certificate Signing Request
 func (s *CertificateService) GenerateCSR(ctx context.Context, req models.CSRRequest, encryptionKey []byte) (*models.CSRResponse, error) {
 	ctx, log := logger.WithOperation(ctx, "generate_csr")
+	start := time.Now()
+
+	prepared, err := s.prepareCSR(ctx, log, req, encryptionKey)
+	if err != nil {
+		return nil, err
+	}
+	log = logger.WithHostname(log, prepared.req.Hostname)
+
+	// Store the CSR and record the history event atomically.
+	t := time.Now()
+	var dependents []string
+	if err = s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		dependents, err = s.storeCSRTx(ctx, q, prepared, encryptionKey)
+		return err
+	}); err != nil {
+		log.Error("failed to store CSR", logger.Err(err))
+		return nil, err
+	}
+	log.Debug("profile: Database write", slog.Duration("duration", time.Since(t)))
+
+	log.Info("CSR generated successfully",
+		slog.Duration("total_duration", time.Since(start)),
+		slog.Int("csr_size", len(prepared.csrPEM)),
+	)
+
+	resp := &models.CSRResponse{
+		Hostname:              prepared.req.Hostname,
+		CSR:                   string(prepared.csrPEM),
+		Message:               "CSR generated successfully",
+		DependentCertificates: dependents,
+		Warnings:              prepared.warnings,
+	}
+	if len(prepared.inherited) > 0 {
+		resp.SANsInherited = true
+		for _, san := range prepared.inherited {
+			resp.InheritedSANs = append(resp.InheritedSANs, san.Value)
+		}
+	}
+	return resp, nil
+}
+
+// prepareCSR validates a CSR request, then generates the key pair and the CSR
+// and encrypts the key. Nothing is stored.
+func (s *CertificateService) prepareCSR(ctx context.Context, log *slog.Logger, req models.CSRRequest, encryptionKey []byte) (*preparedCSR, error) {
+	// Normalize hostname so near-duplicates can never be created
+	normalized, err := hostnames.Normalize(req.Hostname)
+	if err != nil {
+		return nil, err
+	}
+	req.Hostname = normalized
+	if req.KeyAlgorithm == "" {
+		req.KeyAlgorithm = models.KeyAlgorithmRSA
+	}
 	log = logger.WithHostname(log, req.Hostname)
 	log.Info("starting CSR generation",
+		slog.String("key_algorithm", req.KeyAlgorithm),
 		slog.Int("key_size", req.KeySize),
 		slog.Bool("is_renewal", req.IsRenewal),
 		slog.Int("san_count", len(req.SANs)),
 	)
 
-	start := time.Now()
-
 	// Validate hostname (with optional bypass for admin mode)
 	t := time.Now()
 	if err := s.validateHostname(ctx, req.Hostname, req.SkipSuffixValidation); err != nil {
@@ -35,6 +100,27 @@ func (s *CertificateService) GenerateCSR(ctx context.Context, req models.CSRRequ
 	}
 	log.Debug("profile: validateHostname", slog.Duration("duration", time.Since(t)))
 
+	warnings := models.NewWarnings()
+	if req.SkipSuffixValidation {
+		if err := s.validateHostname(ctx, req.Hostname, false); err != nil {
+			warnings.Warn("Suffix check skipped: %v", err)
+		}
+	}
+
+	if err := checkNoteForSecrets(req.Note); err != nil {
+		log.Warn("note refused", logger.Err(err))
+		return nil, err
+	}
+
+	if req.Requester != nil {
+		requester := *req.Requester
+		if err := normalizeRequester(&requester); err != nil {
+			log.Warn("requester refused", logger.Err(err))
+			return nil, err
+		}
+		req.Requester = &requester
+	}
+
 	// Check for duplicates
 	t = time.Now()
 	exists, err := s.db.Queries().CertificateExists(ctx, req.Hostname)
@@ -44,44 +130,112 @@ func (s *CertificateService) GenerateCSR(ctx context.Context, req models.CSRRequ
 	}
 	log.Debug("profile: CertificateExists", slog.Duration("duration", time.Since(t)))
 
+	if exists == 1 {
+		if err := checkNotInTrash(ctx, s.db.Queries(), req.Hostname); err != nil {
+			log.Warn("certificate is in the trash", slog.String("hostname", req.Hostname))
+			return nil, err
+		}
+	}
 	if exists == 1 && !req.IsRenewal {
 		log.Warn("certificate already exists", slog.String("hostname", req.Hostname))
 		return nil, fmt.Errorf("certificate already exists for hostname: %s", req.Hostname)
 	}
 
+	// Renewals keep the SANs of the active certificate unless others are given
+	var inherited []models.SANEntry
+	if req.InheritSANs || (req.IsRenewal && exists == 1 && len(req.SANs) == 0) {
+		inherited, err = s.activeCertificateSANs(ctx, req.Hostname, req.InheritSANs)
+		if err != nil {
+			log.Warn("SANs not inherited", logger.Err(err))
+			return nil, err
+		}
+		req.SANs = mergeSANEntries(req.SANs, inherited)
+		log.Info("SANs inherited from the active certificate", slog.Int("count", len(inherited)))
+	}
+
 	// Process SANs into DNS and IP categories
 	t = time.Now()
-	dnsSANs, ipSANs, err := s.processSANEntries(req.SANs)
+	dnsSANs, ipSANs, duplicates, err := s.processSANEntries(req.SANs)
 	if err != nil {
 		log.Error("invalid SAN entry", logger.Err(err))
 		return nil, fmt.Errorf("invalid SAN entry: %w", err)
 	}
+	if len(duplicates) > 0 {
+		log.Warn("duplicate SANs dropped", slog.Int("count", len(duplicates)))
+		warnings.Warn("Duplicate SANs dropped: %s", strings.Join(duplicates, ", "))
+	}
 	log.Debug("profile: processSANEntries",
 		slog.Duration("duration", time.Since(t)),
 		slog.Int("dns_sans", len(dnsSANs)),
 		slog.Int("ip_sans", len(ipSANs)),
 	)
 
-	// Generate RSA key pair
-	t = time.Now()
-	privateKey, err := crypto.GenerateRSAKey(req.KeySize)
+	if err := validateKeyAlgorithm(req.KeyAlgorithm, req.KeySize); err != nil {
+		log.Warn("key algorithm rejected", logger.Err(err))
+		return nil, err
+	}
+
+	fips, err := s.fipsMode(ctx)
 	if err != nil {
-		log.Error("failed to generate RSA key", logger.Err(err))
-		return nil, fmt.Errorf("failed to generate RSA key: %w", err)
+		return nil, err
+	}
+	// Both ECDSA curves offered are FIPS-approved
+	if fips && req.KeyAlgorithm == models.KeyAlgorithmRSA {
+		if err := crypto.CheckFIPSKeySize(req.KeySize); err != nil {
+			log.Warn("key size rejected by FIPS mode", logger.Err(err))
+			return nil, err
+		}
 	}
-	log.Debug("profile: GenerateRSAKey",
+
+	if req.PresetID != 0 {
+		if err := s.applySubjectPreset(ctx, &req); err != nil {
+			log.Warn("subject preset not applied", logger.Err(err))
+			return nil, err
+		}
+	}
+
+	fields := subject.Normalize(subject.Fields{
+		Organization:       req.Organization,
+		OrganizationalUnit: req.OrganizationalUnit,
+		City:               req.City,
+		State:              req.State,
+		Country:            req.Country,
+	})
+	if err := subject.Validate(fields, ""); err != nil {
+		log.Warn("subject validation failed", logger.Err(err))
+		return nil, err
+	}
+
+	// Take an RSA key generated ahead of time, or generate the key pair
+	t = time.Now()
+	var privateKey gocrypto.Signer
+	var encryptedKey []byte
+	pooled := false
+	if req.KeyAlgorithm == models.KeyAlgorithmRSA {
+		privateKey, encryptedKey, pooled = s.keyPool.Take(req.KeySize, encryptionKey)
+	}
+	if !pooled {
+		privateKey, err = crypto.GenerateKey(req.KeyAlgorithm, req.KeySize)
+		if err != nil {
+			log.Error("failed to generate key", logger.Err(err))
+			return nil, fmt.Errorf("failed to generate key: %w", err)
+		}
+	}
+	log.Debug("profile: GenerateKey",
 		slog.Duration("duration", time.Since(t)),
+		slog.String("key_algorithm", req.KeyAlgorithm),
 		slog.Int("key_size", req.KeySize),
+		slog.Bool("pooled", pooled),
 	)
 
 	// Convert CSRRequest to crypto.CSRRequest
 	csrReq := crypto.CSRRequest{
 		CommonName:         req.Hostname,
-		Organization:       req.Organization,
-		OrganizationalUnit: req.OrganizationalUnit,
-		City:               req.City,
-		State:              req.State,
-		Country:            req.Country,
+		Organization:       fields.Organization,
+		OrganizationalUnit: fields.OrganizationalUnit,
+		City:               fields.City,
+		State:              fields.State,
+		Country:            fields.Country,
 		DNSSANs:            dnsSANs,
 		IPSANs:             ipSANs,
 	}
@@ -95,75 +249,129 @@ func (s *CertificateService) GenerateCSR(ctx context.Context, req models.CSRRequ
 	}
 	log.Debug("profile: CreateCSR", slog.Duration("duration", time.Since(t)))
 
-	// Convert private key to PEM
-	t = time.Now()
-	keyPEM, err := crypto.PrivateKeyToPEM(privateKey)
-	if err != nil {
-		log.Error("failed to encode private key", logger.Err(err))
-		return nil, fmt.Errorf("failed to encode private key: %w", err)
-	}
-	log.Debug("profile: PrivateKeyToPEM", slog.Duration("duration", time.Since(t)))
+	// Convert private key to PEM and encrypt it (pooled keys already are)
+	if !pooled {
+		t = time.Now()
+		keyPEM, err := crypto.PrivateKeyToPEM(privateKey)
+		if err != nil {
+			log.Error("failed to encode private key", logger.Err(err))
+			return nil, fmt.Errorf("failed to encode private key: %w", err)
+		}
+		log.Debug("profile: PrivateKeyToPEM", slog.Duration("duration", time.Since(t)))
 
-	// Encrypt private key
-	t = time.Now()
-	encryptedKey, err := crypto.EncryptPrivateKey(keyPEM, encryptionKey)
-	crypto.Zero(keyPEM)
-	if err != nil {
-		log.Error("failed to encrypt private key", logger.Err(err))
-		return nil, fmt.Errorf("failed to encrypt private key: %w", err)
+		t = time.Now()
+		encryptedKey, err = crypto.EncryptPrivateKey(keyPEM, encryptionKey)
+		crypto.Zero(keyPEM)
+		if err != nil {
+			log.Error("failed to encrypt private key", logger.Err(err))
+			return nil, fmt.Errorf("failed to encrypt private key: %w", err)
+		}
+		log.Debug("profile: EncryptPrivateKey", slog.Duration("duration", time.Since(t)))
 	}
-	log.Debug("profile: EncryptPrivateKey", slog.Duration("duration", time.Since(t)))
 
-	// Store the CSR and record the history event atomically.
-	t = time.Now()
+	return &preparedCSR{req: req, csrPEM: csrPEM, encryptedKey: encryptedKey, inherited: inherited, warnings: warnings}, nil
+}
+
+// storeCSRTx stores a prepared CSR within the caller's transaction: a new
+// certificate, or the pending renewal of an existing one, with its requester,
+// key status and history event. Returns the certificates depending on a
+// renewed one.
+func (s *CertificateService) storeCSRTx(ctx context.Context, q *sqlc.Queries, prepared *preparedCSR, encryptionKey []byte) ([]string, error) {
+	req := prepared.req
 	eventType := models.EventCSRGenerated
-	message := fmt.Sprintf("CSR generated (%d-bit key, %d SANs)", req.KeySize, len(req.SANs))
+	message := fmt.Sprintf("CSR generated (%s, %d SANs)", keyDescription(req.KeyAlgorithm, req.KeySize), len(req.SANs))
 	if req.IsRenewal {
 		eventType = models.EventCSRRegenerated
-		message = fmt.Sprintf("CSR regenerated for renewal (%d-bit key, %d SANs)", req.KeySize, len(req.SANs))
+		message = fmt.Sprintf("CSR regenerated for renewal (%s, %d SANs)", keyDescription(req.KeyAlgorithm, req.KeySize), len(req.SANs))
+	}
+	if len(prepared.inherited) > 0 {
+		message += fmt.Sprintf(", %d inherited from the active certificate", len(prepared.inherited))
+	}
+	if req.Requester != nil {
+		message += fmt.Sprintf(", requested by %s", req.Requester.Name)
 	}
 
-	if err = s.db.WithTx(ctx, func(q *sqlc.Queries) error {
-		if req.IsRenewal {
-			// Update existing certificate with pending renewal
-			if err := q.UpdatePendingCSR(ctx, sqlc.UpdatePendingCSRParams{
-				Hostname:                   req.Hostname,
-				PendingCsrPem:              sql.NullString{String: string(csrPEM), Valid: true},
-				PendingEncryptedPrivateKey: encryptedKey,
-				PendingNote:                sql.NullString{String: req.Note, Valid: req.Note != ""},
-			}); err != nil {
-				return fmt.Errorf("failed to store CSR: %w", err)
-			}
-		} else {
-			// Create new certificate record
-			// Key stored in pending column — ActivateCertificate moves it to active on upload
-			if err := q.CreateCertificate(ctx, sqlc.CreateCertificateParams{
-				Hostname:                   req.Hostname,
-				PendingEncryptedPrivateKey: encryptedKey,
-				PendingCsrPem:              sql.NullString{String: string(csrPEM), Valid: true},
-				Note:                       sql.NullString{String: req.Note, Valid: req.Note != ""},
-				ReadOnly:                   0,
-			}); err != nil {
-				return fmt.Errorf("failed to store CSR: %w", err)
-			}
+	var dependents []string
+	if req.IsRenewal {
+		// Update existing certificate with pending renewal
+		if err := q.UpdatePendingCSR(ctx, sqlc.UpdatePendingCSRParams{
+			Hostname:                   req.Hostname,
+			PendingCsrPem:              sql.NullString{String: string(prepared.csrPEM), Valid: true},
+			PendingEncryptedPrivateKey: prepared.encryptedKey,
+			PendingNote:                sql.NullString{String: req.Note, Valid: req.Note != ""},
+		}); err != nil {
+			return nil, fmt.Errorf("failed to store CSR: %w", err)
 		}
-		return s.history.LogEventTx(ctx, q, req.Hostname, eventType, message)
-	}); err != nil {
-		log.Error("failed to store CSR", logger.Err(err))
+		// A new renewal starts with an empty checklist
+		if err := q.ClearRenewalChecklist(ctx, req.Hostname); err != nil {
+			return nil, fmt.Errorf("failed to reset renewal checklist: %w", err)
+		}
+		// Endpoints that depend on the certificate are reported so the
+		// operator checks them once the renewed certificate is deployed
+		related, err := dependentCertificates(ctx, q, req.Hostname)
+		if err != nil {
+			return nil, err
+		}
+		dependents = related
+	} else {
+		// Create new certificate record
+		// Key stored in pending column — ActivateCertificate moves it to active on upload
+		if err := q.CreateCertificate(ctx, sqlc.CreateCertificateParams{
+			Hostname:                   req.Hostname,
+			PendingEncryptedPrivateKey: prepared.encryptedKey,
+			PendingCsrPem:              sql.NullString{String: string(prepared.csrPEM), Valid: true},
+			Note:                       sql.NullString{String: req.Note, Valid: req.Note != ""},
+			ReadOnly:                   0,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to store CSR: %w", err)
+		}
+	}
+	if req.Requester != nil {
+		if err := q.UpsertCertificateRequester(ctx, sqlc.UpsertCertificateRequesterParams{
+			Hostname:       req.Hostname,
+			RequesterName:  req.Requester.Name,
+			RequesterEmail: req.Requester.Email,
+			Team:           req.Requester.Team,
+			Justification:  req.Requester.Justification,
+			RecordedAt:     s.clock.Now().Unix(),
+		}); err != nil {
+			return nil, fmt.Errorf("failed to store requester: %w", err)
+		}
+	}
+	if err := RefreshKeyStatusTx(ctx, q, req.Hostname, encryptionKey); err != nil {
 		return nil, err
 	}
-	log.Debug("profile: Database write", slog.Duration("duration", time.Since(t)))
+	if err := s.history.LogEventTx(ctx, q, req.Hostname, eventType, message); err != nil {
+		return nil, err
+	}
+	return dependents, nil
+}
 
-	log.Info("CSR generated successfully",
-		slog.Duration("total_duration", time.Since(start)),
-		slog.Int("csr_size", len(csrPEM)),
-	)
+// validateKeyAlgorithm checks the key algorithm of a CSR request and that the
+// key size suits it
+func validateKeyAlgorithm(algorithm string, keySize int) error {
+	switch algorithm {
+	case models.KeyAlgorithmRSA:
+		if keySize < 2048 {
+			return fmt.Errorf("key size must be at least 2048 bits")
+		}
+	case models.KeyAlgorithmECDSA:
+		if keySize != 256 && keySize != 384 {
+			return fmt.Errorf("ECDSA key size must be 256 (P-256) or 384 (P-384)")
+		}
+	default:
+		return fmt.Errorf("key algorithm must be rsa or ecdsa")
+	}
+	return nil
+}
 
-	return &models.CSRResponse{
-		Hostname: req.Hostname,
-		CSR:      string(csrPEM),
-		Message:  "CSR generated successfully",
-	}, nil
+// keyDescription describes a key for history messages: "4096-bit key" for
+// RSA, "ECDSA P-256 key" for ECDSA
+func keyDescription(algorithm string, keySize int) string {
+	if algorithm == models.KeyAlgorithmECDSA {
+		return fmt.Sprintf("ECDSA P-%d key", keySize)
+	}
+	return fmt.Sprintf("%d-bit key", keySize)
 }
 
 // validateHostname validates the hostname against configuration
@@ -183,7 +391,13 @@ func (s *CertificateService) validateHostname(ctx context.Context, hostname stri
 	}
 
 	if cfg != nil && cfg.HostnameSuffix != "" {
-		if !strings.HasSuffix(hostname, cfg.HostnameSuffix) {
+		// Compare in punycode form: the hostname is already normalized, and the
+		// configured suffix may have been entered in unicode.
+		suffix, err := hostnames.NormalizeSuffix(cfg.HostnameSuffix)
+		if err != nil {
+			return fmt.Errorf("invalid hostname suffix in configuration: %w", err)
+		}
+		if !strings.HasSuffix(hostname, suffix) {
 			return fmt.Errorf("hostname must end with %s", cfg.HostnameSuffix)
 		}
 	}
@@ -191,25 +405,114 @@ func (s *CertificateService) validateHostname(ctx context.Context, hostname stri
 	return nil
 }
 
-// processSANEntries converts SANEntry slice to separate DNS and IP SAN slices
-func (s *CertificateService) processSANEntries(entries []models.SANEntry) ([]string, []net.IP, error) {
+// applySubjectPreset fills the subject fields left empty in req from the
+// selected subject preset. Fields the user typed win over the preset.
+func (s *CertificateService) applySubjectPreset(ctx context.Context, req *models.CSRRequest) error {
+	preset, err := s.config.GetSubjectPreset(ctx, req.PresetID)
+	if err != nil {
+		return err
+	}
+
+	fill := func(field *string, value string) {
+		if strings.TrimSpace(*field) == "" {
+			*field = value
+		}
+	}
+	fill(&req.Organization, preset.Organization)
+	fill(&req.OrganizationalUnit, preset.OrganizationalUnit)
+	fill(&req.City, preset.City)
+	fill(&req.State, preset.State)
+	fill(&req.Country, preset.Country)
+	return nil
+}
+
+// activeCertificateSANs returns the DNS and IP SANs of the hostname's active
+// certificate. A missing or unreadable active certificate means there is
+// nothing to inherit: that is an error only when inheritance was explicitly
+// requested.
+func (s *CertificateService) activeCertificateSANs(ctx context.Context, hostname string, required bool) ([]models.SANEntry, error) {
+	cert, err := s.db.Queries().GetCertificateByHostname(ctx, hostname)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get certificate: %w", err)
+	}
+	if err != nil || !cert.CertificatePem.Valid || cert.CertificatePem.String == "" {
+		if required {
+			return nil, fmt.Errorf("no active certificate for %s to inherit SANs from", hostname)
+		}
+		return nil, nil
+	}
+
+	parsed, err := crypto.ParseCertificate([]byte(cert.CertificatePem.String))
+	if err != nil {
+		if required {
+			return nil, fmt.Errorf("failed to parse active certificate: %w", err)
+		}
+		return nil, nil
+	}
+
+	var entries []models.SANEntry
+	for _, name := range parsed.DNSNames {
+		entries = append(entries, models.SANEntry{Value: name, Type: models.SANTypeDNS})
+	}
+	for _, ip := range parsed.IPAddresses {
+		entries = append(entries, models.SANEntry{Value: ip.String(), Type: models.SANTypeIP})
+	}
+	return entries, nil
+}
+
+// mergeSANEntries appends the inherited SANs missing from requested, in order.
+func mergeSANEntries(requested, inherited []models.SANEntry) []models.SANEntry {
+	seen := make(map[string]bool, len(requested))
+	for _, san := range requested {
+		seen[strings.ToLower(san.Value)] = true
+	}
+	merged := requested
+	for _, san := range inherited {
+		if !seen[strings.ToLower(san.Value)] {
+			seen[strings.ToLower(san.Value)] = true
+			merged = append(merged, san)
+		}
+	}
+	return merged
+}
+
+// processSANEntries converts SANEntry slice to separate DNS and IP SAN slices.
+// An entry equal to an earlier one once normalized is dropped and returned
+// as a duplicate.
+func (s *CertificateService) processSANEntries(entries []models.SANEntry) ([]string, []net.IP, []string, error) {
 	var dnsSANs []string
 	var ipSANs []net.IP
+	var duplicates []string
+	seen := make(map[string]bool, len(entries))
 
 	for _, entry := range entries {
 		switch entry.Type {
 		case models.SANTypeDNS:
-			dnsSANs = append(dnsSANs, entry.Value)
+			value, err := hostnames.Normalize(entry.Value)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if seen["dns:"+value] {
+				duplicates = append(duplicates, entry.Value)
+				continue
+			}
+			seen["dns:"+value] = true
+			dnsSANs = append(dnsSANs, value)
 		case models.SANTypeIP:
 			ip := net.ParseIP(entry.Value)
 			if ip == nil {
-				return nil, nil, fmt.Errorf("invalid IP address: %s", entry.Value)
+				return nil, nil, nil, fmt.Errorf("invalid IP address: %s", entry.Value)
+			}
+			if seen["ip:"+ip.String()] {
+				duplicates = append(duplicates, entry.Value)
+				continue
 			}
+			seen["ip:"+ip.String()] = true
 			ipSANs = append(ipSANs, ip)
 		default:
-			return nil, nil, fmt.Errorf("unknown SAN type: %s", entry.Type)
+			return nil, nil, nil, fmt.Errorf("unknown SAN type: %s", entry.Type)
 		}
 	}
 
-	return dnsSANs, ipSANs, nil
+	return dnsSANs, ipSANs, duplicates, nil
 }
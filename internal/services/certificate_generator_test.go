@@ -5,6 +5,7 @@ import (
 	"crypto/x509"
 	"database/sql"
 	"encoding/pem"
+	"errors"
 	"net"
 	"strings"
 	"testing"
@@ -135,6 +136,92 @@ func TestGenerateCSR_WithDNSSANs(t *testing.T) {
 	}
 }
 
+func TestGenerateCSR_DuplicateSANs_Dropped(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	req := models.CSRRequest{
+		Hostname:     "server.example.com",
+		Organization: "Test Org",
+		City:         "Paris",
+		State:        "IDF",
+		Country:      "FR",
+		KeySize:      2048,
+		SANs: []models.SANEntry{
+			{Value: "alias.example.com", Type: models.SANTypeDNS},
+			{Value: "ALIAS.example.com", Type: models.SANTypeDNS},
+			{Value: "10.0.0.1", Type: models.SANTypeIP},
+			{Value: "10.0.0.1", Type: models.SANTypeIP},
+		},
+	}
+
+	resp, err := svc.GenerateCSR(ctx, req, encryptionKey)
+	if err != nil {
+		t.Fatalf("GenerateCSR failed: %v", err)
+	}
+	if len(resp.Warnings.Warnings) != 1 || resp.Warnings.Warnings[0] != "Duplicate SANs dropped: ALIAS.example.com, 10.0.0.1" {
+		t.Errorf("unexpected warnings: %v", resp.Warnings.Warnings)
+	}
+
+	block, _ := pem.Decode([]byte(resp.CSR))
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		t.Fatalf("failed to parse CSR: %v", err)
+	}
+	if len(csr.DNSNames) != 1 || len(csr.IPAddresses) != 1 {
+		t.Errorf("expected one DNS and one IP SAN, got %v %v", csr.DNSNames, csr.IPAddresses)
+	}
+}
+
+func TestGenerateCSR_IDNHostname_EncodedAsPunycode(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	req := models.CSRRequest{
+		Hostname:     "münchen.example.com",
+		Organization: "Test Org",
+		City:         "Paris",
+		State:        "IDF",
+		Country:      "FR",
+		KeySize:      2048,
+		SANs: []models.SANEntry{
+			{Value: "köln.example.com", Type: models.SANTypeDNS},
+		},
+	}
+
+	resp, err := svc.GenerateCSR(ctx, req, encryptionKey)
+	if err != nil {
+		t.Fatalf("GenerateCSR failed: %v", err)
+	}
+	if resp.Hostname != "xn--mnchen-3ya.example.com" {
+		t.Errorf("expected punycode hostname, got %s", resp.Hostname)
+	}
+
+	block, _ := pem.Decode([]byte(resp.CSR))
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		t.Fatalf("failed to parse CSR: %v", err)
+	}
+	if csr.Subject.CommonName != "xn--mnchen-3ya.example.com" {
+		t.Errorf("expected punycode CN, got %s", csr.Subject.CommonName)
+	}
+	if len(csr.DNSNames) != 1 || csr.DNSNames[0] != "xn--kln-sna.example.com" {
+		t.Errorf("expected punycode DNS SAN, got %v", csr.DNSNames)
+	}
+
+	cert, err := svc.GetCertificate(ctx, resp.Hostname)
+	if err != nil {
+		t.Fatalf("GetCertificate failed: %v", err)
+	}
+	if cert.DisplayHostname != "münchen.example.com" {
+		t.Errorf("expected unicode display hostname, got %s", cert.DisplayHostname)
+	}
+}
+
 func TestGenerateCSR_WithIPSANs(t *testing.T) {
 	svc, database := setupTestService(t)
 	setupTestConfig(t, database)
@@ -208,7 +295,7 @@ func TestGenerateCSR_StoresEncryptedKey(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to decrypt stored key: %v", err)
 	}
-	if !strings.Contains(string(decrypted), "PRIVATE KEY") {
+	if !strings.Contains(string(decrypted.Bytes()), "PRIVATE KEY") {
 		t.Error("decrypted key should be PEM-encoded")
 	}
 }
@@ -285,6 +372,9 @@ func TestGenerateCSR_SkipSuffixValidation(t *testing.T) {
 	if resp.Hostname != "server.otherdomain.com" {
 		t.Errorf("expected hostname server.otherdomain.com, got %s", resp.Hostname)
 	}
+	if len(resp.Warnings.Warnings) != 1 || !containsSubstring(resp.Warnings.Warnings[0], "Suffix check skipped") {
+		t.Errorf("expected a skipped suffix warning, got %v", resp.Warnings.Warnings)
+	}
 }
 
 func TestGenerateCSR_DuplicateHostname_ReturnsError(t *testing.T) {
@@ -446,7 +536,7 @@ func TestGenerateCSR_LogsHistoryEvent(t *testing.T) {
 	}
 
 	// Check history
-	history := NewHistoryService(database)
+	history := NewHistoryService(database, "test")
 	entries, err := history.GetHistory(ctx, "server.example.com", 10)
 	if err != nil {
 		t.Fatalf("GetHistory failed: %v", err)
@@ -489,7 +579,7 @@ func TestGenerateCSR_Renewal_LogsHistoryEvent(t *testing.T) {
 		t.Fatalf("GenerateCSR renewal failed: %v", err)
 	}
 
-	history := NewHistoryService(database)
+	history := NewHistoryService(database, "test")
 	entries, err := history.GetHistory(ctx, "server.example.com", 10)
 	if err != nil {
 		t.Fatalf("GetHistory failed: %v", err)
@@ -531,3 +621,304 @@ func TestGenerateCSR_WithNote(t *testing.T) {
 		t.Errorf("expected note 'Production web server', got %v", cert.Note)
 	}
 }
+
+func TestGenerateCSR_FIPSMode_RejectsSmallKeys(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	if _, err := database.DB().Exec("UPDATE config SET fips_mode = 1"); err != nil {
+		t.Fatalf("failed to enable FIPS mode: %v", err)
+	}
+
+	req := models.CSRRequest{
+		Hostname: "server.example.com",
+		Country:  "FR",
+		KeySize:  2048,
+	}
+	_, err := svc.GenerateCSR(ctx, req, testutil.RandomMasterKey(t))
+	if !errors.Is(err, crypto.ErrFIPSViolation) {
+		t.Fatalf("expected ErrFIPSViolation, got %v", err)
+	}
+
+	exists, err := database.Queries().CertificateExists(ctx, "server.example.com")
+	if err != nil {
+		t.Fatalf("CertificateExists failed: %v", err)
+	}
+	if exists == 1 {
+		t.Error("no certificate should be stored when FIPS mode refuses the key size")
+	}
+}
+
+func TestGenerateCSR_ECDSA(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	req := models.CSRRequest{
+		Hostname:     "server.example.com",
+		Organization: "Test Org",
+		City:         "Paris",
+		State:        "IDF",
+		Country:      "FR",
+		KeyAlgorithm: models.KeyAlgorithmECDSA,
+		KeySize:      384,
+	}
+	resp, err := svc.GenerateCSR(ctx, req, encryptionKey)
+	if err != nil {
+		t.Fatalf("GenerateCSR failed: %v", err)
+	}
+
+	block, _ := pem.Decode([]byte(resp.CSR))
+	if block == nil {
+		t.Fatal("failed to decode CSR PEM")
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		t.Fatalf("failed to parse CSR: %v", err)
+	}
+	if csr.PublicKeyAlgorithm != x509.ECDSA || csr.SignatureAlgorithm != x509.ECDSAWithSHA384 {
+		t.Errorf("expected an ECDSA P-384 CSR, got %v signed with %v", csr.PublicKeyAlgorithm, csr.SignatureAlgorithm)
+	}
+
+	cert, err := database.Queries().GetCertificateByHostname(ctx, "server.example.com")
+	if err != nil {
+		t.Fatalf("failed to get certificate: %v", err)
+	}
+	decrypted, err := crypto.DecryptPrivateKey(cert.PendingEncryptedPrivateKey, encryptionKey)
+	if err != nil {
+		t.Fatalf("failed to decrypt stored key: %v", err)
+	}
+	key, err := crypto.ParsePrivateKeyFromPEM(decrypted.Bytes())
+	if err != nil {
+		t.Fatalf("failed to parse stored key: %v", err)
+	}
+	if algorithm, size := crypto.PublicKeyInfo(key.Public()); algorithm != models.KeyAlgorithmECDSA || size != 384 {
+		t.Errorf("expected an ECDSA P-384 key, got %s %d", algorithm, size)
+	}
+
+	history, err := NewHistoryService(database, "test").GetHistory(ctx, "server.example.com", 10)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(history) != 1 || !strings.Contains(history[0].Message, "ECDSA P-384") {
+		t.Errorf("expected the history to name the ECDSA key, got %+v", history)
+	}
+}
+
+func TestGenerateCSR_RejectsInvalidKeyAlgorithm(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+
+	for _, key := range []struct {
+		algorithm string
+		size      int
+	}{
+		{models.KeyAlgorithmECDSA, 2048},
+		{models.KeyAlgorithmRSA, 256},
+		{"dsa", 2048},
+	} {
+		req := models.CSRRequest{
+			Hostname:     "server.example.com",
+			Organization: "Test Org",
+			City:         "Paris",
+			State:        "IDF",
+			Country:      "FR",
+			KeyAlgorithm: key.algorithm,
+			KeySize:      key.size,
+		}
+		_, err := svc.GenerateCSR(ctx, req, testutil.RandomMasterKey(t))
+		if err == nil || !strings.Contains(err.Error(), "key") {
+			t.Errorf("expected %s %d to be rejected, got %v", key.algorithm, key.size, err)
+		}
+	}
+}
+
+func TestGenerateCSR_ValidatesSubject(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	req := models.CSRRequest{
+		Hostname:     "server.example.com",
+		Organization: strings.Repeat("x", 65),
+		City:         "Paris",
+		State:        "IDF",
+		Country:      "FR",
+		KeySize:      2048,
+	}
+	if _, err := svc.GenerateCSR(ctx, req, encryptionKey); err == nil || !strings.Contains(err.Error(), "must not exceed 64") {
+		t.Fatalf("expected an organization length error, got %v", err)
+	}
+
+	// Values are trimmed and NFC-normalized before encoding
+	req.Organization = " Société "
+	req.City = "Mu\u0308nchen"
+	req.Country = "de"
+	resp, err := svc.GenerateCSR(ctx, req, encryptionKey)
+	if err != nil {
+		t.Fatalf("GenerateCSR failed: %v", err)
+	}
+	csr, err := crypto.ParseCSR([]byte(resp.CSR))
+	if err != nil {
+		t.Fatalf("failed to parse CSR: %v", err)
+	}
+	if got := csr.Subject.Organization; len(got) != 1 || got[0] != "Société" {
+		t.Errorf("Organization = %q, want [Société]", got)
+	}
+	if got := csr.Subject.Locality; len(got) != 1 || got[0] != "München" {
+		t.Errorf("Locality = %q, want the precomposed form", got)
+	}
+	if got := csr.Subject.Country; len(got) != 1 || got[0] != "DE" {
+		t.Errorf("Country = %q, want [DE]", got)
+	}
+}
+
+func TestGenerateCSR_SubjectPreset(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	preset, err := svc.config.SaveSubjectPreset(ctx, models.SubjectPreset{
+		Name:               "Subsidiary",
+		Organization:       "Subsidiary GmbH",
+		OrganizationalUnit: "Platform",
+		City:               "Berlin",
+		State:              "Berlin",
+		Country:            "DE",
+	})
+	if err != nil {
+		t.Fatalf("SaveSubjectPreset failed: %v", err)
+	}
+
+	// Empty fields come from the preset, typed fields win
+	resp, err := svc.GenerateCSR(ctx, models.CSRRequest{
+		Hostname: "server.example.com",
+		City:     "Hamburg",
+		KeySize:  2048,
+		PresetID: preset.ID,
+	}, encryptionKey)
+	if err != nil {
+		t.Fatalf("GenerateCSR failed: %v", err)
+	}
+	csr, err := crypto.ParseCSR([]byte(resp.CSR))
+	if err != nil {
+		t.Fatalf("failed to parse CSR: %v", err)
+	}
+	if got := csr.Subject.Organization; len(got) != 1 || got[0] != "Subsidiary GmbH" {
+		t.Errorf("Organization = %q, want [Subsidiary GmbH]", got)
+	}
+	if got := csr.Subject.OrganizationalUnit; len(got) != 1 || got[0] != "Platform" {
+		t.Errorf("OrganizationalUnit = %q, want [Platform]", got)
+	}
+	if got := csr.Subject.Locality; len(got) != 1 || got[0] != "Hamburg" {
+		t.Errorf("Locality = %q, want [Hamburg]", got)
+	}
+
+	if _, err := svc.GenerateCSR(ctx, models.CSRRequest{
+		Hostname: "other.example.com",
+		KeySize:  2048,
+		PresetID: preset.ID + 1,
+	}, encryptionKey); err == nil || !strings.Contains(err.Error(), "subject preset not found") {
+		t.Fatalf("expected an unknown preset error, got %v", err)
+	}
+}
+
+func TestGenerateCSR_Renewal_InheritsSANs(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	key, err := crypto.GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	csrPEM, err := crypto.CreateCSR(crypto.CSRRequest{
+		CommonName: "server.example.com",
+		Country:    "FR",
+		DNSSANs:    []string{"server.example.com", "www.example.com"},
+		IPSANs:     []net.IP{net.ParseIP("10.0.0.1")},
+	}, key)
+	if err != nil {
+		t.Fatalf("failed to create CSR: %v", err)
+	}
+	certPEM, err := selfSignCertFromCSR(csrPEM, key)
+	if err != nil {
+		t.Fatalf("failed to sign certificate: %v", err)
+	}
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:       "server.example.com",
+		CertificatePem: sql.NullString{String: certPEM, Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	req := models.CSRRequest{
+		Hostname:     "server.example.com",
+		Organization: "Test Org",
+		City:         "Paris",
+		State:        "IDF",
+		Country:      "FR",
+		KeySize:      2048,
+		IsRenewal:    true,
+	}
+	resp, err := svc.GenerateCSR(ctx, req, encryptionKey)
+	if err != nil {
+		t.Fatalf("GenerateCSR failed: %v", err)
+	}
+	if !resp.SANsInherited || len(resp.InheritedSANs) != 3 {
+		t.Errorf("expected 3 inherited SANs, got %+v", resp)
+	}
+	csr, err := crypto.ParseCSR([]byte(resp.CSR))
+	if err != nil {
+		t.Fatalf("failed to parse CSR: %v", err)
+	}
+	if len(csr.DNSNames) != 2 || len(csr.IPAddresses) != 1 {
+		t.Errorf("expected the active SANs in the CSR, got %v %v", csr.DNSNames, csr.IPAddresses)
+	}
+
+	// Explicit SANs replace the active ones unless InheritSANs is set
+	req.SANs = []models.SANEntry{{Value: "api.example.com", Type: models.SANTypeDNS}}
+	resp, err = svc.GenerateCSR(ctx, req, encryptionKey)
+	if err != nil {
+		t.Fatalf("GenerateCSR failed: %v", err)
+	}
+	if resp.SANsInherited {
+		t.Error("SANs should not be inherited when SANs are given")
+	}
+
+	req.InheritSANs = true
+	resp, err = svc.GenerateCSR(ctx, req, encryptionKey)
+	if err != nil {
+		t.Fatalf("GenerateCSR failed: %v", err)
+	}
+	csr, err = crypto.ParseCSR([]byte(resp.CSR))
+	if err != nil {
+		t.Fatalf("failed to parse CSR: %v", err)
+	}
+	if len(csr.DNSNames) != 3 || csr.DNSNames[0] != "api.example.com" {
+		t.Errorf("expected the given SAN followed by the active ones, got %v", csr.DNSNames)
+	}
+}
+
+func TestGenerateCSR_InheritSANs_RequiresActiveCertificate(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+
+	_, err := svc.GenerateCSR(context.Background(), models.CSRRequest{
+		Hostname:     "new.example.com",
+		Organization: "Test Org",
+		City:         "Paris",
+		State:        "IDF",
+		Country:      "FR",
+		KeySize:      2048,
+		InheritSANs:  true,
+	}, testutil.RandomMasterKey(t))
+	if err == nil || !strings.Contains(err.Error(), "no active certificate") {
+		t.Fatalf("expected a missing active certificate error, got %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/hostnames"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// FindHostnameDuplicates returns the stored hostnames that are not in normalized
+// form, grouped by their normalized hostname. A group with several hostnames is a
+// set of near-duplicates ("Web.example.com" and "web.example.com") that must be
+// merged; a group with a single hostname only needs to be renamed.
+func (s *CertificateService) FindHostnameDuplicates(ctx context.Context) ([]models.HostnameDuplicateGroup, error) {
+	certs, err := s.db.Queries().ListAllCertificates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	groups := make(map[string][]string)
+	for _, cert := range certs {
+		normalized, err := hostnames.Normalize(cert.Hostname)
+		if err != nil {
+			// Unparseable legacy hostname — nothing to merge it with
+			continue
+		}
+		groups[normalized] = append(groups[normalized], cert.Hostname)
+	}
+
+	result := []models.HostnameDuplicateGroup{}
+	for normalized, names := range groups {
+		if len(names) == 1 && names[0] == normalized {
+			continue
+		}
+		sort.Strings(names)
+		result = append(result, models.HostnameDuplicateGroup{
+			Normalized: normalized,
+			Hostnames:  names,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Normalized < result[j].Normalized
+	})
+
+	return result, nil
+}
+
+// MergeHostnameDuplicates resolves a group of near-duplicate hostnames. The
+// certificate stored under keep is renamed to its normalized hostname, every other
+// certificate of the group is deleted, and all of the group's history is moved to
+// the kept certificate. Runs in a single transaction.
+func (s *CertificateService) MergeHostnameDuplicates(ctx context.Context, keep string) (string, error) {
+	normalized, err := hostnames.Normalize(keep)
+	if err != nil {
+		return "", err
+	}
+
+	certs, err := s.db.Queries().ListAllCertificates(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	var found bool
+	var others []string
+	for _, cert := range certs {
+		n, err := hostnames.Normalize(cert.Hostname)
+		if err != nil || n != normalized {
+			continue
+		}
+		if cert.Hostname == keep {
+			found = true
+			continue
+		}
+		others = append(others, cert.Hostname)
+	}
+	if !found {
+		return "", fmt.Errorf("certificate not found: %s", keep)
+	}
+	if len(others) == 0 && keep == normalized {
+		return "", fmt.Errorf("hostname %s is already normalized and has no duplicates", keep)
+	}
+
+	err = s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		// Drop the duplicates first, keeping their history under the kept hostname.
+		// ReadOnly protection is enforced: merging must not silently delete a
+		// certificate the user explicitly protected.
+		for _, other := range others {
+			cert, err := q.GetCertificateByHostname(ctx, other)
+			if err != nil {
+				return fmt.Errorf("failed to get certificate %s: %w", other, err)
+			}
+			if cert.ReadOnly == 1 {
+				return fmt.Errorf("certificate %s is read-only and cannot be merged", other)
+			}
+			if err := renameHostnameTx(ctx, q, other, keep, false); err != nil {
+				return err
+			}
+		}
+
+		if keep != normalized {
+			if err := renameHostnameTx(ctx, q, keep, normalized, true); err != nil {
+				return err
+			}
+		}
+
+		message := fmt.Sprintf("Hostname normalized to %s", normalized)
+		if len(others) > 0 {
+			message = fmt.Sprintf("Merged near-duplicate hostnames (%s) into %s",
+				strings.Join(append([]string{keep}, others...), ", "), normalized)
+		}
+		return s.history.LogEventTx(ctx, q, normalized, models.EventHostnameMerged, message)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return normalized, nil
+}
+
+// renameHostnameTx moves the history and deployment targets of oldHostname to
+// newHostname and deletes the old certificate row. When copyRow is true the
+// certificate row itself is first copied to newHostname, with its renewal
+// checklist, chain override, relations and requester (a rename); otherwise the
+// row is discarded (a merge into an existing certificate).
+func renameHostnameTx(ctx context.Context, q *sqlc.Queries, oldHostname, newHostname string, copyRow bool) error {
+	if copyRow {
+		if err := q.CopyCertificateToHostname(ctx, sqlc.CopyCertificateToHostnameParams{
+			NewHostname: newHostname,
+			OldHostname: oldHostname,
+		}); err != nil {
+			return fmt.Errorf("failed to rename certificate %s: %w", oldHostname, err)
+		}
+		if err := q.ReassignRenewalChecklist(ctx, sqlc.ReassignRenewalChecklistParams{
+			NewHostname: newHostname,
+			OldHostname: oldHostname,
+		}); err != nil {
+			return fmt.Errorf("failed to move renewal checklist of %s: %w", oldHostname, err)
+		}
+		if err := q.ReassignChainOverride(ctx, sqlc.ReassignChainOverrideParams{
+			NewHostname: sql.NullString{String: newHostname, Valid: true},
+			OldHostname: sql.NullString{String: oldHostname, Valid: true},
+		}); err != nil {
+			return fmt.Errorf("failed to move chain override of %s: %w", oldHostname, err)
+		}
+		if err := q.ReassignCertificateRelationSources(ctx, sqlc.ReassignCertificateRelationSourcesParams{
+			NewHostname: newHostname,
+			OldHostname: oldHostname,
+		}); err != nil {
+			return fmt.Errorf("failed to move relations of %s: %w", oldHostname, err)
+		}
+		if err := q.ReassignCertificateRelationTargets(ctx, sqlc.ReassignCertificateRelationTargetsParams{
+			NewHostname: newHostname,
+			OldHostname: oldHostname,
+		}); err != nil {
+			return fmt.Errorf("failed to move relations of %s: %w", oldHostname, err)
+		}
+		if err := q.ReassignCertificateRequester(ctx, sqlc.ReassignCertificateRequesterParams{
+			NewHostname: newHostname,
+			OldHostname: oldHostname,
+		}); err != nil {
+			return fmt.Errorf("failed to move requester of %s: %w", oldHostname, err)
+		}
+	}
+	if err := q.ReassignDeploymentTargets(ctx, sqlc.ReassignDeploymentTargetsParams{
+		NewHostname: newHostname,
+		OldHostname: oldHostname,
+	}); err != nil {
+		return fmt.Errorf("failed to move deployment targets of %s: %w", oldHostname, err)
+	}
+	if err := q.ReassignSyncAgentHostnames(ctx, sqlc.ReassignSyncAgentHostnamesParams{
+		NewHostname: newHostname,
+		OldHostname: oldHostname,
+	}); err != nil {
+		return fmt.Errorf("failed to move sync agent assignments of %s: %w", oldHostname, err)
+	}
+	if err := q.ReassignCertificateTags(ctx, sqlc.ReassignCertificateTagsParams{
+		NewHostname: newHostname,
+		OldHostname: oldHostname,
+	}); err != nil {
+		return fmt.Errorf("failed to move tags of %s: %w", oldHostname, err)
+	}
+	if err := q.ReassignCertificateHistory(ctx, sqlc.ReassignCertificateHistoryParams{
+		NewHostname: newHostname,
+		OldHostname: oldHostname,
+	}); err != nil {
+		return fmt.Errorf("failed to move history of %s: %w", oldHostname, err)
+	}
+	if err := q.DeleteCertificate(ctx, oldHostname); err != nil {
+		return fmt.Errorf("failed to delete certificate %s: %w", oldHostname, err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// ============================================================================
+// FindHostnameDuplicates / MergeHostnameDuplicates Tests
+// ============================================================================
+
+func TestFindHostnameDuplicates_GroupsNearDuplicates(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+
+	for _, h := range []string{"Web.example.com", "web.example.com", "API.example.com", "ok.example.com"} {
+		if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{Hostname: h}); err != nil {
+			t.Fatalf("failed to create certificate %s: %v", h, err)
+		}
+	}
+
+	groups, err := svc.FindHostnameDuplicates(ctx)
+	if err != nil {
+		t.Fatalf("FindHostnameDuplicates failed: %v", err)
+	}
+
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d: %+v", len(groups), groups)
+	}
+	if groups[0].Normalized != "api.example.com" || len(groups[0].Hostnames) != 1 {
+		t.Errorf("unexpected first group: %+v", groups[0])
+	}
+	if groups[1].Normalized != "web.example.com" || len(groups[1].Hostnames) != 2 {
+		t.Errorf("unexpected second group: %+v", groups[1])
+	}
+}
+
+func TestMergeHostnameDuplicates_KeepsChosenCertificate(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	q := database.Queries()
+
+	if err := q.CreateCertificate(ctx, sqlc.CreateCertificateParams{Hostname: "Web.example.com"}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	if err := q.CreateCertificate(ctx, sqlc.CreateCertificateParams{Hostname: "web.example.com"}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	if err := q.UpdateCertificateNote(ctx, sqlc.UpdateCertificateNoteParams{
+		Hostname: "Web.example.com",
+		Note:     sql.NullString{String: "keep me", Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to set note: %v", err)
+	}
+	if err := q.AddHistoryEntry(ctx, sqlc.AddHistoryEntryParams{Hostname: "web.example.com", EventType: models.EventCSRGenerated, Message: "old"}); err != nil {
+		t.Fatalf("failed to add history: %v", err)
+	}
+
+	normalized, err := svc.MergeHostnameDuplicates(ctx, "Web.example.com")
+	if err != nil {
+		t.Fatalf("MergeHostnameDuplicates failed: %v", err)
+	}
+	if normalized != "web.example.com" {
+		t.Fatalf("expected normalized hostname web.example.com, got %s", normalized)
+	}
+
+	exists, _ := q.CertificateExists(ctx, "Web.example.com")
+	if exists != 0 {
+		t.Error("non-normalized hostname should no longer exist")
+	}
+
+	cert, err := q.GetCertificateByHostname(ctx, "web.example.com")
+	if err != nil {
+		t.Fatalf("failed to get merged certificate: %v", err)
+	}
+	if cert.Note.String != "keep me" {
+		t.Errorf("expected kept certificate's note, got %q", cert.Note.String)
+	}
+
+	history, err := svc.GetHistory(ctx, "web.example.com", 0)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 history entries (moved + merge event), got %d", len(history))
+	}
+}
+
+func TestMergeHostnameDuplicates_RefusesReadOnlyDuplicate(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	q := database.Queries()
+
+	if err := q.CreateCertificate(ctx, sqlc.CreateCertificateParams{Hostname: "Web.example.com"}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	if err := q.CreateCertificate(ctx, sqlc.CreateCertificateParams{Hostname: "web.example.com", ReadOnly: 1}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	if _, err := svc.MergeHostnameDuplicates(ctx, "Web.example.com"); err == nil {
+		t.Fatal("expected error when a duplicate is read-only")
+	}
+
+	// Transaction rolled back: both rows still present
+	for _, h := range []string{"Web.example.com", "web.example.com"} {
+		if exists, _ := q.CertificateExists(ctx, h); exists != 1 {
+			t.Errorf("expected %s to still exist", h)
+		}
+	}
+}
+
+func TestMergeHostnameDuplicates_AlreadyNormalized(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{Hostname: "web.example.com"}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	if _, err := svc.MergeHostnameDuplicates(ctx, "web.example.com"); err == nil {
+		t.Fatal("expected error for an already-normalized hostname")
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+
+	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/xlsx"
+)
+
+// inventoryHeader lists the columns of a certificate inventory export
+var inventoryHeader = []string{
+	"hostname", "status", "sans", "expires_at", "days_until_expiration",
+	"key_algorithm", "key_size", "read_only", "pending_csr", "note",
+}
+
+// BuildInventory renders every certificate, sorted by hostname, as a CSV or
+// XLSX report (models.InventoryFormat*) for audits: status, SANs, expiry (RFC
+// 3339, UTC), key, read-only flag and note. SANs and the key come from the
+// active certificate, or the pending CSR before one is issued.
+func (s *CertificateService) BuildInventory(ctx context.Context, format string) ([]byte, error) {
+	if format != models.InventoryFormatCSV && format != models.InventoryFormatXLSX {
+		return nil, fmt.Errorf("unsupported inventory format: %s", format)
+	}
+
+	if err := s.refreshCertificateMetadata(ctx); err != nil {
+		return nil, err
+	}
+	certs, err := s.db.Queries().ListAllCertificates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+	sort.Slice(certs, func(i, j int) bool { return certs[i].Hostname < certs[j].Hostname })
+
+	threshold := s.expiringThresholdDays(ctx)
+	now := s.clock.Now()
+	rows := make([][]any, 0, len(certs))
+	for i := range certs {
+		item := s.toCertificateListItem(&certs[i], db.ComputeStatusAt(&certs[i], threshold, now))
+		var days, keySize any
+		if item.ExpiresAt != nil {
+			days = item.DaysUntilExpiration
+		}
+		if item.KeySize > 0 {
+			keySize = item.KeySize
+		}
+		rows = append(rows, []any{
+			item.Hostname,
+			item.Status,
+			strings.Join(item.SANs, "; "),
+			item.ExpiresAtUTC,
+			days,
+			item.KeyAlgorithm,
+			keySize,
+			item.ReadOnly,
+			item.HasPendingCSR,
+			certs[i].Note.String,
+		})
+	}
+
+	if format == models.InventoryFormatXLSX {
+		var buf bytes.Buffer
+		if err := xlsx.Write(&buf, "Certificates", inventoryHeader, rows); err != nil {
+			return nil, fmt.Errorf("failed to write XLSX: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+	return inventoryCSV(rows)
+}
+
+// inventoryCSV renders inventory rows as CSV, with free text guarded against
+// formula injection
+func inventoryCSV(rows [][]any) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(inventoryHeader); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	record := make([]string, len(inventoryHeader))
+	for _, row := range rows {
+		for i, cell := range row {
+			switch v := cell.(type) {
+			case nil:
+				record[i] = ""
+			case string:
+				record[i] = csvCell(v)
+			default:
+				record[i] = fmt.Sprint(v)
+			}
+		}
+		if err := w.Write(record); err != nil {
+			return nil, fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"io"
+	"strings"
+	"testing"
+
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+func TestBuildInventory_CSV(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	storePKCS12TestCertificate(t, database, "b.example.com", testutil.RandomMasterKey(t))
+	seedCert(t, database, "a.example.com")
+	if _, err := database.DB().Exec("UPDATE certificates SET note = '=HYPERLINK(\"x\")', read_only = 1 WHERE hostname = 'a.example.com'"); err != nil {
+		t.Fatalf("failed to set note: %v", err)
+	}
+
+	data, err := svc.BuildInventory(ctx, models.InventoryFormatCSV)
+	if err != nil {
+		t.Fatalf("BuildInventory failed: %v", err)
+	}
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records) != 3 || strings.Join(records[0], ",") != strings.Join(inventoryHeader, ",") {
+		t.Fatalf("expected a header and 2 rows, got %v", records)
+	}
+
+	a, b := records[1], records[2]
+	if a[0] != "a.example.com" || a[7] != "true" || a[9] != `'=HYPERLINK("x")` {
+		t.Errorf("unexpected row for a.example.com: %v", a)
+	}
+	if a[4] != "" || a[6] != "" {
+		t.Errorf("expected no expiry or key size without a certificate, got %v", a)
+	}
+	if b[0] != "b.example.com" || b[1] != "active" || b[5] != "rsa" || b[6] != "2048" || b[7] != "false" {
+		t.Errorf("unexpected row for b.example.com: %v", b)
+	}
+}
+
+func TestBuildInventory_XLSX(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	seedCert(t, database, "a.example.com")
+
+	data, err := svc.BuildInventory(context.Background(), models.InventoryFormatXLSX)
+	if err != nil {
+		t.Fatalf("BuildInventory failed: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("XLSX is not a ZIP archive: %v", err)
+	}
+	f, err := zr.Open("xl/worksheets/sheet1.xml")
+	if err != nil {
+		t.Fatalf("XLSX has no worksheet: %v", err)
+	}
+	defer f.Close()
+	sheet, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("failed to read worksheet: %v", err)
+	}
+	for _, want := range []string{">hostname<", ">a.example.com<", ">pending<"} {
+		if !bytes.Contains(sheet, []byte(want)) {
+			t.Errorf("expected the worksheet to contain %q", want)
+		}
+	}
+}
+
+func TestBuildInventory_RejectsUnknownFormat(t *testing.T) {
+	svc, _ := setupTestService(t)
+
+	if _, err := svc.BuildInventory(context.Background(), "pdf"); err == nil {
+		t.Error("expected an unsupported format to be rejected")
+	}
+}
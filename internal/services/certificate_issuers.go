@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"sort"
+	"time"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// IssuerExpiringThresholdDays is the "expiring soon" window for CA certificates.
+// It is wider than the leaf window: replacing an intermediate means reissuing
+// every certificate that depends on it.
+const IssuerExpiringThresholdDays = 90
+
+// GetIssuerExpiries returns the intermediate and root certificates found in the
+// chains of the active certificates, with the hostnames depending on each.
+// Chains stored at upload are used first; when fetchAIA is true, certificates
+// without a stored chain have theirs fetched via AIA (cached). Results are ordered
+// by expiry, soonest first.
+func (s *CertificateService) GetIssuerExpiries(ctx context.Context, fetchAIA bool) ([]models.IssuerExpiry, error) {
+	certs, err := s.db.Queries().ListAllCertificates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	var overrides *chainOverrideSet
+	if fetchAIA {
+		if overrides, err = s.loadChainOverrides(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	now := s.clock.Now().UTC()
+	issuers := make(map[[sha256.Size]byte]*models.IssuerExpiry)
+	for i := range certs {
+		cert := &certs[i]
+		if !cert.CertificatePem.Valid || cert.CertificatePem.String == "" {
+			continue
+		}
+		leaf, err := crypto.ParseCertificate([]byte(cert.CertificatePem.String))
+		if err != nil || leaf.Subject.String() == leaf.Issuer.String() {
+			continue
+		}
+
+		chain := storedChain(cert)
+		if len(chain) == 0 && fetchAIA {
+			// A partial chain is still worth tracking
+			chain, _ = crypto.BuildChainWithOverrides(leaf, overrides.forCertificate(cert.Hostname, leaf))
+		}
+
+		for _, ca := range chain {
+			key := sha256.Sum256(ca.Raw)
+			entry, ok := issuers[key]
+			if !ok {
+				entry = newIssuerExpiry(ca, key[:], now)
+				issuers[key] = entry
+			}
+			entry.Hostnames = append(entry.Hostnames, cert.Hostname)
+			if leaf.NotAfter.After(ca.NotAfter) {
+				entry.OutlivedBy = append(entry.OutlivedBy, cert.Hostname)
+			}
+		}
+	}
+
+	result := make([]models.IssuerExpiry, 0, len(issuers))
+	for _, entry := range issuers {
+		sort.Strings(entry.Hostnames)
+		sort.Strings(entry.OutlivedBy)
+		result = append(result, *entry)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].NotAfterTimestamp < result[j].NotAfterTimestamp
+	})
+
+	return result, nil
+}
+
+func newIssuerExpiry(ca *x509.Certificate, fingerprint []byte, now time.Time) *models.IssuerExpiry {
+	entry := &models.IssuerExpiry{
+		SubjectCN:         ca.Subject.CommonName,
+		FingerprintSHA256: crypto.FormatHexColon(fingerprint),
+		CertType:          "intermediate",
+		NotAfterTimestamp: ca.NotAfter.Unix(),
+		Status:            "active",
+		Hostnames:         []string{},
+	}
+	if len(ca.Subject.Organization) > 0 {
+		entry.SubjectO = ca.Subject.Organization[0]
+	}
+	if ca.Subject.String() == ca.Issuer.String() {
+		entry.CertType = "root"
+	}
+
+	remaining := ca.NotAfter.UTC().Sub(now)
+	switch {
+	case remaining <= 0:
+		entry.Status = "expired"
+	case remaining <= IssuerExpiringThresholdDays*24*time.Hour:
+		entry.Status = "expiring"
+		entry.DaysUntilExpiration = int(remaining.Hours() / 24)
+	default:
+		entry.DaysUntilExpiration = int(remaining.Hours() / 24)
+	}
+
+	return entry
+}
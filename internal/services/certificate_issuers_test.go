@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"crypto/x509"
+	"database/sql"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+func TestGetIssuerExpiries_GroupsDependentsByIssuer(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	root, rootKey := newTestCA(t, "Test Root", time.Now().Add(10*365*24*time.Hour), nil, nil)
+	intermediate, intermediateKey := newTestCA(t, "Test Intermediate", time.Now().Add(30*24*time.Hour), root, rootKey)
+	chainPEM := crypto.ConvertChainToPEM([]*x509.Certificate{intermediate, root})
+
+	for _, hostname := range []string{"b.example.com", "a.example.com"} {
+		csrPEM, _, _ := generateTestCSRAndKey(t, hostname, encryptionKey)
+		leafPEM := signCSRWithCA(t, csrPEM, intermediate, intermediateKey)
+		if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+			Hostname:       hostname,
+			CertificatePem: sql.NullString{String: leafPEM, Valid: true},
+			ChainPem:       sql.NullString{String: chainPEM[0] + chainPEM[1], Valid: true},
+		}); err != nil {
+			t.Fatalf("failed to create certificate: %v", err)
+		}
+	}
+
+	// A pending entry has no chain and must be ignored
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{Hostname: "pending.example.com"}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	issuers, err := svc.GetIssuerExpiries(ctx, false)
+	if err != nil {
+		t.Fatalf("GetIssuerExpiries failed: %v", err)
+	}
+	if len(issuers) != 2 {
+		t.Fatalf("expected 2 issuers, got %d", len(issuers))
+	}
+
+	// Soonest expiry first
+	inter := issuers[0]
+	if inter.SubjectCN != "Test Intermediate" || inter.CertType != "intermediate" {
+		t.Fatalf("expected intermediate first, got %s (%s)", inter.SubjectCN, inter.CertType)
+	}
+	if inter.Status != "expiring" {
+		t.Errorf("expected intermediate status expiring, got %s", inter.Status)
+	}
+	if len(inter.Hostnames) != 2 || inter.Hostnames[0] != "a.example.com" || inter.Hostnames[1] != "b.example.com" {
+		t.Errorf("expected both hostnames sorted, got %v", inter.Hostnames)
+	}
+	// The leaves (90 days) outlive the intermediate (30 days)
+	if len(inter.OutlivedBy) != 2 {
+		t.Errorf("expected both leaves to outlive the intermediate, got %v", inter.OutlivedBy)
+	}
+
+	if issuers[1].CertType != "root" || issuers[1].Status != "active" {
+		t.Errorf("expected active root second, got %s (%s)", issuers[1].CertType, issuers[1].Status)
+	}
+	if len(issuers[1].OutlivedBy) != 0 {
+		t.Errorf("expected no leaf to outlive the root, got %v", issuers[1].OutlivedBy)
+	}
+}
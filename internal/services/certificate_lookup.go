@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net"
+	"sort"
+	"strings"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/hostnames"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// GetCertificateBySerial returns the certificate whose active certificate has the
+// given serial number. The serial is read as hexadecimal and may use the colon,
+// space or dash separators shown by browsers and OpenSSL (e.g. "0A:1B:2C").
+func (s *CertificateService) GetCertificateBySerial(ctx context.Context, serial string) (*models.Certificate, error) {
+	want, err := parseSerialNumber(serial)
+	if err != nil {
+		return nil, err
+	}
+
+	certs, err := s.db.Queries().ListAllCertificates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	for _, cert := range certs {
+		if !cert.CertificatePem.Valid || cert.CertificatePem.String == "" {
+			continue
+		}
+		parsed, err := crypto.ParseCertificate([]byte(cert.CertificatePem.String))
+		if err != nil {
+			continue
+		}
+		if parsed.SerialNumber.Cmp(want) == 0 {
+			return s.GetCertificate(ctx, cert.Hostname)
+		}
+	}
+
+	return nil, fmt.Errorf("no certificate with serial number %s", serial)
+}
+
+// FindCertificatesBySAN returns the certificates whose active certificate or
+// pending CSR covers the given DNS name or IP address. Wildcard SANs match a
+// single label ("*.example.com" covers "web.example.com").
+func (s *CertificateService) FindCertificatesBySAN(ctx context.Context, san string) ([]*models.CertificateListItem, error) {
+	san = strings.TrimSpace(san)
+	if san == "" {
+		return nil, fmt.Errorf("SAN is required")
+	}
+
+	ip := net.ParseIP(san)
+	var name string
+	if ip == nil {
+		normalized, err := hostnames.Normalize(san)
+		if err != nil {
+			return nil, err
+		}
+		name = normalized
+	}
+
+	if err := s.refreshCertificateMetadata(ctx); err != nil {
+		return nil, err
+	}
+	certs, err := s.db.Queries().ListAllCertificates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	threshold := s.expiringThresholdDays(ctx)
+	items := []*models.CertificateListItem{}
+	for i := range certs {
+		cert := &certs[i]
+		var dnsNames []string
+		var ips []net.IP
+
+		if cert.CertificatePem.Valid && cert.CertificatePem.String != "" {
+			if parsed, err := crypto.ParseCertificate([]byte(cert.CertificatePem.String)); err == nil {
+				dnsNames = append(dnsNames, parsed.DNSNames...)
+				ips = append(ips, parsed.IPAddresses...)
+			}
+		}
+		if cert.PendingCsrPem.Valid && cert.PendingCsrPem.String != "" {
+			if parsed, err := crypto.ParseCSR([]byte(cert.PendingCsrPem.String)); err == nil {
+				dnsNames = append(dnsNames, parsed.DNSNames...)
+				ips = append(ips, parsed.IPAddresses...)
+			}
+		}
+
+		if (ip != nil && containsIP(ips, ip)) || (ip == nil && matchesAnyDNSName(dnsNames, name)) {
+			items = append(items, s.toCertificateListItem(cert, db.ComputeStatusAt(cert, threshold, s.clock.Now())))
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Hostname < items[j].Hostname
+	})
+
+	return items, nil
+}
+
+// parseSerialNumber parses a hexadecimal serial number, ignoring separators and
+// an optional 0x prefix.
+func parseSerialNumber(serial string) (*big.Int, error) {
+	cleaned := strings.NewReplacer(":", "", " ", "", "-", "").Replace(strings.TrimSpace(serial))
+	cleaned = strings.TrimPrefix(strings.ToLower(cleaned), "0x")
+	if cleaned == "" {
+		return nil, fmt.Errorf("serial number is required")
+	}
+	n, ok := new(big.Int).SetString(cleaned, 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid serial number: %s", serial)
+	}
+	return n, nil
+}
+
+// matchesAnyDNSName reports whether name is covered by one of the DNS SANs,
+// honouring single-label wildcards.
+func matchesAnyDNSName(sans []string, name string) bool {
+	for _, san := range sans {
+		san = strings.ToLower(san)
+		if san == name {
+			return true
+		}
+		if suffix, ok := strings.CutPrefix(san, "*."); ok {
+			if _, rest, found := strings.Cut(name, "."); found && rest == suffix {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsIP(ips []net.IP, ip net.IP) bool {
+	for _, candidate := range ips {
+		if candidate.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
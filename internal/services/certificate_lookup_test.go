@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"database/sql"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+)
+
+// storeTestCertificate creates a certificate entry with a self-signed active
+// certificate carrying the given serial number and SANs.
+func storeTestCertificate(t *testing.T, q *sqlc.Queries, hostname string, serial int64, dnsNames []string, ips []net.IP) {
+	t.Helper()
+	key, err := crypto.GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: hostname},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(365 * 24 * time.Hour),
+		DNSNames:     dnsNames,
+		IPAddresses:  ips,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+
+	if err := q.CreateCertificate(context.Background(), sqlc.CreateCertificateParams{
+		Hostname:       hostname,
+		CertificatePem: sql.NullString{String: string(certPEM), Valid: true},
+		ExpiresAt:      sql.NullInt64{Int64: template.NotAfter.Unix(), Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to store certificate: %v", err)
+	}
+}
+
+func TestGetCertificateBySerial(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	storeTestCertificate(t, database.Queries(), "a.example.com", 0x0a1b2c, []string{"a.example.com"}, nil)
+	storeTestCertificate(t, database.Queries(), "b.example.com", 0x0d0e0f, []string{"b.example.com"}, nil)
+
+	for _, serial := range []string{"0A:1B:2C", "0a1b2c", "0x0A1B2C", "0a 1b 2c"} {
+		cert, err := svc.GetCertificateBySerial(ctx, serial)
+		if err != nil {
+			t.Fatalf("GetCertificateBySerial(%q) failed: %v", serial, err)
+		}
+		if cert.Hostname != "a.example.com" {
+			t.Errorf("GetCertificateBySerial(%q) = %s, want a.example.com", serial, cert.Hostname)
+		}
+	}
+
+	if _, err := svc.GetCertificateBySerial(ctx, "ff:ff"); err == nil {
+		t.Error("expected error for unknown serial, got nil")
+	}
+	if _, err := svc.GetCertificateBySerial(ctx, "not-hex"); err == nil {
+		t.Error("expected error for invalid serial, got nil")
+	}
+}
+
+func TestFindCertificatesBySAN(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	q := database.Queries()
+	storeTestCertificate(t, q, "web.example.com", 1, []string{"web.example.com", "www.example.com"}, nil)
+	storeTestCertificate(t, q, "wild.example.com", 2, []string{"*.example.com"}, nil)
+	storeTestCertificate(t, q, "ip.example.com", 3, []string{"ip.example.com"}, []net.IP{net.ParseIP("10.0.0.5")})
+
+	tests := []struct {
+		san  string
+		want []string
+	}{
+		{"WWW.example.com", []string{"web.example.com", "wild.example.com"}},
+		{"api.example.com", []string{"wild.example.com"}},
+		{"deep.api.example.com", nil},
+		{"10.0.0.5", []string{"ip.example.com"}},
+	}
+	for _, tt := range tests {
+		items, err := svc.FindCertificatesBySAN(ctx, tt.san)
+		if err != nil {
+			t.Fatalf("FindCertificatesBySAN(%q) failed: %v", tt.san, err)
+		}
+		if len(items) != len(tt.want) {
+			t.Fatalf("FindCertificatesBySAN(%q) returned %d items, want %d", tt.san, len(items), len(tt.want))
+		}
+		for i, item := range items {
+			if item.Hostname != tt.want[i] {
+				t.Errorf("FindCertificatesBySAN(%q)[%d] = %s, want %s", tt.san, i, item.Hostname, tt.want[i])
+			}
+		}
+	}
+}
@@ -3,45 +3,163 @@ package services
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
-	"sort"
+	"strings"
 	"time"
 
 	"paddockcontrol-desktop/internal/crypto"
 	"paddockcontrol-desktop/internal/db"
 	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/hostnames"
 	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/timing"
 )
 
-// ListCertificates returns a filtered and sorted list of certificates
+// ListCertificates returns a filtered and sorted list of certificates, or one
+// page of it when the filter sets a limit or an offset
 func (s *CertificateService) ListCertificates(ctx context.Context, filter models.CertificateFilter) ([]*models.CertificateListItem, error) {
-	// Get all certificates from DB
-	certs, err := s.db.Queries().ListAllCertificates(ctx)
+	page, err := s.ListCertificatePage(ctx, filter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list certificates: %w", err)
+		return nil, err
+	}
+	return page.Certificates, nil
+}
+
+// ListCertificatePage returns one page of the filtered and sorted certificates
+// with the number of certificates matching the filter. Status, hostname, tag
+// and date filters, sorting and paging run in SQL; SANs and key parameters
+// come from the metadata cached when a PEM is written.
+func (s *CertificateService) ListCertificatePage(ctx context.Context, filter models.CertificateFilter) (*models.CertificatePage, error) {
+	defer timing.Start(timing.ListCertificates)()
+
+	if err := s.refreshCertificateMetadata(ctx); err != nil {
+		return nil, err
 	}
 
-	// Convert to list items with computed fields
-	items := make([]*models.CertificateListItem, 0, len(certs))
-	for i := range certs {
-		// Compute status
-		status := db.ComputeStatus(&certs[i])
+	// Tag filters are matched against normalized tags; an invalid one can
+	// match nothing
+	wantTags := []string{}
+	for _, tag := range filter.Tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" {
+			wantTags = append(wantTags, tag)
+		}
+	}
 
-		// Filter by status if specified
-		if filter.Status != "" && filter.Status != "all" {
-			if string(status) != filter.Status {
-				continue
+	// Reference and search filters resolve to sets of hostnames, intersected
+	// into the list the query is restricted to
+	var restrict map[string]bool
+	intersect := func(set map[string]bool) {
+		if restrict == nil {
+			restrict = set
+			return
+		}
+		for hostname := range restrict {
+			if !set[hostname] {
+				delete(restrict, hostname)
 			}
 		}
+	}
+	if reference := strings.TrimSpace(filter.Reference); reference != "" {
+		referencing, err := s.hostnamesReferencing(ctx, reference)
+		if err != nil {
+			return nil, err
+		}
+		intersect(referencing)
+	}
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		found, err := s.searchHostnames(ctx, search)
+		if err != nil {
+			return nil, err
+		}
+		intersect(found)
+	}
+	restricted := make([]string, 0, len(restrict))
+	for hostname := range restrict {
+		restricted = append(restricted, hostname)
+	}
 
-		item := s.toCertificateListItem(&certs[i], status)
-		items = append(items, item)
+	tagsJSON, err := json.Marshal(wantTags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tag filter: %w", err)
+	}
+	hostnamesJSON, err := json.Marshal(restricted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode hostname filter: %w", err)
 	}
 
-	// Apply sorting
-	s.sortCertificates(items, filter.SortBy, filter.SortOrder)
+	now := s.clock.Now()
+	params := sqlc.CountCertificatePageParams{
+		Now:       now.Unix(),
+		Threshold: int64(s.expiringThresholdDays(ctx)),
+		Hostnames: string(hostnamesJSON),
+		Tags:      string(tagsJSON),
+	}
+	if filter.Status != "all" {
+		params.Status = filter.Status
+	}
+	if hostname := strings.ToLower(strings.TrimSpace(filter.Hostname)); hostname != "" {
+		params.HostnamePattern = "%" + escapeLike(hostname) + "%"
+	}
+	// Keep only CSRs the CA has not answered within the given number of days
+	if filter.AwaitingResponseDays > 0 {
+		params.AwaitingBefore = now.AddDate(0, 0, -filter.AwaitingResponseDays).Unix()
+	}
+	if restrict != nil {
+		params.RestrictHostnames = 1
+	}
+
+	total, err := s.db.Queries().CountCertificatePage(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count certificates: %w", err)
+	}
+
+	sortBy := filter.SortBy
+	if sortBy != "expiring" && sortBy != "hostname" {
+		sortBy = "created"
+	}
+	var sortDesc int64
+	if filter.SortOrder != "asc" {
+		sortDesc = 1
+	}
+	limit := int64(-1)
+	if filter.Limit > 0 {
+		limit = int64(filter.Limit)
+	}
+	rows, err := s.db.Queries().ListCertificatePage(ctx, sqlc.ListCertificatePageParams{
+		Now:               params.Now,
+		Threshold:         params.Threshold,
+		Status:            params.Status,
+		HostnamePattern:   params.HostnamePattern,
+		AwaitingBefore:    params.AwaitingBefore,
+		RestrictHostnames: params.RestrictHostnames,
+		Hostnames:         params.Hostnames,
+		Tags:              params.Tags,
+		SortBy:            sortBy,
+		SortDesc:          sortDesc,
+		PageLimit:         limit,
+		PageOffset:        int64(max(filter.Offset, 0)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
 
-	return items, nil
+	tags, err := certificateTagsByHostname(ctx, s.db.Queries())
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.CertificatePage{
+		Certificates: make([]*models.CertificateListItem, 0, len(rows)),
+		Total:        int(total),
+	}
+	for i := range rows {
+		item := s.toCertificatePageItem(&rows[i])
+		item.Tags = tags[rows[i].Hostname]
+		page.Certificates = append(page.Certificates, item)
+	}
+	return page, nil
 }
 
 // GetCertificate returns detailed certificate information
@@ -52,7 +170,7 @@ func (s *CertificateService) GetCertificate(ctx context.Context, hostname string
 	}
 
 	// Compute status
-	status := db.ComputeStatus(&dbCert)
+	status := db.ComputeStatusAt(&dbCert, s.expiringThresholdDays(ctx), s.clock.Now())
 
 	// Build expires_at pointer
 	var expiresAt *int64
@@ -61,15 +179,20 @@ func (s *CertificateService) GetCertificate(ctx context.Context, hostname string
 	}
 
 	cert := &models.Certificate{
-		Hostname:       dbCert.Hostname,
-		PendingCSR:     dbCert.PendingCsrPem.String,
-		CertificatePEM: dbCert.CertificatePem.String,
-		CreatedAt:      dbCert.CreatedAt,
-		ExpiresAt:      expiresAt,
-		Status:         string(status),
-		Note:           dbCert.Note.String,
-		PendingNote:    dbCert.PendingNote.String,
-		ReadOnly:       dbCert.ReadOnly > 0,
+		Hostname:        dbCert.Hostname,
+		DisplayHostname: hostnames.ToUnicode(dbCert.Hostname),
+		PendingCSR:      dbCert.PendingCsrPem.String,
+		CertificatePEM:  dbCert.CertificatePem.String,
+		CreatedAt:       dbCert.CreatedAt,
+		ExpiresAt:       expiresAt,
+		ExpiresAtUTC:    formatUTC(expiresAt),
+		ExpiresAtLocal:  formatLocal(expiresAt),
+		Status:          string(status),
+		Note:            dbCert.Note.String,
+		PendingNote:     dbCert.PendingNote.String,
+		ReadOnly:        dbCert.ReadOnly > 0,
+		CAReference:     dbCert.CaReference.String,
+		SubmittedAt:     nullInt64Ptr(dbCert.SubmittedAt),
 	}
 
 	// Parse and add computed fields from certificate
@@ -78,13 +201,8 @@ func (s *CertificateService) GetCertificate(ctx context.Context, hostname string
 		if err == nil {
 			details, _ := crypto.ExtractCertificateDetails(certInfo)
 			if details != nil {
-				cert.SANs = details.SANs
-				cert.Organization = details.Organization
-				cert.OrganizationalUnit = details.OrganizationalUnit
-				cert.City = details.City
-				cert.State = details.State
-				cert.Country = details.Country
-				cert.KeySize = details.KeySize
+				cert.Active = toCertificateSubject(details)
+				cert.Extensions = details.Extensions
 			}
 			if expiresAt != nil {
 				cert.DaysUntilExpiration = s.calculateDaysUntilExpiration(*expiresAt)
@@ -92,63 +210,92 @@ func (s *CertificateService) GetCertificate(ctx context.Context, hostname string
 		}
 	}
 
-	// Always parse pending CSR if present (for regenerate functionality)
-	// When both cert and CSR exist, this populates the Pending* fields
-	// When only CSR exists, this populates both main and Pending* fields
+	// Parse the pending CSR separately so a renewal can be compared against the
+	// certificate it replaces
 	if cert.PendingCSR != "" {
 		csrInfo, err := crypto.ParseCSR([]byte(cert.PendingCSR))
 		if err == nil {
 			details, _ := crypto.ExtractCSRDetails(csrInfo)
 			if details != nil {
-				// Always set pending fields from CSR
-				cert.PendingSANs = details.SANs
-				cert.PendingOrganization = details.Organization
-				cert.PendingOrganizationalUnit = details.OrganizationalUnit
-				cert.PendingCity = details.City
-				cert.PendingState = details.State
-				cert.PendingCountry = details.Country
-				cert.PendingKeySize = details.KeySize
-
-				// If no certificate exists, also set main fields from CSR
-				if cert.CertificatePEM == "" {
-					cert.SANs = details.SANs
-					cert.Organization = details.Organization
-					cert.OrganizationalUnit = details.OrganizationalUnit
-					cert.City = details.City
-					cert.State = details.State
-					cert.Country = details.Country
-					cert.KeySize = details.KeySize
-				}
+				cert.Pending = toCertificateSubject(details)
 			}
 		}
 	}
 
+	cert.Requester, err = getRequester(ctx, s.db.Queries(), hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	cert.Tags, err = s.db.Queries().ListCertificateTags(ctx, hostname)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags of %s: %w", hostname, err)
+	}
+
+	cert.NoteReferences, err = s.listNoteReferences(ctx, hostname)
+	if err != nil {
+		return nil, err
+	}
+
 	return cert, nil
 }
 
-// DeleteCertificate deletes a certificate
+// DeleteCertificate moves a certificate to the trash, where it stays with its
+// history and keys until it is restored or purged. With a trash retention of
+// 0 days it is deleted permanently instead, and its history rows are removed
+// via ON DELETE CASCADE.
 func (s *CertificateService) DeleteCertificate(ctx context.Context, hostname string) error {
 	// Read-only check and delete run in one transaction so the guard can't be
-	// bypassed by a concurrent change between the check and the delete. The
-	// certificate's history rows are removed automatically via ON DELETE
-	// CASCADE, so no separate "deleted" history entry is recorded.
+	// bypassed by a concurrent change between the check and the delete.
+	trash := s.trashRetentionDays(ctx) > 0
 	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
-		cert, err := q.GetCertificateByHostname(ctx, hostname)
-		if err != nil {
-			return fmt.Errorf("failed to get certificate: %w", err)
-		}
-		if cert.ReadOnly == 1 {
-			return fmt.Errorf("certificate is read-only and cannot be deleted")
-		}
+		return s.deleteCertificateTx(ctx, q, hostname, trash)
+	})
+}
+
+// deleteCertificateTx deletes a certificate that is neither read-only nor
+// deployed within the caller's transaction, moving it to the trash when trash
+// is set.
+func (s *CertificateService) deleteCertificateTx(ctx context.Context, q *sqlc.Queries, hostname string, trash bool) error {
+	cert, err := q.GetCertificateByHostname(ctx, hostname)
+	if err != nil {
+		return fmt.Errorf("failed to get certificate: %w", err)
+	}
+	if cert.ReadOnly == 1 {
+		return fmt.Errorf("certificate is read-only and cannot be deleted")
+	}
+	deployedOn, err := deploymentTargetNames(ctx, q, hostname)
+	if err != nil {
+		return err
+	}
+	if len(deployedOn) > 0 {
+		return fmt.Errorf("%w on %s; remove these deployment targets before deleting it",
+			ErrCertificateDeployed, strings.Join(deployedOn, ", "))
+	}
+	if !trash {
 		if err := q.DeleteCertificate(ctx, hostname); err != nil {
 			return fmt.Errorf("failed to delete certificate: %w", err)
 		}
 		return nil
-	})
+	}
+	if _, err := q.TrashCertificate(ctx, sqlc.TrashCertificateParams{
+		DeletedAt: sql.NullInt64{Int64: s.clock.Now().Unix(), Valid: true},
+		Hostname:  hostname,
+	}); err != nil {
+		return fmt.Errorf("failed to move certificate to the trash: %w", err)
+	}
+	if err := s.history.LogEventTx(ctx, q, hostname, models.EventCertificateDeleted, "Certificate moved to the trash"); err != nil {
+		return fmt.Errorf("failed to log deletion: %w", err)
+	}
+	return nil
 }
 
-// ClearPendingCSR removes the pending CSR, pending private key, and pending note from a certificate
-func (s *CertificateService) ClearPendingCSR(ctx context.Context, hostname string) error {
+// ClearPendingCSR cancels a renewal: it removes the pending CSR, pending private key,
+// and pending note from a certificate that has an active certificate. A first CSR
+// (no active certificate) must be cancelled with CancelNewRequest instead.
+// encryptionKey is nil while locked; the key status is then rechecked on the
+// next unlock.
+func (s *CertificateService) ClearPendingCSR(ctx context.Context, hostname string, encryptionKey []byte) error {
 	cert, err := s.db.Queries().GetCertificateByHostname(ctx, hostname)
 	if err != nil {
 		return fmt.Errorf("failed to get certificate: %w", err)
@@ -162,17 +309,55 @@ func (s *CertificateService) ClearPendingCSR(ctx context.Context, hostname strin
 		return fmt.Errorf("certificate has no pending CSR")
 	}
 
+	if !cert.CertificatePem.Valid || cert.CertificatePem.String == "" {
+		return fmt.Errorf("certificate has no active certificate; cancel the new request instead")
+	}
+
 	// Clear the pending CSR and record the history event atomically.
 	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
 		if err := q.ClearPendingCSR(ctx, hostname); err != nil {
 			return fmt.Errorf("failed to clear pending CSR: %w", err)
 		}
+		if err := RefreshKeyStatusTx(ctx, q, hostname, encryptionKey); err != nil {
+			return err
+		}
 		return s.history.LogEventTx(ctx, q, hostname, models.EventPendingCSRRemoved, "Pending renewal CSR cancelled")
 	})
 }
 
+// CancelNewRequest removes a certificate entry whose first CSR was never answered.
+// Entries that already have an active certificate are refused: cancelling their
+// renewal is done with ClearPendingCSR. The entry's history is removed with it.
+func (s *CertificateService) CancelNewRequest(ctx context.Context, hostname string) error {
+	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		cert, err := q.GetCertificateByHostname(ctx, hostname)
+		if err != nil {
+			return fmt.Errorf("failed to get certificate: %w", err)
+		}
+		if cert.ReadOnly == 1 {
+			return fmt.Errorf("certificate is read-only and cannot be modified")
+		}
+		if cert.CertificatePem.Valid && cert.CertificatePem.String != "" {
+			return fmt.Errorf("certificate has an active certificate; cancel the renewal instead")
+		}
+		if err := q.DeleteCertificate(ctx, hostname); err != nil {
+			return fmt.Errorf("failed to delete certificate request: %w", err)
+		}
+		return nil
+	})
+}
+
 // SetCertificateReadOnly sets the read-only status of a certificate
 func (s *CertificateService) SetCertificateReadOnly(ctx context.Context, hostname string, readOnly bool) error {
+	// Update the flag and record the history event atomically.
+	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		return s.setReadOnlyTx(ctx, q, hostname, readOnly)
+	})
+}
+
+// setReadOnlyTx updates the read-only flag and records the history event using
+// the caller's transaction.
+func (s *CertificateService) setReadOnlyTx(ctx context.Context, q *sqlc.Queries, hostname string, readOnly bool) error {
 	readOnlyValue := int64(0)
 	if readOnly {
 		readOnlyValue = 1
@@ -184,20 +369,21 @@ func (s *CertificateService) SetCertificateReadOnly(ctx context.Context, hostnam
 		message = "Marked as read-only"
 	}
 
-	// Update the flag and record the history event atomically.
-	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
-		if err := q.UpdateCertificateReadOnly(ctx, sqlc.UpdateCertificateReadOnlyParams{
-			ReadOnly: readOnlyValue,
-			Hostname: hostname,
-		}); err != nil {
-			return err
-		}
-		return s.history.LogEventTx(ctx, q, hostname, eventType, message)
-	})
+	if err := q.UpdateCertificateReadOnly(ctx, sqlc.UpdateCertificateReadOnlyParams{
+		ReadOnly: readOnlyValue,
+		Hostname: hostname,
+	}); err != nil {
+		return err
+	}
+	return s.history.LogEventTx(ctx, q, hostname, eventType, message)
 }
 
-// UpdateCertificateNote updates the note for a certificate
+// UpdateCertificateNote updates the note for a certificate. Notes that look like
+// they hold a secret are refused.
 func (s *CertificateService) UpdateCertificateNote(ctx context.Context, hostname string, note string) error {
+	if err := checkNoteForSecrets(note); err != nil {
+		return err
+	}
 	var noteValue sql.NullString
 	if note != "" {
 		noteValue = sql.NullString{String: note, Valid: true}
@@ -208,8 +394,12 @@ func (s *CertificateService) UpdateCertificateNote(ctx context.Context, hostname
 	})
 }
 
-// UpdatePendingNote updates the pending note for a certificate
+// UpdatePendingNote updates the pending note for a certificate. Notes that look
+// like they hold a secret are refused.
 func (s *CertificateService) UpdatePendingNote(ctx context.Context, hostname string, note string) error {
+	if err := checkNoteForSecrets(note); err != nil {
+		return err
+	}
 	var noteValue sql.NullString
 	if note != "" {
 		noteValue = sql.NullString{String: note, Valid: true}
@@ -220,7 +410,23 @@ func (s *CertificateService) UpdatePendingNote(ctx context.Context, hostname str
 	})
 }
 
-// toCertificateListItem converts a database certificate to a list item
+// toCertificateSubject converts parsed certificate or CSR details to the subject model
+func toCertificateSubject(details *crypto.CertificateDetails) *models.CertificateSubject {
+	return &models.CertificateSubject{
+		SANs:               details.SANs,
+		Organization:       details.Organization,
+		OrganizationalUnit: details.OrganizationalUnit,
+		City:               details.City,
+		State:              details.State,
+		Country:            details.Country,
+		KeyAlgorithm:       details.KeyAlgorithm,
+		KeySize:            details.KeySize,
+	}
+}
+
+// toCertificateListItem converts a database certificate to a list item. SANs
+// and key parameters are read from the cached metadata, so callers refresh it
+// first (refreshCertificateMetadata).
 func (s *CertificateService) toCertificateListItem(cert *sqlc.Certificate, status db.CertificateStatus) *models.CertificateListItem {
 	var expiresAt *int64
 	if cert.ExpiresAt.Valid {
@@ -228,95 +434,107 @@ func (s *CertificateService) toCertificateListItem(cert *sqlc.Certificate, statu
 	}
 
 	item := &models.CertificateListItem{
-		Hostname:      cert.Hostname,
-		Status:        string(status),
-		CreatedAt:     cert.CreatedAt,
-		ExpiresAt:     expiresAt,
-		ReadOnly:      cert.ReadOnly > 0,
-		HasPendingCSR: cert.PendingCsrPem.Valid && cert.PendingCsrPem.String != "",
+		Hostname:        cert.Hostname,
+		DisplayHostname: hostnames.ToUnicode(cert.Hostname),
+		Status:          string(status),
+		SANs:            decodeSANs(cert.Sans),
+		KeyAlgorithm:    cert.KeyAlgorithm,
+		KeySize:         int(cert.KeySize),
+		CreatedAt:       cert.CreatedAt,
+		ExpiresAt:       expiresAt,
+		ExpiresAtUTC:    formatUTC(expiresAt),
+		ExpiresAtLocal:  formatLocal(expiresAt),
+		ReadOnly:        cert.ReadOnly > 0,
+		HasPendingCSR:   cert.PendingCsrPem.Valid && cert.PendingCsrPem.String != "",
+		CAReference:     cert.CaReference.String,
+		SubmittedAt:     nullInt64Ptr(cert.SubmittedAt),
+		KeyStatus:       cert.KeyStatus,
 	}
-
-	// Parse cert/CSR for additional fields
-	if cert.CertificatePem.Valid && cert.CertificatePem.String != "" {
-		if certInfo, err := crypto.ParseCertificate([]byte(cert.CertificatePem.String)); err == nil {
-			details, _ := crypto.ExtractCertificateDetails(certInfo)
-			if details != nil {
-				item.SANs = details.SANs
-				item.KeySize = details.KeySize
-			}
-			if expiresAt != nil {
-				item.DaysUntilExpiration = s.calculateDaysUntilExpiration(*expiresAt)
-			}
-		}
-	} else if cert.PendingCsrPem.Valid && cert.PendingCsrPem.String != "" {
-		if csrInfo, err := crypto.ParseCSR([]byte(cert.PendingCsrPem.String)); err == nil {
-			details, _ := crypto.ExtractCSRDetails(csrInfo)
-			if details != nil {
-				item.SANs = details.SANs
-				item.KeySize = details.KeySize
-			}
-		}
+	if status != db.StatusPending && expiresAt != nil {
+		item.DaysUntilExpiration = s.calculateDaysUntilExpiration(*expiresAt)
 	}
-
 	return item
 }
 
-// sortCertificates sorts certificates by the specified field
-func (s *CertificateService) sortCertificates(certs []*models.CertificateListItem, sortBy, sortOrder string) {
-	if sortBy == "" {
-		sortBy = "created"
-	}
-	if sortOrder == "" {
-		sortOrder = "desc"
+// toCertificatePageItem converts a row of a certificate listing to a list item
+func (s *CertificateService) toCertificatePageItem(row *sqlc.ListCertificatePageRow) *models.CertificateListItem {
+	var expiresAt *int64
+	if row.ExpiresAt.Valid {
+		expiresAt = &row.ExpiresAt.Int64
 	}
 
-	reverse := sortOrder == "desc"
-
-	switch sortBy {
-	case "expiring":
-		sort.Slice(certs, func(i, j int) bool {
-			iTime := int64(0)
-			jTime := int64(0)
-			if certs[i].ExpiresAt != nil {
-				iTime = *certs[i].ExpiresAt
-			}
-			if certs[j].ExpiresAt != nil {
-				jTime = *certs[j].ExpiresAt
-			}
-			if reverse {
-				return iTime > jTime
-			}
-			return iTime < jTime
-		})
-	case "hostname":
-		sort.Slice(certs, func(i, j int) bool {
-			if reverse {
-				return certs[i].Hostname > certs[j].Hostname
-			}
-			return certs[i].Hostname < certs[j].Hostname
-		})
-	case "created":
-		fallthrough
-	default:
-		sort.Slice(certs, func(i, j int) bool {
-			if reverse {
-				return certs[i].CreatedAt > certs[j].CreatedAt
-			}
-			return certs[i].CreatedAt < certs[j].CreatedAt
-		})
+	item := &models.CertificateListItem{
+		Hostname:        row.Hostname,
+		DisplayHostname: hostnames.ToUnicode(row.Hostname),
+		Status:          row.Status,
+		SANs:            decodeSANs(row.Sans),
+		KeyAlgorithm:    row.KeyAlgorithm,
+		KeySize:         int(row.KeySize),
+		CreatedAt:       row.CreatedAt,
+		ExpiresAt:       expiresAt,
+		ExpiresAtUTC:    formatUTC(expiresAt),
+		ExpiresAtLocal:  formatLocal(expiresAt),
+		ReadOnly:        row.ReadOnly > 0,
+		HasPendingCSR:   row.HasPendingCsr > 0,
+		CAReference:     row.CaReference.String,
+		SubmittedAt:     nullInt64Ptr(row.SubmittedAt),
+		KeyStatus:       row.KeyStatus,
+	}
+	if row.Status != string(db.StatusPending) && expiresAt != nil {
+		item.DaysUntilExpiration = s.calculateDaysUntilExpiration(*expiresAt)
 	}
+	return item
 }
 
 // calculateDaysUntilExpiration calculates the number of days until expiration
 func (s *CertificateService) calculateDaysUntilExpiration(expiresAt int64) int {
+	return daysUntilExpirationAt(expiresAt, s.clock.Now())
+}
+
+// daysUntilExpirationAt calculates the number of days between at and expiration
+func daysUntilExpirationAt(expiresAt int64, at time.Time) int {
 	if expiresAt == 0 {
 		return 0
 	}
-	expiresTime := time.Unix(expiresAt, 0)
-	duration := time.Until(expiresTime)
+	expiresTime := time.Unix(expiresAt, 0).UTC()
+	duration := expiresTime.Sub(at.UTC())
 	days := int(duration.Hours() / 24)
 	if days < 0 {
 		return 0
 	}
 	return days
 }
+
+// expiringThresholdDays returns the configured "expiring soon" window, falling
+// back to the default when the configuration cannot be read
+func (s *CertificateService) expiringThresholdDays(ctx context.Context) int {
+	cfg, err := s.config.GetConfig(ctx)
+	if err != nil || cfg == nil || cfg.ExpiringThresholdDays < 1 {
+		return db.DefaultExpiringThresholdDays
+	}
+	return int(cfg.ExpiringThresholdDays)
+}
+
+// nullInt64Ptr returns a pointer to the value of v, or nil when it is NULL
+func nullInt64Ptr(v sql.NullInt64) *int64 {
+	if !v.Valid {
+		return nil
+	}
+	return &v.Int64
+}
+
+// formatUTC formats a Unix timestamp as RFC 3339 in UTC
+func formatUTC(ts *int64) string {
+	if ts == nil {
+		return ""
+	}
+	return time.Unix(*ts, 0).UTC().Format(time.RFC3339)
+}
+
+// formatLocal formats a Unix timestamp as RFC 3339 in the machine's local time zone
+func formatLocal(ts *int64) string {
+	if ts == nil {
+		return ""
+	}
+	return time.Unix(*ts, 0).Local().Format(time.RFC3339)
+}
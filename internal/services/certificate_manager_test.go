@@ -4,7 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
+	"paddockcontrol-desktop/internal/clock"
+	"paddockcontrol-desktop/internal/crypto"
 	"paddockcontrol-desktop/internal/db/sqlc"
 	"paddockcontrol-desktop/internal/models"
 	"paddockcontrol-desktop/internal/testutil"
@@ -38,7 +41,7 @@ func TestClearPendingCSR_Success(t *testing.T) {
 	}
 
 	// Clear the pending CSR
-	err = svc.ClearPendingCSR(ctx, hostname)
+	err = svc.ClearPendingCSR(ctx, hostname, nil)
 	if err != nil {
 		t.Fatalf("ClearPendingCSR failed: %v", err)
 	}
@@ -86,7 +89,7 @@ func TestClearPendingCSR_ReadOnly_ReturnsError(t *testing.T) {
 		t.Fatalf("failed to create certificate: %v", err)
 	}
 
-	err = svc.ClearPendingCSR(ctx, hostname)
+	err = svc.ClearPendingCSR(ctx, hostname, nil)
 	if err == nil {
 		t.Fatal("expected error for read-only certificate, got nil")
 	}
@@ -117,7 +120,7 @@ func TestClearPendingCSR_NoPendingCSR_ReturnsError(t *testing.T) {
 		t.Fatalf("failed to create certificate: %v", err)
 	}
 
-	err = svc.ClearPendingCSR(ctx, hostname)
+	err = svc.ClearPendingCSR(ctx, hostname, nil)
 	if err == nil {
 		t.Fatal("expected error when no pending CSR exists, got nil")
 	}
@@ -130,7 +133,7 @@ func TestClearPendingCSR_NotFound_ReturnsError(t *testing.T) {
 	svc, _ := setupTestService(t)
 	ctx := context.Background()
 
-	err := svc.ClearPendingCSR(ctx, "nonexistent.example.com")
+	err := svc.ClearPendingCSR(ctx, "nonexistent.example.com", nil)
 	if err == nil {
 		t.Fatal("expected error for non-existent hostname, got nil")
 	}
@@ -142,19 +145,22 @@ func TestClearPendingCSR_LogsHistoryEvent(t *testing.T) {
 	hostname := "history.example.com"
 	encryptionKey := testutil.RandomMasterKey(t)
 
-	csrPEM, encryptedKey, _ := generateTestCSRAndKey(t, hostname, encryptionKey)
+	_, encryptedKey, _ := generateTestCSRAndKey(t, hostname, encryptionKey)
+	csrPEM, pendingEncryptedKey, _ := generateTestCSRAndKey(t, hostname, encryptionKey)
 
 	err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
 		Hostname:                   hostname,
+		EncryptedPrivateKey:        encryptedKey,
+		CertificatePem:             sql.NullString{String: "-----BEGIN CERTIFICATE-----\nfake\n-----END CERTIFICATE-----", Valid: true},
 		PendingCsrPem:              sql.NullString{String: string(csrPEM), Valid: true},
-		PendingEncryptedPrivateKey: encryptedKey,
+		PendingEncryptedPrivateKey: pendingEncryptedKey,
 		ReadOnly:                   0,
 	})
 	if err != nil {
 		t.Fatalf("failed to create certificate: %v", err)
 	}
 
-	err = svc.ClearPendingCSR(ctx, hostname)
+	err = svc.ClearPendingCSR(ctx, hostname, nil)
 	if err != nil {
 		t.Fatalf("ClearPendingCSR failed: %v", err)
 	}
@@ -184,6 +190,104 @@ func TestClearPendingCSR_LogsHistoryEvent(t *testing.T) {
 	}
 }
 
+func TestClearPendingCSR_FirstRequest_ReturnsError(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	hostname := "first.example.com"
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	csrPEM, encryptedKey, _ := generateTestCSRAndKey(t, hostname, encryptionKey)
+	err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:                   hostname,
+		PendingCsrPem:              sql.NullString{String: string(csrPEM), Valid: true},
+		PendingEncryptedPrivateKey: encryptedKey,
+	})
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	err = svc.ClearPendingCSR(ctx, hostname, nil)
+	if err == nil {
+		t.Fatal("expected error when clearing a first CSR, got nil")
+	}
+	if !containsSubstring(err.Error(), "no active certificate") {
+		t.Errorf("expected error containing %q, got: %v", "no active certificate", err)
+	}
+}
+
+// ============================================================================
+// CancelNewRequest Tests
+// ============================================================================
+
+func TestCancelNewRequest_RemovesEntry(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	hostname := "new.example.com"
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	csrPEM, encryptedKey, _ := generateTestCSRAndKey(t, hostname, encryptionKey)
+	err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:                   hostname,
+		PendingCsrPem:              sql.NullString{String: string(csrPEM), Valid: true},
+		PendingEncryptedPrivateKey: encryptedKey,
+	})
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	if err := svc.CancelNewRequest(ctx, hostname); err != nil {
+		t.Fatalf("CancelNewRequest failed: %v", err)
+	}
+
+	exists, err := database.Queries().CertificateExists(ctx, hostname)
+	if err != nil {
+		t.Fatalf("failed to check existence: %v", err)
+	}
+	if exists != 0 {
+		t.Error("expected entry to be removed")
+	}
+}
+
+func TestCancelNewRequest_ActiveCertificate_ReturnsError(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	hostname := "active.example.com"
+
+	err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:       hostname,
+		CertificatePem: sql.NullString{String: "-----BEGIN CERTIFICATE-----\nfake\n-----END CERTIFICATE-----", Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	err = svc.CancelNewRequest(ctx, hostname)
+	if err == nil {
+		t.Fatal("expected error for entry with an active certificate, got nil")
+	}
+	if !containsSubstring(err.Error(), "active certificate") {
+		t.Errorf("expected error containing %q, got: %v", "active certificate", err)
+	}
+}
+
+func TestCancelNewRequest_ReadOnly_ReturnsError(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	hostname := "locked.example.com"
+
+	err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname: hostname,
+		ReadOnly: 1,
+	})
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	if err := svc.CancelNewRequest(ctx, hostname); err == nil {
+		t.Fatal("expected error for read-only entry, got nil")
+	}
+}
+
 // ============================================================================
 // ListCertificates HasPendingCSR Tests
 // ============================================================================
@@ -245,3 +349,213 @@ func TestListCertificates_HasPendingCSR_False(t *testing.T) {
 		t.Error("expected HasPendingCSR to be false for certificate without pending CSR")
 	}
 }
+
+// ============================================================================
+// GetCertificate Active/Pending Tests
+// ============================================================================
+
+func TestGetCertificate_SeparatesActiveAndPending(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	hostname := "renew.example.com"
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	activeCSR, encryptedKey, activeKey := generateTestCSRAndKey(t, hostname, encryptionKey)
+	certPEM, err := selfSignCertFromCSR(activeCSR, activeKey)
+	if err != nil {
+		t.Fatalf("failed to sign certificate: %v", err)
+	}
+
+	pendingKey, err := crypto.GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	pendingCSR, err := crypto.CreateCSR(crypto.CSRRequest{
+		CommonName:   hostname,
+		Organization: "Renewed Org",
+		City:         "Test City",
+		State:        "Test State",
+		Country:      "FR",
+		DNSSANs:      []string{hostname, "alias.example.com"},
+	}, pendingKey)
+	if err != nil {
+		t.Fatalf("failed to create pending CSR: %v", err)
+	}
+
+	err = database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:            hostname,
+		EncryptedPrivateKey: encryptedKey,
+		CertificatePem:      sql.NullString{String: certPEM, Valid: true},
+		PendingCsrPem:       sql.NullString{String: string(pendingCSR), Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	cert, err := svc.GetCertificate(ctx, hostname)
+	if err != nil {
+		t.Fatalf("GetCertificate failed: %v", err)
+	}
+
+	if cert.Active == nil || cert.Pending == nil {
+		t.Fatalf("expected both active and pending details, got active=%v pending=%v", cert.Active, cert.Pending)
+	}
+	if cert.Active.Organization != "Test Org" {
+		t.Errorf("expected active organization 'Test Org', got %q", cert.Active.Organization)
+	}
+	if cert.Pending.Organization != "Renewed Org" {
+		t.Errorf("expected pending organization 'Renewed Org', got %q", cert.Pending.Organization)
+	}
+	if len(cert.Pending.SANs) != 2 {
+		t.Errorf("expected 2 pending SANs, got %v", cert.Pending.SANs)
+	}
+}
+
+func TestGetCertificate_PendingOnly(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	hostname := "new.example.com"
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	csrPEM, encryptedKey, _ := generateTestCSRAndKey(t, hostname, encryptionKey)
+	err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:                   hostname,
+		PendingCsrPem:              sql.NullString{String: string(csrPEM), Valid: true},
+		PendingEncryptedPrivateKey: encryptedKey,
+	})
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	cert, err := svc.GetCertificate(ctx, hostname)
+	if err != nil {
+		t.Fatalf("GetCertificate failed: %v", err)
+	}
+
+	if cert.Active != nil {
+		t.Error("expected no active details for a pending-only certificate")
+	}
+	if cert.Pending == nil || cert.Pending.KeySize != 2048 {
+		t.Errorf("expected pending details with 2048-bit key, got %+v", cert.Pending)
+	}
+}
+
+func TestGetCertificate_StatusFollowsClock(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+
+	expires := time.Date(2030, 6, 1, 0, 0, 0, 0, time.UTC)
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:       "clock.example.com",
+		CertificatePem: sql.NullString{String: "pem", Valid: true},
+		ExpiresAt:      sql.NullInt64{Int64: expires.Unix(), Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	fake := clock.NewFake(expires.AddDate(0, -6, 0))
+	svc.SetClock(fake)
+
+	cases := []struct {
+		at   time.Time
+		want string
+	}{
+		{expires.AddDate(0, -6, 0), "active"},
+		{expires.AddDate(0, 0, -7), "expiring"},
+		{expires.AddDate(0, 0, 1), "expired"},
+	}
+	for _, c := range cases {
+		fake.Set(c.at)
+		cert, err := svc.GetCertificate(ctx, "clock.example.com")
+		if err != nil {
+			t.Fatalf("GetCertificate failed: %v", err)
+		}
+		if cert.Status != c.want {
+			t.Errorf("at %s: status = %s, want %s", c.at.Format(time.DateOnly), cert.Status, c.want)
+		}
+	}
+}
+
+func TestListCertificatePage_FiltersAndPagesInSQL(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	svc.SetClock(clock.NewFake(now))
+
+	certs := []struct {
+		hostname string
+		expires  time.Time // zero for a pending request
+	}{
+		{"alpha.example.com", now.AddDate(1, 0, 0)},
+		{"beta.example.com", now.AddDate(0, 0, 10)},
+		{"gamma.example.com", now.AddDate(0, 0, -1)},
+		{"delta.example.com", time.Time{}},
+		{"epsilon.example.com", now.AddDate(0, 6, 0)},
+	}
+	for _, c := range certs {
+		params := sqlc.CreateCertificateParams{
+			Hostname:      c.hostname,
+			PendingCsrPem: sql.NullString{String: "csr", Valid: true},
+		}
+		if !c.expires.IsZero() {
+			params = sqlc.CreateCertificateParams{
+				Hostname:       c.hostname,
+				CertificatePem: sql.NullString{String: "pem", Valid: true},
+				ExpiresAt:      sql.NullInt64{Int64: c.expires.Unix(), Valid: true},
+			}
+		}
+		if err := database.Queries().CreateCertificate(ctx, params); err != nil {
+			t.Fatalf("failed to create certificate: %v", err)
+		}
+	}
+	if err := svc.AddCertificateTag(ctx, "alpha.example.com", "prod"); err != nil {
+		t.Fatalf("AddCertificateTag failed: %v", err)
+	}
+
+	list := func(filter models.CertificateFilter) ([]string, int) {
+		t.Helper()
+		page, err := svc.ListCertificatePage(ctx, filter)
+		if err != nil {
+			t.Fatalf("ListCertificatePage(%+v) failed: %v", filter, err)
+		}
+		hostnames := make([]string, 0, len(page.Certificates))
+		for _, item := range page.Certificates {
+			hostnames = append(hostnames, item.Hostname)
+		}
+		return hostnames, page.Total
+	}
+	expect := func(filter models.CertificateFilter, wantTotal int, want ...string) {
+		t.Helper()
+		got, total := list(filter)
+		if total != wantTotal || len(got) != len(want) {
+			t.Errorf("%+v: got %v (total %d), want %v (total %d)", filter, got, total, want, wantTotal)
+			return
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%+v: got %v, want %v", filter, got, want)
+				return
+			}
+		}
+	}
+
+	byName := models.CertificateFilter{SortBy: "hostname", SortOrder: "asc"}
+	expect(byName, 5, "alpha.example.com", "beta.example.com", "delta.example.com", "epsilon.example.com", "gamma.example.com")
+
+	paged := byName
+	paged.Limit = 2
+	expect(paged, 5, "alpha.example.com", "beta.example.com")
+	paged.Offset = 4
+	expect(paged, 5, "gamma.example.com")
+
+	expect(models.CertificateFilter{Status: "expiring"}, 1, "beta.example.com")
+	expect(models.CertificateFilter{Status: "expired"}, 1, "gamma.example.com")
+	expect(models.CertificateFilter{Status: "pending"}, 1, "delta.example.com")
+	expect(models.CertificateFilter{Hostname: "ETA"}, 1, "beta.example.com")
+	expect(models.CertificateFilter{Hostname: "_"}, 0)
+	expect(models.CertificateFilter{Tags: []string{"Prod"}}, 1, "alpha.example.com")
+	expect(models.CertificateFilter{SortBy: "expiring", SortOrder: "asc", Status: "active"}, 2,
+		"epsilon.example.com", "alpha.example.com")
+}
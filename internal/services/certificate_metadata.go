@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+)
+
+// refreshCertificateMetadata parses the SANs and key parameters of the
+// certificates whose certificate or CSR was written since they were last
+// parsed, and stores them in the certificates table so listings read them
+// without parsing PEMs. Triggers queue every PEM write, so this is a no-op
+// when nothing changed.
+func (s *CertificateService) refreshCertificateMetadata(ctx context.Context) error {
+	pending, err := s.db.Queries().ListPendingCertificateMetadata(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list pending certificate metadata: %w", err)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		return refreshCertificateMetadataTx(ctx, q)
+	})
+}
+
+// refreshCertificateMetadataTx parses the queued certificates within the
+// caller's transaction. Storing the metadata does not count as a write for
+// backup freshness.
+func refreshCertificateMetadataTx(ctx context.Context, q *sqlc.Queries) error {
+	pending, err := q.ListPendingCertificateMetadata(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list pending certificate metadata: %w", err)
+	}
+	for _, row := range pending {
+		params := certificateMetadata(row)
+		params.Hostname = row.Hostname
+		if err := q.UpdateCertificateMetadata(ctx, params); err != nil {
+			return fmt.Errorf("failed to store metadata of %s: %w", row.Hostname, err)
+		}
+		if err := q.DiscountCertificateWrite(ctx); err != nil {
+			return fmt.Errorf("failed to discount metadata write: %w", err)
+		}
+		if err := q.ClearPendingCertificateMetadata(ctx, row.Hostname); err != nil {
+			return fmt.Errorf("failed to clear pending metadata of %s: %w", row.Hostname, err)
+		}
+	}
+	return nil
+}
+
+// certificateMetadata returns the SANs (as a JSON array) and key parameters
+// of the active certificate, or of the pending CSR before one is issued. A
+// PEM that does not parse yields empty metadata.
+func certificateMetadata(row sqlc.ListPendingCertificateMetadataRow) sqlc.UpdateCertificateMetadataParams {
+	var sans []string
+	var algorithm string
+	var size int
+	if row.CertificatePem.Valid && row.CertificatePem.String != "" {
+		if cert, err := crypto.ParseCertificate([]byte(row.CertificatePem.String)); err == nil {
+			if details, err := crypto.ExtractCertificateDetails(cert); err == nil {
+				sans, algorithm, size = details.SANs, details.KeyAlgorithm, details.KeySize
+			}
+		}
+	} else if row.PendingCsrPem.Valid && row.PendingCsrPem.String != "" {
+		if csr, err := crypto.ParseCSR([]byte(row.PendingCsrPem.String)); err == nil {
+			if details, err := crypto.ExtractCSRDetails(csr); err == nil {
+				sans, algorithm, size = details.SANs, details.KeyAlgorithm, details.KeySize
+			}
+		}
+	}
+
+	encoded := "[]"
+	if len(sans) > 0 {
+		if data, err := json.Marshal(sans); err == nil {
+			encoded = string(data)
+		}
+	}
+	return sqlc.UpdateCertificateMetadataParams{
+		Sans:         encoded,
+		KeyAlgorithm: algorithm,
+		KeySize:      int64(size),
+	}
+}
+
+// decodeSANs reads the SANs cached by refreshCertificateMetadata
+func decodeSANs(encoded string) []string {
+	var sans []string
+	if err := json.Unmarshal([]byte(encoded), &sans); err != nil || len(sans) == 0 {
+		return nil
+	}
+	return sans
+}
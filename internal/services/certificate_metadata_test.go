@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+func TestListCertificates_CachesMetadata(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	q := database.Queries()
+	key, err := crypto.GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatalf("GenerateRSAKey failed: %v", err)
+	}
+	csrPEM, err := crypto.CreateCSR(crypto.CSRRequest{
+		CommonName: "cached.example.com",
+		DNSSANs:    []string{"cached.example.com", "www.cached.example.com"},
+	}, key)
+	if err != nil {
+		t.Fatalf("CreateCSR failed: %v", err)
+	}
+	certPEM, err := selfSignCertFromCSR(csrPEM, key)
+	if err != nil {
+		t.Fatalf("failed to sign certificate: %v", err)
+	}
+	if err := q.CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:       "cached.example.com",
+		CertificatePem: sql.NullString{String: certPEM, Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	items, err := svc.ListCertificates(ctx, models.CertificateFilter{})
+	if err != nil {
+		t.Fatalf("ListCertificates failed: %v", err)
+	}
+	if len(items) != 1 || len(items[0].SANs) != 2 || items[0].KeyAlgorithm != "rsa" || items[0].KeySize != 2048 {
+		t.Fatalf("items = %+v, want the SANs and the RSA 2048 key", items[0])
+	}
+
+	stored, err := q.GetCertificateByHostname(ctx, "cached.example.com")
+	if err != nil {
+		t.Fatalf("GetCertificateByHostname failed: %v", err)
+	}
+	if stored.Sans != `["cached.example.com","www.cached.example.com"]` || stored.KeyAlgorithm != "rsa" || stored.KeySize != 2048 {
+		t.Errorf("stored metadata = %q %q %d, want it cached", stored.Sans, stored.KeyAlgorithm, stored.KeySize)
+	}
+	pending, err := q.ListPendingCertificateMetadata(ctx)
+	if err != nil {
+		t.Fatalf("ListPendingCertificateMetadata failed: %v", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("pending metadata = %v, want none after listing", pending)
+	}
+
+	// Replacing the certificate queues it to be parsed again
+	if err := q.ActivateCertificate(ctx, sqlc.ActivateCertificateParams{
+		Hostname:       "cached.example.com",
+		CertificatePem: sql.NullString{String: "not a certificate", Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to update certificate: %v", err)
+	}
+	items, err = svc.ListCertificates(ctx, models.CertificateFilter{})
+	if err != nil {
+		t.Fatalf("ListCertificates failed: %v", err)
+	}
+	if len(items) != 1 || len(items[0].SANs) != 0 || items[0].KeyAlgorithm != "" {
+		t.Errorf("items = %+v, want the metadata cleared for an unparseable certificate", items)
+	}
+}
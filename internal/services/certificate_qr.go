@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/models"
+
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	// qrImageSize is the width and height of generated QR code images, in pixels.
+	qrImageSize = 320
+	// qrPEMChunkSize is the number of PEM characters per frame. Small enough that
+	// every frame stays scannable from a screen by a phone camera.
+	qrPEMChunkSize = 600
+)
+
+// GetCertificateQRCodes renders the SHA-256 fingerprint of a certificate as a
+// QR code and, with includePEM, the certificate PEM as a sequence of frames.
+func (s *CertificateService) GetCertificateQRCodes(ctx context.Context, hostname string, includePEM bool) (*models.CertificateQRCodes, error) {
+	certPEM, err := s.GetCertificateForDownload(ctx, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := crypto.ParseCertificate([]byte(certPEM))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+	sum := sha256.Sum256(cert.Raw)
+	fingerprint := crypto.FormatHexColon(sum[:])
+
+	fingerprintFrame, err := renderQRFrame(fingerprint)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.CertificateQRCodes{
+		Hostname:          hostname,
+		FingerprintSHA256: fingerprint,
+		Fingerprint:       fingerprintFrame,
+	}
+
+	if includePEM {
+		for _, content := range splitQRFrames(certPEM, qrPEMChunkSize) {
+			frame, err := renderQRFrame(content)
+			if err != nil {
+				return nil, err
+			}
+			result.PEMFrames = append(result.PEMFrames, frame)
+		}
+	}
+
+	return result, nil
+}
+
+// splitQRFrames cuts data into chunks of at most size bytes, each prefixed with
+// a "PCQR <n>/<total>" line so a scanner can reassemble them in order.
+func splitQRFrames(data string, size int) []string {
+	total := (len(data) + size - 1) / size
+	frames := make([]string, 0, total)
+	for i := 0; i < total; i++ {
+		end := min((i+1)*size, len(data))
+		frames = append(frames, fmt.Sprintf("PCQR %d/%d\n%s", i+1, total, data[i*size:end]))
+	}
+	return frames
+}
+
+// renderQRFrame encodes content as a PNG QR code data URL.
+func renderQRFrame(content string) (models.QRFrame, error) {
+	png, err := qrcode.Encode(content, qrcode.Medium, qrImageSize)
+	if err != nil {
+		return models.QRFrame{}, fmt.Errorf("failed to generate QR code: %w", err)
+	}
+	return models.QRFrame{
+		Content: content,
+		Image:   "data:image/png;base64," + base64.StdEncoding.EncodeToString(png),
+	}, nil
+}
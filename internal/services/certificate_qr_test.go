@@ -0,0 +1,98 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"image/png"
+	"strings"
+	"testing"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+func TestGetCertificateQRCodes(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	hostname := "qr.example.com"
+
+	csrPEM, encryptedKey, key := generateTestCSRAndKey(t, hostname, testutil.RandomMasterKey(t))
+	certPEM, err := selfSignCertFromCSR(csrPEM, key)
+	if err != nil {
+		t.Fatalf("failed to sign certificate: %v", err)
+	}
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:            hostname,
+		EncryptedPrivateKey: encryptedKey,
+		CertificatePem:      sql.NullString{String: certPEM, Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	codes, err := svc.GetCertificateQRCodes(ctx, hostname, true)
+	if err != nil {
+		t.Fatalf("GetCertificateQRCodes() error: %v", err)
+	}
+
+	cert, err := crypto.ParseCertificate([]byte(certPEM))
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	sum := sha256.Sum256(cert.Raw)
+	if want := crypto.FormatHexColon(sum[:]); codes.FingerprintSHA256 != want || codes.Fingerprint.Content != want {
+		t.Errorf("fingerprint = %q, want %q", codes.FingerprintSHA256, want)
+	}
+
+	data, ok := strings.CutPrefix(codes.Fingerprint.Image, "data:image/png;base64,")
+	if !ok {
+		t.Fatalf("expected a PNG data URL, got %.30q", codes.Fingerprint.Image)
+	}
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		t.Fatalf("invalid base64: %v", err)
+	}
+	if _, err := png.Decode(bytes.NewReader(raw)); err != nil {
+		t.Fatalf("invalid PNG: %v", err)
+	}
+
+	// The frames reassemble to the stored PEM
+	if len(codes.PEMFrames) < 2 {
+		t.Fatalf("expected the PEM to span several frames, got %d", len(codes.PEMFrames))
+	}
+	var rebuilt strings.Builder
+	for i, frame := range codes.PEMFrames {
+		header, chunk, _ := strings.Cut(frame.Content, "\n")
+		if want := fmt.Sprintf("PCQR %d/%d", i+1, len(codes.PEMFrames)); header != want {
+			t.Errorf("frame %d header = %q, want %q", i, header, want)
+		}
+		rebuilt.WriteString(chunk)
+	}
+	if rebuilt.String() != certPEM {
+		t.Error("reassembled frames do not match the certificate PEM")
+	}
+
+	withoutPEM, err := svc.GetCertificateQRCodes(ctx, hostname, false)
+	if err != nil {
+		t.Fatalf("GetCertificateQRCodes() error: %v", err)
+	}
+	if len(withoutPEM.PEMFrames) != 0 {
+		t.Errorf("expected no PEM frames, got %d", len(withoutPEM.PEMFrames))
+	}
+}
+
+func TestGetCertificateQRCodes_PendingCertificate(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{Hostname: "pending.example.com"}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	if _, err := svc.GetCertificateQRCodes(ctx, "pending.example.com", false); err == nil {
+		t.Fatal("expected error for a certificate without PEM")
+	}
+}
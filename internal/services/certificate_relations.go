@@ -0,0 +1,156 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// maxRelationLabelLength bounds the label of a relation
+const maxRelationLabelLength = 128
+
+// GetCertificateGraph returns the certificates that take part in a relation
+// and the relations between them, for the dependency view
+func (s *CertificateService) GetCertificateGraph(ctx context.Context) (*models.CertificateGraph, error) {
+	rows, err := s.db.Queries().ListCertificateRelations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificate relations: %w", err)
+	}
+
+	graph := &models.CertificateGraph{
+		Nodes: []*models.CertificateListItem{},
+		Edges: make([]models.CertificateRelation, 0, len(rows)),
+	}
+	if len(rows) == 0 {
+		return graph, nil
+	}
+
+	related := make(map[string]bool)
+	for _, row := range rows {
+		graph.Edges = append(graph.Edges, toCertificateRelation(row))
+		related[row.SourceHostname] = true
+		related[row.TargetHostname] = true
+	}
+
+	certs, err := s.ListCertificates(ctx, models.CertificateFilter{SortBy: "hostname", SortOrder: "asc"})
+	if err != nil {
+		return nil, err
+	}
+	for _, cert := range certs {
+		if related[cert.Hostname] {
+			graph.Nodes = append(graph.Nodes, cert)
+		}
+	}
+	return graph, nil
+}
+
+// ListCertificateRelations returns the relations a certificate takes part in,
+// on either side
+func (s *CertificateService) ListCertificateRelations(ctx context.Context, hostname string) ([]models.CertificateRelation, error) {
+	rows, err := s.db.Queries().ListCertificateRelationsForHostname(ctx, hostname)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list relations of %s: %w", hostname, err)
+	}
+
+	relations := make([]models.CertificateRelation, 0, len(rows))
+	for _, row := range rows {
+		relations = append(relations, toCertificateRelation(row))
+	}
+	return relations, nil
+}
+
+// AddCertificateRelation declares a relation of relationType between two
+// certificates. Declaring an existing relation again replaces its label; a
+// shared_endpoint relation has no direction, so it matches either way round.
+func (s *CertificateService) AddCertificateRelation(ctx context.Context, source, target, relationType, label string) error {
+	label = strings.TrimSpace(label)
+	if !slices.Contains(models.RelationTypes, relationType) {
+		return fmt.Errorf("unknown relation type: %s", relationType)
+	}
+	if source == target {
+		return fmt.Errorf("a certificate cannot be related to itself")
+	}
+	if len(label) > maxRelationLabelLength {
+		return fmt.Errorf("label must not exceed %d characters", maxRelationLabelLength)
+	}
+
+	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		for _, hostname := range []string{source, target} {
+			if _, err := q.GetCertificateByHostname(ctx, hostname); err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return fmt.Errorf("certificate not found: %s", hostname)
+				}
+				return fmt.Errorf("failed to get certificate: %w", err)
+			}
+		}
+
+		if relationType == models.RelationSharedEndpoint {
+			existing, err := q.ListCertificateRelationsForHostname(ctx, target)
+			if err != nil {
+				return fmt.Errorf("failed to list relations of %s: %w", target, err)
+			}
+			for _, row := range existing {
+				if row.RelationType == relationType && row.SourceHostname == target && row.TargetHostname == source {
+					source, target = target, source
+					break
+				}
+			}
+		}
+
+		return q.UpsertCertificateRelation(ctx, sqlc.UpsertCertificateRelationParams{
+			SourceHostname: source,
+			TargetHostname: target,
+			RelationType:   relationType,
+			Label:          label,
+		})
+	})
+}
+
+// DeleteCertificateRelation removes a relation
+func (s *CertificateService) DeleteCertificateRelation(ctx context.Context, id int64) error {
+	deleted, err := s.db.Queries().DeleteCertificateRelation(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete relation: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("relation not found: %d", id)
+	}
+	return nil
+}
+
+// dependentCertificates returns the hostnames related to a certificate, sorted
+// and without duplicates
+func dependentCertificates(ctx context.Context, q *sqlc.Queries, hostname string) ([]string, error) {
+	rows, err := q.ListCertificateRelationsForHostname(ctx, hostname)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list relations of %s: %w", hostname, err)
+	}
+
+	var dependents []string
+	for _, row := range rows {
+		other := row.TargetHostname
+		if other == hostname {
+			other = row.SourceHostname
+		}
+		dependents = append(dependents, other)
+	}
+	slices.Sort(dependents)
+	return slices.Compact(dependents), nil
+}
+
+func toCertificateRelation(row sqlc.CertificateRelation) models.CertificateRelation {
+	return models.CertificateRelation{
+		ID:        row.ID,
+		Source:    row.SourceHostname,
+		Target:    row.TargetHostname,
+		Type:      row.RelationType,
+		Label:     row.Label,
+		CreatedAt: row.CreatedAt,
+	}
+}
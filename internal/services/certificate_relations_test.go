@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+func createRelationTestCertificates(t *testing.T, q *sqlc.Queries, names ...string) {
+	t.Helper()
+	for _, h := range names {
+		if err := q.CreateCertificate(context.Background(), sqlc.CreateCertificateParams{Hostname: h}); err != nil {
+			t.Fatalf("failed to create certificate %s: %v", h, err)
+		}
+	}
+}
+
+func TestCertificateRelations_Graph(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	createRelationTestCertificates(t, database.Queries(), "client.example.com", "api.example.com", "www.example.com", "alone.example.com")
+
+	graph, err := svc.GetCertificateGraph(ctx)
+	if err != nil {
+		t.Fatalf("GetCertificateGraph failed: %v", err)
+	}
+	if len(graph.Nodes) != 0 || len(graph.Edges) != 0 {
+		t.Fatalf("expected an empty graph, got %+v", graph)
+	}
+
+	if err := svc.AddCertificateRelation(ctx, "client.example.com", "api.example.com", models.RelationClientOf, ""); err != nil {
+		t.Fatalf("AddCertificateRelation failed: %v", err)
+	}
+	if err := svc.AddCertificateRelation(ctx, "api.example.com", "www.example.com", models.RelationSharedEndpoint, "lb-1"); err != nil {
+		t.Fatalf("AddCertificateRelation failed: %v", err)
+	}
+	// A shared endpoint has no direction: declaring it the other way round relabels it
+	if err := svc.AddCertificateRelation(ctx, "www.example.com", "api.example.com", models.RelationSharedEndpoint, "lb-prod"); err != nil {
+		t.Fatalf("AddCertificateRelation failed: %v", err)
+	}
+
+	graph, err = svc.GetCertificateGraph(ctx)
+	if err != nil {
+		t.Fatalf("GetCertificateGraph failed: %v", err)
+	}
+	var nodes []string
+	for _, node := range graph.Nodes {
+		nodes = append(nodes, node.Hostname)
+	}
+	if !slices.Equal(nodes, []string{"api.example.com", "client.example.com", "www.example.com"}) {
+		t.Errorf("unexpected nodes: %v", nodes)
+	}
+	if len(graph.Edges) != 2 {
+		t.Fatalf("expected 2 edges, got %+v", graph.Edges)
+	}
+	if edge := graph.Edges[1]; edge.Source != "api.example.com" || edge.Label != "lb-prod" {
+		t.Errorf("expected the shared endpoint to be relabelled, got %+v", edge)
+	}
+
+	// Deleting a certificate drops its relations
+	if err := database.Queries().DeleteCertificate(ctx, "client.example.com"); err != nil {
+		t.Fatalf("DeleteCertificate failed: %v", err)
+	}
+	relations, err := svc.ListCertificateRelations(ctx, "api.example.com")
+	if err != nil {
+		t.Fatalf("ListCertificateRelations failed: %v", err)
+	}
+	if len(relations) != 1 {
+		t.Fatalf("expected 1 relation left, got %+v", relations)
+	}
+
+	if err := svc.DeleteCertificateRelation(ctx, relations[0].ID); err != nil {
+		t.Fatalf("DeleteCertificateRelation failed: %v", err)
+	}
+	if err := svc.DeleteCertificateRelation(ctx, relations[0].ID); err == nil {
+		t.Error("expected deleting a missing relation to fail")
+	}
+}
+
+func TestAddCertificateRelation_Rejects(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	createRelationTestCertificates(t, database.Queries(), "a.example.com", "b.example.com")
+
+	tests := []struct {
+		name, source, target, relationType string
+	}{
+		{"self", "a.example.com", "a.example.com", models.RelationClientOf},
+		{"unknown type", "a.example.com", "b.example.com", "depends_on"},
+		{"missing certificate", "a.example.com", "missing.example.com", models.RelationClientOf},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := svc.AddCertificateRelation(ctx, tt.source, tt.target, tt.relationType, ""); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestGenerateCSR_Renewal_ReportsDependents(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	createRelationTestCertificates(t, database.Queries(), "server.example.com", "client.example.com", "www.example.com")
+
+	if err := svc.AddCertificateRelation(ctx, "client.example.com", "server.example.com", models.RelationClientOf, ""); err != nil {
+		t.Fatalf("AddCertificateRelation failed: %v", err)
+	}
+	if err := svc.AddCertificateRelation(ctx, "server.example.com", "www.example.com", models.RelationSharedEndpoint, "lb-1"); err != nil {
+		t.Fatalf("AddCertificateRelation failed: %v", err)
+	}
+
+	resp, err := svc.GenerateCSR(ctx, models.CSRRequest{
+		Hostname:     "server.example.com",
+		Organization: "Test Org",
+		City:         "Paris",
+		State:        "IDF",
+		Country:      "FR",
+		KeySize:      2048,
+		IsRenewal:    true,
+	}, testutil.RandomMasterKey(t))
+	if err != nil {
+		t.Fatalf("GenerateCSR renewal failed: %v", err)
+	}
+	if !slices.Equal(resp.DependentCertificates, []string{"client.example.com", "www.example.com"}) {
+		t.Errorf("unexpected dependent certificates: %v", resp.DependentCertificates)
+	}
+}
+
+func TestMergeHostnameDuplicates_MovesRelations(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	createRelationTestCertificates(t, database.Queries(), "Web.example.com", "client.example.com")
+
+	if err := svc.AddCertificateRelation(ctx, "client.example.com", "Web.example.com", models.RelationClientOf, ""); err != nil {
+		t.Fatalf("AddCertificateRelation failed: %v", err)
+	}
+	if _, err := svc.MergeHostnameDuplicates(ctx, "Web.example.com"); err != nil {
+		t.Fatalf("MergeHostnameDuplicates failed: %v", err)
+	}
+
+	relations, err := svc.ListCertificateRelations(ctx, "web.example.com")
+	if err != nil {
+		t.Fatalf("ListCertificateRelations failed: %v", err)
+	}
+	if len(relations) != 1 || relations[0].Target != "web.example.com" {
+		t.Errorf("expected the relation to follow the renamed certificate, got %+v", relations)
+	}
+}
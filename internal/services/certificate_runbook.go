@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// runbookHistoryLimit is the number of recent history entries listed in a runbook.
+const runbookHistoryLimit = 10
+
+// BuildRunbook renders a Markdown summary of a certificate for change-management
+// attachments: subject and SANs, the issued certificate, any pending renewal, the
+// renewal policy, contacts, notes and recent history. No private key material is
+// included.
+func (s *CertificateService) BuildRunbook(ctx context.Context, hostname string) (string, error) {
+	cert, err := s.GetCertificate(ctx, hostname)
+	if err != nil {
+		return "", err
+	}
+
+	cfg, err := s.config.GetConfig(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get config: %w", err)
+	}
+
+	history, err := s.history.GetHistory(ctx, cert.Hostname, runbookHistoryLimit)
+	if err != nil {
+		return "", fmt.Errorf("failed to get history: %w", err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Certificate runbook: %s\n\n", cert.DisplayHostname)
+	fmt.Fprintf(&b, "Generated %s.\n\n", formatRunbookTime(s.clock.Now().Unix()))
+
+	b.WriteString("## Summary\n\n")
+	b.WriteString("| | |\n|---|---|\n")
+	writeRunbookRow(&b, "Hostname", cert.Hostname)
+	writeRunbookRow(&b, "Status", cert.Status)
+	if cert.ExpiresAt != nil {
+		writeRunbookRow(&b, "Expires", formatRunbookTime(*cert.ExpiresAt))
+		writeRunbookRow(&b, "Days until expiration", fmt.Sprintf("%d", cert.DaysUntilExpiration))
+	}
+	writeRunbookRow(&b, "Created", formatRunbookTime(cert.CreatedAt))
+	writeRunbookRow(&b, "Read-only", yesNo(cert.ReadOnly))
+	b.WriteString("\n")
+
+	if cert.Active != nil {
+		b.WriteString("## Issued certificate\n\n")
+		writeRunbookSubject(&b, cert.Active)
+		if cert.Extensions != nil {
+			writeRunbookRow(&b, "Serial number", cert.Extensions.SerialNumber)
+			writeRunbookRow(&b, "Signature algorithm", cert.Extensions.SignatureAlgorithm)
+			writeRunbookRow(&b, "SHA-256 fingerprint", cert.Extensions.FingerprintSHA256)
+		}
+		if parsed, err := crypto.ParseCertificate([]byte(cert.CertificatePEM)); err == nil {
+			writeRunbookRow(&b, "Issuer", parsed.Issuer.String())
+			writeRunbookRow(&b, "Valid from", formatRunbookTime(parsed.NotBefore.Unix()))
+		}
+		b.WriteString("\n")
+		writeRunbookSANs(&b, cert.Active.SANs)
+	}
+
+	if cert.Pending != nil {
+		b.WriteString("## Pending request\n\n")
+		writeRunbookSubject(&b, cert.Pending)
+		b.WriteString("\n")
+		writeRunbookSANs(&b, cert.Pending.SANs)
+	}
+
+	b.WriteString("## Renewal policy\n\n")
+	b.WriteString("| | |\n|---|---|\n")
+	writeRunbookRow(&b, "Certificate authority", cfg.CaName)
+	writeRunbookRow(&b, "Validity period", fmt.Sprintf("%d days", cfg.ValidityPeriodDays))
+	writeRunbookRow(&b, "Expiring threshold", fmt.Sprintf("%d days", cfg.ExpiringThresholdDays))
+	if cert.ExpiresAt != nil {
+		renewBy := time.Unix(*cert.ExpiresAt, 0).AddDate(0, 0, -int(cfg.ExpiringThresholdDays))
+		writeRunbookRow(&b, "Renew by", formatRunbookTime(renewBy.Unix()))
+	}
+	b.WriteString("\n")
+
+	b.WriteString("## Contacts\n\n")
+	b.WriteString("| | |\n|---|---|\n")
+	writeRunbookRow(&b, "Owner", cfg.OwnerEmail)
+	b.WriteString("\n")
+
+	if cert.Note != "" || cert.PendingNote != "" {
+		b.WriteString("## Notes\n\n")
+		if cert.Note != "" {
+			b.WriteString(cert.Note + "\n\n")
+		}
+		if cert.PendingNote != "" {
+			b.WriteString("Pending request: " + cert.PendingNote + "\n\n")
+		}
+	}
+
+	if len(history) > 0 {
+		b.WriteString("## Recent history\n\n")
+		b.WriteString("| Date | Event | By | Details |\n|---|---|---|---|\n")
+		for _, entry := range history {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n",
+				formatRunbookTime(entry.CreatedAt),
+				runbookCell(entry.EventType),
+				runbookCell(entry.Actor),
+				runbookCell(entry.Message),
+			)
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String(), nil
+}
+
+// writeRunbookSubject writes the subject fields of a certificate or CSR as the
+// start of a two-column table.
+func writeRunbookSubject(b *strings.Builder, subject *models.CertificateSubject) {
+	b.WriteString("| | |\n|---|---|\n")
+	writeRunbookRow(b, "Organization", subject.Organization)
+	writeRunbookRow(b, "Organizational unit", subject.OrganizationalUnit)
+	writeRunbookRow(b, "Locality", strings.Join(nonEmpty(subject.City, subject.State, subject.Country), ", "))
+	if subject.KeySize > 0 {
+		writeRunbookRow(b, "Key size", fmt.Sprintf("%d bits", subject.KeySize))
+	}
+}
+
+// writeRunbookSANs writes the subject alternative names as a list.
+func writeRunbookSANs(b *strings.Builder, sans []string) {
+	if len(sans) == 0 {
+		return
+	}
+	b.WriteString("Subject alternative names:\n\n")
+	for _, san := range sans {
+		fmt.Fprintf(b, "- `%s`\n", san)
+	}
+	b.WriteString("\n")
+}
+
+// writeRunbookRow writes a table row, skipping empty values.
+func writeRunbookRow(b *strings.Builder, label, value string) {
+	if value == "" {
+		return
+	}
+	fmt.Fprintf(b, "| %s | %s |\n", label, runbookCell(value))
+}
+
+// runbookCell keeps a value on one line and escapes table separators.
+func runbookCell(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", " ")
+	s = strings.ReplaceAll(s, "\n", " ")
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// formatRunbookTime formats a Unix timestamp in UTC.
+func formatRunbookTime(ts int64) string {
+	return time.Unix(ts, 0).UTC().Format("2006-01-02 15:04 MST")
+}
+
+// yesNo formats a flag for a runbook table.
+func yesNo(v bool) string {
+	if v {
+		return "yes"
+	}
+	return "no"
+}
+
+// nonEmpty returns values without the empty strings.
+func nonEmpty(values ...string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
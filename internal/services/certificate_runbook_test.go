@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+func TestBuildRunbook(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	hostname := "runbook.example.com"
+
+	csrPEM, encryptedKey, key := generateTestCSRAndKey(t, hostname, testutil.RandomMasterKey(t))
+	certPEM, err := selfSignCertFromCSR(csrPEM, key)
+	if err != nil {
+		t.Fatalf("failed to sign certificate: %v", err)
+	}
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:            hostname,
+		EncryptedPrivateKey: encryptedKey,
+		CertificatePem:      sql.NullString{String: certPEM, Valid: true},
+		ExpiresAt:           sql.NullInt64{Int64: svc.clock.Now().AddDate(0, 0, 90).Unix(), Valid: true},
+		Note:                sql.NullString{String: "Load balancer | pool A", Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	if err := svc.history.LogEvent(ctx, hostname, models.EventCertificateUploaded, "Certificate uploaded"); err != nil {
+		t.Fatalf("failed to log event: %v", err)
+	}
+
+	runbook, err := svc.BuildRunbook(ctx, hostname)
+	if err != nil {
+		t.Fatalf("BuildRunbook() error = %v", err)
+	}
+
+	for _, want := range []string{
+		"# Certificate runbook: " + hostname,
+		"## Issued certificate",
+		"| Certificate authority | Test CA |",
+		"| Owner | test@example.com |",
+		"| Renew by |",
+		"Load balancer | pool A",
+		"| " + models.EventCertificateUploaded + " |",
+	} {
+		if !strings.Contains(runbook, want) {
+			t.Errorf("runbook is missing %q:\n%s", want, runbook)
+		}
+	}
+	if strings.Contains(runbook, "PRIVATE KEY") || strings.Contains(runbook, "BEGIN CERTIFICATE") {
+		t.Error("runbook must not contain PEM material")
+	}
+	if strings.Contains(runbook, "## Pending request") {
+		t.Error("runbook should have no pending section without a pending CSR")
+	}
+}
+
+func TestBuildRunbook_UnknownHostname(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+
+	if _, err := svc.BuildRunbook(context.Background(), "missing.example.com"); err == nil {
+		t.Fatal("expected an error for an unknown hostname")
+	}
+}
@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+)
+
+// minTrigramTerm is the shortest term the trigram index can match; shorter
+// ones fall back to a LIKE scan of the indexed text
+const minTrigramTerm = 3
+
+// refreshSearchSANs parses the SANs of the certificates whose certificate or
+// CSR changed since they were last indexed. Triggers keep hostnames and notes
+// in sync and mark changed PEMs stale, so this is a no-op when nothing changed.
+func (s *CertificateService) refreshSearchSANs(ctx context.Context) error {
+	stale, err := s.db.Queries().ListStaleSearchSANs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list stale search entries: %w", err)
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		stale, err := q.ListStaleSearchSANs(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list stale search entries: %w", err)
+		}
+		for _, row := range stale {
+			if err := q.SetSearchSANs(ctx, sqlc.SetSearchSANsParams{
+				Sans: strings.Join(searchSANs(row), " "),
+				ID:   row.ID,
+			}); err != nil {
+				return fmt.Errorf("failed to index SANs: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// searchSANs returns the SANs of an issued certificate and of its pending CSR.
+// A PEM that does not parse contributes nothing.
+func searchSANs(row sqlc.ListStaleSearchSANsRow) []string {
+	seen := make(map[string]bool)
+	var sans []string
+	add := func(values []string) {
+		for _, v := range values {
+			if !seen[v] {
+				seen[v] = true
+				sans = append(sans, v)
+			}
+		}
+	}
+
+	if row.CertificatePem.Valid {
+		if cert, err := crypto.ParseCertificate([]byte(row.CertificatePem.String)); err == nil {
+			if details, err := crypto.ExtractCertificateDetails(cert); err == nil {
+				add(details.SANs)
+			}
+		}
+	}
+	if row.PendingCsrPem.Valid {
+		if csr, err := crypto.ParseCSR([]byte(row.PendingCsrPem.String)); err == nil {
+			if details, err := crypto.ExtractCSRDetails(csr); err == nil {
+				add(details.SANs)
+			}
+		}
+	}
+	return sans
+}
+
+// searchHostnames returns the set of certificates whose hostname, SANs or
+// notes contain every word of search. Words of three characters or more go
+// through the FTS5 index; shorter ones are matched with LIKE.
+func (s *CertificateService) searchHostnames(ctx context.Context, search string) (map[string]bool, error) {
+	if err := s.refreshSearchSANs(ctx); err != nil {
+		return nil, err
+	}
+
+	var result map[string]bool
+	for _, word := range strings.Fields(search) {
+		var (
+			hostnames []string
+			err       error
+		)
+		if utf8.RuneCountInString(word) >= minTrigramTerm {
+			hostnames, err = s.db.Queries().SearchCertificateHostnames(ctx, ftsPhrase(word))
+		} else {
+			hostnames, err = s.db.Queries().SearchCertificateHostnamesLike(ctx, "%"+escapeLike(word)+"%")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to search certificates for %q: %w", word, err)
+		}
+
+		matched := make(map[string]bool, len(hostnames))
+		for _, hostname := range hostnames {
+			if result == nil || result[hostname] {
+				matched[hostname] = true
+			}
+		}
+		result = matched
+		if len(result) == 0 {
+			break
+		}
+	}
+	if result == nil {
+		result = make(map[string]bool)
+	}
+	return result, nil
+}
+
+// ftsPhrase quotes a word as an FTS5 string so that operators and
+// punctuation in it are matched literally
+func ftsPhrase(word string) string {
+	return `"` + strings.ReplaceAll(word, `"`, `""`) + `"`
+}
+
+// escapeLike escapes the LIKE wildcards of s for use with ESCAPE '\'
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
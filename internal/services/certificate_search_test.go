@@ -0,0 +1,94 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"testing"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+func TestListCertificates_Search(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	q := database.Queries()
+
+	key, err := crypto.GenerateECDSAKey(256)
+	if err != nil {
+		t.Fatalf("GenerateECDSAKey failed: %v", err)
+	}
+	csrPEM, err := crypto.CreateCSR(crypto.CSRRequest{
+		CommonName: "web.example.com",
+		DNSSANs:    []string{"web.example.com", "legacy-portal.internal"},
+		IPSANs:     []net.IP{net.ParseIP("10.20.30.40")},
+	}, key)
+	if err != nil {
+		t.Fatalf("CreateCSR failed: %v", err)
+	}
+	if err := q.CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:      "web.example.com",
+		PendingCsrPem: sql.NullString{String: string(csrPEM), Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	if err := q.CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname: "db.example.com",
+		Note:     sql.NullString{String: "Owned by the DBA team", Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	search := func(text string) []string {
+		t.Helper()
+		items, err := svc.ListCertificates(ctx, models.CertificateFilter{Search: text, SortBy: "hostname", SortOrder: "asc"})
+		if err != nil {
+			t.Fatalf("ListCertificates(%q) failed: %v", text, err)
+		}
+		hostnames := make([]string, 0, len(items))
+		for _, item := range items {
+			hostnames = append(hostnames, item.Hostname)
+		}
+		return hostnames
+	}
+	expect := func(text string, want ...string) {
+		t.Helper()
+		got := search(text)
+		if len(got) != len(want) {
+			t.Errorf("search %q = %v, want %v", text, got, want)
+			return
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("search %q = %v, want %v", text, got, want)
+				return
+			}
+		}
+	}
+
+	expect("LEGACY-portal", "web.example.com")
+	expect("10.20.30", "web.example.com")
+	expect("dba", "db.example.com")
+	expect("example", "db.example.com", "web.example.com")
+	expect("example dba", "db.example.com")
+	expect("db", "db.example.com") // shorter than a trigram
+	expect(`"OR NEAR(`)
+	expect("100%")
+
+	// Note changes are indexed by triggers
+	if err := q.UpdateCertificateNote(ctx, sqlc.UpdateCertificateNoteParams{
+		Note:     sql.NullString{String: "Handed over to the DBA team", Valid: true},
+		Hostname: "web.example.com",
+	}); err != nil {
+		t.Fatalf("UpdateCertificateNote failed: %v", err)
+	}
+	expect("dba", "db.example.com", "web.example.com")
+
+	if err := q.DeleteCertificate(ctx, "db.example.com"); err != nil {
+		t.Fatalf("DeleteCertificate failed: %v", err)
+	}
+	expect("dba", "web.example.com")
+}
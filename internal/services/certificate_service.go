@@ -2,7 +2,11 @@ package services
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 
+	"paddockcontrol-desktop/internal/clock"
 	"paddockcontrol-desktop/internal/config"
 	"paddockcontrol-desktop/internal/db"
 	"paddockcontrol-desktop/internal/models"
@@ -13,18 +17,56 @@ type CertificateService struct {
 	db      *db.Database
 	config  *config.Service
 	history *HistoryService
+	clock   clock.Clock
+	keyPool *KeyPool // nil when keys are always generated on demand
 }
 
-// NewCertificateService creates a new certificate service
-func NewCertificateService(database *db.Database, configSvc *config.Service) *CertificateService {
+// NewCertificateService creates a new certificate service. appVersion is
+// recorded on every history entry the service writes.
+func NewCertificateService(database *db.Database, configSvc *config.Service, appVersion string) *CertificateService {
 	return &CertificateService{
 		db:      database,
 		config:  configSvc,
-		history: NewHistoryService(database),
+		history: NewHistoryService(database, appVersion),
+		clock:   clock.System(),
 	}
 }
 
+// SetClock replaces the clock used for status and expiry computations.
+func (s *CertificateService) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// SetKeyPool makes GenerateCSR take keys from pool when it has one of the
+// requested size.
+func (s *CertificateService) SetKeyPool(pool *KeyPool) {
+	s.keyPool = pool
+}
+
+// fipsMode reports whether the FIPS-compatible crypto mode is enabled. It is
+// off until setup has written the config row.
+func (s *CertificateService) fipsMode(ctx context.Context) (bool, error) {
+	cfg, err := s.config.GetConfig(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get configuration: %w", err)
+	}
+	return cfg.FipsMode == 1, nil
+}
+
 // GetHistory returns the activity history for a certificate
 func (s *CertificateService) GetHistory(ctx context.Context, hostname string, limit int) ([]models.HistoryEntry, error) {
 	return s.history.GetHistory(ctx, hostname, limit)
 }
+
+// ListHistory returns one page of the activity history across all certificates
+func (s *CertificateService) ListHistory(ctx context.Context, filter models.HistoryFilter, limit, offset int) (*models.HistoryPage, error) {
+	return s.history.ListHistory(ctx, filter, limit, offset)
+}
+
+// BuildHistoryCSV renders the activity history across all certificates as CSV
+func (s *CertificateService) BuildHistoryCSV(ctx context.Context, filter models.HistoryFilter) ([]byte, error) {
+	return s.history.BuildHistoryCSV(ctx, filter)
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// PreviewStatusAt evaluates every certificate's status as it would be at the
+// given instant, next to its status now (per the service clock), to show who
+// will have expired and which renewals will be due by then. Results are
+// ordered by expiry, soonest first; certificates without one come last.
+func (s *CertificateService) PreviewStatusAt(ctx context.Context, at time.Time) (*models.StatusPreview, error) {
+	certs, err := s.db.Queries().ListAllCertificates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	threshold := s.expiringThresholdDays(ctx)
+	now := s.clock.Now()
+
+	preview := &models.StatusPreview{
+		At:           at.Unix(),
+		Certificates: make([]models.StatusPreviewEntry, 0, len(certs)),
+	}
+	for i := range certs {
+		cert := &certs[i]
+		current := db.ComputeStatusAt(cert, threshold, now)
+		future := db.ComputeStatusAt(cert, threshold, at)
+		hasPendingCSR := cert.PendingCsrPem.Valid && cert.PendingCsrPem.String != ""
+
+		entry := models.StatusPreviewEntry{
+			Hostname:      cert.Hostname,
+			CurrentStatus: string(current),
+			Status:        string(future),
+			Changed:       current != future,
+			HasPendingCSR: hasPendingCSR,
+			// A certificate with a pending CSR already has its renewal under way
+			NeedsRenewal: !hasPendingCSR && (future == db.StatusExpiring || future == db.StatusExpired),
+		}
+		if cert.ExpiresAt.Valid {
+			expiresAt := cert.ExpiresAt.Int64
+			entry.ExpiresAt = &expiresAt
+			entry.DaysUntilExpiration = daysUntilExpirationAt(expiresAt, at)
+		}
+
+		switch future {
+		case db.StatusActive:
+			preview.Active++
+		case db.StatusExpiring:
+			preview.Expiring++
+		case db.StatusExpired:
+			preview.Expired++
+		case db.StatusPending:
+			preview.Pending++
+		}
+		if entry.NeedsRenewal {
+			preview.NeedsRenewal++
+		}
+
+		preview.Certificates = append(preview.Certificates, entry)
+	}
+
+	sort.SliceStable(preview.Certificates, func(i, j int) bool {
+		a, b := preview.Certificates[i], preview.Certificates[j]
+		if (a.ExpiresAt == nil) != (b.ExpiresAt == nil) {
+			return a.ExpiresAt != nil
+		}
+		if a.ExpiresAt != nil && *a.ExpiresAt != *b.ExpiresAt {
+			return *a.ExpiresAt < *b.ExpiresAt
+		}
+		return a.Hostname < b.Hostname
+	})
+
+	return preview, nil
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/clock"
+	"paddockcontrol-desktop/internal/db/sqlc"
+)
+
+func TestPreviewStatusAt(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	svc.SetClock(clock.NewFake(now))
+
+	certs := []sqlc.CreateCertificateParams{
+		{Hostname: "soon.example.com", ExpiresAt: sql.NullInt64{Int64: now.AddDate(0, 0, 40).Unix(), Valid: true}},
+		{Hostname: "renewing.example.com", ExpiresAt: sql.NullInt64{Int64: now.AddDate(0, 0, 50).Unix(), Valid: true},
+			PendingCsrPem: sql.NullString{String: "csr", Valid: true}},
+		{Hostname: "later.example.com", ExpiresAt: sql.NullInt64{Int64: now.AddDate(1, 0, 0).Unix(), Valid: true}},
+		{Hostname: "gone.example.com", ExpiresAt: sql.NullInt64{Int64: now.AddDate(0, 0, 10).Unix(), Valid: true}},
+	}
+	for _, params := range certs {
+		if params.ExpiresAt.Valid {
+			params.CertificatePem = sql.NullString{String: "pem", Valid: true}
+		}
+		if err := database.Queries().CreateCertificate(ctx, params); err != nil {
+			t.Fatalf("failed to create %s: %v", params.Hostname, err)
+		}
+	}
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:      "pending.example.com",
+		PendingCsrPem: sql.NullString{String: "csr", Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create pending certificate: %v", err)
+	}
+
+	preview, err := svc.PreviewStatusAt(ctx, now.AddDate(0, 0, 30))
+	if err != nil {
+		t.Fatalf("PreviewStatusAt failed: %v", err)
+	}
+
+	if preview.Active != 1 || preview.Expiring != 2 || preview.Expired != 1 || preview.Pending != 1 {
+		t.Errorf("counts = active %d, expiring %d, expired %d, pending %d; want 1, 2, 1, 1",
+			preview.Active, preview.Expiring, preview.Expired, preview.Pending)
+	}
+	if preview.NeedsRenewal != 2 {
+		t.Errorf("NeedsRenewal = %d, want 2", preview.NeedsRenewal)
+	}
+
+	wantOrder := []string{"gone.example.com", "soon.example.com", "renewing.example.com", "later.example.com", "pending.example.com"}
+	if len(preview.Certificates) != len(wantOrder) {
+		t.Fatalf("expected %d entries, got %d", len(wantOrder), len(preview.Certificates))
+	}
+	for i, hostname := range wantOrder {
+		if preview.Certificates[i].Hostname != hostname {
+			t.Errorf("entry %d = %s, want %s", i, preview.Certificates[i].Hostname, hostname)
+		}
+	}
+
+	gone := preview.Certificates[0]
+	if gone.CurrentStatus != "expiring" || gone.Status != "expired" || !gone.Changed || !gone.NeedsRenewal {
+		t.Errorf("unexpected entry for gone.example.com: %+v", gone)
+	}
+	soon := preview.Certificates[1]
+	if soon.CurrentStatus != "active" || soon.Status != "expiring" || soon.DaysUntilExpiration != 10 {
+		t.Errorf("unexpected entry for soon.example.com: %+v", soon)
+	}
+	renewing := preview.Certificates[2]
+	if renewing.Status != "expiring" || renewing.NeedsRenewal {
+		t.Errorf("a certificate with a pending CSR should not need renewal: %+v", renewing)
+	}
+	if later := preview.Certificates[3]; later.Changed {
+		t.Errorf("later.example.com should be unchanged: %+v", later)
+	}
+}
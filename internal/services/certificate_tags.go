@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// maxTagLength bounds a single certificate tag
+const maxTagLength = 64
+
+// tagPattern is what a normalized tag may look like: lowercase letters,
+// digits and a few separators, starting with a letter or digit
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._:-]*$`)
+
+// NormalizeTag lowercases and trims a tag and checks it is well formed
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return "", fmt.Errorf("tag is required")
+	}
+	if len(tag) > maxTagLength {
+		return "", fmt.Errorf("tag must not exceed %d characters", maxTagLength)
+	}
+	if !tagPattern.MatchString(tag) {
+		return "", fmt.Errorf("invalid tag %q: use lowercase letters, digits, '.', '_', ':' or '-'", tag)
+	}
+	return tag, nil
+}
+
+// ListCertificateTags returns the tags of a certificate, sorted
+func (s *CertificateService) ListCertificateTags(ctx context.Context, hostname string) ([]string, error) {
+	tags, err := s.db.Queries().ListCertificateTags(ctx, hostname)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags of %s: %w", hostname, err)
+	}
+	if tags == nil {
+		tags = []string{}
+	}
+	return tags, nil
+}
+
+// AddCertificateTag tags a certificate. Adding a tag the certificate already
+// has is a no-op.
+func (s *CertificateService) AddCertificateTag(ctx context.Context, hostname, tag string) error {
+	tag, err := NormalizeTag(tag)
+	if err != nil {
+		return err
+	}
+
+	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		if _, err := q.GetCertificateByHostname(ctx, hostname); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("certificate not found: %s", hostname)
+			}
+			return fmt.Errorf("failed to get certificate: %w", err)
+		}
+		return q.AddCertificateTag(ctx, sqlc.AddCertificateTagParams{
+			Hostname: hostname,
+			Tag:      tag,
+		})
+	})
+}
+
+// RemoveCertificateTag removes a tag from a certificate
+func (s *CertificateService) RemoveCertificateTag(ctx context.Context, hostname, tag string) error {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	deleted, err := s.db.Queries().RemoveCertificateTag(ctx, sqlc.RemoveCertificateTagParams{
+		Hostname: hostname,
+		Tag:      tag,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove tag: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("certificate %s has no tag %q", hostname, tag)
+	}
+	return nil
+}
+
+// ListTags returns every tag in use with the number of certificates carrying it
+func (s *CertificateService) ListTags(ctx context.Context) ([]models.TagCount, error) {
+	rows, err := s.db.Queries().ListTagCounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	counts := make([]models.TagCount, 0, len(rows))
+	for _, row := range rows {
+		counts = append(counts, models.TagCount{Tag: row.Tag, Certificates: int(row.Certificates)})
+	}
+	return counts, nil
+}
+
+// certificateTagsByHostname loads the tags of every certificate in one query
+func certificateTagsByHostname(ctx context.Context, q *sqlc.Queries) (map[string][]string, error) {
+	rows, err := q.ListAllCertificateTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificate tags: %w", err)
+	}
+
+	tags := make(map[string][]string)
+	for _, row := range rows {
+		tags[row.Hostname] = append(tags[row.Hostname], row.Tag)
+	}
+	return tags, nil
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"paddockcontrol-desktop/internal/models"
+)
+
+func TestCertificateTags_FilterList(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	createRelationTestCertificates(t, database.Queries(), "web.example.com", "db.example.com", "spare.example.com")
+
+	for _, tag := range [][2]string{
+		{"web.example.com", "Production"},
+		{"web.example.com", " dmz "},
+		{"db.example.com", "production"},
+		// Adding a tag again is a no-op
+		{"db.example.com", "production"},
+	} {
+		if err := svc.AddCertificateTag(ctx, tag[0], tag[1]); err != nil {
+			t.Fatalf("AddCertificateTag(%q, %q) failed: %v", tag[0], tag[1], err)
+		}
+	}
+
+	tags, err := svc.ListCertificateTags(ctx, "web.example.com")
+	if err != nil {
+		t.Fatalf("ListCertificateTags failed: %v", err)
+	}
+	if strings.Join(tags, ",") != "dmz,production" {
+		t.Errorf("expected normalized sorted tags, got %v", tags)
+	}
+
+	hostnamesOf := func(filter models.CertificateFilter) string {
+		t.Helper()
+		filter.SortBy = "hostname"
+		filter.SortOrder = "asc"
+		items, err := svc.ListCertificates(ctx, filter)
+		if err != nil {
+			t.Fatalf("ListCertificates failed: %v", err)
+		}
+		var names []string
+		for _, item := range items {
+			names = append(names, item.Hostname)
+		}
+		return strings.Join(names, ",")
+	}
+	if got := hostnamesOf(models.CertificateFilter{Tags: []string{"production"}}); got != "db.example.com,web.example.com" {
+		t.Errorf("filter production: got %q", got)
+	}
+	if got := hostnamesOf(models.CertificateFilter{Tags: []string{"PRODUCTION", "dmz"}}); got != "web.example.com" {
+		t.Errorf("filter production+dmz: got %q", got)
+	}
+	if got := hostnamesOf(models.CertificateFilter{}); got != "db.example.com,spare.example.com,web.example.com" {
+		t.Errorf("no tag filter: got %q", got)
+	}
+
+	counts, err := svc.ListTags(ctx)
+	if err != nil {
+		t.Fatalf("ListTags failed: %v", err)
+	}
+	if len(counts) != 2 || counts[0] != (models.TagCount{Tag: "dmz", Certificates: 1}) || counts[1] != (models.TagCount{Tag: "production", Certificates: 2}) {
+		t.Errorf("unexpected tag counts: %+v", counts)
+	}
+
+	if err := svc.RemoveCertificateTag(ctx, "web.example.com", "dmz"); err != nil {
+		t.Fatalf("RemoveCertificateTag failed: %v", err)
+	}
+	if err := svc.RemoveCertificateTag(ctx, "web.example.com", "dmz"); err == nil {
+		t.Error("expected an error when removing a tag twice")
+	}
+	cert, err := svc.GetCertificate(ctx, "web.example.com")
+	if err != nil {
+		t.Fatalf("GetCertificate failed: %v", err)
+	}
+	if strings.Join(cert.Tags, ",") != "production" {
+		t.Errorf("expected GetCertificate to return the remaining tag, got %v", cert.Tags)
+	}
+}
+
+func TestAddCertificateTag_Rejects(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	createRelationTestCertificates(t, database.Queries(), "web.example.com")
+
+	for name, args := range map[string][2]string{
+		"unknown certificate": {"missing.example.com", "production"},
+		"empty tag":           {"web.example.com", "  "},
+		"long tag":            {"web.example.com", strings.Repeat("x", maxTagLength+1)},
+		"space in tag":        {"web.example.com", "team infra"},
+		"leading separator":   {"web.example.com", "-prod"},
+	} {
+		if err := svc.AddCertificateTag(ctx, args[0], args[1]); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestMergeHostnameDuplicates_MovesTags(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	createRelationTestCertificates(t, database.Queries(), "Web.example.com")
+
+	if err := svc.AddCertificateTag(ctx, "Web.example.com", "dmz"); err != nil {
+		t.Fatalf("AddCertificateTag failed: %v", err)
+	}
+	if _, err := svc.MergeHostnameDuplicates(ctx, "Web.example.com"); err != nil {
+		t.Fatalf("MergeHostnameDuplicates failed: %v", err)
+	}
+
+	tags, err := svc.ListCertificateTags(ctx, "web.example.com")
+	if err != nil {
+		t.Fatalf("ListCertificateTags failed: %v", err)
+	}
+	if len(tags) != 1 || tags[0] != "dmz" {
+		t.Errorf("expected the tag to follow the renamed certificate, got %v", tags)
+	}
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/hostnames"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// defaultTrashRetentionDays is used when the configuration cannot be read
+const defaultTrashRetentionDays = 30
+
+// trashRetentionDays returns how many days deleted certificates stay in the
+// trash; 0 means they are deleted permanently
+func (s *CertificateService) trashRetentionDays(ctx context.Context) int {
+	cfg, err := s.config.GetConfig(ctx)
+	if err != nil || cfg == nil || cfg.TrashRetentionDays < 0 {
+		return defaultTrashRetentionDays
+	}
+	return int(cfg.TrashRetentionDays)
+}
+
+// ListDeletedCertificates lists the certificates in the trash, most recently
+// deleted first, with the time the automatic purge deletes each of them
+func (s *CertificateService) ListDeletedCertificates(ctx context.Context) ([]*models.DeletedCertificate, error) {
+	rows, err := s.db.Queries().ListDeletedCertificates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deleted certificates: %w", err)
+	}
+
+	retention := s.trashRetentionDays(ctx)
+	deleted := make([]*models.DeletedCertificate, 0, len(rows))
+	for _, row := range rows {
+		deleted = append(deleted, &models.DeletedCertificate{
+			Hostname:        row.Hostname,
+			DisplayHostname: hostnames.ToUnicode(row.Hostname),
+			ExpiresAt:       nullInt64Ptr(row.ExpiresAt),
+			Note:            row.Note.String,
+			HasPrivateKey:   row.HasPrivateKey == 1,
+			DeletedAt:       row.DeletedAt.Int64,
+			PurgeAt:         time.Unix(row.DeletedAt.Int64, 0).AddDate(0, 0, retention).Unix(),
+		})
+	}
+	return deleted, nil
+}
+
+// RestoreDeletedCertificate takes a certificate out of the trash, with the
+// history, tags and keys it had when it was deleted
+func (s *CertificateService) RestoreDeletedCertificate(ctx context.Context, hostname string) error {
+	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		restored, err := q.RestoreDeletedCertificate(ctx, hostname)
+		if err != nil {
+			return fmt.Errorf("failed to restore certificate: %w", err)
+		}
+		if restored == 0 {
+			return fmt.Errorf("certificate not found in the trash: %s", hostname)
+		}
+		return s.history.LogEventTx(ctx, q, hostname, models.EventCertificateRestored, "Certificate restored from the trash")
+	})
+}
+
+// PurgeDeletedCertificate permanently deletes a certificate in the trash. Its
+// history rows are removed via ON DELETE CASCADE.
+func (s *CertificateService) PurgeDeletedCertificate(ctx context.Context, hostname string) error {
+	purged, err := s.db.Queries().PurgeDeletedCertificate(ctx, hostname)
+	if err != nil {
+		return fmt.Errorf("failed to purge certificate: %w", err)
+	}
+	if purged == 0 {
+		return fmt.Errorf("certificate not found in the trash: %s", hostname)
+	}
+	return nil
+}
+
+// PurgeExpiredTrash permanently deletes the certificates that have been in
+// the trash longer than the configured retention and returns how many were
+// deleted. With a retention of 0 days the whole trash is emptied.
+func (s *CertificateService) PurgeExpiredTrash(ctx context.Context) (int, error) {
+	cutoff := s.clock.Now().AddDate(0, 0, -s.trashRetentionDays(ctx)).Unix()
+	purged, err := s.db.Queries().PurgeExpiredTrash(ctx, sql.NullInt64{Int64: cutoff, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge the trash: %w", err)
+	}
+	return int(purged), nil
+}
+
+// checkNotInTrash returns an error naming the trash when the certificate
+// holding hostname is in it, so creating a certificate over it is refused
+// with a clearer message than "already exists"
+func checkNotInTrash(ctx context.Context, q *sqlc.Queries, hostname string) error {
+	trashed, err := q.CertificateInTrash(ctx, hostname)
+	if err != nil {
+		return fmt.Errorf("failed to check the trash: %w", err)
+	}
+	if trashed == 1 {
+		return fmt.Errorf("certificate for %s is in the trash; restore or purge it first", hostname)
+	}
+	return nil
+}
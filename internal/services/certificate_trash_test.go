@@ -0,0 +1,128 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/clock"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+func TestDeleteCertificate_MovesToTrash(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(now)
+	svc.SetClock(fake)
+	createBulkTestCertificates(t, svc, map[string]string{"trash.example.com": "web frontend"})
+
+	if err := svc.DeleteCertificate(ctx, "trash.example.com"); err != nil {
+		t.Fatalf("DeleteCertificate() error = %v", err)
+	}
+	if _, err := svc.GetCertificate(ctx, "trash.example.com"); err == nil {
+		t.Error("a certificate in the trash should not be found")
+	}
+
+	deleted, err := svc.ListDeletedCertificates(ctx)
+	if err != nil {
+		t.Fatalf("ListDeletedCertificates() error = %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].Hostname != "trash.example.com" || deleted[0].Note != "web frontend" {
+		t.Fatalf("trash = %+v, want trash.example.com", deleted)
+	}
+	if deleted[0].DeletedAt != now.Unix() || deleted[0].PurgeAt != now.AddDate(0, 0, 30).Unix() {
+		t.Errorf("deleted_at = %d, purge_at = %d, want a 30 day retention from %d", deleted[0].DeletedAt, deleted[0].PurgeAt, now.Unix())
+	}
+
+	// The hostname stays taken until the certificate is purged
+	if err := checkNotInTrash(ctx, database.Queries(), "trash.example.com"); err == nil || !strings.Contains(err.Error(), "in the trash") {
+		t.Errorf("err = %v, want the hostname reported in the trash", err)
+	}
+
+	if err := svc.RestoreDeletedCertificate(ctx, "trash.example.com"); err != nil {
+		t.Fatalf("RestoreDeletedCertificate() error = %v", err)
+	}
+	cert, err := svc.GetCertificate(ctx, "trash.example.com")
+	if err != nil {
+		t.Fatalf("GetCertificate() after restore error = %v", err)
+	}
+	if cert.Note != "web frontend" {
+		t.Errorf("note = %q, want it kept through the trash", cert.Note)
+	}
+	history, err := database.Queries().GetCertificateHistory(ctx, sqlc.GetCertificateHistoryParams{Hostname: "trash.example.com", Limit: 10})
+	if err != nil {
+		t.Fatalf("failed to get history: %v", err)
+	}
+	if len(history) != 2 || history[0].EventType != models.EventCertificateDeleted || history[1].EventType != models.EventCertificateRestored {
+		t.Errorf("history = %+v, want the deletion and the restore", history)
+	}
+	if err := svc.RestoreDeletedCertificate(ctx, "trash.example.com"); err == nil {
+		t.Error("expected an error restoring a certificate that is not in the trash")
+	}
+}
+
+func TestPurgeTrash(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(now)
+	svc.SetClock(fake)
+	createBulkTestCertificates(t, svc, map[string]string{"old.example.com": "", "recent.example.com": "", "manual.example.com": ""})
+
+	if err := svc.DeleteCertificate(ctx, "old.example.com"); err != nil {
+		t.Fatalf("DeleteCertificate() error = %v", err)
+	}
+	fake.Advance(20 * 24 * time.Hour)
+	for _, hostname := range []string{"recent.example.com", "manual.example.com"} {
+		if err := svc.DeleteCertificate(ctx, hostname); err != nil {
+			t.Fatalf("DeleteCertificate(%s) error = %v", hostname, err)
+		}
+	}
+
+	if err := svc.PurgeDeletedCertificate(ctx, "manual.example.com"); err != nil {
+		t.Fatalf("PurgeDeletedCertificate() error = %v", err)
+	}
+	if exists, _ := database.Queries().CertificateExists(ctx, "manual.example.com"); exists != 0 {
+		t.Error("a purged certificate should free its hostname")
+	}
+	if err := svc.PurgeDeletedCertificate(ctx, "manual.example.com"); err == nil {
+		t.Error("expected an error purging a certificate that is not in the trash")
+	}
+
+	// 30 days after the first deletion only that certificate is purged
+	fake.Advance(10 * 24 * time.Hour)
+	purged, err := svc.PurgeExpiredTrash(ctx)
+	if err != nil {
+		t.Fatalf("PurgeExpiredTrash() error = %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("purged = %d, want 1", purged)
+	}
+	deleted, err := svc.ListDeletedCertificates(ctx)
+	if err != nil {
+		t.Fatalf("ListDeletedCertificates() error = %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].Hostname != "recent.example.com" {
+		t.Errorf("trash = %+v, want only recent.example.com", deleted)
+	}
+
+	// Without a retention period deletions are permanent and the trash is emptied
+	if _, err := database.DB().Exec("UPDATE config SET trash_retention_days = 0"); err != nil {
+		t.Fatalf("failed to disable the trash: %v", err)
+	}
+	createBulkTestCertificates(t, svc, map[string]string{"gone.example.com": ""})
+	if err := svc.DeleteCertificate(ctx, "gone.example.com"); err != nil {
+		t.Fatalf("DeleteCertificate() error = %v", err)
+	}
+	if exists, _ := database.Queries().CertificateExists(ctx, "gone.example.com"); exists != 0 {
+		t.Error("with no retention, a deleted certificate should not be kept")
+	}
+	if purged, err := svc.PurgeExpiredTrash(ctx); err != nil || purged != 1 {
+		t.Errorf("PurgeExpiredTrash() = %d, %v, want the rest of the trash purged", purged, err)
+	}
+}
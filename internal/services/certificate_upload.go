@@ -2,19 +2,25 @@ package services
 
 import (
 	"context"
+	"crypto/x509"
 	"database/sql"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
 	"paddockcontrol-desktop/internal/crypto"
 	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/hostnames"
 	"paddockcontrol-desktop/internal/logger"
 	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/subject"
 )
 
-// UploadCertificate uploads and activates a signed certificate
-func (s *CertificateService) UploadCertificate(ctx context.Context, hostname, certPEM string, encryptionKey []byte) error {
+// UploadCertificate uploads and activates a signed certificate. The result
+// warns about SANs the CA dropped, a missing chain and the validity window.
+func (s *CertificateService) UploadCertificate(ctx context.Context, hostname, certPEM string, encryptionKey []byte) (*models.CertificateResult, error) {
 	log := logger.WithComponent("certificate")
 	log = logger.WithHostname(log, hostname)
 	log.Info("starting certificate upload")
@@ -22,78 +28,115 @@ func (s *CertificateService) UploadCertificate(ctx context.Context, hostname, ce
 	// Get pending certificate
 	cert, err := s.db.Queries().GetCertificateByHostname(ctx, hostname)
 	if err != nil {
-		return fmt.Errorf("failed to get certificate: %w", err)
+		return nil, fmt.Errorf("failed to get certificate: %w", err)
 	}
 
 	// Verify has pending CSR
 	if !cert.PendingCsrPem.Valid || cert.PendingCsrPem.String == "" {
-		return fmt.Errorf("no pending CSR for hostname: %s", hostname)
+		return nil, fmt.Errorf("no pending CSR for hostname: %s", hostname)
 	}
 
 	// Guard against invalid state: pending private key must exist to avoid destroying the active key
 	if len(cert.PendingEncryptedPrivateKey) == 0 {
-		return fmt.Errorf("cannot activate certificate: pending private key is missing for hostname: %s", hostname)
+		return nil, fmt.Errorf("cannot activate certificate: pending private key is missing for hostname: %s", hostname)
 	}
 
-	// Parse certificate and CSR to validate match
-	parsedCert, err := crypto.ParseCertificate([]byte(certPEM))
+	// Parse certificate and CSR to validate match. CA responses often bundle the
+	// intermediates with the leaf; they are kept as the stored chain.
+	parsedCert, chain, err := crypto.SplitCertificateBundle([]byte(certPEM))
 	if err != nil {
-		return fmt.Errorf("invalid certificate: %w", err)
+		return nil, fmt.Errorf("invalid certificate: %w", err)
 	}
+	leafPEM, chainPEM := bundlePEMs(parsedCert, chain)
 
 	parsedCSR, err := crypto.ParseCSR([]byte(cert.PendingCsrPem.String))
 	if err != nil {
-		return fmt.Errorf("invalid pending CSR: %w", err)
+		return nil, fmt.Errorf("invalid pending CSR: %w", err)
 	}
 
 	// Validate certificate matches CSR
 	if err := crypto.ValidateCSRMatch(parsedCSR, parsedCert); err != nil {
 		log.Warn("CSR match validation failed", logger.Err(err))
-		return fmt.Errorf("certificate does not match CSR: %w", err)
+		return nil, fmt.Errorf("certificate does not match CSR: %w", err)
 	}
 	log.Info("CSR match validated")
 
+	if err := s.checkFIPSBundle(ctx, parsedCert, chain); err != nil {
+		log.Warn("certificate rejected by FIPS mode", logger.Err(err))
+		return nil, err
+	}
+
 	// Validate certificate matches pending private key
 	decryptedKeyPEM, err := crypto.DecryptPrivateKey(cert.PendingEncryptedPrivateKey, encryptionKey)
 	if err != nil {
-		return fmt.Errorf("failed to decrypt pending private key: %w", err)
+		return nil, fmt.Errorf("failed to decrypt pending private key: %w", err)
 	}
-	privateKey, err := crypto.ParsePrivateKeyFromPEM(decryptedKeyPEM)
+	privateKey, err := crypto.ParsePrivateKeyFromPEM(decryptedKeyPEM.Bytes())
+	decryptedKeyPEM.Destroy()
 	if err != nil {
-		return fmt.Errorf("failed to parse pending private key: %w", err)
+		return nil, fmt.Errorf("failed to parse pending private key: %w", err)
 	}
 	certPubKey, err := crypto.ExtractPublicKey(parsedCert)
 	if err != nil {
-		return fmt.Errorf("failed to extract certificate public key: %w", err)
+		return nil, fmt.Errorf("failed to extract certificate public key: %w", err)
 	}
-	if !crypto.ComparePublicKeys(certPubKey, &privateKey.PublicKey) {
+	if !crypto.ComparePublicKeys(certPubKey, privateKey.Public()) {
 		log.Warn("certificate public key does not match pending private key")
-		return fmt.Errorf("certificate public key does not match pending private key")
+		return nil, fmt.Errorf("certificate public key does not match pending private key")
 	}
 	log.Info("key match validated")
 
+	result := &models.CertificateResult{Hostname: hostname, Warnings: s.certificateWarnings(ctx, parsedCert, chain)}
+	if dropped := droppedSANs(parsedCSR, parsedCert); len(dropped) > 0 {
+		log.Warn("certificate lacks SANs requested in the CSR", slog.Int("count", len(dropped)))
+		result.Warn("The CA dropped SANs requested in the CSR: %s", strings.Join(dropped, ", "))
+	}
+
 	// Extract expiration date
 	expiresAt := parsedCert.NotAfter.Unix()
 
 	// Activate the certificate and record the history event atomically.
-	log.Info("activating certificate", slog.Int64("expires_at", expiresAt))
+	log.Info("activating certificate", slog.Int64("expires_at", expiresAt), slog.Int("chain_certificates", len(chain)))
 	expiresDate := time.Unix(expiresAt, 0).Format("2006-01-02")
 	message := fmt.Sprintf("Certificate uploaded (expires %s)", expiresDate)
+	details := map[string]any{"new_expires_at": expiresAt}
+	if issuer := issuerName(parsedCert.Issuer.CommonName, parsedCert.Issuer.Organization); issuer != "" {
+		// Groups the renewal lead times by CA
+		details["issuer_cn"] = issuer
+	}
+	if cert.ExpiresAt.Valid {
+		details["old_expires_at"] = cert.ExpiresAt.Int64
+	}
+	finish, err := s.beginIntent(ctx, models.IntentUploadCertificate, hostname, uploadIntent{
+		CertificateSHA256: pemSHA256(leafPEM),
+		ExpiresAt:         expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer finish()
 	if err = s.db.WithTx(ctx, func(q *sqlc.Queries) error {
 		if err := q.ActivateCertificate(ctx, sqlc.ActivateCertificateParams{
 			Hostname:       hostname,
-			CertificatePem: sql.NullString{String: certPEM, Valid: true},
+			CertificatePem: sql.NullString{String: leafPEM, Valid: true},
+			ChainPem:       chainPEM,
 			ExpiresAt:      sql.NullInt64{Int64: expiresAt, Valid: true},
 		}); err != nil {
 			return fmt.Errorf("failed to activate certificate: %w", err)
 		}
-		return s.history.LogEventTx(ctx, q, hostname, models.EventCertificateUploaded, message)
+		if err := s.completeRenewalStepsTx(ctx, q, hostname, models.RenewalStepCertReceived, models.RenewalStepUploaded); err != nil {
+			return err
+		}
+		if err := RefreshKeyStatusTx(ctx, q, hostname, encryptionKey); err != nil {
+			return err
+		}
+		return s.history.LogEventDetailsTx(ctx, q, hostname, models.EventCertificateUploaded, message, details)
 	}); err != nil {
-		return err
+		return nil, err
 	}
 
 	log.Info("certificate uploaded successfully", slog.String("expires", expiresDate))
-	return nil
+	return result, nil
 }
 
 // PreviewCertificateUpload validates and returns metadata about a signed certificate without storing it
@@ -113,8 +156,8 @@ func (s *CertificateService) PreviewCertificateUpload(ctx context.Context, hostn
 		return nil, fmt.Errorf("no pending CSR for hostname: %s", hostname)
 	}
 
-	// Parse certificate
-	parsedCert, err := crypto.ParseCertificate([]byte(certPEM))
+	// Parse certificate (and any issuer certificates bundled with it)
+	parsedCert, chain, err := crypto.SplitCertificateBundle([]byte(certPEM))
 	if err != nil {
 		return nil, fmt.Errorf("invalid certificate: %w", err)
 	}
@@ -133,11 +176,12 @@ func (s *CertificateService) PreviewCertificateUpload(ctx context.Context, hostn
 	if len(cert.PendingEncryptedPrivateKey) > 0 {
 		decryptedKeyPEM, err := crypto.DecryptPrivateKey(cert.PendingEncryptedPrivateKey, encryptionKey)
 		if err == nil {
-			privateKey, err := crypto.ParsePrivateKeyFromPEM(decryptedKeyPEM)
+			privateKey, err := crypto.ParsePrivateKeyFromPEM(decryptedKeyPEM.Bytes())
+			decryptedKeyPEM.Destroy()
 			if err == nil {
 				certPubKey, err := crypto.ExtractPublicKey(parsedCert)
 				if err == nil {
-					keyMatch = crypto.ComparePublicKeys(certPubKey, &privateKey.PublicKey)
+					keyMatch = crypto.ComparePublicKeys(certPubKey, privateKey.Public())
 				}
 			}
 		}
@@ -155,13 +199,14 @@ func (s *CertificateService) PreviewCertificateUpload(ctx context.Context, hostn
 	}
 
 	preview := &models.CertificateUploadPreview{
-		Hostname:  details.Hostname,
-		NotBefore: parsedCert.NotBefore.Unix(),
-		NotAfter:  parsedCert.NotAfter.Unix(),
-		SANs:      details.SANs,
-		KeySize:   details.KeySize,
-		CSRMatch:  csrMatch,
-		KeyMatch:  keyMatch,
+		Hostname:   details.Hostname,
+		NotBefore:  parsedCert.NotBefore.Unix(),
+		NotAfter:   parsedCert.NotAfter.Unix(),
+		SANs:       details.SANs,
+		KeySize:    details.KeySize,
+		CSRMatch:   csrMatch,
+		KeyMatch:   keyMatch,
+		ChainCount: len(chain),
 	}
 
 	// Extract issuer info
@@ -175,51 +220,77 @@ func (s *CertificateService) PreviewCertificateUpload(ctx context.Context, hostn
 	return preview, nil
 }
 
-// ImportCertificate imports a certificate with its private key
-func (s *CertificateService) ImportCertificate(ctx context.Context, req models.ImportRequest, encryptionKey []byte) error {
+// ImportCertificate imports a certificate with its private key. The result
+// warns about a missing chain, the validity window and a common name stored
+// in normalized form.
+func (s *CertificateService) ImportCertificate(ctx context.Context, req models.ImportRequest, encryptionKey []byte) (*models.CertificateResult, error) {
+	// Parse certificate to extract metadata, keeping any bundled issuers as the chain
+	parsedCert, chain, err := crypto.SplitCertificateBundle([]byte(req.CertificatePEM))
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate: %w", err)
+	}
+	leafPEM, chainPEM := bundlePEMs(parsedCert, chain)
+
+	if err := checkNoteForSecrets(req.Note); err != nil {
+		return nil, err
+	}
+
 	// Validate cert and key match
-	if err := crypto.ValidateCertificateAndKey(req.CertificatePEM, req.PrivateKeyPEM); err != nil {
-		return fmt.Errorf("certificate and key validation failed: %w", err)
+	if err := crypto.ValidateCertificateAndKey(leafPEM, req.PrivateKeyPEM); err != nil {
+		return nil, fmt.Errorf("certificate and key validation failed: %w", err)
 	}
 
-	// Parse certificate to extract metadata
-	parsedCert, err := crypto.ParseCertificate([]byte(req.CertificatePEM))
-	if err != nil {
-		return fmt.Errorf("invalid certificate: %w", err)
+	if err := s.checkFIPSBundle(ctx, parsedCert, chain); err != nil {
+		return nil, err
 	}
 
 	// Extract hostname from certificate CN
-	hostname := parsedCert.Subject.CommonName
-	if hostname == "" {
-		return fmt.Errorf("certificate has no common name")
+	if parsedCert.Subject.CommonName == "" {
+		return nil, fmt.Errorf("certificate has no common name")
+	}
+	hostname, err := hostnames.Normalize(parsedCert.Subject.CommonName)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate common name: %w", err)
+	}
+
+	if err := subject.ValidateName(parsedCert.Subject); err != nil {
+		return nil, fmt.Errorf("invalid certificate subject: %w", err)
 	}
 
 	// Parse private key
 	privateKey, err := crypto.ParsePrivateKeyFromPEM([]byte(req.PrivateKeyPEM))
 	if err != nil {
-		return fmt.Errorf("invalid private key: %w", err)
+		return nil, fmt.Errorf("invalid private key: %w", err)
 	}
 
 	// Convert to PEM if needed
 	keyPEM, err := crypto.PrivateKeyToPEM(privateKey)
 	if err != nil {
-		return fmt.Errorf("failed to encode private key: %w", err)
+		return nil, fmt.Errorf("failed to encode private key: %w", err)
 	}
 
 	// Encrypt private key
 	encryptedKey, err := crypto.EncryptPrivateKey(keyPEM, encryptionKey)
 	crypto.Zero(keyPEM)
 	if err != nil {
-		return fmt.Errorf("failed to encrypt private key: %w", err)
+		return nil, fmt.Errorf("failed to encrypt private key: %w", err)
 	}
 
 	// Check for duplicates
 	exists, err := s.db.Queries().CertificateExists(ctx, hostname)
 	if err != nil {
-		return fmt.Errorf("failed to check certificate existence: %w", err)
+		return nil, fmt.Errorf("failed to check certificate existence: %w", err)
 	}
 	if exists == 1 {
-		return fmt.Errorf("certificate already exists for hostname: %s", hostname)
+		if err := checkNotInTrash(ctx, s.db.Queries(), hostname); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("certificate already exists for hostname: %s", hostname)
+	}
+
+	result := &models.CertificateResult{Hostname: hostname, Warnings: s.certificateWarnings(ctx, parsedCert, chain)}
+	if hostname != parsedCert.Subject.CommonName {
+		result.Warn("Common name %s is stored as %s", parsedCert.Subject.CommonName, hostname)
 	}
 
 	// Extract expiration date
@@ -228,17 +299,84 @@ func (s *CertificateService) ImportCertificate(ctx context.Context, req models.I
 	// Store the certificate and record the history event atomically.
 	expiresDate := time.Unix(expiresAt, 0).Format("2006-01-02")
 	message := fmt.Sprintf("Certificate imported (expires %s)", expiresDate)
-	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+	if err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
 		if err := q.CreateCertificate(ctx, sqlc.CreateCertificateParams{
 			Hostname:            hostname,
 			EncryptedPrivateKey: encryptedKey,
-			CertificatePem:      sql.NullString{String: req.CertificatePEM, Valid: true},
+			CertificatePem:      sql.NullString{String: leafPEM, Valid: true},
+			ChainPem:            chainPEM,
 			ExpiresAt:           sql.NullInt64{Int64: expiresAt, Valid: true},
 			Note:                sql.NullString{String: req.Note, Valid: req.Note != ""},
 			ReadOnly:            0,
 		}); err != nil {
 			return fmt.Errorf("failed to import certificate: %w", err)
 		}
+		if err := RefreshKeyStatusTx(ctx, q, hostname, encryptionKey); err != nil {
+			return err
+		}
 		return s.history.LogEventTx(ctx, q, hostname, models.EventCertificateImported, message)
-	})
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// checkFIPSBundle rejects a certificate bundle outside the FIPS-approved subset
+// when FIPS mode is enabled
+func (s *CertificateService) checkFIPSBundle(ctx context.Context, leaf *x509.Certificate, chain []*x509.Certificate) error {
+	fips, err := s.fipsMode(ctx)
+	if err != nil {
+		return err
+	}
+	if !fips {
+		return nil
+	}
+	return crypto.CheckFIPSBundle(leaf, chain)
+}
+
+// bundlePEMs encodes the leaf and its issuer chain for storage. The chain is NULL
+// when the bundle carried no issuer certificates.
+func bundlePEMs(leaf *x509.Certificate, chain []*x509.Certificate) (string, sql.NullString) {
+	leafPEM := crypto.ConvertChainToPEM([]*x509.Certificate{leaf})[0]
+	if len(chain) == 0 {
+		return leafPEM, sql.NullString{}
+	}
+	return leafPEM, sql.NullString{String: strings.Join(crypto.ConvertChainToPEM(chain), ""), Valid: true}
+}
+
+// certificateWarnings describes the caveats of storing cert with the issuer
+// certificates bundled with it: a validity window that has not started or
+// ends within the expiring threshold, and no chain for a CA-issued leaf
+func (s *CertificateService) certificateWarnings(ctx context.Context, cert *x509.Certificate, chain []*x509.Certificate) models.Warnings {
+	warnings := models.NewWarnings()
+	now := s.clock.Now()
+	threshold := s.expiringThresholdDays(ctx)
+	switch {
+	case now.Before(cert.NotBefore):
+		warnings.Warn("Certificate is not valid before %s", cert.NotBefore.UTC().Format(time.DateOnly))
+	case !now.Before(cert.NotAfter):
+		warnings.Warn("Certificate expired on %s", cert.NotAfter.UTC().Format(time.DateOnly))
+	case cert.NotAfter.Before(now.AddDate(0, 0, threshold)):
+		warnings.Warn("Certificate expires on %s, within %d days", cert.NotAfter.UTC().Format(time.DateOnly), threshold)
+	}
+	if len(chain) == 0 && cert.Subject.String() != cert.Issuer.String() {
+		warnings.Warn("No issuer certificates were bundled; the chain will be fetched from the issuer when needed")
+	}
+	return warnings
+}
+
+// droppedSANs lists the SANs requested in csr that cert does not carry
+func droppedSANs(csr *x509.CertificateRequest, cert *x509.Certificate) []string {
+	var dropped []string
+	for _, name := range csr.DNSNames {
+		if !slices.ContainsFunc(cert.DNSNames, func(issued string) bool { return strings.EqualFold(issued, name) }) {
+			dropped = append(dropped, name)
+		}
+	}
+	for _, ip := range csr.IPAddresses {
+		if !slices.ContainsFunc(cert.IPAddresses, ip.Equal) {
+			dropped = append(dropped, ip.String())
+		}
+	}
+	return dropped
 }
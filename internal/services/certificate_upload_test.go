@@ -2,11 +2,17 @@ package services
 
 import (
 	"context"
+	"crypto/x509"
 	"database/sql"
+	"errors"
+	"net"
 	"testing"
+	"time"
 
+	"paddockcontrol-desktop/internal/clock"
 	"paddockcontrol-desktop/internal/crypto"
 	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
 	"paddockcontrol-desktop/internal/testutil"
 )
 
@@ -37,7 +43,7 @@ func TestUploadCertificate_NewCSR_PreservesPrivateKey(t *testing.T) {
 	}
 
 	// Upload the signed certificate
-	err = svc.UploadCertificate(ctx, hostname, certPEM, encryptionKey)
+	_, err = svc.UploadCertificate(ctx, hostname, certPEM, encryptionKey)
 	if err != nil {
 		t.Fatalf("UploadCertificate failed: %v", err)
 	}
@@ -57,7 +63,7 @@ func TestUploadCertificate_NewCSR_PreservesPrivateKey(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to decrypt active private key: %v", err)
 	}
-	if len(decrypted) == 0 {
+	if decrypted.Len() == 0 {
 		t.Fatal("decrypted private key is empty")
 	}
 
@@ -115,7 +121,7 @@ func TestUploadCertificate_Renewal_PreservesPrivateKey(t *testing.T) {
 	}
 
 	// Upload the signed certificate
-	err = svc.UploadCertificate(ctx, hostname, certPEM, encryptionKey)
+	_, err = svc.UploadCertificate(ctx, hostname, certPEM, encryptionKey)
 	if err != nil {
 		t.Fatalf("UploadCertificate failed: %v", err)
 	}
@@ -136,12 +142,12 @@ func TestUploadCertificate_Renewal_PreservesPrivateKey(t *testing.T) {
 		t.Fatalf("failed to decrypt active private key: %v", err)
 	}
 
-	parsedKey, err := crypto.ParsePrivateKeyFromPEM(decrypted)
+	parsedKey, err := crypto.ParsePrivateKeyFromPEM(decrypted.Bytes())
 	if err != nil {
 		t.Fatalf("failed to parse decrypted key: %v", err)
 	}
 
-	if !crypto.ComparePublicKeys(&parsedKey.PublicKey, &renewalKey.PublicKey) {
+	if !crypto.ComparePublicKeys(parsedKey.Public(), renewalKey.Public()) {
 		t.Error("active key should be the renewal key, not the original key")
 	}
 }
@@ -178,7 +184,7 @@ func TestUploadCertificate_MissingPendingKey_ReturnsError(t *testing.T) {
 	}
 
 	// Upload should fail with defensive error
-	err = svc.UploadCertificate(ctx, hostname, certPEM, encryptionKey)
+	_, err = svc.UploadCertificate(ctx, hostname, certPEM, encryptionKey)
 	if err == nil {
 		t.Fatal("expected error when pending private key is missing, got nil")
 	}
@@ -220,7 +226,7 @@ func TestUploadCertificate_NoCSR_ReturnsError(t *testing.T) {
 		t.Fatalf("failed to create certificate: %v", err)
 	}
 
-	err = svc.UploadCertificate(ctx, hostname, "-----BEGIN CERTIFICATE-----\nfake\n-----END CERTIFICATE-----", encryptionKey)
+	_, err = svc.UploadCertificate(ctx, hostname, "-----BEGIN CERTIFICATE-----\nfake\n-----END CERTIFICATE-----", encryptionKey)
 	if err == nil {
 		t.Fatal("expected error when no pending CSR exists, got nil")
 	}
@@ -273,7 +279,7 @@ func TestUploadCertificate_KeyMismatch_ReturnsError(t *testing.T) {
 	}
 
 	// Upload should fail because certificate doesn't match CSR
-	err = svc.UploadCertificate(ctx, hostname, certPEM, encryptionKey)
+	_, err = svc.UploadCertificate(ctx, hostname, certPEM, encryptionKey)
 	if err == nil {
 		t.Fatal("expected error when certificate key doesn't match, got nil")
 	}
@@ -386,3 +392,171 @@ func TestPreviewCertificateUpload_KeyMismatch(t *testing.T) {
 		t.Error("expected KeyMatch to be false for mismatched certificate")
 	}
 }
+
+func TestUploadCertificate_Bundle_StoresChain(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	hostname := "bundle.example.com"
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	csrPEM, encryptedKey, _ := generateTestCSRAndKey(t, hostname, encryptionKey)
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:                   hostname,
+		PendingEncryptedPrivateKey: encryptedKey,
+		PendingCsrPem:              sql.NullString{String: string(csrPEM), Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	root, rootKey := newTestCA(t, "Test Root", time.Now().Add(10*365*24*time.Hour), nil, nil)
+	intermediate, intermediateKey := newTestCA(t, "Test Intermediate", time.Now().Add(5*365*24*time.Hour), root, rootKey)
+	leafPEM := signCSRWithCA(t, csrPEM, intermediate, intermediateKey)
+	intermediatePEM := crypto.ConvertChainToPEM([]*x509.Certificate{intermediate})[0]
+	rootPEM := crypto.ConvertChainToPEM([]*x509.Certificate{root})[0]
+
+	// CA responses don't always put the leaf first
+	bundle := intermediatePEM + leafPEM + rootPEM
+
+	preview, err := svc.PreviewCertificateUpload(ctx, hostname, bundle, encryptionKey)
+	if err != nil {
+		t.Fatalf("PreviewCertificateUpload failed: %v", err)
+	}
+	if !preview.KeyMatch || !preview.CSRMatch {
+		t.Fatalf("expected bundle leaf to match CSR and key, got csr=%v key=%v", preview.CSRMatch, preview.KeyMatch)
+	}
+	if preview.ChainCount != 2 {
+		t.Errorf("expected chain count 2, got %d", preview.ChainCount)
+	}
+
+	result, err := svc.UploadCertificate(ctx, hostname, bundle, encryptionKey)
+	if err != nil {
+		t.Fatalf("UploadCertificate failed: %v", err)
+	}
+	if len(result.Warnings.Warnings) != 0 {
+		t.Errorf("expected no warnings for a complete bundle, got %v", result.Warnings.Warnings)
+	}
+
+	cert, err := database.Queries().GetCertificateByHostname(ctx, hostname)
+	if err != nil {
+		t.Fatalf("failed to get certificate: %v", err)
+	}
+	if cert.CertificatePem.String != leafPEM {
+		t.Error("expected only the leaf to be stored as the certificate")
+	}
+	if cert.ChainPem.String != intermediatePEM+rootPEM {
+		t.Error("expected intermediate then root to be stored as the chain")
+	}
+
+	// The stored chain is used without any AIA fetch (the test CAs have no AIA URLs)
+	chainResult, err := svc.GetCertificateChain(ctx, hostname)
+	if err != nil {
+		t.Fatalf("GetCertificateChain failed: %v", err)
+	}
+	if chainResult.Diagnostics != nil {
+		t.Errorf("expected no chain diagnostics, got %+v", chainResult.Diagnostics)
+	}
+	chainInfo := chainResult.Certificates
+	if len(chainInfo) != 3 {
+		t.Fatalf("expected 3 chain entries, got %d", len(chainInfo))
+	}
+	if chainInfo[1].CertType != "intermediate" || chainInfo[2].CertType != "root" {
+		t.Errorf("unexpected chain types: %s, %s", chainInfo[1].CertType, chainInfo[2].CertType)
+	}
+
+	download, err := svc.GetChainPEMForDownload(ctx, hostname, models.ChainVariantFull)
+	if err != nil {
+		t.Fatalf("GetChainPEMForDownload failed: %v", err)
+	}
+	if download != leafPEM+"\n"+intermediatePEM+"\n"+rootPEM {
+		t.Error("expected downloaded chain to be leaf, intermediate, root")
+	}
+}
+
+func TestUploadCertificate_FIPSMode_RejectsSmallKey(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	hostname := "test.example.com"
+	encryptionKey := testutil.RandomMasterKey(t)
+	if _, err := database.DB().Exec("UPDATE config SET fips_mode = 1"); err != nil {
+		t.Fatalf("failed to enable FIPS mode: %v", err)
+	}
+
+	// CSR generated before FIPS mode was turned on, with a 2048-bit key
+	csrPEM, encryptedKey, privateKey := generateTestCSRAndKey(t, hostname, encryptionKey)
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:                   hostname,
+		PendingEncryptedPrivateKey: encryptedKey,
+		PendingCsrPem:              sql.NullString{String: string(csrPEM), Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	certPEM, err := selfSignCertFromCSR(csrPEM, privateKey)
+	if err != nil {
+		t.Fatalf("failed to self-sign certificate: %v", err)
+	}
+
+	_, err = svc.UploadCertificate(ctx, hostname, certPEM, encryptionKey)
+	if !errors.Is(err, crypto.ErrFIPSViolation) {
+		t.Fatalf("expected ErrFIPSViolation, got %v", err)
+	}
+
+	cert, err := database.Queries().GetCertificateByHostname(ctx, hostname)
+	if err != nil {
+		t.Fatalf("failed to get certificate: %v", err)
+	}
+	if cert.CertificatePem.Valid {
+		t.Error("certificate should not be activated")
+	}
+}
+
+func TestUploadCertificate_Warnings(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	hostname := "warned.example.com"
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	csrPEM, encryptedKey, _ := generateTestCSRAndKey(t, hostname, encryptionKey)
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:                   hostname,
+		PendingEncryptedPrivateKey: encryptedKey,
+		PendingCsrPem:              sql.NullString{String: string(csrPEM), Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	// A CA-issued leaf uploaded without its issuer, inside the default
+	// 30-day expiring window once the clock moves 70 days ahead
+	root, rootKey := newTestCA(t, "Test Root", time.Now().Add(10*365*24*time.Hour), nil, nil)
+	leafPEM := signCSRWithCA(t, csrPEM, root, rootKey)
+	svc.SetClock(clock.NewFake(time.Now().Add(70 * 24 * time.Hour)))
+
+	result, err := svc.UploadCertificate(ctx, hostname, leafPEM, encryptionKey)
+	if err != nil {
+		t.Fatalf("UploadCertificate failed: %v", err)
+	}
+	if result.Hostname != hostname || len(result.Warnings.Warnings) != 2 {
+		t.Fatalf("expected 2 warnings, got %+v", result)
+	}
+	if !containsSubstring(result.Warnings.Warnings[0], "within 30 days") {
+		t.Errorf("expected an expiry warning, got %q", result.Warnings.Warnings[0])
+	}
+	if !containsSubstring(result.Warnings.Warnings[1], "No issuer certificates") {
+		t.Errorf("expected a missing chain warning, got %q", result.Warnings.Warnings[1])
+	}
+}
+
+func TestDroppedSANs(t *testing.T) {
+	csr := &x509.CertificateRequest{
+		DNSNames:    []string{"a.example.com", "b.example.com"},
+		IPAddresses: []net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2")},
+	}
+	cert := &x509.Certificate{
+		DNSNames:    []string{"A.example.com"},
+		IPAddresses: []net.IP{net.ParseIP("10.0.0.2")},
+	}
+	dropped := droppedSANs(csr, cert)
+	if len(dropped) != 2 || dropped[0] != "b.example.com" || dropped[1] != "10.0.0.1" {
+		t.Errorf("droppedSANs = %v, want b.example.com and 10.0.0.1", dropped)
+	}
+}
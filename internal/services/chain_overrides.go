@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"crypto/x509"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// ListChainOverrides returns the chain overrides of certificates and issuing CAs
+func (s *CertificateService) ListChainOverrides(ctx context.Context) ([]models.ChainOverride, error) {
+	rows, err := s.db.Queries().ListChainOverrides(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chain overrides: %w", err)
+	}
+
+	overrides := make([]models.ChainOverride, 0, len(rows))
+	for _, row := range rows {
+		override := models.ChainOverride{
+			Hostname:  row.Hostname.String,
+			IssuerDN:  row.IssuerDn.String,
+			IssuerURL: row.IssuerUrl,
+			IssuerPEM: row.IssuerPem,
+			CreatedAt: row.CreatedAt,
+		}
+		if issuer, err := crypto.ParseCertificate([]byte(row.IssuerPem)); err == nil {
+			override.IssuerSubject = issuer.Subject.CommonName
+		}
+		overrides = append(overrides, override)
+	}
+	return overrides, nil
+}
+
+// SetCertificateChainOverride sets where the issuer of a certificate is taken
+// from when its chain is built: an issuer URL replacing the certificate's AIA
+// URL, or the pasted PEM of the issuer certificate. An empty override removes it.
+func (s *CertificateService) SetCertificateChainOverride(ctx context.Context, hostname, override string) error {
+	dbCert, err := s.db.Queries().GetCertificateByHostname(ctx, hostname)
+	if err != nil {
+		return fmt.Errorf("certificate not found: %w", err)
+	}
+
+	if strings.TrimSpace(override) == "" {
+		_, err := s.db.Queries().DeleteCertificateChainOverride(ctx, sql.NullString{String: hostname, Valid: true})
+		return err
+	}
+
+	issuerURL, issuer, err := parseChainOverride(override)
+	if err != nil {
+		return err
+	}
+	if issuer != nil && dbCert.CertificatePem.Valid && dbCert.CertificatePem.String != "" {
+		leaf, err := crypto.ParseCertificate([]byte(dbCert.CertificatePem.String))
+		if err != nil {
+			return fmt.Errorf("failed to parse certificate: %w", err)
+		}
+		if issuer.Subject.String() != leaf.Issuer.String() {
+			return fmt.Errorf("the pasted certificate (%s) is not the issuer of %s (%s)", issuer.Subject, hostname, leaf.Issuer)
+		}
+	}
+
+	return s.db.Queries().UpsertCertificateChainOverride(ctx, sqlc.UpsertCertificateChainOverrideParams{
+		Hostname:  sql.NullString{String: hostname, Valid: true},
+		IssuerUrl: issuerURL,
+		IssuerPem: issuerPEM(issuer),
+	})
+}
+
+// SetIssuerChainOverride sets where the issuer of every certificate issued by
+// the CA named issuerDN is taken from, as SetCertificateChainOverride does for
+// one certificate. An empty override removes it.
+func (s *CertificateService) SetIssuerChainOverride(ctx context.Context, issuerDN, override string) error {
+	issuerDN = strings.TrimSpace(issuerDN)
+	if issuerDN == "" {
+		return fmt.Errorf("issuer DN is required")
+	}
+
+	if strings.TrimSpace(override) == "" {
+		_, err := s.db.Queries().DeleteIssuerChainOverride(ctx, sql.NullString{String: issuerDN, Valid: true})
+		return err
+	}
+
+	issuerURL, issuer, err := parseChainOverride(override)
+	if err != nil {
+		return err
+	}
+	if issuer != nil && issuer.Subject.String() != issuerDN {
+		return fmt.Errorf("the pasted certificate (%s) is not %s", issuer.Subject, issuerDN)
+	}
+
+	return s.db.Queries().UpsertIssuerChainOverride(ctx, sqlc.UpsertIssuerChainOverrideParams{
+		IssuerDn:  sql.NullString{String: issuerDN, Valid: true},
+		IssuerUrl: issuerURL,
+		IssuerPem: issuerPEM(issuer),
+	})
+}
+
+// parseChainOverride reads an override as entered: a pasted PEM certificate
+// (which must be a CA) or an issuer URL.
+func parseChainOverride(override string) (string, *x509.Certificate, error) {
+	override = strings.TrimSpace(override)
+	if !strings.Contains(override, "-----BEGIN") {
+		if err := crypto.ValidateAIAURL(override); err != nil {
+			return "", nil, fmt.Errorf("invalid issuer URL: %w", err)
+		}
+		return override, nil, nil
+	}
+
+	certs, err := crypto.ParseMultipleCertificates([]byte(override))
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid issuer certificate: %w", err)
+	}
+	if len(certs) != 1 {
+		return "", nil, fmt.Errorf("paste only the issuer certificate (got %d certificates)", len(certs))
+	}
+	if !certs[0].IsCA {
+		return "", nil, fmt.Errorf("the pasted certificate (%s) is not a CA certificate", certs[0].Subject)
+	}
+	return "", certs[0], nil
+}
+
+func issuerPEM(issuer *x509.Certificate) string {
+	if issuer == nil {
+		return ""
+	}
+	return crypto.ConvertChainToPEM([]*x509.Certificate{issuer})[0]
+}
+
+// chainOverrideSet holds the chain overrides, loaded once per operation
+type chainOverrideSet struct {
+	byHostname map[string]crypto.IssuerOverride
+	byIssuer   map[string]crypto.IssuerOverride
+}
+
+// loadChainOverrides reads the chain overrides. Rows whose pasted certificate no
+// longer parses are skipped (they are validated when set).
+func (s *CertificateService) loadChainOverrides(ctx context.Context) (*chainOverrideSet, error) {
+	rows, err := s.db.Queries().ListChainOverrides(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read chain overrides: %w", err)
+	}
+
+	set := &chainOverrideSet{
+		byHostname: make(map[string]crypto.IssuerOverride),
+		byIssuer:   make(map[string]crypto.IssuerOverride),
+	}
+	for _, row := range rows {
+		override := crypto.IssuerOverride{URL: row.IssuerUrl}
+		if row.IssuerPem != "" {
+			issuer, err := crypto.ParseCertificate([]byte(row.IssuerPem))
+			if err != nil {
+				continue
+			}
+			override = crypto.IssuerOverride{Certificate: issuer}
+		}
+		if row.Hostname.Valid {
+			set.byHostname[row.Hostname.String] = override
+		} else {
+			set.byIssuer[row.IssuerDn.String] = override
+		}
+	}
+	return set, nil
+}
+
+// forCertificate returns the overrides that apply to the chain of a
+// certificate: its own for the leaf's issuer, then those of the issuing CAs.
+func (o *chainOverrideSet) forCertificate(hostname string, leaf *x509.Certificate) crypto.IssuerOverrides {
+	if o == nil || (len(o.byHostname) == 0 && len(o.byIssuer) == 0) {
+		return nil
+	}
+	return func(cert *x509.Certificate) (crypto.IssuerOverride, bool) {
+		if cert.Equal(leaf) {
+			if override, ok := o.byHostname[hostname]; ok {
+				return override, true
+			}
+		}
+		override, ok := o.byIssuer[cert.Issuer.String()]
+		return override, ok
+	}
+}
+
+// lookupOverride calls overrides, which may be nil
+func lookupOverride(overrides crypto.IssuerOverrides, cert *x509.Certificate) (crypto.IssuerOverride, bool) {
+	if overrides == nil {
+		return crypto.IssuerOverride{}, false
+	}
+	return overrides(cert)
+}
@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"crypto/x509"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+func TestChainOverrides(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	hostname := "internal.example.com"
+
+	// None of the test CAs has an AIA URL, as with CAs whose URL is internal-only
+	root, rootKey := newTestCA(t, "Test Root", time.Now().Add(10*365*24*time.Hour), nil, nil)
+	intermediate, intermediateKey := newTestCA(t, "Test Intermediate", time.Now().Add(5*365*24*time.Hour), root, rootKey)
+	csrPEM, _, _ := generateTestCSRAndKey(t, hostname, testutil.RandomMasterKey(t))
+	leafPEM := signCSRWithCA(t, csrPEM, intermediate, intermediateKey)
+	intermediatePEM := crypto.ConvertChainToPEM([]*x509.Certificate{intermediate})[0]
+	rootPEM := crypto.ConvertChainToPEM([]*x509.Certificate{root})[0]
+
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:       hostname,
+		CertificatePem: sql.NullString{String: leafPEM, Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	chain, err := svc.GetCertificateChain(ctx, hostname)
+	if err != nil {
+		t.Fatalf("GetCertificateChain failed: %v", err)
+	}
+	if len(chain.Certificates) != 1 || chain.Diagnostics == nil || chain.Diagnostics.Cause != models.ChainCauseBadURL {
+		t.Fatalf("expected leaf only with bad_url diagnostics, got %d certificates, %+v", len(chain.Certificates), chain.Diagnostics)
+	}
+
+	// The certificate's own override supplies the intermediate, the CA's override the root
+	if err := svc.SetCertificateChainOverride(ctx, hostname, intermediatePEM); err != nil {
+		t.Fatalf("SetCertificateChainOverride failed: %v", err)
+	}
+	if err := svc.SetIssuerChainOverride(ctx, intermediate.Issuer.String(), rootPEM); err != nil {
+		t.Fatalf("SetIssuerChainOverride failed: %v", err)
+	}
+	chain, err = svc.GetCertificateChain(ctx, hostname)
+	if err != nil {
+		t.Fatalf("GetCertificateChain failed: %v", err)
+	}
+	if len(chain.Certificates) != 3 || chain.Diagnostics != nil {
+		t.Fatalf("expected the full chain, got %d certificates, %+v", len(chain.Certificates), chain.Diagnostics)
+	}
+	if chain.Certificates[1].SubjectCN != "Test Intermediate" || chain.Certificates[2].CertType != "root" {
+		t.Errorf("unexpected chain: %s, %s", chain.Certificates[1].SubjectCN, chain.Certificates[2].CertType)
+	}
+
+	// An issuer URL is fetched instead of the certificate's AIA URL
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(rootPEM))
+	}))
+	defer srv.Close()
+	if err := svc.SetIssuerChainOverride(ctx, intermediate.Issuer.String(), srv.URL+"/root.crt"); err != nil {
+		t.Fatalf("SetIssuerChainOverride failed: %v", err)
+	}
+	download, err := svc.GetChainPEMForDownload(ctx, hostname, models.ChainVariantFull)
+	if err != nil {
+		t.Fatalf("GetChainPEMForDownload failed: %v", err)
+	}
+	if download != leafPEM+"\n"+intermediatePEM+"\n"+rootPEM {
+		t.Error("expected downloaded chain to be leaf, intermediate, root")
+	}
+
+	overrides, err := svc.ListChainOverrides(ctx)
+	if err != nil {
+		t.Fatalf("ListChainOverrides failed: %v", err)
+	}
+	if len(overrides) != 2 || overrides[0].Hostname != hostname || overrides[0].IssuerSubject != "Test Intermediate" ||
+		overrides[1].IssuerDN != root.Subject.String() || overrides[1].IssuerURL != srv.URL+"/root.crt" {
+		t.Fatalf("unexpected overrides %+v", overrides)
+	}
+
+	// Clearing the certificate's override leaves the CA's
+	if err := svc.SetCertificateChainOverride(ctx, hostname, ""); err != nil {
+		t.Fatalf("clearing override failed: %v", err)
+	}
+	if overrides, _ := svc.ListChainOverrides(ctx); len(overrides) != 1 || overrides[0].IssuerDN == "" {
+		t.Fatalf("expected only the issuer override left, got %+v", overrides)
+	}
+}
+
+func TestChainOverrides_Rejected(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	hostname := "internal.example.com"
+
+	root, rootKey := newTestCA(t, "Test Root", time.Now().Add(10*365*24*time.Hour), nil, nil)
+	intermediate, intermediateKey := newTestCA(t, "Test Intermediate", time.Now().Add(5*365*24*time.Hour), root, rootKey)
+	csrPEM, _, _ := generateTestCSRAndKey(t, hostname, testutil.RandomMasterKey(t))
+	leafPEM := signCSRWithCA(t, csrPEM, intermediate, intermediateKey)
+	rootPEM := crypto.ConvertChainToPEM([]*x509.Certificate{root})[0]
+
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:       hostname,
+		CertificatePem: sql.NullString{String: leafPEM, Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		set  func() error
+	}{
+		{"unsupported URL", func() error { return svc.SetCertificateChainOverride(ctx, hostname, "ldap://ca.internal/cn=CA") }},
+		{"not the issuer", func() error { return svc.SetCertificateChainOverride(ctx, hostname, rootPEM) }},
+		{"not a CA", func() error { return svc.SetCertificateChainOverride(ctx, hostname, leafPEM) }},
+		{"unknown certificate", func() error { return svc.SetCertificateChainOverride(ctx, "missing.example.com", rootPEM) }},
+		{"issuer mismatch", func() error { return svc.SetIssuerChainOverride(ctx, "CN=Other CA", rootPEM) }},
+		{"no issuer", func() error { return svc.SetIssuerChainOverride(ctx, " ", rootPEM) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.set(); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+
+	if overrides, _ := svc.ListChainOverrides(ctx); len(overrides) != 0 {
+		t.Errorf("expected no overrides, got %+v", overrides)
+	}
+}
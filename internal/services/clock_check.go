@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"paddockcontrol-desktop/internal/models"
+)
+
+const (
+	// DefaultClockCheckURL is queried when no reference URL is configured
+	DefaultClockCheckURL = "https://www.cloudflare.com"
+
+	// ClockSkewTolerance is the largest local clock offset that is not reported.
+	// HTTP Date headers have one-second resolution, so anything tighter is noise.
+	ClockSkewTolerance = 2 * time.Minute
+)
+
+// CheckClockSkew compares the local clock against the Date header returned by
+// sourceURL. An empty sourceURL uses DefaultClockCheckURL. Network failures are
+// reported in the result rather than as an error: a failed check is not fatal.
+func CheckClockSkew(ctx context.Context, sourceURL string) *models.ClockCheckResult {
+	if sourceURL == "" {
+		sourceURL = DefaultClockCheckURL
+	}
+
+	result := &models.ClockCheckResult{
+		Source:    sourceURL,
+		CheckedAt: time.Now().Unix(),
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, sourceURL, nil)
+	if err != nil {
+		result.Error = fmt.Sprintf("invalid clock check URL: %v", err)
+		return result
+	}
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	sent := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to reach clock reference: %v", err)
+		return result
+	}
+	resp.Body.Close()
+	received := time.Now()
+
+	serverTime, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		result.Error = "clock reference returned no usable Date header"
+		return result
+	}
+
+	// The server stamped the response roughly halfway through the round trip
+	local := sent.Add(received.Sub(sent) / 2)
+	skew := local.Sub(serverTime)
+
+	result.SkewSeconds = int64(skew.Round(time.Second) / time.Second)
+	result.Skewed = skew > ClockSkewTolerance || skew < -ClockSkewTolerance
+	return result
+}
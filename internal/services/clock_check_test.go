@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// ===== CheckClockSkew Tests =====
+
+func newDateServer(t *testing.T, offset time.Duration) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Date", time.Now().Add(offset).UTC().Format(http.TimeFormat))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCheckClockSkew_InSync(t *testing.T) {
+	srv := newDateServer(t, 0)
+
+	result := CheckClockSkew(context.Background(), srv.URL)
+	if result.Error != "" {
+		t.Fatalf("unexpected error: %s", result.Error)
+	}
+	if result.Skewed {
+		t.Errorf("expected clock in sync, got skew of %ds", result.SkewSeconds)
+	}
+	if result.Source != srv.URL {
+		t.Errorf("expected source %s, got %s", srv.URL, result.Source)
+	}
+}
+
+func TestCheckClockSkew_DetectsSkew(t *testing.T) {
+	// Reference clock is an hour ahead, so the local clock is an hour behind
+	srv := newDateServer(t, time.Hour)
+
+	result := CheckClockSkew(context.Background(), srv.URL)
+	if result.Error != "" {
+		t.Fatalf("unexpected error: %s", result.Error)
+	}
+	if !result.Skewed {
+		t.Fatal("expected skew to be reported")
+	}
+	if result.SkewSeconds > -3590 || result.SkewSeconds < -3610 {
+		t.Errorf("expected skew of about -3600s, got %d", result.SkewSeconds)
+	}
+}
+
+func TestCheckClockSkew_UnreachableReportsError(t *testing.T) {
+	srv := newDateServer(t, 0)
+	url := srv.URL
+	srv.Close()
+
+	result := CheckClockSkew(context.Background(), url)
+	if result.Error == "" {
+		t.Fatal("expected error for unreachable reference")
+	}
+	if result.Skewed {
+		t.Error("unreachable reference must not be reported as skew")
+	}
+}
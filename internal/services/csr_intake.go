@@ -0,0 +1,184 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/hostnames"
+	"paddockcontrol-desktop/internal/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MaxCSRIntakeFileSize bounds a request file; real ones are a few lines
+const MaxCSRIntakeFileSize = 64 << 10
+
+// Limits of the fields of a request file
+const (
+	maxRequesterNameLength  = 128
+	maxRequesterEmailLength = 254
+	maxJustificationLength  = 2000
+	maxCSRIntakeSANs        = 100
+)
+
+// csrIntakeFile is the request file a colleague fills, in YAML or JSON:
+//
+//	hostname: app.example.com
+//	sans: [www.app.example.com, 10.0.0.5]
+//	justification: New intranet portal
+//	requester:
+//	  name: Jane Doe
+//	  email: jane@example.com
+//	  team: Web
+type csrIntakeFile struct {
+	Hostname      string   `yaml:"hostname"`
+	SANs          []string `yaml:"sans"`
+	Justification string   `yaml:"justification"`
+	Requester     struct {
+		Name  string `yaml:"name"`
+		Email string `yaml:"email"`
+		Team  string `yaml:"team"`
+	} `yaml:"requester"`
+}
+
+// ParseCSRIntake reads a request (intake) file, YAML or JSON, and checks it
+// for the CSR form: the hostname and SANs are normalized and the requester is
+// validated. The hostname suffix is not enforced here; GenerateCSR does it, so
+// an admin can still bypass it.
+func (s *CertificateService) ParseCSRIntake(ctx context.Context, data []byte) (*models.CSRIntake, error) {
+	if len(data) > MaxCSRIntakeFileSize {
+		return nil, fmt.Errorf("request file is larger than %d KiB", MaxCSRIntakeFileSize>>10)
+	}
+
+	// JSON is YAML, so one strict decoder reads both; unknown fields are
+	// refused so a misspelt key is not silently dropped
+	var file csrIntakeFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("invalid request file: %w", err)
+	}
+
+	hostname, err := hostnames.Normalize(file.Hostname)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hostname in request file: %w", err)
+	}
+
+	if len(file.SANs) > maxCSRIntakeSANs {
+		return nil, fmt.Errorf("request file has more than %d SANs", maxCSRIntakeSANs)
+	}
+	sans := make([]models.SANEntry, 0, len(file.SANs))
+	seen := map[string]bool{hostname: true}
+	for _, value := range file.SANs {
+		entry, err := parseIntakeSAN(value)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[entry.Value] {
+			seen[entry.Value] = true
+			sans = append(sans, entry)
+		}
+	}
+
+	requester := models.CertificateRequester{
+		Name:          file.Requester.Name,
+		Email:         file.Requester.Email,
+		Team:          file.Requester.Team,
+		Justification: file.Justification,
+	}
+	if err := normalizeRequester(&requester); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.db.Queries().CertificateExists(ctx, hostname)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check certificate existence: %w", err)
+	}
+
+	return &models.CSRIntake{
+		Hostname:  hostname,
+		SANs:      sans,
+		Requester: requester,
+		IsRenewal: exists == 1,
+	}, nil
+}
+
+// parseIntakeSAN classifies a SAN of a request file as an IP address or a
+// DNS name
+func parseIntakeSAN(value string) (models.SANEntry, error) {
+	value = strings.TrimSpace(value)
+	if ip := net.ParseIP(value); ip != nil {
+		return models.SANEntry{Value: ip.String(), Type: models.SANTypeIP}, nil
+	}
+	name, err := hostnames.Normalize(value)
+	if err != nil {
+		return models.SANEntry{}, fmt.Errorf("invalid SAN %q in request file: %w", value, err)
+	}
+	return models.SANEntry{Value: name, Type: models.SANTypeDNS}, nil
+}
+
+// normalizeRequester trims and validates the requester of a certificate. A
+// name is required; the justification is stored in the clear, so it is
+// refused if it looks like it holds a secret.
+func normalizeRequester(r *models.CertificateRequester) error {
+	r.Name = strings.TrimSpace(r.Name)
+	r.Email = strings.TrimSpace(r.Email)
+	r.Team = strings.TrimSpace(r.Team)
+	r.Justification = strings.TrimSpace(r.Justification)
+
+	if r.Name == "" {
+		return fmt.Errorf("requester name is required")
+	}
+	for _, field := range []struct {
+		name, value string
+		max         int
+	}{
+		{"requester name", r.Name, maxRequesterNameLength},
+		{"requester team", r.Team, maxRequesterNameLength},
+		{"requester email", r.Email, maxRequesterEmailLength},
+	} {
+		if utf8.RuneCountInString(field.value) > field.max {
+			return fmt.Errorf("%s must be at most %d characters", field.name, field.max)
+		}
+		if strings.IndexFunc(field.value, unicode.IsControl) >= 0 {
+			return fmt.Errorf("%s must not contain control characters", field.name)
+		}
+	}
+	if r.Email != "" {
+		if addr, err := mail.ParseAddress(r.Email); err != nil || addr.Address != r.Email {
+			return fmt.Errorf("invalid requester email: %s", r.Email)
+		}
+	}
+	if utf8.RuneCountInString(r.Justification) > maxJustificationLength {
+		return fmt.Errorf("justification must be at most %d characters", maxJustificationLength)
+	}
+	return checkNoteForSecrets(r.Justification)
+}
+
+// getRequester returns who requested a certificate, or nil when it was not
+// generated from a request file
+func getRequester(ctx context.Context, q *sqlc.Queries, hostname string) (*models.CertificateRequester, error) {
+	row, err := q.GetCertificateRequester(ctx, hostname)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get requester: %w", err)
+	}
+	return &models.CertificateRequester{
+		Name:          row.RequesterName,
+		Email:         row.RequesterEmail,
+		Team:          row.Team,
+		Justification: row.Justification,
+		RecordedAt:    row.RecordedAt,
+	}, nil
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+func TestParseCSRIntake_YAML(t *testing.T) {
+	svc, _ := setupTestService(t)
+
+	intake, err := svc.ParseCSRIntake(context.Background(), []byte(`
+hostname: App.Example.com
+sans:
+  - www.app.example.com
+  - app.example.com
+  - 10.0.0.5
+justification: |
+  New intranet portal
+requester:
+  name: Jane Doe
+  email: jane@example.com
+  team: Web
+`))
+	if err != nil {
+		t.Fatalf("ParseCSRIntake failed: %v", err)
+	}
+
+	if intake.Hostname != "app.example.com" || intake.IsRenewal {
+		t.Errorf("unexpected intake: %+v", intake)
+	}
+	want := []models.SANEntry{
+		{Value: "www.app.example.com", Type: models.SANTypeDNS},
+		{Value: "10.0.0.5", Type: models.SANTypeIP},
+	}
+	if len(intake.SANs) != len(want) || intake.SANs[0] != want[0] || intake.SANs[1] != want[1] {
+		t.Errorf("expected SANs %v without the hostname, got %v", want, intake.SANs)
+	}
+	if intake.Requester != (models.CertificateRequester{Name: "Jane Doe", Email: "jane@example.com", Team: "Web", Justification: "New intranet portal"}) {
+		t.Errorf("unexpected requester: %+v", intake.Requester)
+	}
+}
+
+func TestParseCSRIntake_JSONRenewal(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{Hostname: "app.example.com"}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	intake, err := svc.ParseCSRIntake(ctx, []byte(`{"hostname": "app.example.com", "requester": {"name": "Jane Doe"}}`))
+	if err != nil {
+		t.Fatalf("ParseCSRIntake failed: %v", err)
+	}
+	if !intake.IsRenewal {
+		t.Error("expected a request for an existing certificate to be a renewal")
+	}
+}
+
+func TestParseCSRIntake_Rejects(t *testing.T) {
+	svc, _ := setupTestService(t)
+
+	tests := []struct {
+		name, file, want string
+	}{
+		{"unknown field", "hostname: a.example.com\nrequestor: {name: Jane}\n", "requestor"},
+		{"no hostname", "requester: {name: Jane}\n", "hostname"},
+		{"no requester", "hostname: a.example.com\n", "requester name is required"},
+		{"bad email", "hostname: a.example.com\nrequester: {name: Jane, email: not-an-email}\n", "invalid requester email"},
+		{"bad SAN", "hostname: a.example.com\nsans: ['']\nrequester: {name: Jane}\n", "invalid SAN"},
+		{"secret", "hostname: a.example.com\njustification: 'password: hunter2hunter2'\nrequester: {name: Jane}\n", "secret"},
+		{"too large", "hostname: a.example.com\njustification: '" + strings.Repeat("x", MaxCSRIntakeFileSize) + "'\n", "larger than"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.ParseCSRIntake(context.Background(), []byte(tt.file))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("expected an error containing %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestGenerateCSR_RecordsRequester(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+
+	req := models.CSRRequest{
+		Hostname:     "app.example.com",
+		Organization: "Test Org",
+		City:         "Paris",
+		State:        "IDF",
+		Country:      "FR",
+		KeySize:      2048,
+		Requester:    &models.CertificateRequester{Name: " Jane Doe ", Email: "jane@example.com", Justification: "Portal"},
+	}
+	if _, err := svc.GenerateCSR(ctx, req, testutil.RandomMasterKey(t)); err != nil {
+		t.Fatalf("GenerateCSR failed: %v", err)
+	}
+
+	cert, err := svc.GetCertificate(ctx, "app.example.com")
+	if err != nil {
+		t.Fatalf("GetCertificate failed: %v", err)
+	}
+	if cert.Requester == nil || cert.Requester.Name != "Jane Doe" || cert.Requester.Justification != "Portal" || cert.Requester.RecordedAt == 0 {
+		t.Fatalf("expected the requester to be recorded, got %+v", cert.Requester)
+	}
+
+	history, err := svc.GetHistory(ctx, "app.example.com", 10)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(history) == 0 || !strings.Contains(history[0].Message, "requested by Jane Doe") {
+		t.Errorf("expected the history to name the requester, got %+v", history)
+	}
+
+	// A renewal without a request file keeps the recorded requester
+	req.IsRenewal = true
+	req.Requester = nil
+	if _, err := svc.GenerateCSR(ctx, req, testutil.RandomMasterKey(t)); err != nil {
+		t.Fatalf("GenerateCSR renewal failed: %v", err)
+	}
+	if cert, err = svc.GetCertificate(ctx, "app.example.com"); err != nil || cert.Requester == nil {
+		t.Errorf("expected the requester to be kept on renewal, got %+v (%v)", cert, err)
+	}
+}
+
+func TestGenerateCSR_RejectsInvalidRequester(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+
+	_, err := svc.GenerateCSR(context.Background(), models.CSRRequest{
+		Hostname:     "app.example.com",
+		Organization: "Test Org",
+		City:         "Paris",
+		State:        "IDF",
+		Country:      "FR",
+		KeySize:      2048,
+		Requester:    &models.CertificateRequester{Email: "jane@example.com"},
+	}, testutil.RandomMasterKey(t))
+	if err == nil {
+		t.Error("expected a requester without a name to be rejected")
+	}
+}
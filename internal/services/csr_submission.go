@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// maxCAReferenceLength bounds the CA order/reference ID
+const maxCAReferenceLength = 128
+
+// MarkCSRSubmitted records that the pending CSR of a certificate was submitted
+// to the CA, with the CA's reference ID if it gave one. Marking it again
+// replaces the recorded submission (e.g. to fix a mistyped reference). The
+// "csr_sent" renewal step is completed along the way.
+func (s *CertificateService) MarkCSRSubmitted(ctx context.Context, hostname string, submission models.CSRSubmission) error {
+	log := logger.WithHostname(logger.WithComponent("certificate"), hostname)
+
+	reference := strings.TrimSpace(submission.CAReference)
+	if err := validateCAReference(reference); err != nil {
+		return err
+	}
+
+	now := s.clock.Now()
+	submittedAt := submission.SubmittedAt
+	if submittedAt == 0 {
+		submittedAt = now.Unix()
+	}
+	if submittedAt < 0 {
+		return fmt.Errorf("invalid submission time")
+	}
+	// Allow for a little clock drift between the frontend and the backend
+	if submittedAt > now.Add(5*time.Minute).Unix() {
+		return fmt.Errorf("submission time is in the future")
+	}
+
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		cert, err := q.GetCertificateByHostname(ctx, hostname)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("certificate not found: %s", hostname)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get certificate: %w", err)
+		}
+		if cert.ReadOnly == 1 {
+			return fmt.Errorf("certificate is read-only and cannot be modified")
+		}
+		if !cert.PendingCsrPem.Valid || cert.PendingCsrPem.String == "" {
+			return fmt.Errorf("certificate has no pending CSR")
+		}
+
+		if err := q.UpdateCSRSubmission(ctx, sqlc.UpdateCSRSubmissionParams{
+			CaReference: sql.NullString{String: reference, Valid: reference != ""},
+			SubmittedAt: sql.NullInt64{Int64: submittedAt, Valid: true},
+			Hostname:    hostname,
+		}); err != nil {
+			return fmt.Errorf("failed to record CSR submission: %w", err)
+		}
+		if err := s.completeRenewalStepsTx(ctx, q, hostname, models.RenewalStepCSRSent); err != nil {
+			return err
+		}
+
+		message := "CSR submitted to CA"
+		details := map[string]any{"submitted_at": submittedAt}
+		if reference != "" {
+			message = fmt.Sprintf("CSR submitted to CA (reference %s)", reference)
+			details["ca_reference"] = reference
+		}
+		if err := s.history.LogEventDetailsTx(ctx, q, hostname, models.EventCSRSubmitted, message, details); err != nil {
+			return fmt.Errorf("failed to log history: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error("failed to record CSR submission", logger.Err(err))
+		return err
+	}
+
+	log.Info("CSR submission recorded", slog.Bool("has_reference", reference != ""))
+	return nil
+}
+
+// validateCAReference checks a trimmed CA reference ID; empty is allowed
+func validateCAReference(reference string) error {
+	if utf8.RuneCountInString(reference) > maxCAReferenceLength {
+		return fmt.Errorf("CA reference must be at most %d characters", maxCAReferenceLength)
+	}
+	for _, r := range reference {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("CA reference must not contain control characters")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+func TestMarkCSRSubmitted_RecordsAndClearsOnUpload(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	hostname := "server.example.com"
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	csrPEM, encryptedKey, privateKey := generateTestCSRAndKey(t, hostname, encryptionKey)
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:                   hostname,
+		PendingEncryptedPrivateKey: encryptedKey,
+		PendingCsrPem:              sql.NullString{String: string(csrPEM), Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	if err := svc.MarkCSRSubmitted(ctx, hostname, models.CSRSubmission{CAReference: "  REQ-1234 "}); err != nil {
+		t.Fatalf("MarkCSRSubmitted failed: %v", err)
+	}
+	cert, err := svc.GetCertificate(ctx, hostname)
+	if err != nil {
+		t.Fatalf("GetCertificate failed: %v", err)
+	}
+	if cert.CAReference != "REQ-1234" || cert.SubmittedAt == nil || *cert.SubmittedAt == 0 {
+		t.Errorf("expected the submission to be recorded, got reference %q at %v", cert.CAReference, cert.SubmittedAt)
+	}
+
+	checklist, err := svc.GetRenewalChecklist(ctx, hostname)
+	if err != nil {
+		t.Fatalf("GetRenewalChecklist failed: %v", err)
+	}
+	if !checklist.Items[0].Done {
+		t.Errorf("expected csr_sent to be done, got %+v", checklist)
+	}
+
+	history, err := svc.GetHistory(ctx, hostname, 10)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(history) != 1 || history[0].EventType != models.EventCSRSubmitted || history[0].Details["ca_reference"] != "REQ-1234" {
+		t.Errorf("unexpected history: %+v", history)
+	}
+
+	certPEM, err := selfSignCertFromCSR(csrPEM, privateKey)
+	if err != nil {
+		t.Fatalf("failed to sign certificate: %v", err)
+	}
+	if _, err := svc.UploadCertificate(ctx, hostname, certPEM, encryptionKey); err != nil {
+		t.Fatalf("UploadCertificate failed: %v", err)
+	}
+	cert, err = svc.GetCertificate(ctx, hostname)
+	if err != nil {
+		t.Fatalf("GetCertificate failed: %v", err)
+	}
+	if cert.CAReference != "" || cert.SubmittedAt != nil {
+		t.Errorf("expected the submission to be cleared by the upload, got reference %q at %v", cert.CAReference, cert.SubmittedAt)
+	}
+}
+
+func TestMarkCSRSubmitted_Rejects(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+
+	for _, params := range []sqlc.CreateCertificateParams{
+		{Hostname: "nocsr.example.com"},
+		{Hostname: "locked.example.com", PendingCsrPem: sql.NullString{String: "csr", Valid: true}, ReadOnly: 1},
+		{Hostname: "pending.example.com", PendingCsrPem: sql.NullString{String: "csr", Valid: true}},
+	} {
+		if err := database.Queries().CreateCertificate(ctx, params); err != nil {
+			t.Fatalf("failed to create certificate: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		hostname   string
+		submission models.CSRSubmission
+		wantErr    string
+	}{
+		{"no pending CSR", "nocsr.example.com", models.CSRSubmission{}, "no pending CSR"},
+		{"read-only", "locked.example.com", models.CSRSubmission{}, "read-only"},
+		{"missing", "missing.example.com", models.CSRSubmission{}, "not found"},
+		{"future", "pending.example.com", models.CSRSubmission{SubmittedAt: time.Now().Add(24 * time.Hour).Unix()}, "in the future"},
+		{"long reference", "pending.example.com", models.CSRSubmission{CAReference: strings.Repeat("x", 129)}, "at most"},
+		{"control character", "pending.example.com", models.CSRSubmission{CAReference: "REQ\u00001"}, "control characters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.MarkCSRSubmitted(ctx, tt.hostname, tt.submission)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestListCertificates_AwaitingResponse(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	for _, params := range []sqlc.CreateCertificateParams{
+		{Hostname: "old.example.com", PendingCsrPem: sql.NullString{String: "csr", Valid: true}},
+		{Hostname: "recent.example.com", PendingCsrPem: sql.NullString{String: "csr", Valid: true}},
+		{Hostname: "unsubmitted.example.com", PendingCsrPem: sql.NullString{String: "csr", Valid: true}},
+	} {
+		if err := database.Queries().CreateCertificate(ctx, params); err != nil {
+			t.Fatalf("failed to create certificate: %v", err)
+		}
+	}
+	if err := svc.MarkCSRSubmitted(ctx, "old.example.com", models.CSRSubmission{SubmittedAt: now.AddDate(0, 0, -10).Unix()}); err != nil {
+		t.Fatalf("MarkCSRSubmitted failed: %v", err)
+	}
+	if err := svc.MarkCSRSubmitted(ctx, "recent.example.com", models.CSRSubmission{SubmittedAt: now.AddDate(0, 0, -2).Unix()}); err != nil {
+		t.Fatalf("MarkCSRSubmitted failed: %v", err)
+	}
+
+	items, err := svc.ListCertificates(ctx, models.CertificateFilter{AwaitingResponseDays: 7})
+	if err != nil {
+		t.Fatalf("ListCertificates failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Hostname != "old.example.com" {
+		t.Errorf("expected only old.example.com, got %+v", items)
+	}
+	if items[0].SubmittedAt == nil {
+		t.Errorf("expected the submission time in the list item")
+	}
+
+	items, err = svc.ListCertificates(ctx, models.CertificateFilter{})
+	if err != nil {
+		t.Fatalf("ListCertificates failed: %v", err)
+	}
+	if len(items) != 3 {
+		t.Errorf("expected every certificate without the filter, got %d", len(items))
+	}
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// Bounds on the free-text fields of a deployment target
+const (
+	maxDeploymentNameLength     = 128
+	maxDeploymentLocationLength = 512
+)
+
+// ErrCertificateDeployed is returned when deleting a certificate that still
+// has deployment targets; the error message lists them.
+var ErrCertificateDeployed = errors.New("certificate is still deployed")
+
+// ListDeploymentTargets returns where a certificate is deployed, by name
+func (s *CertificateService) ListDeploymentTargets(ctx context.Context, hostname string) ([]models.DeploymentTarget, error) {
+	rows, err := s.db.Queries().ListDeploymentTargets(ctx, hostname)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployment targets of %s: %w", hostname, err)
+	}
+
+	targets := make([]models.DeploymentTarget, 0, len(rows))
+	for _, row := range rows {
+		targets = append(targets, toDeploymentTarget(row))
+	}
+	return targets, nil
+}
+
+// AddDeploymentTarget records that a certificate is deployed on the target
+// called name. Adding a target again updates its location and deployment time.
+func (s *CertificateService) AddDeploymentTarget(ctx context.Context, hostname, name, location string) error {
+	name = strings.TrimSpace(name)
+	location = strings.TrimSpace(location)
+	if name == "" {
+		return fmt.Errorf("deployment target name is required")
+	}
+	if len(name) > maxDeploymentNameLength {
+		return fmt.Errorf("deployment target name must not exceed %d characters", maxDeploymentNameLength)
+	}
+	if len(location) > maxDeploymentLocationLength {
+		return fmt.Errorf("deployment location must not exceed %d characters", maxDeploymentLocationLength)
+	}
+
+	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		if _, err := q.GetCertificateByHostname(ctx, hostname); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("certificate not found: %s", hostname)
+			}
+			return fmt.Errorf("failed to get certificate: %w", err)
+		}
+		return q.UpsertDeploymentTarget(ctx, sqlc.UpsertDeploymentTargetParams{
+			Hostname:   hostname,
+			Name:       name,
+			Location:   location,
+			DeployedAt: s.clock.Now().Unix(),
+		})
+	})
+}
+
+// RemoveDeploymentTarget removes a deployment target
+func (s *CertificateService) RemoveDeploymentTarget(ctx context.Context, id int64) error {
+	deleted, err := s.db.Queries().DeleteDeploymentTarget(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to remove deployment target: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("deployment target not found: %d", id)
+	}
+	return nil
+}
+
+// deploymentTargetNames returns the names of the targets a certificate is
+// deployed on, with their location when set
+func deploymentTargetNames(ctx context.Context, q *sqlc.Queries, hostname string) ([]string, error) {
+	rows, err := q.ListDeploymentTargets(ctx, hostname)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployment targets of %s: %w", hostname, err)
+	}
+
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row.Location != "" {
+			names = append(names, fmt.Sprintf("%s (%s)", row.Name, row.Location))
+		} else {
+			names = append(names, row.Name)
+		}
+	}
+	return names, nil
+}
+
+func toDeploymentTarget(row sqlc.DeploymentTarget) models.DeploymentTarget {
+	return models.DeploymentTarget{
+		ID:         row.ID,
+		Hostname:   row.Hostname,
+		Name:       row.Name,
+		Location:   row.Location,
+		DeployedAt: row.DeployedAt,
+	}
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestDeploymentTargets_BlockDeletion(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	createRelationTestCertificates(t, database.Queries(), "web.example.com", "spare.example.com")
+
+	if err := svc.AddDeploymentTarget(ctx, "web.example.com", "web01", "/etc/nginx/ssl"); err != nil {
+		t.Fatalf("AddDeploymentTarget failed: %v", err)
+	}
+	if err := svc.AddDeploymentTarget(ctx, "web.example.com", "lb-prod", ""); err != nil {
+		t.Fatalf("AddDeploymentTarget failed: %v", err)
+	}
+	// Adding a target again updates it instead of duplicating it
+	if err := svc.AddDeploymentTarget(ctx, "web.example.com", "web01", "/etc/ssl"); err != nil {
+		t.Fatalf("AddDeploymentTarget failed: %v", err)
+	}
+
+	targets, err := svc.ListDeploymentTargets(ctx, "web.example.com")
+	if err != nil {
+		t.Fatalf("ListDeploymentTargets failed: %v", err)
+	}
+	if len(targets) != 2 || targets[0].Name != "lb-prod" || targets[1].Location != "/etc/ssl" {
+		t.Fatalf("unexpected targets: %+v", targets)
+	}
+
+	err = svc.DeleteCertificate(ctx, "web.example.com")
+	if !errors.Is(err, ErrCertificateDeployed) {
+		t.Fatalf("expected ErrCertificateDeployed, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "lb-prod") || !strings.Contains(err.Error(), "web01 (/etc/ssl)") {
+		t.Errorf("expected the error to list the targets, got %q", err)
+	}
+
+	items, err := svc.PreviewBulkDelete(ctx, []string{"web.example.com", "spare.example.com"})
+	if err != nil {
+		t.Fatalf("PreviewBulkDelete failed: %v", err)
+	}
+	if len(items[0].DeployedOn) != 2 || len(items[1].DeployedOn) != 0 {
+		t.Errorf("unexpected deployed_on in preview: %+v", items)
+	}
+
+	for _, target := range targets {
+		if err := svc.RemoveDeploymentTarget(ctx, target.ID); err != nil {
+			t.Fatalf("RemoveDeploymentTarget failed: %v", err)
+		}
+	}
+	if err := svc.RemoveDeploymentTarget(ctx, targets[0].ID); err == nil {
+		t.Error("expected an error when removing a target twice")
+	}
+	if err := svc.DeleteCertificate(ctx, "web.example.com"); err != nil {
+		t.Fatalf("DeleteCertificate failed once undeployed: %v", err)
+	}
+}
+
+func TestAddDeploymentTarget_Rejects(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	createRelationTestCertificates(t, database.Queries(), "web.example.com")
+
+	for name, args := range map[string][2]string{
+		"unknown certificate": {"missing.example.com", "web01"},
+		"empty name":          {"web.example.com", "  "},
+		"long name":           {"web.example.com", strings.Repeat("x", maxDeploymentNameLength+1)},
+	} {
+		if err := svc.AddDeploymentTarget(ctx, args[0], args[1], ""); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestMergeHostnameDuplicates_MovesDeploymentTargets(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	createRelationTestCertificates(t, database.Queries(), "Web.example.com")
+
+	if err := svc.AddDeploymentTarget(ctx, "Web.example.com", "web01", ""); err != nil {
+		t.Fatalf("AddDeploymentTarget failed: %v", err)
+	}
+	if _, err := svc.MergeHostnameDuplicates(ctx, "Web.example.com"); err != nil {
+		t.Fatalf("MergeHostnameDuplicates failed: %v", err)
+	}
+
+	targets, err := svc.ListDeploymentTargets(ctx, "web.example.com")
+	if err != nil {
+		t.Fatalf("ListDeploymentTargets failed: %v", err)
+	}
+	if len(targets) != 1 || targets[0].Name != "web01" {
+		t.Errorf("expected the target to follow the renamed certificate, got %+v", targets)
+	}
+}
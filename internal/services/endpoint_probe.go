@@ -0,0 +1,154 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/hostnames"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/truststores"
+)
+
+const (
+	// defaultProbePort is probed when no port is given
+	defaultProbePort = 443
+
+	// probeTimeout bounds the connection and TLS handshake of a probe
+	probeTimeout = 10 * time.Second
+)
+
+// ProbeEndpoint connects to host on port (443 when 0), reads the certificate
+// chain the server presents and compares its leaf with the certificate stored
+// for hostname: serial number, fingerprint and expiry. host is the name or IP
+// address to dial, e.g. one node behind a load balancer, and defaults to
+// hostname, which a wildcard certificate cannot use; hostname is always sent
+// as the TLS server name. The handshake accepts any chain so servers using a
+// private CA can be probed; the presented chain is then evaluated against each
+// trust store.
+func (s *CertificateService) ProbeEndpoint(ctx context.Context, hostname, host string, port int) (*models.EndpointProbe, error) {
+	hostname, err := hostnames.Normalize(hostname)
+	if err != nil {
+		return nil, err
+	}
+	if port == 0 {
+		port = defaultProbePort
+	}
+	if port < 1 || port > 65535 {
+		return nil, fmt.Errorf("invalid port: %d", port)
+	}
+
+	host = strings.TrimSpace(host)
+	switch {
+	case host == "" && strings.HasPrefix(hostname, "*."):
+		return nil, fmt.Errorf("a wildcard certificate needs a host to connect to")
+	case host == "":
+		host = hostname
+	case net.ParseIP(host) == nil:
+		if host, err = hostnames.Normalize(host); err != nil {
+			return nil, err
+		}
+		if strings.Contains(host, "*") {
+			return nil, fmt.Errorf("invalid host: %s", host)
+		}
+	}
+	return s.probeEndpoint(ctx, hostname, net.JoinHostPort(host, strconv.Itoa(port)))
+}
+
+// probeEndpoint probes address, sending hostname as the TLS server name
+func (s *CertificateService) probeEndpoint(ctx context.Context, hostname, address string) (*models.EndpointProbe, error) {
+	stored, err := s.db.Queries().GetCertificateByHostname(ctx, hostname)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("certificate not found: %s", hostname)
+		}
+		return nil, fmt.Errorf("failed to get certificate: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	dialer := &tls.Dialer{
+		Config: &tls.Config{
+			ServerName: hostname,
+			// The chain is evaluated against the trust stores below instead,
+			// so a certificate from a private CA is still read
+			InsecureSkipVerify: true,
+		},
+	}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+	defer conn.Close()
+
+	state := conn.(*tls.Conn).ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return nil, fmt.Errorf("%s presented no certificate", address)
+	}
+	leaf, chain := state.PeerCertificates[0], state.PeerCertificates[1:]
+	now := s.clock.Now()
+
+	probe := &models.EndpointProbe{
+		Hostname:        hostname,
+		Address:         address,
+		TLSVersion:      tls.VersionName(state.Version),
+		ProbedAt:        now.Unix(),
+		Presented:       crypto.BuildChainInfo(leaf, chain),
+		PresentedSHA256: sha256Fingerprint(leaf),
+		Mismatches:      []string{},
+	}
+
+	probe.Trust = evaluateChainTrust(leaf, chain, truststores.Stores(), now)
+	probe.Trust.Hostname = hostname
+	probe.Trust.ChainSource = "served"
+
+	if !stored.CertificatePem.Valid || stored.CertificatePem.String == "" {
+		probe.Mismatches = append(probe.Mismatches, "no certificate is stored for "+hostname)
+		return probe, nil
+	}
+	storedCert, err := crypto.ParseCertificate([]byte(stored.CertificatePem.String))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse stored certificate: %w", err)
+	}
+	probe.StoredSHA256 = sha256Fingerprint(storedCert)
+	probe.StoredSerial = fmt.Sprintf("%X", storedCert.SerialNumber)
+	probe.StoredNotAfter = storedCert.NotAfter.Unix()
+	probe.Mismatches = compareProbedCertificate(leaf, storedCert)
+	probe.Matches = len(probe.Mismatches) == 0
+	return probe, nil
+}
+
+// compareProbedCertificate describes how the presented leaf differs from the
+// stored certificate; empty when they are the same certificate
+func compareProbedCertificate(presented, stored *x509.Certificate) []string {
+	mismatches := []string{}
+	if bytes.Equal(presented.Raw, stored.Raw) {
+		return mismatches
+	}
+	if presented.SerialNumber.Cmp(stored.SerialNumber) != 0 {
+		mismatches = append(mismatches, fmt.Sprintf("serial number is %X, stored %X", presented.SerialNumber, stored.SerialNumber))
+	}
+	if !presented.NotAfter.Equal(stored.NotAfter) {
+		mismatches = append(mismatches, fmt.Sprintf("expires %s, stored %s",
+			presented.NotAfter.UTC().Format(time.DateOnly), stored.NotAfter.UTC().Format(time.DateOnly)))
+	}
+	mismatches = append(mismatches, fmt.Sprintf("SHA-256 fingerprint is %s, stored %s",
+		sha256Fingerprint(presented), sha256Fingerprint(stored)))
+	return mismatches
+}
+
+func sha256Fingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return crypto.FormatHexColon(sum[:])
+}
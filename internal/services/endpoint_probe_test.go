@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"database/sql"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+)
+
+// newProbeTarget starts a TLS server presenting a certificate issued for
+// hostname and returns its address with the certificate PEM
+func newProbeTarget(t *testing.T, hostname string) (string, string) {
+	t.Helper()
+	key, err := crypto.GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatalf("GenerateRSAKey failed: %v", err)
+	}
+	csrPEM, err := crypto.CreateCSR(crypto.CSRRequest{CommonName: hostname, DNSSANs: []string{hostname}}, key)
+	if err != nil {
+		t.Fatalf("CreateCSR failed: %v", err)
+	}
+	certPEM, err := selfSignCertFromCSR(csrPEM, key)
+	if err != nil {
+		t.Fatalf("failed to sign certificate: %v", err)
+	}
+	keyPEM, err := crypto.PrivateKeyToPEM(key)
+	if err != nil {
+		t.Fatalf("PrivateKeyToPEM failed: %v", err)
+	}
+	pair, err := tls.X509KeyPair([]byte(certPEM), keyPEM)
+	if err != nil {
+		t.Fatalf("X509KeyPair failed: %v", err)
+	}
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{pair}}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server.Listener.Addr().String(), certPEM
+}
+
+func TestProbeEndpoint_ComparesPresentedCertificate(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	q := database.Queries()
+	address, servedPEM := newProbeTarget(t, "probe.example.com")
+
+	if err := q.CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:       "probe.example.com",
+		CertificatePem: sql.NullString{String: servedPEM, Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	probe, err := svc.probeEndpoint(ctx, "probe.example.com", address)
+	if err != nil {
+		t.Fatalf("probeEndpoint failed: %v", err)
+	}
+	if !probe.Matches || len(probe.Mismatches) != 0 {
+		t.Errorf("probe = %+v, want the served certificate to match", probe)
+	}
+	if len(probe.Presented) != 1 || probe.Presented[0].SerialNumber != probe.StoredSerial {
+		t.Errorf("presented = %+v, want the stored leaf", probe.Presented)
+	}
+	if probe.PresentedSHA256 != probe.StoredSHA256 || probe.TLSVersion == "" {
+		t.Errorf("probe = %+v, want matching fingerprints and a TLS version", probe)
+	}
+	if probe.Trust == nil || probe.Trust.ChainSource != "served" {
+		t.Errorf("trust = %+v, want the served chain evaluated", probe.Trust)
+	}
+
+	// A server still presenting another certificate is reported
+	_, storedPEM := newProbeTarget(t, "probe.example.com")
+	if err := q.ActivateCertificate(ctx, sqlc.ActivateCertificateParams{
+		Hostname:       "probe.example.com",
+		CertificatePem: sql.NullString{String: storedPEM, Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to update certificate: %v", err)
+	}
+	probe, err = svc.probeEndpoint(ctx, "probe.example.com", address)
+	if err != nil {
+		t.Fatalf("probeEndpoint failed: %v", err)
+	}
+	if probe.Matches || len(probe.Mismatches) == 0 {
+		t.Fatalf("probe = %+v, want a mismatch", probe)
+	}
+	if !strings.Contains(strings.Join(probe.Mismatches, "; "), "SHA-256 fingerprint") {
+		t.Errorf("mismatches = %v, want the fingerprints compared", probe.Mismatches)
+	}
+}
+
+func TestProbeEndpoint_Errors(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+
+	if _, err := svc.ProbeEndpoint(ctx, "missing.example.com", "", 70000); err == nil || !strings.Contains(err.Error(), "invalid port") {
+		t.Errorf("err = %v, want an invalid port error", err)
+	}
+	if _, err := svc.ProbeEndpoint(ctx, "missing.example.com", "", 443); err == nil || !strings.Contains(err.Error(), "certificate not found") {
+		t.Errorf("err = %v, want certificate not found", err)
+	}
+
+	// A certificate awaiting issuance cannot match what is served
+	address, _ := newProbeTarget(t, "pending.example.com")
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{Hostname: "pending.example.com"}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	probe, err := svc.probeEndpoint(ctx, "pending.example.com", address)
+	if err != nil {
+		t.Fatalf("probeEndpoint failed: %v", err)
+	}
+	if probe.Matches || len(probe.Mismatches) != 1 || len(probe.Presented) != 1 {
+		t.Errorf("probe = %+v, want the presented chain and no stored certificate", probe)
+	}
+
+	// Nothing listens on port 1
+	if _, err := svc.probeEndpoint(ctx, "pending.example.com", "127.0.0.1:1"); err == nil || !strings.Contains(err.Error(), "failed to connect") {
+		t.Errorf("err = %v, want a connection error", err)
+	}
+}
+
+func TestProbeEndpoint_DialsHost(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	address, servedPEM := newProbeTarget(t, "probe.example.com")
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:       "probe.example.com",
+		CertificatePem: sql.NullString{String: servedPEM, Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	host, portText, err := net.SplitHostPort(address)
+	if err != nil {
+		t.Fatalf("SplitHostPort failed: %v", err)
+	}
+	port, err := strconv.Atoi(portText)
+	if err != nil {
+		t.Fatalf("invalid port %q: %v", portText, err)
+	}
+
+	// The hostname is normalized before the stored certificate is looked up
+	probe, err := svc.ProbeEndpoint(ctx, " Probe.Example.com. ", host, port)
+	if err != nil {
+		t.Fatalf("ProbeEndpoint failed: %v", err)
+	}
+	if probe.Hostname != "probe.example.com" || probe.Address != address || !probe.Matches {
+		t.Errorf("probe = %+v, want %s probed at %s and matching", probe, "probe.example.com", address)
+	}
+
+	if _, err := svc.ProbeEndpoint(ctx, "*.example.com", "", port); err == nil || !strings.Contains(err.Error(), "needs a host") {
+		t.Errorf("err = %v, want a wildcard certificate to need a host", err)
+	}
+}
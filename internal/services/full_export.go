@@ -0,0 +1,197 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"paddockcontrol-desktop/internal/aeszip"
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// MinFullExportPasswordLength is the shortest password accepted for a full
+// export archive
+const MinFullExportPasswordLength = 16
+
+// fullExportEntry is one file of a full export archive
+type fullExportEntry struct {
+	name    string
+	content []byte
+	mode    os.FileMode
+}
+
+// BuildFullExport writes every certificate, with its decrypted private keys,
+// into a ZIP archive encrypted with password (AES-256), for migrating away or
+// emergency offline access when this installation can no longer be used:
+//
+//	README.txt                       what the archive holds and when it was made
+//	inventory.csv                    the certificate inventory (BuildInventory)
+//	audit_log.csv                    the audit log (AuditService.BuildCSV)
+//	certificates/<host>/<host>.crt   certificate, and <host>-chain.crt with the
+//	                                 stored issuers
+//	certificates/<host>/<host>.key   private key of the certificate
+//	certificates/<host>/<host>.csr   pending CSR, with <host>.pending.key
+//	certificates/<host>/note.txt     note, when set
+//
+// Wildcard hosts use "_" for "*" in file names. Returns the archive and the
+// hostnames of the certificates in it, to pass to RecordFullExport once the
+// archive is saved.
+func (s *CertificateService) BuildFullExport(ctx context.Context, password string, encryptionKey []byte) ([]byte, []string, error) {
+	if len(password) < MinFullExportPasswordLength {
+		return nil, nil, fmt.Errorf("password must be at least %d characters", MinFullExportPasswordLength)
+	}
+
+	certs, err := s.db.Queries().ListAllCertificates(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+	inventory, err := s.BuildInventory(ctx, models.InventoryFormatCSV)
+	if err != nil {
+		return nil, nil, err
+	}
+	auditLog, err := NewAuditService(s.db, s.history.appVersion).BuildCSV(ctx, models.AuditFilter{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var entries []fullExportEntry
+	defer func() {
+		for _, e := range entries {
+			crypto.Zero(e.content)
+		}
+	}()
+	keys := 0
+	for i := range certs {
+		certEntries, err := fullExportEntries(&certs[i], encryptionKey)
+		entries = append(entries, certEntries...)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, e := range certEntries {
+			if strings.HasSuffix(e.name, ".key") {
+				keys++
+			}
+		}
+	}
+
+	now := s.clock.Now()
+	readme := fmt.Sprintf(fullExportReadme, now.UTC().Format(time.RFC3339), s.history.appVersion, len(certs), keys)
+
+	var buf bytes.Buffer
+	zw := aeszip.NewWriter(&buf, password)
+	files := append([]fullExportEntry{
+		{"README.txt", []byte(readme), 0644},
+		{"inventory.csv", inventory, 0644},
+		{"audit_log.csv", auditLog, 0644},
+	}, entries...)
+	for _, e := range files {
+		w, err := zw.Create(e.name, e.mode)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, err := w.Write(e.content); err != nil {
+			return nil, nil, fmt.Errorf("failed to write %s: %w", e.name, err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+
+	hostnames := make([]string, len(certs))
+	for i := range certs {
+		hostnames[i] = certs[i].Hostname
+	}
+	return buf.Bytes(), hostnames, nil
+}
+
+// RecordFullExport gives each certificate of a saved full export a full_export
+// history entry
+func (s *CertificateService) RecordFullExport(ctx context.Context, hostnames []string) error {
+	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		for _, hostname := range hostnames {
+			if err := s.history.LogEventTx(ctx, q, hostname, models.EventFullExport,
+				"Included in a full export with decrypted private keys"); err != nil {
+				return fmt.Errorf("failed to log history: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// fullExportEntries returns the files of one certificate, decrypting its keys.
+// Entries decrypted before an error are returned so the caller can zero them.
+func fullExportEntries(cert *sqlc.Certificate, encryptionKey []byte) ([]fullExportEntry, error) {
+	name := strings.ReplaceAll(cert.Hostname, "*", "_")
+	dir := "certificates/" + name + "/"
+	var entries []fullExportEntry
+
+	if cert.CertificatePem.Valid && cert.CertificatePem.String != "" {
+		entries = append(entries, fullExportEntry{dir + name + ".crt", []byte(cert.CertificatePem.String), 0644})
+		if chain := storedChain(cert); len(chain) > 0 {
+			full := cert.CertificatePem.String
+			for _, pem := range crypto.ConvertChainToPEM(chain) {
+				full += "\n" + pem
+			}
+			entries = append(entries, fullExportEntry{dir + name + "-chain.crt", []byte(full), 0644})
+		}
+	}
+	if len(cert.EncryptedPrivateKey) > 0 {
+		key, err := crypto.DecryptPrivateKey(cert.EncryptedPrivateKey, encryptionKey)
+		if err != nil {
+			return entries, fmt.Errorf("failed to decrypt private key of %s: %w", cert.Hostname, err)
+		}
+		entries = append(entries, fullExportEntry{dir + name + ".key", bytes.Clone(key.Bytes()), 0600})
+		key.Destroy()
+	}
+	if cert.PendingCsrPem.Valid && cert.PendingCsrPem.String != "" {
+		entries = append(entries, fullExportEntry{dir + name + ".csr", []byte(cert.PendingCsrPem.String), 0644})
+	}
+	if len(cert.PendingEncryptedPrivateKey) > 0 {
+		key, err := crypto.DecryptPrivateKey(cert.PendingEncryptedPrivateKey, encryptionKey)
+		if err != nil {
+			return entries, fmt.Errorf("failed to decrypt pending private key of %s: %w", cert.Hostname, err)
+		}
+		entries = append(entries, fullExportEntry{dir + name + ".pending.key", bytes.Clone(key.Bytes()), 0600})
+		key.Destroy()
+	}
+	if cert.Note.Valid && cert.Note.String != "" {
+		entries = append(entries, fullExportEntry{dir + "note.txt", []byte(cert.Note.String), 0644})
+	}
+	return entries, nil
+}
+
+const fullExportReadme = `PaddockControl full export
+==========================
+
+Created:      %s
+App version:  %s
+Certificates: %d
+Private keys: %d
+
+This archive holds DECRYPTED private keys. Keep it offline, share the password
+separately, and delete it once it is no longer needed.
+
+Open it with 7-Zip, WinZip or another tool supporting AES-256 encrypted ZIP
+archives (the built-in Windows and macOS extractors do not).
+
+Layout:
+  inventory.csv                    every certificate: status, SANs, expiry, key
+  audit_log.csv                    unlocks, key exports, password changes,
+                                   restores, resets and sync agent
+                                   enrollments recorded by the app
+  certificates/<host>/<host>.crt   certificate (PEM)
+  certificates/<host>/<host>-chain.crt
+                                   certificate followed by its issuers, when stored
+  certificates/<host>/<host>.key   private key (PEM, unencrypted)
+  certificates/<host>/<host>.csr   pending CSR of a renewal in progress
+  certificates/<host>/<host>.pending.key
+                                   private key of the pending CSR
+  certificates/<host>/note.txt     certificate note
+
+Wildcard hostnames use "_" in place of "*" in file and folder names.
+`
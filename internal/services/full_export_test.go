@@ -0,0 +1,120 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"paddockcontrol-desktop/internal/aeszip"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+const testFullExportPassword = "correct horse battery staple"
+
+func TestBuildFullExport(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	masterKey := testutil.RandomMasterKey(t)
+	storePKCS12TestCertificate(t, database, "*.example.com", masterKey)
+	seedCert(t, database, "empty.example.com")
+	if _, err := database.DB().Exec("UPDATE certificates SET note = 'owner: ops' WHERE hostname = '*.example.com'"); err != nil {
+		t.Fatalf("failed to set note: %v", err)
+	}
+
+	data, hostnames, err := svc.BuildFullExport(ctx, testFullExportPassword, masterKey)
+	if err != nil {
+		t.Fatalf("BuildFullExport failed: %v", err)
+	}
+	if len(hostnames) != 2 {
+		t.Errorf("expected 2 certificates, got %v", hostnames)
+	}
+	if bytes.Contains(data, []byte("PRIVATE KEY")) {
+		t.Fatal("archive contains a plaintext private key")
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("export is not a ZIP archive: %v", err)
+	}
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		content, err := aeszip.ReadFile(f, testFullExportPassword)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", f.Name, err)
+		}
+		files[f.Name] = string(content)
+	}
+
+	for name, want := range map[string]string{
+		"README.txt":    "Private keys: 1",
+		"inventory.csv": "empty.example.com",
+		"audit_log.csv": "timestamp,event_type",
+		"certificates/_.example.com/_.example.com.crt":       "BEGIN CERTIFICATE",
+		"certificates/_.example.com/_.example.com-chain.crt": "BEGIN CERTIFICATE",
+		"certificates/_.example.com/_.example.com.key":       "PRIVATE KEY",
+		"certificates/_.example.com/note.txt":                "owner: ops",
+	} {
+		if !strings.Contains(files[name], want) {
+			t.Errorf("expected %s to contain %q", name, want)
+		}
+	}
+	if strings.Count(files["certificates/_.example.com/_.example.com-chain.crt"], "BEGIN CERTIFICATE") != 2 {
+		t.Error("expected the chain file to hold the certificate and its stored issuer")
+	}
+	for name := range files {
+		if strings.HasPrefix(name, "certificates/empty.example.com/") {
+			t.Errorf("expected no files for a certificate without content, got %s", name)
+		}
+	}
+
+	// History is only written once the caller has saved the archive
+	history, err := svc.GetHistory(ctx, "*.example.com", 10)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(history) > 0 && history[0].EventType == models.EventFullExport {
+		t.Errorf("expected no full export history entry before the archive is saved, got %+v", history)
+	}
+
+	if err := svc.RecordFullExport(ctx, hostnames); err != nil {
+		t.Fatalf("RecordFullExport failed: %v", err)
+	}
+	history, err = svc.GetHistory(ctx, "*.example.com", 10)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(history) == 0 || history[0].EventType != models.EventFullExport {
+		t.Errorf("expected a full export history entry, got %+v", history)
+	}
+}
+
+func TestBuildFullExport_RejectsShortPassword(t *testing.T) {
+	svc, _ := setupTestService(t)
+
+	if _, _, err := svc.BuildFullExport(context.Background(), "short", testutil.RandomMasterKey(t)); err == nil {
+		t.Error("expected a short password to be rejected")
+	}
+}
+
+func TestBuildFullExport_WrongEncryptionKey(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	storePKCS12TestCertificate(t, database, "app.example.com", testutil.RandomMasterKey(t))
+
+	if _, _, err := svc.BuildFullExport(context.Background(), testFullExportPassword, testutil.RandomMasterKey(t)); err == nil {
+		t.Error("expected a wrong encryption key to fail the export")
+	}
+	history, err := svc.GetHistory(context.Background(), "app.example.com", 10)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	for _, entry := range history {
+		if entry.EventType == models.EventFullExport {
+			t.Error("expected no history entry for a failed export")
+		}
+	}
+}
@@ -1,8 +1,15 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"os"
+	"os/user"
+	"strings"
+	"time"
 
 	"paddockcontrol-desktop/internal/db"
 	"paddockcontrol-desktop/internal/db/sqlc"
@@ -11,32 +18,67 @@ import (
 
 // HistoryService handles certificate activity history
 type HistoryService struct {
-	db *db.Database
+	db         *db.Database
+	actor      string
+	appVersion string
 }
 
-// NewHistoryService creates a new history service
-func NewHistoryService(database *db.Database) *HistoryService {
+// NewHistoryService creates a new history service. Every entry it records is
+// stamped with the current OS username and the given application version, so
+// that shared databases show who changed what with which build.
+func NewHistoryService(database *db.Database, appVersion string) *HistoryService {
 	return &HistoryService{
-		db: database,
+		db:         database,
+		actor:      currentActor(),
+		appVersion: appVersion,
 	}
 }
 
+// currentActor returns the OS username of the current process, falling back to
+// the USER/USERNAME environment variables when the user database is unavailable.
+func currentActor() string {
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return os.Getenv("USERNAME")
+}
+
 // LogEvent adds a new history entry for a certificate.
 func (s *HistoryService) LogEvent(ctx context.Context, hostname, eventType, message string) error {
-	return logEvent(ctx, s.db.Queries(), hostname, eventType, message)
+	return s.logEvent(ctx, s.db.Queries(), hostname, eventType, message, nil)
 }
 
 // LogEventTx adds a history entry using the provided transaction-scoped queries
 // so it commits atomically with the caller's other writes.
 func (s *HistoryService) LogEventTx(ctx context.Context, q *sqlc.Queries, hostname, eventType, message string) error {
-	return logEvent(ctx, q, hostname, eventType, message)
+	return s.logEvent(ctx, q, hostname, eventType, message, nil)
 }
 
-func logEvent(ctx context.Context, q *sqlc.Queries, hostname, eventType, message string) error {
+// LogEventDetailsTx is LogEventTx with structured event details, stored as JSON.
+func (s *HistoryService) LogEventDetailsTx(ctx context.Context, q *sqlc.Queries, hostname, eventType, message string, details map[string]any) error {
+	return s.logEvent(ctx, q, hostname, eventType, message, details)
+}
+
+func (s *HistoryService) logEvent(ctx context.Context, q *sqlc.Queries, hostname, eventType, message string, details map[string]any) error {
+	var encoded string
+	if len(details) > 0 {
+		data, err := json.Marshal(details)
+		if err != nil {
+			return fmt.Errorf("failed to encode history details: %w", err)
+		}
+		encoded = string(data)
+	}
+
 	return q.AddHistoryEntry(ctx, sqlc.AddHistoryEntryParams{
-		Hostname:  hostname,
-		EventType: eventType,
-		Message:   message,
+		Hostname:   hostname,
+		EventType:  eventType,
+		Message:    message,
+		Actor:      s.actor,
+		AppVersion: s.appVersion,
+		Details:    encoded,
 	})
 }
 
@@ -56,14 +98,158 @@ func (s *HistoryService) GetHistory(ctx context.Context, hostname string, limit
 
 	result := make([]models.HistoryEntry, len(entries))
 	for i, e := range entries {
-		result[i] = models.HistoryEntry{
-			ID:        e.ID,
-			Hostname:  e.Hostname,
-			EventType: e.EventType,
-			Message:   e.Message,
-			CreatedAt: e.CreatedAt,
-		}
+		result[i] = toHistoryEntry(e)
 	}
 
 	return result, nil
 }
+
+// maxHistoryPageSize caps the entries returned by one ListHistory call
+const maxHistoryPageSize = 500
+
+// ListHistory returns one page of history entries across all certificates,
+// most recent first, with the number of entries matching the filter. A
+// non-positive limit defaults to 50.
+func (s *HistoryService) ListHistory(ctx context.Context, filter models.HistoryFilter, limit, offset int) (*models.HistoryPage, error) {
+	params, err := historyFilterParams(filter)
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	limit = min(limit, maxHistoryPageSize)
+	offset = max(offset, 0)
+
+	total, err := s.db.Queries().CountHistory(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count history: %w", err)
+	}
+	entries, err := s.db.Queries().ListHistory(ctx, sqlc.ListHistoryParams{
+		EventTypes:  params.EventTypes,
+		CreatedFrom: params.CreatedFrom,
+		CreatedTo:   params.CreatedTo,
+		PageLimit:   int64(limit),
+		PageOffset:  int64(offset),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list history: %w", err)
+	}
+
+	page := &models.HistoryPage{
+		Entries: make([]models.HistoryEntry, len(entries)),
+		Total:   int(total),
+	}
+	for i, e := range entries {
+		page.Entries[i] = toHistoryEntry(e)
+	}
+	return page, nil
+}
+
+// BuildHistoryCSV renders every history entry matching the filter as CSV,
+// most recent first. Timestamps are RFC 3339 in UTC and details are JSON.
+func (s *HistoryService) BuildHistoryCSV(ctx context.Context, filter models.HistoryFilter) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{"timestamp", "hostname", "event_type", "actor", "app_version", "message", "details"}); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	for offset := 0; ; offset += maxHistoryPageSize {
+		page, err := s.ListHistory(ctx, filter, maxHistoryPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range page.Entries {
+			details := ""
+			if len(e.Details) > 0 {
+				data, err := json.Marshal(e.Details)
+				if err != nil {
+					return nil, fmt.Errorf("failed to encode history details: %w", err)
+				}
+				details = string(data)
+			}
+			if err := w.Write([]string{
+				time.Unix(e.CreatedAt, 0).UTC().Format(time.RFC3339),
+				e.Hostname,
+				e.EventType,
+				csvCell(e.Actor),
+				e.AppVersion,
+				csvCell(e.Message),
+				csvCell(details),
+			}); err != nil {
+				return nil, fmt.Errorf("failed to write CSV: %w", err)
+			}
+		}
+		if len(page.Entries) < maxHistoryPageSize {
+			break
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// historyFilterParams validates a history filter and converts it to query
+// parameters
+func historyFilterParams(filter models.HistoryFilter) (sqlc.CountHistoryParams, error) {
+	if filter.From < 0 || filter.To < 0 {
+		return sqlc.CountHistoryParams{}, fmt.Errorf("invalid date range")
+	}
+	if filter.To != 0 && filter.To <= filter.From {
+		return sqlc.CountHistoryParams{}, fmt.Errorf("invalid date range: end must be after start")
+	}
+
+	eventTypes := make([]string, 0, len(filter.EventTypes))
+	for _, eventType := range filter.EventTypes {
+		eventType = strings.TrimSpace(eventType)
+		if eventType == "" {
+			continue
+		}
+		// The query matches against a comma-separated list
+		if strings.Contains(eventType, ",") {
+			return sqlc.CountHistoryParams{}, fmt.Errorf("invalid event type: %s", eventType)
+		}
+		eventTypes = append(eventTypes, eventType)
+	}
+
+	return sqlc.CountHistoryParams{
+		EventTypes:  strings.Join(eventTypes, ","),
+		CreatedFrom: filter.From,
+		CreatedTo:   filter.To,
+	}, nil
+}
+
+// toHistoryEntry converts a history row, decoding its details
+func toHistoryEntry(e sqlc.CertificateHistory) models.HistoryEntry {
+	entry := models.HistoryEntry{
+		ID:         e.ID,
+		Hostname:   e.Hostname,
+		EventType:  e.EventType,
+		Message:    e.Message,
+		CreatedAt:  e.CreatedAt,
+		Actor:      e.Actor,
+		AppVersion: e.AppVersion,
+	}
+	if e.Details != "" {
+		// Details are written by logEvent; a malformed value is dropped
+		// rather than failing the whole history listing
+		var details map[string]any
+		if err := json.Unmarshal([]byte(e.Details), &details); err == nil {
+			entry.Details = details
+		}
+	}
+	return entry
+}
+
+// csvCell keeps a free-text value from being read as a formula when the CSV is
+// opened in a spreadsheet
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -1,8 +1,11 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"encoding/csv"
 	"testing"
+	"time"
 
 	"paddockcontrol-desktop/internal/db"
 	"paddockcontrol-desktop/internal/db/sqlc"
@@ -16,7 +19,7 @@ func setupHistoryService(t *testing.T) (*HistoryService, *db.Database) {
 		t.Fatalf("failed to create database: %v", err)
 	}
 	t.Cleanup(func() { database.Close() })
-	return NewHistoryService(database), database
+	return NewHistoryService(database, "test"), database
 }
 
 // seedCert inserts a minimal certificate so history rows for it satisfy the
@@ -193,3 +196,147 @@ func TestGetHistory_FiltersByHostname(t *testing.T) {
 		t.Fatalf("expected 1 entry for host2, got %d", len(entries))
 	}
 }
+
+func TestLogEvent_RecordsActorAndAppVersion(t *testing.T) {
+	svc, database := setupHistoryService(t)
+	ctx := context.Background()
+	seedCert(t, database, "test.example.com")
+
+	if err := svc.LogEvent(ctx, "test.example.com", models.EventCSRGenerated, "CSR generated"); err != nil {
+		t.Fatalf("LogEvent failed: %v", err)
+	}
+
+	entries, err := svc.GetHistory(ctx, "test.example.com", 10)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	if entries[0].AppVersion != "test" {
+		t.Errorf("expected app version 'test', got %q", entries[0].AppVersion)
+	}
+	if entries[0].Actor != currentActor() {
+		t.Errorf("expected actor %q, got %q", currentActor(), entries[0].Actor)
+	}
+	if entries[0].Details != nil {
+		t.Errorf("expected no details, got %v", entries[0].Details)
+	}
+}
+
+func TestLogEventDetailsTx_RoundTripsDetails(t *testing.T) {
+	svc, database := setupHistoryService(t)
+	ctx := context.Background()
+	seedCert(t, database, "test.example.com")
+
+	details := map[string]any{"old_expires_at": int64(1700000000), "new_expires_at": int64(1800000000)}
+	if err := svc.LogEventDetailsTx(ctx, database.Queries(), "test.example.com", models.EventCertificateUploaded, "Certificate uploaded", details); err != nil {
+		t.Fatalf("LogEventDetailsTx failed: %v", err)
+	}
+
+	entries, err := svc.GetHistory(ctx, "test.example.com", 10)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	// JSON numbers decode as float64
+	if got := entries[0].Details["old_expires_at"]; got != float64(1700000000) {
+		t.Errorf("expected old_expires_at 1700000000, got %v", got)
+	}
+	if got := entries[0].Details["new_expires_at"]; got != float64(1800000000) {
+		t.Errorf("expected new_expires_at 1800000000, got %v", got)
+	}
+}
+
+// seedHistoryAt logs an event and backdates it to createdAt
+func seedHistoryAt(t *testing.T, svc *HistoryService, database *db.Database, hostname, eventType, message string, createdAt int64) {
+	t.Helper()
+	ctx := context.Background()
+	if err := svc.LogEvent(ctx, hostname, eventType, message); err != nil {
+		t.Fatalf("LogEvent failed: %v", err)
+	}
+	if _, err := database.DB().ExecContext(ctx,
+		"UPDATE certificate_history SET created_at = ? WHERE id = (SELECT MAX(id) FROM certificate_history)", createdAt,
+	); err != nil {
+		t.Fatalf("failed to backdate history entry: %v", err)
+	}
+}
+
+func TestListHistory_FiltersAndPages(t *testing.T) {
+	svc, database := setupHistoryService(t)
+	ctx := context.Background()
+	seedCert(t, database, "a.example.com")
+	seedCert(t, database, "b.example.com")
+
+	feb := time.Date(2026, 2, 15, 12, 0, 0, 0, time.UTC).Unix()
+	mar1 := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC).Unix()
+	apr1 := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC).Unix()
+	seedHistoryAt(t, svc, database, "a.example.com", models.EventCSRGenerated, "CSR generated", feb)
+	seedHistoryAt(t, svc, database, "a.example.com", models.EventCertificateUploaded, "Uploaded", mar1)
+	seedHistoryAt(t, svc, database, "b.example.com", models.EventCSRGenerated, "CSR generated", mar1+3600)
+	seedHistoryAt(t, svc, database, "b.example.com", models.EventReadOnlyEnabled, "Locked", apr1)
+
+	page, err := svc.ListHistory(ctx, models.HistoryFilter{From: mar1, To: apr1}, 0, 0)
+	if err != nil {
+		t.Fatalf("ListHistory failed: %v", err)
+	}
+	if page.Total != 2 || len(page.Entries) != 2 {
+		t.Fatalf("expected the 2 March entries, got total %d: %+v", page.Total, page.Entries)
+	}
+	if page.Entries[0].Hostname != "b.example.com" || page.Entries[1].Hostname != "a.example.com" {
+		t.Errorf("expected most recent first, got %+v", page.Entries)
+	}
+
+	page, err = svc.ListHistory(ctx, models.HistoryFilter{EventTypes: []string{models.EventCSRGenerated}}, 1, 1)
+	if err != nil {
+		t.Fatalf("ListHistory failed: %v", err)
+	}
+	if page.Total != 2 || len(page.Entries) != 1 || page.Entries[0].CreatedAt != feb {
+		t.Errorf("expected the second CSR event on page 2, got total %d: %+v", page.Total, page.Entries)
+	}
+
+	page, err = svc.ListHistory(ctx, models.HistoryFilter{EventTypes: []string{models.EventCSRGenerated, models.EventReadOnlyEnabled}}, 10, 0)
+	if err != nil {
+		t.Fatalf("ListHistory failed: %v", err)
+	}
+	if page.Total != 3 {
+		t.Errorf("expected 3 entries for two event types, got %d", page.Total)
+	}
+
+	if _, err := svc.ListHistory(ctx, models.HistoryFilter{From: apr1, To: mar1}, 10, 0); err == nil {
+		t.Error("expected an inverted date range to be refused")
+	}
+	if _, err := svc.ListHistory(ctx, models.HistoryFilter{EventTypes: []string{"a,b"}}, 10, 0); err == nil {
+		t.Error("expected an event type with a comma to be refused")
+	}
+}
+
+func TestBuildHistoryCSV(t *testing.T) {
+	svc, database := setupHistoryService(t)
+	ctx := context.Background()
+	seedCert(t, database, "a.example.com")
+
+	createdAt := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC).Unix()
+	seedHistoryAt(t, svc, database, "a.example.com", models.EventCSRSubmitted, "=HYPERLINK(\"x\")", createdAt)
+
+	data, err := svc.BuildHistoryCSV(ctx, models.HistoryFilter{})
+	if err != nil {
+		t.Fatalf("BuildHistoryCSV failed: %v", err)
+	}
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("expected a header and 1 row, got %v", records)
+	}
+	row := records[1]
+	if row[0] != "2026-03-02T09:30:00Z" || row[1] != "a.example.com" || row[2] != models.EventCSRSubmitted {
+		t.Errorf("unexpected row: %v", row)
+	}
+	if row[5] != "'=HYPERLINK(\"x\")" {
+		t.Errorf("expected the formula to be neutralized, got %q", row[5])
+	}
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/logger"
+)
+
+// MigrateKeyEnvelopes re-encrypts every stored private key (active and pending)
+// that is not in the current envelope format, so older blobs do not have to be
+// read forever. Each blob is swapped only if it has not changed since it was
+// read, so the migration can run in the background next to normal edits.
+// Re-encryption does not count as a change for backup freshness: the old backups
+// still hold the same keys. Keys that do not decrypt are skipped. Returns the
+// number of blobs rewritten; stops early if ctx is cancelled.
+func (s *CertificateService) MigrateKeyEnvelopes(ctx context.Context, masterKey []byte) (int, error) {
+	log := logger.WithComponent("certificate")
+
+	certs, err := s.db.Queries().ListAllCertificatesIncludingDeleted(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	migrated := 0
+	for _, cert := range certs {
+		if err := ctx.Err(); err != nil {
+			return migrated, err
+		}
+
+		blobs := []struct {
+			encrypted []byte
+			replace   func(q *sqlc.Queries, newKey []byte) (int64, error)
+		}{
+			{cert.EncryptedPrivateKey, func(q *sqlc.Queries, newKey []byte) (int64, error) {
+				return q.ReplaceEncryptedPrivateKey(ctx, sqlc.ReplaceEncryptedPrivateKeyParams{
+					NewKey: newKey, Hostname: cert.Hostname, OldKey: cert.EncryptedPrivateKey,
+				})
+			}},
+			{cert.PendingEncryptedPrivateKey, func(q *sqlc.Queries, newKey []byte) (int64, error) {
+				return q.ReplacePendingEncryptedPrivateKey(ctx, sqlc.ReplacePendingEncryptedPrivateKeyParams{
+					NewKey: newKey, Hostname: cert.Hostname, OldKey: cert.PendingEncryptedPrivateKey,
+				})
+			}},
+		}
+
+		for _, blob := range blobs {
+			if len(blob.encrypted) == 0 || crypto.IsCurrentEnvelope(blob.encrypted) {
+				continue
+			}
+
+			reencrypted, err := reencryptKey(blob.encrypted, masterKey)
+			if err != nil {
+				// Left as is; ValidateEncryptedKeys reports keys that do not decrypt
+				log.Warn("failed to re-encrypt key, skipped",
+					slog.String("hostname", cert.Hostname),
+					logger.Err(err),
+				)
+				continue
+			}
+
+			var replaced int64
+			if err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+				var err error
+				if replaced, err = blob.replace(q, reencrypted); err != nil || replaced == 0 {
+					return err
+				}
+				return q.DiscountCertificateWrite(ctx)
+			}); err != nil {
+				return migrated, fmt.Errorf("failed to store re-encrypted key of %s: %w", cert.Hostname, err)
+			}
+
+			if replaced == 0 {
+				// Changed (or deleted) since it was read; the new blob is current
+				log.Debug("key changed during envelope migration, skipped", slog.String("hostname", cert.Hostname))
+				continue
+			}
+			migrated++
+		}
+	}
+
+	return migrated, nil
+}
+
+// reencryptKey decrypts an encrypted key blob and encrypts it again in the
+// current envelope format.
+func reencryptKey(encrypted, masterKey []byte) ([]byte, error) {
+	plaintext, err := crypto.DecryptPrivateKey(encrypted, masterKey)
+	if err != nil {
+		return nil, err
+	}
+	defer plaintext.Destroy()
+
+	return crypto.EncryptPrivateKey(plaintext.Bytes(), masterKey)
+}
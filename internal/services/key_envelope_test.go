@@ -0,0 +1,145 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"testing"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+// sealPreEnvelope encrypts data in the format written before envelopes existed:
+// nonce + ciphertext.
+func sealPreEnvelope(t *testing.T, data, key []byte) []byte {
+	t.Helper()
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatalf("failed to create cipher: %v", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatalf("failed to create GCM: %v", err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		t.Fatalf("failed to generate nonce: %v", err)
+	}
+	return gcm.Seal(nonce, nonce, data, nil)
+}
+
+func TestMigrateKeyEnvelopes(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	masterKey := testutil.RandomMasterKey(t)
+
+	activePEM := []byte("active key")
+	pendingPEM := []byte("pending key")
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:                   "legacy.example.com",
+		EncryptedPrivateKey:        sealPreEnvelope(t, activePEM, masterKey),
+		PendingEncryptedPrivateKey: sealPreEnvelope(t, pendingPEM, masterKey),
+		PendingCsrPem:              sql.NullString{String: "csr", Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	current, err := crypto.EncryptPrivateKey([]byte("current key"), masterKey)
+	if err != nil {
+		t.Fatalf("EncryptPrivateKey() error = %v", err)
+	}
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:            "current.example.com",
+		EncryptedPrivateKey: current,
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	before, err := database.Queries().GetConfig(ctx)
+	if err != nil {
+		t.Fatalf("GetConfig() error = %v", err)
+	}
+
+	migrated, err := svc.MigrateKeyEnvelopes(ctx, masterKey)
+	if err != nil {
+		t.Fatalf("MigrateKeyEnvelopes() error = %v", err)
+	}
+	if migrated != 2 {
+		t.Errorf("migrated = %d, want 2", migrated)
+	}
+
+	cert, err := database.Queries().GetCertificateByHostname(ctx, "legacy.example.com")
+	if err != nil {
+		t.Fatalf("failed to get certificate: %v", err)
+	}
+	for _, tc := range []struct {
+		blob []byte
+		want []byte
+	}{
+		{cert.EncryptedPrivateKey, activePEM},
+		{cert.PendingEncryptedPrivateKey, pendingPEM},
+	} {
+		if !crypto.IsCurrentEnvelope(tc.blob) {
+			t.Errorf("key blob was not rewritten in the current envelope")
+		}
+		plaintext, err := crypto.DecryptPrivateKey(tc.blob, masterKey)
+		if err != nil {
+			t.Fatalf("DecryptPrivateKey() error = %v", err)
+		}
+		if !bytes.Equal(plaintext.Bytes(), tc.want) {
+			t.Errorf("re-encrypted key = %q, want %q", plaintext.Bytes(), tc.want)
+		}
+		plaintext.Destroy()
+	}
+
+	unchanged, err := database.Queries().GetCertificateByHostname(ctx, "current.example.com")
+	if err != nil {
+		t.Fatalf("failed to get certificate: %v", err)
+	}
+	if !bytes.Equal(unchanged.EncryptedPrivateKey, current) {
+		t.Error("a key already in the current envelope should not be rewritten")
+	}
+
+	after, err := database.Queries().GetConfig(ctx)
+	if err != nil {
+		t.Fatalf("GetConfig() error = %v", err)
+	}
+	if after.WritesSinceBackup != before.WritesSinceBackup {
+		t.Errorf("writes_since_backup = %d, want %d: re-encryption is not a change",
+			after.WritesSinceBackup, before.WritesSinceBackup)
+	}
+
+	if migrated, err := svc.MigrateKeyEnvelopes(ctx, masterKey); err != nil || migrated != 0 {
+		t.Errorf("second run: migrated = %d, err = %v; want 0, nil", migrated, err)
+	}
+}
+
+func TestMigrateKeyEnvelopes_SkipsUndecryptableKeys(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	masterKey := testutil.RandomMasterKey(t)
+
+	foreign := sealPreEnvelope(t, []byte("key"), testutil.RandomMasterKey(t))
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:            "foreign.example.com",
+		EncryptedPrivateKey: foreign,
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	migrated, err := svc.MigrateKeyEnvelopes(ctx, masterKey)
+	if err != nil || migrated != 0 {
+		t.Fatalf("migrated = %d, err = %v; want 0, nil", migrated, err)
+	}
+	cert, err := database.Queries().GetCertificateByHostname(ctx, "foreign.example.com")
+	if err != nil {
+		t.Fatalf("failed to get certificate: %v", err)
+	}
+	if !bytes.Equal(cert.EncryptedPrivateKey, foreign) {
+		t.Error("a key that does not decrypt should be left as is")
+	}
+}
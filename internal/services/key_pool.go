@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	gocrypto "crypto"
+	"fmt"
+	"sync"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/logger"
+)
+
+// KeyPool holds RSA keys generated ahead of time, so GenerateCSR does not wait
+// for key generation (several seconds for 4096-bit keys on older hardware).
+// Keys are encrypted under the master key as soon as they are generated, and
+// are only pooled for one key size at a time. Safe for concurrent use; the
+// methods of a nil pool do nothing.
+type KeyPool struct {
+	mu      sync.Mutex
+	keySize int
+	keys    [][]byte // encrypted private key PEMs
+	// Bumped by Clear, so a fill in progress drops the key it is generating
+	generation int
+}
+
+// Take removes a pooled key of keySize bits and returns it decrypted, along
+// with its encrypted PEM ready to be stored. ok is false when the pool has
+// none; a key that no longer decrypts (the master key changed) is discarded.
+func (p *KeyPool) Take(keySize int, encryptionKey []byte) (key gocrypto.Signer, encrypted []byte, ok bool) {
+	if p == nil {
+		return nil, nil, false
+	}
+
+	p.mu.Lock()
+	if p.keySize != keySize || len(p.keys) == 0 {
+		p.mu.Unlock()
+		return nil, nil, false
+	}
+	encrypted = p.keys[len(p.keys)-1]
+	p.keys = p.keys[:len(p.keys)-1]
+	p.mu.Unlock()
+
+	keyPEM, err := crypto.DecryptPrivateKey(encrypted, encryptionKey)
+	if err != nil {
+		logger.WithComponent("key_pool").Warn("pooled key discarded", logger.Err(err))
+		return nil, nil, false
+	}
+	defer keyPEM.Destroy()
+
+	key, err = crypto.ParsePrivateKeyFromPEM(keyPEM.Bytes())
+	if err != nil {
+		logger.WithComponent("key_pool").Warn("pooled key discarded", logger.Err(err))
+		return nil, nil, false
+	}
+	return key, encrypted, true
+}
+
+// Fill generates keys of keySize bits until the pool holds target of them,
+// ctx is cancelled or generation fails. Keys of another size are dropped
+// first. Returns the number of keys added.
+func (p *KeyPool) Fill(ctx context.Context, keySize, target int, encryptionKey []byte) (int, error) {
+	if p == nil {
+		return 0, nil
+	}
+
+	added := 0
+	for {
+		p.mu.Lock()
+		if p.keySize != keySize {
+			p.keySize = keySize
+			p.keys = nil
+		}
+		generation := p.generation
+		full := len(p.keys) >= target
+		p.mu.Unlock()
+
+		if full {
+			return added, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return added, err
+		}
+
+		encrypted, err := generateEncryptedKey(keySize, encryptionKey)
+		if err != nil {
+			return added, err
+		}
+
+		p.mu.Lock()
+		if p.generation == generation && p.keySize == keySize {
+			p.keys = append(p.keys, encrypted)
+			added++
+		}
+		p.mu.Unlock()
+	}
+}
+
+// Clear drops the pooled keys, e.g. when the master key is cleared.
+func (p *KeyPool) Clear() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.keys = nil
+	p.generation++
+}
+
+// Available returns the number of pooled keys of keySize bits.
+func (p *KeyPool) Available(keySize int) int {
+	if p == nil {
+		return 0
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.keySize != keySize {
+		return 0
+	}
+	return len(p.keys)
+}
+
+// generateEncryptedKey generates an RSA key and returns its PEM encrypted
+// under the master key.
+func generateEncryptedKey(keySize int, encryptionKey []byte) ([]byte, error) {
+	privateKey, err := crypto.GenerateRSAKey(keySize)
+	if err != nil {
+		return nil, err
+	}
+	keyPEM, err := crypto.PrivateKeyToPEM(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	defer crypto.Zero(keyPEM)
+
+	encrypted, err := crypto.EncryptPrivateKey(keyPEM, encryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt private key: %w", err)
+	}
+	return encrypted, nil
+}
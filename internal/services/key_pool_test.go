@@ -0,0 +1,117 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+func TestKeyPool_FillAndTake(t *testing.T) {
+	encryptionKey := testutil.RandomMasterKey(t)
+	var pool KeyPool
+
+	added, err := pool.Fill(context.Background(), 2048, 2, encryptionKey)
+	if err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	if added != 2 || pool.Available(2048) != 2 {
+		t.Fatalf("expected 2 pooled keys, added %d, available %d", added, pool.Available(2048))
+	}
+	if pool.Available(4096) != 0 {
+		t.Error("expected no pooled keys of another size")
+	}
+
+	// A full pool is left alone
+	if added, _ := pool.Fill(context.Background(), 2048, 2, encryptionKey); added != 0 {
+		t.Errorf("expected a full pool not to grow, added %d", added)
+	}
+
+	if _, _, ok := pool.Take(4096, encryptionKey); ok {
+		t.Error("expected no key of another size")
+	}
+	key, encrypted, ok := pool.Take(2048, encryptionKey)
+	if !ok {
+		t.Fatal("expected a pooled key")
+	}
+	if _, bits := crypto.PublicKeyInfo(key.Public()); bits != 2048 || len(encrypted) == 0 {
+		t.Errorf("unexpected pooled key: %d bits, %d encrypted bytes", bits, len(encrypted))
+	}
+	if pool.Available(2048) != 1 {
+		t.Errorf("expected 1 pooled key left, got %d", pool.Available(2048))
+	}
+
+	// Another master key cannot decrypt the pooled key, which is discarded
+	if _, _, ok := pool.Take(2048, testutil.RandomMasterKey(t)); ok {
+		t.Error("expected the key not to be usable with another master key")
+	}
+	if pool.Available(2048) != 0 {
+		t.Error("expected the undecryptable key to be discarded")
+	}
+}
+
+func TestKeyPool_ClearAndResize(t *testing.T) {
+	encryptionKey := testutil.RandomMasterKey(t)
+	var pool KeyPool
+
+	if _, err := pool.Fill(context.Background(), 2048, 1, encryptionKey); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	pool.Clear()
+	if pool.Available(2048) != 0 {
+		t.Error("expected Clear to drop the pooled keys")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if added, err := pool.Fill(ctx, 2048, 1, encryptionKey); err == nil || added != 0 {
+		t.Errorf("expected a cancelled fill to stop, added %d, err %v", added, err)
+	}
+
+	var nilPool *KeyPool
+	if _, _, ok := nilPool.Take(2048, encryptionKey); ok || nilPool.Available(2048) != 0 {
+		t.Error("expected a nil pool to hold no keys")
+	}
+}
+
+func TestGenerateCSR_UsesKeyPool(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	var pool KeyPool
+	if _, err := pool.Fill(ctx, 2048, 1, encryptionKey); err != nil {
+		t.Fatalf("Fill failed: %v", err)
+	}
+	pool.mu.Lock()
+	pooled := bytes.Clone(pool.keys[0])
+	pool.mu.Unlock()
+	svc.SetKeyPool(&pool)
+
+	req := models.CSRRequest{
+		Hostname:     "pooled.example.com",
+		Organization: "Test Org",
+		City:         "Paris",
+		State:        "IDF",
+		Country:      "FR",
+		KeySize:      2048,
+	}
+	if _, err := svc.GenerateCSR(ctx, req, encryptionKey); err != nil {
+		t.Fatalf("GenerateCSR failed: %v", err)
+	}
+	if pool.Available(2048) != 0 {
+		t.Error("expected GenerateCSR to take the pooled key")
+	}
+
+	cert, err := database.Queries().GetCertificateByHostname(ctx, "pooled.example.com")
+	if err != nil {
+		t.Fatalf("failed to get certificate: %v", err)
+	}
+	if !bytes.Equal(cert.PendingEncryptedPrivateKey, pooled) {
+		t.Error("expected the pooled key to be stored as the pending key")
+	}
+}
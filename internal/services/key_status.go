@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// keyStatusSeverity orders key statuses from healthy to worst; a certificate
+// shows the worst status of its active and pending pairs
+var keyStatusSeverity = map[string]int{
+	models.KeyStatusOK:            1,
+	models.KeyStatusMissing:       2,
+	models.KeyStatusMismatched:    3,
+	models.KeyStatusUndecryptable: 4,
+}
+
+// computeKeyStatus checks a certificate's key pairs: the active key against the
+// issued certificate and the pending key against the pending CSR. Without the
+// master key only missing keys can be told apart; otherwise it returns "",
+// leaving the status to be computed on the next unlock.
+func computeKeyStatus(cert sqlc.Certificate, encryptionKey []byte) string {
+	type pair struct {
+		key     []byte
+		csrPEM  *string
+		certPEM *string
+	}
+	var pairs []pair
+	if cert.CertificatePem.Valid && cert.CertificatePem.String != "" {
+		pairs = append(pairs, pair{key: cert.EncryptedPrivateKey, certPEM: &cert.CertificatePem.String})
+	}
+	if cert.PendingCsrPem.Valid && cert.PendingCsrPem.String != "" {
+		pairs = append(pairs, pair{key: cert.PendingEncryptedPrivateKey, csrPEM: &cert.PendingCsrPem.String})
+	}
+
+	worst := ""
+	for _, p := range pairs {
+		var status string
+		switch {
+		case len(p.key) == 0:
+			status = models.KeyStatusMissing
+		case len(encryptionKey) == 0:
+			continue
+		default:
+			result := crypto.ValidateKeyMatches(p.key, p.csrPEM, p.certPEM, encryptionKey)
+			switch {
+			case result.KeyMatchesCSR == nil && result.KeyMatchesCert == nil:
+				// Stopped before comparing: the key does not decrypt or parse
+				status = models.KeyStatusUndecryptable
+			case result.KeyMatchesCSR != nil && !*result.KeyMatchesCSR,
+				result.KeyMatchesCert != nil && !*result.KeyMatchesCert:
+				status = models.KeyStatusMismatched
+			default:
+				status = models.KeyStatusOK
+			}
+		}
+		if keyStatusSeverity[status] > keyStatusSeverity[worst] {
+			worst = status
+		}
+	}
+	return worst
+}
+
+// RefreshKeyStatusTx recomputes and stores the key status of hostname within
+// the caller's transaction. encryptionKey may be nil when the app is locked.
+// Storing the status does not count as a write for backup freshness.
+func RefreshKeyStatusTx(ctx context.Context, q *sqlc.Queries, hostname string, encryptionKey []byte) error {
+	cert, err := q.GetCertificateByHostname(ctx, hostname)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get certificate: %w", err)
+	}
+
+	status := computeKeyStatus(cert, encryptionKey)
+	changed, err := q.UpdateCertificateKeyStatus(ctx, sqlc.UpdateCertificateKeyStatusParams{
+		KeyStatus: status,
+		Hostname:  hostname,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store key status: %w", err)
+	}
+	if changed == 0 {
+		return nil
+	}
+	return q.DiscountCertificateWrite(ctx)
+}
+
+// CheckUncheckedKeyStatuses computes the key status of every certificate that
+// has none yet (rows from before the column existed, or written while
+// locked). Run in the background after unlock; returns the number checked and
+// stops early if ctx is cancelled.
+func (s *CertificateService) CheckUncheckedKeyStatuses(ctx context.Context, masterKey []byte) (int, error) {
+	hostnames, err := s.db.Queries().ListUncheckedKeyStatusHostnames(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	checked := 0
+	for _, hostname := range hostnames {
+		if err := ctx.Err(); err != nil {
+			return checked, err
+		}
+		if err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+			return RefreshKeyStatusTx(ctx, q, hostname, masterKey)
+		}); err != nil {
+			return checked, fmt.Errorf("failed to check key status of %s: %w", hostname, err)
+		}
+		checked++
+	}
+
+	if checked > 0 {
+		logger.WithComponent("certificate").Debug("key statuses computed", slog.Int("checked", checked))
+	}
+	return checked, nil
+}
@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+func TestComputeKeyStatus(t *testing.T) {
+	encryptionKey := testutil.RandomMasterKey(t)
+	csrPEM, encryptedKey, privateKey := generateTestCSRAndKey(t, "a.example.com", encryptionKey)
+	certPEM, err := selfSignCertFromCSR(csrPEM, privateKey)
+	if err != nil {
+		t.Fatalf("failed to self-sign certificate: %v", err)
+	}
+	_, otherKey, _ := generateTestCSRAndKey(t, "b.example.com", encryptionKey)
+
+	active := func(key []byte) sqlc.Certificate {
+		return sqlc.Certificate{
+			EncryptedPrivateKey: key,
+			CertificatePem:      sql.NullString{String: certPEM, Valid: true},
+		}
+	}
+	renewal := active(encryptedKey)
+	renewal.PendingCsrPem = sql.NullString{String: string(csrPEM), Valid: true}
+	renewal.PendingEncryptedPrivateKey = otherKey
+
+	for _, tc := range []struct {
+		name string
+		cert sqlc.Certificate
+		key  []byte
+		want string
+	}{
+		{"matching pair", active(encryptedKey), encryptionKey, models.KeyStatusOK},
+		{"no key", active(nil), encryptionKey, models.KeyStatusMissing},
+		{"key of another certificate", active(otherKey), encryptionKey, models.KeyStatusMismatched},
+		{"wrong master key", active(encryptedKey), testutil.RandomMasterKey(t), models.KeyStatusUndecryptable},
+		{"pending pair mismatched", renewal, encryptionKey, models.KeyStatusMismatched},
+		{"locked", active(encryptedKey), nil, ""},
+		{"locked without key", active(nil), nil, models.KeyStatusMissing},
+	} {
+		if got := computeKeyStatus(tc.cert, tc.key); got != tc.want {
+			t.Errorf("%s: computeKeyStatus() = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestKeyStatus_StoredOnWrite(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	if _, err := svc.GenerateCSR(ctx, models.CSRRequest{
+		Hostname:     "web.example.com",
+		Organization: "Test Org",
+		City:         "Paris",
+		State:        "IDF",
+		Country:      "FR",
+		KeyAlgorithm: models.KeyAlgorithmECDSA,
+		KeySize:      256,
+	}, encryptionKey); err != nil {
+		t.Fatalf("GenerateCSR failed: %v", err)
+	}
+
+	items, err := svc.ListCertificates(ctx, models.CertificateFilter{})
+	if err != nil {
+		t.Fatalf("ListCertificates failed: %v", err)
+	}
+	if len(items) != 1 || items[0].KeyStatus != models.KeyStatusOK {
+		t.Fatalf("expected key_status %q after GenerateCSR, got %+v", models.KeyStatusOK, items)
+	}
+
+	before, err := database.Queries().GetConfig(ctx)
+	if err != nil {
+		t.Fatalf("GetConfig failed: %v", err)
+	}
+
+	// A row written while locked is left unchecked, then computed on unlock
+	if err := database.WithTx(ctx, func(q *sqlc.Queries) error {
+		return RefreshKeyStatusTx(ctx, q, "web.example.com", nil)
+	}); err != nil {
+		t.Fatalf("RefreshKeyStatusTx failed: %v", err)
+	}
+	cert, _ := database.Queries().GetCertificateByHostname(ctx, "web.example.com")
+	if cert.KeyStatus != "" {
+		t.Fatalf("expected an unchecked key status without the master key, got %q", cert.KeyStatus)
+	}
+
+	checked, err := svc.CheckUncheckedKeyStatuses(ctx, encryptionKey)
+	if err != nil || checked != 1 {
+		t.Fatalf("CheckUncheckedKeyStatuses() = %d, %v; want 1 checked", checked, err)
+	}
+	cert, _ = database.Queries().GetCertificateByHostname(ctx, "web.example.com")
+	if cert.KeyStatus != models.KeyStatusOK {
+		t.Errorf("expected key_status %q after the unlock check, got %q", models.KeyStatusOK, cert.KeyStatus)
+	}
+
+	after, err := database.Queries().GetConfig(ctx)
+	if err != nil {
+		t.Fatalf("GetConfig failed: %v", err)
+	}
+	if after.WritesSinceBackup != before.WritesSinceBackup {
+		t.Errorf("storing key statuses should not count as writes: %d -> %d", before.WritesSinceBackup, after.WritesSinceBackup)
+	}
+}
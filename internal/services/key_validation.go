@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// ValidateEncryptedKeys decrypts every stored private key (active and pending)
+// with the master key and reports the hostnames whose keys cannot be decrypted.
+// The key status of every certificate is refreshed on the way.
+// Unlock only checks the wrapped master key; this full-inventory check is slow
+// for large inventories and is meant to run in the background. progress, if not
+// nil, is called after each certificate. Stops early if ctx is cancelled.
+func (s *CertificateService) ValidateEncryptedKeys(ctx context.Context, masterKey []byte, progress func(models.KeyValidationProgress)) (*models.KeyValidationResult, error) {
+	certs, err := s.db.Queries().ListAllCertificates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	result := &models.KeyValidationResult{Valid: true}
+	for i, cert := range certs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for _, encrypted := range [][]byte{cert.EncryptedPrivateKey, cert.PendingEncryptedPrivateKey} {
+			if len(encrypted) == 0 {
+				continue
+			}
+			plaintext, err := crypto.DecryptPrivateKey(encrypted, masterKey)
+			plaintext.Destroy()
+			if err != nil {
+				result.Valid = false
+				result.FailedHostnames = append(result.FailedHostnames, cert.Hostname)
+				break
+			}
+		}
+		if err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+			return RefreshKeyStatusTx(ctx, q, cert.Hostname, masterKey)
+		}); err != nil {
+			return nil, fmt.Errorf("failed to refresh key status of %s: %w", cert.Hostname, err)
+		}
+
+		if progress != nil {
+			progress(models.KeyValidationProgress{Checked: i + 1, Total: len(certs)})
+		}
+	}
+
+	return result, nil
+}
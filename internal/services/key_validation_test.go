@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+// ============================================================================
+// ValidateEncryptedKeys Tests
+// ============================================================================
+
+func TestValidateEncryptedKeys_ReportsUndecryptableKeys(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	q := database.Queries()
+
+	masterKey := testutil.RandomMasterKey(t)
+	otherKey := testutil.RandomMasterKey(t)
+
+	encrypt := func(key []byte) []byte {
+		t.Helper()
+		encrypted, err := crypto.EncryptPrivateKey([]byte("private key"), key)
+		if err != nil {
+			t.Fatalf("failed to encrypt key: %v", err)
+		}
+		return encrypted
+	}
+
+	certs := []sqlc.CreateCertificateParams{
+		{Hostname: "ok.example.com", EncryptedPrivateKey: encrypt(masterKey)},
+		{Hostname: "pending.example.com", PendingEncryptedPrivateKey: encrypt(otherKey)},
+		{Hostname: "active.example.com", EncryptedPrivateKey: encrypt(otherKey), PendingEncryptedPrivateKey: encrypt(masterKey)},
+		{Hostname: "nokey.example.com"},
+	}
+	for _, c := range certs {
+		if err := q.CreateCertificate(ctx, c); err != nil {
+			t.Fatalf("failed to create certificate %s: %v", c.Hostname, err)
+		}
+	}
+
+	var updates []models.KeyValidationProgress
+	result, err := svc.ValidateEncryptedKeys(ctx, masterKey, func(p models.KeyValidationProgress) {
+		updates = append(updates, p)
+	})
+	if err != nil {
+		t.Fatalf("ValidateEncryptedKeys failed: %v", err)
+	}
+
+	if result.Valid {
+		t.Error("expected validation to fail")
+	}
+	if len(result.FailedHostnames) != 2 {
+		t.Fatalf("expected 2 failed hostnames, got %v", result.FailedHostnames)
+	}
+	for _, h := range result.FailedHostnames {
+		if h != "pending.example.com" && h != "active.example.com" {
+			t.Errorf("unexpected failed hostname %s", h)
+		}
+	}
+
+	if len(updates) != len(certs) {
+		t.Fatalf("expected %d progress updates, got %d", len(certs), len(updates))
+	}
+	last := updates[len(updates)-1]
+	if last.Checked != len(certs) || last.Total != len(certs) {
+		t.Errorf("unexpected final progress: %+v", last)
+	}
+}
+
+func TestValidateEncryptedKeys_StopsWhenCancelled(t *testing.T) {
+	svc, database := setupTestService(t)
+	q := database.Queries()
+
+	masterKey := testutil.RandomMasterKey(t)
+	encrypted, err := crypto.EncryptPrivateKey([]byte("private key"), masterKey)
+	if err != nil {
+		t.Fatalf("failed to encrypt key: %v", err)
+	}
+	if err := q.CreateCertificate(context.Background(), sqlc.CreateCertificateParams{
+		Hostname:            "web.example.com",
+		EncryptedPrivateKey: encrypted,
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := svc.ValidateEncryptedKeys(ctx, masterKey, nil); err == nil {
+		t.Fatal("expected error for cancelled context, got nil")
+	}
+}
@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// leadTimeEvents are the history events that start, end or abandon a wait for
+// the CA
+var leadTimeEvents = []string{
+	models.EventCSRGenerated,
+	models.EventCSRRegenerated,
+	models.EventCertificateUploaded,
+	models.EventPendingCSRRemoved,
+	models.EventCertificateDeleted,
+}
+
+// GetRenewalLeadTimes measures, from the history, how long each CA took between
+// CSR generation and the upload of the signed certificate, so the "expiring
+// soon" threshold can leave enough time for a renewal. A regenerated CSR
+// restarts the wait. Uploads record their issuer; older ones fall back to the
+// issuer of the active certificate when they activated it.
+func (s *CertificateService) GetRenewalLeadTimes(ctx context.Context) (*models.LeadTimeReport, error) {
+	var entries []models.HistoryEntry
+	filter := models.HistoryFilter{EventTypes: leadTimeEvents}
+	for offset := 0; ; offset += maxHistoryPageSize {
+		page, err := s.history.ListHistory(ctx, filter, maxHistoryPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, page.Entries...)
+		if len(page.Entries) < maxHistoryPageSize {
+			break
+		}
+	}
+	// Oldest first, one hostname after the other
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Hostname != entries[j].Hostname {
+			return entries[i].Hostname < entries[j].Hostname
+		}
+		if entries[i].CreatedAt != entries[j].CreatedAt {
+			return entries[i].CreatedAt < entries[j].CreatedAt
+		}
+		return entries[i].ID < entries[j].ID
+	})
+
+	activeIssuers, err := s.activeIssuers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make(map[string][]float64)
+	var all []float64
+	pending := 0
+	for i := 0; i < len(entries); {
+		hostname := entries[i].Hostname
+		end := i
+		for end < len(entries) && entries[end].Hostname == hostname {
+			end++
+		}
+		lastUpload := -1
+		for j := i; j < end; j++ {
+			if entries[j].EventType == models.EventCertificateUploaded {
+				lastUpload = j
+			}
+		}
+
+		var started int64
+		waiting := false
+		for j := i; j < end; j++ {
+			e := entries[j]
+			switch e.EventType {
+			case models.EventCSRGenerated, models.EventCSRRegenerated:
+				started, waiting = e.CreatedAt, true
+			case models.EventPendingCSRRemoved, models.EventCertificateDeleted:
+				waiting = false
+			case models.EventCertificateUploaded:
+				if !waiting {
+					continue
+				}
+				waiting = false
+				issuer, _ := e.Details["issuer_cn"].(string)
+				if issuer == "" && j == lastUpload {
+					issuer = activeIssuers[hostname]
+				}
+				if issuer == "" {
+					issuer = models.UnknownIssuer
+				}
+				days := float64(max(e.CreatedAt-started, 0)) / 86400
+				samples[issuer] = append(samples[issuer], days)
+				all = append(all, days)
+			}
+		}
+		if waiting {
+			pending++
+		}
+		i = end
+	}
+
+	report := &models.LeadTimeReport{
+		Issuers:               make([]models.CALeadTime, 0, len(samples)),
+		Pending:               pending,
+		ExpiringThresholdDays: s.expiringThresholdDays(ctx),
+	}
+	for issuer, days := range samples {
+		report.Issuers = append(report.Issuers, summarizeLeadTimes(issuer, days))
+	}
+	sort.Slice(report.Issuers, func(i, j int) bool {
+		if report.Issuers[i].Samples != report.Issuers[j].Samples {
+			return report.Issuers[i].Samples > report.Issuers[j].Samples
+		}
+		return report.Issuers[i].Issuer < report.Issuers[j].Issuer
+	})
+	if len(all) > 0 {
+		overall := summarizeLeadTimes("", all)
+		report.Overall = &overall
+	}
+	return report, nil
+}
+
+// activeIssuers returns the issuer name of each active certificate
+func (s *CertificateService) activeIssuers(ctx context.Context) (map[string]string, error) {
+	certs, err := s.db.Queries().ListAllCertificates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+	issuers := make(map[string]string, len(certs))
+	for _, cert := range certs {
+		if !cert.CertificatePem.Valid || cert.CertificatePem.String == "" {
+			continue
+		}
+		if parsed, err := crypto.ParseCertificate([]byte(cert.CertificatePem.String)); err == nil {
+			issuers[cert.Hostname] = issuerName(parsed.Issuer.CommonName, parsed.Issuer.Organization)
+		}
+	}
+	return issuers, nil
+}
+
+// issuerName names a CA by its common name, or its organization without one
+func issuerName(commonName string, organization []string) string {
+	if commonName == "" && len(organization) > 0 {
+		return organization[0]
+	}
+	return commonName
+}
+
+// summarizeLeadTimes computes the statistics of the lead times, in days, of one CA
+func summarizeLeadTimes(issuer string, days []float64) models.CALeadTime {
+	sorted := append([]float64(nil), days...)
+	sort.Float64s(sorted)
+
+	var total float64
+	for _, d := range sorted {
+		total += d
+	}
+	n := len(sorted)
+	median := sorted[n/2]
+	if n%2 == 0 {
+		median = (sorted[n/2-1] + sorted[n/2]) / 2
+	}
+	average := total / float64(n)
+
+	return models.CALeadTime{
+		Issuer:                 issuer,
+		Samples:                n,
+		AverageDays:            average,
+		MedianDays:             median,
+		MinDays:                sorted[0],
+		MaxDays:                sorted[n-1],
+		RecommendedWarningDays: int(math.Ceil(max(2*average+3, sorted[n-1]))),
+	}
+}
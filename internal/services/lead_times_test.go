@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// seedLeadTimeEvent logs an event with an optional issuer and backdates it by
+// the given number of days
+func seedLeadTimeEvent(t *testing.T, svc *CertificateService, database *db.Database, hostname, eventType, issuer string, day int64) {
+	t.Helper()
+	ctx := context.Background()
+	var details map[string]any
+	if issuer != "" {
+		details = map[string]any{"issuer_cn": issuer}
+	}
+	if err := svc.history.LogEventDetailsTx(ctx, database.Queries(), hostname, eventType, eventType, details); err != nil {
+		t.Fatalf("LogEventDetailsTx failed: %v", err)
+	}
+	if _, err := database.DB().ExecContext(ctx,
+		"UPDATE certificate_history SET created_at = ? WHERE id = (SELECT MAX(id) FROM certificate_history)", 1700000000+day*86400,
+	); err != nil {
+		t.Fatalf("failed to backdate history entry: %v", err)
+	}
+}
+
+func TestGetRenewalLeadTimes(t *testing.T) {
+	svc, database := setupTestService(t)
+	for _, hostname := range []string{"a.example.com", "b.example.com", "c.example.com", "d.example.com"} {
+		seedCert(t, database, hostname)
+	}
+
+	// a: 4 days, then a regenerated CSR restarts the wait: 10 days
+	seedLeadTimeEvent(t, svc, database, "a.example.com", models.EventCSRGenerated, "", 0)
+	seedLeadTimeEvent(t, svc, database, "a.example.com", models.EventCertificateUploaded, "Corp CA", 4)
+	seedLeadTimeEvent(t, svc, database, "a.example.com", models.EventCSRRegenerated, "", 100)
+	seedLeadTimeEvent(t, svc, database, "a.example.com", models.EventCSRRegenerated, "", 105)
+	seedLeadTimeEvent(t, svc, database, "a.example.com", models.EventCertificateUploaded, "Corp CA", 115)
+	// b: 13 days with the same CA
+	seedLeadTimeEvent(t, svc, database, "b.example.com", models.EventCSRGenerated, "", 0)
+	seedLeadTimeEvent(t, svc, database, "b.example.com", models.EventCertificateUploaded, "Corp CA", 13)
+	// c: an upload recorded before issuers were, with no active certificate
+	seedLeadTimeEvent(t, svc, database, "c.example.com", models.EventCSRGenerated, "", 0)
+	seedLeadTimeEvent(t, svc, database, "c.example.com", models.EventCertificateUploaded, "", 2)
+	// d: a cancelled CSR is not measured, the next one is still pending
+	seedLeadTimeEvent(t, svc, database, "d.example.com", models.EventCSRRegenerated, "", 0)
+	seedLeadTimeEvent(t, svc, database, "d.example.com", models.EventPendingCSRRemoved, "", 1)
+	seedLeadTimeEvent(t, svc, database, "d.example.com", models.EventCertificateUploaded, "Corp CA", 50)
+	seedLeadTimeEvent(t, svc, database, "d.example.com", models.EventCSRRegenerated, "", 60)
+
+	report, err := svc.GetRenewalLeadTimes(context.Background())
+	if err != nil {
+		t.Fatalf("GetRenewalLeadTimes failed: %v", err)
+	}
+
+	if report.Pending != 1 {
+		t.Errorf("expected 1 pending CSR, got %d", report.Pending)
+	}
+	if report.ExpiringThresholdDays != 30 {
+		t.Errorf("expected the default threshold, got %d", report.ExpiringThresholdDays)
+	}
+	if len(report.Issuers) != 2 {
+		t.Fatalf("expected 2 issuers, got %+v", report.Issuers)
+	}
+	want := models.CALeadTime{
+		Issuer:                 "Corp CA",
+		Samples:                3,
+		AverageDays:            9,
+		MedianDays:             10,
+		MinDays:                4,
+		MaxDays:                13,
+		RecommendedWarningDays: 21,
+	}
+	if report.Issuers[0] != want {
+		t.Errorf("expected %+v, got %+v", want, report.Issuers[0])
+	}
+	if report.Issuers[1].Issuer != models.UnknownIssuer || report.Issuers[1].Samples != 1 {
+		t.Errorf("expected the unrecorded issuer to be unknown, got %+v", report.Issuers[1])
+	}
+	if report.Overall == nil || report.Overall.Samples != 4 || report.Overall.MedianDays != 7 {
+		t.Errorf("unexpected overall lead time: %+v", report.Overall)
+	}
+}
+
+func TestGetRenewalLeadTimes_Empty(t *testing.T) {
+	svc, _ := setupTestService(t)
+
+	report, err := svc.GetRenewalLeadTimes(context.Background())
+	if err != nil {
+		t.Fatalf("GetRenewalLeadTimes failed: %v", err)
+	}
+	if report.Overall != nil || len(report.Issuers) != 0 || report.Pending != 0 {
+		t.Errorf("expected an empty report, got %+v", report)
+	}
+}
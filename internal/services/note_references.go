@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/noterefs"
+)
+
+// refreshNoteReferences extracts the references of the certificates whose
+// notes changed since the last extraction. Triggers queue them in
+// note_references_pending, so this is a no-op when nothing changed.
+func (s *CertificateService) refreshNoteReferences(ctx context.Context) error {
+	pending, err := s.db.Queries().ListPendingNoteReferences(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list pending note references: %w", err)
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	return s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		return refreshNoteReferencesTx(ctx, q)
+	})
+}
+
+// refreshNoteReferencesTx replaces the stored references of every queued
+// certificate with the ones found in its current notes, and empties the queue
+func refreshNoteReferencesTx(ctx context.Context, q *sqlc.Queries) error {
+	pending, err := q.ListPendingNoteReferences(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list pending note references: %w", err)
+	}
+
+	pattern, err := q.GetTicketPattern(ctx)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to get ticket pattern: %w", err)
+	}
+	// The pattern is validated when saved; one that no longer compiles only
+	// disables ticket extraction
+	ticket, _ := noterefs.CompileTicketPattern(pattern)
+
+	for _, row := range pending {
+		if err := q.DeleteNoteReferences(ctx, row.Hostname); err != nil {
+			return fmt.Errorf("failed to clear note references of %s: %w", row.Hostname, err)
+		}
+		notes := []struct {
+			source string
+			text   sql.NullString
+		}{
+			{models.NoteSourceNote, row.Note},
+			{models.NoteSourcePendingNote, row.PendingNote},
+		}
+		for _, note := range notes {
+			for _, ref := range noterefs.Extract(note.text.String, ticket) {
+				if err := q.AddNoteReference(ctx, sqlc.AddNoteReferenceParams{
+					Hostname: row.Hostname,
+					Source:   note.source,
+					Kind:     ref.Kind,
+					Value:    ref.Value,
+				}); err != nil {
+					return fmt.Errorf("failed to store note reference of %s: %w", row.Hostname, err)
+				}
+			}
+		}
+		if err := q.ClearPendingNoteReferences(ctx, row.Hostname); err != nil {
+			return fmt.Errorf("failed to mark note references of %s as extracted: %w", row.Hostname, err)
+		}
+	}
+	return nil
+}
+
+// listNoteReferences returns the URLs and ticket IDs found in the notes of a
+// certificate
+func (s *CertificateService) listNoteReferences(ctx context.Context, hostname string) ([]models.NoteReference, error) {
+	if err := s.refreshNoteReferences(ctx); err != nil {
+		return nil, err
+	}
+	rows, err := s.db.Queries().ListNoteReferences(ctx, hostname)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list note references of %s: %w", hostname, err)
+	}
+
+	refs := make([]models.NoteReference, 0, len(rows))
+	for _, row := range rows {
+		refs = append(refs, models.NoteReference{Source: row.Source, Kind: row.Kind, Value: row.Value})
+	}
+	return refs, nil
+}
+
+// hostnamesReferencing returns the set of certificates whose notes reference
+// value, a URL or ticket ID
+func (s *CertificateService) hostnamesReferencing(ctx context.Context, value string) (map[string]bool, error) {
+	if err := s.refreshNoteReferences(ctx); err != nil {
+		return nil, err
+	}
+	hostnames, err := s.db.Queries().ListHostnamesByNoteReference(ctx, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find certificates referencing %s: %w", value, err)
+	}
+
+	set := make(map[string]bool, len(hostnames))
+	for _, hostname := range hostnames {
+		set[hostname] = true
+	}
+	return set, nil
+}
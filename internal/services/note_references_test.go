@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+func TestNoteReferences_ExtractedOnRead(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	q := database.Queries()
+	createRelationTestCertificates(t, q, "web.example.com", "db.example.com")
+
+	setNote := func(hostname, note string) {
+		t.Helper()
+		if err := q.UpdateCertificateNote(ctx, sqlc.UpdateCertificateNoteParams{
+			Note:     sql.NullString{String: note, Valid: true},
+			Hostname: hostname,
+		}); err != nil {
+			t.Fatalf("UpdateCertificateNote failed: %v", err)
+		}
+	}
+	setNote("web.example.com", "Renewed under CHG0012345, see https://wiki.example.com/pki.")
+	setNote("db.example.com", "Nothing to see")
+
+	cert, err := svc.GetCertificate(ctx, "web.example.com")
+	if err != nil {
+		t.Fatalf("GetCertificate failed: %v", err)
+	}
+	want := models.NoteReference{Source: models.NoteSourceNote, Kind: models.NoteReferenceURL, Value: "https://wiki.example.com/pki"}
+	if len(cert.NoteReferences) != 1 || cert.NoteReferences[0] != want {
+		t.Fatalf("expected only the URL without a ticket pattern, got %+v", cert.NoteReferences)
+	}
+
+	// Setting a ticket pattern queues every note for extraction again
+	if _, err := database.DB().ExecContext(ctx, `UPDATE config SET ticket_pattern = 'CHG\d{7}'`); err != nil {
+		t.Fatalf("failed to set ticket pattern: %v", err)
+	}
+	setNote("db.example.com", "Same change as web: chg0012345")
+
+	items, err := svc.ListCertificates(ctx, models.CertificateFilter{Reference: "CHG0012345", SortBy: "hostname", SortOrder: "asc"})
+	if err != nil {
+		t.Fatalf("ListCertificates failed: %v", err)
+	}
+	if len(items) != 1 || items[0].Hostname != "web.example.com" {
+		t.Fatalf("expected web.example.com (the pattern is case-sensitive), got %+v", items)
+	}
+
+	setNote("db.example.com", "Same change as web: CHG0012345")
+	items, err = svc.ListCertificates(ctx, models.CertificateFilter{Reference: "chg0012345", SortBy: "hostname", SortOrder: "asc"})
+	if err != nil {
+		t.Fatalf("ListCertificates failed: %v", err)
+	}
+	if len(items) != 2 || items[0].Hostname != "db.example.com" {
+		t.Fatalf("expected both certificates, matched case-insensitively, got %+v", items)
+	}
+
+	// Clearing a note drops its references
+	if err := q.UpdateCertificateNote(ctx, sqlc.UpdateCertificateNoteParams{Hostname: "web.example.com"}); err != nil {
+		t.Fatalf("UpdateCertificateNote failed: %v", err)
+	}
+	cert, err = svc.GetCertificate(ctx, "web.example.com")
+	if err != nil {
+		t.Fatalf("GetCertificate failed: %v", err)
+	}
+	if len(cert.NoteReferences) != 0 {
+		t.Errorf("expected no references once the note is cleared, got %+v", cert.NoteReferences)
+	}
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"paddockcontrol-desktop/internal/models"
+)
+
+// Notes are stored in clear text, so they must never hold a private key or a
+// password. Notes are checked before they are written, and ScanNotesForSecrets
+// reports the ones already stored (e.g. restored from an older backup).
+
+// ErrNoteContainsSecret is wrapped by the error returned when a note is refused
+// because it looks like it holds a secret.
+var ErrNoteContainsSecret = errors.New("note appears to contain a secret")
+
+var (
+	privateKeyPattern = regexp.MustCompile(`-----BEGIN (?:[A-Z0-9]+ )*PRIVATE KEY(?: BLOCK)?-----`)
+	passwordPattern   = regexp.MustCompile(`(?i)\b(pass(?:word|wd|phrase)?|pwd|secret|api[_-]?key|token)\s*[:=]\s*(\S+)`)
+	tokenPattern      = regexp.MustCompile(`[A-Za-z0-9+/=_.\-]{24,}`)
+	hexPattern        = regexp.MustCompile(`^[0-9a-fA-F]+$`)
+	wordPattern       = regexp.MustCompile(`^([A-Z]?[a-z]+|[A-Z]{1,5}|[0-9]+)$`)
+	tokenSeparators   = regexp.MustCompile(`[-_./+=]+`)
+)
+
+// minSecretEntropyRatio is the Shannon entropy of a token, relative to the
+// highest possible for its length, above which it is reported. Random base64
+// tokens score above 0.8; identifiers and sentences joined by dashes stay below.
+const minSecretEntropyRatio = 0.8
+
+// ScanTextForSecrets returns what in text looks like a private key, a password
+// assignment or a random token. Hex strings are not reported: notes often quote
+// fingerprints and serial numbers.
+func ScanTextForSecrets(text string) []models.SecretFinding {
+	findings := []models.SecretFinding{}
+
+	if loc := privateKeyPattern.FindStringIndex(text); loc != nil {
+		findings = append(findings, models.SecretFinding{
+			Kind:        "private_key",
+			Description: "PEM private key",
+			Excerpt:     text[loc[0]:loc[1]],
+		})
+	}
+
+	for _, m := range passwordPattern.FindAllStringSubmatch(text, -1) {
+		// "password: in the vault" is fine; a value mixing character classes is not
+		if len(m[2]) < 6 || characterClasses(m[2]) < 2 {
+			continue
+		}
+		findings = append(findings, models.SecretFinding{
+			Kind:        "password",
+			Description: fmt.Sprintf("value assigned to %q", m[1]),
+			Excerpt:     m[1] + "=" + maskSecret(m[2]),
+		})
+	}
+
+	// The body of a PEM key is random too; the key is reported once
+	if len(findings) > 0 && findings[0].Kind == "private_key" {
+		return findings
+	}
+
+	for _, token := range tokenPattern.FindAllString(text, -1) {
+		if !looksRandom(token) {
+			continue
+		}
+		findings = append(findings, models.SecretFinding{
+			Kind:        "high_entropy",
+			Description: fmt.Sprintf("random-looking %d-character token", len(token)),
+			Excerpt:     maskSecret(token),
+		})
+	}
+
+	return findings
+}
+
+// checkNoteForSecrets refuses a note that looks like it holds a secret.
+func checkNoteForSecrets(note string) error {
+	findings := ScanTextForSecrets(note)
+	if len(findings) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w (%s: %s); store secrets in a password manager instead",
+		ErrNoteContainsSecret, findings[0].Description, findings[0].Excerpt)
+}
+
+// ScanNotesForSecrets checks every stored note and pending note and reports the
+// ones that look like they hold a secret. Nothing is modified.
+func (s *CertificateService) ScanNotesForSecrets(ctx context.Context) (*models.NoteScanResult, error) {
+	certs, err := s.db.Queries().ListAllCertificates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	result := &models.NoteScanResult{Reports: []models.NoteSecretReport{}}
+	for _, cert := range certs {
+		for _, field := range []struct {
+			name  string
+			value string
+		}{
+			{"note", cert.Note.String},
+			{"pending_note", cert.PendingNote.String},
+		} {
+			if field.value == "" {
+				continue
+			}
+			result.Scanned++
+			if findings := ScanTextForSecrets(field.value); len(findings) > 0 {
+				result.Reports = append(result.Reports, models.NoteSecretReport{
+					Hostname: cert.Hostname,
+					Field:    field.name,
+					Findings: findings,
+				})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// maskSecret keeps the first characters of a suspected secret so the user can
+// locate it, and hides the rest.
+func maskSecret(s string) string {
+	const visible = 3
+	if len(s) <= visible {
+		return strings.Repeat("*", len(s))
+	}
+	return s[:visible] + strings.Repeat("*", min(len(s)-visible, 8))
+}
+
+// looksRandom reports whether a long token looks like a generated secret rather
+// than a fingerprint, an identifier or words joined by separators.
+func looksRandom(token string) bool {
+	if hexPattern.MatchString(token) || characterClasses(token) < 3 {
+		return false
+	}
+
+	segments := tokenSeparators.Split(strings.Trim(token, "-_./+="), -1)
+	words := 0
+	for _, segment := range segments {
+		if wordPattern.MatchString(segment) {
+			words++
+		}
+	}
+	if words*2 > len(segments) {
+		return false
+	}
+
+	return shannonEntropy(token)/math.Log2(float64(len(token))) >= minSecretEntropyRatio
+}
+
+// characterClasses counts which of lower case, upper case, digits and symbols
+// appear in s.
+func characterClasses(s string) int {
+	var lower, upper, digit, symbol int
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			symbol = 1
+		}
+	}
+	return lower + upper + digit + symbol
+}
+
+// shannonEntropy returns the Shannon entropy of s in bits per character.
+func shannonEntropy(s string) float64 {
+	counts := map[rune]int{}
+	for _, r := range s {
+		counts[r]++
+	}
+	entropy := 0.0
+	n := float64(len(s))
+	for _, c := range counts {
+		p := float64(c) / n
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"paddockcontrol-desktop/internal/models"
+)
+
+// shellSafe matches words that need no quoting in a POSIX shell
+var shellSafe = regexp.MustCompile(`^[A-Za-z0-9._:/@+=-]+$`)
+
+// BuildOpenSSLCommands returns the openssl commands checking a certificate's
+// downloads (content, key match, chain) and the server it is deployed on, with
+// the file names the downloads default to. format is the configured download
+// format: the commands read PEM files, so DER downloads get a note on
+// converting them. Nothing is decrypted.
+func (s *CertificateService) BuildOpenSSLCommands(ctx context.Context, hostname, format string) (*models.OpenSSLCommands, error) {
+	cert, err := s.GetCertificate(ctx, hostname)
+	if err != nil {
+		return nil, err
+	}
+	if cert.CertificatePEM == "" {
+		return nil, fmt.Errorf("certificate for %s has not been issued yet", hostname)
+	}
+
+	host := cert.Hostname
+	leaf := shellQuote(host + ".crt")
+	key := shellQuote(host + ".key")
+	root := shellQuote(host + "-root.crt")
+	fullchain := shellQuote(host + "-fullchain.pem")
+
+	// A wildcard can't be connected to; default to its parent domain
+	target := strings.TrimPrefix(host, "*.")
+	connect := fmt.Sprintf("openssl s_client -connect %s -servername %s", shellQuote(target+":443"), shellQuote(target))
+
+	var fingerprint string
+	if cert.Extensions != nil {
+		fingerprint = cert.Extensions.FingerprintSHA256
+	}
+
+	result := &models.OpenSSLCommands{
+		Hostname:          host,
+		FingerprintSHA256: fingerprint,
+		Files:             []string{host + ".crt", host + ".key", host + "-root.crt", host + "-fullchain.pem"},
+		Commands: []models.OpenSSLCommand{
+			{
+				Title:       "Inspect the certificate",
+				Description: "Check the subject, issuer, validity dates and SANs.",
+				Command:     fmt.Sprintf("openssl x509 -in %s -noout -subject -issuer -dates -ext subjectAltName", leaf),
+			},
+			{
+				Title:       "Check the fingerprint",
+				Description: "The SHA-256 fingerprint must match " + fingerprint + ".",
+				Command:     fmt.Sprintf("openssl x509 -in %s -noout -fingerprint -sha256", leaf),
+			},
+			{
+				Title:       "Check the private key matches",
+				Description: "Both commands must print the same hash.",
+				Command: fmt.Sprintf("openssl x509 -in %s -noout -pubkey | openssl sha256\nopenssl pkey -in %s -pubout | openssl sha256",
+					leaf, key),
+			},
+			{
+				Title:       "Verify the chain",
+				Description: "Must print \"" + host + ".crt: OK\".",
+				Command:     fmt.Sprintf("openssl verify -CAfile %s -untrusted %s %s", root, fullchain, leaf),
+			},
+			{
+				Title:       "Verify the deployment",
+				Description: "Must end with \"Verify return code: 0 (ok)\": the server sends the full chain for the right name.",
+				Command: fmt.Sprintf("%s -CAfile %s -verify_return_error -verify_hostname %s -showcerts </dev/null",
+					connect, root, shellQuote(target)),
+			},
+			{
+				Title:       "Check the served certificate",
+				Description: "The fingerprint must match " + fingerprint + ", or the server still serves an old certificate.",
+				Command:     connect + " </dev/null 2>/dev/null | openssl x509 -noout -fingerprint -sha256 -enddate",
+			},
+		},
+		Notes: []string{"In the Windows command prompt, replace </dev/null with <NUL and 2>/dev/null with 2>NUL."},
+	}
+	if target != host {
+		result.Notes = append(result.Notes, "For a wildcard certificate, replace "+target+" with the name of a server it covers.")
+	}
+	if format == models.DownloadFormatDER {
+		result.Notes = append(result.Notes, fmt.Sprintf(
+			"Downloads are set to DER; convert them to PEM first, e.g. openssl x509 -inform DER -in %s -out %s, or switch the download format to PEM.",
+			shellQuote(host+".der"), leaf))
+	}
+	return result, nil
+}
+
+// shellQuote single-quotes a word for a POSIX shell when it holds characters
+// the shell would interpret, such as the * of a wildcard hostname
+func shellQuote(word string) string {
+	if shellSafe.MatchString(word) {
+		return word
+	}
+	return "'" + strings.ReplaceAll(word, "'", `'\''`) + "'"
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+func TestBuildOpenSSLCommands(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	storePKCS12TestCertificate(t, database, "app.example.com", testutil.RandomMasterKey(t))
+
+	result, err := svc.BuildOpenSSLCommands(ctx, "app.example.com", models.DownloadFormatPEM)
+	if err != nil {
+		t.Fatalf("BuildOpenSSLCommands failed: %v", err)
+	}
+	if result.FingerprintSHA256 == "" || !strings.Contains(result.Commands[1].Description, result.FingerprintSHA256) {
+		t.Errorf("expected the fingerprint to be given, got %+v", result)
+	}
+
+	var all strings.Builder
+	for _, c := range result.Commands {
+		all.WriteString(c.Command + "\n")
+	}
+	for _, want := range []string{
+		"openssl verify -CAfile app.example.com-root.crt -untrusted app.example.com-fullchain.pem app.example.com.crt",
+		"openssl pkey -in app.example.com.key -pubout",
+		"openssl s_client -connect app.example.com:443 -servername app.example.com",
+	} {
+		if !strings.Contains(all.String(), want) {
+			t.Errorf("expected the commands to contain %q, got:\n%s", want, all.String())
+		}
+	}
+	if len(result.Notes) != 1 {
+		t.Errorf("expected only the Windows note for PEM downloads, got %v", result.Notes)
+	}
+
+	der, err := svc.BuildOpenSSLCommands(ctx, "app.example.com", models.DownloadFormatDER)
+	if err != nil {
+		t.Fatalf("BuildOpenSSLCommands failed: %v", err)
+	}
+	if len(der.Notes) != 2 || !strings.Contains(der.Notes[1], "-inform DER") {
+		t.Errorf("expected a conversion note for DER downloads, got %v", der.Notes)
+	}
+}
+
+func TestBuildOpenSSLCommands_RequiresIssuedCertificate(t *testing.T) {
+	svc, database := setupTestService(t)
+	seedCert(t, database, "pending.example.com")
+
+	if _, err := svc.BuildOpenSSLCommands(context.Background(), "pending.example.com", models.DownloadFormatPEM); err == nil {
+		t.Error("expected an error before the certificate is issued")
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	for word, want := range map[string]string{
+		"app.example.com.crt":  "app.example.com.crt",
+		"*.example.com.crt":    "'*.example.com.crt'",
+		"it's":                 `'it'\''s'`,
+		"host.example.com:443": "host.example.com:443",
+	} {
+		if got := shellQuote(word); got != want {
+			t.Errorf("shellQuote(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// uploadIntent is the payload of an upload_certificate intent: enough to tell
+// whether the activation was committed
+type uploadIntent struct {
+	CertificateSHA256 string `json:"certificate_sha256"` // of the leaf PEM being activated
+	ExpiresAt         int64  `json:"expires_at"`
+}
+
+// beginIntent records that a multi-step operation is starting. The returned
+// finish removes the record once the operation is over, successful or not; a
+// record still present at startup marks an operation interrupted by a crash,
+// resolved by RecoverOperationIntents.
+func (s *CertificateService) beginIntent(ctx context.Context, operation, hostname string, payload any) (finish func(), err error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode operation intent: %w", err)
+	}
+	id, err := s.db.Queries().CreateOperationIntent(ctx, sqlc.CreateOperationIntentParams{
+		Operation: operation,
+		Hostname:  hostname,
+		Payload:   string(data),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record operation intent: %w", err)
+	}
+	return func() {
+		// A leftover record is harmless: recovery finds the operation finished
+		if err := s.db.Queries().DeleteOperationIntent(context.WithoutCancel(ctx), id); err != nil {
+			logger.WithComponent("intents").Warn("failed to remove operation intent",
+				slog.Int64("intent_id", id), slog.String("operation", operation), logger.Err(err))
+		}
+	}, nil
+}
+
+// RecoverOperationIntents resolves the operations left unfinished by a previous
+// run, removes their intents and returns what was found. The steps of an
+// operation are committed in one transaction, so each one is either complete
+// or was rolled back by the database and has to be run again.
+func (s *CertificateService) RecoverOperationIntents(ctx context.Context) ([]models.RecoveredOperation, error) {
+	log := logger.WithComponent("intents")
+
+	intents, err := s.db.Queries().ListOperationIntents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list operation intents: %w", err)
+	}
+
+	recovered := make([]models.RecoveredOperation, 0, len(intents))
+	for _, intent := range intents {
+		op := models.RecoveredOperation{
+			ID:        intent.ID,
+			Operation: intent.Operation,
+			Hostname:  intent.Hostname,
+			StartedAt: intent.CreatedAt,
+		}
+		switch intent.Operation {
+		case models.IntentUploadCertificate:
+			op.Outcome, op.Detail, err = s.recoverUpload(ctx, intent)
+			if err != nil {
+				return recovered, fmt.Errorf("failed to recover %s for %s: %w", intent.Operation, intent.Hostname, err)
+			}
+		default:
+			op.Outcome = models.IntentUnknown
+			op.Detail = "Operation unknown to this version; its outcome could not be checked"
+		}
+
+		if err := s.db.Queries().DeleteOperationIntent(ctx, intent.ID); err != nil {
+			return recovered, fmt.Errorf("failed to remove operation intent: %w", err)
+		}
+		log.Warn("recovered interrupted operation",
+			slog.String("operation", op.Operation),
+			slog.String("hostname", op.Hostname),
+			slog.String("outcome", op.Outcome),
+		)
+		recovered = append(recovered, op)
+	}
+	return recovered, nil
+}
+
+// recoverUpload checks whether an interrupted upload activated its certificate.
+// When it did not, the pending CSR and key are untouched and the signed
+// certificate can be uploaded again.
+func (s *CertificateService) recoverUpload(ctx context.Context, intent sqlc.OperationIntent) (outcome, detail string, err error) {
+	var payload uploadIntent
+	if err := json.Unmarshal([]byte(intent.Payload), &payload); err != nil {
+		return "", "", fmt.Errorf("invalid intent payload: %w", err)
+	}
+
+	cert, err := s.db.Queries().GetCertificateByHostname(ctx, intent.Hostname)
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.IntentRolledBack, "Certificate was deleted since; nothing was activated", nil
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	if cert.CertificatePem.Valid && pemSHA256(cert.CertificatePem.String) == payload.CertificateSHA256 {
+		return models.IntentCompleted, "Certificate was activated before the interruption", nil
+	}
+	return models.IntentRolledBack, "Certificate was not activated; upload the signed certificate again", nil
+}
+
+// pemSHA256 is the hex SHA-256 of a stored PEM, used to recognize it in intents
+func pemSHA256(pem string) string {
+	sum := sha256.Sum256([]byte(pem))
+	return hex.EncodeToString(sum[:])
+}
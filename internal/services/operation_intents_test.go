@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+func TestRecoverOperationIntents(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	csrPEM, encryptedKey, privateKey := generateTestCSRAndKey(t, "done.example.com", encryptionKey)
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:                   "done.example.com",
+		PendingEncryptedPrivateKey: encryptedKey,
+		PendingCsrPem:              sql.NullString{String: string(csrPEM), Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	certPEM, err := selfSignCertFromCSR(csrPEM, privateKey)
+	if err != nil {
+		t.Fatalf("failed to self-sign certificate: %v", err)
+	}
+	if _, err := svc.UploadCertificate(ctx, "done.example.com", certPEM, encryptionKey); err != nil {
+		t.Fatalf("UploadCertificate failed: %v", err)
+	}
+
+	// A finished upload leaves no intent behind
+	if intents, _ := database.Queries().ListOperationIntents(ctx); len(intents) != 0 {
+		t.Fatalf("expected no intent after upload, got %+v", intents)
+	}
+
+	// Simulate crashes: after the activation was committed, before it was,
+	// and an intent written by a newer version
+	active, err := database.Queries().GetCertificateByHostname(ctx, "done.example.com")
+	if err != nil {
+		t.Fatalf("failed to get certificate: %v", err)
+	}
+	for _, intent := range []struct{ operation, hostname string }{
+		{models.IntentUploadCertificate, "done.example.com"},
+		{models.IntentUploadCertificate, "missing.example.com"},
+		{"deploy_hook", "done.example.com"},
+	} {
+		if _, err := svc.beginIntent(ctx, intent.operation, intent.hostname, uploadIntent{
+			CertificateSHA256: pemSHA256(active.CertificatePem.String),
+		}); err != nil {
+			t.Fatalf("beginIntent() error = %v", err)
+		}
+	}
+	if _, err := svc.beginIntent(ctx, models.IntentUploadCertificate, "done.example.com", uploadIntent{
+		CertificateSHA256: pemSHA256("another certificate"),
+	}); err != nil {
+		t.Fatalf("beginIntent() error = %v", err)
+	}
+
+	recovered, err := svc.RecoverOperationIntents(ctx)
+	if err != nil {
+		t.Fatalf("RecoverOperationIntents() error = %v", err)
+	}
+	want := []string{models.IntentCompleted, models.IntentRolledBack, models.IntentUnknown, models.IntentRolledBack}
+	if len(recovered) != len(want) {
+		t.Fatalf("expected %d recovered operations, got %+v", len(want), recovered)
+	}
+	for i, op := range recovered {
+		if op.Outcome != want[i] {
+			t.Errorf("recovered[%d] (%s %s) outcome = %q, want %q", i, op.Operation, op.Hostname, op.Outcome, want[i])
+		}
+	}
+
+	if intents, _ := database.Queries().ListOperationIntents(ctx); len(intents) != 0 {
+		t.Errorf("expected intents to be removed after recovery, got %+v", intents)
+	}
+	if recovered, _ := svc.RecoverOperationIntents(ctx); len(recovered) != 0 {
+		t.Errorf("expected nothing left to recover, got %+v", recovered)
+	}
+}
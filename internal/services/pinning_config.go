@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/models"
+)
+
+//go:embed pinning_configs/*.tmpl
+var pinningConfigFS embed.FS
+
+var pinningConfigTemplates = template.Must(
+	template.New("").Funcs(template.FuncMap{"join": strings.Join}).ParseFS(pinningConfigFS, "pinning_configs/*.tmpl"),
+)
+
+// pinningLayout is the template of a pinning format and the file the
+// rendered configuration goes into
+type pinningLayout struct {
+	template string
+	filename string
+}
+
+var pinningLayouts = map[string]pinningLayout{
+	models.PinFormatAndroid:  {template: "android.xml.tmpl", filename: "res/xml/network_security_config.xml"},
+	models.PinFormatOkHttp:   {template: "okhttp.kt.tmpl", filename: "CertificatePinner.kt"},
+	models.PinFormatTrustKit: {template: "trustkit.swift.tmpl", filename: "AppDelegate.swift"},
+}
+
+// pinningDomain is a domain of the rendered configuration with the pins of
+// every selected certificate covering it
+type pinningDomain struct {
+	Name              string
+	Pattern           string // OkHttp form: "**.name" when subdomains are included
+	IncludeSubdomains bool
+	Pins              []string
+	Expiration        string // YYYY-MM-DD, latest expiry of the active certificates; "" when none is issued
+	expiresAt         time.Time
+}
+
+// pinningConfigData is what the templates are rendered with
+type pinningConfigData struct {
+	Hostnames []string
+	Domains   []*pinningDomain
+}
+
+// BuildPinningConfig renders a pinning configuration (models.PinFormat*) for
+// the certificates of hostnames, pinning the SPKI SHA-256 of their active
+// key and of the key of their pending renewal CSR, so an app released before
+// a rotation keeps working after it. Every DNS name of the certificate and
+// its CSR is pinned; a wildcard pins the domain and its subdomains. A
+// certificate with a single key is warned about. Nothing is decrypted.
+func (s *CertificateService) BuildPinningConfig(ctx context.Context, hostnames []string, format string) (*models.PinningConfig, error) {
+	layout, ok := pinningLayouts[format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported pinning format: %s", format)
+	}
+	hostnames = uniqueStrings(hostnames)
+	if len(hostnames) == 0 {
+		return nil, fmt.Errorf("no certificates selected")
+	}
+
+	result := &models.PinningConfig{
+		Format:       format,
+		Filename:     layout.filename,
+		Certificates: make([]models.CertificatePins, 0, len(hostnames)),
+		Warnings:     models.NewWarnings(),
+	}
+	data := pinningConfigData{Hostnames: hostnames}
+	domains := map[string]*pinningDomain{}
+
+	for _, hostname := range hostnames {
+		stored, err := s.db.Queries().GetCertificateByHostname(ctx, hostname)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("certificate not found: %s", hostname)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get certificate %s: %w", hostname, err)
+		}
+
+		pins := models.CertificatePins{Hostname: hostname}
+		names := []string{hostname}
+		var expiresAt time.Time
+		if stored.CertificatePem.Valid && stored.CertificatePem.String != "" {
+			cert, err := crypto.ParseCertificate([]byte(stored.CertificatePem.String))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse certificate of %s: %w", hostname, err)
+			}
+			if pins.ActivePin, err = crypto.PublicKeyPin(cert.PublicKey); err != nil {
+				return nil, fmt.Errorf("failed to pin certificate of %s: %w", hostname, err)
+			}
+			expiresAt = cert.NotAfter
+			expiry := cert.NotAfter.Unix()
+			pins.ExpiresAt = &expiry
+			names = append(names, cert.DNSNames...)
+		}
+		if stored.PendingCsrPem.Valid && stored.PendingCsrPem.String != "" {
+			csr, err := crypto.ParseCSR([]byte(stored.PendingCsrPem.String))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse CSR of %s: %w", hostname, err)
+			}
+			if pins.PendingPin, err = crypto.PublicKeyPin(csr.PublicKey); err != nil {
+				return nil, fmt.Errorf("failed to pin CSR of %s: %w", hostname, err)
+			}
+			names = append(names, csr.DNSNames...)
+		}
+		result.Certificates = append(result.Certificates, pins)
+
+		switch {
+		case pins.ActivePin == "":
+			result.Warn("%s has no issued certificate yet: only the key of its CSR is pinned", hostname)
+		case pins.PendingPin == "" || pins.PendingPin == pins.ActivePin:
+			result.Warn("%s has a single key pinned: generate its renewal CSR first, or the app breaks when the certificate is rotated", hostname)
+		}
+
+		for _, name := range names {
+			addPinningDomain(&data, domains, name, pins, expiresAt)
+		}
+	}
+
+	for _, domain := range data.Domains {
+		if !domain.expiresAt.IsZero() {
+			domain.Expiration = domain.expiresAt.UTC().Format(time.DateOnly)
+		}
+	}
+
+	var b strings.Builder
+	if err := pinningConfigTemplates.ExecuteTemplate(&b, layout.template, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s pinning configuration: %w", format, err)
+	}
+	result.Config = b.String()
+	return result, nil
+}
+
+// addPinningDomain adds the pins of a certificate to the domain of a DNS name,
+// creating the domain the first time it is seen. "*.example.com" is pinned as
+// example.com with its subdomains.
+func addPinningDomain(data *pinningConfigData, domains map[string]*pinningDomain, name string, pins models.CertificatePins, expiresAt time.Time) {
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	wildcard := strings.HasPrefix(name, "*.")
+	name = strings.TrimPrefix(name, "*.")
+	if name == "" {
+		return
+	}
+
+	domain, ok := domains[name]
+	if !ok {
+		domain = &pinningDomain{Name: name, Pattern: name}
+		domains[name] = domain
+		data.Domains = append(data.Domains, domain)
+	}
+	if wildcard && !domain.IncludeSubdomains {
+		domain.IncludeSubdomains = true
+		domain.Pattern = "**." + name
+	}
+	for _, pin := range []string{pins.ActivePin, pins.PendingPin} {
+		if pin != "" && !slices.Contains(domain.Pins, pin) {
+			domain.Pins = append(domain.Pins, pin)
+		}
+	}
+	if expiresAt.After(domain.expiresAt) {
+		domain.expiresAt = expiresAt
+	}
+}
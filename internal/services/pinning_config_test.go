@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"crypto/rsa"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// newPinningCSR creates a CSR for hostname and extra DNS SANs and returns it
+// with its key and pin
+func newPinningCSR(t *testing.T, hostname string, sans ...string) ([]byte, *rsa.PrivateKey, string) {
+	t.Helper()
+	key, err := crypto.GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatalf("GenerateRSAKey failed: %v", err)
+	}
+	csrPEM, err := crypto.CreateCSR(crypto.CSRRequest{CommonName: hostname, DNSSANs: append([]string{hostname}, sans...)}, key)
+	if err != nil {
+		t.Fatalf("CreateCSR failed: %v", err)
+	}
+	pin, err := crypto.PublicKeyPin(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("PublicKeyPin failed: %v", err)
+	}
+	return csrPEM, key, pin
+}
+
+func TestBuildPinningConfig(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+
+	// api.example.com is issued and has a pending renewal CSR with a new key
+	activeCSR, activeKey, activePin := newPinningCSR(t, "api.example.com", "*.api.example.com")
+	certPEM, err := selfSignCertFromCSR(activeCSR, activeKey)
+	if err != nil {
+		t.Fatalf("failed to sign certificate: %v", err)
+	}
+	pendingCSR, _, pendingPin := newPinningCSR(t, "api.example.com", "*.api.example.com")
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:       "api.example.com",
+		CertificatePem: sql.NullString{String: certPEM, Valid: true},
+		PendingCsrPem:  sql.NullString{String: string(pendingCSR), Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	// cdn.example.com is issued without a renewal CSR
+	cdnCSR, cdnKey, cdnPin := newPinningCSR(t, "cdn.example.com")
+	cdnPEM, err := selfSignCertFromCSR(cdnCSR, cdnKey)
+	if err != nil {
+		t.Fatalf("failed to sign certificate: %v", err)
+	}
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:       "cdn.example.com",
+		CertificatePem: sql.NullString{String: cdnPEM, Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	tests := []struct {
+		format string
+		want   []string
+	}{
+		{models.PinFormatAndroid, []string{
+			`<domain includeSubdomains="true">api.example.com</domain>`,
+			`<domain includeSubdomains="false">cdn.example.com</domain>`,
+			`<pin digest="SHA-256">` + activePin + `</pin>
+            <pin digest="SHA-256">` + pendingPin + `</pin>`,
+			`<pin-set expiration="`,
+		}},
+		{models.PinFormatOkHttp, []string{
+			`.add("**.api.example.com", "sha256/` + activePin + `")`,
+			`.add("**.api.example.com", "sha256/` + pendingPin + `")`,
+			`.add("cdn.example.com", "sha256/` + cdnPin + `")`,
+		}},
+		{models.PinFormatTrustKit, []string{
+			`"api.example.com": [
+            kTSKIncludeSubdomains: true,`,
+			`"` + pendingPin + `",`,
+			`kTSKExpirationDate: "`,
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			config, err := svc.BuildPinningConfig(ctx, []string{"api.example.com", "cdn.example.com", "api.example.com"}, tt.format)
+			if err != nil {
+				t.Fatalf("BuildPinningConfig failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(config.Config, want) {
+					t.Errorf("expected the configuration to contain %q:\n%s", want, config.Config)
+				}
+			}
+			if len(config.Certificates) != 2 || config.Certificates[0].PendingPin != pendingPin || config.Certificates[1].ActivePin != cdnPin {
+				t.Errorf("certificates = %+v, want the pins of both certificates", config.Certificates)
+			}
+			if len(config.Warnings.Warnings) != 1 || !strings.Contains(config.Warnings.Warnings[0], "cdn.example.com has a single key") {
+				t.Errorf("warnings = %v, want cdn.example.com reported with a single key", config.Warnings.Warnings)
+			}
+		})
+	}
+}
+
+func TestBuildPinningConfig_Errors(t *testing.T) {
+	svc, _ := setupTestService(t)
+	ctx := context.Background()
+
+	if _, err := svc.BuildPinningConfig(ctx, []string{"app.example.com"}, "hpkp"); err == nil || !strings.Contains(err.Error(), "unsupported pinning format") {
+		t.Errorf("err = %v, want an unsupported format error", err)
+	}
+	if _, err := svc.BuildPinningConfig(ctx, nil, models.PinFormatAndroid); err == nil {
+		t.Error("expected an error without certificates")
+	}
+	if _, err := svc.BuildPinningConfig(ctx, []string{"missing.example.com"}, models.PinFormatAndroid); err == nil || !strings.Contains(err.Error(), "certificate not found") {
+		t.Errorf("err = %v, want certificate not found", err)
+	}
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// renewalStepLabels are the step names used in history messages
+var renewalStepLabels = map[string]string{
+	models.RenewalStepCSRSent:      "CSR sent to CA",
+	models.RenewalStepCertReceived: "certificate received",
+	models.RenewalStepUploaded:     "certificate uploaded",
+	models.RenewalStepDeployed:     "certificate deployed",
+	models.RenewalStepVerified:     "deployment verified",
+}
+
+// GetRenewalChecklist returns the state of every renewal step of a certificate
+func (s *CertificateService) GetRenewalChecklist(ctx context.Context, hostname string) (*models.RenewalChecklist, error) {
+	if _, err := s.db.Queries().GetCertificateByHostname(ctx, hostname); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("certificate not found: %s", hostname)
+		}
+		return nil, fmt.Errorf("failed to get certificate: %w", err)
+	}
+	return renewalChecklistTx(ctx, s.db.Queries(), hostname)
+}
+
+// SetRenewalStep marks a renewal step as done or not done and records the
+// transition in the certificate history. Setting a step to its current state
+// is a no-op and logs nothing.
+func (s *CertificateService) SetRenewalStep(ctx context.Context, hostname, step string, done bool) (*models.RenewalChecklist, error) {
+	log := logger.WithHostname(logger.WithComponent("certificate"), hostname)
+
+	if !slices.Contains(models.RenewalSteps, step) {
+		return nil, fmt.Errorf("unknown renewal step: %s", step)
+	}
+
+	var checklist *models.RenewalChecklist
+	err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		cert, err := q.GetCertificateByHostname(ctx, hostname)
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("certificate not found: %s", hostname)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get certificate: %w", err)
+		}
+		if cert.ReadOnly == 1 {
+			return fmt.Errorf("certificate is read-only and cannot be modified")
+		}
+
+		current, err := renewalChecklistTx(ctx, q, hostname)
+		if err != nil {
+			return err
+		}
+		checklist = current
+		if current.Items[slices.Index(models.RenewalSteps, step)].Done == done {
+			return nil
+		}
+
+		eventType := models.EventRenewalStepCompleted
+		message := fmt.Sprintf("Renewal step completed: %s", renewalStepLabels[step])
+		if done {
+			if err := q.CompleteRenewalStep(ctx, sqlc.CompleteRenewalStepParams{
+				Hostname: hostname,
+				Step:     step,
+				Actor:    s.history.actor,
+			}); err != nil {
+				return fmt.Errorf("failed to complete renewal step: %w", err)
+			}
+		} else {
+			eventType = models.EventRenewalStepReopened
+			message = fmt.Sprintf("Renewal step reopened: %s", renewalStepLabels[step])
+			if _, err := q.ReopenRenewalStep(ctx, sqlc.ReopenRenewalStepParams{
+				Hostname: hostname,
+				Step:     step,
+			}); err != nil {
+				return fmt.Errorf("failed to reopen renewal step: %w", err)
+			}
+		}
+		if err := s.history.LogEventDetailsTx(ctx, q, hostname, eventType, message, map[string]any{"step": step}); err != nil {
+			return fmt.Errorf("failed to log history: %w", err)
+		}
+
+		checklist, err = renewalChecklistTx(ctx, q, hostname)
+		return err
+	})
+	if err != nil {
+		log.Error("failed to update renewal checklist", slog.String("step", step), logger.Err(err))
+		return nil, err
+	}
+
+	log.Info("renewal checklist updated", slog.String("step", step), slog.Bool("done", done))
+	return checklist, nil
+}
+
+// completeRenewalStepsTx marks steps as done without logging them; used when
+// another recorded event (e.g. an upload) implies them.
+func (s *CertificateService) completeRenewalStepsTx(ctx context.Context, q *sqlc.Queries, hostname string, steps ...string) error {
+	for _, step := range steps {
+		if err := q.CompleteRenewalStep(ctx, sqlc.CompleteRenewalStepParams{
+			Hostname: hostname,
+			Step:     step,
+			Actor:    s.history.actor,
+		}); err != nil {
+			return fmt.Errorf("failed to complete renewal step %s: %w", step, err)
+		}
+	}
+	return nil
+}
+
+// renewalChecklistTx builds the checklist of a certificate from its completed steps
+func renewalChecklistTx(ctx context.Context, q *sqlc.Queries, hostname string) (*models.RenewalChecklist, error) {
+	rows, err := q.ListRenewalChecklist(ctx, hostname)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get renewal checklist: %w", err)
+	}
+
+	checklist := &models.RenewalChecklist{
+		Hostname: hostname,
+		Items:    make([]models.RenewalChecklistItem, len(models.RenewalSteps)),
+	}
+	for i, step := range models.RenewalSteps {
+		checklist.Items[i].Step = step
+	}
+	for _, row := range rows {
+		i := slices.Index(models.RenewalSteps, row.Step)
+		if i < 0 {
+			continue
+		}
+		checklist.Items[i] = models.RenewalChecklistItem{
+			Step:        row.Step,
+			Done:        true,
+			CompletedAt: row.CompletedAt,
+			Actor:       row.Actor,
+		}
+		checklist.Completed++
+	}
+	return checklist, nil
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+func TestSetRenewalStep_RecordsTransitions(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+	hostname := "server.example.com"
+
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{Hostname: hostname}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	checklist, err := svc.SetRenewalStep(ctx, hostname, models.RenewalStepCSRSent, true)
+	if err != nil {
+		t.Fatalf("SetRenewalStep failed: %v", err)
+	}
+	if checklist.Completed != 1 || !checklist.Items[0].Done || checklist.Items[0].CompletedAt == 0 {
+		t.Errorf("expected csr_sent to be done, got %+v", checklist)
+	}
+	if len(checklist.Items) != len(models.RenewalSteps) {
+		t.Errorf("expected %d items, got %d", len(models.RenewalSteps), len(checklist.Items))
+	}
+
+	// Completing a done step again is a no-op
+	if _, err := svc.SetRenewalStep(ctx, hostname, models.RenewalStepCSRSent, true); err != nil {
+		t.Fatalf("SetRenewalStep failed: %v", err)
+	}
+	checklist, err = svc.SetRenewalStep(ctx, hostname, models.RenewalStepCSRSent, false)
+	if err != nil {
+		t.Fatalf("SetRenewalStep failed: %v", err)
+	}
+	if checklist.Completed != 0 {
+		t.Errorf("expected no completed step after reopening, got %+v", checklist)
+	}
+
+	history, err := svc.GetHistory(ctx, hostname, 10)
+	if err != nil {
+		t.Fatalf("GetHistory failed: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("expected 2 history entries, got %d", len(history))
+	}
+	events := map[string]bool{history[0].EventType: true, history[1].EventType: true}
+	if !events[models.EventRenewalStepCompleted] || !events[models.EventRenewalStepReopened] {
+		t.Errorf("unexpected history events: %+v", history)
+	}
+	if history[0].Details["step"] != models.RenewalStepCSRSent {
+		t.Errorf("expected step in history details, got %+v", history[0].Details)
+	}
+}
+
+func TestSetRenewalStep_Rejects(t *testing.T) {
+	svc, database := setupTestService(t)
+	ctx := context.Background()
+
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname: "locked.example.com",
+		ReadOnly: 1,
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	if _, err := svc.SetRenewalStep(ctx, "locked.example.com", "ordered", true); err == nil || !strings.Contains(err.Error(), "unknown renewal step") {
+		t.Errorf("expected an unknown step error, got %v", err)
+	}
+	if _, err := svc.SetRenewalStep(ctx, "locked.example.com", models.RenewalStepDeployed, true); err == nil || !strings.Contains(err.Error(), "read-only") {
+		t.Errorf("expected a read-only error, got %v", err)
+	}
+	if _, err := svc.SetRenewalStep(ctx, "missing.example.com", models.RenewalStepDeployed, true); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestRenewalChecklist_FollowsRenewal(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	hostname := "server.example.com"
+	encryptionKey := testutil.RandomMasterKey(t)
+
+	csrPEM, encryptedKey, privateKey := generateTestCSRAndKey(t, hostname, encryptionKey)
+	if err := database.Queries().CreateCertificate(ctx, sqlc.CreateCertificateParams{
+		Hostname:                   hostname,
+		PendingEncryptedPrivateKey: encryptedKey,
+		PendingCsrPem:              sql.NullString{String: string(csrPEM), Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	certPEM, err := selfSignCertFromCSR(csrPEM, privateKey)
+	if err != nil {
+		t.Fatalf("failed to sign certificate: %v", err)
+	}
+
+	// Uploading completes the received and uploaded steps
+	if _, err := svc.UploadCertificate(ctx, hostname, certPEM, encryptionKey); err != nil {
+		t.Fatalf("UploadCertificate failed: %v", err)
+	}
+	checklist, err := svc.GetRenewalChecklist(ctx, hostname)
+	if err != nil {
+		t.Fatalf("GetRenewalChecklist failed: %v", err)
+	}
+	if checklist.Completed != 2 || !checklist.Items[1].Done || !checklist.Items[2].Done {
+		t.Errorf("expected cert_received and uploaded to be done, got %+v", checklist)
+	}
+
+	// A new renewal CSR starts over
+	if _, err := svc.GenerateCSR(ctx, models.CSRRequest{
+		Hostname:     hostname,
+		Organization: "Test Org",
+		City:         "Paris",
+		State:        "IDF",
+		Country:      "FR",
+		KeySize:      2048,
+		IsRenewal:    true,
+	}, encryptionKey); err != nil {
+		t.Fatalf("GenerateCSR failed: %v", err)
+	}
+	checklist, err = svc.GetRenewalChecklist(ctx, hostname)
+	if err != nil {
+		t.Fatalf("GetRenewalChecklist failed: %v", err)
+	}
+	if checklist.Completed != 0 {
+		t.Errorf("expected an empty checklist after a new renewal CSR, got %+v", checklist)
+	}
+}
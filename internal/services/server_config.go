@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"net"
+	"strings"
+	"text/template"
+
+	"paddockcontrol-desktop/internal/models"
+)
+
+//go:embed server_configs/*.tmpl
+var serverConfigFS embed.FS
+
+var serverConfigTemplates = template.Must(
+	template.New("").Funcs(template.FuncMap{"join": strings.Join}).ParseFS(serverConfigFS, "server_configs/*.tmpl"),
+)
+
+// Cipher settings of Mozilla's "intermediate" TLS profile. The TLS 1.2 list
+// has no DHE suites, so no dhparam file is needed.
+var (
+	serverConfigCiphers = []string{
+		"ECDHE-ECDSA-AES128-GCM-SHA256", "ECDHE-RSA-AES128-GCM-SHA256",
+		"ECDHE-ECDSA-AES256-GCM-SHA384", "ECDHE-RSA-AES256-GCM-SHA384",
+		"ECDHE-ECDSA-CHACHA20-POLY1305", "ECDHE-RSA-CHACHA20-POLY1305",
+	}
+	serverConfigCipherSuites = []string{
+		"TLS_AES_128_GCM_SHA256", "TLS_AES_256_GCM_SHA384", "TLS_CHACHA20_POLY1305_SHA256",
+	}
+)
+
+// serverConfigLayout is where a server type expects its files, named like the
+// app's downloads so the saved files can be copied as they are
+type serverConfigLayout struct {
+	template string
+	files    func(hostname string) (cert, key models.ServerConfigFile)
+}
+
+var serverConfigLayouts = map[string]serverConfigLayout{
+	models.ServerTypeNginx: {
+		template: "nginx.conf.tmpl",
+		files: func(hostname string) (models.ServerConfigFile, models.ServerConfigFile) {
+			return models.ServerConfigFile{Path: "/etc/nginx/ssl/" + hostname + "-fullchain.pem", Source: "Chain download, leaf + intermediates"},
+				models.ServerConfigFile{Path: "/etc/nginx/ssl/" + hostname + ".key", Source: "Private key download", PrivateKey: true}
+		},
+	},
+	models.ServerTypeApache: {
+		template: "apache.conf.tmpl",
+		files: func(hostname string) (models.ServerConfigFile, models.ServerConfigFile) {
+			return models.ServerConfigFile{Path: "/etc/ssl/certs/" + hostname + "-fullchain.pem", Source: "Chain download, leaf + intermediates"},
+				models.ServerConfigFile{Path: "/etc/ssl/private/" + hostname + ".key", Source: "Private key download", PrivateKey: true}
+		},
+	},
+	models.ServerTypeHAProxy: {
+		template: "haproxy.cfg.tmpl",
+		files: func(hostname string) (models.ServerConfigFile, models.ServerConfigFile) {
+			// HAProxy reads the chain and the key from one file
+			return models.ServerConfigFile{
+				Path:       "/etc/haproxy/certs/" + hostname + ".pem",
+				Source:     fmt.Sprintf("cat %[1]s-fullchain.pem %[1]s.key > %[1]s.pem", hostname),
+				PrivateKey: true,
+			}, models.ServerConfigFile{}
+		},
+	},
+}
+
+// serverConfigData is what the templates are rendered with
+type serverConfigData struct {
+	Hostname     string
+	ServerNames  []string // hostname first, then the DNS SANs
+	Aliases      []string // ServerNames without the hostname
+	CertFile     string
+	KeyFile      string
+	Ciphers      string
+	CipherSuites string
+}
+
+// BuildServerConfigSnippet renders a TLS configuration block for a web server
+// (models.ServerType*), with the certificate's DNS names and Mozilla's
+// intermediate protocol and cipher settings; FIPS mode drops ChaCha20. The
+// names come from the active certificate, or the pending CSR before one is
+// issued. Nothing is decrypted.
+func (s *CertificateService) BuildServerConfigSnippet(ctx context.Context, hostname, serverType string) (*models.ServerConfigSnippet, error) {
+	layout, ok := serverConfigLayouts[serverType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported server type: %s", serverType)
+	}
+
+	cert, err := s.GetCertificate(ctx, hostname)
+	if err != nil {
+		return nil, err
+	}
+	subject := cert.Active
+	if subject == nil {
+		subject = cert.Pending
+	}
+
+	fips, err := s.fipsMode(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ciphers, suites := serverConfigCiphers, serverConfigCipherSuites
+	if fips {
+		ciphers, suites = withoutChaCha20(ciphers), withoutChaCha20(suites)
+	}
+
+	certFile, keyFile := layout.files(cert.Hostname)
+	data := serverConfigData{
+		Hostname:     cert.Hostname,
+		ServerNames:  []string{cert.Hostname},
+		CertFile:     certFile.Path,
+		KeyFile:      keyFile.Path,
+		Ciphers:      strings.Join(ciphers, ":"),
+		CipherSuites: strings.Join(suites, ":"),
+	}
+	if subject != nil {
+		for _, san := range subject.SANs {
+			if net.ParseIP(san) != nil || san == cert.Hostname {
+				continue
+			}
+			data.ServerNames = append(data.ServerNames, san)
+			data.Aliases = append(data.Aliases, san)
+		}
+	}
+
+	var b strings.Builder
+	if err := serverConfigTemplates.ExecuteTemplate(&b, layout.template, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s configuration: %w", serverType, err)
+	}
+
+	files := []models.ServerConfigFile{certFile}
+	if keyFile.Path != "" {
+		files = append(files, keyFile)
+	}
+	return &models.ServerConfigSnippet{
+		Hostname:   cert.Hostname,
+		ServerType: serverType,
+		Config:     b.String(),
+		Files:      files,
+	}, nil
+}
+
+// withoutChaCha20 drops the ChaCha20-Poly1305 suites, which are not FIPS
+// approved
+func withoutChaCha20(suites []string) []string {
+	kept := make([]string, 0, len(suites))
+	for _, suite := range suites {
+		if !strings.Contains(suite, "CHACHA20") {
+			kept = append(kept, suite)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+func TestBuildServerConfigSnippet(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+
+	if _, err := svc.GenerateCSR(ctx, models.CSRRequest{
+		Hostname:     "app.example.com",
+		Organization: "Test Org",
+		City:         "Paris",
+		State:        "IDF",
+		Country:      "FR",
+		KeySize:      2048,
+		SANs: []models.SANEntry{
+			{Value: "www.app.example.com", Type: models.SANTypeDNS},
+			{Value: "10.0.0.5", Type: models.SANTypeIP},
+		},
+	}, testutil.RandomMasterKey(t)); err != nil {
+		t.Fatalf("GenerateCSR failed: %v", err)
+	}
+
+	tests := []struct {
+		serverType string
+		want       []string
+		files      int
+	}{
+		{models.ServerTypeNginx, []string{
+			"server_name app.example.com www.app.example.com;",
+			"ssl_certificate     /etc/nginx/ssl/app.example.com-fullchain.pem;",
+			"ssl_certificate_key /etc/nginx/ssl/app.example.com.key;",
+			"ssl_protocols TLSv1.2 TLSv1.3;",
+		}, 2},
+		{models.ServerTypeApache, []string{
+			"ServerName app.example.com\n    ServerAlias www.app.example.com\n",
+			"SSLCertificateKeyFile /etc/ssl/private/app.example.com.key",
+			"SSLProtocol -all +TLSv1.2 +TLSv1.3",
+		}, 2},
+		{models.ServerTypeHAProxy, []string{
+			"bind :443 ssl crt /etc/haproxy/certs/app.example.com.pem",
+			"ssl-default-bind-ciphersuites TLS_AES_128_GCM_SHA256:TLS_AES_256_GCM_SHA384:TLS_CHACHA20_POLY1305_SHA256",
+		}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.serverType, func(t *testing.T) {
+			snippet, err := svc.BuildServerConfigSnippet(ctx, "app.example.com", tt.serverType)
+			if err != nil {
+				t.Fatalf("BuildServerConfigSnippet failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(snippet.Config, want) {
+					t.Errorf("expected the configuration to contain %q:\n%s", want, snippet.Config)
+				}
+			}
+			if strings.Contains(snippet.Config, "10.0.0.5") {
+				t.Errorf("expected IP SANs to be left out:\n%s", snippet.Config)
+			}
+			if len(snippet.Files) != tt.files || !snippet.Files[len(snippet.Files)-1].PrivateKey {
+				t.Errorf("expected %d files, the last holding the key, got %+v", tt.files, snippet.Files)
+			}
+		})
+	}
+}
+
+func TestBuildServerConfigSnippet_FIPS(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	seedCert(t, database, "app.example.com")
+	if _, err := database.DB().Exec("UPDATE config SET fips_mode = 1"); err != nil {
+		t.Fatalf("failed to enable FIPS mode: %v", err)
+	}
+
+	snippet, err := svc.BuildServerConfigSnippet(ctx, "app.example.com", models.ServerTypeHAProxy)
+	if err != nil {
+		t.Fatalf("BuildServerConfigSnippet failed: %v", err)
+	}
+	if strings.Contains(snippet.Config, "CHACHA20") {
+		t.Errorf("expected ChaCha20 to be dropped in FIPS mode:\n%s", snippet.Config)
+	}
+}
+
+func TestBuildServerConfigSnippet_Rejects(t *testing.T) {
+	svc, database := setupTestService(t)
+	setupTestConfig(t, database)
+	ctx := context.Background()
+	seedCert(t, database, "app.example.com")
+
+	if _, err := svc.BuildServerConfigSnippet(ctx, "app.example.com", "iis"); err == nil {
+		t.Error("expected an unsupported server type to be rejected")
+	}
+	if _, err := svc.BuildServerConfigSnippet(ctx, "missing.example.com", models.ServerTypeNginx); err == nil {
+		t.Error("expected an unknown hostname to be rejected")
+	}
+}
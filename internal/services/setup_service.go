@@ -3,16 +3,23 @@ package services
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 
 	"paddockcontrol-desktop/internal/config"
+	"paddockcontrol-desktop/internal/crypto"
 	"paddockcontrol-desktop/internal/db"
 	"paddockcontrol-desktop/internal/db/sqlc"
 	"paddockcontrol-desktop/internal/logger"
 	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/subject"
 )
 
+// maxSetupStepLength bounds the wizard step name stored with the progress
+const maxSetupStepLength = 32
+
 // SetupService handles initial application setup and configuration
 type SetupService struct {
 	db     *db.Database
@@ -34,8 +41,25 @@ func (s *SetupService) IsConfigured(ctx context.Context) (bool, error) {
 	return s.config.IsConfigured(ctx)
 }
 
-// SetupFromScratch creates a new configuration from scratch
+// SetupFromScratch creates a new configuration from scratch. The master key is
+// created at the first unlock; CompleteSetup stores it along with the
+// configuration instead.
 func (s *SetupService) SetupFromScratch(ctx context.Context, req models.SetupRequest) error {
+	return s.setup(ctx, req, nil)
+}
+
+// CompleteSetup creates a new configuration from scratch together with its
+// first unlock method, so a failure or crash midway leaves neither behind:
+// the app is either configured with a password or still in the wizard.
+func (s *SetupService) CompleteSetup(ctx context.Context, req models.SetupRequest, passwordKey sqlc.InsertSecurityKeyParams) error {
+	return s.setup(ctx, req, &passwordKey)
+}
+
+// setup validates req and, in one transaction, creates the configuration
+// (replacing one left incomplete), stores passwordKey as the only unlock
+// method when given, marks setup complete and forgets the saved wizard
+// progress
+func (s *SetupService) setup(ctx context.Context, req models.SetupRequest, passwordKey *sqlc.InsertSecurityKeyParams) error {
 	ctx, log := logger.WithOperation(ctx, "setup_fresh")
 	log.Info("starting fresh setup",
 		slog.String("owner_email", req.OwnerEmail),
@@ -43,7 +67,19 @@ func (s *SetupService) SetupFromScratch(ctx context.Context, req models.SetupReq
 		slog.String("hostname_suffix", req.HostnameSuffix),
 	)
 
-	// Validate setup request
+	// Normalize and validate setup request
+	defaults := subject.Normalize(subject.Fields{
+		Organization:       req.DefaultOrganization,
+		OrganizationalUnit: req.DefaultOrganizationalUnit,
+		City:               req.DefaultCity,
+		State:              req.DefaultState,
+		Country:            req.DefaultCountry,
+	})
+	req.DefaultOrganization = defaults.Organization
+	req.DefaultOrganizationalUnit = defaults.OrganizationalUnit
+	req.DefaultCity = defaults.City
+	req.DefaultState = defaults.State
+	req.DefaultCountry = defaults.Country
 	if err := s.validateSetupRequest(req); err != nil {
 		log.Error("setup request validation failed", logger.Err(err))
 		return err
@@ -54,6 +90,11 @@ func (s *SetupService) SetupFromScratch(ctx context.Context, req models.SetupReq
 	// between the two would otherwise leave a config row with is_configured=0,
 	// trapping the user in the setup wizard despite a config existing.
 	if err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		// A configuration never marked complete is left over from an
+		// interrupted setup; a complete one makes CreateConfig fail below
+		if _, err := q.DeleteIncompleteConfig(ctx); err != nil {
+			return fmt.Errorf("failed to remove incomplete configuration: %w", err)
+		}
 		if err := q.CreateConfig(ctx, sqlc.CreateConfigParams{
 			OwnerEmail:                req.OwnerEmail,
 			CaName:                    req.CAName,
@@ -65,12 +106,26 @@ func (s *SetupService) SetupFromScratch(ctx context.Context, req models.SetupReq
 			DefaultCountry:            req.DefaultCountry,
 			DefaultKeySize:            int64(req.DefaultKeySize),
 			ValidityPeriodDays:        int64(req.ValidityPeriodDays),
+			KdfProfile:                crypto.DetectKDFProfile(),
 		}); err != nil {
 			return fmt.Errorf("failed to create configuration: %w", err)
 		}
+		if passwordKey != nil {
+			// Unlock methods created before setup completed wrap a master key
+			// that nothing was encrypted with
+			if err := q.DeleteAllSecurityKeys(ctx); err != nil {
+				return fmt.Errorf("failed to remove unlock methods: %w", err)
+			}
+			if _, err := q.InsertSecurityKey(ctx, *passwordKey); err != nil {
+				return fmt.Errorf("failed to store security key: %w", err)
+			}
+		}
 		if err := q.SetConfigured(ctx); err != nil {
 			return fmt.Errorf("failed to mark as configured: %w", err)
 		}
+		if err := q.DeleteSetupProgress(ctx); err != nil {
+			return fmt.Errorf("failed to clear setup progress: %w", err)
+		}
 		return nil
 	}); err != nil {
 		log.Error("fresh setup failed", logger.Err(err))
@@ -81,6 +136,96 @@ func (s *SetupService) SetupFromScratch(ctx context.Context, req models.SetupReq
 	return nil
 }
 
+// GetProgress returns where the setup wizard stopped, or nil when it has no
+// saved progress or setup is complete
+func (s *SetupService) GetProgress(ctx context.Context) (*models.SetupProgress, error) {
+	configured, err := s.config.IsConfigured(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if configured {
+		return nil, nil
+	}
+
+	row, err := s.db.Queries().GetSetupProgress(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get setup progress: %w", err)
+	}
+
+	progress := &models.SetupProgress{Step: row.Step, UpdatedAt: row.UpdatedAt}
+	if err := json.Unmarshal([]byte(row.Draft), &progress.Draft); err != nil {
+		// A draft that no longer decodes restarts the wizard with empty fields
+		s.log.Warn("discarding unreadable setup draft", logger.Err(err))
+		progress.Draft = models.SetupRequest{}
+	}
+	return progress, nil
+}
+
+// SaveProgress records the wizard step shown and the values entered so far,
+// so the wizard resumes there after a crash or restart
+func (s *SetupService) SaveProgress(ctx context.Context, progress models.SetupProgress) error {
+	if progress.Step == "" || len(progress.Step) > maxSetupStepLength {
+		return fmt.Errorf("invalid setup step")
+	}
+	configured, err := s.config.IsConfigured(ctx)
+	if err != nil {
+		return err
+	}
+	if configured {
+		return fmt.Errorf("setup is already complete")
+	}
+
+	draft, err := json.Marshal(progress.Draft)
+	if err != nil {
+		return fmt.Errorf("failed to encode setup draft: %w", err)
+	}
+	if err := s.db.Queries().SaveSetupProgress(ctx, sqlc.SaveSetupProgressParams{
+		Step:  progress.Step,
+		Draft: string(draft),
+	}); err != nil {
+		return fmt.Errorf("failed to save setup progress: %w", err)
+	}
+	return nil
+}
+
+// Abort discards an incomplete setup: the saved wizard progress, a
+// configuration that was never marked complete and any unlock method created
+// before setup finished. It refuses once setup is complete.
+func (s *SetupService) Abort(ctx context.Context) error {
+	ctx, log := logger.WithOperation(ctx, "abort_setup")
+
+	configured, err := s.config.IsConfigured(ctx)
+	if err != nil {
+		return err
+	}
+	if configured {
+		return fmt.Errorf("setup is already complete")
+	}
+
+	var removedConfig int64
+	if err := s.db.WithTx(ctx, func(q *sqlc.Queries) error {
+		if err := q.DeleteSetupProgress(ctx); err != nil {
+			return fmt.Errorf("failed to clear setup progress: %w", err)
+		}
+		if removedConfig, err = q.DeleteIncompleteConfig(ctx); err != nil {
+			return fmt.Errorf("failed to remove incomplete configuration: %w", err)
+		}
+		if err := q.DeleteAllSecurityKeys(ctx); err != nil {
+			return fmt.Errorf("failed to remove unlock methods: %w", err)
+		}
+		return nil
+	}); err != nil {
+		log.Error("abort setup failed", logger.Err(err))
+		return err
+	}
+
+	log.Info("setup aborted", slog.Bool("removed_config", removedConfig > 0))
+	return nil
+}
+
 // GetSetupDefaults returns default values for setup form
 func (s *SetupService) GetSetupDefaults() *models.SetupDefaults {
 	return &models.SetupDefaults{
@@ -102,24 +247,14 @@ func (s *SetupService) validateSetupRequest(req models.SetupRequest) error {
 		return fmt.Errorf("CA name is required")
 	}
 
-	if req.DefaultOrganization == "" {
-		return fmt.Errorf("default organization is required")
-	}
-
-	if req.DefaultCity == "" {
-		return fmt.Errorf("default city is required")
-	}
-
-	if req.DefaultState == "" {
-		return fmt.Errorf("default state is required")
-	}
-
-	if req.DefaultCountry == "" {
-		return fmt.Errorf("default country is required")
-	}
-
-	if len(req.DefaultCountry) != 2 {
-		return fmt.Errorf("default country must be a 2-letter ISO code")
+	if err := subject.Validate(subject.Fields{
+		Organization:       req.DefaultOrganization,
+		OrganizationalUnit: req.DefaultOrganizationalUnit,
+		City:               req.DefaultCity,
+		State:              req.DefaultState,
+		Country:            req.DefaultCountry,
+	}, "default_"); err != nil {
+		return err
 	}
 
 	if req.DefaultKeySize < 2048 {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db/sqlc"
 	"paddockcontrol-desktop/internal/models"
 )
 
@@ -179,6 +181,9 @@ func TestSetupFromScratch_StoresAllFields(t *testing.T) {
 	if cfg.DefaultKeySize != 4096 {
 		t.Errorf("expected key size 4096, got %d", cfg.DefaultKeySize)
 	}
+	if cfg.KdfProfile != crypto.DetectKDFProfile() {
+		t.Errorf("expected the KDF profile detected at first run, got %s", cfg.KdfProfile)
+	}
 }
 
 func TestGetSetupDefaults(t *testing.T) {
@@ -230,3 +235,117 @@ func TestIsConfigured_AfterSetup(t *testing.T) {
 		t.Error("expected IsConfigured to be true after setup")
 	}
 }
+
+func TestSetupProgress_ResumesUntilSetupCompletes(t *testing.T) {
+	svc, _ := setupSetupService(t)
+	ctx := context.Background()
+
+	progress, err := svc.GetProgress(ctx)
+	if err != nil || progress != nil {
+		t.Fatalf("GetProgress() = %+v, %v; want nothing saved", progress, err)
+	}
+
+	draft := makeTestSetupRequest()
+	if err := svc.SaveProgress(ctx, models.SetupProgress{Step: "organization", Draft: draft}); err != nil {
+		t.Fatalf("SaveProgress failed: %v", err)
+	}
+	progress, err = svc.GetProgress(ctx)
+	if err != nil {
+		t.Fatalf("GetProgress failed: %v", err)
+	}
+	if progress == nil || progress.Step != "organization" || progress.Draft.CAName != draft.CAName {
+		t.Fatalf("GetProgress() = %+v, want the saved step and draft", progress)
+	}
+
+	if err := svc.SetupFromScratch(ctx, draft); err != nil {
+		t.Fatalf("SetupFromScratch failed: %v", err)
+	}
+	if progress, err := svc.GetProgress(ctx); err != nil || progress != nil {
+		t.Errorf("GetProgress() after setup = %+v, %v; want nothing", progress, err)
+	}
+	if err := svc.SaveProgress(ctx, models.SetupProgress{Step: "email"}); err == nil {
+		t.Error("expected SaveProgress to fail once setup is complete")
+	}
+}
+
+func TestAbort_RemovesIncompleteSetup(t *testing.T) {
+	svc, database := setupSetupService(t)
+	ctx := context.Background()
+	q := database.Queries()
+
+	// State left by a setup interrupted before is_configured was set
+	req := makeTestSetupRequest()
+	if err := q.CreateConfig(ctx, sqlc.CreateConfigParams{
+		OwnerEmail:     req.OwnerEmail,
+		CaName:         req.CAName,
+		DefaultCountry: req.DefaultCountry,
+		DefaultKeySize: int64(req.DefaultKeySize),
+		KdfProfile:     crypto.KDFProfileStandard,
+	}); err != nil {
+		t.Fatalf("CreateConfig failed: %v", err)
+	}
+	if _, err := q.InsertSecurityKey(ctx, sqlc.InsertSecurityKeyParams{
+		Method:           models.SecurityKeyMethodPassword,
+		Label:            "Password",
+		WrappedMasterKey: []byte("wrapped"),
+	}); err != nil {
+		t.Fatalf("InsertSecurityKey failed: %v", err)
+	}
+	if err := svc.SaveProgress(ctx, models.SetupProgress{Step: "review", Draft: req}); err != nil {
+		t.Fatalf("SaveProgress failed: %v", err)
+	}
+
+	if err := svc.Abort(ctx); err != nil {
+		t.Fatalf("Abort failed: %v", err)
+	}
+	if _, err := q.GetConfig(ctx); err == nil {
+		t.Error("expected the incomplete configuration to be removed")
+	}
+	if has, _ := q.HasAnySecurityKeys(ctx); has != 0 {
+		t.Error("expected the unlock methods to be removed")
+	}
+	if progress, _ := svc.GetProgress(ctx); progress != nil {
+		t.Errorf("GetProgress() = %+v, want nothing after abort", progress)
+	}
+
+	// Setup runs again from scratch, and cannot be aborted once complete
+	if err := svc.SetupFromScratch(ctx, req); err != nil {
+		t.Fatalf("SetupFromScratch after abort failed: %v", err)
+	}
+	if err := svc.Abort(ctx); err == nil {
+		t.Error("expected Abort to fail once setup is complete")
+	}
+}
+
+func TestCompleteSetup_StoresUnlockMethodAtomically(t *testing.T) {
+	svc, database := setupSetupService(t)
+	ctx := context.Background()
+	q := database.Queries()
+
+	passwordKey := sqlc.InsertSecurityKeyParams{
+		Method:           models.SecurityKeyMethodPassword,
+		Label:            "Password",
+		WrappedMasterKey: []byte("wrapped"),
+	}
+
+	// A failed validation stores nothing
+	bad := makeTestSetupRequest()
+	bad.OwnerEmail = ""
+	if err := svc.CompleteSetup(ctx, bad, passwordKey); err == nil {
+		t.Fatal("expected CompleteSetup to reject an invalid request")
+	}
+	if has, _ := q.HasAnySecurityKeys(ctx); has != 0 {
+		t.Error("expected no unlock method after a failed setup")
+	}
+
+	if err := svc.CompleteSetup(ctx, makeTestSetupRequest(), passwordKey); err != nil {
+		t.Fatalf("CompleteSetup failed: %v", err)
+	}
+	configured, err := svc.IsConfigured(ctx)
+	if err != nil || !configured {
+		t.Fatalf("IsConfigured() = %v, %v; want true", configured, err)
+	}
+	if count, _ := q.CountSecurityKeysByMethod(ctx, models.SecurityKeyMethodPassword); count != 1 {
+		t.Errorf("password unlock methods = %d, want 1", count)
+	}
+}
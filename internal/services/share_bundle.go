@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/models"
+)
+
+const (
+	// shareBundleFormat identifies a share bundle file.
+	shareBundleFormat = "paddockcontrol-share-bundle"
+	// shareBundleVersion is the current share bundle format version.
+	shareBundleVersion = 1
+	// MaxShareBundleLifetime caps how long a share bundle stays importable.
+	MaxShareBundleLifetime = 30 * 24 * time.Hour
+)
+
+// shareBundleFile is the on-disk form of a share bundle. Only Format, Version
+// and the KDF parameters are read before decryption; Hostname and ExpiresAt are
+// informational copies of the sealed payload, which is the one trusted on open.
+type shareBundleFile struct {
+	Format     string                  `json:"format"`
+	Version    int                     `json:"version"`
+	Hostname   string                  `json:"hostname"`
+	ExpiresAt  int64                   `json:"expires_at"`
+	KDF        models.PasswordMetadata `json:"kdf"`
+	Ciphertext []byte                  `json:"ciphertext"`
+}
+
+// shareBundlePayload is the sealed content of a share bundle.
+type shareBundlePayload struct {
+	Hostname       string `json:"hostname"`
+	CertificatePEM string `json:"certificate_pem"`
+	ChainPEM       string `json:"chain_pem,omitempty"`
+	PrivateKeyPEM  string `json:"private_key_pem,omitempty"`
+	Note           string `json:"note,omitempty"`
+	CreatedAt      int64  `json:"created_at"`
+	ExpiresAt      int64  `json:"expires_at"`
+	AppVersion     string `json:"app_version"`
+}
+
+// CreateShareBundle seals a certificate, its chain and note (and, when
+// includeKey is set, its decrypted private key) into a password-protected
+// bundle that another installation can import until expiresIn has elapsed.
+// Bundles that carry a private key are recorded in the certificate's history.
+func (s *CertificateService) CreateShareBundle(ctx context.Context, hostname string, includeKey bool, password string, expiresIn time.Duration, encryptionKey []byte, params crypto.Argon2idParams) ([]byte, error) {
+	if expiresIn < time.Hour || expiresIn > MaxShareBundleLifetime {
+		return nil, fmt.Errorf("share bundle lifetime must be between 1 hour and %d days", int(MaxShareBundleLifetime.Hours()/24))
+	}
+
+	cert, err := s.db.Queries().GetCertificateByHostname(ctx, hostname)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get certificate: %w", err)
+	}
+	if !cert.CertificatePem.Valid || cert.CertificatePem.String == "" {
+		return nil, fmt.Errorf("no certificate for hostname: %s", hostname)
+	}
+
+	now := s.clock.Now()
+	payload := shareBundlePayload{
+		Hostname:       cert.Hostname,
+		CertificatePEM: cert.CertificatePem.String,
+		ChainPEM:       cert.ChainPem.String,
+		Note:           cert.Note.String,
+		CreatedAt:      now.Unix(),
+		ExpiresAt:      now.Add(expiresIn).Unix(),
+		AppVersion:     s.history.appVersion,
+	}
+
+	if includeKey {
+		if len(cert.EncryptedPrivateKey) == 0 {
+			return nil, fmt.Errorf("no private key for hostname: %s", hostname)
+		}
+		key, err := crypto.DecryptPrivateKey(cert.EncryptedPrivateKey, encryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt private key: %w", err)
+		}
+		payload.PrivateKeyPEM = string(key.Bytes())
+		key.Destroy()
+	}
+
+	plaintext, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode share bundle: %w", err)
+	}
+	defer crypto.Zero(plaintext)
+
+	salt, err := crypto.GenerateSalt(params.SaltLength)
+	if err != nil {
+		return nil, err
+	}
+	bundleKey := crypto.DeriveKeyFromPassword(password, salt, params)
+	defer crypto.Zero(bundleKey)
+
+	ciphertext, err := crypto.EncryptPrivateKey(plaintext, bundleKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt share bundle: %w", err)
+	}
+
+	data, err := json.MarshalIndent(shareBundleFile{
+		Format:    shareBundleFormat,
+		Version:   shareBundleVersion,
+		Hostname:  payload.Hostname,
+		ExpiresAt: payload.ExpiresAt,
+		KDF: models.PasswordMetadata{
+			Salt:              salt,
+			Argon2Memory:      params.Memory,
+			Argon2Iterations:  params.Iterations,
+			Argon2Parallelism: params.Parallelism,
+		},
+		Ciphertext: ciphertext,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode share bundle: %w", err)
+	}
+
+	if includeKey {
+		message := fmt.Sprintf("Share bundle with private key created (expires %s)", time.Unix(payload.ExpiresAt, 0).Format("2006-01-02 15:04"))
+		if err := s.history.LogEvent(ctx, hostname, models.EventShareBundleCreated, message); err != nil {
+			return nil, fmt.Errorf("failed to log history: %w", err)
+		}
+	}
+
+	return data, nil
+}
+
+// openShareBundle decrypts a share bundle and refuses it once expired. The
+// expiry checked is the one sealed in the payload, so editing the file's
+// plaintext header cannot extend a bundle's life.
+func (s *CertificateService) openShareBundle(data []byte, password string) (*shareBundlePayload, error) {
+	var file shareBundleFile
+	if err := json.Unmarshal(data, &file); err != nil || file.Format != shareBundleFormat {
+		return nil, fmt.Errorf("not a PaddockControl share bundle")
+	}
+	if file.Version != shareBundleVersion {
+		return nil, fmt.Errorf("unsupported share bundle version: %d", file.Version)
+	}
+
+	params := crypto.Argon2idParams{
+		Memory:      file.KDF.Argon2Memory,
+		Iterations:  file.KDF.Argon2Iterations,
+		Parallelism: file.KDF.Argon2Parallelism,
+		KeyLength:   32,
+		SaltLength:  uint32(len(file.KDF.Salt)),
+	}
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid share bundle: %w", err)
+	}
+
+	bundleKey := crypto.DeriveKeyFromPassword(password, file.KDF.Salt, params)
+	defer crypto.Zero(bundleKey)
+
+	plaintext, err := crypto.DecryptPrivateKey(file.Ciphertext, bundleKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid password or corrupted share bundle")
+	}
+	defer plaintext.Destroy()
+
+	var payload shareBundlePayload
+	if err := json.Unmarshal(plaintext.Bytes(), &payload); err != nil {
+		return nil, fmt.Errorf("invalid share bundle contents: %w", err)
+	}
+
+	if now := s.clock.Now(); now.Unix() >= payload.ExpiresAt {
+		return nil, fmt.Errorf("share bundle expired on %s", time.Unix(payload.ExpiresAt, 0).Format("2006-01-02 15:04"))
+	}
+
+	return &payload, nil
+}
+
+// PeekShareBundle decrypts a share bundle and describes its contents without
+// importing anything.
+func (s *CertificateService) PeekShareBundle(data []byte, password string) (*models.ShareBundleInfo, error) {
+	payload, err := s.openShareBundle(data, password)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ShareBundleInfo{
+		Hostname:      payload.Hostname,
+		HasPrivateKey: payload.PrivateKeyPEM != "",
+		HasChain:      payload.ChainPEM != "",
+		Note:          payload.Note,
+		CreatedAt:     payload.CreatedAt,
+		ExpiresAt:     payload.ExpiresAt,
+		AppVersion:    payload.AppVersion,
+	}, nil
+}
+
+// ImportShareBundle imports the certificate and private key sealed in a share
+// bundle, re-encrypting the key with encryptionKey. Bundles created without a
+// private key cannot be imported. Returns the imported hostname.
+func (s *CertificateService) ImportShareBundle(ctx context.Context, data []byte, password string, encryptionKey []byte) (string, error) {
+	payload, err := s.openShareBundle(data, password)
+	if err != nil {
+		return "", err
+	}
+	if payload.PrivateKeyPEM == "" {
+		return "", fmt.Errorf("share bundle does not include a private key and cannot be imported")
+	}
+
+	certificatePEM := payload.CertificatePEM
+	if payload.ChainPEM != "" {
+		certificatePEM = strings.TrimRight(certificatePEM, "\n") + "\n" + payload.ChainPEM
+	}
+
+	if _, err := s.ImportCertificate(ctx, models.ImportRequest{
+		CertificatePEM: certificatePEM,
+		PrivateKeyPEM:  payload.PrivateKeyPEM,
+		Note:           payload.Note,
+	}, encryptionKey); err != nil {
+		return "", err
+	}
+	return payload.Hostname, nil
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"paddockcontrol-desktop/internal/clock"
+	"paddockcontrol-desktop/internal/config"
+	"paddockcontrol-desktop/internal/crypto"
+	"paddockcontrol-desktop/internal/db"
+	"paddockcontrol-desktop/internal/db/sqlc"
+	"paddockcontrol-desktop/internal/models"
+	"paddockcontrol-desktop/internal/testutil"
+)
+
+const testBundlePassword = "correct horse battery staple"
+
+// fastBundleParams are the cheapest Argon2id parameters share bundles accept.
+var fastBundleParams = crypto.Argon2idParams{
+	Memory:      19 * 1024,
+	Iterations:  1,
+	Parallelism: 1,
+	KeyLength:   32,
+	SaltLength:  16,
+}
+
+// setupShareBundleSource returns a service holding one issued certificate with
+// a note, driven by a fake clock, and the master key its private key is
+// encrypted with.
+func setupShareBundleSource(t *testing.T, hostname string) (*CertificateService, *clock.Fake, []byte) {
+	t.Helper()
+	svc, database := setupTestService(t)
+	fake := clock.NewFake(time.Now())
+	svc.SetClock(fake)
+	masterKey := testutil.RandomMasterKey(t)
+
+	csrPEM, encryptedKey, key := generateTestCSRAndKey(t, hostname, masterKey)
+	certPEM, err := selfSignCertFromCSR(csrPEM, key)
+	if err != nil {
+		t.Fatalf("failed to sign certificate: %v", err)
+	}
+	if err := database.Queries().CreateCertificate(context.Background(), sqlc.CreateCertificateParams{
+		Hostname:            hostname,
+		EncryptedPrivateKey: encryptedKey,
+		CertificatePem:      sql.NullString{String: certPEM, Valid: true},
+		Note:                sql.NullString{String: "load balancer", Valid: true},
+	}); err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+	return svc, fake, masterKey
+}
+
+// setupShareBundleTarget returns a service on a separate, file-based database:
+// in-memory test databases share one cache, so they would see the source's rows.
+func setupShareBundleTarget(t *testing.T) (*CertificateService, *db.Database) {
+	t.Helper()
+	database, err := db.NewDatabase(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	t.Cleanup(func() { database.Close() })
+	return NewCertificateService(database, config.NewService(database), "test"), database
+}
+
+func TestShareBundle_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	source, _, sourceKey := setupShareBundleSource(t, "share.example.com")
+
+	data, err := source.CreateShareBundle(ctx, "share.example.com", true, testBundlePassword, 24*time.Hour, sourceKey, fastBundleParams)
+	if err != nil {
+		t.Fatalf("CreateShareBundle() error: %v", err)
+	}
+	if bytes.Contains(data, []byte("PRIVATE KEY")) {
+		t.Fatal("share bundle must not contain the private key in clear")
+	}
+
+	target, targetDB := setupShareBundleTarget(t)
+	targetKey := testutil.RandomMasterKey(t)
+
+	info, err := target.PeekShareBundle(data, testBundlePassword)
+	if err != nil {
+		t.Fatalf("PeekShareBundle() error: %v", err)
+	}
+	if info.Hostname != "share.example.com" || !info.HasPrivateKey || info.Note != "load balancer" {
+		t.Errorf("unexpected bundle info: %+v", info)
+	}
+
+	hostname, err := target.ImportShareBundle(ctx, data, testBundlePassword, targetKey)
+	if err != nil {
+		t.Fatalf("ImportShareBundle() error: %v", err)
+	}
+	if hostname != "share.example.com" {
+		t.Errorf("hostname = %q, want share.example.com", hostname)
+	}
+
+	imported, err := targetDB.Queries().GetCertificateByHostname(ctx, hostname)
+	if err != nil {
+		t.Fatalf("GetCertificateByHostname() error: %v", err)
+	}
+	if imported.Note.String != "load balancer" {
+		t.Errorf("note = %q, want load balancer", imported.Note.String)
+	}
+	key, err := crypto.DecryptPrivateKey(imported.EncryptedPrivateKey, targetKey)
+	if err != nil {
+		t.Fatalf("imported key should decrypt with the target master key: %v", err)
+	}
+	key.Destroy()
+
+	history, err := source.GetHistory(ctx, "share.example.com", 10)
+	if err != nil {
+		t.Fatalf("GetHistory() error: %v", err)
+	}
+	if len(history) == 0 || history[0].EventType != models.EventShareBundleCreated {
+		t.Errorf("expected a %s history entry on the source, got %+v", models.EventShareBundleCreated, history)
+	}
+}
+
+func TestShareBundle_RefusedOnceExpired(t *testing.T) {
+	ctx := context.Background()
+	svc, fake, masterKey := setupShareBundleSource(t, "expiring.example.com")
+
+	data, err := svc.CreateShareBundle(ctx, "expiring.example.com", true, testBundlePassword, 2*time.Hour, masterKey, fastBundleParams)
+	if err != nil {
+		t.Fatalf("CreateShareBundle() error: %v", err)
+	}
+
+	fake.Advance(time.Hour)
+	if _, err := svc.PeekShareBundle(data, testBundlePassword); err != nil {
+		t.Fatalf("bundle should still open before expiry: %v", err)
+	}
+
+	fake.Advance(time.Hour)
+	if _, err := svc.PeekShareBundle(data, testBundlePassword); err == nil || !strings.Contains(err.Error(), "expired") {
+		t.Fatalf("expected expiry error, got %v", err)
+	}
+}
+
+func TestShareBundle_HeaderExpiryIsNotTrusted(t *testing.T) {
+	ctx := context.Background()
+	svc, fake, masterKey := setupShareBundleSource(t, "tamper.example.com")
+
+	data, err := svc.CreateShareBundle(ctx, "tamper.example.com", false, testBundlePassword, time.Hour, masterKey, fastBundleParams)
+	if err != nil {
+		t.Fatalf("CreateShareBundle() error: %v", err)
+	}
+
+	var file shareBundleFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		t.Fatalf("failed to decode bundle: %v", err)
+	}
+	file.ExpiresAt = fake.Now().Add(365 * 24 * time.Hour).Unix()
+	tampered, err := json.Marshal(file)
+	if err != nil {
+		t.Fatalf("failed to encode bundle: %v", err)
+	}
+
+	fake.Advance(2 * time.Hour)
+	if _, err := svc.PeekShareBundle(tampered, testBundlePassword); err == nil {
+		t.Fatal("editing the plaintext expiry must not extend the bundle")
+	}
+}
+
+func TestShareBundle_WrongPassword(t *testing.T) {
+	ctx := context.Background()
+	svc, _, masterKey := setupShareBundleSource(t, "secret.example.com")
+
+	data, err := svc.CreateShareBundle(ctx, "secret.example.com", true, testBundlePassword, time.Hour, masterKey, fastBundleParams)
+	if err != nil {
+		t.Fatalf("CreateShareBundle() error: %v", err)
+	}
+	if _, err := svc.PeekShareBundle(data, "wrong password entirely"); err == nil {
+		t.Fatal("expected error for wrong password")
+	}
+}
+
+func TestShareBundle_WithoutKeyCannotBeImported(t *testing.T) {
+	ctx := context.Background()
+	source, _, sourceKey := setupShareBundleSource(t, "public.example.com")
+
+	data, err := source.CreateShareBundle(ctx, "public.example.com", false, testBundlePassword, time.Hour, sourceKey, fastBundleParams)
+	if err != nil {
+		t.Fatalf("CreateShareBundle() error: %v", err)
+	}
+
+	target, _ := setupShareBundleTarget(t)
+	info, err := target.PeekShareBundle(data, testBundlePassword)
+	if err != nil {
+		t.Fatalf("PeekShareBundle() error: %v", err)
+	}
+	if info.HasPrivateKey {
+		t.Error("bundle created without key should not carry one")
+	}
+	if _, err := target.ImportShareBundle(ctx, data, testBundlePassword, testutil.RandomMasterKey(t)); err == nil {
+		t.Fatal("expected import of a key-less bundle to fail")
+	}
+
+	history, err := source.GetHistory(ctx, "public.example.com", 10)
+	if err != nil {
+		t.Fatalf("GetHistory() error: %v", err)
+	}
+	if len(history) != 0 {
+		t.Errorf("key-less bundles are not recorded, got %+v", history)
+	}
+}
+
+func TestCreateShareBundle_RejectsLifetime(t *testing.T) {
+	ctx := context.Background()
+	svc, _, masterKey := setupShareBundleSource(t, "life.example.com")
+
+	for _, lifetime := range []time.Duration{0, 30 * time.Minute, MaxShareBundleLifetime + time.Hour} {
+		if _, err := svc.CreateShareBundle(ctx, "life.example.com", false, testBundlePassword, lifetime, masterKey, fastBundleParams); err == nil {
+			t.Errorf("expected error for lifetime %s", lifetime)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"crypto/x509/pkix"
 	"database/sql"
 	"encoding/pem"
 	"math/big"
@@ -29,7 +30,7 @@ func setupTestService(t *testing.T) (*CertificateService, *db.Database) {
 	t.Cleanup(func() { database.Close() })
 
 	configSvc := config.NewService(database)
-	svc := NewCertificateService(database, configSvc)
+	svc := NewCertificateService(database, configSvc, "test")
 	return svc, database
 }
 
@@ -91,6 +92,60 @@ func selfSignCertFromCSR(csrPEM []byte, signerKey *rsa.PrivateKey) (string, erro
 	return string(certPEM), nil
 }
 
+// newTestCA creates a CA certificate named cn. It is self-signed when parent is
+// nil, otherwise issued by parent (an intermediate).
+func newTestCA(t *testing.T, cn string, notAfter time.Time, parent *x509.Certificate, parentKey *rsa.PrivateKey) (*x509.Certificate, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := crypto.GenerateRSAKey(2048)
+	if err != nil {
+		t.Fatalf("failed to generate CA key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("failed to create CA certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse CA certificate: %v", err)
+	}
+	return cert, key
+}
+
+// signCSRWithCA issues a leaf certificate for the CSR, signed by the given CA
+func signCSRWithCA(t *testing.T, csrPEM []byte, ca *x509.Certificate, caKey *rsa.PrivateKey) string {
+	t.Helper()
+	csr, err := crypto.ParseCSR(csrPEM)
+	if err != nil {
+		t.Fatalf("failed to parse CSR: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      csr.Subject,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(90 * 24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		DNSNames:     csr.DNSNames,
+		IPAddresses:  csr.IPAddresses,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, csr.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("failed to sign CSR: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
 // containsSubstring checks if s contains substr
 func containsSubstring(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsHelper(s, substr))
@@ -120,6 +175,7 @@ func setupTestConfig(t *testing.T, database *db.Database) {
 		DefaultCountry:            "FR",
 		DefaultKeySize:            2048,
 		ValidityPeriodDays:        365,
+		KdfProfile:                crypto.KDFProfileStandard,
 	})
 	if err != nil {
 		t.Fatalf("failed to create test config: %v", err)
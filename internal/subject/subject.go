@@ -0,0 +1,144 @@
+package subject
+
+import (
+	"crypto/x509/pkix"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Upper bounds of the subject attributes, in characters, from RFC 5280
+// Appendix A.1 (ub-organization-name, ub-locality-name, ...).
+const (
+	MaxOrganizationLength       = 64
+	MaxOrganizationalUnitLength = 64
+	MaxLocalityLength           = 128
+	MaxStateLength              = 128
+)
+
+// ISO 3166-1 alpha-2 country code pattern (2 uppercase letters)
+var countryCodePattern = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// Fields holds the subject attributes the app puts in a CSR, or the
+// configuration defaults for them. The common name is the hostname and is
+// validated by the hostnames package.
+type Fields struct {
+	Organization       string
+	OrganizationalUnit string
+	City               string
+	State              string
+	Country            string
+}
+
+// Normalize trims surrounding whitespace, converts each value to Unicode NFC so
+// a name typed with combining accents encodes like its precomposed form, and
+// upper-cases the country code. Values keep their script: x509 encodes them as
+// PrintableString when possible and as UTF8String otherwise.
+func Normalize(f Fields) Fields {
+	return Fields{
+		Organization:       normalizeValue(f.Organization),
+		OrganizationalUnit: normalizeValue(f.OrganizationalUnit),
+		City:               normalizeValue(f.City),
+		State:              normalizeValue(f.State),
+		Country:            strings.ToUpper(strings.TrimSpace(f.Country)),
+	}
+}
+
+// Validate checks subject fields against RFC 5280: organization, city, state and
+// country are required, the organizational unit is optional. prefix is put in
+// front of the field names in errors, e.g. "default_" for configuration
+// defaults.
+func Validate(f Fields, prefix string) error {
+	required := []struct {
+		field, value string
+		max          int
+	}{
+		{"organization", f.Organization, MaxOrganizationLength},
+		{"city", f.City, MaxLocalityLength},
+		{"state", f.State, MaxStateLength},
+	}
+	for _, r := range required {
+		if strings.TrimSpace(r.value) == "" {
+			return fmt.Errorf("%s%s is required", prefix, r.field)
+		}
+		if err := ValidateValue(prefix+r.field, r.value, r.max); err != nil {
+			return err
+		}
+	}
+
+	if err := ValidateValue(prefix+"organizational_unit", f.OrganizationalUnit, MaxOrganizationalUnitLength); err != nil {
+		return err
+	}
+
+	return ValidateCountry(prefix+"country", f.Country)
+}
+
+// ValidateName checks the attributes present in a parsed certificate subject,
+// e.g. of an imported certificate. Missing attributes are accepted.
+func ValidateName(name pkix.Name) error {
+	attributes := []struct {
+		field  string
+		values []string
+		max    int
+	}{
+		{"organization", name.Organization, MaxOrganizationLength},
+		{"organizational_unit", name.OrganizationalUnit, MaxOrganizationalUnitLength},
+		{"city", name.Locality, MaxLocalityLength},
+		{"state", name.Province, MaxStateLength},
+	}
+	for _, attr := range attributes {
+		for _, value := range attr.values {
+			if err := ValidateValue("subject "+attr.field, value, attr.max); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, country := range name.Country {
+		if err := ValidateCountry("subject country", country); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateValue checks one attribute value: valid UTF-8, at most maxLength
+// characters once in NFC form, and no control, formatting (e.g. bidirectional
+// overrides, zero-width spaces) or private-use characters. Empty values pass.
+func ValidateValue(field, value string, maxLength int) error {
+	if !utf8.ValidString(value) {
+		return fmt.Errorf("%s is not valid UTF-8", field)
+	}
+
+	value = norm.NFC.String(value)
+	if n := utf8.RuneCountInString(value); n > maxLength {
+		return fmt.Errorf("%s must not exceed %d characters (RFC 5280), got %d", field, maxLength, n)
+	}
+
+	for _, r := range value {
+		if r == utf8.RuneError || unicode.In(r, unicode.Cc, unicode.Cf, unicode.Co) {
+			return fmt.Errorf("%s contains a forbidden character (%U)", field, r)
+		}
+	}
+	return nil
+}
+
+// ValidateCountry checks an ISO 3166-1 alpha-2 country code.
+func ValidateCountry(field, code string) error {
+	if strings.TrimSpace(code) == "" {
+		return fmt.Errorf("%s is required", field)
+	}
+	if !countryCodePattern.MatchString(code) {
+		return fmt.Errorf("%s must be a 2-letter ISO 3166-1 alpha-2 code in uppercase", field)
+	}
+	return nil
+}
+
+// normalizeValue trims a value and converts it to Unicode NFC.
+func normalizeValue(value string) string {
+	return norm.NFC.String(strings.TrimSpace(value))
+}
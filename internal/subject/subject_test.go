@@ -0,0 +1,105 @@
+package subject
+
+import (
+	"crypto/x509/pkix"
+	"strings"
+	"testing"
+)
+
+func validFields() Fields {
+	return Fields{
+		Organization: "Test Org",
+		City:         "Paris",
+		State:        "Île-de-France",
+		Country:      "FR",
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		modify  func(*Fields)
+		wantErr string // empty: valid
+	}{
+		{name: "valid", modify: func(f *Fields) {}},
+		{name: "unicode values", modify: func(f *Fields) {
+			f.Organization = "Société Générale"
+			f.City = "München"
+			f.State = "東京都"
+		}},
+		{name: "organizational unit optional", modify: func(f *Fields) { f.OrganizationalUnit = "" }},
+		{name: "organization required", modify: func(f *Fields) { f.Organization = "  " }, wantErr: "organization is required"},
+		{name: "city required", modify: func(f *Fields) { f.City = "" }, wantErr: "city is required"},
+		{name: "state required", modify: func(f *Fields) { f.State = "" }, wantErr: "state is required"},
+		{name: "organization too long", modify: func(f *Fields) { f.Organization = strings.Repeat("a", 65) }, wantErr: "must not exceed 64"},
+		{name: "organization at limit", modify: func(f *Fields) { f.Organization = strings.Repeat("é", 64) }},
+		{name: "organizational unit too long", modify: func(f *Fields) { f.OrganizationalUnit = strings.Repeat("a", 65) }, wantErr: "must not exceed 64"},
+		{name: "city too long", modify: func(f *Fields) { f.City = strings.Repeat("a", 129) }, wantErr: "must not exceed 128"},
+		{name: "control character", modify: func(f *Fields) { f.City = "Paris\n" }, wantErr: "forbidden character"},
+		{name: "bidi override", modify: func(f *Fields) { f.Organization = "Evil\u202eCorp" }, wantErr: "forbidden character"},
+		{name: "zero-width space", modify: func(f *Fields) { f.State = "Ile\u200bde France" }, wantErr: "forbidden character"},
+		{name: "invalid UTF-8", modify: func(f *Fields) { f.Organization = "Org\xff" }, wantErr: "not valid UTF-8"},
+		{name: "country lowercase", modify: func(f *Fields) { f.Country = "fr" }, wantErr: "2-letter"},
+		{name: "country three letters", modify: func(f *Fields) { f.Country = "FRA" }, wantErr: "2-letter"},
+		{name: "country required", modify: func(f *Fields) { f.Country = "" }, wantErr: "country is required"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := validFields()
+			tc.modify(&f)
+			err := Validate(f, "")
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("Validate() error = %v, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_Prefix(t *testing.T) {
+	f := validFields()
+	f.City = ""
+	err := Validate(f, "default_")
+	if err == nil || err.Error() != "default_city is required" {
+		t.Fatalf("Validate() error = %v, want default_city is required", err)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	f := Normalize(Fields{
+		Organization: "  Test Org ",
+		City:         "Mu\u0308nchen", // decomposed ü
+		State:        "Bayern",
+		Country:      " de ",
+	})
+	if f.Organization != "Test Org" {
+		t.Errorf("Organization = %q", f.Organization)
+	}
+	if f.City != "München" {
+		t.Errorf("City = %q, want the precomposed form", f.City)
+	}
+	if f.Country != "DE" {
+		t.Errorf("Country = %q, want DE", f.Country)
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	if err := ValidateName(pkix.Name{CommonName: "web.example.com"}); err != nil {
+		t.Errorf("subject without attributes: error = %v", err)
+	}
+	if err := ValidateName(pkix.Name{Organization: []string{"Org"}, Country: []string{"US"}}); err != nil {
+		t.Errorf("valid subject: error = %v", err)
+	}
+	if err := ValidateName(pkix.Name{Organization: []string{strings.Repeat("x", 65)}}); err == nil {
+		t.Error("expected an error for an oversized organization")
+	}
+	if err := ValidateName(pkix.Name{Country: []string{"USA"}}); err == nil {
+		t.Error("expected an error for a 3-letter country")
+	}
+}
@@ -0,0 +1,153 @@
+// Package timing measures operations that can be slow on some machines (key
+// generation, the password KDF, backups, certificate listing, chain fetches),
+// logs the runs over their threshold and keeps per-operation statistics for the
+// health status. Statistics are kept in memory since startup.
+package timing
+
+import (
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"paddockcontrol-desktop/internal/logger"
+	"paddockcontrol-desktop/internal/models"
+)
+
+// Timed operations
+const (
+	KeyGeneration    = "key_generation"    // RSA private key generation
+	PasswordKDF      = "password_kdf"      // Argon2id key derivation (unlock, password changes, exports)
+	Backup           = "backup"            // database snapshot for an auto or manual backup
+	ListCertificates = "list_certificates" // loading and filtering the certificate list
+	ChainFetch       = "chain_fetch"       // downloading an issuer certificate from an AIA URL
+)
+
+// thresholds is how long each operation may take before a run is reported as
+// slow. 4096-bit RSA generation varies widely, so its threshold is generous.
+var thresholds = map[string]time.Duration{
+	KeyGeneration:    10 * time.Second,
+	PasswordKDF:      3 * time.Second,
+	Backup:           5 * time.Second,
+	ListCertificates: time.Second,
+	ChainFetch:       5 * time.Second,
+}
+
+// defaultThreshold applies to operations without their own threshold
+const defaultThreshold = 2 * time.Second
+
+// maxSlowOperations caps the slow runs kept for the health status
+const maxSlowOperations = 20
+
+type stats struct {
+	count     int64
+	total     time.Duration
+	max       time.Duration
+	last      time.Duration
+	lastAt    time.Time
+	slowCount int64
+}
+
+var (
+	mu   sync.Mutex
+	ops  = make(map[string]*stats)
+	slow []models.SlowOperation // oldest first
+)
+
+// Threshold returns the duration at or above which a run of op is slow.
+func Threshold(op string) time.Duration {
+	if d, ok := thresholds[op]; ok {
+		return d
+	}
+	return defaultThreshold
+}
+
+// Start begins timing a run of op and returns the function that ends it, so
+// a whole function can be measured with
+//
+//	defer timing.Start(timing.Backup)()
+//
+// The attributes are added to the log line.
+func Start(op string, attrs ...any) func() {
+	start := time.Now()
+	return func() {
+		Record(op, time.Since(start), attrs...)
+	}
+}
+
+// Record adds a run of op that took d. Slow runs are logged as warnings,
+// others at debug level.
+func Record(op string, d time.Duration, attrs ...any) {
+	threshold := Threshold(op)
+	isSlow := d >= threshold
+	now := time.Now()
+
+	mu.Lock()
+	s, ok := ops[op]
+	if !ok {
+		s = &stats{}
+		ops[op] = s
+	}
+	s.count++
+	s.total += d
+	s.max = max(s.max, d)
+	s.last = d
+	s.lastAt = now
+	if isSlow {
+		s.slowCount++
+		slow = append(slow, models.SlowOperation{
+			Operation:  op,
+			DurationMs: d.Milliseconds(),
+			At:         now.Unix(),
+		})
+		if len(slow) > maxSlowOperations {
+			slow = slow[len(slow)-maxSlowOperations:]
+		}
+	}
+	mu.Unlock()
+
+	log := logger.WithComponent("timing")
+	args := append([]any{slog.String("operation", op), slog.Duration("duration", d)}, attrs...)
+	if isSlow {
+		log.Warn("slow operation", append(args, slog.Duration("threshold", threshold))...)
+		return
+	}
+	log.Debug("operation timed", args...)
+}
+
+// Snapshot returns the statistics of every operation run since startup,
+// sorted by name, and the most recent slow runs, newest first.
+func Snapshot() ([]models.OperationTiming, []models.SlowOperation) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	timings := make([]models.OperationTiming, 0, len(ops))
+	for op, s := range ops {
+		timings = append(timings, models.OperationTiming{
+			Operation:   op,
+			Count:       s.count,
+			AvgMs:       (s.total / time.Duration(s.count)).Milliseconds(),
+			MaxMs:       s.max.Milliseconds(),
+			LastMs:      s.last.Milliseconds(),
+			LastAt:      s.lastAt.Unix(),
+			LastSlow:    s.last >= Threshold(op),
+			SlowCount:   s.slowCount,
+			ThresholdMs: Threshold(op).Milliseconds(),
+		})
+	}
+	sort.Slice(timings, func(i, j int) bool { return timings[i].Operation < timings[j].Operation })
+
+	recent := make([]models.SlowOperation, len(slow))
+	for i, op := range slow {
+		recent[len(slow)-1-i] = op
+	}
+	return timings, recent
+}
+
+// Reset clears all statistics (used by tests).
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	ops = make(map[string]*stats)
+	slow = nil
+}
@@ -0,0 +1,64 @@
+package timing
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecord_AggregatesPerOperation(t *testing.T) {
+	Reset()
+	t.Cleanup(Reset)
+
+	Record(ListCertificates, 200*time.Millisecond)
+	Record(ListCertificates, 400*time.Millisecond)
+	Record(KeyGeneration, 12*time.Second)
+
+	timings, slow := Snapshot()
+	if len(timings) != 2 {
+		t.Fatalf("expected 2 operations, got %+v", timings)
+	}
+	keygen, list := timings[0], timings[1]
+	if list.Operation != ListCertificates || list.Count != 2 || list.AvgMs != 300 || list.MaxMs != 400 || list.LastMs != 400 {
+		t.Errorf("unexpected list timing: %+v", list)
+	}
+	if list.LastSlow || list.SlowCount != 0 || list.ThresholdMs != 1000 {
+		t.Errorf("list runs should not be slow: %+v", list)
+	}
+	if keygen.Operation != KeyGeneration || !keygen.LastSlow || keygen.SlowCount != 1 {
+		t.Errorf("expected a slow key generation: %+v", keygen)
+	}
+	if len(slow) != 1 || slow[0].Operation != KeyGeneration || slow[0].DurationMs != 12000 {
+		t.Errorf("unexpected slow operations: %+v", slow)
+	}
+}
+
+func TestRecord_KeepsRecentSlowRunsNewestFirst(t *testing.T) {
+	Reset()
+	t.Cleanup(Reset)
+
+	for i := 1; i <= maxSlowOperations+5; i++ {
+		Record("custom", time.Duration(i)*defaultThreshold)
+	}
+
+	_, slow := Snapshot()
+	if len(slow) != maxSlowOperations {
+		t.Fatalf("expected %d slow runs, got %d", maxSlowOperations, len(slow))
+	}
+	if want := (time.Duration(maxSlowOperations+5) * defaultThreshold).Milliseconds(); slow[0].DurationMs != want {
+		t.Errorf("newest slow run = %dms, want %dms", slow[0].DurationMs, want)
+	}
+}
+
+func TestStart_MeasuresElapsedTime(t *testing.T) {
+	Reset()
+	t.Cleanup(Reset)
+
+	done := Start(Backup)
+	time.Sleep(20 * time.Millisecond)
+	done()
+
+	timings, _ := Snapshot()
+	if len(timings) != 1 || timings[0].LastMs < 20 {
+		t.Errorf("expected a run of at least 20ms, got %+v", timings)
+	}
+}
@@ -0,0 +1,159 @@
+// Package tray renders the system tray icon: the application icon scaled down
+// to tray size, with a badge counting the certificates that need attention.
+package tray
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strconv"
+)
+
+// IconSize is the edge of the rendered tray icon in pixels; the OS scales it
+// down to the tray height (16 to 32 px depending on platform and DPI)
+const IconSize = 64
+
+// badgeRadius and badgeScale size the badge circle and its digits at IconSize
+const (
+	badgeRadius = 15
+	badgeScale  = 3
+)
+
+var (
+	badgeColor = color.RGBA{R: 220, G: 38, B: 38, A: 255}
+	textColor  = color.RGBA{R: 255, G: 255, B: 255, A: 255}
+)
+
+// glyphs is a 3x5 bitmap font for the badge, one row per string
+var glyphs = map[rune][5]string{
+	'0': {"111", "101", "101", "101", "111"},
+	'1': {"010", "110", "010", "010", "111"},
+	'2': {"111", "001", "111", "100", "111"},
+	'3': {"111", "001", "111", "001", "111"},
+	'4': {"101", "101", "111", "001", "001"},
+	'5': {"111", "100", "111", "001", "111"},
+	'6': {"111", "100", "111", "101", "111"},
+	'7': {"111", "001", "001", "001", "001"},
+	'8': {"111", "101", "111", "101", "111"},
+	'9': {"111", "101", "111", "001", "111"},
+	'+': {"000", "010", "111", "010", "000"},
+}
+
+// Base decodes the application icon and scales it down to IconSize.
+func Base(appIconPNG []byte) (*image.RGBA, error) {
+	src, err := png.Decode(bytes.NewReader(appIconPNG))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode tray icon: %w", err)
+	}
+	return scale(src, IconSize), nil
+}
+
+// scale box-filters src down to a size x size image.
+func scale(src image.Image, size int) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		y0 := b.Min.Y + y*b.Dy()/size
+		y1 := max(b.Min.Y+(y+1)*b.Dy()/size, y0+1)
+		for x := 0; x < size; x++ {
+			x0 := b.Min.X + x*b.Dx()/size
+			x1 := max(b.Min.X+(x+1)*b.Dx()/size, x0+1)
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(bl / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
+
+// Badge returns a copy of base with count drawn in a badge in its top-right
+// corner ("9+" above nine). A count of zero or less returns base unchanged.
+func Badge(base *image.RGBA, count int) *image.RGBA {
+	img := image.NewRGBA(base.Bounds())
+	copy(img.Pix, base.Pix)
+	if count <= 0 {
+		return img
+	}
+
+	size := base.Bounds().Dx()
+	cx, cy := size-badgeRadius-1, badgeRadius+1
+	for y := cy - badgeRadius; y <= cy+badgeRadius; y++ {
+		for x := cx - badgeRadius; x <= cx+badgeRadius; x++ {
+			if dx, dy := x-cx, y-cy; dx*dx+dy*dy <= badgeRadius*badgeRadius {
+				img.SetRGBA(x, y, badgeColor)
+			}
+		}
+	}
+
+	label := strconv.Itoa(count)
+	if count > 9 {
+		label = "9+"
+	}
+	width := len(label)*4*badgeScale - badgeScale
+	x := cx - width/2
+	y := cy - 5*badgeScale/2
+	for _, r := range label {
+		drawGlyph(img, glyphs[r], x, y)
+		x += 4 * badgeScale
+	}
+	return img
+}
+
+// drawGlyph draws a glyph with its top-left corner at (x, y), scaled by
+// badgeScale.
+func drawGlyph(img *image.RGBA, glyph [5]string, x, y int) {
+	for row, bits := range glyph {
+		for col, bit := range bits {
+			if bit != '1' {
+				continue
+			}
+			for dy := 0; dy < badgeScale; dy++ {
+				for dx := 0; dx < badgeScale; dx++ {
+					img.SetRGBA(x+col*badgeScale+dx, y+row*badgeScale+dy, textColor)
+				}
+			}
+		}
+	}
+}
+
+// Encode returns img as PNG, or as an ICO embedding that PNG when ico is set
+// (the Windows tray only loads icons).
+func Encode(img image.Image, ico bool) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode tray icon: %w", err)
+	}
+	if !ico {
+		return buf.Bytes(), nil
+	}
+
+	// ICONDIR followed by a single ICONDIRENTRY pointing at the PNG data
+	const headerSize = 6 + 16
+	size := img.Bounds().Dx()
+	out := make([]byte, headerSize, headerSize+buf.Len())
+	binary.LittleEndian.PutUint16(out[2:], 1) // type: icon
+	binary.LittleEndian.PutUint16(out[4:], 1) // image count
+	if size < 256 {
+		out[6], out[7] = byte(size), byte(size) // 0 means 256
+	}
+	binary.LittleEndian.PutUint16(out[10:], 1)  // color planes
+	binary.LittleEndian.PutUint16(out[12:], 32) // bits per pixel
+	binary.LittleEndian.PutUint32(out[14:], uint32(buf.Len()))
+	binary.LittleEndian.PutUint32(out[18:], headerSize)
+	return append(out, buf.Bytes()...), nil
+}
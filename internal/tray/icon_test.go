@@ -0,0 +1,96 @@
+package tray
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func testIconPNG(t *testing.T, size int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = 0, 0, 255, 255
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode icon: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestBase_ScalesToIconSize(t *testing.T) {
+	base, err := Base(testIconPNG(t, 1000))
+	if err != nil {
+		t.Fatalf("Base() error = %v", err)
+	}
+	if got := base.Bounds().Dx(); got != IconSize {
+		t.Fatalf("width = %d, want %d", got, IconSize)
+	}
+	if got := base.RGBAAt(IconSize/2, IconSize/2); got != (color.RGBA{B: 255, A: 255}) {
+		t.Errorf("center pixel = %v, want opaque blue", got)
+	}
+
+	if _, err := Base([]byte("not a png")); err == nil {
+		t.Error("expected an error for invalid PNG data")
+	}
+}
+
+func TestBadge(t *testing.T) {
+	base, err := Base(testIconPNG(t, 128))
+	if err != nil {
+		t.Fatalf("Base() error = %v", err)
+	}
+	corner := image.Pt(IconSize-badgeRadius-1, 3)
+
+	plain := Badge(base, 0)
+	if !bytes.Equal(plain.Pix, base.Pix) {
+		t.Error("a zero count should leave the icon unchanged")
+	}
+
+	badged := Badge(base, 12)
+	if got := badged.RGBAAt(corner.X, corner.Y); got != badgeColor {
+		t.Errorf("badge pixel = %v, want %v", got, badgeColor)
+	}
+	if got := badged.RGBAAt(2, IconSize-3); got != base.RGBAAt(2, IconSize-3) {
+		t.Error("the badge should only cover the top-right corner")
+	}
+	if bytes.Equal(Badge(base, 3).Pix, badged.Pix) {
+		t.Error("different counts should render differently")
+	}
+	if !bytes.Equal(Badge(base, 42).Pix, badged.Pix) {
+		t.Error("counts above nine should all render as 9+")
+	}
+	if base.RGBAAt(corner.X, corner.Y) == badgeColor {
+		t.Error("Badge should not modify the base icon")
+	}
+}
+
+func TestEncode_ICO(t *testing.T) {
+	base, err := Base(testIconPNG(t, 64))
+	if err != nil {
+		t.Fatalf("Base() error = %v", err)
+	}
+	ico, err := Encode(base, true)
+	if err != nil {
+		t.Fatalf("Encode() error = %v", err)
+	}
+
+	if binary.LittleEndian.Uint16(ico[2:]) != 1 || binary.LittleEndian.Uint16(ico[4:]) != 1 {
+		t.Fatalf("unexpected ICO header % x", ico[:6])
+	}
+	if ico[6] != IconSize || ico[7] != IconSize {
+		t.Errorf("ICO entry size = %dx%d, want %d", ico[6], ico[7], IconSize)
+	}
+	offset := binary.LittleEndian.Uint32(ico[18:])
+	length := binary.LittleEndian.Uint32(ico[14:])
+	if int(offset+length) != len(ico) {
+		t.Fatalf("ICO entry spans %d+%d bytes of %d", offset, length, len(ico))
+	}
+	if _, err := png.Decode(bytes.NewReader(ico[offset:])); err != nil {
+		t.Errorf("ICO entry is not a PNG: %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+// Package truststores provides the root certificate stores a delivered chain is
+// evaluated against: a bundled snapshot of the Mozilla root program (used by
+// Firefox, most Linux distributions and many language runtimes) and the trust
+// store of the operating system the application is running on.
+package truststores
+
+import (
+	"crypto/x509"
+	_ "embed"
+	"fmt"
+	"sync"
+)
+
+// mozillaPEM is the Mozilla CA program root set as shipped by Debian
+// ca-certificates 20230311 (/usr/share/ca-certificates/mozilla).
+//
+//go:embed mozilla.pem
+var mozillaPEM []byte
+
+// MozillaSnapshot identifies the bundled Mozilla root snapshot
+const MozillaSnapshot = "Debian ca-certificates 20230311"
+
+// Store is a named set of trusted root certificates
+type Store struct {
+	Name        string // Stable identifier, e.g. "mozilla"
+	Description string // Human readable description of who uses the store
+	// Pool returns the store's roots. An error means the store is unavailable
+	// on this machine.
+	Pool func() (*x509.CertPool, error)
+}
+
+var (
+	mozillaOnce sync.Once
+	mozillaPool *x509.CertPool
+)
+
+// Mozilla returns the bundled Mozilla root store snapshot
+func Mozilla() (*x509.CertPool, error) {
+	mozillaOnce.Do(func() {
+		pool := x509.NewCertPool()
+		if pool.AppendCertsFromPEM(mozillaPEM) {
+			mozillaPool = pool
+		}
+	})
+	if mozillaPool == nil {
+		return nil, fmt.Errorf("bundled Mozilla root store is empty")
+	}
+	return mozillaPool, nil
+}
+
+// Stores returns the trust stores a chain is evaluated against
+func Stores() []Store {
+	return []Store{
+		{
+			Name:        "mozilla",
+			Description: fmt.Sprintf("Mozilla root program (%s): Firefox, Linux distributions, curl, Python, Node.js", MozillaSnapshot),
+			Pool:        Mozilla,
+		},
+		{
+			Name:        "system",
+			Description: "Operating system trust store of this machine (Windows, macOS or Linux): Chrome, Edge, Safari, .NET",
+			Pool:        x509.SystemCertPool,
+		},
+	}
+}
@@ -0,0 +1,26 @@
+package truststores
+
+import "testing"
+
+func TestMozilla_LoadsBundledRoots(t *testing.T) {
+	pool, err := Mozilla()
+	if err != nil {
+		t.Fatalf("Mozilla failed: %v", err)
+	}
+	if n := len(pool.Subjects()); n < 100 {
+		t.Errorf("expected at least 100 bundled roots, got %d", n)
+	}
+}
+
+func TestStores_HaveUniqueNames(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, s := range Stores() {
+		if s.Name == "" || s.Pool == nil {
+			t.Errorf("store %q is incomplete", s.Name)
+		}
+		if seen[s.Name] {
+			t.Errorf("duplicate store name %q", s.Name)
+		}
+		seen[s.Name] = true
+	}
+}
@@ -0,0 +1,106 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+)
+
+type testSheet struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			Ref    string `xml:"r,attr"`
+			Type   string `xml:"t,attr"`
+			Style  string `xml:"s,attr"`
+			Value  string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+func readPart(t *testing.T, data []byte, name string) []byte {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("workbook is not a ZIP archive: %v", err)
+	}
+	f, err := zr.Open(name)
+	if err != nil {
+		t.Fatalf("workbook has no %s: %v", name, err)
+	}
+	defer f.Close()
+	content, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", name, err)
+	}
+	return content
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, "Inventory: 2026/10", []string{"name", "count", "ok"}, [][]any{
+		{"=SUM(A1) <&>", 42, true},
+		{"b", nil, 1.5},
+	})
+	if err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	for _, part := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml"} {
+		if err := xml.Unmarshal(readPart(t, buf.Bytes(), part), new(struct{})); err != nil {
+			t.Errorf("%s is not well-formed XML: %v", part, err)
+		}
+	}
+
+	var workbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	if err := xml.Unmarshal(readPart(t, buf.Bytes(), "xl/workbook.xml"), &workbook); err != nil {
+		t.Fatalf("failed to parse workbook: %v", err)
+	}
+	if len(workbook.Sheets) != 1 || workbook.Sheets[0].Name != "Inventory 202610" {
+		t.Errorf("unexpected sheets: %+v", workbook.Sheets)
+	}
+
+	var sheet testSheet
+	if err := xml.Unmarshal(readPart(t, buf.Bytes(), "xl/worksheets/sheet1.xml"), &sheet); err != nil {
+		t.Fatalf("failed to parse sheet: %v", err)
+	}
+	if len(sheet.Rows) != 3 {
+		t.Fatalf("expected 3 rows, got %d", len(sheet.Rows))
+	}
+	header := sheet.Rows[0].Cells
+	if len(header) != 3 || header[0].Inline != "name" || header[0].Style != "1" {
+		t.Errorf("unexpected header row: %+v", header)
+	}
+	first := sheet.Rows[1].Cells
+	if first[0].Type != "inlineStr" || first[0].Inline != "=SUM(A1) <&>" {
+		t.Errorf("expected the string to be stored inline as text, got %+v", first[0])
+	}
+	if first[1].Ref != "B2" || first[1].Value != "42" || first[2].Type != "b" || first[2].Value != "1" {
+		t.Errorf("unexpected cells: %+v", first)
+	}
+	second := sheet.Rows[2].Cells
+	if len(second) != 2 || second[1].Ref != "C3" || second[1].Value != "1.5" {
+		t.Errorf("expected the nil cell to be skipped, got %+v", second)
+	}
+}
+
+func TestWrite_RejectsUnsupportedCell(t *testing.T) {
+	if err := Write(io.Discard, "s", []string{"a"}, [][]any{{struct{}{}}}); err == nil {
+		t.Error("expected an unsupported cell type to be rejected")
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	for index, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 51: "AZ", 52: "BA", 701: "ZZ", 702: "AAA"} {
+		if got := columnName(index); got != want {
+			t.Errorf("columnName(%d) = %q, want %q", index, got, want)
+		}
+	}
+}
@@ -2,10 +2,15 @@ package main
 
 import (
 	"embed"
+	"os"
+	"slices"
+
+	"paddockcontrol-desktop/internal/autostart"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
 	"github.com/wailsapp/wails/v2/pkg/options/assetserver"
+	"github.com/wailsapp/wails/v2/pkg/options/mac"
 )
 
 //go:embed all:frontend/dist
@@ -20,12 +25,23 @@ func main() {
 	// Create an instance of the app structure
 	app := NewApp()
 
+	// Health probe for endpoint management: prints a JSON report and exits
+	// without starting the UI (see app_healthcheck.go)
+	if slices.Contains(os.Args[1:], healthcheckFlag) {
+		os.Exit(app.runHealthcheck(os.Stdout))
+	}
+
+	// Tray icon with the expiring badge and quick menu (see app_tray.go)
+	app.startTray()
+
 	// Create application with options
 	err := wails.Run(&options.App{
 		Title:     appWindowTitle,
 		Width:     1024,
 		Height:    768,
 		Frameless: true,
+		// Started at login: stay in the tray until opened (see app_autostart.go)
+		StartHidden: slices.Contains(os.Args[1:], autostart.Flag),
 		AssetServer: &assetserver.Options{
 			Assets: assets,
 		},
@@ -33,6 +49,19 @@ func main() {
 		OnStartup:        app.startup,
 		OnDomReady:       app.domReady,
 		OnShutdown:       app.shutdown,
+		// In background mode closing the window hides it to the tray
+		OnBeforeClose: app.beforeClose,
+		// A second launch (e.g. double-clicking a backup or PEM file) hands its
+		// arguments to the running app and exits
+		SingleInstanceLock: &options.SingleInstanceLock{
+			UniqueId:               singleInstanceID,
+			OnSecondInstanceLaunch: app.onSecondInstanceLaunch,
+		},
+		// macOS delivers associated files (.pcbackup, .crt, .csr) as events
+		// instead of arguments
+		Mac: &mac.Options{
+			OnFileOpen: app.onFileOpen,
+		},
 		Bind: []interface{}{
 			app,
 		},
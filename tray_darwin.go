@@ -0,0 +1,13 @@
+//go:build darwin
+
+package main
+
+import "fyne.io/systray"
+
+// runTray creates the status item on the calling (main) thread and leaves the
+// event loop to Wails' NSApplication; it returns the function removing it.
+func runTray(onReady, onExit func()) func() {
+	start, end := systray.RunWithExternalLoop(onReady, onExit)
+	start()
+	return end
+}
@@ -0,0 +1,19 @@
+//go:build !darwin
+
+package main
+
+import (
+	"runtime"
+
+	"fyne.io/systray"
+)
+
+// runTray runs the tray on its own locked OS thread: on Windows its window
+// and message loop must share one. It returns the function removing it.
+func runTray(onReady, onExit func()) func() {
+	go func() {
+		runtime.LockOSThread()
+		systray.Run(onReady, onExit)
+	}()
+	return systray.Quit
+}